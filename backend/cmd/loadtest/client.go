@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+// clientResult is what one SSE client observed for one stream
+type clientResult struct {
+	latencies []time.Duration // provider send -> client receive, per text delta
+	deltas    int             // text deltas received
+	completed bool            // turn_complete received
+	turnError string          // turn_error message, if any
+	err       error           // connection or protocol failure
+}
+
+// runClient connects to a turn's SSE endpoint and reads until the server closes the stream.
+// ready is closed once the catchup turn_start arrives (the client is about to be subscribed).
+func runClient(ctx context.Context, httpClient *http.Client, url string, ready chan<- struct{}) clientResult {
+	var result clientResult
+	signalled := false
+	signalReady := func() {
+		if !signalled {
+			signalled = true
+			close(ready)
+		}
+	}
+	defer signalReady()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		result.err = fmt.Errorf("unexpected status %d", resp.StatusCode)
+		return result
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	var eventType, data string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// Blank line dispatches the event
+			if eventType != "" || data != "" {
+				handleEvent(eventType, data, &result, signalReady)
+			}
+			eventType, data = "", ""
+		case strings.HasPrefix(line, ":"):
+			// Keep-alive comment
+		case strings.HasPrefix(line, "event: "):
+			eventType = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		result.err = err
+	}
+
+	return result
+}
+
+// handleEvent records a single SSE event
+func handleEvent(eventType, data string, result *clientResult, signalReady func()) {
+	received := time.Now()
+
+	switch eventType {
+	case llmModels.SSEEventTurnStart:
+		signalReady()
+
+	case llmModels.SSEEventBlockDelta:
+		var delta llmModels.BlockDeltaEvent
+		if err := json.Unmarshal([]byte(data), &delta); err != nil || delta.TextDelta == nil {
+			return
+		}
+		result.deltas++
+		stamp, _, found := strings.Cut(*delta.TextDelta, "|")
+		if !found {
+			return
+		}
+		if sentNanos, err := strconv.ParseInt(stamp, 10, 64); err == nil {
+			result.latencies = append(result.latencies, received.Sub(time.Unix(0, sentNanos)))
+		}
+
+	case llmModels.SSEEventTurnComplete:
+		result.completed = true
+
+	case llmModels.SSEEventTurnError:
+		var turnError llmModels.TurnErrorEvent
+		if err := json.Unmarshal([]byte(data), &turnError); err == nil {
+			result.turnError = turnError.Error
+		}
+	}
+}
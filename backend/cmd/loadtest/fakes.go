@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	llmModels "meridian/internal/domain/models/llm"
+	domainllm "meridian/internal/domain/services/llm"
+)
+
+// memoryTurnStore is a minimal in-memory TurnWriter, TurnReader and TurnNavigator.
+// Blocks are counted, not kept, so the store doesn't inflate the memory being measured.
+type memoryTurnStore struct {
+	mu     sync.RWMutex
+	turns  map[string]*llmModels.Turn
+	blocks atomic.Int64
+}
+
+func newMemoryTurnStore() *memoryTurnStore {
+	return &memoryTurnStore{turns: make(map[string]*llmModels.Turn)}
+}
+
+func (s *memoryTurnStore) addTurn(turnID, model string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.turns[turnID] = &llmModels.Turn{ID: turnID, Role: "assistant", Status: "pending", Model: &model}
+}
+
+func (s *memoryTurnStore) setStatus(turnID, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if turn, ok := s.turns[turnID]; ok {
+		turn.Status = status
+	}
+}
+
+// statusCounts returns the number of turns per status
+func (s *memoryTurnStore) statusCounts() map[string]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	counts := make(map[string]int)
+	for _, turn := range s.turns {
+		counts[turn.Status]++
+	}
+	return counts
+}
+
+func (s *memoryTurnStore) CreateTurn(ctx context.Context, turn *llmModels.Turn) error { return nil }
+
+func (s *memoryTurnStore) CreateTurnBlock(ctx context.Context, block *llmModels.TurnBlock) error {
+	s.blocks.Add(1)
+	return nil
+}
+
+func (s *memoryTurnStore) CreateTurnBlocks(ctx context.Context, blocks []llmModels.TurnBlock) error {
+	s.blocks.Add(int64(len(blocks)))
+	return nil
+}
+
+func (s *memoryTurnStore) DeleteTurnBlocks(ctx context.Context, turnID string) error { return nil }
+
+func (s *memoryTurnStore) UpdateTurnStatus(ctx context.Context, turnID, status string, completedAt *llmModels.Turn) error {
+	s.setStatus(turnID, status)
+	return nil
+}
+
+func (s *memoryTurnStore) UpdateTurn(ctx context.Context, turn *llmModels.Turn) error { return nil }
+
+func (s *memoryTurnStore) UpdateTurnError(ctx context.Context, turnID, errorMsg string) error {
+	s.setStatus(turnID, "error")
+	return nil
+}
+
+func (s *memoryTurnStore) UpdateTurnMetadata(ctx context.Context, turnID string, update *llmModels.TurnMetadataUpdate) error {
+	return nil
+}
+
+func (s *memoryTurnStore) UpdateTurnAnnotations(ctx context.Context, turnID string, update *llmModels.TurnAnnotationsUpdate) (*llmModels.TurnAnnotations, error) {
+	return &llmModels.TurnAnnotations{}, nil
+}
+
+func (s *memoryTurnStore) SaveStreamIndex(ctx context.Context, turnID string, index *llmModels.StreamIndex) error {
+	return nil
+}
+
+func (s *memoryTurnStore) GetTurn(ctx context.Context, turnID string) (*llmModels.Turn, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	turn, ok := s.turns[turnID]
+	if !ok {
+		return nil, fmt.Errorf("turn %s not found", turnID)
+	}
+	copied := *turn
+	return &copied, nil
+}
+
+func (s *memoryTurnStore) GetTurns(ctx context.Context, turnIDs []string) ([]llmModels.Turn, error) {
+	return nil, errors.New("not supported by the load test store")
+}
+
+func (s *memoryTurnStore) GetTurnOwnerIDs(ctx context.Context, turnIDs []string) (map[string]string, error) {
+	return nil, errors.New("not supported by the load test store")
+}
+
+func (s *memoryTurnStore) GetRootTurns(ctx context.Context, chatID string) ([]llmModels.Turn, error) {
+	return nil, errors.New("not supported by the load test store")
+}
+
+// GetTurnBlocks returns no blocks: catchup replays only turn_start, live events carry the rest
+func (s *memoryTurnStore) GetTurnBlocks(ctx context.Context, turnID string) ([]llmModels.TurnBlock, error) {
+	return nil, nil
+}
+
+func (s *memoryTurnStore) GetTurnBlocksForTurns(ctx context.Context, turnIDs []string) (map[string][]llmModels.TurnBlock, error) {
+	return nil, errors.New("not supported by the load test store")
+}
+
+// GetStreamIndex returns no index: the load test doesn't replay finished streams
+func (s *memoryTurnStore) GetStreamIndex(ctx context.Context, turnID string) (*llmModels.StreamIndex, error) {
+	return nil, nil
+}
+
+func (s *memoryTurnStore) SearchTurns(ctx context.Context, opts *llmModels.TurnSearchOptions) ([]llmModels.TurnSearchMatch, bool, error) {
+	return nil, false, errors.New("not supported by the load test store")
+}
+
+func (s *memoryTurnStore) RecallTurns(ctx context.Context, opts *llmModels.ConversationRecallOptions) ([]llmModels.RecalledTurn, error) {
+	return nil, errors.New("not supported by the load test store")
+}
+
+func (s *memoryTurnStore) SearchChats(ctx context.Context, opts *llmModels.ChatSearchOptions) ([]llmModels.ChatSearchMatch, bool, error) {
+	return nil, false, errors.New("not supported by the load test store")
+}
+
+func (s *memoryTurnStore) GetTurnPath(ctx context.Context, turnID string) ([]llmModels.Turn, error) {
+	return nil, errors.New("not supported by the load test store")
+}
+
+func (s *memoryTurnStore) GetTurnPathWindow(ctx context.Context, turnID string, last int) ([]llmModels.Turn, error) {
+	return nil, errors.New("not supported by the load test store")
+}
+
+func (s *memoryTurnStore) GetTurnSiblings(ctx context.Context, turnID string, opts *llmModels.TurnSiblingsOptions) (*llmModels.TurnSiblings, error) {
+	return nil, errors.New("not supported by the load test store")
+}
+
+func (s *memoryTurnStore) GetSiblingsForTurns(ctx context.Context, turnIDs []string) (map[string][]string, error) {
+	return nil, errors.New("not supported by the load test store")
+}
+
+func (s *memoryTurnStore) GetPaginatedTurns(ctx context.Context, chatID, userID string, fromTurnID *string, limit int, direction string, updateLastViewed bool) (*llmModels.PaginatedTurnsResponse, error) {
+	return nil, errors.New("not supported by the load test store")
+}
+
+// timedProvider streams a single text block of a fixed number of deltas at a fixed pace.
+// Each delta starts with its send time ("<unix nanos>|"), so clients can measure the
+// end-to-end latency from provider to SSE client.
+type timedProvider struct {
+	deltas   int
+	interval time.Duration
+	filler   string
+}
+
+func newTimedProvider(deltas int, interval time.Duration, deltaSize int) *timedProvider {
+	return &timedProvider{
+		deltas:   deltas,
+		interval: interval,
+		filler:   strings.Repeat("x", max(deltaSize, 0)),
+	}
+}
+
+func (p *timedProvider) Name() string { return "loadtest" }
+
+func (p *timedProvider) SupportsModel(model string) bool { return true }
+
+func (p *timedProvider) GenerateResponse(ctx context.Context, req *domainllm.GenerateRequest) (*domainllm.GenerateResponse, error) {
+	return nil, errors.New("the load test provider only streams")
+}
+
+func (p *timedProvider) StreamResponse(ctx context.Context, req *domainllm.GenerateRequest) (<-chan domainllm.StreamEvent, error) {
+	ch := make(chan domainllm.StreamEvent)
+
+	go func() {
+		defer close(ch)
+
+		send := func(event domainllm.StreamEvent) bool {
+			select {
+			case ch <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		blockType := llmModels.BlockTypeText
+		var text strings.Builder
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for i := 0; i < p.deltas; i++ {
+			if i > 0 {
+				select {
+				case <-ticker.C:
+				case <-ctx.Done():
+					return
+				}
+			}
+			delta := fmt.Sprintf("%d|%s", time.Now().UnixNano(), p.filler)
+			text.WriteString(delta)
+			if !send(domainllm.StreamEvent{Delta: &llmModels.TurnBlockDelta{
+				BlockIndex: 0,
+				BlockType:  &blockType,
+				DeltaType:  llmModels.DeltaTypeText,
+				TextDelta:  &delta,
+			}}) {
+				return
+			}
+		}
+
+		full := text.String()
+		if !send(domainllm.StreamEvent{Block: &llmModels.TurnBlock{
+			BlockType:   llmModels.BlockTypeText,
+			Sequence:    0,
+			TextContent: &full,
+		}}) {
+			return
+		}
+		send(domainllm.StreamEvent{Metadata: &domainllm.StreamMetadata{
+			Model:        req.Model,
+			InputTokens:  100,
+			OutputTokens: llmModels.EstimateTokens(full),
+			StopReason:   "end_turn",
+		}})
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,409 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	llmModels "meridian/internal/domain/models/llm"
+	domainllm "meridian/internal/domain/services/llm"
+	"meridian/internal/eventstream"
+	"meridian/internal/handler"
+	"meridian/internal/handler/sse"
+	"meridian/internal/service/llm/streaming"
+)
+
+// Load test for the SSE streaming path.
+//
+// Runs N concurrent turns through the real StreamExecutor, stream registry and SSE handler
+// (served over HTTP on a local test server), each watched by M SSE clients. The provider is
+// a fake that streams timestamped text deltas at a fixed pace, and turns are stored in
+// memory, so results measure the backend streaming path only - no database or LLM.
+
+const loadtestModel = "loadtest-model"
+
+type options struct {
+	streams       int
+	clients       int
+	deltas        int
+	deltaInterval time.Duration
+	deltaSize     int
+	ramp          time.Duration
+	timeout       time.Duration
+	sampleEvery   time.Duration
+	jsonOutput    bool
+	verbose       bool
+
+	// Regression thresholds (exit code 1 when exceeded)
+	maxP99     time.Duration
+	maxDropped int
+}
+
+func main() {
+	var opts options
+	flag.IntVar(&opts.streams, "streams", 50, "Number of concurrent streams (turns)")
+	flag.IntVar(&opts.clients, "clients", 2, "SSE clients per stream")
+	flag.IntVar(&opts.deltas, "deltas", 200, "Text deltas per stream")
+	flag.DurationVar(&opts.deltaInterval, "delta-interval", 10*time.Millisecond, "Time between deltas from the fake provider")
+	flag.IntVar(&opts.deltaSize, "delta-size", 16, "Filler bytes per delta (on top of the timestamp)")
+	flag.DurationVar(&opts.ramp, "ramp", time.Second, "Spread stream starts over this duration")
+	flag.DurationVar(&opts.timeout, "timeout", 5*time.Minute, "Abort the run after this duration")
+	flag.DurationVar(&opts.sampleEvery, "sample-every", 100*time.Millisecond, "Memory and registry sampling interval")
+	flag.BoolVar(&opts.jsonOutput, "json", false, "Print the report as JSON")
+	flag.BoolVar(&opts.verbose, "v", false, "Log streaming internals (noisy)")
+	flag.DurationVar(&opts.maxP99, "max-p99", 0, "Fail if p99 delta latency exceeds this (0 = no check)")
+	flag.IntVar(&opts.maxDropped, "max-dropped", -1, "Fail if more deltas than this are missing across all clients (-1 = no check)")
+	flag.Parse()
+
+	if opts.streams < 1 || opts.clients < 1 || opts.deltas < 1 {
+		log.Fatalf("streams, clients and deltas must be at least 1")
+	}
+
+	report := run(opts)
+
+	if opts.jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("Failed to encode report: %v", err)
+		}
+	} else {
+		report.print(os.Stdout)
+	}
+
+	if failures := report.check(opts); len(failures) > 0 {
+		for _, failure := range failures {
+			fmt.Fprintf(os.Stderr, "FAIL: %s\n", failure)
+		}
+		os.Exit(1)
+	}
+}
+
+// run executes the load test and returns the aggregated report
+func run(opts options) *report {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if opts.verbose {
+		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+
+	store := newMemoryTurnStore()
+	provider := newTimedProvider(opts.deltas, opts.deltaInterval, opts.deltaSize)
+	registry := eventstream.NewRegistry(eventstream.RegistryOptions{})
+
+	// Same SSE handler the API serves at GET /api/turns/{id}/stream (minus authorization)
+	sseHandler := handler.NewSSEHandler(registry, nil, logger, sse.DefaultConfig())
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/turns/{id}/stream", sseHandler.StreamTurn)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: &http.Transport{
+		MaxIdleConnsPerHost: opts.streams * opts.clients,
+	}}
+
+	var baseline runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&baseline)
+
+	sampler := newSampler(registry, opts.sampleEvery)
+	go sampler.run(ctx)
+
+	started := time.Now()
+	results := make([][]clientResult, opts.streams)
+	var wg sync.WaitGroup
+	for i := 0; i < opts.streams; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			if opts.ramp > 0 && opts.streams > 1 {
+				delay := opts.ramp * time.Duration(index) / time.Duration(opts.streams)
+				select {
+				case <-time.After(delay):
+				case <-ctx.Done():
+					return
+				}
+			}
+			results[index] = runStream(ctx, opts, server.URL, httpClient, store, provider, registry, logger)
+		}(i)
+	}
+	wg.Wait()
+	elapsed := time.Since(started)
+	sampler.stop()
+
+	// Completed streams leave the registry on completion; anything left is a leak
+	time.Sleep(opts.sampleEvery)
+
+	var final runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&final)
+
+	return buildReport(opts, results, elapsed, store, registry, sampler, &baseline, &final)
+}
+
+// runStream runs one turn: registers its stream, connects the SSE clients, starts the
+// executor once every client is subscribed and waits for all clients to finish
+func runStream(
+	ctx context.Context,
+	opts options,
+	baseURL string,
+	httpClient *http.Client,
+	store *memoryTurnStore,
+	provider domainllm.LLMProvider,
+	registry eventstream.Registry,
+	logger *slog.Logger,
+) []clientResult {
+	turnID := uuid.New().String()
+	store.addTurn(turnID, loadtestModel)
+
+	executor := streaming.NewStreamExecutor(
+		turnID,
+		loadtestModel,
+		store, // TurnWriter
+		store, // TurnReader
+		store, // TurnNavigator
+		provider,
+		nil, // No tools
+		nil, // No continuation, so no message builder
+		nil, // No tool results to guard
+		logger,
+		1,
+		streaming.ToolResultLimits{},
+		streaming.DefaultAccumulatorLimits(),
+		false,
+	)
+	if err := registry.Register(executor.GetStream()); err != nil {
+		return []clientResult{{err: err}}
+	}
+
+	url := fmt.Sprintf("%s/api/turns/%s/stream", baseURL, turnID)
+	results := make([]clientResult, opts.clients)
+	readies := make([]chan struct{}, opts.clients)
+	var wg sync.WaitGroup
+	for i := 0; i < opts.clients; i++ {
+		readies[i] = make(chan struct{})
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			results[index] = runClient(ctx, httpClient, url, readies[index])
+		}(i)
+	}
+
+	// The handler subscribes a client right after writing the catchup turn_start;
+	// a short pause covers the gap so no client misses the first deltas
+	for _, ready := range readies {
+		select {
+		case <-ready:
+		case <-ctx.Done():
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	executor.Start(&domainllm.GenerateRequest{
+		Model:  loadtestModel,
+		Params: &llmModels.RequestParams{},
+	})
+
+	wg.Wait()
+	return results
+}
+
+// sampler tracks peak memory, goroutines and registry size while the test runs
+type sampler struct {
+	registry eventstream.Registry
+	interval time.Duration
+	done     chan struct{}
+	finished chan struct{}
+
+	peakHeap       uint64
+	peakGoroutines int
+	peakStreams    int
+}
+
+func newSampler(registry eventstream.Registry, interval time.Duration) *sampler {
+	return &sampler{
+		registry: registry,
+		interval: interval,
+		done:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+}
+
+func (s *sampler) run(ctx context.Context) {
+	defer close(s.finished)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	var stats runtime.MemStats
+	for {
+		runtime.ReadMemStats(&stats)
+		s.peakHeap = max(s.peakHeap, stats.HeapAlloc)
+		s.peakGoroutines = max(s.peakGoroutines, runtime.NumGoroutine())
+		s.peakStreams = max(s.peakStreams, s.registry.Count())
+
+		select {
+		case <-ticker.C:
+		case <-s.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *sampler) stop() {
+	close(s.done)
+	<-s.finished
+}
+
+// report is the load test result
+type report struct {
+	Streams        int     `json:"streams"`
+	ClientsPerTurn int     `json:"clients_per_stream"`
+	DeltasPerTurn  int     `json:"deltas_per_stream"`
+	DurationMs     int64   `json:"duration_ms"`
+	DeltasPerSec   float64 `json:"delivered_deltas_per_sec"`
+
+	ExpectedDeltas  int `json:"expected_deltas"`
+	DeliveredDeltas int `json:"delivered_deltas"`
+	DroppedDeltas   int `json:"dropped_deltas"`
+
+	LatencyP50Ms float64 `json:"latency_p50_ms"`
+	LatencyP90Ms float64 `json:"latency_p90_ms"`
+	LatencyP99Ms float64 `json:"latency_p99_ms"`
+	LatencyMaxMs float64 `json:"latency_max_ms"`
+
+	ClientsCompleted int            `json:"clients_completed"`
+	ClientsErrored   int            `json:"clients_errored"`
+	ClientErrors     []string       `json:"client_errors,omitempty"` // first few, for diagnosis
+	TurnStatuses     map[string]int `json:"turn_statuses"`
+	BlocksPersisted  int64          `json:"blocks_persisted"`
+
+	PeakHeapMB     float64 `json:"peak_heap_mb"`
+	HeapGrowthMB   float64 `json:"heap_growth_mb"` // after the run and a GC, vs. before
+	PeakGoroutines int     `json:"peak_goroutines"`
+	PeakRegistered int     `json:"peak_registered_streams"`
+	LeftInRegistry int     `json:"streams_left_in_registry"`
+}
+
+const maxReportedErrors = 5
+
+func buildReport(
+	opts options,
+	results [][]clientResult,
+	elapsed time.Duration,
+	store *memoryTurnStore,
+	registry eventstream.Registry,
+	sampler *sampler,
+	baseline, final *runtime.MemStats,
+) *report {
+	r := &report{
+		Streams:         opts.streams,
+		ClientsPerTurn:  opts.clients,
+		DeltasPerTurn:   opts.deltas,
+		DurationMs:      elapsed.Milliseconds(),
+		ExpectedDeltas:  opts.streams * opts.clients * opts.deltas,
+		TurnStatuses:    store.statusCounts(),
+		BlocksPersisted: store.blocks.Load(),
+		PeakHeapMB:      float64(sampler.peakHeap) / (1 << 20),
+		HeapGrowthMB:    (float64(final.HeapAlloc) - float64(baseline.HeapAlloc)) / (1 << 20),
+		PeakGoroutines:  sampler.peakGoroutines,
+		PeakRegistered:  sampler.peakStreams,
+		LeftInRegistry:  registry.Count(),
+	}
+
+	var latencies []time.Duration
+	for _, streamResults := range results {
+		for _, result := range streamResults {
+			r.DeliveredDeltas += result.deltas
+			latencies = append(latencies, result.latencies...)
+			if result.completed {
+				r.ClientsCompleted++
+			}
+			failure := ""
+			switch {
+			case result.err != nil:
+				failure = result.err.Error()
+			case result.turnError != "":
+				failure = "turn_error: " + result.turnError
+			}
+			if failure != "" {
+				r.ClientsErrored++
+				if len(r.ClientErrors) < maxReportedErrors {
+					r.ClientErrors = append(r.ClientErrors, failure)
+				}
+			}
+		}
+	}
+	r.DroppedDeltas = r.ExpectedDeltas - r.DeliveredDeltas
+	if elapsed > 0 {
+		r.DeltasPerSec = float64(r.DeliveredDeltas) / elapsed.Seconds()
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	r.LatencyP50Ms = percentileMs(latencies, 0.50)
+	r.LatencyP90Ms = percentileMs(latencies, 0.90)
+	r.LatencyP99Ms = percentileMs(latencies, 0.99)
+	r.LatencyMaxMs = percentileMs(latencies, 1)
+
+	return r
+}
+
+// percentileMs returns the p-th percentile (0-1) of sorted latencies in milliseconds
+func percentileMs(sorted []time.Duration, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := int(p * float64(len(sorted)-1))
+	return float64(sorted[index].Microseconds()) / 1000
+}
+
+// check returns the threshold violations for this report
+func (r *report) check(opts options) []string {
+	var failures []string
+	if opts.maxP99 > 0 && r.LatencyP99Ms > float64(opts.maxP99.Microseconds())/1000 {
+		failures = append(failures, fmt.Sprintf("p99 latency %.1fms exceeds %s", r.LatencyP99Ms, opts.maxP99))
+	}
+	if opts.maxDropped >= 0 && r.DroppedDeltas > opts.maxDropped {
+		failures = append(failures, fmt.Sprintf("%d deltas dropped (max %d)", r.DroppedDeltas, opts.maxDropped))
+	}
+	if r.ClientsErrored > 0 {
+		failures = append(failures, fmt.Sprintf("%d clients failed", r.ClientsErrored))
+	}
+	if r.LeftInRegistry > 0 {
+		failures = append(failures, fmt.Sprintf("%d finished streams still registered", r.LeftInRegistry))
+	}
+	return failures
+}
+
+func (r *report) print(w io.Writer) {
+	fmt.Fprintf(w, "Streams:     %d x %d clients, %d deltas each (%.1fs)\n",
+		r.Streams, r.ClientsPerTurn, r.DeltasPerTurn, float64(r.DurationMs)/1000)
+	fmt.Fprintf(w, "Deltas:      %d/%d delivered, %d dropped (%.0f/s)\n",
+		r.DeliveredDeltas, r.ExpectedDeltas, r.DroppedDeltas, r.DeltasPerSec)
+	fmt.Fprintf(w, "Latency:     p50 %.2fms  p90 %.2fms  p99 %.2fms  max %.2fms\n",
+		r.LatencyP50Ms, r.LatencyP90Ms, r.LatencyP99Ms, r.LatencyMaxMs)
+	fmt.Fprintf(w, "Clients:     %d completed, %d failed\n", r.ClientsCompleted, r.ClientsErrored)
+	for _, failure := range r.ClientErrors {
+		fmt.Fprintf(w, "             - %s\n", failure)
+	}
+	fmt.Fprintf(w, "Turns:       %v, %d blocks persisted\n", r.TurnStatuses, r.BlocksPersisted)
+	fmt.Fprintf(w, "Memory:      peak heap %.1fMB, growth after run %.1fMB, peak goroutines %d\n",
+		r.PeakHeapMB, r.HeapGrowthMB, r.PeakGoroutines)
+	fmt.Fprintf(w, "Registry:    peak %d streams, %d left after run\n", r.PeakRegistered, r.LeftInRegistry)
+}
@@ -9,16 +9,12 @@ import (
 	"os"
 	"time"
 
+	"meridian/internal/app"
 	"meridian/internal/auth"
 	"meridian/internal/config"
 	docsysSvc "meridian/internal/domain/services/docsystem"
 	"meridian/internal/repository/postgres"
-	postgresDocsys "meridian/internal/repository/postgres/docsystem"
-	postgresLLM "meridian/internal/repository/postgres/llm"
 	"meridian/internal/seed"
-	serviceAuth "meridian/internal/service/auth"
-	serviceDocsys "meridian/internal/service/docsystem"
-	"meridian/internal/service/docsystem/converter"
 	"meridian/internal/utils"
 
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -53,16 +49,14 @@ func main() {
 		log.Printf("🌱 Seeding database (environment: %s, prefix: %s)", cfg.Environment, cfg.TablePrefix)
 	}
 
-	// Create database connection pool
+	// Construct repositories and services (internal/app); no background workers needed
 	ctx := context.Background()
-	pool, err := postgres.CreateConnectionPool(ctx, cfg.SupabaseDBURL)
+	a, err := app.New(ctx, cfg, logger)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		log.Fatalf("Failed to initialize: %v", err)
 	}
-	defer pool.Close()
-
-	// Create table names
-	tables := postgres.NewTableNames(cfg.TablePrefix)
+	defer a.Stop(ctx)
+	pool, tables := a.Pool, a.Tables
 
 	// Create auth admin client for user management
 	authClient := auth.NewAdminClient(cfg.SupabaseURL, cfg.SupabaseKey)
@@ -103,45 +97,6 @@ func main() {
 		log.Fatalf("Failed to ensure test project: %v", err)
 	}
 
-	// Create repositories for document seeding
-	repoConfig := &postgres.RepositoryConfig{
-		Pool:   pool,
-		Tables: tables,
-		Logger: logger,
-	}
-	projectRepo := postgresDocsys.NewProjectRepository(repoConfig)
-	docRepo := postgresDocsys.NewDocumentRepository(repoConfig)
-	folderRepo := postgresDocsys.NewFolderRepository(repoConfig)
-	txManager := postgres.NewTransactionManager(pool)
-
-	// Chat/turn repos for authorizer (needed for auth chain: turn → chat → project → user)
-	chatRepo := postgresLLM.NewChatRepository(repoConfig)
-	turnRepo := postgresLLM.NewTurnRepository(repoConfig)
-
-	// Create validator for soft-delete validation
-	docsysValidator := serviceDocsys.NewResourceValidator(projectRepo, folderRepo)
-
-	// Create authorizer (ownership-based)
-	authorizer := serviceAuth.NewOwnerBasedAuthorizer(projectRepo, folderRepo, docRepo, chatRepo, turnRepo)
-
-	// Create services for document seeding
-	contentAnalyzer := serviceDocsys.NewContentAnalyzer()
-	pathResolver := serviceDocsys.NewPathResolver(folderRepo, txManager)
-	docService := serviceDocsys.NewDocumentService(docRepo, folderRepo, txManager, contentAnalyzer, pathResolver, docsysValidator, authorizer, logger)
-	converterRegistry := converter.NewConverterRegistry()
-
-	// Create file processor registry
-	fileProcessorRegistry := serviceDocsys.NewFileProcessorRegistry()
-
-	// Register file processors
-	zipProcessor := serviceDocsys.NewZipFileProcessor(docRepo, docService, converterRegistry, logger)
-	individualProcessor := serviceDocsys.NewIndividualFileProcessor(docRepo, docService, converterRegistry, logger)
-	fileProcessorRegistry.Register(zipProcessor)
-	fileProcessorRegistry.Register(individualProcessor)
-
-	// Create import service with processor registry
-	importService := serviceDocsys.NewImportService(docRepo, fileProcessorRegistry, logger)
-
 	// Seed documents using import service (additive - use --clear-data flag to clear first)
 	log.Println("📝 Seeding documents from seed_data directory...")
 
@@ -158,7 +113,7 @@ func main() {
 			Content:  bytes.NewReader(zipBuffer.Bytes()),
 		},
 	}
-	result, err := importService.ProcessFiles(ctx, projectID, userID, uploadedFiles, "", true) // overwrite=true for seeding
+	result, err := a.Services.Import.ProcessFiles(ctx, projectID, userID, uploadedFiles, "", docsysSvc.ImportOptions{Conflict: docsysSvc.ConflictOverwrite})
 	if err != nil {
 		log.Fatalf("Failed to process seed data: %v", err)
 	}
@@ -7,23 +7,21 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
-	"strings"
+	"os/signal"
+	"syscall"
 	"time"
+	_ "time/tzdata" // Users' time zones must load on images without a zoneinfo database
 
+	"meridian/internal/app"
 	"meridian/internal/auth"
-	"meridian/internal/capabilities"
 	"meridian/internal/config"
+	"meridian/internal/errreport"
+	"meridian/internal/eventstream"
 	"meridian/internal/handler"
+	"meridian/internal/httputil"
 	"meridian/internal/middleware"
-	"meridian/internal/repository/postgres"
-	postgresDocsys "meridian/internal/repository/postgres/docsystem"
-	postgresLLM "meridian/internal/repository/postgres/llm"
-	"meridian/internal/service"
-	serviceAuth "meridian/internal/service/auth"
-	serviceDocsys "meridian/internal/service/docsystem"
-	"meridian/internal/service/docsystem/converter"
-	serviceLLM "meridian/internal/service/llm"
-	domainLLM "meridian/internal/domain/services/llm"
+	"meridian/internal/router"
+	"meridian/internal/storage"
 
 	"github.com/joho/godotenv"
 	"github.com/rs/cors"
@@ -36,11 +34,6 @@ func main() {
 	// Load configuration
 	cfg := config.Load()
 
-	// Create tool limit resolver (tier-ready architecture)
-	// Uses MAX_TOOL_ROUNDS from env (default: 10) - generous while no subscription tiers
-	// When Stripe subscriptions go live, swap in JWTTierResolver (one line change)
-	toolLimitResolver := domainLLM.NewConfigToolLimitResolver(cfg.MaxToolRounds)
-
 	// Setup structured logging
 	logLevel := slog.LevelInfo
 	if cfg.Environment == "dev" {
@@ -63,229 +56,166 @@ func main() {
 	}))
 	slog.SetDefault(logger) // Set as default logger
 
+	// Error reporting (no-op without SENTRY_DSN)
+	reporter, err := errreport.New(errreport.Config{
+		DSN:         cfg.SentryDSN,
+		Environment: cfg.Environment,
+		Release:     cfg.SentryRelease,
+	}, logger)
+	if err != nil {
+		log.Fatalf("Failed to setup error reporting: %v", err)
+	}
+	errreport.SetDefault(reporter)
+	defer errreport.Flush(5 * time.Second)
+
 	logger.Info("server starting",
 		"environment", cfg.Environment,
+		"error_reporting", cfg.SentryDSN != "",
 		"port", cfg.Port,
 		"table_prefix", cfg.TablePrefix,
 	)
 
-	// Create JWT verifier for Supabase authentication
-	jwtVerifier, err := auth.NewJWTVerifier(cfg.SupabaseJWKSURL, logger)
+	// Construct repositories and services (internal/app)
+	ctx := context.Background()
+	a, err := app.New(ctx, cfg, logger)
 	if err != nil {
-		log.Fatalf("Failed to create JWT verifier: %v", err)
+		log.Fatalf("Failed to initialize app: %v", err)
 	}
-	defer jwtVerifier.Close()
+	svcs := a.Services
 
-	// Create pgx connection pool
-	ctx := context.Background()
-	pool, err := postgres.CreateConnectionPool(ctx, cfg.SupabaseDBURL)
+	// Create JWT verifier for Supabase authentication (closed with the app)
+	jwtVerifier, err := auth.NewJWTVerifier(cfg.SupabaseJWKSURL, logger)
 	if err != nil {
-		log.Fatalf("Failed to create connection pool: %v", err)
+		_ = a.Stop(ctx)
+		log.Fatalf("Failed to create JWT verifier: %v", err)
 	}
-	defer pool.Close()
-
-	logger.Info("database connected",
-		"max_conns", 25,
-		"min_conns", 5,
-	)
-
-	// Create table names
-	tables := postgres.NewTableNames(cfg.TablePrefix)
-
-	// Create repositories
-	repoConfig := &postgres.RepositoryConfig{
-		Pool:   pool,
-		Tables: tables,
-		Logger: logger,
+	a.Append(app.Hook{Name: "JWT verifier", Stop: func(context.Context) error {
+		jwtVerifier.Close()
+		return nil
+	}})
+
+	// Startup cleanup and background workers (stream cleanup, periodic jobs)
+	if err := a.Start(ctx); err != nil {
+		log.Fatalf("Failed to start app: %v", err)
 	}
-	projectRepo := postgresDocsys.NewProjectRepository(repoConfig)
-	docRepo := postgresDocsys.NewDocumentRepository(repoConfig)
-	folderRepo := postgresDocsys.NewFolderRepository(repoConfig)
-	txManager := postgres.NewTransactionManager(pool)
-
-	// Chat repositories
-	chatRepo := postgresLLM.NewChatRepository(repoConfig)
-	turnRepo := postgresLLM.NewTurnRepository(repoConfig)
 
-	// User preferences repository
-	userPrefsRepo := postgres.NewUserPreferencesRepository(repoConfig)
-
-	// Create validators (for soft-delete validation)
-	docsysValidator := serviceDocsys.NewResourceValidator(projectRepo, folderRepo)
-
-	// Create authorizer (ownership-based, swappable for role-based later)
-	// Needs all repositories for checking ownership chains (turn → chat → project → user)
-	authorizer := serviceAuth.NewOwnerBasedAuthorizer(projectRepo, folderRepo, docRepo, chatRepo, turnRepo)
-
-	// Setup LLM providers
-	providerRegistry, err := serviceLLM.SetupProviders(cfg, logger)
-	if err != nil {
-		log.Fatalf("Failed to setup LLM providers: %v", err)
+	// Handlers (follow Clean Architecture - no repository access)
+	handlers := &router.Handlers{
+		Project:           handler.NewProjectHandler(svcs.Project, svcs.ProjectSettings, logger),
+		StyleProfile:      handler.NewStyleProfileHandler(svcs.StyleProfile, logger),
+		Tree:              handler.NewTreeHandler(svcs.Tree, logger),
+		Skill:             handler.NewSkillHandler(svcs.Skill, logger),
+		Duplicate:         handler.NewDuplicateHandler(svcs.Duplicate, logger),
+		Snapshot:          handler.NewSnapshotHandler(svcs.Snapshot, logger),
+		Export:            handler.NewExportHandler(svcs.Export, logger),
+		Archive:           handler.NewArchiveHandler(svcs.Archive, logger),
+		Publish:           handler.NewPublishHandler(svcs.Publish, logger),
+		Folder:            handler.NewFolderHandler(svcs.Folder, logger),
+		Document:          handler.NewDocumentHandler(svcs.Document, logger),
+		DocumentDiff:      handler.NewDocumentDiffHandler(svcs.DocumentDiff, logger),
+		DocumentAnalytics: handler.NewDocumentAnalyticsHandler(svcs.DocumentAnalytics, logger),
+		DocumentPresence:  handler.NewDocumentPresenceHandler(svcs.DocumentPresence, logger),
+		DocumentCollab:    handler.NewDocumentCollabHandler(svcs.DocumentCollab, a.Authorizer, logger),
+		Import:            handler.NewImportHandler(svcs.Import, a.Authorizer, logger),
+		Job:               handler.NewJobHandler(svcs.Job, logger),
+		Trash:             handler.NewTrashHandler(svcs.Trash, logger),
+
+		Chat:       handler.NewChatHandler(svcs.Chat, svcs.Conversation, svcs.Streaming, a.StreamRegistry, a.Authorizer, logger),
+		Workflow:   handler.NewWorkflowHandler(svcs.Workflow, logger),
+		Eval:       handler.NewEvalHandler(svcs.Eval, logger),
+		Speech:     handler.NewSpeechHandler(svcs.Speech, logger),
+		Critique:   handler.NewCritiqueHandler(svcs.Critique, logger),
+		Pin:        handler.NewPinHandler(svcs.Pin, logger),
+		Attachment: handler.NewAttachmentHandler(svcs.Attachment, int64(cfg.AttachmentMaxMB)<<20, logger),
+		Editor:     handler.NewEditorHandler(svcs.Editor, logger),
+		Models:     handler.NewModelsHandler(cfg, logger, a.Capabilities),
+		Usage:      handler.NewUsageHandler(svcs.Usage, logger),
+		Experiment: handler.NewExperimentHandler(svcs.Experiment, logger),
+		Abuse:      handler.NewAbuseHandler(svcs.Abuse, logger),
+
+		UserPreferences: handler.NewUserPreferencesHandler(svcs.UserPreferences, logger),
+		ProviderKey:     handler.NewProviderKeyHandler(svcs.ProviderKeys, logger),
+		DataControls:    handler.NewDataControlsHandler(svcs.DataControls, logger),
 	}
 
-	// Initialize capability registry
-	capabilityRegistry, err := capabilities.NewRegistry()
-	if err != nil {
-		log.Fatalf("Failed to initialize capability registry: %v", err)
-	}
-	logger.Info("capability registry initialized")
-
-	// Setup LLM services (chat, conversation, streaming)
-	llmServices, streamRegistry, err := serviceLLM.SetupServices(
-		chatRepo,
-		turnRepo,
-		projectRepo,
-		docRepo,
-		folderRepo,
-		providerRegistry,
-		cfg,
-		txManager,
-		capabilityRegistry,
-		authorizer,
-		toolLimitResolver,
-		logger,
-	)
-	if err != nil {
-		log.Fatalf("Failed to setup LLM services: %v", err)
+	// Signed object downloads (local storage backend only; S3 URLs point at the bucket)
+	if localStore, ok := a.ObjectStore.(*storage.LocalStore); ok {
+		handlers.ObjectDownloads = localStore
 	}
 
-	// Create document services
-	contentAnalyzer := serviceDocsys.NewContentAnalyzer()
-	pathResolver := serviceDocsys.NewPathResolver(folderRepo, txManager)
-	projectService := serviceDocsys.NewProjectService(projectRepo, logger)
-	docService := serviceDocsys.NewDocumentService(docRepo, folderRepo, txManager, contentAnalyzer, pathResolver, docsysValidator, authorizer, logger)
-	folderService := serviceDocsys.NewFolderService(folderRepo, docRepo, docService, pathResolver, txManager, docsysValidator, authorizer, logger)
-	treeService := serviceDocsys.NewTreeService(folderRepo, docRepo, authorizer, logger)
-	converterRegistry := converter.NewConverterRegistry()
-
-	// Create file processor registry
-	fileProcessorRegistry := serviceDocsys.NewFileProcessorRegistry()
-
-	// Register file processors
-	zipProcessor := serviceDocsys.NewZipFileProcessor(docRepo, docService, converterRegistry, logger)
-	individualProcessor := serviceDocsys.NewIndividualFileProcessor(docRepo, docService, converterRegistry, logger)
-	fileProcessorRegistry.Register(zipProcessor)
-	fileProcessorRegistry.Register(individualProcessor)
-
-	// Create import service with processor registry
-	importService := serviceDocsys.NewImportService(docRepo, fileProcessorRegistry, logger)
-
-	// Create user preferences service
-	userPrefsService := service.NewUserPreferencesService(userPrefsRepo, logger)
-
-	// Create new handlers
-	projectHandler := handler.NewProjectHandler(projectService, logger)
-	newDocHandler := handler.NewDocumentHandler(docService, logger)
-	newFolderHandler := handler.NewFolderHandler(folderService, logger)
-	newTreeHandler := handler.NewTreeHandler(treeService, logger)
-	importHandler := handler.NewImportHandler(importService, authorizer, logger)
-
-	// Chat handlers (follows Clean Architecture - no repository access)
-	chatHandler := handler.NewChatHandler(
-		llmServices.Chat,
-		llmServices.Conversation,
-		llmServices.Streaming,
-		streamRegistry,
-		authorizer,
-		logger,
-	)
-
-	// Model capabilities and user preferences handlers
-	modelsHandler := handler.NewModelsHandler(cfg, logger, capabilityRegistry)
-	userPrefsHandler := handler.NewUserPreferencesHandler(userPrefsService, logger)
-
 	// Debug handlers (only in dev environment)
-	var chatDebugHandler *handler.ChatDebugHandler
 	if cfg.Environment == "dev" {
-		chatDebugHandler = handler.NewChatDebugHandler(llmServices.Conversation, llmServices.Streaming, cfg)
-		logger.Warn("DEBUG MODE: Debug endpoints enabled (NEVER use in production!)")
+		handlers.ChatDebug = handler.NewChatDebugHandler(svcs.Conversation, svcs.Streaming, cfg)
+		logger.Warn("DEBUG MODE: Debug endpoints enabled under /debug/api (NEVER use in production!)")
+	}
+
+	// Maintenance mode (MAINTENANCE_MODE or MAINTENANCE_FILE): blocks writes, lets streams drain
+	maintenance := middleware.NewMaintenanceMode(middleware.MaintenanceConfig{
+		Enabled:    cfg.MaintenanceMode,
+		FlagFile:   cfg.MaintenanceFile,
+		Message:    cfg.MaintenanceMessage,
+		RetryAfter: time.Duration(cfg.MaintenanceRetryAfterSec) * time.Second,
+	})
+	if maintenance.Active() {
+		logger.Warn("maintenance mode active: mutating requests will be rejected")
 	}
+	handlers.Health = handler.NewHealthHandler(maintenance, a.StreamRegistry)
 
 	logger.Info("services initialized")
 
-	// Create HTTP router (Go 1.22+ enhanced patterns)
-	mux := http.NewServeMux()
-
-	// Health check
-	mux.HandleFunc("GET /health", newDocHandler.HealthCheck)
-
-	// Project routes
-	mux.HandleFunc("GET /api/projects", projectHandler.ListProjects)
-	mux.HandleFunc("POST /api/projects", projectHandler.CreateProject)
-	mux.HandleFunc("GET /api/projects/{id}", projectHandler.GetProject)
-	mux.HandleFunc("PATCH /api/projects/{id}", projectHandler.UpdateProject)
-	mux.HandleFunc("DELETE /api/projects/{id}", projectHandler.DeleteProject)
-
-	// Project tree endpoint
-	mux.HandleFunc("GET /api/projects/{id}/tree", newTreeHandler.GetTree)
-
-	// Folder routes
-	mux.HandleFunc("POST /api/folders", newFolderHandler.CreateFolder)
-	mux.HandleFunc("GET /api/folders/{id}", newFolderHandler.GetFolder)
-	mux.HandleFunc("PATCH /api/folders/{id}", newFolderHandler.UpdateFolder)
-	mux.HandleFunc("DELETE /api/folders/{id}", newFolderHandler.DeleteFolder)
-	mux.HandleFunc("GET /api/folders/{id}/children", newFolderHandler.ListChildren)
-
-	// Document routes
-	mux.HandleFunc("POST /api/documents", newDocHandler.CreateDocument)
-	mux.HandleFunc("GET /api/documents/search", newDocHandler.SearchDocuments) // Must come before {id} route
-	mux.HandleFunc("GET /api/documents/{id}", newDocHandler.GetDocument)
-	mux.HandleFunc("PATCH /api/documents/{id}", newDocHandler.UpdateDocument)
-	mux.HandleFunc("DELETE /api/documents/{id}", newDocHandler.DeleteDocument)
-
-	// Import routes
-	mux.HandleFunc("POST /api/import", importHandler.Merge)
-	mux.HandleFunc("POST /api/import/replace", importHandler.Replace)
-
-	// Model capabilities routes
-	mux.HandleFunc("GET /api/models/capabilities", modelsHandler.GetCapabilities)
-
-	// User preferences routes
-	mux.HandleFunc("GET /api/users/me/preferences", userPrefsHandler.GetPreferences)
-	mux.HandleFunc("PATCH /api/users/me/preferences", userPrefsHandler.UpdatePreferences)
-
-	// Chat routes
-	mux.HandleFunc("POST /api/chats", chatHandler.CreateChat)
-	mux.HandleFunc("GET /api/chats", chatHandler.ListChats)
-	mux.HandleFunc("GET /api/chats/{id}", chatHandler.GetChat)
-	mux.HandleFunc("PATCH /api/chats/{id}", chatHandler.UpdateChat)
-	mux.HandleFunc("PATCH /api/chats/{id}/last-viewed-turn", chatHandler.UpdateLastViewedTurn)
-	mux.HandleFunc("DELETE /api/chats/{id}", chatHandler.DeleteChat)
-	mux.HandleFunc("GET /api/chats/{id}/turns", chatHandler.GetPaginatedTurns)
-	mux.HandleFunc("POST /api/chats/{id}/turns", chatHandler.CreateTurn) // Deprecated: use POST /api/turns
-	mux.HandleFunc("POST /api/turns", chatHandler.CreateTurnV2)          // New: chat_id/project_id in body
-	mux.HandleFunc("GET /api/turns/{id}/path", chatHandler.GetTurnPath)
-	mux.HandleFunc("GET /api/turns/{id}/siblings", chatHandler.GetTurnSiblings)
-
-	// Streaming routes
-	mux.HandleFunc("GET /api/turns/{id}/stream", chatHandler.StreamTurn)            // SSE streaming endpoint
-	mux.HandleFunc("GET /api/turns/{id}/blocks", chatHandler.GetTurnBlocks)         // Get completed blocks
-	mux.HandleFunc("GET /api/turns/{id}/token-usage", chatHandler.GetTurnTokenUsage) // Get token usage stats
-	mux.HandleFunc("POST /api/turns/{id}/interrupt", chatHandler.InterruptTurn)     // Cancel streaming turn
-
-	// Debug routes (only in dev environment)
-	if cfg.Environment == "dev" && chatDebugHandler != nil {
-		mux.HandleFunc("POST /debug/api/chats/{id}/turns", chatDebugHandler.CreateAssistantTurn)
-		mux.HandleFunc("GET /debug/api/chats/{id}/tree", chatDebugHandler.GetChatTree)
-		mux.HandleFunc("POST /debug/api/chats/{id}/llm-request", chatDebugHandler.BuildProviderRequest)
-		logger.Warn("Debug route registered: POST /debug/api/chats/:id/turns (assistant turn creation)")
-		logger.Warn("Debug route registered: GET /debug/api/chats/:id/tree (full conversation tree - use pagination in production)")
-		logger.Warn("Debug route registered: POST /debug/api/chats/:id/llm-request (LLM provider request preview)")
+	// Operator API (/admin/api): only registered when ADMIN_API_TOKEN is set
+	var adminAuth router.Middleware
+	if cfg.AdminAPIToken != "" {
+		adminAuth = middleware.AdminTokenMiddleware(cfg.AdminAPIToken)
+		logger.Info("admin API enabled under /admin/api")
 	}
 
-	// Build middleware chain
-	var handler http.Handler = mux
+	// Per-user rate limits; provider-calling routes (generation group) get the tighter one
+	rateLimit := rateLimitMiddleware(middleware.RateLimit{PerMinute: cfg.RateLimitPerMinute, Burst: cfg.RateLimitBurst})
+	generationRateLimit := rateLimitMiddleware(middleware.RateLimit{PerMinute: cfg.GenerationRateLimitPerMinute, Burst: cfg.GenerationRateLimitBurst})
+
+	// Route groups (internal/router); every group but admin requires auth, and every route
+	// but streaming ones has the request timeout (the server's WriteTimeout is off for SSE)
+	routes := router.New(handlers, router.Options{
+		Environment:         cfg.Environment,
+		Auth:                middleware.AuthMiddleware(jwtVerifier),
+		Timeout:             middleware.Timeout(time.Duration(cfg.RequestTimeoutSeconds) * time.Second),
+		AdminAuth:           adminAuth,
+		RateLimit:           rateLimit,
+		GenerationRateLimit: generationRateLimit,
+	})
+	mux, err := routes.Handler()
+	if err != nil {
+		_ = a.Stop(ctx)
+		log.Fatalf("Failed to register routes: %v", err)
+	}
 
-	// Apply middleware in reverse order (they wrap each other)
-	// Order: CORS → Recovery → Auth → Routes
-	handler = middleware.AuthMiddleware(jwtVerifier)(handler)
+	// Apply global middleware in reverse order (they wrap each other)
+	// Order: CORS → RequestID → Recovery → Maintenance → route group middleware → Routes
+	handler := maintenance.Middleware()(mux)
 	handler = middleware.Recovery(logger)(handler)
+	handler = middleware.RequestID()(handler)
 
 	// CORS - Must be before auth to handle OPTIONS pre-flight requests
+	// Origins support wildcards and presets; CORS_ORIGINS_FILE (if set) overrides CORS_ORIGINS
+	// and is re-read on SIGHUP
+	corsOrigins, err := middleware.NewCORSOrigins(cfg.CORSOrigins)
+	if err != nil {
+		log.Fatalf("Invalid CORS_ORIGINS: %v", err)
+	}
+	if cfg.CORSOriginsFile != "" {
+		if err := corsOrigins.ReloadFile(cfg.CORSOriginsFile); err != nil {
+			log.Fatalf("Invalid CORS_ORIGINS_FILE: %v", err)
+		}
+		go reloadCORSOnSignal(corsOrigins, cfg.CORSOriginsFile, logger)
+	}
+	logCORSOrigins(logger, cfg.Environment, corsOrigins.Current())
+
 	corsHandler := cors.New(cors.Options{
-		AllowedOrigins:   strings.Split(cfg.CORSOrigins, ","),
+		AllowOriginFunc:  corsOrigins.Allowed,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Origin", "Content-Type", "Accept", "Authorization", "Last-Event-ID"},
+		AllowedHeaders:   []string{"Origin", "Content-Type", "Accept", "Authorization", "Last-Event-ID", middleware.RequestIDHeader},
+		ExposedHeaders:   []string{middleware.RequestIDHeader, httputil.TotalCountHeader},
 		AllowCredentials: true,
 	})
 	handler = corsHandler.Handler(handler)
@@ -301,7 +231,102 @@ func main() {
 
 	// Start server
 	logger.Info("server starting", "port", cfg.Port)
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		log.Fatalf("Failed to start server: %v", err)
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	// Run until SIGINT/SIGTERM, then stop accepting requests and stop the app
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case err := <-serverErr:
+		if err != http.ErrServerClosed {
+			_ = a.Stop(ctx)
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	case sig := <-stop:
+		logger.Info("shutting down", "signal", sig.String())
+	}
+
+	// Tell connected stream clients to reconnect elsewhere once their stream ends (or is
+	// evicted by Drain) rather than retrying this instance
+	a.StreamRegistry.Advise(eventstream.ReconnectAdvice{
+		Reason:     eventstream.AdviceDraining,
+		RetryAfter: time.Duration(cfg.StreamReconnectRetryAfterSeconds) * time.Second,
+	})
+
+	// Stop accepting requests. Shutdown returns once open connections are done, which for
+	// SSE and WebSocket clients is when their turn's stream finishes.
+	drainTimeout := time.Duration(cfg.ShutdownDrainTimeoutSeconds) * time.Second
+	serverCtx, cancelServer := context.WithTimeout(ctx, drainTimeout+streamStopTimeout+shutdownTimeout)
+	defer cancelServer()
+	serverStopped := make(chan error, 1)
+	go func() {
+		serverStopped <- server.Shutdown(serverCtx)
+	}()
+
+	// Let streaming turns finish, then cancel the rest; a cancelled turn keeps the blocks
+	// completed so far, so the pool must stay open until its executor has stopped
+	logger.Info("draining streams", "active_streams", a.StreamRegistry.Count(), "timeout", drainTimeout)
+	drainCtx, cancelDrain := context.WithTimeout(ctx, drainTimeout)
+	cancelled, err := eventstream.Drain(drainCtx, a.StreamRegistry, streamStopTimeout)
+	cancelDrain()
+	if cancelled > 0 {
+		logger.Warn("cancelled streams still running after the drain timeout", "count", cancelled)
+	}
+	if err != nil {
+		logger.Error("streams did not stop, their partial content may be lost", "error", err)
+	}
+
+	if err := <-serverStopped; err != nil {
+		logger.Warn("server shutdown incomplete", "error", err)
+	}
+
+	stopCtx, cancelStop := context.WithTimeout(ctx, shutdownTimeout)
+	defer cancelStop()
+	if err := a.Stop(stopCtx); err != nil {
+		logger.Error("failed to stop app", "error", err)
+	}
+	logger.Info("server stopped")
+}
+
+// rateLimitMiddleware returns the middleware enforcing limit, or nil if it is disabled
+func rateLimitMiddleware(limit middleware.RateLimit) router.Middleware {
+	if !limit.Enabled() {
+		return nil
+	}
+	return middleware.NewRateLimiter(limit).Middleware()
+}
+
+// shutdownTimeout bounds how long shutdown waits for requests and workers to finish
+const shutdownTimeout = 30 * time.Second
+
+// streamStopTimeout bounds how long shutdown waits for cancelled streams to persist and stop
+const streamStopTimeout = 10 * time.Second
+
+// reloadCORSOnSignal re-reads the CORS origins file on every SIGHUP.
+// A file that fails to parse leaves the current origins in place.
+func reloadCORSOnSignal(origins *middleware.CORSOrigins, path string, logger *slog.Logger) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	for range signals {
+		if err := origins.ReloadFile(path); err != nil {
+			logger.Error("failed to reload CORS origins, keeping current", "error", err, "file", path)
+			continue
+		}
+		logger.Info("CORS origins reloaded", "origins", origins.Current().Entries())
+	}
+}
+
+// logCORSOrigins logs the active origins and warns about risky configurations
+func logCORSOrigins(logger *slog.Logger, environment string, matcher *middleware.OriginMatcher) {
+	entries := matcher.Entries()
+	logger.Info("CORS origins configured", "origins", entries)
+	switch {
+	case matcher.AllowsAll() && environment == "prod":
+		logger.Warn("CORS allows all origins with credentials in production")
+	case len(entries) == 0:
+		logger.Warn("no CORS origins configured, browsers will block cross-origin requests")
 	}
 }
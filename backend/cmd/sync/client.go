@@ -0,0 +1,178 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	docsysModels "meridian/internal/domain/models/docsystem"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/handler"
+	"meridian/internal/httputil"
+)
+
+// apiClient talks to the Meridian API on behalf of one user and project
+type apiClient struct {
+	baseURL    string
+	token      string
+	projectID  string
+	httpClient *http.Client
+}
+
+func newAPIClient(baseURL, token, projectID string) *apiClient {
+	return &apiClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+		projectID:  projectID,
+		httpClient: &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// manifest returns every document's path and content hash
+func (c *apiClient) manifest(ctx context.Context) ([]docsysSvc.ImportManifestEntry, error) {
+	query := url.Values{"project_id": {c.projectID}}
+	var response handler.ImportManifestResponse
+	if err := c.do(ctx, http.MethodGet, "/api/import/manifest?"+query.Encode(), nil, "", &response); err != nil {
+		return nil, err
+	}
+	return response.Documents, nil
+}
+
+// document fetches one document with its content
+func (c *apiClient) document(ctx context.Context, id string) (*docsysModels.Document, error) {
+	var doc docsysModels.Document
+	if err := c.do(ctx, http.MethodGet, "/api/documents/"+url.PathEscape(id), nil, "", &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// jobPollInterval is how often upload checks a queued import
+const jobPollInterval = time.Second
+
+// upload imports files (document path → Markdown) as one zip, so folders are
+// created from the paths, using the given conflict strategy and base revisions.
+// The import runs as a background job; upload waits for it to finish.
+func (c *apiClient) upload(
+	ctx context.Context,
+	files map[string]string,
+	conflict docsysSvc.ConflictStrategy,
+	baseRevisions map[string]string,
+) (*docsysSvc.ImportResult, error) {
+	var archive bytes.Buffer
+	zipWriter := zip.NewWriter(&archive)
+	for path, content := range files {
+		entry, err := zipWriter.Create(path + ".md")
+		if err != nil {
+			return nil, fmt.Errorf("add %s to upload: %w", path, err)
+		}
+		if _, err := io.WriteString(entry, content); err != nil {
+			return nil, fmt.Errorf("add %s to upload: %w", path, err)
+		}
+	}
+	if err := zipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("build upload: %w", err)
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("files", "sync.zip")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(archive.Bytes()); err != nil {
+		return nil, err
+	}
+	if len(baseRevisions) > 0 {
+		encoded, err := json.Marshal(baseRevisions)
+		if err != nil {
+			return nil, err
+		}
+		if err := form.WriteField("base_revisions", string(encoded)); err != nil {
+			return nil, err
+		}
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{"project_id": {c.projectID}, "conflict": {string(conflict)}}
+	var job docsysModels.Job
+	if err := c.do(ctx, http.MethodPost, "/api/import?"+query.Encode(), &body, form.FormDataContentType(), &job); err != nil {
+		return nil, err
+	}
+	return c.waitForImport(ctx, job.ID)
+}
+
+// waitForImport polls an import job until it finishes and returns its result
+func (c *apiClient) waitForImport(ctx context.Context, jobID string) (*docsysSvc.ImportResult, error) {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		var job docsysModels.Job
+		if err := c.do(ctx, http.MethodGet, "/api/jobs/"+url.PathEscape(jobID), nil, "", &job); err != nil {
+			return nil, err
+		}
+		switch job.Status {
+		case docsysModels.JobStatusCompleted:
+			var result docsysSvc.ImportResult
+			if err := json.Unmarshal(job.Result, &result); err != nil {
+				return nil, fmt.Errorf("decode import result: %w", err)
+			}
+			return &result, nil
+		case docsysModels.JobStatusFailed:
+			message := "unknown error"
+			if job.Error != nil {
+				message = *job.Error
+			}
+			return nil, fmt.Errorf("import failed: %s", message)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// do sends a request and decodes a JSON response, turning problem details into errors
+func (c *apiClient) do(ctx context.Context, method, path string, body io.Reader, contentType string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var problem httputil.ProblemDetail
+		if err := json.NewDecoder(resp.Body).Decode(&problem); err == nil && problem.Detail != "" {
+			return fmt.Errorf("%s %s: %d %s", method, strings.SplitN(path, "?", 2)[0], resp.StatusCode, problem.Detail)
+		}
+		return fmt.Errorf("%s %s: unexpected status %d", method, strings.SplitN(path, "?", 2)[0], resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode %s response: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+// Filesystem sync CLI.
+//
+// Mirrors a local directory of Markdown files with a project through the API, so writers
+// can use local editors and git while the web app stays in sync:
+//
+//	push   upload local files that differ from the project (content hashes decide)
+//	pull   download documents that changed in the project, merging local edits
+//	watch  push and pull on an interval until interrupted
+//
+// Each document "chapters/intro" maps to chapters/intro.md. The last synced content of every
+// document is kept under .meridian/ and used as the base revision for three-way merges.
+
+type options struct {
+	apiURL    string
+	token     string
+	projectID string
+	dir       string
+	conflict  string
+	interval  time.Duration
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	command := os.Args[1]
+
+	var opts options
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	flags.StringVar(&opts.apiURL, "api", envOr("MERIDIAN_API_URL", "http://localhost:8080"), "API base URL (env MERIDIAN_API_URL)")
+	flags.StringVar(&opts.token, "token", os.Getenv("MERIDIAN_TOKEN"), "Bearer token (env MERIDIAN_TOKEN)")
+	flags.StringVar(&opts.projectID, "project", os.Getenv("MERIDIAN_PROJECT_ID"), "Project ID (env MERIDIAN_PROJECT_ID)")
+	flags.StringVar(&opts.dir, "dir", ".", "Local directory to sync")
+	flags.StringVar(&opts.conflict, "conflict", string(docsysSvc.ConflictMerge), "Push conflict strategy: skip, overwrite, rename or merge")
+	flags.DurationVar(&opts.interval, "interval", 5*time.Second, "Watch mode polling interval")
+	flags.Parse(os.Args[2:])
+
+	if opts.token == "" || opts.projectID == "" {
+		log.Fatalf("--token and --project are required (or MERIDIAN_TOKEN / MERIDIAN_PROJECT_ID)")
+	}
+	conflict, err := docsysSvc.ParseConflictStrategy(opts.conflict)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	dir, err := openSyncDir(opts.dir, opts.projectID)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	s := &syncer{
+		client:   newAPIClient(opts.apiURL, opts.token, opts.projectID),
+		dir:      dir,
+		conflict: conflict,
+		out:      os.Stdout,
+	}
+
+	switch command {
+	case "push":
+		err = s.push(ctx)
+	case "pull":
+		err = s.pull(ctx)
+	case "watch":
+		err = s.watch(ctx, opts.interval)
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatalf("%s failed: %v", command, err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: sync <push|pull|watch> --project <id> --token <token> [--dir .] [--conflict merge] [--api URL] [--interval 5s]")
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
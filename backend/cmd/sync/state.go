@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	docsysModels "meridian/internal/domain/models/docsystem"
+	serviceDocsys "meridian/internal/service/docsystem"
+)
+
+// Sync state lives next to the synced files:
+//
+//	<dir>/.meridian/sync.json   project ID and, per document path, the last synced ID and hash
+//	<dir>/.meridian/base/...    last synced content per document (base revisions for merges)
+const (
+	stateDirName  = ".meridian"
+	stateFileName = "sync.json"
+	baseDirName   = "base"
+	markdownExt   = ".md"
+)
+
+// syncState records what local and remote agreed on at the last sync
+type syncState struct {
+	ProjectID string                 `json:"project_id"`
+	Documents map[string]syncedEntry `json:"documents"` // Document path → entry
+}
+
+type syncedEntry struct {
+	ID          string `json:"id"`
+	ContentHash string `json:"content_hash"`
+}
+
+// localFile is a Markdown file in the synced directory
+type localFile struct {
+	content     string
+	contentHash string
+}
+
+// syncDir is a local directory mirrored with a project
+type syncDir struct {
+	root  string
+	state syncState
+}
+
+// openSyncDir loads the sync state of root, starting fresh when there is none.
+// A directory already linked to a different project is rejected.
+func openSyncDir(root, projectID string) (*syncDir, error) {
+	dir := &syncDir{root: root, state: syncState{ProjectID: projectID, Documents: map[string]syncedEntry{}}}
+
+	data, err := os.ReadFile(dir.statePath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return dir, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read sync state: %w", err)
+	}
+	if err := json.Unmarshal(data, &dir.state); err != nil {
+		return nil, fmt.Errorf("parse sync state %s: %w", dir.statePath(), err)
+	}
+	if dir.state.ProjectID != projectID {
+		return nil, fmt.Errorf("%s is synced with project %s, not %s", root, dir.state.ProjectID, projectID)
+	}
+	if dir.state.Documents == nil {
+		dir.state.Documents = map[string]syncedEntry{}
+	}
+	return dir, nil
+}
+
+func (d *syncDir) statePath() string {
+	return filepath.Join(d.root, stateDirName, stateFileName)
+}
+
+func (d *syncDir) basePath(docPath string) string {
+	return filepath.Join(d.root, stateDirName, baseDirName, filepath.FromSlash(docPath)+markdownExt)
+}
+
+func (d *syncDir) filePath(docPath string) string {
+	return filepath.Join(d.root, filepath.FromSlash(docPath)+markdownExt)
+}
+
+// save writes the sync state
+func (d *syncDir) save() error {
+	data, err := json.MarshalIndent(d.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(d.statePath(), data)
+}
+
+// base returns the last synced content of a document
+func (d *syncDir) base(docPath string) (string, bool) {
+	data, err := os.ReadFile(d.basePath(docPath))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// recordSynced stores content as the agreed state of a document
+func (d *syncDir) recordSynced(docPath, id, content string) error {
+	if err := writeFileAtomic(d.basePath(docPath), []byte(content)); err != nil {
+		return fmt.Errorf("save base revision of %s: %w", docPath, err)
+	}
+	d.state.Documents[docPath] = syncedEntry{ID: id, ContentHash: docsysModels.ContentHash(content)}
+	return nil
+}
+
+// writeDocument writes a document's content to its local file
+func (d *syncDir) writeDocument(docPath, content string) error {
+	return writeFileAtomic(d.filePath(docPath), []byte(content))
+}
+
+// scan returns every Markdown file under the directory keyed by document path
+// ("chapters/intro" for chapters/intro.md), skipping hidden files and directories
+func (d *syncDir) scan() (map[string]localFile, error) {
+	files := make(map[string]localFile)
+	err := filepath.WalkDir(d.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != d.root && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(path), markdownExt) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(d.root, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		files[documentPath(rel)] = localFile{
+			content:     string(content),
+			contentHash: docsysModels.ContentHash(string(content)),
+		}
+		return nil
+	})
+	return files, err
+}
+
+// documentPath converts a relative file path to the document path the server
+// assigns on import (same name normalization as the import processors)
+func documentPath(rel string) string {
+	rel = filepath.ToSlash(rel)
+	rel = strings.TrimSuffix(rel, filepath.Ext(rel))
+
+	folderPath, name := "", rel
+	if i := strings.LastIndex(rel, "/"); i >= 0 {
+		folderPath, name = serviceDocsys.NormalizeFolderPath(rel[:i]), rel[i+1:]
+	}
+	return serviceDocsys.BuildFullPath(folderPath, serviceDocsys.SanitizeDocName(name))
+}
+
+// writeFileAtomic writes data via a temporary file so editors never see partial content
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".sync-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
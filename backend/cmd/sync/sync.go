@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	serviceDocsys "meridian/internal/service/docsystem"
+)
+
+// syncer runs push and pull for one directory
+type syncer struct {
+	client   *apiClient
+	dir      *syncDir
+	conflict docsysSvc.ConflictStrategy
+	out      io.Writer
+}
+
+// push uploads local files whose content differs from the project.
+// Files only changed remotely since the last sync are left for pull.
+func (s *syncer) push(ctx context.Context) error {
+	local, err := s.dir.scan()
+	if err != nil {
+		return fmt.Errorf("scan %s: %w", s.dir.root, err)
+	}
+	remote, err := s.remoteByPath(ctx)
+	if err != nil {
+		return err
+	}
+
+	changed := make(map[string]string)
+	bases := make(map[string]string)
+	for path, file := range local {
+		entry, exists := remote[path]
+		if exists && entry.ContentHash == file.contentHash {
+			if s.dir.state.Documents[path].ContentHash != file.contentHash {
+				if err := s.dir.recordSynced(path, entry.ID, file.content); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if synced, ok := s.dir.state.Documents[path]; ok && exists && synced.ContentHash == file.contentHash {
+			continue // Unchanged locally; the remote edit is pulled, not overwritten
+		}
+
+		changed[path] = file.content
+		if base, ok := s.dir.base(path); ok {
+			bases[path] = base
+		}
+	}
+
+	if len(changed) == 0 {
+		fmt.Fprintln(s.out, "push: nothing to upload")
+		return s.dir.save()
+	}
+
+	response, err := s.client.upload(ctx, changed, s.conflict, bases)
+	if err != nil {
+		return err
+	}
+
+	for _, doc := range response.Documents {
+		switch doc.Action {
+		case "created", "updated", "unchanged":
+			if err := s.dir.recordSynced(doc.Path, doc.ID, changed[doc.Path]); err != nil {
+				return err
+			}
+		case "merged":
+			// The project now holds the merge of both sides; bring it back locally
+			if err := s.download(ctx, doc.Path, doc.ID); err != nil {
+				return err
+			}
+		}
+		s.report("push", doc)
+	}
+	for _, importErr := range response.Errors {
+		fmt.Fprintf(s.out, "push: %s failed: %s\n", importErr.File, importErr.Error)
+	}
+
+	return s.dir.save()
+}
+
+// pull downloads documents that changed in the project since the last sync.
+// When the local file changed as well, both sides are three-way merged against
+// the last synced content; overlapping edits are written with conflict markers.
+func (s *syncer) pull(ctx context.Context) error {
+	local, err := s.dir.scan()
+	if err != nil {
+		return fmt.Errorf("scan %s: %w", s.dir.root, err)
+	}
+	remote, err := s.remoteByPath(ctx)
+	if err != nil {
+		return err
+	}
+
+	pulled := 0
+	for path, entry := range remote {
+		file, hasLocal := local[path]
+		synced, wasSynced := s.dir.state.Documents[path]
+
+		if hasLocal && file.contentHash == entry.ContentHash {
+			if synced.ContentHash != entry.ContentHash {
+				if err := s.dir.recordSynced(path, entry.ID, file.content); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if wasSynced && synced.ContentHash == entry.ContentHash {
+			continue // Unchanged remotely; local edits are pushed, not overwritten
+		}
+
+		doc, err := s.client.document(ctx, entry.ID)
+		if err != nil {
+			return err
+		}
+		pulled++
+
+		if !hasLocal || (wasSynced && file.contentHash == synced.ContentHash) {
+			if err := s.dir.writeDocument(path, doc.Content); err != nil {
+				return err
+			}
+			if err := s.dir.recordSynced(path, doc.ID, doc.Content); err != nil {
+				return err
+			}
+			fmt.Fprintf(s.out, "pull: %s updated\n", path)
+			continue
+		}
+
+		// Changed on both sides
+		base, _ := s.dir.base(path)
+		merged, conflicts := serviceDocsys.MergeText(base, file.content, doc.Content)
+		if err := s.dir.writeDocument(path, merged); err != nil {
+			return err
+		}
+		// The remote version is the new base, so pushing the merged file applies cleanly
+		if err := s.dir.recordSynced(path, doc.ID, doc.Content); err != nil {
+			return err
+		}
+		if conflicts > 0 {
+			fmt.Fprintf(s.out, "pull: %s has %d conflict(s); resolve the markers and push\n", path, conflicts)
+		} else {
+			fmt.Fprintf(s.out, "pull: %s merged\n", path)
+		}
+	}
+
+	if pulled == 0 {
+		fmt.Fprintln(s.out, "pull: up to date")
+	}
+	return s.dir.save()
+}
+
+// watch pushes and pulls every interval until ctx is cancelled.
+// Failures are logged and retried on the next round.
+func (s *syncer) watch(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.push(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("push failed: %v", err)
+		}
+		if err := s.pull(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("pull failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// download writes a document's current content locally and records it as synced
+func (s *syncer) download(ctx context.Context, path, id string) error {
+	doc, err := s.client.document(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.dir.writeDocument(path, doc.Content); err != nil {
+		return err
+	}
+	return s.dir.recordSynced(path, doc.ID, doc.Content)
+}
+
+func (s *syncer) remoteByPath(ctx context.Context) (map[string]docsysSvc.ImportManifestEntry, error) {
+	entries, err := s.client.manifest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byPath := make(map[string]docsysSvc.ImportManifestEntry, len(entries))
+	for _, entry := range entries {
+		byPath[entry.Path] = entry
+	}
+	return byPath, nil
+}
+
+func (s *syncer) report(command string, doc docsysSvc.ImportDocument) {
+	line := fmt.Sprintf("%s: %s %s", command, doc.Path, doc.Action)
+	if doc.OriginalName != "" {
+		line += fmt.Sprintf(" (from %q)", doc.OriginalName)
+	}
+	if doc.Detail != "" {
+		line += ": " + doc.Detail
+	}
+	fmt.Fprintln(s.out, line)
+}
@@ -0,0 +1,437 @@
+// Package app builds the backend's object graph from config in one place: the
+// connection pool, repositories, providers and services. The server, the seed command
+// and integration tests construct it with New instead of wiring constructors by hand.
+//
+// Components with a lifecycle register hooks: resources opened during construction (the
+// pool) are closed by Stop; background work (stream cleanup, periodic jobs, startup
+// cleanup of interrupted jobs) only runs between Start and Stop, so one-shot commands
+// can use the services without starting it.
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"meridian/internal/capabilities"
+	"meridian/internal/config"
+	models "meridian/internal/domain/models/docsystem"
+	"meridian/internal/domain/repositories"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/eventstream"
+	"meridian/internal/repository/postgres"
+	postgresDocsys "meridian/internal/repository/postgres/docsystem"
+	postgresLLM "meridian/internal/repository/postgres/llm"
+	"meridian/internal/service"
+	serviceAuth "meridian/internal/service/auth"
+	serviceDocsys "meridian/internal/service/docsystem"
+	"meridian/internal/service/docsystem/converter"
+	"meridian/internal/service/docsystem/export"
+	"meridian/internal/service/docsystem/publish"
+	serviceLLM "meridian/internal/service/llm"
+	"meridian/internal/service/llm/attachment"
+	"meridian/internal/service/llm/datacontrols"
+	"meridian/internal/service/llm/editor"
+	"meridian/internal/service/llm/eval"
+	"meridian/internal/service/llm/pin"
+	"meridian/internal/service/llm/providerkeys"
+	"meridian/internal/service/llm/speech"
+	"meridian/internal/service/llm/streaming"
+	"meridian/internal/service/llm/usage"
+	"meridian/internal/service/llm/workflow"
+	"meridian/internal/storage"
+)
+
+// App is the constructed backend
+type App struct {
+	Config *config.Config
+	Logger *slog.Logger
+
+	Pool   *pgxpool.Pool
+	Tables *postgres.TableNames
+	Repos  *Repositories
+
+	Authorizer     services.ResourceAuthorizer
+	Capabilities   capabilities.Lookup
+	ObjectStore    services.ObjectStore
+	StreamRegistry eventstream.Registry
+	Services       *Services
+
+	lifecycle Lifecycle
+}
+
+// Repositories holds the Postgres repositories
+type Repositories struct {
+	Project           docsysRepo.ProjectRepository
+	ProjectSettings   docsysRepo.ProjectSettingsRepository
+	Document          docsysRepo.DocumentRepository
+	Folder            docsysRepo.FolderRepository
+	Snapshot          docsysRepo.SnapshotRepository
+	ExportJob         docsysRepo.ExportJobRepository
+	Job               docsysRepo.JobRepository
+	Publish           docsysRepo.PublishRepository
+	Suggestion        docsysRepo.SuggestionRepository
+	DocumentAnalytics docsysRepo.DocumentAnalyticsRepository
+	DocumentCollab    docsysRepo.DocumentCollabRepository
+	Trash             docsysRepo.TrashRepository
+
+	Chat        llmRepo.ChatRepository
+	Turn        llmRepo.TurnRepository
+	Workflow    llmRepo.WorkflowRepository
+	Eval        llmRepo.EvalRepository
+	Critique    llmRepo.CritiqueRepository
+	Experiment  llmRepo.ExperimentRepository
+	Pin         llmRepo.PinRepository
+	ProviderKey llmRepo.ProviderKeyRepository
+	Usage       llmRepo.UsageRepository
+	Attachment  llmRepo.AttachmentRepository
+	AbuseFlag   llmRepo.AbuseFlagRepository
+
+	UserPreferences repositories.UserPreferencesRepository
+	TxManager       repositories.TransactionManager
+}
+
+// Services holds the business services the handlers use
+type Services struct {
+	// Document system
+	Project           docsysSvc.ProjectService
+	ProjectSettings   docsysSvc.ProjectSettingsService
+	Document          docsysSvc.DocumentService
+	Folder            docsysSvc.FolderService
+	Tree              docsysSvc.TreeService
+	Skill             docsysSvc.SkillService
+	Snapshot          docsysSvc.SnapshotService
+	DocumentDiff      docsysSvc.DocumentDiffService
+	DocumentAnalytics docsysSvc.DocumentAnalyticsService
+	DocumentPresence  docsysSvc.DocumentPresenceService
+	DocumentCollab    docsysSvc.DocumentCollabService
+	Export            docsysSvc.ExportService
+	Duplicate         docsysSvc.DuplicateService
+	Archive           docsysSvc.ArchiveService
+	StyleProfile      docsysSvc.StyleProfileService
+	Publish           docsysSvc.PublishService
+	Import            docsysSvc.ImportService
+	Job               docsysSvc.JobService
+	Trash             docsysSvc.TrashService
+
+	// LLM
+	Chat         llmSvc.ChatService
+	Conversation llmSvc.ConversationService
+	Streaming    llmSvc.StreamingService
+	Critique     llmSvc.CritiqueService
+	Experiment   llmSvc.ExperimentService
+	Workflow     llmSvc.WorkflowService
+	Eval         llmSvc.EvalService
+	Speech       llmSvc.SpeechService
+	Pin          llmSvc.PinService
+	Editor       llmSvc.EditorService
+	ProviderKeys llmSvc.ProviderKeyService
+	DataControls llmSvc.DataControlsService
+	Usage        llmSvc.UsageService
+	Attachment   llmSvc.AttachmentService
+	Abuse        llmSvc.AbuseService
+
+	UserPreferences services.UserPreferencesService
+}
+
+// New connects to the database and constructs every repository and service. Nothing
+// runs in the background until Start; call Stop to release the app's resources.
+func New(ctx context.Context, cfg *config.Config, logger *slog.Logger) (*App, error) {
+	a := &App{Config: cfg, Logger: logger}
+
+	pool, err := postgres.CreateConnectionPool(ctx, cfg.SupabaseDBURL)
+	if err != nil {
+		return nil, fmt.Errorf("create connection pool: %w", err)
+	}
+	a.Pool = pool
+	a.lifecycle.Append(Hook{Name: "database pool", Stop: func(context.Context) error {
+		pool.Close()
+		return nil
+	}})
+	logger.Info("database connected", "max_conns", pool.Config().MaxConns, "min_conns", pool.Config().MinConns)
+
+	if err := a.build(); err != nil {
+		_ = a.Stop(ctx)
+		return nil, err
+	}
+	return a, nil
+}
+
+// Start runs the startup hooks and background workers
+func (a *App) Start(ctx context.Context) error {
+	return a.lifecycle.Start(ctx)
+}
+
+// Stop stops the workers and releases resources, in reverse order of registration
+func (a *App) Stop(ctx context.Context) error {
+	return a.lifecycle.Stop(ctx)
+}
+
+// Append registers a hook for a component created outside the app (e.g. the server's
+// JWT verifier) so it starts and stops with it
+func (a *App) Append(hook Hook) {
+	a.lifecycle.Append(hook)
+}
+
+// build constructs the repositories and services on the open pool
+func (a *App) build() error {
+	cfg, logger := a.Config, a.Logger
+
+	a.Tables = postgres.NewTableNames(cfg.TablePrefix)
+	a.Repos = newRepositories(&postgres.RepositoryConfig{
+		Pool:   a.Pool,
+		Tables: a.Tables,
+		Logger: logger,
+	})
+	repos := a.Repos
+
+	// Ownership-based authorizer; checks ownership chains (turn → chat → project → user)
+	a.Authorizer = serviceAuth.NewOwnerBasedAuthorizer(repos.Project, repos.Folder, repos.Document, repos.Chat, repos.Turn, time.Duration(cfg.AuthCacheTTLSeconds)*time.Second)
+
+	providerRegistry, err := serviceLLM.SetupProviders(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("setup LLM providers: %w", err)
+	}
+
+	capabilityRegistry, err := capabilities.NewRegistry()
+	if err != nil {
+		return fmt.Errorf("initialize capability registry: %w", err)
+	}
+	a.Capabilities = capabilityRegistry
+	logger.Info("capability registry initialized")
+
+	// Object storage for binary assets (export files, generated images)
+	a.ObjectStore, err = storage.New(storage.Config{
+		Backend:       cfg.StorageBackend,
+		LocalDir:      cfg.StorageLocalDir,
+		PublicURL:     cfg.StoragePublicURL,
+		SigningSecret: cfg.StorageSigningSecret,
+		S3: storage.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("invalid object storage configuration: %w", err)
+	}
+	storageURLTTL := time.Duration(cfg.StorageURLTTLSeconds) * time.Second
+
+	svcs := &Services{}
+	a.Services = svcs
+
+	// Preferences hold the users' data controls, which every LLM service enforces
+	svcs.UserPreferences = service.NewUserPreferencesService(repos.UserPreferences, logger)
+	svcs.DataControls = datacontrols.NewService(svcs.UserPreferences)
+
+	// Users' own provider API keys (encrypted with PROVIDER_KEY_SECRET)
+	svcs.ProviderKeys, err = providerkeys.NewService(repos.ProviderKey, cfg.ProviderKeySecret, logger)
+	if err != nil {
+		return fmt.Errorf("setup provider keys: %w", err)
+	}
+	if cfg.ProviderKeySecret == "" {
+		logger.Warn("PROVIDER_KEY_SECRET not set - users cannot store their own provider keys")
+	}
+
+	// Create tool limit resolver (tier-ready architecture)
+	// Uses MAX_TOOL_ROUNDS from env (default: 10) - generous while no subscription tiers
+	// When Stripe subscriptions go live, swap in JWTTierResolver (one line change)
+	toolLimitResolver := llmSvc.NewConfigToolLimitResolver(cfg.MaxToolRounds)
+
+	// Models "auto" may route to (tier-ready, same swap as the tool limit resolver)
+	modelAllowlist := llmSvc.NewConfigModelAllowlistResolver(cfg.ModelAllowlist)
+
+	// LLM services (chat, conversation, streaming, critique, experiments)
+	llmServices, streamRegistry, err := serviceLLM.SetupServices(
+		repos.Chat,
+		repos.Turn,
+		repos.Critique,
+		repos.Experiment,
+		repos.Attachment,
+		repos.AbuseFlag,
+		repos.Project,
+		repos.ProjectSettings,
+		repos.Document,
+		repos.Folder,
+		providerRegistry,
+		cfg,
+		repos.TxManager,
+		capabilityRegistry,
+		a.Authorizer,
+		toolLimitResolver,
+		modelAllowlist,
+		a.ObjectStore,
+		svcs.ProviderKeys,
+		svcs.DataControls,
+		logger,
+	)
+	if err != nil {
+		return fmt.Errorf("setup LLM services: %w", err)
+	}
+	a.StreamRegistry = streamRegistry
+	svcs.Chat = llmServices.Chat
+	svcs.Conversation = llmServices.Conversation
+	svcs.Streaming = llmServices.Streaming
+	svcs.Critique = llmServices.Critique
+	svcs.Experiment = llmServices.Experiment
+	svcs.Abuse = llmServices.Abuse
+
+	svcs.Workflow = workflow.NewService(repos.Workflow, repos.Chat, repos.TxManager, a.Authorizer, logger)
+
+	// Text-to-speech (optional; nil synthesizer makes the endpoint report it's not configured)
+	var speechSynthesizer llmSvc.SpeechSynthesizer
+	if cfg.TTSProvider != "" {
+		speechSynthesizer, err = speech.NewSynthesizer(cfg.TTSProvider, cfg.TTSAPIKey, cfg.TTSModel, cfg.TTSVoice)
+		if err != nil {
+			return fmt.Errorf("invalid TTS_PROVIDER: %w", err)
+		}
+	}
+	svcs.Speech = speech.NewService(speechSynthesizer, repos.Turn, a.ObjectStore, a.Authorizer, storageURLTTL, cfg.TTSMaxCharacters, logger)
+
+	// Images users attach to chat messages
+	svcs.Attachment = attachment.NewService(repos.Attachment, a.ObjectStore, repos.TxManager, a.Authorizer, storageURLTTL, int64(cfg.AttachmentMaxMB)<<20, int64(cfg.AttachmentProjectQuotaMB)<<20, logger)
+
+	// Spend reporting from the costs the streaming executor stores on turns, bucketed in
+	// each user's time zone
+	svcs.Usage = usage.NewService(repos.Usage, a.Authorizer, svcs.UserPreferences, logger)
+
+	// Document services
+	docsysValidator := serviceDocsys.NewResourceValidator(repos.Project, repos.Folder)
+	contentAnalyzer := serviceDocsys.NewContentAnalyzer()
+	namePolicy := serviceDocsys.NewNamePolicy(cfg.NameUniqueness)
+	pathResolver := serviceDocsys.NewPathResolver(repos.Folder, repos.TxManager, namePolicy)
+	svcs.Project = serviceDocsys.NewProjectService(repos.Project, a.Authorizer, logger)
+	svcs.ProjectSettings = serviceDocsys.NewProjectSettingsService(repos.ProjectSettings, logger)
+	svcs.Document = serviceDocsys.NewDocumentService(repos.Document, repos.Folder, repos.ProjectSettings, repos.TxManager, contentAnalyzer, pathResolver, docsysValidator, a.Authorizer, namePolicy, logger)
+	svcs.Folder = serviceDocsys.NewFolderService(repos.Folder, repos.Document, pathResolver, repos.TxManager, docsysValidator, a.Authorizer, namePolicy, logger)
+	svcs.Tree = serviceDocsys.NewTreeService(repos.Folder, a.Authorizer, logger)
+	svcs.Skill = serviceDocsys.NewSkillService(repos.Folder, repos.Document, a.Authorizer, logger)
+	svcs.Snapshot = serviceDocsys.NewSnapshotService(repos.Snapshot, repos.Document, repos.Folder, repos.TxManager, contentAnalyzer, a.Authorizer, logger)
+	svcs.DocumentDiff = serviceDocsys.NewDocumentDiffService(repos.Document, repos.Snapshot, repos.Suggestion, a.Authorizer, logger)
+	svcs.DocumentAnalytics = serviceDocsys.NewDocumentAnalyticsService(repos.Document, repos.DocumentAnalytics, contentAnalyzer, a.Authorizer, logger)
+	svcs.DocumentPresence = serviceDocsys.NewDocumentPresenceService(a.Authorizer, time.Duration(cfg.PresenceTTLSeconds)*time.Second, logger)
+	svcs.DocumentCollab = serviceDocsys.NewDocumentCollabService(repos.DocumentCollab, repos.Document, svcs.Document, repos.TxManager, a.Authorizer, time.Duration(cfg.CollabSaveIntervalSeconds)*time.Second, logger)
+	pdfRenderer, err := export.NewCommandRenderer(cfg.ExportPDFCommand)
+	if err != nil {
+		return fmt.Errorf("invalid EXPORT_PDF_COMMAND: %w", err)
+	}
+	svcs.Export = serviceDocsys.NewExportService(repos.ExportJob, repos.Project, repos.Folder, repos.Document, pdfRenderer, a.ObjectStore, storageURLTTL, a.Authorizer, logger)
+	svcs.Duplicate = serviceDocsys.NewDuplicateService(repos.Document, contentAnalyzer, a.Authorizer, logger)
+	svcs.Archive = serviceDocsys.NewArchiveService(repos.Project, repos.Folder, repos.Document, repos.Chat, repos.Turn, a.Authorizer, logger)
+	svcs.StyleProfile = serviceDocsys.NewStyleProfileService(repos.ProjectSettings, repos.Document, contentAnalyzer, logger)
+	svcs.Publish = serviceDocsys.NewPublishService(repos.Publish, repos.Folder, repos.Document, publish.NewRegistry(publish.NewEgress(cfg.PublishAllowedHosts)), a.Authorizer, logger)
+	svcs.Trash = serviceDocsys.NewTrashService(repos.Trash, repos.Folder, repos.Document, repos.TxManager, a.Authorizer, namePolicy, days(cfg.TrashRetentionDays), days(cfg.ChatRetentionDays), logger)
+
+	// Pinning turns creates documents, so it needs the document service
+	svcs.Pin = pin.NewService(repos.Pin, repos.Turn, repos.Chat, repos.ProjectSettings, svcs.Document, repos.TxManager, a.Authorizer, logger)
+	svcs.Editor = editor.NewService(svcs.Document, repos.ProjectSettings, repos.Suggestion, repos.TxManager, providerRegistry, a.Authorizer, cfg.EditorModel, cfg.DefaultModel, svcs.DataControls, logger)
+
+	// Import service with zip and individual file processors
+	converterRegistry := converter.NewConverterRegistry()
+	fileProcessorRegistry := serviceDocsys.NewFileProcessorRegistry()
+	fileProcessorRegistry.Register(serviceDocsys.NewZipFileProcessor(repos.Document, svcs.Document, repos.TxManager, converterRegistry, logger))
+	fileProcessorRegistry.Register(serviceDocsys.NewIndividualFileProcessor(repos.Document, svcs.Document, repos.TxManager, converterRegistry, logger))
+	// Imports run on the background job queue
+	svcs.Job = serviceDocsys.NewJobService(repos.Job, a.Authorizer, cfg.JobWorkers, logger)
+	svcs.Import = serviceDocsys.NewImportService(repos.Document, fileProcessorRegistry, repos.TxManager, svcs.Job, a.ObjectStore, logger)
+	svcs.Job.Register(models.JobKindImport, svcs.Import.RunJob)
+
+	// Eval runs generate on the job queue too, with the system prompt a new chat would get
+	evalJudgeModel := cfg.CritiqueModel
+	if evalJudgeModel == "" {
+		evalJudgeModel = cfg.DefaultModel
+	}
+	evalPrompts := streaming.NewSystemPromptResolver(repos.ProjectSettings, repos.Chat, repos.Document, logger)
+	svcs.Eval = eval.NewService(repos.Eval, svcs.Job, evalPrompts, providerRegistry, a.Authorizer, evalJudgeModel, svcs.DataControls, logger)
+	svcs.Job.Register(models.JobKindEval, svcs.Eval.RunJob)
+
+	a.registerHooks()
+	return nil
+}
+
+// registerHooks registers the startup cleanup and background workers run by Start
+func (a *App) registerHooks() {
+	cfg, logger, svcs := a.Config, a.Logger, a.Services
+
+	// Jobs a previous process left running can't finish; mark them failed
+	a.lifecycle.Append(Hook{Name: "interrupted jobs", Start: func(ctx context.Context) error {
+		if err := svcs.Export.FailInterrupted(ctx); err != nil {
+			logger.Warn("failed to clean up interrupted exports", "error", err)
+		}
+		if err := svcs.StyleProfile.FailInterrupted(ctx); err != nil {
+			logger.Warn("failed to clean up interrupted style analyses", "error", err)
+		}
+		if err := svcs.Job.FailInterrupted(ctx); err != nil {
+			logger.Warn("failed to clean up interrupted background jobs", "error", err)
+		}
+		return nil
+	}})
+
+	a.lifecycle.Append(Worker("job queue", svcs.Job.Run))
+
+	a.lifecycle.Append(Worker("stream registry cleanup", a.StreamRegistry.StartCleanup))
+
+	// Also closes the collaboration sockets on shutdown, after a last save
+	a.lifecycle.Append(Worker("document collaboration", svcs.DocumentCollab.Run))
+
+	if cfg.ChatRetentionDays > 0 {
+		a.lifecycle.Append(Worker("deleted chat purge", purgeDeletedChatsPeriodically(svcs.Chat, days(cfg.ChatRetentionDays), logger)))
+	}
+	if cfg.TrashRetentionDays > 0 {
+		a.lifecycle.Append(Worker("trash purge", purgeTrashPeriodically(svcs.Trash, days(cfg.TrashRetentionDays), logger)))
+	}
+	if cfg.ContentCompressionMinBytes > 0 {
+		a.lifecycle.Append(Worker("snapshot compression", compressSnapshotContentPeriodically(svcs.Snapshot, cfg.ContentCompressionMinBytes, logger)))
+	}
+	if cfg.PublishPollIntervalSeconds > 0 {
+		interval := time.Duration(cfg.PublishPollIntervalSeconds) * time.Second
+		a.lifecycle.Append(Worker("publish on change", publishChangedPeriodically(svcs.Publish, interval, logger)))
+	}
+}
+
+// newRepositories creates the Postgres repositories
+func newRepositories(repoConfig *postgres.RepositoryConfig) *Repositories {
+	return &Repositories{
+		Project:           postgresDocsys.NewProjectRepository(repoConfig),
+		ProjectSettings:   postgresDocsys.NewProjectSettingsRepository(repoConfig),
+		Document:          postgresDocsys.NewDocumentRepository(repoConfig),
+		Folder:            postgresDocsys.NewFolderRepository(repoConfig),
+		Snapshot:          postgresDocsys.NewSnapshotRepository(repoConfig),
+		ExportJob:         postgresDocsys.NewExportJobRepository(repoConfig),
+		Job:               postgresDocsys.NewJobRepository(repoConfig),
+		Publish:           postgresDocsys.NewPublishRepository(repoConfig),
+		Suggestion:        postgresDocsys.NewSuggestionRepository(repoConfig),
+		DocumentAnalytics: postgresDocsys.NewDocumentAnalyticsRepository(repoConfig),
+		DocumentCollab:    postgresDocsys.NewDocumentCollabRepository(repoConfig),
+		Trash:             postgresDocsys.NewTrashRepository(repoConfig),
+
+		Chat:        postgresLLM.NewChatRepository(repoConfig),
+		Turn:        postgresLLM.NewTurnRepository(repoConfig),
+		Workflow:    postgresLLM.NewWorkflowRepository(repoConfig),
+		Eval:        postgresLLM.NewEvalRepository(repoConfig),
+		Critique:    postgresLLM.NewCritiqueRepository(repoConfig),
+		Experiment:  postgresLLM.NewExperimentRepository(repoConfig),
+		Pin:         postgresLLM.NewPinRepository(repoConfig),
+		ProviderKey: postgresLLM.NewProviderKeyRepository(repoConfig),
+		Usage:       postgresLLM.NewUsageRepository(repoConfig),
+		Attachment:  postgresLLM.NewAttachmentRepository(repoConfig),
+		AbuseFlag:   postgresLLM.NewAbuseFlagRepository(repoConfig),
+
+		UserPreferences: postgres.NewUserPreferencesRepository(repoConfig),
+		TxManager:       postgres.NewTransactionManager(repoConfig.Pool),
+	}
+}
+
+// days converts a day-count setting to a duration
+func days(n int) time.Duration {
+	return time.Duration(n) * 24 * time.Hour
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Hook is a component's lifecycle. Start runs when the app starts; Stop runs when it
+// stops, for hooks that started. A hook without Start is started when appended (a
+// resource opened during construction, like the connection pool, that only needs
+// closing).
+type Hook struct {
+	Name  string
+	Start func(ctx context.Context) error // Optional
+	Stop  func(ctx context.Context) error // Optional
+}
+
+// Lifecycle runs hooks: Start in the order they were appended, Stop in reverse
+type Lifecycle struct {
+	mu      sync.Mutex
+	hooks   []Hook
+	started []bool
+}
+
+// Append adds a hook
+func (l *Lifecycle) Append(hook Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, hook)
+	l.started = append(l.started, hook.Start == nil)
+}
+
+// Start runs the Start of every hook not yet started. If one fails, the hooks started
+// so far are stopped and the error is returned.
+func (l *Lifecycle) Start(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, hook := range l.hooks {
+		if l.started[i] || hook.Start == nil {
+			continue
+		}
+		if err := hook.Start(ctx); err != nil {
+			startErr := fmt.Errorf("start %s: %w", hook.Name, err)
+			return errors.Join(startErr, l.stopLocked(ctx))
+		}
+		l.started[i] = true
+	}
+	return nil
+}
+
+// Stop runs the Stop of every started hook in reverse order. All hooks are stopped even
+// if some fail; their errors are joined.
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stopLocked(ctx)
+}
+
+func (l *Lifecycle) stopLocked(ctx context.Context) error {
+	var errs []error
+	for i := len(l.hooks) - 1; i >= 0; i-- {
+		if !l.started[i] {
+			continue
+		}
+		l.started[i] = false
+		if stop := l.hooks[i].Stop; stop != nil {
+			if err := stop(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("stop %s: %w", l.hooks[i].Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Worker returns a hook that runs fn in a goroutine from Start until Stop, which cancels
+// fn's context and waits for it to return (or for Stop's context to end)
+func Worker(name string, fn func(ctx context.Context)) Hook {
+	var (
+		cancel context.CancelFunc
+		done   chan struct{}
+	)
+	return Hook{
+		Name: name,
+		Start: func(context.Context) error {
+			var ctx context.Context
+			ctx, cancel = context.WithCancel(context.Background())
+			done = make(chan struct{})
+			go func() {
+				defer close(done)
+				fn(ctx)
+			}()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return fmt.Errorf("worker still running: %w", ctx.Err())
+			}
+		},
+	}
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// recorder builds hooks that record their calls
+type recorder struct {
+	calls []string
+}
+
+func (r *recorder) hook(name string, startErr error) Hook {
+	return Hook{
+		Name: name,
+		Start: func(context.Context) error {
+			r.calls = append(r.calls, "start "+name)
+			return startErr
+		},
+		Stop: func(context.Context) error {
+			r.calls = append(r.calls, "stop "+name)
+			return nil
+		},
+	}
+}
+
+func (r *recorder) resource(name string) Hook {
+	return Hook{Name: name, Stop: func(context.Context) error {
+		r.calls = append(r.calls, "stop "+name)
+		return nil
+	}}
+}
+
+func TestLifecycle_StartAndStopOrder(t *testing.T) {
+	var rec recorder
+	var l Lifecycle
+	l.Append(rec.resource("pool"))
+	l.Append(rec.hook("cleanup", nil))
+	l.Append(rec.hook("worker", nil))
+
+	if err := l.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if err := l.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	want := []string{"start cleanup", "start worker", "stop worker", "stop cleanup", "stop pool"}
+	if !reflect.DeepEqual(rec.calls, want) {
+		t.Errorf("calls = %v, want %v", rec.calls, want)
+	}
+
+	// Stopping again is a no-op
+	rec.calls = nil
+	if err := l.Stop(context.Background()); err != nil || len(rec.calls) != 0 {
+		t.Errorf("second Stop: err = %v, calls = %v", err, rec.calls)
+	}
+}
+
+func TestLifecycle_StopWithoutStart(t *testing.T) {
+	var rec recorder
+	var l Lifecycle
+	l.Append(rec.resource("pool"))
+	l.Append(rec.hook("worker", nil))
+
+	if err := l.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if want := []string{"stop pool"}; !reflect.DeepEqual(rec.calls, want) {
+		t.Errorf("calls = %v, want %v (hooks that never started aren't stopped)", rec.calls, want)
+	}
+}
+
+func TestLifecycle_FailedStartStopsStartedHooks(t *testing.T) {
+	var rec recorder
+	var l Lifecycle
+	errBoom := errors.New("boom")
+	l.Append(rec.resource("pool"))
+	l.Append(rec.hook("first", nil))
+	l.Append(rec.hook("second", errBoom))
+	l.Append(rec.hook("third", nil))
+
+	err := l.Start(context.Background())
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("Start err = %v, want boom", err)
+	}
+	want := []string{"start first", "start second", "stop first", "stop pool"}
+	if !reflect.DeepEqual(rec.calls, want) {
+		t.Errorf("calls = %v, want %v", rec.calls, want)
+	}
+}
+
+func TestLifecycle_StopJoinsErrors(t *testing.T) {
+	var l Lifecycle
+	errFirst, errSecond := errors.New("first"), errors.New("second")
+	l.Append(Hook{Name: "a", Stop: func(context.Context) error { return errFirst }})
+	l.Append(Hook{Name: "b", Stop: func(context.Context) error { return errSecond }})
+
+	err := l.Stop(context.Background())
+	if !errors.Is(err, errFirst) || !errors.Is(err, errSecond) {
+		t.Errorf("Stop err = %v, want both errors", err)
+	}
+}
+
+func TestWorker(t *testing.T) {
+	running := make(chan struct{})
+	hook := Worker("worker", func(ctx context.Context) {
+		close(running)
+		<-ctx.Done()
+	})
+
+	if err := hook.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	<-running
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := hook.Stop(ctx); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+}
+
+func TestWorker_StopTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	hook := Worker("stuck", func(ctx context.Context) {
+		<-release // Ignores cancellation
+	})
+	if err := hook.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := hook.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Stop err = %v, want deadline exceeded", err)
+	}
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+// every calls fn every interval until ctx is done, first right away if immediately is
+// set. Runs one call at a time; a slow call delays the next tick.
+func every(ctx context.Context, interval time.Duration, immediately bool, fn func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	if immediately {
+		fn(ctx)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn(ctx)
+		}
+	}
+}
+
+// publishChangedPeriodically publishes on-change targets whose documents changed
+func publishChangedPeriodically(publishService docsysSvc.PublishService, interval time.Duration, logger *slog.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		every(ctx, interval, false, func(ctx context.Context) {
+			if err := publishService.PublishChanged(ctx); err != nil && ctx.Err() == nil {
+				logger.Error("failed to publish changed documents", "error", err)
+			}
+		})
+	}
+}
+
+// purgeTrashPeriodically hard-deletes documents and folders past their retention once an
+// hour, starting immediately like the chat purge
+func purgeTrashPeriodically(trashService docsysSvc.TrashService, retention time.Duration, logger *slog.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		every(ctx, time.Hour, true, func(ctx context.Context) {
+			if _, err := trashService.PurgeDeleted(ctx, retention); err != nil && ctx.Err() == nil {
+				logger.Error("failed to purge trash", "error", err)
+			}
+		})
+	}
+}
+
+// compressSnapshotContentPeriodically gzips large snapshot content once an hour,
+// starting immediately so content captured before a restart isn't left waiting
+func compressSnapshotContentPeriodically(snapshotService docsysSvc.SnapshotService, minBytes int, logger *slog.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		every(ctx, time.Hour, true, func(ctx context.Context) {
+			if _, err := snapshotService.CompressContent(ctx, minBytes); err != nil && ctx.Err() == nil {
+				logger.Error("failed to compress snapshot content", "error", err)
+			}
+		})
+	}
+}
+
+// purgeDeletedChatsPeriodically hard-deletes chats past their retention once an hour,
+// starting immediately so a restart doesn't postpone cleanup
+func purgeDeletedChatsPeriodically(chatService llmSvc.ChatService, retention time.Duration, logger *slog.Logger) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		every(ctx, time.Hour, true, func(ctx context.Context) {
+			if _, err := chatService.PurgeDeletedChats(ctx, retention); err != nil && ctx.Err() == nil {
+				logger.Error("failed to purge deleted chats", "error", err)
+			}
+		})
+	}
+}
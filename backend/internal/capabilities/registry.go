@@ -11,6 +11,16 @@ import (
 //go:embed config/*.yaml
 var configFiles embed.FS
 
+// Lookup is the read-only view of model capabilities that services depend on
+// Registry implements it; tests can substitute fixed capabilities (see testmocks)
+type Lookup interface {
+	// GetModelCapabilities returns capabilities for a specific model
+	GetModelCapabilities(provider, model string) (*ModelCapabilities, error)
+
+	// ListProviderModels returns all models for a provider
+	ListProviderModels(provider string) ([]ModelCapabilities, error)
+}
+
 // Registry manages model capabilities across all providers
 type Registry struct {
 	providers map[string]*ProviderCapabilities
@@ -32,6 +42,18 @@ func NewRegistry() (*Registry, error) {
 		return nil, fmt.Errorf("failed to load openrouter capabilities: %w", err)
 	}
 
+	if err := r.loadProviderFile("openai"); err != nil {
+		return nil, fmt.Errorf("failed to load openai capabilities: %w", err)
+	}
+
+	if err := r.loadProviderFile("google"); err != nil {
+		return nil, fmt.Errorf("failed to load google capabilities: %w", err)
+	}
+
+	if err := r.loadProviderFile("ollama"); err != nil {
+		return nil, fmt.Errorf("failed to load ollama capabilities: %w", err)
+	}
+
 	return r, nil
 }
 
@@ -55,7 +77,8 @@ func (r *Registry) loadProviderFile(provider string) error {
 	return nil
 }
 
-// GetModelCapabilities returns capabilities for a specific model
+// GetModelCapabilities returns capabilities for a specific model. Models a provider
+// doesn't list get the provider's defaults, if it has any.
 func (r *Registry) GetModelCapabilities(provider, model string) (*ModelCapabilities, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -71,6 +94,13 @@ func (r *Registry) GetModelCapabilities(provider, model string) (*ModelCapabilit
 		}
 	}
 
+	if providerCaps.Defaults != nil {
+		modelCaps := *providerCaps.Defaults
+		modelCaps.ID = model
+		modelCaps.DisplayName = model
+		return &modelCaps, nil
+	}
+
 	return nil, fmt.Errorf("unknown model %s for provider %s", model, provider)
 }
 
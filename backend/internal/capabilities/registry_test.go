@@ -0,0 +1,34 @@
+package capabilities
+
+import "testing"
+
+func TestRegistry_ProviderDefaults(t *testing.T) {
+	r, err := NewRegistry()
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+
+	listed, err := r.GetModelCapabilities("ollama", "qwen3:8b")
+	if err != nil {
+		t.Fatalf("listed model: %v", err)
+	}
+	if listed.ID != "qwen3:8b" || !listed.SupportsThinking || listed.SupportsTools {
+		t.Errorf("listed model = %+v", listed)
+	}
+
+	unlisted, err := r.GetModelCapabilities("ollama", "my-finetune:latest")
+	if err != nil {
+		t.Fatalf("unlisted model should get the defaults: %v", err)
+	}
+	if unlisted.ID != "my-finetune:latest" || unlisted.DisplayName != "my-finetune:latest" || unlisted.SupportsTools {
+		t.Errorf("unlisted model = %+v", unlisted)
+	}
+	if cost, ok := unlisted.Cost(1000, 1000); !ok || cost != 0 {
+		t.Errorf("unlisted model cost = %v, %v; want free", cost, ok)
+	}
+
+	// Providers without defaults still reject unknown models
+	if _, err := r.GetModelCapabilities("anthropic", "my-finetune:latest"); err == nil {
+		t.Error("expected an error for an unknown anthropic model")
+	}
+}
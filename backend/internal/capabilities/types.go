@@ -57,10 +57,52 @@ type ModelCapabilities struct {
 	PricingTiers []PricingTier `yaml:"pricing_tiers" json:"pricing_tiers"`
 }
 
+// TextModality is the pricing modality for text tokens
+const TextModality = "text"
+
+// PricingTierFor returns the tier that applies to a request with inputTokens of context:
+// the tier with the highest threshold the input exceeds, else the base (null threshold)
+// tier. Returns nil if the model has no pricing.
+func (m *ModelCapabilities) PricingTierFor(inputTokens int) *PricingTier {
+	var applicable *PricingTier
+	for i := range m.PricingTiers {
+		tier := &m.PricingTiers[i]
+		if tier.Threshold == nil {
+			if applicable == nil {
+				applicable = tier
+			}
+			continue
+		}
+		if inputTokens > *tier.Threshold && (applicable == nil || applicable.Threshold == nil || *tier.Threshold > *applicable.Threshold) {
+			applicable = tier
+		}
+	}
+	return applicable
+}
+
+// Cost returns the USD cost of one request at the model's text prices.
+// ok is false if the model has no text pricing for the applicable tier.
+func (m *ModelCapabilities) Cost(inputTokens, outputTokens int) (cost float64, ok bool) {
+	tier := m.PricingTierFor(inputTokens)
+	if tier == nil {
+		return 0, false
+	}
+	inputPrice, hasInput := tier.InputPrice[TextModality]
+	outputPrice, hasOutput := tier.OutputPrice[TextModality]
+	if !hasInput && !hasOutput {
+		return 0, false
+	}
+	return (float64(inputTokens)*inputPrice + float64(outputTokens)*outputPrice) / 1_000_000, true
+}
+
 // ProviderCapabilities represents all models for a provider
 type ProviderCapabilities struct {
 	Provider string              `yaml:"provider" json:"provider"`
 	Models   []ModelCapabilities `yaml:"-" json:"models"` // Ordered slice, populated by custom unmarshaler
+
+	// Defaults apply to models not listed in Models, for providers serving any model by
+	// name (e.g. Ollama). Nil = unlisted models are unknown.
+	Defaults *ModelCapabilities `yaml:"defaults" json:"defaults,omitempty"`
 }
 
 // UnmarshalYAML implements custom YAML unmarshaling to preserve model order from YAML file
@@ -75,12 +117,14 @@ func (p *ProviderCapabilities) UnmarshalYAML(node *yaml.Node) error {
 
 	// Decode models into a map first to get the full data
 	type modelsOnly struct {
-		Models map[string]ModelCapabilities `yaml:"models"`
+		Models   map[string]ModelCapabilities `yaml:"models"`
+		Defaults *ModelCapabilities           `yaml:"defaults"`
 	}
 	var m modelsOnly
 	if err := node.Decode(&m); err != nil {
 		return err
 	}
+	p.Defaults = m.Defaults
 
 	// Now extract model keys in YAML order and build the slice
 	for i := 0; i < len(node.Content); i += 2 {
@@ -0,0 +1,47 @@
+package capabilities
+
+import (
+	"math"
+	"testing"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestModelCapabilities_Cost(t *testing.T) {
+	model := ModelCapabilities{
+		PricingTiers: []PricingTier{
+			{Threshold: intPtr(128000), InputPrice: map[string]float64{"text": 4}, OutputPrice: map[string]float64{"text": 10}},
+			{Threshold: nil, InputPrice: map[string]float64{"text": 2}, OutputPrice: map[string]float64{"text": 5}},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		inputTokens  int
+		outputTokens int
+		want         float64
+	}{
+		{"base tier", 1000, 500, (1000*2 + 500*5) / 1e6},
+		{"at threshold uses base tier", 128000, 0, 128000 * 2 / 1e6},
+		{"above threshold", 200000, 1000, (200000*4 + 1000*10) / 1e6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := model.Cost(tt.inputTokens, tt.outputTokens)
+			if !ok {
+				t.Fatal("Cost reported no pricing")
+			}
+			if math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("Cost = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, ok := (&ModelCapabilities{}).Cost(1000, 1000); ok {
+		t.Error("Cost of a model without pricing reported ok")
+	}
+	imageOnly := ModelCapabilities{PricingTiers: []PricingTier{{OutputPrice: map[string]float64{"image": 20}}}}
+	if _, ok := imageOnly.Cost(1000, 1000); ok {
+		t.Error("Cost without text prices reported ok")
+	}
+}
@@ -12,23 +12,148 @@ type Config struct {
 	SupabaseKey     string
 	SupabaseDBURL   string
 	SupabaseJWKSURL string // Constructed from SupabaseURL + /auth/v1/.well-known/jwks.json
-	CORSOrigins     string
+	CORSOrigins     string // Origins, wildcard patterns and @presets (see middleware/cors.go)
+	CORSOriginsFile string // Optional file re-read on SIGHUP to change origins without restart
 	TablePrefix     string
 	// LLM Configuration
 	AnthropicAPIKey  string
 	OpenRouterAPIKey string
-	DefaultProvider  string
-	DefaultModel     string
-	MaxToolRounds    int    // Fallback limit if resolver fails (default: 10)
+	OpenAIAPIKey     string
+	OpenAIBaseURL    string // Empty = https://api.openai.com/v1 (or an OpenAI-compatible server)
+	OpenAIAPI        string // "responses" (default) or "chat_completions"
+	GeminiAPIKey     string
+	GeminiBaseURL    string // Empty = https://generativelanguage.googleapis.com/v1beta
+	OllamaBaseURL    string // Ollama server (e.g. http://localhost:11434); empty disables Ollama
+	// Secret that encrypts users' own provider API keys at rest; empty disables user keys
+	ProviderKeySecret string
+	DefaultProvider   string
+	DefaultModel      string
+	MaxToolRounds     int // Fallback limit if resolver fails (default: 10)
+	// Model auto-routing (request_params.model = "auto")
+	AutoModelCheap           string // Model for short, simple prompts (default: google/gemini-2.5-flash)
+	AutoModelStrong          string // Model for long or complex prompts, empty = DEFAULT_MODEL
+	AutoModelLongPromptChars int    // Prompt length that counts as long, 0 disables the signal (default: 2000)
+	ModelAllowlist           string // Comma-separated models auto-routing may pick, empty = any
+	// System prompt budget (share of the model's context window, in percent)
+	SystemPromptWarnPercent int // Warn in create-turn response above this share (default: 25)
+	SystemPromptMaxPercent  int // Reject the turn above this share, 0 disables (default: 50)
+	// Prompt-injection guard for tool results: "off", "standard" or "strict" (default: standard)
+	ToolResultSanitization string
+	// Per-result cap for tool results in tokens, 0 disables (default: 8000).
+	// Results are also limited to a share of the model's remaining context.
+	ToolResultMaxTokens int
+	// Retries of transiently failing read-only tools within a round
+	ToolRetryMaxAttempts int // Total attempts per tool call, 1 disables retries (default: 2)
+	ToolRetryBackoffMs   int // Wait before the first retry, doubled for each further retry (default: 250)
+	// Streamed delta size limits; a turn exceeding them fails instead of buffering further
+	StreamMaxBlockBytes int // Per block, 0 disables (default: 4 MiB)
+	StreamMaxTurnBytes  int // Per turn across all rounds, 0 disables (default: 16 MiB)
 	// Search API Configuration (optional - for web_search tool)
 	SearchAPIKey      string // API key for external search provider
 	SearchAPIProvider string // Provider name: "tavily", "brave", "serper", etc.
+	// Image generation (optional - for image_generate tool); empty provider disables
+	ImageProvider string // "openai" or "stability"
+	ImageAPIKey   string
+	ImageModel    string // Provider model, empty = provider default (gpt-image-1, core)
+	// Text-to-speech (optional - for POST /api/turns/{id}/tts); empty provider disables
+	TTSProvider      string // "openai" or "elevenlabs"
+	TTSAPIKey        string
+	TTSModel         string // Provider model, empty = provider default (gpt-4o-mini-tts, eleven_multilingual_v2)
+	TTSVoice         string // Provider voice, empty = provider default
+	TTSMaxCharacters int    // Longest turn text read aloud, 0 = no limit (default: 20000)
+	// Critic pass (enabled per project in settings.critique)
+	CritiqueModel string // Critic model when the project sets none, empty = the turn's own model
+	// Editor generations (inline completions)
+	EditorModel string // Model for editor generations, empty = the project's default model
 	// Debug flags
 	Debug bool // Enables DEBUG features like SSE event IDs
 	// Logging configuration
 	LogToFile   bool   // Enable file logging instead of stdout
 	LogDir      string // Directory for log files
 	LogMaxFiles int    // Max session log files to keep
+	// Maintenance mode: mutating requests get 503, reads and running streams continue
+	MaintenanceMode          bool   // Start in maintenance mode
+	MaintenanceFile          string // Maintenance is on while this file exists (toggle without restart)
+	MaintenanceMessage       string // Optional message returned to clients
+	MaintenanceRetryAfterSec int    // Retry-After hint in seconds (default: 120)
+	// Time limit for non-streaming requests (SSE, WebSocket, downloads and uploads are
+	// exempt); 0 disables it
+	RequestTimeoutSeconds int
+	// Per-user rate limits (token buckets; requests per minute, 0 disables). Routes that
+	// call providers (turn creation, retries, critiques, TTS, editor generations) have
+	// their own limit instead of the general one.
+	RateLimitPerMinute           int // Default: 300
+	RateLimitBurst               int // Default: 60
+	GenerationRateLimitPerMinute int // Default: 10
+	GenerationRateLimitBurst     int // Default: 5
+	// Abuse detection, per user over a sliding window (0 disables a limit): more turns or
+	// tool rounds than allowed throttle the user's turns for AbuseThrottleMinutes (0 only
+	// flags them); larger tool inputs are refused and flagged. Admins review flags in the
+	// admin API and are alerted at the webhook (empty = logs only).
+	AbuseWindowMinutes          int    // Default: 10
+	AbuseMaxTurnsPerWindow      int    // Default: 60
+	AbuseMaxToolRoundsPerWindow int    // Default: 200
+	AbuseMaxToolInputKB         int    // Default: 256
+	AbuseThrottleMinutes        int    // Default: 30
+	AbuseAlertWebhookURL        string // Posted a JSON alert per flag
+	// How long shutdown lets streaming turns finish before cancelling them (they keep the
+	// blocks completed so far); 0 cancels them right away (default: 60)
+	ShutdownDrainTimeoutSeconds int
+	// How long stream clients are advised to wait before reconnecting once shutdown starts
+	// (reconnect_advice event); default: 5
+	StreamReconnectRetryAfterSeconds int
+	// How long finished streams stay in the stream registry; 0 removes them when they
+	// finish (default: 0). Clients connecting later are replayed from the stream index.
+	StreamRetentionSeconds int
+	// How often expired streams are removed from the registry (default: 60)
+	StreamCleanupIntervalSeconds int
+	// Folder/document sibling name uniqueness: "exact" or "case_insensitive" (default: exact)
+	NameUniqueness string
+	// Optional PDF renderer for book exports, e.g. "ebook-convert {input} {output}";
+	// empty disables PDF export (EPUB only)
+	ExportPDFCommand string
+	// Background jobs (imports) run at once by this server's worker pool (default: 2)
+	JobWorkers int
+	// How often on-change publish targets are checked for changed documents; 0 disables
+	PublishPollIntervalSeconds int
+	// Comma-separated hosts publish targets may reach over http or at private addresses;
+	// others must be https to public addresses
+	PublishAllowedHosts string
+	// Days a soft-deleted chat (or a chat of a soft-deleted project) is kept before its turns
+	// and blocks are purged; 0 keeps deleted chats forever
+	ChatRetentionDays int
+	// Days a soft-deleted document or folder stays restorable from the trash before it is
+	// purged; 0 keeps the trash forever
+	TrashRetentionDays int
+	// How long a successful authorization check is cached per user; 0 disables caching
+	AuthCacheTTLSeconds int
+	// How long an editor session stays present on a document after its last heartbeat
+	// (clients heartbeat well within it, e.g. every third of it; default: 60)
+	PresenceTTLSeconds int
+	// How often content reported on the collaboration channel is saved to its documents
+	// (also saved when a document's last client leaves; default: 10)
+	CollabSaveIntervalSeconds int
+	// Bearer token of the operator API (/admin/api/...); empty disables the admin API
+	AdminAPIToken string
+	// Snapshot content blobs at least this large are gzipped in the background; 0 disables
+	ContentCompressionMinBytes int
+	// Object storage for binary assets (export files, images): "local" (default) or "s3"
+	StorageBackend       string
+	StorageLocalDir      string // Local backend root directory
+	StoragePublicURL     string // Base URL of this server in local signed URLs
+	StorageSigningSecret string // HMAC key for local signed URLs; empty = random per process
+	StorageURLTTLSeconds int    // Lifetime of signed download URLs
+	S3Endpoint           string // S3-compatible endpoint (AWS, Supabase Storage, R2, MinIO)
+	S3Region             string
+	S3Bucket             string
+	S3AccessKeyID        string
+	S3SecretAccessKey    string
+	// Chat attachments (POST /api/attachments), kept in object storage
+	AttachmentMaxMB          int // Largest file accepted (default: 10)
+	AttachmentProjectQuotaMB int // Total attachment size per project, 0 = no limit (default: 500)
+	// Error reporting (Sentry-compatible); empty DSN disables reporting
+	SentryDSN     string
+	SentryRelease string // Optional release/version tag attached to events
 }
 
 func Load() *Config {
@@ -46,23 +171,122 @@ func Load() *Config {
 		SupabaseKey:     getEnv("SUPABASE_KEY", ""),
 		SupabaseDBURL:   getEnv("SUPABASE_DB_URL", ""),
 		SupabaseJWKSURL: jwksURL,
-		CORSOrigins:     getEnv("CORS_ORIGINS", "http://localhost:3000"),
+		CORSOrigins:     getEnv("CORS_ORIGINS", getDefaultCORSOrigins(env)),
+		CORSOriginsFile: getEnv("CORS_ORIGINS_FILE", ""),
 		TablePrefix:     tablePrefix,
 		// LLM Configuration
-		AnthropicAPIKey:  getEnv("ANTHROPIC_API_KEY", ""),
-		OpenRouterAPIKey: getEnv("OPENROUTER_API_KEY", ""),
-		DefaultProvider:  getEnv("DEFAULT_PROVIDER", "openrouter"),
-		DefaultModel:     getEnv("DEFAULT_MODEL", "moonshotai/kimi-k2-thinking"),
-		MaxToolRounds:    getEnvInt("MAX_TOOL_ROUNDS", 10),
+		AnthropicAPIKey:   getEnv("ANTHROPIC_API_KEY", ""),
+		OpenRouterAPIKey:  getEnv("OPENROUTER_API_KEY", ""),
+		OpenAIAPIKey:      getEnv("OPENAI_API_KEY", ""),
+		OpenAIBaseURL:     getEnv("OPENAI_BASE_URL", ""),
+		OpenAIAPI:         getEnv("OPENAI_API", "responses"),
+		GeminiAPIKey:      getEnv("GEMINI_API_KEY", ""),
+		GeminiBaseURL:     getEnv("GEMINI_BASE_URL", ""),
+		OllamaBaseURL:     getEnv("OLLAMA_BASE_URL", ""),
+		ProviderKeySecret: getEnv("PROVIDER_KEY_SECRET", ""),
+		DefaultProvider:   getEnv("DEFAULT_PROVIDER", "openrouter"),
+		DefaultModel:      getEnv("DEFAULT_MODEL", "moonshotai/kimi-k2-thinking"),
+		MaxToolRounds:     getEnvInt("MAX_TOOL_ROUNDS", 10),
+		// Model auto-routing
+		AutoModelCheap:           getEnv("AUTO_MODEL_CHEAP", "google/gemini-2.5-flash"),
+		AutoModelStrong:          getEnv("AUTO_MODEL_STRONG", ""),
+		AutoModelLongPromptChars: getEnvInt("AUTO_MODEL_LONG_PROMPT_CHARS", 2000),
+		ModelAllowlist:           getEnv("MODEL_ALLOWLIST", ""),
+		// System prompt budget
+		SystemPromptWarnPercent: getEnvInt("SYSTEM_PROMPT_WARN_PERCENT", 25),
+		SystemPromptMaxPercent:  getEnvInt("SYSTEM_PROMPT_MAX_PERCENT", 50),
+		// Tool result sanitization
+		ToolResultSanitization: getEnv("TOOL_RESULT_SANITIZATION", "standard"),
+		ToolResultMaxTokens:    getEnvInt("TOOL_RESULT_MAX_TOKENS", 8000),
+		ToolRetryMaxAttempts:   getEnvInt("TOOL_RETRY_MAX_ATTEMPTS", 2),
+		ToolRetryBackoffMs:     getEnvInt("TOOL_RETRY_BACKOFF_MS", 250),
+		StreamMaxBlockBytes:    getEnvInt("STREAM_MAX_BLOCK_BYTES", 4<<20),
+		StreamMaxTurnBytes:     getEnvInt("STREAM_MAX_TURN_BYTES", 16<<20),
 		// Search API Configuration (optional)
 		SearchAPIKey:      getEnv("SEARCH_API_KEY", ""),
 		SearchAPIProvider: getEnv("SEARCH_API_PROVIDER", "tavily"),
+		// Image generation (optional)
+		ImageProvider: getEnv("IMAGE_PROVIDER", ""),
+		ImageAPIKey:   getEnv("IMAGE_API_KEY", ""),
+		ImageModel:    getEnv("IMAGE_MODEL", ""),
+		// Text-to-speech (optional)
+		TTSProvider:      getEnv("TTS_PROVIDER", ""),
+		TTSAPIKey:        getEnv("TTS_API_KEY", ""),
+		TTSModel:         getEnv("TTS_MODEL", ""),
+		TTSVoice:         getEnv("TTS_VOICE", ""),
+		TTSMaxCharacters: getEnvInt("TTS_MAX_CHARACTERS", 20000),
+		// Critic pass
+		CritiqueModel: getEnv("CRITIQUE_MODEL", ""),
+		// Editor generations
+		EditorModel: getEnv("EDITOR_MODEL", ""),
 		// Debug flags - default to true in dev/test, false in production
 		Debug: getEnv("DEBUG", getDefaultDebug(env)) == "true",
 		// Logging configuration
 		LogToFile:   getEnv("LOG_TO_FILE", "false") == "true",
 		LogDir:      getEnv("LOG_DIR", "./logs"),
 		LogMaxFiles: getEnvInt("LOG_MAX_FILES", 10),
+		// Maintenance mode
+		MaintenanceMode:          getEnv("MAINTENANCE_MODE", "false") == "true",
+		MaintenanceFile:          getEnv("MAINTENANCE_FILE", ""),
+		MaintenanceMessage:       getEnv("MAINTENANCE_MESSAGE", ""),
+		MaintenanceRetryAfterSec: getEnvInt("MAINTENANCE_RETRY_AFTER_SECONDS", 120),
+		// Request timeout
+		RequestTimeoutSeconds: getEnvInt("REQUEST_TIMEOUT_SECONDS", 30),
+		// Rate limits
+		RateLimitPerMinute:           getEnvInt("RATE_LIMIT_PER_MINUTE", 300),
+		RateLimitBurst:               getEnvInt("RATE_LIMIT_BURST", 60),
+		GenerationRateLimitPerMinute: getEnvInt("GENERATION_RATE_LIMIT_PER_MINUTE", 10),
+		GenerationRateLimitBurst:     getEnvInt("GENERATION_RATE_LIMIT_BURST", 5),
+		// Abuse detection
+		AbuseWindowMinutes:          getEnvInt("ABUSE_WINDOW_MINUTES", 10),
+		AbuseMaxTurnsPerWindow:      getEnvInt("ABUSE_MAX_TURNS_PER_WINDOW", 60),
+		AbuseMaxToolRoundsPerWindow: getEnvInt("ABUSE_MAX_TOOL_ROUNDS_PER_WINDOW", 200),
+		AbuseMaxToolInputKB:         getEnvInt("ABUSE_MAX_TOOL_INPUT_KB", 256),
+		AbuseThrottleMinutes:        getEnvInt("ABUSE_THROTTLE_MINUTES", 30),
+		AbuseAlertWebhookURL:        getEnv("ABUSE_ALERT_WEBHOOK_URL", ""),
+		// Graceful shutdown
+		ShutdownDrainTimeoutSeconds:      getEnvInt("SHUTDOWN_DRAIN_TIMEOUT_SECONDS", 60),
+		StreamReconnectRetryAfterSeconds: getEnvInt("STREAM_RECONNECT_RETRY_AFTER_SECONDS", 5),
+		// Stream registry
+		StreamRetentionSeconds:       getEnvInt("STREAM_RETENTION_SECONDS", 0),
+		StreamCleanupIntervalSeconds: getEnvInt("STREAM_CLEANUP_INTERVAL_SECONDS", 60),
+		// Name policy
+		NameUniqueness: getEnv("NAME_UNIQUENESS", "exact"),
+		// Book export
+		ExportPDFCommand: getEnv("EXPORT_PDF_COMMAND", ""),
+		// Background jobs
+		JobWorkers: getEnvInt("JOB_WORKERS", 2),
+		// Publishing
+		PublishPollIntervalSeconds: getEnvInt("PUBLISH_POLL_INTERVAL_SECONDS", 30),
+		PublishAllowedHosts:        getEnv("PUBLISH_ALLOWED_HOSTS", ""),
+
+		ChatRetentionDays:  getEnvInt("CHAT_RETENTION_DAYS", 30),
+		TrashRetentionDays: getEnvInt("TRASH_RETENTION_DAYS", 30),
+
+		AuthCacheTTLSeconds: getEnvInt("AUTH_CACHE_TTL_SECONDS", 5),
+		PresenceTTLSeconds:  getEnvInt("PRESENCE_TTL_SECONDS", 60),
+		AdminAPIToken:       getEnv("ADMIN_API_TOKEN", ""),
+
+		CollabSaveIntervalSeconds: getEnvInt("COLLAB_SAVE_INTERVAL_SECONDS", 10),
+
+		ContentCompressionMinBytes: getEnvInt("CONTENT_COMPRESSION_MIN_BYTES", 4096),
+		// Object storage
+		StorageBackend:       getEnv("STORAGE_BACKEND", "local"),
+		StorageLocalDir:      getEnv("STORAGE_LOCAL_DIR", "./data/storage"),
+		StoragePublicURL:     getEnv("STORAGE_PUBLIC_URL", "http://localhost:"+getEnv("PORT", "8080")),
+		StorageSigningSecret: getEnv("STORAGE_SIGNING_SECRET", ""),
+		StorageURLTTLSeconds: getEnvInt("STORAGE_URL_TTL_SECONDS", 900),
+		S3Endpoint:           getEnv("S3_ENDPOINT", ""),
+		S3Region:             getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:             getEnv("S3_BUCKET", ""),
+		S3AccessKeyID:        getEnv("S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey:    getEnv("S3_SECRET_ACCESS_KEY", ""),
+		// Chat attachments
+		AttachmentMaxMB:          getEnvInt("ATTACHMENT_MAX_MB", 10),
+		AttachmentProjectQuotaMB: getEnvInt("ATTACHMENT_PROJECT_QUOTA_MB", 500),
+		// Error reporting
+		SentryDSN:     getEnv("SENTRY_DSN", ""),
+		SentryRelease: getEnv("SENTRY_RELEASE", ""),
 	}
 }
 
@@ -74,6 +298,18 @@ func getDefaultDebug(env string) string {
 	return "true" // Enable DEBUG in dev/test by default
 }
 
+// getDefaultCORSOrigins returns the CORS preset for the environment (see middleware.CORSPresets)
+func getDefaultCORSOrigins(env string) string {
+	switch env {
+	case "prod":
+		return "@prod" // Empty: production origins must be configured explicitly
+	case "test":
+		return "@test"
+	default:
+		return "@dev"
+	}
+}
+
 // getTablePrefix returns the table prefix based on environment
 func getTablePrefix(env string) string {
 	// Allow manual override via TABLE_PREFIX env var
@@ -25,4 +25,101 @@ const (
 	// segment can be up to 100 characters. Longer paths indicate
 	// overly deep hierarchies (anti-pattern).
 	MaxDocumentPathLength = 500
+
+	// MaxSnapshotNameLength is the maximum length for project snapshot names.
+	MaxSnapshotNameLength = 255
+
+	// MaxExportFolders is the maximum number of folders selected for one export.
+	MaxExportFolders = 100
+
+	// MaxPublishSelection is the maximum number of documents (and of folders)
+	// selected for one publish target.
+	MaxPublishSelection = 500
+
+	// MaxSlugLength is the maximum length (in characters) of generated URL slugs
+	// (e.g. chat share URLs). Slugs are decorative; the ID in the URL is authoritative.
+	MaxSlugLength = 60
+
+	// MaxChatPreviewLength is the maximum length (in characters) of the
+	// last-turn preview text in chat lists.
+	MaxChatPreviewLength = 120
+
+	// MaxChatDefaultSkills is the maximum number of default skills per chat.
+	// Every skill is injected into the system prompt, so long lists mostly
+	// burn context budget.
+	MaxChatDefaultSkills = 20
+
+	// MaxChatDefaultTools is the maximum number of default tools per chat.
+	MaxChatDefaultTools = 20
+
+	// MaxWorkflowNameLength is the maximum length for workflow names.
+	MaxWorkflowNameLength = 255
+
+	// MaxWorkflowSteps is the maximum number of steps in a workflow.
+	MaxWorkflowSteps = 20
+
+	// MaxWorkflowPromptLength is the maximum length (in characters) of one
+	// workflow step's prompt template.
+	MaxWorkflowPromptLength = 20000
+
+	// MaxCritiqueRubricLength is the maximum length (in characters) of a
+	// project's critique rubric.
+	MaxCritiqueRubricLength = 10000
+
+	// MaxEditorCompletionTokens is the maximum number of tokens an inline
+	// editor completion may request. Ghost text is meant to be short.
+	MaxEditorCompletionTokens = 256
+
+	// MaxEditorRewriteTokens is the maximum number of tokens each rewrite
+	// variant may request.
+	MaxEditorRewriteTokens = 4096
+
+	// MaxEditorRewriteVariants is the maximum number of alternatives one
+	// rewrite request may stream. Variants run concurrently.
+	MaxEditorRewriteVariants = 5
+
+	// MaxEditorSelectionLength is the maximum length (in characters) of the
+	// text range a rewrite replaces.
+	MaxEditorSelectionLength = 20000
+
+	// MaxEditorInstructionLength is the maximum length (in characters) of a
+	// rewrite instruction.
+	MaxEditorInstructionLength = 2000
+
+	// MaxEditorTranslationLength is the maximum length (in characters) of the
+	// text one translation pass reads. Longer documents are translated in ranges.
+	MaxEditorTranslationLength = 40000
+
+	// MaxEditorTranslationTokens is the maximum number of tokens a translation
+	// may request.
+	MaxEditorTranslationTokens = 16384
+
+	// MaxEvalCaseNameLength is the maximum length for eval case names.
+	MaxEvalCaseNameLength = 255
+
+	// MaxEvalPromptLength is the maximum length (in characters) of an eval
+	// case's prompt.
+	MaxEvalPromptLength = 20000
+
+	// MaxEvalCriteria is the maximum number of criteria of one eval case.
+	MaxEvalCriteria = 20
+
+	// MaxEvalRunModels is the maximum number of models one eval run compares.
+	MaxEvalRunModels = 5
+
+	// MaxEvalRunGenerations is the maximum number of outputs (cases x models)
+	// one eval run generates. Larger suites are run in parts.
+	MaxEvalRunGenerations = 200
+
+	// MaxEvalOutputTokens is the maximum number of tokens each eval output
+	// may request.
+	MaxEvalOutputTokens = 8192
+
+	// MaxStyleProfileDocuments is the maximum number of documents one style
+	// analysis may read.
+	MaxStyleProfileDocuments = 50
+
+	// MaxProjectToolPolicyTools is the maximum number of tool names in each
+	// list (allowed / denied) of a project's tool policy.
+	MaxProjectToolPolicyTools = 50
 )
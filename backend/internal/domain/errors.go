@@ -12,6 +12,13 @@ type HTTPError interface {
 	StatusCode() int
 }
 
+// DetailedError is an HTTPError that carries structured details beyond the message
+// (e.g. per-field validation issues). The extras are added to the problem response.
+type DetailedError interface {
+	HTTPError
+	Extras() map[string]interface{}
+}
+
 // Domain error types implementing HTTPError interface
 type (
 	// NotFoundError indicates a resource was not found
@@ -0,0 +1,48 @@
+package docsystem
+
+import "time"
+
+// Pace values of document analytics
+const (
+	PaceFast     = "fast"
+	PaceModerate = "moderate"
+	PaceSlow     = "slow"
+)
+
+// DocumentAnalytics are readability and pacing statistics of a document's prose.
+// Cached per content hash and recomputed on the first read after the content changes.
+type DocumentAnalytics struct {
+	DocumentID  string `json:"document_id"`
+	ContentHash string `json:"content_hash"` // Hash of the content the statistics describe
+
+	WordCount      int `json:"word_count"`
+	SentenceCount  int `json:"sentence_count"`
+	ParagraphCount int `json:"paragraph_count"`
+	ReadingMinutes int `json:"reading_minutes"` // At 238 words per minute, rounded up
+
+	FleschReadingEase  float64 `json:"flesch_reading_ease"`  // 0-100, higher is easier
+	FleschKincaidGrade float64 `json:"flesch_kincaid_grade"` // US school grade
+	ReadingLevel       string  `json:"reading_level"`        // Label of the reading ease, e.g. "fairly easy"
+
+	AvgSentenceLength       float64  `json:"avg_sentence_length"`       // Words per sentence
+	SentenceLengthVariation float64  `json:"sentence_length_variation"` // Standard deviation of words per sentence
+	AvgParagraphLength      float64  `json:"avg_paragraph_length"`      // Words per paragraph
+	DialogueRatio           float64  `json:"dialogue_ratio"`            // Share of words inside quotation marks
+	AdverbDensity           float64  `json:"adverb_density"`            // -ly adverbs per 100 words
+	TopAdverbs              []string `json:"top_adverbs"`               // Most frequent -ly adverbs
+
+	Pace     string          `json:"pace"`     // fast | moderate | slow
+	Sections []PacingSection `json:"sections"` // The document in up to 10 runs of paragraphs, in order
+
+	ComputedAt time.Time `json:"computed_at"`
+}
+
+// PacingSection is the pacing of a run of consecutive paragraphs
+type PacingSection struct {
+	StartParagraph    int     `json:"start_paragraph"` // 0-based
+	Paragraphs        int     `json:"paragraphs"`
+	Words             int     `json:"words"`
+	AvgSentenceLength float64 `json:"avg_sentence_length"`
+	DialogueRatio     float64 `json:"dialogue_ratio"`
+	Pace              string  `json:"pace"`
+}
@@ -0,0 +1,36 @@
+package docsystem
+
+import "time"
+
+// Revision sources
+const (
+	RevisionSourceCollab = "collab" // Saved by the collaboration channel
+)
+
+// Revision listing limits; MaxRevisionsLimit is also how many revisions are kept per
+// document
+const (
+	DefaultRevisionsLimit = 20
+	MaxRevisionsLimit     = 50
+)
+
+// CollabUpdate is one entry of a document's Yjs update log. The server stores and relays
+// updates without decoding them.
+type CollabUpdate struct {
+	Seq        int64     `json:"seq"`
+	DocumentID string    `json:"document_id"`
+	Data       []byte    `json:"-"` // Yjs update, v1 encoding
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// DocumentRevision is document content saved by the collaboration channel
+type DocumentRevision struct {
+	ID          string    `json:"id"`
+	DocumentID  string    `json:"document_id"`
+	Content     string    `json:"content,omitempty"` // Omitted in lists
+	ContentHash string    `json:"content_hash"`
+	WordCount   int       `json:"word_count"`
+	Source      string    `json:"source"`               // RevisionSourceCollab
+	UpdateSeq   *int64    `json:"update_seq,omitempty"` // Last collab update the content includes
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -1,18 +1,28 @@
 package docsystem
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 )
 
 type Document struct {
-	ID        string     `json:"id" db:"id"`
-	ProjectID string     `json:"project_id" db:"project_id"`
-	FolderID  *string    `json:"folder_id" db:"folder_id"` // NULL = root level
-	Name      string     `json:"name" db:"name"`           // Just "Aria", not "Characters/Aria"
-	Path      string     `json:"path,omitempty"`           // Computed display path, not stored in DB
-	Content   string     `json:"content" db:"content"`     // Markdown content
-	WordCount int        `json:"word_count" db:"word_count"`
-	CreatedAt time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	ID          string     `json:"id" db:"id"`
+	ProjectID   string     `json:"project_id" db:"project_id"`
+	FolderID    *string    `json:"folder_id" db:"folder_id"`                 // NULL = root level
+	Name        string     `json:"name" db:"name"`                           // Just "Aria", not "Characters/Aria"
+	Path        string     `json:"path,omitempty"`                           // Computed display path, not stored in DB
+	Content     string     `json:"content" db:"content"`                     // Markdown content
+	ContentHash string     `json:"content_hash,omitempty" db:"content_hash"` // Hex SHA-256 of Content (see ContentHash)
+	WordCount   int        `json:"word_count" db:"word_count"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+}
+
+// ContentHash returns the hex SHA-256 of Markdown content, the same value the
+// database stores in documents.content_hash. Sync clients hash local files the same way.
+func ContentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
 }
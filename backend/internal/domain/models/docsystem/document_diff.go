@@ -0,0 +1,38 @@
+package docsystem
+
+// DocumentDiff is a line diff between two versions of a document, grouped into
+// hunks like a unified diff so every client renders it the same way
+type DocumentDiff struct {
+	DocumentID   string     `json:"document_id"`
+	From         string     `json:"from"` // Version spec, e.g. "snapshot:<id>"
+	To           string     `json:"to"`   // Version spec, e.g. "current"
+	LinesAdded   int        `json:"lines_added"`
+	LinesRemoved int        `json:"lines_removed"`
+	Hunks        []DiffHunk `json:"hunks"`
+}
+
+// DiffHunk is a run of changes with surrounding context lines.
+// Starts are 1-based; a side with no lines starts at the line before the hunk (0 at the top).
+type DiffHunk struct {
+	OldStart int        `json:"old_start"`
+	OldLines int        `json:"old_lines"`
+	NewStart int        `json:"new_start"`
+	NewLines int        `json:"new_lines"`
+	Lines    []DiffLine `json:"lines"`
+}
+
+// Diff line types
+const (
+	DiffLineContext = "context"
+	DiffLineAdded   = "added"
+	DiffLineRemoved = "removed"
+)
+
+// DiffLine is one line of a hunk. OldLine/NewLine are 1-based and omitted on the side
+// the line doesn't exist on.
+type DiffLine struct {
+	Type    string `json:"type"`
+	Content string `json:"content"` // Without the trailing newline
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+}
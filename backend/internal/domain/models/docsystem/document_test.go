@@ -0,0 +1,19 @@
+package docsystem
+
+import "testing"
+
+func TestContentHash(t *testing.T) {
+	// Must match encode(sha256(convert_to(content, 'UTF8')), 'hex') in Postgres
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{"abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+	}
+	for _, tt := range tests {
+		if got := ContentHash(tt.content); got != tt.want {
+			t.Errorf("ContentHash(%q) = %s, want %s", tt.content, got, tt.want)
+		}
+	}
+}
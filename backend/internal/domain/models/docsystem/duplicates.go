@@ -0,0 +1,28 @@
+package docsystem
+
+// DuplicateReport lists groups of near-duplicate paragraphs across a project's documents
+type DuplicateReport struct {
+	ProjectID         string           `json:"project_id"`
+	Threshold         float64          `json:"threshold"` // Minimum estimated similarity of a reported pair
+	DocumentsScanned  int              `json:"documents_scanned"`
+	ParagraphsScanned int              `json:"paragraphs_scanned"` // Paragraphs long enough to compare
+	Groups            []DuplicateGroup `json:"groups"`
+	Truncated         bool             `json:"truncated"` // More groups were found than reported
+}
+
+// DuplicateGroup is a set of paragraphs that are near-duplicates of each other
+type DuplicateGroup struct {
+	Similarity float64            `json:"similarity"` // Lowest similarity of the pairs linking the group, 0-1
+	Passages   []DuplicatePassage `json:"passages"`   // In document path and line order
+}
+
+// DuplicatePassage locates one paragraph of a duplicate group
+type DuplicatePassage struct {
+	DocumentID   string `json:"document_id"`
+	DocumentPath string `json:"document_path"`
+	Paragraph    int    `json:"paragraph"`  // 0-based index among the document's paragraphs
+	StartLine    int    `json:"start_line"` // 1-based, inclusive
+	EndLine      int    `json:"end_line"`
+	Words        int    `json:"words"`
+	Excerpt      string `json:"excerpt"` // Start of the paragraph's text
+}
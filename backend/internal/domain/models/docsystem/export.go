@@ -0,0 +1,48 @@
+package docsystem
+
+import "time"
+
+// Export formats
+const (
+	ExportFormatEPUB = "epub"
+	ExportFormatPDF  = "pdf"
+)
+
+// Export job statuses
+const (
+	ExportStatusPending   = "pending"
+	ExportStatusRunning   = "running"
+	ExportStatusCompleted = "completed"
+	ExportStatusFailed    = "failed"
+)
+
+// ExportJob is an asynchronous compilation of a project into a book file.
+// The file itself lives in object storage and is only loaded for download (see ExportFile).
+type ExportJob struct {
+	ID          string     `json:"id"`
+	ProjectID   string     `json:"project_id"`
+	UserID      string     `json:"user_id"`
+	Format      string     `json:"format"`
+	FolderIDs   []string   `json:"folder_ids"` // Reading order; empty = whole project
+	Title       string     `json:"title"`
+	Author      string     `json:"author,omitempty"`
+	Language    string     `json:"language"`
+	Status      string     `json:"status"`
+	Error       *string    `json:"error,omitempty"`
+	FileName    *string    `json:"file_name,omitempty"`
+	FileSize    int64      `json:"file_size"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	StorageKey  *string `json:"-"`                      // Object storage key of the file (nil for legacy jobs)
+	DownloadURL string  `json:"download_url,omitempty"` // Short-lived signed URL, set on completed jobs
+}
+
+// ExportFile is the output of a completed export job
+type ExportFile struct {
+	FileName    string
+	ContentType string
+	StorageKey  string // Object storage key; empty for legacy jobs whose Data came from the row
+	Data        []byte
+}
@@ -0,0 +1,51 @@
+package docsystem
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Job kinds
+const (
+	JobKindImport = "import"
+	JobKindEval   = "eval"
+)
+
+// Job statuses
+const (
+	JobStatusPending   = "pending"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusFailed    = "failed"
+)
+
+// Job is a unit of long-running work queued by a request and run by the worker pool.
+// Params and Result are kind-specific JSON (for imports: the uploaded file keys and the
+// ImportResult).
+type Job struct {
+	ID             string          `json:"id"`
+	ProjectID      string          `json:"project_id"`
+	UserID         string          `json:"user_id"`
+	Kind           string          `json:"kind"`
+	Status         string          `json:"status"`
+	FilesProcessed int             `json:"files_processed"`
+	Errors         []JobError      `json:"errors"`           // Per-file errors so far
+	Result         json.RawMessage `json:"result,omitempty"` // Set once completed
+	Error          *string         `json:"error,omitempty"`  // Why the whole job failed
+	CreatedAt      time.Time       `json:"created_at"`
+	StartedAt      *time.Time      `json:"started_at,omitempty"`
+	CompletedAt    *time.Time      `json:"completed_at,omitempty"`
+
+	Params json.RawMessage `json:"-"`
+}
+
+// JobError is a file a job couldn't process
+type JobError struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
+
+// IsFinished reports whether the job has completed or failed
+func (j *Job) IsFinished() bool {
+	return j.Status == JobStatusCompleted || j.Status == JobStatusFailed
+}
@@ -0,0 +1,21 @@
+package docsystem
+
+import "time"
+
+// EditSession is one client session (a browser tab or device) of a user with a
+// document open in the editor
+type EditSession struct {
+	SessionID  string    `json:"session_id"`
+	Label      string    `json:"label,omitempty"` // Client-chosen description, e.g. "Firefox on Linux"
+	OpenedAt   time.Time `json:"opened_at"`
+	LastSeenAt time.Time `json:"last_seen_at"` // Last heartbeat
+}
+
+// DocumentPresence lists the user's live edit sessions of a document, so a client can
+// warn before editing in a second tab or device. Only the user's own sessions are
+// listed; documents aren't shared.
+type DocumentPresence struct {
+	DocumentID       string        `json:"document_id"`
+	Sessions         []EditSession `json:"sessions"`          // Oldest first, including the caller's
+	EditingElsewhere bool          `json:"editing_elsewhere"` // Another session has the document open
+}
@@ -1,6 +1,7 @@
 package docsystem
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -12,4 +13,49 @@ type Project struct {
 	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
 	DeletedAt    *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+
+	// Computed list summaries (only set when requested, see ProjectListOptions)
+	DocumentCount  *int       `json:"document_count,omitempty"`
+	WordCount      *int       `json:"word_count,omitempty"`
+	LastActivityAt *time.Time `json:"last_activity_at,omitempty"` // Latest project, document, chat or turn change
+	ActiveStreams  *int       `json:"active_streams,omitempty"`   // Assistant turns still pending or streaming
+}
+
+// Project list include values (GET /api/projects?include=...)
+const (
+	ProjectIncludeDocumentCount = "document_count"
+	ProjectIncludeWordCount     = "word_count"
+	ProjectIncludeLastActivity  = "last_activity"
+	ProjectIncludeActiveStreams = "active_streams"
+)
+
+// ProjectListOptions selects the activity summaries joined onto each project of a list.
+// All summaries are computed in the list query itself (one aggregate query).
+type ProjectListOptions struct {
+	IncludeDocumentCount bool
+	IncludeWordCount     bool
+	IncludeLastActivity  bool
+	IncludeActiveStreams bool
+}
+
+// ParseProjectListInclude builds list options from include values
+// Returns an error naming the first unknown value
+func ParseProjectListInclude(include []string) (*ProjectListOptions, error) {
+	opts := &ProjectListOptions{}
+	for _, value := range include {
+		switch value {
+		case ProjectIncludeDocumentCount:
+			opts.IncludeDocumentCount = true
+		case ProjectIncludeWordCount:
+			opts.IncludeWordCount = true
+		case ProjectIncludeLastActivity:
+			opts.IncludeLastActivity = true
+		case ProjectIncludeActiveStreams:
+			opts.IncludeActiveStreams = true
+		default:
+			return nil, fmt.Errorf("unknown include value %q (supported: %s, %s, %s, %s)", value,
+				ProjectIncludeDocumentCount, ProjectIncludeWordCount, ProjectIncludeLastActivity, ProjectIncludeActiveStreams)
+		}
+	}
+	return opts, nil
 }
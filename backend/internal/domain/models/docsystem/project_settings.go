@@ -0,0 +1,63 @@
+package docsystem
+
+import (
+	"slices"
+	"time"
+)
+
+// ProjectSettings are project-wide defaults applied to every chat in a project.
+// Stored in project_settings (system_prompt stays on the project row); a project
+// without a settings row has the defaults from DefaultProjectSettings.
+type ProjectSettings struct {
+	ProjectID      string            `json:"project_id"`
+	SystemPrompt   *string           `json:"system_prompt"`   // Base system prompt for all chats
+	DefaultModel   *string           `json:"default_model"`   // Model for turns that don't specify one (nil = server default)
+	ToolPolicy     ProjectToolPolicy `json:"tool_policy"`     // Which tools chats may use
+	Guardrails     ProjectGuardrails `json:"guardrails"`      // Overrides of server-wide safety limits
+	Critique       ProjectCritique   `json:"critique"`        // Automatic review of assistant turns by a second model
+	PinFolder      *string           `json:"pin_folder"`      // Folder path for turns pinned to the project (nil = DefaultPinFolder)
+	SearchLanguage string            `json:"search_language"` // Postgres text search configuration (pg_ts_config) for document search
+	StyleProfile   *StyleProfile     `json:"style_profile"`   // Read-only here; set by POST /api/projects/{id}/style-profile (nil = never analyzed)
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// ProjectToolPolicy restricts tools available to turns in a project.
+// Empty Allowed permits every tool; Denied always wins.
+type ProjectToolPolicy struct {
+	Allowed []string `json:"allowed"`
+	Denied  []string `json:"denied"`
+}
+
+// Permits reports whether the policy allows the named tool
+func (p ProjectToolPolicy) Permits(name string) bool {
+	if slices.Contains(p.Denied, name) {
+		return false
+	}
+	return len(p.Allowed) == 0 || slices.Contains(p.Allowed, name)
+}
+
+// ProjectGuardrails override server-wide safety settings for a project (nil = server default)
+type ProjectGuardrails struct {
+	ToolResultSanitization *string `json:"tool_result_sanitization,omitempty"` // off | standard | strict
+	MaxToolRounds          *int    `json:"max_tool_rounds,omitempty"`          // Can only lower the user's limit
+}
+
+// ProjectCritique configures the critic pass: when enabled, every completed assistant turn
+// is reviewed by a second model and the review is stored as the turn's critique
+type ProjectCritique struct {
+	Enabled bool    `json:"enabled"`
+	Model   *string `json:"model,omitempty"`  // nil = server default (CRITIQUE_MODEL), else the turn's own model
+	Rubric  *string `json:"rubric,omitempty"` // What to review for; nil = the built-in editorial rubric
+}
+
+// DefaultPinFolder is where pinned turns are saved when the project sets no pin_folder
+const DefaultPinFolder = "Notes"
+
+// DefaultProjectSettings returns the settings of a project that has never saved any
+func DefaultProjectSettings(projectID string) *ProjectSettings {
+	return &ProjectSettings{
+		ProjectID:      projectID,
+		ToolPolicy:     ProjectToolPolicy{Allowed: []string{}, Denied: []string{}},
+		SearchLanguage: DefaultSearchLanguage,
+	}
+}
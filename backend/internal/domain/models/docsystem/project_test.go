@@ -0,0 +1,17 @@
+package docsystem
+
+import "testing"
+
+func TestParseProjectListInclude(t *testing.T) {
+	opts, err := ParseProjectListInclude([]string{ProjectIncludeWordCount, ProjectIncludeActiveStreams})
+	if err != nil {
+		t.Fatalf("ParseProjectListInclude: %v", err)
+	}
+	if opts.IncludeDocumentCount || !opts.IncludeWordCount || opts.IncludeLastActivity || !opts.IncludeActiveStreams {
+		t.Errorf("opts = %+v", opts)
+	}
+
+	if _, err := ParseProjectListInclude([]string{"chats"}); err == nil {
+		t.Error("accepted unknown include value")
+	}
+}
@@ -0,0 +1,73 @@
+package docsystem
+
+import "time"
+
+// Publish target kinds
+const (
+	PublishKindGitHub  = "github"
+	PublishKindS3      = "s3"
+	PublishKindWebhook = "webhook"
+)
+
+// Publish triggers
+const (
+	PublishTriggerManual         = "manual"
+	PublishTriggerDocumentChange = "document_change"
+)
+
+// Publish delivery statuses
+const (
+	PublishStatusSucceeded = "succeeded"
+	PublishStatusFailed    = "failed"
+)
+
+// PublishTarget is a project's connection to an external destination.
+// Config holds kind-specific settings; secrets in it are masked in API responses.
+type PublishTarget struct {
+	ID              string            `json:"id"`
+	ProjectID       string            `json:"project_id"`
+	Name            string            `json:"name"`
+	Kind            string            `json:"kind"`
+	Config          map[string]string `json:"config"`
+	DocumentIDs     []string          `json:"document_ids"`
+	FolderIDs       []string          `json:"folder_ids"` // Whole subtrees; both empty = whole project
+	OnChange        bool              `json:"on_change"`  // Publish automatically when the selection changes
+	LastFingerprint string            `json:"-"`          // Selection state at the last attempt
+	LastStatus      *string           `json:"last_status,omitempty"`
+	LastAttemptAt   *time.Time        `json:"last_attempt_at,omitempty"`
+	LastPublishedAt *time.Time        `json:"last_published_at,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+	UpdatedAt       time.Time         `json:"updated_at"`
+}
+
+// PublishDelivery is one publish attempt in a target's delivery log
+type PublishDelivery struct {
+	ID            string    `json:"id"`
+	TargetID      string    `json:"target_id"`
+	Trigger       string    `json:"trigger"`
+	Status        string    `json:"status"`
+	DocumentCount int       `json:"document_count"`
+	Detail        string    `json:"detail,omitempty"` // e.g. commit SHA, HTTP status
+	Error         *string   `json:"error,omitempty"`
+	DurationMs    int       `json:"duration_ms"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// PublishedDocument is one document as sent to a publish target
+type PublishedDocument struct {
+	ID          string    `json:"id"`
+	Path        string    `json:"path"` // "Chapters/One"
+	Name        string    `json:"name"`
+	Markdown    string    `json:"markdown"`
+	HTML        string    `json:"html"` // Rendered body fragment
+	ContentHash string    `json:"content_hash"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// PublishBundle is everything a publisher sends in one delivery
+type PublishBundle struct {
+	ProjectID string              `json:"project_id"`
+	TargetID  string              `json:"target_id"`
+	Trigger   string              `json:"trigger"`
+	Documents []PublishedDocument `json:"documents"`
+}
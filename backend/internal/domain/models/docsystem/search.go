@@ -70,6 +70,11 @@ type SearchOptions struct {
 	// Default: "english"
 	Language string
 
+	// UseDocumentLanguage matches each document in its own language (documents.search_language,
+	// kept in sync with the project's search_language) instead of Language.
+	// Served by the per-language FTS indexes; Language is then only reported in result metadata.
+	UseDocumentLanguage bool
+
 	// Strategy selects which search algorithm to use
 	// Default: SearchStrategyFullText
 	// Currently only fulltext is implemented
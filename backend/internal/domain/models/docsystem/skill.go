@@ -0,0 +1,230 @@
+package docsystem
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"meridian/internal/domain"
+)
+
+// Skills live at .skills/{name}/SKILL (SKILL.md on disk, stored without the extension).
+// A SKILL document starts with YAML frontmatter describing the skill, followed by the
+// instructions injected into the system prompt:
+//
+//	---
+//	name: worldbuilding
+//	description: Keeps places, factions and history consistent
+//	version: 1.2.0
+//	---
+//	When the user asks about ...
+const (
+	SkillsFolderName      = ".skills"
+	SkillDocumentName     = "SKILL"
+	MaxSkillNameLength    = 64
+	MaxSkillDescLength    = 1024
+	skillFrontmatterFence = "---"
+)
+
+// Skill issue severities
+const (
+	SkillIssueError   = "error"   // Skill is rejected on save
+	SkillIssueWarning = "warning" // Skill is accepted, but should be fixed
+)
+
+var (
+	skillNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	semverPattern    = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+)
+
+// SkillMetadata is the frontmatter of a SKILL document
+type SkillMetadata struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	Version     string `json:"version,omitempty" yaml:"version"`
+}
+
+// SkillIssue is a single validation problem found in a SKILL document
+type SkillIssue struct {
+	Field    string `json:"field"` // Frontmatter field, or "frontmatter" for structural problems
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+}
+
+// ParsedSkill is a SKILL document split into metadata and body
+type ParsedSkill struct {
+	Metadata SkillMetadata
+	Body     string       // Content after the frontmatter
+	Issues   []SkillIssue // Validation issues (errors and warnings)
+}
+
+// HasErrors reports whether any issue is an error (as opposed to a warning)
+func (p *ParsedSkill) HasErrors() bool {
+	for _, issue := range p.Issues {
+		if issue.Severity == SkillIssueError {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidSkillName reports whether name is a well-formed skill folder name
+// (lowercase letters, digits and hyphens, starting with a letter or digit)
+func IsValidSkillName(name string) bool {
+	return len(name) <= MaxSkillNameLength && skillNamePattern.MatchString(name)
+}
+
+// SkillNameFromPath returns the skill name when path is a SKILL document
+// (".skills/{name}/SKILL"), or false for any other path.
+func SkillNameFromPath(path string) (string, bool) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 || parts[0] != SkillsFolderName || parts[2] != SkillDocumentName || parts[1] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// ParseSkill splits a SKILL document into frontmatter and body and validates the metadata.
+// dirName is the skill's folder name, which the frontmatter name must match.
+// Never returns an error: every problem is reported as an issue so callers can decide
+// whether to reject (save) or degrade gracefully (prompt resolution).
+func ParseSkill(dirName, content string) *ParsedSkill {
+	parsed := &ParsedSkill{Body: content}
+
+	frontmatter, body, ok := splitFrontmatter(content)
+	if !ok {
+		parsed.addError("frontmatter", "SKILL must start with YAML frontmatter delimited by '---' lines (name, description, version)")
+		return parsed
+	}
+	parsed.Body = body
+
+	if err := yaml.Unmarshal([]byte(frontmatter), &parsed.Metadata); err != nil {
+		parsed.addError("frontmatter", fmt.Sprintf("invalid YAML: %v", err))
+		return parsed
+	}
+
+	parsed.validate(dirName)
+	return parsed
+}
+
+// validate checks the metadata fields
+func (p *ParsedSkill) validate(dirName string) {
+	meta := &p.Metadata
+	meta.Name = strings.TrimSpace(meta.Name)
+	meta.Description = strings.TrimSpace(meta.Description)
+	meta.Version = strings.TrimSpace(meta.Version)
+
+	switch {
+	case meta.Name == "":
+		p.addError("name", "name is required")
+	case len(meta.Name) > MaxSkillNameLength:
+		p.addError("name", fmt.Sprintf("name must be at most %d characters", MaxSkillNameLength))
+	case !skillNamePattern.MatchString(meta.Name):
+		p.addError("name", "name may only contain lowercase letters, digits and hyphens, and must start with a letter or digit")
+	case dirName != "" && meta.Name != dirName:
+		p.addError("name", fmt.Sprintf("name %q must match the skill folder name %q", meta.Name, dirName))
+	}
+
+	switch {
+	case meta.Description == "":
+		p.addError("description", "description is required")
+	case len(meta.Description) > MaxSkillDescLength:
+		p.addError("description", fmt.Sprintf("description must be at most %d characters", MaxSkillDescLength))
+	}
+
+	switch {
+	case meta.Version == "":
+		p.addWarning("version", "version is not set; add a semantic version (e.g. 1.0.0) to track changes")
+	case !semverPattern.MatchString(meta.Version):
+		p.addError("version", fmt.Sprintf("version %q is not a semantic version (MAJOR.MINOR.PATCH)", meta.Version))
+	}
+
+	if strings.TrimSpace(p.Body) == "" {
+		p.addWarning("body", "skill has no instructions after the frontmatter")
+	}
+}
+
+func (p *ParsedSkill) addError(field, message string) {
+	p.Issues = append(p.Issues, SkillIssue{Field: field, Message: message, Severity: SkillIssueError})
+}
+
+func (p *ParsedSkill) addWarning(field, message string) {
+	p.Issues = append(p.Issues, SkillIssue{Field: field, Message: message, Severity: SkillIssueWarning})
+}
+
+// splitFrontmatter separates "---\n<yaml>\n---\n<body>"
+// Returns false when the content doesn't open with a frontmatter fence or the fence isn't closed.
+func splitFrontmatter(content string) (frontmatter, body string, ok bool) {
+	normalized := strings.ReplaceAll(content, "\r\n", "\n")
+	normalized = strings.TrimPrefix(normalized, "\ufeff")
+
+	lines := strings.Split(normalized, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != skillFrontmatterFence {
+		return "", "", false
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == skillFrontmatterFence {
+			frontmatter = strings.Join(lines[1:i], "\n")
+			body = strings.TrimLeft(strings.Join(lines[i+1:], "\n"), "\n")
+			return frontmatter, body, true
+		}
+	}
+	return "", "", false
+}
+
+// SkillValidationError is returned when a SKILL document with errors is saved.
+// Carries the individual issues so clients can highlight the offending fields.
+type SkillValidationError struct {
+	Skill  string
+	Issues []SkillIssue
+}
+
+// Error implements the error interface
+func (e *SkillValidationError) Error() string {
+	var messages []string
+	for _, issue := range e.Issues {
+		if issue.Severity == SkillIssueError {
+			messages = append(messages, issue.Message)
+		}
+	}
+	return fmt.Sprintf("invalid skill '%s': %s", e.Skill, strings.Join(messages, "; "))
+}
+
+// StatusCode implements the HTTPError interface
+func (e *SkillValidationError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// Is allows errors.Is() to match against ErrValidation
+func (e *SkillValidationError) Is(target error) bool {
+	return target == domain.ErrValidation
+}
+
+// Extras implements domain.DetailedError
+func (e *SkillValidationError) Extras() map[string]interface{} {
+	return map[string]interface{}{
+		"skill":  e.Skill,
+		"issues": e.Issues,
+	}
+}
+
+// SkillLintReport is the lint result for one skill folder
+type SkillLintReport struct {
+	Skill      string         `json:"skill"`
+	Path       string         `json:"path"`
+	DocumentID *string        `json:"document_id"` // nil when the folder has no SKILL document
+	Valid      bool           `json:"valid"`       // No error-severity issues
+	Metadata   *SkillMetadata `json:"metadata,omitempty"`
+	Issues     []SkillIssue   `json:"issues"`
+}
+
+// SkillLintResults is the lint result for every skill in a project
+type SkillLintResults struct {
+	Skills       []SkillLintReport `json:"skills"`
+	ErrorCount   int               `json:"error_count"`
+	WarningCount int               `json:"warning_count"`
+}
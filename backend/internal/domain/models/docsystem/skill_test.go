@@ -0,0 +1,77 @@
+package docsystem
+
+import "testing"
+
+func TestParseSkill(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantErrors []string // fields with error-severity issues
+		wantBody   string
+	}{
+		{
+			name:     "valid",
+			content:  "---\nname: prose\ndescription: Tight prose\nversion: 1.0.0\n---\nWrite well.",
+			wantBody: "Write well.",
+		},
+		{
+			name:       "no frontmatter",
+			content:    "Write well.",
+			wantErrors: []string{"frontmatter"},
+			wantBody:   "Write well.",
+		},
+		{
+			name:       "unclosed frontmatter",
+			content:    "---\nname: prose\n",
+			wantErrors: []string{"frontmatter"},
+			wantBody:   "---\nname: prose\n",
+		},
+		{
+			name:       "name mismatch and bad version",
+			content:    "---\nname: other\ndescription: x\nversion: v2\n---\nbody",
+			wantErrors: []string{"name", "version"},
+			wantBody:   "body",
+		},
+		{
+			name:       "missing description, CRLF",
+			content:    "---\r\nname: prose\r\n---\r\nbody",
+			wantErrors: []string{"description"},
+			wantBody:   "body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := ParseSkill("prose", tt.content)
+
+			var gotErrors []string
+			for _, issue := range parsed.Issues {
+				if issue.Severity == SkillIssueError {
+					gotErrors = append(gotErrors, issue.Field)
+				}
+			}
+			if len(gotErrors) != len(tt.wantErrors) {
+				t.Fatalf("error fields = %v, want %v (issues: %+v)", gotErrors, tt.wantErrors, parsed.Issues)
+			}
+			for i := range gotErrors {
+				if gotErrors[i] != tt.wantErrors[i] {
+					t.Errorf("error fields = %v, want %v", gotErrors, tt.wantErrors)
+				}
+			}
+			if parsed.Body != tt.wantBody {
+				t.Errorf("body = %q, want %q", parsed.Body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestSkillNameFromPath(t *testing.T) {
+	if name, ok := SkillNameFromPath(".skills/prose/SKILL"); !ok || name != "prose" {
+		t.Errorf("got %q, %v", name, ok)
+	}
+	for _, path := range []string{"SKILL", ".skills/SKILL", "notes/prose/SKILL", ".skills/prose/ref/SKILL", ".skills/prose/README"} {
+		if _, ok := SkillNameFromPath(path); ok {
+			t.Errorf("%q should not be a skill path", path)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package docsystem
+
+import "time"
+
+// Snapshot is an immutable save point of a project's whole tree.
+// Document content is stored content-addressed (content_blobs), so unchanged
+// documents cost one entry row per snapshot.
+type Snapshot struct {
+	ID            string    `json:"id"`
+	ProjectID     string    `json:"project_id"`
+	Name          string    `json:"name"`
+	DocumentCount int       `json:"document_count"`
+	FolderCount   int       `json:"folder_count"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Snapshot entry kinds
+const (
+	SnapshotEntryFolder   = "folder"
+	SnapshotEntryDocument = "document"
+)
+
+// SnapshotEntry is one folder or document in a snapshot, addressed by path
+type SnapshotEntry struct {
+	Kind        string `json:"kind"`
+	Path        string `json:"path"`                   // "Characters/Aria"
+	SourceID    string `json:"source_id"`              // Folder/document ID at capture time
+	ContentHash string `json:"content_hash,omitempty"` // Documents only
+}
+
+// SnapshotWithEntries is a snapshot and its tree
+type SnapshotWithEntries struct {
+	Snapshot
+	Entries []SnapshotEntry `json:"entries"`
+}
+
+// SnapshotDiff lists what changed between two trees, by path.
+// To is a snapshot ID or "current" (the live project tree).
+type SnapshotDiff struct {
+	From     string              `json:"from"`
+	To       string              `json:"to"`
+	Added    []SnapshotDiffEntry `json:"added"`
+	Removed  []SnapshotDiffEntry `json:"removed"`
+	Modified []SnapshotDiffEntry `json:"modified"`
+}
+
+// SnapshotDiffEntry is one changed path; line counts are set for modified documents
+type SnapshotDiffEntry struct {
+	Kind         string `json:"kind"`
+	Path         string `json:"path"`
+	LinesAdded   int    `json:"lines_added,omitempty"`
+	LinesRemoved int    `json:"lines_removed,omitempty"`
+}
+
+// SnapshotRestoreResult reports what a restore changed.
+// Backup is the snapshot of the tree taken right before restoring, so a restore can be undone.
+type SnapshotRestoreResult struct {
+	Restored         Snapshot `json:"restored"`
+	Backup           Snapshot `json:"backup"`
+	DocumentsCreated int      `json:"documents_created"`
+	DocumentsUpdated int      `json:"documents_updated"`
+	DocumentsDeleted int      `json:"documents_deleted"`
+	FoldersCreated   int      `json:"folders_created"`
+	FoldersDeleted   int      `json:"folders_deleted"`
+}
@@ -0,0 +1,52 @@
+package docsystem
+
+import "time"
+
+// Style profile statuses
+const (
+	StyleProfileStatusAnalyzing = "analyzing"
+	StyleProfileStatusComplete  = "complete"
+	StyleProfileStatusFailed    = "failed"
+)
+
+// Tense and person values of a style profile
+const (
+	StyleTensePast    = "past"
+	StyleTensePresent = "present"
+	StyleTenseMixed   = "mixed"
+
+	StylePersonFirst  = "first"
+	StylePersonSecond = "second"
+	StylePersonThird  = "third"
+	StylePersonMixed  = "mixed"
+)
+
+// StyleProfile is a project's style fingerprint: statistics of the narration in documents
+// the user selected. Stored on project_settings; turns that set match_style get it in
+// their system prompt. The statistics are zero until Status is complete.
+type StyleProfile struct {
+	ID          string   `json:"id"`     // New for each analysis, so a result is stored only on the profile it was started for
+	Status      string   `json:"status"` // analyzing | complete | failed
+	Error       *string  `json:"error,omitempty"`
+	DocumentIDs []string `json:"document_ids"`
+
+	WordCount           int      `json:"word_count"`
+	SentenceCount       int      `json:"sentence_count"`
+	AvgSentenceLength   float64  `json:"avg_sentence_length"`  // Words per sentence
+	ShortSentenceShare  float64  `json:"short_sentence_share"` // Share of sentences under 8 words
+	LongSentenceShare   float64  `json:"long_sentence_share"`  // Share of sentences over 25 words
+	AvgWordLength       float64  `json:"avg_word_length"`      // Letters per word
+	VocabularyRichness  float64  `json:"vocabulary_richness"`  // Distinct words per 100 words, over 1000-word windows
+	DialogueShare       float64  `json:"dialogue_share"`       // Share of words inside quotation marks
+	Tense               string   `json:"tense,omitempty"`      // past | present | mixed
+	Person              string   `json:"person,omitempty"`     // first | second | third | mixed
+	CharacteristicWords []string `json:"characteristic_words"` // Most frequent content words
+
+	StartedAt  time.Time  `json:"started_at"`
+	AnalyzedAt *time.Time `json:"analyzed_at,omitempty"`
+}
+
+// IsComplete reports whether the profile holds finished statistics
+func (p *StyleProfile) IsComplete() bool {
+	return p != nil && p.Status == StyleProfileStatusComplete
+}
@@ -0,0 +1,38 @@
+package docsystem
+
+import (
+	"strings"
+	"time"
+)
+
+// DocumentSuggestion is a saved rewrite of a document range, shown by the editor as an
+// inline suggestion. Start and End are character (Unicode code point) offsets at the time
+// the suggestion was made; OriginalText lets the editor detect that the range has since
+// changed and drop the suggestion.
+type DocumentSuggestion struct {
+	ID            string    `json:"id"`
+	DocumentID    string    `json:"document_id"`
+	Start         int       `json:"start"`
+	End           int       `json:"end"`
+	OriginalText  string    `json:"original_text"`
+	SuggestedText string    `json:"suggested_text"`
+	Instruction   string    `json:"instruction"`
+	Model         string    `json:"model"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Apply returns the content with the suggestion applied. The suggested text replaces the
+// original range if it still holds OriginalText, else the only occurrence of OriginalText
+// (the range moves when other edits or suggestions land first). ok is false when neither
+// holds: the text the suggestion rewrites has changed or is ambiguous.
+func (s *DocumentSuggestion) Apply(content string) (applied string, ok bool) {
+	runes := []rune(content)
+	if s.Start >= 0 && s.End >= s.Start && s.End <= len(runes) && string(runes[s.Start:s.End]) == s.OriginalText {
+		return string(runes[:s.Start]) + s.SuggestedText + string(runes[s.End:]), true
+	}
+
+	if s.OriginalText == "" || strings.Count(content, s.OriginalText) != 1 {
+		return "", false
+	}
+	return strings.Replace(content, s.OriginalText, s.SuggestedText, 1), true
+}
@@ -0,0 +1,29 @@
+package docsystem
+
+import "testing"
+
+func TestDocumentSuggestionApply(t *testing.T) {
+	suggestion := &DocumentSuggestion{Start: 4, End: 9, OriginalText: "quick", SuggestedText: "sluggish"}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantOK  bool
+	}{
+		{name: "range unchanged", content: "The quick fox", want: "The sluggish fox", wantOK: true},
+		{name: "range moved", content: "Today the quick fox", want: "Today the sluggish fox", wantOK: true},
+		{name: "multibyte offsets", content: "Thé quick fox", want: "Thé sluggish fox", wantOK: true},
+		{name: "text changed", content: "The slow fox", wantOK: false},
+		{name: "ambiguous", content: "A quick, quick fox", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := suggestion.Apply(tt.content)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Apply(%q) = %q, %v; want %q, %v", tt.content, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
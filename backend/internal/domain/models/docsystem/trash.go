@@ -0,0 +1,33 @@
+package docsystem
+
+import "time"
+
+// TrashItemType is the kind of soft-deleted resource in a project's trash
+type TrashItemType string
+
+const (
+	TrashItemDocument TrashItemType = "document"
+	TrashItemFolder   TrashItemType = "folder"
+	TrashItemChat     TrashItemType = "chat"
+)
+
+// TrashItem is a soft-deleted document, folder or chat.
+// Items deleted together with their parent folder aren't listed: restoring the folder
+// brings them back.
+type TrashItem struct {
+	Type      TrashItemType `json:"type"`
+	ID        string        `json:"id"`
+	ProjectID string        `json:"project_id"`
+	Name      string        `json:"name"`                // Document/folder name, chat title
+	FolderID  *string       `json:"folder_id,omitempty"` // Containing folder (documents, folders); nil = root
+	DeletedAt time.Time     `json:"deleted_at"`
+	PurgeAt   *time.Time    `json:"purge_at,omitempty"` // When the purge job removes it; nil = kept forever
+}
+
+// RestoreResult reports what a restore brought back
+type RestoreResult struct {
+	Type      TrashItemType `json:"type"`
+	ID        string        `json:"id"`
+	Folders   int64         `json:"folders"`   // Folders restored (a folder and its subfolders)
+	Documents int64         `json:"documents"` // Documents restored
+}
@@ -2,10 +2,19 @@ package docsystem
 
 import "time"
 
+// Tree entry kinds (TreeEntry.Kind)
+const (
+	TreeEntryFolder   = "folder"
+	TreeEntryDocument = "document"
+)
+
 // TreeNode represents the root of the document tree
+// DocumentCount/WordCount are project totals, including anything beyond a depth limit
 type TreeNode struct {
-	Folders   []*FolderTreeNode  `json:"folders"`
-	Documents []DocumentTreeNode `json:"documents"`
+	Folders       []*FolderTreeNode  `json:"folders"`
+	Documents     []DocumentTreeNode `json:"documents"`
+	DocumentCount int                `json:"document_count"`
+	WordCount     int                `json:"word_count"`
 }
 
 // FolderTreeNode represents a folder in the tree with nested children
@@ -16,6 +25,11 @@ type FolderTreeNode struct {
 	CreatedAt time.Time          `json:"created_at"`
 	Folders   []*FolderTreeNode  `json:"folders"` // Pointers for proper nesting
 	Documents []DocumentTreeNode `json:"documents"`
+
+	// Subtree aggregates (all descendants, even those cut off by a depth limit)
+	FolderCount   int `json:"folder_count"`
+	DocumentCount int `json:"document_count"`
+	WordCount     int `json:"word_count"`
 }
 
 // DocumentTreeNode represents a document in the tree (metadata only, no content)
@@ -26,3 +40,17 @@ type DocumentTreeNode struct {
 	WordCount int       `json:"word_count"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// TreeEntry is one row of the flattened project tree: a folder or a document
+// Depth is 1 for root-level entries. For folders, WordCount and the counts are subtree totals.
+type TreeEntry struct {
+	Kind          string // TreeEntryFolder or TreeEntryDocument
+	ID            string
+	ParentID      *string // Parent folder (nil at root)
+	Name          string
+	Depth         int
+	Timestamp     time.Time // created_at for folders, updated_at for documents
+	WordCount     int
+	FolderCount   int
+	DocumentCount int
+}
@@ -0,0 +1,65 @@
+package llm
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+)
+
+// Abuse signals: the anomaly a flag was raised for
+const (
+	AbuseSignalTurnRate      = "turn_rate"       // Too many turns created within the window
+	AbuseSignalToolRounds    = "tool_rounds"     // Too many tool rounds within the window
+	AbuseSignalToolInputSize = "tool_input_size" // A tool call's input exceeded the size limit
+)
+
+// Abuse actions: what a flag did to the user
+const (
+	AbuseActionFlagged   = "flagged"   // Recorded for review only (the offending call was refused)
+	AbuseActionThrottled = "throttled" // New turns are refused until throttled_until
+)
+
+// AbuseFlag records anomalous usage by a user. Throttling flags refuse the user's new turns
+// until they expire or an admin resolves them.
+type AbuseFlag struct {
+	ID             string     `json:"id"`
+	UserID         string     `json:"user_id"`
+	Signal         string     `json:"signal"` // "turn_rate", "tool_rounds" or "tool_input_size"
+	Action         string     `json:"action"` // "flagged" or "throttled"
+	Detail         string     `json:"detail"` // What was observed, for admins
+	TurnID         *string    `json:"turn_id,omitempty"`
+	ThrottledUntil *time.Time `json:"throttled_until,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+}
+
+// ThrottledError is returned when a throttled user creates a turn.
+// Implements domain.DetailedError (429 with retry_after_seconds).
+type ThrottledError struct {
+	Signal string
+	Until  time.Time
+}
+
+// Error implements the error interface
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("generation is paused until %s after unusual activity (%s)", e.Until.UTC().Format(time.RFC3339), e.Signal)
+}
+
+// StatusCode implements the HTTPError interface
+func (e *ThrottledError) StatusCode() int {
+	return http.StatusTooManyRequests
+}
+
+// RetryAfterSeconds is how long until the throttle expires (at least 1)
+func (e *ThrottledError) RetryAfterSeconds() int {
+	return int(math.Max(1, math.Ceil(time.Until(e.Until).Seconds())))
+}
+
+// Extras implements domain.DetailedError
+func (e *ThrottledError) Extras() map[string]interface{} {
+	return map[string]interface{}{
+		"signal":              e.Signal,
+		"retry_after_seconds": e.RetryAfterSeconds(),
+	}
+}
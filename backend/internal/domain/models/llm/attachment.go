@@ -0,0 +1,60 @@
+package llm
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Attachment is a file a user uploaded to attach to chat messages, kept in object storage.
+// Image blocks reference it by ID.
+type Attachment struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"project_id"`
+	UserID      string    `json:"user_id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	StorageKey  string    `json:"-"`
+	URL         string    `json:"url,omitempty"` // Signed download link, set by the service
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// imageProviders are the providers whose integrations send image blocks to the model.
+// The library's Anthropic and OpenRouter providers drop them.
+var imageProviders = map[string]bool{
+	"openai": true,
+	"google": true,
+	"ollama": true,
+}
+
+// ProviderSendsImages reports whether a provider's integration sends attached images
+// (to the models that support vision)
+func ProviderSendsImages(provider string) bool {
+	return imageProviders[provider]
+}
+
+// QuotaExceededError is returned when an upload would take a project's attachments past
+// its quota. Implements domain.DetailedError (413 with the usage and quota in bytes).
+type QuotaExceededError struct {
+	UsedBytes  int64
+	QuotaBytes int64
+}
+
+// Error implements the error interface
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("project attachment quota exceeded (%d of %d bytes used)", e.UsedBytes, e.QuotaBytes)
+}
+
+// StatusCode implements the HTTPError interface
+func (e *QuotaExceededError) StatusCode() int {
+	return http.StatusRequestEntityTooLarge
+}
+
+// Extras implements domain.DetailedError
+func (e *QuotaExceededError) Extras() map[string]interface{} {
+	return map[string]interface{}{
+		"used_bytes":  e.UsedBytes,
+		"quota_bytes": e.QuotaBytes,
+	}
+}
@@ -3,7 +3,7 @@ package llm
 import (
 	"encoding/json"
 
-	mstream "github.com/haowjy/meridian-stream-go"
+	"meridian/internal/eventstream"
 )
 
 // BlockSerializer converts TurnBlocks to SSE events for streaming/catchup
@@ -21,16 +21,15 @@ func NewBlockSerializer() *BlockSerializer {
 // used for:
 // - Catchup (replaying missed events during reconnection)
 // - Future features (exporting conversations, testing)
-func (s *BlockSerializer) BlockToSSEEvents(block *TurnBlock, blockIndex int) []mstream.Event {
-	var events []mstream.Event
+func (s *BlockSerializer) BlockToSSEEvents(block *TurnBlock, blockIndex int) []eventstream.Event {
+	var events []eventstream.Event
 
 	// 1. block_start event
 	blockStartData, _ := json.Marshal(BlockStartEvent{
 		BlockIndex: blockIndex,
 		BlockType:  &block.BlockType,
 	})
-	events = append(events, mstream.NewEvent(blockStartData).
-		WithType(SSEEventBlockStart))
+	events = append(events, eventstream.NewEvent(SSEEventBlockStart, blockStartData))
 
 	// 2. block_delta event(s) - send full content as single delta
 	//    (During catchup, we send the complete content at once)
@@ -43,8 +42,7 @@ func (s *BlockSerializer) BlockToSSEEvents(block *TurnBlock, blockIndex int) []m
 			TextDelta:  block.TextContent,
 			JSONDelta:  nil,
 		})
-		events = append(events, mstream.NewEvent(blockDeltaData).
-			WithType(SSEEventBlockDelta))
+		events = append(events, eventstream.NewEvent(SSEEventBlockDelta, blockDeltaData))
 	}
 
 	// Structured content (for tool_use, tool_result blocks)
@@ -57,16 +55,14 @@ func (s *BlockSerializer) BlockToSSEEvents(block *TurnBlock, blockIndex int) []m
 			TextDelta:  nil,
 			JSONDelta:  &contentStr,
 		})
-		events = append(events, mstream.NewEvent(blockDeltaData).
-			WithType(SSEEventBlockDelta))
+		events = append(events, eventstream.NewEvent(SSEEventBlockDelta, blockDeltaData))
 	}
 
 	// 3. block_stop event
 	blockStopData, _ := json.Marshal(BlockStopEvent{
 		BlockIndex: blockIndex,
 	})
-	events = append(events, mstream.NewEvent(blockStopData).
-		WithType(SSEEventBlockStop))
+	events = append(events, eventstream.NewEvent(SSEEventBlockStop, blockStopData))
 
 	return events
 }
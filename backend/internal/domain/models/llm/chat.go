@@ -1,7 +1,11 @@
 package llm
 
 import (
+	"encoding/json"
 	"time"
+
+	"meridian/internal/config"
+	"meridian/internal/utils"
 )
 
 // Chat represents a chat session within a project
@@ -11,10 +15,33 @@ type Chat struct {
 	UserID           string     `json:"user_id" db:"user_id"`
 	Title            string     `json:"title" db:"title"`
 	SystemPrompt     *string    `json:"system_prompt,omitempty" db:"system_prompt"`
+	DefaultSkills    []string   `json:"default_skills" db:"default_skills"` // Used when a turn selects no skills
+	DefaultTools     []string   `json:"default_tools" db:"default_tools"`   // Used when a turn requests no tools
 	LastViewedTurnID *string    `json:"last_viewed_turn_id" db:"last_viewed_turn_id"`
 	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
 	DeletedAt        *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	IdempotencyKey   *string    `json:"-" db:"idempotency_key"` // Key of the cold-start turn that created the chat (write-only)
+
+	// Computed list summaries (only set when requested, see ChatListOptions)
+	LastTurnPreview *ChatTurnPreview `json:"last_turn_preview,omitempty"`
+	TurnCount       *int             `json:"turn_count,omitempty"`
+	UnreadCount     *int             `json:"unread_count,omitempty"` // Assistant turns created after the last viewed turn
+}
+
+// Slug is a URL slug derived from the title for share URLs ("" when the title has no
+// usable characters). Share URLs must still resolve chats by ID.
+func (c Chat) Slug() string {
+	return utils.Slugify(c.Title, config.MaxSlugLength)
+}
+
+// MarshalJSON adds the derived slug to the chat's JSON
+func (c Chat) MarshalJSON() ([]byte, error) {
+	type chatFields Chat // Drops methods to avoid recursion
+	return json.Marshal(struct {
+		chatFields
+		Slug string `json:"slug"`
+	}{chatFields(c), c.Slug()})
 }
 
 // PaginatedTurnsResponse contains paginated turns with metadata
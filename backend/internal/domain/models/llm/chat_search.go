@@ -0,0 +1,81 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ChatSearchOptions configures full-text search over the turns of a project's chats
+// (GET /api/chats/search). Like TurnSearchOptions, only text blocks are searched.
+type ChatSearchOptions struct {
+	// ProjectID limits the search to chats in this project (required)
+	ProjectID string
+
+	// UserID limits the search to this user's non-deleted chats (required)
+	UserID string
+
+	// Query is the search string (required), websearch syntax (quotes, OR, -term)
+	Query string
+
+	// Limit is the maximum number of matched turns to return (default: 20, max: 100)
+	Limit int
+
+	// Offset skips that many matches, for paging
+	Offset int
+
+	// Language is the FTS configuration used for stemming (default: "english")
+	Language string
+}
+
+// ApplyDefaults fills in default values for unset fields
+func (opts *ChatSearchOptions) ApplyDefaults() {
+	opts.Query = strings.TrimSpace(opts.Query)
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultTurnSearchLimit
+	}
+	if opts.Language == "" {
+		opts.Language = DefaultTurnSearchLanguage
+	}
+}
+
+// Validate checks that required fields are set and values are reasonable
+func (opts *ChatSearchOptions) Validate() error {
+	if opts.ProjectID == "" {
+		return fmt.Errorf("project ID is required")
+	}
+	if opts.UserID == "" {
+		return fmt.Errorf("user ID is required")
+	}
+	if opts.Query == "" {
+		return fmt.Errorf("search query cannot be empty")
+	}
+	if opts.Limit > MaxTurnSearchLimit {
+		return fmt.Errorf("limit cannot exceed %d (requested: %d)", MaxTurnSearchLimit, opts.Limit)
+	}
+	if opts.Offset < 0 {
+		return fmt.Errorf("offset cannot be negative")
+	}
+	return nil
+}
+
+// ChatSearchMatch is a turn matching a search across chats, with its chat for context
+// Each turn appears at most once, represented by its best-matching block
+type ChatSearchMatch struct {
+	ChatID        string    `json:"chat_id"`
+	ChatTitle     string    `json:"chat_title"`
+	ChatUpdatedAt time.Time `json:"chat_updated_at"`
+	TurnID        string    `json:"turn_id"`
+	PrevTurnID    *string   `json:"prev_turn_id"` // The turn it answers (or that precedes it)
+	Role          string    `json:"role"`
+	Snippet       string    `json:"snippet"` // ts_headline excerpt, matches wrapped in <b></b>
+	Score         float64   `json:"score"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ChatSearchResults is the response for search across chats
+type ChatSearchResults struct {
+	Matches  []ChatSearchMatch `json:"matches"`
+	HasMore  bool              `json:"has_more"`
+	Language string            `json:"language"` // FTS configuration used
+}
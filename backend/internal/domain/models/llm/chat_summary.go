@@ -0,0 +1,50 @@
+package llm
+
+import (
+	"fmt"
+	"time"
+)
+
+// Chat list include values (GET /api/chats?include=...)
+const (
+	ChatIncludeLastTurnPreview = "last_turn_preview"
+	ChatIncludeTurnCount       = "turn_count"
+	ChatIncludeUnreadCount     = "unread_count"
+)
+
+// ChatListOptions selects the summaries joined onto each chat of a list.
+// All summaries are computed in the list query itself (no per-chat follow-up queries).
+type ChatListOptions struct {
+	IncludeLastTurnPreview bool
+	IncludeTurnCount       bool
+	IncludeUnreadCount     bool
+}
+
+// ParseChatListInclude builds list options from include values
+// Returns an error naming the first unknown value
+func ParseChatListInclude(include []string) (*ChatListOptions, error) {
+	opts := &ChatListOptions{}
+	for _, value := range include {
+		switch value {
+		case ChatIncludeLastTurnPreview:
+			opts.IncludeLastTurnPreview = true
+		case ChatIncludeTurnCount:
+			opts.IncludeTurnCount = true
+		case ChatIncludeUnreadCount:
+			opts.IncludeUnreadCount = true
+		default:
+			return nil, fmt.Errorf("unknown include value %q (supported: %s, %s, %s)",
+				value, ChatIncludeLastTurnPreview, ChatIncludeTurnCount, ChatIncludeUnreadCount)
+		}
+	}
+	return opts, nil
+}
+
+// ChatTurnPreview summarizes the most recent turn of a chat (any branch) for the sidebar
+type ChatTurnPreview struct {
+	TurnID    string    `json:"turn_id"`
+	Role      string    `json:"role"`
+	Status    string    `json:"status"`
+	Text      *string   `json:"text"` // Start of the first text block (nil if the turn has no text yet)
+	CreatedAt time.Time `json:"created_at"`
+}
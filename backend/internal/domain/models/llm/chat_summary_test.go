@@ -0,0 +1,17 @@
+package llm
+
+import "testing"
+
+func TestParseChatListInclude(t *testing.T) {
+	opts, err := ParseChatListInclude([]string{ChatIncludeTurnCount, ChatIncludeUnreadCount})
+	if err != nil {
+		t.Fatalf("ParseChatListInclude: %v", err)
+	}
+	if opts.IncludeLastTurnPreview || !opts.IncludeTurnCount || !opts.IncludeUnreadCount {
+		t.Errorf("opts = %+v", opts)
+	}
+
+	if _, err := ParseChatListInclude([]string{"messages"}); err == nil {
+		t.Error("accepted unknown include value")
+	}
+}
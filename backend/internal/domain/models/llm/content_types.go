@@ -3,17 +3,41 @@ package llm
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"time"
 )
 
-// Content type structs define the JSONB schema for each block type
-// These provide type safety and validation for the content field
+// Content type structs define the JSONB schema for each block type.
+// They provide type safety and validation for the content field; code that reads or
+// writes block content goes through DecodeContent / EncodeContent instead of map keys.
+//
+// Schemas are versioned: content carries "schema_version" (BlockContentSchemaVersion).
+// Rows written before versioning have no version and are upgraded on read by
+// UpgradeContent (the compatibility reader), so old chats keep working.
 
-// ImageContent represents the content structure for image blocks
+// BlockContentSchemaVersion is the current version of the block content schemas
+const BlockContentSchemaVersion = 1
+
+// ContentSchemaVersionKey is the content key holding the schema version
+const ContentSchemaVersionKey = "schema_version"
+
+// BlockContent is the typed content of one block type
+type BlockContent interface {
+	// Validate checks required fields and allowed values
+	Validate() error
+}
+
+// ImageContent represents the content structure for image blocks.
+// Images kept in object storage (e.g. generated by image_generate) carry their
+// StorageKey; their URL is a signed link that is refreshed whenever blocks are read.
+// Clients attach uploads by AttachmentID alone; the URL, MIME type and key are filled
+// in from the attachment when the turn is created.
 type ImageContent struct {
-	URL      string  `json:"url"`
-	MIMEType string  `json:"mime_type"`
-	AltText  *string `json:"alt_text,omitempty"`
+	URL          string  `json:"url"`
+	MIMEType     string  `json:"mime_type"`
+	AltText      *string `json:"alt_text,omitempty"`
+	StorageKey   string  `json:"storage_key,omitempty"`
+	AttachmentID string  `json:"attachment_id,omitempty"`
 }
 
 // ReferenceContent represents the content structure for reference blocks
@@ -25,17 +49,63 @@ type ReferenceContent struct {
 	SelectionEnd     *int       `json:"selection_end,omitempty"`
 }
 
-// ToolUseContent represents the content structure for tool_use blocks
+// ToolUseContent represents the content structure for tool_use, web_search_use and server_tool_use blocks
 type ToolUseContent struct {
 	ToolUseID string                 `json:"tool_use_id"`
 	ToolName  string                 `json:"tool_name"`
 	Input     map[string]interface{} `json:"input"`
 }
 
-// ToolResultContent represents the content structure for tool_result blocks
+// ToolResultContent represents the content structure for tool_result blocks.
+// Exactly one of Result (success) or Error (IsError) is set.
 type ToolResultContent struct {
-	ToolUseID string `json:"tool_use_id"`
-	IsError   bool   `json:"is_error"`
+	ToolUseID    string                  `json:"tool_use_id"`
+	ToolName     string                  `json:"tool_name,omitempty"`
+	IsError      bool                    `json:"is_error"`
+	Result       interface{}             `json:"result,omitempty"` // String or structured tool output
+	Error        string                  `json:"error,omitempty"`
+	Attempts     int                     `json:"attempts,omitempty"`
+	Truncated    bool                    `json:"truncated,omitempty"`
+	Truncation   *ToolResultTruncation   `json:"truncation,omitempty"`
+	Sanitized    bool                    `json:"sanitized,omitempty"`
+	Sanitization *ToolResultSanitization `json:"sanitization,omitempty"`
+}
+
+// ToolResultTruncation records how an oversized tool result was cut down
+type ToolResultTruncation struct {
+	OriginalTokens int `json:"original_tokens"`
+	BudgetTokens   int `json:"budget_tokens"`
+}
+
+// ToolResultSanitization records prompt injection patterns stripped from a tool result
+type ToolResultSanitization struct {
+	Level   string         `json:"level"`
+	Matches map[string]int `json:"matches"` // Rule name → occurrences removed
+}
+
+// WebSearchResultContent represents the content structure for web_search_result blocks
+// (success: Results; failure: IsError with ErrorCode)
+type WebSearchResultContent struct {
+	ToolUseID string            `json:"tool_use_id,omitempty"`
+	Results   []WebSearchResult `json:"results,omitempty"`
+	IsError   bool              `json:"is_error,omitempty"`
+	ErrorCode string            `json:"error_code,omitempty"`
+}
+
+// WebSearchResult is one search hit in a web_search_result block
+type WebSearchResult struct {
+	Title   string  `json:"title,omitempty"`
+	URL     string  `json:"url"`
+	PageAge *string `json:"page_age,omitempty"`
+	Content string  `json:"content,omitempty"`
+}
+
+// ServerToolResultContent represents the content structure for server_tool_result blocks.
+// The result itself stays in provider_data (provider-specific, often encrypted);
+// content only links it back to its server_tool_use block.
+type ServerToolResultContent struct {
+	ToolUseID  string `json:"tool_use_id"`
+	ResultType string `json:"result_type,omitempty"` // Provider block type, e.g. "web_fetch_tool_result"
 }
 
 // ThinkingContent represents the content structure for thinking blocks (optional signature)
@@ -43,135 +113,235 @@ type ThinkingContent struct {
 	Signature *string `json:"signature,omitempty"`
 }
 
+// contentSchema describes one block type's content
+type contentSchema struct {
+	required bool                         // Content may not be null
+	new      func() BlockContent          // Typed content (nil = content must be null)
+	upgrade  func(map[string]interface{}) // Converts legacy (unversioned) content in place
+}
+
+// contentSchemas is the block content schema registry
+var contentSchemas = map[string]contentSchema{
+	BlockTypeText:             {},
+	BlockTypeThinking:         {new: func() BlockContent { return &ThinkingContent{} }},
+	BlockTypeRedactedThinking: {},
+	BlockTypeToolUse:          {required: true, new: func() BlockContent { return &ToolUseContent{} }, upgrade: upgradeToolUseContent},
+	BlockTypeWebSearch:        {required: true, new: func() BlockContent { return &ToolUseContent{} }, upgrade: upgradeToolUseContent},
+	BlockTypeToolResult:       {required: true, new: func() BlockContent { return &ToolResultContent{} }, upgrade: upgradeToolResultContent},
+	BlockTypeWebSearchResult:  {required: true, new: func() BlockContent { return &WebSearchResultContent{} }},
+	BlockTypeServerToolUse:    {required: true, new: func() BlockContent { return &ToolUseContent{} }, upgrade: upgradeToolUseContent},
+	BlockTypeServerToolResult: {required: true, new: func() BlockContent { return &ServerToolResultContent{} }},
+	BlockTypeImage:            {required: true, new: func() BlockContent { return &ImageContent{} }},
+	BlockTypeReference:        {required: true, new: func() BlockContent { return &ReferenceContent{} }},
+	BlockTypePartialReference: {required: true, new: func() BlockContent { return &ReferenceContent{} }},
+}
+
 // ValidateContent validates the content map against the expected schema for the given block type
 // Returns an error if the content is invalid
 func ValidateContent(blockType string, content map[string]interface{}) error {
+	schema, ok := contentSchemas[blockType]
+	if !ok {
+		return fmt.Errorf("unknown block type: %s", blockType)
+	}
 	if content == nil {
-		// null content is only valid for text blocks
-		if blockType == BlockTypeText {
-			return nil
-		}
-		// For other types, check if content is required
-		switch blockType {
-		case BlockTypeThinking:
-			// Thinking can have null content (signature is optional)
-			return nil
-		case BlockTypeToolUse, BlockTypeToolResult, BlockTypeImage, BlockTypeReference, BlockTypePartialReference:
+		if schema.required {
 			return fmt.Errorf("%s block requires content", blockType)
-		default:
-			return fmt.Errorf("unknown block type: %s", blockType)
 		}
+		return nil
 	}
-
-	switch blockType {
-	case BlockTypeText:
-		// Text blocks should have null content
+	if schema.new == nil {
+		// Text blocks keep their text in text_content (redacted thinking in provider_data); content is ignored
 		return nil
+	}
 
-	case BlockTypeThinking:
-		return validateThinkingContent(content)
+	typed := schema.new()
+	if err := mapToStruct(UpgradeContent(blockType, content), typed); err != nil {
+		return fmt.Errorf("invalid %s content structure: %w", blockType, err)
+	}
+	return typed.Validate()
+}
 
-	case BlockTypeToolUse:
-		return validateToolUseContent(content)
+// DecodeContent reads a block's content into its typed struct (upgrading legacy
+// content first) and validates it
+func DecodeContent(block *TurnBlock, target BlockContent) error {
+	if block.Content == nil {
+		return fmt.Errorf("%s block has no content", block.BlockType)
+	}
+	if err := mapToStruct(UpgradeContent(block.BlockType, block.Content), target); err != nil {
+		return fmt.Errorf("invalid %s content structure: %w", block.BlockType, err)
+	}
+	if err := target.Validate(); err != nil {
+		return fmt.Errorf("invalid %s content: %w", block.BlockType, err)
+	}
+	return nil
+}
 
-	case BlockTypeToolResult:
-		return validateToolResultContent(content)
+// EncodeContent validates typed content and converts it to the stored map,
+// stamped with the current schema version
+func EncodeContent(content BlockContent) (map[string]interface{}, error) {
+	if err := content.Validate(); err != nil {
+		return nil, err
+	}
+	jsonBytes, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal content: %w", err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(jsonBytes, &m); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal content: %w", err)
+	}
+	m[ContentSchemaVersionKey] = BlockContentSchemaVersion
+	return m, nil
+}
 
-	case BlockTypeImage:
-		return validateImageContent(content)
+// UpgradeContent is the compatibility reader: it returns content in the current schema
+// version, converting legacy shapes. Current content is returned as is; legacy content
+// is copied (the input is not modified). Unknown block types and null content pass through.
+func UpgradeContent(blockType string, content map[string]interface{}) map[string]interface{} {
+	if content == nil || ContentSchemaVersion(content) >= BlockContentSchemaVersion {
+		return content
+	}
+	schema, ok := contentSchemas[blockType]
+	if !ok || schema.new == nil {
+		return content
+	}
 
-	case BlockTypeReference, BlockTypePartialReference:
-		return validateReferenceContent(content)
+	upgraded := maps.Clone(content)
+	if schema.upgrade != nil {
+		schema.upgrade(upgraded)
+	}
+	upgraded[ContentSchemaVersionKey] = BlockContentSchemaVersion
+	return upgraded
+}
 
+// ContentSchemaVersion returns the schema version content was written with (0 = legacy)
+func ContentSchemaVersion(content map[string]interface{}) int {
+	switch v := content[ContentSchemaVersionKey].(type) {
+	case int:
+		return v
+	case float64: // Decoded from JSON
+		return int(v)
 	default:
-		return fmt.Errorf("unknown block type: %s", blockType)
+		return 0
+	}
+}
+
+// upgradeToolUseContent converts legacy tool_use content: delta-style "id"/"name" keys,
+// and input stored as a JSON string (unparsed accumulated deltas) or missing
+func upgradeToolUseContent(content map[string]interface{}) {
+	renameContentKey(content, "id", "tool_use_id")
+	renameContentKey(content, "name", "tool_name")
+
+	switch input := content["input"].(type) {
+	case nil:
+		content["input"] = map[string]interface{}{}
+	case string:
+		var parsed map[string]interface{}
+		if input == "" {
+			parsed = map[string]interface{}{}
+		} else if err := json.Unmarshal([]byte(input), &parsed); err != nil || parsed == nil {
+			// Keep unparseable input visible to the model rather than dropping it
+			parsed = map[string]interface{}{"raw": input}
+		}
+		content["input"] = parsed
 	}
 }
 
-// validateThinkingContent validates thinking block content
-func validateThinkingContent(content map[string]interface{}) error {
-	// Thinking content is optional (just signature field)
-	// No required fields, so just check it can be marshaled to the struct
-	var thinking ThinkingContent
-	return mapToStruct(content, &thinking)
+// upgradeToolResultContent converts legacy tool_result content: "id" key, and
+// errors stored without the is_error flag
+func upgradeToolResultContent(content map[string]interface{}) {
+	renameContentKey(content, "id", "tool_use_id")
+	if _, hasFlag := content["is_error"]; !hasFlag {
+		_, hasError := content["error"]
+		content["is_error"] = hasError
+	}
 }
 
-// validateToolUseContent validates tool_use block content
-func validateToolUseContent(content map[string]interface{}) error {
-	var toolUse ToolUseContent
-	if err := mapToStruct(content, &toolUse); err != nil {
-		return fmt.Errorf("invalid tool_use content structure: %w", err)
+// renameContentKey moves a legacy key to its current name unless the current key is set
+func renameContentKey(content map[string]interface{}, legacy, current string) {
+	value, ok := content[legacy]
+	if !ok {
+		return
 	}
+	if _, exists := content[current]; !exists {
+		content[current] = value
+	}
+	delete(content, legacy)
+}
 
-	// Validate required fields
-	if toolUse.ToolUseID == "" {
+// Validate checks thinking block content (no required fields)
+func (c *ThinkingContent) Validate() error {
+	return nil
+}
+
+// Validate checks tool_use block content
+func (c *ToolUseContent) Validate() error {
+	if c.ToolUseID == "" {
 		return fmt.Errorf("tool_use_id is required")
 	}
-	if toolUse.ToolName == "" {
+	if c.ToolName == "" {
 		return fmt.Errorf("tool_name is required")
 	}
-	if toolUse.Input == nil {
+	if c.Input == nil {
 		return fmt.Errorf("input is required")
 	}
+	return nil
+}
 
+// Validate checks tool_result block content
+func (c *ToolResultContent) Validate() error {
+	if c.ToolUseID == "" {
+		return fmt.Errorf("tool_use_id is required")
+	}
 	return nil
 }
 
-// validateToolResultContent validates tool_result block content
-func validateToolResultContent(content map[string]interface{}) error {
-	var toolResult ToolResultContent
-	if err := mapToStruct(content, &toolResult); err != nil {
-		return fmt.Errorf("invalid tool_result content structure: %w", err)
+// Validate checks web_search_result block content
+func (c *WebSearchResultContent) Validate() error {
+	if c.IsError && c.ErrorCode == "" {
+		return fmt.Errorf("error_code is required for failed searches")
 	}
+	return nil
+}
 
-	// Validate required fields
-	if toolResult.ToolUseID == "" {
+// Validate checks server_tool_result block content
+func (c *ServerToolResultContent) Validate() error {
+	if c.ToolUseID == "" {
 		return fmt.Errorf("tool_use_id is required")
 	}
-
 	return nil
 }
 
-// validateImageContent validates image block content
-func validateImageContent(content map[string]interface{}) error {
-	var image ImageContent
-	if err := mapToStruct(content, &image); err != nil {
-		return fmt.Errorf("invalid image content structure: %w", err)
+// Validate checks image block content
+func (c *ImageContent) Validate() error {
+	if c.AttachmentID != "" {
+		return nil
 	}
-
-	// Validate required fields
-	if image.URL == "" {
+	if c.URL == "" {
 		return fmt.Errorf("url is required")
 	}
-	if image.MIMEType == "" {
+	if c.MIMEType == "" {
 		return fmt.Errorf("mime_type is required")
 	}
-
 	return nil
 }
 
-// validateReferenceContent validates reference/partial_reference block content
-func validateReferenceContent(content map[string]interface{}) error {
-	var ref ReferenceContent
-	if err := mapToStruct(content, &ref); err != nil {
-		return fmt.Errorf("invalid reference content structure: %w", err)
-	}
-
-	// Validate required fields
-	if ref.RefID == "" {
+// Validate checks reference/partial_reference block content
+func (c *ReferenceContent) Validate() error {
+	if c.RefID == "" {
 		return fmt.Errorf("ref_id is required")
 	}
-	if ref.RefType == "" {
+	if c.RefType == "" {
 		return fmt.Errorf("ref_type is required")
 	}
 
 	// Validate ref_type is one of allowed values
 	validRefTypes := map[string]bool{
-		"document":     true,
-		"image":        true,
-		"s3_document":  true,
+		"document":    true,
+		"image":       true,
+		"s3_document": true,
 	}
-	if !validRefTypes[ref.RefType] {
+	if !validRefTypes[c.RefType] {
 		return fmt.Errorf("ref_type must be one of: document, image, s3_document")
 	}
 
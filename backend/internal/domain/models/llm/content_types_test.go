@@ -0,0 +1,57 @@
+package llm
+
+import "testing"
+
+func TestDecodeContent_UpgradesLegacyToolUse(t *testing.T) {
+	legacy := map[string]interface{}{
+		"id":    "toolu_1",
+		"name":  "doc_view",
+		"input": `{"path": "Chapters/One"}`,
+	}
+	block := &TurnBlock{BlockType: BlockTypeToolUse, Content: legacy}
+
+	var toolUse ToolUseContent
+	if err := DecodeContent(block, &toolUse); err != nil {
+		t.Fatalf("DecodeContent: %v", err)
+	}
+	if toolUse.ToolUseID != "toolu_1" || toolUse.ToolName != "doc_view" || toolUse.Input["path"] != "Chapters/One" {
+		t.Errorf("decoded = %+v", toolUse)
+	}
+	if _, ok := legacy["tool_use_id"]; ok {
+		t.Error("UpgradeContent modified the stored map")
+	}
+}
+
+func TestEncodeContent_RoundTrip(t *testing.T) {
+	content, err := EncodeContent(&ToolResultContent{ToolUseID: "toolu_1", ToolName: "doc_view", Result: "", Attempts: 2})
+	if err != nil {
+		t.Fatalf("EncodeContent: %v", err)
+	}
+	if ContentSchemaVersion(content) != BlockContentSchemaVersion {
+		t.Errorf("schema_version = %v", content[ContentSchemaVersionKey])
+	}
+	if _, ok := content["result"]; !ok {
+		t.Error("empty string result was dropped")
+	}
+	if err := ValidateContent(BlockTypeToolResult, content); err != nil {
+		t.Errorf("ValidateContent: %v", err)
+	}
+
+	if _, err := EncodeContent(&ToolUseContent{ToolName: "doc_view", Input: map[string]interface{}{}}); err == nil {
+		t.Error("EncodeContent accepted tool_use content without tool_use_id")
+	}
+}
+
+func TestValidateContent_ProviderOpaqueBlocks(t *testing.T) {
+	if err := ValidateContent(BlockTypeRedactedThinking, nil); err != nil {
+		t.Errorf("redacted_thinking without content: %v", err)
+	}
+	if err := ValidateContent(BlockTypeServerToolResult, map[string]interface{}{"result_type": "web_fetch_tool_result"}); err == nil {
+		t.Error("accepted server_tool_result without tool_use_id")
+	}
+
+	legacy := map[string]interface{}{"id": "srvtoolu_1", "name": "web_fetch", "input": `{"url": "https://example.com"}`}
+	if err := ValidateContent(BlockTypeServerToolUse, legacy); err != nil {
+		t.Errorf("legacy server_tool_use: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Default conversation recall configuration values
+const (
+	DefaultRecallLimit = 5
+	MaxRecallLimit     = 20
+)
+
+// ConversationRecallOptions configures a search of a project's past conversations
+// (the conversation_recall tool). Like TurnSearchOptions, only text blocks of
+// completed turns are searched; the chat the model is answering in is excluded.
+type ConversationRecallOptions struct {
+	// ProjectID limits the search to chats in this project (required)
+	ProjectID string
+
+	// UserID limits the search to this user's chats (required)
+	UserID string
+
+	// ExcludeChatID skips a chat, normally the current one (optional)
+	ExcludeChatID string
+
+	// Query is the search string (required), websearch syntax (quotes, OR, -term)
+	Query string
+
+	// Limit is the maximum number of turns to return (default: 5, max: 20)
+	Limit int
+
+	// Language is the FTS configuration used for stemming (default: "english")
+	Language string
+}
+
+// ApplyDefaults fills in default values for unset fields
+func (opts *ConversationRecallOptions) ApplyDefaults() {
+	opts.Query = strings.TrimSpace(opts.Query)
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultRecallLimit
+	}
+	if opts.Language == "" {
+		opts.Language = DefaultTurnSearchLanguage
+	}
+}
+
+// Validate checks that required fields are set and values are reasonable
+func (opts *ConversationRecallOptions) Validate() error {
+	if opts.ProjectID == "" {
+		return fmt.Errorf("project ID is required")
+	}
+	if opts.UserID == "" {
+		return fmt.Errorf("user ID is required")
+	}
+	if opts.Query == "" {
+		return fmt.Errorf("search query cannot be empty")
+	}
+	if opts.Limit > MaxRecallLimit {
+		return fmt.Errorf("limit cannot exceed %d (requested: %d)", MaxRecallLimit, opts.Limit)
+	}
+	return nil
+}
+
+// RecalledTurn is a turn from a past conversation matching a recall query
+type RecalledTurn struct {
+	ChatID    string    `json:"chat_id"`
+	ChatTitle string    `json:"chat_title"`
+	TurnID    string    `json:"turn_id"`
+	Role      string    `json:"role"`
+	Excerpt   string    `json:"excerpt"` // ts_headline fragments around the matches
+	Score     float64   `json:"score"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,99 @@
+package llm
+
+import "time"
+
+// ContentPolicyVersion is the content policy version users acknowledge in their data
+// controls. Bump it when the policy text changes so users are asked again.
+const ContentPolicyVersion = "2026-10-16"
+
+// Data categories a provider integration can transmit
+const (
+	DataCategoryMessages        = "chat_messages"    // Prompts and conversation history
+	DataCategoryInstructions    = "instructions"     // System instructions, project/chat prompts and selected skills
+	DataCategoryDocuments       = "document_content" // Documents read by tools, editor selections and project context
+	DataCategoryToolResults     = "tool_results"     // Tool outputs, including web search results
+	DataCategoryToolDefinitions = "tool_definitions" // Names and schemas of the tools offered to the model
+	DataCategoryImages          = "images"           // Images attached to messages (vision models only)
+)
+
+// ProviderDataDisclosure describes what a provider integration sends off the server
+type ProviderDataDisclosure struct {
+	Provider       string   `json:"provider"`
+	Name           string   `json:"name"`
+	Destination    string   `json:"destination"` // Where content is sent
+	DataCategories []string `json:"data_categories"`
+	Notes          string   `json:"notes,omitempty"`
+	Allowed        bool     `json:"allowed"` // Whether the user's data controls allow the provider
+}
+
+// DataControlsStatus is a user's data controls with the provider disclosures
+type DataControlsStatus struct {
+	PolicyVersion             string                   `json:"policy_version"`
+	PolicyAcknowledged        bool                     `json:"policy_acknowledged"` // The current version was acknowledged
+	PolicyAcknowledgedVersion *string                  `json:"policy_acknowledged_version"`
+	PolicyAcknowledgedAt      *time.Time               `json:"policy_acknowledged_at"`
+	BlockedProviders          []string                 `json:"blocked_providers"`
+	Providers                 []ProviderDataDisclosure `json:"providers"`
+}
+
+// allDataCategories is what every chat model request can carry
+var allDataCategories = []string{
+	DataCategoryMessages,
+	DataCategoryInstructions,
+	DataCategoryDocuments,
+	DataCategoryToolResults,
+	DataCategoryToolDefinitions,
+}
+
+// ProviderDisclosures lists the provider integrations that send user content off the
+// server (the lorem mock provider sends nothing). Allowed is true in the returned list.
+func ProviderDisclosures() []ProviderDataDisclosure {
+	disclosures := []ProviderDataDisclosure{
+		{
+			Provider:    "anthropic",
+			Name:        "Anthropic",
+			Destination: "Anthropic API (api.anthropic.com)",
+		},
+		{
+			Provider:    "openai",
+			Name:        "OpenAI",
+			Destination: "OpenAI API (api.openai.com, or the server's OPENAI_BASE_URL)",
+			Notes:       "Responses API requests are sent with store disabled",
+		},
+		{
+			Provider:    "google",
+			Name:        "Google Gemini",
+			Destination: "Gemini API (generativelanguage.googleapis.com)",
+		},
+		{
+			Provider:    "ollama",
+			Name:        "Ollama",
+			Destination: "The server's Ollama instance (OLLAMA_BASE_URL)",
+			Notes:       "Self-hosted: content goes only to the machine running Ollama",
+		},
+		{
+			Provider:    "openrouter",
+			Name:        "OpenRouter",
+			Destination: "OpenRouter (openrouter.ai)",
+			Notes:       "OpenRouter forwards requests to the model's upstream provider",
+		},
+	}
+	for i := range disclosures {
+		disclosures[i].DataCategories = append([]string(nil), allDataCategories...)
+		if ProviderSendsImages(disclosures[i].Provider) {
+			disclosures[i].DataCategories = append(disclosures[i].DataCategories, DataCategoryImages)
+		}
+		disclosures[i].Allowed = true
+	}
+	return disclosures
+}
+
+// IsDisclosedProvider reports whether provider is listed by ProviderDisclosures
+func IsDisclosedProvider(provider string) bool {
+	for _, d := range ProviderDisclosures() {
+		if d.Provider == provider {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package llm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Eval criterion types: how a case's output is checked
+const (
+	EvalCriterionJudge    = "judge"     // An LLM judge decides whether the output meets the value (a rubric)
+	EvalCriterionRegex    = "regex"     // The output must match the value (RE2 pattern)
+	EvalCriterionNotRegex = "not_regex" // The output must not match the value (RE2 pattern)
+)
+
+// EvalCase is a project's test case for prompts and skills: a prompt and the criteria
+// its response must meet
+type EvalCase struct {
+	ID        string          `json:"id"`
+	ProjectID string          `json:"project_id"`
+	Name      string          `json:"name"`
+	Prompt    string          `json:"prompt"`
+	Criteria  []EvalCriterion `json:"criteria"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// EvalCriterion is one check of a case's output
+type EvalCriterion struct {
+	Type  string `json:"type"`  // "judge", "regex" or "not_regex"
+	Value string `json:"value"` // Rubric for the judge, pattern for the regex checks
+}
+
+// Validate checks the criterion's type and that its pattern compiles
+func (c EvalCriterion) Validate() error {
+	if strings.TrimSpace(c.Value) == "" {
+		return fmt.Errorf("value is required")
+	}
+	switch c.Type {
+	case EvalCriterionJudge:
+	case EvalCriterionRegex, EvalCriterionNotRegex:
+		if _, err := regexp.Compile(c.Value); err != nil {
+			return fmt.Errorf("invalid pattern: %v", err)
+		}
+	default:
+		return fmt.Errorf("type must be 'judge', 'regex' or 'not_regex'")
+	}
+	return nil
+}
+
+// EvalRun runs a project's cases against one or more models with a selection of skills.
+// It's executed by the background job queue; its status is the job's.
+type EvalRun struct {
+	ID         string             `json:"id"`
+	ProjectID  string             `json:"project_id"`
+	UserID     string             `json:"user_id"`
+	JobID      *string            `json:"job_id,omitempty"`
+	Label      string             `json:"label"` // What's being validated, e.g. "tighter outline skill"
+	Models     []string           `json:"models"`
+	Skills     []string           `json:"skills"`
+	CaseIDs    []string           `json:"case_ids"`
+	JudgeModel string             `json:"judge_model"`
+	Status     string             `json:"status"`          // The job's: "pending", "running", "completed" or "failed"
+	Error      *string            `json:"error,omitempty"` // Why the run failed
+	Summary    []EvalModelSummary `json:"summary"`         // Per model, set once completed
+	CreatedAt  time.Time          `json:"created_at"`
+	Results    []EvalResult       `json:"results,omitempty"` // Set by GET /api/projects/{id}/evals/runs/{runId}
+}
+
+// EvalModelSummary aggregates a run's results for one model
+type EvalModelSummary struct {
+	Model        string  `json:"model"`
+	Cases        int     `json:"cases"`
+	Passed       int     `json:"passed"`
+	Errors       int     `json:"errors"`    // Cases whose generation failed
+	PassRate     float64 `json:"pass_rate"` // passed / cases
+	AvgScore     float64 `json:"avg_score"` // Mean share of criteria met
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+}
+
+// EvalResult is one case's output from one model in a run, with its checks
+type EvalResult struct {
+	ID           string          `json:"id"`
+	RunID        string          `json:"run_id"`
+	CaseID       string          `json:"case_id"`
+	CaseName     string          `json:"case_name"`
+	Model        string          `json:"model"`
+	Output       string          `json:"output"`
+	Passed       bool            `json:"passed"` // Every criterion was met
+	Score        float64         `json:"score"`  // Share of criteria met (0-1)
+	Assertions   []EvalAssertion `json:"assertions"`
+	InputTokens  int             `json:"input_tokens"`
+	OutputTokens int             `json:"output_tokens"`
+	Error        *string         `json:"error,omitempty"` // Why the output couldn't be generated
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// EvalAssertion is the outcome of one criterion
+type EvalAssertion struct {
+	Type   string `json:"type"`
+	Value  string `json:"value"`
+	Passed bool   `json:"passed"`
+	Reason string `json:"reason,omitempty"` // The judge's explanation, or why the check failed
+}
+
+// SummarizeEvalResults aggregates results per model, in the order models are given
+func SummarizeEvalResults(models []string, results []EvalResult) []EvalModelSummary {
+	summaries := make([]EvalModelSummary, len(models))
+	index := make(map[string]int, len(models))
+	for i, model := range models {
+		summaries[i].Model = model
+		index[model] = i
+	}
+
+	scores := make([]float64, len(models))
+	for _, result := range results {
+		i, ok := index[result.Model]
+		if !ok {
+			continue
+		}
+		summary := &summaries[i]
+		summary.Cases++
+		summary.InputTokens += result.InputTokens
+		summary.OutputTokens += result.OutputTokens
+		scores[i] += result.Score
+		if result.Error != nil {
+			summary.Errors++
+		} else if result.Passed {
+			summary.Passed++
+		}
+	}
+
+	for i := range summaries {
+		if summaries[i].Cases > 0 {
+			summaries[i].PassRate = float64(summaries[i].Passed) / float64(summaries[i].Cases)
+			summaries[i].AvgScore = scores[i] / float64(summaries[i].Cases)
+		}
+	}
+	return summaries
+}
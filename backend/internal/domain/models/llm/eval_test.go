@@ -0,0 +1,51 @@
+package llm
+
+import "testing"
+
+func TestEvalCriterion_Validate(t *testing.T) {
+	valid := []EvalCriterion{
+		{Type: EvalCriterionJudge, Value: "Stays in the first person"},
+		{Type: EvalCriterionRegex, Value: `(?i)chapter \d+`},
+		{Type: EvalCriterionNotRegex, Value: "As an AI"},
+	}
+	for _, c := range valid {
+		if err := c.Validate(); err != nil {
+			t.Errorf("%+v: unexpected error %v", c, err)
+		}
+	}
+
+	invalid := []EvalCriterion{
+		{Type: EvalCriterionJudge, Value: "  "},
+		{Type: EvalCriterionRegex, Value: "(unclosed"},
+		{Type: "contains", Value: "dragon"},
+	}
+	for _, c := range invalid {
+		if err := c.Validate(); err == nil {
+			t.Errorf("%+v: expected an error", c)
+		}
+	}
+}
+
+func TestSummarizeEvalResults(t *testing.T) {
+	failure := "provider unavailable"
+	results := []EvalResult{
+		{Model: "model-a", Passed: true, Score: 1, InputTokens: 100, OutputTokens: 50},
+		{Model: "model-a", Passed: false, Score: 0.5, InputTokens: 120, OutputTokens: 40},
+		{Model: "model-b", Error: &failure},
+		{Model: "model-c", Passed: true, Score: 1}, // Not in the run's models
+	}
+
+	summaries := SummarizeEvalResults([]string{"model-a", "model-b"}, results)
+	if len(summaries) != 2 {
+		t.Fatalf("got %d summaries, want 2", len(summaries))
+	}
+
+	a := summaries[0]
+	if a.Model != "model-a" || a.Cases != 2 || a.Passed != 1 || a.PassRate != 0.5 || a.AvgScore != 0.75 || a.InputTokens != 220 || a.OutputTokens != 90 {
+		t.Errorf("unexpected model-a summary: %+v", a)
+	}
+	b := summaries[1]
+	if b.Cases != 1 || b.Errors != 1 || b.Passed != 0 || b.PassRate != 0 {
+		t.Errorf("unexpected model-b summary: %+v", b)
+	}
+}
@@ -0,0 +1,131 @@
+package llm
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+)
+
+// Experiment statuses
+const (
+	ExperimentActive  = "active"  // Assigns new assistant turns
+	ExperimentStopped = "stopped" // Keeps its recorded turns, assigns nothing
+)
+
+// Experiment units: what is assigned a variant
+const (
+	ExperimentUnitUser = "user" // Every chat of a user gets the same variant
+	ExperimentUnitChat = "chat" // Each chat gets its own variant
+)
+
+// Turn feedback ratings (PUT /api/turns/{id}/feedback)
+const (
+	FeedbackUp   = 1
+	FeedbackDown = -1
+)
+
+// Experiment compares variants of the system prompt and default request params. Users or
+// chats are assigned a variant deterministically from a hash, so an assignment never
+// changes while the experiment's variants stay the same.
+type Experiment struct {
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Unit        string              `json:"unit"`   // "user" or "chat"
+	Status      string              `json:"status"` // "active" or "stopped"
+	Variants    []ExperimentVariant `json:"variants"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// ExperimentVariant is one arm of an experiment. Its request params are defaults: values
+// the client sends still win. Its system prompt is appended to the resolved one.
+type ExperimentVariant struct {
+	Name          string         `json:"name"`
+	Weight        int            `json:"weight"` // Relative share of units assigned to the variant
+	SystemPrompt  *string        `json:"system_prompt,omitempty"`
+	RequestParams *RequestParams `json:"request_params,omitempty"`
+}
+
+// ExperimentAssignment is the variant an experiment chose for an assistant turn
+type ExperimentAssignment struct {
+	ExperimentID string            `json:"experiment_id"`
+	Variant      ExperimentVariant `json:"variant"`
+}
+
+// ExperimentVariantResult aggregates the assistant turns recorded for one variant
+type ExperimentVariantResult struct {
+	Variant       string  `json:"variant"`
+	Turns         int     `json:"turns"`
+	ErrorTurns    int     `json:"error_turns"`
+	InputTokens   int64   `json:"input_tokens"`
+	OutputTokens  int64   `json:"output_tokens"`
+	CostUSD       float64 `json:"cost_usd"`
+	FeedbackUp    int     `json:"feedback_up"`
+	FeedbackDown  int     `json:"feedback_down"`
+	FeedbackScore float64 `json:"feedback_score"` // (up - down) / rated turns, 0 when none are rated
+}
+
+// ExperimentResults is the response of GET /admin/api/experiments/{id}/results
+type ExperimentResults struct {
+	Experiment *Experiment               `json:"experiment"`
+	Variants   []ExperimentVariantResult `json:"variants"`
+}
+
+// Validate checks the unit, status and variants
+func (e *Experiment) Validate() error {
+	if e.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	switch e.Unit {
+	case ExperimentUnitUser, ExperimentUnitChat:
+	default:
+		return fmt.Errorf("unit must be %q or %q", ExperimentUnitUser, ExperimentUnitChat)
+	}
+	switch e.Status {
+	case ExperimentActive, ExperimentStopped:
+	default:
+		return fmt.Errorf("status must be %q or %q", ExperimentActive, ExperimentStopped)
+	}
+	if len(e.Variants) < 2 {
+		return fmt.Errorf("at least two variants are required")
+	}
+	names := make(map[string]bool, len(e.Variants))
+	for i, variant := range e.Variants {
+		if variant.Name == "" {
+			return fmt.Errorf("variants[%d]: name is required", i)
+		}
+		if names[variant.Name] {
+			return fmt.Errorf("variants[%d]: duplicate name %q", i, variant.Name)
+		}
+		names[variant.Name] = true
+		if variant.Weight <= 0 {
+			return fmt.Errorf("variants[%d]: weight must be positive", i)
+		}
+		if err := variant.RequestParams.Validate(); err != nil {
+			return fmt.Errorf("variants[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Assign picks the variant of a unit (user or chat ID). The hash covers the experiment
+// ID, so different experiments split the same users independently.
+func (e *Experiment) Assign(unitID string) ExperimentVariant {
+	total := 0
+	for _, variant := range e.Variants {
+		total += variant.Weight
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(e.ID + ":" + unitID))
+	point := int(h.Sum64() % uint64(total))
+
+	for _, variant := range e.Variants {
+		if point < variant.Weight {
+			return variant
+		}
+		point -= variant.Weight
+	}
+	return e.Variants[len(e.Variants)-1]
+}
@@ -0,0 +1,86 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestExperimentValidate(t *testing.T) {
+	badTemperature := 3.0
+	valid := func() *Experiment {
+		return &Experiment{
+			Name:   "concise-prompt",
+			Unit:   ExperimentUnitUser,
+			Status: ExperimentActive,
+			Variants: []ExperimentVariant{
+				{Name: "control", Weight: 1},
+				{Name: "concise", Weight: 1},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(e *Experiment)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(e *Experiment) {}},
+		{name: "missing name", mutate: func(e *Experiment) { e.Name = "" }, wantErr: "name is required"},
+		{name: "bad unit", mutate: func(e *Experiment) { e.Unit = "project" }, wantErr: "unit must be"},
+		{name: "bad status", mutate: func(e *Experiment) { e.Status = "paused" }, wantErr: "status must be"},
+		{name: "one variant", mutate: func(e *Experiment) { e.Variants = e.Variants[:1] }, wantErr: "at least two variants"},
+		{name: "duplicate variant", mutate: func(e *Experiment) { e.Variants[1].Name = "control" }, wantErr: "duplicate name"},
+		{name: "zero weight", mutate: func(e *Experiment) { e.Variants[1].Weight = 0 }, wantErr: "weight must be positive"},
+		{
+			name:    "invalid params",
+			mutate:  func(e *Experiment) { e.Variants[1].RequestParams = &RequestParams{Temperature: &badTemperature} },
+			wantErr: "variants[1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			experiment := valid()
+			tt.mutate(experiment)
+			err := experiment.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExperimentAssign(t *testing.T) {
+	experiment := &Experiment{
+		ID: "experiment-1",
+		Variants: []ExperimentVariant{
+			{Name: "control", Weight: 3},
+			{Name: "treatment", Weight: 1},
+		},
+	}
+
+	counts := map[string]int{}
+	for i := 0; i < 4000; i++ {
+		unitID := fmt.Sprintf("user-%d", i)
+		variant := experiment.Assign(unitID)
+		if again := experiment.Assign(unitID); again.Name != variant.Name {
+			t.Fatalf("Assign(%q) = %q then %q, want stable", unitID, variant.Name, again.Name)
+		}
+		counts[variant.Name]++
+	}
+
+	// 3:1 weights, with generous slack for the hash
+	if counts["control"] < 2700 || counts["control"] > 3300 {
+		t.Errorf("control assigned %d of 4000 units, want about 3000", counts["control"])
+	}
+	if counts["control"]+counts["treatment"] != 4000 {
+		t.Errorf("counts = %v, want every unit assigned", counts)
+	}
+}
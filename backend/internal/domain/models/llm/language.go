@@ -0,0 +1,173 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// minDetectionLetters is the fewest letters DetectLanguage decides on; shorter text
+// ("ok", "thanks!") is undetermined
+const minDetectionLetters = 12
+
+// NormalizeLanguage validates a BCP 47 language tag and returns its canonical form
+// ("pt-br" becomes "pt-BR"). Turn languages, response languages and translation targets
+// all go through it, so the same language is always stored and compared the same way.
+func NormalizeLanguage(tag string) (string, error) {
+	parsed, err := language.Parse(strings.TrimSpace(tag))
+	if err != nil || parsed == language.Und {
+		return "", fmt.Errorf("invalid BCP 47 language tag %q", tag)
+	}
+	return parsed.String(), nil
+}
+
+// LanguageName returns the English name of a language tag for prompts
+// ("fr" is "French", "pt-BR" is "Brazilian Portuguese"), or the tag itself if unknown
+func LanguageName(tag string) string {
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return tag
+	}
+	if name := display.English.Tags().Name(parsed); name != "" {
+		return name
+	}
+	return tag
+}
+
+// scriptLanguages maps scripts that (mostly) identify a language on their own.
+// Han is resolved separately: kana alongside it means Japanese.
+var scriptLanguages = []struct {
+	script   *unicode.RangeTable
+	language string
+}{
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Hangul, "ko"},
+	{unicode.Han, "zh"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Greek, "el"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Thai, "th"},
+	{unicode.Devanagari, "hi"},
+}
+
+// stopwords are frequent function words of the Latin-script languages DetectLanguage
+// tells apart. Words shared between languages count for each of them.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "in", "that", "it", "was", "for", "with", "he", "she", "you", "i", "this", "but", "not", "are", "what"},
+	"es": {"el", "la", "de", "que", "y", "en", "los", "las", "un", "una", "por", "con", "no", "es", "se", "lo", "del", "pero", "su", "como"},
+	"fr": {"le", "la", "les", "de", "et", "un", "une", "est", "que", "qui", "en", "des", "du", "il", "elle", "pas", "ne", "je", "dans", "pour"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ein", "eine", "zu", "ich", "sie", "er", "es", "mit", "den", "von", "auf", "dem", "sich", "auch"},
+	"it": {"il", "la", "di", "che", "e", "un", "una", "non", "per", "del", "della", "sono", "è", "lo", "gli", "ha", "con", "ma", "si", "le"},
+	"pt": {"o", "a", "os", "as", "de", "que", "e", "um", "uma", "não", "do", "da", "em", "para", "com", "se", "é", "no", "na", "mas"},
+	"nl": {"de", "het", "een", "en", "van", "is", "niet", "dat", "ik", "je", "zijn", "op", "te", "met", "voor", "die", "er", "maar", "ze", "wat"},
+	"pl": {"i", "w", "nie", "na", "się", "z", "to", "że", "jest", "do", "jak", "co", "ale", "o", "jej", "go", "tak", "już", "od", "po"},
+	"sv": {"och", "att", "det", "som", "en", "är", "på", "i", "av", "för", "med", "inte", "jag", "han", "hon", "den", "till", "har", "var", "men"},
+}
+
+// stopwordSets indexes stopwords by language
+var stopwordSets = func() map[string]map[string]bool {
+	sets := make(map[string]map[string]bool, len(stopwords))
+	for lang, words := range stopwords {
+		set := make(map[string]bool, len(words))
+		for _, word := range words {
+			set[word] = true
+		}
+		sets[lang] = set
+	}
+	return sets
+}()
+
+// DetectLanguage guesses the language of a text and returns its ISO 639-1 code, or ""
+// when the text is too short or too mixed to tell. A dominant non-Latin script decides on
+// its own; Latin-script text is scored on common function words.
+// It's a cheap local heuristic (no provider call), good enough to label turns and pick a
+// translation's source language, not to identify a language from a few words.
+func DetectLanguage(text string) string {
+	letters, latin := 0, 0
+	scripts := make([]int, len(scriptLanguages))
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.Is(unicode.Latin, r) {
+			latin++
+			continue
+		}
+		for i, sl := range scriptLanguages {
+			if unicode.Is(sl.script, r) {
+				scripts[i]++
+				break
+			}
+		}
+	}
+	if letters < minDetectionLetters {
+		return ""
+	}
+
+	if latin*2 < letters {
+		return detectByScript(text, scripts, letters)
+	}
+	return detectByStopwords(text)
+}
+
+// detectByScript picks the language of the dominant non-Latin script
+func detectByScript(text string, scripts []int, letters int) string {
+	kana := 0
+	best := -1
+	for i, sl := range scriptLanguages {
+		if sl.language == "ja" {
+			kana += scripts[i]
+		}
+		if best < 0 || scripts[i] > scripts[best] {
+			best = i
+		}
+	}
+	// Japanese mixes kanji (Han) with kana; Chinese has no kana at all
+	if kana > 0 && (scriptLanguages[best].script == unicode.Han || kana*2 >= scripts[best]) {
+		return "ja"
+	}
+	if scripts[best]*2 < letters {
+		return ""
+	}
+	lang := scriptLanguages[best].language
+	if lang == "ru" && strings.ContainsAny(text, "іїєґІЇЄҐ") {
+		return "uk"
+	}
+	return lang
+}
+
+// detectByStopwords scores Latin-script text by how many of its words are each
+// language's function words. The best language needs a few hits and a clear lead.
+func detectByStopwords(text string) string {
+	scores := make(map[string]int, len(stopwordSets))
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	for _, word := range words {
+		for lang, set := range stopwordSets {
+			if set[word] {
+				scores[lang]++
+			}
+		}
+	}
+
+	best, bestScore, runnerUp := "", 0, 0
+	for lang, score := range scores {
+		switch {
+		case score > bestScore:
+			best, bestScore, runnerUp = lang, score, bestScore
+		case score > runnerUp:
+			runnerUp = score
+		}
+	}
+	if bestScore < 2 || bestScore == runnerUp {
+		return ""
+	}
+	return best
+}
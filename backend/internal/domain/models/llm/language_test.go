@@ -0,0 +1,66 @@
+package llm
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"english", "Can you tighten the pacing of the second chapter? It drags in the middle.", "en"},
+		{"spanish", "¿Puedes revisar el capítulo dos? La escena de la batalla es demasiado larga y no se entiende.", "es"},
+		{"french", "Peux-tu relire le chapitre deux ? La scène est trop longue et je ne sais pas pourquoi.", "fr"},
+		{"german", "Kannst du das zweite Kapitel kürzen? Die Szene ist zu lang und ich weiß nicht, wie es weitergeht.", "de"},
+		{"portuguese", "Você pode revisar o capítulo dois? A cena da batalha não funciona e é longa demais.", "pt"},
+		{"japanese", "第二章のペースを少し速くしてもらえますか？戦いの場面が長すぎます。", "ja"},
+		{"chinese", "你能帮我修改第二章吗？战斗场景太长了，读者会感到无聊。", "zh"},
+		{"korean", "두 번째 장의 속도를 조금 더 빠르게 해 줄 수 있나요? 전투 장면이 너무 깁니다.", "ko"},
+		{"russian", "Можешь сократить вторую главу? Сцена битвы слишком длинная.", "ru"},
+		{"ukrainian", "Чи можеш скоротити другий розділ? Сцена битви занадто довга і нудна.", "uk"},
+		{"too short", "Thanks!", ""},
+		{"no function words", "Aria, Borin, Cael: swords, shields, dragons.", ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectLanguage(tt.text); got != tt.want {
+				t.Errorf("DetectLanguage(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := map[string]string{
+		"fr":      "fr",
+		"pt-br":   "pt-BR",
+		" EN-gb ": "en-GB",
+		"zh-Hant": "zh-Hant",
+	}
+	for input, want := range tests {
+		got, err := NormalizeLanguage(input)
+		if err != nil || got != want {
+			t.Errorf("NormalizeLanguage(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	for _, input := range []string{"", "und", "not a tag", "french"} {
+		if _, err := NormalizeLanguage(input); err == nil {
+			t.Errorf("NormalizeLanguage(%q) accepted an invalid tag", input)
+		}
+	}
+}
+
+func TestLanguageName(t *testing.T) {
+	tests := map[string]string{
+		"fr":    "French",
+		"pt-BR": "Brazilian Portuguese",
+		"ja":    "Japanese",
+	}
+	for tag, want := range tests {
+		if got := LanguageName(tag); got != want {
+			t.Errorf("LanguageName(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
@@ -22,6 +22,7 @@ func GetProviderForModel(model string) (string, bool) {
 
 	// OpenAI models
 	if strings.HasPrefix(modelLower, "gpt-") || strings.HasPrefix(modelLower, "o1-") ||
+	   strings.HasPrefix(modelLower, "o3") || strings.HasPrefix(modelLower, "o4-") ||
 	   strings.HasPrefix(modelLower, "text-") || strings.HasPrefix(modelLower, "davinci-") {
 		return "openai", true
 	}
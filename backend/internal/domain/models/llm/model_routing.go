@@ -0,0 +1,58 @@
+package llm
+
+// AutoModel is the request_params model that lets the backend pick the model per turn:
+// a cheap model for simple prompts, a stronger one for long or complex ones
+const AutoModel = "auto"
+
+// Auto-routing tiers
+const (
+	ModelTierCheap  = "cheap"
+	ModelTierStrong = "strong"
+)
+
+// Reasons recorded with an auto-routing decision
+const (
+	RoutingReasonShortPrompt     = "short_prompt"
+	RoutingReasonLongPrompt      = "long_prompt"
+	RoutingReasonToolsRequested  = "tools_requested"
+	RoutingReasonThinkingEnabled = "thinking_enabled"
+	RoutingReasonTierNotAllowed  = "tier_not_allowed" // Chosen tier's model isn't in the user's allowlist
+)
+
+// ModelRouting records how an "auto" model was resolved for an assistant turn
+type ModelRouting struct {
+	Tier    string   `json:"tier"`    // "cheap" or "strong"
+	Model   string   `json:"model"`   // Model the turn was sent to
+	Reasons []string `json:"reasons"` // Signals that decided the tier
+}
+
+// ModelRoutingSignals are the features of a turn the auto router looks at
+type ModelRoutingSignals struct {
+	PromptChars     int  // Length of the user message text
+	ToolsRequested  bool // Any tools in the request params
+	ThinkingEnabled bool // Extended thinking requested
+}
+
+// IsAutoModel reports whether the params ask the backend to pick the model
+func (rp *RequestParams) IsAutoModel() bool {
+	return rp != nil && rp.Model != nil && *rp.Model == AutoModel
+}
+
+// RouteModelTier picks the tier for a turn: any sign of complexity (a prompt of at least
+// longPromptChars, tools, thinking) selects the strong tier, otherwise the cheap one.
+// A longPromptChars of 0 or less disables the length signal.
+func RouteModelTier(signals ModelRoutingSignals, longPromptChars int) (tier string, reasons []string) {
+	if longPromptChars > 0 && signals.PromptChars >= longPromptChars {
+		reasons = append(reasons, RoutingReasonLongPrompt)
+	}
+	if signals.ToolsRequested {
+		reasons = append(reasons, RoutingReasonToolsRequested)
+	}
+	if signals.ThinkingEnabled {
+		reasons = append(reasons, RoutingReasonThinkingEnabled)
+	}
+	if len(reasons) > 0 {
+		return ModelTierStrong, reasons
+	}
+	return ModelTierCheap, []string{RoutingReasonShortPrompt}
+}
@@ -0,0 +1,57 @@
+package llm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRouteModelTier(t *testing.T) {
+	tests := []struct {
+		name        string
+		signals     ModelRoutingSignals
+		longPrompt  int
+		wantTier    string
+		wantReasons []string
+	}{
+		{
+			name:        "short prompt",
+			signals:     ModelRoutingSignals{PromptChars: 80},
+			longPrompt:  2000,
+			wantTier:    ModelTierCheap,
+			wantReasons: []string{RoutingReasonShortPrompt},
+		},
+		{
+			name:        "long prompt",
+			signals:     ModelRoutingSignals{PromptChars: 2000},
+			longPrompt:  2000,
+			wantTier:    ModelTierStrong,
+			wantReasons: []string{RoutingReasonLongPrompt},
+		},
+		{
+			name:        "tools and thinking",
+			signals:     ModelRoutingSignals{PromptChars: 10, ToolsRequested: true, ThinkingEnabled: true},
+			longPrompt:  2000,
+			wantTier:    ModelTierStrong,
+			wantReasons: []string{RoutingReasonToolsRequested, RoutingReasonThinkingEnabled},
+		},
+		{
+			name:        "length signal disabled",
+			signals:     ModelRoutingSignals{PromptChars: 50000},
+			longPrompt:  0,
+			wantTier:    ModelTierCheap,
+			wantReasons: []string{RoutingReasonShortPrompt},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tier, reasons := RouteModelTier(tt.signals, tt.longPrompt)
+			if tier != tt.wantTier {
+				t.Errorf("tier = %q, want %q", tier, tt.wantTier)
+			}
+			if !reflect.DeepEqual(reasons, tt.wantReasons) {
+				t.Errorf("reasons = %v, want %v", reasons, tt.wantReasons)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package llm
+
+import "time"
+
+// UserProviderKey is a user's own API key for an LLM provider. The key itself is stored
+// encrypted and never returned; KeyHint (its last characters) identifies it.
+type UserProviderKey struct {
+	ID           string    `json:"id"`
+	UserID       string    `json:"-"`
+	Provider     string    `json:"provider"` // "anthropic", "google", "openai" or "openrouter"
+	EncryptedKey []byte    `json:"-"`
+	KeyHint      string    `json:"key_hint"` // e.g. "…a1b2"
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
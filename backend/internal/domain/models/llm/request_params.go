@@ -1,6 +1,7 @@
 package llm
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 
@@ -8,7 +9,9 @@ import (
 )
 
 // RequestParams represents all possible LLM request parameters across providers.
-// Client sends these as JSONB, provider adapters extract what they support.
+// Client sends these as JSON, provider adapters extract what they support.
+// Services only ever see this typed struct; conversion to/from the JSONB column
+// (including tolerant decoding of older stored blobs) lives in the repository layer.
 // All fields are optional pointers to distinguish "not set" from "set to zero value".
 type RequestParams struct {
 	// ===== Core Parameters (Most Providers) =====
@@ -49,6 +52,12 @@ type RequestParams struct {
 	// System prompt override (can also be set per turn)
 	System *string `json:"system,omitempty"`
 
+	// CacheControl enables prompt caching: the system prompt (with its document context)
+	// and the conversation so far are cached, so later requests in the chat re-read them
+	// instead of paying for them again. {"type": "ephemeral"} caches for 5 minutes; add
+	// "ttl": "1h" for an hour. Ignored by other providers.
+	CacheControl *CacheControl `json:"cache_control,omitempty"`
+
 	// ===== OpenAI-Specific Parameters =====
 
 	// FrequencyPenalty reduces repetition of token sequences (-2.0 to 2.0)
@@ -93,10 +102,28 @@ type RequestParams struct {
 	// ParallelToolCalls allows model to use multiple tools simultaneously
 	ParallelToolCalls *bool `json:"parallel_tool_calls,omitempty"`
 
+	// ===== Continuation =====
+
+	// AutoContinue continues generation in the same turn when the response is cut off
+	// by stop_reason "max_tokens": the partial assistant content is sent back as a
+	// prefix and the new blocks are appended to the turn. Provider-dependent (requires
+	// assistant prefill); ignored when unsupported or when thinking is enabled.
+	AutoContinue *bool `json:"auto_continue,omitempty"`
+
+	// MaxContinuations bounds auto-continue rounds per turn (default: 2, max: 5)
+	MaxContinuations *int `json:"max_continuations,omitempty"`
+
+	// ===== Response Language =====
+
+	// ResponseLanguage asks the model to answer in a language: a BCP 47 tag (e.g. "fr",
+	// "pt-BR"), or "auto" for the detected language of the user's message. Added to the
+	// system prompt; not sent to providers as a parameter.
+	ResponseLanguage *string `json:"response_language,omitempty"`
+
 	// ===== Provider Routing =====
 
 	// Provider explicitly specifies which LLM provider to use
-	// Values: "anthropic", "openrouter", "openai", "google", "lorem"
+	// Values: "anthropic", "openrouter", "openai", "google", "ollama", "lorem"
 	// If not specified, provider is inferred from model name or defaults to "openrouter"
 	Provider *string `json:"provider,omitempty"`
 
@@ -112,6 +139,22 @@ type RequestParams struct {
 	LoremMax *int `json:"lorem_max,omitempty"`
 }
 
+// Auto-continue limits (see RequestParams.AutoContinue)
+const (
+	DefaultAutoContinuations = 2
+	MaxAutoContinuations     = 5
+)
+
+// ResponseLanguageAuto answers in the detected language of the user's message
+// (see RequestParams.ResponseLanguage)
+const ResponseLanguageAuto = "auto"
+
+// CacheControl configures Anthropic prompt caching (see RequestParams.CacheControl)
+type CacheControl struct {
+	Type string `json:"type"`          // "ephemeral"
+	TTL  string `json:"ttl,omitempty"` // "5m" (default) or "1h"
+}
+
 // ResponseFormat specifies the format for structured outputs
 type ResponseFormat struct {
 	Type       string      `json:"type"`                  // "text", "json_object", "json_schema"
@@ -132,21 +175,32 @@ type ToolFunction struct {
 	Parameters  interface{} `json:"parameters,omitempty"` // JSON schema for parameters
 }
 
-// ValidateRequestParams validates request parameters
-func ValidateRequestParams(params map[string]interface{}) error {
-	if params == nil {
-		return nil // Empty params is valid
-	}
+// UnmarshalJSON rejects keys that aren't request parameters, so a typo such as
+// "temprature" fails the request instead of being silently ignored.
+// Stored blobs from before the typed struct may still carry such keys; the
+// repository's tolerant decoder drops them key by key.
+func (rp *RequestParams) UnmarshalJSON(data []byte) error {
+	type plain RequestParams // Same fields without this method, so Decode doesn't recurse
 
-	// Marshal and unmarshal to validate structure
-	jsonBytes, err := json.Marshal(params)
-	if err != nil {
-		return fmt.Errorf("invalid request params: %w", err)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var decoded plain
+	if err := decoder.Decode(&decoded); err != nil {
+		return fmt.Errorf("request_params: %w", err)
 	}
 
-	var rp RequestParams
-	if err := json.Unmarshal(jsonBytes, &rp); err != nil {
-		return fmt.Errorf("invalid request params structure: %w", err)
+	*rp = RequestParams(decoded)
+	return nil
+}
+
+// Validate checks value ranges and tool definitions.
+// Type and key checking already happen when the JSON is decoded into this struct
+// (e.g., a string temperature or an unknown key fails to unmarshal), so Validate
+// only enforces the constraints the type system can't express.
+func (rp *RequestParams) Validate() error {
+	if rp == nil {
+		return nil // Empty params is valid
 	}
 
 	// Validate ranges
@@ -181,6 +235,15 @@ func ValidateRequestParams(params map[string]interface{}) error {
 		}
 	}
 
+	if rp.CacheControl != nil {
+		if rp.CacheControl.Type != "ephemeral" {
+			return fmt.Errorf("cache_control.type must be 'ephemeral', got '%s'", rp.CacheControl.Type)
+		}
+		if ttl := rp.CacheControl.TTL; ttl != "" && ttl != "5m" && ttl != "1h" {
+			return fmt.Errorf("cache_control.ttl must be '5m' or '1h', got '%s'", ttl)
+		}
+	}
+
 	if rp.FrequencyPenalty != nil {
 		if *rp.FrequencyPenalty < -2.0 || *rp.FrequencyPenalty > 2.0 {
 			return fmt.Errorf("frequency_penalty must be between -2.0 and 2.0, got %f", *rp.FrequencyPenalty)
@@ -193,49 +256,163 @@ func ValidateRequestParams(params map[string]interface{}) error {
 		}
 	}
 
+	if rp.MaxContinuations != nil {
+		if *rp.MaxContinuations < 1 || *rp.MaxContinuations > MaxAutoContinuations {
+			return fmt.Errorf("max_continuations must be between 1 and %d, got %d", MaxAutoContinuations, *rp.MaxContinuations)
+		}
+	}
+
+	if rp.ResponseLanguage != nil && *rp.ResponseLanguage != ResponseLanguageAuto {
+		if _, err := NormalizeLanguage(*rp.ResponseLanguage); err != nil {
+			return fmt.Errorf("response_language must be \"auto\" or a BCP 47 language tag, got '%s'", *rp.ResponseLanguage)
+		}
+	}
+
 	if rp.LoremMax != nil {
 		if *rp.LoremMax < 1 {
 			return fmt.Errorf("lorem_max must be positive, got %d", *rp.LoremMax)
 		}
 	}
 
+	// Every tool must be identifiable, either by minimal name or full function definition
+	for i, tool := range rp.Tools {
+		if tool.Name == "" && (tool.Function == nil || tool.Function.Name == "") {
+			return fmt.Errorf("tools[%d] must have either 'name' or 'function.name'", i)
+		}
+	}
+
 	return nil
 }
 
-// GetRequestParamStruct unmarshals a JSONB map into a typed RequestParams struct
-// It also resolves minimal tool definitions ({"name": "doc_view"}) to full tool schemas.
-func GetRequestParamStruct(params map[string]interface{}) (*RequestParams, error) {
-	if params == nil {
-		return &RequestParams{}, nil
+// AutoContinueRounds returns how many max_tokens continuation rounds are allowed
+// (0 when auto-continue is disabled)
+func (rp *RequestParams) AutoContinueRounds() int {
+	if rp == nil || rp.AutoContinue == nil || !*rp.AutoContinue {
+		return 0
+	}
+	if rp.MaxContinuations != nil {
+		return *rp.MaxContinuations
 	}
+	return DefaultAutoContinuations
+}
+
+// ToolNames returns the names of the requested tools, in request order.
+// Handles both minimal format {"name": "tool"} and full format {"function": {"name": "tool"}}.
+// Call this on the unresolved params: resolution renames provider-specific
+// variants (e.g., "tavily_web_search" → "web_search").
+func (rp *RequestParams) ToolNames() []string {
+	toolNames := []string{}
+	if rp == nil {
+		return toolNames
+	}
+
+	for _, tool := range rp.Tools {
+		if tool.Name != "" {
+			toolNames = append(toolNames, tool.Name)
+			continue
+		}
+		if tool.Function != nil && tool.Function.Name != "" {
+			toolNames = append(toolNames, tool.Function.Name)
+		}
+	}
+
+	return toolNames
+}
 
-	jsonBytes, err := json.Marshal(params)
+// Clone returns a copy of the params that can be modified without affecting the original.
+// Slices and maps are copied; pointer fields are shared because callers replace them
+// (params.System = &prompt) rather than writing through them.
+func (rp *RequestParams) Clone() *RequestParams {
+	if rp == nil {
+		return &RequestParams{}
+	}
+
+	clone := *rp
+	if rp.Stop != nil {
+		clone.Stop = append([]string(nil), rp.Stop...)
+	}
+	if rp.Tools != nil {
+		clone.Tools = append([]ToolDefinition(nil), rp.Tools...)
+	}
+	if rp.FallbackModels != nil {
+		clone.FallbackModels = append([]string(nil), rp.FallbackModels...)
+	}
+	if rp.LogitBias != nil {
+		clone.LogitBias = make(map[string]float64, len(rp.LogitBias))
+		for k, v := range rp.LogitBias {
+			clone.LogitBias[k] = v
+		}
+	}
+	return &clone
+}
+
+// WithOverrides returns a copy of the params with every field set in overrides replaced
+// (slices and maps as a whole). A new model without a provider drops the old provider,
+// so it is inferred from the new model again.
+func (rp *RequestParams) WithOverrides(overrides *RequestParams) (*RequestParams, error) {
+	if overrides == nil {
+		return rp.Clone(), nil
+	}
+
+	// Every field is omitempty, so the encoded overrides hold exactly the fields they set.
+	// Overlaying them key by key and decoding into a fresh struct shares nothing with
+	// either input.
+	fields := make(map[string]json.RawMessage)
+	for _, params := range []*RequestParams{rp, overrides} {
+		if params == nil {
+			continue
+		}
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, fmt.Errorf("encode request params: %w", err)
+		}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, fmt.Errorf("decode request params: %w", err)
+		}
+	}
+	if overrides.Model != nil && overrides.Provider == nil {
+		delete(fields, "provider")
+	}
+
+	data, err := json.Marshal(fields)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal params: %w", err)
-	}
-
-	var rp RequestParams
-	if err := json.Unmarshal(jsonBytes, &rp); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal params: %w", err)
-	}
-
-	// Resolve minimal tool definitions to full schemas
-	// This allows users to specify {"name": "doc_view"} instead of the full OpenAI schema
-	if len(rp.Tools) > 0 {
-		for i, tool := range rp.Tools {
-			// Check if this is a minimal definition (only Name set, no Function)
-			if tool.Function == nil && tool.Name != "" {
-				// Try to resolve as a custom read-only tool first
-				if fullDef := GetToolDefinitionByName(tool.Name); fullDef != nil {
-					rp.Tools[i] = *fullDef
-					continue
-				}
-				// Otherwise, leave it as-is to be resolved as a built-in tool by the library
+		return nil, fmt.Errorf("encode request params: %w", err)
+	}
+	merged := &RequestParams{}
+	if err := json.Unmarshal(data, merged); err != nil {
+		return nil, fmt.Errorf("decode request params: %w", err)
+	}
+	return merged, nil
+}
+
+// WithResolvedTools returns a copy of the params with minimal tool definitions
+// ({"name": "doc_view"}) resolved to full tool schemas.
+// The receiver is left untouched so the persisted request_params keep what the
+// client actually sent (needed for turn history/edit and for ToolNames).
+func (rp *RequestParams) WithResolvedTools() *RequestParams {
+	resolved := rp.Clone()
+
+	for i, tool := range resolved.Tools {
+		// Check if this is a minimal definition (only Name set, no Function)
+		if tool.Function == nil && tool.Name != "" {
+			// Try to resolve as a custom read-only tool first
+			if fullDef := GetToolDefinitionByName(tool.Name); fullDef != nil {
+				resolved.Tools[i] = *fullDef
+				continue
 			}
+			// Otherwise, leave it as-is to be resolved as a built-in tool by the library
 		}
 	}
 
-	return &rp, nil
+	return resolved
+}
+
+// GetProvider returns the explicitly requested provider, or "" if not set
+func (rp *RequestParams) GetProvider() string {
+	if rp == nil || rp.Provider == nil {
+		return ""
+	}
+	return *rp.Provider
 }
 
 // GetMaxTokens returns max_tokens with default fallback
@@ -0,0 +1,147 @@
+package llm
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRequestParamsWithOverrides(t *testing.T) {
+	temperature, newTemperature := 0.7, 0.2
+	model, newModel := "claude-haiku-4-5-20251001", "gpt-4o"
+	provider := "anthropic"
+	maxTokens := 1024
+
+	original := &RequestParams{
+		Model:       &model,
+		Provider:    &provider,
+		Temperature: &temperature,
+		MaxTokens:   &maxTokens,
+		Stop:        []string{"END"},
+		LogitBias:   map[string]float64{"50256": -100},
+	}
+
+	merged, err := original.WithOverrides(&RequestParams{
+		Model:       &newModel,
+		Temperature: &newTemperature,
+		LogitBias:   map[string]float64{"11": 5},
+	})
+	if err != nil {
+		t.Fatalf("WithOverrides: %v", err)
+	}
+
+	if merged.Model == nil || *merged.Model != newModel {
+		t.Errorf("model = %v, want %q", merged.Model, newModel)
+	}
+	if merged.Provider != nil {
+		t.Errorf("provider = %q, want it dropped for the new model", *merged.Provider)
+	}
+	if merged.Temperature == nil || *merged.Temperature != newTemperature {
+		t.Errorf("temperature = %v, want %v", merged.Temperature, newTemperature)
+	}
+	if merged.MaxTokens == nil || *merged.MaxTokens != maxTokens {
+		t.Errorf("max_tokens = %v, want %d kept", merged.MaxTokens, maxTokens)
+	}
+	if !reflect.DeepEqual(merged.Stop, []string{"END"}) {
+		t.Errorf("stop = %v, want kept", merged.Stop)
+	}
+	if !reflect.DeepEqual(merged.LogitBias, map[string]float64{"11": 5}) {
+		t.Errorf("logit_bias = %v, want replaced as a whole", merged.LogitBias)
+	}
+
+	// The original is untouched
+	*merged.MaxTokens = 1
+	if *original.Temperature != temperature || *original.MaxTokens != maxTokens || *original.Provider != provider {
+		t.Errorf("original modified: %+v", original)
+	}
+}
+
+func TestRequestParamsWithOverrides_KeepsProvider(t *testing.T) {
+	model, provider := "claude-haiku-4-5-20251001", "anthropic"
+	temperature := 0.5
+
+	merged, err := (&RequestParams{Model: &model, Provider: &provider}).WithOverrides(&RequestParams{Temperature: &temperature})
+	if err != nil {
+		t.Fatalf("WithOverrides: %v", err)
+	}
+	if merged.Provider == nil || *merged.Provider != provider {
+		t.Errorf("provider = %v, want %q kept", merged.Provider, provider)
+	}
+
+	// Nil receiver and nil overrides both work
+	merged, err = (*RequestParams)(nil).WithOverrides(nil)
+	if err != nil || merged == nil {
+		t.Fatalf("WithOverrides(nil) = %v, %v", merged, err)
+	}
+}
+
+func TestRequestParamsUnmarshalJSON(t *testing.T) {
+	t.Run("known keys decode", func(t *testing.T) {
+		var params RequestParams
+		data := `{"model": "gpt-4o", "temperature": 0.5, "tools": [{"name": "doc_view"}], "tool_choice": {"mode": "auto"}}`
+		if err := json.Unmarshal([]byte(data), &params); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if params.Model == nil || *params.Model != "gpt-4o" {
+			t.Errorf("model = %v, want gpt-4o", params.Model)
+		}
+		if params.Temperature == nil || *params.Temperature != 0.5 {
+			t.Errorf("temperature = %v, want 0.5", params.Temperature)
+		}
+	})
+
+	for name, data := range map[string]string{
+		"unknown key":        `{"temprature": 0.5}`,
+		"unknown nested key": `{"tools": [{"name": "doc_view", "input_schema": {}}]}`,
+		"wrong type":         `{"temperature": "warm"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var params RequestParams
+			if err := json.Unmarshal([]byte(data), &params); err == nil {
+				t.Fatalf("Unmarshal(%s) succeeded, want error", data)
+			}
+		})
+	}
+
+	t.Run("inside a request body", func(t *testing.T) {
+		var body struct {
+			Role          string         `json:"role"`
+			RequestParams *RequestParams `json:"request_params"`
+		}
+		err := json.Unmarshal([]byte(`{"role": "user", "request_params": {"max_token": 100}}`), &body)
+		if err == nil || !strings.Contains(err.Error(), "max_token") {
+			t.Fatalf("Unmarshal() error = %v, want unknown field max_token", err)
+		}
+	})
+}
+
+func TestRequestParamsValidate_ResponseLanguage(t *testing.T) {
+	for _, lang := range []string{"auto", "fr", "pt-BR", "zh-Hant"} {
+		params := &RequestParams{ResponseLanguage: &lang}
+		if err := params.Validate(); err != nil {
+			t.Errorf("Validate(response_language=%q) error = %v", lang, err)
+		}
+	}
+	for _, lang := range []string{"", "french", "und"} {
+		params := &RequestParams{ResponseLanguage: &lang}
+		if err := params.Validate(); err == nil {
+			t.Errorf("Validate(response_language=%q) succeeded, want error", lang)
+		}
+	}
+}
+
+func TestRequestParamsValidate_CacheControl(t *testing.T) {
+	for _, cc := range []CacheControl{{Type: "ephemeral"}, {Type: "ephemeral", TTL: "5m"}, {Type: "ephemeral", TTL: "1h"}} {
+		params := &RequestParams{CacheControl: &cc}
+		if err := params.Validate(); err != nil {
+			t.Errorf("Validate(cache_control=%+v) error = %v", cc, err)
+		}
+	}
+	for _, cc := range []CacheControl{{}, {Type: "persistent"}, {Type: "ephemeral", TTL: "24h"}} {
+		params := &RequestParams{CacheControl: &cc}
+		if err := params.Validate(); err == nil {
+			t.Errorf("Validate(cache_control=%+v) succeeded, want error", cc)
+		}
+	}
+}
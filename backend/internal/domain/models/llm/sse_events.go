@@ -14,6 +14,12 @@ const (
 	SSEEventBlockCatchup = "block_catchup" // Replaying completed block (reconnection)
 	SSEEventTurnComplete = "turn_complete" // Turn finished successfully
 	SSEEventTurnError    = "turn_error"    // Turn encountered error
+
+	SSEEventToolExecutionStart    = "tool_execution_start"    // Backend tool started running (between stream rounds)
+	SSEEventToolExecutionProgress = "tool_execution_progress" // Running backend tool reported progress (e.g. a retry)
+	SSEEventToolExecutionComplete = "tool_execution_complete" // Backend tool finished (result block follows)
+
+	SSEEventReconnectAdvice = "reconnect_advice" // Server is going away; reconnect after retry_after (not buffered or replayed)
 )
 
 // SSEEvent represents a Server-Sent Event for turn streaming
@@ -73,6 +79,47 @@ type TurnErrorEvent struct {
 	LastBlockIndex *int  `json:"last_block_index,omitempty"` // Last successfully written block (if any)
 }
 
+// ToolExecutionStartEvent signals that a backend tool started running
+// Sent for every tool_use collected in a round as its tool starts (tools run in parallel)
+type ToolExecutionStartEvent struct {
+	TurnID    string `json:"turn_id"`
+	ToolUseID string `json:"tool_use_id"` // Matches the tool_use block's tool_use_id
+	ToolName  string `json:"tool_name"`   // e.g. "web_search", "doc_view"
+	Iteration int    `json:"iteration"`   // Tool round (0 = first)
+}
+
+// ToolExecutionProgressEvent reports progress of a running backend tool
+// Sent between its start and complete events, e.g. before a retry or per stage of image generation
+type ToolExecutionProgressEvent struct {
+	TurnID    string `json:"turn_id"`
+	ToolUseID string `json:"tool_use_id"`
+	ToolName  string `json:"tool_name"`
+	Iteration int    `json:"iteration"`
+	Stage     string `json:"stage"`   // "retrying", "generating", "storing"
+	Message   string `json:"message"` // Human-readable status
+}
+
+// ToolExecutionCompleteEvent signals that a backend tool finished
+// Sent as each tool finishes (completion order); the tool_result block is streamed afterwards
+type ToolExecutionCompleteEvent struct {
+	TurnID     string  `json:"turn_id"`
+	ToolUseID  string  `json:"tool_use_id"`
+	ToolName   string  `json:"tool_name"`
+	Iteration  int     `json:"iteration"`
+	DurationMs int64   `json:"duration_ms"` // Including retries and backoff
+	Attempts   int     `json:"attempts"`    // > 1 when transient failures were retried
+	IsError    bool    `json:"is_error"`
+	Error      *string `json:"error,omitempty"` // Error message when is_error is true
+}
+
+// ReconnectAdviceEvent tells a client the server instance is going away (deploy), so it
+// backs off before reconnecting instead of retrying a dying instance. The SSE retry field
+// carries the same delay for EventSource's automatic reconnect.
+type ReconnectAdviceEvent struct {
+	Reason     string `json:"reason"`      // "draining" (the stream may still finish) or "evicting" (it is being cancelled)
+	RetryAfter int    `json:"retry_after"` // Seconds to wait before reconnecting
+}
+
 // FormatSSE formats an SSE event for transmission
 // Returns a string in SSE format:
 //   event: event_name
@@ -0,0 +1,38 @@
+package llm
+
+import "encoding/json"
+
+// StreamIndex is the compact event history of an assistant turn's stream, persisted
+// when the stream ends. With the turn's blocks it rebuilds the whole stream for a
+// client connecting after the stream left memory: the blocks hold the content, the
+// index where each block ended and the final event.
+//
+// Event counts number the live events "1", "2", ... the way the stream's DEBUG event
+// IDs do, so a Last-Event-ID maps to the blocks a client has already seen.
+type StreamIndex struct {
+	Blocks []StreamIndexBlock `json:"blocks"`          // Block boundaries, in stream order
+	Final  *StreamIndexFinal  `json:"final,omitempty"` // The event that ended the stream
+}
+
+// StreamIndexBlock is where one block ended in the stream
+type StreamIndexBlock struct {
+	Index  int `json:"index"`  // block_index of the block's events (turn_blocks.sequence)
+	Events int `json:"events"` // Events sent up to and including its block_stop
+}
+
+// StreamIndexFinal is the stream's final event (turn_complete or turn_error), as sent
+type StreamIndexFinal struct {
+	Event  string          `json:"event"`
+	Data   json.RawMessage `json:"data"`
+	Events int             `json:"events"` // Events sent up to and including it
+}
+
+// BlockEvents returns the event count of the block with the index (0 if not indexed)
+func (idx *StreamIndex) BlockEvents(index int) int {
+	for _, block := range idx.Blocks {
+		if block.Index == index {
+			return block.Events
+		}
+	}
+	return 0
+}
@@ -0,0 +1,15 @@
+package llm
+
+import "unicode/utf8"
+
+// CharsPerToken is the rough characters-per-token ratio for English prose across
+// current tokenizers (Claude, GPT, Kimi). Used where an exact count isn't needed.
+const CharsPerToken = 4
+
+// EstimateTokens approximates the token count of text without calling a tokenizer.
+// Counts runes (not bytes) so non-ASCII text isn't overestimated 2-3x, and rounds up
+// so any non-empty text counts as at least one token.
+func EstimateTokens(text string) int {
+	runes := utf8.RuneCountInString(text)
+	return (runes + CharsPerToken - 1) / CharsPerToken
+}
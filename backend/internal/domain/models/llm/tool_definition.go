@@ -267,6 +267,65 @@ func getWebSearchToolDefinition() ToolDefinition {
 	}
 }
 
+// getImageGenerateToolDefinition returns the schema for the 'image_generate' tool.
+// This tool generates an image with an external API (OpenAI Images, Stability AI).
+func getImageGenerateToolDefinition() ToolDefinition {
+	return ToolDefinition{
+		Type: "function",
+		Function: &FunctionDetails{
+			Name:        "image_generate",
+			Description: "Generate an image from a text description (e.g. a character portrait, a map, a cover sketch). The image is shown to the user below your message; you only receive a confirmation. Write a detailed visual prompt: subject, setting, style, lighting, composition. Each call is billed, so don't generate variations unless asked.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"prompt": map[string]interface{}{
+						"type":        "string",
+						"description": "Detailed description of the image to generate (max 4000 characters).",
+					},
+					"aspect": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"square", "landscape", "portrait"},
+						"description": "Optional: image shape. Default: square.",
+					},
+					"alt_text": map[string]interface{}{
+						"type":        "string",
+						"description": "Optional: one-sentence description of the image for accessibility. Defaults to the prompt.",
+					},
+				},
+				"required": []string{"prompt"},
+			},
+		},
+	}
+}
+
+// getConversationRecallToolDefinition returns the schema for the 'conversation_recall' tool.
+// This tool searches the user's earlier chats in the project.
+func getConversationRecallToolDefinition() ToolDefinition {
+	return ToolDefinition{
+		Type: "function",
+		Function: &FunctionDetails{
+			Name:        "conversation_recall",
+			Description: "Search the user's earlier conversations in this project (not the current one) for what was discussed or decided, e.g. a character's backstory agreed three chats ago. Returns matching messages with the chat title, date, who said it and an excerpt around the match. Use it when the user refers to a past discussion you can't see. Supports the same syntax as doc_search: OR, minus to exclude, double quotes for phrases.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "What to look for. Use distinctive keywords or names (e.g., 'Aria backstory' or '\"magic system\" rules').",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Optional: maximum number of messages to return (default: 5, max: 20).",
+						"minimum":     1,
+						"maximum":     20,
+					},
+				},
+				"required": []string{"query"},
+			},
+		},
+	}
+}
+
 // isWebSearchVariant returns true if the tool name is a web search provider variant.
 // Web search variants (tavily_web_search, brave_web_search, etc.) should be treated
 // as custom backend tools with ExecutionSide: Server, not provider-side tools.
@@ -279,6 +338,16 @@ func isWebSearchVariant(name string) bool {
 	}
 }
 
+// IsKnownToolName reports whether name can be requested in minimal format ({"name": ...}):
+// a custom backend tool (doc_view, web search variants, ...) or a library built-in.
+func IsKnownToolName(name string) bool {
+	if GetToolDefinitionByName(name) != nil {
+		return true
+	}
+	_, err := llmprovider.MapToolByName(name)
+	return err == nil
+}
+
 // GetToolDefinitionByName returns the full tool definition for a given tool name.
 // This is used to resolve minimal format {"name": "doc_view"} to full schemas.
 // Returns nil if the tool name is not recognized as a custom read-only tool.
@@ -298,6 +367,14 @@ func GetToolDefinitionByName(name string) *ToolDefinition {
 		def := getSearchToolDefinition()
 		return &def
 
+	case "conversation_recall":
+		def := getConversationRecallToolDefinition()
+		return &def
+
+	case "image_generate":
+		def := getImageGenerateToolDefinition()
+		return &def
+
 	// Provider-specific web search tools
 	// All map to "web_search" schema, backend routes to appropriate provider
 	case "tavily_web_search":
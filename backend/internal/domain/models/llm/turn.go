@@ -1,43 +1,112 @@
 package llm
 
 import (
+	"fmt"
 	"time"
 )
 
+// MaxTurnsByIDs caps the turn IDs of one GET /api/turns?ids= request
+const MaxTurnsByIDs = 100
+
 // Turn represents a single turn in a conversation (user or assistant)
 // Turns form a tree structure via prev_turn_id for branching conversations
 type Turn struct {
 	ID           string     `json:"id" db:"id"`
 	ChatID       string     `json:"chat_id" db:"chat_id"`
 	PrevTurnID   *string    `json:"prev_turn_id" db:"prev_turn_id"`
-	Role         string     `json:"role" db:"role"` // "user" or "assistant"
+	Role         string     `json:"role" db:"role"`     // "user" or "assistant"
 	Status       string     `json:"status" db:"status"` // "pending", "streaming", "waiting_subagents", "complete", "cancelled", "error"
 	Error        *string    `json:"error,omitempty" db:"error"`
 	Model        *string    `json:"model,omitempty" db:"model"` // LLM model used for assistant turns
 	InputTokens  *int       `json:"input_tokens,omitempty" db:"input_tokens"`
 	OutputTokens *int       `json:"output_tokens,omitempty" db:"output_tokens"`
+	CostUSD      *float64   `json:"cost_usd,omitempty" db:"cost_usd"` // Provider cost of the turn (nil if the model has no pricing)
 	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
 	CompletedAt  *time.Time `json:"completed_at,omitempty" db:"completed_at"`
 
 	// LLM Request/Response Metadata (JSONB columns)
-	RequestParams    map[string]interface{} `json:"request_params,omitempty" db:"request_params"`       // All request parameters (temperature, max_tokens, thinking settings, etc.)
-	StopReason       *string                `json:"stop_reason,omitempty" db:"stop_reason"`             // Why generation stopped ("end_turn", "max_tokens", "stop_sequence", etc.)
-	ResponseMetadata map[string]interface{} `json:"response_metadata,omitempty" db:"response_metadata"` // Provider-specific response data (stop_sequence, cache tokens, etc.)
+	RequestParams      *RequestParams         `json:"request_params,omitempty" db:"request_params"`             // All request parameters (temperature, max_tokens, thinking settings, etc.)
+	StopReason         *string                `json:"stop_reason,omitempty" db:"stop_reason"`                   // Why generation stopped ("end_turn", "max_tokens", "stop_sequence", etc.)
+	ResponseMetadata   map[string]interface{} `json:"response_metadata,omitempty" db:"response_metadata"`       // Provider-specific response data (stop_sequence, cache tokens, etc.)
+	SystemPromptTokens *int                   `json:"system_prompt_tokens,omitempty" db:"system_prompt_tokens"` // Estimated tokens of the resolved system prompt (assistant turns)
+	ModelRouting       *ModelRouting          `json:"model_routing,omitempty" db:"model_routing"`               // How an "auto" model was routed (assistant turns)
+	Annotations        *TurnAnnotations       `json:"annotations,omitempty" db:"annotations"`                   // Client rendering hints (nil = none)
+	Language           *string                `json:"language,omitempty" db:"language"`                         // Detected language of a user turn's text (ISO 639-1, nil = undetermined)
 
 	// Computed fields (not stored in DB)
-	Blocks     []TurnBlock `json:"blocks,omitempty"`      // Content blocks for this turn
-	SiblingIDs []string    `json:"sibling_ids,omitempty"` // IDs of sibling turns (same prev_turn_id)
+	Blocks     []TurnBlock    `json:"blocks,omitempty"`      // Content blocks for this turn
+	SiblingIDs []string       `json:"sibling_ids,omitempty"` // IDs of sibling turns (same prev_turn_id)
+	ModelInfo  *TurnModelInfo `json:"model_info,omitempty"`  // Display metadata for Model, joined from the capability registry
+}
+
+// TurnModelInfo is display metadata for the model that produced a turn
+// Joined server-side from the capability registry so the UI doesn't duplicate the model catalog.
+// Known is false when the model isn't in the registry (e.g. retired or custom OpenRouter models);
+// DisplayName then falls back to the raw model ID and capability flags are false.
+type TurnModelInfo struct {
+	Provider         string `json:"provider"`
+	DisplayName      string `json:"display_name"`
+	Known            bool   `json:"known"`
+	ContextWindow    int    `json:"context_window,omitempty"`
+	SupportsTools    bool   `json:"supports_tools"`
+	SupportsThinking bool   `json:"supports_thinking"`
+	SupportsVision   bool   `json:"supports_vision"`
 }
 
 // TokenUsageInfo provides token usage statistics for a turn
 type TokenUsageInfo struct {
 	TurnID         string   `json:"turn_id"`
-	InputTokens    *int     `json:"input_tokens"`     // Tokens used for input (nil if not available)
-	OutputTokens   *int     `json:"output_tokens"`    // Tokens used for output (nil if not available)
-	TotalTokens    *int     `json:"total_tokens"`     // Sum of input + output (nil if either is unavailable)
-	ContextLimit   *int     `json:"context_limit"`    // Model's max context window (nil if unknown)
-	UsagePercent   *float64 `json:"usage_percent"`    // Percentage of context used (nil if limit unknown)
-	Model          *string  `json:"model"`            // Model name
-	ProviderName   *string  `json:"provider_name"`    // Provider name (derived from model or request params)
-	WarningMessage *string  `json:"warning_message"`  // Human-readable warning if usage is high
+	InputTokens    *int     `json:"input_tokens"`    // Tokens used for input (nil if not available)
+	OutputTokens   *int     `json:"output_tokens"`   // Tokens used for output (nil if not available)
+	TotalTokens    *int     `json:"total_tokens"`    // Sum of input + output (nil if either is unavailable)
+	ContextLimit   *int     `json:"context_limit"`   // Model's max context window (nil if unknown)
+	UsagePercent   *float64 `json:"usage_percent"`   // Percentage of context used (nil if limit unknown)
+	Model          *string  `json:"model"`           // Model name
+	ProviderName   *string  `json:"provider_name"`   // Provider name (derived from model or request params)
+	WarningMessage *string  `json:"warning_message"` // Human-readable warning if usage is high
+
+	SystemPromptTokens  *int     `json:"system_prompt_tokens"`  // Estimated tokens of the resolved system prompt (nil if unknown)
+	SystemPromptPercent *float64 `json:"system_prompt_percent"` // Share of the context window taken by the system prompt
+}
+
+// SystemPromptUsage reports the size of the resolved system prompt for a new turn
+// (user + project + chat + skills prompts concatenated by the SystemPromptResolver)
+type SystemPromptUsage struct {
+	EstimatedTokens int      `json:"estimated_tokens"`
+	ContextLimit    *int     `json:"context_limit"`   // Model's context window (nil if model unknown)
+	UsagePercent    *float64 `json:"usage_percent"`   // Share of the context window (nil if limit unknown)
+	WarningMessage  *string  `json:"warning_message"` // Set when the share exceeds the warning threshold
+}
+
+// TurnMetadataUpdate is a partial update of a turn's completion metadata.
+// Every field is nullable: nil means "leave the stored value unchanged", so metadata
+// from a provider that omits a field can never zero out what's already persisted.
+type TurnMetadataUpdate struct {
+	Model            *string                // LLM model that produced the response
+	InputTokens      *int                   // Token count for input
+	OutputTokens     *int                   // Token count for output
+	CostUSD          *float64               // Cost in USD from the model's pricing tiers
+	StopReason       *string                // Why generation stopped
+	ResponseMetadata map[string]interface{} // Provider-specific response data (nil = unchanged)
+	CompletedAt      *time.Time             // Completion time
+}
+
+// Validate checks the values that are set
+func (u *TurnMetadataUpdate) Validate() error {
+	if u == nil {
+		return fmt.Errorf("metadata update cannot be nil")
+	}
+	if u.Model != nil && *u.Model == "" {
+		return fmt.Errorf("model cannot be empty when set")
+	}
+	if u.InputTokens != nil && *u.InputTokens < 0 {
+		return fmt.Errorf("input_tokens must be non-negative, got %d", *u.InputTokens)
+	}
+	if u.OutputTokens != nil && *u.OutputTokens < 0 {
+		return fmt.Errorf("output_tokens must be non-negative, got %d", *u.OutputTokens)
+	}
+	if u.CostUSD != nil && *u.CostUSD < 0 {
+		return fmt.Errorf("cost_usd must be non-negative, got %f", *u.CostUSD)
+	}
+	return nil
 }
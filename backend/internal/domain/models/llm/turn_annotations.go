@@ -0,0 +1,75 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"meridian/internal/domain"
+)
+
+const (
+	// MaxTurnColorLabelLength caps TurnAnnotations.ColorLabel (characters)
+	MaxTurnColorLabelLength = 32
+
+	// MaxTurnBranchNameLength caps TurnAnnotations.BranchName (characters)
+	MaxTurnBranchNameLength = 100
+)
+
+// TurnAnnotations are rendering hints a client stores on a turn (stored as JSONB)
+// The server doesn't interpret them; they let branch management UI (collapsed turns,
+// colored or named branches) look the same across devices.
+type TurnAnnotations struct {
+	Collapsed  bool   `json:"collapsed,omitempty"`   // Turn is shown collapsed
+	ColorLabel string `json:"color_label,omitempty"` // Client-defined color label (e.g. "blue")
+	BranchName string `json:"branch_name,omitempty"` // Custom name of the branch starting at this turn
+}
+
+// TurnAnnotationsUpdate is a partial update of a turn's annotations (PATCH semantics)
+// Absent fields are left unchanged; null (or false / "") removes an annotation.
+type TurnAnnotationsUpdate struct {
+	Collapsed  domain.Optional[bool]   `json:"collapsed,omitzero"`
+	ColorLabel domain.Optional[string] `json:"color_label,omitzero"`
+	BranchName domain.Optional[string] `json:"branch_name,omitzero"`
+}
+
+// Validate checks the labels that are set
+func (u *TurnAnnotationsUpdate) Validate() error {
+	if u == nil {
+		return fmt.Errorf("annotations update cannot be nil")
+	}
+	if label, ok := u.ColorLabel.Value(); ok && utf8.RuneCountInString(label) > MaxTurnColorLabelLength {
+		return fmt.Errorf("color_label cannot exceed %d characters", MaxTurnColorLabelLength)
+	}
+	if name, ok := u.BranchName.Value(); ok && utf8.RuneCountInString(strings.TrimSpace(name)) > MaxTurnBranchNameLength {
+		return fmt.Errorf("branch_name cannot exceed %d characters", MaxTurnBranchNameLength)
+	}
+	return nil
+}
+
+// Changes returns the annotation keys the update sets, merged over the stored JSONB
+// Removed annotations map to nil (JSON null), which the repository strips.
+func (u *TurnAnnotationsUpdate) Changes() map[string]any {
+	changes := make(map[string]any)
+	if u.Collapsed.IsSet() {
+		changes["collapsed"] = nil
+		if collapsed, _ := u.Collapsed.Value(); collapsed {
+			changes["collapsed"] = true
+		}
+	}
+	if u.ColorLabel.IsSet() {
+		changes["color_label"] = nonEmptyOrNil(u.ColorLabel.Ptr())
+	}
+	if u.BranchName.IsSet() {
+		changes["branch_name"] = nonEmptyOrNil(u.BranchName.Ptr())
+	}
+	return changes
+}
+
+// nonEmptyOrNil returns the trimmed string, or nil if s is nil or blank
+func nonEmptyOrNil(s *string) any {
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return nil
+	}
+	return strings.TrimSpace(*s)
+}
@@ -0,0 +1,81 @@
+package llm
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTurnAnnotationsUpdate_Changes(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want map[string]any
+	}{
+		{
+			name: "absent fields are left out",
+			body: `{"collapsed": true}`,
+			want: map[string]any{"collapsed": true},
+		},
+		{
+			name: "values are trimmed",
+			body: `{"color_label": "blue", "branch_name": "  Shorter draft "}`,
+			want: map[string]any{"color_label": "blue", "branch_name": "Shorter draft"},
+		},
+		{
+			name: "null, false and blank remove annotations",
+			body: `{"collapsed": false, "color_label": null, "branch_name": " "}`,
+			want: map[string]any{"collapsed": nil, "color_label": nil, "branch_name": nil},
+		},
+		{
+			name: "empty update",
+			body: `{}`,
+			want: map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var update TurnAnnotationsUpdate
+			if err := json.Unmarshal([]byte(tt.body), &update); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if got := update.Changes(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Changes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTurnAnnotationsUpdate_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{name: "valid", body: `{"color_label": "blue", "branch_name": "Draft B"}`},
+		{name: "clear", body: `{"color_label": null, "branch_name": null}`},
+		{name: "long color label", body: `{"color_label": "` + strings.Repeat("x", MaxTurnColorLabelLength+1) + `"}`, wantErr: "color_label"},
+		{name: "long branch name", body: `{"branch_name": "` + strings.Repeat("x", MaxTurnBranchNameLength+1) + `"}`, wantErr: "branch_name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var update TurnAnnotationsUpdate
+			if err := json.Unmarshal([]byte(tt.body), &update); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			err := update.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package llm
+
+import "time"
+
+// TurnAudio is a spoken rendition of a turn's text, kept in object storage
+type TurnAudio struct {
+	TurnID     string    `json:"turn_id"`
+	URL        string    `json:"url"` // Signed download link, valid until ExpiresAt
+	MIMEType   string    `json:"mime_type"`
+	Characters int       `json:"characters"` // Length of the text that was read
+	Cached     bool      `json:"cached"`     // Audio was synthesized by an earlier request
+	ExpiresAt  time.Time `json:"expires_at"`
+}
@@ -14,25 +14,36 @@ const (
 	BlockTypeImage            = "image"
 	BlockTypeReference        = "reference"
 	BlockTypePartialReference = "partial_reference"
-	BlockTypeWebSearch        = "web_search_use"    // Server-executed web search invocation (LLM request)
-	BlockTypeWebSearchResult  = "web_search_result" // Server-executed web search result (provider response)
+	BlockTypeWebSearch        = "web_search_use"     // Server-executed web search invocation (LLM request)
+	BlockTypeWebSearchResult  = "web_search_result"  // Server-executed web search result (provider response)
+	BlockTypeRedactedThinking = "redacted_thinking"  // Encrypted reasoning (opaque data in provider_data)
+	BlockTypeServerToolUse    = "server_tool_use"    // Provider-executed tool invocation other than web search
+	BlockTypeServerToolResult = "server_tool_result" // Provider-executed tool result other than web search
 )
 
 // TurnBlock represents a multimodal content block in a turn (user or assistant)
 // Accumulated from Anthropic's streaming content_block deltas during LLM execution
 //
 // User blocks: text, image, reference, partial_reference, tool_result
-// Assistant blocks: text, thinking, tool_use, web_search, web_search_result
+// Assistant blocks: text, thinking, redacted_thinking, tool_use, web_search, web_search_result,
+// server_tool_use, server_tool_result, image (generated by image_generate; display only,
+// not replayed to providers)
 //
 // The content field stores block-type-specific structured data as JSONB:
 // - text: null (text in text_content field)
 // - thinking: null (text in text_content, signature in provider_data)
+// - redacted_thinking: null (encrypted data only in provider_data)
 // - tool_use: {"tool_use_id": "toolu_...", "tool_name": "...", "input": {...}}
-// - tool_result: {"tool_use_id": "toolu_...", "is_error": false}
+// - tool_result: {"tool_use_id": "toolu_...", "tool_name": "...", "is_error": false, "result": ...}
 // - web_search: {"tool_use_id": "toolu_...", "tool_name": "web_search", "input": {...}}
 // - web_search_result: {"tool_use_id": "toolu_...", "results": [{title, url, page_age}]} or {"tool_use_id": "...", "is_error": true, "error_code": "..."}
-// - image: {"url": "...", "mime_type": "...", "alt_text": "..."}
+// - server_tool_use: {"tool_use_id": "srvtoolu_...", "tool_name": "...", "input": {...}}
+// - server_tool_result: {"tool_use_id": "srvtoolu_...", "result_type": "code_execution_tool_result"} (full result in provider_data)
+// - image: {"url": "...", "mime_type": "...", "alt_text": "...", "storage_key": "..."}
 // - reference: {"ref_id": "...", "ref_type": "...", "selection_start": 0, ...}
+//
+// Structured content also carries "schema_version". The typed structs and the schema
+// registry live in content_types.go; use DecodeContent/EncodeContent rather than map keys.
 type TurnBlock struct {
 	ID            string                 `json:"id" db:"id"`
 	TurnID        string                 `json:"turn_id" db:"turn_id"`
@@ -59,17 +70,33 @@ func (tb *TurnBlock) IsUserBlock() bool {
 func (tb *TurnBlock) IsAssistantBlock() bool {
 	return tb.BlockType == BlockTypeText ||
 		tb.BlockType == BlockTypeThinking ||
+		tb.BlockType == BlockTypeRedactedThinking ||
 		tb.BlockType == BlockTypeToolUse ||
 		tb.BlockType == BlockTypeWebSearch ||
-		tb.BlockType == BlockTypeWebSearchResult
+		tb.BlockType == BlockTypeWebSearchResult ||
+		tb.BlockType == BlockTypeServerToolUse ||
+		tb.BlockType == BlockTypeServerToolResult ||
+		tb.BlockType == BlockTypeImage
 }
 
-// IsToolBlock returns true if this is a tool-related block (tool_use, tool_result, web_search, web_search_result)
+// IsToolBlock returns true if this is a tool-related block (tool_use, tool_result, web_search,
+// web_search_result, server_tool_use, server_tool_result)
 func (tb *TurnBlock) IsToolBlock() bool {
 	return tb.BlockType == BlockTypeToolUse ||
 		tb.BlockType == BlockTypeToolResult ||
 		tb.BlockType == BlockTypeWebSearch ||
-		tb.BlockType == BlockTypeWebSearchResult
+		tb.BlockType == BlockTypeWebSearchResult ||
+		tb.BlockType == BlockTypeServerToolUse ||
+		tb.BlockType == BlockTypeServerToolResult
+}
+
+// IsProviderOpaque returns true if the block can only be replayed from its provider_data
+// (redacted_thinking, server_tool_use, server_tool_result). Providers that can't replay
+// the raw block skip it, so these never turn into malformed requests.
+func (tb *TurnBlock) IsProviderOpaque() bool {
+	return tb.BlockType == BlockTypeRedactedThinking ||
+		tb.BlockType == BlockTypeServerToolUse ||
+		tb.BlockType == BlockTypeServerToolResult
 }
 
 // IsProviderSideTool returns true if this is a provider-side tool_use block (e.g., Anthropic's web_search)
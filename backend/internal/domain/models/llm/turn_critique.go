@@ -0,0 +1,26 @@
+package llm
+
+import "time"
+
+// Turn critique statuses
+const (
+	CritiqueStatusPending  = "pending"
+	CritiqueStatusComplete = "complete"
+	CritiqueStatusError    = "error"
+)
+
+// TurnCritique is a second model's review of an assistant turn (the critic pass).
+// It annotates the turn and is never sent back to the model as conversation.
+type TurnCritique struct {
+	ID           string     `json:"id"`
+	TurnID       string     `json:"turn_id"`
+	Model        string     `json:"model"`
+	Rubric       string     `json:"rubric"`
+	Status       string     `json:"status"`  // "pending", "complete" or "error"
+	Content      string     `json:"content"` // The review (Markdown)
+	Error        *string    `json:"error,omitempty"`
+	InputTokens  *int       `json:"input_tokens,omitempty"`
+	OutputTokens *int       `json:"output_tokens,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+}
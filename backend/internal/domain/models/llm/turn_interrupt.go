@@ -0,0 +1,26 @@
+package llm
+
+import "fmt"
+
+// InterruptMode says what happens to a streaming turn's content when the user stops it
+type InterruptMode string
+
+const (
+	InterruptKeep    InterruptMode = "keep"    // Keep the blocks completed before the stop (default)
+	InterruptDiscard InterruptMode = "discard" // Delete the turn's blocks
+)
+
+// Stop reasons of interrupted turns (status "cancelled"), one per InterruptMode
+const (
+	StopReasonInterrupted = "interrupted" // Stopped by the user, completed blocks kept
+	StopReasonDiscarded   = "discarded"   // Stopped by the user, blocks deleted
+)
+
+// Validate checks the mode is known; empty means InterruptKeep
+func (m InterruptMode) Validate() error {
+	switch m {
+	case "", InterruptKeep, InterruptDiscard:
+		return nil
+	}
+	return fmt.Errorf("mode must be %q or %q, got %q", InterruptKeep, InterruptDiscard, m)
+}
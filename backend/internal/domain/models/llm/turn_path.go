@@ -0,0 +1,35 @@
+package llm
+
+import "fmt"
+
+// MaxTurnPathWindow caps the ?last=K window of GET /api/turns/{id}/path
+// (paths are loaded at most 100 turns deep anyway)
+const MaxTurnPathWindow = 100
+
+// TurnPathOptions controls how much of a root-to-turn path is loaded
+// The zero value loads turns without blocks; use DefaultTurnPathOptions for the full path with blocks.
+type TurnPathOptions struct {
+	// Last keeps only the last K turns ending at the requested turn (0 = whole path).
+	// The window is applied in the recursive query, so earlier turns are never read.
+	// If the first returned turn has a prev_turn_id, earlier turns exist.
+	Last int
+
+	// IncludeBlocks loads each turn's content blocks
+	IncludeBlocks bool
+}
+
+// DefaultTurnPathOptions returns options that load the whole path with blocks
+func DefaultTurnPathOptions() *TurnPathOptions {
+	return &TurnPathOptions{IncludeBlocks: true}
+}
+
+// Validate checks that the window is reasonable
+func (opts *TurnPathOptions) Validate() error {
+	if opts.Last < 0 {
+		return fmt.Errorf("last cannot be negative")
+	}
+	if opts.Last > MaxTurnPathWindow {
+		return fmt.Errorf("last cannot exceed %d (requested: %d)", MaxTurnPathWindow, opts.Last)
+	}
+	return nil
+}
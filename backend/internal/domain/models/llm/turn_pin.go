@@ -0,0 +1,12 @@
+package llm
+
+import "time"
+
+// TurnPin links a document to the assistant turn it was saved from (pin-to-project)
+type TurnPin struct {
+	ID         string    `json:"id"`
+	DocumentID string    `json:"document_id"`
+	TurnID     string    `json:"turn_id"`
+	ChatID     string    `json:"chat_id"` // The turn's chat, for linking back to the conversation
+	CreatedAt  time.Time `json:"created_at"`
+}
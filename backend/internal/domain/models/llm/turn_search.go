@@ -0,0 +1,83 @@
+package llm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Default turn search configuration values
+const (
+	DefaultTurnSearchLimit    = 20
+	MaxTurnSearchLimit        = 100
+	DefaultTurnSearchLanguage = "english"
+)
+
+// TurnSearchOptions configures full-text search over the blocks of a single chat
+// Only text blocks are searched (what the user actually sees in the conversation);
+// thinking, tool_use and tool_result blocks are excluded.
+type TurnSearchOptions struct {
+	// ChatID limits the search to turns in this chat (required)
+	ChatID string
+
+	// Query is the search string (required), websearch syntax (quotes, OR, -term)
+	Query string
+
+	// Limit is the maximum number of matched turns to return (default: 20, max: 100)
+	Limit int
+
+	// Language is the FTS configuration used for stemming (default: "english")
+	Language string
+}
+
+// ApplyDefaults fills in default values for unset fields
+func (opts *TurnSearchOptions) ApplyDefaults() {
+	opts.Query = strings.TrimSpace(opts.Query)
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultTurnSearchLimit
+	}
+	if opts.Language == "" {
+		opts.Language = DefaultTurnSearchLanguage
+	}
+}
+
+// Validate checks that required fields are set and values are reasonable
+func (opts *TurnSearchOptions) Validate() error {
+	if opts.ChatID == "" {
+		return fmt.Errorf("chat ID is required")
+	}
+	if opts.Query == "" {
+		return fmt.Errorf("search query cannot be empty")
+	}
+	if opts.Limit < 0 {
+		return fmt.Errorf("limit cannot be negative")
+	}
+	if opts.Limit > MaxTurnSearchLimit {
+		return fmt.Errorf("limit cannot exceed %d (requested: %d)", MaxTurnSearchLimit, opts.Limit)
+	}
+	return nil
+}
+
+// TurnSearchMatch is a single turn matching a search query
+// Each turn appears at most once, represented by its best-matching block
+type TurnSearchMatch struct {
+	TurnID    string    `json:"turn_id"`
+	Role      string    `json:"role"`
+	Snippet   string    `json:"snippet"` // ts_headline excerpt, matches wrapped in <b></b>
+	Score     float64   `json:"score"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// OnCurrentPath is true when the turn lies on the path from the root to the
+	// viewed turn, i.e. jumping to it doesn't require switching branches
+	OnCurrentPath bool `json:"on_current_path"`
+}
+
+// TurnSearchResults is the response for in-conversation find
+type TurnSearchResults struct {
+	Matches []TurnSearchMatch `json:"matches"`
+	HasMore bool              `json:"has_more"`
+
+	// ViewedTurnID is the turn whose path was used to compute on_current_path
+	// (from_turn_id if given, otherwise the chat's last_viewed_turn_id)
+	ViewedTurnID *string `json:"viewed_turn_id"`
+}
@@ -0,0 +1,44 @@
+package llm
+
+import "fmt"
+
+// MaxTurnSiblingsLimit caps one page of GET /api/turns/{id}/siblings
+const MaxTurnSiblingsLimit = 100
+
+// TurnSiblingsOptions controls how much of a turn's sibling set is loaded
+// The zero value loads nothing; use DefaultTurnSiblingsOptions for the full legacy behavior.
+type TurnSiblingsOptions struct {
+	// IncludeBlocks loads each sibling's content blocks (the bulk of the payload)
+	IncludeBlocks bool
+
+	// Limit is the page size (0 = all siblings)
+	Limit int
+
+	// Offset skips the first siblings (ordered by created_at)
+	Offset int
+}
+
+// DefaultTurnSiblingsOptions returns options that load every sibling with blocks
+func DefaultTurnSiblingsOptions() *TurnSiblingsOptions {
+	return &TurnSiblingsOptions{IncludeBlocks: true}
+}
+
+// Validate checks that pagination values are reasonable
+func (opts *TurnSiblingsOptions) Validate() error {
+	if opts.Limit < 0 {
+		return fmt.Errorf("limit cannot be negative")
+	}
+	if opts.Limit > MaxTurnSiblingsLimit {
+		return fmt.Errorf("limit cannot exceed %d (requested: %d)", MaxTurnSiblingsLimit, opts.Limit)
+	}
+	if opts.Offset < 0 {
+		return fmt.Errorf("offset cannot be negative")
+	}
+	return nil
+}
+
+// TurnSiblings is one page of a turn's siblings (including the turn itself)
+type TurnSiblings struct {
+	Turns []Turn // Ordered by created_at
+	Total int    // Number of siblings across all pages
+}
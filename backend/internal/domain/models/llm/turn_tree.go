@@ -0,0 +1,64 @@
+package llm
+
+import (
+	"fmt"
+	"time"
+)
+
+// Turn tree page size limits for GET /api/chats/{id}/tree
+const (
+	DefaultTurnTreeLimit = 500
+	MaxTurnTreeLimit     = 2000
+)
+
+// TurnTreeOptions pages through the skeleton of a chat's whole turn tree
+// Turns are ordered by (created_at, id), so a parent always comes before its children
+type TurnTreeOptions struct {
+	// After is the cursor: the ID of the last turn of the previous page ("" = first page)
+	After string
+
+	// Limit is the page size (default: 500, max: 2000)
+	Limit int
+}
+
+// ApplyDefaults fills in default values for unset fields
+func (opts *TurnTreeOptions) ApplyDefaults() {
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultTurnTreeLimit
+	}
+}
+
+// Validate checks that values are reasonable
+func (opts *TurnTreeOptions) Validate() error {
+	if opts.Limit < 0 {
+		return fmt.Errorf("limit cannot be negative")
+	}
+	if opts.Limit > MaxTurnTreeLimit {
+		return fmt.Errorf("limit cannot exceed %d (requested: %d)", MaxTurnTreeLimit, opts.Limit)
+	}
+	return nil
+}
+
+// TurnSkeleton is a turn without content: enough to render branch pickers
+type TurnSkeleton struct {
+	ID         string    `json:"id"`
+	Role       string    `json:"role"`
+	PrevTurnID *string   `json:"prev_turn_id"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	// SiblingCount is the number of turns sharing prev_turn_id (including this one);
+	// root turns are siblings of each other
+	SiblingCount int `json:"sibling_count"`
+
+	// SiblingIndex is the turn's 1-based position among its siblings by created_at ("2 of 3")
+	SiblingIndex int `json:"sibling_index"`
+}
+
+// TurnTreePage is one page of a chat's turn tree
+type TurnTreePage struct {
+	Turns   []TurnSkeleton `json:"turns"`
+	HasMore bool           `json:"has_more"`
+
+	// NextCursor is passed as after to get the next page (nil on the last page)
+	NextCursor *string `json:"next_cursor"`
+}
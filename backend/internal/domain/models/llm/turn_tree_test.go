@@ -0,0 +1,31 @@
+package llm
+
+import "testing"
+
+func TestTurnTreeOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		wantLimit int
+		wantErr   bool
+	}{
+		{name: "default limit", limit: 0, wantLimit: DefaultTurnTreeLimit},
+		{name: "custom limit", limit: 50, wantLimit: 50},
+		{name: "max limit", limit: MaxTurnTreeLimit, wantLimit: MaxTurnTreeLimit},
+		{name: "over max", limit: MaxTurnTreeLimit + 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &TurnTreeOptions{Limit: tt.limit}
+			opts.ApplyDefaults()
+			err := opts.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && opts.Limit != tt.wantLimit {
+				t.Errorf("Limit = %d, want %d", opts.Limit, tt.wantLimit)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package llm
+
+import "time"
+
+// UsageGroupBy selects how GET /api/usage groups a user's spend
+type UsageGroupBy string
+
+const (
+	UsageGroupByChat    UsageGroupBy = "chat"
+	UsageGroupByProject UsageGroupBy = "project"
+	UsageGroupByMonth   UsageGroupBy = "month"
+)
+
+// UsageQuery selects the assistant turns whose usage is summed
+type UsageQuery struct {
+	UserID   string
+	GroupBy  UsageGroupBy
+	From     *time.Time     // Inclusive lower bound on turn creation (nil = no bound)
+	To       *time.Time     // Exclusive upper bound on turn creation (nil = no bound)
+	Location *time.Location // Time zone months are bucketed in (nil = UTC)
+}
+
+// UsageTotals sums the tokens and cost of assistant turns
+type UsageTotals struct {
+	Turns         int     `json:"turns"`
+	InputTokens   int64   `json:"input_tokens"`
+	OutputTokens  int64   `json:"output_tokens"`
+	CostUSD       float64 `json:"cost_usd"`
+	UnpricedTurns int     `json:"unpriced_turns"` // Turns without a cost (model had no pricing, or failed before completing)
+}
+
+// Add adds other's counts to t
+func (t *UsageTotals) Add(other UsageTotals) {
+	t.Turns += other.Turns
+	t.InputTokens += other.InputTokens
+	t.OutputTokens += other.OutputTokens
+	t.CostUSD += other.CostUSD
+	t.UnpricedTurns += other.UnpricedTurns
+}
+
+// UsageGroup is the usage of one chat, project or month
+type UsageGroup struct {
+	Key   string `json:"key"`   // Chat ID, project ID, or month ("2026-10", in the report's time zone)
+	Label string `json:"label"` // Chat title, project name, or the month again
+	UsageTotals
+}
+
+// UsageReport is the response of GET /api/usage
+type UsageReport struct {
+	GroupBy  UsageGroupBy `json:"group_by"`
+	Timezone string       `json:"timezone"` // IANA time zone months are bucketed in
+	From     *time.Time   `json:"from,omitempty"`
+	To       *time.Time   `json:"to,omitempty"`
+	Groups   []UsageGroup `json:"groups"`
+	Total    UsageTotals  `json:"total"`
+}
+
+// UsageGranularity selects the period of the points of a usage rollup
+type UsageGranularity string
+
+const (
+	UsageGranularityDay   UsageGranularity = "day"
+	UsageGranularityMonth UsageGranularity = "month"
+)
+
+// UsageRollupQuery selects the daily usage of one chat or one project
+type UsageRollupQuery struct {
+	ChatID      string // Set for a chat's usage
+	ProjectID   string // Set for a project's usage (all its chats, including deleted ones)
+	Granularity UsageGranularity
+	From        *time.Time     // Inclusive lower bound on the day (nil = no bound)
+	To          *time.Time     // Exclusive upper bound on the day (nil = no bound)
+	Location    *time.Location // Time zone of the days (nil = UTC)
+}
+
+// UsagePoint is the usage of one model in one period
+type UsagePoint struct {
+	Period string `json:"period"` // Day ("2026-10-15") or month ("2026-10") in the rollup's time zone
+	Model  string `json:"model"`  // "" for turns that failed before a model was recorded
+	UsageTotals
+}
+
+// UsageRollup is the response of GET /api/chats/{id}/usage and GET /api/projects/{id}/usage
+type UsageRollup struct {
+	Granularity UsageGranularity `json:"granularity"`
+	Timezone    string           `json:"timezone"` // IANA time zone of the periods
+	From        *time.Time       `json:"from,omitempty"`
+	To          *time.Time       `json:"to,omitempty"`
+	Points      []UsagePoint     `json:"points"` // Oldest period first, then by model
+	Total       UsageTotals      `json:"total"`
+}
@@ -0,0 +1,53 @@
+package llm
+
+import "time"
+
+// Workflow run statuses
+const (
+	WorkflowRunActive    = "active"
+	WorkflowRunCompleted = "completed"
+)
+
+// Workflow is a project's reusable sequence of prompts, e.g. outline → chapter draft →
+// critique. Prompts may contain {{placeholder}} variables filled in when a run starts.
+type Workflow struct {
+	ID           string         `json:"id"`
+	ProjectID    string         `json:"project_id"`
+	Name         string         `json:"name"`
+	Description  string         `json:"description"`
+	SystemPrompt *string        `json:"system_prompt,omitempty"` // Applied to chats started from the workflow
+	Steps        []WorkflowStep `json:"steps"`
+	Placeholders []string       `json:"placeholders"` // Derived from the step prompts, in order of appearance
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+}
+
+// WorkflowStep is one prompt in a workflow
+type WorkflowStep struct {
+	Title  string `json:"title"`
+	Prompt string `json:"prompt"`
+}
+
+// WorkflowRun is a workflow instantiated into a chat. Steps are rendered with the run's
+// values when it starts, so later edits to the workflow don't change running chats.
+type WorkflowRun struct {
+	ID           string            `json:"id"`
+	ChatID       string            `json:"chat_id"`
+	WorkflowID   *string           `json:"workflow_id"` // nil once the workflow is deleted
+	WorkflowName string            `json:"workflow_name"`
+	Values       map[string]string `json:"values"`
+	Steps        []WorkflowStep    `json:"steps"`
+	CurrentStep  int               `json:"current_step"` // Index of the next step to send; len(Steps) when completed
+	Status       string            `json:"status"`       // "active" or "completed"
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	CompletedAt  *time.Time        `json:"completed_at,omitempty"`
+}
+
+// NextStep returns the step the user should send next (nil when the run is completed)
+func (r *WorkflowRun) NextStep() *WorkflowStep {
+	if r.CurrentStep < 0 || r.CurrentStep >= len(r.Steps) {
+		return nil
+	}
+	return &r.Steps[r.CurrentStep]
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,7 +21,7 @@ type JSONMap map[string]interface{}
 // All preferences are stored in a single JSONB column with namespaced structure
 type UserPreferences struct {
 	UserID      uuid.UUID `json:"user_id" db:"user_id"`
-	Preferences JSONMap   `json:"preferences" db:"preferences"` // Namespaced JSONB: {models, ui, editor, system_instructions, notifications}
+	Preferences JSONMap   `json:"preferences" db:"preferences"` // Namespaced JSONB: {models, ui, editor, system_instructions, notifications, data_controls, locale}
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -52,6 +53,46 @@ type NotificationPreferences struct {
 	InAppAlerts  *bool `json:"in_app_alerts"`  // Pointer to allow null
 }
 
+// DataControlsPreferences represents the data_controls namespace in preferences
+type DataControlsPreferences struct {
+	BlockedProviders          []string   `json:"blocked_providers"`           // Providers that must never receive the user's content
+	PolicyAcknowledgedVersion *string    `json:"policy_acknowledged_version"` // Content policy version the user acknowledged, null = none
+	PolicyAcknowledgedAt      *time.Time `json:"policy_acknowledged_at"`      // Set by the server when the version changes
+}
+
+// LocalePreferences represents the locale namespace in preferences
+type LocalePreferences struct {
+	Timezone string `json:"timezone"` // IANA name, e.g. "America/New_York" ("" = UTC)
+	Locale   string `json:"locale"`   // BCP 47 tag, e.g. "en-GB" ("" = the client's default)
+}
+
+// Location returns the time zone the user's dates are bucketed in (UTC when unset)
+func (lp *LocalePreferences) Location() (*time.Location, error) {
+	return LoadTimezone(lp.Timezone)
+}
+
+// LoadTimezone loads an IANA time zone name ("" = UTC). "Local" is rejected: it would
+// be the server's zone, not the user's.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if name == "Local" {
+		return nil, errors.New("unknown time zone Local")
+	}
+	return time.LoadLocation(name)
+}
+
+// BlocksProvider reports whether the user blocked sending content to provider
+func (dc *DataControlsPreferences) BlocksProvider(provider string) bool {
+	for _, blocked := range dc.BlockedProviders {
+		if blocked == provider {
+			return true
+		}
+	}
+	return false
+}
+
 // GetModels extracts the models namespace from preferences with type safety
 func (up *UserPreferences) GetModels() (*ModelsPreferences, error) {
 	if up.Preferences == nil {
@@ -142,6 +183,57 @@ func (up *UserPreferences) SetUI(ui *UIPreferences) error {
 	return nil
 }
 
+// GetDataControls extracts the data_controls namespace from preferences
+func (up *UserPreferences) GetDataControls() (*DataControlsPreferences, error) {
+	if up.Preferences == nil {
+		return &DataControlsPreferences{BlockedProviders: []string{}}, nil
+	}
+
+	dataControlsData, ok := up.Preferences["data_controls"]
+	if !ok || dataControlsData == nil {
+		return &DataControlsPreferences{BlockedProviders: []string{}}, nil
+	}
+
+	data, err := json.Marshal(dataControlsData)
+	if err != nil {
+		return nil, err
+	}
+
+	var dataControls DataControlsPreferences
+	if err := json.Unmarshal(data, &dataControls); err != nil {
+		return nil, err
+	}
+	if dataControls.BlockedProviders == nil {
+		dataControls.BlockedProviders = []string{}
+	}
+
+	return &dataControls, nil
+}
+
+// GetLocale extracts the locale namespace from preferences
+func (up *UserPreferences) GetLocale() (*LocalePreferences, error) {
+	if up.Preferences == nil {
+		return &LocalePreferences{}, nil
+	}
+
+	localeData, ok := up.Preferences["locale"]
+	if !ok || localeData == nil {
+		return &LocalePreferences{}, nil
+	}
+
+	data, err := json.Marshal(localeData)
+	if err != nil {
+		return nil, err
+	}
+
+	var locale LocalePreferences
+	if err := json.Unmarshal(data, &locale); err != nil {
+		return nil, err
+	}
+
+	return &locale, nil
+}
+
 // GetSystemInstructions extracts system_instructions from preferences
 func (up *UserPreferences) GetSystemInstructions() *string {
 	if up.Preferences == nil {
@@ -177,9 +269,11 @@ func (up *UserPreferences) SetSystemInstructions(instructions *string) {
 // UpdatePreferencesRequest represents the request to update user preferences
 // Supports partial updates via pointers - only provided fields are updated
 type UpdatePreferencesRequest struct {
-	Models              *ModelsPreferences       `json:"models"`               // Update entire models namespace
-	UI                  *UIPreferences           `json:"ui"`                   // Update entire ui namespace
-	Editor              *EditorPreferences       `json:"editor"`               // Update entire editor namespace
-	SystemInstructions  *string                  `json:"system_instructions"`  // Update system instructions (null to clear)
-	Notifications       *NotificationPreferences `json:"notifications"`        // Update entire notifications namespace
+	Models             *ModelsPreferences       `json:"models"`              // Update entire models namespace
+	UI                 *UIPreferences           `json:"ui"`                  // Update entire ui namespace
+	Editor             *EditorPreferences       `json:"editor"`              // Update entire editor namespace
+	SystemInstructions *string                  `json:"system_instructions"` // Update system instructions (null to clear)
+	Notifications      *NotificationPreferences `json:"notifications"`       // Update entire notifications namespace
+	DataControls       *DataControlsPreferences `json:"data_controls"`       // Update entire data_controls namespace (acknowledged_at is set by the server)
+	Locale             *LocalePreferences       `json:"locale"`              // Update entire locale namespace
 }
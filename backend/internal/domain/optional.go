@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Optional is a tri-state field for PATCH requests.
+// Plain pointers can only express two states (absent/nil vs value), which makes it
+// impossible to tell "leave unchanged" apart from "clear this field". Optional keeps
+// all three:
+//
+//	{}                         → IsSet() == false          (leave unchanged)
+//	{"system_prompt": null}    → IsSet() && IsNull()       (clear)
+//	{"system_prompt": "text"}  → IsSet() && !IsNull()      (update to "text")
+//
+// Use as a non-pointer struct field; encoding/json only calls UnmarshalJSON when
+// the key is present, so absent keys keep the zero value (not set).
+type Optional[T any] struct {
+	set   bool
+	null  bool
+	value T
+}
+
+// Some returns an Optional set to value
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{set: true, value: value}
+}
+
+// Null returns an Optional explicitly set to null
+func Null[T any]() Optional[T] {
+	return Optional[T]{set: true, null: true}
+}
+
+// IsSet reports whether the field was present in the request (value or null)
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// IsNull reports whether the field was explicitly set to null
+func (o Optional[T]) IsNull() bool {
+	return o.set && o.null
+}
+
+// HasValue reports whether the field was set to a non-null value
+func (o Optional[T]) HasValue() bool {
+	return o.set && !o.null
+}
+
+// Value returns the value and whether one was provided (false for absent or null)
+func (o Optional[T]) Value() (T, bool) {
+	return o.value, o.HasValue()
+}
+
+// Ptr returns a pointer to the value, or nil when absent or null.
+// Useful for assigning to nullable model fields after checking IsSet().
+func (o Optional[T]) Ptr() *T {
+	if !o.HasValue() {
+		return nil
+	}
+	v := o.value
+	return &v
+}
+
+// IsZero lets `json:",omitzero"` omit fields that were never set
+func (o Optional[T]) IsZero() bool {
+	return !o.set
+}
+
+// UnmarshalJSON marks the field as set and decodes null or the value
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		o.null = true
+		var zero T
+		o.value = zero
+		return nil
+	}
+	o.null = false
+	return json.Unmarshal(data, &o.value)
+}
+
+// MarshalJSON encodes null for absent/null fields and the value otherwise
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.HasValue() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
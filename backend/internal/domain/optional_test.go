@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOptional_UnmarshalJSON(t *testing.T) {
+	type patch struct {
+		Prompt Optional[string] `json:"prompt,omitzero"`
+	}
+
+	tests := []struct {
+		name      string
+		body      string
+		wantSet   bool
+		wantNull  bool
+		wantValue string
+	}{
+		{name: "absent", body: `{}`},
+		{name: "null", body: `{"prompt": null}`, wantSet: true, wantNull: true},
+		{name: "empty string", body: `{"prompt": ""}`, wantSet: true},
+		{name: "value", body: `{"prompt": "be brief"}`, wantSet: true, wantValue: "be brief"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p patch
+			if err := json.Unmarshal([]byte(tt.body), &p); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.Prompt.IsSet() != tt.wantSet {
+				t.Errorf("IsSet() = %v, want %v", p.Prompt.IsSet(), tt.wantSet)
+			}
+			if p.Prompt.IsNull() != tt.wantNull {
+				t.Errorf("IsNull() = %v, want %v", p.Prompt.IsNull(), tt.wantNull)
+			}
+			if v, _ := p.Prompt.Value(); v != tt.wantValue {
+				t.Errorf("Value() = %q, want %q", v, tt.wantValue)
+			}
+			if tt.wantNull && p.Prompt.Ptr() != nil {
+				t.Errorf("Ptr() = %v, want nil", *p.Prompt.Ptr())
+			}
+		})
+	}
+
+	t.Run("wrong type is rejected", func(t *testing.T) {
+		var p patch
+		if err := json.Unmarshal([]byte(`{"prompt": 42}`), &p); err == nil {
+			t.Fatal("expected error for non-string value")
+		}
+	})
+}
+
+func TestOptional_MarshalJSON(t *testing.T) {
+	type patch struct {
+		Prompt Optional[string] `json:"prompt,omitzero"`
+	}
+
+	for _, tt := range []struct {
+		in   patch
+		want string
+	}{
+		{patch{}, `{}`},
+		{patch{Prompt: Null[string]()}, `{"prompt":null}`},
+		{patch{Prompt: Some("x")}, `{"prompt":"x"}`},
+	} {
+		got, err := json.Marshal(tt.in)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("Marshal() = %s, want %s", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// DocumentAnalyticsRepository caches computed document analytics
+type DocumentAnalyticsRepository interface {
+	// GetAnalytics returns the cached analytics of a document, whatever content they describe
+	// Returns ErrNotFound if none were cached
+	GetAnalytics(ctx context.Context, documentID string) (*docsystem.DocumentAnalytics, error)
+
+	// SaveAnalytics replaces a document's cached analytics
+	SaveAnalytics(ctx context.Context, analytics *docsystem.DocumentAnalytics) error
+}
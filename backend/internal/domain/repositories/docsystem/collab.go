@@ -0,0 +1,33 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// DocumentCollabRepository stores the Yjs update logs and revisions of collaboratively
+// edited documents
+type DocumentCollabRepository interface {
+	// AppendUpdate adds an update to the document's log and returns its seq
+	// Returns ErrNotFound if the document doesn't exist
+	AppendUpdate(ctx context.Context, documentID string, data []byte) (int64, error)
+
+	// ListUpdates returns the document's update log in seq order
+	ListUpdates(ctx context.Context, documentID string) ([]docsystem.CollabUpdate, error)
+
+	// DeleteUpdatesThrough removes the document's updates with seq <= throughSeq
+	// (compaction; run it with the AppendUpdate of the replacing state in a transaction)
+	DeleteUpdatesThrough(ctx context.Context, documentID string, throughSeq int64) error
+
+	// CreateRevision stores a revision and deletes the document's revisions beyond the
+	// newest keep
+	CreateRevision(ctx context.Context, revision *docsystem.DocumentRevision, keep int) error
+
+	// ListRevisions returns the document's newest revisions first, without content
+	ListRevisions(ctx context.Context, documentID string, limit int) ([]docsystem.DocumentRevision, error)
+
+	// GetRevision returns a revision with its content
+	// Returns ErrNotFound if the document has no such revision
+	GetRevision(ctx context.Context, documentID, revisionID string) (*docsystem.DocumentRevision, error)
+}
@@ -18,6 +18,10 @@ type DocumentRepository interface {
 	// Use when authorization is handled separately (e.g., by ResourceAuthorizer)
 	GetByIDOnly(ctx context.Context, id string) (*docsystem.Document, error)
 
+	// GetOwnerID returns the user owning a document (its project's owner, denormalized onto the row)
+	// Returns domain.ErrNotFound if the document or its project is deleted
+	GetOwnerID(ctx context.Context, id string) (string, error)
+
 	// GetByPath retrieves a document by its path (e.g., ".skills/cw-prose-writing/SKILL.md")
 	GetByPath(ctx context.Context, path string, projectID string) (*docsystem.Document, error)
 
@@ -27,6 +31,10 @@ type DocumentRepository interface {
 	// Delete deletes a document
 	Delete(ctx context.Context, id, projectID string) error
 
+	// DeleteByFolderTree deletes all documents in a folder and its descendant folders
+	// in one statement. Returns the number of documents deleted.
+	DeleteByFolderTree(ctx context.Context, folderID, projectID string) (int64, error)
+
 	// DeleteAllByProject deletes all documents in a project
 	DeleteAllByProject(ctx context.Context, projectID string) error
 
@@ -36,6 +44,10 @@ type DocumentRepository interface {
 	// GetPath computes the display path for a document
 	GetPath(ctx context.Context, doc *docsystem.Document) (string, error)
 
+	// GetPaths computes display paths for many documents of one project in a single query
+	// Returns document ID → path
+	GetPaths(ctx context.Context, projectID string, docs []docsystem.Document) (map[string]string, error)
+
 	// GetAllMetadataByProject retrieves all document metadata in a project (no content)
 	GetAllMetadataByProject(ctx context.Context, projectID string) ([]docsystem.Document, error)
 
@@ -0,0 +1,35 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// ExportJobRepository defines data access operations for export jobs
+type ExportJobRepository interface {
+	// Create inserts a pending job (sets ID, Status and CreatedAt)
+	Create(ctx context.Context, job *docsystem.ExportJob) error
+
+	// Get retrieves a job without its file
+	// Returns ErrNotFound if it doesn't exist
+	Get(ctx context.Context, id string) (*docsystem.ExportJob, error)
+
+	// MarkRunning moves a pending job to running
+	MarkRunning(ctx context.Context, id string) error
+
+	// Complete records the output file (already in object storage under file.StorageKey)
+	// and marks the job completed
+	Complete(ctx context.Context, id string, file *docsystem.ExportFile) error
+
+	// Fail marks the job failed with a message
+	Fail(ctx context.Context, id, message string) error
+
+	// FailUnfinished fails every pending or running job (e.g. after a restart
+	// interrupted them). Returns the number of jobs failed.
+	FailUnfinished(ctx context.Context, message string) (int64, error)
+
+	// GetFile returns a completed job's output: StorageKey, or Data for jobs completed
+	// before files moved to object storage. Returns ErrNotFound if the job has no file.
+	GetFile(ctx context.Context, id string) (*docsystem.ExportFile, error)
+}
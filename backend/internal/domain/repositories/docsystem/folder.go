@@ -24,6 +24,10 @@ type FolderRepository interface {
 	// Delete deletes a folder
 	Delete(ctx context.Context, id, projectID string) error
 
+	// DeleteTree deletes a folder and all of its descendant folders in one statement
+	// Returns the number of folders deleted (including the root)
+	DeleteTree(ctx context.Context, id, projectID string) (int64, error)
+
 	// ListChildren lists immediate child folders
 	ListChildren(ctx context.Context, folderID *string, projectID string) ([]docsystem.Folder, error)
 
@@ -35,4 +39,8 @@ type FolderRepository interface {
 
 	// GetAllByProject retrieves all folders in a project (flat list)
 	GetAllByProject(ctx context.Context, projectID string) ([]docsystem.Folder, error)
+
+	// GetTree retrieves the project's folders and documents (metadata only) in one query,
+	// with subtree aggregates on folders. maxDepth > 0 omits entries deeper than maxDepth.
+	GetTree(ctx context.Context, projectID string, maxDepth int) ([]docsystem.TreeEntry, error)
 }
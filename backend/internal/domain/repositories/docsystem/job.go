@@ -0,0 +1,40 @@
+package docsystem
+
+import (
+	"context"
+	"encoding/json"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// JobRepository defines data access operations for the background job queue
+type JobRepository interface {
+	// Create inserts a pending job (sets ID, Status and CreatedAt)
+	Create(ctx context.Context, job *docsystem.Job) error
+
+	// Get retrieves a job
+	// Returns ErrNotFound if it doesn't exist
+	Get(ctx context.Context, id string) (*docsystem.Job, error)
+
+	// ClaimNext moves the oldest pending job to running and returns it, skipping jobs
+	// other workers hold. Returns ErrNotFound if no job is pending.
+	ClaimNext(ctx context.Context) (*docsystem.Job, error)
+
+	// UpdateProgress records how many files a running job has processed and its
+	// per-file errors so far
+	UpdateProgress(ctx context.Context, id string, filesProcessed int, errors []docsystem.JobError) error
+
+	// Complete records the final progress and result and marks the job completed
+	Complete(ctx context.Context, id string, filesProcessed int, errors []docsystem.JobError, result json.RawMessage) error
+
+	// Release returns a running job to the queue with its progress reset (e.g. when the
+	// server shuts down mid-job and the job's work was rolled back)
+	Release(ctx context.Context, id string) error
+
+	// Fail marks the job failed with a message
+	Fail(ctx context.Context, id, message string) error
+
+	// FailRunning fails every running job (e.g. after a restart interrupted them).
+	// Pending jobs stay queued. Returns the number of jobs failed.
+	FailRunning(ctx context.Context, message string) (int64, error)
+}
@@ -14,8 +14,9 @@ type ProjectRepository interface {
 	// GetByID retrieves a project by ID
 	GetByID(ctx context.Context, id, userID string) (*docsystem.Project, error)
 
-	// List retrieves all projects for a user, ordered by updated_at DESC
-	List(ctx context.Context, userID string) ([]docsystem.Project, error)
+	// List retrieves all projects for a user, ordered by updated_at DESC, with the
+	// summaries selected in opts (nil = none) aggregated in the same query
+	List(ctx context.Context, userID string, opts *docsystem.ProjectListOptions) ([]docsystem.Project, error)
 
 	// Update updates a project's name and updated_at timestamp
 	Update(ctx context.Context, project *docsystem.Project) error
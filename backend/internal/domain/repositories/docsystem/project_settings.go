@@ -0,0 +1,42 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// ProjectSettingsRepository defines data access operations for project settings
+type ProjectSettingsRepository interface {
+	// Get retrieves a project's settings (defaults if none were saved)
+	// Returns ErrNotFound if the project doesn't exist or isn't owned by userID
+	Get(ctx context.Context, projectID, userID string) (*docsystem.ProjectSettings, error)
+
+	// Upsert saves all settings, including the project's system_prompt, and sets UpdatedAt
+	// The style profile is left unchanged (see SetStyleProfile)
+	// Documents are re-tagged when the search language changes
+	// Returns ErrNotFound if the project doesn't exist or isn't owned by userID
+	Upsert(ctx context.Context, userID string, settings *docsystem.ProjectSettings) error
+
+	// SetStyleProfile replaces the project's style profile (nil clears it)
+	// Ownership is not checked: callers authorize first, and the analysis job has no user
+	// Returns ErrNotFound if the project doesn't exist
+	SetStyleProfile(ctx context.Context, projectID string, profile *docsystem.StyleProfile) error
+
+	// StartStyleProfile stores an analyzing profile unless an analysis is already running,
+	// in one statement so concurrent starts can't both succeed
+	// Returns ErrConflict if one is running, ErrNotFound if the project doesn't exist
+	StartStyleProfile(ctx context.Context, projectID string, profile *docsystem.StyleProfile) error
+
+	// FinishStyleProfile stores the outcome of the analysis with ID analysisID, only if the
+	// project's profile is still that analysis and still analyzing
+	// Returns false if it was deleted or replaced meanwhile (nothing is written)
+	FinishStyleProfile(ctx context.Context, projectID, analysisID string, profile *docsystem.StyleProfile) (bool, error)
+
+	// FailAnalyzingStyleProfiles marks profiles still being analyzed as failed with message
+	// Returns the number of profiles failed
+	FailAnalyzingStyleProfiles(ctx context.Context, message string) (int64, error)
+
+	// SearchLanguageExists reports whether language is an installed text search configuration (pg_ts_config)
+	SearchLanguageExists(ctx context.Context, language string) (bool, error)
+}
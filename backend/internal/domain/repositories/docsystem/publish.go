@@ -0,0 +1,38 @@
+package docsystem
+
+import (
+	"context"
+	"time"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// PublishRepository defines data access operations for publish targets and their deliveries
+type PublishRepository interface {
+	// CreateTarget inserts a target (sets ID and timestamps)
+	// Returns ErrConflict if the project already has a target with this name
+	CreateTarget(ctx context.Context, target *docsystem.PublishTarget) error
+
+	// GetTarget retrieves a target scoped to its project
+	// Returns ErrNotFound if it doesn't exist in the project
+	GetTarget(ctx context.Context, targetID, projectID string) (*docsystem.PublishTarget, error)
+
+	// ListTargets returns a project's targets ordered by name
+	ListTargets(ctx context.Context, projectID string) ([]docsystem.PublishTarget, error)
+
+	// ListOnChangeTargets returns every target that publishes on document changes
+	ListOnChangeTargets(ctx context.Context) ([]docsystem.PublishTarget, error)
+
+	// UpdateTarget saves a target's settings
+	UpdateTarget(ctx context.Context, target *docsystem.PublishTarget) error
+
+	// DeleteTarget removes a target and its delivery log
+	DeleteTarget(ctx context.Context, targetID, projectID string) error
+
+	// RecordAttempt stores the outcome of a publish attempt on the target and appends
+	// the delivery to its log, keeping the newest keepDeliveries entries
+	RecordAttempt(ctx context.Context, delivery *docsystem.PublishDelivery, fingerprint string, attemptedAt time.Time, keepDeliveries int) error
+
+	// ListDeliveries returns a target's delivery log, newest first
+	ListDeliveries(ctx context.Context, targetID string, limit int) ([]docsystem.PublishDelivery, error)
+}
@@ -0,0 +1,42 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// SnapshotRepository defines data access operations for project snapshots
+type SnapshotRepository interface {
+	// Create captures the project's current tree (content stored in content_blobs)
+	Create(ctx context.Context, projectID, name string) (*docsystem.Snapshot, error)
+
+	// List returns a project's snapshots, newest first
+	List(ctx context.Context, projectID string) ([]docsystem.Snapshot, error)
+
+	// Get retrieves a snapshot scoped to its project
+	// Returns ErrNotFound if it doesn't exist in the project
+	Get(ctx context.Context, snapshotID, projectID string) (*docsystem.Snapshot, error)
+
+	// GetEntries returns a snapshot's folders and documents, ordered by path
+	GetEntries(ctx context.Context, snapshotID string) ([]docsystem.SnapshotEntry, error)
+
+	// CurrentEntries returns the project's live tree in the same shape as GetEntries
+	CurrentEntries(ctx context.Context, projectID string) ([]docsystem.SnapshotEntry, error)
+
+	// GetDocumentContent returns a document's content as captured in a snapshot
+	// Returns ErrNotFound if the document isn't in the snapshot
+	GetDocumentContent(ctx context.Context, snapshotID, documentID string) (string, error)
+
+	// GetContents returns content by hash, from content_blobs or the project's live documents
+	GetContents(ctx context.Context, projectID string, hashes []string) (map[string]string, error)
+
+	// CompressBlobs compresses up to limit uncompressed blobs of at least minBytes
+	// (reads stay transparent). Returns the number of blobs compressed.
+	CompressBlobs(ctx context.Context, minBytes, limit int) (int, error)
+
+	// Undelete revives the most recently soft-deleted folder or document (kind) named
+	// name under parentID, so a restore keeps its ID (and the unique name slot it holds).
+	// Returns "" when there is none.
+	Undelete(ctx context.Context, kind, projectID string, parentID *string, name string) (string, error)
+}
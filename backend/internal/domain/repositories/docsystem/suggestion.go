@@ -0,0 +1,24 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// SuggestionRepository defines data access operations for inline document suggestions
+type SuggestionRepository interface {
+	// CreateSuggestion inserts a suggestion (sets ID and CreatedAt)
+	CreateSuggestion(ctx context.Context, suggestion *docsystem.DocumentSuggestion) error
+
+	// ListSuggestions returns a document's suggestions, oldest first
+	ListSuggestions(ctx context.Context, documentID string) ([]docsystem.DocumentSuggestion, error)
+
+	// GetSuggestion retrieves a suggestion scoped to its document
+	// Returns ErrNotFound if it doesn't exist on the document
+	GetSuggestion(ctx context.Context, suggestionID, documentID string) (*docsystem.DocumentSuggestion, error)
+
+	// DeleteSuggestion removes a suggestion scoped to its document
+	// Returns ErrNotFound if it doesn't exist on the document
+	DeleteSuggestion(ctx context.Context, suggestionID, documentID string) error
+}
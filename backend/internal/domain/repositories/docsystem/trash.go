@@ -0,0 +1,33 @@
+package docsystem
+
+import (
+	"context"
+	"time"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// TrashRepository defines data access for soft-deleted documents, folders and chats
+type TrashRepository interface {
+	// ListTrash lists a project's soft-deleted documents and folders and the user's
+	// soft-deleted chats, most recently deleted first
+	ListTrash(ctx context.Context, projectID, userID string) ([]docsystem.TrashItem, error)
+
+	// GetTrashItem retrieves a soft-deleted item by type and ID
+	// Returns domain.ErrNotFound if it doesn't exist or isn't deleted
+	GetTrashItem(ctx context.Context, itemType docsystem.TrashItemType, id string) (*docsystem.TrashItem, error)
+
+	// RestoreDocument clears a document's deleted_at
+	RestoreDocument(ctx context.Context, id string) error
+
+	// RestoreFolderTree restores a folder plus the subfolders and documents deleted with it
+	// (same deleted_at); items deleted separately before it stay in the trash
+	RestoreFolderTree(ctx context.Context, id string, deletedAt time.Time) (folders, documents int64, err error)
+
+	// RestoreChat clears the deleted_at of a chat owned by userID
+	RestoreChat(ctx context.Context, id, userID string) error
+
+	// PurgeDeleted hard-deletes documents and folders soft-deleted before deletedBefore
+	// Returns the number of documents and folders removed
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
@@ -0,0 +1,27 @@
+package llm
+
+import (
+	"context"
+	"time"
+
+	"meridian/internal/domain/models/llm"
+)
+
+// AbuseFlagRepository defines data access operations for abuse flags
+type AbuseFlagRepository interface {
+	// CreateFlag records a flag (sets ID and CreatedAt)
+	CreateFlag(ctx context.Context, flag *llm.AbuseFlag) error
+
+	// GetActiveThrottle returns the unresolved throttling flag of the user that expires last,
+	// if it hasn't expired at now
+	// Returns domain.ErrNotFound if the user isn't throttled
+	GetActiveThrottle(ctx context.Context, userID string, now time.Time) (*llm.AbuseFlag, error)
+
+	// ListFlags returns flags newest first, optionally only one user's and only unresolved ones
+	ListFlags(ctx context.Context, userID string, unresolvedOnly bool, limit int) ([]llm.AbuseFlag, error)
+
+	// ResolveFlag marks a flag resolved (lifting its throttle); resolving it again keeps
+	// the first resolved_at
+	// Returns domain.ErrNotFound if it doesn't exist
+	ResolveFlag(ctx context.Context, id string, resolvedAt time.Time) (*llm.AbuseFlag, error)
+}
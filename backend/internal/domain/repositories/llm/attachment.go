@@ -0,0 +1,27 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/llm"
+)
+
+// AttachmentRepository defines data access operations for uploaded attachments
+type AttachmentRepository interface {
+	// CreateAttachment records an uploaded file (sets ID and CreatedAt) if the project's
+	// attachments stay within quotaBytes (0 = no limit). Creates for one project are
+	// serialized by a lock held until the transaction ends, so call it inside ExecTx.
+	// Returns *llm.QuotaExceededError if the file doesn't fit
+	CreateAttachment(ctx context.Context, attachment *llm.Attachment, quotaBytes int64) error
+
+	// GetAttachment retrieves an attachment
+	// Returns domain.ErrNotFound if it doesn't exist
+	GetAttachment(ctx context.Context, id string) (*llm.Attachment, error)
+
+	// DeleteAttachment removes an attachment's record
+	// Returns domain.ErrNotFound if it doesn't exist
+	DeleteAttachment(ctx context.Context, id string) error
+
+	// GetProjectUsageBytes returns the total size of a project's attachments
+	GetProjectUsageBytes(ctx context.Context, projectID string) (int64, error)
+}
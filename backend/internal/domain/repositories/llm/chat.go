@@ -2,6 +2,7 @@ package llm
 
 import (
 	"context"
+	"time"
 
 	"meridian/internal/domain/models/llm"
 )
@@ -15,14 +16,19 @@ type ChatRepository interface {
 	// Returns domain.ErrNotFound if not found
 	GetChat(ctx context.Context, chatID, userID string) (*llm.Chat, error)
 
+	// GetChatByIdempotencyKey retrieves the live chat a cold-start turn created with the key
+	// Returns domain.ErrNotFound if not found
+	GetChatByIdempotencyKey(ctx context.Context, projectID, userID, key string) (*llm.Chat, error)
+
 	// GetChatByIDOnly retrieves a chat by UUID only (no user scoping)
 	// Used by ResourceAuthorizer when authorization is handled separately
 	// Returns domain.ErrNotFound if not found
 	GetChatByIDOnly(ctx context.Context, chatID string) (*llm.Chat, error)
 
-	// ListChatsByProject retrieves all chats for a project
+	// ListChatsByProject retrieves all chats for a project, with the summaries selected in opts
+	// (nil = none) computed in the same query
 	// Returns empty slice if no chats found
-	ListChatsByProject(ctx context.Context, projectID, userID string) ([]llm.Chat, error)
+	ListChatsByProject(ctx context.Context, projectID, userID string, opts *llm.ChatListOptions) ([]llm.Chat, error)
 
 	// UpdateChat updates a chat's mutable fields (title, last_viewed_turn_id, updated_at)
 	// Returns domain.ErrNotFound if not found
@@ -41,4 +47,15 @@ type ChatRepository interface {
 	// Performance: <100ms even for 1000+ turns
 	// Used by frontend to detect gaps, new branches, and structural changes
 	GetChatTree(ctx context.Context, chatID, userID string) (*llm.ChatTree, error)
+
+	// GetTurnTree retrieves one page of turn skeletons (no content) of a chat's whole tree,
+	// ordered by (created_at, id), with each turn's sibling count and position
+	// Returns domain.ErrNotFound if the chat isn't the user's, domain.ErrValidation if
+	// opts.After isn't a turn of the chat
+	GetTurnTree(ctx context.Context, chatID, userID string, opts *llm.TurnTreeOptions) (*llm.TurnTreePage, error)
+
+	// PurgeDeletedChats hard-deletes chats soft-deleted before deletedBefore, and chats of
+	// projects soft-deleted before it; their turns and blocks are removed by ON DELETE CASCADE
+	// Returns the number of chats purged
+	PurgeDeletedChats(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
@@ -0,0 +1,17 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/llm"
+)
+
+// CritiqueRepository defines data access operations for turn critiques
+type CritiqueRepository interface {
+	// SaveCritique inserts the turn's critique or replaces the existing one (sets ID and CreatedAt)
+	SaveCritique(ctx context.Context, critique *llm.TurnCritique) error
+
+	// GetCritique retrieves a turn's critique
+	// Returns domain.ErrNotFound if the turn has none
+	GetCritique(ctx context.Context, turnID string) (*llm.TurnCritique, error)
+}
@@ -0,0 +1,59 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/llm"
+)
+
+// EvalRepository defines data access operations for eval cases, runs and results
+type EvalRepository interface {
+	// CreateCase inserts a case (sets ID and timestamps)
+	// Returns ErrConflict if the project already has a case with this name
+	CreateCase(ctx context.Context, evalCase *llm.EvalCase) error
+
+	// GetCase retrieves a case scoped to its project
+	// Returns ErrNotFound if it doesn't exist in the project
+	GetCase(ctx context.Context, caseID, projectID string) (*llm.EvalCase, error)
+
+	// ListCases returns a project's cases ordered by name
+	ListCases(ctx context.Context, projectID string) ([]llm.EvalCase, error)
+
+	// UpdateCase saves a case's name, prompt and criteria (updates UpdatedAt)
+	// Returns ErrNotFound or ErrConflict (duplicate name)
+	UpdateCase(ctx context.Context, evalCase *llm.EvalCase) error
+
+	// DeleteCase removes a case and its results
+	DeleteCase(ctx context.Context, caseID, projectID string) error
+
+	// CreateRun inserts a run (sets ID and CreatedAt)
+	CreateRun(ctx context.Context, run *llm.EvalRun) error
+
+	// SetRunJob links a run to the job executing it
+	SetRunJob(ctx context.Context, runID, jobID string) error
+
+	// DeleteRun removes a run and its results
+	DeleteRun(ctx context.Context, runID string) error
+
+	// GetRun retrieves a run scoped to its project, with its job's status (without results)
+	// Returns ErrNotFound if it doesn't exist in the project
+	GetRun(ctx context.Context, runID, projectID string) (*llm.EvalRun, error)
+
+	// ListRuns returns a project's runs, newest first (without results)
+	ListRuns(ctx context.Context, projectID string, limit int) ([]llm.EvalRun, error)
+
+	// CompleteRun saves a run's per-model summary
+	CompleteRun(ctx context.Context, runID string, summary []llm.EvalModelSummary) error
+
+	// SaveResult inserts a result (sets ID and CreatedAt)
+	SaveResult(ctx context.Context, result *llm.EvalResult) error
+
+	// DeleteResults removes a run's results, so a requeued run starts afresh
+	DeleteResults(ctx context.Context, runID string) error
+
+	// ListResults returns a run's results ordered by case name, then model
+	ListResults(ctx context.Context, runID string) ([]llm.EvalResult, error)
+
+	// ListCaseResults returns a case's results across runs, newest first
+	ListCaseResults(ctx context.Context, caseID string, limit int) ([]llm.EvalResult, error)
+}
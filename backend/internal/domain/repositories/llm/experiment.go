@@ -0,0 +1,40 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/llm"
+)
+
+// ExperimentRepository defines data access for experiments, the variants recorded on
+// turns, and the turn feedback experiments are evaluated with
+type ExperimentRepository interface {
+	// CreateExperiment inserts an experiment (sets ID and timestamps)
+	// Returns ErrConflict if an experiment with this name exists
+	CreateExperiment(ctx context.Context, experiment *llm.Experiment) error
+
+	// GetExperiment retrieves an experiment
+	// Returns ErrNotFound if it doesn't exist
+	GetExperiment(ctx context.Context, experimentID string) (*llm.Experiment, error)
+
+	// ListExperiments returns all experiments, newest first
+	ListExperiments(ctx context.Context) ([]llm.Experiment, error)
+
+	// ListActiveExperiments returns the active experiments, oldest first (the order
+	// their variants are applied in)
+	ListActiveExperiments(ctx context.Context) ([]llm.Experiment, error)
+
+	// UpdateExperimentStatus sets an experiment's status
+	// Returns ErrNotFound if it doesn't exist
+	UpdateExperimentStatus(ctx context.Context, experimentID, status string) error
+
+	// RecordTurnVariants stores the variants an assistant turn ran with
+	RecordTurnVariants(ctx context.Context, turnID string, assignments []llm.ExperimentAssignment) error
+
+	// GetVariantResults aggregates usage and feedback of an experiment's turns by variant
+	GetVariantResults(ctx context.Context, experimentID string) ([]llm.ExperimentVariantResult, error)
+
+	// SetTurnFeedback sets (or clears, with nil) a turn's rating
+	// Returns ErrNotFound if the turn doesn't exist
+	SetTurnFeedback(ctx context.Context, turnID string, rating *int) error
+}
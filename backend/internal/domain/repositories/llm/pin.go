@@ -0,0 +1,17 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/llm"
+)
+
+// PinRepository defines data access operations for turn pins
+type PinRepository interface {
+	// CreatePin records that a document was created from a turn (sets ID, ChatID and CreatedAt)
+	CreatePin(ctx context.Context, pin *llm.TurnPin) error
+
+	// GetPinByDocument retrieves the pin of a document
+	// Returns domain.ErrNotFound if the document wasn't created from a turn
+	GetPinByDocument(ctx context.Context, documentID string) (*llm.TurnPin, error)
+}
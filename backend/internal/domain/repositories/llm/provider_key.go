@@ -0,0 +1,25 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/llm"
+)
+
+// ProviderKeyRepository defines data access operations for users' provider API keys
+type ProviderKeyRepository interface {
+	// UpsertKey stores a user's key for a provider, replacing any existing one
+	// (sets ID, CreatedAt and UpdatedAt)
+	UpsertKey(ctx context.Context, key *llm.UserProviderKey) error
+
+	// ListKeys returns a user's keys ordered by provider
+	ListKeys(ctx context.Context, userID string) ([]llm.UserProviderKey, error)
+
+	// GetKey returns a user's key for a provider
+	// Returns domain.ErrNotFound if the user has no key for it
+	GetKey(ctx context.Context, userID, provider string) (*llm.UserProviderKey, error)
+
+	// DeleteKey removes a user's key for a provider
+	// Returns domain.ErrNotFound if the user has no key for it
+	DeleteKey(ctx context.Context, userID, provider string) error
+}
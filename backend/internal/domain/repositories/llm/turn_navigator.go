@@ -14,10 +14,15 @@ type TurnNavigator interface {
 	// Uses recursive CTE with depth limit
 	GetTurnPath(ctx context.Context, turnID string) ([]llm.Turn, error)
 
-	// GetTurnSiblings retrieves all sibling turns (including self) for a given turn
+	// GetTurnPathWindow retrieves only the last turns (at most last) of the path ending at a turn
+	// The window limits the recursive CTE itself; earlier turns are never read
+	// Returns turns in path order, ending with the specified turn
+	GetTurnPathWindow(ctx context.Context, turnID string, last int) ([]llm.Turn, error)
+
+	// GetTurnSiblings retrieves sibling turns (including self) for a given turn
 	// Siblings are turns that share the same prev_turn_id (alternative conversation branches)
-	// Returns turns with blocks nested, ordered by created_at
-	GetTurnSiblings(ctx context.Context, turnID string) ([]llm.Turn, error)
+	// Returns one page ordered by created_at (blocks nested if opts.IncludeBlocks) and the total count
+	GetTurnSiblings(ctx context.Context, turnID string, opts *llm.TurnSiblingsOptions) (*llm.TurnSiblings, error)
 
 	// GetSiblingsForTurns retrieves sibling turn IDs for multiple turns in a single query (batch operation)
 	// Returns a map of turn ID to sibling IDs (turns with same prev_turn_id)
@@ -27,7 +32,8 @@ type TurnNavigator interface {
 	// GetPaginatedTurns retrieves turns and blocks for a chat in paginated fashion
 	// Follows path-based navigation (prev_turn_id chains)
 	// Direction: "before" (follow prev_turn_id backwards), "after" (follow children forward), "both" (split limit)
-	// When direction is "after" and multiple children exist, follows the most recent child (latest created_at)
+	// When direction is "after" and multiple children exist, follows the active branch: the child the user last
+	// viewed or created there, else the most recent child (latest created_at)
 	// fromTurnID: starting point (optional - defaults to chat.last_viewed_turn_id)
 	// Returns turns with blocks in a single response, plus has_more flags for pagination
 	GetPaginatedTurns(ctx context.Context, chatID, userID string, fromTurnID *string, limit int, direction string, updateLastViewed bool) (*llm.PaginatedTurnsResponse, error)
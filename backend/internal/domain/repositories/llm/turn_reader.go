@@ -10,9 +10,19 @@ import (
 // Used by components that only need to query turns/blocks
 type TurnReader interface {
 	// GetTurn retrieves a turn by ID
-	// Returns domain.ErrNotFound if not found
+	// Returns domain.ErrNotFound if not found or its chat is soft-deleted
 	GetTurn(ctx context.Context, turnID string) (*llm.Turn, error)
 
+	// GetTurns retrieves turns by ID in a single query, in the order of turnIDs
+	// Turns that don't exist or belong to a soft-deleted chat are absent
+	GetTurns(ctx context.Context, turnIDs []string) ([]llm.Turn, error)
+
+	// GetTurnOwnerIDs maps turn IDs to their owning user in a single query, for batch
+	// authorization. The owner is read from the turn row; chats and projects are joined
+	// only to check they aren't deleted.
+	// Turns that don't exist or belong to a soft-deleted chat or project are absent from the map
+	GetTurnOwnerIDs(ctx context.Context, turnIDs []string) (map[string]string, error)
+
 	// GetRootTurns retrieves all root turns for a specific chat
 	// Root turns are turns where prev_turn_id IS NULL
 	// Returns empty slice if no root turns found
@@ -26,4 +36,23 @@ type TurnReader interface {
 	// Returns a map of turn ID to blocks, ordered by sequence within each turn
 	// This eliminates N+1 query problems when loading many turns with their blocks
 	GetTurnBlocksForTurns(ctx context.Context, turnIDs []string) (map[string][]llm.TurnBlock, error)
+
+	// GetStreamIndex retrieves the event index of a turn's stream
+	// Returns nil while the turn streams, and for turns streamed before the index existed
+	GetStreamIndex(ctx context.Context, turnID string) (*llm.StreamIndex, error)
+
+	// SearchTurns performs full-text search over the text blocks of a chat
+	// Returns at most one match per turn (best-ranked block), ordered by relevance
+	// hasMore reports whether more matches exist beyond opts.Limit
+	// OnCurrentPath is not populated (requires the viewed path, see ConversationService)
+	SearchTurns(ctx context.Context, opts *llm.TurnSearchOptions) (matches []llm.TurnSearchMatch, hasMore bool, err error)
+
+	// RecallTurns performs full-text search over the text blocks of a project's other chats
+	// Returns at most one match per turn (best-ranked block), ordered by relevance
+	RecallTurns(ctx context.Context, opts *llm.ConversationRecallOptions) ([]llm.RecalledTurn, error)
+
+	// SearchChats performs full-text search over the text blocks of a user's chats in a project
+	// Returns at most one match per turn (best-ranked block), ordered by relevance
+	// hasMore reports whether more matches exist beyond opts.Offset+opts.Limit
+	SearchChats(ctx context.Context, opts *llm.ChatSearchOptions) (matches []llm.ChatSearchMatch, hasMore bool, err error)
 }
@@ -22,18 +22,31 @@ type TurnWriter interface {
 	// Handles JSONB metadata for assistant blocks (thinking, tool_use)
 	CreateTurnBlocks(ctx context.Context, blocks []llm.TurnBlock) error
 
+	// DeleteTurnBlocks deletes all blocks of a turn
+	// Used when an interrupted turn's partial content is discarded
+	DeleteTurnBlocks(ctx context.Context, turnID string) error
+
 	// UpdateTurnStatus updates a turn's status and completion time
-	// Used for streaming state management
+	// Used for streaming state management. A final status (complete, cancelled) adds an
+	// assistant turn's usage to the daily rollups.
 	UpdateTurnStatus(ctx context.Context, turnID, status string, completedAt *llm.Turn) error
 
 	// UpdateTurn updates a turn's fields (status, tokens, model, error, etc.)
 	UpdateTurn(ctx context.Context, turn *llm.Turn) error
 
 	// UpdateTurnError updates a turn's error message and sets status to "error"
-	// Used during streaming error handling
+	// Used during streaming error handling. Adds an assistant turn's usage to the daily rollups.
 	UpdateTurnError(ctx context.Context, turnID, errorMsg string) error
 
-	// UpdateTurnMetadata updates a turn's metadata fields (model, tokens, stop_reason, etc.)
+	// UpdateTurnMetadata partially updates a turn's metadata fields (model, tokens, stop_reason, etc.)
+	// Only non-nil fields of the update are written; nil fields keep their stored value
 	// Used when streaming completes to store final metadata
-	UpdateTurnMetadata(ctx context.Context, turnID string, metadata map[string]interface{}) error
+	UpdateTurnMetadata(ctx context.Context, turnID string, update *llm.TurnMetadataUpdate) error
+
+	// UpdateTurnAnnotations merges an annotations update into a turn's stored annotations
+	// Returns the turn's annotations after the update (empty when none are left)
+	UpdateTurnAnnotations(ctx context.Context, turnID string, update *llm.TurnAnnotationsUpdate) (*llm.TurnAnnotations, error)
+
+	// SaveStreamIndex stores the event index of a turn's stream when the stream ends
+	SaveStreamIndex(ctx context.Context, turnID string, index *llm.StreamIndex) error
 }
@@ -0,0 +1,20 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/llm"
+)
+
+// UsageRepository aggregates the token usage and cost stored on assistant turns, and
+// the daily rollups maintained as they finish
+type UsageRepository interface {
+	// GetUsage sums a user's assistant turns (including those in deleted chats, whose
+	// spend is still real) grouped by query.GroupBy. Chats and projects are ordered by
+	// cost, most expensive first; months oldest first.
+	GetUsage(ctx context.Context, query *llm.UsageQuery) ([]llm.UsageGroup, error)
+
+	// GetUsageRollup reads the daily rollups of a chat or project (query.ChatID or
+	// query.ProjectID), summed per period and model, oldest period first
+	GetUsageRollup(ctx context.Context, query *llm.UsageRollupQuery) ([]llm.UsagePoint, error)
+}
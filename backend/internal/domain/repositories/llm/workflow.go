@@ -0,0 +1,41 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/llm"
+)
+
+// WorkflowRepository defines data access operations for workflows and their runs
+type WorkflowRepository interface {
+	// CreateWorkflow inserts a workflow (sets ID and timestamps)
+	// Returns ErrConflict if the project already has a workflow with this name
+	CreateWorkflow(ctx context.Context, workflow *llm.Workflow) error
+
+	// GetWorkflow retrieves a workflow scoped to its project
+	// Returns ErrNotFound if it doesn't exist in the project
+	GetWorkflow(ctx context.Context, workflowID, projectID string) (*llm.Workflow, error)
+
+	// ListWorkflows returns a project's workflows ordered by name
+	ListWorkflows(ctx context.Context, projectID string) ([]llm.Workflow, error)
+
+	// UpdateWorkflow saves a workflow's name, description, system prompt and steps
+	// Returns ErrNotFound or ErrConflict (duplicate name)
+	UpdateWorkflow(ctx context.Context, workflow *llm.Workflow) error
+
+	// DeleteWorkflow removes a workflow; its runs keep their steps
+	DeleteWorkflow(ctx context.Context, workflowID, projectID string) error
+
+	// CreateRun inserts a run (sets ID and timestamps)
+	// Returns ErrConflict if the chat already has a run
+	CreateRun(ctx context.Context, run *llm.WorkflowRun) error
+
+	// GetRunByChat retrieves the run of a chat
+	// Returns ErrNotFound if the chat wasn't started from a workflow
+	GetRunByChat(ctx context.Context, chatID string) (*llm.WorkflowRun, error)
+
+	// UpdateRunProgress moves a run from step fromStep to run.CurrentStep and saves its
+	// status and completed_at (updates UpdatedAt)
+	// Returns ErrConflict if the run is no longer at fromStep (advanced concurrently)
+	UpdateRunProgress(ctx context.Context, run *llm.WorkflowRun, fromStep int) error
+}
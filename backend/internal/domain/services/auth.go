@@ -23,4 +23,12 @@ type ResourceAuthorizer interface {
 
 	// CanAccessTurn checks if user can access a turn (via its chat's project)
 	CanAccessTurn(ctx context.Context, userID, turnID string) error
+
+	// CanAccessTurns checks if user can access all of turnIDs, batching the lookups
+	// Fails on the first turn that is missing or not accessible
+	CanAccessTurns(ctx context.Context, userID string, turnIDs []string) error
+
+	// InvalidateUser drops any cached grants of userID; called after a delete that
+	// revokes access (chat, project). A no-op for authorizers that don't cache.
+	InvalidateUser(userID string)
 }
@@ -0,0 +1,15 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// DocumentAnalyticsService computes readability and pacing statistics for the editor's
+// analytics panel, so clients don't have to
+type DocumentAnalyticsService interface {
+	// GetDocumentAnalytics returns a document's analytics, computing them when the cached
+	// ones describe older content
+	GetDocumentAnalytics(ctx context.Context, userID, documentID string) (*docsystem.DocumentAnalytics, error)
+}
@@ -0,0 +1,28 @@
+package docsystem
+
+import (
+	"context"
+	"io"
+)
+
+// ArchiveService exports whole projects as zip archives in the layout the zip import
+// reads back: one markdown file per document at its folder path
+type ArchiveService interface {
+	// OpenArchive checks access and loads the project's tree. Nothing is written until
+	// ProjectArchive.Write, so errors from OpenArchive can still become error responses.
+	OpenArchive(ctx context.Context, userID, projectID string, opts ArchiveOptions) (*ProjectArchive, error)
+}
+
+// ArchiveOptions selects what goes into a project archive besides the documents
+type ArchiveOptions struct {
+	IncludeChats bool // Each chat with all of its turns as .chats/{title}-{id}.json
+}
+
+// ProjectArchive is a project archive ready to be streamed
+type ProjectArchive struct {
+	FileName string // e.g. "my-novel.zip"
+
+	// Write streams the zip to w, loading document content as it goes. An error
+	// leaves a truncated archive behind.
+	Write func(ctx context.Context, w io.Writer) error
+}
@@ -0,0 +1,35 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// DocumentCollabService runs the collaboration channel of documents: clients exchange
+// Yjs updates over the y-websocket protocol, the server keeps each document's update
+// log so late joiners catch up, and the Markdown the clients report is saved into the
+// document's content with a revision.
+type DocumentCollabService interface {
+	// Serve runs a client connection in the document's room until the client
+	// disconnects or the service stops
+	Serve(ctx context.Context, userID, documentID string, conn CollabConn) error
+
+	// ListRevisions returns the document's newest revisions first, without content
+	ListRevisions(ctx context.Context, userID, documentID string, limit int) ([]docsystem.DocumentRevision, error)
+
+	// GetRevision returns a revision with its content
+	GetRevision(ctx context.Context, userID, documentID, revisionID string) (*docsystem.DocumentRevision, error)
+
+	// Run saves the rooms' reported content periodically until ctx is cancelled, then
+	// saves what's left and disconnects the clients
+	Run(ctx context.Context)
+}
+
+// CollabConn is a client connection carrying binary y-websocket messages
+type CollabConn interface {
+	// Receive blocks until the next message; it fails once the connection is closed
+	Receive() ([]byte, error)
+	Send(message []byte) error
+	Close() error
+}
@@ -3,6 +3,7 @@ package docsystem
 import (
 	"context"
 
+	"meridian/internal/domain"
 	"meridian/internal/domain/models/docsystem"
 )
 
@@ -38,13 +39,15 @@ type CreateDocumentRequest struct {
 	Content    string  `json:"content"`               // Markdown content
 }
 
-// UpdateDocumentRequest represents a document update request
+// UpdateDocumentRequest represents a document update request (PATCH semantics)
+// Absent fields are left unchanged. name and content cannot be null;
+// folder_id null (or "") moves the document to the root.
 type UpdateDocumentRequest struct {
-	ProjectID  string  `json:"project_id"`
-	Name       *string `json:"name,omitempty"`
-	FolderPath *string `json:"folder_path,omitempty"` // Move to folder path (resolve/auto-create)
-	FolderID   *string `json:"folder_id,omitempty"`   // Move to folder ID (direct, faster)
-	Content    *string `json:"content,omitempty"`
+	ProjectID  string                  `json:"project_id"`
+	Name       domain.Optional[string] `json:"name,omitzero"`
+	FolderPath domain.Optional[string] `json:"folder_path,omitzero"` // Move to folder path (resolve/auto-create)
+	FolderID   domain.Optional[string] `json:"folder_id,omitzero"`   // Move to folder ID (direct, faster)
+	Content    domain.Optional[string] `json:"content,omitzero"`
 }
 
 // SearchDocumentsRequest represents a document search request
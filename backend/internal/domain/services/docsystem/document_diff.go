@@ -0,0 +1,24 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// DocumentDiffService compares versions of a document.
+//
+// A version is "current" (the live content), "snapshot:<id>" (the content
+// captured in a project snapshot) or "suggestion:<id>" (the live content with an inline
+// suggestion applied, for reviewing it before accepting).
+type DocumentDiffService interface {
+	// DiffDocument returns the line diff from one version of a document to another
+	DiffDocument(ctx context.Context, userID, documentID, from, to string) (*docsystem.DocumentDiff, error)
+}
+
+// Document version specs
+const (
+	DocumentVersionCurrent          = "current"
+	DocumentVersionSnapshotPrefix   = "snapshot:"
+	DocumentVersionSuggestionPrefix = "suggestion:"
+)
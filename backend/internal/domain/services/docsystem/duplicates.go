@@ -0,0 +1,22 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// DuplicateService finds accidentally duplicated passages in a project, e.g. after
+// imports and merges
+type DuplicateService interface {
+	// FindDuplicates compares every paragraph of the project's documents and groups
+	// the near-duplicates
+	FindDuplicates(ctx context.Context, userID, projectID string, req *FindDuplicatesRequest) (*docsystem.DuplicateReport, error)
+}
+
+// FindDuplicatesRequest tunes duplicate detection
+type FindDuplicatesRequest struct {
+	// Threshold is the minimum similarity (0.5-1) of two paragraphs to report them;
+	// 0 uses the default
+	Threshold float64
+}
@@ -0,0 +1,37 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// ExportService compiles projects into books (EPUB, optionally PDF) asynchronously
+type ExportService interface {
+	// StartExport validates the request, creates a pending job and starts it in the background
+	StartExport(ctx context.Context, userID, projectID string, req *CreateExportRequest) (*docsystem.ExportJob, error)
+
+	// GetExport returns a job's status
+	GetExport(ctx context.Context, userID, jobID string) (*docsystem.ExportJob, error)
+
+	// DownloadExport returns a completed job's file
+	// Returns ErrNotFound until the job has completed
+	DownloadExport(ctx context.Context, userID, jobID string) (*docsystem.ExportFile, error)
+
+	// FailInterrupted fails jobs left pending or running by a previous process
+	FailInterrupted(ctx context.Context) error
+}
+
+// PDFRenderer converts an EPUB into a PDF
+type PDFRenderer interface {
+	RenderPDF(ctx context.Context, epub []byte) ([]byte, error)
+}
+
+// CreateExportRequest represents an export request
+type CreateExportRequest struct {
+	Format    string   `json:"format"`     // "epub" (default) or "pdf"
+	FolderIDs []string `json:"folder_ids"` // Folders in reading order; empty = whole project
+	Title     string   `json:"title"`      // Defaults to the project name
+	Author    string   `json:"author"`
+	Language  string   `json:"language"` // BCP 47 tag (default: "en")
+}
@@ -12,7 +12,7 @@ type FileProcessor interface {
 	CanProcess(filename string) bool
 
 	// Process handles file upload and returns import results
-	// If overwrite is true, existing documents are updated; if false, duplicates are skipped
+	// opts.Conflict decides what happens with files whose document already exists
 	Process(
 		ctx context.Context,
 		projectID string,
@@ -20,7 +20,7 @@ type FileProcessor interface {
 		file io.Reader,
 		filename string,
 		folderPath string,
-		overwrite bool,
+		opts ImportOptions,
 	) (*ImportResult, error)
 
 	// Name returns the processor name for logging
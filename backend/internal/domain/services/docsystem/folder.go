@@ -3,6 +3,7 @@ package docsystem
 import (
 	"context"
 
+	"meridian/internal/domain"
 	"meridian/internal/domain/models/docsystem"
 )
 
@@ -37,11 +38,13 @@ type CreateFolderRequest struct {
 	FolderPath *string `json:"folder_path,omitempty"` // Alternative: resolve path to folder
 }
 
-// UpdateFolderRequest represents a folder update request
+// UpdateFolderRequest represents a folder update request (PATCH semantics)
+// Absent fields are left unchanged. name cannot be null;
+// folder_id null (or "") moves the folder to the root.
 type UpdateFolderRequest struct {
-	ProjectID string  `json:"project_id"`
-	Name      *string `json:"name,omitempty"`      // rename
-	FolderID  *string `json:"folder_id,omitempty"` // move (use empty string for root)
+	ProjectID string                  `json:"project_id"`
+	Name      domain.Optional[string] `json:"name,omitzero"`      // rename
+	FolderID  domain.Optional[string] `json:"folder_id,omitzero"` // move
 }
 
 // FolderContents represents a folder with its children
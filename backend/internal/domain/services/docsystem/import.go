@@ -2,18 +2,99 @@ package docsystem
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"meridian/internal/domain"
+	"meridian/internal/domain/models/docsystem"
 )
 
 // ImportService handles bulk document import operations
 type ImportService interface {
-	// DeleteAllDocuments deletes all documents in a project
-	DeleteAllDocuments(ctx context.Context, projectID string) error
-
 	// ProcessFiles processes uploaded files (zip or individual files) and imports documents
 	// Uses file processor strategies to handle different file types
-	// If overwrite is true, existing documents are updated; if false, duplicates are skipped
+	// opts.Conflict decides what happens when a document with the same path already exists
 	// Returns detailed results including created/updated/skipped/failed counts
-	ProcessFiles(ctx context.Context, projectID, userID string, files []UploadedFile, folderPath string, overwrite bool) (*ImportResult, error)
+	ProcessFiles(ctx context.Context, projectID, userID string, files []UploadedFile, folderPath string, opts ImportOptions) (*ImportResult, error)
+
+	// StartImport stores the uploaded files and queues an import job that runs
+	// ProcessFiles in the background. Progress is reported per file (zip entries count
+	// individually); the job's result is the ImportResult.
+	StartImport(ctx context.Context, projectID, userID string, files []UploadedFile, folderPath string, opts ImportOptions) (*docsystem.Job, error)
+
+	// RunJob runs a queued import job (the JobHandler for import jobs)
+	RunJob(ctx context.Context, job *docsystem.Job, progress JobProgressFunc) (any, error)
+
+	// Manifest lists every document's path and content hash so sync clients can
+	// upload only files that differ
+	Manifest(ctx context.Context, projectID string) ([]ImportManifestEntry, error)
+}
+
+// ImportManifestEntry describes one existing document for sync clients.
+// ContentHash is the hex SHA-256 of the Markdown content; a local .md file with the
+// same hash would be reported as "unchanged" by an import.
+type ImportManifestEntry struct {
+	ID          string    `json:"id"`
+	Path        string    `json:"path"`
+	ContentHash string    `json:"content_hash"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ConflictStrategy decides what an import does with a file whose document already exists
+type ConflictStrategy string
+
+const (
+	// ConflictSkip leaves the existing document untouched (default)
+	ConflictSkip ConflictStrategy = "skip"
+	// ConflictOverwrite replaces the existing document's content
+	ConflictOverwrite ConflictStrategy = "overwrite"
+	// ConflictRename creates a new document with a numeric suffix ("intro (2)")
+	ConflictRename ConflictStrategy = "rename"
+	// ConflictMerge three-way merges the imported content into the existing document.
+	// Requires the base revision (the content both sides started from); files without
+	// a base, or with overlapping edits, are reported as conflicts and left untouched.
+	ConflictMerge ConflictStrategy = "merge"
+)
+
+// ParseConflictStrategy parses a conflict strategy name ("" means skip)
+func ParseConflictStrategy(value string) (ConflictStrategy, error) {
+	switch strategy := ConflictStrategy(value); strategy {
+	case "":
+		return ConflictSkip, nil
+	case ConflictSkip, ConflictOverwrite, ConflictRename, ConflictMerge:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("%w: unknown conflict strategy %q (use skip, overwrite, rename or merge)", domain.ErrValidation, value)
+	}
+}
+
+// ImportOptions configures how an import treats existing documents
+type ImportOptions struct {
+	Conflict ConflictStrategy
+	// BaseRevisions maps document paths (e.g. "chapters/intro", as reported in
+	// ImportDocument.Path) to the Markdown content the imported file was based on.
+	// Only used by ConflictMerge.
+	BaseRevisions map[string]string
+	// ReplaceAll deletes every document in the project before importing, in the same
+	// transaction as the import (a failed import leaves the project untouched)
+	ReplaceAll bool
+	// OnFileProcessed, if set, is called after each file (or zip entry) is processed,
+	// with its error ("" on success). Import jobs use it to report progress.
+	OnFileProcessed func(file, fileErr string)
+}
+
+// ReportFile passes one processed file to OnFileProcessed; errs are the errors the
+// file added to the import result
+func (o ImportOptions) ReportFile(file string, errs []ImportError) {
+	if o.OnFileProcessed == nil {
+		return
+	}
+	messages := make([]string, len(errs))
+	for i, importErr := range errs {
+		messages[i] = importErr.Error
+	}
+	o.OnFileProcessed(file, strings.Join(messages, "; "))
 }
 
 // ImportResult represents the result of a bulk import operation
@@ -28,6 +109,10 @@ type ImportSummary struct {
 	Created    int `json:"created"`
 	Updated    int `json:"updated"`
 	Skipped    int `json:"skipped"`
+	Unchanged  int `json:"unchanged"` // Content hash matched the existing document
+	Renamed    int `json:"renamed"`
+	Merged     int `json:"merged"`
+	Conflicts  int `json:"conflicts"`
 	Failed     int `json:"failed"`
 	TotalFiles int `json:"total_files"`
 }
@@ -43,5 +128,10 @@ type ImportDocument struct {
 	ID     string `json:"id"`
 	Path   string `json:"path"`
 	Name   string `json:"name"`
-	Action string `json:"action"` // "created", "updated", or "skipped"
+	Action string `json:"action"` // "created", "updated", "skipped", "unchanged", "renamed", "merged", or "conflict"
+
+	// OriginalName is the name the file would have had, for renamed documents
+	OriginalName string `json:"original_name,omitempty"`
+	// Detail explains skipped and conflicting files
+	Detail string `json:"detail,omitempty"`
 }
@@ -0,0 +1,38 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// JobService runs the background job queue: requests enqueue jobs, a pool of workers
+// claims and runs them, and clients poll or watch their progress
+type JobService interface {
+	// Register sets the handler that runs jobs of a kind. Call before Run.
+	Register(kind string, handler JobHandler)
+
+	// Enqueue creates a pending job and wakes a worker
+	Enqueue(ctx context.Context, job *docsystem.Job) error
+
+	// GetJob returns a job's status and progress
+	GetJob(ctx context.Context, userID, jobID string) (*docsystem.Job, error)
+
+	// WatchJob sends the job's current state, then its state whenever its progress
+	// changes. The channel closes after the job finishes or when ctx ends.
+	WatchJob(ctx context.Context, userID, jobID string) (<-chan *docsystem.Job, error)
+
+	// Run runs the worker pool until ctx is cancelled. Jobs interrupted by the
+	// cancellation go back to the queue.
+	Run(ctx context.Context)
+
+	// FailInterrupted fails jobs left running by a previous process (pending jobs stay queued)
+	FailInterrupted(ctx context.Context) error
+}
+
+// JobHandler runs one job of its kind. It calls progress after each file; the returned
+// result is stored on the job as JSON.
+type JobHandler func(ctx context.Context, job *docsystem.Job, progress JobProgressFunc) (any, error)
+
+// JobProgressFunc records one processed file; fileErr is empty if the file succeeded
+type JobProgressFunc func(file, fileErr string)
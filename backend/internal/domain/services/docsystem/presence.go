@@ -0,0 +1,29 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// DocumentPresenceService tracks which documents a user has open in which editor
+// session. Sessions announce themselves with heartbeats and expire when the heartbeats
+// stop, so closed tabs disappear without an explicit leave.
+type DocumentPresenceService interface {
+	// Heartbeat records that the session has the document open and returns the
+	// document's sessions
+	Heartbeat(ctx context.Context, userID, documentID string, req *PresenceHeartbeatRequest) (*docsystem.DocumentPresence, error)
+
+	// GetPresence returns the document's live sessions; sessionID ("" = none) is the
+	// caller's own session, which doesn't count as editing elsewhere
+	GetPresence(ctx context.Context, userID, documentID, sessionID string) (*docsystem.DocumentPresence, error)
+
+	// Leave removes the session from the document (no-op if it isn't there)
+	Leave(ctx context.Context, userID, documentID, sessionID string) error
+}
+
+// PresenceHeartbeatRequest represents an editor session heartbeat
+type PresenceHeartbeatRequest struct {
+	SessionID string `json:"session_id"`      // Client-generated, stable for the tab's lifetime
+	Label     string `json:"label,omitempty"` // Optional description shown to other sessions
+}
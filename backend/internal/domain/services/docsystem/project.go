@@ -25,8 +25,8 @@ type ProjectService interface {
 	// GetProject retrieves a project by ID
 	GetProject(ctx context.Context, id, userID string) (*docsystem.Project, error)
 
-	// ListProjects retrieves all projects for a user
-	ListProjects(ctx context.Context, userID string) ([]docsystem.Project, error)
+	// ListProjects retrieves all projects for a user, with the summaries selected in opts (nil = none)
+	ListProjects(ctx context.Context, userID string, opts *docsystem.ProjectListOptions) ([]docsystem.Project, error)
 
 	// UpdateProject updates a project's name
 	UpdateProject(ctx context.Context, id, userID string, req *UpdateProjectRequest) (*docsystem.Project, error)
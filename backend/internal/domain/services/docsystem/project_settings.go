@@ -0,0 +1,30 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain"
+	"meridian/internal/domain/models/docsystem"
+)
+
+// UpdateProjectSettingsRequest is the DTO for updating project settings (PATCH semantics)
+// Absent fields are left unchanged; null resets a field to its default.
+// tool_policy, guardrails and critique are replaced as a whole.
+type UpdateProjectSettingsRequest struct {
+	SystemPrompt   domain.Optional[string]                      `json:"system_prompt,omitzero"`
+	DefaultModel   domain.Optional[string]                      `json:"default_model,omitzero"`
+	ToolPolicy     domain.Optional[docsystem.ProjectToolPolicy] `json:"tool_policy,omitzero"`
+	Guardrails     domain.Optional[docsystem.ProjectGuardrails] `json:"guardrails,omitzero"`
+	Critique       domain.Optional[docsystem.ProjectCritique]   `json:"critique,omitzero"`
+	PinFolder      domain.Optional[string]                      `json:"pin_folder,omitzero"`
+	SearchLanguage domain.Optional[string]                      `json:"search_language,omitzero"`
+}
+
+// ProjectSettingsService defines business logic operations for project settings
+type ProjectSettingsService interface {
+	// GetProjectSettings retrieves a project's settings (defaults if never saved)
+	GetProjectSettings(ctx context.Context, projectID, userID string) (*docsystem.ProjectSettings, error)
+
+	// UpdateProjectSettings partially updates a project's settings
+	UpdateProjectSettings(ctx context.Context, projectID, userID string, req *UpdateProjectSettingsRequest) (*docsystem.ProjectSettings, error)
+}
@@ -0,0 +1,64 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain"
+	"meridian/internal/domain/models/docsystem"
+)
+
+// PublishService manages publish targets and delivers documents to them
+type PublishService interface {
+	CreateTarget(ctx context.Context, userID, projectID string, req *CreatePublishTargetRequest) (*docsystem.PublishTarget, error)
+	ListTargets(ctx context.Context, userID, projectID string) ([]docsystem.PublishTarget, error)
+	GetTarget(ctx context.Context, userID, projectID, targetID string) (*docsystem.PublishTarget, error)
+	UpdateTarget(ctx context.Context, userID, projectID, targetID string, req *UpdatePublishTargetRequest) (*docsystem.PublishTarget, error)
+	DeleteTarget(ctx context.Context, userID, projectID, targetID string) error
+
+	// Publish delivers the target's documents now and returns the logged delivery.
+	// A failed delivery is returned (status "failed"), not an error.
+	Publish(ctx context.Context, userID, projectID, targetID string) (*docsystem.PublishDelivery, error)
+
+	// ListDeliveries returns a target's delivery log, newest first
+	ListDeliveries(ctx context.Context, userID, projectID, targetID string) ([]docsystem.PublishDelivery, error)
+
+	// PublishChanged publishes every on-change target whose documents changed since its
+	// last attempt. Run periodically by the server.
+	PublishChanged(ctx context.Context) error
+}
+
+// Publisher delivers documents to one kind of destination
+type Publisher interface {
+	// Kind is the target kind this publisher handles ("github", "s3", "webhook")
+	Kind() string
+
+	// Validate checks a target's config (required keys, formats)
+	Validate(config map[string]string) error
+
+	// SecretKeys lists config keys holding credentials, masked in API responses
+	SecretKeys() []string
+
+	// Publish delivers the bundle and returns a short description of the result
+	// (commit SHA, HTTP status, object count)
+	Publish(ctx context.Context, config map[string]string, bundle *docsystem.PublishBundle) (string, error)
+}
+
+// CreatePublishTargetRequest represents a publish target creation request
+type CreatePublishTargetRequest struct {
+	Name        string            `json:"name"`
+	Kind        string            `json:"kind"`
+	Config      map[string]string `json:"config"`
+	DocumentIDs []string          `json:"document_ids"`
+	FolderIDs   []string          `json:"folder_ids"`
+	OnChange    bool              `json:"on_change"`
+}
+
+// UpdatePublishTargetRequest is the DTO for updating a publish target (PATCH semantics).
+// Config is replaced as a whole; masked secret values keep the stored secret.
+type UpdatePublishTargetRequest struct {
+	Name        domain.Optional[string]            `json:"name,omitzero"`
+	Config      domain.Optional[map[string]string] `json:"config,omitzero"`
+	DocumentIDs domain.Optional[[]string]          `json:"document_ids,omitzero"`
+	FolderIDs   domain.Optional[[]string]          `json:"folder_ids,omitzero"`
+	OnChange    domain.Optional[bool]              `json:"on_change,omitzero"`
+}
@@ -0,0 +1,14 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// SkillService defines operations on project skills (.skills/{name}/SKILL documents)
+type SkillService interface {
+	// LintSkills validates every skill folder in a project and reports issues per skill
+	// userID is used for authorization check
+	LintSkills(ctx context.Context, userID, projectID string) (*docsystem.SkillLintResults, error)
+}
@@ -0,0 +1,39 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// SnapshotService manages project snapshots ("save points" of the whole tree)
+type SnapshotService interface {
+	// CreateSnapshot captures the project's current tree
+	CreateSnapshot(ctx context.Context, userID, projectID string, req *CreateSnapshotRequest) (*docsystem.Snapshot, error)
+
+	// ListSnapshots returns a project's snapshots, newest first
+	ListSnapshots(ctx context.Context, userID, projectID string) ([]docsystem.Snapshot, error)
+
+	// GetSnapshot returns a snapshot with its folders and documents
+	GetSnapshot(ctx context.Context, userID, projectID, snapshotID string) (*docsystem.SnapshotWithEntries, error)
+
+	// DiffSnapshot compares a snapshot with another snapshot, or with the live tree
+	// when against is "" or SnapshotCurrent
+	DiffSnapshot(ctx context.Context, userID, projectID, snapshotID, against string) (*docsystem.SnapshotDiff, error)
+
+	// RestoreSnapshot makes the project tree match a snapshot. The current tree is
+	// snapshotted first, so the restore can itself be undone.
+	RestoreSnapshot(ctx context.Context, userID, projectID, snapshotID string) (*docsystem.SnapshotRestoreResult, error)
+
+	// CompressContent compresses stored snapshot content of at least minBytes (maintenance,
+	// no user scope). Returns the number of blobs compressed.
+	CompressContent(ctx context.Context, minBytes int) (int, error)
+}
+
+// SnapshotCurrent names the live project tree in diffs
+const SnapshotCurrent = "current"
+
+// CreateSnapshotRequest represents a snapshot creation request
+type CreateSnapshotRequest struct {
+	Name string `json:"name"` // Optional label, e.g. "Before chapter 3 rewrite"
+}
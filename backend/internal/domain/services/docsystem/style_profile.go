@@ -0,0 +1,31 @@
+package docsystem
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// StyleProfileService derives a project's style fingerprint from documents the user
+// selects. Analysis runs in the background; the profile is stored on the project's
+// settings and used by turns that set match_style.
+type StyleProfileService interface {
+	// AnalyzeStyle validates the request, marks the profile as analyzing and starts the
+	// analysis in the background. Returns ErrConflict while an analysis is running.
+	AnalyzeStyle(ctx context.Context, userID, projectID string, req *AnalyzeStyleRequest) (*docsystem.StyleProfile, error)
+
+	// GetStyleProfile returns the project's style profile
+	// Returns ErrNotFound if the project has never been analyzed
+	GetStyleProfile(ctx context.Context, userID, projectID string) (*docsystem.StyleProfile, error)
+
+	// DeleteStyleProfile clears the project's style profile
+	DeleteStyleProfile(ctx context.Context, userID, projectID string) error
+
+	// FailInterrupted fails analyses left running by a previous process
+	FailInterrupted(ctx context.Context) error
+}
+
+// AnalyzeStyleRequest selects the documents a style profile is derived from
+type AnalyzeStyleRequest struct {
+	DocumentIDs []string `json:"document_ids"`
+}
@@ -0,0 +1,24 @@
+package docsystem
+
+import (
+	"context"
+	"time"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// TrashService lists and restores soft-deleted documents, folders and chats, and purges
+// documents and folders once their retention has passed
+type TrashService interface {
+	// ListTrash lists a project's deleted documents and folders and the user's deleted chats
+	// userID is used for authorization check
+	ListTrash(ctx context.Context, userID, projectID string) ([]docsystem.TrashItem, error)
+
+	// Restore brings a deleted item back; a folder comes back with everything deleted with it
+	// Fails with a conflict if the parent folder is still deleted or the name is now taken
+	Restore(ctx context.Context, userID string, itemType docsystem.TrashItemType, id string) (*docsystem.RestoreResult, error)
+
+	// PurgeDeleted permanently removes documents and folders soft-deleted for longer than retention
+	// Returns the number of rows purged
+	PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error)
+}
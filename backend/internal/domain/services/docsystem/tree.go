@@ -10,5 +10,6 @@ import (
 type TreeService interface {
 	// GetProjectTree builds and returns the nested folder/document tree for a project
 	// userID is used for authorization check
-	GetProjectTree(ctx context.Context, userID, projectID string) (*docsystem.TreeNode, error)
+	// maxDepth > 0 limits nesting (1 = root-level entries only); aggregates always cover the full tree
+	GetProjectTree(ctx context.Context, userID, projectID string, maxDepth int) (*docsystem.TreeNode, error)
 }
@@ -0,0 +1,14 @@
+package docsystem
+
+import "context"
+
+// ParentValidator validates that parent resources are not soft-deleted
+// before allowing operations on child resources
+type ParentValidator interface {
+	// ValidateProject returns domain.ErrNotFound if the project is deleted or doesn't exist
+	ValidateProject(ctx context.Context, projectID, userID string) error
+
+	// ValidateFolder returns domain.ErrNotFound if the folder is deleted or doesn't exist
+	// An empty folderID (root) is always valid
+	ValidateFolder(ctx context.Context, folderID, projectID string) error
+}
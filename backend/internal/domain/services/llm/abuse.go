@@ -0,0 +1,41 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/llm"
+)
+
+// AbuseService detects anomalous usage and lets admins review the flags it raises
+type AbuseService interface {
+	AbuseMonitor
+
+	// ListFlags returns flags newest first (admin API)
+	ListFlags(ctx context.Context, req *ListAbuseFlagsRequest) ([]llm.AbuseFlag, error)
+
+	// ResolveFlag marks a flag reviewed; a throttling flag stops throttling the user
+	ResolveFlag(ctx context.Context, flagID string) (*llm.AbuseFlag, error)
+}
+
+// AbuseMonitor watches turn creation and tool use for runaway or abusive usage.
+// Used by the streaming service; nil disables abuse detection.
+type AbuseMonitor interface {
+	// CheckTurn records a turn the user is creating. Returns *llm.ThrottledError if the
+	// user is throttled, or becomes throttled by this turn.
+	CheckTurn(ctx context.Context, userID string) error
+
+	// CheckToolInput refuses (and flags) a tool call whose input is too large, and any tool
+	// call of a user throttled while the turn streams
+	CheckToolInput(ctx context.Context, userID, turnID, toolName string, input map[string]interface{}) error
+
+	// RecordToolRound records a tool round of the user's turn; too many rounds within the
+	// window throttle the user
+	RecordToolRound(ctx context.Context, userID, turnID string)
+}
+
+// ListAbuseFlagsRequest holds the query parameters of GET /admin/api/abuse-flags
+type ListAbuseFlagsRequest struct {
+	UserID string // Only this user's flags ("" = everyone's)
+	Status string // "open" (unresolved, default) or "all"
+	Limit  int    // Default 100, at most 500
+}
@@ -0,0 +1,30 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/llm"
+)
+
+// AttachmentService stores files users attach to chat messages. An uploaded image is
+// sent by referencing its ID from an image block ({"attachment_id": ...}) of a user turn.
+type AttachmentService interface {
+	// UploadAttachment stores a file for a project and returns it with a signed URL.
+	// Returns ErrValidation if the file isn't a supported image, is too large, or would
+	// exceed the project's attachment quota.
+	UploadAttachment(ctx context.Context, userID string, req *UploadAttachmentRequest) (*llm.Attachment, error)
+
+	// GetAttachment returns an attachment with a fresh signed URL
+	GetAttachment(ctx context.Context, userID, attachmentID string) (*llm.Attachment, error)
+
+	// DeleteAttachment deletes an attachment and its file. Turns that referenced it keep
+	// their image blocks, but the image is no longer sent to models.
+	DeleteAttachment(ctx context.Context, userID, attachmentID string) error
+}
+
+// UploadAttachmentRequest is an uploaded file
+type UploadAttachmentRequest struct {
+	ProjectID string
+	FileName  string
+	Data      []byte
+}
@@ -2,7 +2,9 @@ package llm
 
 import (
 	"context"
+	"time"
 
+	"meridian/internal/domain"
 	"meridian/internal/domain/models/llm"
 )
 
@@ -19,14 +21,18 @@ type ChatService interface {
 	// Validates user has access to the chat's project
 	GetChat(ctx context.Context, chatID, userID string) (*llm.Chat, error)
 
-	// ListChats retrieves all chats for a project
+	// ListChats retrieves all chats for a project, with the summaries selected in opts (nil = none)
 	// Validates user has access to the project
-	ListChats(ctx context.Context, projectID, userID string) ([]llm.Chat, error)
+	ListChats(ctx context.Context, projectID, userID string, opts *llm.ChatListOptions) ([]llm.Chat, error)
 
-	// UpdateChat updates a chat's title
+	// UpdateChat partially updates a chat (title, system prompt)
 	// Validates user has access
 	UpdateChat(ctx context.Context, chatID, userID string, req *UpdateChatRequest) (*llm.Chat, error)
 
+	// UpdateChatSettings partially updates a chat's settings (system prompt, default skills, default tools)
+	// Validates user has access
+	UpdateChatSettings(ctx context.Context, chatID, userID string, req *UpdateChatSettingsRequest) (*llm.Chat, error)
+
 	// UpdateLastViewedTurn updates the last_viewed_turn_id field for a chat
 	// Validates user has access to the chat
 	UpdateLastViewedTurn(ctx context.Context, chatID, userID, turnID string) error
@@ -34,6 +40,18 @@ type ChatService interface {
 	// DeleteChat soft-deletes a chat and returns the deleted chat object
 	// Validates user has access
 	DeleteChat(ctx context.Context, chatID, userID string) (*llm.Chat, error)
+
+	// PurgeDeletedChats permanently removes chats (with their turns and blocks) that have been
+	// soft-deleted, directly or through their project, for longer than retention
+	// Returns the number of chats purged
+	PurgeDeletedChats(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// ChatValidator validates that a chat exists and is not soft-deleted
+// before allowing operations on it or its turns
+type ChatValidator interface {
+	// ValidateChat returns domain.ErrNotFound if the chat is deleted or doesn't exist
+	ValidateChat(ctx context.Context, chatID, userID string) error
 }
 
 // CreateChatRequest is the DTO for creating a new chat
@@ -43,7 +61,19 @@ type CreateChatRequest struct {
 	Title     string `json:"title"`
 }
 
-// UpdateChatRequest is the DTO for updating a chat
+// UpdateChatRequest is the DTO for updating a chat (PATCH semantics)
+// Absent fields are left unchanged. title cannot be null;
+// system_prompt null clears the chat-level prompt.
 type UpdateChatRequest struct {
-	Title string `json:"title"`
+	Title        domain.Optional[string] `json:"title,omitzero"`
+	SystemPrompt domain.Optional[string] `json:"system_prompt,omitzero"`
+}
+
+// UpdateChatSettingsRequest is the DTO for updating chat settings (PATCH semantics)
+// Absent fields are left unchanged; null clears a field.
+// Default skills and tools apply to new turns that don't specify their own.
+type UpdateChatSettingsRequest struct {
+	SystemPrompt  domain.Optional[string]   `json:"system_prompt,omitzero"`
+	DefaultSkills domain.Optional[[]string] `json:"default_skills,omitzero"` // Skill names under .skills/
+	DefaultTools  domain.Optional[[]string] `json:"default_tools,omitzero"`  // Tool names (e.g. "doc_view", "tavily_web_search")
 }
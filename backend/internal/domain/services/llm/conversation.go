@@ -7,22 +7,23 @@ import (
 )
 
 // ConversationService defines the business logic for conversation history and navigation
-// This service handles reading and navigating through turn history
+// This service handles reading and navigating through turn history (and the turn
+// annotations clients keep for branch management)
 // For chat session management, see ChatService
 // For creating new turns, see StreamingService
 type ConversationService interface {
 	// GetTurnPath retrieves the conversation path from a turn to root
-	// Used to build context for LLM requests
-	// Returns turns in order from root to the specified turn
+	// opts can limit it to the last K turns and omit blocks
+	// Returns turns in order from root (or the window start) to the specified turn
 	// userID is used for authorization check
-	GetTurnPath(ctx context.Context, userID, turnID string) ([]llm.Turn, error)
+	GetTurnPath(ctx context.Context, userID, turnID string, opts *llm.TurnPathOptions) ([]llm.Turn, error)
 
-	// GetTurnSiblings retrieves all sibling turns (including self) for a given turn
+	// GetTurnSiblings retrieves sibling turns (including self) for a given turn
 	// Siblings are turns that share the same prev_turn_id (alternative conversation branches)
-	// Returns turns with blocks nested, ordered by created_at
+	// Returns one page ordered by created_at (blocks nested if opts.IncludeBlocks) and the total count
 	// Used for version browsing UI ("1 of 3" navigation)
 	// userID is used for authorization check
-	GetTurnSiblings(ctx context.Context, userID, turnID string) ([]llm.Turn, error)
+	GetTurnSiblings(ctx context.Context, userID, turnID string, opts *llm.TurnSiblingsOptions) (*llm.TurnSiblings, error)
 
 	// GetChatTree retrieves the lightweight tree structure for cache validation
 	// Returns only turn IDs and parent relationships (no content)
@@ -30,6 +31,12 @@ type ConversationService interface {
 	// Used by frontend to detect gaps, new branches, and structural changes
 	GetChatTree(ctx context.Context, chatID, userID string) (*llm.ChatTree, error)
 
+	// GetTurnTree retrieves one page of the chat's whole turn tree as skeletons
+	// (id, role, prev_turn_id, created_at, sibling count and position; no blocks)
+	// Pages follow (created_at, id) order, so parents arrive before their children
+	// Used by branch pickers and multi-root branch UIs
+	GetTurnTree(ctx context.Context, chatID, userID string, opts *llm.TurnTreeOptions) (*llm.TurnTreePage, error)
+
 	// GetPaginatedTurns retrieves turns and blocks in paginated fashion
 	// Follows path-based navigation (prev_turn_id chains)
 	// Direction: "before" (history), "after" (future/branches), "both" (split limit)
@@ -43,9 +50,30 @@ type ConversationService interface {
 	// userID is used for authorization check
 	GetTurnWithBlocks(ctx context.Context, userID, turnID string) (*llm.Turn, error)
 
+	// GetTurns retrieves specific turns by ID (e.g. to reconcile a client's branch cache)
+	// Returns the turns in the order of turnIDs (duplicates dropped), with blocks if includeBlocks
+	// Every turn must be accessible by userID (checked in one batch); at most MaxTurnsByIDs IDs
+	GetTurns(ctx context.Context, userID string, turnIDs []string, includeBlocks bool) ([]llm.Turn, error)
+
 	// GetTurnTokenUsage retrieves token usage statistics for a turn
 	// Returns input/output tokens, model context limit, and usage percentage
 	// Used by frontend to display warnings and make continuation decisions
 	// userID is used for authorization check
 	GetTurnTokenUsage(ctx context.Context, userID, turnID string) (*llm.TokenUsageInfo, error)
+
+	// SearchTurns performs full-text search over the text blocks of a chat (in-conversation find)
+	// Each match is flagged with whether it lies on the currently viewed path
+	// fromTurnID: viewed turn (optional - defaults to chat.last_viewed_turn_id)
+	// userID is used for authorization check
+	SearchTurns(ctx context.Context, chatID, userID string, opts *llm.TurnSearchOptions, fromTurnID *string) (*llm.TurnSearchResults, error)
+
+	// SearchChats performs full-text search over the text blocks of the user's chats in a project
+	// The language defaults to the project's search_language (the indexed one)
+	// userID is used for authorization check
+	SearchChats(ctx context.Context, userID string, opts *llm.ChatSearchOptions) (*llm.ChatSearchResults, error)
+
+	// UpdateTurnAnnotations partially updates a turn's client annotations (collapsed, color label, branch name)
+	// Returns the turn's annotations after the update
+	// userID is used for authorization check
+	UpdateTurnAnnotations(ctx context.Context, userID, turnID string, update *llm.TurnAnnotationsUpdate) (*llm.TurnAnnotations, error)
 }
@@ -0,0 +1,34 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+	"meridian/internal/domain/models/llm"
+)
+
+// CritiqueService runs the critic pass: a second model reviews an assistant turn against
+// a rubric and the review is stored as the turn's critique
+type CritiqueService interface {
+	// CritiqueTurn starts a review of an assistant turn in the background, replacing any
+	// earlier critique, and returns the pending critique. Poll GetCritique for the result.
+	CritiqueTurn(ctx context.Context, userID, turnID string, req *CritiqueTurnRequest) (*llm.TurnCritique, error)
+
+	// GetCritique returns a turn's critique (ErrNotFound if it has none)
+	GetCritique(ctx context.Context, userID, turnID string) (*llm.TurnCritique, error)
+}
+
+// TurnCritic reviews turns as they complete (used by the streaming service when a
+// project enables the critic pass)
+type TurnCritic interface {
+	// CritiqueCompletedTurn reviews a user's completed assistant turn with the project's
+	// settings. Blocks until the review is stored; failures are recorded on the critique.
+	// Skipped when the user's data controls block the critic model's provider.
+	CritiqueCompletedTurn(ctx context.Context, userID, turnID string, settings docsystem.ProjectCritique)
+}
+
+// CritiqueTurnRequest overrides the project's critique settings for one review
+type CritiqueTurnRequest struct {
+	Model  *string `json:"model"`
+	Rubric *string `json:"rubric"`
+}
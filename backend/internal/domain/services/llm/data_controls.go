@@ -0,0 +1,22 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/llm"
+)
+
+// DataControlsService reports and enforces users' per-provider data controls
+type DataControlsService interface {
+	ProviderDataPolicy
+
+	// GetDataControls returns the user's data controls with what each provider
+	// integration transmits and whether the user allows it
+	GetDataControls(ctx context.Context, userID string) (*llm.DataControlsStatus, error)
+}
+
+// ProviderDataPolicy is checked before a user's content is sent to a provider
+type ProviderDataPolicy interface {
+	// CheckProvider returns domain.ErrForbidden if the user's data controls block provider
+	CheckProvider(ctx context.Context, userID, provider string) error
+}
@@ -0,0 +1,98 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+)
+
+// Editor stream event types (EditorEvent.Type, sent as the SSE event name)
+const (
+	EditorEventDelta = "delta" // Text is the next chunk of a variant
+	EditorEventDone  = "done"  // Text is the variant's full text; usage fields are set
+	EditorEventError = "error" // Error describes why the variant failed
+)
+
+// EditorService runs editor-side generations that stream straight to the editor
+// without creating chats or turns (e.g. inline "ghost text" completions)
+type EditorService interface {
+	// CompleteDocument streams a short continuation of a document at the cursor.
+	// Validation and authorization errors are returned before streaming starts; the
+	// channel is closed after the done or error event, or when ctx is cancelled.
+	CompleteDocument(ctx context.Context, userID, documentID string, req *CompleteDocumentRequest) (<-chan EditorEvent, error)
+
+	// RewriteSelection streams alternative rewrites of a document range, one variant
+	// per index, concurrently. Each variant ends with its own done or error event; the
+	// channel is closed when every variant has ended, or when ctx is cancelled.
+	// With SaveSuggestions, each completed variant is saved as an inline suggestion.
+	RewriteSelection(ctx context.Context, userID, documentID string, req *RewriteSelectionRequest) (<-chan EditorEvent, error)
+
+	// TranslateDocument streams a translation of a document, or of a range of it, into the
+	// target language. The source language is detected from the text unless given.
+	// With SaveSuggestion, the completed translation is saved as an inline suggestion
+	// replacing the range.
+	TranslateDocument(ctx context.Context, userID, documentID string, req *TranslateDocumentRequest) (<-chan EditorEvent, error)
+
+	// ListSuggestions returns a document's saved inline suggestions, oldest first
+	ListSuggestions(ctx context.Context, userID, documentID string) ([]docsystem.DocumentSuggestion, error)
+
+	// AcceptSuggestion applies an inline suggestion to the saved document and removes it.
+	// Returns a ConflictError if the text it rewrites has changed since.
+	AcceptSuggestion(ctx context.Context, userID, documentID, suggestionID string) (*docsystem.Document, error)
+
+	// DeleteSuggestion removes an inline suggestion (rejecting it, or after the editor
+	// applied it itself)
+	DeleteSuggestion(ctx context.Context, userID, documentID, suggestionID string) error
+}
+
+// CompleteDocumentRequest asks for a continuation at a cursor position
+type CompleteDocumentRequest struct {
+	Cursor                int     `json:"cursor"`                  // Offset in characters (Unicode code points) into the content
+	Content               *string `json:"content"`                 // Editor content when it differs from the saved document (nil = saved content)
+	Model                 *string `json:"model"`                   // nil = server editor model, then the project's default model
+	MaxTokens             *int    `json:"max_tokens"`              // nil = a short default
+	IncludeProjectContext bool    `json:"include_project_context"` // Add the project's system prompt and the document's path
+}
+
+// RewriteSelectionRequest asks for rewrites of the range [Start, End) of the content
+type RewriteSelectionRequest struct {
+	Start                 int     `json:"start"`                   // Offsets in characters (Unicode code points), like CompleteDocumentRequest.Cursor
+	End                   int     `json:"end"`                     // Exclusive
+	Instruction           string  `json:"instruction"`             // e.g. "make it punchier"
+	Variants              *int    `json:"variants"`                // nil = 3
+	Content               *string `json:"content"`                 // Editor content when it differs from the saved document (nil = saved content)
+	Model                 *string `json:"model"`                   // nil = server editor model, then the project's default model
+	MaxTokens             *int    `json:"max_tokens"`              // Per variant; nil = a default sized for a paragraph or two
+	IncludeProjectContext bool    `json:"include_project_context"` // Add the project's system prompt and the document's path
+	SaveSuggestions       bool    `json:"save_suggestions"`        // Save each completed variant as an inline suggestion
+}
+
+// TranslateDocumentRequest asks for a translation of the range [Start, End) of the
+// content, or of all of it when neither is set
+type TranslateDocumentRequest struct {
+	TargetLanguage        string  `json:"target_language"`         // BCP 47 tag, e.g. "fr", "pt-BR"
+	SourceLanguage        *string `json:"source_language"`         // nil = detected from the text
+	Start                 *int    `json:"start"`                   // Offsets in characters (Unicode code points), like RewriteSelectionRequest
+	End                   *int    `json:"end"`                     // Exclusive
+	Content               *string `json:"content"`                 // Editor content when it differs from the saved document (nil = saved content)
+	Model                 *string `json:"model"`                   // nil = server editor model, then the project's default model
+	MaxTokens             *int    `json:"max_tokens"`              // nil = a default sized for a chapter
+	IncludeProjectContext bool    `json:"include_project_context"` // Add the project's system prompt and the document's path
+	SaveSuggestion        bool    `json:"save_suggestion"`         // Save the completed translation as an inline suggestion
+}
+
+// EditorEvent is one event of an editor stream. Variant is always 0 for completions.
+// A done event with Error set produced text that could not be saved as a suggestion.
+type EditorEvent struct {
+	Type           string `json:"-"`
+	Variant        int    `json:"variant"`
+	Text           string `json:"text,omitempty"`
+	Model          string `json:"model,omitempty"`
+	InputTokens    int    `json:"input_tokens,omitempty"`
+	OutputTokens   int    `json:"output_tokens,omitempty"`
+	StopReason     string `json:"stop_reason,omitempty"`
+	SuggestionID   string `json:"suggestion_id,omitempty"`   // Done events of saved rewrite variants and translations
+	SourceLanguage string `json:"source_language,omitempty"` // Done events of translations ("" = undetermined)
+	TargetLanguage string `json:"target_language,omitempty"` // Done events of translations
+	Error          string `json:"error,omitempty"`
+}
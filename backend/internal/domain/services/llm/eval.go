@@ -0,0 +1,58 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain"
+	"meridian/internal/domain/models/docsystem"
+	"meridian/internal/domain/models/llm"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+// EvalService manages a project's eval cases and runs them against models and skills, so
+// prompt and skill changes can be validated before rollout
+type EvalService interface {
+	CreateCase(ctx context.Context, userID, projectID string, req *CreateEvalCaseRequest) (*llm.EvalCase, error)
+	ListCases(ctx context.Context, userID, projectID string) ([]llm.EvalCase, error)
+	UpdateCase(ctx context.Context, userID, projectID, caseID string, req *UpdateEvalCaseRequest) (*llm.EvalCase, error)
+	DeleteCase(ctx context.Context, userID, projectID, caseID string) error
+
+	// CaseHistory returns a case's results across runs, newest first
+	CaseHistory(ctx context.Context, userID, projectID, caseID string, limit int) ([]llm.EvalResult, error)
+
+	// StartRun validates the run and queues it as a background job; progress is polled
+	// via the job (GET /api/jobs/{id}), results via GetRun
+	StartRun(ctx context.Context, userID, projectID string, req *StartEvalRunRequest) (*llm.EvalRun, error)
+	ListRuns(ctx context.Context, userID, projectID string, limit int) ([]llm.EvalRun, error)
+
+	// GetRun returns a run with its results
+	GetRun(ctx context.Context, userID, projectID, runID string) (*llm.EvalRun, error)
+
+	// RunJob executes an eval run job (the job queue's handler for JobKindEval)
+	RunJob(ctx context.Context, job *docsystem.Job, progress docsysSvc.JobProgressFunc) (any, error)
+}
+
+// CreateEvalCaseRequest represents an eval case creation request
+type CreateEvalCaseRequest struct {
+	Name     string              `json:"name"`
+	Prompt   string              `json:"prompt"`
+	Criteria []llm.EvalCriterion `json:"criteria"`
+}
+
+// UpdateEvalCaseRequest is the DTO for updating an eval case (PATCH semantics).
+// Criteria are replaced as a whole.
+type UpdateEvalCaseRequest struct {
+	Name     domain.Optional[string]              `json:"name,omitzero"`
+	Prompt   domain.Optional[string]              `json:"prompt,omitzero"`
+	Criteria domain.Optional[[]llm.EvalCriterion] `json:"criteria,omitzero"`
+}
+
+// StartEvalRunRequest represents a request to run cases against models
+type StartEvalRunRequest struct {
+	Label      string   `json:"label"`       // What's being validated
+	Models     []string `json:"models"`      // Models to compare (at least one)
+	Skills     []string `json:"skills"`      // Skills added to the system prompt, as in a chat
+	CaseIDs    []string `json:"case_ids"`    // Cases to run; empty = all of the project's
+	JudgeModel string   `json:"judge_model"` // Model scoring judge criteria; empty = server default
+	MaxTokens  int      `json:"max_tokens"`  // Output cap per generation; 0 = server default
+}
@@ -0,0 +1,60 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/llm"
+)
+
+// ExperimentService manages prompt/params experiments (admin API) and turn feedback
+type ExperimentService interface {
+	ExperimentAssigner
+
+	// CreateExperiment validates and stores a new experiment (active unless the request
+	// says otherwise)
+	CreateExperiment(ctx context.Context, req *CreateExperimentRequest) (*llm.Experiment, error)
+
+	// ListExperiments returns all experiments, newest first
+	ListExperiments(ctx context.Context) ([]llm.Experiment, error)
+
+	// SetExperimentStatus starts or stops an experiment
+	SetExperimentStatus(ctx context.Context, experimentID, status string) (*llm.Experiment, error)
+
+	// GetResults aggregates the experiment's turns (usage, errors, feedback) by variant
+	GetResults(ctx context.Context, experimentID string) (*llm.ExperimentResults, error)
+
+	// SetTurnFeedback rates an assistant turn the user can access: 1 (up), -1 (down),
+	// or nil to clear the rating
+	SetTurnFeedback(ctx context.Context, userID, turnID string, rating *int) error
+}
+
+// ExperimentAssigner enrolls new assistant turns in the active experiments.
+// Used by the streaming service; nil disables experiments.
+type ExperimentAssigner interface {
+	// AssignTurn returns the variant of every active experiment for a turn of the user's
+	// chat. chatID is empty for a cold start, whose chat doesn't exist yet: chat-unit
+	// experiments then skip the turn.
+	AssignTurn(ctx context.Context, userID, chatID string) ([]llm.ExperimentAssignment, error)
+
+	// RecordTurn stores the assignments on the created assistant turn
+	RecordTurn(ctx context.Context, turnID string, assignments []llm.ExperimentAssignment) error
+}
+
+// CreateExperimentRequest is the DTO for POST /admin/api/experiments
+type CreateExperimentRequest struct {
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Unit        string                  `json:"unit"`             // "user" or "chat"
+	Status      string                  `json:"status,omitempty"` // Default "active"
+	Variants    []llm.ExperimentVariant `json:"variants"`
+}
+
+// UpdateExperimentRequest is the DTO for PATCH /admin/api/experiments/{id}
+type UpdateExperimentRequest struct {
+	Status string `json:"status"` // "active" or "stopped"
+}
+
+// TurnFeedbackRequest is the DTO for PUT /api/turns/{id}/feedback
+type TurnFeedbackRequest struct {
+	Rating *int `json:"rating"` // 1 (up), -1 (down) or null to clear
+}
@@ -12,5 +12,13 @@ type MessageBuilder interface {
 	// BuildMessages converts a turn path (with blocks already loaded) to LLM messages
 	// suitable for provider requests. The path should be ordered from oldest to newest.
 	// The caller must load turn blocks before calling this method.
-	BuildMessages(ctx context.Context, path []llm.Turn) ([]Message, error)
+	BuildMessages(ctx context.Context, path []llm.Turn, target MessageTarget) ([]Message, error)
+}
+
+// MessageTarget is the provider and model messages are built for. It decides how content
+// not every model accepts is sent: images are inlined for vision models and described
+// in text for the others.
+type MessageTarget struct {
+	Provider string
+	Model    string
 }
@@ -0,0 +1,40 @@
+package llm
+
+import (
+	"context"
+	"strings"
+)
+
+// ModelAllowlistResolver resolves which models a user's plan may be routed to.
+// This interface allows swapping between different allowlist strategies:
+// - ConfigModelAllowlistResolver: One allowlist for all users (current)
+// - A tier-based resolver reading the user's plan (future)
+type ModelAllowlistResolver interface {
+	// GetAllowedModels returns the models the user may use, or nil if every model is allowed
+	GetAllowedModels(ctx context.Context, userID string) ([]string, error)
+}
+
+// ConfigModelAllowlistResolver returns the same allowlist for all users.
+// Used when no plan system is in place.
+type ConfigModelAllowlistResolver struct {
+	models []string
+}
+
+// NewConfigModelAllowlistResolver creates a resolver from a comma-separated model list.
+// An empty list allows every model.
+func NewConfigModelAllowlistResolver(allowlist string) *ConfigModelAllowlistResolver {
+	var models []string
+	for _, model := range strings.Split(allowlist, ",") {
+		if model = strings.TrimSpace(model); model != "" {
+			models = append(models, model)
+		}
+	}
+	return &ConfigModelAllowlistResolver{
+		models: models,
+	}
+}
+
+// GetAllowedModels returns the configured allowlist for any user.
+func (r *ConfigModelAllowlistResolver) GetAllowedModels(ctx context.Context, userID string) ([]string, error) {
+	return r.models, nil
+}
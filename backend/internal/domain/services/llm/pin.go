@@ -0,0 +1,30 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/docsystem"
+	"meridian/internal/domain/models/llm"
+)
+
+// PinService saves assistant turns into the project as documents ("save this answer to my notes")
+type PinService interface {
+	// PinTurn creates a document from an assistant turn's text in the project's pin folder
+	// and records the document's backlink to the turn
+	PinTurn(ctx context.Context, userID, turnID string, req *PinTurnRequest) (*PinnedTurn, error)
+
+	// GetDocumentSource returns the turn a document was pinned from (ErrNotFound if none)
+	GetDocumentSource(ctx context.Context, userID, documentID string) (*llm.TurnPin, error)
+}
+
+// PinTurnRequest optionally overrides where the pinned document goes and its name
+type PinTurnRequest struct {
+	FolderPath *string `json:"folder_path"` // Default: the project's pin_folder, else "Notes"
+	Name       *string `json:"name"`        // Default: derived from the turn's first line
+}
+
+// PinnedTurn is the result of pinning a turn
+type PinnedTurn struct {
+	Document *docsystem.Document `json:"document"`
+	Source   *llm.TurnPin        `json:"source"`
+}
@@ -0,0 +1,33 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/llm"
+)
+
+// ProviderKeyService manages users' own provider API keys ("bring your own key")
+type ProviderKeyService interface {
+	ProviderKeyResolver
+
+	// ListKeys returns the user's keys (hints only, never the keys)
+	ListKeys(ctx context.Context, userID string) ([]llm.UserProviderKey, error)
+
+	// SetKey stores the user's key for a provider, replacing any existing one
+	SetKey(ctx context.Context, userID, provider string, req *SetProviderKeyRequest) (*llm.UserProviderKey, error)
+
+	// DeleteKey removes the user's key for a provider (ErrNotFound if none)
+	DeleteKey(ctx context.Context, userID, provider string) error
+}
+
+// ProviderKeyResolver looks up the API key a user's requests to a provider should use
+type ProviderKeyResolver interface {
+	// GetAPIKey returns the user's decrypted key for a provider
+	// Returns domain.ErrNotFound if the user has none (use the server's key)
+	GetAPIKey(ctx context.Context, userID, provider string) (string, error)
+}
+
+// SetProviderKeyRequest is the body of PUT /api/users/me/provider-keys/{provider}
+type SetProviderKeyRequest struct {
+	APIKey string `json:"api_key"`
+}
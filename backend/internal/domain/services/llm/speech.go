@@ -0,0 +1,31 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain/models/llm"
+)
+
+// SpeechService turns assistant answers into audio for users who'd rather listen
+type SpeechService interface {
+	// SynthesizeTurn reads the turn's text blocks aloud and returns a link to the audio.
+	// Audio is cached per turn text and voice, so repeated requests don't hit the provider.
+	// Returns ErrValidation if text-to-speech isn't configured, the turn is still streaming,
+	// has no text, or its text exceeds the configured limit.
+	SynthesizeTurn(ctx context.Context, userID, turnID string) (*llm.TurnAudio, error)
+}
+
+// SpeechSynthesizer is a text-to-speech provider (OpenAI, ElevenLabs, ...)
+type SpeechSynthesizer interface {
+	// Synthesize converts text (at most MaxInputChars characters) to audio
+	Synthesize(ctx context.Context, text string) ([]byte, error)
+
+	// MaxInputChars is the longest text one Synthesize call accepts
+	MaxInputChars() int
+
+	// MIMEType is the format Synthesize returns, e.g. "audio/mpeg"
+	MIMEType() string
+
+	// Voice identifies the provider, model and voice; audio is cached per voice
+	Voice() string
+}
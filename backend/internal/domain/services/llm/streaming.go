@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"meridian/internal/domain/models/llm"
+	"meridian/internal/eventstream"
 )
 
 // StreamingService defines the business logic for turn creation and streaming orchestration
@@ -18,6 +19,11 @@ type StreamingService interface {
 	// Note: Only accepts "user" role. Assistant turns are created internally
 	CreateTurn(ctx context.Context, req *CreateTurnRequest) (*CreateTurnResponse, error)
 
+	// RetryTurn regenerates an assistant turn: creates a sibling assistant turn off the
+	// same user turn and streams it, with the original request params plus any overrides
+	// Returns the existing user turn and the new assistant turn (Chat is never set)
+	RetryTurn(ctx context.Context, req *RetryTurnRequest) (*CreateTurnResponse, error)
+
 	// CreateAssistantTurnDebug creates an assistant turn (DEBUG/INTERNAL USE ONLY)
 	// WARNING: This method should ONLY be called by:
 	// - Debug handlers (when ENVIRONMENT=dev)
@@ -31,10 +37,26 @@ type StreamingService interface {
 	// the underlying LLM provider library for a CreateTurn request.
 	BuildDebugProviderRequest(ctx context.Context, req *CreateTurnRequest) (map[string]interface{}, error)
 
+	// ReplayTurnDebug copies the path of an assistant turn into a new sandbox chat in the
+	// caller's project and regenerates the turn there (DEBUG/INTERNAL USE ONLY).
+	// The source turn is not ownership-checked; only expose via debug handlers.
+	ReplayTurnDebug(ctx context.Context, req *ReplayTurnRequest) (*CreateTurnResponse, error)
+
+	// InterruptTurn stops a streaming turn and waits for it to finish. The turn ends
+	// "cancelled" with stop_reason "interrupted" (completed blocks kept) or, in discard
+	// mode, "discarded" (blocks deleted). Returns the turn as it was left.
+	// A turn that finished before the stop is returned unchanged.
+	InterruptTurn(ctx context.Context, req *InterruptTurnRequest) (*llm.Turn, error)
+
+	// StreamHistory rebuilds the events of a turn whose stream has ended, for a client
+	// connecting after the stream left the registry: turn_start, the blocks and the event
+	// that ended the stream. Events a numeric lastEventID (DEBUG event IDs) covers are
+	// skipped. Returns domain.ErrNotFound if no stream index was saved for the turn.
+	StreamHistory(ctx context.Context, turnID, lastEventID string) ([]eventstream.Event, error)
+
 	// TODO: Phase 2 - Additional streaming methods
 	// Future methods to add:
 	// - GetTurnExecutor(turnID string) (*TurnExecutor, error) - Get executor for SSE connection
-	// - InterruptTurn(ctx, turnID) error - Cancel streaming turn
 }
 
 // CreateTurnRequest is the DTO for creating a new turn
@@ -45,14 +67,42 @@ type StreamingService interface {
 // 3. Else if ProjectID provided → create new chat (cold start, title from first text block)
 // 4. Else → validation error
 type CreateTurnRequest struct {
-	ChatID         *string                `json:"chat_id,omitempty"`    // Optional - if nil with ProjectID, creates new chat
-	ProjectID      *string                `json:"project_id,omitempty"` // Required if ChatID is nil (for new chat creation)
-	UserID         string                 `json:"-"`                    // Set by handler from auth context, not from request body
-	PrevTurnID     *string                `json:"prev_turn_id,omitempty"`
-	Role           string                 `json:"role"`                      // "user" only (backend generates assistant turns)
-	SelectedSkills []string               `json:"selected_skills,omitempty"` // Skills to load from .skills/ folder
-	TurnBlocks     []TurnBlockInput       `json:"turn_blocks,omitempty"`
-	RequestParams  map[string]interface{} `json:"request_params,omitempty"` // LLM request parameters (model, temperature, thinking_enabled, system, etc.)
+	ChatID         *string            `json:"chat_id,omitempty"`    // Optional - if nil with ProjectID, creates new chat
+	ProjectID      *string            `json:"project_id,omitempty"` // Required if ChatID is nil (for new chat creation)
+	UserID         string             `json:"-"`                    // Set by handler from auth context, not from request body
+	PrevTurnID     *string            `json:"prev_turn_id,omitempty"`
+	Role           string             `json:"role"`                      // "user" only (backend generates assistant turns)
+	SelectedSkills []string           `json:"selected_skills,omitempty"` // Skills to load from .skills/ folder
+	MatchStyle     bool               `json:"match_style,omitempty"`     // Add the project's style profile to the system prompt
+	TurnBlocks     []TurnBlockInput   `json:"turn_blocks,omitempty"`
+	RequestParams  *llm.RequestParams `json:"request_params,omitempty"` // LLM request parameters (model, temperature, thinking_enabled, system, etc.)
+
+	// ChatIdempotencyKey is a client-generated key for a cold start: a repeated key fails
+	// with a conflict naming the chat the first request created instead of creating another
+	ChatIdempotencyKey *string `json:"chat_idempotency_key,omitempty"`
+}
+
+// RetryTurnRequest is the DTO for regenerating an assistant turn
+type RetryTurnRequest struct {
+	TurnID         string             `json:"-"`                         // Assistant turn to regenerate, from the URL
+	UserID         string             `json:"-"`                         // Set by handler from auth context, not from request body
+	SelectedSkills []string           `json:"selected_skills,omitempty"` // Skills to load from .skills/ folder
+	MatchStyle     bool               `json:"match_style,omitempty"`     // Add the project's style profile to the system prompt
+	RequestParams  *llm.RequestParams `json:"request_params,omitempty"`  // Overrides of the retried turn's request params
+}
+
+// ReplayTurnRequest is the DTO for replaying a turn into a sandbox chat (debug only)
+type ReplayTurnRequest struct {
+	TurnID        string             `json:"-"`                        // Assistant turn to replay, from the URL
+	UserID        string             `json:"-"`                        // Set by handler from auth context; owns the sandbox chat
+	ProjectID     string             `json:"project_id"`               // Caller's project that receives the sandbox chat
+	RequestParams *llm.RequestParams `json:"request_params,omitempty"` // Overrides of the replayed turn's params (e.g. a lorem model)
+}
+
+// InterruptTurnRequest is the DTO for stopping a streaming turn
+type InterruptTurnRequest struct {
+	TurnID string            `json:"-"`              // Streaming assistant turn, from the URL
+	Mode   llm.InterruptMode `json:"mode,omitempty"` // "keep" (default) or "discard"
 }
 
 // TurnBlockInput is the DTO for content block creation
@@ -70,4 +120,7 @@ type CreateTurnResponse struct {
 	UserTurn      *llm.Turn `json:"user_turn"`
 	AssistantTurn *llm.Turn `json:"assistant_turn"`
 	StreamURL     string    `json:"stream_url"` // Convenience URL for SSE streaming
+
+	// SystemPrompt reports the estimated size of the resolved system prompt (nil if none)
+	SystemPrompt *llm.SystemPromptUsage `json:"system_prompt,omitempty"`
 }
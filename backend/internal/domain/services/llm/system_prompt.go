@@ -9,9 +9,15 @@ type SystemPromptResolver interface {
 	// Resolve builds the final system prompt by concatenating:
 	// 1. user-provided system prompt (from request_params.system)
 	// 2. project.system_prompt
-	// 3. chat.system_prompt
-	// 4. Content of each skill's SKILL file from .skills/{skill_name}/SKILL
+	// 3. The project's style profile, when matchStyle is set and the profile is complete
+	// 4. chat.system_prompt
+	// 5. Content of each skill's SKILL file from .skills/{skill_name}/SKILL
 	//
 	// Returns nil if no prompts are found.
-	Resolve(ctx context.Context, chatID string, userID string, userSystem *string, selectedSkills []string) (*string, error)
+	Resolve(ctx context.Context, chatID string, userID string, userSystem *string, selectedSkills []string, matchStyle bool) (*string, error)
+
+	// ResolveProject builds the system prompt a new chat of the project would get, without
+	// chat or user prompts: the project's system_prompt, then each selected skill.
+	// Used where prompts run outside a chat (evals). Returns nil if no prompts are found.
+	ResolveProject(ctx context.Context, projectID string, userID string, selectedSkills []string) (*string, error)
 }
@@ -0,0 +1,37 @@
+package llm
+
+import (
+	"context"
+	"time"
+
+	"meridian/internal/domain/models/llm"
+)
+
+// UsageService reports users' LLM spend
+type UsageService interface {
+	// GetUsage returns the user's token usage and cost, grouped by chat, project or month
+	GetUsage(ctx context.Context, userID string, req *GetUsageRequest) (*llm.UsageReport, error)
+
+	// GetChatUsage returns a chat's daily (or monthly) usage per model
+	GetChatUsage(ctx context.Context, userID, chatID string, req *GetUsageRollupRequest) (*llm.UsageRollup, error)
+
+	// GetProjectUsage returns the daily (or monthly) usage per model of a project's chats
+	GetProjectUsage(ctx context.Context, userID, projectID string, req *GetUsageRollupRequest) (*llm.UsageRollup, error)
+}
+
+// GetUsageRequest holds the query parameters of GET /api/usage
+type GetUsageRequest struct {
+	GroupBy  string     // "chat", "project" or "month" (default "month")
+	From     *time.Time // Inclusive (nil = since the first turn)
+	To       *time.Time // Exclusive (nil = until now)
+	Timezone string     // IANA time zone ("" = the user's preference, else UTC)
+}
+
+// GetUsageRollupRequest holds the query parameters of GET /api/chats/{id}/usage and
+// GET /api/projects/{id}/usage
+type GetUsageRollupRequest struct {
+	Granularity string     // "day" or "month" (default "day")
+	From        *time.Time // Inclusive day (nil = since the first turn)
+	To          *time.Time // Exclusive day (nil = until now)
+	Timezone    string     // IANA time zone ("" = the user's preference, else UTC)
+}
@@ -0,0 +1,63 @@
+package llm
+
+import (
+	"context"
+
+	"meridian/internal/domain"
+	"meridian/internal/domain/models/llm"
+)
+
+// WorkflowService manages workflows and starts them as chats
+type WorkflowService interface {
+	CreateWorkflow(ctx context.Context, userID, projectID string, req *CreateWorkflowRequest) (*llm.Workflow, error)
+	ListWorkflows(ctx context.Context, userID, projectID string) ([]llm.Workflow, error)
+	GetWorkflow(ctx context.Context, userID, projectID, workflowID string) (*llm.Workflow, error)
+	UpdateWorkflow(ctx context.Context, userID, projectID, workflowID string, req *UpdateWorkflowRequest) (*llm.Workflow, error)
+	DeleteWorkflow(ctx context.Context, userID, projectID, workflowID string) error
+
+	// StartWorkflow renders the workflow's steps with the request's values and creates a
+	// chat with a run at step 1. The client sends each step's prompt as a turn.
+	StartWorkflow(ctx context.Context, userID, projectID, workflowID string, req *StartWorkflowRequest) (*StartedWorkflow, error)
+
+	// GetRun returns the workflow run of a chat (ErrNotFound if it has none)
+	GetRun(ctx context.Context, userID, chatID string) (*llm.WorkflowRun, error)
+
+	// AdvanceRun marks the current step done and moves to the next one; advancing past
+	// the last step completes the run
+	AdvanceRun(ctx context.Context, userID, chatID string, req *AdvanceWorkflowRunRequest) (*llm.WorkflowRun, error)
+}
+
+// CreateWorkflowRequest represents a workflow creation request
+type CreateWorkflowRequest struct {
+	Name         string             `json:"name"`
+	Description  string             `json:"description"`
+	SystemPrompt *string            `json:"system_prompt"`
+	Steps        []llm.WorkflowStep `json:"steps"`
+}
+
+// UpdateWorkflowRequest is the DTO for updating a workflow (PATCH semantics).
+// Steps are replaced as a whole; system_prompt null clears it.
+type UpdateWorkflowRequest struct {
+	Name         domain.Optional[string]             `json:"name,omitzero"`
+	Description  domain.Optional[string]             `json:"description,omitzero"`
+	SystemPrompt domain.Optional[string]             `json:"system_prompt,omitzero"`
+	Steps        domain.Optional[[]llm.WorkflowStep] `json:"steps,omitzero"`
+}
+
+// StartWorkflowRequest represents a request to start a workflow in a new chat
+type StartWorkflowRequest struct {
+	Title  string            `json:"title"`  // Chat title; empty = workflow name
+	Values map[string]string `json:"values"` // Placeholder values; every placeholder is required
+}
+
+// AdvanceWorkflowRunRequest represents a request to advance a workflow run.
+// Step is the 0-based step the client is completing, so a repeated click can't skip one.
+type AdvanceWorkflowRunRequest struct {
+	Step *int `json:"step"`
+}
+
+// StartedWorkflow is the chat created for a workflow and its run
+type StartedWorkflow struct {
+	Chat *llm.Chat        `json:"chat"`
+	Run  *llm.WorkflowRun `json:"run"`
+}
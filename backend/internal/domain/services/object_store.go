@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"time"
+)
+
+// ObjectStore stores binary assets (export files, generated images, uploads) outside
+// Postgres. Keys are slash-separated relative paths, e.g. "exports/{project_id}/{job_id}/book.epub".
+//
+// Implementations: storage.LocalStore (disk), storage.S3Store (S3, Supabase Storage, R2, MinIO).
+type ObjectStore interface {
+	// Put stores data under key, replacing any existing object
+	Put(ctx context.Context, key, contentType string, data []byte) error
+
+	// Get loads an object. Returns ErrNotFound if it doesn't exist.
+	Get(ctx context.Context, key string) (*StoredObject, error)
+
+	// Exists reports whether an object is stored under key
+	Exists(ctx context.Context, key string) (bool, error)
+
+	// Delete removes an object; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+
+	// SignedURL returns a URL that downloads the object without other credentials
+	// until ttl has passed
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// StoredObject is an object loaded from an ObjectStore
+type StoredObject struct {
+	ContentType string
+	Data        []byte
+}
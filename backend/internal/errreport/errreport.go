@@ -0,0 +1,82 @@
+// Package errreport sends panics and unexpected errors to a Sentry-compatible error tracker.
+//
+// Capture points call the package-level functions (like slog's default logger), so code
+// without an injected reporter can still report. Until SetDefault is called with a reporter
+// built from a DSN, captures are no-ops.
+//
+// Request-scoped identifiers (request_id, user_id, chat_id, turn_id) are read from the
+// context via the logging package and attached to every event as tags.
+package errreport
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// Reporter delivers error events to an error tracker
+type Reporter interface {
+	// CaptureError reports an error; extra holds additional key-value pairs (like slog args)
+	CaptureError(ctx context.Context, err error, extra ...any)
+	// CapturePanic reports a recovered panic value
+	CapturePanic(ctx context.Context, recovered any, extra ...any)
+	// Flush waits up to timeout for queued events to be sent. Returns false on timeout.
+	Flush(timeout time.Duration) bool
+}
+
+// nopReporter discards everything (used when no DSN is configured)
+type nopReporter struct{}
+
+func (nopReporter) CaptureError(context.Context, error, ...any) {}
+func (nopReporter) CapturePanic(context.Context, any, ...any)   {}
+func (nopReporter) Flush(time.Duration) bool                    { return true }
+
+// Nop returns a reporter that discards all events
+func Nop() Reporter {
+	return nopReporter{}
+}
+
+type holder struct{ Reporter }
+
+var defaultReporter atomic.Value // holder
+
+func init() {
+	defaultReporter.Store(holder{Nop()})
+}
+
+// SetDefault makes r the reporter used by the package-level capture functions
+func SetDefault(r Reporter) {
+	if r == nil {
+		r = Nop()
+	}
+	defaultReporter.Store(holder{r})
+}
+
+// Default returns the reporter used by the package-level capture functions
+func Default() Reporter {
+	return defaultReporter.Load().(holder).Reporter
+}
+
+// CaptureError reports err via the default reporter. Nil errors and context
+// cancellation (client went away, user interrupted) are ignored.
+func CaptureError(ctx context.Context, err error, extra ...any) {
+	if err == nil || isCancellation(err) {
+		return
+	}
+	Default().CaptureError(ctx, err, extra...)
+}
+
+// CapturePanic reports a recovered panic value via the default reporter
+func CapturePanic(ctx context.Context, recovered any, extra ...any) {
+	Default().CapturePanic(ctx, recovered, extra...)
+}
+
+// Flush waits for the default reporter's queued events (call before exit)
+func Flush(timeout time.Duration) bool {
+	return Default().Flush(timeout)
+}
+
+func isCancellation(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
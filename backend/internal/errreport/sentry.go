@@ -0,0 +1,342 @@
+package errreport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"meridian/internal/logging"
+)
+
+// Config configures the Sentry-compatible reporter
+type Config struct {
+	DSN         string // https://<public_key>@<host>/<project_id>; empty disables reporting
+	Environment string // e.g. "dev", "prod"
+	Release     string // optional build/version identifier
+	QueueSize   int    // events buffered for sending (default: 64); further events are dropped
+}
+
+const (
+	defaultQueueSize = 64
+	sendTimeout      = 10 * time.Second
+	clientName       = "meridian-errreport/1.0"
+)
+
+// dsn is a parsed Sentry DSN
+type dsn struct {
+	publicKey   string
+	envelopeURL string
+}
+
+func parseDSN(raw string) (*dsn, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DSN: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid DSN: unsupported scheme %q", u.Scheme)
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return nil, fmt.Errorf("invalid DSN: missing public key")
+	}
+
+	// Path is /<project_id>, optionally prefixed (self-hosted behind a path)
+	path := strings.TrimSuffix(u.Path, "/")
+	idx := strings.LastIndex(path, "/")
+	projectID := path[idx+1:]
+	if projectID == "" {
+		return nil, fmt.Errorf("invalid DSN: missing project ID")
+	}
+
+	return &dsn{
+		publicKey:   u.User.Username(),
+		envelopeURL: fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, path[:idx], projectID),
+	}, nil
+}
+
+// SentryReporter sends events to a Sentry-compatible envelope endpoint.
+// Capturing never blocks: events are queued and sent by a background worker.
+type SentryReporter struct {
+	dsn         *dsn
+	environment string
+	release     string
+	serverName  string
+	client      *http.Client
+	logger      *slog.Logger
+
+	queue   chan []byte
+	pending sync.WaitGroup
+}
+
+// New creates a reporter from cfg. Returns Nop() when no DSN is configured.
+func New(cfg Config, logger *slog.Logger) (Reporter, error) {
+	if cfg.DSN == "" {
+		return Nop(), nil
+	}
+	parsed, err := parseDSN(cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	hostname, _ := os.Hostname()
+
+	r := &SentryReporter{
+		dsn:         parsed,
+		environment: cfg.Environment,
+		release:     cfg.Release,
+		serverName:  hostname,
+		client:      &http.Client{Timeout: sendTimeout},
+		logger:      logger,
+		queue:       make(chan []byte, queueSize),
+	}
+	go r.worker()
+	return r, nil
+}
+
+// CaptureError implements Reporter
+func (r *SentryReporter) CaptureError(ctx context.Context, err error, extra ...any) {
+	r.capture(ctx, "error", exception{
+		Type:  fmt.Sprintf("%T", err),
+		Value: err.Error(),
+	}, extra)
+}
+
+// CapturePanic implements Reporter. Must be called from the deferred function that
+// recovered, so the captured stack still includes the panicking frames.
+func (r *SentryReporter) CapturePanic(ctx context.Context, recovered any, extra ...any) {
+	value := fmt.Sprint(recovered)
+	if err, ok := recovered.(error); ok {
+		value = err.Error()
+	}
+	r.capture(ctx, "fatal", exception{
+		Type:      "panic",
+		Value:     value,
+		Mechanism: &mechanism{Type: "recover", Handled: false},
+	}, extra)
+}
+
+// Flush implements Reporter
+func (r *SentryReporter) Flush(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		r.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+func (r *SentryReporter) capture(ctx context.Context, level string, exc exception, extra []any) {
+	exc.Stacktrace = &stacktrace{Frames: callerFrames(4)}
+
+	ev := event{
+		EventID:     strings.ReplaceAll(uuid.NewString(), "-", ""),
+		Timestamp:   time.Now().UTC().Format(time.RFC3339Nano),
+		Platform:    "go",
+		Level:       level,
+		Logger:      "meridian",
+		Environment: r.environment,
+		Release:     r.release,
+		ServerName:  r.serverName,
+		Exception:   exceptionList{Values: []exception{exc}},
+		Tags:        map[string]string{},
+		Extra:       kvMap(extra),
+	}
+
+	// Request-scoped identifiers become tags (searchable); user_id also fills the user field
+	attrs := logging.Attrs(ctx)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		key, _ := attrs[i].(string)
+		value, _ := attrs[i+1].(string)
+		ev.Tags[key] = value
+		if key == logging.KeyUserID {
+			ev.User = &user{ID: value}
+		}
+	}
+
+	payload, err := buildEnvelope(&ev)
+	if err != nil {
+		r.logger.Warn("failed to encode error report", "error", err)
+		return
+	}
+
+	r.pending.Add(1)
+	select {
+	case r.queue <- payload:
+	default:
+		r.pending.Done()
+		r.logger.Warn("error report queue full, dropping event", "event_id", ev.EventID)
+	}
+}
+
+func (r *SentryReporter) worker() {
+	for payload := range r.queue {
+		if err := r.send(payload); err != nil {
+			r.logger.Warn("failed to send error report", "error", err)
+		}
+		r.pending.Done()
+	}
+}
+
+func (r *SentryReporter) send(payload []byte) error {
+	req, err := http.NewRequest(http.MethodPost, r.dsn.envelopeURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s",
+		clientName, r.dsn.publicKey))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("error tracker responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// buildEnvelope encodes an event as a single-item envelope:
+// envelope header, item header, item payload (newline-separated JSON)
+func buildEnvelope(ev *event) ([]byte, error) {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return nil, err
+	}
+	header, err := json.Marshal(map[string]string{
+		"event_id": ev.EventID,
+		"sent_at":  time.Now().UTC().Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return nil, err
+	}
+	itemHeader, err := json.Marshal(map[string]any{"type": "event", "length": len(body)})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(header)
+	buf.WriteByte('\n')
+	buf.Write(itemHeader)
+	buf.WriteByte('\n')
+	buf.Write(body)
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// callerFrames returns the current goroutine's stack, oldest frame first (as Sentry expects).
+// skip drops the innermost frames belonging to the reporter itself.
+func callerFrames(skip int) []frame {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip, pcs)
+	iter := runtime.CallersFrames(pcs[:n])
+
+	var frames []frame
+	for {
+		f, more := iter.Next()
+		frames = append(frames, frame{
+			Function: f.Function,
+			AbsPath:  f.File,
+			Lineno:   f.Line,
+			InApp:    strings.HasPrefix(f.Function, "meridian/") && !strings.HasPrefix(f.Function, "meridian/internal/errreport."),
+		})
+		if !more {
+			break
+		}
+	}
+
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+	return frames
+}
+
+// kvMap converts slog-style key-value pairs to a map
+func kvMap(kv []any) map[string]any {
+	if len(kv) == 0 {
+		return nil
+	}
+	m := make(map[string]any, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			key = fmt.Sprint(kv[i])
+		}
+		value := kv[i+1]
+		if err, ok := value.(error); ok {
+			value = err.Error()
+		}
+		m[key] = value
+	}
+	return m
+}
+
+// Event payload (subset of the Sentry event schema)
+
+type event struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   string            `json:"timestamp"`
+	Platform    string            `json:"platform"`
+	Level       string            `json:"level"`
+	Logger      string            `json:"logger"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Exception   exceptionList     `json:"exception"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Extra       map[string]any    `json:"extra,omitempty"`
+	User        *user             `json:"user,omitempty"`
+}
+
+type exceptionList struct {
+	Values []exception `json:"values"`
+}
+
+type exception struct {
+	Type       string      `json:"type"`
+	Value      string      `json:"value"`
+	Mechanism  *mechanism  `json:"mechanism,omitempty"`
+	Stacktrace *stacktrace `json:"stacktrace,omitempty"`
+}
+
+type mechanism struct {
+	Type    string `json:"type"`
+	Handled bool   `json:"handled"`
+}
+
+type stacktrace struct {
+	Frames []frame `json:"frames"`
+}
+
+type frame struct {
+	Function string `json:"function"`
+	AbsPath  string `json:"abs_path,omitempty"`
+	Lineno   int    `json:"lineno,omitempty"`
+	InApp    bool   `json:"in_app"`
+}
+
+type user struct {
+	ID string `json:"id"`
+}
@@ -0,0 +1,140 @@
+package errreport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"meridian/internal/logging"
+)
+
+func TestParseDSN(t *testing.T) {
+	tests := []struct {
+		raw     string
+		wantURL string
+		wantErr bool
+	}{
+		{raw: "https://abc@o1.ingest.sentry.io/42", wantURL: "https://o1.ingest.sentry.io/api/42/envelope/"},
+		{raw: "http://abc@localhost:9000/sentry/7", wantURL: "http://localhost:9000/sentry/api/7/envelope/"},
+		{raw: "https://o1.ingest.sentry.io/42", wantErr: true}, // no key
+		{raw: "https://abc@o1.ingest.sentry.io/", wantErr: true},
+		{raw: "ftp://abc@host/1", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseDSN(tt.raw)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseDSN(%q) expected error", tt.raw)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("parseDSN(%q): %v", tt.raw, err)
+		}
+		if got.envelopeURL != tt.wantURL || got.publicKey != "abc" {
+			t.Errorf("parseDSN(%q) = %+v", tt.raw, got)
+		}
+	}
+}
+
+func TestSentryReporter_CaptureError(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		auth     string
+		payloads [][]byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		auth = r.Header.Get("X-Sentry-Auth")
+		payloads = append(payloads, body)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "http://", "http://pubkey@", 1) + "/5"
+	reporter, err := New(Config{DSN: dsn, Environment: "test"}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := logging.WithTurnID(logging.WithUserID(context.Background(), "user-1"), "turn-1")
+	reporter.CaptureError(ctx, errors.New("provider exploded"), "model", "m-1")
+	if !reporter.Flush(time.Second) {
+		t.Fatal("flush timed out")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(payloads) != 1 {
+		t.Fatalf("got %d envelopes, want 1", len(payloads))
+	}
+	if !strings.Contains(auth, "sentry_key=pubkey") {
+		t.Errorf("auth header = %q", auth)
+	}
+
+	// Envelope: header line, item header line, event line
+	scanner := bufio.NewScanner(bytes.NewReader(payloads[0]))
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if len(lines) != 3 {
+		t.Fatalf("envelope has %d lines, want 3", len(lines))
+	}
+
+	var ev event
+	if err := json.Unmarshal([]byte(lines[2]), &ev); err != nil {
+		t.Fatal(err)
+	}
+	if ev.Environment != "test" || ev.Level != "error" {
+		t.Errorf("event = %+v", ev)
+	}
+	if ev.Exception.Values[0].Value != "provider exploded" {
+		t.Errorf("exception = %+v", ev.Exception.Values[0])
+	}
+	if ev.Tags[logging.KeyTurnID] != "turn-1" || ev.User == nil || ev.User.ID != "user-1" {
+		t.Errorf("tags = %v, user = %v", ev.Tags, ev.User)
+	}
+	if ev.Extra["model"] != "m-1" {
+		t.Errorf("extra = %v", ev.Extra)
+	}
+
+	// Innermost frame is the test (reporter frames are skipped), oldest first
+	frames := ev.Exception.Values[0].Stacktrace.Frames
+	if last := frames[len(frames)-1]; !strings.HasSuffix(last.Function, "TestSentryReporter_CaptureError") {
+		t.Errorf("innermost frame = %q", last.Function)
+	}
+}
+
+func TestCaptureError_IgnoresCancellation(t *testing.T) {
+	rec := &recordingReporter{}
+	SetDefault(rec)
+	defer SetDefault(nil)
+
+	CaptureError(context.Background(), nil)
+	CaptureError(context.Background(), context.Canceled)
+	CaptureError(context.Background(), errors.Join(errors.New("stream"), context.DeadlineExceeded))
+	CaptureError(context.Background(), errors.New("boom"))
+
+	if rec.errors != 1 {
+		t.Errorf("captured %d errors, want 1", rec.errors)
+	}
+}
+
+type recordingReporter struct {
+	nopReporter
+	errors int
+}
+
+func (r *recordingReporter) CaptureError(context.Context, error, ...any) { r.errors++ }
@@ -0,0 +1,58 @@
+package eventstream
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// drainPollInterval is how often Drain checks whether the running streams finished
+const drainPollInterval = 50 * time.Millisecond
+
+// Drain waits for the registry's running streams to finish, including streams registered
+// while it waits. Streams still running when ctx is done are cancelled, so their work
+// functions stop and persist what they have, and are waited for up to cancelWait. Their
+// clients are first advised that the streams are being evicted (with the RetryAfter of
+// the current advice). Returns the number of streams cancelled.
+func Drain(ctx context.Context, registry Registry, cancelWait time.Duration) (int, error) {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		running := registry.Running()
+		if len(running) == 0 {
+			return 0, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			var retryAfter time.Duration
+			if advice, _ := registry.Advice(); advice != nil {
+				retryAfter = advice.RetryAfter
+			}
+			registry.Advise(ReconnectAdvice{Reason: AdviceEvicting, RetryAfter: retryAfter})
+			for _, stream := range running {
+				stream.Cancel()
+			}
+			return len(running), waitStopped(registry, cancelWait, ticker)
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitStopped polls until no stream is running or the timeout passes
+func waitStopped(registry Registry, timeout time.Duration, ticker *time.Ticker) error {
+	deadline := time.After(timeout)
+	for {
+		running := registry.Running()
+		if len(running) == 0 {
+			return nil
+		}
+
+		select {
+		case <-deadline:
+			return fmt.Errorf("%d streams did not stop within %s", len(running), timeout)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package eventstream
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDrain(t *testing.T) {
+	registry := NewRegistry(RegistryOptions{})
+	finishing := NewStream("finishing", func(ctx context.Context, sink EventSink) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	}, StreamOptions{})
+	blocked := NewStream("blocked", func(ctx context.Context, sink EventSink) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, StreamOptions{})
+	for _, stream := range []StreamHandle{finishing, blocked} {
+		if err := registry.Register(stream); err != nil {
+			t.Fatalf("Register(%s): %v", stream.ID(), err)
+		}
+		stream.Start()
+	}
+
+	registry.Advise(ReconnectAdvice{Reason: AdviceDraining, RetryAfter: 3 * time.Second})
+	_, adviceChanged := registry.Advice()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	cancelled, err := Drain(ctx, registry, 5*time.Second)
+	if err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+
+	if cancelled != 1 {
+		t.Errorf("cancelled %d streams, want only the blocked one", cancelled)
+	}
+	if status := finishing.Status(); status != StatusComplete {
+		t.Errorf("finishing stream = %s, want complete", status)
+	}
+	if status := blocked.Status(); !status.Done() {
+		t.Errorf("blocked stream = %s, want finished", status)
+	}
+	if running := registry.Running(); len(running) != 0 {
+		t.Errorf("%d streams still running after drain", len(running))
+	}
+
+	select {
+	case <-adviceChanged:
+	default:
+		t.Error("advice watchers not woken before eviction")
+	}
+	advice, _ := registry.Advice()
+	if want := (ReconnectAdvice{Reason: AdviceEvicting, RetryAfter: 3 * time.Second}); advice == nil || *advice != want {
+		t.Errorf("advice = %+v, want %+v", advice, want)
+	}
+}
+
+func TestDrain_NothingRunning(t *testing.T) {
+	registry := NewRegistry(RegistryOptions{})
+	cancelled, err := Drain(context.Background(), registry, time.Second)
+	if cancelled != 0 || err != nil {
+		t.Errorf("Drain = %d, %v; want 0, nil", cancelled, err)
+	}
+	if advice, _ := registry.Advice(); advice != nil {
+		t.Errorf("advice = %+v, want none when nothing was evicted", advice)
+	}
+}
@@ -0,0 +1,133 @@
+// Package eventstream is the streaming layer between turn executors and the SSE and
+// WebSocket handlers: a stream runs one turn's work function, buffers its events for
+// catchup and fans them out to connected clients; a registry finds streams by turn ID.
+//
+// The rest of the backend depends only on the types and interfaces here. The
+// implementation (mstream.go) is backed by meridian-stream-go, pinned in go.mod;
+// upgrading or replacing that library (or adding a Redis-backed registry) only touches
+// this package. mstream_test.go checks the semantics the backend relies on.
+package eventstream
+
+import (
+	"context"
+	"time"
+)
+
+// Event is one streamed event; the fields map to the SSE event fields
+type Event struct {
+	Type  string // SSE event name (llmModels.SSEEvent*)
+	ID    string // Set by the stream when event IDs are enabled
+	Data  []byte // JSON payload
+	Retry int    // Reconnect delay in milliseconds (0 = unset)
+}
+
+// NewEvent creates an event of the given type
+func NewEvent(eventType string, data []byte) Event {
+	return Event{Type: eventType, Data: data}
+}
+
+// Status is a stream's lifecycle state
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // Created but not started
+	StatusRunning   Status = "running"   // Work function running
+	StatusComplete  Status = "complete"  // Work function returned nil
+	StatusError     Status = "error"     // Work function returned an error
+	StatusCancelled Status = "cancelled" // Cancelled before finishing
+)
+
+// Done reports whether the stream has finished (completed, failed or cancelled)
+func (s Status) Done() bool {
+	return s == StatusComplete || s == StatusError || s == StatusCancelled
+}
+
+// EventSink receives the events a work function produces. Send buffers the event for
+// catchup and delivers it to connected clients without blocking on slow ones.
+type EventSink interface {
+	Send(event Event)
+}
+
+// WorkFunc produces a stream's events. ctx is cancelled when the stream is cancelled.
+type WorkFunc func(ctx context.Context, sink EventSink) error
+
+// CatchupFunc rebuilds a stream's events from storage for a client whose last event is
+// no longer buffered (or a first connection, with an empty lastEventID)
+type CatchupFunc func(streamID, lastEventID string) ([]Event, error)
+
+// StreamOptions configures a new stream
+type StreamOptions struct {
+	Catchup    CatchupFunc // Replays persisted events (nil = buffer only)
+	EventIDs   bool        // Number events "1", "2", ... (Last-Event-ID support; DEBUG mode)
+	BufferSize int         // Per-client channel size (0 = library default)
+}
+
+// StreamHandle is one stream. The producer starts it and persists its buffer as
+// content is saved; consumers replay its catchup events, then follow live events.
+type StreamHandle interface {
+	ID() string
+	Status() Status
+
+	// Start runs the work function in the background (once)
+	Start()
+	// Cancel cancels the work function's context
+	Cancel()
+
+	// CatchupEvents returns the events a client that last saw lastEventID has missed:
+	// the buffered events after it, or the catchup events plus the whole buffer
+	CatchupEvents(lastEventID string) []Event
+	// Subscribe returns a channel of live events, closed when the stream finishes or the
+	// client unsubscribes. Events are dropped for clients that fall behind (they reconnect
+	// for catchup).
+	Subscribe(clientID string) <-chan Event
+	Unsubscribe(clientID string)
+
+	// PersistAndClear passes the buffered events to persist and clears the buffer if it
+	// succeeds; catchup never sees the events in neither or both places. persist is not
+	// called when the buffer is empty.
+	PersistAndClear(persist func(events []Event) error) error
+	// ClearBuffer drops the buffered events
+	ClearBuffer()
+}
+
+// Reconnect advice reasons
+const (
+	AdviceDraining = "draining" // The server is shutting down; running streams may still finish
+	AdviceEvicting = "evicting" // Running streams are about to be cancelled (their content is persisted)
+)
+
+// ReconnectAdvice tells stream clients the instance is going away, so they reconnect
+// after RetryAfter (reaching another instance) instead of retrying a dying one in a loop
+type ReconnectAdvice struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+// RegistryOptions configures a new registry
+type RegistryOptions struct {
+	Retention       time.Duration // How long finished streams stay registered (0 = removed when they finish)
+	CleanupInterval time.Duration // How often StartCleanup removes expired streams (0 = every minute)
+}
+
+// Registry tracks the streams of running turns by ID. Finished streams are kept for the
+// retention period (RegistryOptions), then removed; their buffers are dropped when they
+// finish, so clients connecting later are replayed by the catchup function.
+type Registry interface {
+	// Register adds a stream; registering an ID twice is an error
+	Register(stream StreamHandle) error
+	// Get returns the stream with the ID, or nil (also once its retention period passed)
+	Get(id string) StreamHandle
+	// Count returns the number of running streams
+	Count() int
+	// Running returns the registered streams that haven't finished
+	Running() []StreamHandle
+	// StartCleanup removes expired streams periodically until ctx is done (blocks)
+	StartCleanup(ctx context.Context)
+
+	// Advise sets the reconnect advice for the clients of every stream (it is never cleared;
+	// the instance is going away)
+	Advise(advice ReconnectAdvice)
+	// Advice returns the current advice (nil while the instance is healthy) and a channel
+	// closed when it next changes
+	Advice() (*ReconnectAdvice, <-chan struct{})
+}
@@ -0,0 +1,280 @@
+package eventstream
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	mstream "github.com/haowjy/meridian-stream-go"
+)
+
+// defaultClientBuffer matches meridian-stream-go's default per-client channel size
+const defaultClientBuffer = 20
+
+var (
+	_ StreamHandle = (*mstreamHandle)(nil)
+	_ Registry     = (*mstreamRegistry)(nil)
+)
+
+// mstreamHandle is a StreamHandle backed by an mstream.Stream
+type mstreamHandle struct {
+	stream     *mstream.Stream
+	bufferSize int
+
+	mu         sync.Mutex
+	finishedAt time.Time // When the work function returned (zero while it runs)
+}
+
+// NewStream creates a stream that runs work once started
+func NewStream(id string, work WorkFunc, opts StreamOptions) StreamHandle {
+	bufferSize := opts.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultClientBuffer
+	}
+
+	streamOpts := []mstream.StreamOption{
+		mstream.WithEventIDs(opts.EventIDs),
+		mstream.WithBufferSize(bufferSize),
+	}
+	if opts.Catchup != nil {
+		catchup := opts.Catchup
+		streamOpts = append(streamOpts, mstream.WithCatchup(func(streamID, lastEventID string) ([]mstream.Event, error) {
+			events, err := catchup(streamID, lastEventID)
+			return toMStreamEvents(events), err
+		}))
+	}
+
+	handle := &mstreamHandle{bufferSize: bufferSize}
+	workFunc := func(ctx context.Context, send func(mstream.Event)) error {
+		defer handle.finish()
+		return work(ctx, mstreamSink(send))
+	}
+	handle.stream = mstream.NewStream(id, workFunc, streamOpts...)
+	return handle
+}
+
+// finish records that the work function returned and drops the buffer: everything
+// was persisted, so clients connecting later are replayed by the catchup function
+// alone. It runs just before the library sets the final status.
+func (h *mstreamHandle) finish() {
+	h.stream.ClearBuffer()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.finishedAt = time.Now()
+}
+
+// finished returns when the work function returned (zero while it runs)
+func (h *mstreamHandle) finished() time.Time {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.finishedAt
+}
+
+// mstreamSink adapts the library's send function to EventSink
+type mstreamSink func(mstream.Event)
+
+func (send mstreamSink) Send(event Event) {
+	send(toMStreamEvent(event))
+}
+
+func (h *mstreamHandle) ID() string { return h.stream.ID() }
+
+func (h *mstreamHandle) Status() Status { return Status(h.stream.Status()) }
+
+func (h *mstreamHandle) Start() { h.stream.Start() }
+
+func (h *mstreamHandle) Cancel() { h.stream.Cancel() }
+
+func (h *mstreamHandle) CatchupEvents(lastEventID string) []Event {
+	return fromMStreamEvents(h.stream.GetCatchupEvents(lastEventID))
+}
+
+// Subscribe relays the library's client channel. The relay never blocks: like the
+// library's broadcast, it drops events for a client whose channel is full, and it ends
+// when the library closes the client channel (on Unsubscribe or when the stream ends).
+func (h *mstreamHandle) Subscribe(clientID string) <-chan Event {
+	in := h.stream.AddClient(clientID)
+	out := make(chan Event, h.bufferSize)
+	go func() {
+		defer close(out)
+		for event := range in {
+			select {
+			case out <- fromMStreamEvent(event):
+			default:
+			}
+		}
+	}()
+	return out
+}
+
+func (h *mstreamHandle) Unsubscribe(clientID string) { h.stream.RemoveClient(clientID) }
+
+func (h *mstreamHandle) PersistAndClear(persist func(events []Event) error) error {
+	return h.stream.PersistAndClear(func(events []mstream.Event) error {
+		return persist(fromMStreamEvents(events))
+	})
+}
+
+func (h *mstreamHandle) ClearBuffer() { h.stream.ClearBuffer() }
+
+// defaultCleanupInterval is how often StartCleanup removes expired streams by default
+const defaultCleanupInterval = time.Minute
+
+// mstreamRegistry is a Registry of streams created by NewStream. It keeps the streams
+// itself rather than in an mstream.Registry, which removes a stream (and clears its
+// buffer) as soon as it finishes, whatever its retention period.
+type mstreamRegistry struct {
+	retention       time.Duration
+	cleanupInterval time.Duration
+
+	mu            sync.Mutex
+	handles       map[string]*mstreamHandle
+	advice        *ReconnectAdvice
+	adviceChanged chan struct{} // Closed and replaced by Advise
+}
+
+// NewRegistry creates an in-memory registry for streams created by NewStream
+func NewRegistry(opts RegistryOptions) Registry {
+	cleanupInterval := opts.CleanupInterval
+	if cleanupInterval <= 0 {
+		cleanupInterval = defaultCleanupInterval
+	}
+	return &mstreamRegistry{
+		retention:       max(opts.Retention, 0),
+		cleanupInterval: cleanupInterval,
+		handles:         make(map[string]*mstreamHandle),
+		adviceChanged:   make(chan struct{}),
+	}
+}
+
+func (r *mstreamRegistry) Register(stream StreamHandle) error {
+	handle, ok := stream.(*mstreamHandle)
+	if !ok {
+		return fmt.Errorf("stream %s was not created by eventstream.NewStream", stream.ID())
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.handles[handle.ID()]; ok && !r.expired(existing, time.Now()) {
+		return fmt.Errorf("stream with ID %s already exists", handle.ID())
+	}
+	r.handles[handle.ID()] = handle
+	return nil
+}
+
+func (r *mstreamRegistry) Running() []StreamHandle {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	var running []StreamHandle
+	for id, handle := range r.handles {
+		if r.expired(handle, now) {
+			delete(r.handles, id)
+			continue
+		}
+		if !handle.Status().Done() {
+			running = append(running, handle)
+		}
+	}
+	return running
+}
+
+// Get returns running streams and finished ones within the retention period
+func (r *mstreamRegistry) Get(id string) StreamHandle {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	handle, ok := r.handles[id]
+	if !ok {
+		return nil
+	}
+	if r.expired(handle, time.Now()) {
+		delete(r.handles, id)
+		return nil
+	}
+	return handle
+}
+
+// Count returns the number of running streams
+func (r *mstreamRegistry) Count() int {
+	return len(r.Running())
+}
+
+func (r *mstreamRegistry) StartCleanup(ctx context.Context) {
+	ticker := time.NewTicker(r.cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.cleanup(now)
+		}
+	}
+}
+
+// cleanup removes the streams whose retention period has passed
+func (r *mstreamRegistry) cleanup(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, handle := range r.handles {
+		if r.expired(handle, now) {
+			delete(r.handles, id)
+		}
+	}
+}
+
+// expired reports whether a stream finished longer than the retention period ago
+// (a stream without retention expires as soon as it finishes). Call with r.mu held.
+func (r *mstreamRegistry) expired(handle *mstreamHandle, now time.Time) bool {
+	finishedAt := handle.finished()
+	return !finishedAt.IsZero() && now.Sub(finishedAt) >= r.retention
+}
+
+func (r *mstreamRegistry) Advise(advice ReconnectAdvice) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.advice = &advice
+	close(r.adviceChanged)
+	r.adviceChanged = make(chan struct{})
+}
+
+func (r *mstreamRegistry) Advice() (*ReconnectAdvice, <-chan struct{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.advice, r.adviceChanged
+}
+
+func toMStreamEvent(event Event) mstream.Event {
+	return mstream.NewEvent(event.Data).WithType(event.Type).WithID(event.ID).WithRetry(event.Retry)
+}
+
+func fromMStreamEvent(event mstream.Event) Event {
+	return Event{Type: event.Type, ID: event.ID, Data: event.Data, Retry: event.Retry}
+}
+
+func toMStreamEvents(events []Event) []mstream.Event {
+	if events == nil {
+		return nil
+	}
+	converted := make([]mstream.Event, len(events))
+	for i, event := range events {
+		converted[i] = toMStreamEvent(event)
+	}
+	return converted
+}
+
+func fromMStreamEvents(events []mstream.Event) []Event {
+	if events == nil {
+		return nil
+	}
+	converted := make([]Event, len(events))
+	for i, event := range events {
+		converted[i] = fromMStreamEvent(event)
+	}
+	return converted
+}
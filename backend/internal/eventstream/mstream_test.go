@@ -0,0 +1,248 @@
+package eventstream
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// These tests pin the meridian-stream-go semantics the backend relies on; run them when
+// upgrading the library.
+
+// collect reads events until the channel closes
+func collect(t *testing.T, events <-chan Event) []Event {
+	t.Helper()
+	var received []Event
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return received
+			}
+			received = append(received, event)
+		case <-timeout:
+			t.Fatal("timed out waiting for the stream to finish")
+		}
+	}
+}
+
+// waitDone waits for a stream to reach a final status
+func waitDone(t *testing.T, stream StreamHandle) Status {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !stream.Status().Done() {
+		if time.Now().After(deadline) {
+			t.Fatalf("stream still %s", stream.Status())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return stream.Status()
+}
+
+func TestStream_DeliversEventsInOrder(t *testing.T) {
+	release := make(chan struct{})
+	stream := NewStream("turn-1", func(ctx context.Context, sink EventSink) error {
+		<-release
+		sink.Send(NewEvent("turn_start", []byte(`{"n":1}`)))
+		sink.Send(Event{Type: "block_delta", Data: []byte(`{"n":2}`), Retry: 500})
+		return nil
+	}, StreamOptions{EventIDs: true})
+
+	if stream.ID() != "turn-1" || stream.Status() != StatusPending {
+		t.Fatalf("new stream = %s (%s)", stream.ID(), stream.Status())
+	}
+
+	events := stream.Subscribe("client")
+	stream.Start()
+	close(release)
+	received := collect(t, events)
+
+	if len(received) != 2 {
+		t.Fatalf("received %d events, want 2", len(received))
+	}
+	first, second := received[0], received[1]
+	if first.Type != "turn_start" || string(first.Data) != `{"n":1}` || first.ID != "1" {
+		t.Errorf("first event = %+v", first)
+	}
+	if second.Type != "block_delta" || second.ID != "2" || second.Retry != 500 {
+		t.Errorf("second event = %+v", second)
+	}
+	if status := waitDone(t, stream); status != StatusComplete {
+		t.Errorf("status = %s, want complete", status)
+	}
+}
+
+func TestStream_CatchupAndPersist(t *testing.T) {
+	persisted := make(chan struct{})
+	finish := make(chan struct{})
+	stream := NewStream("turn-1", func(ctx context.Context, sink EventSink) error {
+		sink.Send(NewEvent("block_delta", []byte(`"a"`)))
+		sink.Send(NewEvent("block_delta", []byte(`"b"`)))
+		close(persisted)
+		<-finish
+		return nil
+	}, StreamOptions{
+		Catchup: func(streamID, lastEventID string) ([]Event, error) {
+			return []Event{NewEvent("turn_start", []byte(`"`+streamID+`"`))}, nil
+		},
+	})
+	stream.Start()
+	<-persisted
+
+	// First connection: catchup events, then the buffer
+	events := stream.CatchupEvents("")
+	if len(events) != 3 || events[0].Type != "turn_start" || string(events[0].Data) != `"turn-1"` || string(events[2].Data) != `"b"` {
+		t.Fatalf("catchup = %+v", events)
+	}
+
+	// A failed persist keeps the buffer
+	if err := stream.PersistAndClear(func([]Event) error { return errors.New("db down") }); err == nil {
+		t.Error("PersistAndClear ignored the persist error")
+	}
+	var saved []Event
+	if err := stream.PersistAndClear(func(events []Event) error {
+		saved = events
+		return nil
+	}); err != nil {
+		t.Fatalf("PersistAndClear: %v", err)
+	}
+	if len(saved) != 2 {
+		t.Errorf("persisted %d events, want 2", len(saved))
+	}
+	if events := stream.CatchupEvents(""); len(events) != 1 {
+		t.Errorf("catchup after persist = %+v, want the catchup event only", events)
+	}
+
+	// Nothing buffered: persist is not called
+	if err := stream.PersistAndClear(func([]Event) error {
+		t.Error("persist called with an empty buffer")
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	close(finish)
+	waitDone(t, stream)
+}
+
+func TestStream_CancelAndError(t *testing.T) {
+	cancelled := NewStream("cancelled", func(ctx context.Context, sink EventSink) error {
+		<-ctx.Done()
+		return nil
+	}, StreamOptions{})
+	events := cancelled.Subscribe("client")
+	cancelled.Start()
+	cancelled.Cancel()
+	collect(t, events)
+	if status := waitDone(t, cancelled); status != StatusCancelled {
+		t.Errorf("status = %s, want cancelled", status)
+	}
+
+	failed := NewStream("failed", func(ctx context.Context, sink EventSink) error {
+		return errors.New("provider failed")
+	}, StreamOptions{})
+	failed.Start()
+	if status := waitDone(t, failed); status != StatusError {
+		t.Errorf("status = %s, want error", status)
+	}
+}
+
+func TestStream_Unsubscribe(t *testing.T) {
+	finish := make(chan struct{})
+	stream := NewStream("turn-1", func(ctx context.Context, sink EventSink) error {
+		<-finish
+		return nil
+	}, StreamOptions{})
+	events := stream.Subscribe("client")
+	stream.Start()
+
+	stream.Unsubscribe("client")
+	collect(t, events) // Closed without the stream finishing
+
+	close(finish)
+	waitDone(t, stream)
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry(RegistryOptions{})
+	finish := make(chan struct{})
+	stream := NewStream("turn-1", func(ctx context.Context, sink EventSink) error {
+		<-finish
+		return nil
+	}, StreamOptions{})
+
+	if err := registry.Register(stream); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := registry.Register(stream); err == nil {
+		t.Error("registered the same ID twice")
+	}
+	if err := registry.Register(foreignStream{}); err == nil {
+		t.Error("registered a stream not created by NewStream")
+	}
+	if registry.Get("missing") != nil {
+		t.Error("Get of an unknown ID is not nil")
+	}
+	if registry.Count() != 1 {
+		t.Errorf("Count = %d, want 1", registry.Count())
+	}
+
+	found := registry.Get("turn-1")
+	if found == nil || found.ID() != "turn-1" {
+		t.Fatalf("Get = %v", found)
+	}
+	events := found.Subscribe("client")
+	stream.Start()
+	close(finish)
+	collect(t, events)
+
+	// Finished streams leave the registry
+	deadline := time.Now().Add(5 * time.Second)
+	for registry.Count() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("finished stream still registered")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRegistry_Retention(t *testing.T) {
+	registry := NewRegistry(RegistryOptions{Retention: time.Hour})
+	stream := NewStream("turn-1", func(ctx context.Context, sink EventSink) error {
+		sink.Send(NewEvent("turn_complete", []byte(`{}`)))
+		return nil
+	}, StreamOptions{
+		Catchup: func(streamID, lastEventID string) ([]Event, error) {
+			return []Event{NewEvent("turn_start", []byte(`{}`))}, nil
+		},
+	})
+	if err := registry.Register(stream); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	stream.Start()
+	waitDone(t, stream)
+
+	// Kept, but no longer running
+	if registry.Get("turn-1") == nil {
+		t.Fatal("finished stream removed before its retention period")
+	}
+	if registry.Count() != 0 || len(registry.Running()) != 0 {
+		t.Errorf("Count = %d, Running = %d; want no running streams", registry.Count(), len(registry.Running()))
+	}
+	// The buffer is dropped: catchup replays from storage only
+	if events := stream.CatchupEvents(""); len(events) != 1 || events[0].Type != "turn_start" {
+		t.Errorf("catchup of a finished stream = %+v, want the catchup events only", events)
+	}
+
+	registry.(*mstreamRegistry).cleanup(time.Now().Add(time.Hour))
+	if registry.Get("turn-1") != nil {
+		t.Error("stream still registered after its retention period")
+	}
+}
+
+// foreignStream is a StreamHandle from another implementation
+type foreignStream struct{ StreamHandle }
+
+func (foreignStream) ID() string { return "foreign" }
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/httputil"
+)
+
+// AbuseHandler handles the abuse flags admin API
+type AbuseHandler struct {
+	abuseService llmSvc.AbuseService
+	logger       *slog.Logger
+}
+
+// NewAbuseHandler creates a new abuse handler
+func NewAbuseHandler(abuseService llmSvc.AbuseService, logger *slog.Logger) *AbuseHandler {
+	return &AbuseHandler{
+		abuseService: abuseService,
+		logger:       logger,
+	}
+}
+
+// ListFlags returns abuse flags, newest first
+// GET /admin/api/abuse-flags?status=open|all&user_id=...&limit=100
+func (h *AbuseHandler) ListFlags(w http.ResponseWriter, r *http.Request) {
+	req := &llmSvc.ListAbuseFlagsRequest{
+		UserID: r.URL.Query().Get("user_id"),
+		Status: r.URL.Query().Get("status"),
+		Limit:  QueryInt(r, "limit", 0, 1, 500),
+	}
+
+	flags, err := h.abuseService.ListFlags(r.Context(), req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, flags)
+}
+
+// ResolveFlag marks a flag reviewed, lifting its throttle
+// POST /admin/api/abuse-flags/{id}/resolve
+func (h *AbuseHandler) ResolveFlag(w http.ResponseWriter, r *http.Request) {
+	flagID, ok := PathParam(w, r, "id", "Flag ID")
+	if !ok {
+		return
+	}
+	if _, err := uuid.Parse(flagID); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid flag ID format")
+		return
+	}
+
+	flag, err := h.abuseService.ResolveFlag(r.Context(), flagID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, flag)
+}
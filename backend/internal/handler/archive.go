@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/httputil"
+	"meridian/internal/logging"
+)
+
+// ArchiveHandler handles zip exports of whole projects
+type ArchiveHandler struct {
+	archiveService docsysSvc.ArchiveService
+	logger         *slog.Logger
+}
+
+// NewArchiveHandler creates a new archive handler
+func NewArchiveHandler(archiveService docsysSvc.ArchiveService, logger *slog.Logger) *ArchiveHandler {
+	return &ArchiveHandler{
+		archiveService: archiveService,
+		logger:         logger,
+	}
+}
+
+// ExportProject streams a zip of the project's folders and documents
+// GET /api/projects/{id}/export?include_chats=true
+// The zip is written as it is built, so a failure after the headers are sent leaves a
+// truncated download rather than an error response.
+func (h *ArchiveHandler) ExportProject(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	var opts docsysSvc.ArchiveOptions
+	if include := r.URL.Query().Get("include_chats"); include != "" {
+		parsed, err := strconv.ParseBool(include)
+		if err != nil {
+			httputil.RespondError(w, http.StatusBadRequest, "include_chats must be true or false")
+			return
+		}
+		opts.IncludeChats = parsed
+	}
+
+	userID := httputil.GetUserID(r)
+
+	archive, err := h.archiveService.OpenArchive(r.Context(), userID, projectID, opts)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": archive.FileName}))
+	w.WriteHeader(http.StatusOK)
+
+	if err := archive.Write(r.Context(), w); err != nil {
+		logging.FromContext(r.Context(), h.logger).Error("project archive export failed",
+			"project_id", projectID,
+			"error", err,
+		)
+	}
+}
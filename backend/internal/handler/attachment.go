@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/httputil"
+)
+
+// multipartOverhead is allowed on top of the file size for the form's boundaries and headers
+const multipartOverhead = 1 << 20
+
+// AttachmentHandler handles HTTP requests for chat attachments
+type AttachmentHandler struct {
+	attachmentService llmSvc.AttachmentService
+	maxUploadBytes    int64 // Largest file accepted; bounds the request body
+	logger            *slog.Logger
+}
+
+// NewAttachmentHandler creates a new attachment handler
+func NewAttachmentHandler(attachmentService llmSvc.AttachmentService, maxUploadBytes int64, logger *slog.Logger) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+		maxUploadBytes:    maxUploadBytes,
+		logger:            logger,
+	}
+}
+
+// UploadAttachment stores an image for a project's chats. The returned ID goes into an
+// image block of a user turn ({"attachment_id": ...}).
+// POST /api/attachments
+//
+// Query parameters:
+//   - project_id: required
+//
+// Form fields:
+//   - file: the image (PNG, JPEG, GIF or WebP)
+func (h *AttachmentHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("project_id")
+	if projectID == "" {
+		httputil.RespondError(w, http.StatusBadRequest, "project_id query parameter is required")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadBytes+multipartOverhead)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			httputil.RespondError(w, http.StatusRequestEntityTooLarge, "file is too large")
+			return
+		}
+		httputil.RespondError(w, http.StatusBadRequest, "file form field is required")
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Failed to read file")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+	attachment, err := h.attachmentService.UploadAttachment(r.Context(), userID, &llmSvc.UploadAttachmentRequest{
+		ProjectID: projectID,
+		FileName:  header.Filename,
+		Data:      data,
+	})
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusCreated, attachment)
+}
+
+// GetAttachment returns an attachment with a fresh download URL
+// GET /api/attachments/{id}
+func (h *AttachmentHandler) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	attachmentID, ok := PathParam(w, r, "id", "Attachment ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+	attachment, err := h.attachmentService.GetAttachment(r.Context(), userID, attachmentID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, attachment)
+}
+
+// DeleteAttachment deletes an attachment and its file
+// DELETE /api/attachments/{id}
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	attachmentID, ok := PathParam(w, r, "id", "Attachment ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+	if err := h.attachmentService.DeleteAttachment(r.Context(), userID, attachmentID); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,17 +1,20 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"log/slog"
 	"math"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
-	mstream "github.com/haowjy/meridian-stream-go"
 
 	llmModels "meridian/internal/domain/models/llm"
 	"meridian/internal/domain/services"
 	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/eventstream"
 	"meridian/internal/handler/sse"
 	"meridian/internal/httputil"
 )
@@ -22,7 +25,7 @@ type ChatHandler struct {
 	chatService         llmSvc.ChatService
 	conversationService llmSvc.ConversationService
 	streamingService    llmSvc.StreamingService
-	registry            *mstream.Registry
+	registry            eventstream.Registry
 	authorizer          services.ResourceAuthorizer
 	logger              *slog.Logger
 }
@@ -32,7 +35,7 @@ func NewChatHandler(
 	chatService llmSvc.ChatService,
 	conversationService llmSvc.ConversationService,
 	streamingService llmSvc.StreamingService,
-	registry *mstream.Registry,
+	registry eventstream.Registry,
 	authorizer services.ResourceAuthorizer,
 	logger *slog.Logger,
 ) *ChatHandler {
@@ -64,7 +67,7 @@ func (h *ChatHandler) CreateChat(w http.ResponseWriter, r *http.Request) {
 	// Call service
 	chat, err := h.chatService.CreateChat(r.Context(), &req)
 	if err != nil {
-		HandleCreateConflict(w, err, func(id string) (*llmModels.Chat, error) {
+		HandleCreateConflict(w, r, err, func(id string) (*llmModels.Chat, error) {
 			return h.chatService.GetChat(r.Context(), id, userID)
 		})
 		return
@@ -74,7 +77,7 @@ func (h *ChatHandler) CreateChat(w http.ResponseWriter, r *http.Request) {
 }
 
 // ListChats retrieves all chats for a project
-// GET /api/chats?project_id=:id
+// GET /api/chats?project_id=:id&include=last_turn_preview,turn_count,unread_count
 func (h *ChatHandler) ListChats(w http.ResponseWriter, r *http.Request) {
 	// Extract user ID from context
 	userID := httputil.GetUserID(r)
@@ -86,10 +89,17 @@ func (h *ChatHandler) ListChats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Optional summaries, computed in the list query
+	opts, err := llmModels.ParseChatListInclude(QueryList(r, "include"))
+	if err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Call service
-	chats, err := h.chatService.ListChats(r.Context(), projectID, userID)
+	chats, err := h.chatService.ListChats(r.Context(), projectID, userID, opts)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -107,14 +117,14 @@ func (h *ChatHandler) GetChat(w http.ResponseWriter, r *http.Request) {
 	userID := httputil.GetUserID(r)
 	chat, err := h.chatService.GetChat(r.Context(), chatID, userID)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
 	httputil.RespondJSON(w, http.StatusOK, chat)
 }
 
-// UpdateChat updates a chat's title
+// UpdateChat partially updates a chat (title, system_prompt)
 // PATCH /api/chats/{id}
 func (h *ChatHandler) UpdateChat(w http.ResponseWriter, r *http.Request) {
 	chatID, ok := PathParam(w, r, "id", "Chat ID")
@@ -132,7 +142,32 @@ func (h *ChatHandler) UpdateChat(w http.ResponseWriter, r *http.Request) {
 	// Call service
 	chat, err := h.chatService.UpdateChat(r.Context(), chatID, userID, &req)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, chat)
+}
+
+// UpdateChatSettings updates a chat's system prompt and default skills/tools
+// PATCH /api/chats/{id}/settings
+func (h *ChatHandler) UpdateChatSettings(w http.ResponseWriter, r *http.Request) {
+	chatID, ok := PathParam(w, r, "id", "Chat ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+	var req llmSvc.UpdateChatSettingsRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Call service
+	chat, err := h.chatService.UpdateChatSettings(r.Context(), chatID, userID, &req)
+	if err != nil {
+		handleError(w, r, err)
 		return
 	}
 
@@ -158,7 +193,7 @@ func (h *ChatHandler) UpdateLastViewedTurn(w http.ResponseWriter, r *http.Reques
 
 	// Call service (all validation handled by service layer)
 	if err := h.chatService.UpdateLastViewedTurn(r.Context(), chatID, userID, req.TurnID); err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -177,7 +212,7 @@ func (h *ChatHandler) DeleteChat(w http.ResponseWriter, r *http.Request) {
 	userID := httputil.GetUserID(r)
 	deletedChat, err := h.chatService.DeleteChat(r.Context(), chatID, userID)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -203,7 +238,10 @@ func (h *ChatHandler) CreateTurnV2(w http.ResponseWriter, r *http.Request) {
 	// Call service - chat_id, project_id, prev_turn_id come from body
 	response, err := h.streamingService.CreateTurn(r.Context(), &req)
 	if err != nil {
-		handleError(w, err)
+		// A repeated chat_idempotency_key returns the chat the first cold start created
+		HandleCreateConflict(w, r, err, func(id string) (*llmModels.Chat, error) {
+			return h.chatService.GetChat(r.Context(), id, userID)
+		})
 		return
 	}
 
@@ -231,7 +269,40 @@ func (h *ChatHandler) CreateTurn(w http.ResponseWriter, r *http.Request) {
 	// Call service
 	response, err := h.streamingService.CreateTurn(r.Context(), &req)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusCreated, response)
+}
+
+// RetryTurn regenerates an assistant turn as a new sibling off the same user turn
+// POST /api/turns/{id}/retry
+// Optional body: {"request_params": {...}, "selected_skills": [...], "match_style": true}.
+// request_params override the retried turn's (e.g. a different model or temperature).
+func (h *ChatHandler) RetryTurn(w http.ResponseWriter, r *http.Request) {
+	turnID, ok := PathParam(w, r, "id", "Turn ID")
+	if !ok {
+		return
+	}
+
+	// Validate turn ID format
+	if _, err := uuid.Parse(turnID); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid turn ID format")
+		return
+	}
+
+	var req llmSvc.RetryTurnRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil && !errors.Is(err, io.EOF) {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	req.TurnID = turnID
+	req.UserID = httputil.GetUserID(r)
+
+	response, err := h.streamingService.RetryTurn(r.Context(), &req)
+	if err != nil {
+		handleError(w, r, err)
 		return
 	}
 
@@ -239,39 +310,131 @@ func (h *ChatHandler) CreateTurn(w http.ResponseWriter, r *http.Request) {
 }
 
 // GetTurnPath retrieves the conversation path from a turn to root
-// GET /api/turns/{id}/path
+// GET /api/turns/{id}/path?last=20&blocks=false
 func (h *ChatHandler) GetTurnPath(w http.ResponseWriter, r *http.Request) {
 	turnID, ok := PathParam(w, r, "id", "Turn ID")
 	if !ok {
 		return
 	}
 
+	opts := llmModels.DefaultTurnPathOptions()
+	if !parseBlocksParam(w, r, &opts.IncludeBlocks) {
+		return
+	}
+	opts.Last = QueryInt(r, "last", 0, 1, llmModels.MaxTurnPathWindow)
+
+	userID := httputil.GetUserID(r)
+	turns, err := h.conversationService.GetTurnPath(r.Context(), userID, turnID, opts)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, turns)
+}
+
+// GetTurns retrieves specific turns by ID (e.g. to reconcile a client's branch cache)
+// GET /api/turns?ids=X,Y,Z&blocks=false
+// Turns come back in the order requested; blocks are included unless blocks=false
+func (h *ChatHandler) GetTurns(w http.ResponseWriter, r *http.Request) {
+	turnIDs := QueryList(r, "ids")
+	if len(turnIDs) == 0 {
+		httputil.RespondError(w, http.StatusBadRequest, "ids is required")
+		return
+	}
+	for _, turnID := range turnIDs {
+		if _, err := uuid.Parse(turnID); err != nil {
+			httputil.RespondError(w, http.StatusBadRequest, "Invalid turn ID format")
+			return
+		}
+	}
+
+	includeBlocks := true
+	if !parseBlocksParam(w, r, &includeBlocks) {
+		return
+	}
+
 	userID := httputil.GetUserID(r)
-	turns, err := h.conversationService.GetTurnPath(r.Context(), userID, turnID)
+	turns, err := h.conversationService.GetTurns(r.Context(), userID, turnIDs, includeBlocks)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
 	httputil.RespondJSON(w, http.StatusOK, turns)
 }
 
-// GetTurnSiblings retrieves all sibling turns (including self) for version browsing
-// GET /api/turns/{id}/siblings
+// GetTurnSiblings retrieves sibling turns (including self) for version browsing
+// GET /api/turns/{id}/siblings?blocks=false&limit=20&offset=0
+// The body stays a plain array; the total sibling count is sent in the X-Total-Count header
 func (h *ChatHandler) GetTurnSiblings(w http.ResponseWriter, r *http.Request) {
 	turnID, ok := PathParam(w, r, "id", "Turn ID")
 	if !ok {
 		return
 	}
 
+	opts := llmModels.DefaultTurnSiblingsOptions()
+	if !parseBlocksParam(w, r, &opts.IncludeBlocks) {
+		return
+	}
+	opts.Limit = QueryInt(r, "limit", 0, 1, llmModels.MaxTurnSiblingsLimit)
+	opts.Offset = QueryInt(r, "offset", 0, 0, math.MaxInt)
+
 	userID := httputil.GetUserID(r)
-	siblings, err := h.conversationService.GetTurnSiblings(r.Context(), userID, turnID)
+	siblings, err := h.conversationService.GetTurnSiblings(r.Context(), userID, turnID, opts)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
-	httputil.RespondJSON(w, http.StatusOK, siblings)
+	w.Header().Set(httputil.TotalCountHeader, strconv.Itoa(siblings.Total))
+	httputil.RespondJSON(w, http.StatusOK, siblings.Turns)
+}
+
+// parseBlocksParam reads the optional blocks=true|false query parameter into includeBlocks
+// Writes a 400 response and returns false if the value isn't a boolean
+func parseBlocksParam(w http.ResponseWriter, r *http.Request, includeBlocks *bool) bool {
+	blocks := r.URL.Query().Get("blocks")
+	if blocks == "" {
+		return true
+	}
+	parsed, err := strconv.ParseBool(blocks)
+	if err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "blocks must be true or false")
+		return false
+	}
+	*includeBlocks = parsed
+	return true
+}
+
+// GetTurnTree retrieves one page of the chat's whole turn tree as skeletons (no blocks)
+// GET /api/chats/{id}/tree?after=X&limit=500
+// Pass the response's next_cursor as after to get the next page
+func (h *ChatHandler) GetTurnTree(w http.ResponseWriter, r *http.Request) {
+	chatID, ok := PathParam(w, r, "id", "Chat ID")
+	if !ok {
+		return
+	}
+
+	opts := &llmModels.TurnTreeOptions{
+		After: r.URL.Query().Get("after"),
+		Limit: QueryInt(r, "limit", llmModels.DefaultTurnTreeLimit, 1, llmModels.MaxTurnTreeLimit),
+	}
+	if opts.After != "" {
+		if _, err := uuid.Parse(opts.After); err != nil {
+			httputil.RespondError(w, http.StatusBadRequest, "Invalid after turn ID format")
+			return
+		}
+	}
+
+	userID := httputil.GetUserID(r)
+	page, err := h.conversationService.GetTurnTree(r.Context(), chatID, userID, opts)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, page)
 }
 
 // GetPaginatedTurns retrieves turns and blocks in paginated fashion
@@ -307,13 +470,86 @@ func (h *ChatHandler) GetPaginatedTurns(w http.ResponseWriter, r *http.Request)
 	// Call service
 	response, err := h.conversationService.GetPaginatedTurns(r.Context(), chatID, userID, fromTurnID, limit, direction, updateLastViewed)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
 	httputil.RespondJSON(w, http.StatusOK, response)
 }
 
+// SearchTurns performs full-text search over the turns of a chat (in-conversation find)
+// GET /api/chats/{id}/turns/search?q=dragon&limit=20&from_turn_id=X
+// Matches are flagged with on_current_path so the UI can jump without switching branches
+func (h *ChatHandler) SearchTurns(w http.ResponseWriter, r *http.Request) {
+	chatID, ok := PathParam(w, r, "id", "Chat ID")
+	if !ok {
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		httputil.RespondError(w, http.StatusBadRequest, "q parameter is required")
+		return
+	}
+
+	opts := &llmModels.TurnSearchOptions{
+		Query:    query,
+		Limit:    QueryInt(r, "limit", llmModels.DefaultTurnSearchLimit, 1, llmModels.MaxTurnSearchLimit),
+		Language: r.URL.Query().Get("language"), // Optional - validated by the service, default handled by repository
+	}
+
+	var fromTurnID *string
+	if fromTurnIDStr := r.URL.Query().Get("from_turn_id"); fromTurnIDStr != "" {
+		fromTurnID = &fromTurnIDStr
+	}
+
+	userID := httputil.GetUserID(r)
+
+	results, err := h.conversationService.SearchTurns(r.Context(), chatID, userID, opts, fromTurnID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, results)
+}
+
+// SearchChats performs full-text search over the turns of the user's chats in a project
+// GET /api/chats/search?project_id=X&q=dragon&limit=20&offset=0
+// Each match carries its chat (id, title) and turn (id, role, prev_turn_id) so the UI can
+// open the conversation at the match
+func (h *ChatHandler) SearchChats(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("project_id")
+	if projectID == "" {
+		httputil.RespondError(w, http.StatusBadRequest, "project_id parameter is required")
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		httputil.RespondError(w, http.StatusBadRequest, "q parameter is required")
+		return
+	}
+
+	opts := &llmModels.ChatSearchOptions{
+		ProjectID: projectID,
+		Query:     query,
+		Limit:     QueryInt(r, "limit", llmModels.DefaultTurnSearchLimit, 1, llmModels.MaxTurnSearchLimit),
+		Offset:    QueryInt(r, "offset", 0, 0, math.MaxInt),
+		Language:  r.URL.Query().Get("language"), // Optional - validated by the service, default is the project's
+	}
+
+	userID := httputil.GetUserID(r)
+
+	results, err := h.conversationService.SearchChats(r.Context(), userID, opts)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, results)
+}
+
 // GetTurnBlocksResponse is the response for GET /api/turns/{id}/blocks
 type GetTurnBlocksResponse struct {
 	TurnID string                `json:"turn_id"`
@@ -342,7 +578,7 @@ func (h *ChatHandler) GetTurnBlocks(w http.ResponseWriter, r *http.Request) {
 	// Get turn with blocks from service (follows Clean Architecture)
 	turn, err := h.conversationService.GetTurnWithBlocks(r.Context(), userID, turnID)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -376,15 +612,50 @@ func (h *ChatHandler) GetTurnTokenUsage(w http.ResponseWriter, r *http.Request)
 	// Get token usage from service
 	tokenUsage, err := h.conversationService.GetTurnTokenUsage(r.Context(), userID, turnID)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
 	httputil.RespondJSON(w, http.StatusOK, tokenUsage)
 }
 
+// UpdateTurnAnnotations partially updates a turn's client annotations
+// PATCH /api/turns/{id}/annotations
+// Body: {"collapsed": true, "color_label": "blue", "branch_name": "Shorter draft"}
+// Absent fields are left unchanged; null removes one. Returns the turn's annotations.
+func (h *ChatHandler) UpdateTurnAnnotations(w http.ResponseWriter, r *http.Request) {
+	turnID, ok := PathParam(w, r, "id", "Turn ID")
+	if !ok {
+		return
+	}
+
+	// Validate turn ID format
+	if _, err := uuid.Parse(turnID); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid turn ID format")
+		return
+	}
+
+	var req llmModels.TurnAnnotationsUpdate
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	annotations, err := h.conversationService.UpdateTurnAnnotations(r.Context(), userID, turnID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, annotations)
+}
+
 // InterruptTurn cancels a streaming turn
 // POST /api/turns/{id}/interrupt
+// Optional body {"mode": "keep" | "discard"}: keep (default) leaves the completed
+// blocks, discard deletes them. The turn's stop_reason records which.
 func (h *ChatHandler) InterruptTurn(w http.ResponseWriter, r *http.Request) {
 	turnID, ok := PathParam(w, r, "id", "Turn ID")
 	if !ok {
@@ -397,28 +668,33 @@ func (h *ChatHandler) InterruptTurn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var req llmSvc.InterruptTurnRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil && !errors.Is(err, io.EOF) {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	req.TurnID = turnID
+
 	userID := httputil.GetUserID(r)
 
 	// Authorize: check user can access this turn
 	if err := h.authorizer.CanAccessTurn(r.Context(), userID, turnID); err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
-	// Get stream from registry
-	stream := h.registry.Get(turnID)
-	if stream == nil {
-		httputil.RespondError(w, http.StatusNotFound, "Turn is not currently streaming")
+	// Cancel the stream and wait for the executor to record the outcome
+	turn, err := h.streamingService.InterruptTurn(r.Context(), &req)
+	if err != nil {
+		handleError(w, r, err)
 		return
 	}
 
-	// Cancel the stream (executor will update turn status in database)
-	stream.Cancel()
-
 	httputil.RespondJSON(w, http.StatusOK, map[string]interface{}{
-		"success": true,
-		"turn_id": turnID,
-		"status":  "cancelled",
+		"success":     true,
+		"turn_id":     turnID,
+		"status":      turn.Status,
+		"stop_reason": turn.StopReason,
 	})
 }
 
@@ -434,12 +710,32 @@ func (h *ChatHandler) StreamTurn(w http.ResponseWriter, r *http.Request) {
 
 	// Authorize: check user can access this turn
 	if err := h.authorizer.CanAccessTurn(r.Context(), userID, turnID); err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
 	// Note: SSE config is created here with defaults
 	// TODO: Consider injecting SSE config at ChatHandler creation time for better testability
 	sseConfig := sse.DefaultConfig()
-	NewSSEHandler(h.registry, h.logger, sseConfig).StreamTurn(w, r)
+	NewSSEHandler(h.registry, h.streamingService.StreamHistory, h.logger, sseConfig).StreamTurn(w, r)
+}
+
+// StreamTurnWebSocket streams turn deltas over WebSocket, for clients behind proxies
+// that buffer SSE. Same events, catchup and authorization as StreamTurn.
+// GET /api/turns/{id}/ws
+func (h *ChatHandler) StreamTurnWebSocket(w http.ResponseWriter, r *http.Request) {
+	turnID, ok := PathParam(w, r, "id", "Turn ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	// Authorize before upgrading, so failures are plain HTTP errors
+	if err := h.authorizer.CanAccessTurn(r.Context(), userID, turnID); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	NewWSHandler(h.registry, h.streamingService.StreamHistory, h.logger, sse.DefaultConfig()).StreamTurn(w, r)
 }
@@ -79,7 +79,7 @@ func (h *ChatDebugHandler) CreateAssistantTurn(w http.ResponseWriter, r *http.Re
 	}
 	turn, err := h.streamingService.CreateAssistantTurnDebug(r.Context(), chatID, userID, req.PrevTurnID, req.TurnBlocks, model)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -122,19 +122,58 @@ func (h *ChatDebugHandler) BuildProviderRequest(w http.ResponseWriter, r *http.R
 	// Delegate to streaming service debug builder
 	debugReq, err := h.streamingService.BuildDebugProviderRequest(r.Context(), &req)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
 	httputil.RespondJSON(w, http.StatusOK, debugReq)
 }
 
+// ReplayTurn replays an assistant turn into a sandbox chat (DEBUG ONLY)
+// POST /debug/api/turns/{id}/replay
+//
+// Copies the conversation path that led to the turn into a new chat in the caller's
+// project and regenerates the turn there, so a reported bad generation can be
+// reproduced without touching the reporter's chat.
+//
+// Request body:
+//
+//	{
+//	  "project_id": "uuid",              // caller's project for the sandbox chat
+//	  "request_params": {"model": "..."} // optional overrides (e.g. a lorem model)
+//	}
+//
+// Response: same shape as CreateTurn (chat, user_turn, assistant_turn, stream_url).
+func (h *ChatDebugHandler) ReplayTurn(w http.ResponseWriter, r *http.Request) {
+	turnID, ok := PathParam(w, r, "id", "Turn ID")
+	if !ok {
+		return
+	}
+
+	var req llmSvc.ReplayTurnRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	req.TurnID = turnID
+	req.UserID = httputil.GetUserID(r)
+
+	response, err := h.streamingService.ReplayTurnDebug(r.Context(), &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusCreated, response)
+}
+
 // GetChatTree retrieves the complete conversation tree structure (DEBUG ONLY)
 // GET /debug/api/chats/{id}/tree
 //
 // WARNING: This endpoint is DEBUG ONLY and should NEVER be used in production.
 // Production code should use the pagination endpoint (/api/chats/{id}/turns) which
-// returns turns with nested blocks and sibling_ids for efficient branch discovery.
+// returns turns with nested blocks and sibling_ids for efficient branch discovery,
+// or the paginated tree endpoint (/api/chats/{id}/tree) for the whole branch structure.
 //
 // This endpoint exists solely for debugging and visualizing the full conversation tree
 // structure during development. It returns ALL turns in depth-first order with only
@@ -155,7 +194,7 @@ func (h *ChatDebugHandler) GetChatTree(w http.ResponseWriter, r *http.Request) {
 	userID := httputil.GetUserID(r)
 	tree, err := h.conversationService.GetChatTree(r.Context(), chatID, userID)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
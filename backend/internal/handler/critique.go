@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/httputil"
+)
+
+// CritiqueHandler handles HTTP requests for turn critiques
+type CritiqueHandler struct {
+	critiqueService llmSvc.CritiqueService
+	logger          *slog.Logger
+}
+
+// NewCritiqueHandler creates a new critique handler
+func NewCritiqueHandler(critiqueService llmSvc.CritiqueService, logger *slog.Logger) *CritiqueHandler {
+	return &CritiqueHandler{
+		critiqueService: critiqueService,
+		logger:          logger,
+	}
+}
+
+// CritiqueTurn starts a critic pass over an assistant turn
+// POST /api/turns/{id}/critique
+// Optional body: {"model": "...", "rubric": "..."}. Returns 202 with the pending critique;
+// poll GET /api/turns/{id}/critique for the result.
+func (h *CritiqueHandler) CritiqueTurn(w http.ResponseWriter, r *http.Request) {
+	turnID, ok := h.turnID(w, r)
+	if !ok {
+		return
+	}
+
+	var req llmSvc.CritiqueTurnRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil && !errors.Is(err, io.EOF) {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	critique, err := h.critiqueService.CritiqueTurn(r.Context(), userID, turnID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusAccepted, critique)
+}
+
+// GetCritique returns a turn's critique
+// GET /api/turns/{id}/critique
+func (h *CritiqueHandler) GetCritique(w http.ResponseWriter, r *http.Request) {
+	turnID, ok := h.turnID(w, r)
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	critique, err := h.critiqueService.GetCritique(r.Context(), userID, turnID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, critique)
+}
+
+// turnID reads and validates the turn ID path parameter
+func (h *CritiqueHandler) turnID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	turnID, ok := PathParam(w, r, "id", "Turn ID")
+	if !ok {
+		return "", false
+	}
+	if _, err := uuid.Parse(turnID); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid turn ID format")
+		return "", false
+	}
+	return turnID, true
+}
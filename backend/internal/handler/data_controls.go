@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/httputil"
+)
+
+// DataControlsHandler handles HTTP requests for users' per-provider data controls
+type DataControlsHandler struct {
+	dataControlsService llmSvc.DataControlsService
+	logger              *slog.Logger
+}
+
+// NewDataControlsHandler creates a new data controls handler
+func NewDataControlsHandler(dataControlsService llmSvc.DataControlsService, logger *slog.Logger) *DataControlsHandler {
+	return &DataControlsHandler{
+		dataControlsService: dataControlsService,
+		logger:              logger,
+	}
+}
+
+// GetDataControls returns the user's data controls, the current content policy version
+// and what each provider integration transmits. Controls are changed through the
+// data_controls namespace of PATCH /api/users/me/preferences.
+// GET /api/users/me/data-controls
+func (h *DataControlsHandler) GetDataControls(w http.ResponseWriter, r *http.Request) {
+	userID := httputil.GetUserID(r)
+
+	status, err := h.dataControlsService.GetDataControls(r.Context(), userID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, status)
+}
@@ -5,7 +5,6 @@ import (
 	"math"
 	"net/http"
 	"strings"
-	"time"
 
 	docsystem "meridian/internal/domain/models/docsystem"
 	docsysSvc "meridian/internal/domain/services/docsystem"
@@ -45,7 +44,7 @@ func (h *DocumentHandler) CreateDocument(w http.ResponseWriter, r *http.Request)
 	// Call service (all business logic is here)
 	doc, err := h.docService.CreateDocument(r.Context(), &req)
 	if err != nil {
-		HandleCreateConflict(w, err, func(id string) (*docsystem.Document, error) {
+		HandleCreateConflict(w, r, err, func(id string) (*docsystem.Document, error) {
 			return h.docService.GetDocument(r.Context(), userID, id)
 		})
 		return
@@ -66,7 +65,7 @@ func (h *DocumentHandler) GetDocument(w http.ResponseWriter, r *http.Request) {
 
 	doc, err := h.docService.GetDocument(r.Context(), userID, id)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -92,7 +91,7 @@ func (h *DocumentHandler) UpdateDocument(w http.ResponseWriter, r *http.Request)
 
 	doc, err := h.docService.UpdateDocument(r.Context(), userID, id, &req)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -110,7 +109,7 @@ func (h *DocumentHandler) DeleteDocument(w http.ResponseWriter, r *http.Request)
 	userID := httputil.GetUserID(r)
 
 	if err := h.docService.DeleteDocument(r.Context(), userID, id); err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -163,17 +162,9 @@ func (h *DocumentHandler) SearchDocuments(w http.ResponseWriter, r *http.Request
 	// Call service
 	results, err := h.docService.SearchDocuments(r.Context(), userID, req)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
 	httputil.RespondJSON(w, http.StatusOK, results)
 }
-
-// HealthCheck is a simple health check endpoint
-func (h *DocumentHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	httputil.RespondJSON(w, http.StatusOK, map[string]interface{}{
-		"status": "ok",
-		"time":   time.Now(),
-	})
-}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/httputil"
+)
+
+// DocumentAnalyticsHandler handles HTTP requests for document analytics
+type DocumentAnalyticsHandler struct {
+	analyticsService docsysSvc.DocumentAnalyticsService
+	logger           *slog.Logger
+}
+
+// NewDocumentAnalyticsHandler creates a new document analytics handler
+func NewDocumentAnalyticsHandler(analyticsService docsysSvc.DocumentAnalyticsService, logger *slog.Logger) *DocumentAnalyticsHandler {
+	return &DocumentAnalyticsHandler{
+		analyticsService: analyticsService,
+		logger:           logger,
+	}
+}
+
+// GetDocumentAnalytics returns a document's readability and pacing statistics
+// GET /api/documents/{id}/analytics
+func (h *DocumentAnalyticsHandler) GetDocumentAnalytics(w http.ResponseWriter, r *http.Request) {
+	id, ok := PathParam(w, r, "id", "Document ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	analytics, err := h.analyticsService.GetDocumentAnalytics(r.Context(), userID, id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, analytics)
+}
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/websocket"
+
+	models "meridian/internal/domain/models/docsystem"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/httputil"
+	"meridian/internal/logging"
+)
+
+// collabMaxMessageBytes caps collaboration messages; a client's first state answer
+// carries the whole document
+const collabMaxMessageBytes = 8 << 20
+
+// DocumentCollabHandler handles the document collaboration channel and its revisions
+type DocumentCollabHandler struct {
+	collabService docsysSvc.DocumentCollabService
+	authorizer    services.ResourceAuthorizer
+	logger        *slog.Logger
+}
+
+// NewDocumentCollabHandler creates a new document collaboration handler
+func NewDocumentCollabHandler(
+	collabService docsysSvc.DocumentCollabService,
+	authorizer services.ResourceAuthorizer,
+	logger *slog.Logger,
+) *DocumentCollabHandler {
+	return &DocumentCollabHandler{
+		collabService: collabService,
+		authorizer:    authorizer,
+		logger:        logger,
+	}
+}
+
+// Collaborate handles GET /api/documents/{id}/collab
+// Upgrades to a WebSocket carrying binary y-websocket messages (a y-websocket
+// WebsocketProvider with the meridian-collab subprotocol). Access is checked before the
+// upgrade so clients get a status code.
+func (h *DocumentCollabHandler) Collaborate(w http.ResponseWriter, r *http.Request) {
+	id, ok := PathParam(w, r, "id", "Document ID")
+	if !ok {
+		return
+	}
+	if !httputil.IsWebSocketUpgrade(r) {
+		httputil.RespondError(w, http.StatusUpgradeRequired, "WebSocket upgrade required")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+	if err := h.authorizer.CanAccessDocument(r.Context(), userID, id); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	logger := logging.FromContext(r.Context(), h.logger).With("document_id", id)
+	server := websocket.Server{
+		Handshake: collabHandshake,
+		Handler: func(ws *websocket.Conn) {
+			h.serve(ws, userID, id, logger)
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+// collabHandshake selects the meridian-collab subprotocol when offered (origins aren't
+// checked, see wsHandshake)
+func collabHandshake(config *websocket.Config, r *http.Request) error {
+	if slices.Contains(config.Protocol, httputil.WebSocketCollabProtocol) {
+		config.Protocol = []string{httputil.WebSocketCollabProtocol}
+	} else {
+		config.Protocol = nil
+	}
+	return nil
+}
+
+// serve runs the connection in the document's room until either side closes it
+func (h *DocumentCollabHandler) serve(ws *websocket.Conn, userID, documentID string, logger *slog.Logger) {
+	defer ws.Close()
+
+	// The server's read timeout still applies to the hijacked connection
+	if err := ws.SetDeadline(time.Time{}); err != nil {
+		logger.Warn("failed to clear WebSocket deadline", "error", err)
+		return
+	}
+	ws.MaxPayloadBytes = collabMaxMessageBytes
+	ws.PayloadType = websocket.BinaryFrame
+
+	logger.Info("collaboration connection established")
+	if err := h.collabService.Serve(ws.Request().Context(), userID, documentID, &wsCollabConn{ws: ws}); err != nil {
+		logger.Warn("collaboration connection failed", "error", err)
+		return
+	}
+	logger.Info("collaboration connection closed")
+}
+
+// wsCollabConn adapts a WebSocket to a CollabConn
+type wsCollabConn struct {
+	ws *websocket.Conn
+}
+
+func (c *wsCollabConn) Receive() ([]byte, error) {
+	var message []byte
+	err := websocket.Message.Receive(c.ws, &message)
+	return message, err
+}
+
+func (c *wsCollabConn) Send(message []byte) error {
+	return websocket.Message.Send(c.ws, message)
+}
+
+func (c *wsCollabConn) Close() error {
+	return c.ws.Close()
+}
+
+// ListRevisions lists the document's saved revisions, newest first, without content
+// GET /api/documents/{id}/revisions?limit=
+func (h *DocumentCollabHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	id, ok := PathParam(w, r, "id", "Document ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+	limit := QueryInt(r, "limit", 0, 1, models.MaxRevisionsLimit) // 0 = use service default
+
+	revisions, err := h.collabService.ListRevisions(r.Context(), userID, id, limit)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, revisions)
+}
+
+// GetRevision returns a revision with its content
+// GET /api/documents/{id}/revisions/{revisionId}
+func (h *DocumentCollabHandler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	id, ok := PathParam(w, r, "id", "Document ID")
+	if !ok {
+		return
+	}
+	revisionID, ok := PathParam(w, r, "revisionId", "Revision ID")
+	if !ok {
+		return
+	}
+	if _, err := uuid.Parse(revisionID); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid revision ID format")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	revision, err := h.collabService.GetRevision(r.Context(), userID, id, revisionID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, revision)
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/httputil"
+)
+
+// DocumentDiffHandler handles HTTP requests for comparing document versions
+type DocumentDiffHandler struct {
+	diffService docsysSvc.DocumentDiffService
+	logger      *slog.Logger
+}
+
+// NewDocumentDiffHandler creates a new document diff handler
+func NewDocumentDiffHandler(diffService docsysSvc.DocumentDiffService, logger *slog.Logger) *DocumentDiffHandler {
+	return &DocumentDiffHandler{
+		diffService: diffService,
+		logger:      logger,
+	}
+}
+
+// DiffDocument returns a structured line diff between two versions of a document
+// GET /api/documents/{id}/diff?from=snapshot:{snapshotId}&to=current
+func (h *DocumentDiffHandler) DiffDocument(w http.ResponseWriter, r *http.Request) {
+	id, ok := PathParam(w, r, "id", "Document ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+	query := r.URL.Query()
+
+	diff, err := h.diffService.DiffDocument(r.Context(), userID, id, query.Get("from"), query.Get("to"))
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, diff)
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/httputil"
+)
+
+// DocumentPresenceHandler handles HTTP requests for editor session presence
+type DocumentPresenceHandler struct {
+	presenceService docsysSvc.DocumentPresenceService
+	logger          *slog.Logger
+}
+
+// NewDocumentPresenceHandler creates a new document presence handler
+func NewDocumentPresenceHandler(presenceService docsysSvc.DocumentPresenceService, logger *slog.Logger) *DocumentPresenceHandler {
+	return &DocumentPresenceHandler{
+		presenceService: presenceService,
+		logger:          logger,
+	}
+}
+
+// Heartbeat records that an editor session has the document open
+// POST /api/documents/{id}/presence
+func (h *DocumentPresenceHandler) Heartbeat(w http.ResponseWriter, r *http.Request) {
+	id, ok := PathParam(w, r, "id", "Document ID")
+	if !ok {
+		return
+	}
+
+	var req docsysSvc.PresenceHeartbeatRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	presence, err := h.presenceService.Heartbeat(r.Context(), userID, id, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, presence)
+}
+
+// GetPresence lists the user's live editor sessions of the document
+// GET /api/documents/{id}/presence?session_id=
+func (h *DocumentPresenceHandler) GetPresence(w http.ResponseWriter, r *http.Request) {
+	id, ok := PathParam(w, r, "id", "Document ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	presence, err := h.presenceService.GetPresence(r.Context(), userID, id, r.URL.Query().Get("session_id"))
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, presence)
+}
+
+// Leave removes an editor session from the document (e.g. when the tab closes)
+// DELETE /api/documents/{id}/presence/{sessionId}
+func (h *DocumentPresenceHandler) Leave(w http.ResponseWriter, r *http.Request) {
+	id, ok := PathParam(w, r, "id", "Document ID")
+	if !ok {
+		return
+	}
+	sessionID, ok := PathParam(w, r, "sessionId", "Session ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	if err := h.presenceService.Leave(r.Context(), userID, id, sessionID); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
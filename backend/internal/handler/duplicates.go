@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/httputil"
+)
+
+// DuplicateHandler handles duplicate-content detection across projects
+type DuplicateHandler struct {
+	duplicateService docsysSvc.DuplicateService
+	logger           *slog.Logger
+}
+
+// NewDuplicateHandler creates a new duplicate handler
+func NewDuplicateHandler(duplicateService docsysSvc.DuplicateService, logger *slog.Logger) *DuplicateHandler {
+	return &DuplicateHandler{
+		duplicateService: duplicateService,
+		logger:           logger,
+	}
+}
+
+// FindDuplicates reports near-duplicate paragraphs across the project's documents
+// GET /api/projects/{id}/duplicates?threshold=0.8
+func (h *DuplicateHandler) FindDuplicates(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	var req docsysSvc.FindDuplicatesRequest
+	if threshold := r.URL.Query().Get("threshold"); threshold != "" {
+		parsed, err := strconv.ParseFloat(threshold, 64)
+		if err != nil {
+			httputil.RespondError(w, http.StatusBadRequest, "threshold must be a number")
+			return
+		}
+		req.Threshold = parsed
+	}
+
+	userID := httputil.GetUserID(r)
+
+	report, err := h.duplicateService.FindDuplicates(r.Context(), userID, projectID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/httputil"
+	"meridian/internal/logging"
+)
+
+// EditorHandler handles editor-side generations streamed over SSE
+type EditorHandler struct {
+	editorService llmSvc.EditorService
+	logger        *slog.Logger
+}
+
+// NewEditorHandler creates a new editor handler
+func NewEditorHandler(editorService llmSvc.EditorService, logger *slog.Logger) *EditorHandler {
+	return &EditorHandler{
+		editorService: editorService,
+		logger:        logger,
+	}
+}
+
+// CompleteDocument streams an inline continuation of a document at the cursor
+// POST /api/documents/{id}/complete
+// Body: {"cursor": 120, "content": "...", "model": "...", "max_tokens": 64, "include_project_context": true}.
+// Errors before generation starts are normal JSON error responses; after that the
+// response is an SSE stream of delta events followed by one done or error event.
+func (h *EditorHandler) CompleteDocument(w http.ResponseWriter, r *http.Request) {
+	documentID, ok := h.documentID(w, r)
+	if !ok {
+		return
+	}
+
+	var req llmSvc.CompleteDocumentRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	events, err := h.editorService.CompleteDocument(r.Context(), userID, documentID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	h.stream(w, r, events)
+}
+
+// RewriteSelection streams alternative rewrites of a document range
+// POST /api/documents/{id}/rewrite
+// Body: {"start": 10, "end": 42, "instruction": "...", "variants": 3, "save_suggestions": true, ...}.
+// Same SSE stream as CompleteDocument, with the variants' events interleaved.
+func (h *EditorHandler) RewriteSelection(w http.ResponseWriter, r *http.Request) {
+	documentID, ok := h.documentID(w, r)
+	if !ok {
+		return
+	}
+
+	var req llmSvc.RewriteSelectionRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	events, err := h.editorService.RewriteSelection(r.Context(), userID, documentID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	h.stream(w, r, events)
+}
+
+// TranslateDocument streams a translation of a document, or of a range of it
+// POST /api/documents/{id}/translate
+// Body: {"target_language": "fr", "source_language": "en", "start": 0, "end": 1200, "save_suggestion": true, ...}.
+// Without start and end the whole document is translated. Same SSE stream as
+// CompleteDocument; the done event carries the source and target languages.
+func (h *EditorHandler) TranslateDocument(w http.ResponseWriter, r *http.Request) {
+	documentID, ok := h.documentID(w, r)
+	if !ok {
+		return
+	}
+
+	var req llmSvc.TranslateDocumentRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	events, err := h.editorService.TranslateDocument(r.Context(), userID, documentID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	h.stream(w, r, events)
+}
+
+// ListSuggestions returns a document's saved inline suggestions
+// GET /api/documents/{id}/suggestions
+func (h *EditorHandler) ListSuggestions(w http.ResponseWriter, r *http.Request) {
+	documentID, ok := h.documentID(w, r)
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	suggestions, err := h.editorService.ListSuggestions(r.Context(), userID, documentID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, map[string]interface{}{"suggestions": suggestions})
+}
+
+// AcceptSuggestion applies an inline suggestion to the saved document and returns the
+// updated document (409 if the text it rewrites has changed)
+// POST /api/documents/{id}/suggestions/{suggestionId}/accept
+func (h *EditorHandler) AcceptSuggestion(w http.ResponseWriter, r *http.Request) {
+	documentID, ok := h.documentID(w, r)
+	if !ok {
+		return
+	}
+	suggestionID, ok := h.suggestionID(w, r)
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	doc, err := h.editorService.AcceptSuggestion(r.Context(), userID, documentID, suggestionID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, doc)
+}
+
+// DeleteSuggestion rejects an inline suggestion, or removes one the editor applied itself
+// DELETE /api/documents/{id}/suggestions/{suggestionId}
+// POST /api/documents/{id}/suggestions/{suggestionId}/reject
+func (h *EditorHandler) DeleteSuggestion(w http.ResponseWriter, r *http.Request) {
+	documentID, ok := h.documentID(w, r)
+	if !ok {
+		return
+	}
+	suggestionID, ok := h.suggestionID(w, r)
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	if err := h.editorService.DeleteSuggestion(r.Context(), userID, documentID, suggestionID); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// documentID reads and validates the document ID path parameter
+func (h *EditorHandler) documentID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	documentID, ok := PathParam(w, r, "id", "Document ID")
+	if !ok {
+		return "", false
+	}
+	if _, err := uuid.Parse(documentID); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid document ID format")
+		return "", false
+	}
+	return documentID, true
+}
+
+// stream writes editor events as SSE until the channel closes or the client goes away
+func (h *EditorHandler) stream(w http.ResponseWriter, r *http.Request, events <-chan llmSvc.EditorEvent) {
+	logger := logging.FromContext(r.Context(), h.logger)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		logger.Error("ResponseWriter does not support flushing")
+		httputil.RespondError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable nginx buffering
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// Cancelling the request context ends the generation, which closes the channel
+	for event := range events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			logger.Error("failed to encode editor event", "error", err)
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+			logger.Debug("client disconnected from editor stream", "error", err)
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// suggestionID reads and validates the suggestion ID path parameter
+func (h *EditorHandler) suggestionID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	suggestionID, ok := PathParam(w, r, "suggestionId", "Suggestion ID")
+	if !ok {
+		return "", false
+	}
+	if _, err := uuid.Parse(suggestionID); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid suggestion ID format")
+		return "", false
+	}
+	return suggestionID, true
+}
@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/httputil"
+)
+
+// EvalHandler handles HTTP requests for eval cases and runs
+type EvalHandler struct {
+	evalService llmSvc.EvalService
+	logger      *slog.Logger
+}
+
+// NewEvalHandler creates a new eval handler
+func NewEvalHandler(evalService llmSvc.EvalService, logger *slog.Logger) *EvalHandler {
+	return &EvalHandler{
+		evalService: evalService,
+		logger:      logger,
+	}
+}
+
+// CreateCase adds an eval case to a project
+// POST /api/projects/{id}/evals/cases
+func (h *EvalHandler) CreateCase(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	var req llmSvc.CreateEvalCaseRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	evalCase, err := h.evalService.CreateCase(r.Context(), userID, projectID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusCreated, evalCase)
+}
+
+// ListCases lists a project's eval cases
+// GET /api/projects/{id}/evals/cases
+func (h *EvalHandler) ListCases(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	cases, err := h.evalService.ListCases(r.Context(), userID, projectID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, cases)
+}
+
+// UpdateCase partially updates an eval case
+// PATCH /api/projects/{id}/evals/cases/{caseId}
+func (h *EvalHandler) UpdateCase(w http.ResponseWriter, r *http.Request) {
+	projectID, caseID, ok := evalPathParams(w, r, "caseId", "Case ID")
+	if !ok {
+		return
+	}
+
+	var req llmSvc.UpdateEvalCaseRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	evalCase, err := h.evalService.UpdateCase(r.Context(), userID, projectID, caseID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, evalCase)
+}
+
+// DeleteCase removes an eval case and its results
+// DELETE /api/projects/{id}/evals/cases/{caseId}
+func (h *EvalHandler) DeleteCase(w http.ResponseWriter, r *http.Request) {
+	projectID, caseID, ok := evalPathParams(w, r, "caseId", "Case ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	if err := h.evalService.DeleteCase(r.Context(), userID, projectID, caseID); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CaseHistory returns an eval case's results across runs, newest first
+// GET /api/projects/{id}/evals/cases/{caseId}/history?limit=50
+func (h *EvalHandler) CaseHistory(w http.ResponseWriter, r *http.Request) {
+	projectID, caseID, ok := evalPathParams(w, r, "caseId", "Case ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	results, err := h.evalService.CaseHistory(r.Context(), userID, projectID, caseID, QueryInt(r, "limit", 0, 1, 200))
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, results)
+}
+
+// StartRun queues a run of a project's eval cases
+// POST /api/projects/{id}/evals/runs
+// Returns 202 with the run; poll its job (GET /api/jobs/{job_id}) for progress
+func (h *EvalHandler) StartRun(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	var req llmSvc.StartEvalRunRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	run, err := h.evalService.StartRun(r.Context(), userID, projectID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusAccepted, run)
+}
+
+// ListRuns lists a project's eval runs, newest first
+// GET /api/projects/{id}/evals/runs?limit=50
+func (h *EvalHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	runs, err := h.evalService.ListRuns(r.Context(), userID, projectID, QueryInt(r, "limit", 0, 1, 200))
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, runs)
+}
+
+// GetRun returns an eval run with its results
+// GET /api/projects/{id}/evals/runs/{runId}
+func (h *EvalHandler) GetRun(w http.ResponseWriter, r *http.Request) {
+	projectID, runID, ok := evalPathParams(w, r, "runId", "Run ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	run, err := h.evalService.GetRun(r.Context(), userID, projectID, runID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, run)
+}
+
+func evalPathParams(w http.ResponseWriter, r *http.Request, name, label string) (string, string, bool) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return "", "", false
+	}
+	id, ok := PathParam(w, r, name, label)
+	if !ok {
+		return "", "", false
+	}
+	return projectID, id, true
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/httputil"
+)
+
+// ExperimentHandler handles the experiments admin API and turn feedback
+type ExperimentHandler struct {
+	experimentService llmSvc.ExperimentService
+	logger            *slog.Logger
+}
+
+// NewExperimentHandler creates a new experiment handler
+func NewExperimentHandler(experimentService llmSvc.ExperimentService, logger *slog.Logger) *ExperimentHandler {
+	return &ExperimentHandler{
+		experimentService: experimentService,
+		logger:            logger,
+	}
+}
+
+// CreateExperiment defines a new experiment
+// POST /admin/api/experiments
+func (h *ExperimentHandler) CreateExperiment(w http.ResponseWriter, r *http.Request) {
+	var req llmSvc.CreateExperimentRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	experiment, err := h.experimentService.CreateExperiment(r.Context(), &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusCreated, experiment)
+}
+
+// ListExperiments returns all experiments, newest first
+// GET /admin/api/experiments
+func (h *ExperimentHandler) ListExperiments(w http.ResponseWriter, r *http.Request) {
+	experiments, err := h.experimentService.ListExperiments(r.Context())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, experiments)
+}
+
+// UpdateExperiment starts or stops an experiment
+// PATCH /admin/api/experiments/{id}
+// Body: {"status": "active" | "stopped"}
+func (h *ExperimentHandler) UpdateExperiment(w http.ResponseWriter, r *http.Request) {
+	experimentID, ok := h.experimentID(w, r)
+	if !ok {
+		return
+	}
+
+	var req llmSvc.UpdateExperimentRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	experiment, err := h.experimentService.SetExperimentStatus(r.Context(), experimentID, req.Status)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, experiment)
+}
+
+// GetResults returns usage and feedback aggregated per variant
+// GET /admin/api/experiments/{id}/results
+func (h *ExperimentHandler) GetResults(w http.ResponseWriter, r *http.Request) {
+	experimentID, ok := h.experimentID(w, r)
+	if !ok {
+		return
+	}
+
+	results, err := h.experimentService.GetResults(r.Context(), experimentID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, results)
+}
+
+// SetTurnFeedback rates an assistant turn
+// PUT /api/turns/{id}/feedback
+// Body: {"rating": 1 | -1 | null}
+func (h *ExperimentHandler) SetTurnFeedback(w http.ResponseWriter, r *http.Request) {
+	turnID, ok := PathParam(w, r, "id", "Turn ID")
+	if !ok {
+		return
+	}
+	if _, err := uuid.Parse(turnID); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid turn ID format")
+		return
+	}
+
+	var req llmSvc.TurnFeedbackRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	if err := h.experimentService.SetTurnFeedback(r.Context(), userID, turnID, req.Rating); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// experimentID reads and validates the experiment ID path parameter
+func (h *ExperimentHandler) experimentID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	experimentID, ok := PathParam(w, r, "id", "Experiment ID")
+	if !ok {
+		return "", false
+	}
+	if _, err := uuid.Parse(experimentID); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid experiment ID format")
+		return "", false
+	}
+	return experimentID, true
+}
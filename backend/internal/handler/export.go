@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/httputil"
+)
+
+// ExportHandler handles HTTP requests for book exports
+type ExportHandler struct {
+	exportService docsysSvc.ExportService
+	logger        *slog.Logger
+}
+
+// NewExportHandler creates a new export handler
+func NewExportHandler(exportService docsysSvc.ExportService, logger *slog.Logger) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+		logger:        logger,
+	}
+}
+
+// CreateExport starts an asynchronous export of a project
+// POST /api/projects/{id}/exports
+// Returns 202 with the job; poll GET /api/exports/{id} until it completes
+func (h *ExportHandler) CreateExport(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	var req docsysSvc.CreateExportRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	job, err := h.exportService.StartExport(r.Context(), userID, projectID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusAccepted, job)
+}
+
+// GetExport returns an export job's status
+// GET /api/exports/{id}
+func (h *ExportHandler) GetExport(w http.ResponseWriter, r *http.Request) {
+	id, ok := PathParam(w, r, "id", "Export ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	job, err := h.exportService.GetExport(r.Context(), userID, id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, job)
+}
+
+// DownloadExport streams a completed export's file
+// GET /api/exports/{id}/download
+func (h *ExportHandler) DownloadExport(w http.ResponseWriter, r *http.Request) {
+	id, ok := PathParam(w, r, "id", "Export ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	file, err := h.exportService.DownloadExport(r.Context(), userID, id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(file.Data)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(file.Data)
+}
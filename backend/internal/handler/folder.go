@@ -47,7 +47,7 @@ func (h *FolderHandler) CreateFolder(w http.ResponseWriter, r *http.Request) {
 	// Call service
 	folder, err := h.folderService.CreateFolder(r.Context(), &req)
 	if err != nil {
-		HandleCreateConflict(w, err, func(id string) (*docsystem.Folder, error) {
+		HandleCreateConflict(w, r, err, func(id string) (*docsystem.Folder, error) {
 			return h.folderService.GetFolder(r.Context(), userID, id)
 		})
 		return
@@ -68,7 +68,7 @@ func (h *FolderHandler) GetFolder(w http.ResponseWriter, r *http.Request) {
 
 	folder, err := h.folderService.GetFolder(r.Context(), userID, id)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -94,7 +94,7 @@ func (h *FolderHandler) UpdateFolder(w http.ResponseWriter, r *http.Request) {
 
 	folder, err := h.folderService.UpdateFolder(r.Context(), userID, id, &req)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -112,7 +112,7 @@ func (h *FolderHandler) DeleteFolder(w http.ResponseWriter, r *http.Request) {
 	userID := httputil.GetUserID(r)
 
 	if err := h.folderService.DeleteFolder(r.Context(), userID, id); err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -140,7 +140,7 @@ func (h *FolderHandler) ListChildren(w http.ResponseWriter, r *http.Request) {
 
 	contents, err := h.folderService.ListChildren(r.Context(), userID, folderID, projectID)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"meridian/internal/eventstream"
+	"meridian/internal/httputil"
+	"meridian/internal/middleware"
+)
+
+// HealthHandler serves the unauthenticated health check
+type HealthHandler struct {
+	maintenance *middleware.MaintenanceMode
+	registry    eventstream.Registry
+}
+
+// NewHealthHandler creates a new health handler
+func NewHealthHandler(maintenance *middleware.MaintenanceMode, registry eventstream.Registry) *HealthHandler {
+	return &HealthHandler{
+		maintenance: maintenance,
+		registry:    registry,
+	}
+}
+
+// HealthCheck reports server status, maintenance mode and the number of active streams.
+// Deployments can enable maintenance mode and wait for active_streams to reach 0.
+// GET /health
+func (h *HealthHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
+	httputil.RespondJSON(w, http.StatusOK, map[string]interface{}{
+		"status":         "ok",
+		"time":           time.Now(),
+		"maintenance":    h.maintenance.Active(),
+		"active_streams": h.registry.Count(),
+	})
+}
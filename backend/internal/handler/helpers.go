@@ -4,9 +4,11 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"meridian/internal/domain"
+	"meridian/internal/errreport"
 	"meridian/internal/httputil"
 )
 
@@ -32,10 +34,39 @@ func QueryInt(r *http.Request, name string, defaultVal, min, max int) int {
 	return defaultVal
 }
 
+// QueryList parses a comma-separated query parameter (e.g. include=a,b)
+// Values are trimmed; empty values are dropped. Returns nil if the parameter is absent.
+func QueryList(r *http.Request, name string) []string {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil
+	}
+	var values []string
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 // handleError converts domain errors to HTTP responses.
 // Uses HTTPError interface for extensible error handling (OCP compliance).
 // New error types can be added by implementing HTTPError interface without modifying this function.
-func handleError(w http.ResponseWriter, err error) {
+// Unmapped errors (500s, typically repository failures) are reported to the error tracker.
+func handleError(w http.ResponseWriter, r *http.Request, err error) {
+	// Errors with structured details (e.g. per-field issues) include them in the response
+	var detailedErr domain.DetailedError
+	if errors.As(err, &detailedErr) {
+		extras := detailedErr.Extras()
+		// Throttled requests carry the wait as a Retry-After header too, like rate limits
+		if seconds, ok := extras["retry_after_seconds"].(int); ok {
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+		}
+		httputil.RespondErrorWithExtras(w, detailedErr.StatusCode(), detailedErr.Error(), extras)
+		return
+	}
+
 	// Try to use HTTPError interface (supports new error types without modification)
 	var httpErr domain.HTTPError
 	if errors.As(err, &httpErr) {
@@ -49,29 +80,35 @@ func handleError(w http.ResponseWriter, err error) {
 		httputil.RespondError(w, http.StatusBadRequest, err.Error())
 	case errors.Is(err, domain.ErrNotFound):
 		httputil.RespondError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrConflict):
+		httputil.RespondError(w, http.StatusConflict, err.Error())
 	case errors.Is(err, domain.ErrUnauthorized):
 		httputil.RespondError(w, http.StatusUnauthorized, err.Error())
 	case errors.Is(err, domain.ErrForbidden):
 		httputil.RespondError(w, http.StatusForbidden, err.Error())
 	default:
+		errreport.CaptureError(r.Context(), err,
+			"path", r.URL.Path,
+			"method", r.Method,
+		)
 		httputil.RespondError(w, http.StatusInternalServerError, "internal server error")
 	}
 }
 
 // HandleCreateConflict handles conflicts during creation by returning the existing resource with 409.
 // If the error is a ConflictError, extracts the resourceID and calls fetchByID to retrieve the existing resource.
-func HandleCreateConflict[T any](w http.ResponseWriter, err error, fetchByID func(resourceID string) (*T, error)) {
+func HandleCreateConflict[T any](w http.ResponseWriter, r *http.Request, err error, fetchByID func(resourceID string) (*T, error)) {
 	var conflictErr *domain.ConflictError
 	if !errors.As(err, &conflictErr) {
 		// Not a conflict error, handle normally
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
 	// Try to fetch existing resource by ID from conflict error
 	existing, fetchErr := fetchByID(conflictErr.ResourceID)
 	if fetchErr != nil {
-		handleError(w, fetchErr)
+		handleError(w, r, fetchErr)
 		return
 	}
 
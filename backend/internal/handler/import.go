@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 	"meridian/internal/domain/services"
 	docsysSvc "meridian/internal/domain/services/docsystem"
 	"meridian/internal/httputil"
+	"meridian/internal/logging"
 )
 
 // ImportHandler handles bulk import HTTP requests.
@@ -15,6 +17,9 @@ import (
 // Supports two modes:
 //   - Merge: Upserts documents (creates new, optionally updates existing)
 //   - Replace: Deletes all project documents first, then imports
+//
+// Imports run as background jobs: both modes respond 202 with the job, whose progress
+// is at GET /api/jobs/{id} (see JobHandler).
 type ImportHandler struct {
 	importService docsysSvc.ImportService
 	authorizer    services.ResourceAuthorizer
@@ -32,35 +37,44 @@ func NewImportHandler(importService docsysSvc.ImportService, authorizer services
 
 // importOptions configures the import operation
 type importOptions struct {
-	deleteFirst bool // If true, delete all existing documents before import
-	overwrite   bool // If true, update existing documents; if false, skip duplicates
-}
-
-// ImportResponse represents the response for import operations
-type ImportResponse struct {
-	Success   bool                       `json:"success"`
-	Summary   docsysSvc.ImportSummary    `json:"summary"`
-	Errors    []docsysSvc.ImportError    `json:"errors"`
-	Documents []docsysSvc.ImportDocument `json:"documents"`
+	deleteFirst bool                       // If true, delete all existing documents before import
+	conflict    docsysSvc.ConflictStrategy // What to do with files whose document already exists
 }
 
-// Merge handles bulk import in merge mode (upserts documents).
+// Merge queues a bulk import in merge mode (upserts documents).
 // POST /api/import
+// Returns 202 with the job; its result is the ImportResult
 //
 // Query parameters:
 //   - project_id: required
 //   - folder_path: optional, target folder path (empty = root)
-//   - overwrite: optional, if "true" updates existing documents
+//   - conflict: optional, "skip" (default), "overwrite", "rename" or "merge"
+//   - overwrite: optional, legacy alias: "true" means conflict=overwrite
+//
+// Form fields (besides files):
+//   - base_revisions: optional JSON object mapping document path to the Markdown
+//     content the upload was based on; required per file for conflict=merge
 func (h *ImportHandler) Merge(w http.ResponseWriter, r *http.Request) {
-	overwrite := r.URL.Query().Get("overwrite") == "true"
+	query := r.URL.Query()
+	conflictParam := query.Get("conflict")
+	if conflictParam == "" && query.Get("overwrite") == "true" {
+		conflictParam = string(docsysSvc.ConflictOverwrite)
+	}
+	conflict, err := docsysSvc.ParseConflictStrategy(conflictParam)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
 	h.processImportRequest(w, r, importOptions{
 		deleteFirst: false,
-		overwrite:   overwrite,
+		conflict:    conflict,
 	})
 }
 
-// Replace handles bulk import in replace mode (deletes all documents then imports).
+// Replace queues a bulk import in replace mode (deletes all documents then imports).
 // POST /api/import/replace
+// Returns 202 with the job; its result is the ImportResult
 //
 // Query parameters:
 //   - project_id: required
@@ -68,12 +82,47 @@ func (h *ImportHandler) Merge(w http.ResponseWriter, r *http.Request) {
 func (h *ImportHandler) Replace(w http.ResponseWriter, r *http.Request) {
 	h.processImportRequest(w, r, importOptions{
 		deleteFirst: true,
-		overwrite:   true, // Always overwrite in replace mode (though irrelevant since we delete first)
+		conflict:    docsysSvc.ConflictOverwrite, // Irrelevant since we delete first
 	})
 }
 
+// ImportManifestResponse lists existing documents for sync clients
+type ImportManifestResponse struct {
+	Documents []docsysSvc.ImportManifestEntry `json:"documents"`
+}
+
+// Manifest lists every document's path and content hash.
+// GET /api/import/manifest
+//
+// Sync clients hash local files (hex SHA-256 of the Markdown) and upload only
+// files that are new or whose hash differs.
+//
+// Query parameters:
+//   - project_id: required
+func (h *ImportHandler) Manifest(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("project_id")
+	if projectID == "" {
+		httputil.RespondError(w, http.StatusBadRequest, "project_id query parameter is required")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+	if err := h.authorizer.CanAccessProject(r.Context(), userID, projectID); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	entries, err := h.importService.Manifest(r.Context(), projectID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, ImportManifestResponse{Documents: entries})
+}
+
 // processImportRequest handles common import logic for both merge and replace modes.
-// Extracts project/user IDs, validates authorization, parses files, and processes import.
+// Extracts project/user IDs, validates authorization, parses files, and queues the import.
 func (h *ImportHandler) processImportRequest(w http.ResponseWriter, r *http.Request, opts importOptions) {
 	// Get project ID from query parameter
 	projectID := r.URL.Query().Get("project_id")
@@ -87,7 +136,7 @@ func (h *ImportHandler) processImportRequest(w http.ResponseWriter, r *http.Requ
 
 	// Verify user owns the project before importing
 	if err := h.authorizer.CanAccessProject(r.Context(), userID, projectID); err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -107,41 +156,38 @@ func (h *ImportHandler) processImportRequest(w http.ResponseWriter, r *http.Requ
 	// Get folder path from query parameter (empty string = root level)
 	folderPath := r.URL.Query().Get("folder_path")
 
+	// Base revisions for three-way merges
+	var baseRevisions map[string]string
+	if raw := r.FormValue("base_revisions"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &baseRevisions); err != nil {
+			httputil.RespondError(w, http.StatusBadRequest, "base_revisions must be a JSON object of path to content")
+			return
+		}
+	}
+
 	// Determine mode label for logging
 	mode := "merge"
 	if opts.deleteFirst {
 		mode = "replace"
 	}
 
-	h.logger.Info("starting import",
+	logger := logging.FromContext(r.Context(), h.logger).With("project_id", projectID)
+	logger.Info("queueing import",
 		"mode", mode,
-		"project_id", projectID,
 		"file_count", len(files),
 		"folder_path", folderPath,
-		"overwrite", opts.overwrite,
+		"conflict", opts.conflict,
+		"base_revisions", len(baseRevisions),
 	)
 
-	// Delete all documents first if in replace mode
-	if opts.deleteFirst {
-		if err := h.importService.DeleteAllDocuments(r.Context(), projectID); err != nil {
-			h.logger.Error("failed to delete all documents",
-				"project_id", projectID,
-				"error", err,
-			)
-			handleError(w, err)
-			return
-		}
-		h.logger.Info("deleted all documents", "project_id", projectID)
-	}
-
 	// Convert uploaded files to UploadedFile slice.
-	// Note: defer file.Close() is safe here because all files are processed
+	// Note: defer file.Close() is safe here because StartImport has stored the files
 	// before this function returns.
 	uploadedFiles := make([]docsysSvc.UploadedFile, 0, len(files))
 	for _, fileHeader := range files {
 		file, err := fileHeader.Open()
 		if err != nil {
-			h.logger.Error("failed to open uploaded file",
+			logger.Error("failed to open uploaded file",
 				"file", fileHeader.Filename,
 				"error", err,
 			)
@@ -156,30 +202,19 @@ func (h *ImportHandler) processImportRequest(w http.ResponseWriter, r *http.Requ
 		})
 	}
 
-	// Process files using file processor strategies
-	result, err := h.importService.ProcessFiles(r.Context(), projectID, userID, uploadedFiles, folderPath, opts.overwrite)
+	// Store the files and queue the import; a worker runs it in the background
+	job, err := h.importService.StartImport(r.Context(), projectID, userID, uploadedFiles, folderPath, docsysSvc.ImportOptions{
+		Conflict:      opts.conflict,
+		BaseRevisions: baseRevisions,
+		ReplaceAll:    opts.deleteFirst, // Replace mode: delete-all and import are one transaction
+	})
 	if err != nil {
-		h.logger.Error("failed to process files", "error", err)
-		httputil.RespondError(w, http.StatusInternalServerError, "Failed to process files")
+		logger.Error("failed to queue import", "error", err)
+		httputil.RespondError(w, http.StatusInternalServerError, "Failed to queue import")
 		return
 	}
 
-	h.logger.Info("import complete",
-		"mode", mode,
-		"project_id", projectID,
-		"created", result.Summary.Created,
-		"updated", result.Summary.Updated,
-		"skipped", result.Summary.Skipped,
-		"failed", result.Summary.Failed,
-	)
-
-	// Build response
-	response := ImportResponse{
-		Success:   result.Summary.Failed == 0,
-		Summary:   result.Summary,
-		Errors:    result.Errors,
-		Documents: result.Documents,
-	}
+	logger.Info("import queued", "mode", mode, "job_id", job.ID)
 
-	httputil.RespondJSON(w, http.StatusOK, response)
+	httputil.RespondJSON(w, http.StatusAccepted, job)
 }
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/handler/sse"
+	"meridian/internal/httputil"
+	"meridian/internal/logging"
+)
+
+// JobHandler handles HTTP requests for background jobs (imports)
+type JobHandler struct {
+	jobService        docsysSvc.JobService
+	keepAliveInterval time.Duration
+	logger            *slog.Logger
+}
+
+// NewJobHandler creates a new job handler
+func NewJobHandler(jobService docsysSvc.JobService, logger *slog.Logger) *JobHandler {
+	return &JobHandler{
+		jobService:        jobService,
+		keepAliveInterval: sse.DefaultConfig().KeepAliveInterval,
+		logger:            logger,
+	}
+}
+
+// GetJob returns a job's status and progress (files processed, per-file errors),
+// and its result once completed
+// GET /api/jobs/{id}
+func (h *JobHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	jobID, ok := h.jobID(w, r)
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	job, err := h.jobService.GetJob(r.Context(), userID, jobID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, job)
+}
+
+// StreamJob streams a job's progress as SSE: a "progress" event with the job now and
+// whenever its progress changes. The stream ends after the event with the job's final
+// status (completed or failed).
+// GET /api/jobs/{id}/events
+func (h *JobHandler) StreamJob(w http.ResponseWriter, r *http.Request) {
+	jobID, ok := h.jobID(w, r)
+	if !ok {
+		return
+	}
+
+	logger := logging.FromContext(r.Context(), h.logger).With("job_id", jobID)
+	userID := httputil.GetUserID(r)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		logger.Error("ResponseWriter does not support flushing")
+		httputil.RespondError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	updates, err := h.jobService.WatchJob(r.Context(), userID, jobID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable nginx buffering
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(h.keepAliveInterval)
+	defer keepAlive.Stop()
+
+	// Cancelling the request context stops the watch, which closes the channel
+	for {
+		select {
+		case job, ok := <-updates:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(job)
+			if err != nil {
+				logger.Error("failed to encode job", "error", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: progress\ndata: %s\n\n", data); err != nil {
+				logger.Debug("client disconnected from job stream", "error", err)
+				return
+			}
+			flusher.Flush()
+
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				logger.Debug("client disconnected from job stream", "error", err)
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// jobID reads and validates the job ID path parameter
+func (h *JobHandler) jobID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	jobID, ok := PathParam(w, r, "id", "Job ID")
+	if !ok {
+		return "", false
+	}
+	if _, err := uuid.Parse(jobID); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid job ID format")
+		return "", false
+	}
+	return jobID, true
+}
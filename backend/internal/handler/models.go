@@ -13,11 +13,11 @@ import (
 type ModelsHandler struct {
 	config   *config.Config
 	logger   *slog.Logger
-	registry *capabilities.Registry
+	registry capabilities.Lookup
 }
 
 // NewModelsHandler creates a new models handler
-func NewModelsHandler(cfg *config.Config, logger *slog.Logger, registry *capabilities.Registry) *ModelsHandler {
+func NewModelsHandler(cfg *config.Config, logger *slog.Logger, registry capabilities.Lookup) *ModelsHandler {
 	return &ModelsHandler{
 		config:   cfg,
 		logger:   logger,
@@ -69,18 +69,21 @@ type PricingTierResponse struct {
 func (h *ModelsHandler) GetCapabilities(w http.ResponseWriter, r *http.Request) {
 	var providers []ProviderResponse
 
-	// Fixed provider order: Anthropic first, then OpenRouter
+	// Fixed provider order: direct providers first, then OpenRouter
 	providerOrder := []struct {
-		id     string
-		name   string
-		apiKey string
+		id      string
+		name    string
+		setting string // Setting that enables the provider: its API key, or Ollama's URL
 	}{
 		{"anthropic", "Anthropic", h.config.AnthropicAPIKey},
+		{"openai", "OpenAI", h.config.OpenAIAPIKey},
+		{"google", "Google Gemini", h.config.GeminiAPIKey},
+		{"ollama", "Ollama", h.config.OllamaBaseURL},
 		{"openrouter", "OpenRouter", h.config.OpenRouterAPIKey},
 	}
 
 	for _, p := range providerOrder {
-		if p.apiKey != "" {
+		if p.setting != "" {
 			if models, err := h.registry.ListProviderModels(p.id); err == nil {
 				provider := h.convertProvider(p.id, p.name, models)
 				providers = append(providers, provider)
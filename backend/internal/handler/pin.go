@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/httputil"
+)
+
+// PinHandler handles HTTP requests for pinning turns to the project
+type PinHandler struct {
+	pinService llmSvc.PinService
+	logger     *slog.Logger
+}
+
+// NewPinHandler creates a new pin handler
+func NewPinHandler(pinService llmSvc.PinService, logger *slog.Logger) *PinHandler {
+	return &PinHandler{
+		pinService: pinService,
+		logger:     logger,
+	}
+}
+
+// PinTurn saves an assistant turn as a document in the project
+// POST /api/turns/{id}/pin-to-project
+// Optional body: {"folder_path": "...", "name": "..."}
+func (h *PinHandler) PinTurn(w http.ResponseWriter, r *http.Request) {
+	turnID, ok := PathParam(w, r, "id", "Turn ID")
+	if !ok {
+		return
+	}
+
+	// Validate turn ID format
+	if _, err := uuid.Parse(turnID); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid turn ID format")
+		return
+	}
+
+	var req llmSvc.PinTurnRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil && !errors.Is(err, io.EOF) {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	pinned, err := h.pinService.PinTurn(r.Context(), userID, turnID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusCreated, pinned)
+}
+
+// GetDocumentSource returns the turn a document was pinned from
+// GET /api/documents/{id}/source
+func (h *PinHandler) GetDocumentSource(w http.ResponseWriter, r *http.Request) {
+	documentID, ok := PathParam(w, r, "id", "Document ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	source, err := h.pinService.GetDocumentSource(r.Context(), userID, documentID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, source)
+}
@@ -11,28 +11,37 @@ import (
 
 // ProjectHandler handles project HTTP requests
 type ProjectHandler struct {
-	projectService docsysSvc.ProjectService
-	logger         *slog.Logger
+	projectService  docsysSvc.ProjectService
+	settingsService docsysSvc.ProjectSettingsService
+	logger          *slog.Logger
 }
 
 // NewProjectHandler creates a new project handler
-func NewProjectHandler(projectService docsysSvc.ProjectService, logger *slog.Logger) *ProjectHandler {
+func NewProjectHandler(projectService docsysSvc.ProjectService, settingsService docsysSvc.ProjectSettingsService, logger *slog.Logger) *ProjectHandler {
 	return &ProjectHandler{
-		projectService: projectService,
-		logger:         logger,
+		projectService:  projectService,
+		settingsService: settingsService,
+		logger:          logger,
 	}
 }
 
 // ListProjects retrieves all projects for the user
-// GET /api/projects
+// GET /api/projects?include=document_count,word_count,last_activity,active_streams
 func (h *ProjectHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
 	// Extract user ID from context
 	userID := httputil.GetUserID(r)
 
+	// Optional activity summaries, aggregated in the list query
+	opts, err := docsystem.ParseProjectListInclude(QueryList(r, "include"))
+	if err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Call service
-	projects, err := h.projectService.ListProjects(r.Context(), userID)
+	projects, err := h.projectService.ListProjects(r.Context(), userID, opts)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -57,7 +66,7 @@ func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
 	// Call service (all business logic is here)
 	project, err := h.projectService.CreateProject(r.Context(), &req)
 	if err != nil {
-		HandleCreateConflict(w, err, func(id string) (*docsystem.Project, error) {
+		HandleCreateConflict(w, r, err, func(id string) (*docsystem.Project, error) {
 			return h.projectService.GetProject(r.Context(), id, userID)
 		})
 		return
@@ -77,7 +86,7 @@ func (h *ProjectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
 	userID := httputil.GetUserID(r)
 	project, err := h.projectService.GetProject(r.Context(), id, userID)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -101,7 +110,7 @@ func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
 
 	project, err := h.projectService.UpdateProject(r.Context(), id, userID, &req)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -119,9 +128,52 @@ func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
 	userID := httputil.GetUserID(r)
 	project, err := h.projectService.DeleteProject(r.Context(), id, userID)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
 	httputil.RespondJSON(w, http.StatusOK, project)
 }
+
+// GetProjectSettings retrieves a project's settings
+// GET /api/projects/{id}/settings
+func (h *ProjectHandler) GetProjectSettings(w http.ResponseWriter, r *http.Request) {
+	id, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+	settings, err := h.settingsService.GetProjectSettings(r.Context(), id, userID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, settings)
+}
+
+// UpdateProjectSettings updates a project's settings (system prompt, default model,
+// tool policy, guardrails, search language)
+// PATCH /api/projects/{id}/settings
+func (h *ProjectHandler) UpdateProjectSettings(w http.ResponseWriter, r *http.Request) {
+	id, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+	var req docsysSvc.UpdateProjectSettingsRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	settings, err := h.settingsService.UpdateProjectSettings(r.Context(), id, userID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, settings)
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/httputil"
+)
+
+// ProviderKeyHandler handles HTTP requests for users' own provider API keys
+type ProviderKeyHandler struct {
+	providerKeyService llmSvc.ProviderKeyService
+	logger             *slog.Logger
+}
+
+// NewProviderKeyHandler creates a new provider key handler
+func NewProviderKeyHandler(providerKeyService llmSvc.ProviderKeyService, logger *slog.Logger) *ProviderKeyHandler {
+	return &ProviderKeyHandler{
+		providerKeyService: providerKeyService,
+		logger:             logger,
+	}
+}
+
+// ListKeys lists the user's provider keys (hints only, never the keys)
+// GET /api/users/me/provider-keys
+func (h *ProviderKeyHandler) ListKeys(w http.ResponseWriter, r *http.Request) {
+	userID := httputil.GetUserID(r)
+
+	keys, err := h.providerKeyService.ListKeys(r.Context(), userID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, keys)
+}
+
+// SetKey stores or replaces the user's key for a provider
+// PUT /api/users/me/provider-keys/{provider}
+// Body: {"api_key": "..."}
+func (h *ProviderKeyHandler) SetKey(w http.ResponseWriter, r *http.Request) {
+	provider, ok := PathParam(w, r, "provider", "Provider")
+	if !ok {
+		return
+	}
+
+	var req llmSvc.SetProviderKeyRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	key, err := h.providerKeyService.SetKey(r.Context(), userID, provider, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, key)
+}
+
+// DeleteKey removes the user's key for a provider (the server's key is used again)
+// DELETE /api/users/me/provider-keys/{provider}
+func (h *ProviderKeyHandler) DeleteKey(w http.ResponseWriter, r *http.Request) {
+	provider, ok := PathParam(w, r, "provider", "Provider")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	if err := h.providerKeyService.DeleteKey(r.Context(), userID, provider); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/httputil"
+)
+
+// PublishHandler handles HTTP requests for publish targets and deliveries
+type PublishHandler struct {
+	publishService docsysSvc.PublishService
+	logger         *slog.Logger
+}
+
+// NewPublishHandler creates a new publish handler
+func NewPublishHandler(publishService docsysSvc.PublishService, logger *slog.Logger) *PublishHandler {
+	return &PublishHandler{
+		publishService: publishService,
+		logger:         logger,
+	}
+}
+
+// CreateTarget adds a publish target to a project
+// POST /api/projects/{id}/publish-targets
+func (h *PublishHandler) CreateTarget(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	var req docsysSvc.CreatePublishTargetRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	target, err := h.publishService.CreateTarget(r.Context(), userID, projectID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusCreated, target)
+}
+
+// ListTargets lists a project's publish targets
+// GET /api/projects/{id}/publish-targets
+func (h *PublishHandler) ListTargets(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	targets, err := h.publishService.ListTargets(r.Context(), userID, projectID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, targets)
+}
+
+// GetTarget retrieves a publish target
+// GET /api/projects/{id}/publish-targets/{targetId}
+func (h *PublishHandler) GetTarget(w http.ResponseWriter, r *http.Request) {
+	projectID, targetID, ok := publishTargetPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	target, err := h.publishService.GetTarget(r.Context(), userID, projectID, targetID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, target)
+}
+
+// UpdateTarget partially updates a publish target
+// PATCH /api/projects/{id}/publish-targets/{targetId}
+func (h *PublishHandler) UpdateTarget(w http.ResponseWriter, r *http.Request) {
+	projectID, targetID, ok := publishTargetPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req docsysSvc.UpdatePublishTargetRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	target, err := h.publishService.UpdateTarget(r.Context(), userID, projectID, targetID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, target)
+}
+
+// DeleteTarget removes a publish target and its delivery log
+// DELETE /api/projects/{id}/publish-targets/{targetId}
+func (h *PublishHandler) DeleteTarget(w http.ResponseWriter, r *http.Request) {
+	projectID, targetID, ok := publishTargetPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	if err := h.publishService.DeleteTarget(r.Context(), userID, projectID, targetID); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Publish delivers a target's documents now.
+// Returns the logged delivery; a failed delivery is still a 200 with status "failed".
+// POST /api/projects/{id}/publish-targets/{targetId}/publish
+func (h *PublishHandler) Publish(w http.ResponseWriter, r *http.Request) {
+	projectID, targetID, ok := publishTargetPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	delivery, err := h.publishService.Publish(r.Context(), userID, projectID, targetID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, delivery)
+}
+
+// ListDeliveries returns a target's recent deliveries, newest first
+// GET /api/projects/{id}/publish-targets/{targetId}/deliveries
+func (h *PublishHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	projectID, targetID, ok := publishTargetPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	deliveries, err := h.publishService.ListDeliveries(r.Context(), userID, projectID, targetID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, deliveries)
+}
+
+func publishTargetPathParams(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return "", "", false
+	}
+	targetID, ok := PathParam(w, r, "targetId", "Publish target ID")
+	if !ok {
+		return "", "", false
+	}
+	return projectID, targetID, true
+}
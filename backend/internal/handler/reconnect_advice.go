@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"encoding/json"
+	"time"
+
+	llmModels "meridian/internal/domain/models/llm"
+	"meridian/internal/eventstream"
+)
+
+// adviceWatcher follows the registry's reconnect advice for one stream client, so each
+// advice is sent to it once
+type adviceWatcher struct {
+	registry eventstream.Registry
+	sent     *eventstream.ReconnectAdvice
+
+	// changed is closed when the advice changes; call next to get the new advice
+	changed <-chan struct{}
+}
+
+// newAdviceWatcher starts following the registry's advice (call next for the current one)
+func newAdviceWatcher(registry eventstream.Registry) *adviceWatcher {
+	return &adviceWatcher{registry: registry, changed: make(chan struct{})}
+}
+
+// next returns the reconnect_advice event to send if the advice changed since the last
+// call, and re-arms changed
+func (a *adviceWatcher) next() (eventstream.Event, bool) {
+	advice, changed := a.registry.Advice()
+	a.changed = changed
+	if advice == nil || advice == a.sent {
+		return eventstream.Event{}, false
+	}
+	a.sent = advice
+
+	data, _ := json.Marshal(llmModels.ReconnectAdviceEvent{
+		Reason:     advice.Reason,
+		RetryAfter: int(advice.RetryAfter / time.Second),
+	})
+	event := eventstream.NewEvent(llmModels.SSEEventReconnectAdvice, data)
+	event.Retry = int(advice.RetryAfter / time.Millisecond)
+	return event, true
+}
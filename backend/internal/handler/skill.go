@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/httputil"
+)
+
+// SkillHandler handles HTTP requests for project skills
+type SkillHandler struct {
+	skillService docsysSvc.SkillService
+	logger       *slog.Logger
+}
+
+// NewSkillHandler creates a new skill handler
+func NewSkillHandler(skillService docsysSvc.SkillService, logger *slog.Logger) *SkillHandler {
+	return &SkillHandler{
+		skillService: skillService,
+		logger:       logger,
+	}
+}
+
+// LintSkills validates every skill in a project
+// GET /api/projects/{id}/skills/lint
+func (h *SkillHandler) LintSkills(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	results, err := h.skillService.LintSkills(r.Context(), userID, projectID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, results)
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/httputil"
+)
+
+// SnapshotHandler handles HTTP requests for project snapshots
+type SnapshotHandler struct {
+	snapshotService docsysSvc.SnapshotService
+	logger          *slog.Logger
+}
+
+// NewSnapshotHandler creates a new snapshot handler
+func NewSnapshotHandler(snapshotService docsysSvc.SnapshotService, logger *slog.Logger) *SnapshotHandler {
+	return &SnapshotHandler{
+		snapshotService: snapshotService,
+		logger:          logger,
+	}
+}
+
+// CreateSnapshot captures the project's current tree
+// POST /api/projects/{id}/snapshots
+// Body is optional: {"name": "..."}
+func (h *SnapshotHandler) CreateSnapshot(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	var req docsysSvc.CreateSnapshotRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil && !errors.Is(err, io.EOF) {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	snapshot, err := h.snapshotService.CreateSnapshot(r.Context(), userID, projectID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusCreated, snapshot)
+}
+
+// ListSnapshots lists a project's snapshots, newest first
+// GET /api/projects/{id}/snapshots
+func (h *SnapshotHandler) ListSnapshots(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	snapshots, err := h.snapshotService.ListSnapshots(r.Context(), userID, projectID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, snapshots)
+}
+
+// GetSnapshot retrieves a snapshot with its folders and documents
+// GET /api/projects/{id}/snapshots/{snapshotId}
+func (h *SnapshotHandler) GetSnapshot(w http.ResponseWriter, r *http.Request) {
+	projectID, snapshotID, ok := snapshotPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	snapshot, err := h.snapshotService.GetSnapshot(r.Context(), userID, projectID, snapshotID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, snapshot)
+}
+
+// DiffSnapshot compares a snapshot with the live tree or another snapshot
+// GET /api/projects/{id}/snapshots/{snapshotId}/diff?against=current|{snapshotId}
+func (h *SnapshotHandler) DiffSnapshot(w http.ResponseWriter, r *http.Request) {
+	projectID, snapshotID, ok := snapshotPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+	against := r.URL.Query().Get("against")
+
+	diff, err := h.snapshotService.DiffSnapshot(r.Context(), userID, projectID, snapshotID, against)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, diff)
+}
+
+// RestoreSnapshot makes the project tree match a snapshot
+// POST /api/projects/{id}/snapshots/{snapshotId}/restore
+func (h *SnapshotHandler) RestoreSnapshot(w http.ResponseWriter, r *http.Request) {
+	projectID, snapshotID, ok := snapshotPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	result, err := h.snapshotService.RestoreSnapshot(r.Context(), userID, projectID, snapshotID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, result)
+}
+
+func snapshotPathParams(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return "", "", false
+	}
+	snapshotID, ok := PathParam(w, r, "snapshotId", "Snapshot ID")
+	if !ok {
+		return "", "", false
+	}
+	return projectID, snapshotID, true
+}
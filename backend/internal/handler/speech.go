@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/httputil"
+)
+
+// SpeechHandler handles HTTP requests for text-to-speech
+type SpeechHandler struct {
+	speechService llmSvc.SpeechService
+	logger        *slog.Logger
+}
+
+// NewSpeechHandler creates a new speech handler
+func NewSpeechHandler(speechService llmSvc.SpeechService, logger *slog.Logger) *SpeechHandler {
+	return &SpeechHandler{
+		speechService: speechService,
+		logger:        logger,
+	}
+}
+
+// SynthesizeTurn reads a turn's text aloud
+// POST /api/turns/{id}/tts
+// Returns a signed URL to the audio; repeated calls reuse the stored audio
+func (h *SpeechHandler) SynthesizeTurn(w http.ResponseWriter, r *http.Request) {
+	turnID, ok := PathParam(w, r, "id", "Turn ID")
+	if !ok {
+		return
+	}
+
+	// Validate turn ID format
+	if _, err := uuid.Parse(turnID); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid turn ID format")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	audio, err := h.speechService.SynthesizeTurn(r.Context(), userID, turnID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, audio)
+}
@@ -8,17 +8,19 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	mstream "github.com/haowjy/meridian-stream-go"
 
 	llmModels "meridian/internal/domain/models/llm"
+	"meridian/internal/eventstream"
 	"meridian/internal/handler/sse"
 	"meridian/internal/httputil"
+	"meridian/internal/logging"
 )
 
 // SSEHandler handles Server-Sent Events for streaming turn responses
 // Follows Dependency Inversion Principle - depends on KeepAliveStrategy interface
 type SSEHandler struct {
-	registry         *mstream.Registry
+	registry         eventstream.Registry
+	history          StreamHistoryFunc // Replays streams no longer registered (nil = none)
 	logger           *slog.Logger
 	config           *sse.Config
 	keepAliveFactory func(time.Duration) sse.KeepAliveStrategy
@@ -27,12 +29,14 @@ type SSEHandler struct {
 // NewSSEHandler creates a new SSE handler
 // Dependency Injection: Accepts config instead of hardcoding values
 func NewSSEHandler(
-	registry *mstream.Registry,
+	registry eventstream.Registry,
+	history StreamHistoryFunc,
 	logger *slog.Logger,
 	config *sse.Config,
 ) *SSEHandler {
 	return &SSEHandler{
 		registry: registry,
+		history:  history,
 		logger:   logger,
 		config:   config,
 		// Factory for creating keep-alive strategies (testable via injection)
@@ -44,15 +48,14 @@ func NewSSEHandler(
 
 // safeFlush flushes the response and checks if the connection is still healthy
 // Returns an error if the connection has been closed/broken
-func (h *SSEHandler) safeFlush(w http.ResponseWriter, flusher http.Flusher, turnID, clientID string) error {
+func (h *SSEHandler) safeFlush(w http.ResponseWriter, flusher http.Flusher, logger *slog.Logger, clientID string) error {
 	// Flush buffered data
 	flusher.Flush()
 
 	// Check connection health by attempting a zero-byte write
 	// If connection is closed, this will return an error
 	if _, err := w.Write([]byte{}); err != nil {
-		h.logger.Warn("flush failed, client likely disconnected",
-			"turn_id", turnID,
+		logger.Warn("flush failed, client likely disconnected",
 			"client_id", clientID,
 			"error", err,
 		)
@@ -62,21 +65,36 @@ func (h *SSEHandler) safeFlush(w http.ResponseWriter, flusher http.Flusher, turn
 	return nil
 }
 
+// writeEvent formats one event as SSE and flushes it
+func (h *SSEHandler) writeEvent(w http.ResponseWriter, flusher http.Flusher, logger *slog.Logger, clientID string, event eventstream.Event) error {
+	if event.Type != "" {
+		fmt.Fprintf(w, "event: %s\n", event.Type)
+	}
+	if event.ID != "" {
+		fmt.Fprintf(w, "id: %s\n", event.ID)
+	}
+	if event.Retry > 0 {
+		fmt.Fprintf(w, "retry: %d\n", event.Retry)
+	}
+	fmt.Fprintf(w, "data: %s\n\n", string(event.Data))
+
+	return h.safeFlush(w, flusher, logger, clientID)
+}
+
 // StreamTurn handles GET /api/turns/{id}/stream
 // Streams turn events via Server-Sent Events (SSE)
 func (h *SSEHandler) StreamTurn(w http.ResponseWriter, r *http.Request) {
 	turnID := r.PathValue("id")
 	clientIP := r.RemoteAddr
+	logger := logging.FromContext(logging.WithTurnID(r.Context(), turnID), h.logger)
 
-	h.logger.Info("SSE connection request",
-		"turn_id", turnID,
+	logger.Info("SSE connection request",
 		"client_ip", clientIP,
 	)
 
 	// Validate turn ID
 	if _, err := uuid.Parse(turnID); err != nil {
-		h.logger.Warn("invalid turn ID format",
-			"turn_id", turnID,
+		logger.Warn("invalid turn ID format",
 			"error", err,
 		)
 		httputil.RespondError(w, http.StatusBadRequest, "invalid turn ID format")
@@ -92,9 +110,7 @@ func (h *SSEHandler) StreamTurn(w http.ResponseWriter, r *http.Request) {
 	// Get the http.Flusher - required for SSE
 	flusher, ok := w.(http.Flusher)
 	if !ok {
-		h.logger.Error("ResponseWriter does not support flushing",
-			"turn_id", turnID,
-		)
+		logger.Error("ResponseWriter does not support flushing")
 		httputil.RespondError(w, http.StatusInternalServerError, "streaming not supported")
 		return
 	}
@@ -102,14 +118,12 @@ func (h *SSEHandler) StreamTurn(w http.ResponseWriter, r *http.Request) {
 	// Get Stream from registry
 	stream := h.registry.Get(turnID)
 	if stream == nil {
-		h.logger.Warn("stream not found for SSE connection",
-			"turn_id", turnID,
+		logger.Warn("stream not found for SSE connection",
 			"client_ip", clientIP,
 		)
 		// Don't return early - establish SSE connection first, then send error
 	} else {
-		h.logger.Info("stream found for SSE connection",
-			"turn_id", turnID,
+		logger.Info("stream found for SSE connection",
 			"client_ip", clientIP,
 		)
 	}
@@ -122,57 +136,57 @@ func (h *SSEHandler) StreamTurn(w http.ResponseWriter, r *http.Request) {
 
 	// Write 200 status and flush headers
 	w.WriteHeader(http.StatusOK)
-	if err := h.safeFlush(w, flusher, turnID, clientID); err != nil {
+	if err := h.safeFlush(w, flusher, logger, clientID); err != nil {
 		// Client disconnected before stream established
 		return
 	}
 
-	// If no stream, send error event and close gracefully
+	// A client connecting to an instance that is going away is told so right away
+	advice := newAdviceWatcher(h.registry)
+	if event, ok := advice.next(); ok {
+		if err := h.writeEvent(w, flusher, logger, clientID, event); err != nil {
+			return
+		}
+	}
+
+	// If no stream, replay it from the database if it has ended (it left the registry),
+	// otherwise send error event and close gracefully
 	if stream == nil {
+		if events, ok := loadStreamHistory(r.Context(), h.history, turnID, lastEventID, logger); ok {
+			for _, event := range events {
+				if err := h.writeEvent(w, flusher, logger, clientID, event); err != nil {
+					return
+				}
+			}
+			logger.Info("replayed stream history, closing stream",
+				"client_id", clientID,
+				"events", len(events),
+			)
+			return
+		}
+
 		errorData, _ := json.Marshal(llmModels.TurnErrorEvent{
 			TurnID: turnID,
 			Error:  "streaming not active for this turn",
 		})
 		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", llmModels.SSEEventTurnError, string(errorData))
-		if err := h.safeFlush(w, flusher, turnID, clientID); err != nil {
+		if err := h.safeFlush(w, flusher, logger, clientID); err != nil {
 			// Client disconnected, error already logged
 			return
 		}
-		h.logger.Info("sent error event for missing stream, closing stream",
-			"turn_id", turnID,
+		logger.Info("sent error event for missing stream, closing stream",
 			"client_id", clientID,
 		)
 		return
 	}
 
-	// If stream exists but is no longer running, do not replay.
-	// Clear the buffer to avoid lingering in-memory events and close immediately.
-	status := stream.Status()
-	if status == mstream.StatusComplete ||
-		status == mstream.StatusError ||
-		status == mstream.StatusCancelled {
-		// Clear buffer after completion to prevent 10-minute replay semantics.
-		stream.ClearBuffer()
-		return
-	}
-
-	// Get catchup events (for first connection or reconnection)
-	catchupEvents := stream.GetCatchupEvents(lastEventID)
+	// Get catchup events (for first connection or reconnection). A finished stream kept
+	// for its retention period has no buffer: catchup replays it from the database.
+	catchupEvents := stream.CatchupEvents(lastEventID)
 	if len(catchupEvents) > 0 {
 		// Send catchup events
 		for _, event := range catchupEvents {
-			if event.Type != "" {
-				fmt.Fprintf(w, "event: %s\n", event.Type)
-			}
-			if event.ID != "" {
-				fmt.Fprintf(w, "id: %s\n", event.ID)
-			}
-			if event.Retry > 0 {
-				fmt.Fprintf(w, "retry: %d\n", event.Retry)
-			}
-			fmt.Fprintf(w, "data: %s\n\n", string(event.Data))
-
-			if err := h.safeFlush(w, flusher, turnID, clientID); err != nil {
+			if err := h.writeEvent(w, flusher, logger, clientID, event); err != nil {
 				// Client disconnected during catchup
 				return
 			}
@@ -180,16 +194,13 @@ func (h *SSEHandler) StreamTurn(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Check if stream is already done (completed/error/cancelled)
-	status = stream.Status()
-	if status == mstream.StatusComplete ||
-		status == mstream.StatusError ||
-		status == mstream.StatusCancelled {
+	if stream.Status().Done() {
 		return // Close SSE connection gracefully
 	}
 
 	// Stream still active - add client to stream (get event channel for live events)
-	eventChan := stream.AddClient(clientID)
-	defer stream.RemoveClient(clientID)
+	eventChan := stream.Subscribe(clientID)
+	defer stream.Unsubscribe(clientID)
 
 	// Initialize keep-alive strategy (Dependency Inversion Principle)
 	// SSEHandler depends on KeepAliveStrategy interface, not concrete implementation
@@ -199,34 +210,34 @@ func (h *SSEHandler) StreamTurn(w http.ResponseWriter, r *http.Request) {
 
 	// Start keep-alive in background
 	// Returns channel that closes if keep-alive fails (e.g., connection dropped)
-	keepAliveDone := keepAliveStrategy.Start(keepAliveWriter, h.logger)
+	keepAliveDone := keepAliveStrategy.Start(keepAliveWriter, logger)
 
 	// Event loop: Stream events until completion or connection drop
 	for {
 		select {
 		case event, ok := <-eventChan:
 			if !ok {
-				// Channel closed - streaming complete/error/cancelled
+				// Channel closed - streaming complete/error/cancelled. A stream evicted
+				// on shutdown closes right after the advice, which may not be sent yet.
+				if event, ok := advice.next(); ok {
+					h.writeEvent(w, flusher, logger, clientID, event)
+				}
 				return
 			}
 
-			// Format mstream.Event as SSE
-			if event.Type != "" {
-				fmt.Fprintf(w, "event: %s\n", event.Type)
-			}
-			if event.ID != "" {
-				fmt.Fprintf(w, "id: %s\n", event.ID)
-			}
-			if event.Retry > 0 {
-				fmt.Fprintf(w, "retry: %d\n", event.Retry)
-			}
-			fmt.Fprintf(w, "data: %s\n\n", string(event.Data))
-
-			if err := h.safeFlush(w, flusher, turnID, clientID); err != nil {
+			if err := h.writeEvent(w, flusher, logger, clientID, event); err != nil {
 				// Client disconnected during event stream
 				return
 			}
 
+		case <-advice.changed:
+			// Advice events go to connected clients only (not buffered for catchup)
+			if event, ok := advice.next(); ok {
+				if err := h.writeEvent(w, flusher, logger, clientID, event); err != nil {
+					return
+				}
+			}
+
 		case <-keepAliveDone:
 			// Keep-alive failed (connection dropped)
 			return
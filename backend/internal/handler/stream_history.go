@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"meridian/internal/domain"
+	"meridian/internal/eventstream"
+)
+
+// StreamHistoryFunc rebuilds the events of a turn whose stream has ended
+// (StreamingService.StreamHistory)
+type StreamHistoryFunc func(ctx context.Context, turnID, lastEventID string) ([]eventstream.Event, error)
+
+// loadStreamHistory returns the events to replay for a turn that has no stream in the
+// registry. ok is false when the turn has no saved history (still streaming on another
+// instance, or never streamed) or it couldn't be loaded.
+func loadStreamHistory(ctx context.Context, history StreamHistoryFunc, turnID, lastEventID string, logger *slog.Logger) (events []eventstream.Event, ok bool) {
+	if history == nil {
+		return nil, false
+	}
+	events, err := history(ctx, turnID, lastEventID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			logger.Error("failed to load stream history", "error", err)
+		}
+		return nil, false
+	}
+	return events, true
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/httputil"
+)
+
+// StyleProfileHandler handles HTTP requests for project style profiles
+type StyleProfileHandler struct {
+	styleService docsysSvc.StyleProfileService
+	logger       *slog.Logger
+}
+
+// NewStyleProfileHandler creates a new style profile handler
+func NewStyleProfileHandler(styleService docsysSvc.StyleProfileService, logger *slog.Logger) *StyleProfileHandler {
+	return &StyleProfileHandler{
+		styleService: styleService,
+		logger:       logger,
+	}
+}
+
+// AnalyzeStyle starts deriving the project's style profile from selected documents
+// POST /api/projects/{id}/style-profile
+// Body: {"document_ids": ["..."]}. Returns 202 with the analyzing profile; poll
+// GET /api/projects/{id}/style-profile until its status is complete or failed
+func (h *StyleProfileHandler) AnalyzeStyle(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	var req docsysSvc.AnalyzeStyleRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	profile, err := h.styleService.AnalyzeStyle(r.Context(), userID, projectID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusAccepted, profile)
+}
+
+// GetStyleProfile returns the project's style profile
+// GET /api/projects/{id}/style-profile
+func (h *StyleProfileHandler) GetStyleProfile(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	profile, err := h.styleService.GetStyleProfile(r.Context(), userID, projectID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, profile)
+}
+
+// DeleteStyleProfile clears the project's style profile
+// DELETE /api/projects/{id}/style-profile
+func (h *StyleProfileHandler) DeleteStyleProfile(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	if err := h.styleService.DeleteStyleProfile(r.Context(), userID, projectID); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	docsystem "meridian/internal/domain/models/docsystem"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/httputil"
+)
+
+// TrashHandler handles trash listing and restore HTTP requests
+type TrashHandler struct {
+	trashService docsysSvc.TrashService
+	logger       *slog.Logger
+}
+
+// NewTrashHandler creates a new trash handler
+func NewTrashHandler(trashService docsysSvc.TrashService, logger *slog.Logger) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+		logger:       logger,
+	}
+}
+
+// ListTrash lists a project's deleted documents, folders and chats, most recent first
+// GET /api/projects/{id}/trash
+func (h *TrashHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	items, err := h.trashService.ListTrash(r.Context(), httputil.GetUserID(r), projectID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, items)
+}
+
+// RestoreDocument restores a deleted document
+// POST /api/documents/{id}/restore
+func (h *TrashHandler) RestoreDocument(w http.ResponseWriter, r *http.Request) {
+	h.restore(w, r, docsystem.TrashItemDocument, "Document ID")
+}
+
+// RestoreFolder restores a deleted folder with the subfolders and documents deleted with it
+// POST /api/folders/{id}/restore
+func (h *TrashHandler) RestoreFolder(w http.ResponseWriter, r *http.Request) {
+	h.restore(w, r, docsystem.TrashItemFolder, "Folder ID")
+}
+
+// RestoreChat restores a deleted chat
+// POST /api/chats/{id}/restore
+func (h *TrashHandler) RestoreChat(w http.ResponseWriter, r *http.Request) {
+	h.restore(w, r, docsystem.TrashItemChat, "Chat ID")
+}
+
+// restore returns 409 if the parent folder is still deleted or the name has been taken
+func (h *TrashHandler) restore(w http.ResponseWriter, r *http.Request, itemType docsystem.TrashItemType, label string) {
+	id, ok := PathParam(w, r, "id", label)
+	if !ok {
+		return
+	}
+
+	result, err := h.trashService.Restore(r.Context(), httputil.GetUserID(r), itemType, id)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, result)
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"log/slog"
+	"math"
 	"net/http"
 
 	docsysSvc "meridian/internal/domain/services/docsystem"
@@ -23,6 +24,7 @@ func NewTreeHandler(treeService docsysSvc.TreeService, logger *slog.Logger) *Tre
 }
 
 // GetTree returns the nested folder/document tree for a project
+// Query: depth (optional, >= 1) limits nesting; omitted returns the full tree
 func (h *TreeHandler) GetTree(w http.ResponseWriter, r *http.Request) {
 	// Get project ID from URL path
 	projectID := r.PathValue("id")
@@ -35,9 +37,10 @@ func (h *TreeHandler) GetTree(w http.ResponseWriter, r *http.Request) {
 	userID := httputil.GetUserID(r)
 
 	// Build the tree
-	tree, err := h.treeService.GetProjectTree(r.Context(), userID, projectID)
+	depth := QueryInt(r, "depth", 0, 1, math.MaxInt)
+	tree, err := h.treeService.GetProjectTree(r.Context(), userID, projectID, depth)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/httputil"
+)
+
+// UsageHandler handles HTTP requests for LLM usage and spend
+type UsageHandler struct {
+	usageService llmSvc.UsageService
+	logger       *slog.Logger
+}
+
+// NewUsageHandler creates a new usage handler
+func NewUsageHandler(usageService llmSvc.UsageService, logger *slog.Logger) *UsageHandler {
+	return &UsageHandler{
+		usageService: usageService,
+		logger:       logger,
+	}
+}
+
+// GetUsage returns the user's token usage and cost
+// GET /api/usage?group_by=chat|project|month&from=2026-09-01&to=2026-10-01&timezone=Europe/Paris
+func (h *UsageHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	req := llmSvc.GetUsageRequest{
+		GroupBy:  r.URL.Query().Get("group_by"),
+		Timezone: r.URL.Query().Get("timezone"),
+	}
+
+	var ok bool
+	if req.From, ok = queryTime(w, r, "from"); !ok {
+		return
+	}
+	if req.To, ok = queryTime(w, r, "to"); !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	report, err := h.usageService.GetUsage(r.Context(), userID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, report)
+}
+
+// GetChatUsage returns a chat's token usage and cost per day (or month) and model
+// GET /api/chats/{id}/usage?granularity=day|month&from=2026-10-01&to=2026-11-01&timezone=Europe/Paris
+func (h *UsageHandler) GetChatUsage(w http.ResponseWriter, r *http.Request) {
+	chatID, ok := PathParam(w, r, "id", "Chat ID")
+	if !ok {
+		return
+	}
+	if _, err := uuid.Parse(chatID); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid chat ID format")
+		return
+	}
+
+	req, ok := usageRollupRequest(w, r)
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	rollup, err := h.usageService.GetChatUsage(r.Context(), userID, chatID, req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, rollup)
+}
+
+// GetProjectUsage returns the token usage and cost of a project's chats per day (or
+// month) and model
+// GET /api/projects/{id}/usage?granularity=day|month&from=2026-10-01&to=2026-11-01&timezone=Europe/Paris
+func (h *UsageHandler) GetProjectUsage(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+	if _, err := uuid.Parse(projectID); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid project ID format")
+		return
+	}
+
+	req, ok := usageRollupRequest(w, r)
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	rollup, err := h.usageService.GetProjectUsage(r.Context(), userID, projectID, req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, rollup)
+}
+
+// usageRollupRequest reads the granularity, range and time zone query parameters.
+// Writes 400 error response if a date is invalid.
+func usageRollupRequest(w http.ResponseWriter, r *http.Request) (*llmSvc.GetUsageRollupRequest, bool) {
+	req := &llmSvc.GetUsageRollupRequest{
+		Granularity: r.URL.Query().Get("granularity"),
+		Timezone:    r.URL.Query().Get("timezone"),
+	}
+
+	var ok bool
+	if req.From, ok = queryTime(w, r, "from"); !ok {
+		return nil, false
+	}
+	if req.To, ok = queryTime(w, r, "to"); !ok {
+		return nil, false
+	}
+	return req, true
+}
+
+// queryTime parses an optional date (2006-01-02, midnight UTC until the service applies
+// the time zone) or RFC 3339 query parameter.
+// Writes 400 error response if the value is invalid.
+func queryTime(w http.ResponseWriter, r *http.Request, name string) (*time.Time, bool) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, true
+	}
+	for _, layout := range []string{time.DateOnly, time.RFC3339} {
+		if parsed, err := time.Parse(layout, raw); err == nil {
+			return &parsed, true
+		}
+	}
+	httputil.RespondError(w, http.StatusBadRequest, name+" must be a date (YYYY-MM-DD) or an RFC 3339 time")
+	return nil, false
+}
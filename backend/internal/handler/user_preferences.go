@@ -39,7 +39,7 @@ func (h *UserPreferencesHandler) GetPreferences(w http.ResponseWriter, r *http.R
 	// Get preferences
 	prefs, err := h.service.GetPreferences(r.Context(), uuid)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -69,7 +69,7 @@ func (h *UserPreferencesHandler) UpdatePreferences(w http.ResponseWriter, r *htt
 	// Update preferences
 	prefs, err := h.service.UpdatePreferences(r.Context(), uuid, &req)
 	if err != nil {
-		handleError(w, err)
+		handleError(w, r, err)
 		return
 	}
 
@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/httputil"
+)
+
+// WorkflowHandler handles HTTP requests for workflows and their runs in chats
+type WorkflowHandler struct {
+	workflowService llmSvc.WorkflowService
+	logger          *slog.Logger
+}
+
+// NewWorkflowHandler creates a new workflow handler
+func NewWorkflowHandler(workflowService llmSvc.WorkflowService, logger *slog.Logger) *WorkflowHandler {
+	return &WorkflowHandler{
+		workflowService: workflowService,
+		logger:          logger,
+	}
+}
+
+// CreateWorkflow adds a workflow to a project
+// POST /api/projects/{id}/workflows
+func (h *WorkflowHandler) CreateWorkflow(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	var req llmSvc.CreateWorkflowRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	workflow, err := h.workflowService.CreateWorkflow(r.Context(), userID, projectID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusCreated, workflow)
+}
+
+// ListWorkflows lists a project's workflows
+// GET /api/projects/{id}/workflows
+func (h *WorkflowHandler) ListWorkflows(w http.ResponseWriter, r *http.Request) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	workflows, err := h.workflowService.ListWorkflows(r.Context(), userID, projectID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, workflows)
+}
+
+// GetWorkflow retrieves a workflow
+// GET /api/projects/{id}/workflows/{workflowId}
+func (h *WorkflowHandler) GetWorkflow(w http.ResponseWriter, r *http.Request) {
+	projectID, workflowID, ok := workflowPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	workflow, err := h.workflowService.GetWorkflow(r.Context(), userID, projectID, workflowID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, workflow)
+}
+
+// UpdateWorkflow partially updates a workflow
+// PATCH /api/projects/{id}/workflows/{workflowId}
+func (h *WorkflowHandler) UpdateWorkflow(w http.ResponseWriter, r *http.Request) {
+	projectID, workflowID, ok := workflowPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req llmSvc.UpdateWorkflowRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	workflow, err := h.workflowService.UpdateWorkflow(r.Context(), userID, projectID, workflowID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, workflow)
+}
+
+// DeleteWorkflow removes a workflow
+// DELETE /api/projects/{id}/workflows/{workflowId}
+func (h *WorkflowHandler) DeleteWorkflow(w http.ResponseWriter, r *http.Request) {
+	projectID, workflowID, ok := workflowPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	if err := h.workflowService.DeleteWorkflow(r.Context(), userID, projectID, workflowID); err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// StartWorkflow creates a chat from a workflow
+// POST /api/projects/{id}/workflows/{workflowId}/start
+// Returns 201 with the chat and its run; the client sends the run's steps as turns
+func (h *WorkflowHandler) StartWorkflow(w http.ResponseWriter, r *http.Request) {
+	projectID, workflowID, ok := workflowPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req llmSvc.StartWorkflowRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil && !errors.Is(err, io.EOF) {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	started, err := h.workflowService.StartWorkflow(r.Context(), userID, projectID, workflowID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusCreated, started)
+}
+
+// GetRun returns the workflow run of a chat
+// GET /api/chats/{id}/workflow
+func (h *WorkflowHandler) GetRun(w http.ResponseWriter, r *http.Request) {
+	chatID, ok := PathParam(w, r, "id", "Chat ID")
+	if !ok {
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	run, err := h.workflowService.GetRun(r.Context(), userID, chatID)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, run)
+}
+
+// AdvanceRun marks the chat's current workflow step done
+// POST /api/chats/{id}/workflow/advance
+func (h *WorkflowHandler) AdvanceRun(w http.ResponseWriter, r *http.Request) {
+	chatID, ok := PathParam(w, r, "id", "Chat ID")
+	if !ok {
+		return
+	}
+
+	var req llmSvc.AdvanceWorkflowRunRequest
+	if err := httputil.ParseJSON(w, r, &req); err != nil && !errors.Is(err, io.EOF) {
+		httputil.RespondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	userID := httputil.GetUserID(r)
+
+	run, err := h.workflowService.AdvanceRun(r.Context(), userID, chatID, &req)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+
+	httputil.RespondJSON(w, http.StatusOK, run)
+}
+
+func workflowPathParams(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	projectID, ok := PathParam(w, r, "id", "Project ID")
+	if !ok {
+		return "", "", false
+	}
+	workflowID, ok := PathParam(w, r, "workflowId", "Workflow ID")
+	if !ok {
+		return "", "", false
+	}
+	return projectID, workflowID, true
+}
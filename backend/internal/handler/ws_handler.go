@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/websocket"
+
+	llmModels "meridian/internal/domain/models/llm"
+	"meridian/internal/eventstream"
+	"meridian/internal/handler/sse"
+	"meridian/internal/httputil"
+	"meridian/internal/logging"
+)
+
+// wsEventKeepAlive is the event name of keep-alive messages (the SSE keep-alive comment)
+const wsEventKeepAlive = "keepalive"
+
+// wsMaxClientMessageBytes caps messages read from clients, which are ignored anyway
+const wsMaxClientMessageBytes = 4096
+
+// wsMessage is one stream event as a WebSocket text message. Event and ID are the SSE
+// event and id fields; Data is the SSE data payload (JSON).
+type wsMessage struct {
+	Event string          `json:"event"`
+	ID    string          `json:"id,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+}
+
+// WSHandler streams turn events over WebSocket, for clients behind proxies that buffer
+// SSE. It serves the same stream events as SSEHandler, with the same catchup semantics.
+type WSHandler struct {
+	registry         eventstream.Registry
+	history          StreamHistoryFunc // Replays streams no longer registered (nil = none)
+	logger           *slog.Logger
+	config           *sse.Config
+	keepAliveFactory func(time.Duration) sse.KeepAliveStrategy
+}
+
+// NewWSHandler creates a new WebSocket handler (keep-alive interval from the SSE config)
+func NewWSHandler(
+	registry eventstream.Registry,
+	history StreamHistoryFunc,
+	logger *slog.Logger,
+	config *sse.Config,
+) *WSHandler {
+	return &WSHandler{
+		registry: registry,
+		history:  history,
+		logger:   logger,
+		config:   config,
+		keepAliveFactory: func(interval time.Duration) sse.KeepAliveStrategy {
+			return sse.NewTickerKeepAlive(interval)
+		},
+	}
+}
+
+// StreamTurn handles GET /api/turns/{id}/ws
+// Streams turn events over WebSocket. Reconnecting clients pass the last event ID they
+// saw as ?last_event_id= (browsers can't set Last-Event-ID on WebSocket requests).
+func (h *WSHandler) StreamTurn(w http.ResponseWriter, r *http.Request) {
+	turnID := r.PathValue("id")
+	logger := logging.FromContext(logging.WithTurnID(r.Context(), turnID), h.logger)
+
+	if _, err := uuid.Parse(turnID); err != nil {
+		httputil.RespondError(w, http.StatusBadRequest, "invalid turn ID format")
+		return
+	}
+	if !httputil.IsWebSocketUpgrade(r) {
+		httputil.RespondError(w, http.StatusUpgradeRequired, "WebSocket upgrade required")
+		return
+	}
+
+	lastEventID := r.URL.Query().Get("last_event_id")
+	if lastEventID == "" {
+		lastEventID = r.Header.Get("Last-Event-ID")
+	}
+
+	server := websocket.Server{
+		Handshake: wsHandshake,
+		Handler: func(ws *websocket.Conn) {
+			h.serve(ws, turnID, lastEventID, logger)
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+// wsHandshake selects the meridian-stream subprotocol when offered. Origins aren't
+// checked: the access token travels in the handshake rather than in cookies, so a page
+// on another origin can't open an authenticated socket.
+func wsHandshake(config *websocket.Config, r *http.Request) error {
+	if slices.Contains(config.Protocol, httputil.WebSocketProtocol) {
+		config.Protocol = []string{httputil.WebSocketProtocol}
+	} else {
+		config.Protocol = nil
+	}
+	return nil
+}
+
+// serve sends catchup and live events until the stream ends or the client goes away
+func (h *WSHandler) serve(ws *websocket.Conn, turnID, lastEventID string, logger *slog.Logger) {
+	defer ws.Close()
+
+	// The server's read timeout still applies to the hijacked connection
+	if err := ws.SetDeadline(time.Time{}); err != nil {
+		logger.Warn("failed to clear WebSocket deadline", "error", err)
+		return
+	}
+	ws.MaxPayloadBytes = wsMaxClientMessageBytes
+
+	clientID := uuid.New().String()
+	logger = logger.With("client_id", clientID)
+	logger.Info("WebSocket connection established")
+
+	// Reading detects the client closing the socket (and answers its pings);
+	// client messages themselves are ignored
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var message []byte
+		for {
+			if err := websocket.Message.Receive(ws, &message); err != nil {
+				return
+			}
+		}
+	}()
+
+	// A client connecting to an instance that is going away is told so right away
+	advice := newAdviceWatcher(h.registry)
+	if event, ok := advice.next(); ok {
+		if err := h.send(ws, toWSMessage(event), logger); err != nil {
+			return
+		}
+	}
+
+	stream := h.registry.Get(turnID)
+	if stream == nil {
+		// Same as SSE: a stream that left the registry is replayed if it has ended
+		if events, ok := loadStreamHistory(ws.Request().Context(), h.history, turnID, lastEventID, logger); ok {
+			for _, event := range events {
+				if err := h.send(ws, toWSMessage(event), logger); err != nil {
+					return
+				}
+			}
+			return
+		}
+
+		logger.Warn("stream not found for WebSocket connection")
+		data, _ := json.Marshal(llmModels.TurnErrorEvent{
+			TurnID: turnID,
+			Error:  "streaming not active for this turn",
+		})
+		h.send(ws, wsMessage{Event: llmModels.SSEEventTurnError, Data: data}, logger)
+		return
+	}
+
+	for _, event := range stream.CatchupEvents(lastEventID) {
+		if err := h.send(ws, toWSMessage(event), logger); err != nil {
+			return
+		}
+	}
+	if stream.Status().Done() {
+		return
+	}
+
+	eventChan := stream.Subscribe(clientID)
+	defer stream.Unsubscribe(clientID)
+
+	keepAliveStrategy := h.keepAliveFactory(h.config.KeepAliveInterval)
+	defer keepAliveStrategy.Stop()
+	keepAliveDone := keepAliveStrategy.Start(&wsKeepAliveWriter{ws: ws}, logger)
+
+	for {
+		select {
+		case event, ok := <-eventChan:
+			if !ok {
+				// Channel closed - streaming complete/error/cancelled (same as SSE: the
+				// advice of an evicted stream may not be sent yet)
+				if event, ok := advice.next(); ok {
+					h.send(ws, toWSMessage(event), logger)
+				}
+				return
+			}
+			if err := h.send(ws, toWSMessage(event), logger); err != nil {
+				return
+			}
+
+		case <-advice.changed:
+			if event, ok := advice.next(); ok {
+				if err := h.send(ws, toWSMessage(event), logger); err != nil {
+					return
+				}
+			}
+
+		case <-keepAliveDone:
+			return
+
+		case <-closed:
+			logger.Info("WebSocket client disconnected")
+			return
+		}
+	}
+}
+
+// send writes one message; conn writes are serialized, so keep-alives may interleave
+func (h *WSHandler) send(ws *websocket.Conn, message wsMessage, logger *slog.Logger) error {
+	if err := websocket.JSON.Send(ws, message); err != nil {
+		logger.Warn("WebSocket write failed, client likely disconnected", "error", err)
+		return err
+	}
+	return nil
+}
+
+// toWSMessage converts a stream event; non-JSON data is sent as a JSON string
+func toWSMessage(event eventstream.Event) wsMessage {
+	data := json.RawMessage(event.Data)
+	if len(event.Data) > 0 && !json.Valid(event.Data) {
+		data, _ = json.Marshal(string(event.Data))
+	}
+	return wsMessage{Event: event.Type, ID: event.ID, Data: data}
+}
+
+// wsKeepAliveWriter implements sse.KeepAliveWriter with keep-alive messages
+type wsKeepAliveWriter struct {
+	ws *websocket.Conn
+}
+
+// WriteKeepAlive sends {"event":"keepalive"}
+func (k *wsKeepAliveWriter) WriteKeepAlive() error {
+	if err := websocket.JSON.Send(k.ws, wsMessage{Event: wsEventKeepAlive}); err != nil {
+		return fmt.Errorf("write keepalive failed: %w", err)
+	}
+	return nil
+}
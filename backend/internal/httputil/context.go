@@ -3,6 +3,8 @@ package httputil
 import (
 	"context"
 	"net/http"
+
+	"meridian/internal/logging"
 )
 
 // Context key type to avoid collisions
@@ -12,9 +14,10 @@ const (
 	userIDKey contextKey = "userID"
 )
 
-// WithUserID adds userID to the request context
+// WithUserID adds userID to the request context (also as a logging field)
 func WithUserID(r *http.Request, userID string) *http.Request {
 	ctx := context.WithValue(r.Context(), userIDKey, userID)
+	ctx = logging.WithUserID(ctx, userID)
 	return r.WithContext(ctx)
 }
 
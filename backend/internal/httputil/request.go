@@ -13,9 +13,9 @@ func ParseJSON(w http.ResponseWriter, r *http.Request, dest interface{}) error {
 	r.Body = http.MaxBytesReader(w, r.Body, 10<<20)
 
 	decoder := json.NewDecoder(r.Body)
-	// Note: DisallowUnknownFields() is intentionally NOT used to allow flexible
-	// map fields like request_params to accept arbitrary LLM provider parameters.
-	// Validation is performed downstream via domain-specific validators.
+	// Note: DisallowUnknownFields() is intentionally NOT used for whole bodies, so
+	// clients can send fields a handler ignores. Types that must be exact (e.g.
+	// request_params) reject unknown keys in their own UnmarshalJSON.
 
 	if err := decoder.Decode(dest); err != nil {
 		return fmt.Errorf("invalid JSON: %w", err)
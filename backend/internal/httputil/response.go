@@ -5,6 +5,9 @@ import (
 	"net/http"
 )
 
+// TotalCountHeader carries the total item count for paginated endpoints whose body is a plain array
+const TotalCountHeader = "X-Total-Count"
+
 // RespondJSON writes a JSON response with the given status code.
 // It handles encoding errors safely by marshaling first, preventing
 // partial responses if encoding fails after headers are sent.
@@ -115,8 +118,14 @@ func errorTypeFromStatus(status int) string {
 		return "https://datatracker.ietf.org/doc/html/rfc7231#section-6.5.4"
 	case http.StatusConflict:
 		return "https://datatracker.ietf.org/doc/html/rfc7231#section-6.5.8"
+	case http.StatusRequestEntityTooLarge:
+		return "https://datatracker.ietf.org/doc/html/rfc7231#section-6.5.11"
+	case http.StatusTooManyRequests:
+		return "https://datatracker.ietf.org/doc/html/rfc6585#section-4"
 	case http.StatusInternalServerError:
 		return "https://datatracker.ietf.org/doc/html/rfc7231#section-6.6.1"
+	case http.StatusServiceUnavailable:
+		return "https://datatracker.ietf.org/doc/html/rfc7231#section-6.6.4"
 	default:
 		return "about:blank"
 	}
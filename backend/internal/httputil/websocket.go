@@ -0,0 +1,46 @@
+package httputil
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Browsers can't set an Authorization header on WebSocket requests, so WebSocket clients
+// offer two subprotocols instead: WebSocketProtocol (WebSocketCollabProtocol on the
+// document collaboration channel), which the server selects, and WebSocketTokenPrefix +
+// the access token, which the auth middleware reads.
+//
+//	new WebSocket(url, ["meridian-stream", "bearer." + accessToken])
+const (
+	WebSocketProtocol       = "meridian-stream"
+	WebSocketCollabProtocol = "meridian-collab"
+	WebSocketTokenPrefix    = "bearer."
+)
+
+// IsWebSocketUpgrade reports whether r asks to upgrade to a WebSocket
+func IsWebSocketUpgrade(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// WebSocketProtocols returns the subprotocols a WebSocket client offered
+func WebSocketProtocols(r *http.Request) []string {
+	var protocols []string
+	for _, header := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, protocol := range strings.Split(header, ",") {
+			if protocol = strings.TrimSpace(protocol); protocol != "" {
+				protocols = append(protocols, protocol)
+			}
+		}
+	}
+	return protocols
+}
+
+// WebSocketToken returns the access token a WebSocket client offered as a subprotocol
+func WebSocketToken(r *http.Request) (string, bool) {
+	for _, protocol := range WebSocketProtocols(r) {
+		if token, ok := strings.CutPrefix(protocol, WebSocketTokenPrefix); ok && token != "" {
+			return token, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,110 @@
+// Package logging carries request-scoped identifiers (request, user, chat, turn) through
+// context.Context and derives child loggers that include them, so log lines don't have
+// to repeat these keys by hand.
+//
+// Usage:
+//
+//	ctx = logging.WithChatID(ctx, chatID)
+//	logger := logging.FromContext(ctx, s.logger)
+//	logger.Info("turn created") // includes request_id, user_id, chat_id
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Log keys for context fields
+const (
+	KeyRequestID = "request_id"
+	KeyUserID    = "user_id"
+	KeyChatID    = "chat_id"
+	KeyTurnID    = "turn_id"
+)
+
+// contextKey is unexported to avoid collisions with other packages
+type contextKey struct{}
+
+// fields holds the identifiers attached to a context.
+// Treated as immutable: every With* stores a modified copy.
+type fields struct {
+	requestID string
+	userID    string
+	chatID    string
+	turnID    string
+}
+
+func fromContext(ctx context.Context) fields {
+	if ctx == nil {
+		return fields{}
+	}
+	f, _ := ctx.Value(contextKey{}).(fields)
+	return f
+}
+
+func with(ctx context.Context, update func(*fields)) context.Context {
+	f := fromContext(ctx)
+	update(&f)
+	return context.WithValue(ctx, contextKey{}, f)
+}
+
+// WithRequestID returns a context carrying the request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return with(ctx, func(f *fields) { f.requestID = requestID })
+}
+
+// WithUserID returns a context carrying the user ID
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return with(ctx, func(f *fields) { f.userID = userID })
+}
+
+// WithChatID returns a context carrying the chat ID
+func WithChatID(ctx context.Context, chatID string) context.Context {
+	return with(ctx, func(f *fields) { f.chatID = chatID })
+}
+
+// WithTurnID returns a context carrying the turn ID
+func WithTurnID(ctx context.Context, turnID string) context.Context {
+	return with(ctx, func(f *fields) { f.turnID = turnID })
+}
+
+// RequestID returns the request ID from the context ("" if none)
+func RequestID(ctx context.Context) string {
+	return fromContext(ctx).requestID
+}
+
+// Detach returns a new background context carrying ctx's identifiers but not its
+// cancellation or deadline - for work that outlives the request (e.g. streaming goroutines)
+func Detach(ctx context.Context) context.Context {
+	f := fromContext(ctx)
+	return context.WithValue(context.Background(), contextKey{}, f)
+}
+
+// Attrs returns the identifiers set on the context as slog key-value pairs
+func Attrs(ctx context.Context) []any {
+	f := fromContext(ctx)
+	attrs := make([]any, 0, 8)
+	if f.requestID != "" {
+		attrs = append(attrs, KeyRequestID, f.requestID)
+	}
+	if f.userID != "" {
+		attrs = append(attrs, KeyUserID, f.userID)
+	}
+	if f.chatID != "" {
+		attrs = append(attrs, KeyChatID, f.chatID)
+	}
+	if f.turnID != "" {
+		attrs = append(attrs, KeyTurnID, f.turnID)
+	}
+	return attrs
+}
+
+// FromContext returns a child of base that includes the context's identifiers.
+// Returns base unchanged when the context carries none.
+func FromContext(ctx context.Context, base *slog.Logger) *slog.Logger {
+	attrs := Attrs(ctx)
+	if len(attrs) == 0 {
+		return base
+	}
+	return base.With(attrs...)
+}
@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	ctx = WithUserID(ctx, "user-1")
+	child := WithChatID(ctx, "chat-1")
+
+	FromContext(child, base).Info("hello", "extra", 1)
+
+	var line map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{KeyRequestID: "req-1", KeyUserID: "user-1", KeyChatID: "chat-1"} {
+		if line[key] != want {
+			t.Errorf("%s = %v, want %s", key, line[key], want)
+		}
+	}
+	if _, ok := line[KeyTurnID]; ok {
+		t.Error("unset turn_id should be omitted")
+	}
+
+	// Deriving a child context leaves the parent unchanged
+	if len(Attrs(ctx)) != 4 {
+		t.Errorf("parent attrs = %v", Attrs(ctx))
+	}
+
+	// No identifiers: the base logger itself is returned
+	if FromContext(context.Background(), base) != base {
+		t.Error("expected base logger for empty context")
+	}
+}
+
+func TestDetach(t *testing.T) {
+	ctx, cancel := context.WithTimeout(WithTurnID(context.Background(), "turn-1"), time.Millisecond)
+	cancel()
+
+	detached := Detach(ctx)
+	if detached.Err() != nil {
+		t.Error("detached context should not inherit cancellation")
+	}
+	if attrs := Attrs(detached); len(attrs) != 2 || attrs[1] != "turn-1" {
+		t.Errorf("detached attrs = %v", attrs)
+	}
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"meridian/internal/httputil"
+)
+
+// AdminTokenMiddleware guards the operator API (/admin/api/...) with a static Bearer token
+// (ADMIN_API_TOKEN). These endpoints act across all users, so they don't take user JWTs.
+func AdminTokenMiddleware(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+				httputil.RespondError(w, http.StatusUnauthorized, "Invalid admin token")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -10,21 +10,24 @@ import (
 
 // AuthMiddleware validates JWT tokens from Supabase Auth.
 // It extracts the Bearer token from the Authorization header, verifies it,
-// and injects the user ID into the request context.
+// and injects the user ID into the request context. WebSocket upgrades without
+// the header may offer the token as a subprotocol instead (see httputil.WebSocketToken).
 //
-// The /health endpoint is excluded from authentication to allow
-// load balancers and monitoring tools to check server health.
+// Public endpoints (/health, signed object storage downloads whose URL signature is the
+// credential) are registered in the router's admin group, which doesn't use this middleware.
 func AuthMiddleware(jwtVerifier auth.JWTVerifier) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Skip auth for health check endpoint
-			if r.URL.Path == "/health" {
-				next.ServeHTTP(w, r)
-				return
-			}
-
 			// Extract Authorization header
 			authHeader := r.Header.Get("Authorization")
+			if authHeader == "" {
+				// Browsers can't set headers on WebSocket requests; the token comes as a subprotocol
+				if httputil.IsWebSocketUpgrade(r) {
+					if token, ok := httputil.WebSocketToken(r); ok {
+						authHeader = "Bearer " + token
+					}
+				}
+			}
 			if authHeader == "" {
 				httputil.RespondError(w, http.StatusUnauthorized, "Missing authorization header")
 				return
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"meridian/internal/domain/models"
+	"meridian/internal/httputil"
+)
+
+// fakeVerifier accepts one token
+type fakeVerifier struct {
+	token string
+}
+
+func (f *fakeVerifier) VerifyToken(tokenString string) (*models.SupabaseClaims, error) {
+	if tokenString != f.token {
+		return nil, errors.New("invalid token")
+	}
+	return &models.SupabaseClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"}}, nil
+}
+
+func (f *fakeVerifier) Close() error { return nil }
+
+func TestAuthMiddleware_WebSocketToken(t *testing.T) {
+	handler := AuthMiddleware(&fakeVerifier{token: "good.jwt.token"})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-User", httputil.GetUserID(r))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name      string
+		upgrade   string
+		protocols string
+		auth      string
+		want      int
+	}{
+		{"token subprotocol", "websocket", "meridian-stream, bearer.good.jwt.token", "", http.StatusNoContent},
+		{"bad token subprotocol", "websocket", "meridian-stream, bearer.bad", "", http.StatusUnauthorized},
+		{"no token subprotocol", "websocket", "meridian-stream", "", http.StatusUnauthorized},
+		{"subprotocol without upgrade", "", "bearer.good.jwt.token", "", http.StatusUnauthorized},
+		{"header wins over subprotocol", "websocket", "bearer.bad", "Bearer good.jwt.token", http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/turns/t1/ws", nil)
+			if tt.upgrade != "" {
+				req.Header.Set("Upgrade", tt.upgrade)
+			}
+			req.Header.Set("Sec-WebSocket-Protocol", tt.protocols)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.want == http.StatusNoContent && rec.Header().Get("X-User") != "user-1" {
+				t.Errorf("user = %q, want user-1", rec.Header().Get("X-User"))
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package middleware
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// CORS origin configuration.
+//
+// CORS_ORIGINS is a comma-separated list of entries:
+//   - exact origins:       https://meridian.app
+//   - wildcard patterns:   https://*.vercel.app, https://meridian-*.vercel.app, http://localhost:*
+//     ("*" in the host matches one or more DNS label characters, including dots;
+//     "*" as the port matches any port)
+//   - presets:             @dev (see CORSPresets)
+//   - allow everything:    * (credentials are still sent, so avoid outside local dev)
+//
+// When CORS_ORIGINS is unset the preset named after the environment is used.
+// Origins can be reloaded at runtime (SIGHUP re-reads CORS_ORIGINS_FILE) via CORSOrigins.Reload.
+
+// CORSPresets are named origin lists usable as "@name" entries
+var CORSPresets = map[string][]string{
+	// Local frontends on any port
+	"dev": {"http://localhost:*", "http://127.0.0.1:*"},
+	// Test runs use the same local origins
+	"test": {"http://localhost:*", "http://127.0.0.1:*"},
+	// Production has no implicit origins: configure CORS_ORIGINS explicitly
+	"prod": {},
+}
+
+// originPattern matches one configured origin entry
+type originPattern struct {
+	raw    string
+	exact  string         // set for entries without wildcards
+	regexp *regexp.Regexp // set for wildcard entries
+}
+
+func (p originPattern) matches(origin string) bool {
+	if p.regexp != nil {
+		return p.regexp.MatchString(origin)
+	}
+	return p.exact == origin
+}
+
+// OriginMatcher decides whether an Origin header is allowed
+type OriginMatcher struct {
+	allowAll bool
+	patterns []originPattern
+}
+
+// hostWildcard replaces "*" in host patterns: one or more labels, each of [a-z0-9-]
+const hostWildcard = `[a-z0-9-]+(?:\.[a-z0-9-]+)*`
+
+// ParseOrigins parses a CORS_ORIGINS value (see package docs above).
+// Invalid entries are rejected rather than silently ignored.
+func ParseOrigins(spec string) (*OriginMatcher, error) {
+	m := &OriginMatcher{}
+	if err := m.add(spec, map[string]bool{}); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *OriginMatcher) add(spec string, seenPresets map[string]bool) error {
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+
+		if entry == "*" {
+			m.allowAll = true
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(entry, "@"); ok {
+			preset, exists := CORSPresets[name]
+			if !exists {
+				return fmt.Errorf("unknown CORS preset %q", entry)
+			}
+			if seenPresets[name] {
+				continue
+			}
+			seenPresets[name] = true
+			if err := m.add(strings.Join(preset, ","), seenPresets); err != nil {
+				return err
+			}
+			continue
+		}
+
+		pattern, err := parseOriginPattern(entry)
+		if err != nil {
+			return err
+		}
+		m.patterns = append(m.patterns, pattern)
+	}
+	return nil
+}
+
+func parseOriginPattern(entry string) (originPattern, error) {
+	// Replace wildcards with placeholders so url.Parse accepts the pattern
+	placeholder := strings.ReplaceAll(strings.ReplaceAll(entry, ":*", ":1"), "*", "wildcard-x")
+	u, err := url.Parse(placeholder)
+	if err != nil || u.Host == "" {
+		return originPattern{}, fmt.Errorf("invalid CORS origin %q", entry)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return originPattern{}, fmt.Errorf("invalid CORS origin %q: scheme must be http or https", entry)
+	}
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return originPattern{}, fmt.Errorf("invalid CORS origin %q: must be scheme://host[:port]", entry)
+	}
+
+	origin := strings.TrimSuffix(entry, "/")
+	if !strings.Contains(origin, "*") {
+		return originPattern{raw: entry, exact: origin}, nil
+	}
+
+	scheme, hostPort, _ := strings.Cut(origin, "://")
+	host, port, hasPort := strings.Cut(hostPort, ":")
+	if strings.Contains(port, "*") && port != "*" {
+		return originPattern{}, fmt.Errorf("invalid CORS origin %q: port wildcard must be the whole port", entry)
+	}
+	if host == "*" {
+		return originPattern{}, fmt.Errorf("invalid CORS origin %q: host wildcard needs a fixed domain suffix", entry)
+	}
+
+	expr := "^" + regexp.QuoteMeta(scheme) + "://" +
+		strings.ReplaceAll(regexp.QuoteMeta(host), `\*`, hostWildcard)
+	if hasPort {
+		if port == "*" {
+			expr += `(?::[0-9]{1,5})?`
+		} else {
+			expr += ":" + regexp.QuoteMeta(port)
+		}
+	}
+	expr += "$"
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return originPattern{}, fmt.Errorf("invalid CORS origin %q: %w", entry, err)
+	}
+	return originPattern{raw: entry, regexp: re}, nil
+}
+
+// Allowed reports whether origin may make cross-origin requests
+func (m *OriginMatcher) Allowed(origin string) bool {
+	if m.allowAll {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	for _, p := range m.patterns {
+		if p.matches(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsAll reports whether the "*" entry was configured
+func (m *OriginMatcher) AllowsAll() bool {
+	return m.allowAll
+}
+
+// Entries returns the configured entries with presets expanded (for logging)
+func (m *OriginMatcher) Entries() []string {
+	entries := make([]string, 0, len(m.patterns)+1)
+	if m.allowAll {
+		entries = append(entries, "*")
+	}
+	for _, p := range m.patterns {
+		entries = append(entries, p.raw)
+	}
+	return entries
+}
+
+// CORSOrigins holds the active OriginMatcher and allows swapping it at runtime.
+// Safe for concurrent use; pass Allowed as rs/cors AllowOriginFunc.
+type CORSOrigins struct {
+	current atomic.Pointer[OriginMatcher]
+}
+
+// NewCORSOrigins parses spec into a reloadable origin set
+func NewCORSOrigins(spec string) (*CORSOrigins, error) {
+	matcher, err := ParseOrigins(spec)
+	if err != nil {
+		return nil, err
+	}
+	o := &CORSOrigins{}
+	o.current.Store(matcher)
+	return o, nil
+}
+
+// Allowed reports whether origin is allowed by the current configuration
+func (o *CORSOrigins) Allowed(origin string) bool {
+	return o.current.Load().Allowed(origin)
+}
+
+// Current returns the active matcher
+func (o *CORSOrigins) Current() *OriginMatcher {
+	return o.current.Load()
+}
+
+// Reload replaces the allowed origins. On a parse error the current set is kept.
+func (o *CORSOrigins) Reload(spec string) error {
+	matcher, err := ParseOrigins(spec)
+	if err != nil {
+		return err
+	}
+	o.current.Store(matcher)
+	return nil
+}
+
+// ReloadFile replaces the allowed origins with the contents of path
+// (comma- or newline-separated entries; lines starting with # are ignored)
+func (o *CORSOrigins) ReloadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read CORS origins file: %w", err)
+	}
+	return o.Reload(originsFromFile(string(data)))
+}
+
+// originsFromFile converts file contents to a CORS_ORIGINS spec
+func originsFromFile(content string) string {
+	var entries []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, line)
+	}
+	return strings.Join(entries, ",")
+}
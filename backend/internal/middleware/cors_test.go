@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseOrigins_Matching(t *testing.T) {
+	matcher, err := ParseOrigins("https://meridian.app, https://meridian-*.vercel.app,https://*.preview.dev,@dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://meridian.app", true},
+		{"https://MERIDIAN.app", true},
+		{"http://meridian.app", false},
+		{"https://meridian.app:8443", false},
+		{"https://meridian-git-feature-team.vercel.app", true},
+		{"https://other.vercel.app", false},
+		{"https://meridian-.vercel.app", false},
+		{"https://a.b.preview.dev", true},
+		{"https://preview.dev", false},
+		{"https://evil.com/.preview.dev", false},
+		{"https://evilpreview.dev", false},
+		{"http://localhost:3000", true},
+		{"http://localhost", true},
+		{"http://localhost.evil.com", false},
+		{"http://127.0.0.1:5173", true},
+	}
+	for _, tt := range tests {
+		if got := matcher.Allowed(tt.origin); got != tt.want {
+			t.Errorf("Allowed(%q) = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+}
+
+func TestParseOrigins_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"meridian.app",             // no scheme
+		"ftp://meridian.app",       // scheme
+		"https://meridian.app/app", // path
+		"https://*",                // unanchored wildcard
+		"http://localhost:30*",     // partial port wildcard
+		"@staging",                 // unknown preset
+	} {
+		if _, err := ParseOrigins(spec); err == nil {
+			t.Errorf("ParseOrigins(%q) expected error", spec)
+		}
+	}
+}
+
+func TestCORSOrigins_ReloadFile(t *testing.T) {
+	origins, err := NewCORSOrigins("https://old.app")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "origins")
+	if err := os.WriteFile(path, []byte("# allowed\nhttps://new.app\nhttps://*.vercel.app\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := origins.ReloadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if origins.Allowed("https://old.app") || !origins.Allowed("https://new.app") || !origins.Allowed("https://x.vercel.app") {
+		t.Errorf("reloaded entries = %v", origins.Current().Entries())
+	}
+
+	// A bad file keeps the current set
+	if err := os.WriteFile(path, []byte("not-an-origin"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := origins.ReloadFile(path); err == nil {
+		t.Fatal("expected error for invalid file")
+	}
+	if !origins.Allowed("https://new.app") {
+		t.Error("invalid reload replaced the current origins")
+	}
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"meridian/internal/httputil"
+)
+
+// flagFileCheckInterval limits how often the maintenance flag file is stat'ed
+const flagFileCheckInterval = 2 * time.Second
+
+// MaintenanceConfig configures maintenance mode
+type MaintenanceConfig struct {
+	Enabled    bool          // Start in maintenance mode
+	FlagFile   string        // Maintenance is also on while this file exists (toggle without restart)
+	Message    string        // Detail returned to clients
+	RetryAfter time.Duration // Sent as Retry-After (0 omits the header)
+}
+
+// MaintenanceMode rejects mutating requests with 503 while active.
+// Reads keep working and running streams are not interrupted, so in-flight turns
+// drain normally while no new turns (or other writes) start. Safe for concurrent use.
+type MaintenanceMode struct {
+	config  MaintenanceConfig
+	enabled atomic.Bool
+
+	// Cached flag file state
+	flagFileExists atomic.Bool
+	flagCheckedAt  atomic.Int64 // unix nanos
+}
+
+// NewMaintenanceMode creates the maintenance switch
+func NewMaintenanceMode(config MaintenanceConfig) *MaintenanceMode {
+	if config.Message == "" {
+		config.Message = "Meridian is undergoing maintenance. Please try again shortly."
+	}
+	m := &MaintenanceMode{config: config}
+	m.enabled.Store(config.Enabled)
+	return m
+}
+
+// SetEnabled turns the configured switch on or off (the flag file is checked independently)
+func (m *MaintenanceMode) SetEnabled(enabled bool) {
+	m.enabled.Store(enabled)
+}
+
+// Active reports whether maintenance mode is currently on
+func (m *MaintenanceMode) Active() bool {
+	return m.enabled.Load() || m.flagFilePresent()
+}
+
+func (m *MaintenanceMode) flagFilePresent() bool {
+	if m.config.FlagFile == "" {
+		return false
+	}
+	now := time.Now().UnixNano()
+	if now-m.flagCheckedAt.Load() < int64(flagFileCheckInterval) {
+		return m.flagFileExists.Load()
+	}
+	_, err := os.Stat(m.config.FlagFile)
+	m.flagFileExists.Store(err == nil)
+	m.flagCheckedAt.Store(now)
+	return err == nil
+}
+
+// Middleware returns 503 for mutating requests while maintenance mode is active
+func (m *MaintenanceMode) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !m.Active() || allowedDuringMaintenance(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			extras := map[string]interface{}{
+				"maintenance": true,
+			}
+			if m.config.RetryAfter > 0 {
+				seconds := int(m.config.RetryAfter.Seconds())
+				w.Header().Set("Retry-After", strconv.Itoa(seconds))
+				extras["retry_after_seconds"] = seconds
+			}
+			httputil.RespondErrorWithExtras(w, http.StatusServiceUnavailable, m.config.Message, extras)
+		})
+	}
+}
+
+// allowedDuringMaintenance lets reads through, plus interrupts (stopping a
+// stream only helps it drain)
+func allowedDuringMaintenance(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return r.Method == http.MethodPost &&
+		strings.HasPrefix(r.URL.Path, "/api/turns/") &&
+		strings.HasSuffix(r.URL.Path, "/interrupt")
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceMode_Middleware(t *testing.T) {
+	mode := NewMaintenanceMode(MaintenanceConfig{Enabled: true, RetryAfter: 90 * time.Second})
+	handler := mode.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodGet, "/api/chats", http.StatusNoContent},
+		{http.MethodGet, "/api/turns/t1/stream", http.StatusNoContent},
+		{http.MethodOptions, "/api/turns", http.StatusNoContent},
+		{http.MethodPost, "/api/turns/t1/interrupt", http.StatusNoContent},
+		{http.MethodPost, "/api/turns", http.StatusServiceUnavailable},
+		{http.MethodPatch, "/api/documents/d1", http.StatusServiceUnavailable},
+		{http.MethodDelete, "/api/chats/c1", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+		if rec.Code != tt.want {
+			t.Errorf("%s %s = %d, want %d", tt.method, tt.path, rec.Code, tt.want)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/turns", nil))
+	if rec.Header().Get("Retry-After") != "90" {
+		t.Errorf("Retry-After = %q", rec.Header().Get("Retry-After"))
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["maintenance"] != true || body["retry_after_seconds"] != float64(90) {
+		t.Errorf("body = %v", body)
+	}
+
+	// Turning it off lets writes through
+	mode.SetEnabled(false)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/turns", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("disabled: POST = %d", rec.Code)
+	}
+}
+
+func TestMaintenanceMode_FlagFile(t *testing.T) {
+	flag := filepath.Join(t.TempDir(), "maintenance")
+	mode := NewMaintenanceMode(MaintenanceConfig{FlagFile: flag})
+	if mode.Active() {
+		t.Fatal("active without flag file")
+	}
+
+	if err := os.WriteFile(flag, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	mode.flagCheckedAt.Store(0) // skip the stat cache
+	if !mode.Active() {
+		t.Error("flag file should enable maintenance mode")
+	}
+}
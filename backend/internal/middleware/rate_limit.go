@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"meridian/internal/httputil"
+)
+
+// rateLimitSweepInterval is how often buckets that have refilled completely are dropped
+const rateLimitSweepInterval = time.Minute
+
+// RateLimit configures the limit of one class of routes: each user gets a bucket of
+// Burst requests, refilled at PerMinute requests per minute
+type RateLimit struct {
+	PerMinute int // Sustained rate (<= 0 disables the limit)
+	Burst     int // Requests allowed at once (default: PerMinute)
+}
+
+// Enabled reports whether the limit applies
+func (l RateLimit) Enabled() bool {
+	return l.PerMinute > 0
+}
+
+// RateLimiter limits each user's requests to one class of routes with token buckets.
+// Buckets live in memory, so each server instance limits on its own. Safe for
+// concurrent use.
+type RateLimiter struct {
+	burst float64
+	rate  float64 // Tokens per second
+	now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	sweptAt time.Time
+}
+
+// tokenBucket is one user's bucket; tokens are refilled lazily when it's used
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// NewRateLimiter creates a limiter for an enabled limit
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	burst := limit.Burst
+	if burst <= 0 {
+		burst = limit.PerMinute
+	}
+	return &RateLimiter{
+		burst:   float64(burst),
+		rate:    float64(limit.PerMinute) / 60,
+		now:     time.Now,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// Allow takes a token from key's bucket. If the bucket is empty it returns false and
+// how long until the next token.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: l.burst, updatedAt: now}
+		l.buckets[key] = bucket
+	}
+	bucket.tokens = math.Min(l.burst, bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*l.rate)
+	bucket.updatedAt = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+}
+
+// sweep drops the buckets that have refilled completely (a new bucket starts full, so
+// nothing changes for their users), at most once per rateLimitSweepInterval
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.sweptAt) < rateLimitSweepInterval {
+		return
+	}
+	l.sweptAt = now
+	for key, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// Middleware rejects requests beyond the limit with 429 and Retry-After. Requests are
+// counted per user, so it must run after the auth middleware; requests without a user
+// are counted per client address.
+func (l *RateLimiter) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			allowed, wait := l.Allow(rateLimitKey(r))
+			if allowed {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			seconds := int(math.Ceil(wait.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			httputil.RespondErrorWithExtras(w, http.StatusTooManyRequests,
+				fmt.Sprintf("Too many requests. Try again in %d seconds.", seconds),
+				map[string]interface{}{"retry_after_seconds": seconds})
+		})
+	}
+}
+
+// rateLimitKey identifies whose bucket a request uses
+func rateLimitKey(r *http.Request) string {
+	if userID := httputil.GetUserID(r); userID != "" {
+		return "user:" + userID
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "addr:" + host
+}
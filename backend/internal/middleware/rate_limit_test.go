@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"meridian/internal/httputil"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(RateLimit{PerMinute: 6, Burst: 2}) // One token per 10s
+	limiter.now = func() time.Time { return now }
+
+	steps := []struct {
+		advance  time.Duration
+		key      string
+		want     bool
+		wantWait time.Duration
+	}{
+		{0, "user-1", true, 0},
+		{0, "user-1", true, 0},
+		{0, "user-1", false, 10 * time.Second}, // Burst used up
+		{0, "user-2", true, 0},                 // Buckets are per key
+		{4 * time.Second, "user-1", false, 6 * time.Second},
+		{6 * time.Second, "user-1", true, 0}, // Refilled one token
+		{0, "user-1", false, 10 * time.Second},
+		{time.Hour, "user-1", true, 0}, // Refill is capped at the burst
+		{0, "user-1", true, 0},
+		{0, "user-1", false, 10 * time.Second},
+	}
+	for i, step := range steps {
+		now = now.Add(step.advance)
+		got, wait := limiter.Allow(step.key)
+		if got != step.want || wait.Round(time.Millisecond) != step.wantWait {
+			t.Errorf("step %d: Allow(%s) = %v, %s; want %v, %s", i, step.key, got, wait, step.want, step.wantWait)
+		}
+	}
+}
+
+func TestRateLimiter_SweepsFullBuckets(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(RateLimit{PerMinute: 60})
+	limiter.now = func() time.Time { return now }
+
+	limiter.Allow("idle")
+	now = now.Add(2 * time.Minute)
+	limiter.Allow("active")
+
+	if _, ok := limiter.buckets["idle"]; ok {
+		t.Error("refilled bucket was not dropped")
+	}
+	if _, ok := limiter.buckets["active"]; !ok {
+		t.Error("bucket in use was dropped")
+	}
+}
+
+func TestRateLimiter_Middleware(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{PerMinute: 1, Burst: 1})
+	handler := limiter.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	request := func(userID string) *httptest.ResponseRecorder {
+		req := httputil.WithUserID(httptest.NewRequest(http.MethodPost, "/api/turns", nil), userID)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := request("user-1"); rec.Code != http.StatusNoContent {
+		t.Fatalf("first request = %d", rec.Code)
+	}
+	rec := request("user-1")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("second request = %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("Retry-After = %q, want 60", got)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["retry_after_seconds"] != float64(60) {
+		t.Errorf("body = %v", body)
+	}
+
+	if rec := request("user-2"); rec.Code != http.StatusNoContent {
+		t.Errorf("other user = %d, want their own bucket", rec.Code)
+	}
+}
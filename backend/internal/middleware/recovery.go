@@ -5,21 +5,27 @@ import (
 	"net/http"
 	"runtime/debug"
 
+	"meridian/internal/errreport"
 	"meridian/internal/httputil"
+	"meridian/internal/logging"
 )
 
-// Recovery middleware recovers from panics and returns a 500 error
+// Recovery middleware recovers from panics, reports them to the error tracker and returns a 500 error
 func Recovery(logger *slog.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			defer func() {
 				if err := recover(); err != nil {
-					logger.Error("panic recovered",
+					logging.FromContext(r.Context(), logger).Error("panic recovered",
 						"error", err,
 						"path", r.URL.Path,
 						"method", r.Method,
 						"stack", string(debug.Stack()),
 					)
+					errreport.CapturePanic(r.Context(), err,
+						"path", r.URL.Path,
+						"method", r.Method,
+					)
 
 					httputil.RespondError(w, http.StatusInternalServerError, "internal server error")
 				}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+
+	"meridian/internal/logging"
+)
+
+// RequestIDHeader carries the request ID in requests (optional) and responses
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID limits client-supplied IDs to short, log-safe tokens
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID assigns every request an ID for log correlation.
+// A valid X-Request-ID from the client (or a proxy) is reused, otherwise a UUID is generated.
+// The ID is echoed in the response header and attached to the context for logging.FromContext.
+func RequestID() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(RequestIDHeader)
+			if !validRequestID.MatchString(requestID) {
+				requestID = uuid.New().String()
+			}
+
+			w.Header().Set(RequestIDHeader, requestID)
+			ctx := logging.WithRequestID(r.Context(), requestID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"meridian/internal/httputil"
+)
+
+// Timeout bounds a request with http.TimeoutHandler: the request context gets a deadline
+// (so repository calls made with it are cancelled), and if the handler hasn't finished in
+// time the client gets a 503 problem response instead.
+//
+// TimeoutHandler buffers the response and supports neither http.Flusher nor
+// http.Hijacker, so streaming routes (SSE, WebSocket, file downloads) must not use it;
+// the router exempts routes registered with Group.Stream. timeout <= 0 disables it.
+func Timeout(timeout time.Duration) func(http.Handler) http.Handler {
+	if timeout <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	body, _ := json.Marshal(httputil.ProblemDetail{
+		Type:   "https://datatracker.ietf.org/doc/html/rfc7231#section-6.6.4",
+		Title:  http.StatusText(http.StatusServiceUnavailable),
+		Status: http.StatusServiceUnavailable,
+		Detail: "request timed out after " + timeout.String(),
+	})
+
+	return func(next http.Handler) http.Handler {
+		timeoutHandler := http.TimeoutHandler(next, timeout, string(body))
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timeoutHandler.ServeHTTP(&timeoutResponseWriter{ResponseWriter: w}, r)
+		})
+	}
+}
+
+// timeoutResponseWriter labels TimeoutHandler's 503 body as a problem response.
+// Handlers' own 503s set their content type, which TimeoutHandler copies first.
+type timeoutResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w *timeoutResponseWriter) WriteHeader(status int) {
+	if status == http.StatusServiceUnavailable && w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/problem+json")
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
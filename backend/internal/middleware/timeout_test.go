@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeout(t *testing.T) {
+	var deadlineSet bool
+	handler := Timeout(20 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, deadlineSet = r.Context().Deadline()
+		if r.URL.Path == "/slow" {
+			<-r.Context().Done() // Like a repository call cancelled by the deadline
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	if rec.Code != http.StatusCreated || rec.Body.String() != `{"ok":true}` || rec.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("fast response = %d %q (%s)", rec.Code, rec.Body.String(), rec.Header().Get("Content-Type"))
+	}
+	if !deadlineSet {
+		t.Error("request context has no deadline")
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("slow response status = %d, want 503", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/problem+json" {
+		t.Errorf("timeout Content-Type = %q", got)
+	}
+	var problem map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil || problem["detail"] != "request timed out after 20ms" {
+		t.Errorf("timeout body = %s (%v)", rec.Body.String(), err)
+	}
+}
+
+func TestTimeout_Disabled(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); ok {
+			t.Error("disabled timeout set a deadline")
+		}
+	})
+	Timeout(0)(next).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
@@ -23,14 +23,61 @@ type TableNames struct {
 	Folders   string
 	Documents string
 
+	// Inline suggestions
+	DocumentSuggestions string
+
+	// Document analytics cache
+	DocumentAnalytics string
+
+	// Document collaboration (Yjs update logs) and saved revisions
+	DocumentCollabUpdates string
+	DocumentRevisions     string
+
+	// Project settings
+	ProjectSettings string
+
+	// Project snapshots
+	ContentBlobs           string
+	ProjectSnapshots       string
+	ProjectSnapshotEntries string
+
+	// Export jobs
+	ExportJobs string
+
+	// Background jobs
+	Jobs string
+
+	// Publishing
+	PublishTargets    string
+	PublishDeliveries string
+
 	// Chat system tables
 	Chats              string
 	Turns              string
 	TurnBlocks         string
 	AssistantResponses string
+	TurnCritiques      string
+	TurnPins           string
+	UsageDaily         string
+	Attachments        string
+	AbuseFlags         string
+
+	// Workflows
+	Workflows    string
+	WorkflowRuns string
+
+	// Evals
+	EvalCases   string
+	EvalRuns    string
+	EvalResults string
+
+	// Experiments
+	Experiments     string
+	TurnExperiments string
 
 	// User preferences
-	UserPreferences string
+	UserPreferences  string
+	UserProviderKeys string
 }
 
 // NewTableNames creates table names with the given prefix
@@ -40,14 +87,61 @@ func NewTableNames(prefix string) *TableNames {
 		Folders:   fmt.Sprintf("%sfolders", prefix),
 		Documents: fmt.Sprintf("%sdocuments", prefix),
 
+		// Inline suggestions
+		DocumentSuggestions: fmt.Sprintf("%sdocument_suggestions", prefix),
+
+		// Document analytics cache
+		DocumentAnalytics: fmt.Sprintf("%sdocument_analytics", prefix),
+
+		// Document collaboration (Yjs update logs) and saved revisions
+		DocumentCollabUpdates: fmt.Sprintf("%sdocument_collab_updates", prefix),
+		DocumentRevisions:     fmt.Sprintf("%sdocument_revisions", prefix),
+
+		// Project settings
+		ProjectSettings: fmt.Sprintf("%sproject_settings", prefix),
+
+		// Project snapshots
+		ContentBlobs:           fmt.Sprintf("%scontent_blobs", prefix),
+		ProjectSnapshots:       fmt.Sprintf("%sproject_snapshots", prefix),
+		ProjectSnapshotEntries: fmt.Sprintf("%sproject_snapshot_entries", prefix),
+
+		// Export jobs
+		ExportJobs: fmt.Sprintf("%sexport_jobs", prefix),
+
+		// Background jobs
+		Jobs: fmt.Sprintf("%sjobs", prefix),
+
+		// Publishing
+		PublishTargets:    fmt.Sprintf("%spublish_targets", prefix),
+		PublishDeliveries: fmt.Sprintf("%spublish_deliveries", prefix),
+
 		// Chat system tables
 		Chats:              fmt.Sprintf("%schats", prefix),
 		Turns:              fmt.Sprintf("%sturns", prefix),
 		TurnBlocks:         fmt.Sprintf("%sturn_blocks", prefix),
 		AssistantResponses: fmt.Sprintf("%sassistant_responses", prefix),
+		TurnCritiques:      fmt.Sprintf("%sturn_critiques", prefix),
+		TurnPins:           fmt.Sprintf("%sturn_pins", prefix),
+		UsageDaily:         fmt.Sprintf("%susage_daily", prefix),
+		Attachments:        fmt.Sprintf("%sattachments", prefix),
+		AbuseFlags:         fmt.Sprintf("%sabuse_flags", prefix),
+
+		// Workflows
+		Workflows:    fmt.Sprintf("%sworkflows", prefix),
+		WorkflowRuns: fmt.Sprintf("%sworkflow_runs", prefix),
+
+		// Evals
+		EvalCases:   fmt.Sprintf("%seval_cases", prefix),
+		EvalRuns:    fmt.Sprintf("%seval_runs", prefix),
+		EvalResults: fmt.Sprintf("%seval_results", prefix),
+
+		// Experiments
+		Experiments:     fmt.Sprintf("%sexperiments", prefix),
+		TurnExperiments: fmt.Sprintf("%sturn_experiments", prefix),
 
 		// User preferences
-		UserPreferences: fmt.Sprintf("%suser_preferences", prefix),
+		UserPreferences:  fmt.Sprintf("%suser_preferences", prefix),
+		UserProviderKeys: fmt.Sprintf("%suser_provider_keys", prefix),
 	}
 }
 
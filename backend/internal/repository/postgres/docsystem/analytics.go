@@ -0,0 +1,63 @@
+package docsystem
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"meridian/internal/domain"
+	docsysModels "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/repository/postgres"
+)
+
+// PostgresDocumentAnalyticsRepository implements the DocumentAnalyticsRepository interface
+type PostgresDocumentAnalyticsRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewDocumentAnalyticsRepository creates a new document analytics repository
+func NewDocumentAnalyticsRepository(config *postgres.RepositoryConfig) docsysRepo.DocumentAnalyticsRepository {
+	return &PostgresDocumentAnalyticsRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+// GetAnalytics returns a document's cached analytics
+func (r *PostgresDocumentAnalyticsRepository) GetAnalytics(ctx context.Context, documentID string) (*docsysModels.DocumentAnalytics, error) {
+	query := fmt.Sprintf(`SELECT analytics FROM %s WHERE document_id = $1`, r.tables.DocumentAnalytics)
+
+	var analytics docsysModels.DocumentAnalytics
+	executor := postgres.GetExecutor(ctx, r.pool)
+	if err := executor.QueryRow(ctx, query, documentID).Scan(&analytics); err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("analytics of document %s: %w", documentID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get document analytics: %w", err)
+	}
+	return &analytics, nil
+}
+
+// SaveAnalytics upserts a document's analytics
+func (r *PostgresDocumentAnalyticsRepository) SaveAnalytics(ctx context.Context, analytics *docsysModels.DocumentAnalytics) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (document_id, content_hash, analytics, computed_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (document_id) DO UPDATE SET
+			content_hash = EXCLUDED.content_hash,
+			analytics = EXCLUDED.analytics,
+			computed_at = EXCLUDED.computed_at
+	`, r.tables.DocumentAnalytics)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	_, err := executor.Exec(ctx, query, analytics.DocumentID, analytics.ContentHash, analytics, analytics.ComputedAt)
+	if err != nil {
+		if postgres.IsPgForeignKeyError(err) {
+			return fmt.Errorf("document %s: %w", analytics.DocumentID, domain.ErrNotFound)
+		}
+		return fmt.Errorf("save document analytics: %w", err)
+	}
+	return nil
+}
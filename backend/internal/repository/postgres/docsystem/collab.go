@@ -0,0 +1,175 @@
+package docsystem
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"meridian/internal/domain"
+	docsysModels "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/repository/postgres"
+)
+
+// PostgresDocumentCollabRepository implements the DocumentCollabRepository interface
+type PostgresDocumentCollabRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewDocumentCollabRepository creates a new document collaboration repository
+func NewDocumentCollabRepository(config *postgres.RepositoryConfig) docsysRepo.DocumentCollabRepository {
+	return &PostgresDocumentCollabRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+// AppendUpdate inserts an update into the document's log
+func (r *PostgresDocumentCollabRepository) AppendUpdate(ctx context.Context, documentID string, data []byte) (int64, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (document_id, data)
+		VALUES ($1, $2)
+		RETURNING seq
+	`, r.tables.DocumentCollabUpdates)
+
+	var seq int64
+	executor := postgres.GetExecutor(ctx, r.pool)
+	if err := executor.QueryRow(ctx, query, documentID, data).Scan(&seq); err != nil {
+		if postgres.IsPgForeignKeyError(err) {
+			return 0, fmt.Errorf("document %s: %w", documentID, domain.ErrNotFound)
+		}
+		return 0, fmt.Errorf("append collab update: %w", err)
+	}
+	return seq, nil
+}
+
+// ListUpdates returns the document's update log in seq order
+func (r *PostgresDocumentCollabRepository) ListUpdates(ctx context.Context, documentID string) ([]docsysModels.CollabUpdate, error) {
+	query := fmt.Sprintf(`
+		SELECT seq, document_id, data, created_at
+		FROM %s
+		WHERE document_id = $1
+		ORDER BY seq
+	`, r.tables.DocumentCollabUpdates)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("list collab updates: %w", err)
+	}
+	defer rows.Close()
+
+	updates := []docsysModels.CollabUpdate{}
+	for rows.Next() {
+		var u docsysModels.CollabUpdate
+		if err := rows.Scan(&u.Seq, &u.DocumentID, &u.Data, &u.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan collab update: %w", err)
+		}
+		updates = append(updates, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate collab updates: %w", err)
+	}
+	return updates, nil
+}
+
+// DeleteUpdatesThrough removes the log entries a compacted state replaces
+func (r *PostgresDocumentCollabRepository) DeleteUpdatesThrough(ctx context.Context, documentID string, throughSeq int64) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE document_id = $1 AND seq <= $2`, r.tables.DocumentCollabUpdates)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	if _, err := executor.Exec(ctx, query, documentID, throughSeq); err != nil {
+		return fmt.Errorf("delete collab updates: %w", err)
+	}
+	return nil
+}
+
+// CreateRevision inserts a revision and prunes the document's older ones
+func (r *PostgresDocumentCollabRepository) CreateRevision(ctx context.Context, revision *docsysModels.DocumentRevision, keep int) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (document_id, content, content_hash, word_count, source, update_seq)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`, r.tables.DocumentRevisions)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		revision.DocumentID,
+		revision.Content,
+		revision.ContentHash,
+		revision.WordCount,
+		revision.Source,
+		revision.UpdateSeq,
+	).Scan(&revision.ID, &revision.CreatedAt)
+	if err != nil {
+		if postgres.IsPgForeignKeyError(err) {
+			return fmt.Errorf("document %s: %w", revision.DocumentID, domain.ErrNotFound)
+		}
+		return fmt.Errorf("create document revision: %w", err)
+	}
+
+	prune := fmt.Sprintf(`
+		DELETE FROM %[1]s
+		WHERE document_id = $1 AND id NOT IN (
+			SELECT id FROM %[1]s WHERE document_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2
+		)
+	`, r.tables.DocumentRevisions)
+	if _, err := executor.Exec(ctx, prune, revision.DocumentID, keep); err != nil {
+		return fmt.Errorf("prune document revisions: %w", err)
+	}
+	return nil
+}
+
+// ListRevisions returns the document's newest revisions without content
+func (r *PostgresDocumentCollabRepository) ListRevisions(ctx context.Context, documentID string, limit int) ([]docsysModels.DocumentRevision, error) {
+	query := fmt.Sprintf(`
+		SELECT id, document_id, content_hash, word_count, source, update_seq, created_at
+		FROM %s
+		WHERE document_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`, r.tables.DocumentRevisions)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, documentID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list document revisions: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []docsysModels.DocumentRevision{}
+	for rows.Next() {
+		var rev docsysModels.DocumentRevision
+		if err := rows.Scan(&rev.ID, &rev.DocumentID, &rev.ContentHash, &rev.WordCount, &rev.Source, &rev.UpdateSeq, &rev.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan document revision: %w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate document revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// GetRevision returns one revision with its content
+func (r *PostgresDocumentCollabRepository) GetRevision(ctx context.Context, documentID, revisionID string) (*docsysModels.DocumentRevision, error) {
+	query := fmt.Sprintf(`
+		SELECT id, document_id, content, content_hash, word_count, source, update_seq, created_at
+		FROM %s
+		WHERE document_id = $1 AND id = $2
+	`, r.tables.DocumentRevisions)
+
+	var rev docsysModels.DocumentRevision
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, documentID, revisionID).Scan(
+		&rev.ID, &rev.DocumentID, &rev.Content, &rev.ContentHash, &rev.WordCount, &rev.Source, &rev.UpdateSeq, &rev.CreatedAt,
+	)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("revision %s: %w", revisionID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get document revision: %w", err)
+	}
+	return &rev, nil
+}
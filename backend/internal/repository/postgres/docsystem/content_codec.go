@@ -0,0 +1,47 @@
+package docsystem
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// compressContent gzips document content for storage in content_blobs.compressed
+func compressContent(content string) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := io.WriteString(zw, content); err != nil {
+		return nil, fmt.Errorf("compress content: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("compress content: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressContent reverses compressContent
+func decompressContent(compressed []byte) (string, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", fmt.Errorf("decompress content: %w", err)
+	}
+	defer zr.Close()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, zr); err != nil {
+		return "", fmt.Errorf("decompress content: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// blobContent returns a content_blobs row's content, whichever form it is stored in
+func blobContent(content *string, compressed []byte) (string, error) {
+	if compressed != nil {
+		return decompressContent(compressed)
+	}
+	if content == nil {
+		return "", nil
+	}
+	return *content, nil
+}
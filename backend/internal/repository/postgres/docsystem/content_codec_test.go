@@ -0,0 +1,100 @@
+package docsystem
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// manuscript builds roughly size bytes of prose-like Markdown
+func manuscript(size int) string {
+	var b strings.Builder
+	for i := 0; b.Len() < size; i++ {
+		if i%20 == 0 {
+			fmt.Fprintf(&b, "\n## Chapter %d\n\n", i/20+1)
+		}
+		fmt.Fprintf(&b, "Aria walked along the %d-th corridor, counting lanterns as the wind rose over the harbor. ", i)
+		if i%5 == 4 {
+			b.WriteString("\n\n")
+		}
+	}
+	return b.String()
+}
+
+func TestContentCodec_RoundTrip(t *testing.T) {
+	for _, content := range []string{"", "short", "ünïcödé ✓", manuscript(64 << 10)} {
+		compressed, err := compressContent(content)
+		if err != nil {
+			t.Fatalf("compress: %v", err)
+		}
+		got, err := decompressContent(compressed)
+		if err != nil {
+			t.Fatalf("decompress: %v", err)
+		}
+		if got != content {
+			t.Errorf("round trip changed content of length %d", len(content))
+		}
+	}
+}
+
+func TestContentCodec_Ratio(t *testing.T) {
+	content := manuscript(256 << 10)
+	compressed, err := compressContent(content)
+	if err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+	// Prose compresses well; anything over half the size means compression is broken
+	if len(compressed)*2 > len(content) {
+		t.Errorf("compressed %d bytes to %d", len(content), len(compressed))
+	}
+}
+
+func TestBlobContent(t *testing.T) {
+	plain := "plain"
+	if got, err := blobContent(&plain, nil); err != nil || got != plain {
+		t.Errorf("plain blob = %q, %v", got, err)
+	}
+
+	compressed, _ := compressContent("packed")
+	if got, err := blobContent(nil, compressed); err != nil || got != "packed" {
+		t.Errorf("compressed blob = %q, %v", got, err)
+	}
+
+	if _, err := blobContent(nil, []byte("not gzip")); err == nil {
+		t.Error("expected error for corrupt compressed blob")
+	}
+}
+
+func BenchmarkCompressContent(b *testing.B) {
+	for _, size := range []int{4 << 10, 64 << 10, 1 << 20} {
+		content := manuscript(size)
+		b.Run(fmt.Sprintf("%dKB", size>>10), func(b *testing.B) {
+			b.SetBytes(int64(len(content)))
+			var compressed []byte
+			for i := 0; i < b.N; i++ {
+				var err error
+				if compressed, err = compressContent(content); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(content))/float64(len(compressed)), "ratio")
+		})
+	}
+}
+
+func BenchmarkDecompressContent(b *testing.B) {
+	for _, size := range []int{4 << 10, 64 << 10, 1 << 20} {
+		compressed, err := compressContent(manuscript(size))
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("%dKB", size>>10), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			for i := 0; i < b.N; i++ {
+				if _, err := decompressContent(compressed); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -49,6 +49,7 @@ func (r *PostgresDocumentRepository) Create(ctx context.Context, doc *models.Doc
 		doc.CreatedAt,
 		doc.UpdatedAt,
 	).Scan(&doc.ID, &doc.CreatedAt, &doc.UpdatedAt)
+	doc.ContentHash = models.ContentHash(doc.Content) // Matches the set_documents_content_hash trigger
 
 	if err != nil {
 		if postgres.IsPgDuplicateError(err) {
@@ -79,14 +80,14 @@ func (r *PostgresDocumentRepository) GetByID(ctx context.Context, id, projectID
 
 	if projectID != "" {
 		query = fmt.Sprintf(`
-			SELECT id, project_id, folder_id, name, content, word_count, created_at, updated_at
+			SELECT id, project_id, folder_id, name, content, content_hash, word_count, created_at, updated_at
 			FROM %s
 			WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL
 		`, r.tables.Documents)
 		args = []interface{}{id, projectID}
 	} else {
 		query = fmt.Sprintf(`
-			SELECT id, project_id, folder_id, name, content, word_count, created_at, updated_at
+			SELECT id, project_id, folder_id, name, content, content_hash, word_count, created_at, updated_at
 			FROM %s
 			WHERE id = $1 AND deleted_at IS NULL
 		`, r.tables.Documents)
@@ -101,6 +102,7 @@ func (r *PostgresDocumentRepository) GetByID(ctx context.Context, id, projectID
 		&doc.FolderID,
 		&doc.Name,
 		&doc.Content,
+		&doc.ContentHash,
 		&doc.WordCount,
 		&doc.CreatedAt,
 		&doc.UpdatedAt,
@@ -120,7 +122,7 @@ func (r *PostgresDocumentRepository) GetByID(ctx context.Context, id, projectID
 // Use when authorization is handled separately (e.g., by ResourceAuthorizer)
 func (r *PostgresDocumentRepository) GetByIDOnly(ctx context.Context, id string) (*models.Document, error) {
 	query := fmt.Sprintf(`
-		SELECT id, project_id, folder_id, name, content, word_count, created_at, updated_at
+		SELECT id, project_id, folder_id, name, content, content_hash, word_count, created_at, updated_at
 		FROM %s
 		WHERE id = $1 AND deleted_at IS NULL
 	`, r.tables.Documents)
@@ -133,6 +135,7 @@ func (r *PostgresDocumentRepository) GetByIDOnly(ctx context.Context, id string)
 		&doc.FolderID,
 		&doc.Name,
 		&doc.Content,
+		&doc.ContentHash,
 		&doc.WordCount,
 		&doc.CreatedAt,
 		&doc.UpdatedAt,
@@ -148,6 +151,27 @@ func (r *PostgresDocumentRepository) GetByIDOnly(ctx context.Context, id string)
 	return &doc, nil
 }
 
+// GetOwnerID returns the denormalized owner of a live document in a live project
+func (r *PostgresDocumentRepository) GetOwnerID(ctx context.Context, id string) (string, error) {
+	query := fmt.Sprintf(`
+		SELECT d.user_id
+		FROM %s d
+		JOIN %s p ON p.id = d.project_id AND p.deleted_at IS NULL
+		WHERE d.id = $1 AND d.deleted_at IS NULL
+	`, r.tables.Documents, r.tables.Projects)
+
+	var ownerID string
+	executor := postgres.GetExecutor(ctx, r.pool)
+	if err := executor.QueryRow(ctx, query, id).Scan(&ownerID); err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return "", fmt.Errorf("document %s: %w", id, domain.ErrNotFound)
+		}
+		return "", fmt.Errorf("get document owner: %w", err)
+	}
+
+	return ownerID, nil
+}
+
 // GetByPath retrieves a document by its path (e.g., ".skills/cw-prose-writing/SKILL.md")
 func (r *PostgresDocumentRepository) GetByPath(ctx context.Context, path string, projectID string) (*models.Document, error) {
 	// Split path into parts
@@ -172,7 +196,7 @@ func (r *PostgresDocumentRepository) GetByPath(ctx context.Context, path string,
 
 	// Query for the document in the final folder
 	query := fmt.Sprintf(`
-		SELECT id, project_id, folder_id, name, content, word_count, created_at, updated_at
+		SELECT id, project_id, folder_id, name, content, content_hash, word_count, created_at, updated_at
 		FROM %s
 		WHERE project_id = $1 AND name = $2 AND deleted_at IS NULL
 	`, r.tables.Documents)
@@ -195,6 +219,7 @@ func (r *PostgresDocumentRepository) GetByPath(ctx context.Context, path string,
 		&doc.FolderID,
 		&doc.Name,
 		&doc.Content,
+		&doc.ContentHash,
 		&doc.WordCount,
 		&doc.CreatedAt,
 		&doc.UpdatedAt,
@@ -309,6 +334,7 @@ func (r *PostgresDocumentRepository) Update(ctx context.Context, doc *models.Doc
 	if result.RowsAffected() == 0 {
 		return fmt.Errorf("document %s: %w", doc.ID, domain.ErrNotFound)
 	}
+	doc.ContentHash = models.ContentHash(doc.Content) // Matches the set_documents_content_hash trigger
 
 	return nil
 }
@@ -347,6 +373,35 @@ func (r *PostgresDocumentRepository) Delete(ctx context.Context, id, projectID s
 	return nil
 }
 
+// DeleteByFolderTree soft-deletes all documents in a folder and its live descendant folders
+// Must run before the folders themselves are deleted (the CTE only walks live folders)
+func (r *PostgresDocumentRepository) DeleteByFolderTree(ctx context.Context, folderID, projectID string) (int64, error) {
+	query := fmt.Sprintf(`
+		WITH RECURSIVE subtree AS (
+			SELECT id
+			FROM %s
+			WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL
+			UNION ALL
+			SELECT f.id
+			FROM %s f
+			JOIN subtree s ON f.parent_id = s.id
+			WHERE f.deleted_at IS NULL
+		)
+		UPDATE %s
+		SET deleted_at = NOW()
+		WHERE project_id = $2 AND deleted_at IS NULL
+		  AND folder_id IN (SELECT id FROM subtree)
+	`, r.tables.Folders, r.tables.Folders, r.tables.Documents)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, folderID, projectID)
+	if err != nil {
+		return 0, fmt.Errorf("delete documents in folder tree: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
 // DeleteAllByProject soft-deletes all documents in a project
 func (r *PostgresDocumentRepository) DeleteAllByProject(ctx context.Context, projectID string) error {
 	query := fmt.Sprintf(`
@@ -371,7 +426,7 @@ func (r *PostgresDocumentRepository) ListByFolder(ctx context.Context, folderID
 
 	if folderID == nil {
 		query = fmt.Sprintf(`
-			SELECT id, project_id, folder_id, name, word_count, updated_at
+			SELECT id, project_id, folder_id, name, content_hash, word_count, updated_at
 			FROM %s
 			WHERE project_id = $1 AND folder_id IS NULL AND deleted_at IS NULL
 			ORDER BY name ASC
@@ -379,7 +434,7 @@ func (r *PostgresDocumentRepository) ListByFolder(ctx context.Context, folderID
 		args = append(args, projectID)
 	} else {
 		query = fmt.Sprintf(`
-			SELECT id, project_id, folder_id, name, word_count, updated_at
+			SELECT id, project_id, folder_id, name, content_hash, word_count, updated_at
 			FROM %s
 			WHERE project_id = $1 AND folder_id = $2 AND deleted_at IS NULL
 			ORDER BY name ASC
@@ -402,6 +457,7 @@ func (r *PostgresDocumentRepository) ListByFolder(ctx context.Context, folderID
 			&doc.ProjectID,
 			&doc.FolderID,
 			&doc.Name,
+			&doc.ContentHash,
 			&doc.WordCount,
 			&doc.UpdatedAt,
 		)
@@ -426,7 +482,7 @@ func (r *PostgresDocumentRepository) ListByFolder(ctx context.Context, folderID
 // GetAllMetadataByProject retrieves all document metadata in a project (no content)
 func (r *PostgresDocumentRepository) GetAllMetadataByProject(ctx context.Context, projectID string) ([]models.Document, error) {
 	query := fmt.Sprintf(`
-		SELECT id, project_id, folder_id, name, word_count, updated_at
+		SELECT id, project_id, folder_id, name, content_hash, word_count, updated_at
 		FROM %s
 		WHERE project_id = $1 AND deleted_at IS NULL
 		ORDER BY updated_at DESC
@@ -447,6 +503,7 @@ func (r *PostgresDocumentRepository) GetAllMetadataByProject(ctx context.Context
 			&doc.ProjectID,
 			&doc.FolderID,
 			&doc.Name,
+			&doc.ContentHash,
 			&doc.WordCount,
 			&doc.UpdatedAt,
 		)
@@ -507,6 +564,72 @@ func (r *PostgresDocumentRepository) GetPath(ctx context.Context, doc *models.Do
 	return folderPath + "/" + doc.Name, nil
 }
 
+// GetPaths computes display paths for many documents with one recursive CTE over their
+// distinct folders (instead of one GetPath query per document).
+// As with GetPath, a document whose folder can't be resolved gets just its name.
+func (r *PostgresDocumentRepository) GetPaths(ctx context.Context, projectID string, docs []models.Document) (map[string]string, error) {
+	var folderIDs []string
+	seen := make(map[string]bool)
+	for _, doc := range docs {
+		if doc.FolderID != nil && !seen[*doc.FolderID] {
+			seen[*doc.FolderID] = true
+			folderIDs = append(folderIDs, *doc.FolderID)
+		}
+	}
+
+	folderPaths := make(map[string]string, len(folderIDs))
+	if len(folderIDs) > 0 {
+		query := fmt.Sprintf(`
+			WITH RECURSIVE folder_path AS (
+				-- Base case: every requested folder, remembering where the walk started
+				SELECT id AS origin_id, parent_id, name::text AS path
+				FROM %s
+				WHERE id = ANY($1) AND project_id = $2 AND deleted_at IS NULL
+				UNION ALL
+				-- Recursive case: walk up the tree, prepending parent names
+				SELECT fp.origin_id, f.parent_id, f.name || '/' || fp.path
+				FROM %s f
+				JOIN folder_path fp ON f.id = fp.parent_id
+				WHERE f.deleted_at IS NULL
+			)
+			SELECT origin_id, path FROM folder_path WHERE parent_id IS NULL
+		`, r.tables.Folders, r.tables.Folders)
+
+		executor := postgres.GetExecutor(ctx, r.pool)
+		rows, err := executor.Query(ctx, query, folderIDs, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("get folder paths: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var folderID, path string
+			if err := rows.Scan(&folderID, &path); err != nil {
+				return nil, fmt.Errorf("scan folder path: %w", err)
+			}
+			folderPaths[folderID] = path
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("iterate folder paths: %w", err)
+		}
+	}
+
+	paths := make(map[string]string, len(docs))
+	for _, doc := range docs {
+		folderPath := ""
+		if doc.FolderID != nil {
+			folderPath = folderPaths[*doc.FolderID]
+		}
+		if folderPath == "" {
+			paths[doc.ID] = doc.Name
+		} else {
+			paths[doc.ID] = folderPath + "/" + doc.Name
+		}
+	}
+
+	return paths, nil
+}
+
 // getExistingDocumentID queries for an existing document by unique constraint fields
 // Returns the document ID if found, error otherwise
 func (r *PostgresDocumentRepository) getExistingDocumentID(ctx context.Context, projectID string, folderID *string, name string) (string, error) {
@@ -581,6 +704,7 @@ func (r *PostgresDocumentRepository) fullTextSearch(ctx context.Context, opts *m
 	// - content matches: 1.0x multiplier (normal weight)
 
 	// Build field-specific search conditions and rank expressions
+	language, args := ftsLanguage(opts)
 	var searchConditions []string
 	var rankExpressions []string
 
@@ -588,19 +712,17 @@ func (r *PostgresDocumentRepository) fullTextSearch(ctx context.Context, opts *m
 		switch field {
 		case models.SearchFieldName:
 			// Search in name/title field
-			searchConditions = append(searchConditions,
-				"to_tsvector($1, name) @@ websearch_to_tsquery($1, $2)")
+			searchConditions = append(searchConditions, ftsCondition(language, "name"))
 			// Weight title matches 2x higher
 			rankExpressions = append(rankExpressions,
-				"ts_rank(to_tsvector($1, name), websearch_to_tsquery($1, $2)) * 2.0")
+				fmt.Sprintf("ts_rank(to_tsvector(%[1]s, name), websearch_to_tsquery(%[1]s, $1)) * 2.0", language))
 
 		case models.SearchFieldContent:
 			// Search in content field
-			searchConditions = append(searchConditions,
-				"to_tsvector($1, content) @@ websearch_to_tsquery($1, $2)")
+			searchConditions = append(searchConditions, ftsCondition(language, "content"))
 			// Normal weight
 			rankExpressions = append(rankExpressions,
-				"ts_rank(to_tsvector($1, content), websearch_to_tsquery($1, $2))")
+				fmt.Sprintf("ts_rank(to_tsvector(%[1]s, content), websearch_to_tsquery(%[1]s, $1))", language))
 		}
 	}
 
@@ -612,17 +734,16 @@ func (r *PostgresDocumentRepository) fullTextSearch(ctx context.Context, opts *m
 
 	baseQuery := fmt.Sprintf(`
 		SELECT id, project_id, folder_id, name,
-		       ts_headline($1, content, websearch_to_tsquery($1, $2),
+		       ts_headline(%[1]s, content, websearch_to_tsquery(%[1]s, $1),
 		                   'MaxWords=50, MinWords=20, MaxFragments=1') AS content,
 		       word_count, created_at, updated_at,
-		       (%s) AS rank_score
-		FROM %s
+		       (%[2]s) AS rank_score
+		FROM %[3]s
 		WHERE deleted_at IS NULL
-		  AND (%s)
-	`, rankExpression, r.tables.Documents, whereClause)
+		  AND (%[4]s)
+	`, language, rankExpression, r.tables.Documents, whereClause)
 
-	args := []interface{}{opts.Language, opts.Query}
-	paramIndex := 3
+	paramIndex := len(args) + 1
 
 	// Add optional project filter
 	if opts.ProjectID != "" {
@@ -705,19 +826,34 @@ func (r *PostgresDocumentRepository) fullTextSearch(ctx context.Context, opts *m
 	return results, nil
 }
 
+// ftsLanguage returns the text search configuration expression for a search and the
+// initial query args ($1 is always the query).
+// With UseDocumentLanguage the expressions match the to_tsvector(search_language, ...)
+// indexes; an explicit language is passed as a parameter (correct, but not indexed).
+func ftsLanguage(opts *models.SearchOptions) (string, []interface{}) {
+	if opts.UseDocumentLanguage {
+		return "search_language", []interface{}{opts.Query}
+	}
+	return "$2::regconfig", []interface{}{opts.Query, opts.Language}
+}
+
+// ftsCondition builds the match condition for one field
+func ftsCondition(language, field string) string {
+	return fmt.Sprintf("to_tsvector(%[1]s, %[2]s) @@ websearch_to_tsquery(%[1]s, $1)", language, field)
+}
+
 // countTotalMatches counts total matching documents (without limit/offset)
 func (r *PostgresDocumentRepository) countTotalMatches(ctx context.Context, opts *models.SearchOptions) (int, error) {
 	// Build same search conditions as fullTextSearch
+	language, args := ftsLanguage(opts)
 	var searchConditions []string
 
 	for _, field := range opts.Fields {
 		switch field {
 		case models.SearchFieldName:
-			searchConditions = append(searchConditions,
-				"to_tsvector($1, name) @@ websearch_to_tsquery($1, $2)")
+			searchConditions = append(searchConditions, ftsCondition(language, "name"))
 		case models.SearchFieldContent:
-			searchConditions = append(searchConditions,
-				"to_tsvector($1, content) @@ websearch_to_tsquery($1, $2)")
+			searchConditions = append(searchConditions, ftsCondition(language, "content"))
 		}
 	}
 
@@ -730,8 +866,7 @@ func (r *PostgresDocumentRepository) countTotalMatches(ctx context.Context, opts
 		  AND (%s)
 	`, r.tables.Documents, whereClause)
 
-	args := []interface{}{opts.Language, opts.Query}
-	paramIndex := 3
+	paramIndex := len(args) + 1
 
 	// Add optional project filter
 	if opts.ProjectID != "" {
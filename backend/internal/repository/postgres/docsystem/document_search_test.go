@@ -297,6 +297,23 @@ func TestNewSearchResults(t *testing.T) {
 	}
 }
 
+func TestFTSLanguage(t *testing.T) {
+	// Project language: expressions must match the to_tsvector(search_language, ...) indexes
+	language, args := ftsLanguage(&docsystem.SearchOptions{Query: "dragon", Language: "french", UseDocumentLanguage: true})
+	if language != "search_language" || len(args) != 1 {
+		t.Errorf("document language = %q, args %v", language, args)
+	}
+	if got, want := ftsCondition(language, "content"), "to_tsvector(search_language, content) @@ websearch_to_tsquery(search_language, $1)"; got != want {
+		t.Errorf("condition = %q, want %q", got, want)
+	}
+
+	// Explicit override is passed as a parameter
+	language, args = ftsLanguage(&docsystem.SearchOptions{Query: "dragon", Language: "german"})
+	if language != "$2::regconfig" || len(args) != 2 || args[1] != "german" {
+		t.Errorf("override language = %q, args %v", language, args)
+	}
+}
+
 // ============================================================================
 // INTEGRATION TEST NOTES
 // ============================================================================
@@ -0,0 +1,187 @@
+package docsystem
+
+import (
+	"context"
+	"fmt"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+
+	"meridian/internal/repository/postgres"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresExportJobRepository implements the ExportJobRepository interface
+type PostgresExportJobRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewExportJobRepository creates a new export job repository
+func NewExportJobRepository(config *postgres.RepositoryConfig) docsysRepo.ExportJobRepository {
+	return &PostgresExportJobRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+// Create inserts a pending job
+func (r *PostgresExportJobRepository) Create(ctx context.Context, job *models.ExportJob) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (project_id, user_id, format, folder_ids, title, author, language)
+		VALUES ($1, $2, $3, $4::uuid[], $5, $6, $7)
+		RETURNING id, status, created_at
+	`, r.tables.ExportJobs)
+
+	folderIDs := job.FolderIDs
+	if folderIDs == nil {
+		folderIDs = []string{}
+	}
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		job.ProjectID,
+		job.UserID,
+		job.Format,
+		folderIDs,
+		job.Title,
+		job.Author,
+		job.Language,
+	).Scan(&job.ID, &job.Status, &job.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create export job: %w", err)
+	}
+
+	job.FolderIDs = folderIDs
+	return nil
+}
+
+// Get retrieves a job without its file
+func (r *PostgresExportJobRepository) Get(ctx context.Context, id string) (*models.ExportJob, error) {
+	query := fmt.Sprintf(`
+		SELECT id, project_id, user_id, format, folder_ids::text[], title, author, language,
+		       status, error, file_name, file_size, created_at, started_at, completed_at, storage_key
+		FROM %s
+		WHERE id = $1
+	`, r.tables.ExportJobs)
+
+	var job models.ExportJob
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, id).Scan(
+		&job.ID,
+		&job.ProjectID,
+		&job.UserID,
+		&job.Format,
+		&job.FolderIDs,
+		&job.Title,
+		&job.Author,
+		&job.Language,
+		&job.Status,
+		&job.Error,
+		&job.FileName,
+		&job.FileSize,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.CompletedAt,
+		&job.StorageKey,
+	)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("export job %s: %w", id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get export job: %w", err)
+	}
+
+	return &job, nil
+}
+
+// MarkRunning moves a pending job to running
+func (r *PostgresExportJobRepository) MarkRunning(ctx context.Context, id string) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = 'running', started_at = NOW()
+		WHERE id = $1 AND status = 'pending'
+	`, r.tables.ExportJobs)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("mark export job running: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: export job %s is not pending", domain.ErrConflict, id)
+	}
+	return nil
+}
+
+// Complete records the stored output file and marks the job completed
+func (r *PostgresExportJobRepository) Complete(ctx context.Context, id string, file *models.ExportFile) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = 'completed', file_name = $2, content_type = $3, storage_key = $4, file_size = $5,
+		    completed_at = NOW()
+		WHERE id = $1
+	`, r.tables.ExportJobs)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	_, err := executor.Exec(ctx, query, id, file.FileName, file.ContentType, file.StorageKey, len(file.Data))
+	if err != nil {
+		return fmt.Errorf("complete export job: %w", err)
+	}
+	return nil
+}
+
+// Fail marks the job failed with a message
+func (r *PostgresExportJobRepository) Fail(ctx context.Context, id, message string) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = 'failed', error = $2, completed_at = NOW()
+		WHERE id = $1
+	`, r.tables.ExportJobs)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	if _, err := executor.Exec(ctx, query, id, message); err != nil {
+		return fmt.Errorf("fail export job: %w", err)
+	}
+	return nil
+}
+
+// FailUnfinished fails every pending or running job
+func (r *PostgresExportJobRepository) FailUnfinished(ctx context.Context, message string) (int64, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = 'failed', error = $1, completed_at = NOW()
+		WHERE status IN ('pending', 'running')
+	`, r.tables.ExportJobs)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, message)
+	if err != nil {
+		return 0, fmt.Errorf("fail unfinished export jobs: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// GetFile returns a completed job's file metadata, with Data only for legacy jobs
+// (whose bytes are on the row); otherwise the file is in object storage under StorageKey
+func (r *PostgresExportJobRepository) GetFile(ctx context.Context, id string) (*models.ExportFile, error) {
+	query := fmt.Sprintf(`
+		SELECT file_name, content_type, COALESCE(storage_key, ''), file
+		FROM %s
+		WHERE id = $1 AND status = 'completed' AND (storage_key IS NOT NULL OR file IS NOT NULL)
+	`, r.tables.ExportJobs)
+
+	var file models.ExportFile
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, id).Scan(&file.FileName, &file.ContentType, &file.StorageKey, &file.Data)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("export file %s: %w", id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get export file: %w", err)
+	}
+
+	return &file, nil
+}
@@ -205,6 +205,39 @@ func (r *PostgresFolderRepository) Delete(ctx context.Context, id, projectID str
 	return nil
 }
 
+// DeleteTree soft-deletes a folder and all live descendant folders using a recursive CTE
+func (r *PostgresFolderRepository) DeleteTree(ctx context.Context, id, projectID string) (int64, error) {
+	query := fmt.Sprintf(`
+		WITH RECURSIVE subtree AS (
+			-- Base case: the folder itself
+			SELECT id
+			FROM %s
+			WHERE id = $1 AND project_id = $2 AND deleted_at IS NULL
+			UNION ALL
+			-- Recursive case: walk down the tree
+			SELECT f.id
+			FROM %s f
+			JOIN subtree s ON f.parent_id = s.id
+			WHERE f.deleted_at IS NULL
+		)
+		UPDATE %s
+		SET deleted_at = NOW()
+		WHERE id IN (SELECT id FROM subtree)
+	`, r.tables.Folders, r.tables.Folders, r.tables.Folders)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, id, projectID)
+	if err != nil {
+		return 0, fmt.Errorf("delete folder tree: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return 0, fmt.Errorf("folder %s: %w", id, domain.ErrNotFound)
+	}
+
+	return result.RowsAffected(), nil
+}
+
 // ListChildren lists immediate child folders
 func (r *PostgresFolderRepository) ListChildren(ctx context.Context, folderID *string, projectID string) ([]models.Folder, error) {
 	var query string
@@ -322,6 +355,96 @@ func (r *PostgresFolderRepository) GetPath(ctx context.Context, folderID *string
 	return path, nil
 }
 
+// GetTree retrieves the whole project tree in one round trip using a recursive CTE.
+// Folders carry subtree aggregates computed from each entry's lineage (root → self),
+// so aggregates stay complete when maxDepth cuts the tree short.
+// Rows come back folders first (oldest first), then documents (most recently updated first).
+func (r *PostgresFolderRepository) GetTree(ctx context.Context, projectID string, maxDepth int) ([]models.TreeEntry, error) {
+	query := fmt.Sprintf(`
+		WITH RECURSIVE folder_tree AS (
+			-- Base case: root-level folders
+			SELECT id, parent_id, name, created_at, 1 AS depth, ARRAY[id] AS lineage
+			FROM %s
+			WHERE project_id = $1 AND parent_id IS NULL AND deleted_at IS NULL
+			UNION ALL
+			-- Recursive case: walk down the tree, extending the lineage
+			SELECT f.id, f.parent_id, f.name, f.created_at, ft.depth + 1, ft.lineage || f.id
+			FROM %s f
+			JOIN folder_tree ft ON f.parent_id = ft.id
+			WHERE f.deleted_at IS NULL
+		),
+		docs AS (
+			-- Documents in live folders (or at root); lineage is their folder's
+			SELECT d.id, d.folder_id, d.name, d.word_count, d.updated_at,
+			       COALESCE(ft.depth, 0) + 1 AS depth, ft.lineage
+			FROM %s d
+			LEFT JOIN folder_tree ft ON ft.id = d.folder_id
+			WHERE d.project_id = $1 AND d.deleted_at IS NULL
+			  AND (d.folder_id IS NULL OR ft.id IS NOT NULL)
+		),
+		doc_totals AS (
+			SELECT a.folder_id, COUNT(*) AS document_count, SUM(docs.word_count) AS word_count
+			FROM docs CROSS JOIN LATERAL unnest(docs.lineage) AS a(folder_id)
+			GROUP BY a.folder_id
+		),
+		folder_totals AS (
+			-- Every folder appears in its own lineage, hence the -1
+			SELECT a.folder_id, COUNT(*) - 1 AS folder_count
+			FROM folder_tree CROSS JOIN LATERAL unnest(folder_tree.lineage) AS a(folder_id)
+			GROUP BY a.folder_id
+		)
+		SELECT kind, id, parent_id, name, depth, ts, word_count, folder_count, document_count
+		FROM (
+			SELECT 'folder' AS kind, ft.id, ft.parent_id, ft.name, ft.depth, ft.created_at AS ts,
+			       COALESCE(dt.word_count, 0) AS word_count, fc.folder_count,
+			       COALESCE(dt.document_count, 0) AS document_count
+			FROM folder_tree ft
+			JOIN folder_totals fc ON fc.folder_id = ft.id
+			LEFT JOIN doc_totals dt ON dt.folder_id = ft.id
+			UNION ALL
+			SELECT 'document', id, folder_id, name, depth, updated_at, word_count, 0, 0
+			FROM docs
+		) entries
+		WHERE $2 <= 0 OR depth <= $2
+		ORDER BY kind DESC,
+		         CASE WHEN kind = 'folder' THEN ts END ASC,
+		         ts DESC
+	`, r.tables.Folders, r.tables.Folders, r.tables.Documents)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, projectID, maxDepth)
+	if err != nil {
+		return nil, fmt.Errorf("get project tree: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.TreeEntry
+	for rows.Next() {
+		var entry models.TreeEntry
+		err := rows.Scan(
+			&entry.Kind,
+			&entry.ID,
+			&entry.ParentID,
+			&entry.Name,
+			&entry.Depth,
+			&entry.Timestamp,
+			&entry.WordCount,
+			&entry.FolderCount,
+			&entry.DocumentCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan tree entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tree entries: %w", err)
+	}
+
+	return entries, nil
+}
+
 // GetAllByProject retrieves all folders in a project (flat list)
 func (r *PostgresFolderRepository) GetAllByProject(ctx context.Context, projectID string) ([]models.Folder, error) {
 	query := fmt.Sprintf(`
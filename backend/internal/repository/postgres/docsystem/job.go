@@ -0,0 +1,221 @@
+package docsystem
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+
+	"meridian/internal/repository/postgres"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// jobColumns are the columns scanned by scanJob
+const jobColumns = `id, project_id, user_id, kind, params, status, files_processed, errors, result, error,
+		       created_at, started_at, completed_at`
+
+// PostgresJobRepository implements the JobRepository interface
+type PostgresJobRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewJobRepository creates a new job repository
+func NewJobRepository(config *postgres.RepositoryConfig) docsysRepo.JobRepository {
+	return &PostgresJobRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+// Create inserts a pending job
+func (r *PostgresJobRepository) Create(ctx context.Context, job *models.Job) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (project_id, user_id, kind, params)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, status, created_at
+	`, r.tables.Jobs)
+
+	params := job.Params
+	if params == nil {
+		params = json.RawMessage(`{}`)
+	}
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		job.ProjectID,
+		job.UserID,
+		job.Kind,
+		[]byte(params),
+	).Scan(&job.ID, &job.Status, &job.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create job: %w", err)
+	}
+
+	job.Params = params
+	job.Errors = []models.JobError{}
+	return nil
+}
+
+// Get retrieves a job
+func (r *PostgresJobRepository) Get(ctx context.Context, id string) (*models.Job, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE id = $1
+	`, jobColumns, r.tables.Jobs)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	job, err := scanJob(executor.QueryRow(ctx, query, id))
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("job %s: %w", id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get job: %w", err)
+	}
+	return job, nil
+}
+
+// ClaimNext moves the oldest pending job to running and returns it
+func (r *PostgresJobRepository) ClaimNext(ctx context.Context) (*models.Job, error) {
+	query := fmt.Sprintf(`
+		UPDATE %[1]s
+		SET status = 'running', started_at = NOW()
+		WHERE id = (
+			SELECT id FROM %[1]s
+			WHERE status = 'pending'
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %[2]s
+	`, r.tables.Jobs, jobColumns)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	job, err := scanJob(executor.QueryRow(ctx, query))
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("pending job: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("claim job: %w", err)
+	}
+	return job, nil
+}
+
+// UpdateProgress records a running job's progress
+func (r *PostgresJobRepository) UpdateProgress(ctx context.Context, id string, filesProcessed int, errors []models.JobError) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET files_processed = $2, errors = $3
+		WHERE id = $1 AND status = 'running'
+	`, r.tables.Jobs)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	if _, err := executor.Exec(ctx, query, id, filesProcessed, jobErrors(errors)); err != nil {
+		return fmt.Errorf("update job progress: %w", err)
+	}
+	return nil
+}
+
+// Complete records the final progress and result and marks the job completed
+func (r *PostgresJobRepository) Complete(ctx context.Context, id string, filesProcessed int, errors []models.JobError, result json.RawMessage) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = 'completed', files_processed = $2, errors = $3, result = $4, completed_at = NOW()
+		WHERE id = $1
+	`, r.tables.Jobs)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	if _, err := executor.Exec(ctx, query, id, filesProcessed, jobErrors(errors), []byte(result)); err != nil {
+		return fmt.Errorf("complete job: %w", err)
+	}
+	return nil
+}
+
+// Release returns a running job to the queue with its progress reset
+func (r *PostgresJobRepository) Release(ctx context.Context, id string) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = 'pending', started_at = NULL, files_processed = 0, errors = '[]'
+		WHERE id = $1 AND status = 'running'
+	`, r.tables.Jobs)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	if _, err := executor.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("release job: %w", err)
+	}
+	return nil
+}
+
+// Fail marks the job failed with a message
+func (r *PostgresJobRepository) Fail(ctx context.Context, id, message string) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = 'failed', error = $2, completed_at = NOW()
+		WHERE id = $1
+	`, r.tables.Jobs)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	if _, err := executor.Exec(ctx, query, id, message); err != nil {
+		return fmt.Errorf("fail job: %w", err)
+	}
+	return nil
+}
+
+// FailRunning fails every running job
+func (r *PostgresJobRepository) FailRunning(ctx context.Context, message string) (int64, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET status = 'failed', error = $1, completed_at = NOW()
+		WHERE status = 'running'
+	`, r.tables.Jobs)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, message)
+	if err != nil {
+		return 0, fmt.Errorf("fail running jobs: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// scanJob scans a row of jobColumns
+func scanJob(row pgx.Row) (*models.Job, error) {
+	var (
+		job            models.Job
+		params, result []byte
+	)
+	err := row.Scan(
+		&job.ID,
+		&job.ProjectID,
+		&job.UserID,
+		&job.Kind,
+		&params,
+		&job.Status,
+		&job.FilesProcessed,
+		&job.Errors, // pgx handles JSONB -> slice
+		&result,
+		&job.Error,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	job.Params = params
+	job.Result = result
+	return &job, nil
+}
+
+// jobErrors keeps an empty error list a JSON array rather than NULL
+func jobErrors(errors []models.JobError) []models.JobError {
+	if errors == nil {
+		return []models.JobError{}
+	}
+	return errors
+}
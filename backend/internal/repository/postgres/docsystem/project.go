@@ -68,7 +68,7 @@ func (r *PostgresProjectRepository) Create(ctx context.Context, project *models.
 // GetByID retrieves a project by ID
 func (r *PostgresProjectRepository) GetByID(ctx context.Context, id, userID string) (*models.Project, error) {
 	query := fmt.Sprintf(`
-		SELECT id, user_id, name, created_at, updated_at
+		SELECT id, user_id, name, system_prompt, created_at, updated_at
 		FROM %s
 		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
 	`, r.tables.Projects)
@@ -79,6 +79,7 @@ func (r *PostgresProjectRepository) GetByID(ctx context.Context, id, userID stri
 		&project.ID,
 		&project.UserID,
 		&project.Name,
+		&project.SystemPrompt,
 		&project.CreatedAt,
 		&project.UpdatedAt,
 	)
@@ -94,13 +95,61 @@ func (r *PostgresProjectRepository) GetByID(ctx context.Context, id, userID stri
 }
 
 // List retrieves all projects for a user, ordered by updated_at DESC
-func (r *PostgresProjectRepository) List(ctx context.Context, userID string) ([]models.Project, error) {
+// Summaries selected in opts are aggregated in the same query (LATERAL subqueries per project)
+func (r *PostgresProjectRepository) List(ctx context.Context, userID string, opts *models.ProjectListOptions) ([]models.Project, error) {
+	if opts == nil {
+		opts = &models.ProjectListOptions{}
+	}
+
+	// Summary columns are NULL unless requested, so the scan is the same for every option set
+	documentCountColumn := "NULL::bigint"
+	wordCountColumn := "NULL::bigint"
+	lastActivityColumn := "NULL::timestamptz"
+	activeStreamsColumn := "NULL::bigint"
+	var joins string
+
+	if opts.IncludeDocumentCount || opts.IncludeWordCount || opts.IncludeLastActivity {
+		joins += fmt.Sprintf(`
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS document_count,
+			       COALESCE(SUM(d.word_count), 0) AS word_count,
+			       MAX(d.updated_at) AS last_updated
+			FROM %s d
+			WHERE d.project_id = p.id AND d.deleted_at IS NULL
+		) ds ON true`, r.tables.Documents)
+	}
+	if opts.IncludeLastActivity || opts.IncludeActiveStreams {
+		joins += fmt.Sprintf(`
+		LEFT JOIN LATERAL (
+			SELECT MAX(GREATEST(c.updated_at, t.created_at, t.completed_at)) AS last_activity,
+			       COUNT(t.id) FILTER (WHERE t.status IN ('pending', 'streaming')) AS active_streams
+			FROM %s c
+			LEFT JOIN %s t ON t.chat_id = c.id
+			WHERE c.project_id = p.id AND c.deleted_at IS NULL
+		) cs ON true`, r.tables.Chats, r.tables.Turns)
+	}
+
+	if opts.IncludeDocumentCount {
+		documentCountColumn = "ds.document_count"
+	}
+	if opts.IncludeWordCount {
+		wordCountColumn = "ds.word_count"
+	}
+	if opts.IncludeLastActivity {
+		// GREATEST ignores NULLs (projects without documents or chats)
+		lastActivityColumn = "GREATEST(p.updated_at, ds.last_updated, cs.last_activity)"
+	}
+	if opts.IncludeActiveStreams {
+		activeStreamsColumn = "cs.active_streams"
+	}
+
 	query := fmt.Sprintf(`
-		SELECT id, user_id, name, created_at, updated_at
-		FROM %s
-		WHERE user_id = $1 AND deleted_at IS NULL
-		ORDER BY updated_at DESC
-	`, r.tables.Projects)
+		SELECT p.id, p.user_id, p.name, p.system_prompt, p.created_at, p.updated_at,
+		       %s, %s, %s, %s
+		FROM %s p%s
+		WHERE p.user_id = $1 AND p.deleted_at IS NULL
+		ORDER BY p.updated_at DESC
+	`, documentCountColumn, wordCountColumn, lastActivityColumn, activeStreamsColumn, r.tables.Projects, joins)
 
 	executor := postgres.GetExecutor(ctx, r.pool)
 	rows, err := executor.Query(ctx, query, userID)
@@ -116,8 +165,13 @@ func (r *PostgresProjectRepository) List(ctx context.Context, userID string) ([]
 			&project.ID,
 			&project.UserID,
 			&project.Name,
+			&project.SystemPrompt,
 			&project.CreatedAt,
 			&project.UpdatedAt,
+			&project.DocumentCount,
+			&project.WordCount,
+			&project.LastActivityAt,
+			&project.ActiveStreams,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan project: %w", err)
@@ -185,7 +239,7 @@ func (r *PostgresProjectRepository) Delete(ctx context.Context, id, userID strin
 		UPDATE %s
 		SET deleted_at = NOW()
 		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
-		RETURNING id, user_id, name, created_at, updated_at, deleted_at
+		RETURNING id, user_id, name, system_prompt, created_at, updated_at, deleted_at
 	`, r.tables.Projects)
 
 	var project models.Project
@@ -194,6 +248,7 @@ func (r *PostgresProjectRepository) Delete(ctx context.Context, id, userID strin
 		&project.ID,
 		&project.UserID,
 		&project.Name,
+		&project.SystemPrompt,
 		&project.CreatedAt,
 		&project.UpdatedAt,
 		&project.DeletedAt,
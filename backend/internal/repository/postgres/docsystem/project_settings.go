@@ -0,0 +1,237 @@
+package docsystem
+
+import (
+	"context"
+	"fmt"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+
+	"meridian/internal/repository/postgres"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresProjectSettingsRepository implements the ProjectSettingsRepository interface
+type PostgresProjectSettingsRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewProjectSettingsRepository creates a new project settings repository
+func NewProjectSettingsRepository(config *postgres.RepositoryConfig) docsysRepo.ProjectSettingsRepository {
+	return &PostgresProjectSettingsRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+// Get retrieves a project's settings, falling back to defaults for projects without a settings row
+func (r *PostgresProjectSettingsRepository) Get(ctx context.Context, projectID, userID string) (*models.ProjectSettings, error) {
+	query := fmt.Sprintf(`
+		SELECT p.id, p.system_prompt, s.default_model,
+		       COALESCE(s.tool_policy, '{}'), COALESCE(s.guardrails, '{}'), COALESCE(s.critique, '{}'),
+		       s.pin_folder, COALESCE(s.search_language, $3), s.style_profile, COALESCE(s.updated_at, p.updated_at)
+		FROM %s p
+		LEFT JOIN %s s ON s.project_id = p.id
+		WHERE p.id = $1 AND p.user_id = $2 AND p.deleted_at IS NULL
+	`, r.tables.Projects, r.tables.ProjectSettings)
+
+	settings := models.DefaultProjectSettings(projectID)
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, projectID, userID, models.DefaultSearchLanguage).Scan(
+		&settings.ProjectID,
+		&settings.SystemPrompt,
+		&settings.DefaultModel,
+		&settings.ToolPolicy,
+		&settings.Guardrails,
+		&settings.Critique,
+		&settings.PinFolder,
+		&settings.SearchLanguage,
+		&settings.StyleProfile,
+		&settings.UpdatedAt,
+	)
+
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("project %s: %w", projectID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get project settings: %w", err)
+	}
+
+	normalizeToolPolicy(&settings.ToolPolicy)
+	return settings, nil
+}
+
+// Upsert writes the system prompt to the project row and the remaining settings to
+// project_settings in a single statement (the ownership check gates all writes).
+// Documents and chat turn blocks carry their project's search language for the FTS
+// indexes, so they are re-tagged in the same statement when it changes.
+func (r *PostgresProjectSettingsRepository) Upsert(ctx context.Context, userID string, settings *models.ProjectSettings) error {
+	normalizeToolPolicy(&settings.ToolPolicy)
+
+	query := fmt.Sprintf(`
+		WITH project AS (
+			UPDATE %s
+			SET system_prompt = $3
+			WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+			RETURNING id
+		),
+		documents AS (
+			UPDATE %s
+			SET search_language = $7::regconfig
+			WHERE project_id IN (SELECT id FROM project) AND search_language <> $7::regconfig
+		),
+		turn_blocks AS (
+			UPDATE %s b
+			SET search_language = $7::regconfig
+			FROM %s t, %s c
+			WHERE t.id = b.turn_id AND c.id = t.chat_id
+			  AND c.project_id IN (SELECT id FROM project) AND b.search_language <> $7::regconfig
+		)
+		INSERT INTO %s (project_id, default_model, tool_policy, guardrails, critique, pin_folder, search_language, updated_at)
+		SELECT id, $4, $5, $6, $8, $9, $7, NOW() FROM project
+		ON CONFLICT (project_id) DO UPDATE SET
+			default_model = EXCLUDED.default_model,
+			tool_policy = EXCLUDED.tool_policy,
+			guardrails = EXCLUDED.guardrails,
+			critique = EXCLUDED.critique,
+			pin_folder = EXCLUDED.pin_folder,
+			search_language = EXCLUDED.search_language,
+			updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`, r.tables.Projects, r.tables.Documents, r.tables.TurnBlocks, r.tables.Turns, r.tables.Chats, r.tables.ProjectSettings)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		settings.ProjectID,
+		userID,
+		settings.SystemPrompt,
+		settings.DefaultModel,
+		settings.ToolPolicy,
+		settings.Guardrails,
+		settings.SearchLanguage,
+		settings.Critique,
+		settings.PinFolder,
+	).Scan(&settings.UpdatedAt)
+
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return fmt.Errorf("project %s: %w", settings.ProjectID, domain.ErrNotFound)
+		}
+		return fmt.Errorf("upsert project settings: %w", err)
+	}
+
+	return nil
+}
+
+// SetStyleProfile writes only the style_profile column, so a running analysis and a
+// settings PATCH can't overwrite each other. Projects without a settings row get one
+// with the defaults.
+func (r *PostgresProjectSettingsRepository) SetStyleProfile(ctx context.Context, projectID string, profile *models.StyleProfile) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (project_id, style_profile)
+		SELECT id, $2 FROM %s WHERE id = $1 AND deleted_at IS NULL
+		ON CONFLICT (project_id) DO UPDATE SET style_profile = EXCLUDED.style_profile
+	`, r.tables.ProjectSettings, r.tables.Projects)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, projectID, profile)
+	if err != nil {
+		return fmt.Errorf("set style profile: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("project %s: %w", projectID, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// StartStyleProfile upserts an analyzing profile, skipping the update while the stored
+// profile is analyzing. An unchanged row means a running analysis unless the project is gone.
+func (r *PostgresProjectSettingsRepository) StartStyleProfile(ctx context.Context, projectID string, profile *models.StyleProfile) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s AS s (project_id, style_profile)
+		SELECT id, $2 FROM %s WHERE id = $1 AND deleted_at IS NULL
+		ON CONFLICT (project_id) DO UPDATE SET style_profile = EXCLUDED.style_profile
+		WHERE s.style_profile IS NULL OR s.style_profile->>'status' <> $3
+	`, r.tables.ProjectSettings, r.tables.Projects)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, projectID, profile, models.StyleProfileStatusAnalyzing)
+	if err != nil {
+		return fmt.Errorf("start style profile: %w", err)
+	}
+	if result.RowsAffected() > 0 {
+		return nil
+	}
+
+	var exists bool
+	err = executor.QueryRow(ctx,
+		fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE id = $1 AND deleted_at IS NULL)`, r.tables.Projects),
+		projectID,
+	).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("check project: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("project %s: %w", projectID, domain.ErrNotFound)
+	}
+	return fmt.Errorf("%w: a style analysis is already running for this project", domain.ErrConflict)
+}
+
+// FinishStyleProfile replaces the profile only while it is still the given analysis
+func (r *PostgresProjectSettingsRepository) FinishStyleProfile(ctx context.Context, projectID, analysisID string, profile *models.StyleProfile) (bool, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET style_profile = $3
+		WHERE project_id = $1 AND style_profile->>'id' = $2 AND style_profile->>'status' = $4
+	`, r.tables.ProjectSettings)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, projectID, analysisID, profile, models.StyleProfileStatusAnalyzing)
+	if err != nil {
+		return false, fmt.Errorf("finish style profile: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// FailAnalyzingStyleProfiles fails profiles whose analysis never finished
+func (r *PostgresProjectSettingsRepository) FailAnalyzingStyleProfiles(ctx context.Context, message string) (int64, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET style_profile = style_profile || jsonb_build_object('status', $1::text, 'error', $2::text)
+		WHERE style_profile->>'status' = $3
+	`, r.tables.ProjectSettings)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, models.StyleProfileStatusFailed, message, models.StyleProfileStatusAnalyzing)
+	if err != nil {
+		return 0, fmt.Errorf("fail analyzing style profiles: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// SearchLanguageExists reports whether language is an installed text search configuration
+func (r *PostgresProjectSettingsRepository) SearchLanguageExists(ctx context.Context, language string) (bool, error) {
+	var exists bool
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM pg_catalog.pg_ts_config WHERE cfgname = $1)`,
+		language,
+	).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check search language: %w", err)
+	}
+	return exists, nil
+}
+
+// normalizeToolPolicy keeps tool lists non-nil so they serialize as [] rather than null
+func normalizeToolPolicy(policy *models.ProjectToolPolicy) {
+	if policy.Allowed == nil {
+		policy.Allowed = []string{}
+	}
+	if policy.Denied == nil {
+		policy.Denied = []string{}
+	}
+}
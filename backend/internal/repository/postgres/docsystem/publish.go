@@ -0,0 +1,313 @@
+package docsystem
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+
+	"meridian/internal/repository/postgres"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresPublishRepository implements the PublishRepository interface
+type PostgresPublishRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewPublishRepository creates a new publish repository
+func NewPublishRepository(config *postgres.RepositoryConfig) docsysRepo.PublishRepository {
+	return &PostgresPublishRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+const publishTargetColumns = `id, project_id, name, kind, config, document_ids::text[], folder_ids::text[],
+	on_change, last_fingerprint, last_status, last_attempt_at, last_published_at, created_at, updated_at`
+
+// CreateTarget inserts a target
+func (r *PostgresPublishRepository) CreateTarget(ctx context.Context, target *models.PublishTarget) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (project_id, name, kind, config, document_ids, folder_ids, on_change)
+		VALUES ($1, $2, $3, $4, $5::uuid[], $6::uuid[], $7)
+		RETURNING id, created_at, updated_at
+	`, r.tables.PublishTargets)
+
+	normalizePublishTarget(target)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		target.ProjectID,
+		target.Name,
+		target.Kind,
+		target.Config,
+		target.DocumentIDs,
+		target.FolderIDs,
+		target.OnChange,
+	).Scan(&target.ID, &target.CreatedAt, &target.UpdatedAt)
+	if err != nil {
+		if postgres.IsPgDuplicateError(err) {
+			return fmt.Errorf("%w: publish target '%s' already exists", domain.ErrConflict, target.Name)
+		}
+		return fmt.Errorf("create publish target: %w", err)
+	}
+
+	return nil
+}
+
+// GetTarget retrieves a target scoped to its project
+func (r *PostgresPublishRepository) GetTarget(ctx context.Context, targetID, projectID string) (*models.PublishTarget, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE id = $1 AND project_id = $2
+	`, publishTargetColumns, r.tables.PublishTargets)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	target, err := scanPublishTarget(executor.QueryRow(ctx, query, targetID, projectID))
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("publish target %s: %w", targetID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get publish target: %w", err)
+	}
+
+	return target, nil
+}
+
+// ListTargets returns a project's targets ordered by name
+func (r *PostgresPublishRepository) ListTargets(ctx context.Context, projectID string) ([]models.PublishTarget, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE project_id = $1
+		ORDER BY name
+	`, publishTargetColumns, r.tables.PublishTargets)
+
+	return r.queryTargets(ctx, query, projectID)
+}
+
+// ListOnChangeTargets returns every target that publishes on document changes
+// (skipping projects that were deleted)
+func (r *PostgresPublishRepository) ListOnChangeTargets(ctx context.Context) ([]models.PublishTarget, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s t
+		WHERE t.on_change
+		  AND EXISTS (SELECT 1 FROM %s p WHERE p.id = t.project_id AND p.deleted_at IS NULL)
+		ORDER BY t.project_id
+	`, publishTargetColumns, r.tables.PublishTargets, r.tables.Projects)
+
+	return r.queryTargets(ctx, query)
+}
+
+func (r *PostgresPublishRepository) queryTargets(ctx context.Context, query string, args ...any) ([]models.PublishTarget, error) {
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list publish targets: %w", err)
+	}
+	defer rows.Close()
+
+	targets := []models.PublishTarget{}
+	for rows.Next() {
+		target, err := scanPublishTarget(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan publish target: %w", err)
+		}
+		targets = append(targets, *target)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate publish targets: %w", err)
+	}
+
+	return targets, nil
+}
+
+// UpdateTarget saves a target's settings
+func (r *PostgresPublishRepository) UpdateTarget(ctx context.Context, target *models.PublishTarget) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET name = $3, config = $4, document_ids = $5::uuid[], folder_ids = $6::uuid[],
+		    on_change = $7, updated_at = NOW()
+		WHERE id = $1 AND project_id = $2
+		RETURNING updated_at
+	`, r.tables.PublishTargets)
+
+	normalizePublishTarget(target)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		target.ID,
+		target.ProjectID,
+		target.Name,
+		target.Config,
+		target.DocumentIDs,
+		target.FolderIDs,
+		target.OnChange,
+	).Scan(&target.UpdatedAt)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return fmt.Errorf("publish target %s: %w", target.ID, domain.ErrNotFound)
+		}
+		if postgres.IsPgDuplicateError(err) {
+			return fmt.Errorf("%w: publish target '%s' already exists", domain.ErrConflict, target.Name)
+		}
+		return fmt.Errorf("update publish target: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteTarget removes a target and its delivery log
+func (r *PostgresPublishRepository) DeleteTarget(ctx context.Context, targetID, projectID string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1 AND project_id = $2`, r.tables.PublishTargets)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, targetID, projectID)
+	if err != nil {
+		return fmt.Errorf("delete publish target: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("publish target %s: %w", targetID, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// RecordAttempt stores the outcome of a publish attempt and appends it to the delivery log
+// in one statement, trimming the log to the newest keepDeliveries entries
+func (r *PostgresPublishRepository) RecordAttempt(ctx context.Context, delivery *models.PublishDelivery, fingerprint string, attemptedAt time.Time, keepDeliveries int) error {
+	query := fmt.Sprintf(`
+		WITH target AS (
+			UPDATE %[1]s
+			SET last_fingerprint = $7, last_status = $3, last_attempt_at = $8,
+			    last_published_at = CASE WHEN $3 = 'succeeded' THEN $8 ELSE last_published_at END
+			WHERE id = $1
+			RETURNING id
+		),
+		delivery AS (
+			INSERT INTO %[2]s (target_id, trigger, status, document_count, detail, error, duration_ms, created_at)
+			SELECT id, $2, $3, $4, $5, $6, $9, $8 FROM target
+			RETURNING id, created_at
+		),
+		trimmed AS (
+			DELETE FROM %[2]s
+			WHERE target_id = $1 AND id IN (
+				SELECT id FROM %[2]s
+				WHERE target_id = $1
+				ORDER BY created_at DESC
+				OFFSET $10
+			)
+		)
+		SELECT id, created_at FROM delivery
+	`, r.tables.PublishTargets, r.tables.PublishDeliveries)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		delivery.TargetID,
+		delivery.Trigger,
+		delivery.Status,
+		delivery.DocumentCount,
+		delivery.Detail,
+		delivery.Error,
+		fingerprint,
+		attemptedAt,
+		delivery.DurationMs,
+		// The new delivery isn't visible to the DELETE's snapshot, so keep one fewer
+		max(keepDeliveries-1, 0),
+	).Scan(&delivery.ID, &delivery.CreatedAt)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return fmt.Errorf("publish target %s: %w", delivery.TargetID, domain.ErrNotFound)
+		}
+		return fmt.Errorf("record publish attempt: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeliveries returns a target's delivery log, newest first
+func (r *PostgresPublishRepository) ListDeliveries(ctx context.Context, targetID string, limit int) ([]models.PublishDelivery, error) {
+	query := fmt.Sprintf(`
+		SELECT id, target_id, trigger, status, document_count, detail, error, duration_ms, created_at
+		FROM %s
+		WHERE target_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, r.tables.PublishDeliveries)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, targetID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list publish deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []models.PublishDelivery{}
+	for rows.Next() {
+		var delivery models.PublishDelivery
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.TargetID,
+			&delivery.Trigger,
+			&delivery.Status,
+			&delivery.DocumentCount,
+			&delivery.Detail,
+			&delivery.Error,
+			&delivery.DurationMs,
+			&delivery.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan publish delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate publish deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func scanPublishTarget(row pgx.Row) (*models.PublishTarget, error) {
+	var target models.PublishTarget
+	err := row.Scan(
+		&target.ID,
+		&target.ProjectID,
+		&target.Name,
+		&target.Kind,
+		&target.Config,
+		&target.DocumentIDs,
+		&target.FolderIDs,
+		&target.OnChange,
+		&target.LastFingerprint,
+		&target.LastStatus,
+		&target.LastAttemptAt,
+		&target.LastPublishedAt,
+		&target.CreatedAt,
+		&target.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+// normalizePublishTarget replaces nil collections so NOT NULL columns get '{}'
+func normalizePublishTarget(target *models.PublishTarget) {
+	if target.Config == nil {
+		target.Config = map[string]string{}
+	}
+	if target.DocumentIDs == nil {
+		target.DocumentIDs = []string{}
+	}
+	if target.FolderIDs == nil {
+		target.FolderIDs = []string{}
+	}
+}
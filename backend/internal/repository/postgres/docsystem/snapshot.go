@@ -0,0 +1,369 @@
+package docsystem
+
+import (
+	"context"
+	"fmt"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+
+	"meridian/internal/repository/postgres"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresSnapshotRepository implements the SnapshotRepository interface
+type PostgresSnapshotRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewSnapshotRepository creates a new snapshot repository
+func NewSnapshotRepository(config *postgres.RepositoryConfig) docsysRepo.SnapshotRepository {
+	return &PostgresSnapshotRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+// liveTreeCTE selects the project's live tree ($1 = project ID) as
+// tree(kind, path, source_id, content_hash, content). Folders whose ancestors are
+// deleted are unreachable and excluded, like their documents.
+func (r *PostgresSnapshotRepository) liveTreeCTE() string {
+	return fmt.Sprintf(`
+		folder_paths AS (
+			SELECT id, name::text AS path
+			FROM %[1]s
+			WHERE project_id = $1 AND parent_id IS NULL AND deleted_at IS NULL
+			UNION ALL
+			SELECT f.id, fp.path || '/' || f.name
+			FROM %[1]s f
+			JOIN folder_paths fp ON f.parent_id = fp.id
+			WHERE f.deleted_at IS NULL
+		),
+		tree AS (
+			SELECT 'folder' AS kind, fp.path, fp.id AS source_id, NULL::text AS content_hash, NULL::text AS content
+			FROM folder_paths fp
+			UNION ALL
+			SELECT 'document', COALESCE(fp.path || '/', '') || d.name, d.id, d.content_hash, d.content
+			FROM %[2]s d
+			LEFT JOIN folder_paths fp ON fp.id = d.folder_id
+			WHERE d.project_id = $1 AND d.deleted_at IS NULL
+			  AND (d.folder_id IS NULL OR fp.id IS NOT NULL)
+		)`, r.tables.Folders, r.tables.Documents)
+}
+
+// Create captures the live tree in one statement: new content goes to content_blobs
+// (existing hashes are reused), then the snapshot row and its entries are written.
+func (r *PostgresSnapshotRepository) Create(ctx context.Context, projectID, name string) (*models.Snapshot, error) {
+	query := fmt.Sprintf(`
+		WITH RECURSIVE %s,
+		blobs AS (
+			INSERT INTO %s (hash, content)
+			SELECT DISTINCT ON (content_hash) content_hash, content
+			FROM tree
+			WHERE kind = 'document'
+			ON CONFLICT (hash) DO NOTHING
+		),
+		snapshot AS (
+			INSERT INTO %s (project_id, name, document_count, folder_count)
+			SELECT $1, $2,
+			       COUNT(*) FILTER (WHERE kind = 'document'),
+			       COUNT(*) FILTER (WHERE kind = 'folder')
+			FROM tree
+			RETURNING id, project_id, name, document_count, folder_count, created_at
+		),
+		entries AS (
+			INSERT INTO %s (snapshot_id, kind, path, source_id, content_hash)
+			SELECT s.id, t.kind, t.path, t.source_id, t.content_hash
+			FROM tree t, snapshot s
+		)
+		SELECT id, project_id, name, document_count, folder_count, created_at FROM snapshot
+	`, r.liveTreeCTE(), r.tables.ContentBlobs, r.tables.ProjectSnapshots, r.tables.ProjectSnapshotEntries)
+
+	var snapshot models.Snapshot
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, projectID, name).Scan(
+		&snapshot.ID,
+		&snapshot.ProjectID,
+		&snapshot.Name,
+		&snapshot.DocumentCount,
+		&snapshot.FolderCount,
+		&snapshot.CreatedAt,
+	)
+	if err != nil {
+		if postgres.IsPgDuplicateError(err) {
+			// Two live documents with the same path (e.g. case variants created before
+			// name normalization) can't both be captured
+			return nil, fmt.Errorf("%w: project contains duplicate paths; rename them before taking a snapshot", domain.ErrConflict)
+		}
+		return nil, fmt.Errorf("create snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// List returns a project's snapshots, newest first
+func (r *PostgresSnapshotRepository) List(ctx context.Context, projectID string) ([]models.Snapshot, error) {
+	query := fmt.Sprintf(`
+		SELECT id, project_id, name, document_count, folder_count, created_at
+		FROM %s
+		WHERE project_id = $1
+		ORDER BY created_at DESC
+	`, r.tables.ProjectSnapshots)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []models.Snapshot{}
+	for rows.Next() {
+		var snapshot models.Snapshot
+		if err := rows.Scan(
+			&snapshot.ID,
+			&snapshot.ProjectID,
+			&snapshot.Name,
+			&snapshot.DocumentCount,
+			&snapshot.FolderCount,
+			&snapshot.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan snapshot: %w", err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// Get retrieves a snapshot scoped to its project
+func (r *PostgresSnapshotRepository) Get(ctx context.Context, snapshotID, projectID string) (*models.Snapshot, error) {
+	query := fmt.Sprintf(`
+		SELECT id, project_id, name, document_count, folder_count, created_at
+		FROM %s
+		WHERE id = $1 AND project_id = $2
+	`, r.tables.ProjectSnapshots)
+
+	var snapshot models.Snapshot
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, snapshotID, projectID).Scan(
+		&snapshot.ID,
+		&snapshot.ProjectID,
+		&snapshot.Name,
+		&snapshot.DocumentCount,
+		&snapshot.FolderCount,
+		&snapshot.CreatedAt,
+	)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("snapshot %s: %w", snapshotID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get snapshot: %w", err)
+	}
+
+	return &snapshot, nil
+}
+
+// GetEntries returns a snapshot's folders and documents, ordered by path
+func (r *PostgresSnapshotRepository) GetEntries(ctx context.Context, snapshotID string) ([]models.SnapshotEntry, error) {
+	query := fmt.Sprintf(`
+		SELECT kind, path, source_id, COALESCE(content_hash, '')
+		FROM %s
+		WHERE snapshot_id = $1
+		ORDER BY path, kind
+	`, r.tables.ProjectSnapshotEntries)
+
+	return r.queryEntries(ctx, query, snapshotID)
+}
+
+// CurrentEntries returns the project's live tree, ordered by path
+func (r *PostgresSnapshotRepository) CurrentEntries(ctx context.Context, projectID string) ([]models.SnapshotEntry, error) {
+	query := fmt.Sprintf(`
+		WITH RECURSIVE %s
+		SELECT kind, path, source_id, COALESCE(content_hash, '')
+		FROM tree
+		ORDER BY path, kind
+	`, r.liveTreeCTE())
+
+	return r.queryEntries(ctx, query, projectID)
+}
+
+func (r *PostgresSnapshotRepository) queryEntries(ctx context.Context, query string, arg string) ([]models.SnapshotEntry, error) {
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, arg)
+	if err != nil {
+		return nil, fmt.Errorf("get snapshot entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.SnapshotEntry{}
+	for rows.Next() {
+		var entry models.SnapshotEntry
+		if err := rows.Scan(&entry.Kind, &entry.Path, &entry.SourceID, &entry.ContentHash); err != nil {
+			return nil, fmt.Errorf("scan snapshot entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate snapshot entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetDocumentContent returns a document's content as captured in a snapshot
+func (r *PostgresSnapshotRepository) GetDocumentContent(ctx context.Context, snapshotID, documentID string) (string, error) {
+	query := fmt.Sprintf(`
+		SELECT b.content, b.compressed
+		FROM %s e
+		JOIN %s b ON b.hash = e.content_hash
+		WHERE e.snapshot_id = $1 AND e.kind = 'document' AND e.source_id = $2
+	`, r.tables.ProjectSnapshotEntries, r.tables.ContentBlobs)
+
+	var content *string
+	var compressed []byte
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, snapshotID, documentID).Scan(&content, &compressed)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return "", fmt.Errorf("document %s in snapshot %s: %w", documentID, snapshotID, domain.ErrNotFound)
+		}
+		return "", fmt.Errorf("get snapshot document content: %w", err)
+	}
+
+	return blobContent(content, compressed)
+}
+
+// GetContents returns content by hash. Blobs cover snapshotted content; live
+// documents cover content changed since the last snapshot.
+func (r *PostgresSnapshotRepository) GetContents(ctx context.Context, projectID string, hashes []string) (map[string]string, error) {
+	contents := make(map[string]string, len(hashes))
+	if len(hashes) == 0 {
+		return contents, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT hash, content, compressed FROM %s WHERE hash = ANY($2)
+		UNION
+		SELECT content_hash, content, NULL FROM %s
+		WHERE project_id = $1 AND content_hash = ANY($2) AND deleted_at IS NULL
+	`, r.tables.ContentBlobs, r.tables.Documents)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, projectID, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("get contents: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hash string
+		var content *string
+		var compressed []byte
+		if err := rows.Scan(&hash, &content, &compressed); err != nil {
+			return nil, fmt.Errorf("scan content: %w", err)
+		}
+		if _, ok := contents[hash]; ok {
+			continue // Same content from a blob and a live document
+		}
+		if contents[hash], err = blobContent(content, compressed); err != nil {
+			return nil, fmt.Errorf("blob %s: %w", hash, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate contents: %w", err)
+	}
+
+	return contents, nil
+}
+
+// CompressBlobs gzips up to limit uncompressed blobs of at least minBytes, oldest first.
+// Content is read and compressed here, then swapped in only if the blob is still
+// uncompressed (a concurrent run may have got there first). Returns the number compressed.
+func (r *PostgresSnapshotRepository) CompressBlobs(ctx context.Context, minBytes, limit int) (int, error) {
+	selectQuery := fmt.Sprintf(`
+		SELECT hash, content
+		FROM %s
+		WHERE compressed IS NULL AND octet_length(content) >= $1
+		ORDER BY created_at
+		LIMIT $2
+	`, r.tables.ContentBlobs)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, selectQuery, minBytes, limit)
+	if err != nil {
+		return 0, fmt.Errorf("list uncompressed blobs: %w", err)
+	}
+	blobs := make(map[string]string)
+	for rows.Next() {
+		var hash, content string
+		if err := rows.Scan(&hash, &content); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan blob: %w", err)
+		}
+		blobs[hash] = content
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("iterate blobs: %w", err)
+	}
+
+	updateQuery := fmt.Sprintf(`
+		UPDATE %s
+		SET compressed = $2, content = NULL
+		WHERE hash = $1 AND compressed IS NULL
+	`, r.tables.ContentBlobs)
+
+	compressedCount := 0
+	for hash, content := range blobs {
+		compressed, err := compressContent(content)
+		if err != nil {
+			return compressedCount, fmt.Errorf("blob %s: %w", hash, err)
+		}
+		result, err := executor.Exec(ctx, updateQuery, hash, compressed)
+		if err != nil {
+			return compressedCount, fmt.Errorf("store compressed blob: %w", err)
+		}
+		compressedCount += int(result.RowsAffected())
+	}
+
+	return compressedCount, nil
+}
+
+// Undelete revives the most recently soft-deleted folder or document with this name and parent
+func (r *PostgresSnapshotRepository) Undelete(ctx context.Context, kind, projectID string, parentID *string, name string) (string, error) {
+	table, parentColumn := r.tables.Documents, "folder_id"
+	if kind == models.SnapshotEntryFolder {
+		table, parentColumn = r.tables.Folders, "parent_id"
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %[1]s
+		SET deleted_at = NULL
+		WHERE id = (
+			SELECT id FROM %[1]s
+			WHERE project_id = $1 AND %[2]s IS NOT DISTINCT FROM $2 AND name = $3 AND deleted_at IS NOT NULL
+			ORDER BY deleted_at DESC
+			LIMIT 1
+		)
+		RETURNING id
+	`, table, parentColumn)
+
+	var id string
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, projectID, parentID, name).Scan(&id)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("undelete %s: %w", kind, err)
+	}
+
+	return id, nil
+}
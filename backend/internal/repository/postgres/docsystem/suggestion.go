@@ -0,0 +1,126 @@
+package docsystem
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"meridian/internal/domain"
+	docsysModels "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/repository/postgres"
+)
+
+// PostgresSuggestionRepository implements the SuggestionRepository interface
+type PostgresSuggestionRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewSuggestionRepository creates a new suggestion repository
+func NewSuggestionRepository(config *postgres.RepositoryConfig) docsysRepo.SuggestionRepository {
+	return &PostgresSuggestionRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+// CreateSuggestion inserts a suggestion
+func (r *PostgresSuggestionRepository) CreateSuggestion(ctx context.Context, suggestion *docsysModels.DocumentSuggestion) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (document_id, start_offset, end_offset, original_text, suggested_text, instruction, model)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`, r.tables.DocumentSuggestions)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		suggestion.DocumentID,
+		suggestion.Start,
+		suggestion.End,
+		suggestion.OriginalText,
+		suggestion.SuggestedText,
+		suggestion.Instruction,
+		suggestion.Model,
+	).Scan(&suggestion.ID, &suggestion.CreatedAt)
+	if err != nil {
+		if postgres.IsPgForeignKeyError(err) {
+			return fmt.Errorf("document %s: %w", suggestion.DocumentID, domain.ErrNotFound)
+		}
+		return fmt.Errorf("create document suggestion: %w", err)
+	}
+
+	return nil
+}
+
+// ListSuggestions returns a document's suggestions, oldest first
+func (r *PostgresSuggestionRepository) ListSuggestions(ctx context.Context, documentID string) ([]docsysModels.DocumentSuggestion, error) {
+	query := fmt.Sprintf(`
+		SELECT id, document_id, start_offset, end_offset, original_text, suggested_text, instruction, model, created_at
+		FROM %s
+		WHERE document_id = $1
+		ORDER BY created_at, id
+	`, r.tables.DocumentSuggestions)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, documentID)
+	if err != nil {
+		return nil, fmt.Errorf("list document suggestions: %w", err)
+	}
+	defer rows.Close()
+
+	suggestions := []docsysModels.DocumentSuggestion{}
+	for rows.Next() {
+		var s docsysModels.DocumentSuggestion
+		if err := rows.Scan(&s.ID, &s.DocumentID, &s.Start, &s.End, &s.OriginalText, &s.SuggestedText, &s.Instruction, &s.Model, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan document suggestion: %w", err)
+		}
+		suggestions = append(suggestions, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate document suggestions: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// GetSuggestion retrieves a suggestion scoped to its document
+func (r *PostgresSuggestionRepository) GetSuggestion(ctx context.Context, suggestionID, documentID string) (*docsysModels.DocumentSuggestion, error) {
+	query := fmt.Sprintf(`
+		SELECT id, document_id, start_offset, end_offset, original_text, suggested_text, instruction, model, created_at
+		FROM %s
+		WHERE id = $1 AND document_id = $2
+	`, r.tables.DocumentSuggestions)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	var s docsysModels.DocumentSuggestion
+	err := executor.QueryRow(ctx, query, suggestionID, documentID).
+		Scan(&s.ID, &s.DocumentID, &s.Start, &s.End, &s.OriginalText, &s.SuggestedText, &s.Instruction, &s.Model, &s.CreatedAt)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("suggestion %s: %w", suggestionID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get document suggestion: %w", err)
+	}
+
+	return &s, nil
+}
+
+// DeleteSuggestion removes a suggestion scoped to its document
+func (r *PostgresSuggestionRepository) DeleteSuggestion(ctx context.Context, suggestionID, documentID string) error {
+	query := fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE id = $1 AND document_id = $2
+	`, r.tables.DocumentSuggestions)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, suggestionID, documentID)
+	if err != nil {
+		return fmt.Errorf("delete document suggestion: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("suggestion %s: %w", suggestionID, domain.ErrNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,242 @@
+package docsystem
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+
+	"meridian/internal/repository/postgres"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresTrashRepository implements the TrashRepository interface
+type PostgresTrashRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewTrashRepository creates a new trash repository
+func NewTrashRepository(config *postgres.RepositoryConfig) docsysRepo.TrashRepository {
+	return &PostgresTrashRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+// ListTrash lists soft-deleted items, skipping those deleted together with their parent
+// folder (a folder delete stamps the whole subtree with one transaction timestamp)
+func (r *PostgresTrashRepository) ListTrash(ctx context.Context, projectID, userID string) ([]models.TrashItem, error) {
+	query := fmt.Sprintf(`
+		SELECT 'document', d.id, d.project_id, d.name, d.folder_id, d.deleted_at
+		FROM %s d
+		LEFT JOIN %s f ON f.id = d.folder_id
+		WHERE d.project_id = $1 AND d.deleted_at IS NOT NULL
+		  AND (f.deleted_at IS NULL OR f.deleted_at <> d.deleted_at)
+		UNION ALL
+		SELECT 'folder', f.id, f.project_id, f.name, f.parent_id, f.deleted_at
+		FROM %s f
+		LEFT JOIN %s p ON p.id = f.parent_id
+		WHERE f.project_id = $1 AND f.deleted_at IS NOT NULL
+		  AND (p.deleted_at IS NULL OR p.deleted_at <> f.deleted_at)
+		UNION ALL
+		SELECT 'chat', c.id, c.project_id, c.title, NULL, c.deleted_at
+		FROM %s c
+		WHERE c.project_id = $1 AND c.user_id = $2 AND c.deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+	`, r.tables.Documents, r.tables.Folders, r.tables.Folders, r.tables.Folders, r.tables.Chats)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, projectID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list trash: %w", err)
+	}
+	defer rows.Close()
+
+	items := []models.TrashItem{}
+	for rows.Next() {
+		var item models.TrashItem
+		if err := rows.Scan(
+			&item.Type,
+			&item.ID,
+			&item.ProjectID,
+			&item.Name,
+			&item.FolderID,
+			&item.DeletedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan trash item: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate trash: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetTrashItem retrieves a soft-deleted item by type and ID
+func (r *PostgresTrashRepository) GetTrashItem(ctx context.Context, itemType models.TrashItemType, id string) (*models.TrashItem, error) {
+	var query string
+	switch itemType {
+	case models.TrashItemDocument:
+		query = fmt.Sprintf(`
+			SELECT id, project_id, name, folder_id, deleted_at
+			FROM %s
+			WHERE id = $1 AND deleted_at IS NOT NULL
+		`, r.tables.Documents)
+	case models.TrashItemFolder:
+		query = fmt.Sprintf(`
+			SELECT id, project_id, name, parent_id, deleted_at
+			FROM %s
+			WHERE id = $1 AND deleted_at IS NOT NULL
+		`, r.tables.Folders)
+	case models.TrashItemChat:
+		query = fmt.Sprintf(`
+			SELECT id, project_id, title, NULL::uuid, deleted_at
+			FROM %s
+			WHERE id = $1 AND deleted_at IS NOT NULL
+		`, r.tables.Chats)
+	default:
+		return nil, fmt.Errorf("unknown trash item type %q: %w", itemType, domain.ErrValidation)
+	}
+
+	item := models.TrashItem{Type: itemType}
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, id).Scan(
+		&item.ID,
+		&item.ProjectID,
+		&item.Name,
+		&item.FolderID,
+		&item.DeletedAt,
+	)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("deleted %s %s: %w", itemType, id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get trash item: %w", err)
+	}
+
+	return &item, nil
+}
+
+// RestoreDocument clears a document's deleted_at
+func (r *PostgresTrashRepository) RestoreDocument(ctx context.Context, id string) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`, r.tables.Documents)
+
+	return r.restore(ctx, query, "document", id)
+}
+
+// RestoreChat clears the deleted_at of a chat owned by userID
+func (r *PostgresTrashRepository) RestoreChat(ctx context.Context, id, userID string) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL
+	`, r.tables.Chats)
+
+	return r.restore(ctx, query, "chat", id, userID)
+}
+
+// restore runs a single-row restore, mapping a unique violation (a live sibling took the
+// name meanwhile) to a conflict
+func (r *PostgresTrashRepository) restore(ctx context.Context, query, resourceType, id string, args ...any) error {
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, append([]any{id}, args...)...)
+	if err != nil {
+		if postgres.IsPgDuplicateError(err) {
+			return fmt.Errorf("a %s with this name already exists at this level: %w", resourceType, domain.ErrConflict)
+		}
+		return fmt.Errorf("restore %s: %w", resourceType, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("deleted %s %s: %w", resourceType, id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// RestoreFolderTree restores a folder and everything deleted with it.
+// Documents go first while the subtree's folders still carry the matching deleted_at.
+func (r *PostgresTrashRepository) RestoreFolderTree(ctx context.Context, id string, deletedAt time.Time) (int64, int64, error) {
+	subtree := fmt.Sprintf(`
+		WITH RECURSIVE subtree AS (
+			SELECT id
+			FROM %s
+			WHERE id = $1 AND deleted_at = $2
+			UNION ALL
+			SELECT f.id
+			FROM %s f
+			JOIN subtree s ON f.parent_id = s.id
+			WHERE f.deleted_at = $2
+		)
+	`, r.tables.Folders, r.tables.Folders)
+
+	documentsQuery := subtree + fmt.Sprintf(`
+		UPDATE %s
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE deleted_at = $2 AND folder_id IN (SELECT id FROM subtree)
+	`, r.tables.Documents)
+
+	foldersQuery := subtree + fmt.Sprintf(`
+		UPDATE %s
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE id IN (SELECT id FROM subtree)
+	`, r.tables.Folders)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	documents, err := executor.Exec(ctx, documentsQuery, id, deletedAt)
+	if err != nil {
+		return 0, 0, fmt.Errorf("restore folder documents: %w", err)
+	}
+
+	folders, err := executor.Exec(ctx, foldersQuery, id, deletedAt)
+	if err != nil {
+		if postgres.IsPgDuplicateError(err) {
+			return 0, 0, fmt.Errorf("a folder with this name already exists at this level: %w", domain.ErrConflict)
+		}
+		return 0, 0, fmt.Errorf("restore folder tree: %w", err)
+	}
+
+	if folders.RowsAffected() == 0 {
+		return 0, 0, fmt.Errorf("deleted folder %s: %w", id, domain.ErrNotFound)
+	}
+
+	return folders.RowsAffected(), documents.RowsAffected(), nil
+}
+
+// PurgeDeleted hard-deletes documents, then folders, soft-deleted before deletedBefore
+// Suggestions, pins and analytics go with the documents via ON DELETE CASCADE
+func (r *PostgresTrashRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	documentsQuery := fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE deleted_at < $1
+	`, r.tables.Documents)
+
+	foldersQuery := fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE deleted_at < $1
+	`, r.tables.Folders)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	documents, err := executor.Exec(ctx, documentsQuery, deletedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("purge deleted documents: %w", err)
+	}
+
+	folders, err := executor.Exec(ctx, foldersQuery, deletedBefore)
+	if err != nil {
+		return documents.RowsAffected(), fmt.Errorf("purge deleted folders: %w", err)
+	}
+
+	return documents.RowsAffected() + folders.RowsAffected(), nil
+}
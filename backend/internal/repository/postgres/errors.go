@@ -31,3 +31,14 @@ func IsPgForeignKeyError(err error) bool {
 	}
 	return false
 }
+
+// IsPgUndefinedObjectError checks if error is a reference to a missing object,
+// e.g. an unknown text search configuration in a ::regconfig cast
+func IsPgUndefinedObjectError(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// 42704 = undefined_object
+		return pgErr.Code == "42704"
+	}
+	return false
+}
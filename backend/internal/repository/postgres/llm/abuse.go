@@ -0,0 +1,146 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/repository/postgres"
+)
+
+// PostgresAbuseFlagRepository implements the AbuseFlagRepository interface
+type PostgresAbuseFlagRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewAbuseFlagRepository creates a new abuse flag repository
+func NewAbuseFlagRepository(config *postgres.RepositoryConfig) llmRepo.AbuseFlagRepository {
+	return &PostgresAbuseFlagRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+const abuseFlagColumns = `id, user_id, signal, action, detail, turn_id, throttled_until, created_at, resolved_at`
+
+// CreateFlag inserts an abuse flag
+func (r *PostgresAbuseFlagRepository) CreateFlag(ctx context.Context, flag *llmModels.AbuseFlag) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (user_id, signal, action, detail, turn_id, throttled_until)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`, r.tables.AbuseFlags)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		flag.UserID,
+		flag.Signal,
+		flag.Action,
+		flag.Detail,
+		flag.TurnID,
+		flag.ThrottledUntil,
+	).Scan(&flag.ID, &flag.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create abuse flag: %w", err)
+	}
+
+	return nil
+}
+
+// GetActiveThrottle returns the user's unresolved, unexpired throttle that ends last
+func (r *PostgresAbuseFlagRepository) GetActiveThrottle(ctx context.Context, userID string, now time.Time) (*llmModels.AbuseFlag, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %s
+		WHERE user_id = $1 AND resolved_at IS NULL AND throttled_until > $2
+		ORDER BY throttled_until DESC
+		LIMIT 1
+	`, abuseFlagColumns, r.tables.AbuseFlags)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	flag, err := scanAbuseFlag(executor.QueryRow(ctx, query, userID, now))
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("throttle of user %s: %w", userID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get active throttle: %w", err)
+	}
+
+	return flag, nil
+}
+
+// ListFlags returns flags newest first
+func (r *PostgresAbuseFlagRepository) ListFlags(ctx context.Context, userID string, unresolvedOnly bool, limit int) ([]llmModels.AbuseFlag, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %s
+		WHERE ($1 = '' OR user_id = $1) AND (NOT $2 OR resolved_at IS NULL)
+		ORDER BY created_at DESC
+		LIMIT $3
+	`, abuseFlagColumns, r.tables.AbuseFlags)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, userID, unresolvedOnly, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list abuse flags: %w", err)
+	}
+	defer rows.Close()
+
+	flags := []llmModels.AbuseFlag{}
+	for rows.Next() {
+		flag, err := scanAbuseFlag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan abuse flag: %w", err)
+		}
+		flags = append(flags, *flag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate abuse flags: %w", err)
+	}
+
+	return flags, nil
+}
+
+// ResolveFlag sets a flag's resolved_at (unless it's already resolved)
+func (r *PostgresAbuseFlagRepository) ResolveFlag(ctx context.Context, id string, resolvedAt time.Time) (*llmModels.AbuseFlag, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET resolved_at = COALESCE(resolved_at, $2)
+		WHERE id = $1
+		RETURNING %s
+	`, r.tables.AbuseFlags, abuseFlagColumns)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	flag, err := scanAbuseFlag(executor.QueryRow(ctx, query, id, resolvedAt))
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("abuse flag %s: %w", id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("resolve abuse flag: %w", err)
+	}
+
+	return flag, nil
+}
+
+func scanAbuseFlag(row pgx.Row) (*llmModels.AbuseFlag, error) {
+	var flag llmModels.AbuseFlag
+	err := row.Scan(
+		&flag.ID,
+		&flag.UserID,
+		&flag.Signal,
+		&flag.Action,
+		&flag.Detail,
+		&flag.TurnID,
+		&flag.ThrottledUntil,
+		&flag.CreatedAt,
+		&flag.ResolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
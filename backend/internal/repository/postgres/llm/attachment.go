@@ -0,0 +1,127 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/repository/postgres"
+)
+
+// PostgresAttachmentRepository implements the AttachmentRepository interface
+type PostgresAttachmentRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewAttachmentRepository creates a new attachment repository
+func NewAttachmentRepository(config *postgres.RepositoryConfig) llmRepo.AttachmentRepository {
+	return &PostgresAttachmentRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+// CreateAttachment inserts an attachment record if it fits the project's quota
+// The per-project advisory lock makes concurrent uploads check the quota one at a time;
+// the insert runs as its own statement so it sees uploads committed while it waited.
+func (r *PostgresAttachmentRepository) CreateAttachment(ctx context.Context, attachment *llmModels.Attachment, quotaBytes int64) error {
+	executor := postgres.GetExecutor(ctx, r.pool)
+
+	if quotaBytes > 0 {
+		if _, err := executor.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended('attachments:' || $1, 0))`, attachment.ProjectID); err != nil {
+			return fmt.Errorf("lock project attachments: %w", err)
+		}
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (project_id, user_id, file_name, content_type, size_bytes, storage_key)
+		SELECT $1, $2, $3, $4, $5, $6
+		WHERE $7 = 0 OR (SELECT COALESCE(SUM(size_bytes), 0) FROM %s WHERE project_id = $1) + $5 <= $7
+		RETURNING id, created_at
+	`, r.tables.Attachments, r.tables.Attachments)
+
+	err := executor.QueryRow(ctx, query,
+		attachment.ProjectID,
+		attachment.UserID,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.SizeBytes,
+		attachment.StorageKey,
+		quotaBytes,
+	).Scan(&attachment.ID, &attachment.CreatedAt)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			used, usageErr := r.GetProjectUsageBytes(ctx, attachment.ProjectID)
+			if usageErr != nil {
+				return usageErr
+			}
+			return &llmModels.QuotaExceededError{UsedBytes: used, QuotaBytes: quotaBytes}
+		}
+		if postgres.IsPgForeignKeyError(err) {
+			return fmt.Errorf("project %s: %w", attachment.ProjectID, domain.ErrNotFound)
+		}
+		return fmt.Errorf("create attachment: %w", err)
+	}
+
+	return nil
+}
+
+// GetAttachment retrieves an attachment by ID
+func (r *PostgresAttachmentRepository) GetAttachment(ctx context.Context, id string) (*llmModels.Attachment, error) {
+	query := fmt.Sprintf(`
+		SELECT id, project_id, user_id, file_name, content_type, size_bytes, storage_key, created_at
+		FROM %s
+		WHERE id = $1
+	`, r.tables.Attachments)
+
+	var a llmModels.Attachment
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, id).Scan(
+		&a.ID, &a.ProjectID, &a.UserID, &a.FileName, &a.ContentType, &a.SizeBytes, &a.StorageKey, &a.CreatedAt,
+	)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("attachment %s: %w", id, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get attachment: %w", err)
+	}
+
+	return &a, nil
+}
+
+// DeleteAttachment deletes an attachment record
+func (r *PostgresAttachmentRepository) DeleteAttachment(ctx context.Context, id string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, r.tables.Attachments)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("delete attachment: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("attachment %s: %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// GetProjectUsageBytes sums the sizes of a project's attachments
+func (r *PostgresAttachmentRepository) GetProjectUsageBytes(ctx context.Context, projectID string) (int64, error) {
+	query := fmt.Sprintf(`
+		SELECT COALESCE(SUM(size_bytes), 0)
+		FROM %s
+		WHERE project_id = $1
+	`, r.tables.Attachments)
+
+	var total int64
+	executor := postgres.GetExecutor(ctx, r.pool)
+	if err := executor.QueryRow(ctx, query, projectID).Scan(&total); err != nil {
+		return 0, fmt.Errorf("get project attachment usage: %w", err)
+	}
+
+	return total, nil
+}
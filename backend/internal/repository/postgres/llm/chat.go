@@ -7,10 +7,12 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"meridian/internal/config"
 	"meridian/internal/domain"
 	llmModels "meridian/internal/domain/models/llm"
 	llmRepo "meridian/internal/domain/repositories/llm"
 	"meridian/internal/repository/postgres"
+	"meridian/internal/utils"
 )
 
 // PostgresChatRepository implements the ChatRepository interface using PostgreSQL
@@ -32,8 +34,8 @@ func NewChatRepository(config *postgres.RepositoryConfig) llmRepo.ChatRepository
 // CreateChat creates a new chat session
 func (r *PostgresChatRepository) CreateChat(ctx context.Context, chat *llmModels.Chat) error {
 	query := fmt.Sprintf(`
-		INSERT INTO %s (project_id, user_id, title, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO %s (project_id, user_id, title, created_at, updated_at, idempotency_key)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id, created_at, updated_at
 	`, r.tables.Chats)
 
@@ -44,9 +46,15 @@ func (r *PostgresChatRepository) CreateChat(ctx context.Context, chat *llmModels
 		chat.Title,
 		chat.CreatedAt,
 		chat.UpdatedAt,
+		chat.IdempotencyKey,
 	).Scan(&chat.ID, &chat.CreatedAt, &chat.UpdatedAt)
 
 	if err != nil {
+		if postgres.IsPgDuplicateError(err) && chat.IdempotencyKey != nil {
+			// A concurrent cold start with the same key created the chat first. The failed
+			// insert aborts the caller's transaction, so the caller looks the chat up.
+			return fmt.Errorf("chat for idempotency key already exists: %w", domain.ErrConflict)
+		}
 		if postgres.IsPgDuplicateError(err) {
 			// Query for existing chat to get its ID
 			existingID, queryErr := r.getExistingChatID(ctx, chat.ProjectID, chat.UserID, chat.Title)
@@ -63,6 +71,10 @@ func (r *PostgresChatRepository) CreateChat(ctx context.Context, chat *llmModels
 		return fmt.Errorf("create chat: %w", err)
 	}
 
+	// Defaults are configured later via chat settings (columns default to empty arrays)
+	chat.DefaultSkills = []string{}
+	chat.DefaultTools = []string{}
+
 	return nil
 }
 
@@ -86,7 +98,7 @@ func (r *PostgresChatRepository) getExistingChatID(ctx context.Context, projectI
 // GetChat retrieves a chat by ID (scoped to user)
 func (r *PostgresChatRepository) GetChat(ctx context.Context, chatID, userID string) (*llmModels.Chat, error) {
 	query := fmt.Sprintf(`
-		SELECT id, project_id, user_id, title, last_viewed_turn_id, created_at, updated_at, deleted_at
+		SELECT id, project_id, user_id, title, system_prompt, default_skills, default_tools, last_viewed_turn_id, created_at, updated_at, deleted_at
 		FROM %s
 		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
 	`, r.tables.Chats)
@@ -98,6 +110,9 @@ func (r *PostgresChatRepository) GetChat(ctx context.Context, chatID, userID str
 		&chat.ProjectID,
 		&chat.UserID,
 		&chat.Title,
+		&chat.SystemPrompt,
+		&chat.DefaultSkills,
+		&chat.DefaultTools,
 		&chat.LastViewedTurnID,
 		&chat.CreatedAt,
 		&chat.UpdatedAt,
@@ -114,11 +129,45 @@ func (r *PostgresChatRepository) GetChat(ctx context.Context, chatID, userID str
 	return &chat, nil
 }
 
+// GetChatByIdempotencyKey retrieves the live chat a cold-start turn created with the key
+func (r *PostgresChatRepository) GetChatByIdempotencyKey(ctx context.Context, projectID, userID, key string) (*llmModels.Chat, error) {
+	query := fmt.Sprintf(`
+		SELECT id, project_id, user_id, title, system_prompt, default_skills, default_tools, last_viewed_turn_id, created_at, updated_at, deleted_at
+		FROM %s
+		WHERE project_id = $1 AND user_id = $2 AND idempotency_key = $3 AND deleted_at IS NULL
+	`, r.tables.Chats)
+
+	var chat llmModels.Chat
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, projectID, userID, key).Scan(
+		&chat.ID,
+		&chat.ProjectID,
+		&chat.UserID,
+		&chat.Title,
+		&chat.SystemPrompt,
+		&chat.DefaultSkills,
+		&chat.DefaultTools,
+		&chat.LastViewedTurnID,
+		&chat.CreatedAt,
+		&chat.UpdatedAt,
+		&chat.DeletedAt,
+	)
+
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("chat for idempotency key: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get chat by idempotency key: %w", err)
+	}
+
+	return &chat, nil
+}
+
 // GetChatByIDOnly retrieves a chat by UUID only (no user scoping)
 // Used by ResourceAuthorizer when authorization is handled separately
 func (r *PostgresChatRepository) GetChatByIDOnly(ctx context.Context, chatID string) (*llmModels.Chat, error) {
 	query := fmt.Sprintf(`
-		SELECT id, project_id, user_id, title, last_viewed_turn_id, created_at, updated_at, deleted_at
+		SELECT id, project_id, user_id, title, system_prompt, default_skills, default_tools, last_viewed_turn_id, created_at, updated_at, deleted_at
 		FROM %s
 		WHERE id = $1 AND deleted_at IS NULL
 	`, r.tables.Chats)
@@ -130,6 +179,9 @@ func (r *PostgresChatRepository) GetChatByIDOnly(ctx context.Context, chatID str
 		&chat.ProjectID,
 		&chat.UserID,
 		&chat.Title,
+		&chat.SystemPrompt,
+		&chat.DefaultSkills,
+		&chat.DefaultTools,
 		&chat.LastViewedTurnID,
 		&chat.CreatedAt,
 		&chat.UpdatedAt,
@@ -147,13 +199,66 @@ func (r *PostgresChatRepository) GetChatByIDOnly(ctx context.Context, chatID str
 }
 
 // ListChatsByProject retrieves all chats for a project
-func (r *PostgresChatRepository) ListChatsByProject(ctx context.Context, projectID, userID string) ([]llmModels.Chat, error) {
+// Summaries selected in opts are joined in the same query (LATERAL subqueries per chat)
+func (r *PostgresChatRepository) ListChatsByProject(ctx context.Context, projectID, userID string, opts *llmModels.ChatListOptions) ([]llmModels.Chat, error) {
+	if opts == nil {
+		opts = &llmModels.ChatListOptions{}
+	}
+
+	// Summary columns are NULL unless requested, so the scan is the same for every option set
+	previewColumns := "NULL::uuid, NULL::text, NULL::text, NULL::text, NULL::timestamptz"
+	turnCountColumn := "NULL::bigint"
+	unreadCountColumn := "NULL::bigint"
+	var joins string
+
+	if opts.IncludeLastTurnPreview {
+		// left(n+1) lets the caller tell a cut preview from one that fits exactly
+		previewColumns = "lt.id, lt.role, lt.status, lt.preview, lt.created_at"
+		joins += fmt.Sprintf(`
+		LEFT JOIN LATERAL (
+			SELECT t.id, t.role, t.status, t.created_at,
+			       (SELECT left(b.text_content, %d)
+			        FROM %s b
+			        WHERE b.turn_id = t.id AND b.block_type = 'text' AND b.text_content <> ''
+			        ORDER BY b.sequence
+			        LIMIT 1) AS preview
+			FROM %s t
+			WHERE t.chat_id = c.id
+			ORDER BY t.created_at DESC
+			LIMIT 1
+		) lt ON true`, config.MaxChatPreviewLength+1, r.tables.TurnBlocks, r.tables.Turns)
+	}
+
+	if opts.IncludeTurnCount {
+		turnCountColumn = "tc.turn_count"
+		joins += fmt.Sprintf(`
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS turn_count FROM %s t WHERE t.chat_id = c.id
+		) tc ON true`, r.tables.Turns)
+	}
+
+	if opts.IncludeUnreadCount {
+		// Unread = assistant turns newer than the last viewed turn (all of them if never viewed)
+		unreadCountColumn = "uc.unread_count"
+		joins += fmt.Sprintf(`
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS unread_count
+			FROM %s t
+			WHERE t.chat_id = c.id AND t.role = 'assistant'
+			  AND t.created_at > COALESCE(
+			      (SELECT v.created_at FROM %s v WHERE v.id = c.last_viewed_turn_id),
+			      '-infinity'::timestamptz)
+		) uc ON true`, r.tables.Turns, r.tables.Turns)
+	}
+
 	query := fmt.Sprintf(`
-		SELECT id, project_id, user_id, title, last_viewed_turn_id, created_at, updated_at, deleted_at
-		FROM %s
-		WHERE project_id = $1 AND user_id = $2 AND deleted_at IS NULL
-		ORDER BY updated_at DESC
-	`, r.tables.Chats)
+		SELECT c.id, c.project_id, c.user_id, c.title, c.system_prompt, c.default_skills, c.default_tools,
+		       c.last_viewed_turn_id, c.created_at, c.updated_at, c.deleted_at,
+		       %s, %s, %s
+		FROM %s c%s
+		WHERE c.project_id = $1 AND c.user_id = $2 AND c.deleted_at IS NULL
+		ORDER BY c.updated_at DESC
+	`, previewColumns, turnCountColumn, unreadCountColumn, r.tables.Chats, joins)
 
 	executor := postgres.GetExecutor(ctx, r.pool)
 	rows, err := executor.Query(ctx, query, projectID, userID)
@@ -165,19 +270,54 @@ func (r *PostgresChatRepository) ListChatsByProject(ctx context.Context, project
 	var chats []llmModels.Chat
 	for rows.Next() {
 		var chat llmModels.Chat
+		var preview struct {
+			TurnID    *string
+			Role      *string
+			Status    *string
+			Text      *string
+			CreatedAt *time.Time
+		}
+		var turnCount, unreadCount *int
 		err := rows.Scan(
 			&chat.ID,
 			&chat.ProjectID,
 			&chat.UserID,
 			&chat.Title,
+			&chat.SystemPrompt,
+			&chat.DefaultSkills,
+			&chat.DefaultTools,
 			&chat.LastViewedTurnID,
 			&chat.CreatedAt,
 			&chat.UpdatedAt,
 			&chat.DeletedAt,
+			&preview.TurnID,
+			&preview.Role,
+			&preview.Status,
+			&preview.Text,
+			&preview.CreatedAt,
+			&turnCount,
+			&unreadCount,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scan chat: %w", err)
 		}
+
+		if preview.TurnID != nil {
+			if preview.Text != nil {
+				text := utils.TruncateWithEllipsis(*preview.Text, config.MaxChatPreviewLength)
+				preview.Text = &text
+			}
+			chat.LastTurnPreview = &llmModels.ChatTurnPreview{
+				TurnID:    *preview.TurnID,
+				Role:      *preview.Role,
+				Status:    *preview.Status,
+				Text:      preview.Text,
+				CreatedAt: *preview.CreatedAt,
+			}
+		}
+		chat.TurnCount = turnCount
+		chat.UnreadCount = unreadCount
+
 		chats = append(chats, chat)
 	}
 
@@ -197,13 +337,16 @@ func (r *PostgresChatRepository) ListChatsByProject(ctx context.Context, project
 func (r *PostgresChatRepository) UpdateChat(ctx context.Context, chat *llmModels.Chat) error {
 	query := fmt.Sprintf(`
 		UPDATE %s
-		SET title = $1, last_viewed_turn_id = $2, updated_at = $3
-		WHERE id = $4 AND user_id = $5 AND deleted_at IS NULL
+		SET title = $1, system_prompt = $2, default_skills = $3, default_tools = $4, last_viewed_turn_id = $5, updated_at = $6
+		WHERE id = $7 AND user_id = $8 AND deleted_at IS NULL
 	`, r.tables.Chats)
 
 	executor := postgres.GetExecutor(ctx, r.pool)
 	result, err := executor.Exec(ctx, query,
 		chat.Title,
+		chat.SystemPrompt,
+		nonNilStrings(chat.DefaultSkills),
+		nonNilStrings(chat.DefaultTools),
 		chat.LastViewedTurnID,
 		chat.UpdatedAt,
 		chat.ID,
@@ -233,7 +376,7 @@ func (r *PostgresChatRepository) UpdateChat(ctx context.Context, chat *llmModels
 	return nil
 }
 
-// UpdateLastViewedTurn updates only the last_viewed_turn_id field
+// UpdateLastViewedTurn updates the last_viewed_turn_id field and the active branch leading to it
 // Validates that the turn belongs to the chat before updating (single query)
 func (r *PostgresChatRepository) UpdateLastViewedTurn(ctx context.Context, chatID, userID, turnID string) error {
 	query := fmt.Sprintf(`
@@ -264,6 +407,11 @@ func (r *PostgresChatRepository) UpdateLastViewedTurn(ctx context.Context, chatI
 		return fmt.Errorf("chat %s: %w", chatID, domain.ErrNotFound)
 	}
 
+	// Switching to a sibling also makes its branch the one forward pagination follows
+	if err := activateTurnPath(ctx, executor, r.tables, turnID); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -273,7 +421,7 @@ func (r *PostgresChatRepository) DeleteChat(ctx context.Context, chatID, userID
 		UPDATE %s
 		SET deleted_at = NOW()
 		WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
-		RETURNING id, project_id, user_id, title, last_viewed_turn_id, created_at, updated_at, deleted_at
+		RETURNING id, project_id, user_id, title, system_prompt, default_skills, default_tools, last_viewed_turn_id, created_at, updated_at, deleted_at
 	`, r.tables.Chats)
 
 	executor := postgres.GetExecutor(ctx, r.pool)
@@ -285,6 +433,9 @@ func (r *PostgresChatRepository) DeleteChat(ctx context.Context, chatID, userID
 		&chat.ProjectID,
 		&chat.UserID,
 		&chat.Title,
+		&chat.SystemPrompt,
+		&chat.DefaultSkills,
+		&chat.DefaultTools,
 		&chat.LastViewedTurnID,
 		&chat.CreatedAt,
 		&chat.UpdatedAt,
@@ -381,3 +532,111 @@ func (r *PostgresChatRepository) GetChatTree(ctx context.Context, chatID, userID
 		UpdatedAt: updatedAt,
 	}, nil
 }
+
+// GetTurnTree retrieves one page of turn skeletons of a chat's tree
+// Sibling counts and positions are computed over the whole chat before paging
+func (r *PostgresChatRepository) GetTurnTree(ctx context.Context, chatID, userID string, opts *llmModels.TurnTreeOptions) (*llmModels.TurnTreePage, error) {
+	executor := postgres.GetExecutor(ctx, r.pool)
+
+	// Verify chat exists, user has access, and neither it nor its project is deleted
+	var exists bool
+	chatQuery := fmt.Sprintf(`
+		SELECT EXISTS (
+			SELECT 1 FROM %s c
+			JOIN %s p ON p.id = c.project_id AND p.deleted_at IS NULL
+			WHERE c.id = $1 AND c.user_id = $2 AND c.deleted_at IS NULL
+		)
+	`, r.tables.Chats, r.tables.Projects)
+	if err := executor.QueryRow(ctx, chatQuery, chatID, userID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("get chat for turn tree: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("chat %s: %w", chatID, domain.ErrNotFound)
+	}
+
+	var after *string
+	if opts.After != "" {
+		cursorQuery := fmt.Sprintf(`
+			SELECT EXISTS (SELECT 1 FROM %s WHERE id = $1 AND chat_id = $2)
+		`, r.tables.Turns)
+		if err := executor.QueryRow(ctx, cursorQuery, opts.After, chatID).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("get turn tree cursor: %w", err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: after turn %s does not belong to chat %s", domain.ErrValidation, opts.After, chatID)
+		}
+		after = &opts.After
+	}
+
+	// Fetch one extra row to detect has_more
+	query := fmt.Sprintf(`
+		WITH nodes AS (
+			SELECT id, role, prev_turn_id, created_at,
+			       COUNT(*) OVER (PARTITION BY prev_turn_id) AS sibling_count,
+			       ROW_NUMBER() OVER (PARTITION BY prev_turn_id ORDER BY created_at, id) AS sibling_index
+			FROM %[1]s
+			WHERE chat_id = $1
+		)
+		SELECT id, role, prev_turn_id, created_at, sibling_count, sibling_index
+		FROM nodes
+		WHERE $2::uuid IS NULL
+		   OR (created_at, id) > (SELECT created_at, id FROM %[1]s WHERE id = $2)
+		ORDER BY created_at, id
+		LIMIT $3
+	`, r.tables.Turns)
+
+	rows, err := executor.Query(ctx, query, chatID, after, opts.Limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("get turn tree: %w", err)
+	}
+	defer rows.Close()
+
+	nodes := []llmModels.TurnSkeleton{}
+	for rows.Next() {
+		var node llmModels.TurnSkeleton
+		if err := rows.Scan(&node.ID, &node.Role, &node.PrevTurnID, &node.CreatedAt, &node.SiblingCount, &node.SiblingIndex); err != nil {
+			return nil, fmt.Errorf("scan turn skeleton: %w", err)
+		}
+		nodes = append(nodes, node)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate turn skeletons: %w", err)
+	}
+
+	page := &llmModels.TurnTreePage{Turns: nodes}
+	if len(nodes) > opts.Limit {
+		page.Turns = nodes[:opts.Limit]
+		page.HasMore = true
+		page.NextCursor = &page.Turns[opts.Limit-1].ID
+	}
+	return page, nil
+}
+
+// PurgeDeletedChats hard-deletes chats whose soft delete (or their project's) is older than deletedBefore
+// Turns and turn blocks go with them via ON DELETE CASCADE
+func (r *PostgresChatRepository) PurgeDeletedChats(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	query := fmt.Sprintf(`
+		DELETE FROM %s c
+		WHERE c.deleted_at < $1
+		   OR EXISTS (
+		       SELECT 1 FROM %s p
+		       WHERE p.id = c.project_id AND p.deleted_at < $1
+		   )
+	`, r.tables.Chats, r.tables.Projects)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	tag, err := executor.Exec(ctx, query, deletedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("purge deleted chats: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// nonNilStrings maps nil to an empty slice so NOT NULL array columns get '{}' instead of NULL
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
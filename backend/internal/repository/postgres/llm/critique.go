@@ -0,0 +1,100 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/repository/postgres"
+)
+
+// PostgresCritiqueRepository implements the CritiqueRepository interface
+type PostgresCritiqueRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewCritiqueRepository creates a new critique repository
+func NewCritiqueRepository(config *postgres.RepositoryConfig) llmRepo.CritiqueRepository {
+	return &PostgresCritiqueRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+// SaveCritique upserts a turn's critique (one per turn)
+func (r *PostgresCritiqueRepository) SaveCritique(ctx context.Context, critique *llmModels.TurnCritique) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s AS c (turn_id, model, rubric, status, content, error, input_tokens, output_tokens, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (turn_id) DO UPDATE SET
+			model = EXCLUDED.model,
+			rubric = EXCLUDED.rubric,
+			status = EXCLUDED.status,
+			content = EXCLUDED.content,
+			error = EXCLUDED.error,
+			input_tokens = EXCLUDED.input_tokens,
+			output_tokens = EXCLUDED.output_tokens,
+			completed_at = EXCLUDED.completed_at,
+			-- A new pending review restarts the clock
+			created_at = CASE WHEN EXCLUDED.status = 'pending' THEN NOW() ELSE c.created_at END
+		RETURNING id, created_at
+	`, r.tables.TurnCritiques)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		critique.TurnID,
+		critique.Model,
+		critique.Rubric,
+		critique.Status,
+		critique.Content,
+		critique.Error,
+		critique.InputTokens,
+		critique.OutputTokens,
+		critique.CompletedAt,
+	).Scan(&critique.ID, &critique.CreatedAt)
+	if err != nil {
+		if postgres.IsPgForeignKeyError(err) {
+			return fmt.Errorf("turn %s: %w", critique.TurnID, domain.ErrNotFound)
+		}
+		return fmt.Errorf("save turn critique: %w", err)
+	}
+
+	return nil
+}
+
+// GetCritique retrieves a turn's critique
+func (r *PostgresCritiqueRepository) GetCritique(ctx context.Context, turnID string) (*llmModels.TurnCritique, error) {
+	query := fmt.Sprintf(`
+		SELECT id, turn_id, model, rubric, status, content, error, input_tokens, output_tokens, created_at, completed_at
+		FROM %s
+		WHERE turn_id = $1
+	`, r.tables.TurnCritiques)
+
+	var critique llmModels.TurnCritique
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, turnID).Scan(
+		&critique.ID,
+		&critique.TurnID,
+		&critique.Model,
+		&critique.Rubric,
+		&critique.Status,
+		&critique.Content,
+		&critique.Error,
+		&critique.InputTokens,
+		&critique.OutputTokens,
+		&critique.CreatedAt,
+		&critique.CompletedAt,
+	)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("critique for turn %s: %w", turnID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get turn critique: %w", err)
+	}
+
+	return &critique, nil
+}
@@ -0,0 +1,449 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/repository/postgres"
+)
+
+// PostgresEvalRepository implements the EvalRepository interface
+type PostgresEvalRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewEvalRepository creates a new eval repository
+func NewEvalRepository(config *postgres.RepositoryConfig) llmRepo.EvalRepository {
+	return &PostgresEvalRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+const evalCaseColumns = `id, project_id, name, prompt, criteria, created_at, updated_at`
+
+// Runs take their status and error from the job executing them (pending until linked)
+const evalRunColumns = `r.id, r.project_id, r.user_id, r.job_id, r.label, r.models, r.skills, r.case_ids,
+	r.judge_model, COALESCE(j.status, 'pending'), j.error, r.summary, r.created_at`
+
+const evalResultColumns = `id, run_id, case_id, case_name, model, output, passed, score, assertions,
+	input_tokens, output_tokens, error, created_at`
+
+// CreateCase inserts a case
+func (r *PostgresEvalRepository) CreateCase(ctx context.Context, evalCase *llmModels.EvalCase) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (project_id, name, prompt, criteria)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at
+	`, r.tables.EvalCases)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		evalCase.ProjectID,
+		evalCase.Name,
+		evalCase.Prompt,
+		evalCriteria(evalCase.Criteria),
+	).Scan(&evalCase.ID, &evalCase.CreatedAt, &evalCase.UpdatedAt)
+	if err != nil {
+		if postgres.IsPgDuplicateError(err) {
+			return fmt.Errorf("%w: eval case '%s' already exists", domain.ErrConflict, evalCase.Name)
+		}
+		return fmt.Errorf("create eval case: %w", err)
+	}
+
+	return nil
+}
+
+// GetCase retrieves a case scoped to its project
+func (r *PostgresEvalRepository) GetCase(ctx context.Context, caseID, projectID string) (*llmModels.EvalCase, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE id = $1 AND project_id = $2
+	`, evalCaseColumns, r.tables.EvalCases)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	evalCase, err := scanEvalCase(executor.QueryRow(ctx, query, caseID, projectID))
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("eval case %s: %w", caseID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get eval case: %w", err)
+	}
+
+	return evalCase, nil
+}
+
+// ListCases returns a project's cases ordered by name
+func (r *PostgresEvalRepository) ListCases(ctx context.Context, projectID string) ([]llmModels.EvalCase, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE project_id = $1
+		ORDER BY name
+	`, evalCaseColumns, r.tables.EvalCases)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list eval cases: %w", err)
+	}
+	defer rows.Close()
+
+	cases := []llmModels.EvalCase{}
+	for rows.Next() {
+		evalCase, err := scanEvalCase(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan eval case: %w", err)
+		}
+		cases = append(cases, *evalCase)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate eval cases: %w", err)
+	}
+
+	return cases, nil
+}
+
+// UpdateCase saves a case's editable fields
+func (r *PostgresEvalRepository) UpdateCase(ctx context.Context, evalCase *llmModels.EvalCase) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET name = $3, prompt = $4, criteria = $5, updated_at = NOW()
+		WHERE id = $1 AND project_id = $2
+		RETURNING updated_at
+	`, r.tables.EvalCases)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		evalCase.ID,
+		evalCase.ProjectID,
+		evalCase.Name,
+		evalCase.Prompt,
+		evalCriteria(evalCase.Criteria),
+	).Scan(&evalCase.UpdatedAt)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return fmt.Errorf("eval case %s: %w", evalCase.ID, domain.ErrNotFound)
+		}
+		if postgres.IsPgDuplicateError(err) {
+			return fmt.Errorf("%w: eval case '%s' already exists", domain.ErrConflict, evalCase.Name)
+		}
+		return fmt.Errorf("update eval case: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCase removes a case (its results cascade)
+func (r *PostgresEvalRepository) DeleteCase(ctx context.Context, caseID, projectID string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1 AND project_id = $2`, r.tables.EvalCases)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, caseID, projectID)
+	if err != nil {
+		return fmt.Errorf("delete eval case: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("eval case %s: %w", caseID, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// CreateRun inserts a run
+func (r *PostgresEvalRepository) CreateRun(ctx context.Context, run *llmModels.EvalRun) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (project_id, user_id, label, models, skills, case_ids, judge_model)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`, r.tables.EvalRuns)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		run.ProjectID,
+		run.UserID,
+		run.Label,
+		evalStrings(run.Models),
+		evalStrings(run.Skills),
+		evalStrings(run.CaseIDs),
+		run.JudgeModel,
+	).Scan(&run.ID, &run.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create eval run: %w", err)
+	}
+
+	return nil
+}
+
+// SetRunJob links a run to its job
+func (r *PostgresEvalRepository) SetRunJob(ctx context.Context, runID, jobID string) error {
+	query := fmt.Sprintf(`UPDATE %s SET job_id = $2 WHERE id = $1`, r.tables.EvalRuns)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, runID, jobID)
+	if err != nil {
+		return fmt.Errorf("set eval run job: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("eval run %s: %w", runID, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// DeleteRun removes a run (its results cascade)
+func (r *PostgresEvalRepository) DeleteRun(ctx context.Context, runID string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, r.tables.EvalRuns)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	if _, err := executor.Exec(ctx, query, runID); err != nil {
+		return fmt.Errorf("delete eval run: %w", err)
+	}
+	return nil
+}
+
+// GetRun retrieves a run scoped to its project
+func (r *PostgresEvalRepository) GetRun(ctx context.Context, runID, projectID string) (*llmModels.EvalRun, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s r
+		LEFT JOIN %s j ON j.id = r.job_id
+		WHERE r.id = $1 AND r.project_id = $2
+	`, evalRunColumns, r.tables.EvalRuns, r.tables.Jobs)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	run, err := scanEvalRun(executor.QueryRow(ctx, query, runID, projectID))
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("eval run %s: %w", runID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get eval run: %w", err)
+	}
+
+	return run, nil
+}
+
+// ListRuns returns a project's runs, newest first
+func (r *PostgresEvalRepository) ListRuns(ctx context.Context, projectID string, limit int) ([]llmModels.EvalRun, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s r
+		LEFT JOIN %s j ON j.id = r.job_id
+		WHERE r.project_id = $1
+		ORDER BY r.created_at DESC
+		LIMIT $2
+	`, evalRunColumns, r.tables.EvalRuns, r.tables.Jobs)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, projectID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list eval runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := []llmModels.EvalRun{}
+	for rows.Next() {
+		run, err := scanEvalRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan eval run: %w", err)
+		}
+		runs = append(runs, *run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate eval runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+// CompleteRun saves a run's summary
+func (r *PostgresEvalRepository) CompleteRun(ctx context.Context, runID string, summary []llmModels.EvalModelSummary) error {
+	if summary == nil {
+		summary = []llmModels.EvalModelSummary{}
+	}
+	query := fmt.Sprintf(`UPDATE %s SET summary = $2 WHERE id = $1`, r.tables.EvalRuns)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	if _, err := executor.Exec(ctx, query, runID, summary); err != nil {
+		return fmt.Errorf("complete eval run: %w", err)
+	}
+	return nil
+}
+
+// SaveResult inserts a result
+func (r *PostgresEvalRepository) SaveResult(ctx context.Context, result *llmModels.EvalResult) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (run_id, case_id, case_name, model, output, passed, score, assertions,
+			input_tokens, output_tokens, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at
+	`, r.tables.EvalResults)
+
+	assertions := result.Assertions
+	if assertions == nil {
+		assertions = []llmModels.EvalAssertion{}
+	}
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		result.RunID,
+		result.CaseID,
+		result.CaseName,
+		result.Model,
+		result.Output,
+		result.Passed,
+		result.Score,
+		assertions,
+		result.InputTokens,
+		result.OutputTokens,
+		result.Error,
+	).Scan(&result.ID, &result.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("save eval result: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteResults removes a run's results
+func (r *PostgresEvalRepository) DeleteResults(ctx context.Context, runID string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE run_id = $1`, r.tables.EvalResults)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	if _, err := executor.Exec(ctx, query, runID); err != nil {
+		return fmt.Errorf("delete eval results: %w", err)
+	}
+	return nil
+}
+
+// ListResults returns a run's results ordered by case name, then model
+func (r *PostgresEvalRepository) ListResults(ctx context.Context, runID string) ([]llmModels.EvalResult, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE run_id = $1
+		ORDER BY case_name, model
+	`, evalResultColumns, r.tables.EvalResults)
+
+	return r.queryResults(ctx, query, runID)
+}
+
+// ListCaseResults returns a case's results across runs, newest first
+func (r *PostgresEvalRepository) ListCaseResults(ctx context.Context, caseID string, limit int) ([]llmModels.EvalResult, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE case_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, evalResultColumns, r.tables.EvalResults)
+
+	return r.queryResults(ctx, query, caseID, limit)
+}
+
+func (r *PostgresEvalRepository) queryResults(ctx context.Context, query string, args ...interface{}) ([]llmModels.EvalResult, error) {
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list eval results: %w", err)
+	}
+	defer rows.Close()
+
+	results := []llmModels.EvalResult{}
+	for rows.Next() {
+		result, err := scanEvalResult(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan eval result: %w", err)
+		}
+		results = append(results, *result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate eval results: %w", err)
+	}
+
+	return results, nil
+}
+
+func scanEvalCase(row pgx.Row) (*llmModels.EvalCase, error) {
+	var evalCase llmModels.EvalCase
+	err := row.Scan(
+		&evalCase.ID,
+		&evalCase.ProjectID,
+		&evalCase.Name,
+		&evalCase.Prompt,
+		&evalCase.Criteria,
+		&evalCase.CreatedAt,
+		&evalCase.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &evalCase, nil
+}
+
+func scanEvalRun(row pgx.Row) (*llmModels.EvalRun, error) {
+	var run llmModels.EvalRun
+	err := row.Scan(
+		&run.ID,
+		&run.ProjectID,
+		&run.UserID,
+		&run.JobID,
+		&run.Label,
+		&run.Models,
+		&run.Skills,
+		&run.CaseIDs,
+		&run.JudgeModel,
+		&run.Status,
+		&run.Error,
+		&run.Summary,
+		&run.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func scanEvalResult(row pgx.Row) (*llmModels.EvalResult, error) {
+	var result llmModels.EvalResult
+	err := row.Scan(
+		&result.ID,
+		&result.RunID,
+		&result.CaseID,
+		&result.CaseName,
+		&result.Model,
+		&result.Output,
+		&result.Passed,
+		&result.Score,
+		&result.Assertions,
+		&result.InputTokens,
+		&result.OutputTokens,
+		&result.Error,
+		&result.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// evalCriteria replaces nil criteria so the NOT NULL column gets '[]'
+func evalCriteria(criteria []llmModels.EvalCriterion) []llmModels.EvalCriterion {
+	if criteria == nil {
+		return []llmModels.EvalCriterion{}
+	}
+	return criteria
+}
+
+// evalStrings replaces nil lists so the NOT NULL columns get '[]'
+func evalStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
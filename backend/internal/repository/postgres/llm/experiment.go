@@ -0,0 +1,241 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/repository/postgres"
+)
+
+// PostgresExperimentRepository implements the ExperimentRepository interface
+type PostgresExperimentRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewExperimentRepository creates a new experiment repository
+func NewExperimentRepository(config *postgres.RepositoryConfig) llmRepo.ExperimentRepository {
+	return &PostgresExperimentRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+const experimentColumns = `id, name, description, unit, status, variants, created_at, updated_at`
+
+// CreateExperiment inserts an experiment
+func (r *PostgresExperimentRepository) CreateExperiment(ctx context.Context, experiment *llmModels.Experiment) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (name, description, unit, status, variants)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`, r.tables.Experiments)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		experiment.Name,
+		experiment.Description,
+		experiment.Unit,
+		experiment.Status,
+		experiment.Variants,
+	).Scan(&experiment.ID, &experiment.CreatedAt, &experiment.UpdatedAt)
+	if err != nil {
+		if postgres.IsPgDuplicateError(err) {
+			return fmt.Errorf("%w: experiment '%s' already exists", domain.ErrConflict, experiment.Name)
+		}
+		return fmt.Errorf("create experiment: %w", err)
+	}
+
+	return nil
+}
+
+// GetExperiment retrieves an experiment
+func (r *PostgresExperimentRepository) GetExperiment(ctx context.Context, experimentID string) (*llmModels.Experiment, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE id = $1`, experimentColumns, r.tables.Experiments)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	experiment, err := scanExperiment(executor.QueryRow(ctx, query, experimentID))
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("experiment %s: %w", experimentID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get experiment: %w", err)
+	}
+
+	return experiment, nil
+}
+
+// ListExperiments returns all experiments, newest first
+func (r *PostgresExperimentRepository) ListExperiments(ctx context.Context) ([]llmModels.Experiment, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %s ORDER BY created_at DESC`, experimentColumns, r.tables.Experiments)
+	return r.listExperiments(ctx, query)
+}
+
+// ListActiveExperiments returns the active experiments, oldest first
+func (r *PostgresExperimentRepository) ListActiveExperiments(ctx context.Context) ([]llmModels.Experiment, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %s
+		WHERE status = 'active'
+		ORDER BY created_at, id
+	`, experimentColumns, r.tables.Experiments)
+	return r.listExperiments(ctx, query)
+}
+
+func (r *PostgresExperimentRepository) listExperiments(ctx context.Context, query string) ([]llmModels.Experiment, error) {
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list experiments: %w", err)
+	}
+	defer rows.Close()
+
+	experiments := []llmModels.Experiment{}
+	for rows.Next() {
+		experiment, err := scanExperiment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan experiment: %w", err)
+		}
+		experiments = append(experiments, *experiment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate experiments: %w", err)
+	}
+
+	return experiments, nil
+}
+
+// UpdateExperimentStatus sets an experiment's status
+func (r *PostgresExperimentRepository) UpdateExperimentStatus(ctx context.Context, experimentID, status string) error {
+	query := fmt.Sprintf(`
+		UPDATE %s SET status = $2, updated_at = NOW()
+		WHERE id = $1
+	`, r.tables.Experiments)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, experimentID, status)
+	if err != nil {
+		return fmt.Errorf("update experiment status: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("experiment %s: %w", experimentID, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+// RecordTurnVariants stores the variants an assistant turn ran with
+func (r *PostgresExperimentRepository) RecordTurnVariants(ctx context.Context, turnID string, assignments []llmModels.ExperimentAssignment) error {
+	if len(assignments) == 0 {
+		return nil
+	}
+
+	experimentIDs := make([]string, len(assignments))
+	variants := make([]string, len(assignments))
+	for i, assignment := range assignments {
+		experimentIDs[i] = assignment.ExperimentID
+		variants[i] = assignment.Variant.Name
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (turn_id, experiment_id, variant)
+		SELECT $1, e.experiment_id, e.variant
+		FROM unnest($2::uuid[], $3::text[]) AS e(experiment_id, variant)
+		ON CONFLICT (turn_id, experiment_id) DO NOTHING
+	`, r.tables.TurnExperiments)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	if _, err := executor.Exec(ctx, query, turnID, experimentIDs, variants); err != nil {
+		return fmt.Errorf("record turn variants: %w", err)
+	}
+
+	return nil
+}
+
+// GetVariantResults aggregates usage and feedback of an experiment's turns by variant
+func (r *PostgresExperimentRepository) GetVariantResults(ctx context.Context, experimentID string) ([]llmModels.ExperimentVariantResult, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			te.variant,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE t.status = 'error'),
+			COALESCE(SUM(t.input_tokens), 0),
+			COALESCE(SUM(t.output_tokens), 0),
+			COALESCE(SUM(t.cost_usd), 0)::float8,
+			COUNT(*) FILTER (WHERE t.feedback = 1),
+			COUNT(*) FILTER (WHERE t.feedback = -1)
+		FROM %s te
+		JOIN %s t ON t.id = te.turn_id
+		WHERE te.experiment_id = $1
+		GROUP BY te.variant
+		ORDER BY te.variant
+	`, r.tables.TurnExperiments, r.tables.Turns)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, experimentID)
+	if err != nil {
+		return nil, fmt.Errorf("query variant results: %w", err)
+	}
+	defer rows.Close()
+
+	results := []llmModels.ExperimentVariantResult{}
+	for rows.Next() {
+		var result llmModels.ExperimentVariantResult
+		if err := rows.Scan(
+			&result.Variant,
+			&result.Turns,
+			&result.ErrorTurns,
+			&result.InputTokens,
+			&result.OutputTokens,
+			&result.CostUSD,
+			&result.FeedbackUp,
+			&result.FeedbackDown,
+		); err != nil {
+			return nil, fmt.Errorf("scan variant result: %w", err)
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate variant results: %w", err)
+	}
+
+	return results, nil
+}
+
+// SetTurnFeedback sets or clears a turn's rating
+func (r *PostgresExperimentRepository) SetTurnFeedback(ctx context.Context, turnID string, rating *int) error {
+	query := fmt.Sprintf(`UPDATE %s SET feedback = $2 WHERE id = $1`, r.tables.Turns)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, turnID, rating)
+	if err != nil {
+		return fmt.Errorf("set turn feedback: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("turn %s: %w", turnID, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func scanExperiment(row pgx.Row) (*llmModels.Experiment, error) {
+	var experiment llmModels.Experiment
+	err := row.Scan(
+		&experiment.ID,
+		&experiment.Name,
+		&experiment.Description,
+		&experiment.Unit,
+		&experiment.Status,
+		&experiment.Variants,
+		&experiment.CreatedAt,
+		&experiment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &experiment, nil
+}
@@ -0,0 +1,76 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/repository/postgres"
+)
+
+// PostgresPinRepository implements the PinRepository interface
+type PostgresPinRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewPinRepository creates a new pin repository
+func NewPinRepository(config *postgres.RepositoryConfig) llmRepo.PinRepository {
+	return &PostgresPinRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+// CreatePin inserts a pin and returns the turn's chat ID with it
+func (r *PostgresPinRepository) CreatePin(ctx context.Context, pin *llmModels.TurnPin) error {
+	query := fmt.Sprintf(`
+		WITH pin AS (
+			INSERT INTO %s (document_id, turn_id)
+			VALUES ($1, $2)
+			RETURNING id, turn_id, created_at
+		)
+		SELECT pin.id, t.chat_id, pin.created_at
+		FROM pin
+		INNER JOIN %s t ON t.id = pin.turn_id
+	`, r.tables.TurnPins, r.tables.Turns)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, pin.DocumentID, pin.TurnID).Scan(&pin.ID, &pin.ChatID, &pin.CreatedAt)
+	if err != nil {
+		if postgres.IsPgDuplicateError(err) {
+			return fmt.Errorf("document %s is already pinned: %w", pin.DocumentID, domain.ErrConflict)
+		}
+		if postgres.IsPgForeignKeyError(err) {
+			return fmt.Errorf("turn %s or document %s: %w", pin.TurnID, pin.DocumentID, domain.ErrNotFound)
+		}
+		return fmt.Errorf("create turn pin: %w", err)
+	}
+
+	return nil
+}
+
+// GetPinByDocument retrieves a document's pin with the chat of its source turn
+func (r *PostgresPinRepository) GetPinByDocument(ctx context.Context, documentID string) (*llmModels.TurnPin, error) {
+	query := fmt.Sprintf(`
+		SELECT p.id, p.document_id, p.turn_id, t.chat_id, p.created_at
+		FROM %s p
+		INNER JOIN %s t ON t.id = p.turn_id
+		WHERE p.document_id = $1
+	`, r.tables.TurnPins, r.tables.Turns)
+
+	var pin llmModels.TurnPin
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, documentID).Scan(&pin.ID, &pin.DocumentID, &pin.TurnID, &pin.ChatID, &pin.CreatedAt)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("pin for document %s: %w", documentID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get turn pin: %w", err)
+	}
+
+	return &pin, nil
+}
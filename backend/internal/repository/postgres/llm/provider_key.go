@@ -0,0 +1,136 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/repository/postgres"
+)
+
+// PostgresProviderKeyRepository implements the ProviderKeyRepository interface
+type PostgresProviderKeyRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewProviderKeyRepository creates a new provider key repository
+func NewProviderKeyRepository(config *postgres.RepositoryConfig) llmRepo.ProviderKeyRepository {
+	return &PostgresProviderKeyRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+// UpsertKey stores a user's key for a provider, replacing any existing one
+func (r *PostgresProviderKeyRepository) UpsertKey(ctx context.Context, key *llmModels.UserProviderKey) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (user_id, provider, encrypted_key, key_hint)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, provider) DO UPDATE SET
+			encrypted_key = EXCLUDED.encrypted_key,
+			key_hint = EXCLUDED.key_hint,
+			updated_at = NOW()
+		RETURNING id, created_at, updated_at
+	`, r.tables.UserProviderKeys)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query, key.UserID, key.Provider, key.EncryptedKey, key.KeyHint).
+		Scan(&key.ID, &key.CreatedAt, &key.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("upsert provider key: %w", err)
+	}
+
+	return nil
+}
+
+// ListKeys returns a user's keys ordered by provider
+func (r *PostgresProviderKeyRepository) ListKeys(ctx context.Context, userID string) ([]llmModels.UserProviderKey, error) {
+	query := fmt.Sprintf(`
+		SELECT id, user_id, provider, encrypted_key, key_hint, created_at, updated_at
+		FROM %s
+		WHERE user_id = $1
+		ORDER BY provider
+	`, r.tables.UserProviderKeys)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list provider keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []llmModels.UserProviderKey{}
+	for rows.Next() {
+		key, err := scanProviderKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan provider key: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate provider keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// GetKey returns a user's key for a provider
+func (r *PostgresProviderKeyRepository) GetKey(ctx context.Context, userID, provider string) (*llmModels.UserProviderKey, error) {
+	query := fmt.Sprintf(`
+		SELECT id, user_id, provider, encrypted_key, key_hint, created_at, updated_at
+		FROM %s
+		WHERE user_id = $1 AND provider = $2
+	`, r.tables.UserProviderKeys)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	key, err := scanProviderKey(executor.QueryRow(ctx, query, userID, provider))
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("%s key: %w", provider, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get provider key: %w", err)
+	}
+
+	return key, nil
+}
+
+// DeleteKey removes a user's key for a provider
+func (r *PostgresProviderKeyRepository) DeleteKey(ctx context.Context, userID, provider string) error {
+	query := fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE user_id = $1 AND provider = $2
+	`, r.tables.UserProviderKeys)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, userID, provider)
+	if err != nil {
+		return fmt.Errorf("delete provider key: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s key: %w", provider, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
+func scanProviderKey(row pgx.Row) (*llmModels.UserProviderKey, error) {
+	var key llmModels.UserProviderKey
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.Provider,
+		&key.EncryptedKey,
+		&key.KeyHint,
+		&key.CreatedAt,
+		&key.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
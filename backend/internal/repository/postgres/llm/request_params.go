@@ -0,0 +1,93 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+// request_params.go - Conversion between the typed RequestParams struct and the
+// turns.request_params JSONB column.
+//
+// This is the ONLY place that sees request_params as a map. Services work with
+// *llmModels.RequestParams exclusively.
+//
+// Migration path for stored blobs:
+// Rows written before the typed struct existed may contain keys the struct doesn't
+// know (ParseJSON never rejected them) or values with the wrong JSON type. Reading
+// must never fail because of an old blob, so decoding is tolerant:
+//   1. Try to decode the whole blob
+//   2. If that fails (RequestParams rejects unknown keys), decode key by key and drop
+//      only the keys that don't fit
+// Blobs are upgraded lazily: the next write of the turn stores the typed shape.
+
+// requestParamsToJSONB converts typed request params into a map for the JSONB column.
+// nil params become NULL.
+func requestParamsToJSONB(params *llmModels.RequestParams) (map[string]interface{}, error) {
+	if params == nil {
+		return nil, nil
+	}
+
+	jsonBytes, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request params: %w", err)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(jsonBytes, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal request params: %w", err)
+	}
+
+	return result, nil
+}
+
+// requestParamsFromJSONB converts a JSONB map (as scanned by pgx) into typed request params.
+// NULL becomes nil. Never fails: keys that can't be decoded are logged and dropped.
+func requestParamsFromJSONB(raw map[string]interface{}, turnID string, logger *slog.Logger) *llmModels.RequestParams {
+	if raw == nil {
+		return nil
+	}
+
+	// Fast path: blob matches the current schema
+	if jsonBytes, err := json.Marshal(raw); err == nil {
+		var params llmModels.RequestParams
+		if err := json.Unmarshal(jsonBytes, &params); err == nil {
+			return &params
+		}
+	}
+
+	// Slow path: legacy blob with at least one incompatible value.
+	// Check each key on its own so one bad value doesn't discard the rest.
+	compatible := make(map[string]interface{}, len(raw))
+	var dropped []string
+	for key, value := range raw {
+		single, err := json.Marshal(map[string]interface{}{key: value})
+		if err != nil {
+			dropped = append(dropped, key)
+			continue
+		}
+		var probe llmModels.RequestParams
+		if err := json.Unmarshal(single, &probe); err != nil {
+			dropped = append(dropped, key)
+			continue
+		}
+		compatible[key] = value
+	}
+
+	// Every remaining key decoded on its own, so the combined decode can't fail
+	params := &llmModels.RequestParams{}
+	if jsonBytes, err := json.Marshal(compatible); err == nil {
+		_ = json.Unmarshal(jsonBytes, params)
+	}
+
+	if logger != nil {
+		logger.Warn("dropped incompatible keys from stored request_params",
+			"turn_id", turnID,
+			"dropped_keys", dropped,
+		)
+	}
+
+	return params
+}
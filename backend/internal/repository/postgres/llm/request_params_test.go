@@ -0,0 +1,69 @@
+package llm
+
+import (
+	"testing"
+
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+func TestRequestParamsFromJSONB(t *testing.T) {
+	t.Run("nil blob stays nil", func(t *testing.T) {
+		if got := requestParamsFromJSONB(nil, "turn-1", nil); got != nil {
+			t.Fatalf("expected nil, got %+v", got)
+		}
+	})
+
+	t.Run("current schema decodes fully", func(t *testing.T) {
+		raw := map[string]interface{}{
+			"model":      "claude-haiku-4-5",
+			"provider":   "anthropic",
+			"max_tokens": float64(1024), // pgx decodes JSON numbers as float64
+			"tools":      []interface{}{map[string]interface{}{"name": "doc_view"}},
+		}
+
+		got := requestParamsFromJSONB(raw, "turn-1", nil)
+		if got.GetProvider() != "anthropic" {
+			t.Errorf("provider = %q, want anthropic", got.GetProvider())
+		}
+		if got.MaxTokens == nil || *got.MaxTokens != 1024 {
+			t.Errorf("max_tokens = %v, want 1024", got.MaxTokens)
+		}
+		if names := got.ToolNames(); len(names) != 1 || names[0] != "doc_view" {
+			t.Errorf("tool names = %v, want [doc_view]", names)
+		}
+	})
+
+	t.Run("legacy blob keeps compatible keys", func(t *testing.T) {
+		raw := map[string]interface{}{
+			"provider":     "openrouter",
+			"temperature":  "warm", // wrong type in an old blob
+			"custom_param": true,   // unknown key from before the typed struct
+		}
+
+		got := requestParamsFromJSONB(raw, "turn-1", nil)
+		if got.GetProvider() != "openrouter" {
+			t.Errorf("provider = %q, want openrouter", got.GetProvider())
+		}
+		if got.Temperature != nil {
+			t.Errorf("temperature = %v, want nil (dropped)", *got.Temperature)
+		}
+	})
+}
+
+func TestRequestParamsToJSONB(t *testing.T) {
+	if got, err := requestParamsToJSONB(nil); err != nil || got != nil {
+		t.Fatalf("expected nil map and no error, got %v, %v", got, err)
+	}
+
+	provider := "anthropic"
+	got, err := requestParamsToJSONB(&llmModels.RequestParams{Provider: &provider})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got["provider"] != "anthropic" {
+		t.Errorf("provider = %v, want anthropic", got["provider"])
+	}
+	if _, ok := got["model"]; ok {
+		t.Errorf("unset fields should be omitted, got model = %v", got["model"])
+	}
+}
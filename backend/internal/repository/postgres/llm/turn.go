@@ -8,10 +8,10 @@ import (
 
 	"meridian/internal/domain"
 	llmModels "meridian/internal/domain/models/llm"
+	"meridian/internal/domain/repositories"
 	llmRepo "meridian/internal/domain/repositories/llm"
 	"meridian/internal/repository/postgres"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -68,18 +68,23 @@ func (r *PostgresTurnRepository) CreateTurn(ctx context.Context, turn *llmModels
 		}
 	}
 
+	requestParams, err := requestParamsToJSONB(turn.RequestParams)
+	if err != nil {
+		return fmt.Errorf("create turn: %w", err)
+	}
+
 	query := fmt.Sprintf(`
 		INSERT INTO %s (
 			chat_id, prev_turn_id, role, status, error,
 			model, input_tokens, output_tokens, created_at, completed_at,
-			request_params, stop_reason, response_metadata
+			request_params, stop_reason, response_metadata, system_prompt_tokens, model_routing, language
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 		RETURNING id, created_at
 	`, r.tables.Turns)
 
 	executor := postgres.GetExecutor(ctx, r.pool)
-	err := executor.QueryRow(ctx, query,
+	err = executor.QueryRow(ctx, query,
 		turn.ChatID,
 		turn.PrevTurnID,
 		turn.Role,
@@ -90,9 +95,12 @@ func (r *PostgresTurnRepository) CreateTurn(ctx context.Context, turn *llmModels
 		turn.OutputTokens,
 		turn.CreatedAt,
 		turn.CompletedAt,
-		turn.RequestParams,    // pgx handles map -> JSONB (nil becomes NULL)
+		requestParams,         // pgx handles map -> JSONB (nil becomes NULL)
 		turn.StopReason,       // TEXT
 		turn.ResponseMetadata, // pgx handles map -> JSONB (nil becomes NULL)
+		turn.SystemPromptTokens,
+		turn.ModelRouting, // pgx handles struct -> JSONB (nil becomes NULL)
+		turn.Language,
 	).Scan(&turn.ID, &turn.CreatedAt)
 
 	if err != nil {
@@ -102,12 +110,73 @@ func (r *PostgresTurnRepository) CreateTurn(ctx context.Context, turn *llmModels
 		return fmt.Errorf("create turn: %w", err)
 	}
 
+	// A new message or regeneration becomes the branch pagination follows
+	if turn.PrevTurnID != nil {
+		if err := activateTurnPath(ctx, executor, r.tables, turn.ID); err != nil {
+			return fmt.Errorf("create turn: %w", err)
+		}
+	}
+
 	return nil
 }
 
-// turnExists checks if a turn exists
+// activateTurnPath points every ancestor of turnID at the child on the path to it
+// (active_child_turn_id), so forward pagination and leaf resolution follow this branch.
+// Only pointers that change are written.
+func activateTurnPath(ctx context.Context, executor repositories.DBTX, tables *postgres.TableNames, turnID string) error {
+	query := fmt.Sprintf(`
+		WITH RECURSIVE turn_path AS (
+			SELECT id, prev_turn_id, 1 AS depth
+			FROM %[1]s
+			WHERE id = $1
+
+			UNION ALL
+
+			SELECT t.id, t.prev_turn_id, tp.depth + 1
+			FROM %[1]s t
+			INNER JOIN turn_path tp ON t.id = tp.prev_turn_id
+			WHERE tp.depth < $2
+		)
+		UPDATE %[1]s t
+		SET active_child_turn_id = tp.id
+		FROM turn_path tp
+		WHERE t.id = tp.prev_turn_id
+		  AND t.active_child_turn_id IS DISTINCT FROM tp.id
+	`, tables.Turns)
+
+	if _, err := executor.Exec(ctx, query, turnID, MaxRecursionDepth); err != nil {
+		return fmt.Errorf("activate turn path: %w", err)
+	}
+	return nil
+}
+
+// activeChildQuery selects the child of the turn parentExpr that forward navigation
+// follows: the parent's active_child_turn_id (the branch last viewed or created), else
+// its most recent child
+func (r *PostgresTurnRepository) activeChildQuery(parentExpr string) string {
+	return fmt.Sprintf(`
+				SELECT c.id FROM %[1]s c
+				WHERE c.prev_turn_id = %[2]s
+				ORDER BY c.id IS NOT DISTINCT FROM (SELECT active_child_turn_id FROM %[1]s WHERE id = %[2]s) DESC,
+				         c.created_at DESC
+				LIMIT 1`, r.tables.Turns, parentExpr)
+}
+
+// liveChatCondition restricts turns (aliased t) to chats that are not soft-deleted and
+// belong to a project that isn't either (deleting a project leaves its chats' deleted_at
+// unset). Turns of a deleted chat stay in the table until retention purges the chat, but
+// are treated as not found everywhere they are looked up by ID.
+func (r *PostgresTurnRepository) liveChatCondition() string {
+	return fmt.Sprintf(`EXISTS (
+		SELECT 1 FROM %s c
+		JOIN %s p ON p.id = c.project_id AND p.deleted_at IS NULL
+		WHERE c.id = t.chat_id AND c.deleted_at IS NULL
+	)`, r.tables.Chats, r.tables.Projects)
+}
+
+// turnExists checks if a turn exists in a live chat
 func (r *PostgresTurnRepository) turnExists(ctx context.Context, turnID string) (bool, error) {
-	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE id = $1)`, r.tables.Turns)
+	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s t WHERE t.id = $1 AND %s)`, r.tables.Turns, r.liveChatCondition())
 
 	var exists bool
 	executor := postgres.GetExecutor(ctx, r.pool)
@@ -129,6 +198,7 @@ type scanner interface {
 // Works with both pgx.Row (from QueryRow) and pgx.Rows (from Query)
 func (r *PostgresTurnRepository) scanTurnRow(row scanner) (*llmModels.Turn, error) {
 	var turn llmModels.Turn
+	var requestParams map[string]interface{}
 	err := row.Scan(
 		&turn.ID,
 		&turn.ChatID,
@@ -141,13 +211,19 @@ func (r *PostgresTurnRepository) scanTurnRow(row scanner) (*llmModels.Turn, erro
 		&turn.OutputTokens,
 		&turn.CreatedAt,
 		&turn.CompletedAt,
-		&turn.RequestParams,    // pgx handles JSONB -> map
+		&requestParams,         // pgx handles JSONB -> map
 		&turn.StopReason,       // TEXT
 		&turn.ResponseMetadata, // pgx handles JSONB -> map
+		&turn.SystemPromptTokens,
+		&turn.CostUSD,
+		&turn.ModelRouting, // pgx handles JSONB -> struct (NULL becomes nil)
+		&turn.Annotations,
+		&turn.Language,
 	)
 	if err != nil {
 		return nil, err
 	}
+	turn.RequestParams = requestParamsFromJSONB(requestParams, turn.ID, r.logger)
 	return &turn, nil
 }
 
@@ -156,10 +232,10 @@ func (r *PostgresTurnRepository) GetTurn(ctx context.Context, turnID string) (*l
 	query := fmt.Sprintf(`
 		SELECT id, chat_id, prev_turn_id, role, status, error,
 		       model, input_tokens, output_tokens, created_at, completed_at,
-		       request_params, stop_reason, response_metadata
-		FROM %s
-		WHERE id = $1
-	`, r.tables.Turns)
+		       request_params, stop_reason, response_metadata, system_prompt_tokens, cost_usd, model_routing, annotations, language
+		FROM %s t
+		WHERE t.id = $1 AND %s
+	`, r.tables.Turns, r.liveChatCondition())
 
 	executor := postgres.GetExecutor(ctx, r.pool)
 	turn, err := r.scanTurnRow(executor.QueryRow(ctx, query, turnID))
@@ -173,35 +249,124 @@ func (r *PostgresTurnRepository) GetTurn(ctx context.Context, turnID string) (*l
 	return turn, nil
 }
 
+// GetTurns retrieves turns by ID in a single query, in the order of turnIDs
+func (r *PostgresTurnRepository) GetTurns(ctx context.Context, turnIDs []string) ([]llmModels.Turn, error) {
+	turns := []llmModels.Turn{}
+	if len(turnIDs) == 0 {
+		return turns, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, chat_id, prev_turn_id, role, status, error,
+		       model, input_tokens, output_tokens, created_at, completed_at,
+		       request_params, stop_reason, response_metadata, system_prompt_tokens, cost_usd, model_routing, annotations, language
+		FROM %s t
+		WHERE t.id = ANY($1) AND %s
+		ORDER BY array_position($1::uuid[], t.id)
+	`, r.tables.Turns, r.liveChatCondition())
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, turnIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get turns: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		turn, err := r.scanTurnRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan turn: %w", err)
+		}
+		turns = append(turns, *turn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate turns: %w", err)
+	}
+
+	return turns, nil
+}
+
+// GetTurnOwnerIDs maps turn IDs to their owners, skipping turns of deleted chats or projects
+// The owner comes from turns.user_id; the joins only filter out soft-deleted chats and projects
+func (r *PostgresTurnRepository) GetTurnOwnerIDs(ctx context.Context, turnIDs []string) (map[string]string, error) {
+	ownerIDs := make(map[string]string, len(turnIDs))
+	if len(turnIDs) == 0 {
+		return ownerIDs, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT t.id, t.user_id
+		FROM %s t
+		JOIN %s c ON c.id = t.chat_id AND c.deleted_at IS NULL
+		JOIN %s p ON p.id = c.project_id AND p.deleted_at IS NULL
+		WHERE t.id = ANY($1)
+	`, r.tables.Turns, r.tables.Chats, r.tables.Projects)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, turnIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get turn owners: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var turnID, ownerID string
+		if err := rows.Scan(&turnID, &ownerID); err != nil {
+			return nil, fmt.Errorf("scan turn owner: %w", err)
+		}
+		ownerIDs[turnID] = ownerID
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate turn owners: %w", err)
+	}
+
+	return ownerIDs, nil
+}
+
 // GetTurnPath retrieves the conversation path from a turn to the root
 // Returns turns in order from root to the specified turn
 func (r *PostgresTurnRepository) GetTurnPath(ctx context.Context, turnID string) ([]llmModels.Turn, error) {
+	return r.getTurnPath(ctx, turnID, MaxRecursionDepth)
+}
+
+// GetTurnPathWindow retrieves the last turns (at most last) of the path ending at a turn
+// The recursion stops at the window, so deep conversations don't read their early turns
+// Returns turns in path order, ending with the specified turn
+func (r *PostgresTurnRepository) GetTurnPathWindow(ctx context.Context, turnID string, last int) ([]llmModels.Turn, error) {
+	if last <= 0 || last > MaxRecursionDepth {
+		last = MaxRecursionDepth
+	}
+	return r.getTurnPath(ctx, turnID, last)
+}
+
+// getTurnPath walks from a turn towards the root, at most maxDepth turns
+func (r *PostgresTurnRepository) getTurnPath(ctx context.Context, turnID string, maxDepth int) ([]llmModels.Turn, error) {
 	// Recursive CTE to traverse from turn to root, then reverse the order
 	query := fmt.Sprintf(`
 		WITH RECURSIVE turn_path AS (
 			-- Base case: start with the specified turn
 			SELECT id, chat_id, prev_turn_id, role, status, error,
 			       model, input_tokens, output_tokens, created_at, completed_at,
-			       request_params, stop_reason, response_metadata, 1 as depth
-			FROM %s
-			WHERE id = $1
+			       request_params, stop_reason, response_metadata, system_prompt_tokens, cost_usd, model_routing, annotations, language, 1 as depth
+			FROM %s t
+			WHERE t.id = $1 AND %s
 
 			UNION ALL
 
 			-- Recursive case: get prev turns
 			SELECT t.id, t.chat_id, t.prev_turn_id, t.role, t.status, t.error,
 			       t.model, t.input_tokens, t.output_tokens, t.created_at, t.completed_at,
-			       t.request_params, t.stop_reason, t.response_metadata, tp.depth + 1
+			       t.request_params, t.stop_reason, t.response_metadata, t.system_prompt_tokens, t.cost_usd, t.model_routing, t.annotations, t.language, tp.depth + 1
 			FROM %s t
 			INNER JOIN turn_path tp ON t.id = tp.prev_turn_id
-			WHERE tp.depth < %d  -- Prevent infinite recursion
+			WHERE tp.depth < %d  -- Window size (and guard against infinite recursion)
 		)
 		SELECT id, chat_id, prev_turn_id, role, status, error,
 		       model, input_tokens, output_tokens, created_at, completed_at,
-		       request_params, stop_reason, response_metadata
+		       request_params, stop_reason, response_metadata, system_prompt_tokens, cost_usd, model_routing, annotations, language
 		FROM turn_path
 		ORDER BY depth DESC  -- Root first, specified turn last
-	`, r.tables.Turns, r.tables.Turns, MaxRecursionDepth)
+	`, r.tables.Turns, r.liveChatCondition(), r.tables.Turns, maxDepth)
 
 	executor := postgres.GetExecutor(ctx, r.pool)
 	rows, err := executor.Query(ctx, query, turnID)
@@ -231,10 +396,15 @@ func (r *PostgresTurnRepository) GetTurnPath(ctx context.Context, turnID string)
 	return turns, nil
 }
 
-// GetTurnSiblings retrieves all sibling turns (including self) for a given turn
+// GetTurnSiblings retrieves sibling turns (including self) for a given turn
 // Siblings are turns that share the same prev_turn_id (alternative conversation branches)
-// Returns turns with blocks nested, ordered by created_at
-func (r *PostgresTurnRepository) GetTurnSiblings(ctx context.Context, turnID string) ([]llmModels.Turn, error) {
+// Returns one page of turns ordered by created_at (blocks nested if opts.IncludeBlocks),
+// plus the total sibling count
+func (r *PostgresTurnRepository) GetTurnSiblings(ctx context.Context, turnID string, opts *llmModels.TurnSiblingsOptions) (*llmModels.TurnSiblings, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: invalid sibling options: %v", domain.ErrValidation, err)
+	}
+
 	executor := postgres.GetExecutor(ctx, r.pool)
 
 	// First get the turn's prev_turn_id and chat_id
@@ -242,9 +412,9 @@ func (r *PostgresTurnRepository) GetTurnSiblings(ctx context.Context, turnID str
 	var chatID string
 	query := fmt.Sprintf(`
 		SELECT prev_turn_id, chat_id
-		FROM %s
-		WHERE id = $1
-	`, r.tables.Turns)
+		FROM %s t
+		WHERE t.id = $1 AND %s
+	`, r.tables.Turns, r.liveChatCondition())
 	err := executor.QueryRow(ctx, query, turnID).Scan(&prevTurnID, &chatID)
 	if err != nil {
 		if postgres.IsPgNoRowsError(err) {
@@ -253,34 +423,30 @@ func (r *PostgresTurnRepository) GetTurnSiblings(ctx context.Context, turnID str
 		return nil, fmt.Errorf("get turn prev_turn_id: %w", err)
 	}
 
-	// Get all turns with the same prev_turn_id (including self)
-	var siblingsQuery string
-	var rows pgx.Rows
+	// Siblings share the prev_turn_id (root turns: all root turns of the chat)
+	where := "chat_id = $1 AND prev_turn_id IS NULL"
+	args := []interface{}{chatID}
+	if prevTurnID != nil {
+		where = "prev_turn_id = $1"
+		args = []interface{}{*prevTurnID}
+	}
 
-	if prevTurnID == nil {
-		// Root turn - get all root turns for this chat
-		siblingsQuery = fmt.Sprintf(`
-			SELECT id, chat_id, prev_turn_id, role, status, error,
-			       model, input_tokens, output_tokens, created_at, completed_at,
-			       request_params, stop_reason, response_metadata
-			FROM %s
-			WHERE chat_id = $1 AND prev_turn_id IS NULL
-			ORDER BY created_at
-		`, r.tables.Turns)
-		rows, err = executor.Query(ctx, siblingsQuery, chatID)
-	} else {
-		// Non-root - get all turns with same prev_turn_id
-		siblingsQuery = fmt.Sprintf(`
-			SELECT id, chat_id, prev_turn_id, role, status, error,
-			       model, input_tokens, output_tokens, created_at, completed_at,
-			       request_params, stop_reason, response_metadata
-			FROM %s
-			WHERE prev_turn_id = $1
-			ORDER BY created_at
-		`, r.tables.Turns)
-		rows, err = executor.Query(ctx, siblingsQuery, *prevTurnID)
+	siblingsQuery := fmt.Sprintf(`
+		SELECT id, chat_id, prev_turn_id, role, status, error,
+		       model, input_tokens, output_tokens, created_at, completed_at,
+		       request_params, stop_reason, response_metadata, system_prompt_tokens, cost_usd, model_routing, annotations, language
+		FROM %s
+		WHERE %s
+		ORDER BY created_at
+	`, r.tables.Turns, where)
+	if opts.Limit > 0 {
+		siblingsQuery += fmt.Sprintf(" LIMIT %d", opts.Limit)
+	}
+	if opts.Offset > 0 {
+		siblingsQuery += fmt.Sprintf(" OFFSET %d", opts.Offset)
 	}
 
+	rows, err := executor.Query(ctx, siblingsQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query siblings: %w", err)
 	}
@@ -305,27 +471,38 @@ func (r *PostgresTurnRepository) GetTurnSiblings(ctx context.Context, turnID str
 		turns = []llmModels.Turn{}
 	}
 
-	// Batch load blocks for all siblings
-	turnIDs := make([]string, len(turns))
-	for i, turn := range turns {
-		turnIDs[i] = turn.ID
+	// Unpaginated requests already hold every sibling; otherwise count them (index-only on prev_turn_id)
+	total := len(turns)
+	if opts.Limit > 0 || opts.Offset > 0 {
+		countQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s`, r.tables.Turns, where)
+		if err := executor.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+			return nil, fmt.Errorf("count siblings: %w", err)
+		}
 	}
 
-	blocksByTurn, err := r.GetTurnBlocksForTurns(ctx, turnIDs)
-	if err != nil {
-		return nil, fmt.Errorf("get blocks for siblings: %w", err)
-	}
+	if opts.IncludeBlocks && len(turns) > 0 {
+		// Batch load blocks for all siblings
+		turnIDs := make([]string, len(turns))
+		for i, turn := range turns {
+			turnIDs[i] = turn.ID
+		}
 
-	// Nest blocks into each turn
-	for i := range turns {
-		if blocks, ok := blocksByTurn[turns[i].ID]; ok {
-			turns[i].Blocks = blocks
-		} else {
-			turns[i].Blocks = []llmModels.TurnBlock{}
+		blocksByTurn, err := r.GetTurnBlocksForTurns(ctx, turnIDs)
+		if err != nil {
+			return nil, fmt.Errorf("get blocks for siblings: %w", err)
+		}
+
+		// Nest blocks into each turn
+		for i := range turns {
+			if blocks, ok := blocksByTurn[turns[i].ID]; ok {
+				turns[i].Blocks = blocks
+			} else {
+				turns[i].Blocks = []llmModels.TurnBlock{}
+			}
 		}
 	}
 
-	return turns, nil
+	return &llmModels.TurnSiblings{Turns: turns, Total: total}, nil
 }
 
 // GetRootTurns retrieves all root turns for a specific chat
@@ -333,7 +510,7 @@ func (r *PostgresTurnRepository) GetRootTurns(ctx context.Context, chatID string
 	query := fmt.Sprintf(`
 		SELECT id, chat_id, prev_turn_id, role, status, error,
 		       model, input_tokens, output_tokens, created_at, completed_at,
-		       request_params, stop_reason, response_metadata
+		       request_params, stop_reason, response_metadata, system_prompt_tokens, cost_usd, model_routing, annotations, language
 		FROM %s
 		WHERE chat_id = $1 AND prev_turn_id IS NULL
 		ORDER BY created_at
@@ -390,17 +567,27 @@ func (r *PostgresTurnRepository) UpdateTurnStatus(ctx context.Context, turnID, s
 		return fmt.Errorf("turn %s: %w", turnID, domain.ErrNotFound)
 	}
 
+	if status == "complete" || status == "cancelled" {
+		return recordTurnUsage(ctx, executor, r.tables, turnID)
+	}
+
 	return nil
 }
 
 // UpdateTurn updates a turn's fields (status, model, tokens, metadata, etc.)
 func (r *PostgresTurnRepository) UpdateTurn(ctx context.Context, turn *llmModels.Turn) error {
+	requestParams, err := requestParamsToJSONB(turn.RequestParams)
+	if err != nil {
+		return fmt.Errorf("update turn: %w", err)
+	}
+
 	query := fmt.Sprintf(`
 		UPDATE %s
 		SET status = $1, model = $2, input_tokens = $3, output_tokens = $4,
 		    completed_at = $5, error = $6,
-		    request_params = $7, stop_reason = $8, response_metadata = $9
-		WHERE id = $10
+		    request_params = $7, stop_reason = $8, response_metadata = $9,
+		    system_prompt_tokens = $10, cost_usd = $11, model_routing = $12
+		WHERE id = $13
 	`, r.tables.Turns)
 
 	executor := postgres.GetExecutor(ctx, r.pool)
@@ -411,9 +598,12 @@ func (r *PostgresTurnRepository) UpdateTurn(ctx context.Context, turn *llmModels
 		turn.OutputTokens,
 		turn.CompletedAt,
 		turn.Error,
-		turn.RequestParams,    // pgx handles map -> JSONB (nil becomes NULL)
+		requestParams,         // pgx handles map -> JSONB (nil becomes NULL)
 		turn.StopReason,       // TEXT
 		turn.ResponseMetadata, // pgx handles map -> JSONB (nil becomes NULL)
+		turn.SystemPromptTokens,
+		turn.CostUSD,
+		turn.ModelRouting,
 		turn.ID,
 	)
 	if err != nil {
@@ -427,6 +617,32 @@ func (r *PostgresTurnRepository) UpdateTurn(ctx context.Context, turn *llmModels
 	return nil
 }
 
+// UpdateTurnAnnotations merges an annotations update into a turn's stored annotations
+// Removed annotations (JSON null) are stripped; a turn left without any stores NULL
+func (r *PostgresTurnRepository) UpdateTurnAnnotations(ctx context.Context, turnID string, update *llmModels.TurnAnnotationsUpdate) (*llmModels.TurnAnnotations, error) {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET annotations = NULLIF(jsonb_strip_nulls(COALESCE(annotations, '{}'::jsonb) || $1::jsonb), '{}'::jsonb)
+		WHERE id = $2
+		RETURNING annotations
+	`, r.tables.Turns)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	var annotations *llmModels.TurnAnnotations
+	err := executor.QueryRow(ctx, query, update.Changes(), turnID).Scan(&annotations)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("turn %s: %w", turnID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("update turn annotations: %w", err)
+	}
+
+	if annotations == nil {
+		annotations = &llmModels.TurnAnnotations{}
+	}
+	return annotations, nil
+}
+
 // UpdateTurnError updates a turn's error message and sets status to "error"
 func (r *PostgresTurnRepository) UpdateTurnError(ctx context.Context, turnID, errorMsg string) error {
 	query := fmt.Sprintf(`
@@ -445,67 +661,64 @@ func (r *PostgresTurnRepository) UpdateTurnError(ctx context.Context, turnID, er
 		return fmt.Errorf("turn %s: %w", turnID, domain.ErrNotFound)
 	}
 
-	return nil
+	return recordTurnUsage(ctx, executor, r.tables, turnID)
 }
 
-// UpdateTurnMetadata updates a turn's metadata fields (model, tokens, stop_reason, etc.)
-func (r *PostgresTurnRepository) UpdateTurnMetadata(ctx context.Context, turnID string, metadata map[string]interface{}) error {
-	// Validate metadata map is not nil
-	if metadata == nil {
-		return fmt.Errorf("metadata cannot be nil")
+// UpdateTurnMetadata partially updates a turn's metadata fields (model, tokens, stop_reason, etc.)
+// Uses COALESCE so nil fields in the update keep their stored value
+func (r *PostgresTurnRepository) UpdateTurnMetadata(ctx context.Context, turnID string, update *llmModels.TurnMetadataUpdate) error {
+	if err := update.Validate(); err != nil {
+		return fmt.Errorf("invalid turn metadata: %w", err)
 	}
 
-	// Extract and validate model (required field)
-	model, ok := metadata["model"].(string)
-	if !ok {
-		return fmt.Errorf("metadata missing or invalid 'model' field (expected non-empty string)")
-	}
-	if model == "" {
-		return fmt.Errorf("metadata 'model' field cannot be empty")
-	}
+	// Explicit casts are required: with COALESCE($n, column) Postgres can't always
+	// infer the parameter type from a NULL argument (notably in simple protocol mode)
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET model = COALESCE($1::text, model),
+		    input_tokens = COALESCE($2::int, input_tokens),
+		    output_tokens = COALESCE($3::int, output_tokens),
+		    stop_reason = COALESCE($4::text, stop_reason),
+		    response_metadata = COALESCE($5::jsonb, response_metadata),
+		    completed_at = COALESCE($6::timestamptz, completed_at),
+		    cost_usd = COALESCE($7::numeric, cost_usd)
+		WHERE id = $8
+	`, r.tables.Turns)
 
-	// Extract and validate token counts (must be non-negative if provided)
-	inputTokens, ok := metadata["input_tokens"].(int)
-	if !ok {
-		// If not provided or wrong type, default to 0
-		inputTokens = 0
-	}
-	if inputTokens < 0 {
-		return fmt.Errorf("metadata 'input_tokens' must be non-negative, got %d", inputTokens)
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query,
+		update.Model,
+		update.InputTokens,
+		update.OutputTokens,
+		update.StopReason,
+		update.ResponseMetadata, // pgx handles map -> JSONB (nil becomes NULL → unchanged)
+		update.CompletedAt,
+		update.CostUSD,
+		turnID,
+	)
+	if err != nil {
+		return fmt.Errorf("update turn metadata: %w", err)
 	}
 
-	outputTokens, ok := metadata["output_tokens"].(int)
-	if !ok {
-		outputTokens = 0
-	}
-	if outputTokens < 0 {
-		return fmt.Errorf("metadata 'output_tokens' must be non-negative, got %d", outputTokens)
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("turn %s: %w", turnID, domain.ErrNotFound)
 	}
 
-	// Extract optional fields (allow missing/wrong type, use zero values)
-	stopReason, _ := metadata["stop_reason"].(string)
-	responseMetadata, _ := metadata["response_metadata"].(map[string]interface{})
-	completedAt, _ := metadata["completed_at"].(time.Time)
+	return nil
+}
 
+// SaveStreamIndex stores the event index of a turn's stream when the stream ends
+func (r *PostgresTurnRepository) SaveStreamIndex(ctx context.Context, turnID string, index *llmModels.StreamIndex) error {
 	query := fmt.Sprintf(`
 		UPDATE %s
-		SET model = $1, input_tokens = $2, output_tokens = $3,
-		    stop_reason = $4, response_metadata = $5, completed_at = $6
-		WHERE id = $7
+		SET stream_index = $1
+		WHERE id = $2
 	`, r.tables.Turns)
 
 	executor := postgres.GetExecutor(ctx, r.pool)
-	result, err := executor.Exec(ctx, query,
-		model,
-		inputTokens,
-		outputTokens,
-		stopReason,
-		responseMetadata, // pgx handles map -> JSONB (nil becomes NULL)
-		completedAt,
-		turnID,
-	)
+	result, err := executor.Exec(ctx, query, index, turnID) // pgx handles struct -> JSONB
 	if err != nil {
-		return fmt.Errorf("update turn metadata: %w", err)
+		return fmt.Errorf("save stream index: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
@@ -515,6 +728,26 @@ func (r *PostgresTurnRepository) UpdateTurnMetadata(ctx context.Context, turnID
 	return nil
 }
 
+// GetStreamIndex retrieves the event index of a turn's stream (nil if none was saved)
+func (r *PostgresTurnRepository) GetStreamIndex(ctx context.Context, turnID string) (*llmModels.StreamIndex, error) {
+	query := fmt.Sprintf(`
+		SELECT t.stream_index
+		FROM %s t
+		WHERE t.id = $1 AND %s
+	`, r.tables.Turns, r.liveChatCondition())
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	var index *llmModels.StreamIndex
+	if err := executor.QueryRow(ctx, query, turnID).Scan(&index); err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("turn %s: %w", turnID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get stream index: %w", err)
+	}
+
+	return index, nil
+}
+
 // CreateTurnBlock creates a single turn block for a turn
 func (r *PostgresTurnRepository) CreateTurnBlock(ctx context.Context, block *llmModels.TurnBlock) error {
 	query := fmt.Sprintf(`
@@ -529,6 +762,8 @@ func (r *PostgresTurnRepository) CreateTurnBlock(ctx context.Context, block *llm
 	if block.CreatedAt.IsZero() {
 		block.CreatedAt = time.Now()
 	}
+	// Store content in the current schema version
+	block.Content = llmModels.UpgradeContent(block.BlockType, block.Content)
 
 	executor := postgres.GetExecutor(ctx, r.pool)
 	err := executor.QueryRow(ctx, query,
@@ -587,7 +822,7 @@ func (r *PostgresTurnRepository) CreateTurnBlocks(ctx context.Context, blocks []
 			block.BlockType,
 			block.Sequence,
 			block.TextContent,
-			block.Content,       // pgx automatically handles map -> JSONB conversion (nil becomes NULL)
+			llmModels.UpgradeContent(block.BlockType, block.Content), // Current schema version; pgx handles map -> JSONB (nil becomes NULL)
 			block.Provider,      // TEXT (nil becomes NULL)
 			block.ProviderData,  // pgx automatically handles json.RawMessage -> JSONB conversion (nil becomes NULL)
 			block.ExecutionSide, // TEXT (nil becomes NULL)
@@ -607,6 +842,18 @@ func (r *PostgresTurnRepository) CreateTurnBlocks(ctx context.Context, blocks []
 	return nil
 }
 
+// DeleteTurnBlocks deletes all turn blocks of a turn
+func (r *PostgresTurnRepository) DeleteTurnBlocks(ctx context.Context, turnID string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE turn_id = $1`, r.tables.TurnBlocks)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	if _, err := executor.Exec(ctx, query, turnID); err != nil {
+		return fmt.Errorf("delete turn blocks: %w", err)
+	}
+
+	return nil
+}
+
 // GetTurnBlocks retrieves all turn blocks for a turn
 func (r *PostgresTurnRepository) GetTurnBlocks(ctx context.Context, turnID string) ([]llmModels.TurnBlock, error) {
 	query := fmt.Sprintf(`
@@ -642,6 +889,8 @@ func (r *PostgresTurnRepository) GetTurnBlocks(ctx context.Context, turnID strin
 		if err != nil {
 			return nil, fmt.Errorf("scan turn block: %w", err)
 		}
+		// Compatibility reader: upgrade content written with older schemas
+		block.Content = llmModels.UpgradeContent(block.BlockType, block.Content)
 		blocks = append(blocks, block)
 	}
 
@@ -702,6 +951,8 @@ func (r *PostgresTurnRepository) GetTurnBlocksForTurns(
 		if err != nil {
 			return nil, fmt.Errorf("scan turn block: %w", err)
 		}
+		// Compatibility reader: upgrade content written with older schemas
+		block.Content = llmModels.UpgradeContent(block.BlockType, block.Content)
 
 		// Append block to the appropriate turn's block list
 		blocksByTurn[block.TurnID] = append(blocksByTurn[block.TurnID], block)
@@ -714,6 +965,214 @@ func (r *PostgresTurnRepository) GetTurnBlocksForTurns(
 	return blocksByTurn, nil
 }
 
+// SearchTurns performs full-text search over the text blocks of a chat
+// Each matching turn is represented by its best-ranked block; the snippet is
+// computed only for that block to keep ts_headline off the hot path.
+func (r *PostgresTurnRepository) SearchTurns(ctx context.Context, opts *llmModels.TurnSearchOptions) ([]llmModels.TurnSearchMatch, bool, error) {
+	opts.ApplyDefaults()
+	if err := opts.Validate(); err != nil {
+		return nil, false, fmt.Errorf("%w: invalid search options: %v", domain.ErrValidation, err)
+	}
+
+	query := fmt.Sprintf(`
+		WITH best AS (
+			SELECT DISTINCT ON (b.turn_id)
+			       b.turn_id, b.text_content,
+			       ts_rank(to_tsvector(b.search_language, b.text_content), websearch_to_tsquery($1::regconfig, $2)) AS rank_score
+			FROM %s b
+			INNER JOIN %s t ON t.id = b.turn_id
+			WHERE t.chat_id = $3
+			  AND b.block_type = $4
+			  AND b.text_content IS NOT NULL
+			  AND to_tsvector(b.search_language, b.text_content) @@ websearch_to_tsquery($1::regconfig, $2)
+			ORDER BY b.turn_id, rank_score DESC, b.sequence
+		)
+		SELECT t.id, t.role, t.created_at,
+		       ts_headline($1::regconfig, best.text_content, websearch_to_tsquery($1::regconfig, $2),
+		                   'MaxWords=30, MinWords=10, MaxFragments=1') AS snippet,
+		       best.rank_score
+		FROM best
+		INNER JOIN %s t ON t.id = best.turn_id
+		ORDER BY best.rank_score DESC, t.created_at DESC
+		LIMIT $5
+	`, r.tables.TurnBlocks, r.tables.Turns, r.tables.Turns)
+
+	// Fetch one extra row to detect whether more matches exist
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, opts.Language, opts.Query, opts.ChatID, llmModels.BlockTypeText, opts.Limit+1)
+	if err != nil {
+		return nil, false, fmt.Errorf("search turns: %w", err)
+	}
+	defer rows.Close()
+
+	matches := []llmModels.TurnSearchMatch{}
+	for rows.Next() {
+		var match llmModels.TurnSearchMatch
+		if err := rows.Scan(&match.TurnID, &match.Role, &match.CreatedAt, &match.Snippet, &match.Score); err != nil {
+			return nil, false, fmt.Errorf("scan turn search match: %w", err)
+		}
+		matches = append(matches, match)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("iterate turn search matches: %w", err)
+	}
+
+	hasMore := len(matches) > opts.Limit
+	if hasMore {
+		matches = matches[:opts.Limit]
+	}
+
+	return matches, hasMore, nil
+}
+
+// RecallTurns performs full-text search over the text blocks of a project's other chats
+// Only completed turns in the user's non-deleted chats are searched. Excerpts mark matches
+// with Markdown bold (not <b>) since they are read by the model, not rendered.
+// Blocks are matched through their indexed search_language (the project's, see migration
+// 00024), so the query must use the same configuration to hit idx_turn_blocks_text_fts_lang.
+func (r *PostgresTurnRepository) RecallTurns(ctx context.Context, opts *llmModels.ConversationRecallOptions) ([]llmModels.RecalledTurn, error) {
+	opts.ApplyDefaults()
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: invalid recall options: %v", domain.ErrValidation, err)
+	}
+
+	query := fmt.Sprintf(`
+		WITH best AS (
+			SELECT DISTINCT ON (b.turn_id)
+			       b.turn_id, b.text_content,
+			       ts_rank(to_tsvector(b.search_language, b.text_content), websearch_to_tsquery($1::regconfig, $2)) AS rank_score
+			FROM %s b
+			INNER JOIN %s t ON t.id = b.turn_id
+			INNER JOIN %s c ON c.id = t.chat_id
+			WHERE c.project_id = $3
+			  AND c.user_id = $4
+			  AND c.deleted_at IS NULL
+			  AND ($5 = '' OR c.id::text <> $5)
+			  AND t.status = 'complete'
+			  AND b.block_type = $6
+			  AND b.text_content IS NOT NULL
+			  AND to_tsvector(b.search_language, b.text_content) @@ websearch_to_tsquery($1::regconfig, $2)
+			ORDER BY b.turn_id, rank_score DESC, b.sequence
+		)
+		SELECT c.id, c.title, t.id, t.role, t.created_at,
+		       ts_headline($1::regconfig, best.text_content, websearch_to_tsquery($1::regconfig, $2),
+		                   'MaxWords=60, MinWords=20, MaxFragments=2, StartSel=**, StopSel=**, FragmentDelimiter=" … "') AS excerpt,
+		       best.rank_score
+		FROM best
+		INNER JOIN %s t ON t.id = best.turn_id
+		INNER JOIN %s c ON c.id = t.chat_id
+		ORDER BY best.rank_score DESC, t.created_at DESC
+		LIMIT $7
+	`, r.tables.TurnBlocks, r.tables.Turns, r.tables.Chats, r.tables.Turns, r.tables.Chats)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, opts.Language, opts.Query, opts.ProjectID, opts.UserID,
+		opts.ExcludeChatID, llmModels.BlockTypeText, opts.Limit)
+	if err != nil {
+		return nil, recallError("recall turns", opts.Language, err)
+	}
+	defer rows.Close()
+
+	recalled := []llmModels.RecalledTurn{}
+	for rows.Next() {
+		var turn llmModels.RecalledTurn
+		if err := rows.Scan(&turn.ChatID, &turn.ChatTitle, &turn.TurnID, &turn.Role, &turn.CreatedAt, &turn.Excerpt, &turn.Score); err != nil {
+			return nil, fmt.Errorf("scan recalled turn: %w", err)
+		}
+		recalled = append(recalled, turn)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, recallError("iterate recalled turns", opts.Language, err)
+	}
+
+	return recalled, nil
+}
+
+// SearchChats performs full-text search over the text blocks of a user's chats in a project
+// Like RecallTurns, blocks are matched through their indexed search_language, so searches
+// in the project's language hit idx_turn_blocks_text_fts_lang.
+func (r *PostgresTurnRepository) SearchChats(ctx context.Context, opts *llmModels.ChatSearchOptions) ([]llmModels.ChatSearchMatch, bool, error) {
+	opts.ApplyDefaults()
+	if err := opts.Validate(); err != nil {
+		return nil, false, fmt.Errorf("%w: invalid search options: %v", domain.ErrValidation, err)
+	}
+
+	query := fmt.Sprintf(`
+		WITH best AS (
+			SELECT DISTINCT ON (b.turn_id)
+			       b.turn_id, b.text_content,
+			       ts_rank(to_tsvector(b.search_language, b.text_content), websearch_to_tsquery($1::regconfig, $2)) AS rank_score
+			FROM %s b
+			INNER JOIN %s t ON t.id = b.turn_id
+			INNER JOIN %s c ON c.id = t.chat_id
+			WHERE c.project_id = $3
+			  AND c.user_id = $4
+			  AND c.deleted_at IS NULL
+			  AND b.block_type = $5
+			  AND b.text_content IS NOT NULL
+			  AND to_tsvector(b.search_language, b.text_content) @@ websearch_to_tsquery($1::regconfig, $2)
+			ORDER BY b.turn_id, rank_score DESC, b.sequence
+		),
+		page AS (
+			SELECT best.turn_id, best.text_content, best.rank_score
+			FROM best
+			INNER JOIN %s t ON t.id = best.turn_id
+			ORDER BY best.rank_score DESC, t.created_at DESC, t.id
+			LIMIT $6 OFFSET $7
+		)
+		SELECT c.id, c.title, c.updated_at, t.id, t.prev_turn_id, t.role, t.created_at,
+		       ts_headline($1::regconfig, page.text_content, websearch_to_tsquery($1::regconfig, $2),
+		                   'MaxWords=30, MinWords=10, MaxFragments=1') AS snippet,
+		       page.rank_score
+		FROM page
+		INNER JOIN %s t ON t.id = page.turn_id
+		INNER JOIN %s c ON c.id = t.chat_id
+		ORDER BY page.rank_score DESC, t.created_at DESC, t.id
+	`, r.tables.TurnBlocks, r.tables.Turns, r.tables.Chats, r.tables.Turns, r.tables.Turns, r.tables.Chats)
+
+	// Fetch one extra row to detect whether more matches exist; ts_headline only runs on
+	// the page
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, opts.Language, opts.Query, opts.ProjectID, opts.UserID,
+		llmModels.BlockTypeText, opts.Limit+1, opts.Offset)
+	if err != nil {
+		return nil, false, recallError("search chats", opts.Language, err)
+	}
+	defer rows.Close()
+
+	matches := []llmModels.ChatSearchMatch{}
+	for rows.Next() {
+		var match llmModels.ChatSearchMatch
+		if err := rows.Scan(&match.ChatID, &match.ChatTitle, &match.ChatUpdatedAt, &match.TurnID, &match.PrevTurnID,
+			&match.Role, &match.CreatedAt, &match.Snippet, &match.Score); err != nil {
+			return nil, false, fmt.Errorf("scan chat search match: %w", err)
+		}
+		matches = append(matches, match)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, false, recallError("iterate chat search matches", opts.Language, err)
+	}
+
+	hasMore := len(matches) > opts.Limit
+	if hasMore {
+		matches = matches[:opts.Limit]
+	}
+
+	return matches, hasMore, nil
+}
+
+// recallError maps an unknown text search configuration (the $1::regconfig cast fails)
+// to a validation error; anything else is wrapped as is
+func recallError(op, language string, err error) error {
+	if postgres.IsPgUndefinedObjectError(err) {
+		return fmt.Errorf("%w: unsupported search language %q", domain.ErrValidation, language)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
+
 // GetSiblingsForTurns retrieves sibling turn IDs for multiple turns in a single query
 // Siblings are turns that share the same prev_turn_id (alternative conversation branches)
 func (r *PostgresTurnRepository) GetSiblingsForTurns(
@@ -930,6 +1389,11 @@ func (r *PostgresTurnRepository) GetPaginatedTurns(
 			// If update fails, pagination succeeds but cache becomes stale
 			r.logger.Error("failed to update last_viewed_turn_id", "error", err)
 		}
+
+		// The viewed branch (e.g. after a sibling switch) is what later pagination follows
+		if err := activateTurnPath(ctx, executor, r.tables, *startTurnID); err != nil {
+			r.logger.Error("failed to update active branch", "turn_id", *startTurnID, "error", err)
+		}
 	}
 
 	// Apply defaults for direction and limit
@@ -1075,7 +1539,7 @@ func (r *PostgresTurnRepository) fetchTurnsBefore(ctx context.Context, startTurn
 			-- Base case: get the prev turn of start turn
 			SELECT t.id, t.chat_id, t.prev_turn_id, t.role, t.status, t.error,
 			       t.model, t.input_tokens, t.output_tokens, t.created_at, t.completed_at,
-			       t.request_params, t.stop_reason, t.response_metadata, 1 as depth
+			       t.request_params, t.stop_reason, t.response_metadata, t.system_prompt_tokens, t.cost_usd, t.model_routing, t.annotations, t.language, 1 as depth
 			FROM %s t
 			INNER JOIN %s start ON t.id = start.prev_turn_id
 			WHERE start.id = $1
@@ -1085,14 +1549,14 @@ func (r *PostgresTurnRepository) fetchTurnsBefore(ctx context.Context, startTurn
 			-- Recursive case: follow prev_turn_id chain
 			SELECT t.id, t.chat_id, t.prev_turn_id, t.role, t.status, t.error,
 			       t.model, t.input_tokens, t.output_tokens, t.created_at, t.completed_at,
-			       t.request_params, t.stop_reason, t.response_metadata, tp.depth + 1
+			       t.request_params, t.stop_reason, t.response_metadata, t.system_prompt_tokens, t.cost_usd, t.model_routing, t.annotations, t.language, tp.depth + 1
 			FROM %s t
 			INNER JOIN turn_path tp ON t.id = tp.prev_turn_id
 			WHERE tp.depth < $2
 		)
 		SELECT id, chat_id, prev_turn_id, role, status, error,
 		       model, input_tokens, output_tokens, created_at, completed_at,
-		       request_params, stop_reason, response_metadata
+		       request_params, stop_reason, response_metadata, system_prompt_tokens, cost_usd, model_routing, annotations, language
 		FROM turn_path
 		ORDER BY depth ASC
 		LIMIT $2
@@ -1121,48 +1585,41 @@ func (r *PostgresTurnRepository) fetchTurnsBefore(ctx context.Context, startTurn
 	return turns, nil
 }
 
-// fetchTurnsAfter follows children forward, picking most recent child when multiple exist
+// fetchTurnsAfter follows children forward along the active branch: each turn's
+// active_child_turn_id, or its most recent child when no branch was chosen
 func (r *PostgresTurnRepository) fetchTurnsAfter(ctx context.Context, startTurnID string, limit int) ([]llmModels.Turn, error) {
-	// Recursive CTE to traverse forward through children (most recent branch)
-	// Uses correlated subquery to select only the most recent child at each level
+	// Recursive CTE to traverse forward through children (active branch)
+	// Uses correlated subquery to select only the active child at each level
 	query := fmt.Sprintf(`
 		WITH RECURSIVE turn_path AS (
-			-- Base case: get the most recent child of start turn
+			-- Base case: get the active child of start turn
 			SELECT t.id, t.chat_id, t.prev_turn_id, t.role, t.status, t.error,
 			       t.model, t.input_tokens, t.output_tokens, t.created_at, t.completed_at,
-			       t.request_params, t.stop_reason, t.response_metadata, 1 as depth
+			       t.request_params, t.stop_reason, t.response_metadata, t.system_prompt_tokens, t.cost_usd, t.model_routing, t.annotations, t.language, 1 as depth
 			FROM %s t
 			WHERE t.prev_turn_id = $1
-			  AND t.id = (
-			    SELECT id FROM %s
-			    WHERE prev_turn_id = $1
-			    ORDER BY created_at DESC
-			    LIMIT 1
+			  AND t.id = (%s
 			  )
 
 			UNION ALL
 
-			-- Recursive case: follow most recent child
+			-- Recursive case: follow active child
 			SELECT t.id, t.chat_id, t.prev_turn_id, t.role, t.status, t.error,
 			       t.model, t.input_tokens, t.output_tokens, t.created_at, t.completed_at,
-			       t.request_params, t.stop_reason, t.response_metadata, tp.depth + 1
+			       t.request_params, t.stop_reason, t.response_metadata, t.system_prompt_tokens, t.cost_usd, t.model_routing, t.annotations, t.language, tp.depth + 1
 			FROM %s t
 			INNER JOIN turn_path tp ON t.prev_turn_id = tp.id
 			WHERE tp.depth < $2
-			  AND t.id = (
-			    SELECT id FROM %s
-			    WHERE prev_turn_id = tp.id
-			    ORDER BY created_at DESC
-			    LIMIT 1
+			  AND t.id = (%s
 			  )
 		)
 		SELECT id, chat_id, prev_turn_id, role, status, error,
 		       model, input_tokens, output_tokens, created_at, completed_at,
-		       request_params, stop_reason, response_metadata
+		       request_params, stop_reason, response_metadata, system_prompt_tokens, cost_usd, model_routing, annotations, language
 		FROM turn_path
 		ORDER BY depth ASC
 		LIMIT $2
-	`, r.tables.Turns, r.tables.Turns, r.tables.Turns, r.tables.Turns)
+	`, r.tables.Turns, r.activeChildQuery("$1"), r.tables.Turns, r.activeChildQuery("tp.id"))
 
 	executor := postgres.GetExecutor(ctx, r.pool)
 	rows, err := executor.Query(ctx, query, startTurnID, limit)
@@ -1187,9 +1644,9 @@ func (r *PostgresTurnRepository) fetchTurnsAfter(ctx context.Context, startTurnI
 	return turns, nil
 }
 
-// findMostRecentLeaf traverses down the tree to find the most recent leaf
+// findMostRecentLeaf traverses down the tree to find the leaf of the active branch
 // ONLY CALLED ON COLD STARTS (when fromTurnID == nil in GetPaginatedTurns)
-// Follows the most recent child (created_at DESC) at each level using a recursive CTE
+// Follows the active child (active_child_turn_id, else most recent) at each level using a recursive CTE
 // Returns the leaf turn_id to show "end of active branch"
 func (r *PostgresTurnRepository) findMostRecentLeaf(ctx context.Context, startTurnID string) (string, error) {
 	// Use recursive CTE to find the leaf in a single query instead of N sequential queries
@@ -1203,20 +1660,15 @@ func (r *PostgresTurnRepository) findMostRecentLeaf(ctx context.Context, startTu
 
 			UNION ALL
 
-			-- Recursive case: find the most recent child
+			-- Recursive case: find the active child
 			SELECT t.id, lf.depth + 1
 			FROM leaf_finder lf
-			CROSS JOIN LATERAL (
-				SELECT id
-				FROM %s
-				WHERE prev_turn_id = lf.id
-				ORDER BY created_at DESC
-				LIMIT 1
+			CROSS JOIN LATERAL (%s
 			) t
 			WHERE lf.depth < $2
 		)
 		SELECT id FROM leaf_finder ORDER BY depth DESC LIMIT 1
-	`, r.tables.Turns, r.tables.Turns)
+	`, r.tables.Turns, r.activeChildQuery("lf.id"))
 
 	executor := postgres.GetExecutor(ctx, r.pool)
 	var leafID string
@@ -0,0 +1,41 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+
+	"meridian/internal/repository/postgres"
+)
+
+func TestLiveChatCondition(t *testing.T) {
+	repo := &PostgresTurnRepository{tables: postgres.NewTableNames("dev_")}
+	condition := repo.liveChatCondition()
+
+	// Turns of a deleted chat, or of any chat in a deleted project, are not found
+	for _, want := range []string{
+		"FROM dev_chats c",
+		"JOIN dev_projects p ON p.id = c.project_id AND p.deleted_at IS NULL",
+		"c.id = t.chat_id AND c.deleted_at IS NULL",
+	} {
+		if !strings.Contains(condition, want) {
+			t.Errorf("liveChatCondition() = %q, missing %q", condition, want)
+		}
+	}
+}
+
+func TestActiveChildQuery(t *testing.T) {
+	repo := &PostgresTurnRepository{tables: postgres.NewTableNames("dev_")}
+	query := repo.activeChildQuery("tp.id")
+
+	// The parent's chosen child wins; otherwise the most recent child
+	for _, want := range []string{
+		"WHERE c.prev_turn_id = tp.id",
+		"c.id IS NOT DISTINCT FROM (SELECT active_child_turn_id FROM dev_turns WHERE id = tp.id) DESC",
+		"c.created_at DESC",
+		"LIMIT 1",
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("activeChildQuery() = %q, missing %q", query, want)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	"meridian/internal/domain/repositories"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/repository/postgres"
+)
+
+// PostgresUsageRepository implements the UsageRepository interface
+type PostgresUsageRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewUsageRepository creates a new usage repository
+func NewUsageRepository(config *postgres.RepositoryConfig) llmRepo.UsageRepository {
+	return &PostgresUsageRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+// usageGrouping is the key, label and order of one GroupBy
+type usageGrouping struct {
+	key     string
+	label   string
+	orderBy string
+	zoned   bool // key and label take the time zone as $4
+}
+
+var usageGroupings = map[llmModels.UsageGroupBy]usageGrouping{
+	llmModels.UsageGroupByChat:    {key: "c.id::text", label: "c.title", orderBy: "cost_usd DESC, key"},
+	llmModels.UsageGroupByProject: {key: "p.id::text", label: "p.name", orderBy: "cost_usd DESC, key"},
+	llmModels.UsageGroupByMonth: {
+		key:     "to_char(date_trunc('month', t.created_at AT TIME ZONE $4), 'YYYY-MM')",
+		label:   "to_char(date_trunc('month', t.created_at AT TIME ZONE $4), 'YYYY-MM')",
+		orderBy: "key",
+		zoned:   true,
+	},
+}
+
+// GetUsage sums a user's assistant turns by chat, project or month (in q.Location)
+func (r *PostgresUsageRepository) GetUsage(ctx context.Context, q *llmModels.UsageQuery) ([]llmModels.UsageGroup, error) {
+	grouping, ok := usageGroupings[q.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown usage grouping %q", domain.ErrValidation, q.GroupBy)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			%s AS key,
+			%s AS label,
+			COUNT(*),
+			COALESCE(SUM(t.input_tokens), 0),
+			COALESCE(SUM(t.output_tokens), 0),
+			COALESCE(SUM(t.cost_usd), 0)::float8 AS cost_usd,
+			COUNT(*) FILTER (WHERE t.cost_usd IS NULL)
+		FROM %s t
+		JOIN %s c ON c.id = t.chat_id
+		JOIN %s p ON p.id = c.project_id
+		WHERE c.user_id = $1
+		  AND t.role = 'assistant'
+		  AND ($2::timestamptz IS NULL OR t.created_at >= $2)
+		  AND ($3::timestamptz IS NULL OR t.created_at < $3)
+		GROUP BY 1, 2
+		ORDER BY %s
+	`, grouping.key, grouping.label, r.tables.Turns, r.tables.Chats, r.tables.Projects, grouping.orderBy)
+
+	args := []any{q.UserID, q.From, q.To}
+	if grouping.zoned {
+		args = append(args, timezoneName(q.Location))
+	}
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query usage: %w", err)
+	}
+	defer rows.Close()
+
+	groups := []llmModels.UsageGroup{}
+	for rows.Next() {
+		var group llmModels.UsageGroup
+		if err := rows.Scan(
+			&group.Key,
+			&group.Label,
+			&group.Turns,
+			&group.InputTokens,
+			&group.OutputTokens,
+			&group.CostUSD,
+			&group.UnpricedTurns,
+		); err != nil {
+			return nil, fmt.Errorf("scan usage: %w", err)
+		}
+		groups = append(groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate usage: %w", err)
+	}
+
+	return groups, nil
+}
+
+// usagePeriods formats the rollup day (d.day) as the period of each granularity
+var usagePeriods = map[llmModels.UsageGranularity]string{
+	llmModels.UsageGranularityDay:   "to_char(d.day, 'YYYY-MM-DD')",
+	llmModels.UsageGranularityMonth: "to_char(d.day, 'YYYY-MM')",
+}
+
+// GetUsageRollup sums a chat's or project's usage per period and model. UTC periods read
+// the daily rollups; other time zones sum the recorded turns, since the rollups are
+// bucketed by UTC day.
+func (r *PostgresUsageRepository) GetUsageRollup(ctx context.Context, q *llmModels.UsageRollupQuery) ([]llmModels.UsagePoint, error) {
+	period, ok := usagePeriods[q.Granularity]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown usage granularity %q", domain.ErrValidation, q.Granularity)
+	}
+	if (q.ChatID == "") == (q.ProjectID == "") {
+		return nil, fmt.Errorf("%w: usage rollup needs exactly one of chat ID and project ID", domain.ErrValidation)
+	}
+
+	tz := timezoneName(q.Location)
+	args := []any{nilIfEmpty(q.ChatID), nilIfEmpty(q.ProjectID), localDay(q.From, q.Location), localDay(q.To, q.Location)}
+	var query string
+	if tz == "UTC" {
+		query = fmt.Sprintf(`
+		SELECT
+			%s AS period,
+			d.model,
+			SUM(d.turns),
+			SUM(d.input_tokens),
+			SUM(d.output_tokens),
+			SUM(d.cost_usd)::float8,
+			SUM(d.unpriced_turns)
+		FROM %s d
+		WHERE ($1::uuid IS NULL OR d.chat_id = $1)
+		  AND ($2::uuid IS NULL OR d.project_id = $2)
+		  AND ($3::date IS NULL OR d.day >= $3)
+		  AND ($4::date IS NULL OR d.day < $4)
+		GROUP BY 1, 2
+		ORDER BY 1, 2
+	`, period, r.tables.UsageDaily)
+	} else {
+		// Same totals as the rollups (recordTurnUsage), with the day taken in tz
+		query = fmt.Sprintf(`
+		SELECT
+			%s AS period,
+			d.model,
+			COUNT(*),
+			SUM(d.input_tokens),
+			SUM(d.output_tokens),
+			COALESCE(SUM(d.cost_usd), 0)::float8,
+			COUNT(*) FILTER (WHERE d.cost_usd IS NULL)
+		FROM (
+			SELECT (t.created_at AT TIME ZONE $5)::date AS day,
+			       COALESCE(t.model, '') AS model,
+			       COALESCE(t.input_tokens, 0) AS input_tokens,
+			       COALESCE(t.output_tokens, 0) AS output_tokens,
+			       t.cost_usd
+			FROM %s t
+			JOIN %s c ON c.id = t.chat_id
+			WHERE t.role = 'assistant'
+			  AND t.usage_recorded
+			  AND ($1::uuid IS NULL OR t.chat_id = $1)
+			  AND ($2::uuid IS NULL OR c.project_id = $2)
+		) d
+		WHERE ($3::date IS NULL OR d.day >= $3)
+		  AND ($4::date IS NULL OR d.day < $4)
+		GROUP BY 1, 2
+		ORDER BY 1, 2
+	`, period, r.tables.Turns, r.tables.Chats)
+		args = append(args, tz)
+	}
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query usage rollup: %w", err)
+	}
+	defer rows.Close()
+
+	points := []llmModels.UsagePoint{}
+	for rows.Next() {
+		var point llmModels.UsagePoint
+		if err := rows.Scan(
+			&point.Period,
+			&point.Model,
+			&point.Turns,
+			&point.InputTokens,
+			&point.OutputTokens,
+			&point.CostUSD,
+			&point.UnpricedTurns,
+		); err != nil {
+			return nil, fmt.Errorf("scan usage rollup: %w", err)
+		}
+		points = append(points, point)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate usage rollup: %w", err)
+	}
+
+	return points, nil
+}
+
+// recordTurnUsage adds a finished assistant turn's tokens and cost to its chat's
+// (day, model) rollup. The turn is flagged in the same statement, so calling it again
+// (e.g. a cancelled turn that later errors) never counts the turn twice.
+func recordTurnUsage(ctx context.Context, executor repositories.DBTX, tables *postgres.TableNames, turnID string) error {
+	query := fmt.Sprintf(`
+		WITH recorded AS (
+			UPDATE %[1]s t
+			SET usage_recorded = TRUE
+			FROM %[2]s c
+			WHERE t.id = $1
+			  AND c.id = t.chat_id
+			  AND t.role = 'assistant'
+			  AND NOT t.usage_recorded
+			RETURNING t.chat_id, c.project_id, c.user_id,
+			          (t.created_at AT TIME ZONE 'UTC')::date AS day,
+			          COALESCE(t.model, '') AS model,
+			          COALESCE(t.input_tokens, 0) AS input_tokens,
+			          COALESCE(t.output_tokens, 0) AS output_tokens,
+			          t.cost_usd
+		)
+		INSERT INTO %[3]s AS d (chat_id, project_id, user_id, day, model, turns, input_tokens, output_tokens, cost_usd, unpriced_turns)
+		SELECT chat_id, project_id, user_id, day, model, 1, input_tokens, output_tokens,
+		       COALESCE(cost_usd, 0), CASE WHEN cost_usd IS NULL THEN 1 ELSE 0 END
+		FROM recorded
+		ON CONFLICT (chat_id, day, model) DO UPDATE SET
+			turns = d.turns + EXCLUDED.turns,
+			input_tokens = d.input_tokens + EXCLUDED.input_tokens,
+			output_tokens = d.output_tokens + EXCLUDED.output_tokens,
+			cost_usd = d.cost_usd + EXCLUDED.cost_usd,
+			unpriced_turns = d.unpriced_turns + EXCLUDED.unpriced_turns
+	`, tables.Turns, tables.Chats, tables.UsageDaily)
+
+	if _, err := executor.Exec(ctx, query, turnID); err != nil {
+		return fmt.Errorf("record turn usage: %w", err)
+	}
+	return nil
+}
+
+// localDay returns t's date in loc (nil = UTC) for a DATE parameter (nil stays nil)
+func localDay(t *time.Time, loc *time.Location) *string {
+	if t == nil {
+		return nil
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	day := t.In(loc).Format(time.DateOnly)
+	return &day
+}
+
+// timezoneName returns the IANA name of loc for AT TIME ZONE (nil = "UTC")
+func timezoneName(loc *time.Location) string {
+	if loc == nil {
+		return "UTC"
+	}
+	return loc.String()
+}
+
+// nilIfEmpty returns nil for "" (a NULL parameter) and &s otherwise
+func nilIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
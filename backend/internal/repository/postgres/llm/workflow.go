@@ -0,0 +1,282 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/repository/postgres"
+)
+
+// PostgresWorkflowRepository implements the WorkflowRepository interface
+type PostgresWorkflowRepository struct {
+	pool   *pgxpool.Pool
+	tables *postgres.TableNames
+}
+
+// NewWorkflowRepository creates a new workflow repository
+func NewWorkflowRepository(config *postgres.RepositoryConfig) llmRepo.WorkflowRepository {
+	return &PostgresWorkflowRepository{
+		pool:   config.Pool,
+		tables: config.Tables,
+	}
+}
+
+const workflowColumns = `id, project_id, name, description, system_prompt, steps, created_at, updated_at`
+
+const workflowRunColumns = `id, chat_id, workflow_id, workflow_name, placeholder_values, steps, current_step,
+	status, created_at, updated_at, completed_at`
+
+// CreateWorkflow inserts a workflow
+func (r *PostgresWorkflowRepository) CreateWorkflow(ctx context.Context, workflow *llmModels.Workflow) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (project_id, name, description, system_prompt, steps)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`, r.tables.Workflows)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		workflow.ProjectID,
+		workflow.Name,
+		workflow.Description,
+		workflow.SystemPrompt,
+		workflowSteps(workflow.Steps),
+	).Scan(&workflow.ID, &workflow.CreatedAt, &workflow.UpdatedAt)
+	if err != nil {
+		if postgres.IsPgDuplicateError(err) {
+			return fmt.Errorf("%w: workflow '%s' already exists", domain.ErrConflict, workflow.Name)
+		}
+		return fmt.Errorf("create workflow: %w", err)
+	}
+
+	return nil
+}
+
+// GetWorkflow retrieves a workflow scoped to its project
+func (r *PostgresWorkflowRepository) GetWorkflow(ctx context.Context, workflowID, projectID string) (*llmModels.Workflow, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE id = $1 AND project_id = $2
+	`, workflowColumns, r.tables.Workflows)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	workflow, err := scanWorkflow(executor.QueryRow(ctx, query, workflowID, projectID))
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("workflow %s: %w", workflowID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get workflow: %w", err)
+	}
+
+	return workflow, nil
+}
+
+// ListWorkflows returns a project's workflows ordered by name
+func (r *PostgresWorkflowRepository) ListWorkflows(ctx context.Context, projectID string) ([]llmModels.Workflow, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE project_id = $1
+		ORDER BY name
+	`, workflowColumns, r.tables.Workflows)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	rows, err := executor.Query(ctx, query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list workflows: %w", err)
+	}
+	defer rows.Close()
+
+	workflows := []llmModels.Workflow{}
+	for rows.Next() {
+		workflow, err := scanWorkflow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan workflow: %w", err)
+		}
+		workflows = append(workflows, *workflow)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate workflows: %w", err)
+	}
+
+	return workflows, nil
+}
+
+// UpdateWorkflow saves a workflow's editable fields
+func (r *PostgresWorkflowRepository) UpdateWorkflow(ctx context.Context, workflow *llmModels.Workflow) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET name = $3, description = $4, system_prompt = $5, steps = $6, updated_at = NOW()
+		WHERE id = $1 AND project_id = $2
+		RETURNING updated_at
+	`, r.tables.Workflows)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		workflow.ID,
+		workflow.ProjectID,
+		workflow.Name,
+		workflow.Description,
+		workflow.SystemPrompt,
+		workflowSteps(workflow.Steps),
+	).Scan(&workflow.UpdatedAt)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return fmt.Errorf("workflow %s: %w", workflow.ID, domain.ErrNotFound)
+		}
+		if postgres.IsPgDuplicateError(err) {
+			return fmt.Errorf("%w: workflow '%s' already exists", domain.ErrConflict, workflow.Name)
+		}
+		return fmt.Errorf("update workflow: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteWorkflow removes a workflow (runs keep their snapshot; workflow_id is set to NULL)
+func (r *PostgresWorkflowRepository) DeleteWorkflow(ctx context.Context, workflowID, projectID string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1 AND project_id = $2`, r.tables.Workflows)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	result, err := executor.Exec(ctx, query, workflowID, projectID)
+	if err != nil {
+		return fmt.Errorf("delete workflow: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("workflow %s: %w", workflowID, domain.ErrNotFound)
+	}
+	return nil
+}
+
+// CreateRun inserts a run
+func (r *PostgresWorkflowRepository) CreateRun(ctx context.Context, run *llmModels.WorkflowRun) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (chat_id, workflow_id, workflow_name, placeholder_values, steps, current_step, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at, updated_at
+	`, r.tables.WorkflowRuns)
+
+	values := run.Values
+	if values == nil {
+		values = map[string]string{}
+	}
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		run.ChatID,
+		run.WorkflowID,
+		run.WorkflowName,
+		values,
+		workflowSteps(run.Steps),
+		run.CurrentStep,
+		run.Status,
+	).Scan(&run.ID, &run.CreatedAt, &run.UpdatedAt)
+	if err != nil {
+		if postgres.IsPgDuplicateError(err) {
+			return fmt.Errorf("%w: chat %s already has a workflow", domain.ErrConflict, run.ChatID)
+		}
+		return fmt.Errorf("create workflow run: %w", err)
+	}
+
+	return nil
+}
+
+// GetRunByChat retrieves the run of a chat
+func (r *PostgresWorkflowRepository) GetRunByChat(ctx context.Context, chatID string) (*llmModels.WorkflowRun, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE chat_id = $1
+	`, workflowRunColumns, r.tables.WorkflowRuns)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	run, err := scanWorkflowRun(executor.QueryRow(ctx, query, chatID))
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return nil, fmt.Errorf("workflow run for chat %s: %w", chatID, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get workflow run: %w", err)
+	}
+
+	return run, nil
+}
+
+// UpdateRunProgress saves a run's progress if it is still at fromStep
+func (r *PostgresWorkflowRepository) UpdateRunProgress(ctx context.Context, run *llmModels.WorkflowRun, fromStep int) error {
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET current_step = $3, status = $4, completed_at = $5, updated_at = NOW()
+		WHERE id = $1 AND current_step = $2
+		RETURNING updated_at
+	`, r.tables.WorkflowRuns)
+
+	executor := postgres.GetExecutor(ctx, r.pool)
+	err := executor.QueryRow(ctx, query,
+		run.ID,
+		fromStep,
+		run.CurrentStep,
+		run.Status,
+		run.CompletedAt,
+	).Scan(&run.UpdatedAt)
+	if err != nil {
+		if postgres.IsPgNoRowsError(err) {
+			return fmt.Errorf("%w: workflow run %s has already moved past step %d", domain.ErrConflict, run.ID, fromStep+1)
+		}
+		return fmt.Errorf("update workflow run: %w", err)
+	}
+
+	return nil
+}
+
+func scanWorkflow(row pgx.Row) (*llmModels.Workflow, error) {
+	var workflow llmModels.Workflow
+	err := row.Scan(
+		&workflow.ID,
+		&workflow.ProjectID,
+		&workflow.Name,
+		&workflow.Description,
+		&workflow.SystemPrompt,
+		&workflow.Steps,
+		&workflow.CreatedAt,
+		&workflow.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+func scanWorkflowRun(row pgx.Row) (*llmModels.WorkflowRun, error) {
+	var run llmModels.WorkflowRun
+	err := row.Scan(
+		&run.ID,
+		&run.ChatID,
+		&run.WorkflowID,
+		&run.WorkflowName,
+		&run.Values,
+		&run.Steps,
+		&run.CurrentStep,
+		&run.Status,
+		&run.CreatedAt,
+		&run.UpdatedAt,
+		&run.CompletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// workflowSteps replaces nil steps so the NOT NULL column gets '[]'
+func workflowSteps(steps []llmModels.WorkflowStep) []llmModels.WorkflowStep {
+	if steps == nil {
+		return []llmModels.WorkflowStep{}
+	}
+	return steps
+}
@@ -9,9 +9,14 @@ import (
 	"meridian/internal/domain/repositories"
 )
 
+// txBeginner starts a transaction: the pool starts a real one, an open pgx.Tx a savepoint
+type txBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
 // TransactionManager implements the TransactionManager interface
 type TransactionManager struct {
-	pool *pgxpool.Pool
+	pool txBeginner
 }
 
 // NewTransactionManager creates a new transaction manager
@@ -20,8 +25,16 @@ func NewTransactionManager(pool *pgxpool.Pool) repositories.TransactionManager {
 }
 
 // ExecTx executes a function within a transaction
+// Nested calls (ctx already carries a transaction) run in a savepoint of the outer
+// transaction: an error from fn rolls back only the savepoint, and nothing is durable
+// until the outermost ExecTx commits
 func (tm *TransactionManager) ExecTx(ctx context.Context, fn repositories.TxFn) error {
-	tx, err := tm.pool.Begin(ctx)
+	var beginner txBeginner = tm.pool
+	if outer := repositories.GetTx(ctx); outer != nil {
+		beginner = outer
+	}
+
+	tx, err := beginner.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
 	}
@@ -42,7 +55,7 @@ func (tm *TransactionManager) ExecTx(ctx context.Context, fn repositories.TxFn)
 		return err
 	}
 
-	// Commit transaction
+	// Commit transaction (releases the savepoint when nested)
 	if err := tx.Commit(ctx); err != nil {
 		return fmt.Errorf("commit transaction: %w", err)
 	}
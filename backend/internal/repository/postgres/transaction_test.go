@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"meridian/internal/domain/repositories"
+)
+
+// fakeTx records transaction control statements; other pgx.Tx methods are not used
+type fakeTx struct {
+	pgx.Tx
+	name   string
+	log    *[]string
+	closed bool
+}
+
+func (t *fakeTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	*t.log = append(*t.log, "savepoint in "+t.name)
+	return &fakeTx{name: t.name + "/sp", log: t.log}, nil
+}
+
+func (t *fakeTx) Commit(ctx context.Context) error {
+	if t.closed {
+		return pgx.ErrTxClosed
+	}
+	t.closed = true
+	*t.log = append(*t.log, "commit "+t.name)
+	return nil
+}
+
+func (t *fakeTx) Rollback(ctx context.Context) error {
+	if t.closed {
+		return pgx.ErrTxClosed
+	}
+	t.closed = true
+	*t.log = append(*t.log, "rollback "+t.name)
+	return nil
+}
+
+type fakePool struct {
+	log *[]string
+}
+
+func (p *fakePool) Begin(ctx context.Context) (pgx.Tx, error) {
+	*p.log = append(*p.log, "begin tx")
+	return &fakeTx{name: "tx", log: p.log}, nil
+}
+
+func TestExecTx_Nesting(t *testing.T) {
+	errInner := errors.New("inner failed")
+	errOuter := errors.New("outer failed")
+
+	tests := []struct {
+		name     string
+		innerErr error
+		outerErr error // returned by the outer fn after the inner ExecTx
+		wantLog  []string
+	}{
+		{
+			name:    "both succeed",
+			wantLog: []string{"begin tx", "savepoint in tx", "commit tx/sp", "commit tx"},
+		},
+		{
+			name:     "inner failure rolls back only the savepoint",
+			innerErr: errInner,
+			wantLog:  []string{"begin tx", "savepoint in tx", "rollback tx/sp", "commit tx"},
+		},
+		{
+			name:     "outer failure rolls back the committed savepoint",
+			outerErr: errOuter,
+			wantLog:  []string{"begin tx", "savepoint in tx", "commit tx/sp", "rollback tx"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var log []string
+			tm := &TransactionManager{pool: &fakePool{log: &log}}
+
+			err := tm.ExecTx(context.Background(), func(ctx context.Context) error {
+				outer := repositories.GetTx(ctx)
+				innerErr := tm.ExecTx(ctx, func(ctx context.Context) error {
+					if repositories.GetTx(ctx) == outer {
+						t.Error("nested ExecTx reused the outer transaction instead of a savepoint")
+					}
+					return tt.innerErr
+				})
+				if !errors.Is(innerErr, tt.innerErr) {
+					t.Errorf("inner err = %v, want %v", innerErr, tt.innerErr)
+				}
+				return tt.outerErr
+			})
+
+			if !errors.Is(err, tt.outerErr) {
+				t.Errorf("outer err = %v, want %v", err, tt.outerErr)
+			}
+			if !reflect.DeepEqual(log, tt.wantLog) {
+				t.Errorf("log = %v, want %v", log, tt.wantLog)
+			}
+		})
+	}
+}
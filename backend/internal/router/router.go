@@ -0,0 +1,114 @@
+// Package router builds the server's route table. Routes are registered in groups
+// (admin, docsystem, llm, generation, users, adminapi, debug); each group carries its own
+// middleware, so public endpoints simply belong to a group without auth instead of being
+// special-cased inside the auth middleware, and provider-calling routes get a tighter
+// rate limit by belonging to the generation group.
+//
+// Every route gets the router's request timeout except streaming routes (SSE, WebSocket,
+// downloads and uploads), registered with Group.Stream, which run as long as the client
+// stays connected.
+//
+// Registration order doesn't matter: http.ServeMux (Go 1.22+ patterns) always picks the
+// most specific pattern, so GET /api/documents/search wins over GET /api/documents/{id}
+// wherever either is registered.
+package router
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Middleware wraps a handler
+type Middleware func(http.Handler) http.Handler
+
+// Route is one registered method+pattern
+type Route struct {
+	Group     string
+	Pattern   string // e.g. "GET /api/projects/{id}"
+	Streaming bool   // Exempt from the request timeout
+	handler   http.Handler
+}
+
+// Group is a named set of routes sharing middleware
+type Group struct {
+	name       string
+	middleware []Middleware
+	routes     []Route
+}
+
+// Handle registers a handler for a pattern
+func (g *Group) Handle(pattern string, handler http.Handler) {
+	g.routes = append(g.routes, Route{Group: g.name, Pattern: pattern, handler: handler})
+}
+
+// HandleFunc registers a handler function for a pattern
+func (g *Group) HandleFunc(pattern string, handler http.HandlerFunc) {
+	g.Handle(pattern, handler)
+}
+
+// Stream registers a streaming handler, exempt from the request timeout
+func (g *Group) Stream(pattern string, handler http.Handler) {
+	g.routes = append(g.routes, Route{Group: g.name, Pattern: pattern, Streaming: true, handler: handler})
+}
+
+// StreamFunc registers a streaming handler function
+func (g *Group) StreamFunc(pattern string, handler http.HandlerFunc) {
+	g.Stream(pattern, handler)
+}
+
+// Router collects route groups and builds the mux
+type Router struct {
+	groups []*Group
+
+	// Timeout wraps every non-streaming route, outside its group's middleware (nil = none)
+	Timeout Middleware
+}
+
+// Group adds a route group. Middleware applies to the group's routes only, outermost
+// first; nil middleware is skipped.
+func (r *Router) Group(name string, middleware ...Middleware) *Group {
+	group := &Group{name: name}
+	for _, mw := range middleware {
+		if mw != nil {
+			group.middleware = append(group.middleware, mw)
+		}
+	}
+	r.groups = append(r.groups, group)
+	return group
+}
+
+// Routes returns every registered route, by group in registration order
+func (r *Router) Routes() []Route {
+	var routes []Route
+	for _, group := range r.groups {
+		routes = append(routes, group.routes...)
+	}
+	return routes
+}
+
+// Handler builds the mux, wrapping each route in its group's middleware and, unless it
+// streams, the timeout.
+// A pattern registered twice (or conflicting with another) is an error.
+func (r *Router) Handler() (handler http.Handler, err error) {
+	mux := http.NewServeMux()
+	defer func() {
+		// ServeMux reports conflicting patterns by panicking
+		if recovered := recover(); recovered != nil {
+			handler, err = nil, fmt.Errorf("register routes: %v", recovered)
+		}
+	}()
+
+	for _, group := range r.groups {
+		for _, route := range group.routes {
+			h := route.handler
+			for i := len(group.middleware) - 1; i >= 0; i-- {
+				h = group.middleware[i](h)
+			}
+			if r.Timeout != nil && !route.Streaming {
+				h = r.Timeout(h)
+			}
+			mux.Handle(route.Pattern, h)
+		}
+	}
+	return mux, nil
+}
@@ -0,0 +1,252 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"meridian/internal/handler"
+)
+
+// expectedRoutes is the route table snapshot; "(stream)" marks routes exempt from the
+// request timeout. A failure here means a route was added, removed, renamed, moved to
+// another group or (un)exempted: update the list if that was intended (and the API
+// contracts doc with it).
+var expectedRoutes = []string{
+	"admin GET /health",
+	"admin GET /storage/ (stream)",
+	"docsystem GET /api/projects",
+	"docsystem POST /api/projects",
+	"docsystem GET /api/projects/{id}",
+	"docsystem PATCH /api/projects/{id}",
+	"docsystem DELETE /api/projects/{id}",
+	"docsystem GET /api/projects/{id}/settings",
+	"docsystem PATCH /api/projects/{id}/settings",
+	"docsystem POST /api/projects/{id}/style-profile",
+	"docsystem GET /api/projects/{id}/style-profile",
+	"docsystem DELETE /api/projects/{id}/style-profile",
+	"docsystem GET /api/projects/{id}/tree",
+	"docsystem GET /api/projects/{id}/skills/lint",
+	"docsystem GET /api/projects/{id}/duplicates",
+	"docsystem POST /api/projects/{id}/snapshots",
+	"docsystem GET /api/projects/{id}/snapshots",
+	"docsystem GET /api/projects/{id}/snapshots/{snapshotId}",
+	"docsystem GET /api/projects/{id}/snapshots/{snapshotId}/diff",
+	"docsystem POST /api/projects/{id}/snapshots/{snapshotId}/restore",
+	"docsystem POST /api/projects/{id}/exports",
+	"docsystem GET /api/exports/{id}",
+	"docsystem GET /api/exports/{id}/download (stream)",
+	"docsystem GET /api/projects/{id}/export (stream)",
+	"docsystem POST /api/projects/{id}/publish-targets",
+	"docsystem GET /api/projects/{id}/publish-targets",
+	"docsystem GET /api/projects/{id}/publish-targets/{targetId}",
+	"docsystem PATCH /api/projects/{id}/publish-targets/{targetId}",
+	"docsystem DELETE /api/projects/{id}/publish-targets/{targetId}",
+	"docsystem POST /api/projects/{id}/publish-targets/{targetId}/publish",
+	"docsystem GET /api/projects/{id}/publish-targets/{targetId}/deliveries",
+	"docsystem POST /api/folders",
+	"docsystem GET /api/folders/{id}",
+	"docsystem PATCH /api/folders/{id}",
+	"docsystem DELETE /api/folders/{id}",
+	"docsystem GET /api/folders/{id}/children",
+	"docsystem POST /api/documents",
+	"docsystem GET /api/documents/search",
+	"docsystem GET /api/documents/{id}",
+	"docsystem PATCH /api/documents/{id}",
+	"docsystem DELETE /api/documents/{id}",
+	"docsystem GET /api/documents/{id}/diff",
+	"docsystem GET /api/documents/{id}/analytics",
+	"docsystem POST /api/documents/{id}/presence",
+	"docsystem GET /api/documents/{id}/presence",
+	"docsystem DELETE /api/documents/{id}/presence/{sessionId}",
+	"docsystem GET /api/documents/{id}/collab (stream)",
+	"docsystem GET /api/documents/{id}/revisions",
+	"docsystem GET /api/documents/{id}/revisions/{revisionId}",
+	"docsystem POST /api/import (stream)",
+	"docsystem POST /api/import/replace (stream)",
+	"docsystem GET /api/import/manifest",
+	"docsystem GET /api/jobs/{id}",
+	"docsystem GET /api/jobs/{id}/events (stream)",
+	"docsystem GET /api/projects/{id}/trash",
+	"docsystem POST /api/documents/{id}/restore",
+	"docsystem POST /api/folders/{id}/restore",
+	"docsystem POST /api/chats/{id}/restore",
+	"llm GET /api/models/capabilities",
+	"llm GET /api/usage",
+	"llm GET /api/chats/{id}/usage",
+	"llm GET /api/projects/{id}/usage",
+	"llm POST /api/chats",
+	"llm GET /api/chats",
+	"llm GET /api/chats/search",
+	"llm GET /api/chats/{id}",
+	"llm PATCH /api/chats/{id}",
+	"llm PATCH /api/chats/{id}/settings",
+	"llm PATCH /api/chats/{id}/last-viewed-turn",
+	"llm DELETE /api/chats/{id}",
+	"llm GET /api/chats/{id}/turns/search",
+	"llm GET /api/chats/{id}/turns",
+	"llm GET /api/chats/{id}/tree",
+	"llm GET /api/turns",
+	"llm GET /api/turns/{id}/path",
+	"llm GET /api/turns/{id}/siblings",
+	"llm GET /api/turns/{id}/blocks",
+	"llm GET /api/turns/{id}/token-usage",
+	"llm PATCH /api/turns/{id}/annotations",
+	"llm POST /api/turns/{id}/interrupt",
+	"llm GET /api/turns/{id}/critique",
+	"llm POST /api/turns/{id}/pin-to-project",
+	"llm GET /api/documents/{id}/source",
+	"llm PUT /api/turns/{id}/feedback",
+	"llm POST /api/attachments",
+	"llm GET /api/attachments/{id}",
+	"llm DELETE /api/attachments/{id}",
+	"llm GET /api/turns/{id}/stream (stream)",
+	"llm GET /api/turns/{id}/ws (stream)",
+	"llm POST /api/projects/{id}/workflows",
+	"llm GET /api/projects/{id}/workflows",
+	"llm GET /api/projects/{id}/workflows/{workflowId}",
+	"llm PATCH /api/projects/{id}/workflows/{workflowId}",
+	"llm DELETE /api/projects/{id}/workflows/{workflowId}",
+	"llm POST /api/projects/{id}/workflows/{workflowId}/start",
+	"llm GET /api/chats/{id}/workflow",
+	"llm POST /api/chats/{id}/workflow/advance",
+	"llm POST /api/projects/{id}/evals/cases",
+	"llm GET /api/projects/{id}/evals/cases",
+	"llm PATCH /api/projects/{id}/evals/cases/{caseId}",
+	"llm DELETE /api/projects/{id}/evals/cases/{caseId}",
+	"llm GET /api/projects/{id}/evals/cases/{caseId}/history",
+	"llm GET /api/projects/{id}/evals/runs",
+	"llm GET /api/projects/{id}/evals/runs/{runId}",
+	"llm GET /api/documents/{id}/suggestions",
+	"llm DELETE /api/documents/{id}/suggestions/{suggestionId}",
+	"llm POST /api/documents/{id}/suggestions/{suggestionId}/accept",
+	"llm POST /api/documents/{id}/suggestions/{suggestionId}/reject",
+	"generation POST /api/turns",
+	"generation POST /api/chats/{id}/turns",
+	"generation POST /api/turns/{id}/retry",
+	"generation POST /api/turns/{id}/critique",
+	"generation POST /api/turns/{id}/tts",
+	"generation POST /api/projects/{id}/evals/runs",
+	"generation POST /api/documents/{id}/complete (stream)",
+	"generation POST /api/documents/{id}/rewrite (stream)",
+	"generation POST /api/documents/{id}/translate (stream)",
+	"users GET /api/users/me/preferences",
+	"users PATCH /api/users/me/preferences",
+	"users GET /api/users/me/provider-keys",
+	"users PUT /api/users/me/provider-keys/{provider}",
+	"users DELETE /api/users/me/provider-keys/{provider}",
+	"users GET /api/users/me/data-controls",
+	"adminapi GET /admin/api/experiments",
+	"adminapi POST /admin/api/experiments",
+	"adminapi PATCH /admin/api/experiments/{id}",
+	"adminapi GET /admin/api/experiments/{id}/results",
+	"adminapi GET /admin/api/abuse-flags",
+	"adminapi POST /admin/api/abuse-flags/{id}/resolve",
+	"debug POST /debug/api/chats/{id}/turns",
+	"debug GET /debug/api/chats/{id}/tree",
+	"debug POST /debug/api/chats/{id}/llm-request",
+	"debug POST /debug/api/turns/{id}/replay",
+}
+
+func routeTable(r *Router) []string {
+	var table []string
+	for _, route := range r.Routes() {
+		entry := route.Group + " " + route.Pattern
+		if route.Streaming {
+			entry += " (stream)"
+		}
+		table = append(table, entry)
+	}
+	return table
+}
+
+func TestNew_RouteTableSnapshot(t *testing.T) {
+	// Handlers are never called; nil handlers are enough to register routes
+	r := New(&Handlers{
+		ObjectDownloads: http.NotFoundHandler(),
+		ChatDebug:       &handler.ChatDebugHandler{},
+	}, Options{Environment: "dev", AdminAuth: func(next http.Handler) http.Handler { return next }})
+
+	got := routeTable(r)
+	if strings.Join(got, "\n") != strings.Join(expectedRoutes, "\n") {
+		t.Errorf("route table changed:\n got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(expectedRoutes, "\n"))
+	}
+	if _, err := r.Handler(); err != nil {
+		t.Errorf("Handler: %v", err)
+	}
+}
+
+func TestNew_EnvironmentSpecificRoutes(t *testing.T) {
+	r := New(&Handlers{ChatDebug: &handler.ChatDebugHandler{}}, Options{Environment: "prod"})
+
+	for _, route := range routeTable(r) {
+		if strings.HasPrefix(route, "debug ") || strings.HasPrefix(route, "adminapi ") || strings.Contains(route, "/storage/ ") {
+			t.Errorf("unexpected route %q", route)
+		}
+	}
+	if got, want := len(routeTable(r)), len(expectedRoutes)-11; got != want {
+		t.Errorf("routes = %d, want %d", got, want)
+	}
+}
+
+func TestHandler_GroupMiddleware(t *testing.T) {
+	denyAll := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+	}
+	tag := func(value string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("X-Tag", value)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	r := &Router{Timeout: tag("timeout")}
+	r.Group("public", nil).HandleFunc("GET /health", ok)
+	tagged := r.Group("tagged", tag("outer"), tag("inner"))
+	tagged.HandleFunc("GET /api/items/{id}", ok)
+	tagged.StreamFunc("GET /api/items/{id}/stream", ok)
+	r.Group("private", denyAll).HandleFunc("GET /api/items/search", ok)
+
+	mux, err := r.Handler()
+	if err != nil {
+		t.Fatalf("Handler: %v", err)
+	}
+
+	tests := []struct {
+		path   string
+		status int
+		tags   string
+	}{
+		{"/health", http.StatusOK, "timeout"},
+		{"/api/items/1", http.StatusOK, "timeout,outer,inner"},
+		{"/api/items/1/stream", http.StatusOK, "outer,inner"},     // No timeout
+		{"/api/items/search", http.StatusUnauthorized, "timeout"}, // Most specific pattern, whatever the group
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if rec.Code != tt.status {
+			t.Errorf("GET %s status = %d, want %d", tt.path, rec.Code, tt.status)
+		}
+		if tags := strings.Join(rec.Header().Values("X-Tag"), ","); tags != tt.tags {
+			t.Errorf("GET %s middleware = %q, want %q", tt.path, tags, tt.tags)
+		}
+	}
+}
+
+func TestHandler_DuplicatePattern(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+	r := &Router{}
+	r.Group("a").HandleFunc("GET /api/items", ok)
+	r.Group("b").HandleFunc("GET /api/items", ok)
+
+	if _, err := r.Handler(); err == nil {
+		t.Error("duplicate pattern registered without error")
+	}
+}
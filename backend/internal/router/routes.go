@@ -0,0 +1,304 @@
+package router
+
+import (
+	"net/http"
+
+	"meridian/internal/handler"
+	"meridian/internal/storage"
+)
+
+// Handlers are the HTTP handlers the routes dispatch to
+type Handlers struct {
+	Health *handler.HealthHandler
+
+	// Document system
+	Project           *handler.ProjectHandler
+	StyleProfile      *handler.StyleProfileHandler
+	Tree              *handler.TreeHandler
+	Skill             *handler.SkillHandler
+	Duplicate         *handler.DuplicateHandler
+	Snapshot          *handler.SnapshotHandler
+	Export            *handler.ExportHandler
+	Archive           *handler.ArchiveHandler
+	Publish           *handler.PublishHandler
+	Folder            *handler.FolderHandler
+	Document          *handler.DocumentHandler
+	DocumentDiff      *handler.DocumentDiffHandler
+	DocumentAnalytics *handler.DocumentAnalyticsHandler
+	DocumentPresence  *handler.DocumentPresenceHandler
+	DocumentCollab    *handler.DocumentCollabHandler
+	Import            *handler.ImportHandler
+	Job               *handler.JobHandler
+	Trash             *handler.TrashHandler
+
+	// LLM
+	Chat       *handler.ChatHandler
+	Workflow   *handler.WorkflowHandler
+	Eval       *handler.EvalHandler
+	Speech     *handler.SpeechHandler
+	Critique   *handler.CritiqueHandler
+	Pin        *handler.PinHandler
+	Attachment *handler.AttachmentHandler
+	Editor     *handler.EditorHandler
+	Models     *handler.ModelsHandler
+	Usage      *handler.UsageHandler
+	Experiment *handler.ExperimentHandler
+	Abuse      *handler.AbuseHandler
+
+	// Users
+	UserPreferences *handler.UserPreferencesHandler
+	ProviderKey     *handler.ProviderKeyHandler
+	DataControls    *handler.DataControlsHandler
+
+	// Optional
+	ObjectDownloads http.Handler              // Signed local-storage downloads (nil with S3, whose URLs point at the bucket)
+	ChatDebug       *handler.ChatDebugHandler // Debug endpoints (registered in dev only)
+}
+
+// Options selects environment-specific routes and the route middleware
+type Options struct {
+	Environment string     // Debug routes are registered in "dev" only
+	Auth        Middleware // Applied to every group except admin and adminapi
+	Timeout     Middleware // Applied to every non-streaming route (nil = no timeout)
+	AdminAuth   Middleware // Guards the operator API; nil leaves it unregistered
+
+	// Per-user rate limits, applied after Auth (nil = unlimited). Generation routes
+	// (provider calls) get their own, tighter limit instead of RateLimit.
+	RateLimit           Middleware
+	GenerationRateLimit Middleware
+}
+
+// New registers the server's routes
+func New(h *Handlers, opts Options) *Router {
+	r := &Router{Timeout: opts.Timeout}
+	registerAdmin(r.Group("admin"), h)
+	registerDocsystem(r.Group("docsystem", opts.Auth, opts.RateLimit), h)
+	registerLLM(r.Group("llm", opts.Auth, opts.RateLimit), h)
+	registerGeneration(r.Group("generation", opts.Auth, opts.GenerationRateLimit), h)
+	registerUsers(r.Group("users", opts.Auth, opts.RateLimit), h)
+	if opts.AdminAuth != nil {
+		registerAdminAPI(r.Group("adminapi", opts.AdminAuth), h)
+	}
+	if opts.Environment == "dev" && h.ChatDebug != nil {
+		registerDebug(r.Group("debug", opts.Auth, opts.RateLimit), h)
+	}
+	return r
+}
+
+// registerAdmin registers public operational routes (no auth)
+func registerAdmin(g *Group, h *Handlers) {
+	// Health check (reports maintenance mode and active streams)
+	g.HandleFunc("GET /health", h.Health.HealthCheck)
+
+	// Signed object downloads (the handler verifies the signature)
+	if h.ObjectDownloads != nil {
+		g.Stream("GET "+storage.LocalRoutePrefix, h.ObjectDownloads)
+	}
+}
+
+// registerDocsystem registers project, folder, document and import routes
+func registerDocsystem(g *Group, h *Handlers) {
+	// Project routes
+	g.HandleFunc("GET /api/projects", h.Project.ListProjects)
+	g.HandleFunc("POST /api/projects", h.Project.CreateProject)
+	g.HandleFunc("GET /api/projects/{id}", h.Project.GetProject)
+	g.HandleFunc("PATCH /api/projects/{id}", h.Project.UpdateProject)
+	g.HandleFunc("DELETE /api/projects/{id}", h.Project.DeleteProject)
+	g.HandleFunc("GET /api/projects/{id}/settings", h.Project.GetProjectSettings)
+	g.HandleFunc("PATCH /api/projects/{id}/settings", h.Project.UpdateProjectSettings)
+
+	// Project style profile routes (analysis runs in the background)
+	g.HandleFunc("POST /api/projects/{id}/style-profile", h.StyleProfile.AnalyzeStyle)
+	g.HandleFunc("GET /api/projects/{id}/style-profile", h.StyleProfile.GetStyleProfile)
+	g.HandleFunc("DELETE /api/projects/{id}/style-profile", h.StyleProfile.DeleteStyleProfile)
+
+	// Project tree, skills lint and duplicate-content report
+	g.HandleFunc("GET /api/projects/{id}/tree", h.Tree.GetTree)
+	g.HandleFunc("GET /api/projects/{id}/skills/lint", h.Skill.LintSkills)
+	g.HandleFunc("GET /api/projects/{id}/duplicates", h.Duplicate.FindDuplicates)
+
+	// Project snapshot routes
+	g.HandleFunc("POST /api/projects/{id}/snapshots", h.Snapshot.CreateSnapshot)
+	g.HandleFunc("GET /api/projects/{id}/snapshots", h.Snapshot.ListSnapshots)
+	g.HandleFunc("GET /api/projects/{id}/snapshots/{snapshotId}", h.Snapshot.GetSnapshot)
+	g.HandleFunc("GET /api/projects/{id}/snapshots/{snapshotId}/diff", h.Snapshot.DiffSnapshot)
+	g.HandleFunc("POST /api/projects/{id}/snapshots/{snapshotId}/restore", h.Snapshot.RestoreSnapshot)
+
+	// Export routes (asynchronous jobs), and the streamed zip export (no job)
+	g.HandleFunc("POST /api/projects/{id}/exports", h.Export.CreateExport)
+	g.HandleFunc("GET /api/exports/{id}", h.Export.GetExport)
+	g.StreamFunc("GET /api/exports/{id}/download", h.Export.DownloadExport)
+	g.StreamFunc("GET /api/projects/{id}/export", h.Archive.ExportProject)
+
+	// Publish target routes
+	g.HandleFunc("POST /api/projects/{id}/publish-targets", h.Publish.CreateTarget)
+	g.HandleFunc("GET /api/projects/{id}/publish-targets", h.Publish.ListTargets)
+	g.HandleFunc("GET /api/projects/{id}/publish-targets/{targetId}", h.Publish.GetTarget)
+	g.HandleFunc("PATCH /api/projects/{id}/publish-targets/{targetId}", h.Publish.UpdateTarget)
+	g.HandleFunc("DELETE /api/projects/{id}/publish-targets/{targetId}", h.Publish.DeleteTarget)
+	g.HandleFunc("POST /api/projects/{id}/publish-targets/{targetId}/publish", h.Publish.Publish)
+	g.HandleFunc("GET /api/projects/{id}/publish-targets/{targetId}/deliveries", h.Publish.ListDeliveries)
+
+	// Folder routes
+	g.HandleFunc("POST /api/folders", h.Folder.CreateFolder)
+	g.HandleFunc("GET /api/folders/{id}", h.Folder.GetFolder)
+	g.HandleFunc("PATCH /api/folders/{id}", h.Folder.UpdateFolder)
+	g.HandleFunc("DELETE /api/folders/{id}", h.Folder.DeleteFolder)
+	g.HandleFunc("GET /api/folders/{id}/children", h.Folder.ListChildren)
+
+	// Document routes
+	g.HandleFunc("POST /api/documents", h.Document.CreateDocument)
+	g.HandleFunc("GET /api/documents/search", h.Document.SearchDocuments)
+	g.HandleFunc("GET /api/documents/{id}", h.Document.GetDocument)
+	g.HandleFunc("PATCH /api/documents/{id}", h.Document.UpdateDocument)
+	g.HandleFunc("DELETE /api/documents/{id}", h.Document.DeleteDocument)
+	g.HandleFunc("GET /api/documents/{id}/diff", h.DocumentDiff.DiffDocument)
+	g.HandleFunc("GET /api/documents/{id}/analytics", h.DocumentAnalytics.GetDocumentAnalytics)
+
+	// Editor session presence (heartbeats), so a second tab can warn before editing
+	g.HandleFunc("POST /api/documents/{id}/presence", h.DocumentPresence.Heartbeat)
+	g.HandleFunc("GET /api/documents/{id}/presence", h.DocumentPresence.GetPresence)
+	g.HandleFunc("DELETE /api/documents/{id}/presence/{sessionId}", h.DocumentPresence.Leave)
+
+	// Collaborative editing: Yjs updates over WebSocket, saved into content and revisions
+	g.StreamFunc("GET /api/documents/{id}/collab", h.DocumentCollab.Collaborate) // WebSocket
+	g.HandleFunc("GET /api/documents/{id}/revisions", h.DocumentCollab.ListRevisions)
+	g.HandleFunc("GET /api/documents/{id}/revisions/{revisionId}", h.DocumentCollab.GetRevision)
+
+	// Import routes (uploads stream in; large zips outlast the request timeout)
+	g.StreamFunc("POST /api/import", h.Import.Merge)
+	g.StreamFunc("POST /api/import/replace", h.Import.Replace)
+	g.HandleFunc("GET /api/import/manifest", h.Import.Manifest)
+
+	// Background job routes (imports); events is an SSE progress stream
+	g.HandleFunc("GET /api/jobs/{id}", h.Job.GetJob)
+	g.StreamFunc("GET /api/jobs/{id}/events", h.Job.StreamJob)
+
+	// Trash: soft-deleted documents, folders and chats
+	g.HandleFunc("GET /api/projects/{id}/trash", h.Trash.ListTrash)
+	g.HandleFunc("POST /api/documents/{id}/restore", h.Trash.RestoreDocument)
+	g.HandleFunc("POST /api/folders/{id}/restore", h.Trash.RestoreFolder)
+	g.HandleFunc("POST /api/chats/{id}/restore", h.Trash.RestoreChat)
+}
+
+// registerLLM registers chat, turn, streaming, workflow and editor suggestion routes (routes
+// that call providers are in registerGeneration)
+func registerLLM(g *Group, h *Handlers) {
+	// Model capabilities and spend
+	g.HandleFunc("GET /api/models/capabilities", h.Models.GetCapabilities)
+	g.HandleFunc("GET /api/usage", h.Usage.GetUsage)
+	g.HandleFunc("GET /api/chats/{id}/usage", h.Usage.GetChatUsage)       // Daily rollups
+	g.HandleFunc("GET /api/projects/{id}/usage", h.Usage.GetProjectUsage) // Daily rollups
+
+	// Chat routes
+	g.HandleFunc("POST /api/chats", h.Chat.CreateChat)
+	g.HandleFunc("GET /api/chats", h.Chat.ListChats)
+	g.HandleFunc("GET /api/chats/search", h.Chat.SearchChats) // Across a project's chats
+	g.HandleFunc("GET /api/chats/{id}", h.Chat.GetChat)
+	g.HandleFunc("PATCH /api/chats/{id}", h.Chat.UpdateChat)
+	g.HandleFunc("PATCH /api/chats/{id}/settings", h.Chat.UpdateChatSettings)
+	g.HandleFunc("PATCH /api/chats/{id}/last-viewed-turn", h.Chat.UpdateLastViewedTurn)
+	g.HandleFunc("DELETE /api/chats/{id}", h.Chat.DeleteChat)
+	g.HandleFunc("GET /api/chats/{id}/turns/search", h.Chat.SearchTurns)
+	g.HandleFunc("GET /api/chats/{id}/turns", h.Chat.GetPaginatedTurns)
+	g.HandleFunc("GET /api/chats/{id}/tree", h.Chat.GetTurnTree) // Turn skeletons for branch pickers
+
+	// Turn routes
+	g.HandleFunc("GET /api/turns", h.Chat.GetTurns) // ?ids=X,Y,Z
+	g.HandleFunc("GET /api/turns/{id}/path", h.Chat.GetTurnPath)
+	g.HandleFunc("GET /api/turns/{id}/siblings", h.Chat.GetTurnSiblings)
+	g.HandleFunc("GET /api/turns/{id}/blocks", h.Chat.GetTurnBlocks)
+	g.HandleFunc("GET /api/turns/{id}/token-usage", h.Chat.GetTurnTokenUsage)
+	g.HandleFunc("PATCH /api/turns/{id}/annotations", h.Chat.UpdateTurnAnnotations) // Client rendering hints
+	g.HandleFunc("POST /api/turns/{id}/interrupt", h.Chat.InterruptTurn)            // Cancel streaming turn
+	g.HandleFunc("GET /api/turns/{id}/critique", h.Critique.GetCritique)            // Get critic review
+	g.HandleFunc("POST /api/turns/{id}/pin-to-project", h.Pin.PinTurn)              // Save answer as a document
+	g.HandleFunc("GET /api/documents/{id}/source", h.Pin.GetDocumentSource)         // Turn a pinned document came from
+
+	// Turn feedback (thumbs up/down; compares experiment variants)
+	g.HandleFunc("PUT /api/turns/{id}/feedback", h.Experiment.SetTurnFeedback)
+
+	// Chat attachments (images referenced by image blocks of user turns)
+	g.HandleFunc("POST /api/attachments", h.Attachment.UploadAttachment) // ?project_id=, multipart "file"
+	g.HandleFunc("GET /api/attachments/{id}", h.Attachment.GetAttachment)
+	g.HandleFunc("DELETE /api/attachments/{id}", h.Attachment.DeleteAttachment)
+
+	// Streaming routes
+	g.StreamFunc("GET /api/turns/{id}/stream", h.Chat.StreamTurn)      // SSE
+	g.StreamFunc("GET /api/turns/{id}/ws", h.Chat.StreamTurnWebSocket) // WebSocket
+
+	// Workflow routes
+	g.HandleFunc("POST /api/projects/{id}/workflows", h.Workflow.CreateWorkflow)
+	g.HandleFunc("GET /api/projects/{id}/workflows", h.Workflow.ListWorkflows)
+	g.HandleFunc("GET /api/projects/{id}/workflows/{workflowId}", h.Workflow.GetWorkflow)
+	g.HandleFunc("PATCH /api/projects/{id}/workflows/{workflowId}", h.Workflow.UpdateWorkflow)
+	g.HandleFunc("DELETE /api/projects/{id}/workflows/{workflowId}", h.Workflow.DeleteWorkflow)
+	g.HandleFunc("POST /api/projects/{id}/workflows/{workflowId}/start", h.Workflow.StartWorkflow)
+	g.HandleFunc("GET /api/chats/{id}/workflow", h.Workflow.GetRun)
+	g.HandleFunc("POST /api/chats/{id}/workflow/advance", h.Workflow.AdvanceRun)
+
+	// Eval cases and runs (runs execute as background jobs; results at GET .../runs/{runId})
+	g.HandleFunc("POST /api/projects/{id}/evals/cases", h.Eval.CreateCase)
+	g.HandleFunc("GET /api/projects/{id}/evals/cases", h.Eval.ListCases)
+	g.HandleFunc("PATCH /api/projects/{id}/evals/cases/{caseId}", h.Eval.UpdateCase)
+	g.HandleFunc("DELETE /api/projects/{id}/evals/cases/{caseId}", h.Eval.DeleteCase)
+	g.HandleFunc("GET /api/projects/{id}/evals/cases/{caseId}/history", h.Eval.CaseHistory)
+	g.HandleFunc("GET /api/projects/{id}/evals/runs", h.Eval.ListRuns)
+	g.HandleFunc("GET /api/projects/{id}/evals/runs/{runId}", h.Eval.GetRun)
+
+	// Editor suggestions (generated by POST /api/documents/{id}/rewrite and /translate)
+	g.HandleFunc("GET /api/documents/{id}/suggestions", h.Editor.ListSuggestions)
+	g.HandleFunc("DELETE /api/documents/{id}/suggestions/{suggestionId}", h.Editor.DeleteSuggestion)
+	g.HandleFunc("POST /api/documents/{id}/suggestions/{suggestionId}/accept", h.Editor.AcceptSuggestion)
+	g.HandleFunc("POST /api/documents/{id}/suggestions/{suggestionId}/reject", h.Editor.DeleteSuggestion)
+}
+
+// registerGeneration registers the routes that call LLM or speech providers (and spend
+// provider credits), which get a tighter rate limit than the rest of the API
+func registerGeneration(g *Group, h *Handlers) {
+	// Turn generation
+	g.HandleFunc("POST /api/turns", h.Chat.CreateTurnV2)                   // chat_id/project_id in body
+	g.HandleFunc("POST /api/chats/{id}/turns", h.Chat.CreateTurn)          // Deprecated: use POST /api/turns
+	g.HandleFunc("POST /api/turns/{id}/retry", h.Chat.RetryTurn)           // Regenerate as a sibling turn
+	g.HandleFunc("POST /api/turns/{id}/critique", h.Critique.CritiqueTurn) // Start critic pass
+	g.HandleFunc("POST /api/turns/{id}/tts", h.Speech.SynthesizeTurn)      // Read turn text aloud
+
+	// Eval runs (queued; each output and judge verdict is a provider call)
+	g.HandleFunc("POST /api/projects/{id}/evals/runs", h.Eval.StartRun)
+
+	// Editor assistance (SSE responses)
+	g.StreamFunc("POST /api/documents/{id}/complete", h.Editor.CompleteDocument)
+	g.StreamFunc("POST /api/documents/{id}/rewrite", h.Editor.RewriteSelection)
+	g.StreamFunc("POST /api/documents/{id}/translate", h.Editor.TranslateDocument)
+}
+
+// registerUsers registers the current user's settings routes
+func registerUsers(g *Group, h *Handlers) {
+	g.HandleFunc("GET /api/users/me/preferences", h.UserPreferences.GetPreferences)
+	g.HandleFunc("PATCH /api/users/me/preferences", h.UserPreferences.UpdatePreferences)
+	g.HandleFunc("GET /api/users/me/provider-keys", h.ProviderKey.ListKeys)
+	g.HandleFunc("PUT /api/users/me/provider-keys/{provider}", h.ProviderKey.SetKey)
+	g.HandleFunc("DELETE /api/users/me/provider-keys/{provider}", h.ProviderKey.DeleteKey)
+	g.HandleFunc("GET /api/users/me/data-controls", h.DataControls.GetDataControls)
+}
+
+// registerAdminAPI registers operator endpoints, which act across all users
+func registerAdminAPI(g *Group, h *Handlers) {
+	// Prompt/params experiments
+	g.HandleFunc("GET /admin/api/experiments", h.Experiment.ListExperiments)
+	g.HandleFunc("POST /admin/api/experiments", h.Experiment.CreateExperiment)
+	g.HandleFunc("PATCH /admin/api/experiments/{id}", h.Experiment.UpdateExperiment)
+	g.HandleFunc("GET /admin/api/experiments/{id}/results", h.Experiment.GetResults)
+
+	// Abuse flags (turn/tool round spikes, oversized tool inputs)
+	g.HandleFunc("GET /admin/api/abuse-flags", h.Abuse.ListFlags)
+	g.HandleFunc("POST /admin/api/abuse-flags/{id}/resolve", h.Abuse.ResolveFlag)
+}
+
+// registerDebug registers development-only endpoints (NEVER enable in production)
+func registerDebug(g *Group, h *Handlers) {
+	g.HandleFunc("POST /debug/api/chats/{id}/turns", h.ChatDebug.CreateAssistantTurn)
+	g.HandleFunc("GET /debug/api/chats/{id}/tree", h.ChatDebug.GetChatTree) // Full tree - use GET /api/chats/{id}/tree in production
+	g.HandleFunc("POST /debug/api/chats/{id}/llm-request", h.ChatDebug.BuildProviderRequest)
+	g.HandleFunc("POST /debug/api/turns/{id}/replay", h.ChatDebug.ReplayTurn) // Regenerate a turn in a sandbox chat
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// maxAccessCacheEntries bounds memory; a full cache is swept of expired grants,
+// and cleared if that frees nothing (grants are cheap to recompute)
+const maxAccessCacheEntries = 10000
+
+// Resource kinds used in cache keys
+const (
+	accessKindProject  = "project"
+	accessKindFolder   = "folder"
+	accessKindDocument = "document"
+	accessKindChat     = "chat"
+	accessKindTurn     = "turn"
+)
+
+// accessCache remembers successful authorization checks per user for a short TTL.
+// Only grants are cached: a denial is always rechecked, so a resource a user just
+// created is reachable immediately. Deleting a chat or project forgets the user's
+// grants (see forget); other revocations may still pass for up to ttl, which is why
+// ttl should stay in the seconds range.
+type accessCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[accessKey]time.Time // key -> expiry
+	now     func() time.Time
+}
+
+// accessKey identifies one cached grant
+type accessKey struct {
+	kind, userID, resourceID string
+}
+
+// newAccessCache returns a cache with the given TTL, or nil (caching disabled) if ttl <= 0
+func newAccessCache(ttl time.Duration) *accessCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &accessCache{
+		ttl:     ttl,
+		entries: make(map[accessKey]time.Time),
+		now:     time.Now,
+	}
+}
+
+// allowed reports whether a non-expired grant is cached. Safe on a nil cache.
+func (c *accessCache) allowed(kind, userID, resourceID string) bool {
+	if c == nil {
+		return false
+	}
+	key := accessKey{kind: kind, userID: userID, resourceID: resourceID}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expiry, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	if !c.now().Before(expiry) {
+		delete(c.entries, key)
+		return false
+	}
+	return true
+}
+
+// grant caches a successful check. Safe on a nil cache.
+func (c *accessCache) grant(kind, userID, resourceID string) {
+	if c == nil {
+		return
+	}
+	now := c.now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxAccessCacheEntries {
+		for key, expiry := range c.entries {
+			if !now.Before(expiry) {
+				delete(c.entries, key)
+			}
+		}
+		if len(c.entries) >= maxAccessCacheEntries {
+			c.entries = make(map[accessKey]time.Time)
+		}
+	}
+	c.entries[accessKey{kind: kind, userID: userID, resourceID: resourceID}] = now.Add(c.ttl)
+}
+
+// forget drops every grant cached for userID. Grants don't record which project they
+// came through, so all of the user's are dropped; they are cheap to recompute.
+// Safe on a nil cache.
+func (c *accessCache) forget(userID string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if key.userID == userID {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAccessCache(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newAccessCache(5 * time.Second)
+	cache.now = func() time.Time { return now }
+
+	if cache.allowed(accessKindChat, "user-1", "chat-1") {
+		t.Fatal("empty cache allowed access")
+	}
+
+	cache.grant(accessKindChat, "user-1", "chat-1")
+	if !cache.allowed(accessKindChat, "user-1", "chat-1") {
+		t.Fatal("granted access not cached")
+	}
+	if cache.allowed(accessKindChat, "user-2", "chat-1") {
+		t.Fatal("grant leaked to another user")
+	}
+	if cache.allowed(accessKindTurn, "user-1", "chat-1") {
+		t.Fatal("grant leaked to another resource kind")
+	}
+
+	now = now.Add(5 * time.Second)
+	if cache.allowed(accessKindChat, "user-1", "chat-1") {
+		t.Fatal("expired grant still allowed")
+	}
+	if len(cache.entries) != 0 {
+		t.Fatalf("expired entry not evicted, %d entries left", len(cache.entries))
+	}
+}
+
+func TestAccessCache_Disabled(t *testing.T) {
+	cache := newAccessCache(0)
+	if cache != nil {
+		t.Fatal("zero TTL should disable the cache")
+	}
+
+	// nil cache is a no-op
+	cache.grant(accessKindProject, "user-1", "project-1")
+	if cache.allowed(accessKindProject, "user-1", "project-1") {
+		t.Fatal("disabled cache allowed access")
+	}
+}
+
+func TestAccessCache_Bounded(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newAccessCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i < maxAccessCacheEntries+10; i++ {
+		cache.grant(accessKindTurn, "user-1", time.Duration(i).String())
+	}
+	if len(cache.entries) > maxAccessCacheEntries {
+		t.Fatalf("cache grew to %d entries, max %d", len(cache.entries), maxAccessCacheEntries)
+	}
+}
+
+func TestAccessCache_Forget(t *testing.T) {
+	cache := newAccessCache(time.Minute)
+	cache.grant(accessKindChat, "user-1", "chat-1")
+	cache.grant(accessKindTurn, "user-1", "turn-1")
+	cache.grant(accessKindChat, "user-2", "chat-2")
+
+	cache.forget("user-1")
+	if cache.allowed(accessKindChat, "user-1", "chat-1") || cache.allowed(accessKindTurn, "user-1", "turn-1") {
+		t.Fatal("forgotten grant still allowed")
+	}
+	if !cache.allowed(accessKindChat, "user-2", "chat-2") {
+		t.Fatal("another user's grant was forgotten")
+	}
+
+	// nil cache is a no-op
+	var disabled *accessCache
+	disabled.forget("user-1")
+}
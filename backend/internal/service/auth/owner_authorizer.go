@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"meridian/internal/domain"
 	docsystemRepo "meridian/internal/domain/repositories/docsystem"
@@ -13,6 +14,12 @@ import (
 // OwnerBasedAuthorizer implements ResourceAuthorizer using ownership checks.
 // A user can access a resource if they own the project that contains it.
 //
+// Turns and documents carry their owner's user_id (denormalized), so they are
+// checked with a single query; folders and chats still resolve their project.
+// Successful checks are cached per user for a short TTL (see accessCache), so
+// repeated checks (e.g. SSE reconnects) skip the database entirely. Deletes that
+// revoke access call InvalidateUser so cached grants don't outlive them.
+//
 // This is the simplest authorization model. For future extensibility:
 // - RoleBasedAuthorizer: Check user's role on the project
 // - PermissionBasedAuthorizer: Check specific permissions
@@ -23,15 +30,18 @@ type OwnerBasedAuthorizer struct {
 	docRepo     docsystemRepo.DocumentRepository
 	chatRepo    llmRepo.ChatRepository
 	turnRepo    llmRepo.TurnReader
+	cache       *accessCache // nil when caching is disabled
 }
 
 // NewOwnerBasedAuthorizer creates a new ownership-based authorizer
+// cacheTTL is how long a successful check is remembered; 0 disables caching
 func NewOwnerBasedAuthorizer(
 	projectRepo docsystemRepo.ProjectRepository,
 	folderRepo docsystemRepo.FolderRepository,
 	docRepo docsystemRepo.DocumentRepository,
 	chatRepo llmRepo.ChatRepository,
 	turnRepo llmRepo.TurnReader,
+	cacheTTL time.Duration,
 ) *OwnerBasedAuthorizer {
 	return &OwnerBasedAuthorizer{
 		projectRepo: projectRepo,
@@ -39,11 +49,16 @@ func NewOwnerBasedAuthorizer(
 		docRepo:     docRepo,
 		chatRepo:    chatRepo,
 		turnRepo:    turnRepo,
+		cache:       newAccessCache(cacheTTL),
 	}
 }
 
 // CanAccessProject checks if user owns the project
 func (a *OwnerBasedAuthorizer) CanAccessProject(ctx context.Context, userID, projectID string) error {
+	if a.cache.allowed(accessKindProject, userID, projectID) {
+		return nil
+	}
+
 	// ProjectRepository.GetByID already filters by userID (ownership check)
 	// If it returns not found, user doesn't own the project
 	_, err := a.projectRepo.GetByID(ctx, projectID, userID)
@@ -53,11 +68,17 @@ func (a *OwnerBasedAuthorizer) CanAccessProject(ctx context.Context, userID, pro
 		}
 		return fmt.Errorf("check project access: %w", err)
 	}
+
+	a.cache.grant(accessKindProject, userID, projectID)
 	return nil
 }
 
 // CanAccessFolder checks if user can access a folder (via its project)
 func (a *OwnerBasedAuthorizer) CanAccessFolder(ctx context.Context, userID, folderID string) error {
+	if a.cache.allowed(accessKindFolder, userID, folderID) {
+		return nil
+	}
+
 	// Get folder by UUID only (no project scoping)
 	folder, err := a.folderRepo.GetByIDOnly(ctx, folderID)
 	if err != nil {
@@ -65,23 +86,39 @@ func (a *OwnerBasedAuthorizer) CanAccessFolder(ctx context.Context, userID, fold
 	}
 
 	// Check user owns the folder's project
-	return a.CanAccessProject(ctx, userID, folder.ProjectID)
+	if err := a.CanAccessProject(ctx, userID, folder.ProjectID); err != nil {
+		return err
+	}
+
+	a.cache.grant(accessKindFolder, userID, folderID)
+	return nil
 }
 
-// CanAccessDocument checks if user can access a document (via its project)
+// CanAccessDocument checks if user can access a document (owns its project)
 func (a *OwnerBasedAuthorizer) CanAccessDocument(ctx context.Context, userID, documentID string) error {
-	// Get document by UUID only (no project scoping)
-	doc, err := a.docRepo.GetByIDOnly(ctx, documentID)
+	if a.cache.allowed(accessKindDocument, userID, documentID) {
+		return nil
+	}
+
+	// Owner is denormalized onto the document row: one query instead of document → project
+	ownerID, err := a.docRepo.GetOwnerID(ctx, documentID)
 	if err != nil {
 		return fmt.Errorf("get document for auth: %w", err)
 	}
+	if ownerID != userID {
+		return fmt.Errorf("access denied to document %s: %w", documentID, domain.ErrForbidden)
+	}
 
-	// Check user owns the document's project
-	return a.CanAccessProject(ctx, userID, doc.ProjectID)
+	a.cache.grant(accessKindDocument, userID, documentID)
+	return nil
 }
 
 // CanAccessChat checks if user can access a chat (via its project)
 func (a *OwnerBasedAuthorizer) CanAccessChat(ctx context.Context, userID, chatID string) error {
+	if a.cache.allowed(accessKindChat, userID, chatID) {
+		return nil
+	}
+
 	// Get chat by UUID only (no user scoping)
 	chat, err := a.chatRepo.GetChatByIDOnly(ctx, chatID)
 	if err != nil {
@@ -89,17 +126,56 @@ func (a *OwnerBasedAuthorizer) CanAccessChat(ctx context.Context, userID, chatID
 	}
 
 	// Check user owns the chat's project
-	return a.CanAccessProject(ctx, userID, chat.ProjectID)
+	if err := a.CanAccessProject(ctx, userID, chat.ProjectID); err != nil {
+		return err
+	}
+
+	a.cache.grant(accessKindChat, userID, chatID)
+	return nil
 }
 
-// CanAccessTurn checks if user can access a turn (via its chat's project)
+// CanAccessTurn checks if user can access a turn (owns its chat's project)
 func (a *OwnerBasedAuthorizer) CanAccessTurn(ctx context.Context, userID, turnID string) error {
-	// Get turn by UUID only
-	turn, err := a.turnRepo.GetTurn(ctx, turnID)
+	return a.CanAccessTurns(ctx, userID, []string{turnID})
+}
+
+// CanAccessTurns checks if user can access every turn in turnIDs
+// All uncached turns are checked in one query, which reads each turn's user_id and joins its
+// chat and project to exclude soft-deleted ones
+func (a *OwnerBasedAuthorizer) CanAccessTurns(ctx context.Context, userID string, turnIDs []string) error {
+	var uncached []string
+	for _, turnID := range turnIDs {
+		if !a.cache.allowed(accessKindTurn, userID, turnID) {
+			uncached = append(uncached, turnID)
+		}
+	}
+	if len(uncached) == 0 {
+		return nil
+	}
+
+	ownerIDs, err := a.turnRepo.GetTurnOwnerIDs(ctx, uncached)
 	if err != nil {
-		return fmt.Errorf("get turn for auth: %w", err)
+		return fmt.Errorf("get turns for auth: %w", err)
 	}
 
-	// Check user can access the turn's chat
-	return a.CanAccessChat(ctx, userID, turn.ChatID)
+	for _, turnID := range uncached {
+		ownerID, ok := ownerIDs[turnID]
+		if !ok {
+			return fmt.Errorf("get turn for auth: turn %s: %w", turnID, domain.ErrNotFound)
+		}
+		if ownerID != userID {
+			return fmt.Errorf("access denied to turn %s: %w", turnID, domain.ErrForbidden)
+		}
+	}
+
+	for _, turnID := range uncached {
+		a.cache.grant(accessKindTurn, userID, turnID)
+	}
+	return nil
+}
+
+// InvalidateUser forgets the user's cached grants, so a deleted chat or project (and
+// everything under it) is rechecked against the database on the next access
+func (a *OwnerBasedAuthorizer) InvalidateUser(userID string) {
+	a.cache.forget(userID)
 }
@@ -0,0 +1,257 @@
+package docsystem
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+
+	"meridian/internal/config"
+	models "meridian/internal/domain/models/docsystem"
+	llmModels "meridian/internal/domain/models/llm"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/utils"
+)
+
+// archiveChatsFolder holds the chats of archives that include them. Import skips the
+// .json files, so an archive can be imported back as-is.
+const archiveChatsFolder = ".chats"
+
+// archiveService implements the ArchiveService interface
+type archiveService struct {
+	projectRepo docsysRepo.ProjectRepository
+	folderRepo  docsysRepo.FolderRepository
+	docRepo     docsysRepo.DocumentRepository
+	chatRepo    llmRepo.ChatRepository
+	turnReader  llmRepo.TurnReader
+	authorizer  services.ResourceAuthorizer
+	logger      *slog.Logger
+}
+
+// NewArchiveService creates a new archive service
+func NewArchiveService(
+	projectRepo docsysRepo.ProjectRepository,
+	folderRepo docsysRepo.FolderRepository,
+	docRepo docsysRepo.DocumentRepository,
+	chatRepo llmRepo.ChatRepository,
+	turnReader llmRepo.TurnReader,
+	authorizer services.ResourceAuthorizer,
+	logger *slog.Logger,
+) docsysSvc.ArchiveService {
+	return &archiveService{
+		projectRepo: projectRepo,
+		folderRepo:  folderRepo,
+		docRepo:     docRepo,
+		chatRepo:    chatRepo,
+		turnReader:  turnReader,
+		authorizer:  authorizer,
+		logger:      logger,
+	}
+}
+
+// archivedChat is the JSON file of one chat: the chat and every turn of its tree
+type archivedChat struct {
+	Chat  llmModels.Chat   `json:"chat"`
+	Turns []llmModels.Turn `json:"turns"`
+}
+
+// archiveEntry is one file or folder of an archive, in write order
+type archiveEntry struct {
+	name     string // Folders end in "/"
+	document *models.Document
+	chat     *llmModels.Chat
+}
+
+// OpenArchive checks access and plans the archive: folders first (so empty folders are
+// kept), then documents and chats, each sorted by path
+func (s *archiveService) OpenArchive(ctx context.Context, userID, projectID string, opts docsysSvc.ArchiveOptions) (*docsysSvc.ProjectArchive, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+	project, err := s.projectRepo.GetByID(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	folders, err := s.folderRepo.GetAllByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := s.docRepo.GetAllMetadataByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	var chats []llmModels.Chat
+	if opts.IncludeChats {
+		chats, err = s.chatRepo.ListChatsByProject(ctx, projectID, userID, &llmModels.ChatListOptions{})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entries := archiveEntries(folders, documents, chats)
+
+	baseName := utils.Slugify(project.Name, config.MaxSlugLength)
+	if baseName == "" {
+		baseName = "project"
+	}
+
+	return &docsysSvc.ProjectArchive{
+		FileName: baseName + ".zip",
+		Write: func(ctx context.Context, w io.Writer) error {
+			return s.write(ctx, w, projectID, userID, entries)
+		},
+	}, nil
+}
+
+// archiveEntries lays out an archive: folder paths as directories, documents as
+// {folder path}/{name}.md and chats under archiveChatsFolder
+func archiveEntries(folders []models.Folder, documents []models.Document, chats []llmModels.Chat) []archiveEntry {
+	folderPaths := buildFolderPaths(folders)
+
+	var folderEntries, documentEntries []archiveEntry
+	for _, folder := range folders {
+		folderEntries = append(folderEntries, archiveEntry{name: folderPaths[folder.ID] + "/"})
+	}
+	for i := range documents {
+		doc := &documents[i]
+		name := doc.Name + ".md"
+		if doc.FolderID != nil {
+			name = folderPaths[*doc.FolderID] + "/" + name
+		}
+		documentEntries = append(documentEntries, archiveEntry{name: name, document: doc})
+	}
+	sort.Slice(folderEntries, func(i, j int) bool { return folderEntries[i].name < folderEntries[j].name })
+	sort.Slice(documentEntries, func(i, j int) bool { return documentEntries[i].name < documentEntries[j].name })
+
+	entries := append(folderEntries, documentEntries...)
+	for i := range chats {
+		chat := &chats[i]
+		slug := utils.Slugify(chat.Title, config.MaxSlugLength)
+		if slug == "" {
+			slug = "chat"
+		}
+		entries = append(entries, archiveEntry{name: archiveChatsFolder + "/" + slug + "-" + chat.ID + ".json", chat: chat})
+	}
+	return entries
+}
+
+// buildFolderPaths maps folder IDs to their paths ("Book/Part 1")
+func buildFolderPaths(folders []models.Folder) map[string]string {
+	byID := make(map[string]models.Folder, len(folders))
+	for _, folder := range folders {
+		byID[folder.ID] = folder
+	}
+
+	paths := make(map[string]string, len(folders))
+	var pathOf func(id string, depth int) string
+	pathOf = func(id string, depth int) string {
+		if path, ok := paths[id]; ok {
+			return path
+		}
+		folder := byID[id]
+		path := folder.Name
+		// The depth bound guards against a corrupt parent cycle
+		if folder.ParentID != nil && depth < len(folders) {
+			if _, ok := byID[*folder.ParentID]; ok {
+				path = pathOf(*folder.ParentID, depth+1) + "/" + folder.Name
+			}
+		}
+		paths[id] = path
+		return path
+	}
+	for _, folder := range folders {
+		pathOf(folder.ID, 0)
+	}
+	return paths
+}
+
+// write streams the planned entries as a zip, one document or chat in memory at a time
+func (s *archiveService) write(ctx context.Context, w io.Writer, projectID, userID string, entries []archiveEntry) error {
+	archive := zip.NewWriter(w)
+	documents, chats := 0, 0
+
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate}
+		var data []byte
+		switch {
+		case entry.document != nil:
+			doc, err := s.docRepo.GetByID(ctx, entry.document.ID, projectID)
+			if err != nil {
+				return fmt.Errorf("load document %s: %w", entry.document.ID, err)
+			}
+			header.Modified = doc.UpdatedAt
+			data = []byte(doc.Content)
+			documents++
+		case entry.chat != nil:
+			chat, err := s.loadChat(ctx, entry.chat, userID)
+			if err != nil {
+				return err
+			}
+			header.Modified = entry.chat.UpdatedAt
+			if data, err = json.MarshalIndent(chat, "", "  "); err != nil {
+				return fmt.Errorf("encode chat %s: %w", entry.chat.ID, err)
+			}
+			chats++
+		default:
+			header.Method = zip.Store // Folder
+		}
+
+		writer, err := archive.CreateHeader(header)
+		if err != nil {
+			return fmt.Errorf("add %s to archive: %w", entry.name, err)
+		}
+		if _, err := writer.Write(data); err != nil {
+			return fmt.Errorf("write %s to archive: %w", entry.name, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("finish archive: %w", err)
+	}
+
+	s.logger.Info("project archive exported",
+		"project_id", projectID,
+		"documents", documents,
+		"chats", chats,
+	)
+	return nil
+}
+
+// loadChat loads every turn of a chat's tree with its blocks
+func (s *archiveService) loadChat(ctx context.Context, chat *llmModels.Chat, userID string) (*archivedChat, error) {
+	tree, err := s.chatRepo.GetChatTree(ctx, chat.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("load chat %s: %w", chat.ID, err)
+	}
+
+	turnIDs := make([]string, 0, len(tree.Turns))
+	for _, node := range tree.Turns {
+		turnIDs = append(turnIDs, node.ID)
+	}
+	blocks, err := s.turnReader.GetTurnBlocksForTurns(ctx, turnIDs)
+	if err != nil {
+		return nil, fmt.Errorf("load blocks of chat %s: %w", chat.ID, err)
+	}
+
+	archived := &archivedChat{Chat: *chat, Turns: make([]llmModels.Turn, 0, len(turnIDs))}
+	for _, turnID := range turnIDs {
+		turn, err := s.turnReader.GetTurn(ctx, turnID)
+		if err != nil {
+			return nil, fmt.Errorf("load turn %s: %w", turnID, err)
+		}
+		turn.Blocks = blocks[turnID]
+		archived.Turns = append(archived.Turns, *turn)
+	}
+	return archived, nil
+}
@@ -0,0 +1,165 @@
+package docsystem
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	llmModels "meridian/internal/domain/models/llm"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/testmocks"
+)
+
+// A small project: Book/Part 1/Chapter 1, an empty folder, a root document and one chat.
+// Unused interface methods panic.
+type (
+	fakeArchiveProjectRepo struct{ docsysRepo.ProjectRepository }
+	fakeArchiveFolderRepo  struct{ docsysRepo.FolderRepository }
+	fakeArchiveDocRepo     struct{ docsysRepo.DocumentRepository }
+	fakeArchiveChatRepo    struct{ llmRepo.ChatRepository }
+	fakeArchiveTurnReader  struct{ llmRepo.TurnReader }
+)
+
+func (fakeArchiveProjectRepo) GetByID(ctx context.Context, id, userID string) (*models.Project, error) {
+	return &models.Project{ID: id, Name: "My Novel"}, nil
+}
+
+func (fakeArchiveFolderRepo) GetAllByProject(ctx context.Context, projectID string) ([]models.Folder, error) {
+	book := "book"
+	return []models.Folder{
+		{ID: "part-1", ParentID: &book, Name: "Part 1"},
+		{ID: "book", Name: "Book"},
+		{ID: "empty", Name: "Empty"},
+	}, nil
+}
+
+func (fakeArchiveDocRepo) GetAllMetadataByProject(ctx context.Context, projectID string) ([]models.Document, error) {
+	part := "part-1"
+	return []models.Document{
+		{ID: "notes", Name: "notes"},
+		{ID: "ch1", FolderID: &part, Name: "Chapter 1"},
+	}, nil
+}
+
+func (fakeArchiveDocRepo) GetByID(ctx context.Context, id, projectID string) (*models.Document, error) {
+	content := map[string]string{"notes": "loose notes", "ch1": "# One"}[id]
+	return &models.Document{ID: id, Content: content}, nil
+}
+
+func (fakeArchiveChatRepo) ListChatsByProject(ctx context.Context, projectID, userID string, opts *llmModels.ChatListOptions) ([]llmModels.Chat, error) {
+	return []llmModels.Chat{{ID: "chat-1", Title: "Plot ideas"}}, nil
+}
+
+func (fakeArchiveChatRepo) GetChatTree(ctx context.Context, chatID, userID string) (*llmModels.ChatTree, error) {
+	first := "t1"
+	return &llmModels.ChatTree{Turns: []llmModels.TurnTreeNode{{ID: "t1"}, {ID: "t2", PrevTurnID: &first}}}, nil
+}
+
+func (fakeArchiveTurnReader) GetTurn(ctx context.Context, turnID string) (*llmModels.Turn, error) {
+	return &llmModels.Turn{ID: turnID, ChatID: "chat-1"}, nil
+}
+
+func (fakeArchiveTurnReader) GetTurnBlocksForTurns(ctx context.Context, turnIDs []string) (map[string][]llmModels.TurnBlock, error) {
+	text := "An idea"
+	return map[string][]llmModels.TurnBlock{"t2": {{TurnID: "t2", BlockType: "text", TextContent: &text}}}, nil
+}
+
+func newTestArchiveService(authorizer *testmocks.Authorizer) docsysSvc.ArchiveService {
+	return NewArchiveService(
+		fakeArchiveProjectRepo{}, fakeArchiveFolderRepo{}, fakeArchiveDocRepo{},
+		fakeArchiveChatRepo{}, fakeArchiveTurnReader{},
+		authorizer, slog.New(slog.DiscardHandler),
+	)
+}
+
+// readArchive writes the archive and returns its entry names in order and their contents
+func readArchive(t *testing.T, archive *docsysSvc.ProjectArchive) ([]string, map[string]string) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := archive.Write(context.Background(), &buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("open zip: %v", err)
+	}
+
+	var names []string
+	contents := make(map[string]string)
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", file.Name, err)
+		}
+		names = append(names, file.Name)
+		contents[file.Name] = string(data)
+	}
+	return names, contents
+}
+
+func TestArchiveService_ExportsTree(t *testing.T) {
+	svc := newTestArchiveService(&testmocks.Authorizer{})
+
+	archive, err := svc.OpenArchive(context.Background(), "user-1", "project-1", docsysSvc.ArchiveOptions{})
+	if err != nil {
+		t.Fatalf("OpenArchive: %v", err)
+	}
+	if archive.FileName != "my-novel.zip" {
+		t.Errorf("file name = %q, want my-novel.zip", archive.FileName)
+	}
+
+	names, contents := readArchive(t, archive)
+	want := []string{"Book/", "Book/Part 1/", "Empty/", "Book/Part 1/Chapter 1.md", "notes.md"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("entries = %v, want %v", names, want)
+	}
+	if contents["Book/Part 1/Chapter 1.md"] != "# One" || contents["notes.md"] != "loose notes" {
+		t.Errorf("contents = %v", contents)
+	}
+}
+
+func TestArchiveService_IncludesChats(t *testing.T) {
+	svc := newTestArchiveService(&testmocks.Authorizer{})
+
+	archive, err := svc.OpenArchive(context.Background(), "user-1", "project-1", docsysSvc.ArchiveOptions{IncludeChats: true})
+	if err != nil {
+		t.Fatalf("OpenArchive: %v", err)
+	}
+
+	_, contents := readArchive(t, archive)
+	data, ok := contents[".chats/plot-ideas-chat-1.json"]
+	if !ok {
+		t.Fatalf("archive has no chat file: %v", contents)
+	}
+	var chat archivedChat
+	if err := json.Unmarshal([]byte(data), &chat); err != nil {
+		t.Fatalf("decode chat: %v", err)
+	}
+	if chat.Chat.Title != "Plot ideas" || len(chat.Turns) != 2 || len(chat.Turns[1].Blocks) != 1 {
+		t.Errorf("chat = %+v", chat)
+	}
+}
+
+func TestArchiveService_ChecksAccess(t *testing.T) {
+	svc := newTestArchiveService(&testmocks.Authorizer{Err: domain.ErrForbidden})
+
+	_, err := svc.OpenArchive(context.Background(), "user-1", "project-1", docsysSvc.ArchiveOptions{})
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("err = %v, want ErrForbidden", err)
+	}
+}
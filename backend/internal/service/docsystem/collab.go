@@ -0,0 +1,496 @@
+package docsystem
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	"meridian/internal/domain/repositories"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+const (
+	// defaultCollabSaveInterval applies when the configured interval isn't positive
+	defaultCollabSaveInterval = 10 * time.Second
+	// collabSendBuffer is how many messages may queue for a client; a client falling
+	// further behind is disconnected and catches up when it reconnects
+	collabSendBuffer = 256
+	// collabSaveTimeout bounds saves that outlive their connection or the service
+	collabSaveTimeout = 10 * time.Second
+)
+
+var errCollabStopped = errors.New("collaboration channel is shutting down")
+
+// documentCollabService implements the DocumentCollabService interface. Rooms live in
+// memory, so clients of one document must reach the same server instance to see each
+// other's edits (the update log is shared, so they converge when they reconnect).
+type documentCollabService struct {
+	collabRepo   docsysRepo.DocumentCollabRepository
+	docRepo      docsysRepo.DocumentRepository
+	documents    docsysSvc.DocumentService
+	txManager    repositories.TransactionManager
+	authorizer   services.ResourceAuthorizer
+	saveInterval time.Duration
+	logger       *slog.Logger
+
+	mu      sync.Mutex // Guards rooms, their refs, and stopped
+	rooms   map[string]*collabRoom
+	stopped bool
+}
+
+// collabRoom is the live state of one document's channel
+type collabRoom struct {
+	documentID string
+	refs       int // Serve calls using the room (service mu)
+
+	mu        sync.Mutex
+	clients   map[*collabClient]struct{}
+	seeder    *collabClient // Client sent the document's content to fill the empty log
+	lastSeq   int64
+	pending   *collabContent // Content reported since the last save
+	savedHash string         // Hash of the document's content as last loaded or saved
+
+	saveMu sync.Mutex // Serializes saves
+}
+
+// collabContent is Markdown a client reported
+type collabContent struct {
+	text   string
+	userID string
+	seq    int64 // Last update logged when it was reported
+}
+
+// collabClient is one connection of a room
+type collabClient struct {
+	userID    string
+	conn      docsysSvc.CollabConn
+	send      chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// Set on join and read by the client's own receive loop
+	historySeq    int64 // Last update of the history it was sent
+	awaitingState bool  // Its answer to the server's step 1 is still due
+
+	awareness map[uint64]uint64 // Awareness client IDs it announced → last clock (room mu)
+}
+
+// NewDocumentCollabService creates a collaboration service that saves reported content
+// every saveInterval
+func NewDocumentCollabService(
+	collabRepo docsysRepo.DocumentCollabRepository,
+	docRepo docsysRepo.DocumentRepository,
+	documents docsysSvc.DocumentService,
+	txManager repositories.TransactionManager,
+	authorizer services.ResourceAuthorizer,
+	saveInterval time.Duration,
+	logger *slog.Logger,
+) docsysSvc.DocumentCollabService {
+	if saveInterval <= 0 {
+		saveInterval = defaultCollabSaveInterval
+	}
+	return &documentCollabService{
+		collabRepo:   collabRepo,
+		docRepo:      docRepo,
+		documents:    documents,
+		txManager:    txManager,
+		authorizer:   authorizer,
+		saveInterval: saveInterval,
+		logger:       logger,
+		rooms:        make(map[string]*collabRoom),
+	}
+}
+
+// Serve sends the client the document's update log, then relays its messages until the
+// connection closes. The connection is closed when Serve returns.
+func (s *documentCollabService) Serve(ctx context.Context, userID, documentID string, conn docsysSvc.CollabConn) error {
+	client := &collabClient{
+		userID:    userID,
+		conn:      conn,
+		send:      make(chan []byte, collabSendBuffer),
+		done:      make(chan struct{}),
+		awareness: make(map[uint64]uint64),
+	}
+	defer client.close()
+
+	if err := s.authorizer.CanAccessDocument(ctx, userID, documentID); err != nil {
+		return err
+	}
+
+	room, history, err := s.join(ctx, documentID, client)
+	if room != nil {
+		defer s.leave(room, client)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The history goes out before anything broadcast since the join
+	for _, msg := range history {
+		if err := conn.Send(msg); err != nil {
+			return nil
+		}
+	}
+	go client.writeLoop()
+
+	for {
+		data, err := conn.Receive()
+		if err != nil {
+			return nil // Client gone, or disconnected by the service
+		}
+		if err := s.handle(ctx, room, client, data); err != nil {
+			return err
+		}
+	}
+}
+
+// join adds the client to the document's room and returns the messages that bring it
+// up to date: the logged updates, the end of the sync, the document's content when the
+// log is empty, and the server's step 1 asking for the client's state. The room is
+// returned even on error so the caller can leave it.
+func (s *documentCollabService) join(ctx context.Context, documentID string, client *collabClient) (*collabRoom, [][]byte, error) {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return nil, nil, errCollabStopped
+	}
+	room := s.rooms[documentID]
+	if room == nil {
+		room = &collabRoom{documentID: documentID, clients: make(map[*collabClient]struct{})}
+		s.rooms[documentID] = room
+	}
+	room.refs++
+	s.mu.Unlock()
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+	first := len(room.clients) == 0
+	room.clients[client] = struct{}{}
+
+	updates, err := s.collabRepo.ListUpdates(ctx, documentID)
+	if err != nil {
+		return room, nil, fmt.Errorf("failed to load collaboration updates: %w", err)
+	}
+
+	history := make([][]byte, 0, len(updates)+3)
+	for _, update := range updates {
+		history = append(history, encodeSyncMessage(collabSyncUpdate, update.Data))
+		client.historySeq = update.Seq
+	}
+	history = append(history, encodeSyncMessage(collabSyncStep2, collabEmptyUpdate))
+	room.lastSeq = max(room.lastSeq, client.historySeq)
+
+	if first || (len(updates) == 0 && room.seeder == nil) {
+		doc, err := s.docRepo.GetByIDOnly(ctx, documentID)
+		if err != nil {
+			return room, nil, err
+		}
+		if first {
+			room.savedHash = models.ContentHash(doc.Content)
+		}
+		if len(updates) == 0 && room.seeder == nil && doc.Content != "" {
+			history = append(history, encodeBytesMessage(collabMessageSeed, []byte(doc.Content)))
+			room.seeder = client
+		}
+	}
+
+	history = append(history, encodeSyncMessage(collabSyncStep1, collabEmptyStateVector))
+	client.awaitingState = true
+
+	s.logger.Debug("collaboration client joined",
+		"document_id", documentID,
+		"updates", len(updates),
+		"clients", len(room.clients),
+	)
+	return room, history, nil
+}
+
+// leave removes the client from its room, announces its awareness states as gone, and
+// saves the room's content when it was the last client
+func (s *documentCollabService) leave(room *collabRoom, client *collabClient) {
+	client.close()
+
+	room.mu.Lock()
+	delete(room.clients, client)
+	if room.seeder == client {
+		room.seeder = nil // The next client seeds the log if this one didn't
+	}
+	if len(client.awareness) > 0 {
+		room.broadcast(nil, encodeAwarenessRemoval(client.awareness))
+	}
+	room.mu.Unlock()
+
+	s.mu.Lock()
+	room.refs--
+	empty := room.refs == 0
+	if empty {
+		delete(s.rooms, room.documentID)
+	}
+	s.mu.Unlock()
+
+	if empty {
+		ctx, cancel := context.WithTimeout(context.Background(), collabSaveTimeout)
+		defer cancel()
+		s.save(ctx, room)
+	}
+}
+
+// handle processes one client message
+func (s *documentCollabService) handle(ctx context.Context, room *collabRoom, client *collabClient, data []byte) error {
+	msg, err := decodeCollabMessage(data)
+	if err != nil {
+		return fmt.Errorf("%w: malformed collaboration message: %v", domain.ErrValidation, err)
+	}
+
+	switch msg.kind {
+	case collabMessageSync:
+		switch msg.syncType {
+		case collabSyncStep1:
+			// The log was sent on join, so the client lacks nothing
+			client.enqueue(encodeSyncMessage(collabSyncStep2, collabEmptyUpdate))
+		case collabSyncStep2:
+			state := client.awaitingState
+			client.awaitingState = false
+			return s.applyUpdate(ctx, room, client, msg.payload, state)
+		case collabSyncUpdate:
+			return s.applyUpdate(ctx, room, client, msg.payload, false)
+		}
+	case collabMessageAwareness:
+		room.mu.Lock()
+		defer room.mu.Unlock()
+		if clocks, err := decodeAwarenessUpdate(msg.payload); err == nil {
+			for _, entry := range clocks {
+				if entry.removed {
+					delete(client.awareness, entry.clientID)
+				} else {
+					client.awareness[entry.clientID] = entry.clock
+				}
+			}
+		}
+		// Echoed to the sender too, as y-websocket servers do: clients treat a silent
+		// connection as dead
+		room.broadcast(nil, data)
+	case collabMessageQueryAwareness:
+		room.mu.Lock()
+		defer room.mu.Unlock()
+		room.broadcast(client, data)
+	case collabMessageContent:
+		room.mu.Lock()
+		defer room.mu.Unlock()
+		room.pending = &collabContent{text: string(msg.payload), userID: client.userID, seq: room.lastSeq}
+	}
+	// Auth and unknown messages are ignored
+	return nil
+}
+
+// applyUpdate logs a client's update and relays it to the room. state is the client's
+// whole document, answering the server's step 1: it includes every update the client
+// was sent, so those are compacted into it.
+func (s *documentCollabService) applyUpdate(ctx context.Context, room *collabRoom, client *collabClient, update []byte, state bool) error {
+	if bytes.Equal(update, collabEmptyUpdate) {
+		return nil
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	var seq int64
+	var err error
+	if state && client.historySeq > 0 {
+		err = s.txManager.ExecTx(ctx, func(txCtx context.Context) error {
+			if err := s.collabRepo.DeleteUpdatesThrough(txCtx, room.documentID, client.historySeq); err != nil {
+				return err
+			}
+			seq, err = s.collabRepo.AppendUpdate(txCtx, room.documentID, update)
+			return err
+		})
+	} else {
+		seq, err = s.collabRepo.AppendUpdate(ctx, room.documentID, update)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to log collaboration update: %w", err)
+	}
+	room.lastSeq = seq
+
+	room.broadcast(client, encodeSyncMessage(collabSyncUpdate, update))
+	return nil
+}
+
+// save writes the room's reported content to the document and records a revision. A
+// document that was deleted or lost to the users disconnects the room.
+func (s *documentCollabService) save(ctx context.Context, room *collabRoom) {
+	room.saveMu.Lock()
+	defer room.saveMu.Unlock()
+
+	room.mu.Lock()
+	pending := room.pending
+	room.pending = nil
+	savedHash := room.savedHash
+	room.mu.Unlock()
+
+	if pending == nil {
+		return
+	}
+	hash := models.ContentHash(pending.text)
+	if hash == savedHash {
+		return
+	}
+
+	logger := s.logger.With("document_id", room.documentID)
+	doc, err := s.documents.UpdateDocument(ctx, pending.userID, room.documentID, &docsysSvc.UpdateDocumentRequest{
+		Content: domain.Some(pending.text),
+	})
+	switch {
+	case errors.Is(err, domain.ErrNotFound), errors.Is(err, domain.ErrForbidden):
+		logger.Info("collaborative document no longer available, disconnecting clients", "error", err)
+		room.closeAll()
+		return
+	case errors.Is(err, domain.ErrValidation):
+		// Wait for the clients' next content
+		logger.Warn("collaborative content rejected", "error", err)
+		return
+	case err != nil:
+		logger.Warn("failed to save collaborative content", "error", err)
+		room.mu.Lock()
+		if room.pending == nil {
+			room.pending = pending // Retry on the next save
+		}
+		room.mu.Unlock()
+		return
+	}
+
+	room.mu.Lock()
+	room.savedHash = hash
+	room.mu.Unlock()
+
+	revision := &models.DocumentRevision{
+		DocumentID:  room.documentID,
+		Content:     pending.text,
+		ContentHash: hash,
+		WordCount:   doc.WordCount,
+		Source:      models.RevisionSourceCollab,
+	}
+	if pending.seq > 0 {
+		revision.UpdateSeq = &pending.seq
+	}
+	if err := s.collabRepo.CreateRevision(ctx, revision, models.MaxRevisionsLimit); err != nil {
+		logger.Warn("failed to record collaborative revision", "error", err)
+		return
+	}
+	logger.Debug("collaborative content saved", "revision_id", revision.ID, "update_seq", pending.seq)
+}
+
+// Run saves the rooms every save interval. On shutdown it saves them once more and
+// closes their connections: hijacked connections outlive the HTTP server's shutdown.
+func (s *documentCollabService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.saveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			for _, room := range s.liveRooms(false) {
+				s.save(ctx, room)
+			}
+		case <-ctx.Done():
+			saveCtx, cancel := context.WithTimeout(context.Background(), collabSaveTimeout)
+			defer cancel()
+			for _, room := range s.liveRooms(true) {
+				s.save(saveCtx, room)
+				room.closeAll()
+			}
+			return
+		}
+	}
+}
+
+// liveRooms returns the current rooms; stop refuses new connections from then on
+func (s *documentCollabService) liveRooms(stop bool) []*collabRoom {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stop {
+		s.stopped = true
+	}
+	rooms := make([]*collabRoom, 0, len(s.rooms))
+	for _, room := range s.rooms {
+		rooms = append(rooms, room)
+	}
+	return rooms
+}
+
+// ListRevisions returns the document's newest revisions first
+func (s *documentCollabService) ListRevisions(ctx context.Context, userID, documentID string, limit int) ([]models.DocumentRevision, error) {
+	if err := s.authorizer.CanAccessDocument(ctx, userID, documentID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = models.DefaultRevisionsLimit
+	}
+	return s.collabRepo.ListRevisions(ctx, documentID, min(limit, models.MaxRevisionsLimit))
+}
+
+// GetRevision returns a revision with its content
+func (s *documentCollabService) GetRevision(ctx context.Context, userID, documentID, revisionID string) (*models.DocumentRevision, error) {
+	if err := s.authorizer.CanAccessDocument(ctx, userID, documentID); err != nil {
+		return nil, err
+	}
+	return s.collabRepo.GetRevision(ctx, documentID, revisionID)
+}
+
+// broadcast queues a message for the room's clients except skip (caller holds mu)
+func (r *collabRoom) broadcast(skip *collabClient, msg []byte) {
+	for client := range r.clients {
+		if client != skip {
+			client.enqueue(msg)
+		}
+	}
+}
+
+// closeAll disconnects the room's clients; their Serve calls then leave the room
+func (r *collabRoom) closeAll() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for client := range r.clients {
+		client.close()
+	}
+}
+
+// enqueue queues a message without blocking, disconnecting a client that fell behind
+func (c *collabClient) enqueue(msg []byte) {
+	select {
+	case c.send <- msg:
+	default:
+		c.close()
+	}
+}
+
+// writeLoop sends queued messages until the client is closed
+func (c *collabClient) writeLoop() {
+	for {
+		select {
+		case msg := <-c.send:
+			if err := c.conn.Send(msg); err != nil {
+				c.close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// close closes the connection, which ends the client's receive loop
+func (c *collabClient) close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		_ = c.conn.Close()
+	})
+}
@@ -0,0 +1,177 @@
+package docsystem
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Collaboration channel messages: the y-websocket protocol (y-protocols sync and
+// awareness, lib0 encoding) plus two Meridian message types. Yjs updates and state
+// vectors are opaque to the server; only awareness updates are decoded, to announce the
+// clients of a closed connection as gone.
+const (
+	collabMessageSync           = 0
+	collabMessageAwareness      = 1
+	collabMessageAuth           = 2
+	collabMessageQueryAwareness = 3
+	collabMessageContent        = 100 // Client → server: the document as Markdown, saved periodically
+	collabMessageSeed           = 101 // Server → client: content to load into an empty Y.Doc
+)
+
+// Sync message types
+const (
+	collabSyncStep1  = 0 // State vector; answered with the updates the sender lacks
+	collabSyncStep2  = 1 // Update answering a step 1
+	collabSyncUpdate = 2 // Incremental update
+)
+
+var (
+	// collabEmptyUpdate is a Yjs update without structs or deletions
+	collabEmptyUpdate = []byte{0, 0}
+	// collabEmptyStateVector asks for the whole document
+	collabEmptyStateVector = []byte{0}
+)
+
+var errCollabTruncated = errors.New("message is truncated")
+
+// collabMessage is a decoded client message
+type collabMessage struct {
+	kind     uint64
+	syncType uint64 // Sync messages
+	payload  []byte // Update or state vector (sync), awareness update, or content
+}
+
+// decodeCollabMessage parses a client message. Unknown message types are returned with
+// an empty payload so they can be ignored.
+func decodeCollabMessage(data []byte) (collabMessage, error) {
+	r := &lib0Reader{data: data}
+	var msg collabMessage
+	var err error
+	if msg.kind, err = r.varUint(); err != nil {
+		return msg, err
+	}
+
+	switch msg.kind {
+	case collabMessageSync:
+		if msg.syncType, err = r.varUint(); err != nil {
+			return msg, err
+		}
+		if msg.syncType > collabSyncUpdate {
+			return msg, fmt.Errorf("unknown sync message type %d", msg.syncType)
+		}
+		msg.payload, err = r.varBytes()
+	case collabMessageAwareness, collabMessageContent:
+		msg.payload, err = r.varBytes()
+	}
+	return msg, err
+}
+
+// encodeSyncMessage builds a sync message
+func encodeSyncMessage(syncType uint64, payload []byte) []byte {
+	buf := appendVarUint(nil, collabMessageSync)
+	buf = appendVarUint(buf, syncType)
+	return appendVarBytes(buf, payload)
+}
+
+// encodeBytesMessage builds a message of kind carrying one byte array (awareness,
+// content and seed messages)
+func encodeBytesMessage(kind uint64, payload []byte) []byte {
+	return appendVarBytes(appendVarUint(nil, kind), payload)
+}
+
+// awarenessClock is the clock of one client in an awareness update
+type awarenessClock struct {
+	clientID uint64
+	clock    uint64
+	removed  bool // The state is null: the client is gone
+}
+
+// decodeAwarenessUpdate returns the client clocks of an awareness update
+func decodeAwarenessUpdate(update []byte) ([]awarenessClock, error) {
+	r := &lib0Reader{data: update}
+	count, err := r.varUint()
+	if err != nil {
+		return nil, err
+	}
+	var clocks []awarenessClock
+	for i := uint64(0); i < count; i++ {
+		var entry awarenessClock
+		if entry.clientID, err = r.varUint(); err != nil {
+			return nil, err
+		}
+		if entry.clock, err = r.varUint(); err != nil {
+			return nil, err
+		}
+		state, err := r.varBytes() // JSON state
+		if err != nil {
+			return nil, err
+		}
+		entry.removed = string(state) == "null"
+		clocks = append(clocks, entry)
+	}
+	return clocks, nil
+}
+
+// encodeAwarenessRemoval builds an awareness message announcing clients as gone (a
+// null state with the next clock), as y-websocket servers do when a connection closes
+func encodeAwarenessRemoval(clocks map[uint64]uint64) []byte {
+	update := appendVarUint(nil, uint64(len(clocks)))
+	for clientID, clock := range clocks {
+		update = appendVarUint(update, clientID)
+		update = appendVarUint(update, clock+1)
+		update = appendVarBytes(update, []byte("null"))
+	}
+	return encodeBytesMessage(collabMessageAwareness, update)
+}
+
+// lib0Reader reads lib0-encoded values
+type lib0Reader struct {
+	data []byte
+	pos  int
+}
+
+// varUint reads an unsigned integer of 7-bit groups, least significant first
+func (r *lib0Reader) varUint() (uint64, error) {
+	var value uint64
+	for shift := uint(0); ; shift += 7 {
+		if r.pos >= len(r.data) {
+			return 0, errCollabTruncated
+		}
+		if shift > 63 {
+			return 0, errors.New("integer overflows 64 bits")
+		}
+		b := r.data[r.pos]
+		r.pos++
+		value |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			return value, nil
+		}
+	}
+}
+
+// varBytes reads a length-prefixed byte array
+func (r *lib0Reader) varBytes() ([]byte, error) {
+	n, err := r.varUint()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(r.data)-r.pos) {
+		return nil, errCollabTruncated
+	}
+	value := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return value, nil
+}
+
+func appendVarUint(buf []byte, value uint64) []byte {
+	for value >= 0x80 {
+		buf = append(buf, byte(value)|0x80)
+		value >>= 7
+	}
+	return append(buf, byte(value))
+}
+
+func appendVarBytes(buf, value []byte) []byte {
+	buf = appendVarUint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
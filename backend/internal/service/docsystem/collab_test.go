@@ -0,0 +1,399 @@
+package docsystem
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/testmocks"
+)
+
+// Fakes for the collaboration service; unused interface methods panic.
+type (
+	fakeCollabRepo struct {
+		docsysRepo.DocumentCollabRepository
+		mu        sync.Mutex
+		updates   []models.CollabUpdate
+		seq       int64
+		revisions []models.DocumentRevision
+		compacted bool // DeleteUpdatesThrough ran in a transaction
+	}
+	fakeCollabDocRepo struct {
+		docsysRepo.DocumentRepository
+		content string
+	}
+	fakeCollabDocuments struct {
+		docsysSvc.DocumentService
+		mu    sync.Mutex
+		saved []string
+	}
+)
+
+func (r *fakeCollabRepo) AppendUpdate(ctx context.Context, documentID string, data []byte) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	r.updates = append(r.updates, models.CollabUpdate{Seq: r.seq, DocumentID: documentID, Data: data})
+	return r.seq, nil
+}
+
+func (r *fakeCollabRepo) ListUpdates(ctx context.Context, documentID string) ([]models.CollabUpdate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.updates), nil
+}
+
+func (r *fakeCollabRepo) DeleteUpdatesThrough(ctx context.Context, documentID string, throughSeq int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.updates = slices.DeleteFunc(r.updates, func(u models.CollabUpdate) bool { return u.Seq <= throughSeq })
+	r.compacted = testmocks.TxDepth(ctx) > 0
+	return nil
+}
+
+func (r *fakeCollabRepo) CreateRevision(ctx context.Context, revision *models.DocumentRevision, keep int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	revision.ID = "rev-1"
+	r.revisions = append(r.revisions, *revision)
+	return nil
+}
+
+func (r *fakeCollabRepo) updateData() [][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var data [][]byte
+	for _, u := range r.updates {
+		data = append(data, u.Data)
+	}
+	return data
+}
+
+func (r fakeCollabDocRepo) GetByIDOnly(ctx context.Context, id string) (*models.Document, error) {
+	return &models.Document{ID: id, Content: r.content}, nil
+}
+
+func (d *fakeCollabDocuments) UpdateDocument(ctx context.Context, userID, documentID string, req *docsysSvc.UpdateDocumentRequest) (*models.Document, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	content, _ := req.Content.Value()
+	d.saved = append(d.saved, content)
+	return &models.Document{ID: documentID, Content: content, WordCount: 3}, nil
+}
+
+// fakeCollabConn is a connection driven by the test
+type fakeCollabConn struct {
+	in        chan []byte
+	out       chan []byte
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newFakeCollabConn() *fakeCollabConn {
+	return &fakeCollabConn{in: make(chan []byte), out: make(chan []byte, 100), closed: make(chan struct{})}
+}
+
+func (c *fakeCollabConn) Receive() ([]byte, error) {
+	select {
+	case msg := <-c.in:
+		return msg, nil
+	case <-c.closed:
+		return nil, io.EOF
+	}
+}
+
+func (c *fakeCollabConn) Send(message []byte) error {
+	select {
+	case <-c.closed:
+		return io.EOF
+	default:
+	}
+	c.out <- message
+	return nil
+}
+
+func (c *fakeCollabConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
+
+// send delivers a client message
+func (c *fakeCollabConn) send(t *testing.T, msg []byte) {
+	t.Helper()
+	select {
+	case c.in <- msg:
+	case <-c.closed:
+		t.Fatal("connection closed")
+	case <-time.After(time.Second):
+		t.Fatal("server isn't reading")
+	}
+}
+
+func (c *fakeCollabConn) next(t *testing.T) []byte {
+	t.Helper()
+	select {
+	case msg := <-c.out:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("no message from server")
+		return nil
+	}
+}
+
+// expect reads the next messages and compares them with want
+func (c *fakeCollabConn) expect(t *testing.T, want ...[]byte) {
+	t.Helper()
+	for i, w := range want {
+		if got := c.next(t); !bytes.Equal(got, w) {
+			t.Fatalf("message %d = %v, want %v", i, got, w)
+		}
+	}
+}
+
+// barrier returns once the server processed the messages sent so far: awareness
+// updates are echoed to their sender
+func (c *fakeCollabConn) barrier(t *testing.T, clientID uint64) {
+	t.Helper()
+	msg := encodeBytesMessage(collabMessageAwareness, awarenessUpdate(clientID, 1, "{}"))
+	c.send(t, msg)
+	c.expect(t, msg)
+}
+
+func awarenessUpdate(clientID, clock uint64, state string) []byte {
+	update := appendVarUint(nil, 1)
+	update = appendVarUint(update, clientID)
+	update = appendVarUint(update, clock)
+	return appendVarBytes(update, []byte(state))
+}
+
+type collabFixture struct {
+	service   *documentCollabService
+	repo      *fakeCollabRepo
+	documents *fakeCollabDocuments
+}
+
+func newCollabFixture(content string) *collabFixture {
+	repo := &fakeCollabRepo{}
+	documents := &fakeCollabDocuments{}
+	service := NewDocumentCollabService(
+		repo,
+		fakeCollabDocRepo{content: content},
+		documents,
+		&testmocks.TxManager{},
+		&testmocks.Authorizer{Deny: map[string]error{"doc-x": domain.ErrForbidden}},
+		time.Hour,
+		slog.New(slog.DiscardHandler),
+	).(*documentCollabService)
+	return &collabFixture{service: service, repo: repo, documents: documents}
+}
+
+// connect serves conn in the background; the returned channel yields Serve's result
+func (f *collabFixture) connect(userID, documentID string, conn *fakeCollabConn) <-chan error {
+	done := make(chan error, 1)
+	go func() { done <- f.service.Serve(context.Background(), userID, documentID, conn) }()
+	return done
+}
+
+func waitServe(t *testing.T, done <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(time.Second):
+		t.Fatal("Serve didn't return")
+		return nil
+	}
+}
+
+func TestCollabProtocol(t *testing.T) {
+	// Multi-byte lengths: 300 = 0xac 0x02
+	payload := bytes.Repeat([]byte{7}, 300)
+	msg := encodeSyncMessage(collabSyncUpdate, payload)
+	if !bytes.Equal(msg[:4], []byte{0, 2, 0xac, 0x02}) {
+		t.Errorf("header = %v", msg[:4])
+	}
+	decoded, err := decodeCollabMessage(msg)
+	if err != nil || decoded.kind != collabMessageSync || decoded.syncType != collabSyncUpdate || !bytes.Equal(decoded.payload, payload) {
+		t.Fatalf("decoded %+v, %v", decoded, err)
+	}
+
+	content, err := decodeCollabMessage(encodeBytesMessage(collabMessageContent, []byte("# Title")))
+	if err != nil || string(content.payload) != "# Title" {
+		t.Errorf("content = %+v, %v", content, err)
+	}
+
+	for name, data := range map[string][]byte{
+		"empty":          {},
+		"truncated":      msg[:100],
+		"unterminated":   {0, 0x80},
+		"bad sync type":  {0, 9, 0},
+		"overlong value": bytes.Repeat([]byte{0xff}, 11),
+	} {
+		if _, err := decodeCollabMessage(data); err == nil {
+			t.Errorf("%s: decoded", name)
+		}
+	}
+
+	clocks, err := decodeAwarenessUpdate(awarenessUpdate(42, 3, "null"))
+	if err != nil || len(clocks) != 1 || clocks[0] != (awarenessClock{clientID: 42, clock: 3, removed: true}) {
+		t.Errorf("awareness = %+v, %v", clocks, err)
+	}
+	removal, _ := decodeCollabMessage(encodeAwarenessRemoval(map[uint64]uint64{42: 3}))
+	clocks, _ = decodeAwarenessUpdate(removal.payload)
+	if len(clocks) != 1 || clocks[0] != (awarenessClock{clientID: 42, clock: 4, removed: true}) {
+		t.Errorf("removal = %+v", clocks)
+	}
+}
+
+func TestDocumentCollab_SyncRelayAndSave(t *testing.T) {
+	f := newCollabFixture("# Draft")
+
+	// The first client gets the empty log and the content to seed it with
+	a := newFakeCollabConn()
+	doneA := f.connect("user-1", "doc-1", a)
+	a.expect(t,
+		encodeSyncMessage(collabSyncStep2, collabEmptyUpdate),
+		encodeBytesMessage(collabMessageSeed, []byte("# Draft")),
+		encodeSyncMessage(collabSyncStep1, collabEmptyStateVector),
+	)
+	a.send(t, encodeSyncMessage(collabSyncStep2, []byte{1, 1, 1}))
+	a.send(t, encodeBytesMessage(collabMessageContent, []byte("# Draft\n\nTogether")))
+	a.barrier(t, 7)
+
+	// The second client catches up from the log, unseeded
+	b := newFakeCollabConn()
+	doneB := f.connect("user-2", "doc-1", b)
+	b.expect(t,
+		encodeSyncMessage(collabSyncUpdate, []byte{1, 1, 1}),
+		encodeSyncMessage(collabSyncStep2, collabEmptyUpdate),
+		encodeSyncMessage(collabSyncStep1, collabEmptyStateVector),
+	)
+
+	// Live updates are relayed and logged; step 1 is answered with nothing to add
+	a.send(t, encodeSyncMessage(collabSyncUpdate, []byte{2, 2}))
+	b.expect(t, encodeSyncMessage(collabSyncUpdate, []byte{2, 2}))
+	b.send(t, encodeSyncMessage(collabSyncStep1, []byte{0}))
+	b.expect(t, encodeSyncMessage(collabSyncStep2, collabEmptyUpdate))
+
+	// b's state replaces the history it was sent (but not the later update)
+	b.send(t, encodeSyncMessage(collabSyncStep2, []byte{3, 3}))
+	a.expect(t, encodeSyncMessage(collabSyncUpdate, []byte{3, 3}))
+	if got := f.repo.updateData(); len(got) != 2 || !bytes.Equal(got[0], []byte{2, 2}) || !bytes.Equal(got[1], []byte{3, 3}) {
+		t.Errorf("log after compaction = %v", got)
+	}
+	if !f.repo.compacted {
+		t.Error("compaction ran outside a transaction")
+	}
+
+	// a leaving announces its awareness client as gone
+	a.Close()
+	if err := waitServe(t, doneA); err != nil {
+		t.Fatal(err)
+	}
+	b.expect(t, encodeAwarenessRemoval(map[uint64]uint64{7: 1}))
+	if len(f.documents.saved) != 0 {
+		t.Errorf("saved before the last client left: %v", f.documents.saved)
+	}
+
+	// The last client leaving saves the reported content with a revision
+	b.Close()
+	if err := waitServe(t, doneB); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(f.documents.saved, []string{"# Draft\n\nTogether"}) {
+		t.Errorf("saved = %q", f.documents.saved)
+	}
+	if len(f.repo.revisions) != 1 {
+		t.Fatalf("revisions = %+v", f.repo.revisions)
+	}
+	revision := f.repo.revisions[0]
+	if revision.ContentHash != models.ContentHash("# Draft\n\nTogether") || revision.WordCount != 3 ||
+		revision.Source != models.RevisionSourceCollab || revision.UpdateSeq == nil || *revision.UpdateSeq != 1 {
+		t.Errorf("revision = %+v", revision)
+	}
+	if len(f.service.rooms) != 0 {
+		t.Errorf("rooms left: %d", len(f.service.rooms))
+	}
+}
+
+func TestDocumentCollab_SaveSkipsUnchangedContent(t *testing.T) {
+	f := newCollabFixture("# Draft")
+
+	c := newFakeCollabConn()
+	done := f.connect("user-1", "doc-1", c)
+	c.expect(t,
+		encodeSyncMessage(collabSyncStep2, collabEmptyUpdate),
+		encodeBytesMessage(collabMessageSeed, []byte("# Draft")),
+		encodeSyncMessage(collabSyncStep1, collabEmptyStateVector),
+	)
+	c.send(t, encodeBytesMessage(collabMessageContent, []byte("# Draft")))
+	c.Close()
+	waitServe(t, done)
+
+	if len(f.documents.saved) != 0 || len(f.repo.revisions) != 0 {
+		t.Errorf("unchanged content saved: %v, %+v", f.documents.saved, f.repo.revisions)
+	}
+}
+
+func TestDocumentCollab_Shutdown(t *testing.T) {
+	f := newCollabFixture("")
+
+	c := newFakeCollabConn()
+	done := f.connect("user-1", "doc-1", c)
+	c.expect(t,
+		encodeSyncMessage(collabSyncStep2, collabEmptyUpdate),
+		encodeSyncMessage(collabSyncStep1, collabEmptyStateVector), // No seed for empty content
+	)
+	c.send(t, encodeBytesMessage(collabMessageContent, []byte("Written live")))
+	c.barrier(t, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		f.service.Run(ctx)
+		close(stopped)
+	}()
+	cancel()
+	<-stopped
+
+	// Saved and disconnected; new connections are refused
+	if err := waitServe(t, done); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(f.documents.saved, []string{"Written live"}) {
+		t.Errorf("saved = %q", f.documents.saved)
+	}
+	if err := f.service.Serve(context.Background(), "user-1", "doc-1", newFakeCollabConn()); !errors.Is(err, errCollabStopped) {
+		t.Errorf("Serve after shutdown = %v", err)
+	}
+}
+
+func TestDocumentCollab_Errors(t *testing.T) {
+	f := newCollabFixture("")
+
+	if err := f.service.Serve(context.Background(), "user-1", "doc-x", newFakeCollabConn()); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("forbidden document: %v", err)
+	}
+
+	c := newFakeCollabConn()
+	done := f.connect("user-1", "doc-1", c)
+	c.next(t)
+	c.next(t)
+	c.send(t, []byte{0, 1, 9}) // Update longer than the message
+	if err := waitServe(t, done); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("malformed message: %v", err)
+	}
+	if len(f.service.rooms) != 0 {
+		t.Errorf("rooms left: %d", len(f.service.rooms))
+	}
+}
@@ -0,0 +1,365 @@
+package converter
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+// maxDOCXPartBytes caps how much of one XML part is read (guards against zip bombs)
+const maxDOCXPartBytes = 64 << 20
+
+// headingStylePattern matches heading style names ("heading 1") and the IDs Word
+// uses for them when styles.xml is missing ("Heading1")
+var headingStylePattern = regexp.MustCompile(`^heading ?([1-6])$`)
+
+// docxConverter converts Word documents (.docx) to markdown.
+// Extracts the body text of word/document.xml:
+//   - Heading and Title paragraph styles become markdown headings
+//   - Numbered and bulleted paragraphs become list items
+//   - Bold and italic runs keep their emphasis
+//
+// Images, tables' layout, comments, footnotes and deleted tracked changes are dropped.
+type docxConverter struct{}
+
+// NewDOCXConverter creates a new Word document to markdown converter.
+func NewDOCXConverter() docsysSvc.ContentConverter {
+	return &docxConverter{}
+}
+
+// Convert extracts the document's paragraphs as markdown.
+// Returns an error if the file isn't a Word document.
+func (c *docxConverter) Convert(ctx context.Context, input []byte) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(input), int64(len(input)))
+	if err != nil {
+		return "", errors.New("not a Word document (.docx files are zip archives)")
+	}
+
+	document, err := readDOCXPart(archive, "word/document.xml")
+	if err != nil {
+		return "", err
+	}
+	if document == nil {
+		return "", errors.New("not a Word document: word/document.xml is missing")
+	}
+
+	styles, err := readDOCXPart(archive, "word/styles.xml")
+	if err != nil {
+		return "", err
+	}
+	styleNames, err := parseDOCXStyleNames(styles)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse styles: %w", err)
+	}
+
+	paragraphs, err := parseDOCXBody(document, styleNames)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse document: %w", err)
+	}
+	if len(paragraphs) == 0 {
+		return "", errors.New("document has no text")
+	}
+
+	return strings.Join(paragraphs, "\n\n") + "\n", nil
+}
+
+// SupportedExtensions returns Word document extensions.
+func (c *docxConverter) SupportedExtensions() []string {
+	return []string{".docx"}
+}
+
+// Name returns the converter name for logging.
+func (c *docxConverter) Name() string {
+	return "docx"
+}
+
+// readDOCXPart reads one part of the archive (nil if it doesn't exist)
+func readDOCXPart(archive *zip.Reader, name string) ([]byte, error) {
+	for _, file := range archive.File {
+		if file.Name != name {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", name, err)
+		}
+		defer reader.Close()
+
+		data, err := io.ReadAll(io.LimitReader(reader, maxDOCXPartBytes+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if len(data) > maxDOCXPartBytes {
+			return nil, fmt.Errorf("%s is larger than %d MB", name, maxDOCXPartBytes>>20)
+		}
+		return data, nil
+	}
+	return nil, nil
+}
+
+// parseDOCXStyleNames maps style IDs to their lowercase names ("Heading1" → "heading 1").
+// Localized documents use IDs like "berschrift1", so headings are matched by name.
+func parseDOCXStyleNames(styles []byte) (map[string]string, error) {
+	names := map[string]string{}
+	if styles == nil {
+		return names, nil
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(styles))
+	styleID := ""
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return names, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "style":
+			styleID = docxAttr(start, "styleId")
+		case "name":
+			if styleID != "" {
+				names[styleID] = strings.ToLower(docxAttr(start, "val"))
+			}
+		}
+	}
+}
+
+// docxRun is a span of text with one formatting
+type docxRun struct {
+	text         string
+	bold, italic bool
+}
+
+// docxParagraph collects a paragraph's properties and runs while it is parsed
+type docxParagraph struct {
+	styleID   string
+	listLevel int // -1 = not a list item
+	runs      []docxRun
+}
+
+// parseDOCXBody returns the markdown of each non-empty body paragraph
+func parseDOCXBody(document []byte, styleNames map[string]string) ([]string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(document))
+
+	type frame struct {
+		para *docxParagraph
+		run  *docxRun
+	}
+	var (
+		paragraphs []string
+		para       *docxParagraph
+		run        *docxRun
+		outer      []frame // Paragraphs containing text boxes, whose paragraphs nest
+		inParaProp bool    // inside <w:pPr> (its <w:rPr> formats the paragraph mark, not text)
+		inRunProp  bool
+		inText     bool
+	)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return paragraphs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "Fallback":
+				// Markup-compatibility fallbacks repeat the content of text boxes
+				if err := decoder.Skip(); err != nil {
+					return nil, err
+				}
+			case "p":
+				outer = append(outer, frame{para: para, run: run})
+				para, run = &docxParagraph{listLevel: -1}, nil
+			case "pPr":
+				inParaProp = true
+			case "pStyle":
+				if para != nil && inParaProp {
+					para.styleID = docxAttr(t, "val")
+				}
+			case "numPr":
+				if para != nil && inParaProp && para.listLevel < 0 {
+					para.listLevel = 0
+				}
+			case "ilvl":
+				if para != nil && inParaProp {
+					var level int
+					if _, err := fmt.Sscanf(docxAttr(t, "val"), "%d", &level); err == nil && level >= 0 && level < 9 {
+						para.listLevel = level
+					}
+				}
+			case "r":
+				if para != nil {
+					run = &docxRun{}
+				}
+			case "rPr":
+				inRunProp = run != nil && !inParaProp
+			case "b":
+				if inRunProp {
+					run.bold = docxToggleOn(t)
+				}
+			case "i":
+				if inRunProp {
+					run.italic = docxToggleOn(t)
+				}
+			case "t":
+				inText = run != nil
+			case "tab":
+				if run != nil && !inParaProp {
+					run.text += " "
+				}
+			case "br", "cr":
+				if run != nil && docxAttr(t, "type") != "page" {
+					run.text += "\n"
+				}
+			}
+		case xml.CharData:
+			if inText {
+				run.text += string(t)
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "pPr":
+				inParaProp = false
+			case "rPr":
+				inRunProp = false
+			case "t":
+				inText = false
+			case "r":
+				if para != nil && run != nil && run.text != "" {
+					para.runs = append(para.runs, *run)
+				}
+				run = nil
+			case "p":
+				if para != nil {
+					if text := para.markdown(styleNames); text != "" {
+						paragraphs = append(paragraphs, text)
+					}
+				}
+				para, run = nil, nil
+				if n := len(outer); n > 0 {
+					para, run = outer[n-1].para, outer[n-1].run
+					outer = outer[:n-1]
+				}
+			}
+		}
+	}
+}
+
+// markdown renders the paragraph, or "" if it has no text
+func (p *docxParagraph) markdown(styleNames map[string]string) string {
+	styleName := styleNames[p.styleID]
+	if styleName == "" {
+		styleName = strings.ToLower(p.styleID)
+	}
+
+	heading := 0
+	if styleName == "title" {
+		heading = 1
+	} else if m := headingStylePattern.FindStringSubmatch(styleName); m != nil {
+		heading = int(m[1][0] - '0')
+	}
+
+	if heading > 0 {
+		// Headings are already emphasized; keep their text only, on one line
+		var text strings.Builder
+		for _, run := range p.runs {
+			text.WriteString(run.text)
+		}
+		title := strings.Join(strings.Fields(text.String()), " ")
+		if title == "" {
+			return ""
+		}
+		return strings.Repeat("#", heading) + " " + escapeMarkdown(title)
+	}
+
+	body := renderDOCXRuns(p.runs)
+	if strings.TrimSpace(body) == "" {
+		return ""
+	}
+
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	lines[0] = escapeLineStart(lines[0])
+	text := strings.Join(lines, "  \n") // Line breaks inside the paragraph stay hard breaks
+
+	if p.listLevel >= 0 {
+		return strings.Repeat("  ", p.listLevel) + "- " + text
+	}
+	return text
+}
+
+// renderDOCXRuns joins the runs, merging neighbours with the same formatting so
+// emphasis markers aren't split ("**a****b**"), and moving surrounding spaces outside
+// the markers as markdown requires
+func renderDOCXRuns(runs []docxRun) string {
+	var merged []docxRun
+	for _, run := range runs {
+		if n := len(merged); n > 0 && merged[n-1].bold == run.bold && merged[n-1].italic == run.italic {
+			merged[n-1].text += run.text
+			continue
+		}
+		merged = append(merged, run)
+	}
+
+	var out strings.Builder
+	for _, run := range merged {
+		marker := ""
+		if run.bold {
+			marker += "**"
+		}
+		if run.italic {
+			marker += "*"
+		}
+
+		text := escapeMarkdown(run.text)
+		core := strings.TrimSpace(text)
+		if marker == "" || core == "" || strings.Contains(core, "\n") {
+			out.WriteString(text)
+			continue
+		}
+		start := strings.Index(text, core)
+		out.WriteString(text[:start])
+		out.WriteString(marker + core + marker)
+		out.WriteString(text[start+len(core):])
+	}
+	return out.String()
+}
+
+// docxAttr returns the value of the attribute with the given local name
+func docxAttr(start xml.StartElement, name string) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// docxToggleOn reports whether a toggle property (<w:b/>, <w:i w:val="0"/>) is on
+func docxToggleOn(start xml.StartElement) bool {
+	switch docxAttr(start, "val") {
+	case "0", "false", "off":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package converter
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+// buildDOCX zips the given parts into a .docx
+func buildDOCX(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range parts {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+		f.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+const docxNamespaces = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006"`
+
+func TestDOCXConverter_Convert(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document ` + docxNamespaces + `><w:body>
+<w:p><w:pPr><w:pStyle w:val="Titel"/></w:pPr><w:r><w:t>The Dragon's Hoard</w:t></w:r></w:p>
+<w:p><w:pPr><w:pStyle w:val="berschrift2"/><w:rPr><w:b/></w:rPr></w:pPr><w:r><w:t>Chapter</w:t></w:r><w:r><w:t xml:space="preserve"> One</w:t></w:r></w:p>
+<w:p><w:r><w:t xml:space="preserve">Aria </w:t></w:r><w:r><w:rPr><w:i/></w:rPr><w:t xml:space="preserve">never </w:t></w:r><w:r><w:rPr><w:i/></w:rPr><w:t>ran</w:t></w:r><w:r><w:t xml:space="preserve">, she </w:t></w:r><w:r><w:rPr><w:b/><w:b w:val="0"/></w:rPr><w:t>walked</w:t></w:r><w:r><w:t>.</w:t></w:r><w:del><w:r><w:delText>Deleted.</w:delText></w:r></w:del></w:p>
+<w:p></w:p>
+<w:p><w:r><w:t>Roses are red,</w:t><w:br/><w:t>the *hoard* is gold</w:t></w:r></w:p>
+<w:p><w:pPr><w:numPr><w:ilvl w:val="1"/><w:numId w:val="3"/></w:numPr></w:pPr><w:r><w:rPr><w:b/></w:rPr><w:t>Sword</w:t></w:r><w:r><w:tab/><w:t>sharp</w:t></w:r></w:p>
+<w:p><w:r><w:t># not a heading</w:t></w:r><w:r><mc:AlternateContent><mc:Choice><w:drawing><w:txbxContent><w:p><w:r><w:t>Margin note</w:t></w:r></w:p></w:txbxContent></w:drawing></mc:Choice><mc:Fallback><w:pict><w:p><w:r><w:t>Margin note</w:t></w:r></w:p></w:pict></mc:Fallback></mc:AlternateContent><w:t xml:space="preserve"> after the box</w:t></w:r></w:p>
+<w:sectPr/>
+</w:body></w:document>`
+
+	styles := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles ` + docxNamespaces + `>
+<w:style w:type="paragraph" w:styleId="Titel"><w:name w:val="Title"/></w:style>
+<w:style w:type="paragraph" w:styleId="berschrift2"><w:name w:val="heading 2"/></w:style>
+</w:styles>`
+
+	input := buildDOCX(t, map[string]string{
+		"[Content_Types].xml": `<Types/>`,
+		"word/document.xml":   document,
+		"word/styles.xml":     styles,
+	})
+
+	markdown, err := NewDOCXConverter().Convert(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"# The Dragon's Hoard",
+		"## Chapter One",
+		"Aria *never ran*, she walked.",
+		"Roses are red,  \nthe \\*hoard\\* is gold",
+		"  - **Sword** sharp",
+		"Margin note",
+		"\\# not a heading after the box",
+	}, "\n\n") + "\n"
+	if markdown != want {
+		t.Errorf("markdown =\n%q\nwant\n%q", markdown, want)
+	}
+}
+
+func TestDOCXConverter_HeadingStyleWithoutStyles(t *testing.T) {
+	input := buildDOCX(t, map[string]string{
+		"word/document.xml": `<w:document ` + docxNamespaces + `><w:body><w:p><w:pPr><w:pStyle w:val="Heading3"/></w:pPr><w:r><w:t>Part I</w:t></w:r></w:p></w:body></w:document>`,
+	})
+
+	markdown, err := NewDOCXConverter().Convert(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	if markdown != "### Part I\n" {
+		t.Errorf("markdown = %q, want a level 3 heading", markdown)
+	}
+}
+
+func TestDOCXConverter_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		wantErr string
+	}{
+		{"not a zip", []byte("plain text"), "not a Word document"},
+		{"no document part", buildDOCX(t, map[string]string{"content.xml": "<office/>"}), "word/document.xml is missing"},
+		{"malformed XML", buildDOCX(t, map[string]string{"word/document.xml": "<w:document><w:body>"}), "failed to parse document"},
+		{"empty", buildDOCX(t, map[string]string{"word/document.xml": `<w:document ` + docxNamespaces + `><w:body><w:p/></w:body></w:document>`}), "no text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDOCXConverter().Convert(context.Background(), tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConverterRegistry_ManuscriptFormats(t *testing.T) {
+	registry := NewConverterRegistry()
+	for _, ext := range []string{".pdf", ".PDF", ".docx"} {
+		if registry.GetConverter(ext) == nil {
+			t.Errorf("no converter for %s", ext)
+		}
+	}
+}
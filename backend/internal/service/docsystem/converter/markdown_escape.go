@@ -0,0 +1,43 @@
+package converter
+
+import (
+	"regexp"
+	"strings"
+)
+
+// markdownInlineEscaper escapes characters that would start inline markdown
+// (emphasis, code, links) in text extracted from binary formats.
+var markdownInlineEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+)
+
+// blockMarkerPattern matches line starts that markdown would read as a heading,
+// quote, list item or thematic break.
+var blockMarkerPattern = regexp.MustCompile(`^(#{1,6}|>|[-+]|\d{1,9}[.)])(\s|$)`)
+
+// escapeMarkdown escapes inline markdown syntax in plain text
+func escapeMarkdown(text string) string {
+	return markdownInlineEscaper.Replace(text)
+}
+
+// escapeLineStart escapes a block marker at the start of a line so extracted
+// text stays a plain paragraph. The line should already be inline-escaped.
+func escapeLineStart(line string) string {
+	trimmed := strings.TrimLeft(line, " \t")
+	loc := blockMarkerPattern.FindStringSubmatchIndex(trimmed)
+	if loc == nil {
+		return line
+	}
+	indent := line[:len(line)-len(trimmed)]
+	marker := trimmed[loc[2]:loc[3]]
+	if last := len(marker) - 1; marker[last] == '.' || marker[last] == ')' {
+		// "1." → "1\." keeps the number
+		return indent + marker[:last] + `\` + trimmed[last:]
+	}
+	return indent + `\` + trimmed
+}
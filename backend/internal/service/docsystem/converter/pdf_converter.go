@@ -0,0 +1,460 @@
+package converter
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+// pdfConverter converts PDF files to markdown by extracting their text.
+// Text is read from the page content streams in order and laid out by position:
+//   - Text on the same baseline forms a line; gaps wider than a space separate words
+//   - Larger vertical gaps, indented first lines and size changes start paragraphs
+//   - Short paragraphs set well above the body size become headings
+//
+// Scanned pages (images without a text layer) have no text to extract.
+type pdfConverter struct{}
+
+// NewPDFConverter creates a new PDF to markdown converter.
+func NewPDFConverter() docsysSvc.ContentConverter {
+	return &pdfConverter{}
+}
+
+// Convert extracts the text of every page as markdown paragraphs.
+// Returns an error if the file isn't a readable PDF or has no text.
+func (c *pdfConverter) Convert(ctx context.Context, input []byte) (string, error) {
+	doc, err := parsePDF(input)
+	if err != nil {
+		return "", err
+	}
+	pages := doc.pages()
+	if len(pages) == 0 {
+		return "", errors.New("PDF has no pages")
+	}
+
+	extractor := &pdfTextExtractor{doc: doc, fonts: map[int]*pdfFont{}}
+	for _, page := range pages {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		extractor.run(doc.contents(page.dict["Contents"]), page.resources, pdfIdentity, 0)
+		extractor.endPage()
+	}
+
+	markdown := extractor.markdown()
+	if markdown == "" {
+		return "", errors.New("PDF has no extractable text (scanned pages need OCR first)")
+	}
+	return markdown, nil
+}
+
+// SupportedExtensions returns PDF file extensions.
+func (c *pdfConverter) SupportedExtensions() []string {
+	return []string{".pdf"}
+}
+
+// Name returns the converter name for logging.
+func (c *pdfConverter) Name() string {
+	return "pdf"
+}
+
+// pdfMatrix is an affine transform [a b c d e f]
+type pdfMatrix [6]float64
+
+var pdfIdentity = pdfMatrix{1, 0, 0, 1, 0, 0}
+
+// mul returns m × n (m applied first)
+func (m pdfMatrix) mul(n pdfMatrix) pdfMatrix {
+	return pdfMatrix{
+		m[0]*n[0] + m[1]*n[2],
+		m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2],
+		m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4],
+		m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+func pdfTranslate(tx, ty float64) pdfMatrix {
+	return pdfMatrix{1, 0, 0, 1, tx, ty}
+}
+
+// pdfGraphicsState is the part of the graphics state text layout depends on
+type pdfGraphicsState struct {
+	ctm         pdfMatrix
+	font        *pdfFont
+	fontSize    float64
+	leading     float64
+	charSpacing float64
+	wordSpacing float64
+	hScale      float64
+}
+
+// pdfParagraph is a run of lines extracted from the text layer
+type pdfParagraph struct {
+	lines []string
+	size  float64 // Largest font size (device space)
+	chars int
+}
+
+// pdfTextExtractor interprets content streams and groups the shown text into lines
+// and paragraphs
+type pdfTextExtractor struct {
+	doc   *pdfDocument
+	fonts map[int]*pdfFont
+
+	paragraphs []pdfParagraph
+	current    pdfParagraph
+	line       strings.Builder
+
+	started    bool    // Text was shown on this page
+	lastY      float64 // Baseline of the current line
+	lastEndX   float64 // Where the last shown text ended
+	lastSize   float64
+	lineStartX float64 // Where the current line started
+	lineStep   float64 // Distance between the last two lines of a paragraph (0 = unknown)
+
+	sizeChars map[float64]int // Characters per (rounded) font size, for the body size
+}
+
+// run interprets a content stream with the given resources
+func (x *pdfTextExtractor) run(content []byte, resources pdfDict, ctm pdfMatrix, depth int) {
+	if depth > maxPDFDepth {
+		return
+	}
+	fontDict := x.doc.dict(resources["Font"])
+	xObjects := x.doc.dict(resources["XObject"])
+
+	state := pdfGraphicsState{ctm: ctm, font: defaultPDFFont, hScale: 1}
+	var saved []pdfGraphicsState
+	tm, tlm := pdfIdentity, pdfIdentity
+
+	nextLine := func() {
+		tlm = pdfTranslate(0, -state.leading).mul(tlm)
+		tm = tlm
+	}
+
+	lexer := &pdfLexer{data: content}
+	var operands []any
+	for {
+		token, err := lexer.next()
+		if err != nil {
+			return
+		}
+		op, ok := token.(pdfKeyword)
+		if !ok {
+			operands = append(operands, token)
+			continue
+		}
+
+		switch op {
+		case "q":
+			saved = append(saved, state)
+		case "Q":
+			if n := len(saved); n > 0 {
+				state, saved = saved[n-1], saved[:n-1]
+			}
+		case "cm":
+			if m, ok := pdfMatrixOperands(operands); ok {
+				state.ctm = m.mul(state.ctm)
+			}
+		case "BT":
+			tm, tlm = pdfIdentity, pdfIdentity
+		case "Tf":
+			if len(operands) == 2 {
+				name, _ := operands[0].(pdfName)
+				state.fontSize, _ = operands[1].(float64)
+				state.font = defaultPDFFont
+				if fontDict != nil && fontDict[name] != nil {
+					state.font = x.doc.font(fontDict[name], x.fonts)
+				}
+			}
+		case "TL":
+			state.leading = pdfNumber(operands, 0)
+		case "Tc":
+			state.charSpacing = pdfNumber(operands, 0)
+		case "Tw":
+			state.wordSpacing = pdfNumber(operands, 0)
+		case "Tz":
+			state.hScale = pdfNumber(operands, 0) / 100
+		case "Td", "TD":
+			tx, ty := pdfNumber(operands, 0), pdfNumber(operands, 1)
+			if op == "TD" {
+				state.leading = -ty
+			}
+			tlm = pdfTranslate(tx, ty).mul(tlm)
+			tm = tlm
+		case "Tm":
+			if m, ok := pdfMatrixOperands(operands); ok {
+				tm, tlm = m, m
+			}
+		case "T*":
+			nextLine()
+		case "Tj":
+			if len(operands) > 0 {
+				x.show(&state, &tm, operands[len(operands)-1])
+			}
+		case "'":
+			nextLine()
+			if len(operands) > 0 {
+				x.show(&state, &tm, operands[len(operands)-1])
+			}
+		case "\"":
+			if len(operands) == 3 {
+				state.wordSpacing, state.charSpacing = pdfNumber(operands, 0), pdfNumber(operands, 1)
+				nextLine()
+				x.show(&state, &tm, operands[2])
+			}
+		case "TJ":
+			if len(operands) > 0 {
+				items, _ := operands[len(operands)-1].([]any)
+				for _, item := range items {
+					if adjust, ok := item.(float64); ok {
+						// Positive adjustments move left (kerning), negative ones right
+						tm = pdfTranslate(-adjust/1000*state.fontSize*state.hScale, 0).mul(tm)
+						continue
+					}
+					x.show(&state, &tm, item)
+				}
+			}
+		case "BI":
+			skipPDFInlineImage(lexer)
+		case "Do":
+			if len(operands) > 0 && xObjects != nil {
+				name, _ := operands[0].(pdfName)
+				x.runForm(xObjects[name], resources, state.ctm, depth)
+			}
+		}
+		operands = operands[:0]
+	}
+}
+
+// runForm interprets a form XObject (other XObjects are images)
+func (x *pdfTextExtractor) runForm(value any, resources pdfDict, ctm pdfMatrix, depth int) {
+	stream, ok := x.doc.resolve(value).(*pdfStream)
+	if !ok || stream.dict["Subtype"] != pdfName("Form") {
+		return
+	}
+	data, err := x.doc.decodeStream(stream)
+	if err != nil {
+		return
+	}
+	if own := x.doc.dict(stream.dict["Resources"]); own != nil {
+		resources = own
+	}
+	if matrix, ok := x.doc.resolve(stream.dict["Matrix"]).([]any); ok {
+		if m, ok := pdfMatrixOperands(matrix); ok {
+			ctm = m.mul(ctm)
+		}
+	}
+	x.run(data, resources, ctm, depth+1)
+}
+
+// show lays out a shown string and advances the text matrix past it
+func (x *pdfTextExtractor) show(state *pdfGraphicsState, tm *pdfMatrix, value any) {
+	s, ok := value.(pdfString)
+	if !ok {
+		return
+	}
+	shown := state.font.decode(s)
+
+	trm := tm.mul(state.ctm)
+	size := math.Abs(state.fontSize) * math.Hypot(trm[2], trm[3])
+	advance := (shown.width*state.fontSize + float64(shown.codes)*state.charSpacing + float64(shown.spaces)*state.wordSpacing) * state.hScale
+	*tm = pdfTranslate(advance, 0).mul(*tm)
+
+	text := strings.Map(func(r rune) rune {
+		if r < ' ' || r == utf8.RuneError || unicode.Is(unicode.Cf, r) {
+			return ' '
+		}
+		return r
+	}, shown.text)
+	if strings.TrimSpace(text) == "" && !x.started {
+		return
+	}
+	if size <= 0 {
+		size = 1
+	}
+
+	x.place(text, trm[4], trm[5], size)
+	x.lastEndX = tm.mul(state.ctm)[4]
+}
+
+// place adds text at a device-space position to the current line, starting a new
+// line or paragraph when the position moved down (or back up the page)
+func (x *pdfTextExtractor) place(text string, posX, posY, size float64) {
+	switch {
+	case !x.started:
+		x.started = true
+		x.lineStartX = posX
+	case math.Abs(x.lastY-posY) <= 0.5*math.Max(size, x.lastSize):
+		// Same line: a gap wider than a thin space separates words
+		gap := posX - x.lastEndX
+		current := x.line.String()
+		if (gap > 0.15*size || gap < -size) && !strings.HasSuffix(current, " ") && !strings.HasPrefix(text, " ") && current != "" {
+			x.line.WriteByte(' ')
+		}
+	default:
+		x.newLine(x.lastY-posY, posX, size)
+	}
+
+	x.line.WriteString(text)
+	x.lastY = posY
+	x.lastSize = size
+	if size > x.current.size {
+		x.current.size = size
+	}
+	if x.sizeChars == nil {
+		x.sizeChars = map[float64]int{}
+	}
+	n := utf8.RuneCountInString(strings.TrimSpace(text))
+	x.sizeChars[math.Round(size*2)/2] += n
+	x.current.chars += n
+}
+
+// newLine ends the current line; dy is how far the text moved down
+func (x *pdfTextExtractor) newLine(dy, posX, size float64) {
+	x.endLine()
+
+	paragraph := false
+	switch {
+	case dy < 0:
+		paragraph = true // Moved up: next column, or text placed out of order
+	case x.lineStep > 0 && dy > 1.6*x.lineStep:
+		paragraph = true // Paragraph spacing
+	case x.lineStep == 0 && dy > 2*size:
+		paragraph = true
+	case math.Abs(size-x.lastSize) > 0.15*math.Max(size, x.lastSize):
+		paragraph = true // Heading or body after one
+	case posX > x.lineStartX+size:
+		paragraph = true // Indented first line
+	}
+
+	if paragraph {
+		x.endParagraph()
+		x.lineStep = 0
+	} else {
+		x.lineStep = dy
+	}
+	x.lineStartX = posX
+}
+
+func (x *pdfTextExtractor) endLine() {
+	if line := strings.Join(strings.Fields(x.line.String()), " "); line != "" {
+		x.current.lines = append(x.current.lines, line)
+	}
+	x.line.Reset()
+}
+
+func (x *pdfTextExtractor) endParagraph() {
+	x.endLine()
+	if len(x.current.lines) > 0 {
+		x.paragraphs = append(x.paragraphs, x.current)
+	}
+	x.current = pdfParagraph{}
+}
+
+// endPage ends the page's last paragraph; pages never share a line
+func (x *pdfTextExtractor) endPage() {
+	x.endParagraph()
+	x.started = false
+	x.lineStep = 0
+}
+
+// markdown renders the paragraphs. Paragraphs of at most two lines set at 1.3 times
+// the body size (the size most characters use) become headings.
+func (x *pdfTextExtractor) markdown() string {
+	bodySize := 0.0
+	sizes := make([]float64, 0, len(x.sizeChars))
+	for size := range x.sizeChars {
+		sizes = append(sizes, size)
+	}
+	sort.Float64s(sizes) // Deterministic tie-break: the smaller size
+	for _, size := range sizes {
+		if bodySize == 0 || x.sizeChars[size] > x.sizeChars[bodySize] {
+			bodySize = size
+		}
+	}
+
+	blocks := make([]string, 0, len(x.paragraphs))
+	for _, para := range x.paragraphs {
+		lines := make([]string, len(para.lines))
+		for i, line := range para.lines {
+			lines[i] = escapeMarkdown(line)
+		}
+
+		if bodySize > 0 && para.size >= 1.3*bodySize && len(lines) <= 2 && para.chars <= 120 {
+			level := "##"
+			if para.size >= 1.8*bodySize {
+				level = "#"
+			}
+			blocks = append(blocks, level+" "+strings.Join(lines, " "))
+			continue
+		}
+
+		for i, line := range lines {
+			lines[i] = escapeLineStart(line)
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+
+	if len(blocks) == 0 {
+		return ""
+	}
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+// pdfNumber returns operand i as a number (0 if it isn't one)
+func pdfNumber(operands []any, i int) float64 {
+	if i < len(operands) {
+		if n, ok := operands[i].(float64); ok {
+			return n
+		}
+	}
+	return 0
+}
+
+// pdfMatrixOperands reads six numbers as a matrix
+func pdfMatrixOperands(operands []any) (pdfMatrix, bool) {
+	if len(operands) < 6 {
+		return pdfMatrix{}, false
+	}
+	var m pdfMatrix
+	for i := range m {
+		n, ok := operands[len(operands)-6+i].(float64)
+		if !ok {
+			return pdfMatrix{}, false
+		}
+		m[i] = n
+	}
+	return m, true
+}
+
+// skipPDFInlineImage moves the lexer past an inline image (BI ... ID <data> EI),
+// whose binary data would otherwise be read as operators
+func skipPDFInlineImage(lexer *pdfLexer) {
+	for {
+		token, err := lexer.next()
+		if err != nil {
+			return
+		}
+		if token == pdfKeyword("ID") {
+			break
+		}
+	}
+	data := lexer.data
+	for i := lexer.pos + 1; i+1 < len(data); i++ {
+		if data[i] == 'E' && data[i+1] == 'I' && isPDFSpace(data[i-1]) &&
+			(i+2 == len(data) || isPDFSpace(data[i+2]) || isPDFDelimiter(data[i+2])) {
+			lexer.pos = i + 2
+			return
+		}
+	}
+	lexer.pos = len(data)
+}
@@ -0,0 +1,187 @@
+package converter
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// buildPDF writes a PDF whose objects are numbered from 1 in order, with object 1
+// as the catalog. Empty objects are left out (defined in an object stream).
+func buildPDF(objects []string, trailerExtra string) []byte {
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		if obj == "" {
+			continue
+		}
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		if offset == 0 {
+			fmt.Fprintf(&out, "0000000000 00000 f \n")
+			continue
+		}
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R %s>>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, trailerExtra, xref)
+	return out.Bytes()
+}
+
+// pdfStreamObject returns a Flate-compressed stream object
+func pdfStreamObject(dict, content string) string {
+	var compressed bytes.Buffer
+	w := zlib.NewWriter(&compressed)
+	w.Write([]byte(content))
+	w.Close()
+	return fmt.Sprintf("<< %s /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", dict, compressed.Len(), compressed.String())
+}
+
+// singlePagePDF builds a one-page PDF drawing content with font /F1
+func singlePagePDF(font, content string) []byte {
+	return buildPDF([]string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		font,
+		pdfStreamObject("", content),
+	}, "")
+}
+
+const helvetica = "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>"
+
+func TestPDFConverter_Layout(t *testing.T) {
+	content := `BT
+/F1 24 Tf 72 700 Td (The Dragon's Hoard) Tj
+/F1 12 Tf 0 -40 Td [(Aria )-20(climbed)] TJ ( the ridge, and) Tj
+0 -14 Td [(saw)-300(the)-300(W)80(yrm.)] TJ
+0 -28 Td (She did not run.) Tj
+0 -28 Td (1. Not a list) Tj
+ET`
+
+	markdown, err := NewPDFConverter().Convert(context.Background(), singlePagePDF(helvetica, content))
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+
+	want := "# The Dragon's Hoard\n\nAria climbed the ridge, and\nsaw the Wyrm.\n\nShe did not run.\n\n1\\. Not a list\n"
+	if markdown != want {
+		t.Errorf("markdown =\n%q\nwant\n%q", markdown, want)
+	}
+}
+
+func TestPDFConverter_IndentedParagraphsAndPages(t *testing.T) {
+	page := func(first, second string) string {
+		return pdfStreamObject("", fmt.Sprintf(`BT /F1 12 Tf 14.4 TL
+90 700 Td (%s) Tj
+-18 -14.4 Td (continues.) Tj
+18 -14.4 Td (%s) Tj
+ET`, first, second))
+	}
+	pdf := buildPDF([]string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /Resources << /Font << /F1 5 0 R >> >> >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 6 0 R >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 7 0 R >>",
+		helvetica,
+		page("One", "Two"),
+		page("Three", "Four"),
+	}, "")
+
+	markdown, err := NewPDFConverter().Convert(context.Background(), pdf)
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+
+	want := "One\ncontinues.\n\nTwo\n\nThree\ncontinues.\n\nFour\n"
+	if markdown != want {
+		t.Errorf("markdown =\n%q\nwant\n%q", markdown, want)
+	}
+}
+
+func TestPDFConverter_CompositeFontInObjectStream(t *testing.T) {
+	cmap := `/CIDInit /ProcSet findresource begin
+12 dict begin
+begincmap
+1 begincodespacerange <0000> <FFFF> endcodespacerange
+2 beginbfrange
+<0001> <0003> <0041>
+<0010> <0011> [<00E9> <2019>]
+endbfrange
+1 beginbfchar
+<0020> <0020>
+endbfchar
+endcmap
+CMapName currentdict /CMap defineresource pop
+end
+end`
+
+	// Objects 3 (page) and 4 (font) are packed in the object stream (object 8)
+	packed := "<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >> " +
+		"<< /Type /Font /Subtype /Type0 /BaseFont /Subset+Serif /Encoding /Identity-H /DescendantFonts [6 0 R] /ToUnicode 7 0 R >>"
+	objStm := fmt.Sprintf("3 0 4 %d ", strings.Index(packed, "<< /Type /Font"))
+
+	pdf := buildPDF([]string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"",
+		"",
+		pdfStreamObject("", "BT /F1 11 Tf 72 700 Td <000100020003002000100011> Tj ET"),
+		"<< /Type /Font /Subtype /CIDFontType2 /DW 500 /W [1 [600 600 600] 16 17 500] >>",
+		pdfStreamObject("", cmap),
+		pdfStreamObject(fmt.Sprintf("/Type /ObjStm /N 2 /First %d", len(objStm)), objStm+packed),
+	}, "")
+
+	markdown, err := NewPDFConverter().Convert(context.Background(), pdf)
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	if want := "ABC é’\n"; markdown != want {
+		t.Errorf("markdown = %q, want %q", markdown, want)
+	}
+}
+
+func TestPDFConverter_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []byte
+		wantErr string
+	}{
+		{"not a PDF", []byte("just some text"), "not a PDF file"},
+		{"encrypted", buildPDF([]string{"<< /Type /Catalog >>", "<< /Filter /Standard /V 2 >>"}, "/Encrypt 2 0 R "), "encrypted"},
+		{"no text layer", singlePagePDF(helvetica, "q 612 0 0 792 0 0 cm 0 0 1 rg 0 0 1 1 re f Q"), "no extractable text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPDFConverter().Convert(context.Background(), tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGlyphText(t *testing.T) {
+	tests := map[string]string{
+		"a":             "a",
+		"quoteright":    "’",
+		"eacute":        "é",
+		"Ccedilla":      "Ç",
+		"uni2014":       "—",
+		"f_f_i":         "ffi",
+		"one.oldstyle":  "1",
+		"notaglyphname": "",
+	}
+	for name, want := range tests {
+		if got, _ := glyphText(name); got != want {
+			t.Errorf("glyphText(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
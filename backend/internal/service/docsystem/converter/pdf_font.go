@@ -0,0 +1,376 @@
+package converter
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxCMapRange caps the codes one bfrange maps (guards against huge ranges)
+const maxCMapRange = 1 << 16
+
+// pdfFont decodes the character codes a font's strings are made of into text
+type pdfFont struct {
+	codeBytes    int                // 1 for simple fonts, 2 for composite (Type0) fonts
+	toUnicode    map[uint32]string  // From the font's ToUnicode CMap (nil = none)
+	encoding     *[256]string       // Simple fonts: text of each code without a ToUnicode entry
+	widths       map[uint32]float64 // Glyph widths in text space units (1/1000 of the glyph space)
+	defaultWidth float64            // Width of codes missing from widths
+}
+
+// pdfShown is the decoded text of a shown string and what moves the text position
+type pdfShown struct {
+	text   string
+	width  float64 // Sum of the glyph widths (unscaled by the font size)
+	codes  int
+	spaces int // Single-byte code 32, which word spacing applies to
+}
+
+// decode returns the text of a string shown in this font. Codes a composite font
+// can't map (no ToUnicode entry) are dropped.
+func (f *pdfFont) decode(s pdfString) pdfShown {
+	var shown pdfShown
+	var out strings.Builder
+	for i := 0; i+f.codeBytes <= len(s); i += f.codeBytes {
+		code := uint32(s[i])
+		if f.codeBytes == 2 {
+			code = code<<8 | uint32(s[i+1])
+		}
+
+		if text, ok := f.toUnicode[code]; ok {
+			out.WriteString(text)
+		} else if f.codeBytes == 1 {
+			out.WriteString(f.encoding[code])
+		}
+
+		width, ok := f.widths[code]
+		if !ok {
+			width = f.defaultWidth
+		}
+		shown.width += width
+		shown.codes++
+		if f.codeBytes == 1 && code == ' ' {
+			shown.spaces++
+		}
+	}
+	shown.text = out.String()
+	return shown
+}
+
+// defaultPDFFont decodes strings shown before any font is set (or in a font that
+// can't be read) as Windows-1252
+var defaultPDFFont = &pdfFont{codeBytes: 1, encoding: baseEncoding("WinAnsiEncoding"), defaultWidth: 0.5}
+
+// font loads the font of a page's resources
+func (d *pdfDocument) font(value any, cache map[int]*pdfFont) *pdfFont {
+	ref, isRef := value.(pdfRef)
+	if isRef {
+		if font, ok := cache[ref.num]; ok {
+			return font
+		}
+	}
+
+	dict := d.dict(value)
+	if dict == nil {
+		return defaultPDFFont
+	}
+	font := &pdfFont{codeBytes: 1}
+	if dict["Subtype"] == pdfName("Type0") {
+		font.codeBytes = 2
+		d.compositeWidths(font, dict)
+	} else {
+		d.simpleWidths(font, dict)
+	}
+	if stream, ok := d.resolve(dict["ToUnicode"]).(*pdfStream); ok {
+		if data, err := d.decodeStream(stream); err == nil {
+			var codeBytes int
+			font.toUnicode, codeBytes = parseToUnicodeCMap(data)
+			if codeBytes == 1 || codeBytes == 2 {
+				font.codeBytes = codeBytes
+			}
+		}
+	}
+	if font.codeBytes == 1 {
+		font.encoding = d.simpleEncoding(dict["Encoding"])
+	}
+
+	if isRef {
+		cache[ref.num] = font
+	}
+	return font
+}
+
+// simpleWidths reads a simple font's /Widths. The standard 14 fonts have none; their
+// glyphs are taken as half an em wide.
+func (d *pdfDocument) simpleWidths(font *pdfFont, dict pdfDict) {
+	first, _ := d.resolve(dict["FirstChar"]).(float64)
+	widths, _ := d.resolve(dict["Widths"]).([]any)
+	font.widths = make(map[uint32]float64, len(widths))
+	for i, w := range widths {
+		if width, ok := d.resolve(w).(float64); ok && int(first)+i >= 0 {
+			font.widths[uint32(int(first)+i)] = width / 1000
+		}
+	}
+
+	font.defaultWidth = 0.5
+	if len(widths) > 0 {
+		missing, _ := d.resolve(d.dict(dict["FontDescriptor"])["MissingWidth"]).(float64)
+		font.defaultWidth = missing / 1000
+	}
+}
+
+// compositeWidths reads the /W and /DW of a composite font's descendant font
+func (d *pdfDocument) compositeWidths(font *pdfFont, dict pdfDict) {
+	font.widths = map[uint32]float64{}
+	font.defaultWidth = 1
+
+	descendants, _ := d.resolve(dict["DescendantFonts"]).([]any)
+	if len(descendants) == 0 {
+		return
+	}
+	descendant := d.dict(descendants[0])
+	if dw, ok := d.resolve(descendant["DW"]).(float64); ok {
+		font.defaultWidth = dw / 1000
+	}
+
+	// Entries are "first [w1 w2 ...]" or "first last w"
+	w, _ := d.resolve(descendant["W"]).([]any)
+	for i := 0; i+1 < len(w); {
+		first, ok := d.resolve(w[i]).(float64)
+		if !ok || first < 0 {
+			return
+		}
+		if widths, ok := d.resolve(w[i+1]).([]any); ok {
+			for j, width := range widths {
+				if v, ok := d.resolve(width).(float64); ok {
+					font.widths[uint32(first)+uint32(j)] = v / 1000
+				}
+			}
+			i += 2
+			continue
+		}
+		if i+2 >= len(w) {
+			return
+		}
+		last, ok1 := d.resolve(w[i+1]).(float64)
+		width, ok2 := d.resolve(w[i+2]).(float64)
+		if ok1 && ok2 && last >= first && last-first < maxCMapRange {
+			for code := uint32(first); code <= uint32(last); code++ {
+				font.widths[code] = width / 1000
+			}
+		}
+		i += 3
+	}
+}
+
+// simpleEncoding builds the code-to-text table of a simple font's /Encoding: a base
+// encoding name, or a dictionary with a base encoding and /Differences
+func (d *pdfDocument) simpleEncoding(value any) *[256]string {
+	switch enc := d.resolve(value).(type) {
+	case pdfName:
+		return baseEncoding(enc)
+	case pdfDict:
+		base, _ := d.resolve(enc["BaseEncoding"]).(pdfName)
+		table := *baseEncoding(base)
+		differences, _ := d.resolve(enc["Differences"]).([]any)
+		code := 0
+		for _, item := range differences {
+			switch v := d.resolve(item).(type) {
+			case float64:
+				code = int(v)
+			case pdfName:
+				if code >= 0 && code < 256 {
+					if text, ok := glyphText(string(v)); ok {
+						table[code] = text
+					}
+				}
+				code++
+			}
+		}
+		return &table
+	}
+	return baseEncoding("")
+}
+
+// baseEncoding returns the table of a named base encoding. Standard encoding (the
+// default) is approximated by Windows-1252, which agrees on letters and digits.
+func baseEncoding(name pdfName) *[256]string {
+	cm := charmap.Windows1252
+	if name == "MacRomanEncoding" {
+		cm = charmap.Macintosh
+	}
+	var table [256]string
+	for code := 32; code < 256; code++ {
+		if r := cm.DecodeByte(byte(code)); r != utf8.RuneError {
+			table[code] = string(r)
+		}
+	}
+	table['\t'], table['\n'], table['\r'] = " ", " ", " "
+	return &table
+}
+
+// parseToUnicodeCMap reads the code-to-text mappings of a ToUnicode CMap and the
+// code length (in bytes) of its codespace (0 = not declared)
+func parseToUnicodeCMap(data []byte) (map[uint32]string, int) {
+	mappings := map[uint32]string{}
+	codeBytes := 0
+	lexer := &pdfLexer{data: data}
+	var operands []any
+	for {
+		token, err := lexer.next()
+		if err != nil {
+			return mappings, codeBytes
+		}
+		keyword, ok := token.(pdfKeyword)
+		if !ok {
+			operands = append(operands, token)
+			continue
+		}
+
+		switch keyword {
+		case "endcodespacerange":
+			if len(operands) > 0 {
+				if low, ok := operands[0].(pdfString); ok {
+					codeBytes = len(low)
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].(pdfString)
+				dst, ok2 := operands[i+1].(pdfString)
+				if ok1 && ok2 {
+					mappings[cmapCode(src)] = utf16BEText(dst)
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				lowStr, ok1 := operands[i].(pdfString)
+				highStr, ok2 := operands[i+1].(pdfString)
+				if !ok1 || !ok2 {
+					continue
+				}
+				low, high := cmapCode(lowStr), cmapCode(highStr)
+				if high < low || high-low >= maxCMapRange {
+					continue
+				}
+				switch dst := operands[i+2].(type) {
+				case pdfString:
+					// Consecutive codes map to consecutive text: increment the last unit
+					units := utf16BEUnits(dst)
+					if len(units) == 0 {
+						continue
+					}
+					for code := low; code <= high; code++ {
+						shifted := append([]uint16(nil), units...)
+						shifted[len(shifted)-1] += uint16(code - low)
+						mappings[code] = string(utf16.Decode(shifted))
+					}
+				case []any:
+					for j, item := range dst {
+						if text, ok := item.(pdfString); ok && low+uint32(j) <= high {
+							mappings[low+uint32(j)] = utf16BEText(text)
+						}
+					}
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+}
+
+// cmapCode reads a big-endian character code
+func cmapCode(s pdfString) uint32 {
+	var code uint32
+	for i := 0; i < len(s) && i < 4; i++ {
+		code = code<<8 | uint32(s[i])
+	}
+	return code
+}
+
+func utf16BEUnits(s pdfString) []uint16 {
+	units := make([]uint16, 0, len(s)/2)
+	for i := 0; i+1 < len(s); i += 2 {
+		units = append(units, uint16(s[i])<<8|uint16(s[i+1]))
+	}
+	return units
+}
+
+func utf16BEText(s pdfString) string {
+	return string(utf16.Decode(utf16BEUnits(s)))
+}
+
+// glyphNames maps the glyph names of /Differences that aren't a single character
+// or a letter with an accent
+var glyphNames = map[string]string{
+	"space": " ", "nbspace": " ", "nonbreakingspace": " ",
+	"exclam": "!", "quotedbl": `"`, "numbersign": "#", "dollar": "$", "percent": "%",
+	"ampersand": "&", "quotesingle": "'", "parenleft": "(", "parenright": ")",
+	"asterisk": "*", "plus": "+", "comma": ",", "hyphen": "-", "minus": "−",
+	"period": ".", "slash": "/", "colon": ":", "semicolon": ";", "less": "<",
+	"equal": "=", "greater": ">", "question": "?", "at": "@", "bracketleft": "[",
+	"backslash": `\`, "bracketright": "]", "asciicircum": "^", "underscore": "_",
+	"grave": "`", "braceleft": "{", "bar": "|", "braceright": "}", "asciitilde": "~",
+	"zero": "0", "one": "1", "two": "2", "three": "3", "four": "4",
+	"five": "5", "six": "6", "seven": "7", "eight": "8", "nine": "9",
+	"quoteleft": "‘", "quoteright": "’", "quotedblleft": "“", "quotedblright": "”",
+	"quotesinglbase": "‚", "quotedblbase": "„", "guillemotleft": "«", "guillemotright": "»",
+	"guilsinglleft": "‹", "guilsinglright": "›", "endash": "–", "emdash": "—",
+	"bullet": "•", "ellipsis": "…", "dagger": "†", "daggerdbl": "‡",
+	"periodcentered": "·", "section": "§", "paragraph": "¶", "copyright": "©",
+	"registered": "®", "trademark": "™", "degree": "°", "exclamdown": "¡",
+	"questiondown": "¿", "sterling": "£", "yen": "¥", "Euro": "€", "cent": "¢",
+	"germandbls": "ß", "ae": "æ", "AE": "Æ", "oe": "œ", "OE": "Œ",
+	"oslash": "ø", "Oslash": "Ø", "eth": "ð", "Eth": "Ð", "thorn": "þ", "Thorn": "Þ",
+	"dotlessi": "ı", "fi": "fi", "fl": "fl", "ff": "ff", "ffi": "ffi", "ffl": "ffl",
+}
+
+// glyphAccents are the combining marks of accented glyph names ("eacute" = e + acute)
+var glyphAccents = map[string]string{
+	"grave": "\u0300", "acute": "\u0301", "circumflex": "\u0302", "tilde": "\u0303",
+	"dieresis": "\u0308", "ring": "\u030a", "cedilla": "\u0327", "caron": "\u030c",
+}
+
+// glyphText returns the text of a glyph name (Adobe glyph list conventions)
+func glyphText(name string) (string, bool) {
+	if base, _, found := strings.Cut(name, "."); found && base != "" {
+		name = base // Variants: "a.sc", "one.oldstyle"
+	}
+	if text, ok := glyphNames[name]; ok {
+		return text, true
+	}
+	if len(name) == 1 {
+		return name, true
+	}
+	if strings.Contains(name, "_") {
+		// Ligatures: "f_f_i"
+		var out strings.Builder
+		for _, part := range strings.Split(name, "_") {
+			text, ok := glyphText(part)
+			if !ok {
+				return "", false
+			}
+			out.WriteString(text)
+		}
+		return out.String(), true
+	}
+	if hexCode, ok := strings.CutPrefix(name, "uni"); ok && len(hexCode) == 4 {
+		if r, err := strconv.ParseUint(hexCode, 16, 16); err == nil {
+			return string(rune(r)), true
+		}
+	}
+	if hexCode, ok := strings.CutPrefix(name, "u"); ok && len(hexCode) >= 4 && len(hexCode) <= 6 {
+		if r, err := strconv.ParseUint(hexCode, 16, 32); err == nil {
+			return string(rune(r)), true
+		}
+	}
+	if len(name) > 1 {
+		if mark, ok := glyphAccents[name[1:]]; ok {
+			return norm.NFC.String(name[:1] + mark), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,653 @@
+package converter
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/ascii85"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxPDFStreamBytes caps one decoded stream (guards against compression bombs)
+const maxPDFStreamBytes = 64 << 20
+
+// maxPDFDepth bounds reference chains, page trees and nested form XObjects
+const maxPDFDepth = 32
+
+var (
+	errPDFEncrypted = errors.New("PDF is encrypted or password-protected")
+	errPDFFilter    = errors.New("unsupported stream filter")
+)
+
+// PDF object values, as parsed:
+//   - nil (null), bool, float64 (numbers)
+//   - pdfName, pdfString (raw bytes), []any (arrays), pdfDict
+//   - pdfRef (indirect reference), *pdfStream, pdfKeyword (content stream operators)
+type (
+	pdfName    string
+	pdfString  string
+	pdfKeyword string
+	pdfDict    map[pdfName]any
+	pdfRef     struct{ num, gen int }
+	pdfStream  struct {
+		dict pdfDict
+		raw  []byte // Encoded data
+	}
+)
+
+// pdfLexer reads PDF objects from a byte slice
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+func isPDFSpace(b byte) bool {
+	switch b {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+func isPDFDelimiter(b byte) bool {
+	switch b {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+// skipSpace skips whitespace and comments
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		b := l.data[l.pos]
+		if b == '%' {
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+			continue
+		}
+		if !isPDFSpace(b) {
+			return
+		}
+		l.pos++
+	}
+}
+
+// regular reads a run of regular (non-space, non-delimiter) characters
+func (l *pdfLexer) regular() string {
+	start := l.pos
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !isPDFDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	return string(l.data[start:l.pos])
+}
+
+// next parses the next object. Returns io.EOF at the end of the data.
+func (l *pdfLexer) next() (any, error) {
+	return l.parse(0)
+}
+
+func (l *pdfLexer) parse(depth int) (any, error) {
+	if depth > maxPDFDepth*4 {
+		return nil, errors.New("objects nested too deeply")
+	}
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, io.EOF
+	}
+
+	switch b := l.data[l.pos]; {
+	case b == '/':
+		l.pos++
+		return pdfName(decodePDFName(l.regular())), nil
+	case b == '(':
+		l.pos++
+		return l.literalString(), nil
+	case b == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<':
+		l.pos += 2
+		return l.dict(depth)
+	case b == '<':
+		l.pos++
+		return l.hexString(), nil
+	case b == '[':
+		l.pos++
+		return l.array(depth)
+	case b == ']' || b == '>' || b == ')' || b == '{' || b == '}':
+		// Stray delimiter: return it as a keyword so callers can stop or skip it
+		l.pos++
+		if b == '>' && l.pos < len(l.data) && l.data[l.pos] == '>' {
+			l.pos++
+			return pdfKeyword(">>"), nil
+		}
+		return pdfKeyword(string(b)), nil
+	}
+
+	token := l.regular()
+	if token == "" {
+		l.pos++ // Unreachable for well-formed data; never loop on one byte
+		return l.parse(depth)
+	}
+	switch token {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	if n, err := strconv.ParseFloat(token, 64); err == nil {
+		if num, isInt := pdfInt(token); isInt {
+			if ref, ok := l.tryRef(num); ok {
+				return ref, nil
+			}
+		}
+		return n, nil
+	}
+	return pdfKeyword(token), nil
+}
+
+// tryRef reads "gen R" after an object number, restoring the position if it isn't one
+func (l *pdfLexer) tryRef(num int) (pdfRef, bool) {
+	start := l.pos
+	l.skipSpace()
+	gen, isInt := pdfInt(l.regular())
+	if isInt {
+		l.skipSpace()
+		if l.regular() == "R" {
+			return pdfRef{num: num, gen: gen}, true
+		}
+	}
+	l.pos = start
+	return pdfRef{}, false
+}
+
+// pdfInt parses a non-negative integer token
+func pdfInt(token string) (int, bool) {
+	if token == "" || len(token) > 10 {
+		return 0, false
+	}
+	for i := 0; i < len(token); i++ {
+		if token[i] < '0' || token[i] > '9' {
+			return 0, false
+		}
+	}
+	n, err := strconv.Atoi(token)
+	return n, err == nil
+}
+
+func (l *pdfLexer) dict(depth int) (pdfDict, error) {
+	dict := pdfDict{}
+	for {
+		key, err := l.parse(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if key == pdfKeyword(">>") {
+			return dict, nil
+		}
+		name, ok := key.(pdfName)
+		if !ok {
+			continue // Malformed key: skip it
+		}
+		value, err := l.parse(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if value == pdfKeyword(">>") {
+			return dict, nil
+		}
+		dict[name] = value
+	}
+}
+
+func (l *pdfLexer) array(depth int) ([]any, error) {
+	array := []any{}
+	for {
+		value, err := l.parse(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if value == pdfKeyword("]") {
+			return array, nil
+		}
+		array = append(array, value)
+	}
+}
+
+func (l *pdfLexer) literalString() pdfString {
+	var out []byte
+	nesting := 1
+	for l.pos < len(l.data) {
+		b := l.data[l.pos]
+		l.pos++
+		switch b {
+		case '(':
+			nesting++
+		case ')':
+			nesting--
+			if nesting == 0 {
+				return pdfString(out)
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				return pdfString(out)
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				b = '\n'
+			case 'r':
+				b = '\r'
+			case 't':
+				b = '\t'
+			case 'b':
+				b = '\b'
+			case 'f':
+				b = '\f'
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue // Line continuation
+			case '\n':
+				continue
+			default:
+				if e >= '0' && e <= '7' {
+					value := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						value = value*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					b = byte(value)
+				} else {
+					b = e
+				}
+			}
+		}
+		out = append(out, b)
+	}
+	return pdfString(out)
+}
+
+func (l *pdfLexer) hexString() pdfString {
+	var digits []byte
+	for l.pos < len(l.data) {
+		b := l.data[l.pos]
+		l.pos++
+		if b == '>' {
+			break
+		}
+		if !isPDFSpace(b) {
+			digits = append(digits, b)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	out := make([]byte, len(digits)/2)
+	if _, err := hex.Decode(out, digits); err != nil {
+		return ""
+	}
+	return pdfString(out)
+}
+
+// decodePDFName decodes #xx escapes in a name
+func decodePDFName(raw string) string {
+	if !strings.Contains(raw, "#") {
+		return raw
+	}
+	var out []byte
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '#' && i+2 < len(raw) {
+			if v, err := strconv.ParseUint(raw[i+1:i+3], 16, 8); err == nil {
+				out = append(out, byte(v))
+				i += 2
+				continue
+			}
+		}
+		out = append(out, raw[i])
+	}
+	return string(out)
+}
+
+// pdfDocument holds every object of a PDF, found by scanning the file rather than
+// trusting its cross-reference table (often wrong in files that were edited)
+type pdfDocument struct {
+	objects map[int]any
+	trailer pdfDict
+}
+
+var (
+	pdfObjectHeader = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+	pdfTrailer      = regexp.MustCompile(`trailer\s*<<`)
+)
+
+// parsePDF loads the objects of a PDF file, including those packed in object streams
+func parsePDF(data []byte) (*pdfDocument, error) {
+	header := data
+	if len(header) > 1024 {
+		header = header[:1024]
+	}
+	if !bytes.Contains(header, []byte("%PDF-")) {
+		return nil, errors.New("not a PDF file")
+	}
+
+	doc := &pdfDocument{objects: map[int]any{}, trailer: pdfDict{}}
+	pos := 0
+	for pos < len(data) {
+		loc := pdfObjectHeader.FindSubmatchIndex(data[pos:])
+		if loc == nil {
+			break
+		}
+		num, _ := strconv.Atoi(string(data[pos+loc[2] : pos+loc[3]]))
+		lexer := &pdfLexer{data: data, pos: pos + loc[1]}
+		pos += loc[1]
+
+		value, err := lexer.next()
+		if err != nil {
+			continue
+		}
+		if dict, ok := value.(pdfDict); ok {
+			if stream, end, ok := readPDFStream(data, lexer.pos, dict); ok {
+				value = stream
+				pos = end
+			}
+			if dict["Type"] == pdfName("XRef") {
+				doc.mergeTrailer(dict) // Cross-reference streams carry the trailer
+			}
+		}
+		doc.objects[num] = value // Later definitions (incremental updates) win
+	}
+
+	for _, loc := range pdfTrailer.FindAllIndex(data, -1) {
+		lexer := &pdfLexer{data: data, pos: loc[0] + len("trailer")}
+		if dict, err := lexer.next(); err == nil {
+			if trailer, ok := dict.(pdfDict); ok {
+				doc.mergeTrailer(trailer)
+			}
+		}
+	}
+	if _, encrypted := doc.trailer["Encrypt"]; encrypted {
+		return nil, errPDFEncrypted
+	}
+
+	doc.expandObjectStreams()
+	if len(doc.objects) == 0 {
+		return nil, errors.New("PDF has no readable objects")
+	}
+	return doc, nil
+}
+
+// mergeTrailer keeps the keys of the latest trailer
+func (d *pdfDocument) mergeTrailer(trailer pdfDict) {
+	for key, value := range trailer {
+		d.trailer[key] = value
+	}
+}
+
+// readPDFStream reads the stream data following a dictionary, if there is one.
+// Returns the stream and the position after "endstream".
+func readPDFStream(data []byte, pos int, dict pdfDict) (*pdfStream, int, bool) {
+	lexer := &pdfLexer{data: data, pos: pos}
+	lexer.skipSpace()
+	if !bytes.HasPrefix(data[lexer.pos:], []byte("stream")) {
+		return nil, 0, false
+	}
+	start := lexer.pos + len("stream")
+	if start < len(data) && data[start] == '\r' {
+		start++
+	}
+	if start < len(data) && data[start] == '\n' {
+		start++
+	}
+
+	// Trust /Length only when "endstream" follows it; it is often an indirect
+	// reference or simply wrong
+	if length, ok := dict["Length"].(float64); ok && length >= 0 && start+int(length) <= len(data) {
+		end := start + int(length)
+		rest := &pdfLexer{data: data, pos: end}
+		rest.skipSpace()
+		if bytes.HasPrefix(data[rest.pos:], []byte("endstream")) {
+			return &pdfStream{dict: dict, raw: data[start:end]}, rest.pos + len("endstream"), true
+		}
+	}
+
+	idx := bytes.Index(data[start:], []byte("endstream"))
+	if idx < 0 {
+		return &pdfStream{dict: dict, raw: data[start:]}, len(data), true
+	}
+	end := start + idx
+	raw := bytes.TrimSuffix(bytes.TrimSuffix(data[start:end], []byte("\n")), []byte("\r"))
+	return &pdfStream{dict: dict, raw: raw}, end + len("endstream"), true
+}
+
+// expandObjectStreams adds the objects packed in object streams (PDF 1.5+). Objects
+// defined directly in the file take precedence.
+func (d *pdfDocument) expandObjectStreams() {
+	var streamNums []int
+	for num, value := range d.objects {
+		if stream, ok := value.(*pdfStream); ok && stream.dict["Type"] == pdfName("ObjStm") {
+			streamNums = append(streamNums, num)
+		}
+	}
+	sort.Ints(streamNums)
+
+	for _, num := range streamNums {
+		stream := d.objects[num].(*pdfStream)
+		data, err := d.decodeStream(stream)
+		if err != nil {
+			continue
+		}
+		count, _ := d.resolve(stream.dict["N"]).(float64)
+		first, _ := d.resolve(stream.dict["First"]).(float64)
+		if first < 0 || int(first) > len(data) {
+			continue
+		}
+
+		header := &pdfLexer{data: data[:int(first)]}
+		for i := 0; i < int(count); i++ {
+			objNum, err1 := header.next()
+			offset, err2 := header.next()
+			if err1 != nil || err2 != nil {
+				break
+			}
+			n, ok1 := objNum.(float64)
+			off, ok2 := offset.(float64)
+			if !ok1 || !ok2 || int(first)+int(off) >= len(data) {
+				continue
+			}
+			if _, defined := d.objects[int(n)]; defined {
+				continue
+			}
+			lexer := &pdfLexer{data: data, pos: int(first) + int(off)}
+			if value, err := lexer.next(); err == nil {
+				d.objects[int(n)] = value
+			}
+		}
+	}
+}
+
+// resolve follows indirect references
+func (d *pdfDocument) resolve(value any) any {
+	for i := 0; i < maxPDFDepth; i++ {
+		ref, ok := value.(pdfRef)
+		if !ok {
+			return value
+		}
+		value = d.objects[ref.num]
+	}
+	return nil
+}
+
+// dict resolves value as a dictionary (a stream's dictionary counts)
+func (d *pdfDocument) dict(value any) pdfDict {
+	switch v := d.resolve(value).(type) {
+	case pdfDict:
+		return v
+	case *pdfStream:
+		return v.dict
+	}
+	return nil
+}
+
+// decodeStream applies the stream's filters
+func (d *pdfDocument) decodeStream(stream *pdfStream) ([]byte, error) {
+	var filters []any
+	switch f := d.resolve(stream.dict["Filter"]).(type) {
+	case pdfName:
+		filters = []any{f}
+	case []any:
+		filters = f
+	}
+
+	data := stream.raw
+	for _, filter := range filters {
+		name, _ := d.resolve(filter).(pdfName)
+		var err error
+		switch name {
+		case "FlateDecode", "Fl":
+			data, err = inflatePDF(data)
+		case "ASCIIHexDecode", "AHx":
+			lexer := &pdfLexer{data: append(bytes.Clone(data), '>')}
+			data = []byte(lexer.hexString())
+		case "ASCII85Decode", "A85":
+			data, err = decodeASCII85(data)
+		default:
+			return nil, fmt.Errorf("%w %s", errPDFFilter, name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// inflatePDF decompresses zlib data, keeping what was read before a truncated or
+// corrupt end (common in real files)
+func inflatePDF(data []byte) ([]byte, error) {
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("inflate stream: %w", err)
+	}
+	defer reader.Close()
+
+	out, err := io.ReadAll(io.LimitReader(reader, maxPDFStreamBytes+1))
+	if len(out) > maxPDFStreamBytes {
+		return nil, fmt.Errorf("stream is larger than %d MB", maxPDFStreamBytes>>20)
+	}
+	if err != nil && len(out) == 0 {
+		return nil, fmt.Errorf("inflate stream: %w", err)
+	}
+	return out, nil
+}
+
+// decodeASCII85 decodes <~ ~> delimited ASCII base-85 data
+func decodeASCII85(data []byte) ([]byte, error) {
+	data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("<~"))
+	if end := bytes.Index(data, []byte("~>")); end >= 0 {
+		data = data[:end]
+	}
+	out := make([]byte, 4*len(data)/5+4)
+	n, _, err := ascii85.Decode(out, data, true)
+	if err != nil {
+		return nil, fmt.Errorf("decode ASCII85 stream: %w", err)
+	}
+	return out[:n], nil
+}
+
+// pdfPage is a page's content and the resources its content refers to
+type pdfPage struct {
+	dict      pdfDict
+	resources pdfDict
+}
+
+// pages returns the pages in reading order, from the document catalog's page tree.
+// Falls back to every page object in file order if the tree can't be read.
+func (d *pdfDocument) pages() []pdfPage {
+	var pages []pdfPage
+	visited := map[int]bool{} // Object numbers of walked nodes (malformed trees can loop)
+	var walk func(value any, inherited pdfDict, depth int)
+	walk = func(value any, inherited pdfDict, depth int) {
+		if ref, ok := value.(pdfRef); ok {
+			if visited[ref.num] {
+				return
+			}
+			visited[ref.num] = true
+		}
+		node := d.dict(value)
+		if node == nil || depth > maxPDFDepth {
+			return
+		}
+		resources := inherited
+		if own := d.dict(node["Resources"]); own != nil {
+			resources = own
+		}
+		if node["Type"] == pdfName("Page") {
+			pages = append(pages, pdfPage{dict: node, resources: resources})
+			return
+		}
+		kids, _ := d.resolve(node["Kids"]).([]any)
+		for _, kid := range kids {
+			walk(kid, resources, depth+1)
+		}
+	}
+
+	if catalog := d.dict(d.trailer["Root"]); catalog != nil {
+		walk(catalog["Pages"], nil, 0)
+	}
+	if len(pages) > 0 {
+		return pages
+	}
+
+	var nums []int
+	for num, value := range d.objects {
+		if dict, ok := value.(pdfDict); ok && dict["Type"] == pdfName("Page") {
+			nums = append(nums, num)
+		}
+	}
+	sort.Ints(nums)
+	for _, num := range nums {
+		page := d.objects[num].(pdfDict)
+		resources := d.dict(page["Resources"])
+		for parent, depth := d.dict(page["Parent"]), 0; resources == nil && parent != nil && depth < maxPDFDepth; parent, depth = d.dict(parent["Parent"]), depth+1 {
+			resources = d.dict(parent["Resources"])
+		}
+		pages = append(pages, pdfPage{dict: page, resources: resources})
+	}
+	return pages
+}
+
+// contents returns a page's (or form XObject's) decoded content streams, joined.
+// Streams with unsupported filters are left out.
+func (d *pdfDocument) contents(value any) []byte {
+	var streams []any
+	switch v := d.resolve(value).(type) {
+	case *pdfStream:
+		streams = []any{v}
+	case []any:
+		streams = v
+	}
+
+	var out []byte
+	for _, s := range streams {
+		stream, ok := d.resolve(s).(*pdfStream)
+		if !ok {
+			continue
+		}
+		data, err := d.decodeStream(stream)
+		if err != nil {
+			continue
+		}
+		out = append(out, data...)
+		out = append(out, '\n')
+	}
+	return out
+}
@@ -29,6 +29,8 @@ func NewConverterRegistry() *ConverterRegistry {
 	registry.Register(NewMarkdownConverter())
 	registry.Register(NewTextConverter())
 	registry.Register(NewHTMLConverter())
+	registry.Register(NewPDFConverter())
+	registry.Register(NewDOCXConverter())
 
 	return registry
 }
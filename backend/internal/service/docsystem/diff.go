@@ -0,0 +1,91 @@
+package docsystem
+
+import (
+	"strings"
+
+	models "meridian/internal/domain/models/docsystem"
+)
+
+// diffContextLines is how many unchanged lines surround each hunk (as in `diff -u`)
+const diffContextLines = 3
+
+// DiffHunks computes a unified line diff from before to after, using the same line
+// matching as MergeText. Returns the hunks and the added/removed line counts.
+func DiffHunks(before, after string) ([]models.DiffHunk, int, int) {
+	beforeLines := splitLines(before)
+	afterLines := splitLines(after)
+	matches := lineMatches(beforeLines, afterLines)
+
+	// Flatten into one line sequence, in order
+	var lines []models.DiffLine
+	added, removed := 0, 0
+	b, a := 0, 0
+	flush := func(beforeEnd, afterEnd int) {
+		for ; b < beforeEnd; b++ {
+			lines = append(lines, models.DiffLine{Type: models.DiffLineRemoved, Content: trimNewline(beforeLines[b]), OldLine: b + 1})
+			removed++
+		}
+		for ; a < afterEnd; a++ {
+			lines = append(lines, models.DiffLine{Type: models.DiffLineAdded, Content: trimNewline(afterLines[a]), NewLine: a + 1})
+			added++
+		}
+	}
+	for i := range beforeLines {
+		j, kept := matches[i]
+		if !kept {
+			continue
+		}
+		flush(i, j)
+		lines = append(lines, models.DiffLine{Type: models.DiffLineContext, Content: trimNewline(beforeLines[i]), OldLine: i + 1, NewLine: j + 1})
+		b, a = i+1, j+1
+	}
+	flush(len(beforeLines), len(afterLines))
+
+	// Group changes into hunks, merging hunks whose context would overlap
+	hunks := []models.DiffHunk{}
+	start, end := -1, -1
+	for i, line := range lines {
+		if line.Type == models.DiffLineContext {
+			continue
+		}
+		lo := max(i-diffContextLines, 0)
+		if start >= 0 && lo > end {
+			hunks = append(hunks, buildHunk(lines[start:end]))
+			start = -1
+		}
+		if start < 0 {
+			start = lo
+		}
+		end = min(i+diffContextLines+1, len(lines))
+	}
+	if start >= 0 {
+		hunks = append(hunks, buildHunk(lines[start:end]))
+	}
+
+	return hunks, added, removed
+}
+
+// buildHunk computes a hunk's header ranges from its lines
+func buildHunk(lines []models.DiffLine) models.DiffHunk {
+	hunk := models.DiffHunk{Lines: lines}
+	for _, line := range lines {
+		if line.OldLine > 0 {
+			if hunk.OldLines == 0 {
+				hunk.OldStart = line.OldLine
+			}
+			hunk.OldLines++
+		}
+		if line.NewLine > 0 {
+			if hunk.NewLines == 0 {
+				hunk.NewStart = line.NewLine
+			}
+			hunk.NewLines++
+		}
+	}
+	// A side with no lines only happens when that version is empty; its start stays 0
+	return hunk
+}
+
+func trimNewline(line string) string {
+	return strings.TrimSuffix(line, "\n")
+}
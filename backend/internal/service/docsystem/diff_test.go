@@ -0,0 +1,74 @@
+package docsystem
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	models "meridian/internal/domain/models/docsystem"
+)
+
+func numberedLines(n int) string {
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	return b.String()
+}
+
+func TestDiffHunks(t *testing.T) {
+	t.Run("identical", func(t *testing.T) {
+		hunks, added, removed := DiffHunks("a\nb\n", "a\nb\n")
+		if len(hunks) != 0 || added != 0 || removed != 0 {
+			t.Fatalf("got %d hunks, +%d -%d; want none", len(hunks), added, removed)
+		}
+	})
+
+	t.Run("single change has context", func(t *testing.T) {
+		before := numberedLines(10)
+		after := strings.Replace(before, "line 5\n", "line five\n", 1)
+
+		hunks, added, removed := DiffHunks(before, after)
+		if added != 1 || removed != 1 {
+			t.Fatalf("got +%d -%d, want +1 -1", added, removed)
+		}
+		if len(hunks) != 1 {
+			t.Fatalf("got %d hunks, want 1", len(hunks))
+		}
+		h := hunks[0]
+		if h.OldStart != 2 || h.OldLines != 7 || h.NewStart != 2 || h.NewLines != 7 {
+			t.Fatalf("header = -%d,%d +%d,%d; want -2,7 +2,7", h.OldStart, h.OldLines, h.NewStart, h.NewLines)
+		}
+		removedLine, addedLine := h.Lines[3], h.Lines[4]
+		if removedLine.Type != models.DiffLineRemoved || removedLine.Content != "line 5" || removedLine.OldLine != 5 {
+			t.Fatalf("unexpected removed line %+v", removedLine)
+		}
+		if addedLine.Type != models.DiffLineAdded || addedLine.Content != "line five" || addedLine.NewLine != 5 {
+			t.Fatalf("unexpected added line %+v", addedLine)
+		}
+	})
+
+	t.Run("distant changes are separate hunks", func(t *testing.T) {
+		before := numberedLines(20)
+		after := strings.Replace(before, "line 2\n", "", 1)
+		after = strings.Replace(after, "line 18\n", "line 18\nextra\n", 1)
+
+		hunks, added, removed := DiffHunks(before, after)
+		if added != 1 || removed != 1 || len(hunks) != 2 {
+			t.Fatalf("got %d hunks, +%d -%d; want 2 hunks, +1 -1", len(hunks), added, removed)
+		}
+		if hunks[1].OldStart != 16 || hunks[1].NewStart != 15 {
+			t.Fatalf("second hunk starts at -%d +%d, want -16 +15", hunks[1].OldStart, hunks[1].NewStart)
+		}
+	})
+
+	t.Run("from empty", func(t *testing.T) {
+		hunks, added, removed := DiffHunks("", "a\nb\n")
+		if added != 2 || removed != 0 || len(hunks) != 1 {
+			t.Fatalf("got %d hunks, +%d -%d; want 1 hunk, +2 -0", len(hunks), added, removed)
+		}
+		if hunks[0].OldStart != 0 || hunks[0].OldLines != 0 || hunks[0].NewStart != 1 || hunks[0].NewLines != 2 {
+			t.Fatalf("unexpected header %+v", hunks[0])
+		}
+	})
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"strings"
 	"time"
 
 	"meridian/internal/config"
@@ -20,11 +19,13 @@ import (
 type documentService struct {
 	docRepo         docsysRepo.DocumentRepository
 	folderRepo      docsysRepo.FolderRepository
+	settingsRepo    docsysRepo.ProjectSettingsRepository // Project search language
 	txManager       repositories.TransactionManager
 	contentAnalyzer docsysSvc.ContentAnalyzer
 	pathResolver    docsysSvc.PathResolver
-	validator       *ResourceValidator
+	validator       docsysSvc.ParentValidator
 	authorizer      services.ResourceAuthorizer
+	namePolicy      NamePolicy // Decides which sibling names collide
 	logger          *slog.Logger
 }
 
@@ -32,21 +33,25 @@ type documentService struct {
 func NewDocumentService(
 	docRepo docsysRepo.DocumentRepository,
 	folderRepo docsysRepo.FolderRepository,
+	settingsRepo docsysRepo.ProjectSettingsRepository,
 	txManager repositories.TransactionManager,
 	contentAnalyzer docsysSvc.ContentAnalyzer,
 	pathResolver docsysSvc.PathResolver,
-	validator *ResourceValidator,
+	validator docsysSvc.ParentValidator,
 	authorizer services.ResourceAuthorizer,
+	namePolicy NamePolicy,
 	logger *slog.Logger,
 ) docsysSvc.DocumentService {
 	return &documentService{
 		docRepo:         docRepo,
 		folderRepo:      folderRepo,
+		settingsRepo:    settingsRepo,
 		txManager:       txManager,
 		contentAnalyzer: contentAnalyzer,
 		pathResolver:    pathResolver,
 		validator:       validator,
 		authorizer:      authorizer,
+		namePolicy:      namePolicy,
 		logger:          logger,
 	}
 }
@@ -99,7 +104,7 @@ func (s *documentService) CreateDocument(ctx context.Context, req *docsysSvc.Cre
 		return nil, fmt.Errorf("failed to check for duplicate names: %w", err)
 	}
 	for _, sibling := range siblings {
-		if sibling.Name == docName {
+		if s.namePolicy.SameName(sibling.Name, docName) {
 			return nil, &domain.ConflictError{
 				Message:      fmt.Sprintf("a document named %q already exists in this folder", docName),
 				ResourceType: "document",
@@ -122,6 +127,10 @@ func (s *documentService) CreateDocument(ctx context.Context, req *docsysSvc.Cre
 		UpdatedAt: time.Now(),
 	}
 
+	if err := s.validateSkill(ctx, doc); err != nil {
+		return nil, err
+	}
+
 	if err := s.docRepo.Create(ctx, doc); err != nil {
 		return nil, err
 	}
@@ -147,6 +156,29 @@ func (s *documentService) CreateDocument(ctx context.Context, req *docsysSvc.Cre
 	return doc, nil
 }
 
+// validateSkill rejects SKILL documents (.skills/{name}/SKILL) whose frontmatter has errors.
+// Warnings (e.g. missing version) don't block the save. Other documents pass through.
+func (s *documentService) validateSkill(ctx context.Context, doc *models.Document) error {
+	if doc.Name != models.SkillDocumentName || doc.FolderID == nil {
+		return nil
+	}
+
+	path, err := s.docRepo.GetPath(ctx, doc)
+	if err != nil {
+		return fmt.Errorf("failed to compute document path: %w", err)
+	}
+	skillName, ok := models.SkillNameFromPath(path)
+	if !ok {
+		return nil
+	}
+
+	parsed := models.ParseSkill(skillName, doc.Content)
+	if parsed.HasErrors() {
+		return &models.SkillValidationError{Skill: skillName, Issues: parsed.Issues}
+	}
+	return nil
+}
+
 // GetDocument retrieves a document with its computed path
 // Authorization is checked first via the injected authorizer
 func (s *documentService) GetDocument(ctx context.Context, userID, documentID string) (*models.Document, error) {
@@ -187,33 +219,43 @@ func (s *documentService) UpdateDocument(ctx context.Context, userID, documentID
 		return nil, err
 	}
 
+	// PATCH semantics: absent fields are left unchanged
+	if req.Name.IsNull() {
+		return nil, fmt.Errorf("%w: document name cannot be null", domain.ErrValidation)
+	}
+	if req.Content.IsNull() {
+		return nil, fmt.Errorf("%w: document content cannot be null", domain.ErrValidation)
+	}
+
 	// Update fields
-	if req.Name != nil {
-		trimmedName := strings.TrimSpace(*req.Name)
-		// Validate name doesn't contain slashes
-		if strings.Contains(trimmedName, "/") {
-			return nil, fmt.Errorf("%w: document name cannot contain slashes", domain.ErrValidation)
+	if name, ok := req.Name.Value(); ok {
+		normalized, err := NormalizeAndValidateName(name, config.MaxDocumentNameLength)
+		if err != nil {
+			return nil, fmt.Errorf("%w: document %v", domain.ErrValidation, err)
 		}
-		doc.Name = trimmedName
+		doc.Name = normalized
 	}
 
 	// Priority-based folder resolution for moving documents:
 	// 1. Try folder_id first (frontend optimization - direct lookup)
 	// 2. Fall back to folder_path (external AI - resolve/auto-create)
 	// 3. Neither = don't move document
-	if req.FolderID != nil {
-		// Validate target folder exists and is not deleted
-		targetFolderID := *req.FolderID
-		if targetFolderID != "" { // Empty string means root, which is always valid
+	if req.FolderID.IsSet() {
+		// null and "" both mean root, which is always valid
+		targetFolderID, _ := req.FolderID.Value()
+		if targetFolderID != "" {
+			// Validate target folder exists and is not deleted
 			if err := s.validator.ValidateFolder(ctx, targetFolderID, doc.ProjectID); err != nil {
 				return nil, err
 			}
+			doc.FolderID = &targetFolderID
+		} else {
+			doc.FolderID = nil
 		}
-		// Frontend optimization: use provided folder_id directly
-		doc.FolderID = req.FolderID
-	} else if req.FolderPath != nil {
-		// External AI: resolve folder path, creating folders if needed
-		resolvedFolder, err := s.pathResolver.ResolveFolderPath(ctx, doc.ProjectID, *req.FolderPath)
+	} else if req.FolderPath.IsSet() {
+		// External AI: resolve folder path, creating folders if needed (null = root)
+		folderPath, _ := req.FolderPath.Value()
+		resolvedFolder, err := s.pathResolver.ResolveFolderPath(ctx, doc.ProjectID, folderPath)
 		if err != nil {
 			return nil, err
 		}
@@ -227,20 +269,20 @@ func (s *documentService) UpdateDocument(ctx context.Context, userID, documentID
 	}
 	// If neither provided: keep current folder location
 
-	if req.Content != nil {
-		doc.Content = *req.Content
+	if content, ok := req.Content.Value(); ok {
+		doc.Content = content
 		// Recalculate word count
 		doc.WordCount = s.contentAnalyzer.CountWords(doc.Content)
 	}
 
 	// Check for duplicate name in target folder (if name or folder changed)
-	if req.Name != nil || req.FolderID != nil || req.FolderPath != nil {
+	if req.Name.IsSet() || req.FolderID.IsSet() || req.FolderPath.IsSet() {
 		siblings, err := s.docRepo.ListByFolder(ctx, doc.FolderID, doc.ProjectID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check for duplicate names: %w", err)
 		}
 		for _, sibling := range siblings {
-			if sibling.ID != doc.ID && sibling.Name == doc.Name {
+			if sibling.ID != doc.ID && s.namePolicy.SameName(sibling.Name, doc.Name) {
 				return nil, &domain.ConflictError{
 					Message:      fmt.Sprintf("a document named %q already exists in this folder", doc.Name),
 					ResourceType: "document",
@@ -250,6 +292,12 @@ func (s *documentService) UpdateDocument(ctx context.Context, userID, documentID
 		}
 	}
 
+	if req.Name.IsSet() || req.Content.IsSet() || req.FolderID.IsSet() || req.FolderPath.IsSet() {
+		if err := s.validateSkill(ctx, doc); err != nil {
+			return nil, err
+		}
+	}
+
 	doc.UpdatedAt = time.Now()
 
 	// Update in database
@@ -345,25 +393,45 @@ func (s *documentService) SearchDocuments(ctx context.Context, userID string, re
 		Strategy:  models.SearchStrategyFullText, // Always use fulltext for now
 	}
 
+	// Without an override, search in the project's language (indexed)
+	if req.Language != "" {
+		if err := validateSearchLanguage(ctx, s.settingsRepo, req.Language); err != nil {
+			return nil, err
+		}
+	} else {
+		settings, err := s.settingsRepo.Get(ctx, req.ProjectID, userID)
+		if err != nil {
+			return nil, err
+		}
+		opts.Language = settings.SearchLanguage
+		opts.UseDocumentLanguage = true
+	}
+
 	// Call repository search
 	results, err := s.docRepo.SearchDocuments(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	// Compute paths for all documents (business logic)
+	// Compute paths for all documents (business logic), in one query for the whole page
+	docs := make([]models.Document, len(results.Results))
+	for i := range results.Results {
+		docs[i] = results.Results[i].Document
+	}
+	paths, err := s.docRepo.GetPaths(ctx, req.ProjectID, docs)
+	if err != nil {
+		// Log warning but don't fail the entire search
+		s.logger.Warn("failed to compute paths for search results",
+			"project_id", req.ProjectID,
+			"error", err,
+		)
+	}
 	for i := range results.Results {
 		doc := &results.Results[i].Document
-		path, err := s.docRepo.GetPath(ctx, doc)
-		if err != nil {
-			// Log warning but don't fail the entire search
-			s.logger.Warn("failed to compute path for search result",
-				"doc_id", doc.ID,
-				"error", err,
-			)
-			doc.Path = doc.Name // Fallback to just the name
-		} else {
+		if path, ok := paths[doc.ID]; ok {
 			doc.Path = path
+		} else {
+			doc.Path = doc.Name // Fallback to just the name
 		}
 	}
 
@@ -0,0 +1,81 @@
+package docsystem
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+// documentAnalyticsService implements the DocumentAnalyticsService interface
+type documentAnalyticsService struct {
+	docRepo         docsysRepo.DocumentRepository
+	analyticsRepo   docsysRepo.DocumentAnalyticsRepository
+	contentAnalyzer docsysSvc.ContentAnalyzer
+	authorizer      services.ResourceAuthorizer
+	logger          *slog.Logger
+}
+
+// NewDocumentAnalyticsService creates a new document analytics service
+func NewDocumentAnalyticsService(
+	docRepo docsysRepo.DocumentRepository,
+	analyticsRepo docsysRepo.DocumentAnalyticsRepository,
+	contentAnalyzer docsysSvc.ContentAnalyzer,
+	authorizer services.ResourceAuthorizer,
+	logger *slog.Logger,
+) docsysSvc.DocumentAnalyticsService {
+	return &documentAnalyticsService{
+		docRepo:         docRepo,
+		analyticsRepo:   analyticsRepo,
+		contentAnalyzer: contentAnalyzer,
+		authorizer:      authorizer,
+		logger:          logger,
+	}
+}
+
+// GetDocumentAnalytics serves cached analytics while the content hash matches. Otherwise
+// they are recomputed and cached; a failed cache write doesn't fail the request.
+func (s *documentAnalyticsService) GetDocumentAnalytics(ctx context.Context, userID, documentID string) (*models.DocumentAnalytics, error) {
+	if err := s.authorizer.CanAccessDocument(ctx, userID, documentID); err != nil {
+		return nil, err
+	}
+
+	doc, err := s.docRepo.GetByIDOnly(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+	contentHash := doc.ContentHash
+	if contentHash == "" {
+		contentHash = models.ContentHash(doc.Content)
+	}
+
+	cached, err := s.analyticsRepo.GetAnalytics(ctx, documentID)
+	switch {
+	case err == nil && cached.ContentHash == contentHash:
+		return cached, nil
+	case err != nil && !errors.Is(err, domain.ErrNotFound):
+		s.logger.Warn("failed to read cached document analytics", "document_id", documentID, "error", err)
+	}
+
+	analytics := computeAnalytics(s.contentAnalyzer, doc.Content)
+	analytics.DocumentID = documentID
+	analytics.ContentHash = contentHash
+	analytics.ComputedAt = time.Now().UTC()
+
+	if err := s.analyticsRepo.SaveAnalytics(ctx, &analytics); err != nil {
+		s.logger.Warn("failed to cache document analytics", "document_id", documentID, "error", err)
+	}
+
+	s.logger.Debug("document analytics computed",
+		"document_id", documentID,
+		"words", analytics.WordCount,
+		"pace", analytics.Pace,
+	)
+	return &analytics, nil
+}
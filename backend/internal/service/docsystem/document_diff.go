@@ -0,0 +1,114 @@
+package docsystem
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+// documentDiffService implements the DocumentDiffService interface
+type documentDiffService struct {
+	docRepo        docsysRepo.DocumentRepository
+	snapshotRepo   docsysRepo.SnapshotRepository
+	suggestionRepo docsysRepo.SuggestionRepository
+	authorizer     services.ResourceAuthorizer
+	logger         *slog.Logger
+}
+
+// NewDocumentDiffService creates a new document diff service
+func NewDocumentDiffService(
+	docRepo docsysRepo.DocumentRepository,
+	snapshotRepo docsysRepo.SnapshotRepository,
+	suggestionRepo docsysRepo.SuggestionRepository,
+	authorizer services.ResourceAuthorizer,
+	logger *slog.Logger,
+) docsysSvc.DocumentDiffService {
+	return &documentDiffService{
+		docRepo:        docRepo,
+		snapshotRepo:   snapshotRepo,
+		suggestionRepo: suggestionRepo,
+		authorizer:     authorizer,
+		logger:         logger,
+	}
+}
+
+// DiffDocument returns the line diff from one version of a document to another
+func (s *documentDiffService) DiffDocument(ctx context.Context, userID, documentID, from, to string) (*models.DocumentDiff, error) {
+	if from == "" {
+		return nil, fmt.Errorf("%w: from is required", domain.ErrValidation)
+	}
+	if to == "" {
+		to = docsysSvc.DocumentVersionCurrent
+	}
+
+	if err := s.authorizer.CanAccessDocument(ctx, userID, documentID); err != nil {
+		return nil, err
+	}
+
+	doc, err := s.docRepo.GetByIDOnly(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.versionContent(ctx, doc, from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.versionContent(ctx, doc, to)
+	if err != nil {
+		return nil, err
+	}
+
+	hunks, added, removed := DiffHunks(before, after)
+	return &models.DocumentDiff{
+		DocumentID:   documentID,
+		From:         from,
+		To:           to,
+		LinesAdded:   added,
+		LinesRemoved: removed,
+		Hunks:        hunks,
+	}, nil
+}
+
+// versionContent resolves a version spec to the document's content at that version
+func (s *documentDiffService) versionContent(ctx context.Context, doc *models.Document, version string) (string, error) {
+	if version == docsysSvc.DocumentVersionCurrent {
+		return doc.Content, nil
+	}
+
+	if suggestionID, ok := strings.CutPrefix(version, docsysSvc.DocumentVersionSuggestionPrefix); ok && suggestionID != "" {
+		suggestion, err := s.suggestionRepo.GetSuggestion(ctx, suggestionID, doc.ID)
+		if err != nil {
+			return "", err
+		}
+		content, ok := suggestion.Apply(doc.Content)
+		if !ok {
+			return "", &domain.ConflictError{
+				Message:      "the text this suggestion rewrites has changed",
+				ResourceType: "suggestion",
+				ResourceID:   suggestionID,
+			}
+		}
+		return content, nil
+	}
+
+	snapshotID, ok := strings.CutPrefix(version, docsysSvc.DocumentVersionSnapshotPrefix)
+	if !ok || snapshotID == "" {
+		return "", fmt.Errorf("%w: invalid version %q (use %q, %q or %q)", domain.ErrValidation,
+			version, docsysSvc.DocumentVersionCurrent, docsysSvc.DocumentVersionSnapshotPrefix+"<id>",
+			docsysSvc.DocumentVersionSuggestionPrefix+"<id>")
+	}
+
+	// Scope the snapshot to the document's project
+	if _, err := s.snapshotRepo.Get(ctx, snapshotID, doc.ProjectID); err != nil {
+		return "", err
+	}
+	return s.snapshotRepo.GetDocumentContent(ctx, snapshotID, doc.ID)
+}
@@ -0,0 +1,348 @@
+package docsystem
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"sort"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+const (
+	// defaultDuplicateThreshold and minDuplicateThreshold bound FindDuplicatesRequest.Threshold
+	defaultDuplicateThreshold = 0.8
+	minDuplicateThreshold     = 0.5
+
+	// minDuplicateParagraphWords leaves out headings and short lines ("Yes.", scene
+	// breaks), which repeat legitimately
+	minDuplicateParagraphWords = 12
+
+	// shingleWords is the length of the word sequences paragraphs are compared by
+	shingleWords = 3
+
+	// minHashBands × minHashRows hash functions make up a paragraph's signature. Pairs
+	// whose signatures agree on every row of some band become candidates; with 16 bands
+	// of 4 rows, pairs at 0.5 similarity are found about half the time and pairs at
+	// 0.8 practically always.
+	minHashBands = 16
+	minHashRows  = 4
+
+	// maxDuplicateGroups bounds DuplicateReport.Groups
+	maxDuplicateGroups = 200
+
+	// duplicateExcerptLength is the length (in runes) of DuplicatePassage.Excerpt
+	duplicateExcerptLength = 160
+)
+
+// minHashSeeds holds one seed per signature row, fixed so signatures are reproducible
+var minHashSeeds = func() [minHashBands * minHashRows]uint64 {
+	var seeds [minHashBands * minHashRows]uint64
+	for i := range seeds {
+		seeds[i] = mix64(uint64(i) + 1)
+	}
+	return seeds
+}()
+
+// minHashSignature is the minimum of each seeded hash over a paragraph's shingles
+type minHashSignature [minHashBands * minHashRows]uint64
+
+// bandKey is the LSH bucket of one band of a signature
+type bandKey struct {
+	band int
+	rows [minHashRows]uint64
+}
+
+// proseParagraph is one paragraph of a document, as found by splitParagraphs
+type proseParagraph struct {
+	index              int // 0-based, counting paragraphs with words only
+	startLine, endLine int // 1-based, inclusive
+	words              []string
+	text               string // Cleaned text, lines joined by spaces
+}
+
+// duplicateService implements the DuplicateService interface
+type duplicateService struct {
+	docRepo         docsysRepo.DocumentRepository
+	contentAnalyzer docsysSvc.ContentAnalyzer
+	authorizer      services.ResourceAuthorizer
+	logger          *slog.Logger
+}
+
+// NewDuplicateService creates a new duplicate detection service
+func NewDuplicateService(
+	docRepo docsysRepo.DocumentRepository,
+	contentAnalyzer docsysSvc.ContentAnalyzer,
+	authorizer services.ResourceAuthorizer,
+	logger *slog.Logger,
+) docsysSvc.DuplicateService {
+	return &duplicateService{
+		docRepo:         docRepo,
+		contentAnalyzer: contentAnalyzer,
+		authorizer:      authorizer,
+		logger:          logger,
+	}
+}
+
+// FindDuplicates reads the project's documents one at a time, keeping only a MinHash
+// signature and the location of each paragraph, then groups the paragraphs whose
+// estimated similarity reaches the threshold
+func (s *duplicateService) FindDuplicates(ctx context.Context, userID, projectID string, req *docsysSvc.FindDuplicatesRequest) (*models.DuplicateReport, error) {
+	if err := validation.ValidateStruct(req,
+		validation.Field(&req.Threshold, validation.Min(minDuplicateThreshold), validation.Max(1.0)),
+	); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrValidation, err)
+	}
+	threshold := req.Threshold
+	if threshold == 0 {
+		threshold = defaultDuplicateThreshold
+	}
+
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	documents, err := s.docRepo.GetAllMetadataByProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get documents: %w", err)
+	}
+	paths, err := s.docRepo.GetPaths(ctx, projectID, documents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute document paths: %w", err)
+	}
+
+	var passages []models.DuplicatePassage
+	var signatures []minHashSignature
+	for _, meta := range documents {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		doc, err := s.docRepo.GetByID(ctx, meta.ID, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("load document %s: %w", meta.ID, err)
+		}
+		for _, p := range splitParagraphs(s.contentAnalyzer, doc.Content) {
+			if len(p.words) < minDuplicateParagraphWords {
+				continue
+			}
+			passages = append(passages, models.DuplicatePassage{
+				DocumentID:   doc.ID,
+				DocumentPath: paths[doc.ID],
+				Paragraph:    p.index,
+				StartLine:    p.startLine,
+				EndLine:      p.endLine,
+				Words:        len(p.words),
+				Excerpt:      excerpt(p.text, duplicateExcerptLength),
+			})
+			signatures = append(signatures, signParagraph(p.words))
+		}
+	}
+
+	groups := groupDuplicates(passages, signatures, threshold)
+	report := &models.DuplicateReport{
+		ProjectID:         projectID,
+		Threshold:         threshold,
+		DocumentsScanned:  len(documents),
+		ParagraphsScanned: len(passages),
+		Groups:            groups,
+	}
+	if len(groups) > maxDuplicateGroups {
+		report.Groups = groups[:maxDuplicateGroups]
+		report.Truncated = true
+	}
+
+	s.logger.Info("duplicate scan finished",
+		"project_id", projectID,
+		"documents", len(documents),
+		"paragraphs", len(passages),
+		"groups", len(groups),
+	)
+	return report, nil
+}
+
+// groupDuplicates links candidate pairs from the LSH buckets whose estimated similarity
+// reaches the threshold, and returns the connected groups ordered by first passage.
+// Pairs already in one group aren't compared again.
+func groupDuplicates(passages []models.DuplicatePassage, signatures []minHashSignature, threshold float64) []models.DuplicateGroup {
+	buckets := make(map[bandKey][]int)
+	for i, sig := range signatures {
+		for band := 0; band < minHashBands; band++ {
+			key := bandKey{band: band}
+			copy(key.rows[:], sig[band*minHashRows:(band+1)*minHashRows])
+			buckets[key] = append(buckets[key], i)
+		}
+	}
+
+	parent := make([]int, len(passages))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	similarity := make(map[int]float64) // Group root → lowest linking similarity
+
+	for _, members := range buckets {
+		for a := 0; a < len(members); a++ {
+			for b := a + 1; b < len(members); b++ {
+				i, j := members[a], members[b]
+				ri, rj := find(i), find(j)
+				if ri == rj {
+					continue
+				}
+				sim := signatureSimilarity(&signatures[i], &signatures[j])
+				if sim < threshold {
+					continue
+				}
+				lowest := sim
+				for _, root := range []int{ri, rj} {
+					if prev, ok := similarity[root]; ok && prev < lowest {
+						lowest = prev
+					}
+					delete(similarity, root)
+				}
+				parent[rj] = ri
+				similarity[ri] = lowest
+			}
+		}
+	}
+
+	members := make(map[int][]models.DuplicatePassage)
+	for i := range passages {
+		root := find(i)
+		if _, linked := similarity[root]; linked {
+			members[root] = append(members[root], passages[i])
+		}
+	}
+
+	groups := make([]models.DuplicateGroup, 0, len(similarity))
+	for root, sim := range similarity {
+		group := members[root]
+		sort.Slice(group, func(a, b int) bool { return passageLess(group[a], group[b]) })
+		groups = append(groups, models.DuplicateGroup{Similarity: round2(sim), Passages: group})
+	}
+	sort.Slice(groups, func(a, b int) bool { return passageLess(groups[a].Passages[0], groups[b].Passages[0]) })
+	return groups
+}
+
+func passageLess(a, b models.DuplicatePassage) bool {
+	if a.DocumentPath != b.DocumentPath {
+		return a.DocumentPath < b.DocumentPath
+	}
+	return a.StartLine < b.StartLine
+}
+
+// signParagraph computes the MinHash signature of a paragraph's word shingles
+func signParagraph(words []string) minHashSignature {
+	var sig minHashSignature
+	for i := range sig {
+		sig[i] = ^uint64(0)
+	}
+	for start := 0; start+shingleWords <= len(words); start++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(words[start:start+shingleWords], " ")))
+		shingle := h.Sum64()
+		for i, seed := range minHashSeeds {
+			if v := mix64(shingle ^ seed); v < sig[i] {
+				sig[i] = v
+			}
+		}
+	}
+	return sig
+}
+
+// signatureSimilarity estimates the Jaccard similarity of two paragraphs' shingles
+func signatureSimilarity(a, b *minHashSignature) float64 {
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a))
+}
+
+// mix64 is the splitmix64 finalizer, used to derive independent hash functions
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// wordCollector collects the words of prose, ignoring sentences and dialogue
+type wordCollector struct {
+	words []string
+}
+
+func (c *wordCollector) word(word string, _ bool) { c.words = append(c.words, word) }
+func (c *wordCollector) endSentence()             {}
+func (c *wordCollector) endParagraph()            {}
+
+// splitParagraphs splits markdown into paragraphs with their line ranges, by the same
+// rules as scanProse: code blocks are skipped and a blank line ends a paragraph
+func splitParagraphs(analyzer docsysSvc.ContentAnalyzer, markdown string) []proseParagraph {
+	var paragraphs []proseParagraph
+	var current *proseParagraph
+	var text []string
+	closeParagraph := func() {
+		if current != nil {
+			current.text = strings.Join(text, " ")
+			paragraphs = append(paragraphs, *current)
+			current, text = nil, nil
+		}
+	}
+
+	inFence := false
+	for i, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if trimmed == "" {
+			closeParagraph()
+			continue
+		}
+
+		cleaned := analyzer.CleanMarkdown(line)
+		collector := &wordCollector{}
+		if !scanLine(cleaned, collector) {
+			continue
+		}
+		if current == nil {
+			current = &proseParagraph{index: len(paragraphs), startLine: i + 1}
+		}
+		current.endLine = i + 1
+		current.words = append(current.words, collector.words...)
+		text = append(text, strings.TrimSpace(cleaned))
+	}
+	closeParagraph()
+	return paragraphs
+}
+
+// excerpt returns the first length runes of text, marking a cut with "…"
+func excerpt(text string, length int) string {
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:length])) + "…"
+}
@@ -0,0 +1,132 @@
+package docsystem
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/testmocks"
+)
+
+const (
+	duplicatedParagraph = "The lighthouse keeper climbed the spiral stairs every evening at dusk, counting each " +
+		"iron step aloud so that the silence of the tower would not swallow him whole."
+	// The same paragraph after a light edit
+	editedParagraph = "The lighthouse keeper climbed the spiral stairs every evening at dusk, counting each " +
+		"iron step aloud so that the silence of the tower would not swallow him entirely."
+	otherParagraph = "Down in the harbor the fishing boats rocked against their moorings while gulls argued " +
+		"over scraps of bait that the morning crews had left behind on the pier."
+)
+
+// Chapter 1 and Chapter 2 share a paragraph, Chapter 1 repeats a lightly edited copy of
+// it, and the notes are unrelated. Unused interface methods panic.
+type fakeDuplicateDocRepo struct{ docsysRepo.DocumentRepository }
+
+func (fakeDuplicateDocRepo) GetAllMetadataByProject(ctx context.Context, projectID string) ([]models.Document, error) {
+	return []models.Document{{ID: "ch2"}, {ID: "ch1"}, {ID: "notes"}}, nil
+}
+
+func (fakeDuplicateDocRepo) GetPaths(ctx context.Context, projectID string, docs []models.Document) (map[string]string, error) {
+	return map[string]string{"ch1": "Book/Chapter 1", "ch2": "Book/Chapter 2", "notes": "notes"}, nil
+}
+
+func (fakeDuplicateDocRepo) GetByID(ctx context.Context, id, projectID string) (*models.Document, error) {
+	content := map[string]string{
+		"ch1":   "# Chapter 1\n\n" + duplicatedParagraph + "\n\n" + otherParagraph + "\n\n" + editedParagraph,
+		"ch2":   "# Chapter 2\n\nShort line.\n\n" + duplicatedParagraph,
+		"notes": "```\n" + duplicatedParagraph + "\n```\n\nNothing to see here at all, only a few notes about the plot and the cast.",
+	}[id]
+	return &models.Document{ID: id, Content: content}, nil
+}
+
+func newTestDuplicateService(authorizer *testmocks.Authorizer) docsysSvc.DuplicateService {
+	return NewDuplicateService(fakeDuplicateDocRepo{}, NewContentAnalyzer(), authorizer, slog.New(slog.DiscardHandler))
+}
+
+func TestFindDuplicates(t *testing.T) {
+	service := newTestDuplicateService(&testmocks.Authorizer{})
+
+	report, err := service.FindDuplicates(context.Background(), "user-1", "project-1", &docsysSvc.FindDuplicatesRequest{})
+	if err != nil {
+		t.Fatalf("FindDuplicates: %v", err)
+	}
+
+	if report.Threshold != defaultDuplicateThreshold || report.DocumentsScanned != 3 || report.ParagraphsScanned != 5 {
+		t.Errorf("report = %+v", report)
+	}
+	if len(report.Groups) != 1 {
+		t.Fatalf("groups = %+v, want 1", report.Groups)
+	}
+	group := report.Groups[0]
+	if group.Similarity < defaultDuplicateThreshold || group.Similarity >= 1 {
+		t.Errorf("similarity = %v", group.Similarity)
+	}
+
+	type location struct {
+		path                 string
+		paragraph, startLine int
+	}
+	var got []location
+	for _, p := range group.Passages {
+		got = append(got, location{p.DocumentPath, p.Paragraph, p.StartLine})
+	}
+	want := []location{{"Book/Chapter 1", 1, 3}, {"Book/Chapter 1", 3, 7}, {"Book/Chapter 2", 2, 5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("passages = %+v, want %+v", got, want)
+	}
+	if p := group.Passages[0]; p.Words != 28 || len([]rune(p.Excerpt)) != duplicateExcerptLength+1 {
+		t.Errorf("passage = %+v", p)
+	}
+}
+
+func TestFindDuplicates_Threshold(t *testing.T) {
+	service := newTestDuplicateService(&testmocks.Authorizer{})
+
+	// Only the exact copies reach a threshold of 1
+	report, err := service.FindDuplicates(context.Background(), "user-1", "project-1", &docsysSvc.FindDuplicatesRequest{Threshold: 1})
+	if err != nil {
+		t.Fatalf("FindDuplicates: %v", err)
+	}
+	if len(report.Groups) != 1 || len(report.Groups[0].Passages) != 2 || report.Groups[0].Similarity != 1 {
+		t.Errorf("groups = %+v", report.Groups)
+	}
+
+	for _, threshold := range []float64{0.3, 1.5} {
+		_, err := service.FindDuplicates(context.Background(), "user-1", "project-1", &docsysSvc.FindDuplicatesRequest{Threshold: threshold})
+		if !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("threshold %v: err = %v, want ErrValidation", threshold, err)
+		}
+	}
+}
+
+func TestFindDuplicates_Forbidden(t *testing.T) {
+	service := newTestDuplicateService(&testmocks.Authorizer{Err: domain.ErrForbidden})
+
+	_, err := service.FindDuplicates(context.Background(), "user-1", "project-1", &docsysSvc.FindDuplicatesRequest{})
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("err = %v, want ErrForbidden", err)
+	}
+}
+
+func TestSplitParagraphs(t *testing.T) {
+	markdown := "# Title\n\nFirst line\nof a *paragraph*.\n\n```\ncode\n```\n\n---\n\nLast."
+
+	paragraphs := splitParagraphs(NewContentAnalyzer(), markdown)
+
+	if len(paragraphs) != 3 {
+		t.Fatalf("paragraphs = %+v, want 3", paragraphs)
+	}
+	p := paragraphs[1]
+	if p.index != 1 || p.startLine != 3 || p.endLine != 4 || p.text != "First line of a paragraph." || len(p.words) != 5 {
+		t.Errorf("paragraph = %+v", p)
+	}
+	if last := paragraphs[2]; last.startLine != 12 || last.index != 2 {
+		t.Errorf("last paragraph = %+v", last)
+	}
+}
@@ -0,0 +1,433 @@
+package docsystem
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"meridian/internal/config"
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/service/docsystem/export"
+	"meridian/internal/utils"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	// maxConcurrentExports bounds background exports; further jobs wait their turn
+	maxConcurrentExports = 2
+
+	// exportTimeout bounds one export, including the PDF renderer
+	exportTimeout = 10 * time.Minute
+)
+
+// exportService implements the ExportService interface
+type exportService struct {
+	exportRepo  docsysRepo.ExportJobRepository
+	projectRepo docsysRepo.ProjectRepository
+	folderRepo  docsysRepo.FolderRepository
+	docRepo     docsysRepo.DocumentRepository
+	pdfRenderer docsysSvc.PDFRenderer // nil = PDF export disabled
+	objectStore services.ObjectStore  // Holds finished files
+	urlTTL      time.Duration         // Lifetime of signed download URLs
+	authorizer  services.ResourceAuthorizer
+	logger      *slog.Logger
+	slots       chan struct{}
+}
+
+// NewExportService creates a new export service.
+// pdfRenderer may be nil, in which case only EPUB exports are accepted.
+// Finished files go to objectStore; urlTTL is how long a job's download_url stays valid.
+func NewExportService(
+	exportRepo docsysRepo.ExportJobRepository,
+	projectRepo docsysRepo.ProjectRepository,
+	folderRepo docsysRepo.FolderRepository,
+	docRepo docsysRepo.DocumentRepository,
+	pdfRenderer docsysSvc.PDFRenderer,
+	objectStore services.ObjectStore,
+	urlTTL time.Duration,
+	authorizer services.ResourceAuthorizer,
+	logger *slog.Logger,
+) docsysSvc.ExportService {
+	return &exportService{
+		exportRepo:  exportRepo,
+		projectRepo: projectRepo,
+		folderRepo:  folderRepo,
+		docRepo:     docRepo,
+		pdfRenderer: pdfRenderer,
+		objectStore: objectStore,
+		urlTTL:      urlTTL,
+		authorizer:  authorizer,
+		logger:      logger,
+		slots:       make(chan struct{}, maxConcurrentExports),
+	}
+}
+
+// StartExport validates the request, creates a pending job and starts it in the background
+func (s *exportService) StartExport(ctx context.Context, userID, projectID string, req *docsysSvc.CreateExportRequest) (*models.ExportJob, error) {
+	if req.Format == "" {
+		req.Format = models.ExportFormatEPUB
+	}
+	if req.Language == "" {
+		req.Language = "en"
+	}
+	req.Title = strings.TrimSpace(req.Title)
+	req.Author = strings.TrimSpace(req.Author)
+
+	if err := s.validateCreateRequest(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrValidation, err)
+	}
+	if req.Format == models.ExportFormatPDF && s.pdfRenderer == nil {
+		return nil, fmt.Errorf("%w: PDF export is not enabled on this server", domain.ErrValidation)
+	}
+
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	// Selected folders must belong to the project
+	seen := make(map[string]bool, len(req.FolderIDs))
+	for _, folderID := range req.FolderIDs {
+		if seen[folderID] {
+			return nil, fmt.Errorf("%w: folder %s is listed twice", domain.ErrValidation, folderID)
+		}
+		seen[folderID] = true
+		if _, err := s.folderRepo.GetByID(ctx, folderID, projectID); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Title == "" {
+		project, err := s.projectRepo.GetByID(ctx, projectID, userID)
+		if err != nil {
+			return nil, err
+		}
+		req.Title = project.Name
+	}
+
+	job := &models.ExportJob{
+		ProjectID: projectID,
+		UserID:    userID,
+		Format:    req.Format,
+		FolderIDs: req.FolderIDs,
+		Title:     req.Title,
+		Author:    req.Author,
+		Language:  req.Language,
+	}
+	if err := s.exportRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("export started",
+		"id", job.ID,
+		"project_id", projectID,
+		"format", job.Format,
+		"folders", len(job.FolderIDs),
+	)
+
+	go s.run(*job)
+
+	return job, nil
+}
+
+// GetExport returns a job's status, with a signed download URL once it has completed
+func (s *exportService) GetExport(ctx context.Context, userID, jobID string) (*models.ExportJob, error) {
+	job, err := s.getAuthorizedJob(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status == models.ExportStatusCompleted && job.StorageKey != nil {
+		downloadURL, err := s.objectStore.SignedURL(ctx, *job.StorageKey, s.urlTTL)
+		if err != nil {
+			// The download endpoint still works; don't fail the status poll
+			s.logger.Warn("failed to sign export download URL", "id", job.ID, "error", err)
+		} else {
+			job.DownloadURL = downloadURL
+		}
+	}
+	return job, nil
+}
+
+// DownloadExport returns a completed job's file
+func (s *exportService) DownloadExport(ctx context.Context, userID, jobID string) (*models.ExportFile, error) {
+	if _, err := s.getAuthorizedJob(ctx, userID, jobID); err != nil {
+		return nil, err
+	}
+	file, err := s.exportRepo.GetFile(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if file.StorageKey == "" {
+		return file, nil // Completed before files moved to object storage
+	}
+
+	object, err := s.objectStore.Get(ctx, file.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+	file.Data = object.Data
+	return file, nil
+}
+
+// getAuthorizedJob loads a job and checks the user can access its project
+func (s *exportService) getAuthorizedJob(ctx context.Context, userID, jobID string) (*models.ExportJob, error) {
+	job, err := s.exportRepo.Get(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizer.CanAccessProject(ctx, userID, job.ProjectID); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// FailInterrupted fails jobs left pending or running by a previous process
+func (s *exportService) FailInterrupted(ctx context.Context) error {
+	failed, err := s.exportRepo.FailUnfinished(ctx, "export was interrupted by a server restart; please start it again")
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		s.logger.Warn("failed interrupted export jobs", "count", failed)
+	}
+	return nil
+}
+
+// run builds a job's file and stores the outcome on the job
+func (s *exportService) run(job models.ExportJob) {
+	s.slots <- struct{}{}
+	defer func() { <-s.slots }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+
+	if err := s.exportRepo.MarkRunning(ctx, job.ID); err != nil {
+		s.logger.Error("failed to start export", "id", job.ID, "error", err)
+		return
+	}
+
+	file, err := s.buildFile(ctx, &job)
+	if err == nil {
+		err = s.storeFile(ctx, &job, file)
+	}
+	if err != nil {
+		s.logger.Error("export failed", "id", job.ID, "project_id", job.ProjectID, "error", err)
+		if failErr := s.exportRepo.Fail(context.Background(), job.ID, err.Error()); failErr != nil {
+			s.logger.Error("failed to record export failure", "id", job.ID, "error", failErr)
+		}
+		return
+	}
+
+	s.logger.Info("export completed",
+		"id", job.ID,
+		"project_id", job.ProjectID,
+		"file", file.FileName,
+		"bytes", len(file.Data),
+	)
+}
+
+// storeFile uploads the file to object storage and completes the job. The object is
+// removed again if the job can't be completed, so failed jobs don't leave files behind.
+func (s *exportService) storeFile(ctx context.Context, job *models.ExportJob, file *models.ExportFile) error {
+	file.StorageKey = exportStorageKey(job, file.FileName)
+	if err := s.objectStore.Put(ctx, file.StorageKey, file.ContentType, file.Data); err != nil {
+		return fmt.Errorf("store export file: %w", err)
+	}
+
+	if err := s.exportRepo.Complete(ctx, job.ID, file); err != nil {
+		if delErr := s.objectStore.Delete(context.Background(), file.StorageKey); delErr != nil {
+			s.logger.Warn("failed to remove export file", "key", file.StorageKey, "error", delErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// exportStorageKey is where a job's file is stored: exports/{project_id}/{job_id}/{file_name}
+func exportStorageKey(job *models.ExportJob, fileName string) string {
+	return "exports/" + job.ProjectID + "/" + job.ID + "/" + fileName
+}
+
+// buildFile compiles the job's folders into an EPUB, then a PDF if requested
+func (s *exportService) buildFile(ctx context.Context, job *models.ExportJob) (*models.ExportFile, error) {
+	sections, err := s.collectSections(ctx, job.ProjectID, job.FolderIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	book := &export.Book{
+		Identifier: "urn:uuid:" + job.ID,
+		Title:      job.Title,
+		Author:     job.Author,
+		Language:   job.Language,
+		Modified:   time.Now(),
+		Sections:   sections,
+	}
+	var epub bytes.Buffer
+	if err := export.WriteEPUB(&epub, book); err != nil {
+		return nil, fmt.Errorf("build EPUB: %w", err)
+	}
+
+	baseName := utils.Slugify(job.Title, config.MaxSlugLength)
+	if baseName == "" {
+		baseName = "export"
+	}
+
+	if job.Format == models.ExportFormatPDF {
+		if s.pdfRenderer == nil {
+			return nil, fmt.Errorf("PDF export is not enabled on this server")
+		}
+		pdf, err := s.pdfRenderer.RenderPDF(ctx, epub.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		return &models.ExportFile{FileName: baseName + ".pdf", ContentType: "application/pdf", Data: pdf}, nil
+	}
+
+	return &models.ExportFile{FileName: baseName + ".epub", ContentType: "application/epub+zip", Data: epub.Bytes()}, nil
+}
+
+// collectSections builds the reading order: the selected folders in the order given
+// (or the whole project), each with its documents first, then its subfolders, both in
+// natural name order ("Chapter 2" before "Chapter 10"). Empty folders are left out.
+func (s *exportService) collectSections(ctx context.Context, projectID string, folderIDs []string) ([]export.Section, error) {
+	folders, err := s.folderRepo.GetAllByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := s.docRepo.GetAllMetadataByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	foldersByID := make(map[string]models.Folder, len(folders))
+	childFolders := make(map[string][]models.Folder) // Parent ID ("" = root) → folders
+	for _, folder := range folders {
+		foldersByID[folder.ID] = folder
+		parent := ""
+		if folder.ParentID != nil {
+			parent = *folder.ParentID
+		}
+		childFolders[parent] = append(childFolders[parent], folder)
+	}
+	childDocs := make(map[string][]models.Document) // Folder ID ("" = root) → documents
+	for _, doc := range documents {
+		parent := ""
+		if doc.FolderID != nil {
+			parent = *doc.FolderID
+		}
+		childDocs[parent] = append(childDocs[parent], doc)
+	}
+
+	var build func(folderID string) ([]export.Section, error)
+	build = func(folderID string) ([]export.Section, error) {
+		docs := childDocs[folderID]
+		sort.SliceStable(docs, func(i, j int) bool { return naturalLess(docs[i].Name, docs[j].Name) })
+		subfolders := childFolders[folderID]
+		sort.SliceStable(subfolders, func(i, j int) bool { return naturalLess(subfolders[i].Name, subfolders[j].Name) })
+
+		sections := make([]export.Section, 0, len(docs)+len(subfolders))
+		for _, doc := range docs {
+			full, err := s.docRepo.GetByID(ctx, doc.ID, projectID)
+			if err != nil {
+				return nil, err
+			}
+			sections = append(sections, export.Section{Title: full.Name, Markdown: full.Content})
+		}
+		for _, folder := range subfolders {
+			children, err := build(folder.ID)
+			if err != nil {
+				return nil, err
+			}
+			if len(children) > 0 {
+				sections = append(sections, export.Section{Title: folder.Name, Children: children})
+			}
+		}
+		return sections, nil
+	}
+
+	var sections []export.Section
+	if len(folderIDs) == 0 {
+		sections, err = build("")
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, folderID := range folderIDs {
+		folder, ok := foldersByID[folderID]
+		if !ok {
+			return nil, fmt.Errorf("folder %s was deleted before the export ran", folderID)
+		}
+		children, err := build(folderID)
+		if err != nil {
+			return nil, err
+		}
+		if len(children) > 0 {
+			sections = append(sections, export.Section{Title: folder.Name, Children: children})
+		}
+	}
+
+	if len(sections) == 0 {
+		return nil, fmt.Errorf("nothing to export: no documents in the selected folders")
+	}
+	return sections, nil
+}
+
+func (s *exportService) validateCreateRequest(req *docsysSvc.CreateExportRequest) error {
+	return validation.ValidateStruct(req,
+		validation.Field(&req.Format, validation.In(models.ExportFormatEPUB, models.ExportFormatPDF)),
+		validation.Field(&req.FolderIDs, validation.Length(0, config.MaxExportFolders)),
+		validation.Field(&req.Title, validation.RuneLength(0, config.MaxProjectNameLength)),
+		validation.Field(&req.Author, validation.RuneLength(0, config.MaxProjectNameLength)),
+		validation.Field(&req.Language, validation.RuneLength(2, 35)),
+	)
+}
+
+// naturalLess orders names case-insensitively with digit runs compared by value,
+// so "Chapter 2" sorts before "Chapter 10"
+func naturalLess(a, b string) bool {
+	ar, br := []rune(strings.ToLower(a)), []rune(strings.ToLower(b))
+	i, j := 0, 0
+	for i < len(ar) && j < len(br) {
+		if isDigit(ar[i]) && isDigit(br[j]) {
+			si, sj := i, j
+			for i < len(ar) && isDigit(ar[i]) {
+				i++
+			}
+			for j < len(br) && isDigit(br[j]) {
+				j++
+			}
+			na := strings.TrimLeft(string(ar[si:i]), "0")
+			nb := strings.TrimLeft(string(br[sj:j]), "0")
+			if len(na) != len(nb) {
+				return len(na) < len(nb)
+			}
+			if na != nb {
+				return na < nb
+			}
+			continue
+		}
+		if ar[i] != br[j] {
+			return ar[i] < br[j]
+		}
+		i++
+		j++
+	}
+	if len(ar)-i != len(br)-j {
+		return len(ar)-i < len(br)-j
+	}
+	return a < b
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
@@ -0,0 +1,225 @@
+package export
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Book is the input of an EPUB build
+type Book struct {
+	Identifier string // Unique ID, e.g. "urn:uuid:<job id>"
+	Title      string
+	Author     string
+	Language   string // BCP 47 tag, e.g. "en"
+	Modified   time.Time
+	Sections   []Section // Reading order
+}
+
+// Section is a chapter (a document) or, when it has children, a part (a folder).
+// A part gets a title page; a chapter's Markdown is rendered as its body.
+type Section struct {
+	Title    string
+	Markdown string
+	Children []Section
+}
+
+// spineItem is one XHTML file in reading order
+type spineItem struct {
+	id    string
+	title string
+	body  string
+}
+
+const stylesheet = `body { font-family: serif; line-height: 1.5; margin: 0 5%; }
+h1, h2, h3 { font-family: sans-serif; line-height: 1.2; }
+h1.part { margin-top: 30%; text-align: center; }
+blockquote { margin: 1em 2em; font-style: italic; }
+pre { white-space: pre-wrap; font-size: 0.9em; }
+hr { border: none; text-align: center; }
+hr::after { content: "* * *"; }
+`
+
+// WriteEPUB writes book as an EPUB 3 file (with an NCX table of contents for
+// EPUB 2 readers)
+func WriteEPUB(w io.Writer, book *Book) error {
+	var items []spineItem
+	flattenSections(book.Sections, &items)
+	if len(items) == 0 {
+		return fmt.Errorf("nothing to export")
+	}
+
+	archive := zip.NewWriter(w)
+
+	// The mimetype entry must come first and be stored uncompressed
+	mimetype, err := archive.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(mimetype, "application/epub+zip"); err != nil {
+		return err
+	}
+
+	files := []struct{ name, content string }{
+		{"META-INF/container.xml", containerXML},
+		{"OEBPS/style.css", stylesheet},
+		{"OEBPS/content.opf", packageDocument(book, items)},
+		{"OEBPS/nav.xhtml", navDocument(book)},
+		{"OEBPS/toc.ncx", ncxDocument(book)},
+	}
+	for _, item := range items {
+		files = append(files, struct{ name, content string }{"OEBPS/" + item.id + ".xhtml", chapterDocument(book, item)})
+	}
+
+	for _, file := range files {
+		writer, err := archive.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(writer, file.content); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// flattenSections lists sections depth-first, which is the reading order
+func flattenSections(sections []Section, items *[]spineItem) {
+	for _, section := range sections {
+		item := spineItem{
+			id:    sectionID(len(*items) + 1),
+			title: section.Title,
+		}
+		if len(section.Children) > 0 {
+			item.body = `<h1 class="part">` + escape(section.Title) + "</h1>\n"
+		} else {
+			item.body = chapterBody(section)
+		}
+		*items = append(*items, item)
+		flattenSections(section.Children, items)
+	}
+}
+
+// chapterBody renders a chapter, adding its title unless the content opens with a heading
+func chapterBody(section Section) string {
+	body := MarkdownToXHTML(section.Markdown)
+	if !strings.HasPrefix(body, "<h1>") {
+		body = "<h1>" + escape(section.Title) + "</h1>\n" + body
+	}
+	return body
+}
+
+const containerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+func packageDocument(book *Book, items []spineItem) string {
+	var manifest, spine strings.Builder
+	for _, item := range items {
+		fmt.Fprintf(&manifest, "    <item id=\"%s\" href=\"%s.xhtml\" media-type=\"application/xhtml+xml\"/>\n", item.id, item.id)
+		fmt.Fprintf(&spine, "    <itemref idref=\"%s\"/>\n", item.id)
+	}
+
+	creator := ""
+	if book.Author != "" {
+		creator = "\n    <dc:creator>" + escape(book.Author) + "</dc:creator>"
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id" xml:lang="%[3]s">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="book-id">%[1]s</dc:identifier>
+    <dc:title>%[2]s</dc:title>
+    <dc:language>%[3]s</dc:language>%[4]s
+    <meta property="dcterms:modified">%[5]s</meta>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>
+    <item id="style" href="style.css" media-type="text/css"/>
+%[6]s  </manifest>
+  <spine toc="ncx">
+%[7]s  </spine>
+</package>
+`, escape(book.Identifier), escape(book.Title), escape(book.Language), creator,
+		book.Modified.UTC().Format("2006-01-02T15:04:05Z"), manifest.String(), spine.String())
+}
+
+// navDocument is the EPUB 3 table of contents, nested like the sections
+func navDocument(book *Book) string {
+	next := 0
+	return xhtmlDocument(book.Language, book.Title,
+		`<nav xmlns:epub="http://www.idpf.org/2007/ops" epub:type="toc" id="toc">`+"\n<h1>Contents</h1>\n"+
+			navList(book.Sections, &next)+"</nav>\n")
+}
+
+func navList(sections []Section, next *int) string {
+	var list strings.Builder
+	list.WriteString("<ol>\n")
+	for _, section := range sections {
+		*next++
+		fmt.Fprintf(&list, "<li><a href=\"%s.xhtml\">%s</a>", sectionID(*next), escape(section.Title))
+		if len(section.Children) > 0 {
+			list.WriteString("\n" + navList(section.Children, next))
+		}
+		list.WriteString("</li>\n")
+	}
+	list.WriteString("</ol>\n")
+	return list.String()
+}
+
+// ncxDocument is the EPUB 2 table of contents
+func ncxDocument(book *Book) string {
+	next := 0
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+  <head><meta name="dtb:uid" content="%s"/></head>
+  <docTitle><text>%s</text></docTitle>
+  <navMap>
+%s  </navMap>
+</ncx>
+`, escape(book.Identifier), escape(book.Title), navPoints(book.Sections, &next))
+}
+
+func navPoints(sections []Section, next *int) string {
+	var points strings.Builder
+	for _, section := range sections {
+		*next++
+		id := sectionID(*next)
+		fmt.Fprintf(&points, "<navPoint id=\"nav-%s\" playOrder=\"%d\"><navLabel><text>%s</text></navLabel><content src=\"%s.xhtml\"/>\n",
+			id, *next, escape(section.Title), id)
+		points.WriteString(navPoints(section.Children, next))
+		points.WriteString("</navPoint>\n")
+	}
+	return points.String()
+}
+
+// sectionID names the nth section (1-based, in reading order)
+func sectionID(n int) string {
+	return fmt.Sprintf("section-%04d", n)
+}
+
+func chapterDocument(book *Book, item spineItem) string {
+	return xhtmlDocument(book.Language, item.title, item.body)
+}
+
+func xhtmlDocument(language, title, body string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xml:lang="%[1]s" lang="%[1]s">
+<head>
+<title>%[2]s</title>
+<link rel="stylesheet" type="text/css" href="style.css"/>
+</head>
+<body>
+%[3]s</body>
+</html>
+`, escape(language), escape(title), body)
+}
@@ -0,0 +1,103 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarkdownToXHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{"heading", "## Part *One*", "<h2>Part <em>One</em></h2>\n"},
+		{"paragraph joins lines", "First line\nsecond line", "<p>First line\nsecond line</p>\n"},
+		{"emphasis", "**bold** and _italic_ and `a < b`", "<p><strong>bold</strong> and <em>italic</em> and <code>a &lt; b</code></p>\n"},
+		{"nested emphasis", "**a *b* c**", "<p><strong>a <em>b</em> c</strong></p>\n"},
+		{"raw html is escaped", "<script>x</script>", "<p>&lt;script&gt;x&lt;/script&gt;</p>\n"},
+		{"link", "[site](https://example.com/?a=1&b=2)", "<p><a href=\"https://example.com/?a=1&amp;b=2\">site</a></p>\n"},
+		{"scene break", "***", "<hr/>\n"},
+		{"list", "- one\n- two", "<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n"},
+		{"quote", "> said", "<blockquote>\n<p>said</p>\n</blockquote>\n"},
+		{"code fence", "```\n<b>\n```", "<pre><code>&lt;b&gt;</code></pre>\n"},
+		{"crossed emphasis falls back to text", "**a *b** c*", "<p>**a *b** c*</p>\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownToXHTML(tt.markdown); got != tt.want {
+				t.Errorf("MarkdownToXHTML(%q) = %q, want %q", tt.markdown, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteEPUB(t *testing.T) {
+	book := &Book{
+		Identifier: "urn:uuid:00000000-0000-0000-0000-000000000001",
+		Title:      "Tom & Jerry",
+		Author:     "A. Writer",
+		Language:   "en",
+		Modified:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Sections: []Section{
+			{Title: "Prologue", Markdown: "It began."},
+			{Title: "Part 1", Children: []Section{
+				{Title: "Chapter 1", Markdown: "# Chapter 1\n\nText with *emphasis*."},
+				{Title: "Chapter 2", Markdown: "More."},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteEPUB(&buf, book); err != nil {
+		t.Fatalf("WriteEPUB: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("open zip: %v", err)
+	}
+	if first := archive.File[0]; first.Name != "mimetype" || first.Method != zip.Store {
+		t.Fatalf("first entry = %s (method %d), want stored mimetype", first.Name, first.Method)
+	}
+
+	chapters := 0
+	for _, file := range archive.File {
+		if !strings.HasSuffix(file.Name, ".xhtml") && !strings.HasSuffix(file.Name, ".opf") && !strings.HasSuffix(file.Name, ".ncx") {
+			continue
+		}
+		if strings.HasPrefix(file.Name, "OEBPS/section-") {
+			chapters++
+		}
+
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		content, _ := io.ReadAll(reader)
+		reader.Close()
+
+		decoder := xml.NewDecoder(bytes.NewReader(content))
+		decoder.Strict = true
+		for {
+			_, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%s is not well-formed: %v\n%s", file.Name, err, content)
+			}
+		}
+	}
+
+	// Prologue, the part title page and two chapters
+	if chapters != 4 {
+		t.Errorf("got %d section files, want 4", chapters)
+	}
+}
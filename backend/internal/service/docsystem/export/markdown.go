@@ -0,0 +1,184 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// markdown.go - Markdown to XHTML for book exports.
+//
+// EPUB readers parse chapters as strict XHTML, so this renders the subset of Markdown
+// that prose uses (headings, paragraphs, emphasis, links, lists, quotes, code, rules)
+// and guarantees well-formed output: raw HTML is escaped, and a paragraph whose inline
+// markup doesn't nest properly falls back to plain escaped text.
+
+var (
+	headingPattern    = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	rulePattern       = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	unorderedPattern  = regexp.MustCompile(`^\s{0,3}[-*+]\s+(.*)$`)
+	orderedPattern    = regexp.MustCompile(`^\s{0,3}\d{1,9}[.)]\s+(.*)$`)
+	fencePattern      = regexp.MustCompile("^\\s{0,3}(```|~~~)")
+	imagePattern      = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]*)\)`)
+	linkPattern       = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	strongStarPattern = regexp.MustCompile(`\*\*([^\s*](?:.*?[^\s*])?)\*\*`)
+	strongUndPattern  = regexp.MustCompile(`__([^\s_](?:.*?[^\s_])?)__`)
+	emStarPattern     = regexp.MustCompile(`\*([^\s*](?:[^*]*[^\s*])?)\*`)
+	emUndPattern      = regexp.MustCompile(`(^|[\s(])_([^\s_](?:[^_]*[^\s_])?)_`)
+)
+
+// MarkdownToXHTML renders Markdown as an XHTML body fragment
+func MarkdownToXHTML(markdown string) string {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	var out strings.Builder
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			writeBlock(&out, "p", renderInlineLines(paragraph))
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+
+		case fencePattern.MatchString(line):
+			flush()
+			fence := fencePattern.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			out.WriteString("<pre><code>")
+			out.WriteString(escape(strings.Join(code, "\n")))
+			out.WriteString("</code></pre>\n")
+
+		case headingPattern.MatchString(trimmed):
+			flush()
+			match := headingPattern.FindStringSubmatch(trimmed)
+			writeBlock(&out, fmt.Sprintf("h%d", len(match[1])), renderInline(match[2]))
+
+		case rulePattern.MatchString(line):
+			flush()
+			out.WriteString("<hr/>\n")
+
+		case strings.HasPrefix(trimmed, ">"):
+			flush()
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				text := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(text, " "))
+			}
+			i--
+			out.WriteString("<blockquote>\n")
+			out.WriteString(MarkdownToXHTML(strings.Join(quoted, "\n")))
+			out.WriteString("</blockquote>\n")
+
+		case unorderedPattern.MatchString(line), orderedPattern.MatchString(line):
+			flush()
+			pattern, tag := unorderedPattern, "ul"
+			if !unorderedPattern.MatchString(line) {
+				pattern, tag = orderedPattern, "ol"
+			}
+			out.WriteString("<" + tag + ">\n")
+			for ; i < len(lines) && pattern.MatchString(lines[i]); i++ {
+				item := []string{pattern.FindStringSubmatch(lines[i])[1]}
+				// Indented continuation lines belong to the item
+				for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" &&
+					(strings.HasPrefix(lines[i+1], "  ") || strings.HasPrefix(lines[i+1], "\t")) &&
+					!pattern.MatchString(lines[i+1]) {
+					i++
+					item = append(item, strings.TrimSpace(lines[i]))
+				}
+				writeBlock(&out, "li", renderInlineLines(item))
+			}
+			i--
+			out.WriteString("</" + tag + ">\n")
+
+		default:
+			paragraph = append(paragraph, line)
+		}
+	}
+	flush()
+
+	return out.String()
+}
+
+func writeBlock(out *strings.Builder, tag, content string) {
+	out.WriteString("<" + tag + ">")
+	out.WriteString(content)
+	out.WriteString("</" + tag + ">\n")
+}
+
+// renderInlineLines joins a block's lines; a line ending in two spaces or a
+// backslash is a hard line break
+func renderInlineLines(lines []string) string {
+	rendered := make([]string, len(lines))
+	for i, line := range lines {
+		hardBreak := i < len(lines)-1 && (strings.HasSuffix(line, "  ") || strings.HasSuffix(line, "\\"))
+		line = strings.TrimSpace(strings.TrimSuffix(line, "\\"))
+		rendered[i] = renderInline(line)
+		if hardBreak {
+			rendered[i] += "<br/>"
+		}
+	}
+	return strings.Join(rendered, "\n")
+}
+
+// renderInline renders emphasis, code spans and links within one block
+func renderInline(text string) string {
+	var out strings.Builder
+	parts := strings.Split(text, "`")
+	for i, part := range parts {
+		switch {
+		case i%2 == 1 && i < len(parts)-1:
+			out.WriteString("<code>" + escape(part) + "</code>")
+		case i%2 == 1:
+			out.WriteString("`" + renderSpans(part)) // Unmatched backtick
+		default:
+			out.WriteString(renderSpans(part))
+		}
+	}
+
+	rendered := out.String()
+	if !wellFormed(rendered) {
+		return escape(text)
+	}
+	return rendered
+}
+
+func renderSpans(text string) string {
+	text = escape(text)
+	text = imagePattern.ReplaceAllString(text, "$1") // Images aren't embedded; keep the alt text
+	text = linkPattern.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	text = strongStarPattern.ReplaceAllString(text, "<strong>$1</strong>")
+	text = strongUndPattern.ReplaceAllString(text, "<strong>$1</strong>")
+	text = emStarPattern.ReplaceAllString(text, "<em>$1</em>")
+	text = emUndPattern.ReplaceAllString(text, "$1<em>$2</em>")
+	return text
+}
+
+// wellFormed reports whether an inline fragment parses as XML
+func wellFormed(fragment string) bool {
+	decoder := xml.NewDecoder(strings.NewReader("<p>" + fragment + "</p>"))
+	decoder.Strict = true
+	for {
+		if _, err := decoder.Token(); err != nil {
+			return err == io.EOF
+		}
+	}
+}
+
+// escape escapes text for XHTML (html.EscapeString also covers quotes for attributes)
+func escape(text string) string {
+	return html.EscapeString(text)
+}
@@ -0,0 +1,74 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+// commandRenderer renders PDFs by running an external converter on the EPUB,
+// e.g. "ebook-convert {input} {output}" (Calibre) or "pandoc {input} -o {output}".
+type commandRenderer struct {
+	args []string
+}
+
+// NewCommandRenderer creates a PDF renderer from a command template.
+// {input} and {output} are replaced with temporary file paths.
+// Returns nil when command is empty (PDF export disabled).
+func NewCommandRenderer(command string) (docsysSvc.PDFRenderer, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, nil
+	}
+	if !strings.Contains(command, "{input}") || !strings.Contains(command, "{output}") {
+		return nil, fmt.Errorf("PDF renderer command must contain {input} and {output}: %q", command)
+	}
+	return &commandRenderer{args: args}, nil
+}
+
+// RenderPDF converts an EPUB file into a PDF
+func (r *commandRenderer) RenderPDF(ctx context.Context, epub []byte) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "meridian-export-*")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "book.epub")
+	output := filepath.Join(dir, "book.pdf")
+	if err := os.WriteFile(input, epub, 0o600); err != nil {
+		return nil, fmt.Errorf("write epub: %w", err)
+	}
+
+	replacer := strings.NewReplacer("{input}", input, "{output}", output)
+	args := make([]string, len(r.args))
+	for i, arg := range r.args {
+		args[i] = replacer.Replace(arg)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = dir
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("PDF renderer failed: %w: %s", err, lastLine(stderr.String()))
+	}
+
+	pdf, err := os.ReadFile(output)
+	if err != nil {
+		return nil, fmt.Errorf("read rendered PDF: %w", err)
+	}
+	return pdf, nil
+}
+
+// lastLine keeps renderer errors short (converters tend to log a lot)
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return lines[len(lines)-1]
+}
@@ -0,0 +1,18 @@
+package docsystem
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestNaturalLess(t *testing.T) {
+	names := []string{"Chapter 10", "chapter 2", "Chapter 1", "Appendix", "Chapter 02b", "Chapter 2a"}
+	sort.SliceStable(names, func(i, j int) bool { return naturalLess(names[i], names[j]) })
+
+	want := []string{"Appendix", "Chapter 1", "chapter 2", "Chapter 2a", "Chapter 02b", "Chapter 10"}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("sorted = %v, want %v", names, want)
+		}
+	}
+}
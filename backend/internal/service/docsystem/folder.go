@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log/slog"
 	"regexp"
-	"strings"
 	"time"
 
 	"meridian/internal/config"
@@ -22,11 +21,11 @@ import (
 type folderService struct {
 	folderRepo   docsysRepo.FolderRepository
 	docRepo      docsysRepo.DocumentRepository
-	docService   docsysSvc.DocumentService // For delegating document deletion (SRP)
 	pathResolver docsysSvc.PathResolver
 	txManager    repositories.TransactionManager
-	validator    *ResourceValidator
+	validator    docsysSvc.ParentValidator
 	authorizer   services.ResourceAuthorizer
+	namePolicy   NamePolicy // Decides which sibling names collide
 	logger       *slog.Logger
 }
 
@@ -34,21 +33,21 @@ type folderService struct {
 func NewFolderService(
 	folderRepo docsysRepo.FolderRepository,
 	docRepo docsysRepo.DocumentRepository,
-	docService docsysSvc.DocumentService, // For delegating document deletion (SRP)
 	pathResolver docsysSvc.PathResolver,
 	txManager repositories.TransactionManager,
-	validator *ResourceValidator,
+	validator docsysSvc.ParentValidator,
 	authorizer services.ResourceAuthorizer,
+	namePolicy NamePolicy,
 	logger *slog.Logger,
 ) docsysSvc.FolderService {
 	return &folderService{
 		folderRepo:   folderRepo,
 		docRepo:      docRepo,
-		docService:   docService,
 		pathResolver: pathResolver,
 		txManager:    txManager,
 		validator:    validator,
 		authorizer:   authorizer,
+		namePolicy:   namePolicy,
 		logger:       logger,
 	}
 }
@@ -92,7 +91,7 @@ func (s *folderService) CreateFolder(ctx context.Context, req *docsysSvc.CreateF
 		return nil, fmt.Errorf("failed to check for duplicate names: %w", err)
 	}
 	for _, sibling := range siblingFolders {
-		if sibling.Name == result.FinalName {
+		if s.namePolicy.SameName(sibling.Name, result.FinalName) {
 			return nil, &domain.ConflictError{
 				Message:      fmt.Sprintf("a folder named %q already exists in this location", result.FinalName),
 				ResourceType: "folder",
@@ -180,20 +179,24 @@ func (s *folderService) UpdateFolder(ctx context.Context, userID, folderID strin
 	}
 
 	// Update fields
-	if req.Name != nil {
-		folder.Name = strings.TrimSpace(*req.Name)
+	if name, ok := req.Name.Value(); ok {
+		normalized, err := NormalizeAndValidateName(name, config.MaxFolderNameLength)
+		if err != nil {
+			return nil, fmt.Errorf("%w: name: %v", domain.ErrValidation, err)
+		}
+		folder.Name = normalized
 	}
 
-	if req.FolderID != nil {
-		// Validate parent folder exists (unless moving to root)
-		if *req.FolderID != "" {
-			parent, err := s.folderRepo.GetByID(ctx, *req.FolderID, folder.ProjectID)
+	if req.FolderID.IsSet() {
+		// Validate parent folder exists (unless moving to root: null or "")
+		if targetFolderID, _ := req.FolderID.Value(); targetFolderID != "" {
+			parent, err := s.folderRepo.GetByID(ctx, targetFolderID, folder.ProjectID)
 			if err != nil {
 				return nil, fmt.Errorf("parent folder not found: %w", err)
 			}
 
 			// Prevent circular references (can't move folder to be a child of itself or its descendants)
-			if err := s.validateNoCircularReference(ctx, folderID, *req.FolderID, folder.ProjectID); err != nil {
+			if err := s.validateNoCircularReference(ctx, folderID, targetFolderID, folder.ProjectID); err != nil {
 				return nil, err
 			}
 
@@ -210,13 +213,13 @@ func (s *folderService) UpdateFolder(ctx context.Context, userID, folderID strin
 	}
 
 	// Check for duplicate name in target folder (if name or parent changed)
-	if req.Name != nil || req.FolderID != nil {
+	if req.Name.IsSet() || req.FolderID.IsSet() {
 		siblingFolders, err := s.folderRepo.ListChildren(ctx, folder.ParentID, folder.ProjectID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check for duplicate names: %w", err)
 		}
 		for _, sibling := range siblingFolders {
-			if sibling.ID != folder.ID && sibling.Name == folder.Name {
+			if sibling.ID != folder.ID && s.namePolicy.SameName(sibling.Name, folder.Name) {
 				return nil, &domain.ConflictError{
 					Message:      fmt.Sprintf("a folder named %q already exists in this location", folder.Name),
 					ResourceType: "folder",
@@ -252,8 +255,10 @@ func (s *folderService) UpdateFolder(ctx context.Context, userID, folderID strin
 	return folder, nil
 }
 
-// DeleteFolder deletes a folder and all its contents (documents and subfolders) recursively.
-// Authorization is checked first via the injected authorizer.
+// DeleteFolder deletes a folder and all its contents (documents and subfolders).
+// Authorization is checked first via the injected authorizer, on the root folder only.
+// The subtree is soft-deleted with one statement per table, in one transaction:
+// a failure leaves everything in place.
 func (s *folderService) DeleteFolder(ctx context.Context, userID, folderID string) error {
 	// Authorize: check user can access this folder
 	if err := s.authorizer.CanAccessFolder(ctx, userID, folderID); err != nil {
@@ -266,13 +271,18 @@ func (s *folderService) DeleteFolder(ctx context.Context, userID, folderID strin
 		return err
 	}
 
-	// Recursively delete all descendants (child folders and documents)
-	if err := s.deleteDescendants(ctx, userID, folderID, folder.ProjectID); err != nil {
+	// Authorization covers the whole subtree (same project), so descendants are
+	// bulk-deleted without per-row checks: documents first, since the CTE walks live folders
+	var docCount, folderCount int64
+	err = s.txManager.ExecTx(ctx, func(txCtx context.Context) error {
+		var err error
+		if docCount, err = s.docRepo.DeleteByFolderTree(txCtx, folderID, folder.ProjectID); err != nil {
+			return fmt.Errorf("failed to delete documents: %w", err)
+		}
+		folderCount, err = s.folderRepo.DeleteTree(txCtx, folderID, folder.ProjectID)
 		return err
-	}
-
-	// Delete the folder itself
-	if err := s.folderRepo.Delete(ctx, folderID, folder.ProjectID); err != nil {
+	})
+	if err != nil {
 		return err
 	}
 
@@ -280,48 +290,13 @@ func (s *folderService) DeleteFolder(ctx context.Context, userID, folderID strin
 		"id", folderID,
 		"name", folder.Name,
 		"project_id", folder.ProjectID,
+		"folders", folderCount,
+		"documents", docCount,
 	)
 
 	return nil
 }
 
-// deleteDescendants recursively deletes all child folders and documents.
-// Documents are deleted via DocumentService to maintain SRP.
-func (s *folderService) deleteDescendants(ctx context.Context, userID, folderID, projectID string) error {
-	// 1. Get and recursively delete child folders
-	childFolders, err := s.folderRepo.ListChildren(ctx, &folderID, projectID)
-	if err != nil {
-		return fmt.Errorf("failed to list child folders: %w", err)
-	}
-
-	for _, child := range childFolders {
-		// Recursively delete this child's descendants first
-		if err := s.deleteDescendants(ctx, userID, child.ID, projectID); err != nil {
-			return err
-		}
-		// Then delete the child folder itself
-		if err := s.folderRepo.Delete(ctx, child.ID, projectID); err != nil {
-			return fmt.Errorf("failed to delete child folder %q: %w", child.Name, err)
-		}
-		s.logger.Debug("deleted child folder", "id", child.ID, "name", child.Name)
-	}
-
-	// 2. Delete all documents in this folder via DocumentService (SRP: delegate to owner)
-	docs, err := s.docRepo.ListByFolder(ctx, &folderID, projectID)
-	if err != nil {
-		return fmt.Errorf("failed to list documents: %w", err)
-	}
-
-	for _, doc := range docs {
-		if err := s.docService.DeleteDocument(ctx, userID, doc.ID); err != nil {
-			return fmt.Errorf("failed to delete document %q: %w", doc.Name, err)
-		}
-		s.logger.Debug("deleted document", "id", doc.ID, "name", doc.Name)
-	}
-
-	return nil
-}
-
 // ListChildren lists all child folders and documents in a folder
 // Authorization is checked first via the injected authorizer
 func (s *folderService) ListChildren(ctx context.Context, userID string, folderID *string, projectID string) (*docsysSvc.FolderContents, error) {
@@ -361,17 +336,19 @@ func (s *folderService) ListChildren(ctx context.Context, userID string, folderI
 		return nil, fmt.Errorf("failed to list documents: %w", err)
 	}
 
-	// Compute paths for all documents
+	// Compute paths for all documents (one query for the whole listing)
+	paths, err := s.docRepo.GetPaths(ctx, projectID, docs)
+	if err != nil {
+		s.logger.Warn("failed to compute document paths",
+			"folder_id", folderID,
+			"error", err,
+		)
+	}
 	for i := range docs {
-		path, err := s.docRepo.GetPath(ctx, &docs[i])
-		if err != nil {
-			s.logger.Warn("failed to compute document path",
-				"doc_id", docs[i].ID,
-				"error", err,
-			)
-			docs[i].Path = docs[i].Name
-		} else {
+		if path, ok := paths[docs[i].ID]; ok {
 			docs[i].Path = path
+		} else {
+			docs[i].Path = docs[i].Name
 		}
 	}
 
@@ -397,25 +374,31 @@ func (s *folderService) validateCreateRequest(req *docsysSvc.CreateFolderRequest
 // validateUpdateRequest validates a folder update request
 func (s *folderService) validateUpdateRequest(req *docsysSvc.UpdateFolderRequest) error {
 	// At least one field must be provided
-	if req.Name == nil && req.FolderID == nil {
+	if !req.Name.IsSet() && !req.FolderID.IsSet() {
 		return fmt.Errorf("at least one field must be provided")
 	}
 
-	rules := []*validation.FieldRules{
+	if err := validation.ValidateStruct(req,
 		validation.Field(&req.ProjectID, validation.Required),
+	); err != nil {
+		return err
 	}
 
-	if req.Name != nil {
-		rules = append(rules,
-			validation.Field(&req.Name,
-				validation.Required,
-				validation.Length(1, config.MaxFolderNameLength),
-				validation.Match(regexp.MustCompile(`^[^/]+$`)).Error("folder name cannot contain slashes"),
-			),
-		)
+	if req.Name.IsSet() {
+		if req.Name.IsNull() {
+			return fmt.Errorf("name: cannot be null")
+		}
+		name, _ := req.Name.Value()
+		if err := validation.Validate(name,
+			validation.Required,
+			validation.Length(1, config.MaxFolderNameLength),
+			validation.Match(regexp.MustCompile(`^[^/]+$`)).Error("folder name cannot contain slashes"),
+		); err != nil {
+			return fmt.Errorf("name: %w", err)
+		}
 	}
 
-	return validation.ValidateStruct(req, rules...)
+	return nil
 }
 
 // validateNoCircularReference ensures moving a folder won't create circular references
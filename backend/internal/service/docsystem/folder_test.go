@@ -0,0 +1,168 @@
+package docsystem
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/testmocks"
+)
+
+// fakeFolderTree records bulk deletes, with whether each ran inside a transaction
+type fakeFolderTree struct {
+	folder     models.Folder
+	docs       []models.Document
+	docPaths   map[string]string
+	pathCalls  int
+	docErr     error
+	calls      []string
+	outsideTxn []string
+}
+
+func (f *fakeFolderTree) record(ctx context.Context, call string) {
+	f.calls = append(f.calls, call)
+	if testmocks.TxDepth(ctx) == 0 {
+		f.outsideTxn = append(f.outsideTxn, call)
+	}
+}
+
+// The repository views of the tree; unused interface methods panic
+type (
+	fakeTreeFolderRepo struct {
+		docsysRepo.FolderRepository
+		*fakeFolderTree
+	}
+	fakeTreeDocRepo struct {
+		docsysRepo.DocumentRepository
+		*fakeFolderTree
+	}
+)
+
+func (f fakeTreeFolderRepo) GetByIDOnly(ctx context.Context, id string) (*models.Folder, error) {
+	folder := f.folder
+	return &folder, nil
+}
+
+func (f fakeTreeFolderRepo) DeleteTree(ctx context.Context, id, projectID string) (int64, error) {
+	f.record(ctx, "folders:"+id+":"+projectID)
+	return 2, nil
+}
+
+func (f fakeTreeDocRepo) DeleteByFolderTree(ctx context.Context, folderID, projectID string) (int64, error) {
+	if f.docErr != nil {
+		return 0, f.docErr
+	}
+	f.record(ctx, "documents:"+folderID+":"+projectID)
+	return 3, nil
+}
+
+func (f fakeTreeFolderRepo) ListChildren(ctx context.Context, folderID *string, projectID string) ([]models.Folder, error) {
+	return nil, nil
+}
+
+func (f fakeTreeDocRepo) ListByFolder(ctx context.Context, folderID *string, projectID string) ([]models.Document, error) {
+	return f.docs, nil
+}
+
+func (f fakeTreeDocRepo) GetPaths(ctx context.Context, projectID string, docs []models.Document) (map[string]string, error) {
+	f.pathCalls++
+	return f.docPaths, nil
+}
+
+func newFakeFolderTree() *fakeFolderTree {
+	return &fakeFolderTree{
+		folder: models.Folder{ID: "root", ProjectID: "p1", Name: "Root"},
+	}
+}
+
+func newTestFolderService(tree *fakeFolderTree, txManager *testmocks.TxManager, authorizer *testmocks.Authorizer) docsysSvc.FolderService {
+	return NewFolderService(
+		fakeTreeFolderRepo{fakeFolderTree: tree},
+		fakeTreeDocRepo{fakeFolderTree: tree},
+		nil, txManager,
+		&testmocks.ParentValidator{}, authorizer,
+		NewNamePolicy("exact"),
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+}
+
+func TestDeleteFolder_BulkDeleteInOneTransaction(t *testing.T) {
+	tree := newFakeFolderTree()
+	txManager := &testmocks.TxManager{}
+	authorizer := &testmocks.Authorizer{}
+
+	if err := newTestFolderService(tree, txManager, authorizer).DeleteFolder(context.Background(), "u1", "root"); err != nil {
+		t.Fatalf("DeleteFolder: %v", err)
+	}
+
+	// Documents go first: the folder CTE only walks live folders
+	want := []string{"documents:root:p1", "folders:root:p1"}
+	if !reflect.DeepEqual(tree.calls, want) {
+		t.Errorf("calls = %v, want %v", tree.calls, want)
+	}
+	if len(tree.outsideTxn) > 0 {
+		t.Errorf("deleted outside the transaction: %v", tree.outsideTxn)
+	}
+	if txManager.Calls() != 1 {
+		t.Errorf("ExecTx calls = %d, want 1", txManager.Calls())
+	}
+	// Only the root is authorized; descendants are not checked one by one
+	if checks := authorizer.Checks(); len(checks) != 1 {
+		t.Errorf("authorization checks = %v, want one (root folder)", checks)
+	}
+}
+
+func TestDeleteFolder_FailureAbortsTransaction(t *testing.T) {
+	tree := newFakeFolderTree()
+	tree.docErr = errors.New("boom")
+
+	err := newTestFolderService(tree, &testmocks.TxManager{}, &testmocks.Authorizer{}).DeleteFolder(context.Background(), "u1", "root")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	// The error escapes ExecTx (so the real transaction rolls back) before folders are touched
+	if len(tree.calls) != 0 {
+		t.Errorf("folders deleted despite failure: %v", tree.calls)
+	}
+}
+
+func TestDeleteFolder_Forbidden(t *testing.T) {
+	tree := newFakeFolderTree()
+	authorizer := &testmocks.Authorizer{Err: errors.New("forbidden")}
+
+	if err := newTestFolderService(tree, &testmocks.TxManager{}, authorizer).DeleteFolder(context.Background(), "u1", "root"); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(tree.calls) != 0 {
+		t.Errorf("deleted without authorization: %v", tree.calls)
+	}
+}
+
+func TestListChildren_BatchesDocumentPaths(t *testing.T) {
+	tree := newFakeFolderTree()
+	tree.docs = []models.Document{{ID: "d1", Name: "a"}, {ID: "d2", Name: "b"}, {ID: "d3", Name: "c"}}
+	tree.docPaths = map[string]string{"d1": "x/a", "d2": "x/y/b"}
+
+	contents, err := newTestFolderService(tree, &testmocks.TxManager{}, &testmocks.Authorizer{}).ListChildren(context.Background(), "u1", nil, "p1")
+	if err != nil {
+		t.Fatalf("ListChildren: %v", err)
+	}
+
+	if tree.pathCalls != 1 {
+		t.Errorf("GetPaths calls = %d, want 1", tree.pathCalls)
+	}
+	var got []string
+	for _, doc := range contents.Documents {
+		got = append(got, doc.Path)
+	}
+	// d3's folder couldn't be resolved: falls back to its name
+	if want := []string{"x/a", "x/y/b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("paths = %v, want %v", got, want)
+	}
+}
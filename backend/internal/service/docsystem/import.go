@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"log/slog"
 
+	"meridian/internal/domain/repositories"
 	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/domain/services"
 	docsysSvc "meridian/internal/domain/services/docsystem"
 )
 
@@ -13,47 +15,44 @@ import (
 type importService struct {
 	docRepo               docsysRepo.DocumentRepository
 	fileProcessorRegistry *FileProcessorRegistry
+	txManager             repositories.TransactionManager
+	jobs                  docsysSvc.JobService // Queues background imports
+	objectStore           services.ObjectStore // Holds uploads until their job runs
 	logger                *slog.Logger
 }
 
-// NewImportService creates a new import service
+// NewImportService creates a new import service.
+// StartImport keeps uploads in objectStore and queues them on jobs.
 func NewImportService(
 	docRepo docsysRepo.DocumentRepository,
 	fileProcessorRegistry *FileProcessorRegistry,
+	txManager repositories.TransactionManager,
+	jobs docsysSvc.JobService,
+	objectStore services.ObjectStore,
 	logger *slog.Logger,
 ) docsysSvc.ImportService {
 	return &importService{
 		docRepo:               docRepo,
 		fileProcessorRegistry: fileProcessorRegistry,
+		txManager:             txManager,
+		jobs:                  jobs,
+		objectStore:           objectStore,
 		logger:                logger,
 	}
 }
 
-// DeleteAllDocuments deletes all documents in a project
-func (s *importService) DeleteAllDocuments(ctx context.Context, projectID string) error {
-	if err := s.docRepo.DeleteAllByProject(ctx, projectID); err != nil {
-		s.logger.Error("failed to delete all documents",
-			"project_id", projectID,
-			"error", err,
-		)
-		return fmt.Errorf("failed to delete all documents: %w", err)
-	}
-
-	s.logger.Info("deleted all documents",
-		"project_id", projectID,
-	)
-
-	return nil
-}
-
 // ProcessFiles processes uploaded files using file processor strategies.
-// If overwrite is true, existing documents are updated; if false, duplicates are skipped.
+// opts.Conflict decides what happens with files whose document already exists.
 //
 // Aggregation Pattern: Each file is processed independently and results are merged.
 // A single file failure does NOT halt the entire batch - this allows partial success
 // (e.g., 8 of 10 files imported successfully). Errors are collected and returned
 // in the ImportResult for the frontend to display.
-func (s *importService) ProcessFiles(ctx context.Context, projectID, userID string, files []docsysSvc.UploadedFile, folderPath string, overwrite bool) (*docsysSvc.ImportResult, error) {
+//
+// The batch runs in one transaction (each document in its own savepoint, see
+// documentImporter): a processor error rolls back the whole batch, including the
+// opts.ReplaceAll deletion, so a failed replace never leaves the project empty.
+func (s *importService) ProcessFiles(ctx context.Context, projectID, userID string, files []docsysSvc.UploadedFile, folderPath string, opts docsysSvc.ImportOptions) (*docsysSvc.ImportResult, error) {
 	// Initialize aggregated result - will collect stats from all processors
 	aggregatedResult := &docsysSvc.ImportResult{
 		Summary:   docsysSvc.ImportSummary{},
@@ -61,6 +60,46 @@ func (s *importService) ProcessFiles(ctx context.Context, projectID, userID stri
 		Documents: []docsysSvc.ImportDocument{},
 	}
 
+	// Base revision paths are compared against normalized document paths
+	if len(opts.BaseRevisions) > 0 {
+		normalized := make(map[string]string, len(opts.BaseRevisions))
+		for path, content := range opts.BaseRevisions {
+			normalized[NormalizeFolderPath(path)] = content
+		}
+		opts.BaseRevisions = normalized
+	}
+
+	err := s.txManager.ExecTx(ctx, func(txCtx context.Context) error {
+		if opts.ReplaceAll {
+			if err := s.docRepo.DeleteAllByProject(txCtx, projectID); err != nil {
+				return fmt.Errorf("failed to delete all documents: %w", err)
+			}
+		}
+		return s.processFiles(txCtx, projectID, userID, files, folderPath, opts, aggregatedResult)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("file processing complete",
+		"project_id", projectID,
+		"replace_all", opts.ReplaceAll,
+		"created", aggregatedResult.Summary.Created,
+		"updated", aggregatedResult.Summary.Updated,
+		"skipped", aggregatedResult.Summary.Skipped,
+		"unchanged", aggregatedResult.Summary.Unchanged,
+		"renamed", aggregatedResult.Summary.Renamed,
+		"merged", aggregatedResult.Summary.Merged,
+		"conflicts", aggregatedResult.Summary.Conflicts,
+		"failed", aggregatedResult.Summary.Failed,
+		"total_files", aggregatedResult.Summary.TotalFiles,
+	)
+
+	return aggregatedResult, nil
+}
+
+// processFiles routes each file to its processor and merges the results into aggregatedResult
+func (s *importService) processFiles(ctx context.Context, projectID, userID string, files []docsysSvc.UploadedFile, folderPath string, opts docsysSvc.ImportOptions, aggregatedResult *docsysSvc.ImportResult) error {
 	// Process each file using appropriate processor
 	for _, file := range files {
 		processor := s.fileProcessorRegistry.GetProcessor(file.Filename)
@@ -68,33 +107,54 @@ func (s *importService) ProcessFiles(ctx context.Context, projectID, userID stri
 			s.logger.Debug("no processor for file", "filename", file.Filename)
 			aggregatedResult.Summary.Skipped++
 			aggregatedResult.Summary.TotalFiles++
+			opts.ReportFile(file.Filename, nil)
 			continue
 		}
 
 		// Process file with matched processor
-		result, err := processor.Process(ctx, projectID, userID, file.Content, file.Filename, folderPath, overwrite)
+		result, err := processor.Process(ctx, projectID, userID, file.Content, file.Filename, folderPath, opts)
 		if err != nil {
-			return nil, fmt.Errorf("processor %s failed for file %s: %w", processor.Name(), file.Filename, err)
+			return fmt.Errorf("processor %s failed for file %s: %w", processor.Name(), file.Filename, err)
 		}
 
 		// Aggregate results
 		aggregatedResult.Summary.Created += result.Summary.Created
 		aggregatedResult.Summary.Updated += result.Summary.Updated
 		aggregatedResult.Summary.Skipped += result.Summary.Skipped
+		aggregatedResult.Summary.Unchanged += result.Summary.Unchanged
+		aggregatedResult.Summary.Renamed += result.Summary.Renamed
+		aggregatedResult.Summary.Merged += result.Summary.Merged
+		aggregatedResult.Summary.Conflicts += result.Summary.Conflicts
 		aggregatedResult.Summary.Failed += result.Summary.Failed
 		aggregatedResult.Summary.TotalFiles += result.Summary.TotalFiles
 		aggregatedResult.Errors = append(aggregatedResult.Errors, result.Errors...)
 		aggregatedResult.Documents = append(aggregatedResult.Documents, result.Documents...)
 	}
 
-	s.logger.Info("file processing complete",
-		"project_id", projectID,
-		"created", aggregatedResult.Summary.Created,
-		"updated", aggregatedResult.Summary.Updated,
-		"skipped", aggregatedResult.Summary.Skipped,
-		"failed", aggregatedResult.Summary.Failed,
-		"total_files", aggregatedResult.Summary.TotalFiles,
-	)
+	return nil
+}
 
-	return aggregatedResult, nil
+// Manifest lists every document in the project with its path and content hash
+func (s *importService) Manifest(ctx context.Context, projectID string) ([]docsysSvc.ImportManifestEntry, error) {
+	docs, err := s.docRepo.GetAllMetadataByProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get documents: %w", err)
+	}
+
+	paths, err := s.docRepo.GetPaths(ctx, projectID, docs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute document paths: %w", err)
+	}
+
+	entries := make([]docsysSvc.ImportManifestEntry, 0, len(docs))
+	for _, doc := range docs {
+		entries = append(entries, docsysSvc.ImportManifestEntry{
+			ID:          doc.ID,
+			Path:        paths[doc.ID],
+			ContentHash: doc.ContentHash,
+			UpdatedAt:   doc.UpdatedAt,
+		})
+	}
+
+	return entries, nil
 }
@@ -0,0 +1,358 @@
+package docsystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"meridian/internal/domain"
+	docsysModels "meridian/internal/domain/models/docsystem"
+	"meridian/internal/domain/repositories"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+// import_conflict.go - Create/update/skip/rename/merge decisions shared by the file processors.
+
+// maxRenameAttempts bounds the "name (n)" suffix search for the rename strategy
+const maxRenameAttempts = 1000
+
+// existingDocument is what an import needs to know about a document already in the project
+type existingDocument struct {
+	ID          string
+	ContentHash string
+}
+
+// buildDocMap builds the deduplication map for an import: "path|name" → existing document.
+// The key format uses pipe separator (see BuildLookupKey) to ensure uniqueness
+// since the same document name can exist in different folders.
+func buildDocMap(ctx context.Context, docRepo docsysRepo.DocumentRepository, projectID string) (map[string]existingDocument, error) {
+	existingDocs, err := docRepo.GetAllMetadataByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := docRepo.GetPaths(ctx, projectID, existingDocs)
+	if err != nil {
+		return nil, fmt.Errorf("compute existing document paths: %w", err)
+	}
+
+	docMap := make(map[string]existingDocument, len(existingDocs))
+	for _, doc := range existingDocs {
+		docMap[BuildLookupKey(paths[doc.ID], doc.Name)] = existingDocument{ID: doc.ID, ContentHash: doc.ContentHash}
+	}
+	return docMap, nil
+}
+
+// documentLookupKey returns the docMap key for a document in folderPath
+func documentLookupKey(folderPath, docName string) string {
+	return BuildLookupKey(BuildFullPath(folderPath, docName), docName)
+}
+
+// documentImporter writes one converted file into the project, applying the
+// import's conflict strategy when a document with the same path exists.
+type documentImporter struct {
+	docService docsysSvc.DocumentService
+	txManager  repositories.TransactionManager
+	logger     *slog.Logger
+}
+
+// errDocumentFailed rolls back a document's savepoint after its failure was recorded in the result
+var errDocumentFailed = errors.New("document import failed")
+
+// importDocument creates, updates, skips, renames or merges a single document and
+// records the outcome in result. Files whose content hash matches the existing
+// document are reported as unchanged whatever the strategy.
+// source identifies the file in error messages. docMap is updated with documents
+// created along the way.
+// Each document runs in its own savepoint of the import transaction: a failed document
+// leaves no partial writes (e.g. auto-created folders) and doesn't abort the rest of the batch.
+func (imp *documentImporter) importDocument(
+	ctx context.Context,
+	projectID string,
+	userID string,
+	source string,
+	folderPath string,
+	docName string,
+	content string,
+	docMap map[string]existingDocument,
+	opts docsysSvc.ImportOptions,
+	result *docsysSvc.ImportResult,
+) {
+	failedBefore := result.Summary.Failed
+	err := imp.txManager.ExecTx(ctx, func(txCtx context.Context) error {
+		imp.applyDocument(txCtx, projectID, userID, source, folderPath, docName, content, docMap, opts, result)
+		if result.Summary.Failed > failedBefore {
+			return errDocumentFailed
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDocumentFailed) {
+		imp.addError(result, source, fmt.Sprintf("failed to import document: %v", err))
+	}
+}
+
+// applyDocument makes the create/update/skip/rename/merge decision for one document
+func (imp *documentImporter) applyDocument(
+	ctx context.Context,
+	projectID string,
+	userID string,
+	source string,
+	folderPath string,
+	docName string,
+	content string,
+	docMap map[string]existingDocument,
+	opts docsysSvc.ImportOptions,
+	result *docsysSvc.ImportResult,
+) {
+	existing, exists := docMap[documentLookupKey(folderPath, docName)]
+	if !exists {
+		imp.createDocument(ctx, projectID, userID, source, folderPath, docName, content, docMap, "", result)
+		return
+	}
+
+	if existing.ContentHash != "" && existing.ContentHash == docsysModels.ContentHash(content) {
+		imp.unchangedDocument(result, existing.ID, folderPath, docName)
+		return
+	}
+
+	switch opts.Conflict {
+	case docsysSvc.ConflictOverwrite:
+		imp.updateDocument(ctx, projectID, userID, source, existing.ID, content, "updated", result)
+	case docsysSvc.ConflictRename:
+		newName, ok := renameCandidate(docMap, folderPath, docName)
+		if !ok {
+			imp.addError(result, source, fmt.Sprintf("failed to find a free name for %q", docName))
+			return
+		}
+		imp.createDocument(ctx, projectID, userID, source, folderPath, newName, content, docMap, docName, result)
+	case docsysSvc.ConflictMerge:
+		imp.mergeDocument(ctx, projectID, userID, source, folderPath, docName, existing.ID, content, opts.BaseRevisions, result)
+	default:
+		imp.skipDocument(result, existing.ID, folderPath, docName, "document already exists")
+	}
+}
+
+// renameCandidate returns the first "name (n)" that is free in folderPath
+func renameCandidate(docMap map[string]existingDocument, folderPath, docName string) (string, bool) {
+	for n := 2; n <= maxRenameAttempts; n++ {
+		candidate := fmt.Sprintf("%s (%d)", docName, n)
+		if _, taken := docMap[documentLookupKey(folderPath, candidate)]; !taken {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// createDocument creates a new document. originalName is set when the document
+// was renamed to avoid a conflict.
+func (imp *documentImporter) createDocument(
+	ctx context.Context,
+	projectID string,
+	userID string,
+	source string,
+	folderPath string,
+	docName string,
+	content string,
+	docMap map[string]existingDocument,
+	originalName string,
+	result *docsysSvc.ImportResult,
+) {
+	doc, err := imp.docService.CreateDocument(ctx, &docsysSvc.CreateDocumentRequest{
+		ProjectID:  projectID,
+		UserID:     userID,
+		FolderPath: &folderPath,
+		Name:       docName,
+		Content:    content,
+	})
+	if err != nil {
+		imp.addError(result, source, fmt.Sprintf("failed to create document: %v", err))
+		return
+	}
+	docMap[documentLookupKey(folderPath, docName)] = existingDocument{ID: doc.ID, ContentHash: doc.ContentHash}
+
+	action := "created"
+	if originalName != "" {
+		action = "renamed"
+		result.Summary.Renamed++
+	} else {
+		result.Summary.Created++
+	}
+	result.Documents = append(result.Documents, docsysSvc.ImportDocument{
+		ID:           doc.ID,
+		Path:         doc.Path,
+		Name:         doc.Name,
+		Action:       action,
+		OriginalName: originalName,
+	})
+
+	imp.logger.Debug("document "+action,
+		"id", doc.ID,
+		"folder_path", folderPath,
+		"name", docName,
+	)
+}
+
+// updateDocument replaces an existing document's content. action is "updated" or "merged".
+func (imp *documentImporter) updateDocument(
+	ctx context.Context,
+	projectID string,
+	userID string,
+	source string,
+	docID string,
+	content string,
+	action string,
+	result *docsysSvc.ImportResult,
+) {
+	doc, err := imp.docService.UpdateDocument(ctx, userID, docID, &docsysSvc.UpdateDocumentRequest{
+		ProjectID: projectID,
+		Content:   domain.Some(content),
+	})
+	if err != nil {
+		imp.addError(result, source, fmt.Sprintf("failed to update document: %v", err))
+		return
+	}
+
+	if action == "merged" {
+		result.Summary.Merged++
+	} else {
+		result.Summary.Updated++
+	}
+	result.Documents = append(result.Documents, docsysSvc.ImportDocument{
+		ID:     doc.ID,
+		Path:   doc.Path,
+		Name:   doc.Name,
+		Action: action,
+	})
+
+	imp.logger.Debug("document "+action,
+		"id", doc.ID,
+		"path", doc.Path,
+	)
+}
+
+// mergeDocument three-way merges content into an existing document using the
+// base revision supplied for its path. Without a base, or when both sides changed
+// the same lines, the document is left untouched and reported as a conflict.
+func (imp *documentImporter) mergeDocument(
+	ctx context.Context,
+	projectID string,
+	userID string,
+	source string,
+	folderPath string,
+	docName string,
+	docID string,
+	content string,
+	baseRevisions map[string]string,
+	result *docsysSvc.ImportResult,
+) {
+	fullPath := BuildFullPath(folderPath, docName)
+	base, ok := baseRevisions[fullPath]
+	if !ok {
+		imp.conflictDocument(result, docID, folderPath, docName, "no base revision provided for merge")
+		return
+	}
+
+	existing, err := imp.docService.GetDocument(ctx, userID, docID)
+	if err != nil {
+		imp.addError(result, source, fmt.Sprintf("failed to load existing document: %v", err))
+		return
+	}
+
+	merged, conflicts := MergeText(base, existing.Content, content)
+	if conflicts > 0 {
+		imp.conflictDocument(result, docID, folderPath, docName,
+			fmt.Sprintf("%d conflicting region(s) changed on both sides", conflicts))
+		return
+	}
+	if merged == existing.Content {
+		imp.unchangedDocument(result, docID, folderPath, docName)
+		return
+	}
+
+	imp.updateDocument(ctx, projectID, userID, source, docID, merged, "merged", result)
+}
+
+// skipDocument records a document left untouched
+func (imp *documentImporter) skipDocument(
+	result *docsysSvc.ImportResult,
+	docID string,
+	folderPath string,
+	docName string,
+	detail string,
+) {
+	result.Summary.Skipped++
+	result.Documents = append(result.Documents, docsysSvc.ImportDocument{
+		ID:     docID,
+		Path:   BuildFullPath(folderPath, docName),
+		Name:   docName,
+		Action: "skipped",
+		Detail: detail,
+	})
+
+	imp.logger.Debug("document skipped",
+		"folder_path", folderPath,
+		"name", docName,
+		"reason", detail,
+	)
+}
+
+// unchangedDocument records a file whose content already matches the existing document
+func (imp *documentImporter) unchangedDocument(
+	result *docsysSvc.ImportResult,
+	docID string,
+	folderPath string,
+	docName string,
+) {
+	result.Summary.Unchanged++
+	result.Documents = append(result.Documents, docsysSvc.ImportDocument{
+		ID:     docID,
+		Path:   BuildFullPath(folderPath, docName),
+		Name:   docName,
+		Action: "unchanged",
+	})
+
+	imp.logger.Debug("document unchanged",
+		"folder_path", folderPath,
+		"name", docName,
+	)
+}
+
+// conflictDocument records a merge that could not be applied
+func (imp *documentImporter) conflictDocument(
+	result *docsysSvc.ImportResult,
+	docID string,
+	folderPath string,
+	docName string,
+	detail string,
+) {
+	result.Summary.Conflicts++
+	result.Documents = append(result.Documents, docsysSvc.ImportDocument{
+		ID:     docID,
+		Path:   BuildFullPath(folderPath, docName),
+		Name:   docName,
+		Action: "conflict",
+		Detail: detail,
+	})
+
+	imp.logger.Debug("document merge conflict",
+		"folder_path", folderPath,
+		"name", docName,
+		"reason", detail,
+	)
+}
+
+// addError adds an error to the result
+func (imp *documentImporter) addError(result *docsysSvc.ImportResult, file string, errorMsg string) {
+	result.Summary.Failed++
+	result.Errors = append(result.Errors, docsysSvc.ImportError{
+		File:  file,
+		Error: errorMsg,
+	})
+
+	imp.logger.Warn("file processing failed",
+		"file", file,
+		"error", errorMsg,
+	)
+}
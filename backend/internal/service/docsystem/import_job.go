@@ -0,0 +1,122 @@
+package docsystem
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	models "meridian/internal/domain/models/docsystem"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+
+	"github.com/google/uuid"
+)
+
+// importJobParams is the input of an import job, stored on the job row
+type importJobParams struct {
+	Files         []importJobFile            `json:"files"`
+	FolderPath    string                     `json:"folder_path"`
+	Conflict      docsysSvc.ConflictStrategy `json:"conflict"`
+	BaseRevisions map[string]string          `json:"base_revisions,omitempty"`
+	ReplaceAll    bool                       `json:"replace_all"`
+}
+
+// importJobFile is an uploaded file held in object storage until its job has run
+type importJobFile struct {
+	Name       string `json:"name"`
+	StorageKey string `json:"storage_key"`
+}
+
+// StartImport stores the uploaded files and queues an import job
+func (s *importService) StartImport(ctx context.Context, projectID, userID string, files []docsysSvc.UploadedFile, folderPath string, opts docsysSvc.ImportOptions) (*models.Job, error) {
+	params := importJobParams{
+		Files:         make([]importJobFile, 0, len(files)),
+		FolderPath:    folderPath,
+		Conflict:      opts.Conflict,
+		BaseRevisions: opts.BaseRevisions,
+		ReplaceAll:    opts.ReplaceAll,
+	}
+
+	// The request's multipart files are removed when it returns; keep the uploads
+	// until a worker has imported them
+	batchID := uuid.NewString()
+	for i, file := range files {
+		data, err := io.ReadAll(file.Content)
+		if err != nil {
+			s.deleteUploads(params.Files)
+			return nil, fmt.Errorf("read uploaded file %s: %w", file.Filename, err)
+		}
+		key := importUploadKey(projectID, batchID, i)
+		if err := s.objectStore.Put(ctx, key, "application/octet-stream", data); err != nil {
+			s.deleteUploads(params.Files)
+			return nil, fmt.Errorf("store uploaded file %s: %w", file.Filename, err)
+		}
+		params.Files = append(params.Files, importJobFile{Name: file.Filename, StorageKey: key})
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		s.deleteUploads(params.Files)
+		return nil, fmt.Errorf("encode import job: %w", err)
+	}
+	job := &models.Job{
+		ProjectID: projectID,
+		UserID:    userID,
+		Kind:      models.JobKindImport,
+		Params:    data,
+	}
+	if err := s.jobs.Enqueue(ctx, job); err != nil {
+		s.deleteUploads(params.Files)
+		return nil, err
+	}
+	return job, nil
+}
+
+// RunJob imports a job's uploaded files, reporting each file to progress
+func (s *importService) RunJob(ctx context.Context, job *models.Job, progress docsysSvc.JobProgressFunc) (any, error) {
+	var params importJobParams
+	if err := json.Unmarshal(job.Params, &params); err != nil {
+		return nil, fmt.Errorf("decode import job: %w", err)
+	}
+	defer func() {
+		// A job interrupted by shutdown is requeued and needs its uploads again
+		if ctx.Err() == nil {
+			s.deleteUploads(params.Files)
+		}
+	}()
+
+	files := make([]docsysSvc.UploadedFile, len(params.Files))
+	for i, file := range params.Files {
+		object, err := s.objectStore.Get(ctx, file.StorageKey)
+		if err != nil {
+			return nil, fmt.Errorf("load uploaded file %s: %w", file.Name, err)
+		}
+		files[i] = docsysSvc.UploadedFile{Filename: file.Name, Content: bytes.NewReader(object.Data)}
+	}
+
+	result, err := s.ProcessFiles(ctx, job.ProjectID, job.UserID, files, params.FolderPath, docsysSvc.ImportOptions{
+		Conflict:        params.Conflict,
+		BaseRevisions:   params.BaseRevisions,
+		ReplaceAll:      params.ReplaceAll,
+		OnFileProcessed: progress,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// deleteUploads removes a job's uploaded files from object storage
+func (s *importService) deleteUploads(files []importJobFile) {
+	for _, file := range files {
+		if err := s.objectStore.Delete(context.Background(), file.StorageKey); err != nil {
+			s.logger.Warn("failed to remove uploaded import file", "key", file.StorageKey, "error", err)
+		}
+	}
+}
+
+// importUploadKey is where an uploaded file waits for its job: imports/{project_id}/{batch_id}/{index}
+func importUploadKey(projectID, batchID string, index int) string {
+	return fmt.Sprintf("imports/%s/%s/%d", projectID, batchID, index)
+}
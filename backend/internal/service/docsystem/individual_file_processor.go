@@ -8,22 +8,22 @@ import (
 	"path/filepath"
 	"strings"
 
-	docsysModels "meridian/internal/domain/models/docsystem"
+	"meridian/internal/domain/repositories"
 	docsysRepo "meridian/internal/domain/repositories/docsystem"
 	docsysSvc "meridian/internal/domain/services/docsystem"
 	"meridian/internal/service/docsystem/converter"
 )
 
-// individualFileProcessor processes individual files (.md, .txt, .html).
+// individualFileProcessor processes individual files (.md, .txt, .html, .pdf, .docx).
 // Implements FileProcessor interface using Strategy pattern.
 //
 // Responsibilities:
 //   - Handle single file uploads (non-zip)
 //   - Route to appropriate ContentConverter based on extension
-//   - Detect duplicates and handle create/update/skip decisions
+//   - Detect duplicates and apply the conflict strategy
 type individualFileProcessor struct {
 	docRepo           docsysRepo.DocumentRepository
-	docService        docsysSvc.DocumentService
+	importer          *documentImporter
 	converterRegistry *converter.ConverterRegistry
 	logger            *slog.Logger
 }
@@ -32,12 +32,13 @@ type individualFileProcessor struct {
 func NewIndividualFileProcessor(
 	docRepo docsysRepo.DocumentRepository,
 	docService docsysSvc.DocumentService,
+	txManager repositories.TransactionManager,
 	converterRegistry *converter.ConverterRegistry,
 	logger *slog.Logger,
 ) docsysSvc.FileProcessor {
 	return &individualFileProcessor{
 		docRepo:           docRepo,
-		docService:        docService,
+		importer:          &documentImporter{docService: docService, txManager: txManager, logger: logger},
 		converterRegistry: converterRegistry,
 		logger:            logger,
 	}
@@ -51,7 +52,7 @@ func (p *individualFileProcessor) CanProcess(filename string) bool {
 }
 
 // Process imports a single file as a document
-// If overwrite is true, existing documents are updated; if false, duplicates are skipped
+// opts.Conflict decides what happens when the document already exists
 func (p *individualFileProcessor) Process(
 	ctx context.Context,
 	projectID string,
@@ -59,14 +60,17 @@ func (p *individualFileProcessor) Process(
 	file io.Reader,
 	filename string,
 	folderPath string,
-	overwrite bool,
+	opts docsysSvc.ImportOptions,
 ) (*docsysSvc.ImportResult, error) {
+	folderPath = NormalizeFolderPath(folderPath) // Match stored (normalized) folder names
+
 	// Initialize result
 	result := &docsysSvc.ImportResult{
 		Summary:   docsysSvc.ImportSummary{TotalFiles: 1},
 		Errors:    []docsysSvc.ImportError{},
 		Documents: []docsysSvc.ImportDocument{},
 	}
+	defer func() { opts.ReportFile(filename, result.Errors) }()
 
 	// Read file content
 	content, err := io.ReadAll(file)
@@ -99,7 +103,7 @@ func (p *individualFileProcessor) Process(
 	docName = SanitizeDocName(docName) // Replace invalid characters
 
 	// Check for existing document with same name in target folder
-	existingDoc, err := p.findExistingDocument(ctx, projectID, folderPath, docName)
+	docMap, err := buildDocMap(ctx, p.docRepo, projectID)
 	if err != nil {
 		result.Summary.Failed = 1
 		result.Errors = append(result.Errors, docsysSvc.ImportError{
@@ -110,128 +114,10 @@ func (p *individualFileProcessor) Process(
 		return result, nil
 	}
 
-	if existingDoc != nil {
-		if overwrite {
-			// Update existing document
-			doc, err := p.docService.UpdateDocument(ctx, userID, existingDoc.ID, &docsysSvc.UpdateDocumentRequest{
-				ProjectID: projectID,
-				Content:   &markdown,
-			})
-			if err != nil {
-				result.Summary.Failed = 1
-				result.Errors = append(result.Errors, docsysSvc.ImportError{
-					File:  filename,
-					Error: fmt.Sprintf("failed to update document: %v", err),
-				})
-				p.logger.Warn("failed to update document", "filename", filename, "error", err)
-				return result, nil
-			}
-
-			result.Summary.Updated = 1
-			result.Documents = append(result.Documents, docsysSvc.ImportDocument{
-				ID:     doc.ID,
-				Path:   doc.Path,
-				Name:   doc.Name,
-				Action: "updated",
-			})
-
-			p.logger.Debug("individual file updated",
-				"filename", filename,
-				"doc_id", doc.ID,
-				"folder_path", folderPath,
-			)
-		} else {
-			// Skip duplicate - document already exists and overwrite is false
-			result.Summary.Skipped = 1
-			result.Documents = append(result.Documents, docsysSvc.ImportDocument{
-				ID:     existingDoc.ID,
-				Path:   BuildFullPath(folderPath, docName),
-				Name:   docName,
-				Action: "skipped",
-			})
-
-			p.logger.Debug("individual file skipped (duplicate)",
-				"filename", filename,
-				"folder_path", folderPath,
-			)
-		}
-		return result, nil
-	}
-
-	// Create new document
-	doc, err := p.docService.CreateDocument(ctx, &docsysSvc.CreateDocumentRequest{
-		ProjectID:  projectID,
-		UserID:     userID,
-		FolderPath: &folderPath, // Use provided folder path (empty string = root)
-		Name:       docName,
-		Content:    markdown,
-	})
-
-	if err != nil {
-		result.Summary.Failed = 1
-		result.Errors = append(result.Errors, docsysSvc.ImportError{
-			File:  filename,
-			Error: fmt.Sprintf("failed to create document: %v", err),
-		})
-		p.logger.Warn("failed to create document", "filename", filename, "error", err)
-		return result, nil
-	}
-
-	// Success
-	result.Summary.Created = 1
-	result.Documents = append(result.Documents, docsysSvc.ImportDocument{
-		ID:     doc.ID,
-		Path:   doc.Path,
-		Name:   doc.Name,
-		Action: "created",
-	})
-
-	p.logger.Debug("individual file imported",
-		"filename", filename,
-		"doc_id", doc.ID,
-		"folder_path", folderPath,
-	)
-
+	p.importer.importDocument(ctx, projectID, userID, filename, folderPath, docName, markdown, docMap, opts, result)
 	return result, nil
 }
 
-// findExistingDocument checks if a document with the given name exists in the target folder.
-//
-// Performance Note: This scans ALL documents in the project (O(n) where n = document count).
-// Acceptable for projects with < 1000 documents. For larger projects, consider:
-//   - Adding a database index on (project_id, folder_path, name)
-//   - Caching the document map across multiple imports
-//   - Using a single bulk lookup at the start (like ZipFileProcessor does)
-func (p *individualFileProcessor) findExistingDocument(
-	ctx context.Context,
-	projectID string,
-	folderPath string,
-	docName string,
-) (*docsysModels.Document, error) {
-	// Fetch all documents - see Performance Note above
-	docs, err := p.docRepo.GetAllMetadataByProject(ctx, projectID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Build target lookup key using the same format as docMap in ZipFileProcessor
-	targetPath := BuildFullPath(folderPath, docName)
-	targetKey := BuildLookupKey(targetPath, docName)
-
-	for _, doc := range docs {
-		path, err := p.docRepo.GetPath(ctx, &doc)
-		if err != nil {
-			continue
-		}
-
-		if BuildLookupKey(path, doc.Name) == targetKey {
-			return &doc, nil
-		}
-	}
-
-	return nil, nil
-}
-
 // Name returns the processor name
 func (p *individualFileProcessor) Name() string {
 	return "IndividualFileProcessor"
@@ -0,0 +1,310 @@
+package docsystem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+const (
+	// jobPollInterval is how often idle workers check the queue for jobs enqueued by
+	// other server instances (local enqueues wake a worker immediately)
+	jobPollInterval = 5 * time.Second
+
+	// jobTimeout bounds one job
+	jobTimeout = 30 * time.Minute
+
+	// jobProgressInterval is the minimum time between progress writes of a running job
+	jobProgressInterval = 500 * time.Millisecond
+
+	// jobWatchInterval is how often WatchJob checks a job for progress
+	jobWatchInterval = time.Second
+)
+
+// jobService implements the JobService interface
+type jobService struct {
+	jobRepo    docsysRepo.JobRepository
+	authorizer services.ResourceAuthorizer
+	workers    int
+	logger     *slog.Logger
+
+	mu       sync.RWMutex
+	handlers map[string]docsysSvc.JobHandler
+	wake     chan struct{}
+
+	watchInterval time.Duration
+}
+
+// NewJobService creates a job service whose pool runs up to workers jobs at once
+func NewJobService(
+	jobRepo docsysRepo.JobRepository,
+	authorizer services.ResourceAuthorizer,
+	workers int,
+	logger *slog.Logger,
+) docsysSvc.JobService {
+	if workers < 1 {
+		workers = 1
+	}
+	return &jobService{
+		jobRepo:       jobRepo,
+		authorizer:    authorizer,
+		workers:       workers,
+		logger:        logger,
+		handlers:      make(map[string]docsysSvc.JobHandler),
+		wake:          make(chan struct{}, 1),
+		watchInterval: jobWatchInterval,
+	}
+}
+
+// Register sets the handler that runs jobs of a kind
+func (s *jobService) Register(kind string, handler docsysSvc.JobHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[kind] = handler
+}
+
+// Enqueue creates a pending job and wakes a worker
+func (s *jobService) Enqueue(ctx context.Context, job *models.Job) error {
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return err
+	}
+
+	s.logger.Info("job queued", "id", job.ID, "kind", job.Kind, "project_id", job.ProjectID)
+
+	select {
+	case s.wake <- struct{}{}:
+	default: // A wake-up is already pending
+	}
+	return nil
+}
+
+// GetJob returns a job's status and progress
+func (s *jobService) GetJob(ctx context.Context, userID, jobID string) (*models.Job, error) {
+	job, err := s.jobRepo.Get(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizer.CanAccessProject(ctx, userID, job.ProjectID); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// WatchJob sends the job's state now and whenever its progress changes, until it finishes
+func (s *jobService) WatchJob(ctx context.Context, userID, jobID string) (<-chan *models.Job, error) {
+	job, err := s.GetJob(ctx, userID, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(chan *models.Job)
+	go func() {
+		defer close(updates)
+
+		ticker := time.NewTicker(s.watchInterval)
+		defer ticker.Stop()
+
+		sent := job
+		select {
+		case updates <- job:
+		case <-ctx.Done():
+			return
+		}
+		for !sent.IsFinished() {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+
+			job, err := s.jobRepo.Get(ctx, jobID)
+			if err != nil {
+				if ctx.Err() == nil {
+					s.logger.Warn("failed to poll job", "id", jobID, "error", err)
+				}
+				return
+			}
+			if !jobProgressed(sent, job) {
+				continue
+			}
+			select {
+			case updates <- job:
+				sent = job
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return updates, nil
+}
+
+// jobProgressed reports whether a watcher that saw before should be sent after
+func jobProgressed(before, after *models.Job) bool {
+	return before.Status != after.Status ||
+		before.FilesProcessed != after.FilesProcessed ||
+		len(before.Errors) != len(after.Errors)
+}
+
+// Run runs the worker pool until ctx is cancelled
+func (s *jobService) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for range s.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+// work runs queued jobs one at a time, sleeping while the queue is empty
+func (s *jobService) work(ctx context.Context) {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for s.runNext(ctx) {
+		}
+		select {
+		case <-s.wake:
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runNext claims and runs the oldest pending job. Returns false if there was none
+// (or the queue couldn't be read).
+func (s *jobService) runNext(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	job, err := s.jobRepo.ClaimNext(ctx)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) && ctx.Err() == nil {
+			s.logger.Error("failed to claim job", "error", err)
+		}
+		return false
+	}
+	s.run(ctx, job)
+	return true
+}
+
+// run runs a claimed job with its kind's handler and stores the outcome
+func (s *jobService) run(ctx context.Context, job *models.Job) {
+	logger := s.logger.With("id", job.ID, "kind", job.Kind, "project_id", job.ProjectID)
+
+	s.mu.RLock()
+	handler, ok := s.handlers[job.Kind]
+	s.mu.RUnlock()
+	if !ok {
+		s.fail(logger, job.ID, fmt.Errorf("no handler for %s jobs", job.Kind))
+		return
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+	defer cancel()
+
+	progress := &jobProgress{jobRepo: s.jobRepo, jobID: job.ID, logger: logger, errors: []models.JobError{}}
+	logger.Info("job started")
+
+	result, err := handler(jobCtx, job, progress.record)
+	if err != nil {
+		if ctx.Err() != nil {
+			// Shutting down: the handler's work was rolled back; another run can redo it
+			if releaseErr := s.jobRepo.Release(context.Background(), job.ID); releaseErr != nil {
+				logger.Error("failed to requeue interrupted job", "error", releaseErr)
+			} else {
+				logger.Info("job interrupted by shutdown; requeued")
+			}
+			return
+		}
+		s.fail(logger, job.ID, err)
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		s.fail(logger, job.ID, fmt.Errorf("encode job result: %w", err))
+		return
+	}
+	processed, fileErrors := progress.snapshot()
+	if err := s.jobRepo.Complete(context.Background(), job.ID, processed, fileErrors, data); err != nil {
+		logger.Error("failed to record job completion", "error", err)
+		return
+	}
+
+	logger.Info("job completed", "files_processed", processed, "file_errors", len(fileErrors))
+}
+
+// fail records a job failure
+func (s *jobService) fail(logger *slog.Logger, jobID string, err error) {
+	logger.Error("job failed", "error", err)
+	if failErr := s.jobRepo.Fail(context.Background(), jobID, err.Error()); failErr != nil {
+		logger.Error("failed to record job failure", "error", failErr)
+	}
+}
+
+// FailInterrupted fails jobs left running by a previous process
+func (s *jobService) FailInterrupted(ctx context.Context) error {
+	failed, err := s.jobRepo.FailRunning(ctx, "job was interrupted by a server restart; please start it again")
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		s.logger.Warn("failed interrupted jobs", "count", failed)
+	}
+	return nil
+}
+
+// jobProgress collects a running job's progress and writes it at most every
+// jobProgressInterval, so large imports don't write once per file
+type jobProgress struct {
+	jobRepo docsysRepo.JobRepository
+	jobID   string
+	logger  *slog.Logger
+
+	mu        sync.Mutex
+	processed int
+	errors    []models.JobError
+	written   time.Time
+}
+
+// record counts one processed file (a JobProgressFunc)
+func (p *jobProgress) record(file, fileErr string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.processed++
+	if fileErr != "" {
+		p.errors = append(p.errors, models.JobError{File: file, Error: fileErr})
+	}
+
+	if time.Since(p.written) < jobProgressInterval {
+		return
+	}
+	p.written = time.Now()
+	// Written outside the job's transaction, so watchers see progress as it happens
+	if err := p.jobRepo.UpdateProgress(context.Background(), p.jobID, p.processed, p.errors); err != nil {
+		p.logger.Warn("failed to record job progress", "error", err)
+	}
+}
+
+// snapshot returns the progress so far
+func (p *jobProgress) snapshot() (int, []models.JobError) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.processed, p.errors
+}
@@ -0,0 +1,243 @@
+package docsystem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+// memoryJobRepo is an in-memory job queue
+type memoryJobRepo struct {
+	docsysRepo.JobRepository
+	mu   sync.Mutex
+	jobs []*models.Job
+}
+
+func (r *memoryJobRepo) Create(ctx context.Context, job *models.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job.ID = fmt.Sprintf("job-%d", len(r.jobs)+1)
+	job.Status = models.JobStatusPending
+	stored := *job
+	r.jobs = append(r.jobs, &stored)
+	return nil
+}
+
+func (r *memoryJobRepo) Get(ctx context.Context, id string) (*models.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, job := range r.jobs {
+		if job.ID == id {
+			copied := *job
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *memoryJobRepo) ClaimNext(ctx context.Context) (*models.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, job := range r.jobs {
+		if job.Status == models.JobStatusPending {
+			job.Status = models.JobStatusRunning
+			copied := *job
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *memoryJobRepo) update(id string, fn func(job *models.Job)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, job := range r.jobs {
+		if job.ID == id {
+			fn(job)
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+func (r *memoryJobRepo) UpdateProgress(ctx context.Context, id string, filesProcessed int, errors []models.JobError) error {
+	return r.update(id, func(job *models.Job) {
+		job.FilesProcessed = filesProcessed
+		job.Errors = append([]models.JobError(nil), errors...)
+	})
+}
+
+func (r *memoryJobRepo) Complete(ctx context.Context, id string, filesProcessed int, errors []models.JobError, result json.RawMessage) error {
+	return r.update(id, func(job *models.Job) {
+		job.Status = models.JobStatusCompleted
+		job.FilesProcessed = filesProcessed
+		job.Errors = errors
+		job.Result = result
+	})
+}
+
+func (r *memoryJobRepo) Release(ctx context.Context, id string) error {
+	return r.update(id, func(job *models.Job) {
+		job.Status = models.JobStatusPending
+		job.FilesProcessed = 0
+		job.Errors = nil
+	})
+}
+
+func (r *memoryJobRepo) Fail(ctx context.Context, id, message string) error {
+	return r.update(id, func(job *models.Job) {
+		job.Status = models.JobStatusFailed
+		job.Error = &message
+	})
+}
+
+// allowAllAuthorizer grants access to everything
+type allowAllAuthorizer struct {
+	services.ResourceAuthorizer
+}
+
+func (allowAllAuthorizer) CanAccessProject(ctx context.Context, userID, projectID string) error {
+	return nil
+}
+
+func TestJobService_Run(t *testing.T) {
+	tests := []struct {
+		name          string
+		kind          string
+		handler       docsysSvc.JobHandler
+		wantStatus    string
+		wantProcessed int
+		wantErrors    int
+		wantResult    string
+	}{
+		{
+			name: "completes with progress",
+			kind: models.JobKindImport,
+			handler: func(ctx context.Context, job *models.Job, progress docsysSvc.JobProgressFunc) (any, error) {
+				progress("intro.md", "")
+				progress("broken.docx", "failed to convert file")
+				progress("outro.md", "")
+				return map[string]int{"created": 2}, nil
+			},
+			wantStatus:    models.JobStatusCompleted,
+			wantProcessed: 3,
+			wantErrors:    1,
+			wantResult:    `{"created":2}`,
+		},
+		{
+			name: "handler error",
+			kind: models.JobKindImport,
+			handler: func(ctx context.Context, job *models.Job, progress docsysSvc.JobProgressFunc) (any, error) {
+				return nil, errors.New("zip is corrupt")
+			},
+			wantStatus: models.JobStatusFailed,
+		},
+		{
+			name:       "unknown kind",
+			kind:       "reindex",
+			wantStatus: models.JobStatusFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memoryJobRepo{}
+			s := NewJobService(repo, allowAllAuthorizer{}, 1, slog.New(slog.DiscardHandler))
+			if tt.handler != nil {
+				s.Register(models.JobKindImport, tt.handler)
+			}
+
+			job := &models.Job{ProjectID: "project-1", UserID: "user-1", Kind: tt.kind}
+			if err := s.Enqueue(context.Background(), job); err != nil {
+				t.Fatalf("Enqueue: %v", err)
+			}
+			if !s.(*jobService).runNext(context.Background()) {
+				t.Fatal("runNext found no job")
+			}
+
+			got, err := s.GetJob(context.Background(), "user-1", job.ID)
+			if err != nil {
+				t.Fatalf("GetJob: %v", err)
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s (error: %v)", got.Status, tt.wantStatus, got.Error)
+			}
+			if got.FilesProcessed != tt.wantProcessed || len(got.Errors) != tt.wantErrors {
+				t.Errorf("progress = %d files, %d errors; want %d, %d", got.FilesProcessed, len(got.Errors), tt.wantProcessed, tt.wantErrors)
+			}
+			if string(got.Result) != tt.wantResult {
+				t.Errorf("result = %s, want %s", got.Result, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestJobService_RunRequeuesOnShutdown(t *testing.T) {
+	repo := &memoryJobRepo{}
+	s := NewJobService(repo, allowAllAuthorizer{}, 1, slog.New(slog.DiscardHandler))
+
+	ctx, shutdown := context.WithCancel(context.Background())
+	s.Register(models.JobKindImport, func(jobCtx context.Context, job *models.Job, progress docsysSvc.JobProgressFunc) (any, error) {
+		progress("intro.md", "")
+		shutdown()
+		<-jobCtx.Done()
+		return nil, jobCtx.Err()
+	})
+
+	job := &models.Job{ProjectID: "project-1", UserID: "user-1", Kind: models.JobKindImport}
+	if err := s.Enqueue(context.Background(), job); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	s.Run(ctx)
+
+	got, _ := repo.Get(context.Background(), job.ID)
+	if got.Status != models.JobStatusPending || got.FilesProcessed != 0 {
+		t.Errorf("interrupted job = %s with %d files, want pending with none", got.Status, got.FilesProcessed)
+	}
+}
+
+func TestJobService_WatchJob(t *testing.T) {
+	repo := &memoryJobRepo{}
+	s := NewJobService(repo, allowAllAuthorizer{}, 1, slog.New(slog.DiscardHandler)).(*jobService)
+	s.watchInterval = time.Millisecond
+
+	job := &models.Job{ProjectID: "project-1", UserID: "user-1", Kind: models.JobKindImport}
+	if err := s.Enqueue(context.Background(), job); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	updates, err := s.WatchJob(ctx, "user-1", job.ID)
+	if err != nil {
+		t.Fatalf("WatchJob: %v", err)
+	}
+
+	if first := <-updates; first.Status != models.JobStatusPending {
+		t.Fatalf("first update = %s, want the current (pending) state", first.Status)
+	}
+	repo.UpdateProgress(ctx, job.ID, 1, nil)
+	if update := <-updates; update.FilesProcessed != 1 {
+		t.Fatalf("update = %d files, want 1", update.FilesProcessed)
+	}
+	repo.Complete(ctx, job.ID, 2, nil, json.RawMessage(`{}`))
+
+	var statuses []string
+	for update := range updates {
+		statuses = append(statuses, update.Status)
+	}
+	if len(statuses) != 1 || statuses[0] != models.JobStatusCompleted {
+		t.Errorf("remaining updates = %v, want only the completed state before the channel closes", statuses)
+	}
+}
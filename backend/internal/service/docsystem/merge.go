@@ -0,0 +1,221 @@
+package docsystem
+
+import (
+	"strings"
+)
+
+// merge.go - Line-based three-way merge for Markdown content (diff3 style).
+//
+// Used by the "merge" import conflict strategy: given the base revision both sides
+// started from, edits made on only one side are combined automatically; regions
+// edited differently on both sides are conflicts.
+
+// maxDiffEdits bounds the Myers diff; beyond it the middle section counts as fully
+// rewritten (which usually ends up as a single conflict rather than a slow merge).
+const maxDiffEdits = 1000
+
+// Conflict markers written around conflicting regions of a merge result
+const (
+	conflictMarkerCurrent  = "<<<<<<< current\n"
+	conflictMarkerDivider  = "=======\n"
+	conflictMarkerImported = ">>>>>>> imported\n"
+)
+
+// MergeText three-way merges current and incoming, which both derive from base.
+// Returns the merged text and the number of conflicting regions. Conflicting regions
+// are written with git-style markers (current first, then imported).
+func MergeText(base, current, incoming string) (string, int) {
+	if current == incoming || incoming == base {
+		return current, 0
+	}
+	if current == base {
+		return incoming, 0
+	}
+
+	baseLines := splitLines(base)
+	currentLines := splitLines(current)
+	incomingLines := splitLines(incoming)
+
+	toCurrent := lineMatches(baseLines, currentLines)
+	toIncoming := lineMatches(baseLines, incomingLines)
+
+	var out strings.Builder
+	conflicts := 0
+	b, c, i := 0, 0, 0 // Next unconsumed line in base, current, incoming
+
+	// emit resolves the region before the stable base line at index stable
+	// (len(baseLines) for the tail)
+	emit := func(stable, currentEnd, incomingEnd int) {
+		baseChunk := baseLines[b:stable]
+		currentChunk := currentLines[c:currentEnd]
+		incomingChunk := incomingLines[i:incomingEnd]
+
+		switch {
+		case equalLines(currentChunk, baseChunk):
+			writeLines(&out, incomingChunk)
+		case equalLines(incomingChunk, baseChunk), equalLines(currentChunk, incomingChunk):
+			writeLines(&out, currentChunk)
+		default:
+			conflicts++
+			out.WriteString(conflictMarkerCurrent)
+			writeLines(&out, currentChunk)
+			ensureNewline(&out)
+			out.WriteString(conflictMarkerDivider)
+			writeLines(&out, incomingChunk)
+			ensureNewline(&out)
+			out.WriteString(conflictMarkerImported)
+		}
+	}
+
+	// Stable lines are base lines kept unchanged on both sides
+	for stable := range baseLines {
+		currentIdx, inCurrent := toCurrent[stable]
+		incomingIdx, inIncoming := toIncoming[stable]
+		if !inCurrent || !inIncoming {
+			continue
+		}
+
+		emit(stable, currentIdx, incomingIdx)
+		out.WriteString(baseLines[stable])
+		b, c, i = stable+1, currentIdx+1, incomingIdx+1
+	}
+	emit(len(baseLines), len(currentLines), len(incomingLines))
+
+	return out.String(), conflicts
+}
+
+// splitLines splits text into lines, keeping each line's trailing newline
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func writeLines(out *strings.Builder, lines []string) {
+	for _, line := range lines {
+		out.WriteString(line)
+	}
+}
+
+// ensureNewline keeps conflict markers on their own line when a side lacks a final newline
+func ensureNewline(out *strings.Builder) {
+	if s := out.String(); s != "" && !strings.HasSuffix(s, "\n") {
+		out.WriteByte('\n')
+	}
+}
+
+// lineMatches returns the lines of a that are kept in b (a longest common subsequence),
+// as a map from index in a to index in b.
+func lineMatches(a, b []string) map[int]int {
+	matches := make(map[int]int)
+
+	// Common prefix and suffix are matched directly; only the middle is diffed
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		matches[prefix] = prefix
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		matches[len(a)-1-suffix] = len(b) - 1 - suffix
+		suffix++
+	}
+
+	for ai, bi := range myersMatches(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		matches[prefix+ai] = prefix + bi
+	}
+	return matches
+}
+
+// myersMatches runs Myers' O((N+M)D) diff and returns the matched line pairs.
+// Returns no matches when the edit distance exceeds maxDiffEdits.
+func myersMatches(a, b []string) map[int]int {
+	n, m := len(a), len(b)
+	matches := make(map[int]int)
+	if n == 0 || m == 0 {
+		return matches
+	}
+
+	// v[k] is the furthest x reached on diagonal k; trace[d] keeps diagonals
+	// -d-1..d+1 as they were before step d, for backtracking
+	offset := maxDiffEdits + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+
+	for d := 0; d <= maxDiffEdits; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // Insertion (move down)
+			} else {
+				x = v[offset+k-1] + 1 // Deletion (move right)
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				backtrackMatches(trace, n, m, matches)
+				return matches
+			}
+		}
+	}
+
+	return matches
+}
+
+// backtrackMatches walks the Myers trace from (n, m) back to the origin,
+// recording the diagonal (matching) moves
+func backtrackMatches(trace [][]int, n, m int, matches map[int]int) {
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		at := func(k int) int { return v[k+d+1] } // trace[d] starts at diagonal -d-1
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			matches[x] = y
+		}
+		if d > 0 {
+			x, y = prevX, prevY
+		}
+	}
+}
+
+// lineChanges counts lines added and removed going from before to after
+func lineChanges(before, after string) (added, removed int) {
+	beforeLines := splitLines(before)
+	afterLines := splitLines(after)
+	kept := len(lineMatches(beforeLines, afterLines))
+	return len(afterLines) - kept, len(beforeLines) - kept
+}
@@ -0,0 +1,84 @@
+package docsystem
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeText(t *testing.T) {
+	base := "# Title\n\nIntro line.\n\nMiddle line.\n\nEnd line.\n"
+
+	tests := []struct {
+		name          string
+		current       string
+		incoming      string
+		want          string
+		wantConflicts int
+	}{
+		{
+			name:     "only incoming changed",
+			current:  base,
+			incoming: strings.Replace(base, "Intro line.", "New intro.", 1),
+			want:     strings.Replace(base, "Intro line.", "New intro.", 1),
+		},
+		{
+			name:     "only current changed",
+			current:  strings.Replace(base, "End line.", "New end.", 1),
+			incoming: base,
+			want:     strings.Replace(base, "End line.", "New end.", 1),
+		},
+		{
+			name:     "non-overlapping edits on both sides",
+			current:  strings.Replace(base, "Intro line.", "Current intro.", 1),
+			incoming: strings.Replace(base, "End line.", "Imported end.", 1),
+			want:     "# Title\n\nCurrent intro.\n\nMiddle line.\n\nImported end.\n",
+		},
+		{
+			name:     "same edit on both sides",
+			current:  strings.Replace(base, "Middle line.", "Same.", 1),
+			incoming: strings.Replace(base, "Middle line.", "Same.", 1),
+			want:     strings.Replace(base, "Middle line.", "Same.", 1),
+		},
+		{
+			name:     "insertion and deletion",
+			current:  base + "Appended.\n",
+			incoming: strings.Replace(base, "Middle line.\n\n", "", 1),
+			want:     "# Title\n\nIntro line.\n\nEnd line.\nAppended.\n",
+		},
+		{
+			name:          "conflicting edits",
+			current:       strings.Replace(base, "Middle line.", "Current middle.", 1),
+			incoming:      strings.Replace(base, "Middle line.", "Imported middle.", 1),
+			want:          "# Title\n\nIntro line.\n\n<<<<<<< current\nCurrent middle.\n=======\nImported middle.\n>>>>>>> imported\n\nEnd line.\n",
+			wantConflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflicts := MergeText(base, tt.current, tt.incoming)
+			if conflicts != tt.wantConflicts {
+				t.Errorf("conflicts = %d, want %d", conflicts, tt.wantConflicts)
+			}
+			if got != tt.want {
+				t.Errorf("merged =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLineMatches(t *testing.T) {
+	a := []string{"a", "b", "c", "d", "e"}
+	b := []string{"a", "x", "c", "e", "f"}
+
+	got := lineMatches(a, b)
+	want := map[int]int{0: 0, 2: 2, 4: 3}
+	if len(got) != len(want) {
+		t.Fatalf("lineMatches() = %v, want %v", got, want)
+	}
+	for ai, bi := range want {
+		if got[ai] != bi {
+			t.Errorf("lineMatches()[%d] = %d, want %d", ai, got[ai], bi)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package docsystem
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+
+	"meridian/internal/utils"
+)
+
+// name_policy.go - Normalization and validation of folder and document names.
+//
+// Every name entering the system (API create/rename, path notation segments,
+// folder_path segments, imports) goes through NormalizeName and then ValidateName,
+// so "Chapter  1", " Chapter 1" and "Chapter 1" (or composed vs decomposed "é")
+// can't coexist as different siblings.
+
+// NameUniqueness modes (NAME_UNIQUENESS config)
+const (
+	// NameUniquenessExact treats names differing only in case as different (default)
+	NameUniquenessExact = "exact"
+	// NameUniquenessCaseInsensitive rejects siblings whose names differ only in case
+	NameUniquenessCaseInsensitive = "case_insensitive"
+)
+
+// NamePolicy decides when two sibling names collide
+type NamePolicy struct {
+	CaseInsensitive bool
+}
+
+// NewNamePolicy creates a policy from a NAME_UNIQUENESS value (unknown values mean exact)
+func NewNamePolicy(uniqueness string) NamePolicy {
+	return NamePolicy{
+		CaseInsensitive: strings.EqualFold(strings.TrimSpace(uniqueness), NameUniquenessCaseInsensitive),
+	}
+}
+
+var caseFolder = cases.Fold()
+
+// Key returns the comparison key for a name under this policy
+func (p NamePolicy) Key(name string) string {
+	key := NormalizeName(name)
+	if p.CaseInsensitive {
+		key = caseFolder.String(key)
+	}
+	return key
+}
+
+// SameName reports whether two names collide under this policy
+func (p NamePolicy) SameName(a, b string) bool {
+	return p.Key(a) == p.Key(b)
+}
+
+// NormalizeName canonicalizes a name: Unicode NFC, surrounding whitespace trimmed and
+// internal whitespace runs (including tabs and non-breaking spaces) collapsed to one space.
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(norm.NFC.String(name)), " ")
+}
+
+// ValidateName checks a normalized name: non-empty, at most maxLength characters,
+// no "/" (path separator), no control or invisible formatting characters, and not "." or "..".
+func ValidateName(name string, maxLength int) error {
+	if name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+
+	if utils.RuneLen(name) > maxLength {
+		return fmt.Errorf("name exceeds maximum length of %d", maxLength)
+	}
+
+	if strings.Contains(name, "/") {
+		return fmt.Errorf("name cannot contain '/' character")
+	}
+
+	if name == "." || name == ".." {
+		return fmt.Errorf("name cannot be '.' or '..'")
+	}
+
+	for _, r := range name {
+		if unicode.IsControl(r) || (unicode.Is(unicode.Cf, r) && r != '\u200d') {
+			return fmt.Errorf("name contains invalid character %U", r)
+		}
+	}
+
+	return nil
+}
+
+// NormalizeAndValidateName normalizes a name and validates the result
+func NormalizeAndValidateName(name string, maxLength int) (string, error) {
+	name = NormalizeName(name)
+	if err := ValidateName(name, maxLength); err != nil {
+		return "", err
+	}
+	return name, nil
+}
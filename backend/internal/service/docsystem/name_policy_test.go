@@ -0,0 +1,73 @@
+package docsystem
+
+import "testing"
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"trim", "  Chapter 1  ", "Chapter 1"},
+		{"collapse spaces", "Chapter   1", "Chapter 1"},
+		{"tabs and nbsp", "Chapter\t 1", "Chapter 1"},
+		{"nfc", "Café", "Café"},
+		{"unchanged", "第一章", "第一章"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeName(tt.input); got != tt.want {
+				t.Errorf("NormalizeName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		max     int
+		wantErr bool
+	}{
+		{"valid", "Chapter 1", 255, false},
+		{"zwj emoji", "Notes 👩\u200d💻", 255, false},
+		{"empty", "", 255, true},
+		{"slash", "a/b", 255, true},
+		{"dot", ".", 255, true},
+		{"dotdot", "..", 255, true},
+		{"control char", "bad\x07name", 255, true},
+		{"zero width space", "bad\u200bname", 255, true},
+		{"bidi override", "bad\u202ename", 255, true},
+		{"too long", "abcdef", 5, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateName(tt.input, tt.max)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateName(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNamePolicy_SameName(t *testing.T) {
+	exact := NewNamePolicy(NameUniquenessExact)
+	insensitive := NewNamePolicy(NameUniquenessCaseInsensitive)
+
+	if !exact.SameName("Chapter  1", " Chapter 1") {
+		t.Error("exact policy should treat whitespace variants as the same name")
+	}
+	if exact.SameName("Notes", "notes") {
+		t.Error("exact policy should treat case variants as different names")
+	}
+	if !insensitive.SameName("Notes", "NOTES") {
+		t.Error("case_insensitive policy should treat case variants as the same name")
+	}
+	if !insensitive.SameName("Straße", "STRASSE") {
+		t.Error("case_insensitive policy should use full case folding")
+	}
+	if NewNamePolicy("bogus").CaseInsensitive {
+		t.Error("unknown uniqueness values should fall back to exact")
+	}
+}
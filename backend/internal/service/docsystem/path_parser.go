@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"unicode"
+
+	"meridian/internal/utils"
 )
 
 // PathParseResult contains the parsed components of a path
@@ -64,16 +66,16 @@ func ParsePath(name string, maxSegmentLength int) (*PathParseResult, error) {
 			return nil, fmt.Errorf("path contains empty segment at position %d", i)
 		}
 
-		// Trim whitespace
-		segment = strings.TrimSpace(segment)
+		// Normalize (trim, collapse whitespace, NFC)
+		segment = NormalizeName(segment)
 		segments[i] = segment
 
 		if segment == "" {
 			return nil, fmt.Errorf("path segment at position %d is empty after trimming whitespace", i)
 		}
 
-		// Length check
-		if len(segment) > maxSegmentLength {
+		// Length check (in characters)
+		if utils.RuneLen(segment) > maxSegmentLength {
 			return nil, fmt.Errorf("path segment '%s' exceeds maximum length of %d", segment, maxSegmentLength)
 		}
 
@@ -107,25 +109,10 @@ func IsPathNotation(name string) bool {
 	return strings.Contains(name, "/")
 }
 
-// ValidateSimpleName validates a name that should NOT contain path notation.
-// This is used after path parsing to validate the final segment.
+// ValidateSimpleName validates a name that should NOT contain path notation
+// (after normalization, see name_policy.go).
 func ValidateSimpleName(name string, maxLength int) error {
-	name = strings.TrimSpace(name)
-
-	if name == "" {
-		return fmt.Errorf("name cannot be empty")
-	}
-
-	if len(name) > maxLength {
-		return fmt.Errorf("name exceeds maximum length of %d", maxLength)
-	}
-
-	// Simple names cannot contain slashes
-	if strings.Contains(name, "/") {
-		return fmt.Errorf("name cannot contain '/' character")
-	}
-
-	return nil
+	return ValidateName(NormalizeName(name), maxLength)
 }
 
 // ResolveParentID resolves the parent ID based on path notation rules:
@@ -10,21 +10,25 @@ import (
 	"meridian/internal/domain/repositories"
 	docsysRepo "meridian/internal/domain/repositories/docsystem"
 	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/utils"
 )
 
 type pathResolverService struct {
 	folderRepo docsysRepo.FolderRepository
 	txManager  repositories.TransactionManager
+	namePolicy NamePolicy // Matches existing folders when resolving path segments
 }
 
 // NewPathResolver creates a new path resolver service
 func NewPathResolver(
 	folderRepo docsysRepo.FolderRepository,
 	txManager repositories.TransactionManager,
+	namePolicy NamePolicy,
 ) docsysSvc.PathResolver {
 	return &pathResolverService{
 		folderRepo: folderRepo,
 		txManager:  txManager,
+		namePolicy: namePolicy,
 	}
 }
 
@@ -50,9 +54,14 @@ func (s *pathResolverService) ResolveFolderPath(ctx context.Context, projectID,
 		var currentParentID *string
 
 		for _, segment := range segments {
-			// Validate folder name
-			if len(segment) > config.MaxFolderNameLength {
-				return fmt.Errorf("folder name '%s' exceeds maximum length of %d", segment, config.MaxFolderNameLength)
+			// Normalize and validate folder name
+			segment, err := NormalizeAndValidateName(segment, config.MaxFolderNameLength)
+			if err != nil {
+				return fmt.Errorf("invalid folder name in folder_path: %w", err)
+			}
+			segment, err = s.existingFolderName(txCtx, projectID, currentParentID, segment)
+			if err != nil {
+				return err
 			}
 
 			// Create folder if it doesn't exist
@@ -84,7 +93,7 @@ func (s *pathResolverService) ValidateFolderPath(path string) error {
 	}
 
 	// Check length
-	if len(path) > config.MaxDocumentPathLength {
+	if utils.RuneLen(path) > config.MaxDocumentPathLength {
 		return fmt.Errorf("folder_path exceeds maximum length of %d", config.MaxDocumentPathLength)
 	}
 
@@ -121,9 +130,9 @@ func (s *pathResolverService) ValidateFolderPath(path string) error {
 func (s *pathResolverService) ResolvePathNotation(ctx context.Context, req *docsysSvc.PathNotationRequest) (*docsysSvc.PathNotationResult, error) {
 	// Check if name contains path notation
 	if !IsPathNotation(req.Name) {
-		// No path notation - just validate simple name and return
-		name := strings.TrimSpace(req.Name)
-		if err := ValidateSimpleName(name, req.MaxNameLength); err != nil {
+		// No path notation - just normalize and validate simple name and return
+		name, err := NormalizeAndValidateName(req.Name, req.MaxNameLength)
+		if err != nil {
 			return nil, fmt.Errorf("invalid name: %w", err)
 		}
 
@@ -186,10 +195,14 @@ func (s *pathResolverService) ResolvePathNotation(ctx context.Context, req *docs
 
 		// Create all intermediate folders (parent path)
 		for _, segment := range pathResult.ParentPath {
-			// Validate segment as folder name
-			if err := ValidateSimpleName(segment, config.MaxFolderNameLength); err != nil {
+			// Validate segment as folder name (ParsePath already normalized it)
+			if err := ValidateName(segment, config.MaxFolderNameLength); err != nil {
 				return fmt.Errorf("invalid folder name '%s': %w", segment, err)
 			}
+			segment, err := s.existingFolderName(txCtx, req.ProjectID, currentParentID, segment)
+			if err != nil {
+				return err
+			}
 
 			// Create folder if it doesn't exist (idempotent)
 			intermediateFolder, err := s.folderRepo.CreateIfNotExists(txCtx, req.ProjectID, currentParentID, segment)
@@ -211,7 +224,7 @@ func (s *pathResolverService) ResolvePathNotation(ctx context.Context, req *docs
 	}
 
 	// Validate final name (no slashes allowed)
-	if err := ValidateSimpleName(pathResult.FinalName, req.MaxNameLength); err != nil {
+	if err := ValidateName(pathResult.FinalName, req.MaxNameLength); err != nil {
 		return nil, fmt.Errorf("invalid final name '%s': %w", pathResult.FinalName, err)
 	}
 
@@ -220,3 +233,22 @@ func (s *pathResolverService) ResolvePathNotation(ctx context.Context, req *docs
 		FinalName:        pathResult.FinalName,
 	}, nil
 }
+
+// existingFolderName returns the name of an existing sibling folder that collides with
+// name under the name policy, so case-insensitive projects reuse "Chapters" for
+// "chapters/..." instead of creating a second folder. Returns name unchanged otherwise.
+func (s *pathResolverService) existingFolderName(ctx context.Context, projectID string, parentID *string, name string) (string, error) {
+	if !s.namePolicy.CaseInsensitive {
+		return name, nil // Exact matching is what CreateIfNotExists already does
+	}
+	siblings, err := s.folderRepo.ListChildren(ctx, parentID, projectID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list folders: %w", err)
+	}
+	for _, sibling := range siblings {
+		if s.namePolicy.SameName(sibling.Name, name) {
+			return sibling.Name, nil
+		}
+	}
+	return name, nil
+}
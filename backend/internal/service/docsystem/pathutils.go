@@ -42,9 +42,25 @@ func BuildLookupKey(path, name string) string {
 //
 // Currently handles:
 //   - "/" → "-" (prevents path injection, maintains readability)
+//   - Name normalization (see NormalizeName), so imported names match existing ones
 //
 // This is applied during import to ensure document names are valid for
 // the file system and don't interfere with path construction.
 func SanitizeDocName(name string) string {
-	return strings.ReplaceAll(name, "/", "-")
+	return NormalizeName(strings.ReplaceAll(name, "/", "-"))
+}
+
+// NormalizeFolderPath applies NormalizeName to every segment of an import folder path,
+// dropping empty segments, so lookup keys match the stored (normalized) folder names.
+//
+// Example:
+//   - NormalizeFolderPath(" Part  1 /intro") → "Part 1/intro"
+func NormalizeFolderPath(folderPath string) string {
+	segments := make([]string, 0, strings.Count(folderPath, "/")+1)
+	for _, segment := range strings.Split(folderPath, "/") {
+		if segment = NormalizeName(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return strings.Join(segments, "/")
 }
@@ -0,0 +1,214 @@
+package docsystem
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+const (
+	// defaultPresenceTTL applies when the configured TTL isn't positive
+	defaultPresenceTTL = time.Minute
+	// maxPresenceSessions caps the sessions kept per user and document; a heartbeat
+	// beyond it evicts the least recently seen one
+	maxPresenceSessions = 20
+	maxSessionIDLength  = 128
+	maxSessionLabelLen  = 100
+)
+
+// presenceKey identifies one user's sessions of one document
+type presenceKey struct {
+	userID     string
+	documentID string
+}
+
+// documentPresenceService implements the DocumentPresenceService interface. Sessions
+// live in memory, so each server instance only knows the heartbeats it received;
+// clients of one user normally reach the same instance. Safe for concurrent use.
+type documentPresenceService struct {
+	authorizer services.ResourceAuthorizer
+	ttl        time.Duration
+	now        func() time.Time
+	logger     *slog.Logger
+
+	mu       sync.Mutex
+	sessions map[presenceKey]map[string]*models.EditSession
+	sweptAt  time.Time
+}
+
+// NewDocumentPresenceService creates a presence service whose sessions expire ttl after
+// their last heartbeat
+func NewDocumentPresenceService(
+	authorizer services.ResourceAuthorizer,
+	ttl time.Duration,
+	logger *slog.Logger,
+) docsysSvc.DocumentPresenceService {
+	if ttl <= 0 {
+		ttl = defaultPresenceTTL
+	}
+	return &documentPresenceService{
+		authorizer: authorizer,
+		ttl:        ttl,
+		now:        time.Now,
+		logger:     logger,
+		sessions:   make(map[presenceKey]map[string]*models.EditSession),
+	}
+}
+
+// Heartbeat adds or refreshes the session
+func (s *documentPresenceService) Heartbeat(ctx context.Context, userID, documentID string, req *docsysSvc.PresenceHeartbeatRequest) (*models.DocumentPresence, error) {
+	if err := validateHeartbeat(req); err != nil {
+		return nil, err
+	}
+	if err := s.authorizer.CanAccessDocument(ctx, userID, documentID); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now().UTC()
+	s.sweep(now)
+
+	key := presenceKey{userID: userID, documentID: documentID}
+	sessions := s.sessions[key]
+	if sessions == nil {
+		sessions = make(map[string]*models.EditSession)
+		s.sessions[key] = sessions
+	}
+
+	session, ok := sessions[req.SessionID]
+	if !ok {
+		if len(sessions) >= maxPresenceSessions {
+			evictLeastRecent(sessions)
+		}
+		session = &models.EditSession{SessionID: req.SessionID, OpenedAt: now}
+		sessions[req.SessionID] = session
+		s.logger.Debug("edit session opened",
+			"document_id", documentID,
+			"session_id", req.SessionID,
+			"sessions", len(sessions),
+		)
+	}
+	session.Label = req.Label
+	session.LastSeenAt = now
+
+	return s.presence(key, req.SessionID, now), nil
+}
+
+// GetPresence lists the live sessions without refreshing any
+func (s *documentPresenceService) GetPresence(ctx context.Context, userID, documentID, sessionID string) (*models.DocumentPresence, error) {
+	if err := s.authorizer.CanAccessDocument(ctx, userID, documentID); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now().UTC()
+	s.sweep(now)
+	return s.presence(presenceKey{userID: userID, documentID: documentID}, sessionID, now), nil
+}
+
+// Leave needs no access check: sessions are keyed by user, so a user can only remove
+// their own, and leaving must work after the document is deleted
+func (s *documentPresenceService) Leave(ctx context.Context, userID, documentID, sessionID string) error {
+	if sessionID == "" {
+		return fmt.Errorf("%w: session_id is required", domain.ErrValidation)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := presenceKey{userID: userID, documentID: documentID}
+	sessions := s.sessions[key]
+	if _, ok := sessions[sessionID]; !ok {
+		return nil
+	}
+	delete(sessions, sessionID)
+	if len(sessions) == 0 {
+		delete(s.sessions, key)
+	}
+	s.logger.Debug("edit session closed", "document_id", documentID, "session_id", sessionID)
+	return nil
+}
+
+// presence builds the document's presence from its live sessions (caller holds mu)
+func (s *documentPresenceService) presence(key presenceKey, sessionID string, now time.Time) *models.DocumentPresence {
+	result := &models.DocumentPresence{
+		DocumentID: key.documentID,
+		Sessions:   []models.EditSession{},
+	}
+	for _, session := range s.sessions[key] {
+		if s.expired(session, now) {
+			continue
+		}
+		result.Sessions = append(result.Sessions, *session)
+		if session.SessionID != sessionID {
+			result.EditingElsewhere = true
+		}
+	}
+	sort.Slice(result.Sessions, func(i, j int) bool {
+		if !result.Sessions[i].OpenedAt.Equal(result.Sessions[j].OpenedAt) {
+			return result.Sessions[i].OpenedAt.Before(result.Sessions[j].OpenedAt)
+		}
+		return result.Sessions[i].SessionID < result.Sessions[j].SessionID
+	})
+	return result
+}
+
+// sweep drops expired sessions, at most once per ttl (caller holds mu). Sessions
+// expiring in between are filtered out when listed.
+func (s *documentPresenceService) sweep(now time.Time) {
+	if now.Sub(s.sweptAt) < s.ttl {
+		return
+	}
+	s.sweptAt = now
+	for key, sessions := range s.sessions {
+		for id, session := range sessions {
+			if s.expired(session, now) {
+				delete(sessions, id)
+			}
+		}
+		if len(sessions) == 0 {
+			delete(s.sessions, key)
+		}
+	}
+}
+
+func (s *documentPresenceService) expired(session *models.EditSession, now time.Time) bool {
+	return now.Sub(session.LastSeenAt) > s.ttl
+}
+
+// evictLeastRecent removes the session with the oldest heartbeat
+func evictLeastRecent(sessions map[string]*models.EditSession) {
+	var oldest *models.EditSession
+	for _, session := range sessions {
+		if oldest == nil || session.LastSeenAt.Before(oldest.LastSeenAt) {
+			oldest = session
+		}
+	}
+	if oldest != nil {
+		delete(sessions, oldest.SessionID)
+	}
+}
+
+func validateHeartbeat(req *docsysSvc.PresenceHeartbeatRequest) error {
+	switch {
+	case req.SessionID == "":
+		return fmt.Errorf("%w: session_id is required", domain.ErrValidation)
+	case len(req.SessionID) > maxSessionIDLength:
+		return fmt.Errorf("%w: session_id must be at most %d characters", domain.ErrValidation, maxSessionIDLength)
+	case len(req.Label) > maxSessionLabelLen:
+		return fmt.Errorf("%w: label must be at most %d characters", domain.ErrValidation, maxSessionLabelLen)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package docsystem
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"meridian/internal/domain"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/testmocks"
+)
+
+func newTestPresenceService(authorizer *testmocks.Authorizer) (*documentPresenceService, *time.Time) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	service := NewDocumentPresenceService(authorizer, time.Minute, slog.New(slog.DiscardHandler)).(*documentPresenceService)
+	service.now = func() time.Time { return now }
+	return service, &now
+}
+
+func TestDocumentPresence_EditingElsewhere(t *testing.T) {
+	service, now := newTestPresenceService(&testmocks.Authorizer{})
+	ctx := context.Background()
+
+	presence, err := service.Heartbeat(ctx, "user-1", "doc-1", &docsysSvc.PresenceHeartbeatRequest{SessionID: "tab-a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if presence.EditingElsewhere || len(presence.Sessions) != 1 {
+		t.Fatalf("first session: %+v", presence)
+	}
+
+	*now = now.Add(10 * time.Second)
+	presence, err = service.Heartbeat(ctx, "user-1", "doc-1", &docsysSvc.PresenceHeartbeatRequest{SessionID: "tab-b", Label: "Phone"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !presence.EditingElsewhere || len(presence.Sessions) != 2 || presence.Sessions[0].SessionID != "tab-a" {
+		t.Fatalf("second session: %+v", presence)
+	}
+
+	// Other users and documents are separate
+	other, _ := service.GetPresence(ctx, "user-2", "doc-1", "")
+	if len(other.Sessions) != 0 {
+		t.Errorf("user-2 sees %+v", other.Sessions)
+	}
+	other, _ = service.GetPresence(ctx, "user-1", "doc-2", "")
+	if len(other.Sessions) != 0 {
+		t.Errorf("doc-2 has %+v", other.Sessions)
+	}
+
+	// tab-a stops heartbeating and expires; tab-b is alone again
+	*now = now.Add(55 * time.Second)
+	presence, _ = service.GetPresence(ctx, "user-1", "doc-1", "tab-b")
+	if presence.EditingElsewhere || len(presence.Sessions) != 1 || presence.Sessions[0].Label != "Phone" {
+		t.Fatalf("after expiry: %+v", presence)
+	}
+
+	if err := service.Leave(ctx, "user-1", "doc-1", "tab-b"); err != nil {
+		t.Fatal(err)
+	}
+	presence, _ = service.GetPresence(ctx, "user-1", "doc-1", "")
+	if len(presence.Sessions) != 0 {
+		t.Errorf("after leave: %+v", presence.Sessions)
+	}
+}
+
+func TestDocumentPresence_Sweep(t *testing.T) {
+	service, now := newTestPresenceService(&testmocks.Authorizer{})
+	ctx := context.Background()
+
+	for _, doc := range []string{"doc-1", "doc-2"} {
+		if _, err := service.Heartbeat(ctx, "user-1", doc, &docsysSvc.PresenceHeartbeatRequest{SessionID: "tab"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	*now = now.Add(2 * time.Minute)
+	if _, err := service.Heartbeat(ctx, "user-1", "doc-3", &docsysSvc.PresenceHeartbeatRequest{SessionID: "tab"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(service.sessions) != 1 {
+		t.Errorf("expired documents kept: %d entries", len(service.sessions))
+	}
+}
+
+func TestDocumentPresence_Validation(t *testing.T) {
+	authorizer := &testmocks.Authorizer{Deny: map[string]error{"doc-x": domain.ErrForbidden}}
+	service, _ := newTestPresenceService(authorizer)
+	ctx := context.Background()
+
+	if _, err := service.Heartbeat(ctx, "user-1", "doc-1", &docsysSvc.PresenceHeartbeatRequest{}); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("missing session_id: %v", err)
+	}
+	if _, err := service.Heartbeat(ctx, "user-1", "doc-x", &docsysSvc.PresenceHeartbeatRequest{SessionID: "tab"}); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("forbidden document: %v", err)
+	}
+	if _, err := service.GetPresence(ctx, "user-1", "doc-x", ""); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("forbidden presence: %v", err)
+	}
+	if len(service.sessions) != 0 {
+		t.Errorf("rejected heartbeats recorded: %+v", service.sessions)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"meridian/internal/domain"
 	models "meridian/internal/domain/models/docsystem"
 	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/domain/services"
 	docsysSvc "meridian/internal/domain/services/docsystem"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -19,16 +20,19 @@ import (
 // projectService implements the ProjectService interface
 type projectService struct {
 	projectRepo docsysRepo.ProjectRepository
+	authorizer  services.ResourceAuthorizer // Cached grants are dropped on delete
 	logger      *slog.Logger
 }
 
 // NewProjectService creates a new project service
 func NewProjectService(
 	projectRepo docsysRepo.ProjectRepository,
+	authorizer services.ResourceAuthorizer,
 	logger *slog.Logger,
 ) docsysSvc.ProjectService {
 	return &projectService{
 		projectRepo: projectRepo,
+		authorizer:  authorizer,
 		logger:      logger,
 	}
 }
@@ -74,9 +78,9 @@ func (s *projectService) GetProject(ctx context.Context, id, userID string) (*mo
 	return project, nil
 }
 
-// ListProjects retrieves all projects for a user
-func (s *projectService) ListProjects(ctx context.Context, userID string) ([]models.Project, error) {
-	projects, err := s.projectRepo.List(ctx, userID)
+// ListProjects retrieves all projects for a user, with the summaries selected in opts (nil = none)
+func (s *projectService) ListProjects(ctx context.Context, userID string, opts *models.ProjectListOptions) ([]models.Project, error) {
+	projects, err := s.projectRepo.List(ctx, userID, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -137,6 +141,9 @@ func (s *projectService) DeleteProject(ctx context.Context, id, userID string) (
 		return nil, err
 	}
 
+	// Everything in the project must stop passing cached authorization checks
+	s.authorizer.InvalidateUser(userID)
+
 	s.logger.Info("project soft-deleted",
 		"id", id,
 		"user_id", userID,
@@ -0,0 +1,212 @@
+package docsystem
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"meridian/internal/config"
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	llmModels "meridian/internal/domain/models/llm"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/service/llm/sanitize"
+)
+
+// projectSettingsService implements the ProjectSettingsService interface
+type projectSettingsService struct {
+	settingsRepo docsysRepo.ProjectSettingsRepository
+	logger       *slog.Logger
+}
+
+// NewProjectSettingsService creates a new project settings service
+func NewProjectSettingsService(
+	settingsRepo docsysRepo.ProjectSettingsRepository,
+	logger *slog.Logger,
+) docsysSvc.ProjectSettingsService {
+	return &projectSettingsService{
+		settingsRepo: settingsRepo,
+		logger:       logger,
+	}
+}
+
+// GetProjectSettings retrieves a project's settings
+func (s *projectSettingsService) GetProjectSettings(ctx context.Context, projectID, userID string) (*models.ProjectSettings, error) {
+	return s.settingsRepo.Get(ctx, projectID, userID)
+}
+
+// UpdateProjectSettings partially updates a project's settings
+// Absent fields are left unchanged; null resets a field to its default.
+func (s *projectSettingsService) UpdateProjectSettings(ctx context.Context, projectID, userID string, req *docsysSvc.UpdateProjectSettingsRequest) (*models.ProjectSettings, error) {
+	// Validate request
+	if err := s.validateUpdateRequest(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrValidation, err)
+	}
+
+	if language, ok := req.SearchLanguage.Value(); ok {
+		if err := validateSearchLanguage(ctx, s.settingsRepo, language); err != nil {
+			return nil, err
+		}
+	}
+
+	// Get existing settings (also checks project access)
+	settings, err := s.settingsRepo.Get(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	if req.SystemPrompt.IsSet() {
+		settings.SystemPrompt = req.SystemPrompt.Ptr()
+	}
+	if req.DefaultModel.IsSet() {
+		settings.DefaultModel = req.DefaultModel.Ptr()
+		if settings.DefaultModel != nil {
+			model := strings.TrimSpace(*settings.DefaultModel)
+			settings.DefaultModel = &model
+		}
+	}
+	if req.ToolPolicy.IsSet() {
+		policy, _ := req.ToolPolicy.Value()
+		settings.ToolPolicy = policy
+	}
+	if req.Guardrails.IsSet() {
+		guardrails, _ := req.Guardrails.Value()
+		settings.Guardrails = guardrails
+	}
+	if req.Critique.IsSet() {
+		critique, _ := req.Critique.Value()
+		settings.Critique = normalizeCritique(critique)
+	}
+	if req.PinFolder.IsSet() {
+		settings.PinFolder = nil
+		if folder, ok := req.PinFolder.Value(); ok {
+			folder = normalizePinFolder(folder)
+			settings.PinFolder = &folder
+		}
+	}
+	if req.SearchLanguage.IsSet() {
+		settings.SearchLanguage = models.DefaultSearchLanguage
+		if language, ok := req.SearchLanguage.Value(); ok {
+			settings.SearchLanguage = language
+		}
+	}
+
+	if err := s.settingsRepo.Upsert(ctx, userID, settings); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("project settings updated",
+		"project_id", projectID,
+		"system_prompt_set", req.SystemPrompt.IsSet(),
+		"default_model", settings.DefaultModel,
+		"tool_policy", settings.ToolPolicy,
+		"critique_enabled", settings.Critique.Enabled,
+		"pin_folder", settings.PinFolder,
+		"search_language", settings.SearchLanguage,
+		"user_id", userID,
+	)
+
+	return settings, nil
+}
+
+// validateUpdateRequest validates an update project settings request
+func (s *projectSettingsService) validateUpdateRequest(req *docsysSvc.UpdateProjectSettingsRequest) error {
+	// At least one field must be provided
+	if !req.SystemPrompt.IsSet() && !req.DefaultModel.IsSet() && !req.ToolPolicy.IsSet() &&
+		!req.Guardrails.IsSet() && !req.Critique.IsSet() && !req.PinFolder.IsSet() && !req.SearchLanguage.IsSet() {
+		return fmt.Errorf("at least one field must be provided")
+	}
+
+	if model, ok := req.DefaultModel.Value(); ok && strings.TrimSpace(model) == "" {
+		return fmt.Errorf("default_model: cannot be empty (use null to clear)")
+	}
+
+	if policy, ok := req.ToolPolicy.Value(); ok {
+		if err := validateToolNames("tool_policy.allowed", policy.Allowed); err != nil {
+			return err
+		}
+		if err := validateToolNames("tool_policy.denied", policy.Denied); err != nil {
+			return err
+		}
+	}
+
+	if guardrails, ok := req.Guardrails.Value(); ok {
+		if level := guardrails.ToolResultSanitization; level != nil {
+			switch sanitize.Level(*level) {
+			case sanitize.LevelOff, sanitize.LevelStandard, sanitize.LevelStrict:
+			default:
+				return fmt.Errorf("guardrails.tool_result_sanitization: must be off, standard or strict")
+			}
+		}
+		if rounds := guardrails.MaxToolRounds; rounds != nil && *rounds < 1 {
+			return fmt.Errorf("guardrails.max_tool_rounds: must be at least 1")
+		}
+	}
+
+	if critique, ok := req.Critique.Value(); ok {
+		if critique.Rubric != nil && utf8.RuneCountInString(*critique.Rubric) > config.MaxCritiqueRubricLength {
+			return fmt.Errorf("critique.rubric: at most %d characters", config.MaxCritiqueRubricLength)
+		}
+	}
+
+	if folder, ok := req.PinFolder.Value(); ok {
+		folder = normalizePinFolder(folder)
+		if folder == "" {
+			return fmt.Errorf("pin_folder: cannot be empty (use null to reset)")
+		}
+		if utf8.RuneCountInString(folder) > config.MaxDocumentPathLength {
+			return fmt.Errorf("pin_folder: at most %d characters", config.MaxDocumentPathLength)
+		}
+	}
+
+	return nil
+}
+
+// normalizePinFolder trims spaces and surrounding slashes ("/Notes/" → "Notes")
+func normalizePinFolder(folder string) string {
+	return strings.Trim(strings.TrimSpace(folder), "/")
+}
+
+// normalizeCritique trims the critique settings; a blank model or rubric means the default
+func normalizeCritique(critique models.ProjectCritique) models.ProjectCritique {
+	trimmed := func(value *string) *string {
+		if value == nil || strings.TrimSpace(*value) == "" {
+			return nil
+		}
+		text := strings.TrimSpace(*value)
+		return &text
+	}
+	critique.Model = trimmed(critique.Model)
+	critique.Rubric = trimmed(critique.Rubric)
+	return critique
+}
+
+// validateSearchLanguage checks language against the database's text search configurations
+// (e.g. "english", "french", "simple")
+func validateSearchLanguage(ctx context.Context, settingsRepo docsysRepo.ProjectSettingsRepository, language string) error {
+	exists, err := settingsRepo.SearchLanguageExists(ctx, language)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: search_language: unsupported language %q", domain.ErrValidation, language)
+	}
+	return nil
+}
+
+// validateToolNames checks a tool policy list against known tools
+func validateToolNames(field string, names []string) error {
+	if len(names) > config.MaxProjectToolPolicyTools {
+		return fmt.Errorf("%s: at most %d tools allowed", field, config.MaxProjectToolPolicyTools)
+	}
+	for _, name := range names {
+		if !llmModels.IsKnownToolName(name) {
+			return fmt.Errorf("%s: unknown tool %q", field, name)
+		}
+	}
+	return nil
+}
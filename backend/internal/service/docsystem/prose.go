@@ -0,0 +1,95 @@
+package docsystem
+
+import (
+	"strings"
+	"unicode"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+// proseVisitor receives the words of markdown prose in reading order
+type proseVisitor interface {
+	// word is called for each word, lowercased; dialogue is set inside quotation marks
+	word(word string, dialogue bool)
+	// endSentence is called after each sentence's last word
+	endSentence()
+	// endParagraph is called after each paragraph's last sentence
+	endParagraph()
+}
+
+// scanProse tokenizes markdown prose. Code blocks and markdown syntax are ignored.
+// A line break always ends a sentence (headings, list items and paragraphs without
+// final punctuation); a blank line also ends the paragraph.
+func scanProse(analyzer docsysSvc.ContentAnalyzer, markdown string, v proseVisitor) {
+	inFence := false
+	inParagraph := false
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if trimmed == "" {
+			if inParagraph {
+				v.endParagraph()
+				inParagraph = false
+			}
+			continue
+		}
+		if scanLine(analyzer.CleanMarkdown(line), v) {
+			inParagraph = true
+		}
+	}
+	if inParagraph {
+		v.endParagraph()
+	}
+}
+
+// scanLine reads one line of text and reports whether it had any words
+func scanLine(line string, v proseVisitor) bool {
+	inQuote := false
+	inSentence := false
+	hadWords := false
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			if w := strings.Trim(strings.ToLower(string(word)), "'’"); w != "" {
+				v.word(w, inQuote)
+				inSentence, hadWords = true, true
+			}
+			word = word[:0]
+		}
+	}
+	end := func() {
+		flush()
+		if inSentence {
+			v.endSentence()
+			inSentence = false
+		}
+	}
+
+	for _, r := range line {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || ((r == '\'' || r == '’') && len(word) > 0):
+			word = append(word, r)
+		case r == '"':
+			flush()
+			inQuote = !inQuote
+		case r == '“':
+			flush()
+			inQuote = true
+		case r == '”':
+			flush()
+			inQuote = false
+		case r == '.' || r == '!' || r == '?' || r == '…':
+			end()
+		default:
+			flush()
+		}
+	}
+	end()
+	return hadWords
+}
@@ -0,0 +1,473 @@
+package docsystem
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"meridian/internal/config"
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/service/docsystem/export"
+	"meridian/internal/service/docsystem/publish"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	// maskedSecret replaces secret config values in responses. Sending it back on
+	// update keeps the stored secret.
+	maskedSecret = "********"
+
+	// publishTimeout bounds one delivery
+	publishTimeout = 2 * time.Minute
+
+	// publishRetryInterval is how long an on-change target waits before retrying
+	// a failed delivery of unchanged documents
+	publishRetryInterval = 10 * time.Minute
+
+	// Delivery log size kept per target, and returned by ListDeliveries
+	publishDeliveriesKept   = 100
+	publishDeliveriesListed = 50
+)
+
+// publishService implements the PublishService interface
+type publishService struct {
+	publishRepo docsysRepo.PublishRepository
+	folderRepo  docsysRepo.FolderRepository
+	docRepo     docsysRepo.DocumentRepository
+	registry    *publish.Registry
+	authorizer  services.ResourceAuthorizer
+	logger      *slog.Logger
+}
+
+// NewPublishService creates a new publish service
+func NewPublishService(
+	publishRepo docsysRepo.PublishRepository,
+	folderRepo docsysRepo.FolderRepository,
+	docRepo docsysRepo.DocumentRepository,
+	registry *publish.Registry,
+	authorizer services.ResourceAuthorizer,
+	logger *slog.Logger,
+) docsysSvc.PublishService {
+	return &publishService{
+		publishRepo: publishRepo,
+		folderRepo:  folderRepo,
+		docRepo:     docRepo,
+		registry:    registry,
+		authorizer:  authorizer,
+		logger:      logger,
+	}
+}
+
+// CreateTarget validates and stores a new publish target
+func (s *publishService) CreateTarget(ctx context.Context, userID, projectID string, req *docsysSvc.CreatePublishTargetRequest) (*models.PublishTarget, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	target := &models.PublishTarget{
+		ProjectID:   projectID,
+		Name:        strings.TrimSpace(req.Name),
+		Kind:        req.Kind,
+		Config:      req.Config,
+		DocumentIDs: req.DocumentIDs,
+		FolderIDs:   req.FolderIDs,
+		OnChange:    req.OnChange,
+	}
+	if err := s.validateTarget(ctx, target); err != nil {
+		return nil, err
+	}
+
+	if err := s.publishRepo.CreateTarget(ctx, target); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("publish target created",
+		"id", target.ID,
+		"project_id", projectID,
+		"kind", target.Kind,
+		"on_change", target.OnChange,
+	)
+
+	return s.masked(target), nil
+}
+
+// ListTargets returns a project's targets with secrets masked
+func (s *publishService) ListTargets(ctx context.Context, userID, projectID string) ([]models.PublishTarget, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	targets, err := s.publishRepo.ListTargets(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range targets {
+		targets[i] = *s.masked(&targets[i])
+	}
+	return targets, nil
+}
+
+// GetTarget returns a target with secrets masked
+func (s *publishService) GetTarget(ctx context.Context, userID, projectID, targetID string) (*models.PublishTarget, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	target, err := s.publishRepo.GetTarget(ctx, targetID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return s.masked(target), nil
+}
+
+// UpdateTarget applies a partial update to a target
+func (s *publishService) UpdateTarget(ctx context.Context, userID, projectID, targetID string, req *docsysSvc.UpdatePublishTargetRequest) (*models.PublishTarget, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	target, err := s.publishRepo.GetTarget(ctx, targetID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if name, ok := req.Name.Value(); ok {
+		target.Name = strings.TrimSpace(name)
+	}
+	if cfg, ok := req.Config.Value(); ok {
+		// Masked secrets sent back unchanged keep their stored value
+		next := maps.Clone(cfg)
+		for key, value := range next {
+			if value == maskedSecret {
+				next[key] = target.Config[key]
+			}
+		}
+		target.Config = next
+	}
+	// null clears a selection
+	if req.DocumentIDs.IsSet() {
+		target.DocumentIDs, _ = req.DocumentIDs.Value()
+	}
+	if req.FolderIDs.IsSet() {
+		target.FolderIDs, _ = req.FolderIDs.Value()
+	}
+	if onChange, ok := req.OnChange.Value(); ok {
+		target.OnChange = onChange
+	}
+
+	if err := s.validateTarget(ctx, target); err != nil {
+		return nil, err
+	}
+	if err := s.publishRepo.UpdateTarget(ctx, target); err != nil {
+		return nil, err
+	}
+
+	return s.masked(target), nil
+}
+
+// DeleteTarget removes a target and its delivery log
+func (s *publishService) DeleteTarget(ctx context.Context, userID, projectID, targetID string) error {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return err
+	}
+
+	if err := s.publishRepo.DeleteTarget(ctx, targetID, projectID); err != nil {
+		return err
+	}
+
+	s.logger.Info("publish target deleted", "id", targetID, "project_id", projectID)
+	return nil
+}
+
+// Publish delivers a target's documents now
+func (s *publishService) Publish(ctx context.Context, userID, projectID, targetID string) (*models.PublishDelivery, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	target, err := s.publishRepo.GetTarget(ctx, targetID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	selection, err := s.selectDocuments(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	return s.deliver(ctx, target, selection, models.PublishTriggerManual)
+}
+
+// ListDeliveries returns a target's most recent deliveries
+func (s *publishService) ListDeliveries(ctx context.Context, userID, projectID, targetID string) ([]models.PublishDelivery, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	// Scope the target to the project before reading its log
+	if _, err := s.publishRepo.GetTarget(ctx, targetID, projectID); err != nil {
+		return nil, err
+	}
+	return s.publishRepo.ListDeliveries(ctx, targetID, publishDeliveriesListed)
+}
+
+// PublishChanged publishes on-change targets whose selection changed since the last
+// attempt. Changes are detected by fingerprint (document IDs, paths and content hashes),
+// so edits from every source count: the editor, imports, sync, restores and AI tools.
+// A failed delivery is retried after publishRetryInterval even if nothing changed.
+func (s *publishService) PublishChanged(ctx context.Context) error {
+	targets, err := s.publishRepo.ListOnChangeTargets(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range targets {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		target := &targets[i]
+
+		selection, err := s.selectDocuments(ctx, target)
+		if err != nil {
+			s.logger.Error("failed to resolve publish selection", "target_id", target.ID, "error", err)
+			continue
+		}
+
+		if selection.fingerprint == target.LastFingerprint {
+			retry := target.LastStatus != nil && *target.LastStatus == models.PublishStatusFailed &&
+				target.LastAttemptAt != nil && time.Since(*target.LastAttemptAt) >= publishRetryInterval
+			if !retry {
+				continue
+			}
+		}
+
+		if _, err := s.deliver(ctx, target, selection, models.PublishTriggerDocumentChange); err != nil {
+			s.logger.Error("failed to publish changed documents", "target_id", target.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// publishSelection is the set of documents a target publishes, in path order
+type publishSelection struct {
+	documents   []models.Document // Metadata only, Path set
+	fingerprint string
+}
+
+// selectDocuments resolves a target's document and folder selection against the
+// current tree. Selected items that were deleted since are skipped.
+func (s *publishService) selectDocuments(ctx context.Context, target *models.PublishTarget) (*publishSelection, error) {
+	folders, err := s.folderRepo.GetAllByProject(ctx, target.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	documents, err := s.docRepo.GetAllMetadataByProject(ctx, target.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := filterPublishDocuments(folders, documents, target.DocumentIDs, target.FolderIDs)
+	return &publishSelection{
+		documents:   selected,
+		fingerprint: publishFingerprint(selected),
+	}, nil
+}
+
+// filterPublishDocuments returns the documents listed directly or inside a listed
+// folder's subtree (all documents when nothing is listed), with paths, sorted by path
+func filterPublishDocuments(folders []models.Folder, documents []models.Document, documentIDs, folderIDs []string) []models.Document {
+	parents := make(map[string]*string, len(folders))
+	names := make(map[string]string, len(folders))
+	for _, folder := range folders {
+		parents[folder.ID] = folder.ParentID
+		names[folder.ID] = folder.Name
+	}
+
+	paths := make(map[string]string, len(folders))
+	var folderPath func(id string) string
+	folderPath = func(id string) string {
+		if path, ok := paths[id]; ok {
+			return path
+		}
+		path := names[id]
+		if parent := parents[id]; parent != nil {
+			path = folderPath(*parent) + "/" + path
+		}
+		paths[id] = path
+		return path
+	}
+
+	wantDocs := make(map[string]bool, len(documentIDs))
+	for _, id := range documentIDs {
+		wantDocs[id] = true
+	}
+	wantFolders := make(map[string]bool, len(folderIDs))
+	for _, id := range folderIDs {
+		wantFolders[id] = true
+	}
+	inSelectedFolder := func(folderID *string) bool {
+		for folderID != nil {
+			if wantFolders[*folderID] {
+				return true
+			}
+			folderID = parents[*folderID]
+		}
+		return false
+	}
+
+	all := len(documentIDs) == 0 && len(folderIDs) == 0
+	selected := make([]models.Document, 0, len(documents))
+	for _, doc := range documents {
+		if !all && !wantDocs[doc.ID] && !inSelectedFolder(doc.FolderID) {
+			continue
+		}
+		doc.Path = doc.Name
+		if doc.FolderID != nil {
+			doc.Path = folderPath(*doc.FolderID) + "/" + doc.Name
+		}
+		selected = append(selected, doc)
+	}
+
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Path < selected[j].Path })
+	return selected
+}
+
+// publishFingerprint summarizes what a selection would publish
+func publishFingerprint(documents []models.Document) string {
+	h := sha256.New()
+	for _, doc := range documents {
+		fmt.Fprintf(h, "%s:%s:%s\n", doc.ID, doc.Path, doc.ContentHash)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// deliver builds the bundle, runs the publisher and records the attempt.
+// Publisher failures are recorded and returned as a failed delivery.
+func (s *publishService) deliver(ctx context.Context, target *models.PublishTarget, selection *publishSelection, trigger string) (*models.PublishDelivery, error) {
+	publisher := s.registry.Get(target.Kind)
+	if publisher == nil {
+		return nil, fmt.Errorf("no publisher registered for kind %q", target.Kind)
+	}
+
+	bundle := &models.PublishBundle{
+		ProjectID: target.ProjectID,
+		TargetID:  target.ID,
+		Trigger:   trigger,
+		Documents: make([]models.PublishedDocument, 0, len(selection.documents)),
+	}
+	for _, meta := range selection.documents {
+		doc, err := s.docRepo.GetByID(ctx, meta.ID, target.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		bundle.Documents = append(bundle.Documents, models.PublishedDocument{
+			ID:          doc.ID,
+			Path:        meta.Path,
+			Name:        doc.Name,
+			Markdown:    doc.Content,
+			HTML:        export.MarkdownToXHTML(doc.Content),
+			ContentHash: doc.ContentHash,
+			UpdatedAt:   doc.UpdatedAt,
+		})
+	}
+
+	started := time.Now()
+	publishCtx, cancel := context.WithTimeout(ctx, publishTimeout)
+	detail, err := publisher.Publish(publishCtx, target.Config, bundle)
+	cancel()
+
+	delivery := &models.PublishDelivery{
+		TargetID:      target.ID,
+		Trigger:       trigger,
+		Status:        models.PublishStatusSucceeded,
+		DocumentCount: len(bundle.Documents),
+		Detail:        detail,
+		DurationMs:    int(time.Since(started).Milliseconds()),
+	}
+	if err != nil {
+		message := err.Error()
+		delivery.Status = models.PublishStatusFailed
+		delivery.Error = &message
+	}
+
+	if err := s.publishRepo.RecordAttempt(ctx, delivery, selection.fingerprint, started, publishDeliveriesKept); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("publish delivered",
+		"target_id", target.ID,
+		"project_id", target.ProjectID,
+		"kind", target.Kind,
+		"trigger", trigger,
+		"status", delivery.Status,
+		"documents", delivery.DocumentCount,
+		"duration_ms", delivery.DurationMs,
+	)
+
+	return delivery, nil
+}
+
+// validateTarget checks a target's fields, its kind's config and that the
+// selected documents and folders belong to the project
+func (s *publishService) validateTarget(ctx context.Context, target *models.PublishTarget) error {
+	err := validation.ValidateStruct(target,
+		validation.Field(&target.Name, validation.Required, validation.RuneLength(1, config.MaxProjectNameLength)),
+		validation.Field(&target.Kind, validation.Required, validation.In(toAny(s.registry.Kinds())...)),
+		validation.Field(&target.DocumentIDs, validation.Length(0, config.MaxPublishSelection)),
+		validation.Field(&target.FolderIDs, validation.Length(0, config.MaxPublishSelection)),
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrValidation, err)
+	}
+
+	if err := s.registry.Get(target.Kind).Validate(target.Config); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrValidation, err)
+	}
+
+	target.DocumentIDs = slices.Compact(slices.Sorted(slices.Values(target.DocumentIDs)))
+	for _, documentID := range target.DocumentIDs {
+		if _, err := s.docRepo.GetByID(ctx, documentID, target.ProjectID); err != nil {
+			return err
+		}
+	}
+	target.FolderIDs = slices.Compact(slices.Sorted(slices.Values(target.FolderIDs)))
+	for _, folderID := range target.FolderIDs {
+		if _, err := s.folderRepo.GetByID(ctx, folderID, target.ProjectID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// masked returns a copy of target with its kind's secret config values masked
+func (s *publishService) masked(target *models.PublishTarget) *models.PublishTarget {
+	masked := *target
+	masked.Config = maps.Clone(target.Config)
+	if publisher := s.registry.Get(target.Kind); publisher != nil {
+		for _, key := range publisher.SecretKeys() {
+			if masked.Config[key] != "" {
+				masked.Config[key] = maskedSecret
+			}
+		}
+	}
+	return &masked
+}
+
+func toAny(values []string) []any {
+	out := make([]any, len(values))
+	for i, value := range values {
+		out[i] = value
+	}
+	return out
+}
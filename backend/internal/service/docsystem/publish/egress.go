@@ -0,0 +1,134 @@
+package publish
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// errBlockedAddress is returned when a destination resolves to an address publishers
+// may not reach
+var errBlockedAddress = errors.New("destination address is not allowed")
+
+var (
+	// sharedAddressSpace is carrier-grade NAT space (RFC 6598), also used for cloud metadata
+	sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+	// thisNetwork is "this host on this network" (RFC 1122), reachable as localhost on Linux
+	thisNetwork = netip.MustParsePrefix("0.0.0.0/8")
+)
+
+// Egress decides which destinations publishers may reach. Target URLs come from users,
+// so by default only https to public addresses is allowed: the address is checked when
+// the connection is made, so a hostname re-resolving to a private address (DNS rebinding)
+// is refused too. Allowed hosts may also use http and private addresses, for self-hosted
+// storage or webhook receivers on the internal network.
+type Egress struct {
+	allowedHosts map[string]bool
+	client       *http.Client
+}
+
+// NewEgress creates an egress policy from a comma-separated list of allowed hosts
+// (hostnames or IP addresses, without ports)
+func NewEgress(allowedHosts string) *Egress {
+	e := &Egress{allowedHosts: make(map[string]bool)}
+	for _, host := range strings.Split(allowedHosts, ",") {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			e.allowedHosts[host] = true
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	guarded := &net.Dialer{Timeout: 10 * time.Second, Control: checkDialAddress}
+	e.client = &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			// No proxy: the dial check must see the destination's address, not the proxy's
+			Proxy: nil,
+			DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+				host, _, err := net.SplitHostPort(address)
+				if err == nil && e.allowed(host) {
+					return dialer.DialContext(ctx, network, address)
+				}
+				return guarded.DialContext(ctx, network, address)
+			},
+			ForceAttemptHTTP2:   true,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			if req.URL.Scheme != "https" && !e.allowed(req.URL.Hostname()) {
+				return errors.New("redirect to a non-https URL")
+			}
+			return nil
+		},
+	}
+	return e
+}
+
+// Do sends a request to a destination
+func (e *Egress) Do(req *http.Request) (*http.Response, error) {
+	return e.client.Do(req)
+}
+
+// CheckURL validates a configured destination URL; field names its config key in errors.
+// Hostnames are checked again when connecting, since they may resolve anywhere.
+func (e *Egress) CheckURL(field, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("config.%s must be an http(s) URL", field)
+	}
+	host := u.Hostname()
+	if e.allowed(host) {
+		return nil
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("config.%s must be an https URL", field)
+	}
+	if ip, err := netip.ParseAddr(host); err == nil && blockedAddress(ip) {
+		return fmt.Errorf("config.%s must not point to a private, loopback or link-local address", field)
+	}
+	return nil
+}
+
+func (e *Egress) allowed(host string) bool {
+	return e.allowedHosts[strings.ToLower(host)]
+}
+
+// checkDialAddress refuses connections to blocked addresses. It runs after name
+// resolution, on the address actually being connected to.
+func checkDialAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errBlockedAddress, address)
+	}
+	if blockedAddress(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", errBlockedAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+// blockedAddress reports whether ip is loopback, private, link-local, multicast,
+// unspecified or otherwise not a public unicast address
+func blockedAddress(ip netip.Addr) bool {
+	ip = ip.Unmap().WithZone("")
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified() ||
+		sharedAddressSpace.Contains(ip) ||
+		thisNetwork.Contains(ip)
+}
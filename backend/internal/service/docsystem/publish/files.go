@@ -0,0 +1,93 @@
+package publish
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+
+	models "meridian/internal/domain/models/docsystem"
+)
+
+// File formats for publishers that write files (GitHub, S3), set with config "format"
+const (
+	formatMarkdown = "markdown" // Default: the document's Markdown as-is
+	formatHTML     = "html"     // A standalone HTML page per document
+)
+
+// requestTimeout bounds each HTTP request to a destination
+const requestTimeout = 30 * time.Second
+
+// publishedFile is one document rendered for a file-based destination
+type publishedFile struct {
+	path        string
+	content     string
+	contentType string
+}
+
+func validateFormat(config map[string]string) error {
+	switch config["format"] {
+	case "", formatMarkdown, formatHTML:
+		return nil
+	default:
+		return fmt.Errorf("format must be %q or %q", formatMarkdown, formatHTML)
+	}
+}
+
+// renderFiles maps documents to files under prefix: "Chapters/One" becomes
+// "<prefix>/Chapters/One.md" (or .html)
+func renderFiles(bundle *models.PublishBundle, config map[string]string) []publishedFile {
+	prefix := strings.Trim(config["prefix"], "/")
+	files := make([]publishedFile, 0, len(bundle.Documents))
+	for _, doc := range bundle.Documents {
+		path := doc.Path
+		if prefix != "" {
+			path = prefix + "/" + path
+		}
+
+		if config["format"] == formatHTML {
+			files = append(files, publishedFile{
+				path:        path + ".html",
+				content:     htmlPage(doc),
+				contentType: "text/html; charset=utf-8",
+			})
+			continue
+		}
+		files = append(files, publishedFile{
+			path:        path + ".md",
+			content:     doc.Markdown,
+			contentType: "text/markdown; charset=utf-8",
+		})
+	}
+	return files
+}
+
+func htmlPage(doc models.PublishedDocument) string {
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8"/>
+<title>%s</title>
+</head>
+<body>
+%s</body>
+</html>
+`, html.EscapeString(doc.Name), doc.HTML)
+}
+
+// requireKeys reports the first missing required config key
+func requireKeys(config map[string]string, keys ...string) error {
+	for _, key := range keys {
+		if strings.TrimSpace(config[key]) == "" {
+			return fmt.Errorf("config.%s is required", key)
+		}
+	}
+	return nil
+}
+
+// responseError summarizes a failed HTTP response. The body isn't included: destinations
+// are user-configured, so it could be the content of a service the user can't reach.
+func responseError(action string, resp *http.Response) error {
+	return fmt.Errorf("%s: HTTP %d %s", action, resp.StatusCode, http.StatusText(resp.StatusCode))
+}
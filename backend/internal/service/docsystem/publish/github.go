@@ -0,0 +1,190 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	models "meridian/internal/domain/models/docsystem"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+const defaultGitHubAPI = "https://api.github.com"
+
+// githubPublisher commits the documents to a repository branch as a single commit
+// (Git Data API: blobs inline in one tree on top of the branch head).
+// Files of documents that are no longer selected are left in the repository.
+//
+// Config: repo ("owner/name", required), token (required), branch (default "main"),
+// prefix (directory in the repository), format ("markdown" or "html"),
+// api_url (GitHub Enterprise, default https://api.github.com)
+type githubPublisher struct {
+	egress *Egress
+}
+
+// NewGitHubPublisher creates a GitHub publisher
+func NewGitHubPublisher(egress *Egress) docsysSvc.Publisher {
+	return &githubPublisher{egress: egress}
+}
+
+func (p *githubPublisher) Kind() string { return models.PublishKindGitHub }
+
+func (p *githubPublisher) SecretKeys() []string { return []string{"token"} }
+
+func (p *githubPublisher) Validate(config map[string]string) error {
+	if err := requireKeys(config, "repo", "token"); err != nil {
+		return err
+	}
+	if owner, name, ok := strings.Cut(config["repo"], "/"); !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return fmt.Errorf("config.repo must be \"owner/name\"")
+	}
+	if apiURL := config["api_url"]; apiURL != "" {
+		if err := p.egress.CheckURL("api_url", apiURL); err != nil {
+			return err
+		}
+	}
+	return validateFormat(config)
+}
+
+func (p *githubPublisher) Publish(ctx context.Context, config map[string]string, bundle *models.PublishBundle) (string, error) {
+	client := &githubClient{
+		egress:  p.egress,
+		baseURL: strings.TrimRight(config["api_url"], "/"),
+		repo:    config["repo"],
+		token:   config["token"],
+	}
+	if client.baseURL == "" {
+		client.baseURL = defaultGitHubAPI
+	}
+	if err := p.egress.CheckURL("api_url", client.baseURL); err != nil {
+		return "", err
+	}
+	branch := config["branch"]
+	if branch == "" {
+		branch = "main"
+	}
+	ref := "heads/" + branch
+
+	var head struct {
+		Object struct {
+			SHA string `json:"sha"`
+		} `json:"object"`
+	}
+	if err := client.call(ctx, http.MethodGet, "/git/ref/"+ref, nil, &head); err != nil {
+		return "", err
+	}
+
+	var parent struct {
+		Tree struct {
+			SHA string `json:"sha"`
+		} `json:"tree"`
+	}
+	if err := client.call(ctx, http.MethodGet, "/git/commits/"+head.Object.SHA, nil, &parent); err != nil {
+		return "", err
+	}
+
+	type treeEntry struct {
+		Path    string `json:"path"`
+		Mode    string `json:"mode"`
+		Type    string `json:"type"`
+		Content string `json:"content"`
+	}
+	files := renderFiles(bundle, config)
+	entries := make([]treeEntry, len(files))
+	for i, file := range files {
+		entries[i] = treeEntry{Path: file.path, Mode: "100644", Type: "blob", Content: file.content}
+	}
+
+	var tree struct {
+		SHA string `json:"sha"`
+	}
+	if err := client.call(ctx, http.MethodPost, "/git/trees", map[string]any{
+		"base_tree": parent.Tree.SHA,
+		"tree":      entries,
+	}, &tree); err != nil {
+		return "", err
+	}
+	if tree.SHA == parent.Tree.SHA {
+		return "no changes (" + shortSHA(head.Object.SHA) + ")", nil
+	}
+
+	var commit struct {
+		SHA string `json:"sha"`
+	}
+	if err := client.call(ctx, http.MethodPost, "/git/commits", map[string]any{
+		"message": fmt.Sprintf("Publish %d document(s) from Meridian", len(files)),
+		"tree":    tree.SHA,
+		"parents": []string{head.Object.SHA},
+	}, &commit); err != nil {
+		return "", err
+	}
+
+	if err := client.call(ctx, http.MethodPatch, "/git/refs/"+ref, map[string]any{
+		"sha": commit.SHA,
+	}, nil); err != nil {
+		return "", err
+	}
+
+	return "commit " + shortSHA(commit.SHA), nil
+}
+
+// githubClient calls the REST API for one repository
+type githubClient struct {
+	egress  *Egress
+	baseURL string
+	repo    string
+	token   string
+}
+
+func (c *githubClient) call(ctx context.Context, method, path string, body any, out any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+"/repos/"+c.repo+path, reader)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.egress.Do(req)
+	if err != nil {
+		return fmt.Errorf("github %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("github %s %s: read response: %w", method, path, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return responseError("github "+method+" "+path, resp)
+	}
+	if out != nil {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("github %s %s: decode response: %w", method, path, err)
+		}
+	}
+	return nil
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
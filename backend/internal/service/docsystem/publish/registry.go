@@ -0,0 +1,58 @@
+package publish
+
+import (
+	"sort"
+	"sync"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+// Registry maps target kinds to publishers.
+// Follows the same Registry pattern as converter.ConverterRegistry.
+//
+// Thread-safe for concurrent access.
+type Registry struct {
+	mu         sync.RWMutex
+	publishers map[string]docsysSvc.Publisher
+}
+
+// NewRegistry creates a registry with the standard publishers pre-registered, all
+// reaching their destinations through egress
+func NewRegistry(egress *Egress) *Registry {
+	registry := &Registry{
+		publishers: make(map[string]docsysSvc.Publisher),
+	}
+
+	registry.Register(NewGitHubPublisher(egress))
+	registry.Register(NewS3Publisher(egress))
+	registry.Register(NewWebhookPublisher(egress))
+
+	return registry
+}
+
+// Register adds a publisher for its kind, replacing any existing one
+func (r *Registry) Register(publisher docsysSvc.Publisher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.publishers[publisher.Kind()] = publisher
+}
+
+// Get returns the publisher for a kind, or nil if none is registered
+func (r *Registry) Get(kind string) docsysSvc.Publisher {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.publishers[kind]
+}
+
+// Kinds lists the registered kinds, sorted
+func (r *Registry) Kinds() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	kinds := make([]string, 0, len(r.publishers))
+	for kind := range r.publishers {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
@@ -0,0 +1,153 @@
+package publish
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	models "meridian/internal/domain/models/docsystem"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+// s3Publisher uploads one object per document (PUT Object, signed with AWS Signature
+// Version 4). Works with S3-compatible storage through config endpoint.
+// Objects of documents that are no longer selected are left in the bucket.
+//
+// Config: bucket, region, access_key_id, secret_access_key (all required),
+// endpoint (default https://s3.<region>.amazonaws.com; path-style addressing),
+// prefix (key prefix), format ("markdown" or "html")
+type s3Publisher struct {
+	egress *Egress
+	now    func() time.Time
+}
+
+// NewS3Publisher creates an S3 publisher
+func NewS3Publisher(egress *Egress) docsysSvc.Publisher {
+	return &s3Publisher{egress: egress, now: time.Now}
+}
+
+func (p *s3Publisher) Kind() string { return models.PublishKindS3 }
+
+func (p *s3Publisher) SecretKeys() []string { return []string{"secret_access_key"} }
+
+func (p *s3Publisher) Validate(config map[string]string) error {
+	if err := requireKeys(config, "bucket", "region", "access_key_id", "secret_access_key"); err != nil {
+		return err
+	}
+	if endpoint := config["endpoint"]; endpoint != "" {
+		if err := p.egress.CheckURL("endpoint", endpoint); err != nil {
+			return err
+		}
+	}
+	return validateFormat(config)
+}
+
+func (p *s3Publisher) Publish(ctx context.Context, config map[string]string, bundle *models.PublishBundle) (string, error) {
+	endpoint := strings.TrimRight(config["endpoint"], "/")
+	if endpoint == "" {
+		endpoint = "https://s3." + config["region"] + ".amazonaws.com"
+	}
+	if err := p.egress.CheckURL("endpoint", endpoint); err != nil {
+		return "", err
+	}
+
+	files := renderFiles(bundle, config)
+	for _, file := range files {
+		if err := p.putObject(ctx, endpoint, config, file); err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("%d object(s) uploaded to %s", len(files), config["bucket"]), nil
+}
+
+func (p *s3Publisher) putObject(ctx context.Context, endpoint string, config map[string]string, file publishedFile) error {
+	objectURL := endpoint + "/" + uriEncode(config["bucket"], false) + "/" + uriEncode(file.path, true)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL, strings.NewReader(file.content))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", file.contentType)
+	p.sign(req, []byte(file.content), config)
+
+	resp, err := p.egress.Do(req)
+	if err != nil {
+		return fmt.Errorf("upload %s: %w", file.path, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return responseError("upload "+file.path, resp)
+	}
+	return nil
+}
+
+// sign adds AWS Signature Version 4 headers for the S3 service
+func (p *s3Publisher) sign(req *http.Request, payload []byte, config map[string]string) {
+	now := p.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "content-type;host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "content-type:" + req.Header.Get("Content-Type") + "\n" +
+		"host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		"", // No query string
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + config["region"] + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+config["secret_access_key"]), day)
+	key = hmacSHA256(key, config["region"])
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		config["access_key_id"], scope, signedHeaders, signature))
+}
+
+// uriEncode percent-encodes per the SigV4 rules (RFC 3986 unreserved characters
+// kept; "/" kept in object keys)
+func uriEncode(s string, keepSlash bool) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', keepSlash && c == '/':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
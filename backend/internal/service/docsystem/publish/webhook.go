@@ -0,0 +1,78 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	models "meridian/internal/domain/models/docsystem"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the webhook body ("sha256=<hex>") when
+// the target has a secret, so receivers can verify deliveries
+const SignatureHeader = "X-Meridian-Signature"
+
+// webhookPublisher POSTs the bundle (Markdown and rendered HTML) as JSON.
+//
+// Config: url (required), secret (optional, signs the body)
+type webhookPublisher struct {
+	egress *Egress
+}
+
+// NewWebhookPublisher creates a webhook publisher
+func NewWebhookPublisher(egress *Egress) docsysSvc.Publisher {
+	return &webhookPublisher{egress: egress}
+}
+
+func (p *webhookPublisher) Kind() string { return models.PublishKindWebhook }
+
+func (p *webhookPublisher) SecretKeys() []string { return []string{"secret"} }
+
+func (p *webhookPublisher) Validate(config map[string]string) error {
+	if err := requireKeys(config, "url"); err != nil {
+		return err
+	}
+	return p.egress.CheckURL("url", config["url"])
+}
+
+func (p *webhookPublisher) Publish(ctx context.Context, config map[string]string, bundle *models.PublishBundle) (string, error) {
+	// Targets saved under an older policy are checked again
+	if err := p.egress.CheckURL("url", config["url"]); err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(bundle)
+	if err != nil {
+		return "", fmt.Errorf("encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config["url"], bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if secret := config["secret"]; secret != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := p.egress.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("deliver webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", responseError("deliver webhook", resp)
+	}
+	return fmt.Sprintf("HTTP %d", resp.StatusCode), nil
+}
@@ -0,0 +1,117 @@
+package publish
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	models "meridian/internal/domain/models/docsystem"
+)
+
+func TestWebhookPublisher_SignsBody(t *testing.T) {
+	var body []byte
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	config := map[string]string{"url": server.URL, "secret": "s3cret"}
+	publisher := NewWebhookPublisher(NewEgress("127.0.0.1"))
+	if err := publisher.Validate(config); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	detail, err := publisher.Publish(context.Background(), config, &models.PublishBundle{
+		ProjectID: "p1",
+		Documents: []models.PublishedDocument{{ID: "d1", Path: "One", Markdown: "# One"}},
+	})
+	if err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if detail != "HTTP 202" {
+		t.Errorf("detail = %q, want HTTP 202", detail)
+	}
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); signature != want {
+		t.Errorf("signature = %q, want %q", signature, want)
+	}
+}
+
+func TestWebhookPublisher_RefusesPrivateDestinations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("internal secret"))
+	}))
+	defer server.Close()
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+	publisher := NewWebhookPublisher(NewEgress(""))
+	for _, url := range []string{
+		server.URL, // http
+		"https://127.0.0.1:" + port,
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::ffff:10.0.0.1]/hook",
+	} {
+		if err := publisher.Validate(map[string]string{"url": url}); err == nil {
+			t.Errorf("Validate(%s) succeeded, want refused", url)
+		}
+	}
+
+	// A hostname passes validation but is refused once it resolves to loopback
+	bundle := &models.PublishBundle{ProjectID: "p1"}
+	_, err := publisher.Publish(context.Background(), map[string]string{"url": "https://localhost:" + port}, bundle)
+	if !errors.Is(err, errBlockedAddress) {
+		t.Errorf("Publish to localhost err = %v, want errBlockedAddress", err)
+	}
+
+	// Allowed hosts are reached, but response bodies aren't echoed
+	_, err = NewWebhookPublisher(NewEgress("127.0.0.1")).Publish(context.Background(), map[string]string{"url": server.URL}, bundle)
+	if err == nil || strings.Contains(err.Error(), "internal secret") {
+		t.Errorf("Publish err = %v, want a failure without the response body", err)
+	}
+}
+
+func TestBlockedAddress(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"127.0.0.1":        true,
+		"10.1.2.3":         true,
+		"172.16.0.1":       true,
+		"192.168.1.1":      true,
+		"169.254.169.254":  true,
+		"100.100.100.200":  true,
+		"0.0.0.0":          true,
+		"::1":              true,
+		"fd00::1":          true,
+		"fe80::1%eth0":     true,
+		"::ffff:127.0.0.1": true,
+		"93.184.216.34":    false,
+		"2606:4700::1111":  false,
+	} {
+		if got := blockedAddress(netip.MustParseAddr(addr)); got != want {
+			t.Errorf("blockedAddress(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestUriEncode(t *testing.T) {
+	if got := uriEncode("Chapters/Ünï (1).md", true); got != "Chapters/%C3%9Cn%C3%AF%20%281%29.md" {
+		t.Errorf("uriEncode = %q", got)
+	}
+	if got := uriEncode("a/b", false); got != "a%2Fb" {
+		t.Errorf("uriEncode without slash = %q", got)
+	}
+}
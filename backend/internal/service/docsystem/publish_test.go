@@ -0,0 +1,58 @@
+package docsystem
+
+import (
+	"testing"
+
+	models "meridian/internal/domain/models/docsystem"
+)
+
+func TestFilterPublishDocuments(t *testing.T) {
+	chapters, drafts := "f-chapters", "f-drafts"
+	folders := []models.Folder{
+		{ID: chapters, Name: "Chapters"},
+		{ID: drafts, Name: "Drafts", ParentID: &chapters},
+	}
+	documents := []models.Document{
+		{ID: "d-one", Name: "One", FolderID: &chapters, ContentHash: "h1"},
+		{ID: "d-draft", Name: "Draft", FolderID: &drafts, ContentHash: "h2"},
+		{ID: "d-notes", Name: "Notes", ContentHash: "h3"},
+	}
+
+	paths := func(docs []models.Document) []string {
+		out := make([]string, len(docs))
+		for i, doc := range docs {
+			out[i] = doc.Path
+		}
+		return out
+	}
+	tests := []struct {
+		name        string
+		documentIDs []string
+		folderIDs   []string
+		want        []string
+	}{
+		{"everything", nil, nil, []string{"Chapters/Drafts/Draft", "Chapters/One", "Notes"}},
+		{"folder subtree", nil, []string{chapters}, []string{"Chapters/Drafts/Draft", "Chapters/One"}},
+		{"documents and folder", []string{"d-notes"}, []string{drafts}, []string{"Chapters/Drafts/Draft", "Notes"}},
+		{"deleted selection", []string{"d-gone"}, nil, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := paths(filterPublishDocuments(folders, documents, tt.documentIDs, tt.folderIDs))
+			if len(got) != len(tt.want) {
+				t.Fatalf("paths = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("paths = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	before := publishFingerprint(filterPublishDocuments(folders, documents, nil, nil))
+	documents[0].ContentHash = "h1-edited"
+	if after := publishFingerprint(filterPublishDocuments(folders, documents, nil, nil)); after == before {
+		t.Error("fingerprint did not change after a content edit")
+	}
+}
@@ -0,0 +1,264 @@
+package docsystem
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	models "meridian/internal/domain/models/docsystem"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+const (
+	// readingWordsPerMinute is the average silent reading speed for prose
+	readingWordsPerMinute = 238
+
+	// maxPacingSections bounds DocumentAnalytics.Sections
+	maxPacingSections = 10
+
+	// maxTopAdverbs bounds DocumentAnalytics.TopAdverbs
+	maxTopAdverbs = 10
+)
+
+// notAdverbs end in -ly but are (mostly) not adverbs
+var notAdverbs = wordSet("family", "supply", "reply", "apply", "comply", "rely", "ally", "belly",
+	"bully", "jelly", "holy", "ugly", "lovely", "lonely", "friendly", "silly", "likely", "early",
+	"only", "daily", "weekly", "monthly", "yearly", "elderly", "costly", "deadly", "lively",
+	"curly", "chilly", "hilly", "smelly", "folly", "lily", "july", "italy", "emily", "sally", "molly")
+
+// paragraphStats are the counts of one paragraph
+type paragraphStats struct {
+	words, sentences, dialogueWords int
+}
+
+// readabilityStats accumulates document analytics while scanning prose
+type readabilityStats struct {
+	syllables     int
+	sentenceWords []int
+	current       int // Words in the sentence being read
+	paragraphs    []paragraphStats
+	paragraph     paragraphStats // The paragraph being read
+	adverbs       map[string]int
+}
+
+// computeAnalytics computes a document's readability and pacing statistics (see
+// scanProse). Readability uses the Flesch formulas with English syllable estimates.
+func computeAnalytics(analyzer docsysSvc.ContentAnalyzer, markdown string) models.DocumentAnalytics {
+	stats := &readabilityStats{adverbs: make(map[string]int)}
+	scanProse(analyzer, markdown, stats)
+	return stats.analytics()
+}
+
+func (s *readabilityStats) word(word string, dialogue bool) {
+	s.current++
+	s.paragraph.words++
+	if dialogue {
+		s.paragraph.dialogueWords++
+	}
+	s.syllables += countSyllables(word)
+	if isAdverb(word) {
+		s.adverbs[word]++
+	}
+}
+
+func (s *readabilityStats) endSentence() {
+	s.sentenceWords = append(s.sentenceWords, s.current)
+	s.current = 0
+	s.paragraph.sentences++
+}
+
+func (s *readabilityStats) endParagraph() {
+	s.paragraphs = append(s.paragraphs, s.paragraph)
+	s.paragraph = paragraphStats{}
+}
+
+// analytics turns the counts into document analytics
+func (s *readabilityStats) analytics() models.DocumentAnalytics {
+	total := paragraphStats{}
+	for _, p := range s.paragraphs {
+		total.words += p.words
+		total.sentences += p.sentences
+		total.dialogueWords += p.dialogueWords
+	}
+
+	analytics := models.DocumentAnalytics{
+		WordCount:      total.words,
+		SentenceCount:  total.sentences,
+		ParagraphCount: len(s.paragraphs),
+		TopAdverbs:     s.topAdverbs(),
+		Sections:       []models.PacingSection{},
+	}
+	if total.words == 0 {
+		return analytics
+	}
+
+	words := float64(total.words)
+	wordsPerSentence := words / float64(total.sentences)
+	syllablesPerWord := float64(s.syllables) / words
+	avgParagraph := words / float64(len(s.paragraphs))
+	dialogue := float64(total.dialogueWords) / words
+
+	adverbs := 0
+	for _, count := range s.adverbs {
+		adverbs += count
+	}
+
+	analytics.ReadingMinutes = (total.words + readingWordsPerMinute - 1) / readingWordsPerMinute
+	analytics.FleschReadingEase = round1(206.835 - 1.015*wordsPerSentence - 84.6*syllablesPerWord)
+	analytics.FleschKincaidGrade = round1(0.39*wordsPerSentence + 11.8*syllablesPerWord - 15.59)
+	analytics.ReadingLevel = readingLevel(analytics.FleschReadingEase)
+	analytics.AvgSentenceLength = round1(wordsPerSentence)
+	analytics.SentenceLengthVariation = round1(stdDev(s.sentenceWords))
+	analytics.AvgParagraphLength = round1(avgParagraph)
+	analytics.DialogueRatio = round2(dialogue)
+	analytics.AdverbDensity = round2(float64(adverbs) * 100 / words)
+	analytics.Pace = pace(wordsPerSentence, avgParagraph, dialogue)
+	analytics.Sections = s.sections()
+	return analytics
+}
+
+// sections splits the paragraphs into up to maxPacingSections runs of similar size
+func (s *readabilityStats) sections() []models.PacingSection {
+	count := min(maxPacingSections, len(s.paragraphs))
+	sections := make([]models.PacingSection, 0, count)
+	for i := 0; i < count; i++ {
+		start := i * len(s.paragraphs) / count
+		end := (i + 1) * len(s.paragraphs) / count
+
+		run := paragraphStats{}
+		for _, p := range s.paragraphs[start:end] {
+			run.words += p.words
+			run.sentences += p.sentences
+			run.dialogueWords += p.dialogueWords
+		}
+		words := float64(run.words)
+		avgSentence := words / float64(run.sentences)
+		dialogue := float64(run.dialogueWords) / words
+
+		sections = append(sections, models.PacingSection{
+			StartParagraph:    start,
+			Paragraphs:        end - start,
+			Words:             run.words,
+			AvgSentenceLength: round1(avgSentence),
+			DialogueRatio:     round2(dialogue),
+			Pace:              pace(avgSentence, words/float64(end-start), dialogue),
+		})
+	}
+	return sections
+}
+
+// topAdverbs returns the most frequent adverbs, ties in alphabetical order
+func (s *readabilityStats) topAdverbs() []string {
+	adverbs := make([]string, 0, len(s.adverbs))
+	for adverb := range s.adverbs {
+		adverbs = append(adverbs, adverb)
+	}
+	sort.Slice(adverbs, func(i, j int) bool {
+		ci, cj := s.adverbs[adverbs[i]], s.adverbs[adverbs[j]]
+		if ci != cj {
+			return ci > cj
+		}
+		return adverbs[i] < adverbs[j]
+	})
+	if len(adverbs) > maxTopAdverbs {
+		adverbs = adverbs[:maxTopAdverbs]
+	}
+	return adverbs
+}
+
+// pace rates prose from its sentence length, paragraph length and dialogue: short
+// sentences, short paragraphs and dialogue read fast; long ones and dense narration slow
+func pace(avgSentence, avgParagraph, dialogue float64) string {
+	score := 0
+	switch {
+	case avgSentence < 12:
+		score++
+	case avgSentence > 20:
+		score--
+	}
+	switch {
+	case avgParagraph < 40:
+		score++
+	case avgParagraph > 100:
+		score--
+	}
+	switch {
+	case dialogue > 0.3:
+		score++
+	case dialogue < 0.05:
+		score--
+	}
+
+	switch {
+	case score >= 2:
+		return models.PaceFast
+	case score <= -2:
+		return models.PaceSlow
+	default:
+		return models.PaceModerate
+	}
+}
+
+// readingLevel labels a Flesch reading ease score
+func readingLevel(ease float64) string {
+	switch {
+	case ease >= 90:
+		return "very easy"
+	case ease >= 80:
+		return "easy"
+	case ease >= 70:
+		return "fairly easy"
+	case ease >= 60:
+		return "standard"
+	case ease >= 50:
+		return "fairly difficult"
+	case ease >= 30:
+		return "difficult"
+	default:
+		return "very difficult"
+	}
+}
+
+// countSyllables estimates the syllables of an English word from its vowel groups,
+// not counting a silent final "e" ("time") but counting "-le" ("table")
+func countSyllables(word string) int {
+	word = strings.TrimSuffix(strings.TrimSuffix(word, "'s"), "’s")
+	if isNumber(word) {
+		return 1
+	}
+
+	count := 0
+	prevVowel := false
+	for _, r := range word {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !prevVowel {
+			count++
+		}
+		prevVowel = vowel
+	}
+	if count > 1 && strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && !strings.HasSuffix(word, "ee") {
+		count--
+	}
+	return max(count, 1)
+}
+
+// isAdverb reports whether word looks like an -ly adverb ("quickly", "suddenly")
+func isAdverb(word string) bool {
+	return len(word) >= 5 && strings.HasSuffix(word, "ly") && !notAdverbs[word]
+}
+
+func stdDev(values []int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += float64(v)
+	}
+	mean := sum / float64(len(values))
+	var squares float64
+	for _, v := range values {
+		squares += (float64(v) - mean) * (float64(v) - mean)
+	}
+	return math.Sqrt(squares / float64(len(values)))
+}
@@ -0,0 +1,74 @@
+package docsystem
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	models "meridian/internal/domain/models/docsystem"
+)
+
+func TestCountSyllables(t *testing.T) {
+	tests := map[string]int{
+		"cat":       1,
+		"time":      1,
+		"table":     2,
+		"reading":   2,
+		"beautiful": 3,
+		"agree":     2,
+		"rhythm":    1,
+		"harbor's":  2,
+		"1984":      1,
+	}
+	for word, want := range tests {
+		if got := countSyllables(word); got != want {
+			t.Errorf("countSyllables(%q) = %d, want %d", word, got, want)
+		}
+	}
+}
+
+func TestComputeAnalytics(t *testing.T) {
+	dialogue := "\"Run,\" she said. \"Now!\" He ran quickly. She followed.\n\n"
+	narration := "The long road wound slowly through the valley, past orchards and farms and the " +
+		"old mill where the river turned the wheel day after day without rest or complaint. " +
+		"Evening settled over the hills, and the travellers finally stopped to make camp.\n\n"
+	content := "# Chapter\n\n" + strings.Repeat(dialogue, 6) + strings.Repeat(narration, 6)
+
+	analytics := computeAnalytics(NewContentAnalyzer(), content)
+
+	if analytics.ParagraphCount != 13 {
+		t.Errorf("paragraphs = %d, want 13", analytics.ParagraphCount)
+	}
+	if analytics.ReadingMinutes != 2 {
+		t.Errorf("reading minutes = %d, want 2 (%d words)", analytics.ReadingMinutes, analytics.WordCount)
+	}
+	if analytics.FleschReadingEase <= 0 || analytics.FleschReadingEase >= 100 || analytics.ReadingLevel == "" {
+		t.Errorf("reading ease = %v (%q)", analytics.FleschReadingEase, analytics.ReadingLevel)
+	}
+	if !reflect.DeepEqual(analytics.TopAdverbs, []string{"finally", "quickly", "slowly"}) {
+		t.Errorf("top adverbs = %v", analytics.TopAdverbs)
+	}
+	if analytics.AdverbDensity <= 0 || analytics.DialogueRatio <= 0 {
+		t.Errorf("adverb density = %v, dialogue ratio = %v", analytics.AdverbDensity, analytics.DialogueRatio)
+	}
+
+	// The dialogue runs at the start read fast, the narration at the end slow
+	if len(analytics.Sections) != 10 {
+		t.Fatalf("sections = %d, want 10", len(analytics.Sections))
+	}
+	first, last := analytics.Sections[1], analytics.Sections[9]
+	if first.Pace != models.PaceFast || last.Pace != models.PaceSlow {
+		t.Errorf("section paces = %q ... %q", first.Pace, last.Pace)
+	}
+	if last.StartParagraph+last.Paragraphs != analytics.ParagraphCount {
+		t.Errorf("sections end at paragraph %d", last.StartParagraph+last.Paragraphs)
+	}
+}
+
+func TestComputeAnalytics_Empty(t *testing.T) {
+	analytics := computeAnalytics(NewContentAnalyzer(), "```\ncode only\n```")
+
+	if analytics.WordCount != 0 || analytics.Pace != "" || len(analytics.Sections) != 0 || analytics.TopAdverbs == nil {
+		t.Errorf("analytics = %+v", analytics)
+	}
+}
@@ -0,0 +1,137 @@
+package docsystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+// skillService implements the SkillService interface
+type skillService struct {
+	folderRepo   docsysRepo.FolderRepository
+	documentRepo docsysRepo.DocumentRepository
+	authorizer   services.ResourceAuthorizer
+	logger       *slog.Logger
+}
+
+// NewSkillService creates a new skill service
+func NewSkillService(
+	folderRepo docsysRepo.FolderRepository,
+	documentRepo docsysRepo.DocumentRepository,
+	authorizer services.ResourceAuthorizer,
+	logger *slog.Logger,
+) docsysSvc.SkillService {
+	return &skillService{
+		folderRepo:   folderRepo,
+		documentRepo: documentRepo,
+		authorizer:   authorizer,
+		logger:       logger,
+	}
+}
+
+// LintSkills validates every folder under .skills/ in a project
+// Folders without a SKILL document are reported as invalid, since they can be selected
+// but would contribute nothing to the system prompt.
+func (s *skillService) LintSkills(ctx context.Context, userID, projectID string) (*models.SkillLintResults, error) {
+	// Authorize: check user can access this project
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	results := &models.SkillLintResults{Skills: []models.SkillLintReport{}}
+
+	skillFolders, err := s.listSkillFolders(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, folder := range skillFolders {
+		report, err := s.lintSkill(ctx, projectID, folder.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range report.Issues {
+			if issue.Severity == models.SkillIssueError {
+				results.ErrorCount++
+			} else {
+				results.WarningCount++
+			}
+		}
+		results.Skills = append(results.Skills, *report)
+	}
+
+	s.logger.Debug("skills linted",
+		"project_id", projectID,
+		"skills", len(results.Skills),
+		"errors", results.ErrorCount,
+		"warnings", results.WarningCount,
+	)
+
+	return results, nil
+}
+
+// listSkillFolders returns the immediate children of the root .skills folder, sorted by name
+func (s *skillService) listSkillFolders(ctx context.Context, projectID string) ([]models.Folder, error) {
+	rootFolders, err := s.folderRepo.ListChildren(ctx, nil, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list root folders: %w", err)
+	}
+
+	for _, folder := range rootFolders {
+		if folder.Name != models.SkillsFolderName {
+			continue
+		}
+		children, err := s.folderRepo.ListChildren(ctx, &folder.ID, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("list skill folders: %w", err)
+		}
+		sort.Slice(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+		return children, nil
+	}
+
+	return nil, nil
+}
+
+// lintSkill builds the report for one skill folder
+func (s *skillService) lintSkill(ctx context.Context, projectID, skillName string) (*models.SkillLintReport, error) {
+	path := fmt.Sprintf("%s/%s/%s", models.SkillsFolderName, skillName, models.SkillDocumentName)
+	report := &models.SkillLintReport{
+		Skill:  skillName,
+		Path:   path,
+		Issues: []models.SkillIssue{},
+	}
+
+	doc, err := s.documentRepo.GetByPath(ctx, path, projectID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			report.Issues = append(report.Issues, models.SkillIssue{
+				Field:    "document",
+				Message:  fmt.Sprintf("skill folder has no %s document", models.SkillDocumentName),
+				Severity: models.SkillIssueError,
+			})
+			return report, nil
+		}
+		return nil, fmt.Errorf("get skill document '%s': %w", skillName, err)
+	}
+
+	parsed := models.ParseSkill(skillName, doc.Content)
+	report.DocumentID = &doc.ID
+	report.Valid = !parsed.HasErrors()
+	if parsed.Metadata != (models.SkillMetadata{}) {
+		meta := parsed.Metadata
+		report.Metadata = &meta
+	}
+	if parsed.Issues != nil {
+		report.Issues = parsed.Issues
+	}
+
+	return report, nil
+}
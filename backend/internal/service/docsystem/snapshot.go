@@ -0,0 +1,458 @@
+package docsystem
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"meridian/internal/config"
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	"meridian/internal/domain/repositories"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	"meridian/internal/utils"
+)
+
+// snapshotService implements the SnapshotService interface
+type snapshotService struct {
+	snapshotRepo    docsysRepo.SnapshotRepository
+	docRepo         docsysRepo.DocumentRepository
+	folderRepo      docsysRepo.FolderRepository
+	txManager       repositories.TransactionManager
+	contentAnalyzer docsysSvc.ContentAnalyzer
+	authorizer      services.ResourceAuthorizer
+	logger          *slog.Logger
+}
+
+// NewSnapshotService creates a new snapshot service
+func NewSnapshotService(
+	snapshotRepo docsysRepo.SnapshotRepository,
+	docRepo docsysRepo.DocumentRepository,
+	folderRepo docsysRepo.FolderRepository,
+	txManager repositories.TransactionManager,
+	contentAnalyzer docsysSvc.ContentAnalyzer,
+	authorizer services.ResourceAuthorizer,
+	logger *slog.Logger,
+) docsysSvc.SnapshotService {
+	return &snapshotService{
+		snapshotRepo:    snapshotRepo,
+		docRepo:         docRepo,
+		folderRepo:      folderRepo,
+		txManager:       txManager,
+		contentAnalyzer: contentAnalyzer,
+		authorizer:      authorizer,
+		logger:          logger,
+	}
+}
+
+// CreateSnapshot captures the project's current tree
+func (s *snapshotService) CreateSnapshot(ctx context.Context, userID, projectID string, req *docsysSvc.CreateSnapshotRequest) (*models.Snapshot, error) {
+	name := strings.TrimSpace(req.Name)
+	if utils.RuneLen(name) > config.MaxSnapshotNameLength {
+		return nil, fmt.Errorf("%w: name exceeds maximum length of %d", domain.ErrValidation, config.MaxSnapshotNameLength)
+	}
+
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	snapshot, err := s.snapshotRepo.Create(ctx, projectID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("snapshot created",
+		"id", snapshot.ID,
+		"project_id", projectID,
+		"documents", snapshot.DocumentCount,
+		"folders", snapshot.FolderCount,
+	)
+
+	return snapshot, nil
+}
+
+// ListSnapshots returns a project's snapshots, newest first
+func (s *snapshotService) ListSnapshots(ctx context.Context, userID, projectID string) ([]models.Snapshot, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+	return s.snapshotRepo.List(ctx, projectID)
+}
+
+// GetSnapshot returns a snapshot with its folders and documents
+func (s *snapshotService) GetSnapshot(ctx context.Context, userID, projectID, snapshotID string) (*models.SnapshotWithEntries, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	snapshot, err := s.snapshotRepo.Get(ctx, snapshotID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.snapshotRepo.GetEntries(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SnapshotWithEntries{Snapshot: *snapshot, Entries: entries}, nil
+}
+
+// DiffSnapshot compares a snapshot (from) with another snapshot or the live tree (to)
+func (s *snapshotService) DiffSnapshot(ctx context.Context, userID, projectID, snapshotID, against string) (*models.SnapshotDiff, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.snapshotRepo.Get(ctx, snapshotID, projectID); err != nil {
+		return nil, err
+	}
+	fromEntries, err := s.snapshotRepo.GetEntries(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	if against == "" {
+		against = docsysSvc.SnapshotCurrent
+	}
+	var toEntries []models.SnapshotEntry
+	if against == docsysSvc.SnapshotCurrent {
+		toEntries, err = s.snapshotRepo.CurrentEntries(ctx, projectID)
+	} else {
+		if _, err := s.snapshotRepo.Get(ctx, against, projectID); err != nil {
+			return nil, err
+		}
+		toEntries, err = s.snapshotRepo.GetEntries(ctx, against)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffSnapshotEntries(fromEntries, toEntries)
+	diff.From, diff.To = snapshotID, against
+
+	if err := s.addLineCounts(ctx, projectID, diff, fromEntries, toEntries); err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+// addLineCounts fills in added/removed line counts for modified documents
+func (s *snapshotService) addLineCounts(ctx context.Context, projectID string, diff *models.SnapshotDiff, from, to []models.SnapshotEntry) error {
+	if len(diff.Modified) == 0 {
+		return nil
+	}
+
+	fromHashes := documentHashesByPath(from)
+	toHashes := documentHashesByPath(to)
+	hashes := make([]string, 0, 2*len(diff.Modified))
+	for _, entry := range diff.Modified {
+		hashes = append(hashes, fromHashes[entry.Path], toHashes[entry.Path])
+	}
+
+	contents, err := s.snapshotRepo.GetContents(ctx, projectID, hashes)
+	if err != nil {
+		return err
+	}
+
+	for i, entry := range diff.Modified {
+		diff.Modified[i].LinesAdded, diff.Modified[i].LinesRemoved =
+			lineChanges(contents[fromHashes[entry.Path]], contents[toHashes[entry.Path]])
+	}
+	return nil
+}
+
+// RestoreSnapshot makes the live tree match a snapshot, by path, in one transaction:
+//   - folders and documents missing from the tree are created (or undeleted, keeping their IDs)
+//   - documents whose content differs are updated
+//   - folders and documents not in the snapshot are deleted (soft delete)
+//
+// The current tree is snapshotted first ("Before restoring ...") so the restore can be undone.
+func (s *snapshotService) RestoreSnapshot(ctx context.Context, userID, projectID, snapshotID string) (*models.SnapshotRestoreResult, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	snapshot, err := s.snapshotRepo.Get(ctx, snapshotID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.SnapshotRestoreResult{Restored: *snapshot}
+	err = s.txManager.ExecTx(ctx, func(txCtx context.Context) error {
+		backupName := "Before restoring " + snapshotLabel(snapshot)
+		backup, err := s.snapshotRepo.Create(txCtx, projectID, utils.TruncateWithEllipsis(backupName, config.MaxSnapshotNameLength))
+		if err != nil {
+			return err
+		}
+		result.Backup = *backup
+
+		return s.restoreTree(txCtx, projectID, snapshotID, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("snapshot restored",
+		"id", snapshotID,
+		"project_id", projectID,
+		"backup_id", result.Backup.ID,
+		"documents_created", result.DocumentsCreated,
+		"documents_updated", result.DocumentsUpdated,
+		"documents_deleted", result.DocumentsDeleted,
+		"folders_created", result.FoldersCreated,
+		"folders_deleted", result.FoldersDeleted,
+	)
+
+	return result, nil
+}
+
+// restoreTree applies a snapshot's tree to the project (must run inside a transaction)
+func (s *snapshotService) restoreTree(ctx context.Context, projectID, snapshotID string, result *models.SnapshotRestoreResult) error {
+	target, err := s.snapshotRepo.GetEntries(ctx, snapshotID)
+	if err != nil {
+		return err
+	}
+	current, err := s.snapshotRepo.CurrentEntries(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	currentFolders := make(map[string]string) // path → folder ID
+	currentDocs := make(map[string]models.SnapshotEntry)
+	for _, entry := range current {
+		if entry.Kind == models.SnapshotEntryFolder {
+			currentFolders[entry.Path] = entry.SourceID
+		} else {
+			currentDocs[entry.Path] = entry
+		}
+	}
+
+	// Folders: entries are ordered by path, so parents come before their children
+	targetPaths := make(map[string]bool, len(target))
+	for _, entry := range target {
+		targetPaths[entry.Kind+"|"+entry.Path] = true
+		if entry.Kind != models.SnapshotEntryFolder {
+			continue
+		}
+		if _, exists := currentFolders[entry.Path]; exists {
+			continue
+		}
+
+		parentPath, name := splitEntryPath(entry.Path)
+		parentID := folderIDForPath(currentFolders, parentPath)
+		id, err := s.snapshotRepo.Undelete(ctx, models.SnapshotEntryFolder, projectID, parentID, name)
+		if err != nil {
+			return err
+		}
+		if id == "" {
+			folder, err := s.folderRepo.CreateIfNotExists(ctx, projectID, parentID, name)
+			if err != nil {
+				return fmt.Errorf("restore folder %s: %w", entry.Path, err)
+			}
+			id = folder.ID
+		}
+		currentFolders[entry.Path] = id
+		result.FoldersCreated++
+	}
+
+	// Documents: load the content of every document that has to change
+	var hashes []string
+	for _, entry := range target {
+		if entry.Kind == models.SnapshotEntryDocument && currentDocs[entry.Path].ContentHash != entry.ContentHash {
+			hashes = append(hashes, entry.ContentHash)
+		}
+	}
+	contents, err := s.snapshotRepo.GetContents(ctx, projectID, hashes)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, entry := range target {
+		if entry.Kind != models.SnapshotEntryDocument {
+			continue
+		}
+		existing, exists := currentDocs[entry.Path]
+		if exists && existing.ContentHash == entry.ContentHash {
+			continue
+		}
+		content, ok := contents[entry.ContentHash]
+		if !ok {
+			return fmt.Errorf("restore document %s: content %s not found", entry.Path, entry.ContentHash)
+		}
+
+		docID := existing.SourceID
+		if !exists {
+			parentPath, name := splitEntryPath(entry.Path)
+			parentID := folderIDForPath(currentFolders, parentPath)
+			docID, err = s.snapshotRepo.Undelete(ctx, models.SnapshotEntryDocument, projectID, parentID, name)
+			if err != nil {
+				return err
+			}
+			if docID == "" {
+				doc := &models.Document{
+					ProjectID: projectID,
+					FolderID:  parentID,
+					Name:      name,
+					Content:   content,
+					WordCount: s.contentAnalyzer.CountWords(content),
+					CreatedAt: now,
+					UpdatedAt: now,
+				}
+				if err := s.docRepo.Create(ctx, doc); err != nil {
+					return fmt.Errorf("restore document %s: %w", entry.Path, err)
+				}
+				result.DocumentsCreated++
+				continue
+			}
+		}
+
+		doc, err := s.docRepo.GetByID(ctx, docID, projectID)
+		if err != nil {
+			return fmt.Errorf("restore document %s: %w", entry.Path, err)
+		}
+		doc.Content = content
+		doc.WordCount = s.contentAnalyzer.CountWords(content)
+		doc.UpdatedAt = now
+		if err := s.docRepo.Update(ctx, doc); err != nil {
+			return fmt.Errorf("restore document %s: %w", entry.Path, err)
+		}
+		if exists {
+			result.DocumentsUpdated++
+		} else {
+			result.DocumentsCreated++
+		}
+	}
+
+	// Deletions: documents first, then folders deepest first
+	for path, entry := range currentDocs {
+		if targetPaths[models.SnapshotEntryDocument+"|"+path] {
+			continue
+		}
+		if err := s.docRepo.Delete(ctx, entry.SourceID, projectID); err != nil {
+			return fmt.Errorf("delete document %s: %w", path, err)
+		}
+		result.DocumentsDeleted++
+	}
+
+	var staleFolders []string
+	for path := range currentFolders {
+		if !targetPaths[models.SnapshotEntryFolder+"|"+path] {
+			staleFolders = append(staleFolders, path)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(staleFolders)))
+	for _, path := range staleFolders {
+		if err := s.folderRepo.Delete(ctx, currentFolders[path], projectID); err != nil {
+			return fmt.Errorf("delete folder %s: %w", path, err)
+		}
+		result.FoldersDeleted++
+	}
+
+	return nil
+}
+
+// compressBatchSize bounds how many blobs are held in memory per CompressBlobs call
+const compressBatchSize = 100
+
+// CompressContent compresses snapshot blobs in batches until none of at least minBytes are left
+func (s *snapshotService) CompressContent(ctx context.Context, minBytes int) (int, error) {
+	total := 0
+	for {
+		n, err := s.snapshotRepo.CompressBlobs(ctx, minBytes, compressBatchSize)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < compressBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		s.logger.Info("snapshot content compressed",
+			"count", total,
+			"min_bytes", minBytes,
+		)
+	}
+
+	return total, nil
+}
+
+// diffSnapshotEntries compares two trees by kind and path
+func diffSnapshotEntries(from, to []models.SnapshotEntry) *models.SnapshotDiff {
+	diff := &models.SnapshotDiff{
+		Added:    []models.SnapshotDiffEntry{},
+		Removed:  []models.SnapshotDiffEntry{},
+		Modified: []models.SnapshotDiffEntry{},
+	}
+
+	key := func(entry models.SnapshotEntry) string { return entry.Kind + "|" + entry.Path }
+	fromByKey := make(map[string]models.SnapshotEntry, len(from))
+	for _, entry := range from {
+		fromByKey[key(entry)] = entry
+	}
+	toByKey := make(map[string]models.SnapshotEntry, len(to))
+	for _, entry := range to {
+		toByKey[key(entry)] = entry
+	}
+
+	for _, entry := range to {
+		previous, existed := fromByKey[key(entry)]
+		switch {
+		case !existed:
+			diff.Added = append(diff.Added, models.SnapshotDiffEntry{Kind: entry.Kind, Path: entry.Path})
+		case previous.ContentHash != entry.ContentHash:
+			diff.Modified = append(diff.Modified, models.SnapshotDiffEntry{Kind: entry.Kind, Path: entry.Path})
+		}
+	}
+	for _, entry := range from {
+		if _, exists := toByKey[key(entry)]; !exists {
+			diff.Removed = append(diff.Removed, models.SnapshotDiffEntry{Kind: entry.Kind, Path: entry.Path})
+		}
+	}
+
+	return diff
+}
+
+// documentHashesByPath maps document paths to content hashes
+func documentHashesByPath(entries []models.SnapshotEntry) map[string]string {
+	hashes := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if entry.Kind == models.SnapshotEntryDocument {
+			hashes[entry.Path] = entry.ContentHash
+		}
+	}
+	return hashes
+}
+
+// splitEntryPath splits "a/b/c" into parent path "a/b" and name "c"
+func splitEntryPath(path string) (string, string) {
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		return path[:i], path[i+1:]
+	}
+	return "", path
+}
+
+// folderIDForPath returns the folder ID for a path (nil for the root)
+func folderIDForPath(folders map[string]string, path string) *string {
+	if path == "" {
+		return nil
+	}
+	id := folders[path]
+	return &id
+}
+
+// snapshotLabel names a snapshot in generated text
+func snapshotLabel(snapshot *models.Snapshot) string {
+	if snapshot.Name != "" {
+		return fmt.Sprintf("%q", snapshot.Name)
+	}
+	return "snapshot of " + snapshot.CreatedAt.UTC().Format("2006-01-02 15:04 UTC")
+}
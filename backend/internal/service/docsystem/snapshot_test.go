@@ -0,0 +1,71 @@
+package docsystem
+
+import (
+	"testing"
+
+	models "meridian/internal/domain/models/docsystem"
+)
+
+func TestDiffSnapshotEntries(t *testing.T) {
+	folder := func(path string) models.SnapshotEntry {
+		return models.SnapshotEntry{Kind: models.SnapshotEntryFolder, Path: path}
+	}
+	doc := func(path, hash string) models.SnapshotEntry {
+		return models.SnapshotEntry{Kind: models.SnapshotEntryDocument, Path: path, ContentHash: hash}
+	}
+
+	from := []models.SnapshotEntry{
+		folder("chapters"),
+		doc("chapters/one", "h1"),
+		doc("chapters/two", "h2"),
+		doc("notes", "h3"),
+	}
+	to := []models.SnapshotEntry{
+		folder("chapters"),
+		doc("chapters/one", "h1"),
+		doc("chapters/two", "h2-edited"),
+		folder("drafts"),
+		doc("drafts/three", "h4"),
+	}
+
+	diff := diffSnapshotEntries(from, to)
+
+	paths := func(entries []models.SnapshotDiffEntry) []string {
+		out := make([]string, len(entries))
+		for i, entry := range entries {
+			out[i] = entry.Kind + ":" + entry.Path
+		}
+		return out
+	}
+	assertPaths := func(name string, got, want []string) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("%s = %v, want %v", name, got, want)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("%s = %v, want %v", name, got, want)
+			}
+		}
+	}
+
+	assertPaths("added", paths(diff.Added), []string{"folder:drafts", "document:drafts/three"})
+	assertPaths("removed", paths(diff.Removed), []string{"document:notes"})
+	assertPaths("modified", paths(diff.Modified), []string{"document:chapters/two"})
+}
+
+func TestSplitEntryPath(t *testing.T) {
+	tests := []struct {
+		path, parent, name string
+	}{
+		{"notes", "", "notes"},
+		{"chapters/one", "chapters", "one"},
+		{"a/b/c", "a/b", "c"},
+	}
+	for _, tt := range tests {
+		parent, name := splitEntryPath(tt.path)
+		if parent != tt.parent || name != tt.name {
+			t.Errorf("splitEntryPath(%q) = (%q, %q), want (%q, %q)", tt.path, parent, name, tt.parent, tt.name)
+		}
+	}
+}
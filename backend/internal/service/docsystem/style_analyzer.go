@@ -0,0 +1,245 @@
+package docsystem
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	models "meridian/internal/domain/models/docsystem"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+const (
+	// shortSentenceWords and longSentenceWords bound the short/long sentence shares
+	shortSentenceWords = 8
+	longSentenceWords  = 25
+
+	// richnessWindow is the window size for vocabulary richness, so long samples
+	// aren't penalized for repeating common words more often
+	richnessWindow = 1000
+
+	// minMarkerCount is how many tense or person markers narration needs before the
+	// profile names a tense or person
+	minMarkerCount = 20
+
+	// maxCharacteristicWords bounds StyleProfile.CharacteristicWords
+	maxCharacteristicWords = 12
+)
+
+// English markers of narrative tense and person. The tense lists are common verbs and
+// auxiliaries; regular past forms are recognized by their -ed ending.
+var (
+	pastTenseMarkers = wordSet("was", "were", "had", "did", "said", "went", "came", "saw", "knew",
+		"thought", "took", "got", "made", "felt", "told", "found", "gave", "left", "began", "stood",
+		"sat", "heard", "ran", "could", "would")
+	presentTenseMarkers = wordSet("is", "are", "am", "has", "does", "says", "goes", "comes", "sees",
+		"knows", "thinks", "takes", "gets", "makes", "feels", "tells", "finds", "gives", "leaves",
+		"begins", "stands", "sits", "hears", "runs", "can", "will")
+
+	firstPersonMarkers  = wordSet("i", "me", "my", "mine", "myself", "we", "us", "our", "ours", "ourselves")
+	secondPersonMarkers = wordSet("you", "your", "yours", "yourself", "yourselves")
+	thirdPersonMarkers  = wordSet("he", "him", "his", "himself", "she", "her", "hers", "herself",
+		"they", "them", "their", "theirs", "themselves")
+
+	// styleStopWords are left out of the characteristic words
+	styleStopWords = wordSet("about", "after", "again", "also", "back", "been", "before", "being",
+		"came", "come", "could", "down", "even", "from", "have", "here", "into", "just", "know",
+		"like", "made", "make", "more", "much", "only", "other", "over", "said", "some", "still",
+		"than", "that", "their", "them", "then", "there", "these", "they", "thing", "this", "those",
+		"through", "time", "very", "want", "were", "what", "when", "where", "which", "while", "will",
+		"with", "would", "your", "yours", "him", "her", "hers", "his", "himself", "herself", "it's",
+		"don't", "didn't", "can't", "wasn't", "i'm", "i'd", "i'll", "she'd", "he'd", "they'd")
+)
+
+// styleStats accumulates the statistics of a style profile across documents
+type styleStats struct {
+	words          []string // Lowercased, in order (for vocabulary richness)
+	letters        int
+	sentenceWords  []int // Words per sentence
+	current        int   // Words in the sentence being read
+	dialogueWords  int
+	past, present  int
+	first, second  int
+	third          int
+	narrationCount map[string]int // Content word frequencies
+}
+
+// analyzeStyle computes a style profile's statistics from markdown documents (see
+// scanProse). Words inside quotation marks count as dialogue and are left out of the
+// tense and person counts, which describe the narration.
+func analyzeStyle(analyzer docsysSvc.ContentAnalyzer, documents []string) models.StyleProfile {
+	stats := &styleStats{narrationCount: make(map[string]int)}
+	for _, markdown := range documents {
+		scanProse(analyzer, markdown, stats)
+	}
+	return stats.profile()
+}
+
+// word counts one word
+func (s *styleStats) word(word string, dialogue bool) {
+	s.words = append(s.words, word)
+	s.current++
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			s.letters++
+		}
+	}
+	if dialogue {
+		s.dialogueWords++
+		return
+	}
+
+	switch {
+	case pastTenseMarkers[word] || (len(word) >= 5 && strings.HasSuffix(word, "ed")):
+		s.past++
+	case presentTenseMarkers[word]:
+		s.present++
+	}
+	switch {
+	case firstPersonMarkers[word]:
+		s.first++
+	case secondPersonMarkers[word]:
+		s.second++
+	case thirdPersonMarkers[word]:
+		s.third++
+	}
+	if len([]rune(word)) >= 4 && !styleStopWords[word] && !isNumber(word) {
+		s.narrationCount[word]++
+	}
+}
+
+// endSentence closes the current sentence
+func (s *styleStats) endSentence() {
+	s.sentenceWords = append(s.sentenceWords, s.current)
+	s.current = 0
+}
+
+// endParagraph is a no-op: style profiles don't look at paragraphs
+func (s *styleStats) endParagraph() {}
+
+// profile turns the counts into a style profile's statistics
+func (s *styleStats) profile() models.StyleProfile {
+	profile := models.StyleProfile{
+		WordCount:           len(s.words),
+		SentenceCount:       len(s.sentenceWords),
+		CharacteristicWords: s.characteristicWords(),
+	}
+	if len(s.words) == 0 {
+		return profile
+	}
+
+	short, long := 0, 0
+	for _, count := range s.sentenceWords {
+		if count < shortSentenceWords {
+			short++
+		} else if count > longSentenceWords {
+			long++
+		}
+	}
+	sentences := float64(len(s.sentenceWords))
+	words := float64(len(s.words))
+
+	profile.AvgSentenceLength = round1(words / sentences)
+	profile.ShortSentenceShare = round2(float64(short) / sentences)
+	profile.LongSentenceShare = round2(float64(long) / sentences)
+	profile.AvgWordLength = round1(float64(s.letters) / words)
+	profile.VocabularyRichness = round1(s.vocabularyRichness())
+	profile.DialogueShare = round2(float64(s.dialogueWords) / words)
+	profile.Tense = s.tense()
+	profile.Person = s.person()
+	return profile
+}
+
+// vocabularyRichness is the mean number of distinct words per 100 words over
+// consecutive windows of richnessWindow words (the whole text if it is shorter)
+func (s *styleStats) vocabularyRichness() float64 {
+	window := min(richnessWindow, len(s.words))
+	var total float64
+	windows := 0
+	for start := 0; start+window <= len(s.words); start += window {
+		distinct := make(map[string]bool, window)
+		for _, word := range s.words[start : start+window] {
+			distinct[word] = true
+		}
+		total += float64(len(distinct)) * 100 / float64(window)
+		windows++
+	}
+	return total / float64(windows)
+}
+
+// tense names the narration's dominant tense ("" with too few markers)
+func (s *styleStats) tense() string {
+	total := s.past + s.present
+	if total < minMarkerCount {
+		return ""
+	}
+	pastShare := float64(s.past) / float64(total)
+	switch {
+	case pastShare >= 0.7:
+		return models.StyleTensePast
+	case pastShare <= 0.3:
+		return models.StyleTensePresent
+	default:
+		return models.StyleTenseMixed
+	}
+}
+
+// person names the narration's point of view ("" with too few markers). Third-person
+// narration rarely says "I" outside dialogue, while first-person narration still
+// mentions other people, so a modest first-person share is enough.
+func (s *styleStats) person() string {
+	total := s.first + s.second + s.third
+	if total < minMarkerCount {
+		return ""
+	}
+	share := func(count int) float64 { return float64(count) / float64(total) }
+	switch {
+	case share(s.first) >= 0.25:
+		return models.StylePersonFirst
+	case share(s.second) >= 0.25:
+		return models.StylePersonSecond
+	case share(s.third) >= 0.6:
+		return models.StylePersonThird
+	default:
+		return models.StylePersonMixed
+	}
+}
+
+// characteristicWords returns the most frequent content words of the narration,
+// ties in alphabetical order; words used fewer than 3 times are left out
+func (s *styleStats) characteristicWords() []string {
+	words := make([]string, 0, len(s.narrationCount))
+	for word, count := range s.narrationCount {
+		if count >= 3 {
+			words = append(words, word)
+		}
+	}
+	sort.Slice(words, func(i, j int) bool {
+		ci, cj := s.narrationCount[words[i]], s.narrationCount[words[j]]
+		if ci != cj {
+			return ci > cj
+		}
+		return words[i] < words[j]
+	})
+	if len(words) > maxCharacteristicWords {
+		words = words[:maxCharacteristicWords]
+	}
+	return words
+}
+
+func wordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
+func isNumber(word string) bool {
+	return strings.IndexFunc(word, func(r rune) bool { return !unicode.IsDigit(r) }) == -1
+}
+
+func round1(value float64) float64 { return math.Round(value*10) / 10 }
+
+func round2(value float64) float64 { return math.Round(value*100) / 100 }
@@ -0,0 +1,72 @@
+package docsystem
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	models "meridian/internal/domain/models/docsystem"
+)
+
+func TestAnalyzeStyle_Narration(t *testing.T) {
+	chapter := "# Chapter One\n\n" + strings.Repeat(
+		"Mara walked to the harbor. She looked at the boats and waited. "+
+			"\"I am not leaving,\" she said. He nodded and turned away from her.\n\n", 10)
+
+	profile := analyzeStyle(NewContentAnalyzer(), []string{chapter, "```\nfunc main() {}\n```"})
+
+	if profile.Tense != models.StyleTensePast {
+		t.Errorf("tense = %q, want past", profile.Tense)
+	}
+	// "I" inside the dialogue doesn't make the narration first person
+	if profile.Person != models.StylePersonThird {
+		t.Errorf("person = %q, want third", profile.Person)
+	}
+	if profile.DialogueShare <= 0 || profile.DialogueShare >= 0.2 {
+		t.Errorf("dialogue share = %v", profile.DialogueShare)
+	}
+	// The heading is a sentence of its own; the code block is ignored
+	if profile.SentenceCount != 41 {
+		t.Errorf("sentences = %d, want 41", profile.SentenceCount)
+	}
+	if slices.Contains(profile.CharacteristicWords, "func") {
+		t.Errorf("characteristic words include code: %v", profile.CharacteristicWords)
+	}
+	if !slices.Contains(profile.CharacteristicWords, "mara") || !slices.Contains(profile.CharacteristicWords, "harbor") {
+		t.Errorf("characteristic words = %v", profile.CharacteristicWords)
+	}
+}
+
+func TestAnalyzeStyle_FirstPersonPresent(t *testing.T) {
+	text := strings.Repeat("I open the door. My hands are cold and the hall is dark. I know he is waiting. ", 8)
+
+	profile := analyzeStyle(NewContentAnalyzer(), []string{text})
+
+	if profile.Tense != models.StyleTensePresent {
+		t.Errorf("tense = %q, want present", profile.Tense)
+	}
+	if profile.Person != models.StylePersonFirst {
+		t.Errorf("person = %q, want first", profile.Person)
+	}
+	if profile.AvgSentenceLength != 6 {
+		t.Errorf("average sentence length = %v, want 6", profile.AvgSentenceLength)
+	}
+	if profile.ShortSentenceShare != 0.67 {
+		t.Errorf("short sentence share = %v, want 0.67", profile.ShortSentenceShare)
+	}
+}
+
+func TestAnalyzeStyle_TooLittleText(t *testing.T) {
+	profile := analyzeStyle(NewContentAnalyzer(), []string{"Short note."})
+
+	if profile.Tense != "" || profile.Person != "" {
+		t.Errorf("tense/person = %q/%q, want undetermined", profile.Tense, profile.Person)
+	}
+	if profile.WordCount != 2 || profile.VocabularyRichness != 100 {
+		t.Errorf("words = %d, richness = %v", profile.WordCount, profile.VocabularyRichness)
+	}
+
+	if empty := analyzeStyle(NewContentAnalyzer(), []string{"", "---"}); empty.WordCount != 0 {
+		t.Errorf("empty documents have %d words", empty.WordCount)
+	}
+}
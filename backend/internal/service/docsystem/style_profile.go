@@ -0,0 +1,220 @@
+package docsystem
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"meridian/internal/config"
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxConcurrentStyleAnalyses bounds background analyses; further ones wait their turn
+	maxConcurrentStyleAnalyses = 2
+
+	// styleAnalysisTimeout bounds one analysis
+	styleAnalysisTimeout = 2 * time.Minute
+)
+
+// styleProfileService implements the StyleProfileService interface
+type styleProfileService struct {
+	settingsRepo    docsysRepo.ProjectSettingsRepository
+	docRepo         docsysRepo.DocumentRepository
+	contentAnalyzer docsysSvc.ContentAnalyzer
+	logger          *slog.Logger
+	slots           chan struct{}
+}
+
+// NewStyleProfileService creates a new style profile service
+func NewStyleProfileService(
+	settingsRepo docsysRepo.ProjectSettingsRepository,
+	docRepo docsysRepo.DocumentRepository,
+	contentAnalyzer docsysSvc.ContentAnalyzer,
+	logger *slog.Logger,
+) docsysSvc.StyleProfileService {
+	return &styleProfileService{
+		settingsRepo:    settingsRepo,
+		docRepo:         docRepo,
+		contentAnalyzer: contentAnalyzer,
+		logger:          logger,
+		slots:           make(chan struct{}, maxConcurrentStyleAnalyses),
+	}
+}
+
+// AnalyzeStyle validates the request, stores an analyzing profile and starts the analysis
+// in the background. The previous profile stays in use until it is replaced.
+func (s *styleProfileService) AnalyzeStyle(ctx context.Context, userID, projectID string, req *docsysSvc.AnalyzeStyleRequest) (*models.StyleProfile, error) {
+	if err := s.validateAnalyzeRequest(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrValidation, err)
+	}
+
+	// Checks project access; StartStyleProfile rejects a running analysis
+	if _, err := s.settingsRepo.Get(ctx, projectID, userID); err != nil {
+		return nil, err
+	}
+
+	// Selected documents must belong to the project
+	documents, err := s.docRepo.GetAllMetadataByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	inProject := make(map[string]bool, len(documents))
+	for _, doc := range documents {
+		inProject[doc.ID] = true
+	}
+	seen := make(map[string]bool, len(req.DocumentIDs))
+	for _, documentID := range req.DocumentIDs {
+		if seen[documentID] {
+			return nil, fmt.Errorf("%w: document %s is listed twice", domain.ErrValidation, documentID)
+		}
+		seen[documentID] = true
+		if !inProject[documentID] {
+			return nil, fmt.Errorf("document %s: %w", documentID, domain.ErrNotFound)
+		}
+	}
+
+	profile := &models.StyleProfile{
+		ID:                  uuid.NewString(),
+		Status:              models.StyleProfileStatusAnalyzing,
+		DocumentIDs:         req.DocumentIDs,
+		CharacteristicWords: []string{},
+		StartedAt:           time.Now().UTC(),
+	}
+	if err := s.settingsRepo.StartStyleProfile(ctx, projectID, profile); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("style analysis started",
+		"project_id", projectID,
+		"documents", len(req.DocumentIDs),
+		"user_id", userID,
+	)
+
+	go s.run(projectID, *profile)
+
+	return profile, nil
+}
+
+// GetStyleProfile returns the project's style profile
+func (s *styleProfileService) GetStyleProfile(ctx context.Context, userID, projectID string) (*models.StyleProfile, error) {
+	settings, err := s.settingsRepo.Get(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.StyleProfile == nil {
+		return nil, fmt.Errorf("style profile for project %s: %w", projectID, domain.ErrNotFound)
+	}
+	return settings.StyleProfile, nil
+}
+
+// DeleteStyleProfile clears the project's style profile
+func (s *styleProfileService) DeleteStyleProfile(ctx context.Context, userID, projectID string) error {
+	// Checks project access
+	if _, err := s.settingsRepo.Get(ctx, projectID, userID); err != nil {
+		return err
+	}
+	if err := s.settingsRepo.SetStyleProfile(ctx, projectID, nil); err != nil {
+		return err
+	}
+
+	s.logger.Info("style profile deleted", "project_id", projectID, "user_id", userID)
+	return nil
+}
+
+// FailInterrupted fails analyses left running by a previous process
+func (s *styleProfileService) FailInterrupted(ctx context.Context) error {
+	failed, err := s.settingsRepo.FailAnalyzingStyleProfiles(ctx, "style analysis was interrupted by a server restart; please start it again")
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		s.logger.Warn("failed interrupted style analyses", "count", failed)
+	}
+	return nil
+}
+
+// run reads the selected documents, computes the profile and stores the outcome, unless
+// the profile was deleted or another analysis replaced it meanwhile
+func (s *styleProfileService) run(projectID string, profile models.StyleProfile) {
+	s.slots <- struct{}{}
+	defer func() { <-s.slots }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), styleAnalysisTimeout)
+	defer cancel()
+
+	result, err := s.analyze(ctx, projectID, profile.DocumentIDs)
+	if err != nil {
+		s.logger.Error("style analysis failed", "project_id", projectID, "error", err)
+		message := err.Error()
+		failed := profile
+		failed.Status = models.StyleProfileStatusFailed
+		failed.Error = &message
+		s.finish(context.Background(), projectID, profile.ID, &failed)
+		return
+	}
+
+	analyzedAt := time.Now().UTC()
+	result.ID = profile.ID
+	result.Status = models.StyleProfileStatusComplete
+	result.DocumentIDs = profile.DocumentIDs
+	result.StartedAt = profile.StartedAt
+	result.AnalyzedAt = &analyzedAt
+	if !s.finish(ctx, projectID, profile.ID, &result) {
+		return
+	}
+
+	s.logger.Info("style analysis completed",
+		"project_id", projectID,
+		"words", result.WordCount,
+		"tense", result.Tense,
+		"person", result.Person,
+	)
+}
+
+// finish stores an analysis outcome, reporting whether it was stored
+func (s *styleProfileService) finish(ctx context.Context, projectID, analysisID string, profile *models.StyleProfile) bool {
+	stored, err := s.settingsRepo.FinishStyleProfile(ctx, projectID, analysisID, profile)
+	if err != nil {
+		s.logger.Error("failed to store style analysis outcome", "project_id", projectID, "status", profile.Status, "error", err)
+		return false
+	}
+	if !stored {
+		s.logger.Info("style analysis discarded: profile was deleted or replaced", "project_id", projectID)
+	}
+	return stored
+}
+
+// analyze loads the documents' content and computes their statistics
+func (s *styleProfileService) analyze(ctx context.Context, projectID string, documentIDs []string) (models.StyleProfile, error) {
+	contents := make([]string, 0, len(documentIDs))
+	for _, documentID := range documentIDs {
+		doc, err := s.docRepo.GetByID(ctx, documentID, projectID)
+		if err != nil {
+			return models.StyleProfile{}, fmt.Errorf("load document %s: %w", documentID, err)
+		}
+		contents = append(contents, doc.Content)
+	}
+
+	result := analyzeStyle(s.contentAnalyzer, contents)
+	if result.WordCount == 0 {
+		return models.StyleProfile{}, fmt.Errorf("the selected documents have no text to analyze")
+	}
+	return result, nil
+}
+
+func (s *styleProfileService) validateAnalyzeRequest(req *docsysSvc.AnalyzeStyleRequest) error {
+	return validation.ValidateStruct(req,
+		validation.Field(&req.DocumentIDs,
+			validation.Required,
+			validation.Length(1, config.MaxStyleProfileDocuments),
+		),
+	)
+}
@@ -0,0 +1,193 @@
+package docsystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	"meridian/internal/domain/repositories"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+)
+
+type trashService struct {
+	trashRepo     docsysRepo.TrashRepository
+	folderRepo    docsysRepo.FolderRepository
+	docRepo       docsysRepo.DocumentRepository
+	txManager     repositories.TransactionManager
+	authorizer    services.ResourceAuthorizer
+	namePolicy    NamePolicy
+	retention     time.Duration // Documents and folders; 0 = kept forever
+	chatRetention time.Duration // Chats (purged by the chat service); 0 = kept forever
+	logger        *slog.Logger
+}
+
+// NewTrashService creates a new trash service
+// The retentions only set each item's purge_at; the purge jobs run separately.
+func NewTrashService(
+	trashRepo docsysRepo.TrashRepository,
+	folderRepo docsysRepo.FolderRepository,
+	docRepo docsysRepo.DocumentRepository,
+	txManager repositories.TransactionManager,
+	authorizer services.ResourceAuthorizer,
+	namePolicy NamePolicy,
+	retention, chatRetention time.Duration,
+	logger *slog.Logger,
+) docsysSvc.TrashService {
+	return &trashService{
+		trashRepo:     trashRepo,
+		folderRepo:    folderRepo,
+		docRepo:       docRepo,
+		txManager:     txManager,
+		authorizer:    authorizer,
+		namePolicy:    namePolicy,
+		retention:     retention,
+		chatRetention: chatRetention,
+		logger:        logger,
+	}
+}
+
+// ListTrash lists a project's deleted items with when each will be purged
+func (s *trashService) ListTrash(ctx context.Context, userID, projectID string) ([]models.TrashItem, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.trashRepo.ListTrash(ctx, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		retention := s.retention
+		if items[i].Type == models.TrashItemChat {
+			retention = s.chatRetention
+		}
+		if retention > 0 {
+			purgeAt := items[i].DeletedAt.Add(retention)
+			items[i].PurgeAt = &purgeAt
+		}
+	}
+
+	return items, nil
+}
+
+// Restore brings a deleted item back
+// Deleted items fail the resource authorizer's lookups, so access is checked on their project
+func (s *trashService) Restore(ctx context.Context, userID string, itemType models.TrashItemType, id string) (*models.RestoreResult, error) {
+	item, err := s.trashRepo.GetTrashItem(ctx, itemType, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizer.CanAccessProject(ctx, userID, item.ProjectID); err != nil {
+		return nil, err
+	}
+
+	result := &models.RestoreResult{Type: itemType, ID: id}
+	switch itemType {
+	case models.TrashItemDocument:
+		if err := s.checkRestorable(ctx, item); err != nil {
+			return nil, err
+		}
+		if err := s.trashRepo.RestoreDocument(ctx, id); err != nil {
+			return nil, err
+		}
+		result.Documents = 1
+
+	case models.TrashItemFolder:
+		if err := s.checkRestorable(ctx, item); err != nil {
+			return nil, err
+		}
+		err = s.txManager.ExecTx(ctx, func(txCtx context.Context) error {
+			var err error
+			result.Folders, result.Documents, err = s.trashRepo.RestoreFolderTree(txCtx, id, item.DeletedAt)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+	case models.TrashItemChat:
+		if err := s.trashRepo.RestoreChat(ctx, id, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Info("restored from trash",
+		"type", itemType,
+		"id", id,
+		"name", item.Name,
+		"project_id", item.ProjectID,
+		"folders", result.Folders,
+		"documents", result.Documents,
+	)
+
+	return result, nil
+}
+
+// checkRestorable rejects restoring a document or folder into a deleted folder, or next to
+// a live sibling that now has the same name
+func (s *trashService) checkRestorable(ctx context.Context, item *models.TrashItem) error {
+	if item.FolderID != nil {
+		if _, err := s.folderRepo.GetByID(ctx, *item.FolderID, item.ProjectID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return fmt.Errorf("the containing folder is in the trash; restore it first: %w", domain.ErrConflict)
+			}
+			return err
+		}
+	}
+
+	if item.Type == models.TrashItemFolder {
+		siblings, err := s.folderRepo.ListChildren(ctx, item.FolderID, item.ProjectID)
+		if err != nil {
+			return fmt.Errorf("failed to check for duplicate names: %w", err)
+		}
+		for _, sibling := range siblings {
+			if s.namePolicy.SameName(sibling.Name, item.Name) {
+				return &domain.ConflictError{
+					Message:      fmt.Sprintf("a folder named %q already exists in this location", item.Name),
+					ResourceType: "folder",
+					ResourceID:   sibling.ID,
+				}
+			}
+		}
+		return nil
+	}
+
+	siblings, err := s.docRepo.ListByFolder(ctx, item.FolderID, item.ProjectID)
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicate names: %w", err)
+	}
+	for _, sibling := range siblings {
+		if s.namePolicy.SameName(sibling.Name, item.Name) {
+			return &domain.ConflictError{
+				Message:      fmt.Sprintf("a document named %q already exists in this folder", item.Name),
+				ResourceType: "document",
+				ResourceID:   sibling.ID,
+			}
+		}
+	}
+	return nil
+}
+
+// PurgeDeleted hard-deletes documents and folders past their retention
+func (s *trashService) PurgeDeleted(ctx context.Context, retention time.Duration) (int64, error) {
+	purged, err := s.trashRepo.PurgeDeleted(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, err
+	}
+
+	if purged > 0 {
+		s.logger.Info("trash purged",
+			"count", purged,
+			"retention", retention,
+		)
+	}
+
+	return purged, nil
+}
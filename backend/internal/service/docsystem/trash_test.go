@@ -0,0 +1,238 @@
+package docsystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"meridian/internal/domain"
+	models "meridian/internal/domain/models/docsystem"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/testmocks"
+)
+
+// A project with a deleted "Drafts" folder (deleted together with one document), a
+// separately deleted root document and a live "Notes" document at the root.
+// Unused interface methods panic.
+type (
+	fakeTrashRepo struct {
+		docsysRepo.TrashRepository
+		items    map[string]models.TrashItem
+		restored []string
+		inTx     bool
+		cutoff   time.Time
+	}
+	fakeTrashFolderRepo struct{ docsysRepo.FolderRepository }
+	fakeTrashDocRepo    struct{ docsysRepo.DocumentRepository }
+)
+
+var trashDeletedAt = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func newFakeTrashRepo() *fakeTrashRepo {
+	drafts := "drafts"
+	return &fakeTrashRepo{items: map[string]models.TrashItem{
+		"drafts":  {Type: models.TrashItemFolder, ID: "drafts", ProjectID: "proj-1", Name: "Drafts", DeletedAt: trashDeletedAt},
+		"scene":   {Type: models.TrashItemDocument, ID: "scene", ProjectID: "proj-1", Name: "Scene", FolderID: &drafts, DeletedAt: trashDeletedAt},
+		"outline": {Type: models.TrashItemDocument, ID: "outline", ProjectID: "proj-1", Name: "Outline", DeletedAt: trashDeletedAt},
+		"notes":   {Type: models.TrashItemDocument, ID: "notes", ProjectID: "proj-1", Name: "notes", DeletedAt: trashDeletedAt},
+		"chat-1":  {Type: models.TrashItemChat, ID: "chat-1", ProjectID: "proj-1", Name: "Plot ideas", DeletedAt: trashDeletedAt},
+	}}
+}
+
+func (r *fakeTrashRepo) ListTrash(ctx context.Context, projectID, userID string) ([]models.TrashItem, error) {
+	return []models.TrashItem{r.items["outline"], r.items["chat-1"]}, nil
+}
+
+func (r *fakeTrashRepo) GetTrashItem(ctx context.Context, itemType models.TrashItemType, id string) (*models.TrashItem, error) {
+	item, ok := r.items[id]
+	if !ok || item.Type != itemType {
+		return nil, fmt.Errorf("deleted %s %s: %w", itemType, id, domain.ErrNotFound)
+	}
+	return &item, nil
+}
+
+func (r *fakeTrashRepo) RestoreDocument(ctx context.Context, id string) error {
+	r.restored = append(r.restored, id)
+	return nil
+}
+
+func (r *fakeTrashRepo) RestoreFolderTree(ctx context.Context, id string, deletedAt time.Time) (int64, int64, error) {
+	r.inTx = testmocks.TxDepth(ctx) > 0
+	r.restored = append(r.restored, id)
+	return 1, 1, nil
+}
+
+func (r *fakeTrashRepo) RestoreChat(ctx context.Context, id, userID string) error {
+	r.restored = append(r.restored, id)
+	return nil
+}
+
+func (r *fakeTrashRepo) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	r.cutoff = deletedBefore
+	return 3, nil
+}
+
+func (fakeTrashFolderRepo) GetByID(ctx context.Context, id, projectID string) (*models.Folder, error) {
+	// The only folder is "drafts", which is in the trash
+	return nil, fmt.Errorf("folder %s: %w", id, domain.ErrNotFound)
+}
+
+func (fakeTrashFolderRepo) ListChildren(ctx context.Context, folderID *string, projectID string) ([]models.Folder, error) {
+	return nil, nil
+}
+
+func (fakeTrashDocRepo) ListByFolder(ctx context.Context, folderID *string, projectID string) ([]models.Document, error) {
+	if folderID != nil {
+		return nil, nil
+	}
+	return []models.Document{{ID: "live-notes", Name: "Notes"}}, nil
+}
+
+func newTestTrashService(repo *fakeTrashRepo, authorizer *testmocks.Authorizer, txManager *testmocks.TxManager, uniqueness string) *trashService {
+	return NewTrashService(
+		repo, fakeTrashFolderRepo{}, fakeTrashDocRepo{}, txManager, authorizer,
+		NewNamePolicy(uniqueness), 30*24*time.Hour, 0,
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	).(*trashService)
+}
+
+func TestTrashService_ListTrash(t *testing.T) {
+	svc := newTestTrashService(newFakeTrashRepo(), &testmocks.Authorizer{}, &testmocks.TxManager{}, NameUniquenessExact)
+
+	items, err := svc.ListTrash(context.Background(), "user-1", "proj-1")
+	if err != nil {
+		t.Fatalf("ListTrash() error = %v", err)
+	}
+
+	// Documents get the trash retention; chats use the chat retention, which is 0 (kept forever)
+	if items[0].PurgeAt == nil || !items[0].PurgeAt.Equal(trashDeletedAt.Add(30*24*time.Hour)) {
+		t.Errorf("document purge_at = %v, want deleted_at + 30 days", items[0].PurgeAt)
+	}
+	if items[1].PurgeAt != nil {
+		t.Errorf("chat purge_at = %v, want nil", items[1].PurgeAt)
+	}
+}
+
+func TestTrashService_Restore(t *testing.T) {
+	tests := []struct {
+		name       string
+		itemType   models.TrashItemType
+		id         string
+		uniqueness string
+		wantErr    error
+		want       models.RestoreResult
+	}{
+		{
+			name:     "document",
+			itemType: models.TrashItemDocument,
+			id:       "outline",
+			want:     models.RestoreResult{Type: models.TrashItemDocument, ID: "outline", Documents: 1},
+		},
+		{
+			name:     "folder with its subtree",
+			itemType: models.TrashItemFolder,
+			id:       "drafts",
+			want:     models.RestoreResult{Type: models.TrashItemFolder, ID: "drafts", Folders: 1, Documents: 1},
+		},
+		{
+			name:     "chat",
+			itemType: models.TrashItemChat,
+			id:       "chat-1",
+			want:     models.RestoreResult{Type: models.TrashItemChat, ID: "chat-1"},
+		},
+		{
+			name:     "document in a deleted folder",
+			itemType: models.TrashItemDocument,
+			id:       "scene",
+			wantErr:  domain.ErrConflict,
+		},
+		{
+			name:       "name taken by a live sibling",
+			itemType:   models.TrashItemDocument,
+			id:         "notes",
+			uniqueness: NameUniquenessCaseInsensitive,
+			wantErr:    domain.ErrConflict,
+		},
+		{
+			name:     "not in the trash",
+			itemType: models.TrashItemDocument,
+			id:       "missing",
+			wantErr:  domain.ErrNotFound,
+		},
+		{
+			name:     "wrong type",
+			itemType: models.TrashItemFolder,
+			id:       "outline",
+			wantErr:  domain.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeTrashRepo()
+			uniqueness := tt.uniqueness
+			if uniqueness == "" {
+				uniqueness = NameUniquenessExact
+			}
+			svc := newTestTrashService(repo, &testmocks.Authorizer{}, &testmocks.TxManager{}, uniqueness)
+
+			result, err := svc.Restore(context.Background(), "user-1", tt.itemType, tt.id)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Restore() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(repo.restored) != 0 {
+					t.Errorf("restored %v after a failed check", repo.restored)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Restore() error = %v", err)
+			}
+			if *result != tt.want {
+				t.Errorf("Restore() = %+v, want %+v", *result, tt.want)
+			}
+			if tt.itemType == models.TrashItemFolder && !repo.inTx {
+				t.Error("folder tree restored outside a transaction")
+			}
+		})
+	}
+}
+
+func TestTrashService_Restore_ChecksProjectAccess(t *testing.T) {
+	repo := newFakeTrashRepo()
+	authorizer := &testmocks.Authorizer{Deny: map[string]error{"proj-1": domain.ErrForbidden}}
+	svc := newTestTrashService(repo, authorizer, &testmocks.TxManager{}, NameUniquenessExact)
+
+	_, err := svc.Restore(context.Background(), "intruder", models.TrashItemDocument, "outline")
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("Restore() error = %v, want ErrForbidden", err)
+	}
+	if len(repo.restored) != 0 {
+		t.Errorf("restored %v without access", repo.restored)
+	}
+}
+
+func TestTrashService_PurgeDeleted(t *testing.T) {
+	repo := newFakeTrashRepo()
+	svc := newTestTrashService(repo, &testmocks.Authorizer{}, &testmocks.TxManager{}, NameUniquenessExact)
+
+	before := time.Now()
+	purged, err := svc.PurgeDeleted(context.Background(), 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("PurgeDeleted() error = %v", err)
+	}
+	if purged != 3 {
+		t.Errorf("PurgeDeleted() = %d, want 3", purged)
+	}
+
+	// Anything deleted more than 7 days ago is purged
+	want := before.Add(-7 * 24 * time.Hour)
+	if repo.cutoff.Before(want) || repo.cutoff.After(time.Now().Add(-7*24*time.Hour)) {
+		t.Errorf("cutoff = %v, want about %v", repo.cutoff, want)
+	}
+}
@@ -12,111 +12,97 @@ import (
 
 // treeService implements the TreeService interface
 type treeService struct {
-	folderRepo   docsysRepo.FolderRepository
-	documentRepo docsysRepo.DocumentRepository
-	authorizer   services.ResourceAuthorizer
-	logger       *slog.Logger
+	folderRepo docsysRepo.FolderRepository
+	authorizer services.ResourceAuthorizer
+	logger     *slog.Logger
 }
 
 // NewTreeService creates a new tree service
 func NewTreeService(
 	folderRepo docsysRepo.FolderRepository,
-	documentRepo docsysRepo.DocumentRepository,
 	authorizer services.ResourceAuthorizer,
 	logger *slog.Logger,
 ) docsysSvc.TreeService {
 	return &treeService{
-		folderRepo:   folderRepo,
-		documentRepo: documentRepo,
-		authorizer:   authorizer,
-		logger:       logger,
+		folderRepo: folderRepo,
+		authorizer: authorizer,
+		logger:     logger,
 	}
 }
 
 // GetProjectTree builds and returns the nested folder/document tree for a project
 // Authorization is checked first via the injected authorizer
-func (s *treeService) GetProjectTree(ctx context.Context, userID, projectID string) (*models.TreeNode, error) {
+// The flattened tree (with aggregates) comes from a single query; this only nests it.
+func (s *treeService) GetProjectTree(ctx context.Context, userID, projectID string, maxDepth int) (*models.TreeNode, error) {
 	// Authorize: check user can access this project
 	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
 		return nil, err
 	}
 
-	// Get all folders in the project
-	allFolders, err := s.folderRepo.GetAllByProject(ctx, projectID)
+	entries, err := s.folderRepo.GetTree(ctx, projectID, maxDepth)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get all documents in the project (metadata only, no content)
-	allDocuments, err := s.documentRepo.GetAllMetadataByProject(ctx, projectID)
-	if err != nil {
-		return nil, err
-	}
+	return buildTree(entries), nil
+}
 
-	// Build folder hierarchy using 3-pass algorithm
-	folderMap := make(map[string]*models.FolderTreeNode)
-	var rootFolderIDs []string
+// buildTree nests flattened tree entries under their parent folders, keeping the
+// repository's sibling order. Root totals are summed from root-level entries.
+func buildTree(entries []models.TreeEntry) *models.TreeNode {
+	tree := &models.TreeNode{
+		Folders:   []*models.FolderTreeNode{},
+		Documents: []models.DocumentTreeNode{},
+	}
 
 	// First pass: create all folder nodes
-	for _, folder := range allFolders {
-		folderMap[folder.ID] = &models.FolderTreeNode{
-			ID:        folder.ID,
-			Name:      folder.Name,
-			ParentID:  folder.ParentID,
-			CreatedAt: folder.CreatedAt,
-			Folders:   []*models.FolderTreeNode{},
-			Documents: []models.DocumentTreeNode{},
+	folderMap := make(map[string]*models.FolderTreeNode)
+	for _, entry := range entries {
+		if entry.Kind != models.TreeEntryFolder {
+			continue
+		}
+		folderMap[entry.ID] = &models.FolderTreeNode{
+			ID:            entry.ID,
+			Name:          entry.Name,
+			ParentID:      entry.ParentID,
+			CreatedAt:     entry.Timestamp,
+			Folders:       []*models.FolderTreeNode{},
+			Documents:     []models.DocumentTreeNode{},
+			FolderCount:   entry.FolderCount,
+			DocumentCount: entry.DocumentCount,
+			WordCount:     entry.WordCount,
 		}
 	}
 
-	// Second pass: nest folders by connecting children to parents
-	for _, folder := range allFolders {
-		node := folderMap[folder.ID]
-		if folder.ParentID == nil {
-			// Root level folder - track ID for final tree
-			rootFolderIDs = append(rootFolderIDs, folder.ID)
-		} else {
-			// Add to parent (as pointer reference for proper nesting)
-			if parent, exists := folderMap[*folder.ParentID]; exists {
+	// Second pass: nest folders and documents under their parents
+	for _, entry := range entries {
+		switch entry.Kind {
+		case models.TreeEntryFolder:
+			node := folderMap[entry.ID]
+			if entry.ParentID == nil {
+				tree.Folders = append(tree.Folders, node)
+				tree.DocumentCount += node.DocumentCount
+				tree.WordCount += node.WordCount
+			} else if parent, exists := folderMap[*entry.ParentID]; exists {
 				parent.Folders = append(parent.Folders, node)
 			}
-		}
-	}
-
-	// Third pass: add documents to their folders
-	rootDocuments := make([]models.DocumentTreeNode, 0)
-	for _, doc := range allDocuments {
-		docNode := models.DocumentTreeNode{
-			ID:        doc.ID,
-			Name:      doc.Name,
-			FolderID:  doc.FolderID,
-			WordCount: doc.WordCount,
-			UpdatedAt: doc.UpdatedAt,
-		}
-
-		if doc.FolderID == nil {
-			// Root level document
-			rootDocuments = append(rootDocuments, docNode)
-		} else {
-			// Add to parent folder
-			if parent, exists := folderMap[*doc.FolderID]; exists {
+		case models.TreeEntryDocument:
+			docNode := models.DocumentTreeNode{
+				ID:        entry.ID,
+				Name:      entry.Name,
+				FolderID:  entry.ParentID,
+				WordCount: entry.WordCount,
+				UpdatedAt: entry.Timestamp,
+			}
+			if entry.ParentID == nil {
+				tree.Documents = append(tree.Documents, docNode)
+				tree.DocumentCount++
+				tree.WordCount += entry.WordCount
+			} else if parent, exists := folderMap[*entry.ParentID]; exists {
 				parent.Documents = append(parent.Documents, docNode)
 			}
 		}
 	}
 
-	// Build final tree using root folder pointers
-	rootFolders := make([]*models.FolderTreeNode, 0)
-	for _, folderID := range rootFolderIDs {
-		if node, exists := folderMap[folderID]; exists {
-			rootFolders = append(rootFolders, node)
-		}
-	}
-
-	tree := &models.TreeNode{
-		Folders:   rootFolders,
-		Documents: rootDocuments,
-	}
-
-	return tree, nil
+	return tree
 }
@@ -0,0 +1,53 @@
+package docsystem
+
+import (
+	"testing"
+	"time"
+
+	models "meridian/internal/domain/models/docsystem"
+)
+
+func TestBuildTree(t *testing.T) {
+	root := "f1"
+	child := "f2"
+	now := time.Now()
+
+	// Flattened as the repository returns it, cut off at depth 2: f2's document is omitted
+	// but still counted in f1's and f2's aggregates
+	entries := []models.TreeEntry{
+		{Kind: models.TreeEntryFolder, ID: "f1", Name: "Characters", Depth: 1, Timestamp: now,
+			FolderCount: 1, DocumentCount: 2, WordCount: 300},
+		{Kind: models.TreeEntryFolder, ID: "f2", ParentID: &root, Name: "Heroes", Depth: 2, Timestamp: now,
+			DocumentCount: 1, WordCount: 200},
+		{Kind: models.TreeEntryDocument, ID: "d1", ParentID: &root, Name: "Aria", Depth: 2, Timestamp: now, WordCount: 100},
+		{Kind: models.TreeEntryDocument, ID: "d2", Name: "Notes", Depth: 1, Timestamp: now, WordCount: 50},
+	}
+
+	tree := buildTree(entries)
+
+	if len(tree.Folders) != 1 || len(tree.Documents) != 1 {
+		t.Fatalf("root = %d folders, %d documents; want 1, 1", len(tree.Folders), len(tree.Documents))
+	}
+	characters := tree.Folders[0]
+	if len(characters.Folders) != 1 || characters.Folders[0].ID != child {
+		t.Fatalf("Characters subfolders = %+v, want [f2]", characters.Folders)
+	}
+	if len(characters.Documents) != 1 || characters.Documents[0].ID != "d1" {
+		t.Fatalf("Characters documents = %+v, want [d1]", characters.Documents)
+	}
+	if heroes := characters.Folders[0]; len(heroes.Documents) != 0 || heroes.DocumentCount != 1 {
+		t.Errorf("Heroes = %d documents listed, count %d; want 0 listed, count 1", len(heroes.Documents), heroes.DocumentCount)
+	}
+
+	// Project totals include the document beyond the depth limit
+	if tree.DocumentCount != 3 || tree.WordCount != 350 {
+		t.Errorf("totals = %d documents, %d words; want 3, 350", tree.DocumentCount, tree.WordCount)
+	}
+}
+
+func TestBuildTree_Empty(t *testing.T) {
+	tree := buildTree(nil)
+	if tree.Folders == nil || tree.Documents == nil {
+		t.Error("empty tree should have non-nil slices (serialized as [])")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"meridian/internal/domain/repositories"
 	docsysRepo "meridian/internal/domain/repositories/docsystem"
 	docsysSvc "meridian/internal/domain/services/docsystem"
 	"meridian/internal/service/docsystem/converter"
@@ -73,10 +74,10 @@ func shouldIgnorePath(path string) bool {
 // Responsibilities:
 //   - Extract files from zip archive preserving folder structure
 //   - Route each file to appropriate ContentConverter based on extension
-//   - Handle create/update/skip decisions based on existing documents
+//   - Apply the conflict strategy to entries whose document already exists
 type zipFileProcessor struct {
 	docRepo           docsysRepo.DocumentRepository
-	docService        docsysSvc.DocumentService
+	importer          *documentImporter
 	converterRegistry *converter.ConverterRegistry
 	logger            *slog.Logger
 }
@@ -85,12 +86,13 @@ type zipFileProcessor struct {
 func NewZipFileProcessor(
 	docRepo docsysRepo.DocumentRepository,
 	docService docsysSvc.DocumentService,
+	txManager repositories.TransactionManager,
 	converterRegistry *converter.ConverterRegistry,
 	logger *slog.Logger,
 ) docsysSvc.FileProcessor {
 	return &zipFileProcessor{
 		docRepo:           docRepo,
-		docService:        docService,
+		importer:          &documentImporter{docService: docService, txManager: txManager, logger: logger},
 		converterRegistry: converterRegistry,
 		logger:            logger,
 	}
@@ -103,7 +105,7 @@ func (p *zipFileProcessor) CanProcess(filename string) bool {
 }
 
 // Process extracts and imports documents from a zip file
-// If overwrite is true, existing documents are updated; if false, duplicates are skipped
+// opts.Conflict decides what happens with entries whose document already exists
 func (p *zipFileProcessor) Process(
 	ctx context.Context,
 	projectID string,
@@ -111,7 +113,7 @@ func (p *zipFileProcessor) Process(
 	file io.Reader,
 	filename string,
 	folderPath string,
-	overwrite bool,
+	opts docsysSvc.ImportOptions,
 ) (*docsysSvc.ImportResult, error) {
 	// Read zip file into memory
 	zipData, err := io.ReadAll(file)
@@ -128,27 +130,11 @@ func (p *zipFileProcessor) Process(
 
 	// Get all existing documents in project to check for updates.
 	// This enables O(1) lookup during import instead of querying for each file.
-	existingDocs, err := p.docRepo.GetAllMetadataByProject(ctx, projectID)
+	docMap, err := buildDocMap(ctx, p.docRepo, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get existing documents: %w", err)
 	}
 
-	// Build lookup map for deduplication: "path|name" → document_id
-	// The key format uses pipe separator (see BuildLookupKey) to ensure uniqueness
-	// since the same document name can exist in different folders.
-	docMap := make(map[string]string)
-	for _, doc := range existingDocs {
-		path, err := p.docRepo.GetPath(ctx, &doc)
-		if err != nil {
-			p.logger.Warn("failed to compute path for existing document",
-				"doc_id", doc.ID,
-				"error", err,
-			)
-			continue
-		}
-		docMap[BuildLookupKey(path, doc.Name)] = doc.ID
-	}
-
 	// Initialize result
 	result := &docsysSvc.ImportResult{
 		Summary:   docsysSvc.ImportSummary{},
@@ -175,11 +161,14 @@ func (p *zipFileProcessor) Process(
 			p.logger.Debug("skipping unsupported file type", "file", zipEntry.Name, "ext", ext)
 			result.Summary.Skipped++
 			result.Summary.TotalFiles++
+			opts.ReportFile(zipEntry.Name, nil)
 			continue
 		}
 
 		// Process file from zip
-		p.processZipEntry(ctx, projectID, userID, zipEntry, docMap, overwrite, result)
+		errCount := len(result.Errors)
+		p.processZipEntry(ctx, projectID, userID, zipEntry, docMap, opts, result)
+		opts.ReportFile(zipEntry.Name, result.Errors[errCount:])
 	}
 
 	p.logger.Info("zip file processing complete",
@@ -206,8 +195,8 @@ func (p *zipFileProcessor) processZipEntry(
 	projectID string,
 	userID string,
 	file *zip.File,
-	docMap map[string]string,
-	overwrite bool,
+	docMap map[string]existingDocument,
+	opts docsysSvc.ImportOptions,
 	result *docsysSvc.ImportResult,
 ) {
 	result.Summary.TotalFiles++
@@ -215,7 +204,7 @@ func (p *zipFileProcessor) processZipEntry(
 	// Open file
 	fileReader, err := file.Open()
 	if err != nil {
-		p.addError(result, file.Name, fmt.Sprintf("failed to open file: %v", err))
+		p.importer.addError(result, file.Name, fmt.Sprintf("failed to open file: %v", err))
 		return
 	}
 	defer fileReader.Close()
@@ -223,14 +212,14 @@ func (p *zipFileProcessor) processZipEntry(
 	// Read file content
 	fileContent, err := io.ReadAll(fileReader)
 	if err != nil {
-		p.addError(result, file.Name, fmt.Sprintf("failed to read file: %v", err))
+		p.importer.addError(result, file.Name, fmt.Sprintf("failed to read file: %v", err))
 		return
 	}
 
 	// Convert content to markdown using appropriate converter
 	markdown, err := p.converterRegistry.Convert(ctx, file.Name, fileContent)
 	if err != nil {
-		p.addError(result, file.Name, fmt.Sprintf("failed to convert file: %v", err))
+		p.importer.addError(result, file.Name, fmt.Sprintf("failed to convert file: %v", err))
 		return
 	}
 
@@ -248,7 +237,7 @@ func (p *zipFileProcessor) processZipEntry(
 	if dirPath == "." {
 		folderPath = "" // File is at root of zip
 	} else {
-		folderPath = dirPath
+		folderPath = NormalizeFolderPath(filepath.ToSlash(dirPath))
 	}
 
 	// Extract document name (filename without extension)
@@ -257,127 +246,5 @@ func (p *zipFileProcessor) processZipEntry(
 	docName = strings.TrimSuffix(baseName, ext)
 	docName = SanitizeDocName(docName) // Replace invalid characters
 
-	// Build lookup key to check for existing document
-	fullPath := BuildFullPath(folderPath, docName)
-	lookupKey := BuildLookupKey(fullPath, docName)
-	existingDocID, exists := docMap[lookupKey]
-
-	if exists {
-		if overwrite {
-			// Update existing document
-			p.updateDocument(ctx, projectID, userID, existingDocID, markdown, result)
-		} else {
-			// Skip duplicate - don't overwrite
-			p.skipDocument(result, folderPath, docName)
-		}
-	} else {
-		// Create new document
-		p.createDocument(ctx, projectID, userID, folderPath, docName, markdown, result)
-	}
-}
-
-// createDocument creates a new document
-func (p *zipFileProcessor) createDocument(
-	ctx context.Context,
-	projectID string,
-	userID string,
-	folderPath string,
-	docName string,
-	content string,
-	result *docsysSvc.ImportResult,
-) {
-	doc, err := p.docService.CreateDocument(ctx, &docsysSvc.CreateDocumentRequest{
-		ProjectID:  projectID,
-		UserID:     userID,
-		FolderPath: &folderPath,
-		Name:       docName,
-		Content:    content,
-	})
-
-	if err != nil {
-		p.addError(result, BuildFullPath(folderPath, docName), fmt.Sprintf("failed to create document: %v", err))
-		return
-	}
-
-	result.Summary.Created++
-	result.Documents = append(result.Documents, docsysSvc.ImportDocument{
-		ID:     doc.ID,
-		Path:   doc.Path,
-		Name:   doc.Name,
-		Action: "created",
-	})
-
-	p.logger.Debug("document created",
-		"id", doc.ID,
-		"folder_path", folderPath,
-		"name", docName,
-	)
-}
-
-// updateDocument updates an existing document
-func (p *zipFileProcessor) updateDocument(
-	ctx context.Context,
-	projectID string,
-	userID string,
-	docID string,
-	content string,
-	result *docsysSvc.ImportResult,
-) {
-	doc, err := p.docService.UpdateDocument(ctx, userID, docID, &docsysSvc.UpdateDocumentRequest{
-		ProjectID: projectID,
-		Content:   &content,
-	})
-
-	if err != nil {
-		p.addError(result, docID, fmt.Sprintf("failed to update document: %v", err))
-		return
-	}
-
-	result.Summary.Updated++
-	result.Documents = append(result.Documents, docsysSvc.ImportDocument{
-		ID:     doc.ID,
-		Path:   doc.Path,
-		Name:   doc.Name,
-		Action: "updated",
-	})
-
-	p.logger.Debug("document updated",
-		"id", doc.ID,
-		"path", doc.Path,
-	)
-}
-
-// skipDocument records a skipped duplicate document
-func (p *zipFileProcessor) skipDocument(
-	result *docsysSvc.ImportResult,
-	folderPath string,
-	docName string,
-) {
-	fullPath := BuildFullPath(folderPath, docName)
-	result.Summary.Skipped++
-	result.Documents = append(result.Documents, docsysSvc.ImportDocument{
-		ID:     "", // No ID for skipped documents
-		Path:   fullPath,
-		Name:   docName,
-		Action: "skipped",
-	})
-
-	p.logger.Debug("document skipped (duplicate)",
-		"folder_path", folderPath,
-		"name", docName,
-	)
-}
-
-// addError adds an error to the result
-func (p *zipFileProcessor) addError(result *docsysSvc.ImportResult, file string, errorMsg string) {
-	result.Summary.Failed++
-	result.Errors = append(result.Errors, docsysSvc.ImportError{
-		File:  file,
-		Error: errorMsg,
-	})
-
-	p.logger.Warn("file processing failed",
-		"file", file,
-		"error", errorMsg,
-	)
+	p.importer.importDocument(ctx, projectID, userID, file.Name, folderPath, docName, markdown, docMap, opts, result)
 }
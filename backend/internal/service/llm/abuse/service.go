@@ -0,0 +1,321 @@
+// Package abuse protects the shared provider keys from runaway or abusive usage. It
+// counts each user's turns and tool rounds within a sliding window and throttles users
+// whose counts spike, refuses (and flags) enormous tool inputs, and alerts admins of
+// every flag it raises. Flags are stored for review in the admin API; resolving a
+// throttling flag lifts the throttle.
+package abuse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/logging"
+)
+
+// Limits of the list endpoint
+const (
+	defaultListLimit = 100
+	maxListLimit     = 500
+)
+
+// Limits configures what counts as anomalous usage
+type Limits struct {
+	Window            time.Duration // Sliding window the turn and tool round counts are taken over
+	MaxTurns          int           // Turns a user may create per window (<= 0 = unlimited)
+	MaxToolRounds     int           // Tool rounds a user's turns may run per window (<= 0 = unlimited)
+	MaxToolInputBytes int           // Largest tool call input, JSON-encoded (<= 0 = unlimited)
+	ThrottleDuration  time.Duration // How long a spike throttles the user (<= 0 = flag only)
+}
+
+// Notifier alerts admins of a new flag
+type Notifier interface {
+	Notify(ctx context.Context, flag *llmModels.AbuseFlag) error
+}
+
+// Service implements the AbuseService interface.
+// Counts live in memory, so each server instance counts the requests it serves; throttles
+// are stored and apply on every instance.
+type Service struct {
+	repo     llmRepo.AbuseFlagRepository
+	notifier Notifier // nil = flags are only logged
+	limits   Limits
+	now      func() time.Time
+	logger   *slog.Logger
+
+	mu      sync.Mutex
+	users   map[string]*activity
+	sweptAt time.Time
+}
+
+// activity is one user's recent usage
+type activity struct {
+	turns          []time.Time
+	toolRounds     []time.Time
+	throttledUntil time.Time // Throttle raised by this instance (also refuses tool calls of streaming turns)
+	inputFlaggedAt time.Time // Last oversized tool input flag, so a looping model doesn't flood admins
+}
+
+// NewService creates an abuse detection service
+func NewService(repo llmRepo.AbuseFlagRepository, notifier Notifier, limits Limits, logger *slog.Logger) llmSvc.AbuseService {
+	return &Service{
+		repo:     repo,
+		notifier: notifier,
+		limits:   limits,
+		now:      time.Now,
+		logger:   logger,
+		users:    make(map[string]*activity),
+	}
+}
+
+// CheckTurn refuses turns of throttled users, and throttles users creating turns faster
+// than the limit
+func (s *Service) CheckTurn(ctx context.Context, userID string) error {
+	now := s.now()
+
+	throttle, err := s.repo.GetActiveThrottle(ctx, userID, now)
+	switch {
+	case err == nil:
+		return &llmModels.ThrottledError{Signal: throttle.Signal, Until: *throttle.ThrottledUntil}
+	case !errors.Is(err, domain.ErrNotFound):
+		// Fail open: a database hiccup shouldn't stop everyone's turns
+		logging.FromContext(ctx, s.logger).Warn("failed to check abuse throttle", "error", err)
+	}
+
+	if s.limits.MaxTurns <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	user := s.activity(userID, now)
+	user.turns = append(s.inWindow(user.turns, now), now)
+	count := len(user.turns)
+	exceeded := count > s.limits.MaxTurns
+	if exceeded {
+		// Count afresh once the throttle has been raised
+		user.turns = nil
+	}
+	s.mu.Unlock()
+
+	if !exceeded {
+		return nil
+	}
+	flag := s.spike(ctx, userID, nil, llmModels.AbuseSignalTurnRate,
+		fmt.Sprintf("%d turns within %s (limit %d)", count, s.limits.Window, s.limits.MaxTurns))
+	if flag.ThrottledUntil == nil {
+		return nil
+	}
+	return &llmModels.ThrottledError{Signal: flag.Signal, Until: *flag.ThrottledUntil}
+}
+
+// CheckToolInput refuses the tool calls of users throttled during their turn, and
+// oversized tool inputs (flagging them)
+func (s *Service) CheckToolInput(ctx context.Context, userID, turnID, toolName string, input map[string]interface{}) error {
+	now := s.now()
+
+	s.mu.Lock()
+	user := s.activity(userID, now)
+	throttledUntil := user.throttledUntil
+	s.mu.Unlock()
+	if now.Before(throttledUntil) {
+		return fmt.Errorf("tool calls are paused after unusual activity; finish the response without tools")
+	}
+
+	if s.limits.MaxToolInputBytes <= 0 {
+		return nil
+	}
+	encoded, err := json.Marshal(input)
+	if err != nil || len(encoded) <= s.limits.MaxToolInputBytes {
+		return nil
+	}
+
+	s.mu.Lock()
+	repeated := now.Sub(user.inputFlaggedAt) < s.limits.Window
+	if !repeated {
+		user.inputFlaggedAt = now
+	}
+	s.mu.Unlock()
+
+	if !repeated {
+		s.raise(ctx, &llmModels.AbuseFlag{
+			UserID: userID,
+			Signal: llmModels.AbuseSignalToolInputSize,
+			Action: llmModels.AbuseActionFlagged,
+			Detail: fmt.Sprintf("%s input of %d bytes (limit %d)", toolName, len(encoded), s.limits.MaxToolInputBytes),
+			TurnID: &turnID,
+		})
+	}
+	return fmt.Errorf("tool input is %d bytes, over the %d byte limit; call the tool with a smaller input", len(encoded), s.limits.MaxToolInputBytes)
+}
+
+// RecordToolRound throttles users whose turns run more tool rounds than the limit
+func (s *Service) RecordToolRound(ctx context.Context, userID, turnID string) {
+	if s.limits.MaxToolRounds <= 0 {
+		return
+	}
+	now := s.now()
+
+	s.mu.Lock()
+	user := s.activity(userID, now)
+	if now.Before(user.throttledUntil) {
+		s.mu.Unlock()
+		return
+	}
+	user.toolRounds = append(s.inWindow(user.toolRounds, now), now)
+	count := len(user.toolRounds)
+	exceeded := count > s.limits.MaxToolRounds
+	if exceeded {
+		user.toolRounds = nil
+	}
+	s.mu.Unlock()
+
+	if exceeded {
+		s.spike(ctx, userID, &turnID, llmModels.AbuseSignalToolRounds,
+			fmt.Sprintf("%d tool rounds within %s (limit %d)", count, s.limits.Window, s.limits.MaxToolRounds))
+	}
+}
+
+// ListFlags returns flags newest first
+func (s *Service) ListFlags(ctx context.Context, req *llmSvc.ListAbuseFlagsRequest) ([]llmModels.AbuseFlag, error) {
+	var unresolvedOnly bool
+	switch req.Status {
+	case "", "open":
+		unresolvedOnly = true
+	case "all":
+	default:
+		return nil, fmt.Errorf("%w: status must be 'open' or 'all'", domain.ErrValidation)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	return s.repo.ListFlags(ctx, req.UserID, unresolvedOnly, limit)
+}
+
+// ResolveFlag marks a flag resolved. The throttle this instance keeps for the user is
+// lifted too, so streaming turns can use tools again.
+func (s *Service) ResolveFlag(ctx context.Context, flagID string) (*llmModels.AbuseFlag, error) {
+	flag, err := s.repo.ResolveFlag(ctx, flagID, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	if flag.ThrottledUntil != nil {
+		s.mu.Lock()
+		if user, ok := s.users[flag.UserID]; ok {
+			user.throttledUntil = time.Time{}
+		}
+		s.mu.Unlock()
+	}
+
+	logging.FromContext(ctx, s.logger).Info("abuse flag resolved",
+		"flag_id", flag.ID,
+		"flagged_user_id", flag.UserID,
+		"signal", flag.Signal,
+	)
+	return flag, nil
+}
+
+// spike raises the flag of a usage spike: it throttles the user unless throttling is off
+func (s *Service) spike(ctx context.Context, userID string, turnID *string, signal, detail string) *llmModels.AbuseFlag {
+	flag := &llmModels.AbuseFlag{
+		UserID: userID,
+		Signal: signal,
+		Action: llmModels.AbuseActionFlagged,
+		Detail: detail,
+		TurnID: turnID,
+	}
+	if s.limits.ThrottleDuration > 0 {
+		until := s.now().Add(s.limits.ThrottleDuration)
+		flag.Action = llmModels.AbuseActionThrottled
+		flag.ThrottledUntil = &until
+
+		s.mu.Lock()
+		s.activity(userID, s.now()).throttledUntil = until
+		s.mu.Unlock()
+	}
+
+	s.raise(ctx, flag)
+	return flag
+}
+
+// raise stores a flag and alerts admins in the background. A flag that can't be stored
+// is still logged and sent; the in-memory throttle applies regardless.
+func (s *Service) raise(ctx context.Context, flag *llmModels.AbuseFlag) {
+	logger := logging.FromContext(ctx, s.logger)
+
+	if err := s.repo.CreateFlag(ctx, flag); err != nil {
+		logger.Error("failed to store abuse flag", "error", err, "signal", flag.Signal)
+		flag.CreatedAt = s.now()
+	}
+
+	logger.Warn("abuse flag raised",
+		"flag_id", flag.ID,
+		"flagged_user_id", flag.UserID,
+		"signal", flag.Signal,
+		"action", flag.Action,
+		"detail", flag.Detail,
+	)
+
+	if s.notifier == nil {
+		return
+	}
+	notifyCtx := logging.Detach(ctx)
+	alert := *flag
+	go func() {
+		if err := s.notifier.Notify(notifyCtx, &alert); err != nil {
+			logging.FromContext(notifyCtx, s.logger).Error("failed to notify admins of abuse flag", "error", err, "flag_id", alert.ID)
+		}
+	}()
+}
+
+// activity returns the user's activity, creating it if needed (callers hold mu)
+func (s *Service) activity(userID string, now time.Time) *activity {
+	s.sweep(now)
+	user, ok := s.users[userID]
+	if !ok {
+		user = &activity{}
+		s.users[userID] = user
+	}
+	return user
+}
+
+// inWindow drops the times that have left the window (callers hold mu)
+func (s *Service) inWindow(times []time.Time, now time.Time) []time.Time {
+	cutoff := now.Add(-s.limits.Window)
+	i := 0
+	for i < len(times) && !times[i].After(cutoff) {
+		i++
+	}
+	return times[i:]
+}
+
+// sweep drops users with nothing left in the window and no throttle, at most once per
+// window (callers hold mu)
+func (s *Service) sweep(now time.Time) {
+	if now.Sub(s.sweptAt) < s.limits.Window {
+		return
+	}
+	s.sweptAt = now
+	for userID, user := range s.users {
+		if len(s.inWindow(user.turns, now)) == 0 &&
+			len(s.inWindow(user.toolRounds, now)) == 0 &&
+			!now.Before(user.throttledUntil) &&
+			now.Sub(user.inputFlaggedAt) >= s.limits.Window {
+			delete(s.users, userID)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package abuse
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+// fakeFlagRepo keeps flags in memory
+type fakeFlagRepo struct {
+	llmRepo.AbuseFlagRepository
+	mu    sync.Mutex
+	flags []*llmModels.AbuseFlag
+}
+
+func (f *fakeFlagRepo) CreateFlag(ctx context.Context, flag *llmModels.AbuseFlag) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	flag.ID = "flag-" + string(rune('a'+len(f.flags)))
+	f.flags = append(f.flags, flag)
+	return nil
+}
+
+func (f *fakeFlagRepo) GetActiveThrottle(ctx context.Context, userID string, now time.Time) (*llmModels.AbuseFlag, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, flag := range f.flags {
+		if flag.UserID == userID && flag.ResolvedAt == nil && flag.ThrottledUntil != nil && flag.ThrottledUntil.After(now) {
+			return flag, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (f *fakeFlagRepo) ResolveFlag(ctx context.Context, id string, resolvedAt time.Time) (*llmModels.AbuseFlag, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, flag := range f.flags {
+		if flag.ID == id {
+			flag.ResolvedAt = &resolvedAt
+			return flag, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (f *fakeFlagRepo) ListFlags(ctx context.Context, userID string, unresolvedOnly bool, limit int) ([]llmModels.AbuseFlag, error) {
+	return nil, nil
+}
+
+func (f *fakeFlagRepo) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.flags)
+}
+
+// fakeNotifier collects alerts
+type fakeNotifier struct {
+	alerts chan *llmModels.AbuseFlag
+}
+
+func (n *fakeNotifier) Notify(ctx context.Context, flag *llmModels.AbuseFlag) error {
+	n.alerts <- flag
+	return nil
+}
+
+var testLimits = Limits{
+	Window:            10 * time.Minute,
+	MaxTurns:          3,
+	MaxToolRounds:     4,
+	MaxToolInputBytes: 64,
+	ThrottleDuration:  30 * time.Minute,
+}
+
+// newTestService returns a service on a fake clock
+func newTestService(limits Limits, notifier Notifier) (*Service, *fakeFlagRepo, *time.Time) {
+	repo := &fakeFlagRepo{}
+	svc := NewService(repo, notifier, limits, slog.New(slog.NewTextHandler(io.Discard, nil))).(*Service)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	return svc, repo, &now
+}
+
+func TestCheckTurn_ThrottlesRapidFireTurns(t *testing.T) {
+	notifier := &fakeNotifier{alerts: make(chan *llmModels.AbuseFlag, 1)}
+	svc, repo, now := newTestService(testLimits, notifier)
+	ctx := context.Background()
+
+	for i := 0; i < testLimits.MaxTurns; i++ {
+		if err := svc.CheckTurn(ctx, "user-1"); err != nil {
+			t.Fatalf("turn %d refused: %v", i+1, err)
+		}
+		*now = now.Add(time.Minute)
+	}
+
+	err := svc.CheckTurn(ctx, "user-1")
+	var throttled *llmModels.ThrottledError
+	if !errors.As(err, &throttled) || throttled.Signal != llmModels.AbuseSignalTurnRate {
+		t.Fatalf("expected turn_rate throttle, got %v", err)
+	}
+	if repo.count() != 1 || repo.flags[0].Action != llmModels.AbuseActionThrottled {
+		t.Fatalf("expected one throttling flag, got %+v", repo.flags)
+	}
+
+	select {
+	case alert := <-notifier.alerts:
+		if alert.UserID != "user-1" || alert.Signal != llmModels.AbuseSignalTurnRate {
+			t.Errorf("unexpected alert: %+v", alert)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("admins were not notified")
+	}
+
+	// Throttled until the flag expires; other users are unaffected
+	*now = now.Add(10 * time.Minute)
+	if err := svc.CheckTurn(ctx, "user-1"); !errors.As(err, &throttled) {
+		t.Errorf("expected throttled turn, got %v", err)
+	}
+	if err := svc.CheckTurn(ctx, "user-2"); err != nil {
+		t.Errorf("other user refused: %v", err)
+	}
+	*now = now.Add(25 * time.Minute)
+	if err := svc.CheckTurn(ctx, "user-1"); err != nil {
+		t.Errorf("turn refused after the throttle expired: %v", err)
+	}
+}
+
+func TestCheckTurn_WindowSlides(t *testing.T) {
+	svc, repo, now := newTestService(testLimits, nil)
+
+	// One turn every 4 minutes never has more than 3 in a 10 minute window
+	for i := 0; i < 10; i++ {
+		if err := svc.CheckTurn(context.Background(), "user-1"); err != nil {
+			t.Fatalf("turn %d refused: %v", i+1, err)
+		}
+		*now = now.Add(4 * time.Minute)
+	}
+	if repo.count() != 0 {
+		t.Errorf("steady usage was flagged: %+v", repo.flags)
+	}
+}
+
+func TestCheckTurn_FlagOnly(t *testing.T) {
+	limits := testLimits
+	limits.ThrottleDuration = 0
+	svc, repo, _ := newTestService(limits, nil)
+
+	for i := 0; i <= limits.MaxTurns; i++ {
+		if err := svc.CheckTurn(context.Background(), "user-1"); err != nil {
+			t.Fatalf("turn %d refused with throttling off: %v", i+1, err)
+		}
+	}
+	if repo.count() != 1 || repo.flags[0].Action != llmModels.AbuseActionFlagged || repo.flags[0].ThrottledUntil != nil {
+		t.Errorf("expected one flag without throttle, got %+v", repo.flags)
+	}
+}
+
+func TestRecordToolRound_ThrottlesToolLoops(t *testing.T) {
+	svc, repo, _ := newTestService(testLimits, nil)
+	ctx := context.Background()
+
+	for i := 0; i <= testLimits.MaxToolRounds; i++ {
+		svc.RecordToolRound(ctx, "user-1", "turn-1")
+	}
+	if repo.count() != 1 || repo.flags[0].Signal != llmModels.AbuseSignalToolRounds || *repo.flags[0].TurnID != "turn-1" {
+		t.Fatalf("expected one tool_rounds flag, got %+v", repo.flags)
+	}
+
+	// The streaming turn's further tool calls are refused, without more flags
+	if err := svc.CheckToolInput(ctx, "user-1", "turn-1", "doc_search", map[string]interface{}{"query": "x"}); err == nil {
+		t.Error("expected tool call of a throttled user to be refused")
+	}
+	svc.RecordToolRound(ctx, "user-1", "turn-1")
+	if repo.count() != 1 {
+		t.Errorf("throttled user flagged again: %d flags", repo.count())
+	}
+
+	// New turns are refused
+	var throttled *llmModels.ThrottledError
+	if err := svc.CheckTurn(ctx, "user-1"); !errors.As(err, &throttled) || throttled.Signal != llmModels.AbuseSignalToolRounds {
+		t.Errorf("expected tool_rounds throttle, got %v", err)
+	}
+
+	// Resolving the flag lifts the throttle
+	if _, err := svc.ResolveFlag(ctx, repo.flags[0].ID); err != nil {
+		t.Fatalf("ResolveFlag: %v", err)
+	}
+	if err := svc.CheckTurn(ctx, "user-1"); err != nil {
+		t.Errorf("turn refused after the flag was resolved: %v", err)
+	}
+	if err := svc.CheckToolInput(ctx, "user-1", "turn-2", "doc_search", map[string]interface{}{"query": "x"}); err != nil {
+		t.Errorf("tool call refused after the flag was resolved: %v", err)
+	}
+}
+
+func TestCheckToolInput_RefusesOversizedInput(t *testing.T) {
+	svc, repo, _ := newTestService(testLimits, nil)
+	ctx := context.Background()
+	huge := map[string]interface{}{"query": strings.Repeat("a", 100)}
+
+	if err := svc.CheckToolInput(ctx, "user-1", "turn-1", "doc_search", map[string]interface{}{"query": "dragons"}); err != nil {
+		t.Fatalf("small input refused: %v", err)
+	}
+
+	err := svc.CheckToolInput(ctx, "user-1", "turn-1", "doc_search", huge)
+	if err == nil || !strings.Contains(err.Error(), "byte limit") {
+		t.Fatalf("expected oversized input to be refused, got %v", err)
+	}
+	if repo.count() != 1 || repo.flags[0].Signal != llmModels.AbuseSignalToolInputSize || repo.flags[0].Action != llmModels.AbuseActionFlagged {
+		t.Fatalf("expected one tool_input_size flag, got %+v", repo.flags)
+	}
+
+	// Repeats are refused without flooding admins, and don't throttle
+	if err := svc.CheckToolInput(ctx, "user-1", "turn-1", "doc_search", huge); err == nil {
+		t.Error("expected repeated oversized input to be refused")
+	}
+	if repo.count() != 1 {
+		t.Errorf("repeated input flagged again: %d flags", repo.count())
+	}
+	if err := svc.CheckTurn(ctx, "user-1"); err != nil {
+		t.Errorf("flagged (not throttled) user refused: %v", err)
+	}
+}
+
+func TestListFlags_Validation(t *testing.T) {
+	svc, _, _ := newTestService(testLimits, nil)
+
+	_, err := svc.ListFlags(context.Background(), &llmSvc.ListAbuseFlagsRequest{Status: "closed"})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package abuse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+// webhookTimeout bounds an alert delivery
+const webhookTimeout = 10 * time.Second
+
+// WebhookNotifier posts each flag to a webhook URL as JSON. The body carries a "text"
+// summary, so Slack-compatible incoming webhooks show it as is, and the flag itself.
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhookNotifier creates a notifier posting to url
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:        url,
+		httpClient: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// webhookPayload is the body of an alert
+type webhookPayload struct {
+	Text string               `json:"text"`
+	Flag *llmModels.AbuseFlag `json:"flag"`
+}
+
+// Notify posts the flag to the webhook
+func (n *WebhookNotifier) Notify(ctx context.Context, flag *llmModels.AbuseFlag) error {
+	body, err := json.Marshal(webhookPayload{
+		Text: fmt.Sprintf("Abuse flag (%s, %s) for user %s: %s", flag.Signal, flag.Action, flag.UserID, flag.Detail),
+		Flag: flag,
+	})
+	if err != nil {
+		return fmt.Errorf("encode abuse alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create abuse alert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("send abuse alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("abuse alert webhook returned %s", resp.Status)
+	}
+	return nil
+}
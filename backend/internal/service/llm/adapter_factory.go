@@ -36,6 +36,15 @@ func NewDefaultAdapterFactory() *DefaultAdapterFactory {
 	factory.Register("openrouter", func(p llmprovider.Provider) domainllm.LLMProvider {
 		return adapters.NewOpenRouterAdapterWithProvider(p)
 	})
+	factory.Register("openai", func(p llmprovider.Provider) domainllm.LLMProvider {
+		return adapters.NewOpenAIAdapterWithProvider(p)
+	})
+	factory.Register("google", func(p llmprovider.Provider) domainllm.LLMProvider {
+		return adapters.NewGeminiAdapterWithProvider(p)
+	})
+	factory.Register("ollama", func(p llmprovider.Provider) domainllm.LLMProvider {
+		return adapters.NewOllamaAdapterWithProvider(p)
+	})
 	factory.Register("lorem", func(p llmprovider.Provider) domainllm.LLMProvider {
 		return adapters.NewLoremAdapterWithProvider(p)
 	})
@@ -54,7 +63,7 @@ func (f *DefaultAdapterFactory) Register(providerName string, creator AdapterCre
 func (f *DefaultAdapterFactory) CreateAdapter(providerName string, libraryProvider llmprovider.Provider) (domainllm.LLMProvider, error) {
 	creator, exists := f.creators[providerName]
 	if !exists {
-		return nil, fmt.Errorf("unsupported provider: %s (supported: anthropic, openrouter, lorem)", providerName)
+		return nil, fmt.Errorf("unsupported provider: %s (supported: anthropic, openrouter, openai, google, ollama, lorem)", providerName)
 	}
 
 	return creator(libraryProvider), nil
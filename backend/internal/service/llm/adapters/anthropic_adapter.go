@@ -4,21 +4,23 @@ import (
 	"context"
 
 	llmprovider "github.com/haowjy/meridian-llm-go"
-	"github.com/haowjy/meridian-llm-go/providers/anthropic"
+	llmanthropic "github.com/haowjy/meridian-llm-go/providers/anthropic"
 
 	domainllm "meridian/internal/domain/services/llm"
+	"meridian/internal/service/llm/anthropic"
 )
 
-// AnthropicAdapter wraps the library's Anthropic provider and implements the backend's LLMProvider interface.
-// It handles conversion between backend types (with DB fields) and library types (content-only).
+// AnthropicAdapter wraps an Anthropic provider and implements the backend's LLMProvider interface.
+// It handles conversion between backend types (with DB fields) and library types (content-only),
+// and applies the request's cache_control when the provider supports prompt caching.
 type AnthropicAdapter struct {
 	provider llmprovider.Provider
 }
 
-// NewAnthropicAdapter creates a new Anthropic adapter using the library's provider.
+// NewAnthropicAdapter creates a new Anthropic adapter using the backend's provider.
 // DEPRECATED: Use NewAnthropicAdapterWithProvider for factory-based creation
 func NewAnthropicAdapter(apiKey string) (*AnthropicAdapter, error) {
-	provider, err := anthropic.NewProvider(apiKey)
+	provider, err := anthropic.NewProvider(anthropic.Config{APIKey: apiKey})
 	if err != nil {
 		return nil, err
 	}
@@ -54,8 +56,8 @@ func (a *AnthropicAdapter) GenerateResponse(ctx context.Context, req *domainllm.
 		return nil, err
 	}
 
-	// Call library provider
-	libResp, err := a.provider.GenerateResponse(ctx, libReq)
+	// Call provider
+	libResp, err := a.providerFor(req).GenerateResponse(ctx, libReq)
 	if err != nil {
 		return nil, err
 	}
@@ -72,8 +74,8 @@ func (a *AnthropicAdapter) StreamResponse(ctx context.Context, req *domainllm.Ge
 		return nil, err
 	}
 
-	// Call library provider
-	libEventCh, err := a.provider.StreamResponse(ctx, libReq)
+	// Call provider
+	libEventCh, err := a.providerFor(req).StreamResponse(ctx, libReq)
 	if err != nil {
 		return nil, err
 	}
@@ -93,8 +95,8 @@ func (a *AnthropicAdapter) StreamResponse(ctx context.Context, req *domainllm.Ge
 }
 
 // BuildDebugProviderRequest builds the Anthropic provider request payload for debugging.
-// It converts the backend GenerateRequest to the library format and then to
-// Anthropic MessageNewParams JSON using the meridian-llm-go helper.
+// It converts the backend GenerateRequest to the library format and then to the
+// Anthropic request JSON the provider would send (including cache breakpoints).
 func (a *AnthropicAdapter) BuildDebugProviderRequest(ctx context.Context, req *domainllm.GenerateRequest) (map[string]interface{}, error) {
 	// Convert backend request to library request
 	libReq, err := ConvertToLibraryRequest(req)
@@ -102,6 +104,20 @@ func (a *AnthropicAdapter) BuildDebugProviderRequest(ctx context.Context, req *d
 		return nil, err
 	}
 
+	if provider, ok := a.providerFor(req).(*anthropic.Provider); ok {
+		return provider.BuildRequestDebug(libReq)
+	}
 	// Build provider-specific params JSON using library helper
-	return anthropic.BuildMessageParamsDebug(libReq)
+	return llmanthropic.BuildMessageParamsDebug(libReq)
+}
+
+// providerFor returns the provider to send req with: one that adds cache breakpoints
+// when the request sets cache_control. The library's provider can't cache, so it is
+// used as is.
+func (a *AnthropicAdapter) providerFor(req *domainllm.GenerateRequest) llmprovider.Provider {
+	provider, ok := a.provider.(*anthropic.Provider)
+	if !ok || req.Params == nil || req.Params.CacheControl == nil {
+		return a.provider
+	}
+	return provider.WithPromptCache(anthropic.PromptCache{TTL: req.Params.CacheControl.TTL})
 }
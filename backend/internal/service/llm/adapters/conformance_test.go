@@ -0,0 +1,506 @@
+package adapters
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"meridian/internal/service/llm/gemini"
+	"meridian/internal/service/llm/ollama"
+	"meridian/internal/service/llm/openai"
+	"meridian/internal/service/llm/providertest"
+)
+
+// Streaming contract tests for the provider adapters. The Anthropic, OpenAI, Gemini and
+// Ollama adapters run their real providers against recorded API streams (testdata/*.sse,
+// *.ndjson); OpenRouter's provider has no configurable base URL, so it isn't covered yet.
+
+const (
+	recordedToolUseID = "toolu_01LookupPlot" // The tool call in anthropic_tool_use.sse
+	recordedCallID    = "call_01LookupPlot"  // The function call in openai_*_tool_use.sse
+	// The thought signature of the function call in gemini_tool_use.sse
+	recordedThoughtSignature = "CiIBVKhc7pGJ0nHqUqB1sV3m1r9dQ0TCKZkYw+Zq3bPp5bGQ"
+)
+
+func TestAnthropicAdapterConformance(t *testing.T) {
+	server := httptest.NewServer(recordedAnthropicAPI(t))
+	defer server.Close()
+	t.Setenv("ANTHROPIC_BASE_URL", server.URL)
+
+	adapter, err := NewAnthropicAdapter("test-key")
+	if err != nil {
+		t.Fatalf("NewAnthropicAdapter: %v", err)
+	}
+	providertest.Run(t, providertest.Target{Provider: adapter, Model: "claude-haiku-4-5-20251001"})
+}
+
+func TestOpenAIAdapterConformance(t *testing.T) {
+	tests := []struct {
+		api   openai.API
+		model string
+	}{
+		{openai.APIResponses, "gpt-5-mini"},
+		{openai.APIChatCompletions, "gpt-4.1"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.api), func(t *testing.T) {
+			server := httptest.NewServer(recordedOpenAIAPI(t, tt.api))
+			defer server.Close()
+
+			adapter, err := NewOpenAIAdapter(openai.Config{APIKey: "test-key", BaseURL: server.URL, API: tt.api})
+			if err != nil {
+				t.Fatalf("NewOpenAIAdapter: %v", err)
+			}
+			providertest.Run(t, providertest.Target{Provider: adapter, Model: tt.model})
+		})
+	}
+}
+
+func TestGeminiAdapterConformance(t *testing.T) {
+	server := httptest.NewServer(recordedGeminiAPI(t))
+	defer server.Close()
+
+	adapter, err := NewGeminiAdapter(gemini.Config{APIKey: "test-key", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewGeminiAdapter: %v", err)
+	}
+	providertest.Run(t, providertest.Target{Provider: adapter, Model: "gemini-2.5-flash"})
+}
+
+func TestOllamaAdapterConformance(t *testing.T) {
+	server := httptest.NewServer(recordedOllamaAPI(t))
+	defer server.Close()
+
+	adapter, err := NewOllamaAdapter(ollama.Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewOllamaAdapter: %v", err)
+	}
+	providertest.Run(t, providertest.Target{Provider: adapter, Model: "qwen3:8b"})
+}
+
+func TestLoremAdapterConformance(t *testing.T) {
+	providertest.Run(t, providertest.Target{
+		Provider: NewLoremAdapter(),
+		Model:    "lorem-fast",
+		KnownGaps: map[string]string{
+			providertest.CheckBlockCompletion:  "lorem streams deltas only and never emits complete blocks",
+			providertest.CheckToolUseRoundTrip: "lorem tool calls never complete as tool_use blocks",
+		},
+	})
+}
+
+// recordedAnthropicAPI serves POST /v1/messages from the recorded streams, picked by the
+// conversation. A tool result must answer the recorded tool call, so the adapter's
+// tool_use → tool_result round trip is checked on the wire.
+func recordedAnthropicAPI(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Messages []struct {
+				Role    string            `json:"role"`
+				Content []json.RawMessage `json:"content"`
+			} `json:"messages"`
+			Tools []struct {
+				Name string `json:"name"`
+			} `json:"tools"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Messages) == 0 {
+			anthropicError(w, fmt.Sprintf("invalid request body: %v", err))
+			return
+		}
+
+		var prompt struct {
+			Text string `json:"text"`
+		}
+		_ = json.Unmarshal(body.Messages[0].Content[0], &prompt)
+		last := body.Messages[len(body.Messages)-1]
+		var lastBlock struct {
+			Type      string `json:"type"`
+			ToolUseID string `json:"tool_use_id"`
+		}
+		_ = json.Unmarshal(last.Content[len(last.Content)-1], &lastBlock)
+
+		switch {
+		case lastBlock.Type == "tool_result":
+			raw := string(last.Content[len(last.Content)-1])
+			if lastBlock.ToolUseID != recordedToolUseID || !strings.Contains(raw, providertest.ToolResultText) {
+				anthropicError(w, "tool_result does not answer the tool call: "+raw)
+				return
+			}
+			replay(t, w, "anthropic_tool_result.sse")
+		case prompt.Text == providertest.PromptToolUse:
+			if len(body.Tools) != 1 || body.Tools[0].Name != providertest.ToolName {
+				anthropicError(w, fmt.Sprintf("expected the %s tool, got %+v", providertest.ToolName, body.Tools))
+				return
+			}
+			replay(t, w, "anthropic_tool_use.sse")
+		case prompt.Text == providertest.PromptText:
+			replay(t, w, "anthropic_text.sse")
+		case prompt.Text == providertest.PromptLong:
+			streamUntilDisconnect(w, r)
+		default:
+			anthropicError(w, "no recording for prompt "+prompt.Text)
+		}
+	})
+}
+
+// replay writes a recorded event stream
+func replay(t *testing.T, w http.ResponseWriter, name string) {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Errorf("read recording: %v", err)
+		anthropicError(w, err.Error())
+		return
+	}
+	contentType := "text/event-stream"
+	if filepath.Ext(name) == ".ndjson" {
+		contentType = "application/x-ndjson"
+	}
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write(data)
+}
+
+// streamUntilDisconnect streams text deltas until the client goes away
+func streamUntilDisconnect(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	flusher := w.(http.Flusher)
+	send := func(event, data string) {
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+		flusher.Flush()
+	}
+
+	send("message_start", `{"type":"message_start","message":{"id":"msg_01Long","type":"message","role":"assistant","model":"claude-haiku-4-5-20251001","content":[],"stop_reason":null,"stop_sequence":null,"usage":{"input_tokens":12,"output_tokens":1}}}`)
+	send("content_block_start", `{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`)
+	ticker := time.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(30 * time.Second)
+	for {
+		select {
+		case <-ticker.C:
+			send("content_block_delta", `{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Once upon a time "}}`)
+		case <-r.Context().Done():
+			return
+		case <-timeout:
+			return
+		}
+	}
+}
+
+// recordedOpenAIAPI serves the endpoint of api from the recorded streams, like
+// recordedAnthropicAPI. Responses input items and Chat Completions messages are read
+// through the fields they share.
+func recordedOpenAIAPI(t *testing.T, api openai.API) http.Handler {
+	path, prefix := "/responses", "openai_responses_"
+	if api == openai.APIChatCompletions {
+		path, prefix = "/chat/completions", "openai_chat_"
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			openAIError(w, "unexpected endpoint "+r.URL.Path)
+			return
+		}
+		var body struct {
+			Input    []json.RawMessage `json:"input"`    // Responses
+			Messages []json.RawMessage `json:"messages"` // Chat Completions
+			Tools    []struct {
+				Name     string `json:"name"`
+				Function struct {
+					Name string `json:"name"`
+				} `json:"function"`
+			} `json:"tools"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			openAIError(w, fmt.Sprintf("invalid request body: %v", err))
+			return
+		}
+		items := body.Input
+		if api == openai.APIChatCompletions {
+			items = body.Messages
+		}
+		if len(items) == 0 {
+			openAIError(w, "no input")
+			return
+		}
+
+		type item struct {
+			Type       string `json:"type"`
+			Role       string `json:"role"`
+			Content    string `json:"content"`
+			CallID     string `json:"call_id"`      // Responses function_call_output
+			ToolCallID string `json:"tool_call_id"` // Chat Completions tool message
+			Output     string `json:"output"`
+		}
+		var first, last item
+		_ = json.Unmarshal(items[0], &first)
+		_ = json.Unmarshal(items[len(items)-1], &last)
+
+		switch {
+		case last.Type == "function_call_output" || last.Role == "tool":
+			callID, output := last.CallID+last.ToolCallID, last.Output+last.Content
+			if callID != recordedCallID || output != providertest.ToolResultText {
+				openAIError(w, "tool output does not answer the function call: "+string(items[len(items)-1]))
+				return
+			}
+			replay(t, w, prefix+"tool_result.sse")
+		case first.Content == providertest.PromptToolUse:
+			name := ""
+			if len(body.Tools) == 1 {
+				name = body.Tools[0].Name + body.Tools[0].Function.Name
+			}
+			if name != providertest.ToolName {
+				openAIError(w, fmt.Sprintf("expected the %s tool, got %+v", providertest.ToolName, body.Tools))
+				return
+			}
+			replay(t, w, prefix+"tool_use.sse")
+		case first.Content == providertest.PromptText:
+			replay(t, w, prefix+"text.sse")
+		case first.Content == providertest.PromptLong:
+			openAIStreamUntilDisconnect(w, r, api)
+		default:
+			openAIError(w, "no recording for prompt "+first.Content)
+		}
+	})
+}
+
+// openAIStreamUntilDisconnect streams text deltas until the client goes away
+func openAIStreamUntilDisconnect(w http.ResponseWriter, r *http.Request, api openai.API) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	flusher := w.(http.Flusher)
+	send := func(data string) {
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		flusher.Flush()
+	}
+
+	delta := `{"id":"chatcmpl-01Long","object":"chat.completion.chunk","model":"gpt-4.1-2025-04-14","choices":[{"index":0,"delta":{"content":"Once upon a time "},"finish_reason":null}]}`
+	if api == openai.APIResponses {
+		send(`{"type":"response.created","response":{"id":"resp_01Long","object":"response","status":"in_progress","model":"gpt-5-mini-2025-08-07","output":[]}}`)
+		send(`{"type":"response.output_item.added","output_index":0,"item":{"id":"msg_01Long","type":"message","status":"in_progress","content":[],"role":"assistant"}}`)
+		delta = `{"type":"response.output_text.delta","item_id":"msg_01Long","output_index":0,"content_index":0,"delta":"Once upon a time "}`
+	}
+	ticker := time.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(30 * time.Second)
+	for {
+		select {
+		case <-ticker.C:
+			send(delta)
+		case <-r.Context().Done():
+			return
+		case <-timeout:
+			return
+		}
+	}
+}
+
+// recordedGeminiAPI serves streamGenerateContent from the recorded streams, like
+// recordedAnthropicAPI. A function response must answer the recorded call by name, and
+// the call must come back with its thought signature.
+func recordedGeminiAPI(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/models/gemini-2.5-flash:streamGenerateContent" || r.URL.Query().Get("alt") != "sse" {
+			geminiError(w, "unexpected endpoint "+r.URL.String())
+			return
+		}
+		type part struct {
+			Text             string `json:"text"`
+			ThoughtSignature string `json:"thoughtSignature"`
+			FunctionCall     *struct {
+				Name string `json:"name"`
+			} `json:"functionCall"`
+			FunctionResponse *struct {
+				Name     string            `json:"name"`
+				Response map[string]string `json:"response"`
+			} `json:"functionResponse"`
+		}
+		var body struct {
+			Contents []struct {
+				Role  string `json:"role"`
+				Parts []part `json:"parts"`
+			} `json:"contents"`
+			Tools []struct {
+				FunctionDeclarations []struct {
+					Name string `json:"name"`
+				} `json:"functionDeclarations"`
+			} `json:"tools"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Contents) == 0 {
+			geminiError(w, fmt.Sprintf("invalid request body: %v", err))
+			return
+		}
+
+		prompt := body.Contents[0].Parts[0].Text
+		last := body.Contents[len(body.Contents)-1]
+		lastPart := last.Parts[len(last.Parts)-1]
+
+		switch {
+		case lastPart.FunctionResponse != nil:
+			call := body.Contents[len(body.Contents)-2].Parts
+			signed := false
+			for _, p := range call {
+				signed = signed || (p.FunctionCall != nil && p.ThoughtSignature == recordedThoughtSignature)
+			}
+			response := lastPart.FunctionResponse
+			if response.Name != providertest.ToolName || response.Response["output"] != providertest.ToolResultText || !signed {
+				geminiError(w, fmt.Sprintf("function response does not answer the signed call: %+v", body.Contents))
+				return
+			}
+			replay(t, w, "gemini_tool_result.sse")
+		case prompt == providertest.PromptToolUse:
+			name := ""
+			if len(body.Tools) == 1 && len(body.Tools[0].FunctionDeclarations) == 1 {
+				name = body.Tools[0].FunctionDeclarations[0].Name
+			}
+			if name != providertest.ToolName {
+				geminiError(w, fmt.Sprintf("expected the %s tool, got %+v", providertest.ToolName, body.Tools))
+				return
+			}
+			replay(t, w, "gemini_tool_use.sse")
+		case prompt == providertest.PromptText:
+			replay(t, w, "gemini_text.sse")
+		case prompt == providertest.PromptLong:
+			geminiStreamUntilDisconnect(w, r)
+		default:
+			geminiError(w, "no recording for prompt "+prompt)
+		}
+	})
+}
+
+// geminiStreamUntilDisconnect streams text chunks until the client goes away
+func geminiStreamUntilDisconnect(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	flusher := w.(http.Flusher)
+
+	ticker := time.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(30 * time.Second)
+	for {
+		select {
+		case <-ticker.C:
+			fmt.Fprintf(w, "data: %s\n\n", `{"candidates":[{"content":{"parts":[{"text":"Once upon a time "}],"role":"model"},"index":0}],"modelVersion":"gemini-2.5-flash"}`)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-timeout:
+			return
+		}
+	}
+}
+
+// recordedOllamaAPI serves /api/chat from the recorded streams, like
+// recordedAnthropicAPI. A tool message must answer the recorded call by tool name, after
+// the assistant message that made the call.
+func recordedOllamaAPI(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/chat" {
+			ollamaError(w, "unexpected endpoint "+r.URL.Path)
+			return
+		}
+		type message struct {
+			Role      string `json:"role"`
+			Content   string `json:"content"`
+			ToolName  string `json:"tool_name"`
+			ToolCalls []struct {
+				Function struct {
+					Name string `json:"name"`
+				} `json:"function"`
+			} `json:"tool_calls"`
+		}
+		var body struct {
+			Messages []message `json:"messages"`
+			Tools    []struct {
+				Function struct {
+					Name string `json:"name"`
+				} `json:"function"`
+			} `json:"tools"`
+			Stream bool `json:"stream"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Messages) == 0 || !body.Stream {
+			ollamaError(w, fmt.Sprintf("invalid request body: %v", err))
+			return
+		}
+
+		prompt := body.Messages[0].Content
+		last := body.Messages[len(body.Messages)-1]
+
+		switch {
+		case last.Role == "tool":
+			call := body.Messages[len(body.Messages)-2]
+			if last.ToolName != providertest.ToolName || last.Content != providertest.ToolResultText ||
+				len(call.ToolCalls) != 1 || call.ToolCalls[0].Function.Name != providertest.ToolName {
+				ollamaError(w, fmt.Sprintf("tool message does not answer the call: %+v", body.Messages))
+				return
+			}
+			replay(t, w, "ollama_tool_result.ndjson")
+		case prompt == providertest.PromptToolUse:
+			if len(body.Tools) != 1 || body.Tools[0].Function.Name != providertest.ToolName {
+				ollamaError(w, fmt.Sprintf("expected the %s tool, got %+v", providertest.ToolName, body.Tools))
+				return
+			}
+			replay(t, w, "ollama_tool_use.ndjson")
+		case prompt == providertest.PromptText:
+			replay(t, w, "ollama_text.ndjson")
+		case prompt == providertest.PromptLong:
+			ollamaStreamUntilDisconnect(w, r)
+		default:
+			ollamaError(w, "no recording for prompt "+prompt)
+		}
+	})
+}
+
+// ollamaStreamUntilDisconnect streams content chunks until the client goes away
+func ollamaStreamUntilDisconnect(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	flusher := w.(http.Flusher)
+
+	ticker := time.NewTicker(20 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(30 * time.Second)
+	for {
+		select {
+		case <-ticker.C:
+			fmt.Fprintln(w, `{"model":"qwen3:8b","message":{"role":"assistant","content":"Once upon a time "},"done":false}`)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-timeout:
+			return
+		}
+	}
+}
+
+func ollamaError(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+func geminiError(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{"code": http.StatusBadRequest, "message": message, "status": "INVALID_ARGUMENT"},
+	})
+}
+
+func openAIError(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]string{"type": "invalid_request_error", "message": message},
+	})
+}
+
+func anthropicError(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"type":  "error",
+		"error": map[string]string{"type": "invalid_request_error", "message": message},
+	})
+}
@@ -43,9 +43,71 @@ func normalizeToolResultContent(content map[string]interface{}) {
 	content["result"] = string(resultJSON)
 }
 
+// providerBlockType reads the provider's own block type ("type") from raw provider data
+func providerBlockType(providerData json.RawMessage) string {
+	if len(providerData) == 0 {
+		return ""
+	}
+	var raw struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(providerData, &raw); err != nil {
+		return ""
+	}
+	return raw.Type
+}
+
+// isServerToolResultType reports whether a provider block type is the result of a
+// provider-executed tool other than web search (e.g. "web_fetch_tool_result",
+// "code_execution_tool_result")
+func isServerToolResultType(providerType string) bool {
+	return strings.HasSuffix(providerType, "_tool_result") && providerType != "web_search_tool_result"
+}
+
+// normalizeProviderBlock re-types blocks the library doesn't recognize.
+// The library normalizes web search, but guesses other provider-specific blocks:
+// redacted_thinking and server tool results arrive as content-less tool_result blocks,
+// and non-web-search server_tool_use as tool_use. Stored under those types they would
+// be replayed as malformed tool blocks, so they get their own block types here.
+// The raw block stays in ProviderData for same-provider replay.
+func normalizeProviderBlock(block *llm.TurnBlock) {
+	providerType := providerBlockType(block.ProviderData)
+	switch {
+	case providerType == "redacted_thinking":
+		block.BlockType = llm.BlockTypeRedactedThinking
+		block.Content = nil
+
+	case providerType == "server_tool_use" && block.BlockType == llm.BlockTypeToolUse:
+		block.BlockType = llm.BlockTypeServerToolUse
+
+	case isServerToolResultType(providerType) && block.BlockType == llm.BlockTypeToolResult:
+		var raw struct {
+			ToolUseID string `json:"tool_use_id"`
+		}
+		_ = json.Unmarshal(block.ProviderData, &raw)
+		block.BlockType = llm.BlockTypeServerToolResult
+		block.Content = map[string]interface{}{
+			"tool_use_id": raw.ToolUseID,
+			"result_type": providerType,
+		}
+	}
+}
+
+// normalizeDeltaBlockType maps the raw provider block types the library passes through
+// on block start to backend block types (see normalizeProviderBlock)
+func normalizeDeltaBlockType(blockType *string) *string {
+	if blockType == nil || !isServerToolResultType(*blockType) {
+		return blockType
+	}
+	normalized := llm.BlockTypeServerToolResult
+	return &normalized
+}
+
 // ConvertToLibraryRequest converts backend GenerateRequest to library GenerateRequest.
 // This is used by provider adapters and debug tooling to inspect the exact payload
 // that will be sent to the underlying meridian-llm-go provider.
+// Provider-opaque blocks (redacted_thinking, server_tool_use, server_tool_result) are passed
+// through unchanged; provider adapters that can't replay them skip them as a unit.
 func ConvertToLibraryRequest(req *domainllm.GenerateRequest) (*llmprovider.GenerateRequest, error) {
 	messages := make([]llmprovider.Message, len(req.Messages))
 	for i, msg := range req.Messages {
@@ -114,6 +176,7 @@ func convertFromLibraryResponse(resp *llmprovider.GenerateResponse) *domainllm.G
 			ProviderData:  block.ProviderData, // Direct copy of raw bytes - no unmarshal
 			ExecutionSide: executionSide,
 		}
+		normalizeProviderBlock(blocks[i])
 	}
 
 	return &domainllm.GenerateResponse{
@@ -135,7 +198,7 @@ func convertFromLibraryEvent(event llmprovider.StreamEvent) domainllm.StreamEven
 	if event.Delta != nil {
 		backendEvent.Delta = &llm.TurnBlockDelta{
 			BlockIndex:        event.Delta.BlockIndex,
-			BlockType:         event.Delta.BlockType,
+			BlockType:         normalizeDeltaBlockType(event.Delta.BlockType),
 			DeltaType:         event.Delta.DeltaType,
 			TextDelta:         event.Delta.TextDelta,
 			SignatureDelta:    event.Delta.SignatureDelta,
@@ -169,6 +232,7 @@ func convertFromLibraryEvent(event llmprovider.StreamEvent) domainllm.StreamEven
 			ProviderData:  event.Block.ProviderData, // Direct copy of raw bytes - no unmarshal
 			ExecutionSide: executionSide,
 		}
+		normalizeProviderBlock(backendEvent.Block)
 	}
 
 	if event.Metadata != nil {
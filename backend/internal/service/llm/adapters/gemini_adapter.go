@@ -0,0 +1,109 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+
+	llmprovider "github.com/haowjy/meridian-llm-go"
+
+	domainllm "meridian/internal/domain/services/llm"
+	"meridian/internal/service/llm/gemini"
+)
+
+// GeminiAdapter wraps the backend's Gemini provider and implements the backend's LLMProvider interface.
+// It handles conversion between backend types (with DB fields) and library types (content-only).
+type GeminiAdapter struct {
+	provider llmprovider.Provider
+}
+
+// NewGeminiAdapter creates a new Gemini adapter with the given provider config.
+func NewGeminiAdapter(cfg gemini.Config) (*GeminiAdapter, error) {
+	provider, err := gemini.NewProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GeminiAdapter{
+		provider: provider,
+	}, nil
+}
+
+// NewGeminiAdapterWithProvider creates a new Gemini adapter from an existing provider.
+// Used by provider factory for dynamic provider creation.
+func NewGeminiAdapterWithProvider(provider llmprovider.Provider) *GeminiAdapter {
+	return &GeminiAdapter{
+		provider: provider,
+	}
+}
+
+// Name returns the provider name.
+func (a *GeminiAdapter) Name() string {
+	return a.provider.Name().String()
+}
+
+// SupportsModel returns true if this provider supports the given model.
+func (a *GeminiAdapter) SupportsModel(model string) bool {
+	return a.provider.SupportsModel(model)
+}
+
+// GenerateResponse generates a response from Gemini.
+func (a *GeminiAdapter) GenerateResponse(ctx context.Context, req *domainllm.GenerateRequest) (*domainllm.GenerateResponse, error) {
+	// Convert backend request to library request
+	libReq, err := ConvertToLibraryRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call provider
+	libResp, err := a.provider.GenerateResponse(ctx, libReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert library response to backend response
+	return convertFromLibraryResponse(libResp), nil
+}
+
+// StreamResponse generates a streaming response from Gemini.
+func (a *GeminiAdapter) StreamResponse(ctx context.Context, req *domainllm.GenerateRequest) (<-chan domainllm.StreamEvent, error) {
+	// Convert backend request to library request
+	libReq, err := ConvertToLibraryRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call provider
+	libEventCh, err := a.provider.StreamResponse(ctx, libReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create backend event channel
+	backendEventCh := make(chan domainllm.StreamEvent)
+
+	// Convert library events to backend events
+	go func() {
+		defer close(backendEventCh)
+		for libEvent := range libEventCh {
+			backendEventCh <- convertFromLibraryEvent(libEvent)
+		}
+	}()
+
+	return backendEventCh, nil
+}
+
+// BuildDebugProviderRequest builds the Gemini generateContent request body for debugging.
+func (a *GeminiAdapter) BuildDebugProviderRequest(ctx context.Context, req *domainllm.GenerateRequest) (map[string]interface{}, error) {
+	provider, ok := a.provider.(*gemini.Provider)
+	if !ok {
+		return nil, fmt.Errorf("debug requests need the Gemini provider, got %T", a.provider)
+	}
+
+	// Convert backend request to library request
+	libReq, err := ConvertToLibraryRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.BuildRequestDebug(libReq)
+}
@@ -0,0 +1,109 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+
+	llmprovider "github.com/haowjy/meridian-llm-go"
+
+	domainllm "meridian/internal/domain/services/llm"
+	"meridian/internal/service/llm/ollama"
+)
+
+// OllamaAdapter wraps the backend's Ollama provider and implements the backend's LLMProvider interface.
+// It handles conversion between backend types (with DB fields) and library types (content-only).
+type OllamaAdapter struct {
+	provider llmprovider.Provider
+}
+
+// NewOllamaAdapter creates a new Ollama adapter with the given provider config.
+func NewOllamaAdapter(cfg ollama.Config) (*OllamaAdapter, error) {
+	provider, err := ollama.NewProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OllamaAdapter{
+		provider: provider,
+	}, nil
+}
+
+// NewOllamaAdapterWithProvider creates a new Ollama adapter from an existing provider.
+// Used by provider factory for dynamic provider creation.
+func NewOllamaAdapterWithProvider(provider llmprovider.Provider) *OllamaAdapter {
+	return &OllamaAdapter{
+		provider: provider,
+	}
+}
+
+// Name returns the provider name.
+func (a *OllamaAdapter) Name() string {
+	return a.provider.Name().String()
+}
+
+// SupportsModel returns true if this provider supports the given model.
+func (a *OllamaAdapter) SupportsModel(model string) bool {
+	return a.provider.SupportsModel(model)
+}
+
+// GenerateResponse generates a response from Ollama.
+func (a *OllamaAdapter) GenerateResponse(ctx context.Context, req *domainllm.GenerateRequest) (*domainllm.GenerateResponse, error) {
+	// Convert backend request to library request
+	libReq, err := ConvertToLibraryRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call provider
+	libResp, err := a.provider.GenerateResponse(ctx, libReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert library response to backend response
+	return convertFromLibraryResponse(libResp), nil
+}
+
+// StreamResponse generates a streaming response from Ollama.
+func (a *OllamaAdapter) StreamResponse(ctx context.Context, req *domainllm.GenerateRequest) (<-chan domainllm.StreamEvent, error) {
+	// Convert backend request to library request
+	libReq, err := ConvertToLibraryRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call provider
+	libEventCh, err := a.provider.StreamResponse(ctx, libReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create backend event channel
+	backendEventCh := make(chan domainllm.StreamEvent)
+
+	// Convert library events to backend events
+	go func() {
+		defer close(backendEventCh)
+		for libEvent := range libEventCh {
+			backendEventCh <- convertFromLibraryEvent(libEvent)
+		}
+	}()
+
+	return backendEventCh, nil
+}
+
+// BuildDebugProviderRequest builds the Ollama chat request body for debugging.
+func (a *OllamaAdapter) BuildDebugProviderRequest(ctx context.Context, req *domainllm.GenerateRequest) (map[string]interface{}, error) {
+	provider, ok := a.provider.(*ollama.Provider)
+	if !ok {
+		return nil, fmt.Errorf("debug requests need the Ollama provider, got %T", a.provider)
+	}
+
+	// Convert backend request to library request
+	libReq, err := ConvertToLibraryRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.BuildRequestDebug(libReq)
+}
@@ -0,0 +1,110 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+
+	llmprovider "github.com/haowjy/meridian-llm-go"
+
+	domainllm "meridian/internal/domain/services/llm"
+	"meridian/internal/service/llm/openai"
+)
+
+// OpenAIAdapter wraps the backend's OpenAI provider and implements the backend's LLMProvider interface.
+// It handles conversion between backend types (with DB fields) and library types (content-only).
+type OpenAIAdapter struct {
+	provider llmprovider.Provider
+}
+
+// NewOpenAIAdapter creates a new OpenAI adapter with the given provider config.
+func NewOpenAIAdapter(cfg openai.Config) (*OpenAIAdapter, error) {
+	provider, err := openai.NewProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenAIAdapter{
+		provider: provider,
+	}, nil
+}
+
+// NewOpenAIAdapterWithProvider creates a new OpenAI adapter from an existing provider.
+// Used by provider factory for dynamic provider creation.
+func NewOpenAIAdapterWithProvider(provider llmprovider.Provider) *OpenAIAdapter {
+	return &OpenAIAdapter{
+		provider: provider,
+	}
+}
+
+// Name returns the provider name.
+func (a *OpenAIAdapter) Name() string {
+	return a.provider.Name().String()
+}
+
+// SupportsModel returns true if this provider supports the given model.
+func (a *OpenAIAdapter) SupportsModel(model string) bool {
+	return a.provider.SupportsModel(model)
+}
+
+// GenerateResponse generates a response from OpenAI.
+func (a *OpenAIAdapter) GenerateResponse(ctx context.Context, req *domainllm.GenerateRequest) (*domainllm.GenerateResponse, error) {
+	// Convert backend request to library request
+	libReq, err := ConvertToLibraryRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call provider
+	libResp, err := a.provider.GenerateResponse(ctx, libReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert library response to backend response
+	return convertFromLibraryResponse(libResp), nil
+}
+
+// StreamResponse generates a streaming response from OpenAI.
+func (a *OpenAIAdapter) StreamResponse(ctx context.Context, req *domainllm.GenerateRequest) (<-chan domainllm.StreamEvent, error) {
+	// Convert backend request to library request
+	libReq, err := ConvertToLibraryRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Call provider
+	libEventCh, err := a.provider.StreamResponse(ctx, libReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create backend event channel
+	backendEventCh := make(chan domainllm.StreamEvent)
+
+	// Convert library events to backend events
+	go func() {
+		defer close(backendEventCh)
+		for libEvent := range libEventCh {
+			backendEventCh <- convertFromLibraryEvent(libEvent)
+		}
+	}()
+
+	return backendEventCh, nil
+}
+
+// BuildDebugProviderRequest builds the OpenAI request body (Responses or Chat Completions,
+// as configured) for debugging.
+func (a *OpenAIAdapter) BuildDebugProviderRequest(ctx context.Context, req *domainllm.GenerateRequest) (map[string]interface{}, error) {
+	provider, ok := a.provider.(*openai.Provider)
+	if !ok {
+		return nil, fmt.Errorf("debug requests need the OpenAI provider, got %T", a.provider)
+	}
+
+	// Convert backend request to library request
+	libReq, err := ConvertToLibraryRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.BuildRequestDebug(libReq)
+}
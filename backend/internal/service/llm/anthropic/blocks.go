@@ -0,0 +1,274 @@
+package anthropic
+
+import (
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/anthropics/anthropic-sdk-go"
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+// Blocks are converted as the library's Anthropic provider converts them, so turns from
+// either provider replay the same way: web search calls and results are normalized to
+// web_search / web_search_result blocks with sparse raw JSON in ProviderData, and thinking
+// keeps its signature in ProviderData.
+
+// providerName returns the provider recorded on blocks
+func providerName() *string {
+	name := llmprovider.ProviderAnthropic.String()
+	return &name
+}
+
+// libraryBlockType returns the library block type for an Anthropic content block type
+func libraryBlockType(blockType, toolName string) string {
+	switch blockType {
+	case "text":
+		return llmprovider.BlockTypeText
+	case "thinking":
+		return llmprovider.BlockTypeThinking
+	case "tool_use":
+		return llmprovider.BlockTypeToolUse
+	case "server_tool_use":
+		if toolName == "web_search" {
+			return llmprovider.BlockTypeWebSearch
+		}
+		return llmprovider.BlockTypeToolUse
+	case "web_search_tool_result":
+		return llmprovider.BlockTypeWebSearchResult
+	default:
+		return blockType
+	}
+}
+
+// convertResponse converts a complete message. Blocks that fail to convert are dropped
+// rather than failing the response.
+func convertResponse(message *sdk.Message) *llmprovider.GenerateResponse {
+	blocks := make([]*llmprovider.Block, 0, len(message.Content))
+	for i, content := range message.Content {
+		if block, err := convertBlock(content, i); err == nil {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return &llmprovider.GenerateResponse{
+		Blocks:           blocks,
+		Model:            string(message.Model),
+		InputTokens:      int(message.Usage.InputTokens),
+		OutputTokens:     int(message.Usage.OutputTokens),
+		StopReason:       string(message.StopReason),
+		ResponseMetadata: responseMetadata(message),
+	}
+}
+
+// responseMetadata returns the stop sequence and prompt cache usage of a message
+func responseMetadata(message *sdk.Message) map[string]interface{} {
+	metadata := make(map[string]interface{})
+	if message.StopSequence != "" {
+		metadata["stop_sequence"] = message.StopSequence
+	}
+	if message.Usage.CacheCreationInputTokens > 0 {
+		metadata["cache_creation_input_tokens"] = int(message.Usage.CacheCreationInputTokens)
+	}
+	if message.Usage.CacheReadInputTokens > 0 {
+		metadata["cache_read_input_tokens"] = int(message.Usage.CacheReadInputTokens)
+	}
+	return metadata
+}
+
+// convertBlock converts a complete content block to a library block
+func convertBlock(content sdk.ContentBlockUnion, sequence int) (*llmprovider.Block, error) {
+	switch content.Type {
+	case "text":
+		text := content.Text
+		return &llmprovider.Block{
+			BlockType:   llmprovider.BlockTypeText,
+			Sequence:    sequence,
+			TextContent: &text,
+			Provider:    providerName(),
+			Citations:   convertCitations(content.Citations),
+		}, nil
+
+	case "thinking":
+		thinking := content.Thinking
+		// Thinking without a signature can't be replayed as thinking, so it becomes text
+		if content.Signature == "" {
+			return &llmprovider.Block{
+				BlockType:   llmprovider.BlockTypeText,
+				Sequence:    sequence,
+				TextContent: &thinking,
+				Provider:    providerName(),
+			}, nil
+		}
+		providerData, err := json.Marshal(map[string]interface{}{"signature": content.Signature})
+		if err != nil {
+			return nil, fmt.Errorf("marshal thinking signature: %w", err)
+		}
+		return &llmprovider.Block{
+			BlockType:    llmprovider.BlockTypeThinking,
+			Sequence:     sequence,
+			TextContent:  &thinking,
+			Provider:     providerName(),
+			ProviderData: providerData,
+		}, nil
+
+	case "tool_use":
+		executionSide := llmprovider.ExecutionSideServer
+		if content.Name == "web_search" {
+			executionSide = llmprovider.ExecutionSideProvider
+		}
+		return &llmprovider.Block{
+			BlockType:     llmprovider.BlockTypeToolUse,
+			Sequence:      sequence,
+			Content:       toolCallContent(content),
+			ExecutionSide: &executionSide,
+			Provider:      providerName(),
+		}, nil
+
+	case "server_tool_use":
+		// Sparse JSON for replay: RawJSON() would carry the union's zero-value fields
+		providerData, err := json.Marshal(map[string]interface{}{
+			"type":  content.Type,
+			"id":    content.ID,
+			"name":  content.Name,
+			"input": content.Input,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("marshal server_tool_use provider data: %w", err)
+		}
+		executionSide := llmprovider.ExecutionSideProvider
+		return &llmprovider.Block{
+			BlockType:     libraryBlockType(content.Type, content.Name),
+			Sequence:      sequence,
+			Content:       toolCallContent(content),
+			ExecutionSide: &executionSide,
+			Provider:      providerName(),
+			ProviderData:  providerData,
+		}, nil
+
+	case "web_search_tool_result":
+		return convertWebSearchResult(content, sequence)
+
+	default:
+		// Unknown block types are kept raw for replay
+		return &llmprovider.Block{
+			BlockType:    llmprovider.BlockTypeToolResult,
+			Sequence:     sequence,
+			Provider:     providerName(),
+			ProviderData: json.RawMessage(content.RawJSON()),
+		}, nil
+	}
+}
+
+// toolCallContent returns the normalized content of a tool call block
+func toolCallContent(content sdk.ContentBlockUnion) map[string]interface{} {
+	return map[string]interface{}{
+		"tool_use_id": content.ID,
+		"tool_name":   content.Name,
+		"input":       content.Input,
+	}
+}
+
+// convertWebSearchResult converts server-executed search results (or a search error).
+// Content holds titles and URLs; ProviderData keeps the encrypted content Anthropic
+// needs when the results are replayed.
+func convertWebSearchResult(content sdk.ContentBlockUnion, sequence int) (*llmprovider.Block, error) {
+	normalized := make(map[string]interface{})
+	if content.ToolUseID != "" {
+		normalized["tool_use_id"] = content.ToolUseID
+	}
+	raw := make(map[string]interface{})
+
+	if content.Content.Type == "web_search_tool_result_error" {
+		normalized["is_error"] = true
+		normalized["error_code"] = string(content.Content.ErrorCode)
+		raw["type"] = "web_search_tool_result_error"
+		raw["error_code"] = string(content.Content.ErrorCode)
+	} else {
+		sources := content.Content.OfWebSearchResultBlockArray
+		results := make([]map[string]interface{}, 0, len(sources))
+		rawResults := make([]map[string]interface{}, 0, len(sources))
+		for _, source := range sources {
+			result := map[string]interface{}{"title": source.Title, "url": source.URL}
+			rawResult := map[string]interface{}{"type": "web_search_result", "title": source.Title, "url": source.URL}
+			if source.PageAge != "" {
+				result["page_age"] = source.PageAge
+				rawResult["page_age"] = source.PageAge
+			}
+			if source.EncryptedContent != "" {
+				rawResult["encrypted_content"] = source.EncryptedContent
+			}
+			results = append(results, result)
+			rawResults = append(rawResults, rawResult)
+		}
+		normalized["results"] = results
+		raw["type"] = "web_search_tool_result_success"
+		raw["results"] = rawResults
+	}
+
+	providerData, err := json.Marshal(map[string]interface{}{
+		"type":        content.Type,
+		"tool_use_id": content.ToolUseID,
+		"content":     raw,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal web_search_tool_result provider data: %w", err)
+	}
+
+	return &llmprovider.Block{
+		BlockType:    llmprovider.BlockTypeWebSearchResult,
+		Sequence:     sequence,
+		Content:      normalized,
+		Provider:     providerName(),
+		ProviderData: providerData,
+	}, nil
+}
+
+// convertCitations converts the citations of a text block
+func convertCitations(cites []sdk.TextCitationUnion) []llmprovider.Citation {
+	if len(cites) == 0 {
+		return nil
+	}
+
+	citations := make([]llmprovider.Citation, 0, len(cites))
+	for _, cite := range cites {
+		citation := llmprovider.Citation{Type: cite.Type}
+		if cite.CitedText != "" {
+			citedText := cite.CitedText
+			citation.CitedText = &citedText
+		}
+
+		switch cite.Type {
+		case "web_search_result_location":
+			citation.URL = cite.URL
+			citation.Title = cite.Title
+			if cite.EncryptedIndex != "" {
+				citation.ProviderData, _ = json.Marshal(map[string]interface{}{"encrypted_index": cite.EncryptedIndex})
+			}
+		case "search_result_location":
+			citation.URL = cite.URL
+			citation.Title = cite.Title
+			if cite.SearchResultIndex >= 0 {
+				index := int(cite.SearchResultIndex)
+				citation.ResultIndex = &index
+			}
+			if cite.Source != "" {
+				citation.ProviderData, _ = json.Marshal(map[string]interface{}{"source": cite.Source})
+			}
+		case "char_location":
+			if cite.StartCharIndex >= 0 {
+				start := int(cite.StartCharIndex)
+				citation.StartIndex = &start
+			}
+			if cite.EndCharIndex >= 0 {
+				end := int(cite.EndCharIndex)
+				citation.EndIndex = &end
+			}
+			if cite.DocumentTitle != "" {
+				citation.Title = cite.DocumentTitle
+			}
+		}
+
+		citations = append(citations, citation)
+	}
+	return citations
+}
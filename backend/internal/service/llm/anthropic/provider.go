@@ -0,0 +1,143 @@
+// Package anthropic implements the meridian-llm-go Provider interface for the Anthropic
+// Messages API, adding prompt caching, which the library's Anthropic provider can't send.
+//
+// Request bodies are built by the library, so messages, tools and thinking replay exactly
+// as they do there; with a PromptCache, cache_control breakpoints are added before the
+// body is sent with the Anthropic SDK. Responses become library blocks the same way the
+// library's provider converts them.
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdk "github.com/anthropics/anthropic-sdk-go"
+	"github.com/anthropics/anthropic-sdk-go/option"
+	llmprovider "github.com/haowjy/meridian-llm-go"
+	llmanthropic "github.com/haowjy/meridian-llm-go/providers/anthropic"
+)
+
+// defaultMaxTokens is the library's max_tokens default for Anthropic requests
+const defaultMaxTokens = 4096
+
+// Config configures a Provider
+type Config struct {
+	APIKey  string
+	BaseURL string // Empty = ANTHROPIC_BASE_URL, or the Anthropic API
+}
+
+// PromptCache asks for the request's prefix to be cached (see addCacheBreakpoints)
+type PromptCache struct {
+	TTL string // "5m" or "1h"; empty = Anthropic's default (5 minutes)
+}
+
+// Provider implements llmprovider.Provider for the Anthropic API
+type Provider struct {
+	client *sdk.Client
+	cache  *PromptCache
+}
+
+var _ llmprovider.Provider = (*Provider)(nil)
+
+// NewProvider creates an Anthropic provider
+func NewProvider(cfg Config) (*Provider, error) {
+	if cfg.APIKey == "" {
+		return nil, llmprovider.ErrInvalidAPIKey
+	}
+
+	opts := []option.RequestOption{option.WithAPIKey(cfg.APIKey)}
+	if cfg.BaseURL != "" {
+		opts = append(opts, option.WithBaseURL(cfg.BaseURL))
+	}
+	client := sdk.NewClient(opts...)
+
+	return &Provider{client: &client}, nil
+}
+
+// WithPromptCache returns a provider that sends requests with cache breakpoints.
+// It shares the client with p.
+func (p *Provider) WithPromptCache(cache PromptCache) *Provider {
+	return &Provider{client: p.client, cache: &cache}
+}
+
+// Name returns the provider identifier
+func (p *Provider) Name() llmprovider.ProviderID {
+	return llmprovider.ProviderAnthropic
+}
+
+// SupportsModel returns true for Claude models ("claude-...")
+func (p *Provider) SupportsModel(model string) bool {
+	return strings.HasPrefix(model, "claude-")
+}
+
+// GenerateResponse generates a complete response
+func (p *Provider) GenerateResponse(ctx context.Context, req *llmprovider.GenerateRequest) (*llmprovider.GenerateResponse, error) {
+	body, err := p.buildRequest(req, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// The typed params only size the SDK's non-streaming timeout; the body is sent as built
+	message, err := p.client.Messages.New(ctx, sizingParams(req), option.WithRequestBody("application/json", body))
+	if err != nil {
+		return nil, fmt.Errorf("anthropic API call failed: %w", err)
+	}
+
+	return convertResponse(message), nil
+}
+
+// BuildRequestDebug returns the request body the provider would send for req
+func (p *Provider) BuildRequestDebug(req *llmprovider.GenerateRequest) (map[string]interface{}, error) {
+	body, err := p.buildRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var debug map[string]interface{}
+	if err := json.Unmarshal(body, &debug); err != nil {
+		return nil, fmt.Errorf("failed to decode request: %w", err)
+	}
+	return debug, nil
+}
+
+// buildRequest validates the model and marshals the request body
+func (p *Provider) buildRequest(req *llmprovider.GenerateRequest, stream bool) ([]byte, error) {
+	if !p.SupportsModel(req.Model) {
+		return nil, &llmprovider.ModelError{
+			Model:    req.Model,
+			Provider: p.Name().String(),
+			Reason:   "model not supported by Anthropic (must start with 'claude-')",
+			Err:      llmprovider.ErrInvalidModel,
+		}
+	}
+
+	body, err := llmanthropic.BuildMessageParamsDebug(req)
+	if err != nil {
+		return nil, err
+	}
+	if p.cache != nil {
+		addCacheBreakpoints(body, p.cache)
+	}
+	if stream {
+		// Set here: the SDK's own stream flag is lost when the body is replaced
+		body["stream"] = true
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	return data, nil
+}
+
+// sizingParams returns the typed params the SDK reads for request options (the
+// non-streaming timeout depends on the model and max_tokens)
+func sizingParams(req *llmprovider.GenerateRequest) sdk.MessageNewParams {
+	maxTokens := defaultMaxTokens
+	if req.Params != nil {
+		maxTokens = req.Params.GetMaxTokens(defaultMaxTokens)
+	}
+	return sdk.MessageNewParams{Model: sdk.Model(req.Model), MaxTokens: int64(maxTokens)}
+}
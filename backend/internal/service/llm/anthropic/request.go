@@ -0,0 +1,50 @@
+package anthropic
+
+// addCacheBreakpoints marks the end of the system prompt and the end of the last message
+// with cache_control. Anthropic caches the request prefix up to each breakpoint (tools,
+// then system, then messages), so the system prompt is read from the cache for the whole
+// chat, and the history up to the latest message for the next request (a tool round, a
+// continuation or the next turn). Prefixes below the model's minimum length are simply
+// not cached.
+func addCacheBreakpoints(body map[string]interface{}, cache *PromptCache) {
+	control := map[string]interface{}{"type": "ephemeral"}
+	if cache.TTL != "" {
+		control["ttl"] = cache.TTL
+	}
+
+	if system, ok := body["system"].([]interface{}); ok && len(system) > 0 {
+		markCacheBreakpoint(system[len(system)-1], control)
+	}
+
+	messages, _ := body["messages"].([]interface{})
+	if len(messages) == 0 {
+		return
+	}
+	message, _ := messages[len(messages)-1].(map[string]interface{})
+	content, _ := message["content"].([]interface{})
+	// Thinking blocks can't carry cache_control; the last block that can ends the prefix
+	for i := len(content) - 1; i >= 0; i-- {
+		if markCacheBreakpoint(content[i], control) {
+			return
+		}
+	}
+}
+
+// markCacheBreakpoint sets cache_control on a content block, reporting false for blocks
+// that can't be breakpoints (thinking and empty text)
+func markCacheBreakpoint(block interface{}, control map[string]interface{}) bool {
+	fields, ok := block.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	switch fields["type"] {
+	case "thinking", "redacted_thinking":
+		return false
+	case "text":
+		if text, _ := fields["text"].(string); text == "" {
+			return false
+		}
+	}
+	fields["cache_control"] = control
+	return true
+}
@@ -0,0 +1,83 @@
+package anthropic
+
+import (
+	"encoding/json"
+	"testing"
+
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+func TestBuildRequest_CacheBreakpoints(t *testing.T) {
+	system, prompt, answer, followUp := "You are an editor. <document>...</document>", "Tighten chapter one.", "Done.", "Now chapter two."
+	thinking := "Chapter two is long."
+	req := &llmprovider.GenerateRequest{
+		Model:  "claude-haiku-4-5-20251001",
+		Params: &llmprovider.RequestParams{System: &system},
+		Messages: []llmprovider.Message{
+			{Role: "user", Blocks: []*llmprovider.Block{{BlockType: llmprovider.BlockTypeText, TextContent: &prompt}}},
+			{Role: "assistant", Blocks: []*llmprovider.Block{{BlockType: llmprovider.BlockTypeText, TextContent: &answer}}},
+			{Role: "user", Blocks: []*llmprovider.Block{{BlockType: llmprovider.BlockTypeText, TextContent: &followUp}}},
+		},
+	}
+
+	provider, err := NewProvider(Config{APIKey: "test-key"})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	body := buildBody(t, provider, req)
+	if body.System[0].CacheControl != nil || body.Messages[2].Content[0].CacheControl != nil {
+		t.Fatalf("breakpoints without a prompt cache: %+v", body)
+	}
+
+	body = buildBody(t, provider.WithPromptCache(PromptCache{TTL: "1h"}), req)
+	if cc := body.System[0].CacheControl; cc == nil || cc.Type != "ephemeral" || cc.TTL != "1h" {
+		t.Errorf("system cache_control = %+v, want ephemeral 1h", cc)
+	}
+	if body.Messages[0].Content[0].CacheControl != nil || body.Messages[2].Content[0].CacheControl == nil {
+		t.Errorf("only the last message should be a breakpoint: %+v", body.Messages)
+	}
+
+	// An assistant prefix ending in thinking puts the breakpoint on the block before it
+	req.Messages = append(req.Messages, llmprovider.Message{Role: "assistant", Blocks: []*llmprovider.Block{
+		{BlockType: llmprovider.BlockTypeText, TextContent: &answer},
+		{BlockType: llmprovider.BlockTypeThinking, TextContent: &thinking, ProviderData: json.RawMessage(`{"signature":"sig"}`)},
+	}})
+	body = buildBody(t, provider.WithPromptCache(PromptCache{}), req)
+	last := body.Messages[len(body.Messages)-1].Content
+	if len(last) != 2 || last[1].CacheControl != nil || last[0].CacheControl == nil || last[0].CacheControl.TTL != "" {
+		t.Errorf("last message content = %+v, want a default-TTL breakpoint on the text block", last)
+	}
+}
+
+type requestBody struct {
+	System   []contentBlock `json:"system"`
+	Messages []struct {
+		Content []contentBlock `json:"content"`
+	} `json:"messages"`
+}
+
+type contentBlock struct {
+	Type         string `json:"type"`
+	CacheControl *struct {
+		Type string `json:"type"`
+		TTL  string `json:"ttl"`
+	} `json:"cache_control"`
+}
+
+// buildBody returns the request body provider would stream for req
+func buildBody(t *testing.T, provider *Provider, req *llmprovider.GenerateRequest) requestBody {
+	t.Helper()
+	data, err := provider.buildRequest(req, true)
+	if err != nil {
+		t.Fatalf("buildRequest: %v", err)
+	}
+	var body requestBody
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Fatalf("decode request: %v", err)
+	}
+	if len(body.System) != 1 || len(body.Messages) < 3 {
+		t.Fatalf("unexpected request: %s", data)
+	}
+	return body
+}
@@ -0,0 +1,148 @@
+package anthropic
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/anthropics/anthropic-sdk-go"
+	"github.com/anthropics/anthropic-sdk-go/option"
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+// StreamResponse streams a response: a delta carrying the type when a block starts,
+// content deltas, then the complete block when it stops. The metadata event comes last;
+// a stream that fails (e.g. cancelled) ends with an error instead.
+func (p *Provider) StreamResponse(ctx context.Context, req *llmprovider.GenerateRequest) (<-chan llmprovider.StreamEvent, error) {
+	body, err := p.buildRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan llmprovider.StreamEvent, 10)
+	go func() {
+		defer close(events)
+
+		stream := p.client.Messages.NewStreaming(ctx, sdk.MessageNewParams{}, option.WithRequestBody("application/json", body))
+		defer stream.Close()
+
+		// The SDK accumulates the message, so complete blocks can be emitted on block stop
+		message := sdk.Message{}
+		for stream.Next() {
+			event := stream.Current()
+			if err := message.Accumulate(event); err != nil {
+				events <- llmprovider.StreamEvent{Error: fmt.Errorf("failed to accumulate message: %w", err)}
+				return
+			}
+
+			streamEvent := transformEvent(event, &message)
+			if streamEvent.Delta == nil && streamEvent.Block == nil && streamEvent.Error == nil {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				events <- llmprovider.StreamEvent{Error: ctx.Err()}
+				return
+			case events <- streamEvent:
+			}
+		}
+		if err := stream.Err(); err != nil {
+			events <- llmprovider.StreamEvent{Error: fmt.Errorf("anthropic streaming error: %w", err)}
+			return
+		}
+
+		events <- llmprovider.StreamEvent{Metadata: &llmprovider.StreamMetadata{
+			Model:            string(message.Model),
+			InputTokens:      int(message.Usage.InputTokens),
+			OutputTokens:     int(message.Usage.OutputTokens),
+			StopReason:       string(message.StopReason),
+			ResponseMetadata: responseMetadata(&message),
+		}}
+	}()
+
+	return events, nil
+}
+
+// transformEvent converts a stream event to a library event. message is the message
+// accumulated so far, which holds the complete block when a content_block_stop arrives.
+// Events without a library counterpart (message_start, message_delta, ...) become an
+// empty event.
+func transformEvent(event sdk.MessageStreamEventUnion, message *sdk.Message) llmprovider.StreamEvent {
+	switch e := event.AsAny().(type) {
+	case sdk.ContentBlockStartEvent:
+		block := e.ContentBlock
+		blockType := libraryBlockType(block.Type, block.Name)
+		delta := &llmprovider.BlockDelta{
+			BlockIndex: int(e.Index),
+			BlockType:  &blockType,
+		}
+
+		switch block.Type {
+		case "text":
+			delta.DeltaType = llmprovider.DeltaTypeText
+		case "thinking":
+			// The signature follows in signature_delta events
+			delta.DeltaType = llmprovider.DeltaTypeThinking
+		case "tool_use", "server_tool_use":
+			// Server tool calls arrive complete here; no input deltas follow
+			delta.DeltaType = llmprovider.DeltaTypeToolCallStart
+			setToolCall(delta, block.ID)
+			if block.Name != "" {
+				name := block.Name
+				delta.ToolCallName = &name
+				delta.ToolName = &name // Legacy field
+			}
+		case "web_search_tool_result":
+			// Search results arrive complete here; the block follows on block stop
+			delta.DeltaType = llmprovider.DeltaTypeToolResult
+			setToolCall(delta, block.ToolUseID)
+		}
+		return llmprovider.StreamEvent{Delta: delta}
+
+	case sdk.ContentBlockDeltaEvent:
+		delta := &llmprovider.BlockDelta{BlockIndex: int(e.Index)}
+		switch e.Delta.Type {
+		case "text_delta":
+			text := e.Delta.Text
+			delta.DeltaType = llmprovider.DeltaTypeText
+			delta.TextDelta = &text
+		case "thinking_delta":
+			text := e.Delta.Thinking
+			delta.DeltaType = llmprovider.DeltaTypeThinking
+			delta.TextDelta = &text
+		case "signature_delta":
+			signature := e.Delta.Signature
+			delta.DeltaType = llmprovider.DeltaTypeSignature
+			delta.SignatureDelta = &signature
+		case "input_json_delta":
+			partial := e.Delta.PartialJSON
+			delta.DeltaType = llmprovider.DeltaTypeJSON
+			delta.JSONDelta = &partial
+		}
+		return llmprovider.StreamEvent{Delta: delta}
+
+	case sdk.ContentBlockStopEvent:
+		index := int(e.Index)
+		if index < 0 || index >= len(message.Content) {
+			return llmprovider.StreamEvent{
+				Error: fmt.Errorf("invalid block index %d, message has %d blocks", index, len(message.Content)),
+			}
+		}
+		block, err := convertBlock(message.Content[index], index)
+		if err != nil {
+			return llmprovider.StreamEvent{Error: fmt.Errorf("convert block %d: %w", index, err)}
+		}
+		return llmprovider.StreamEvent{Block: block}
+
+	default:
+		return llmprovider.StreamEvent{}
+	}
+}
+
+// setToolCall sets the tool call ID of a delta, if there is one
+func setToolCall(delta *llmprovider.BlockDelta, id string) {
+	if id == "" {
+		return
+	}
+	delta.ToolCallID = &id
+	delta.ToolUseID = &id // Legacy field
+}
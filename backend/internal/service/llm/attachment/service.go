@@ -0,0 +1,210 @@
+// Package attachment implements AttachmentService: uploaded chat attachments kept in
+// object storage, with per-project quotas.
+package attachment
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	"meridian/internal/domain/repositories"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/domain/services"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+// maxFileNameLength bounds the stored file name (the name is only shown to users)
+const maxFileNameLength = 255
+
+// contentTypes are the accepted file types (the image types vision models take) and
+// the extension their objects are stored with
+var contentTypes = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// Service implements the AttachmentService interface.
+// Files are stored at attachments/{project_id}/{uuid}{ext}; the type is detected from
+// the file's content, not its name.
+type Service struct {
+	repo        llmRepo.AttachmentRepository
+	objectStore services.ObjectStore
+	txManager   repositories.TransactionManager
+	authorizer  services.ResourceAuthorizer
+	urlTTL      time.Duration
+	maxBytes    int64 // Largest file accepted
+	quotaBytes  int64 // Total attachment size per project (0 = no limit)
+	logger      *slog.Logger
+}
+
+// NewService creates a new attachment service
+func NewService(
+	repo llmRepo.AttachmentRepository,
+	objectStore services.ObjectStore,
+	txManager repositories.TransactionManager,
+	authorizer services.ResourceAuthorizer,
+	urlTTL time.Duration,
+	maxBytes int64,
+	quotaBytes int64,
+	logger *slog.Logger,
+) llmSvc.AttachmentService {
+	return &Service{
+		repo:        repo,
+		objectStore: objectStore,
+		txManager:   txManager,
+		authorizer:  authorizer,
+		urlTTL:      urlTTL,
+		maxBytes:    maxBytes,
+		quotaBytes:  quotaBytes,
+		logger:      logger,
+	}
+}
+
+// UploadAttachment implements AttachmentService
+func (s *Service) UploadAttachment(ctx context.Context, userID string, req *llmSvc.UploadAttachmentRequest) (*llmModels.Attachment, error) {
+	if req.ProjectID == "" {
+		return nil, fmt.Errorf("%w: project_id is required", domain.ErrValidation)
+	}
+	if err := s.authorizer.CanAccessProject(ctx, userID, req.ProjectID); err != nil {
+		return nil, err
+	}
+
+	size := int64(len(req.Data))
+	if size == 0 {
+		return nil, fmt.Errorf("%w: file is empty", domain.ErrValidation)
+	}
+	if s.maxBytes > 0 && size > s.maxBytes {
+		return nil, fmt.Errorf("%w: file is too large (%d bytes, max %d)", domain.ErrValidation, size, s.maxBytes)
+	}
+
+	contentType := http.DetectContentType(req.Data)
+	ext, ok := contentTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported file type %s (want PNG, JPEG, GIF or WebP)", domain.ErrValidation, contentType)
+	}
+
+	// Fail fast before storing the file; CreateAttachment enforces the quota atomically
+	if s.quotaBytes > 0 {
+		used, err := s.repo.GetProjectUsageBytes(ctx, req.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if used+size > s.quotaBytes {
+			return nil, &llmModels.QuotaExceededError{UsedBytes: used, QuotaBytes: s.quotaBytes}
+		}
+	}
+
+	attachment := &llmModels.Attachment{
+		ProjectID:   req.ProjectID,
+		UserID:      userID,
+		FileName:    fileName(req.FileName, ext),
+		ContentType: contentType,
+		SizeBytes:   size,
+		StorageKey:  fmt.Sprintf("attachments/%s/%s%s", req.ProjectID, uuid.NewString(), ext),
+	}
+	if err := s.objectStore.Put(ctx, attachment.StorageKey, contentType, req.Data); err != nil {
+		return nil, fmt.Errorf("store attachment: %w", err)
+	}
+	err := s.txManager.ExecTx(ctx, func(txCtx context.Context) error {
+		return s.repo.CreateAttachment(txCtx, attachment, s.quotaBytes)
+	})
+	if err != nil {
+		s.deleteObject(ctx, attachment.StorageKey)
+		return nil, err
+	}
+
+	if err := s.sign(ctx, attachment); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("attachment uploaded",
+		"attachment_id", attachment.ID,
+		"project_id", attachment.ProjectID,
+		"content_type", contentType,
+		"bytes", size,
+	)
+	return attachment, nil
+}
+
+// GetAttachment implements AttachmentService
+func (s *Service) GetAttachment(ctx context.Context, userID, attachmentID string) (*llmModels.Attachment, error) {
+	attachment, err := s.authorized(ctx, userID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sign(ctx, attachment); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// DeleteAttachment implements AttachmentService
+func (s *Service) DeleteAttachment(ctx context.Context, userID, attachmentID string) error {
+	attachment, err := s.authorized(ctx, userID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteAttachment(ctx, attachmentID); err != nil {
+		return err
+	}
+	// The record is gone, so the file no longer counts against the quota either way
+	s.deleteObject(ctx, attachment.StorageKey)
+
+	s.logger.Info("attachment deleted", "attachment_id", attachmentID, "project_id", attachment.ProjectID)
+	return nil
+}
+
+// authorized loads an attachment the user's project owns. Attachments of other users'
+// projects are reported as not found.
+func (s *Service) authorized(ctx context.Context, userID, attachmentID string) (*llmModels.Attachment, error) {
+	if _, err := uuid.Parse(attachmentID); err != nil {
+		return nil, fmt.Errorf("attachment %s: %w", attachmentID, domain.ErrNotFound)
+	}
+	attachment, err := s.repo.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizer.CanAccessProject(ctx, userID, attachment.ProjectID); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// sign sets the attachment's download URL
+func (s *Service) sign(ctx context.Context, attachment *llmModels.Attachment) error {
+	url, err := s.objectStore.SignedURL(ctx, attachment.StorageKey, s.urlTTL)
+	if err != nil {
+		return fmt.Errorf("sign attachment URL: %w", err)
+	}
+	attachment.URL = url
+	return nil
+}
+
+// deleteObject removes a stored file; failures leave an orphaned object and are only logged
+func (s *Service) deleteObject(ctx context.Context, key string) {
+	if err := s.objectStore.Delete(ctx, key); err != nil {
+		s.logger.Warn("failed to delete attachment file", "key", key, "error", err)
+	}
+}
+
+// fileName cleans an uploaded file name, defaulting to "image{ext}"
+func fileName(name, ext string) string {
+	name = strings.TrimSpace(path.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "image" + ext
+	}
+	if runes := []rune(name); len(runes) > maxFileNameLength {
+		name = string(runes[:maxFileNameLength])
+	}
+	return name
+}
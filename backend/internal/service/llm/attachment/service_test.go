@@ -0,0 +1,182 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/testmocks"
+)
+
+// pngHeader is enough of a PNG for content type detection
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// fakeRepo keeps attachments in memory
+type fakeRepo struct {
+	attachments map[string]*llmModels.Attachment
+	racedBytes  int64 // Stored by a concurrent upload after the service's usage check
+	outsideTx   bool  // A create ran outside a transaction
+}
+
+func (f *fakeRepo) CreateAttachment(ctx context.Context, attachment *llmModels.Attachment, quotaBytes int64) error {
+	f.outsideTx = f.outsideTx || testmocks.TxDepth(ctx) == 0
+	used, _ := f.GetProjectUsageBytes(ctx, attachment.ProjectID)
+	used += f.racedBytes
+	if quotaBytes > 0 && used+attachment.SizeBytes > quotaBytes {
+		return &llmModels.QuotaExceededError{UsedBytes: used, QuotaBytes: quotaBytes}
+	}
+	attachment.ID = uuid.NewString()
+	attachment.CreatedAt = time.Now()
+	stored := *attachment
+	f.attachments[attachment.ID] = &stored
+	return nil
+}
+
+func (f *fakeRepo) GetAttachment(ctx context.Context, id string) (*llmModels.Attachment, error) {
+	attachment, ok := f.attachments[id]
+	if !ok {
+		return nil, fmt.Errorf("attachment %s: %w", id, domain.ErrNotFound)
+	}
+	copied := *attachment
+	return &copied, nil
+}
+
+func (f *fakeRepo) DeleteAttachment(ctx context.Context, id string) error {
+	if _, ok := f.attachments[id]; !ok {
+		return fmt.Errorf("attachment %s: %w", id, domain.ErrNotFound)
+	}
+	delete(f.attachments, id)
+	return nil
+}
+
+func (f *fakeRepo) GetProjectUsageBytes(ctx context.Context, projectID string) (int64, error) {
+	var total int64
+	for _, attachment := range f.attachments {
+		if attachment.ProjectID == projectID {
+			total += attachment.SizeBytes
+		}
+	}
+	return total, nil
+}
+
+func newTestService(quotaBytes int64) (*Service, *testmocks.ObjectStore, *testmocks.Authorizer) {
+	store := &testmocks.ObjectStore{}
+	authorizer := &testmocks.Authorizer{}
+	svc := &Service{
+		repo:        &fakeRepo{attachments: map[string]*llmModels.Attachment{}},
+		objectStore: store,
+		txManager:   &testmocks.TxManager{},
+		authorizer:  authorizer,
+		urlTTL:      time.Minute,
+		maxBytes:    64,
+		quotaBytes:  quotaBytes,
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	return svc, store, authorizer
+}
+
+func TestUploadAttachment(t *testing.T) {
+	svc, store, _ := newTestService(0)
+	ctx := context.Background()
+
+	attachment, err := svc.UploadAttachment(ctx, "user-1", &llmSvc.UploadAttachmentRequest{
+		ProjectID: "project-1",
+		FileName:  `C:\photos\map.png`,
+		Data:      pngHeader,
+	})
+	if err != nil {
+		t.Fatalf("UploadAttachment: %v", err)
+	}
+	if attachment.ContentType != "image/png" || attachment.FileName != "map.png" || attachment.SizeBytes != int64(len(pngHeader)) {
+		t.Errorf("attachment = %+v", attachment)
+	}
+	if !strings.HasPrefix(attachment.StorageKey, "attachments/project-1/") || !strings.HasSuffix(attachment.StorageKey, ".png") {
+		t.Errorf("storage key = %s", attachment.StorageKey)
+	}
+	if attachment.URL != fmt.Sprintf("signed://%s?ttl=1m0s", attachment.StorageKey) {
+		t.Errorf("url = %s", attachment.URL)
+	}
+	if keys := store.Keys(); len(keys) != 1 || keys[0] != attachment.StorageKey {
+		t.Errorf("stored keys = %v", keys)
+	}
+	if svc.repo.(*fakeRepo).outsideTx {
+		t.Error("attachment created outside a transaction")
+	}
+
+	if err := svc.DeleteAttachment(ctx, "user-1", attachment.ID); err != nil {
+		t.Fatalf("DeleteAttachment: %v", err)
+	}
+	if keys := store.Keys(); len(keys) != 0 {
+		t.Errorf("file not deleted: %v", keys)
+	}
+}
+
+func TestUploadAttachment_Rejects(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []byte
+		quotaBytes int64
+		authErr    error
+		wantErr    error
+	}{
+		{name: "not an image", data: []byte("just some text"), wantErr: domain.ErrValidation},
+		{name: "too large", data: append(append([]byte{}, pngHeader...), make([]byte, 64)...), wantErr: domain.ErrValidation},
+		{name: "over quota", data: pngHeader, quotaBytes: int64(len(pngHeader)) + 1},
+		{name: "other user's project", data: pngHeader, authErr: domain.ErrForbidden, wantErr: domain.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store, authorizer := newTestService(tt.quotaBytes)
+			ctx := context.Background()
+			// The quota case starts with one upload already stored
+			if tt.quotaBytes > 0 {
+				if _, err := svc.UploadAttachment(ctx, "user-1", &llmSvc.UploadAttachmentRequest{ProjectID: "project-1", Data: pngHeader}); err != nil {
+					t.Fatalf("first upload: %v", err)
+				}
+			}
+			authorizer.Err = tt.authErr
+
+			_, err := svc.UploadAttachment(ctx, "user-1", &llmSvc.UploadAttachmentRequest{ProjectID: "project-1", Data: tt.data})
+			var quotaErr *llmModels.QuotaExceededError
+			if tt.wantErr == nil {
+				if !errors.As(err, &quotaErr) || quotaErr.UsedBytes != int64(len(pngHeader)) {
+					t.Fatalf("err = %v, want QuotaExceededError", err)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			wantFiles := 0
+			if tt.quotaBytes > 0 {
+				wantFiles = 1
+			}
+			if got := len(store.Keys()); got != wantFiles {
+				t.Errorf("stored %d files, want %d", got, wantFiles)
+			}
+		})
+	}
+}
+
+func TestUploadAttachment_QuotaRace(t *testing.T) {
+	svc, store, _ := newTestService(int64(len(pngHeader)) + 1)
+	// Another upload is recorded between the usage check and the insert
+	svc.repo.(*fakeRepo).racedBytes = int64(len(pngHeader))
+
+	_, err := svc.UploadAttachment(context.Background(), "user-1", &llmSvc.UploadAttachmentRequest{ProjectID: "project-1", Data: pngHeader})
+	var quotaErr *llmModels.QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("err = %v, want QuotaExceededError", err)
+	}
+	if keys := store.Keys(); len(keys) != 0 {
+		t.Errorf("rejected file left in storage: %v", keys)
+	}
+}
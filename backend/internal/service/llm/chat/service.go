@@ -11,9 +11,11 @@ import (
 
 	"meridian/internal/config"
 	"meridian/internal/domain"
+	docsysModels "meridian/internal/domain/models/docsystem"
 	llmModels "meridian/internal/domain/models/llm"
 	docsysRepo "meridian/internal/domain/repositories/docsystem"
 	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/domain/services"
 	llmSvc "meridian/internal/domain/services/llm"
 )
 
@@ -22,6 +24,7 @@ import (
 type Service struct {
 	chatRepo    llmRepo.ChatRepository
 	projectRepo docsysRepo.ProjectRepository
+	authorizer  services.ResourceAuthorizer // Cached grants are dropped on delete
 	logger      *slog.Logger
 }
 
@@ -29,11 +32,13 @@ type Service struct {
 func NewService(
 	chatRepo llmRepo.ChatRepository,
 	projectRepo docsysRepo.ProjectRepository,
+	authorizer services.ResourceAuthorizer,
 	logger *slog.Logger,
 ) llmSvc.ChatService {
 	return &Service{
 		chatRepo:    chatRepo,
 		projectRepo: projectRepo,
+		authorizer:  authorizer,
 		logger:      logger,
 	}
 }
@@ -87,15 +92,15 @@ func (s *Service) GetChat(ctx context.Context, chatID, userID string) (*llmModel
 	return chat, nil
 }
 
-// ListChats retrieves all chats for a project
-func (s *Service) ListChats(ctx context.Context, projectID, userID string) ([]llmModels.Chat, error) {
+// ListChats retrieves all chats for a project, with the summaries selected in opts (nil = none)
+func (s *Service) ListChats(ctx context.Context, projectID, userID string, opts *llmModels.ChatListOptions) ([]llmModels.Chat, error) {
 	// Verify project exists and user has access
 	_, err := s.projectRepo.GetByID(ctx, projectID, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	chats, err := s.chatRepo.ListChatsByProject(ctx, projectID, userID)
+	chats, err := s.chatRepo.ListChatsByProject(ctx, projectID, userID, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +108,8 @@ func (s *Service) ListChats(ctx context.Context, projectID, userID string) ([]ll
 	return chats, nil
 }
 
-// UpdateChat updates a chat's title
+// UpdateChat partially updates a chat (title, system prompt)
+// Absent fields are left unchanged; system_prompt null clears the chat-level prompt.
 func (s *Service) UpdateChat(ctx context.Context, chatID, userID string, req *llmSvc.UpdateChatRequest) (*llmModels.Chat, error) {
 	// Validate request
 	if err := s.validateUpdateChatRequest(req); err != nil {
@@ -116,11 +122,13 @@ func (s *Service) UpdateChat(ctx context.Context, chatID, userID string, req *ll
 		return nil, err
 	}
 
-	// Trim and normalize title
-	title := strings.TrimSpace(req.Title)
-
-	// Update chat
-	chat.Title = title
+	// Update fields
+	if title, ok := req.Title.Value(); ok {
+		chat.Title = strings.TrimSpace(title)
+	}
+	if req.SystemPrompt.IsSet() {
+		chat.SystemPrompt = req.SystemPrompt.Ptr()
+	}
 	chat.UpdatedAt = time.Now()
 
 	if err := s.chatRepo.UpdateChat(ctx, chat); err != nil {
@@ -130,6 +138,50 @@ func (s *Service) UpdateChat(ctx context.Context, chatID, userID string, req *ll
 	s.logger.Info("chat updated",
 		"id", chat.ID,
 		"title", chat.Title,
+		"system_prompt_set", req.SystemPrompt.IsSet(),
+		"user_id", userID,
+	)
+
+	return chat, nil
+}
+
+// UpdateChatSettings partially updates a chat's settings
+// Absent fields are left unchanged; null clears the system prompt or empties a default list.
+func (s *Service) UpdateChatSettings(ctx context.Context, chatID, userID string, req *llmSvc.UpdateChatSettingsRequest) (*llmModels.Chat, error) {
+	// Validate request
+	if err := s.validateUpdateChatSettingsRequest(req); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrValidation, err)
+	}
+
+	// Get existing chat
+	chat, err := s.chatRepo.GetChat(ctx, chatID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update fields
+	if req.SystemPrompt.IsSet() {
+		chat.SystemPrompt = req.SystemPrompt.Ptr()
+	}
+	if req.DefaultSkills.IsSet() {
+		skills, _ := req.DefaultSkills.Value()
+		chat.DefaultSkills = dedupe(skills)
+	}
+	if req.DefaultTools.IsSet() {
+		tools, _ := req.DefaultTools.Value()
+		chat.DefaultTools = dedupe(tools)
+	}
+	chat.UpdatedAt = time.Now()
+
+	if err := s.chatRepo.UpdateChat(ctx, chat); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("chat settings updated",
+		"id", chat.ID,
+		"system_prompt_set", req.SystemPrompt.IsSet(),
+		"default_skills", chat.DefaultSkills,
+		"default_tools", chat.DefaultTools,
 		"user_id", userID,
 	)
 
@@ -171,6 +223,9 @@ func (s *Service) DeleteChat(ctx context.Context, chatID, userID string) (*llmMo
 		return nil, err
 	}
 
+	// Turns of the chat must stop passing cached authorization checks (path, SSE connect)
+	s.authorizer.InvalidateUser(userID)
+
 	s.logger.Info("chat deleted",
 		"id", chatID,
 		"user_id", userID,
@@ -179,6 +234,23 @@ func (s *Service) DeleteChat(ctx context.Context, chatID, userID string) (*llmMo
 	return deletedChat, nil
 }
 
+// PurgeDeletedChats hard-deletes chats soft-deleted longer than retention ago
+func (s *Service) PurgeDeletedChats(ctx context.Context, retention time.Duration) (int64, error) {
+	purged, err := s.chatRepo.PurgeDeletedChats(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, err
+	}
+
+	if purged > 0 {
+		s.logger.Info("deleted chats purged",
+			"count", purged,
+			"retention", retention,
+		)
+	}
+
+	return purged, nil
+}
+
 // Validation methods
 
 func (s *Service) validateCreateChatRequest(req *llmSvc.CreateChatRequest) error {
@@ -193,10 +265,67 @@ func (s *Service) validateCreateChatRequest(req *llmSvc.CreateChatRequest) error
 }
 
 func (s *Service) validateUpdateChatRequest(req *llmSvc.UpdateChatRequest) error {
-	return validation.ValidateStruct(req,
-		validation.Field(&req.Title,
+	// At least one field must be provided
+	if !req.Title.IsSet() && !req.SystemPrompt.IsSet() {
+		return fmt.Errorf("at least one field must be provided")
+	}
+
+	if req.Title.IsSet() {
+		if req.Title.IsNull() {
+			return fmt.Errorf("title: cannot be null")
+		}
+		title, _ := req.Title.Value()
+		if err := validation.Validate(strings.TrimSpace(title),
 			validation.Required,
 			validation.Length(1, config.MaxChatTitleLength),
-		),
-	)
+		); err != nil {
+			return fmt.Errorf("title: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) validateUpdateChatSettingsRequest(req *llmSvc.UpdateChatSettingsRequest) error {
+	// At least one field must be provided
+	if !req.SystemPrompt.IsSet() && !req.DefaultSkills.IsSet() && !req.DefaultTools.IsSet() {
+		return fmt.Errorf("at least one field must be provided")
+	}
+
+	if skills, ok := req.DefaultSkills.Value(); ok {
+		if len(skills) > config.MaxChatDefaultSkills {
+			return fmt.Errorf("default_skills: at most %d skills allowed", config.MaxChatDefaultSkills)
+		}
+		for _, name := range skills {
+			if !docsysModels.IsValidSkillName(name) {
+				return fmt.Errorf("default_skills: invalid skill name %q", name)
+			}
+		}
+	}
+
+	if tools, ok := req.DefaultTools.Value(); ok {
+		if len(tools) > config.MaxChatDefaultTools {
+			return fmt.Errorf("default_tools: at most %d tools allowed", config.MaxChatDefaultTools)
+		}
+		for _, name := range tools {
+			if !llmModels.IsKnownToolName(name) {
+				return fmt.Errorf("default_tools: unknown tool %q", name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// dedupe removes duplicates, keeping first occurrences in order (never returns nil)
+func dedupe(values []string) []string {
+	result := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
 }
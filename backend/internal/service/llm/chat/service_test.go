@@ -0,0 +1,76 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/testmocks"
+)
+
+// fakePurgeChatRepo deletes any chat and records the purge cutoff; unused interface methods panic
+type fakePurgeChatRepo struct {
+	llmRepo.ChatRepository
+	cutoff time.Time
+	err    error
+}
+
+func (r *fakePurgeChatRepo) DeleteChat(ctx context.Context, chatID, userID string) (*llmModels.Chat, error) {
+	return &llmModels.Chat{ID: chatID, UserID: userID}, nil
+}
+
+func (r *fakePurgeChatRepo) PurgeDeletedChats(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	r.cutoff = deletedBefore
+	if r.err != nil {
+		return 0, r.err
+	}
+	return 2, nil
+}
+
+func TestPurgeDeletedChats(t *testing.T) {
+	repo := &fakePurgeChatRepo{}
+	service := NewService(repo, nil, &testmocks.Authorizer{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	retention := 30 * 24 * time.Hour
+	before := time.Now()
+	purged, err := service.PurgeDeletedChats(context.Background(), retention)
+	after := time.Now()
+	if err != nil {
+		t.Fatalf("PurgeDeletedChats() error = %v", err)
+	}
+	if purged != 2 {
+		t.Errorf("PurgeDeletedChats() = %d, want 2", purged)
+	}
+
+	// Chats deleted more than the retention ago are purged; newer ones are kept
+	if repo.cutoff.Before(before.Add(-retention)) || repo.cutoff.After(after.Add(-retention)) {
+		t.Errorf("cutoff = %v, want now - %v", repo.cutoff, retention)
+	}
+}
+
+func TestPurgeDeletedChats_Error(t *testing.T) {
+	repoErr := errors.New("connection reset")
+	service := NewService(&fakePurgeChatRepo{err: repoErr}, nil, &testmocks.Authorizer{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	purged, err := service.PurgeDeletedChats(context.Background(), time.Hour)
+	if !errors.Is(err, repoErr) || purged != 0 {
+		t.Fatalf("PurgeDeletedChats() = %d, %v; want 0, %v", purged, err, repoErr)
+	}
+}
+
+func TestDeleteChat_InvalidatesCachedGrants(t *testing.T) {
+	authorizer := &testmocks.Authorizer{}
+	service := NewService(&fakePurgeChatRepo{}, nil, authorizer, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if _, err := service.DeleteChat(context.Background(), "chat-1", "user-1"); err != nil {
+		t.Fatalf("DeleteChat() error = %v", err)
+	}
+	if got := authorizer.Invalidated(); len(got) != 1 || got[0] != "user-1" {
+		t.Errorf("invalidated = %v, want [user-1]", got)
+	}
+}
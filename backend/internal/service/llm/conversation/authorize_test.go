@@ -0,0 +1,141 @@
+package conversation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/testmocks"
+)
+
+// fakeSiblingNavigator returns three siblings; unused interface methods panic
+type fakeSiblingNavigator struct{ llmRepo.TurnNavigator }
+
+func (fakeSiblingNavigator) GetTurnSiblings(ctx context.Context, turnID string, opts *llmModels.TurnSiblingsOptions) (*llmModels.TurnSiblings, error) {
+	return &llmModels.TurnSiblings{
+		Turns: []llmModels.Turn{{ID: "turn-a"}, {ID: "turn-b"}, {ID: "turn-c"}},
+		Total: 3,
+	}, nil
+}
+
+func TestGetTurnSiblings_AuthorizesReturnedTurns(t *testing.T) {
+	authorizer := &testmocks.Authorizer{}
+	service := &Service{turnNavigator: fakeSiblingNavigator{}, authorizer: authorizer}
+
+	if _, err := service.GetTurnSiblings(context.Background(), "user-1", "turn-b", &llmModels.TurnSiblingsOptions{}); err != nil {
+		t.Fatalf("GetTurnSiblings() error = %v", err)
+	}
+
+	// The anchor turn first, then every returned sibling in one batch
+	var checked []string
+	for _, check := range authorizer.Checks() {
+		checked = append(checked, check.ResourceID)
+	}
+	want := []string{"turn-b", "turn-a", "turn-b", "turn-c"}
+	if len(checked) != len(want) {
+		t.Fatalf("checked %v, want %v", checked, want)
+	}
+	for i := range want {
+		if checked[i] != want[i] {
+			t.Fatalf("checked %v, want %v", checked, want)
+		}
+	}
+}
+
+func TestGetTurnSiblings_DeniedSibling(t *testing.T) {
+	authorizer := &testmocks.Authorizer{Deny: map[string]error{"turn-c": domain.ErrForbidden}}
+	service := &Service{turnNavigator: fakeSiblingNavigator{}, authorizer: authorizer}
+
+	_, err := service.GetTurnSiblings(context.Background(), "user-1", "turn-b", &llmModels.TurnSiblingsOptions{})
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("GetTurnSiblings() error = %v, want ErrForbidden", err)
+	}
+}
+
+// fakeTurnsReader returns the requested turns with one block each; unused interface methods panic
+type fakeTurnsReader struct {
+	llmRepo.TurnReader
+	requested []string
+}
+
+func (r *fakeTurnsReader) GetTurns(ctx context.Context, turnIDs []string) ([]llmModels.Turn, error) {
+	r.requested = turnIDs
+	turns := make([]llmModels.Turn, len(turnIDs))
+	for i, turnID := range turnIDs {
+		turns[i] = llmModels.Turn{ID: turnID}
+	}
+	return turns, nil
+}
+
+func (r *fakeTurnsReader) GetTurnBlocksForTurns(ctx context.Context, turnIDs []string) (map[string][]llmModels.TurnBlock, error) {
+	return map[string][]llmModels.TurnBlock{turnIDs[0]: {{TurnID: turnIDs[0]}}}, nil
+}
+
+func TestGetTurns(t *testing.T) {
+	tooMany := make([]string, llmModels.MaxTurnsByIDs+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("turn-%d", i)
+	}
+
+	tests := []struct {
+		name          string
+		turnIDs       []string
+		deny          map[string]error
+		wantErr       error
+		wantRequested []string
+	}{
+		{
+			name:          "duplicates dropped, order kept",
+			turnIDs:       []string{"turn-b", "turn-a", "turn-b"},
+			wantRequested: []string{"turn-b", "turn-a"},
+		},
+		{
+			name:    "denied turn",
+			turnIDs: []string{"turn-a", "turn-c"},
+			deny:    map[string]error{"turn-c": domain.ErrForbidden},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name:    "no IDs",
+			wantErr: domain.ErrValidation,
+		},
+		{
+			name:    "too many IDs",
+			turnIDs: tooMany,
+			wantErr: domain.ErrValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &fakeTurnsReader{}
+			service := &Service{turnReader: reader, authorizer: &testmocks.Authorizer{Deny: tt.deny}}
+
+			turns, err := service.GetTurns(context.Background(), "user-1", tt.turnIDs, true)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetTurns() error = %v, want %v", err, tt.wantErr)
+				}
+				if reader.requested != nil {
+					t.Errorf("turns were loaded before authorization failed: %v", reader.requested)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetTurns() error = %v", err)
+			}
+			if !reflect.DeepEqual(reader.requested, tt.wantRequested) {
+				t.Errorf("requested %v, want %v", reader.requested, tt.wantRequested)
+			}
+			// Turns without blocks get an empty slice
+			if len(turns[0].Blocks) != 1 || turns[1].Blocks == nil || len(turns[1].Blocks) != 0 {
+				t.Errorf("blocks = %v, %v; want one block, then none", turns[0].Blocks, turns[1].Blocks)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package conversation
+
+import (
+	"context"
+
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+// signImageURLs replaces the URL of every image block kept in object storage with a
+// freshly signed one. The URL persisted with the block was signed when the image was
+// created and has expired by the time an old conversation is reopened.
+func (s *Service) signImageURLs(ctx context.Context, turns []llmModels.Turn) {
+	for i := range turns {
+		s.signBlockImageURLs(ctx, turns[i].Blocks)
+	}
+}
+
+// signBlockImageURLs signs the image blocks of one turn. Blocks that can't be signed keep
+// their stored URL; the client shows a broken image rather than failing the whole request.
+func (s *Service) signBlockImageURLs(ctx context.Context, blocks []llmModels.TurnBlock) {
+	if s.objectStore == nil {
+		return
+	}
+
+	for i := range blocks {
+		block := &blocks[i]
+		if block.BlockType != llmModels.BlockTypeImage || block.Content == nil {
+			continue
+		}
+
+		var image llmModels.ImageContent
+		if err := llmModels.DecodeContent(block, &image); err != nil || image.StorageKey == "" {
+			continue
+		}
+		url, err := s.objectStore.SignedURL(ctx, image.StorageKey, s.imageURLTTL)
+		if err != nil {
+			continue
+		}
+		image.URL = url
+
+		encoded, err := llmModels.EncodeContent(&image)
+		if err != nil {
+			continue
+		}
+		block.Content = encoded
+	}
+}
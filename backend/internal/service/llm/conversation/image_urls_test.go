@@ -0,0 +1,40 @@
+package conversation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	llmModels "meridian/internal/domain/models/llm"
+	"meridian/internal/testmocks"
+)
+
+func TestSignImageURLs(t *testing.T) {
+	service := &Service{objectStore: &testmocks.ObjectStore{}, imageURLTTL: time.Hour}
+
+	stored, err := llmModels.EncodeContent(&llmModels.ImageContent{
+		URL:        "https://expired.example/old",
+		MIMEType:   "image/png",
+		StorageKey: "images/project-1/a.png",
+	})
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	external := map[string]interface{}{"url": "https://example.com/cat.png", "mime_type": "image/png"}
+
+	turns := []llmModels.Turn{{
+		ID: "turn-1",
+		Blocks: []llmModels.TurnBlock{
+			{BlockType: llmModels.BlockTypeImage, Content: stored},
+			{BlockType: llmModels.BlockTypeImage, Content: external},
+		},
+	}}
+	service.signImageURLs(context.Background(), turns)
+
+	if got := turns[0].Blocks[0].Content["url"]; got != "signed://images/project-1/a.png?ttl=1h0m0s" {
+		t.Errorf("stored image url = %v", got)
+	}
+	if got := turns[0].Blocks[1].Content["url"]; got != "https://example.com/cat.png" {
+		t.Errorf("image without storage key should keep its url, got %v", got)
+	}
+}
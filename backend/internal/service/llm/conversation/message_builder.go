@@ -6,28 +6,36 @@ import (
 	"log/slog"
 
 	"meridian/internal/capabilities"
+	"meridian/internal/domain/services"
 	domainllm "meridian/internal/domain/services/llm"
 	llmModels "meridian/internal/domain/models/llm"
 	"meridian/internal/service/llm/formatting"
+	"meridian/internal/service/llm/sanitize"
 )
 
 // MessageBuilderService converts conversation history (database turns) to LLM messages.
 // This is a pure conversion service - data loading happens in the caller.
 type MessageBuilderService struct {
 	formatterRegistry  *formatting.FormatterRegistry
-	capabilityRegistry *capabilities.Registry
+	guard              *sanitize.Guard // Prompt-injection guard for tool results (nil = off)
+	capabilityRegistry capabilities.Lookup
+	objectStore        services.ObjectStore // Loads attached images to inline (nil = images described in text)
 	logger             *slog.Logger
 }
 
 // NewMessageBuilderService creates a new MessageBuilderService
 func NewMessageBuilderService(
 	formatterRegistry *formatting.FormatterRegistry,
-	capabilityRegistry *capabilities.Registry,
+	guard *sanitize.Guard,
+	capabilityRegistry capabilities.Lookup,
+	objectStore services.ObjectStore,
 	logger *slog.Logger,
 ) *MessageBuilderService {
 	return &MessageBuilderService{
 		formatterRegistry:  formatterRegistry,
+		guard:              guard,
 		capabilityRegistry: capabilityRegistry,
+		objectStore:        objectStore,
 		logger:             logger,
 	}
 }
@@ -35,11 +43,14 @@ func NewMessageBuilderService(
 // BuildMessages converts a turn path (with blocks already loaded) to LLM messages
 // suitable for provider requests. The path should be ordered from oldest to newest.
 // The caller must load turn blocks before calling this method.
+// User images are inlined when the target model accepts them (see prepareImageBlock).
 func (mb *MessageBuilderService) BuildMessages(
 	ctx context.Context,
 	path []llmModels.Turn,
+	target domainllm.MessageTarget,
 ) ([]domainllm.Message, error) {
 	messages := make([]domainllm.Message, 0, len(path))
+	inlineImages := mb.acceptsImages(target)
 
 	for _, turn := range path {
 		// Determine role
@@ -72,9 +83,13 @@ func (mb *MessageBuilderService) BuildMessages(
 		// Convert []TurnBlock to []*TurnBlock
 		contentPtrs := make([]*llmModels.TurnBlock, len(validBlocks))
 		for i := range validBlocks {
-			// Apply formatting to tool results if needed
+			// Apply formatting and the injection guard to tool results if needed
 			if validBlocks[i].BlockType == llmModels.BlockTypeToolResult {
 				mb.formatToolResultBlock(&validBlocks[i])
+				mb.guardToolResultBlock(&validBlocks[i])
+			}
+			if validBlocks[i].BlockType == llmModels.BlockTypeImage {
+				mb.prepareImageBlock(ctx, &validBlocks[i], inlineImages)
 			}
 			contentPtrs[i] = &validBlocks[i]
 		}
@@ -96,36 +111,64 @@ func (mb *MessageBuilderService) BuildMessages(
 }
 
 // formatToolResultBlock applies tool-specific formatting to a tool_result block's result field.
-// This modifies the block in-place by replacing Content["result"] with the formatted version.
+// This replaces the block's content with a copy holding the formatted result.
 // Formatting happens on message build (not at storage time), so we keep full data in DB.
 func (mb *MessageBuilderService) formatToolResultBlock(block *llmModels.TurnBlock) {
 	// Defensive: ensure formatter registry and block content exist
-	if mb.formatterRegistry == nil {
+	if mb.formatterRegistry == nil || block.Content == nil {
 		return
 	}
-	if block.Content == nil {
+
+	var content llmModels.ToolResultContent
+	if err := llmModels.DecodeContent(block, &content); err != nil {
+		mb.logger.Warn("skipping formatting of invalid tool_result block", "block_id", block.ID, "error", err)
+		return
+	}
+	if content.ToolName == "" || content.Result == nil {
+		// No tool name or no result (error results) - nothing to format
 		return
 	}
 
-	// Extract tool_name from block content
-	toolName, ok := block.Content["tool_name"].(string)
-	if !ok || toolName == "" {
-		// No tool name - can't format
+	content.Result = mb.formatterRegistry.Format(content.ToolName, content.Result)
+	mb.replaceToolResultContent(block, &content)
+}
+
+// guardToolResultBlock strips injection patterns from a tool_result block's result and wraps
+// it in a delimited section with an instruction header. Results are already stripped when
+// persisted (see StreamExecutor.persistAndStreamToolResult); stripping again here covers
+// blocks persisted before the guard existed or with a lower strictness.
+// Runs after formatting so formatters see the original structure.
+func (mb *MessageBuilderService) guardToolResultBlock(block *llmModels.TurnBlock) {
+	if !mb.guard.Enabled() || block.Content == nil {
 		return
 	}
 
-	// Extract result from block content
-	result, ok := block.Content["result"]
-	if !ok {
-		// No result to format (might be error or already formatted)
+	var content llmModels.ToolResultContent
+	if err := llmModels.DecodeContent(block, &content); err != nil || content.Result == nil {
 		return
 	}
 
-	// Apply formatting
-	formattedResult := mb.formatterRegistry.Format(toolName, result)
+	sanitized, report := mb.guard.Sanitize(content.Result)
+	if report.Sanitized() {
+		mb.logger.Warn("stripped prompt injection patterns from stored tool result",
+			"tool_name", content.ToolName,
+			"block_id", block.ID,
+			"matches", report.Matches,
+		)
+	}
 
-	// Replace result with formatted version in-place
-	block.Content["result"] = formattedResult
+	content.Result = mb.guard.Wrap(content.ToolName, sanitized)
+	mb.replaceToolResultContent(block, &content)
+}
+
+// replaceToolResultContent stores re-encoded tool_result content on the block
+func (mb *MessageBuilderService) replaceToolResultContent(block *llmModels.TurnBlock, content *llmModels.ToolResultContent) {
+	encoded, err := llmModels.EncodeContent(content)
+	if err != nil {
+		mb.logger.Warn("failed to encode tool_result content", "block_id", block.ID, "error", err)
+		return
+	}
+	block.Content = encoded
 }
 
 // injectTokenLimitWarningIfNeeded checks if the last assistant turn is approaching the token limit
@@ -164,10 +207,8 @@ func (mb *MessageBuilderService) injectTokenLimitWarningIfNeeded(path []llmModel
 
 	// Determine provider
 	provider := "anthropic" // default
-	if lastAssistantTurn.RequestParams != nil {
-		if providerParam, ok := lastAssistantTurn.RequestParams["provider"].(string); ok && providerParam != "" {
-			provider = providerParam
-		}
+	if providerParam := lastAssistantTurn.RequestParams.GetProvider(); providerParam != "" {
+		provider = providerParam
 	}
 
 	// Get model capability from registry
@@ -219,6 +260,11 @@ func (mb *MessageBuilderService) sanitizeTurnBlocks(turn llmModels.Turn) []llmMo
 	var validBlocks []llmModels.TurnBlock
 
 	for i, block := range turn.Blocks {
+		// Generated images are display-only; the model learned about them from the tool_result
+		if turn.Role == "assistant" && block.BlockType == llmModels.BlockTypeImage {
+			continue
+		}
+
 		if block.BlockType == llmModels.BlockTypeToolUse {
 			// Check if there is a subsequent tool_result block in this turn
 			hasResult := false
@@ -2,13 +2,16 @@ package conversation
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"os"
 	"testing"
 
 	"meridian/internal/capabilities"
 	llmModels "meridian/internal/domain/models/llm"
+	domainllm "meridian/internal/domain/services/llm"
 	"meridian/internal/service/llm/formatting"
+	"meridian/internal/testmocks"
 )
 
 // TestBuildMessages_NormalConversation tests naive message building with a normal conversation
@@ -20,7 +23,7 @@ func TestBuildMessages_NormalConversation(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create capability registry: %v", err)
 	}
-	service := NewMessageBuilderService(formatterRegistry, capabilityRegistry, logger)
+	service := NewMessageBuilderService(formatterRegistry, nil, capabilityRegistry, nil, logger)
 
 	// Create conversation path: user turn, assistant turn
 	path := []llmModels.Turn{
@@ -57,7 +60,7 @@ func TestBuildMessages_NormalConversation(t *testing.T) {
 	}
 
 	// Build messages
-	messages, err := service.BuildMessages(context.Background(), path)
+	messages, err := service.BuildMessages(context.Background(), path, domainllm.MessageTarget{})
 	if err != nil {
 		t.Fatalf("BuildMessages failed: %v", err)
 	}
@@ -93,7 +96,7 @@ func TestBuildMessages_ToolContinuation(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create capability registry: %v", err)
 	}
-	service := NewMessageBuilderService(formatterRegistry, capabilityRegistry, logger)
+	service := NewMessageBuilderService(formatterRegistry, nil, capabilityRegistry, nil, logger)
 
 	// Create assistant turn with tool_use + tool_result blocks from multiple rounds
 	// This simulates what happens after tool continuation:
@@ -167,7 +170,7 @@ func TestBuildMessages_ToolContinuation(t *testing.T) {
 	}
 
 	// Build messages
-	messages, err := service.BuildMessages(context.Background(), path)
+	messages, err := service.BuildMessages(context.Background(), path, domainllm.MessageTarget{})
 	if err != nil {
 		t.Fatalf("BuildMessages failed: %v", err)
 	}
@@ -221,7 +224,7 @@ func TestBuildMessages_EmptyTurn(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create capability registry: %v", err)
 	}
-	service := NewMessageBuilderService(formatterRegistry, capabilityRegistry, logger)
+	service := NewMessageBuilderService(formatterRegistry, nil, capabilityRegistry, nil, logger)
 
 	// Create path with empty turn
 	path := []llmModels.Turn{
@@ -245,7 +248,7 @@ func TestBuildMessages_EmptyTurn(t *testing.T) {
 	}
 
 	// Build messages
-	messages, err := service.BuildMessages(context.Background(), path)
+	messages, err := service.BuildMessages(context.Background(), path, domainllm.MessageTarget{})
 	if err != nil {
 		t.Fatalf("BuildMessages failed: %v", err)
 	}
@@ -270,7 +273,7 @@ func TestBuildMessages_UnsupportedRole(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create capability registry: %v", err)
 	}
-	service := NewMessageBuilderService(formatterRegistry, capabilityRegistry, logger)
+	service := NewMessageBuilderService(formatterRegistry, nil, capabilityRegistry, nil, logger)
 
 	// Create path with unsupported role
 	path := []llmModels.Turn{
@@ -289,7 +292,7 @@ func TestBuildMessages_UnsupportedRole(t *testing.T) {
 	}
 
 	// Build messages
-	_, err = service.BuildMessages(context.Background(), path)
+	_, err = service.BuildMessages(context.Background(), path, domainllm.MessageTarget{})
 	if err == nil {
 		t.Fatal("expected error for unsupported role, got nil")
 	}
@@ -304,7 +307,7 @@ func TestBuildMessages_ToolResultFormatting(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create capability registry: %v", err)
 	}
-	service := NewMessageBuilderService(formatterRegistry, capabilityRegistry, logger)
+	service := NewMessageBuilderService(formatterRegistry, nil, capabilityRegistry, nil, logger)
 
 	// Create path with tool_result block
 	path := []llmModels.Turn{
@@ -328,7 +331,7 @@ func TestBuildMessages_ToolResultFormatting(t *testing.T) {
 	}
 
 	// Build messages
-	messages, err := service.BuildMessages(context.Background(), path)
+	messages, err := service.BuildMessages(context.Background(), path, domainllm.MessageTarget{})
 	if err != nil {
 		t.Fatalf("BuildMessages failed: %v", err)
 	}
@@ -348,3 +351,93 @@ func TestBuildMessages_ToolResultFormatting(t *testing.T) {
 		t.Errorf("expected block type to be tool_result, got %s", messages[0].Content[0].BlockType)
 	}
 }
+
+// TestBuildMessages_SkipsGeneratedImages tests that image blocks on assistant turns are not replayed
+func TestBuildMessages_SkipsGeneratedImages(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	capabilityRegistry, err := capabilities.NewRegistry()
+	if err != nil {
+		t.Fatalf("Failed to create capability registry: %v", err)
+	}
+	service := NewMessageBuilderService(formatting.NewFormatterRegistry(), nil, capabilityRegistry, nil, logger)
+
+	text := "Here is the lighthouse."
+	path := []llmModels.Turn{
+		{
+			ID:   "turn-1",
+			Role: "assistant",
+			Blocks: []llmModels.TurnBlock{
+				{BlockType: llmModels.BlockTypeText, TextContent: &text},
+				{BlockType: llmModels.BlockTypeImage, Content: map[string]interface{}{"url": "signed://images/p/1.png", "mime_type": "image/png"}},
+			},
+		},
+	}
+
+	messages, err := service.BuildMessages(context.Background(), path, domainllm.MessageTarget{})
+	if err != nil {
+		t.Fatalf("BuildMessages failed: %v", err)
+	}
+	if len(messages) != 1 || len(messages[0].Content) != 1 || messages[0].Content[0].BlockType != llmModels.BlockTypeText {
+		t.Fatalf("expected only the text block, got %+v", messages)
+	}
+}
+
+// TestBuildMessages_UserImages tests that stored user images are inlined for vision models
+// and described in text for other models
+func TestBuildMessages_UserImages(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	lookup := testmocks.NewCapabilityLookup("openai",
+		capabilities.ModelCapabilities{ID: "gpt-vision", SupportsVision: true},
+		capabilities.ModelCapabilities{ID: "gpt-text"},
+	)
+	store := &testmocks.ObjectStore{}
+	if err := store.Put(context.Background(), "attachments/p/1.png", "image/png", []byte("png")); err != nil {
+		t.Fatal(err)
+	}
+	service := NewMessageBuilderService(formatting.NewFormatterRegistry(), nil, lookup, store, logger)
+
+	altText := "map of the city"
+	path := []llmModels.Turn{{
+		ID:   "turn-1",
+		Role: "user",
+		Blocks: []llmModels.TurnBlock{{
+			BlockType: llmModels.BlockTypeImage,
+			Content: map[string]interface{}{
+				"url": "signed://attachments/p/1.png", "mime_type": "image/png", "alt_text": altText,
+				"storage_key": "attachments/p/1.png", "attachment_id": "a-1",
+			},
+		}},
+	}}
+
+	tests := []struct {
+		name     string
+		target   domainllm.MessageTarget
+		wantURL  string
+		wantText string
+	}{
+		{name: "vision model", target: domainllm.MessageTarget{Provider: "openai", Model: "gpt-vision"}, wantURL: "data:image/png;base64,cG5n"},
+		{name: "text model", target: domainllm.MessageTarget{Provider: "openai", Model: "gpt-text"}, wantText: "[The user attached an image (map of the city), which can't be shown to you]"},
+		{name: "provider without images", target: domainllm.MessageTarget{Provider: "anthropic", Model: "gpt-vision"}, wantText: "[The user attached an image (map of the city), which can't be shown to you]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages, err := service.BuildMessages(context.Background(), path, tt.target)
+			if err != nil {
+				t.Fatalf("BuildMessages failed: %v", err)
+			}
+			block := messages[0].Content[0]
+			if tt.wantURL != "" {
+				if block.BlockType != llmModels.BlockTypeImage || block.Content["url"] != tt.wantURL || block.Content["alt_text"] != altText {
+					t.Errorf("inlined block = %s %+v", block.BlockType, block.Content)
+				}
+			} else if block.BlockType != llmModels.BlockTypeText || block.TextContent == nil || *block.TextContent != tt.wantText {
+				t.Errorf("described block = %s %v", block.BlockType, block.TextContent)
+			}
+		})
+	}
+
+	// The stored blocks are left as they were
+	if path[0].Blocks[0].BlockType != llmModels.BlockTypeImage || path[0].Blocks[0].Content["url"] != "signed://attachments/p/1.png" {
+		t.Errorf("stored block modified: %+v", path[0].Blocks[0])
+	}
+}
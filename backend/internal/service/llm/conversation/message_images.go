@@ -0,0 +1,71 @@
+package conversation
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	llmModels "meridian/internal/domain/models/llm"
+	domainllm "meridian/internal/domain/services/llm"
+)
+
+// acceptsImages reports whether images can be sent to the target: its provider sends
+// image blocks and the model supports vision
+func (mb *MessageBuilderService) acceptsImages(target domainllm.MessageTarget) bool {
+	if !llmModels.ProviderSendsImages(target.Provider) || mb.objectStore == nil || mb.capabilityRegistry == nil {
+		return false
+	}
+	caps, err := mb.capabilityRegistry.GetModelCapabilities(target.Provider, target.Model)
+	return err == nil && caps.SupportsVision
+}
+
+// prepareImageBlock prepares a user image for the provider. Images kept in object
+// storage are inlined as a base64 data URL, since providers can't fetch signed URLs of
+// the local backend and links expire. Images that can't be sent (the model has no
+// vision, or the file is gone) become a text note, so the model knows one was attached.
+// The block gets new content; stored content is not modified.
+func (mb *MessageBuilderService) prepareImageBlock(ctx context.Context, block *llmModels.TurnBlock, inline bool) {
+	var image llmModels.ImageContent
+	if err := llmModels.DecodeContent(block, &image); err != nil {
+		mb.logger.Warn("describing invalid image block as text", "block_id", block.ID, "error", err)
+		describeImage(block, nil)
+		return
+	}
+
+	if inline && image.StorageKey != "" {
+		object, err := mb.objectStore.Get(ctx, image.StorageKey)
+		if err == nil {
+			mimeType := image.MIMEType
+			if mimeType == "" {
+				mimeType = object.ContentType
+			}
+			content := map[string]interface{}{
+				"url":       fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(object.Data)),
+				"mime_type": mimeType,
+			}
+			if image.AltText != nil {
+				content["alt_text"] = *image.AltText
+			}
+			block.Content = content
+			return
+		}
+		mb.logger.Warn("failed to load image for the model; describing it as text",
+			"block_id", block.ID,
+			"storage_key", image.StorageKey,
+			"error", err,
+		)
+	}
+
+	describeImage(block, image.AltText)
+}
+
+// describeImage turns an image block into a text block noting the image
+func describeImage(block *llmModels.TurnBlock, altText *string) {
+	text := "[The user attached an image, which can't be shown to you]"
+	if altText != nil && *altText != "" {
+		text = fmt.Sprintf("[The user attached an image (%s), which can't be shown to you]", *altText)
+	}
+	block.BlockType = llmModels.BlockTypeText
+	block.TextContent = &text
+	block.Content = nil
+}
@@ -0,0 +1,68 @@
+package conversation
+
+import (
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+// defaultProvider is assumed for turns whose request params don't record a provider
+// (turns created before the provider was persisted)
+const defaultProvider = "anthropic"
+
+// turnProvider returns the provider that served a turn
+func turnProvider(turn *llmModels.Turn) string {
+	if provider := turn.RequestParams.GetProvider(); provider != "" {
+		return provider
+	}
+	return defaultProvider
+}
+
+// enrichModelInfo attaches display metadata from the capability registry to every turn
+// that has a model. Registry lookups are memoized per call since a conversation usually
+// uses one or two models across many turns.
+func (s *Service) enrichModelInfo(turns []llmModels.Turn) {
+	cache := make(map[[2]string]*llmModels.TurnModelInfo)
+
+	for i := range turns {
+		turn := &turns[i]
+		if turn.Model == nil || *turn.Model == "" {
+			continue
+		}
+
+		key := [2]string{turnProvider(turn), *turn.Model}
+		info, ok := cache[key]
+		if !ok {
+			info = s.lookupModelInfo(key[0], key[1])
+			cache[key] = info
+		}
+		turn.ModelInfo = info
+	}
+}
+
+// lookupModelInfo builds display metadata for a provider/model pair
+// Unknown models fall back to the raw model ID so the UI always has something to show
+func (s *Service) lookupModelInfo(provider, model string) *llmModels.TurnModelInfo {
+	info := &llmModels.TurnModelInfo{
+		Provider:    provider,
+		DisplayName: model,
+	}
+
+	if s.capabilityRegistry == nil {
+		return info
+	}
+
+	modelCap, err := s.capabilityRegistry.GetModelCapabilities(provider, model)
+	if err != nil {
+		return info
+	}
+
+	info.Known = true
+	if modelCap.DisplayName != "" {
+		info.DisplayName = modelCap.DisplayName
+	}
+	info.ContextWindow = modelCap.ContextWindow
+	info.SupportsTools = modelCap.SupportsTools
+	info.SupportsThinking = modelCap.SupportsThinking
+	info.SupportsVision = modelCap.SupportsVision
+
+	return info
+}
@@ -0,0 +1,48 @@
+package conversation
+
+import (
+	"testing"
+
+	"meridian/internal/capabilities"
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+func TestEnrichModelInfo(t *testing.T) {
+	capabilityRegistry, err := capabilities.NewRegistry()
+	if err != nil {
+		t.Fatalf("Failed to create capability registry: %v", err)
+	}
+	service := &Service{capabilityRegistry: capabilityRegistry}
+
+	knownModel := "claude-haiku-4-5"
+	unknownModel := "retired-model-1"
+	openrouter := "openrouter"
+
+	turns := []llmModels.Turn{
+		{ID: "user-turn", Role: "user"},
+		{ID: "known", Role: "assistant", Model: &knownModel},
+		{ID: "unknown", Role: "assistant", Model: &unknownModel, RequestParams: &llmModels.RequestParams{Provider: &openrouter}},
+	}
+
+	service.enrichModelInfo(turns)
+
+	if turns[0].ModelInfo != nil {
+		t.Errorf("user turn without model should not get model info, got %+v", turns[0].ModelInfo)
+	}
+
+	known := turns[1].ModelInfo
+	if known == nil || !known.Known {
+		t.Fatalf("expected known model info, got %+v", known)
+	}
+	if known.Provider != "anthropic" || known.DisplayName != "Claude Haiku 4.5" || !known.SupportsTools {
+		t.Errorf("unexpected model info for %s: %+v", knownModel, known)
+	}
+
+	unknown := turns[2].ModelInfo
+	if unknown == nil || unknown.Known {
+		t.Fatalf("expected unknown model info, got %+v", unknown)
+	}
+	if unknown.Provider != "openrouter" || unknown.DisplayName != unknownModel {
+		t.Errorf("unknown model should fall back to raw ID, got %+v", unknown)
+	}
+}
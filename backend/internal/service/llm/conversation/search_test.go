@@ -0,0 +1,122 @@
+package conversation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"meridian/internal/domain"
+	docsysModels "meridian/internal/domain/models/docsystem"
+	llmModels "meridian/internal/domain/models/llm"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/testmocks"
+)
+
+// Unused interface methods panic
+type (
+	fakeSearchChatRepo   struct{ llmRepo.ChatRepository }
+	fakeSearchTurnReader struct {
+		llmRepo.TurnReader
+		chatSearches *[]llmModels.ChatSearchOptions
+	}
+	fakeSearchSettingsRepo struct {
+		docsysRepo.ProjectSettingsRepository
+	}
+)
+
+func (fakeSearchChatRepo) GetChat(ctx context.Context, chatID, userID string) (*llmModels.Chat, error) {
+	return &llmModels.Chat{ID: chatID}, nil
+}
+
+func (fakeSearchTurnReader) SearchTurns(ctx context.Context, opts *llmModels.TurnSearchOptions) ([]llmModels.TurnSearchMatch, bool, error) {
+	return nil, false, nil
+}
+
+func (f fakeSearchTurnReader) SearchChats(ctx context.Context, opts *llmModels.ChatSearchOptions) ([]llmModels.ChatSearchMatch, bool, error) {
+	*f.chatSearches = append(*f.chatSearches, *opts)
+	return []llmModels.ChatSearchMatch{{ChatID: "chat-1", TurnID: "turn-1"}}, false, nil
+}
+
+func (fakeSearchSettingsRepo) Get(ctx context.Context, projectID, userID string) (*docsysModels.ProjectSettings, error) {
+	return &docsysModels.ProjectSettings{ProjectID: projectID, SearchLanguage: "german"}, nil
+}
+
+func (fakeSearchSettingsRepo) SearchLanguageExists(ctx context.Context, language string) (bool, error) {
+	return language == "english" || language == "french", nil
+}
+
+func TestSearchTurns_Language(t *testing.T) {
+	service := &Service{
+		chatRepo:     fakeSearchChatRepo{},
+		turnReader:   fakeSearchTurnReader{},
+		settingsRepo: fakeSearchSettingsRepo{},
+	}
+
+	tests := []struct {
+		name     string
+		language string
+		wantErr  error
+	}{
+		{name: "default", language: ""},
+		{name: "installed configuration", language: "french"},
+		{name: "unknown configuration", language: "klingon", wantErr: domain.ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &llmModels.TurnSearchOptions{Query: "dragon", Language: tt.language}
+			_, err := service.SearchTurns(context.Background(), "chat-1", "user-1", opts, nil)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("SearchTurns() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SearchTurns() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSearchChats(t *testing.T) {
+	var searches []llmModels.ChatSearchOptions
+	service := &Service{
+		turnReader:   fakeSearchTurnReader{chatSearches: &searches},
+		settingsRepo: fakeSearchSettingsRepo{},
+		authorizer:   &testmocks.Authorizer{},
+	}
+
+	tests := []struct {
+		name         string
+		opts         llmModels.ChatSearchOptions
+		wantLanguage string
+		wantErr      error
+	}{
+		{name: "project language", opts: llmModels.ChatSearchOptions{ProjectID: "project-1", Query: "dragon"}, wantLanguage: "german"},
+		{name: "override", opts: llmModels.ChatSearchOptions{ProjectID: "project-1", Query: "dragon", Language: "french"}, wantLanguage: "french"},
+		{name: "unknown configuration", opts: llmModels.ChatSearchOptions{ProjectID: "project-1", Query: "dragon", Language: "klingon"}, wantErr: domain.ErrValidation},
+		{name: "no project", opts: llmModels.ChatSearchOptions{Query: "dragon"}, wantErr: domain.ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searches = nil
+			opts := tt.opts
+			results, err := service.SearchChats(context.Background(), "user-1", &opts)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || len(searches) != 0 {
+					t.Fatalf("SearchChats() error = %v, searches = %d, want %v and no search", err, len(searches), tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SearchChats() error = %v", err)
+			}
+			if len(searches) != 1 || searches[0].UserID != "user-1" || searches[0].Language != tt.wantLanguage {
+				t.Errorf("searches = %+v, want one by user-1 in %s", searches, tt.wantLanguage)
+			}
+			if results.Language != tt.wantLanguage || len(results.Matches) != 1 {
+				t.Errorf("results = %+v", results)
+			}
+		})
+	}
+}
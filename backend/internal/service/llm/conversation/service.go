@@ -3,9 +3,12 @@ package conversation
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"meridian/internal/capabilities"
+	"meridian/internal/domain"
 	llmModels "meridian/internal/domain/models/llm"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
 	llmRepo "meridian/internal/domain/repositories/llm"
 	"meridian/internal/domain/services"
 	llmSvc "meridian/internal/domain/services/llm"
@@ -18,8 +21,12 @@ type Service struct {
 	chatRepo           llmRepo.ChatRepository
 	turnReader         llmRepo.TurnReader
 	turnNavigator      llmRepo.TurnNavigator
-	capabilityRegistry *capabilities.Registry
+	turnWriter         llmRepo.TurnWriter                   // Annotations only
+	settingsRepo       docsysRepo.ProjectSettingsRepository // Validates search languages
+	capabilityRegistry capabilities.Lookup
 	authorizer         services.ResourceAuthorizer
+	objectStore        services.ObjectStore // Signs URLs of stored images (nil = leave URLs as stored)
+	imageURLTTL        time.Duration
 }
 
 // NewService creates a new conversation service
@@ -27,41 +34,57 @@ func NewService(
 	chatRepo llmRepo.ChatRepository,
 	turnReader llmRepo.TurnReader,
 	turnNavigator llmRepo.TurnNavigator,
-	capabilityRegistry *capabilities.Registry,
+	turnWriter llmRepo.TurnWriter,
+	settingsRepo docsysRepo.ProjectSettingsRepository,
+	capabilityRegistry capabilities.Lookup,
 	authorizer services.ResourceAuthorizer,
+	objectStore services.ObjectStore,
+	imageURLTTL time.Duration,
 ) llmSvc.ConversationService {
 	return &Service{
 		chatRepo:           chatRepo,
 		turnReader:         turnReader,
 		turnNavigator:      turnNavigator,
+		turnWriter:         turnWriter,
+		settingsRepo:       settingsRepo,
 		capabilityRegistry: capabilityRegistry,
 		authorizer:         authorizer,
+		objectStore:        objectStore,
+		imageURLTTL:        imageURLTTL,
 	}
 }
 
 // GetTurnPath retrieves the conversation path from a turn to root
-// Authorization is checked first via the injected authorizer
-func (s *Service) GetTurnPath(ctx context.Context, userID, turnID string) ([]llmModels.Turn, error) {
+// (or its last opts.Last turns), with blocks unless opts.IncludeBlocks is false
+// Authorization is checked first via the injected authorizer, then for every returned turn
+func (s *Service) GetTurnPath(ctx context.Context, userID, turnID string, opts *llmModels.TurnPathOptions) ([]llmModels.Turn, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: invalid path options: %v", domain.ErrValidation, err)
+	}
+
 	// Authorize: check user can access this turn
 	if err := s.authorizer.CanAccessTurn(ctx, userID, turnID); err != nil {
 		return nil, err
 	}
 
-	turns, err := s.turnNavigator.GetTurnPath(ctx, turnID)
+	var turns []llmModels.Turn
+	var err error
+	if opts.Last > 0 {
+		turns, err = s.turnNavigator.GetTurnPathWindow(ctx, turnID, opts.Last)
+	} else {
+		turns, err = s.turnNavigator.GetTurnPath(ctx, turnID)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if err := s.authorizeTurns(ctx, userID, turnIDsOf(turns)); err != nil {
+		return nil, err
+	}
 
 	// Batch load content blocks for all turns (eliminates N+1 query)
-	if len(turns) > 0 {
-		// Extract turn IDs
-		turnIDs := make([]string, len(turns))
-		for i, turn := range turns {
-			turnIDs[i] = turn.ID
-		}
-
+	if opts.IncludeBlocks && len(turns) > 0 {
 		// Load blocks for all turns in a single query
-		blocksByTurn, err := s.turnReader.GetTurnBlocksForTurns(ctx, turnIDs)
+		blocksByTurn, err := s.turnReader.GetTurnBlocksForTurns(ctx, turnIDsOf(turns))
 		if err != nil {
 			return nil, err
 		}
@@ -77,30 +100,65 @@ func (s *Service) GetTurnPath(ctx context.Context, userID, turnID string) ([]llm
 		}
 	}
 
+	s.enrichModelInfo(turns)
+	s.signImageURLs(ctx, turns)
+
 	return turns, nil
 }
 
-// GetTurnSiblings retrieves all sibling turns (including self) with blocks
-// Authorization is checked first via the injected authorizer
-func (s *Service) GetTurnSiblings(ctx context.Context, userID, turnID string) ([]llmModels.Turn, error) {
+// GetTurnSiblings retrieves a page of sibling turns (including self), optionally with blocks
+// Authorization is checked first via the injected authorizer, then for every returned turn
+func (s *Service) GetTurnSiblings(ctx context.Context, userID, turnID string, opts *llmModels.TurnSiblingsOptions) (*llmModels.TurnSiblings, error) {
 	// Authorize: check user can access this turn
 	if err := s.authorizer.CanAccessTurn(ctx, userID, turnID); err != nil {
 		return nil, err
 	}
 
-	return s.turnNavigator.GetTurnSiblings(ctx, turnID)
+	siblings, err := s.turnNavigator.GetTurnSiblings(ctx, turnID, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeTurns(ctx, userID, turnIDsOf(siblings.Turns)); err != nil {
+		return nil, err
+	}
+
+	s.enrichModelInfo(siblings.Turns)
+	s.signImageURLs(ctx, siblings.Turns)
+
+	return siblings, nil
 }
 
 // GetChatTree retrieves the lightweight tree structure for cache validation
+// The repository scopes the chat to the user; the turns are batch-authorized too so
+// the stream connects that follow a tree refresh hit the authorizer's cache
 func (s *Service) GetChatTree(ctx context.Context, chatID, userID string) (*llmModels.ChatTree, error) {
 	tree, err := s.chatRepo.GetChatTree(ctx, chatID, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	turnIDs := make([]string, len(tree.Turns))
+	for i, node := range tree.Turns {
+		turnIDs[i] = node.ID
+	}
+	if err := s.authorizeTurns(ctx, userID, turnIDs); err != nil {
+		return nil, err
+	}
+
 	return tree, nil
 }
 
+// GetTurnTree retrieves one page of the chat's turn tree as skeletons
+// The repository scopes the chat to the user
+func (s *Service) GetTurnTree(ctx context.Context, chatID, userID string, opts *llmModels.TurnTreeOptions) (*llmModels.TurnTreePage, error) {
+	opts.ApplyDefaults()
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: invalid tree options: %v", domain.ErrValidation, err)
+	}
+
+	return s.chatRepo.GetTurnTree(ctx, chatID, userID, opts)
+}
+
 // GetPaginatedTurns retrieves turns and blocks in paginated fashion
 func (s *Service) GetPaginatedTurns(ctx context.Context, chatID, userID string, fromTurnID *string, limit int, direction string, updateLastViewed bool) (*llmModels.PaginatedTurnsResponse, error) {
 	// Delegate to repository (validation happens there)
@@ -109,6 +167,10 @@ func (s *Service) GetPaginatedTurns(ctx context.Context, chatID, userID string,
 		return nil, err
 	}
 
+	// Join model display metadata so the UI doesn't need its own model catalog
+	s.enrichModelInfo(response.Turns)
+	s.signImageURLs(ctx, response.Turns)
+
 	return response, nil
 }
 
@@ -134,10 +196,52 @@ func (s *Service) GetTurnWithBlocks(ctx context.Context, userID, turnID string)
 
 	// Attach blocks to turn
 	turn.Blocks = blocks
+	s.signBlockImageURLs(ctx, turn.Blocks)
 
 	return turn, nil
 }
 
+// GetTurns retrieves specific turns by ID, optionally with blocks
+// All turns are authorized in one batch before anything is loaded
+func (s *Service) GetTurns(ctx context.Context, userID string, turnIDs []string, includeBlocks bool) ([]llmModels.Turn, error) {
+	turnIDs = uniqueTurnIDs(turnIDs)
+	if len(turnIDs) == 0 {
+		return nil, fmt.Errorf("%w: at least one turn ID is required", domain.ErrValidation)
+	}
+	if len(turnIDs) > llmModels.MaxTurnsByIDs {
+		return nil, fmt.Errorf("%w: cannot request more than %d turns (requested: %d)", domain.ErrValidation, llmModels.MaxTurnsByIDs, len(turnIDs))
+	}
+
+	// Authorize: every turn (missing turns are ErrNotFound, other users' ErrForbidden)
+	if err := s.authorizer.CanAccessTurns(ctx, userID, turnIDs); err != nil {
+		return nil, err
+	}
+
+	turns, err := s.turnReader.GetTurns(ctx, turnIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if includeBlocks && len(turns) > 0 {
+		blocksByTurn, err := s.turnReader.GetTurnBlocksForTurns(ctx, turnIDsOf(turns))
+		if err != nil {
+			return nil, err
+		}
+		for i := range turns {
+			if blocks, ok := blocksByTurn[turns[i].ID]; ok {
+				turns[i].Blocks = blocks
+			} else {
+				turns[i].Blocks = []llmModels.TurnBlock{}
+			}
+		}
+	}
+
+	s.enrichModelInfo(turns)
+	s.signImageURLs(ctx, turns)
+
+	return turns, nil
+}
+
 // GetTurnTokenUsage retrieves token usage statistics for a turn
 // Authorization is checked first via the injected authorizer
 func (s *Service) GetTurnTokenUsage(ctx context.Context, userID, turnID string) (*llmModels.TokenUsageInfo, error) {
@@ -154,10 +258,11 @@ func (s *Service) GetTurnTokenUsage(ctx context.Context, userID, turnID string)
 
 	// Initialize response
 	info := &llmModels.TokenUsageInfo{
-		TurnID:       turnID,
-		InputTokens:  turn.InputTokens,
-		OutputTokens: turn.OutputTokens,
-		Model:        turn.Model,
+		TurnID:             turnID,
+		InputTokens:        turn.InputTokens,
+		OutputTokens:       turn.OutputTokens,
+		Model:              turn.Model,
+		SystemPromptTokens: turn.SystemPromptTokens,
 	}
 
 	// Calculate total tokens if both are available
@@ -171,13 +276,8 @@ func (s *Service) GetTurnTokenUsage(ctx context.Context, userID, turnID string)
 		return info, nil
 	}
 
-	// Determine provider from request params or infer from model
-	provider := "anthropic" // default
-	if turn.RequestParams != nil {
-		if providerParam, ok := turn.RequestParams["provider"].(string); ok && providerParam != "" {
-			provider = providerParam
-		}
-	}
+	// Determine provider from request params (default: anthropic)
+	provider := turnProvider(turn)
 	info.ProviderName = &provider
 
 	// Get model capability from registry
@@ -191,6 +291,12 @@ func (s *Service) GetTurnTokenUsage(ctx context.Context, userID, turnID string)
 	contextLimit := modelCap.ContextWindow
 	info.ContextLimit = &contextLimit
 
+	// System prompt share (estimated at turn creation)
+	if turn.SystemPromptTokens != nil && contextLimit > 0 {
+		systemPercent := (float64(*turn.SystemPromptTokens) / float64(contextLimit)) * 100
+		info.SystemPromptPercent = &systemPercent
+	}
+
 	// Calculate usage percentage if we have total tokens
 	if info.TotalTokens != nil && contextLimit > 0 {
 		percent := (float64(*info.TotalTokens) / float64(contextLimit)) * 100
@@ -210,3 +316,148 @@ func (s *Service) GetTurnTokenUsage(ctx context.Context, userID, turnID string)
 
 	return info, nil
 }
+
+// SearchTurns performs full-text search over a chat's text blocks and flags matches
+// that lie on the currently viewed path (root → viewed turn)
+// Authorization: GetChat only returns chats owned by the user
+func (s *Service) SearchTurns(ctx context.Context, chatID, userID string, opts *llmModels.TurnSearchOptions, fromTurnID *string) (*llmModels.TurnSearchResults, error) {
+	chat, err := s.chatRepo.GetChat(ctx, chatID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// An unknown text search configuration would fail the query itself
+	if opts.Language != "" {
+		exists, err := s.settingsRepo.SearchLanguageExists(ctx, opts.Language)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: language: unsupported language %q", domain.ErrValidation, opts.Language)
+		}
+	}
+
+	opts.ChatID = chat.ID
+	matches, hasMore, err := s.turnReader.SearchTurns(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	viewedTurnID := chat.LastViewedTurnID
+	if fromTurnID != nil && *fromTurnID != "" {
+		viewedTurnID = fromTurnID
+	}
+
+	// Flag matches on the viewed path (skip the path query when nothing matched)
+	if viewedTurnID != nil && len(matches) > 0 {
+		path, err := s.turnNavigator.GetTurnPath(ctx, *viewedTurnID)
+		if err != nil {
+			return nil, err
+		}
+		if len(path) == 0 || path[len(path)-1].ChatID != chat.ID {
+			return nil, fmt.Errorf("%w: turn %s does not belong to chat %s", domain.ErrValidation, *viewedTurnID, chat.ID)
+		}
+
+		onPath := make(map[string]bool, len(path))
+		for _, turn := range path {
+			onPath[turn.ID] = true
+		}
+		for i := range matches {
+			matches[i].OnCurrentPath = onPath[matches[i].TurnID]
+		}
+	}
+
+	return &llmModels.TurnSearchResults{
+		Matches:      matches,
+		HasMore:      hasMore,
+		ViewedTurnID: viewedTurnID,
+	}, nil
+}
+
+// SearchChats performs full-text search over the text blocks of the user's chats in a
+// project. Without a language override, the project's search language is used, which is
+// the one its turn blocks are indexed with.
+func (s *Service) SearchChats(ctx context.Context, userID string, opts *llmModels.ChatSearchOptions) (*llmModels.ChatSearchResults, error) {
+	if opts.ProjectID == "" {
+		return nil, fmt.Errorf("%w: project_id is required", domain.ErrValidation)
+	}
+	if err := s.authorizer.CanAccessProject(ctx, userID, opts.ProjectID); err != nil {
+		return nil, err
+	}
+
+	if opts.Language == "" {
+		settings, err := s.settingsRepo.Get(ctx, opts.ProjectID, userID)
+		if err != nil {
+			return nil, err
+		}
+		opts.Language = settings.SearchLanguage
+	} else {
+		// An unknown text search configuration would fail the query itself
+		exists, err := s.settingsRepo.SearchLanguageExists(ctx, opts.Language)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: language: unsupported language %q", domain.ErrValidation, opts.Language)
+		}
+	}
+
+	opts.UserID = userID
+	matches, hasMore, err := s.turnReader.SearchChats(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &llmModels.ChatSearchResults{
+		Matches:  matches,
+		HasMore:  hasMore,
+		Language: opts.Language,
+	}, nil
+}
+
+// UpdateTurnAnnotations partially updates a turn's client annotations
+func (s *Service) UpdateTurnAnnotations(ctx context.Context, userID, turnID string, update *llmModels.TurnAnnotationsUpdate) (*llmModels.TurnAnnotations, error) {
+	if err := update.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: invalid annotations: %v", domain.ErrValidation, err)
+	}
+
+	// Authorize: check user can access this turn
+	if err := s.authorizer.CanAccessTurn(ctx, userID, turnID); err != nil {
+		return nil, err
+	}
+
+	return s.turnWriter.UpdateTurnAnnotations(ctx, turnID, update)
+}
+
+// authorizeTurns checks every turn a list endpoint returns with one batched query.
+// The anchor turn was already authorized, so this rarely denies; its point is that
+// the grants are cached, so switching to a listed turn or connecting to its stream
+// skips the database.
+func (s *Service) authorizeTurns(ctx context.Context, userID string, turnIDs []string) error {
+	if len(turnIDs) == 0 {
+		return nil
+	}
+	return s.authorizer.CanAccessTurns(ctx, userID, turnIDs)
+}
+
+// uniqueTurnIDs returns turnIDs without duplicates, keeping the first occurrence
+func uniqueTurnIDs(turnIDs []string) []string {
+	seen := make(map[string]bool, len(turnIDs))
+	unique := make([]string, 0, len(turnIDs))
+	for _, turnID := range turnIDs {
+		if !seen[turnID] {
+			seen[turnID] = true
+			unique = append(unique, turnID)
+		}
+	}
+	return unique
+}
+
+// turnIDsOf returns the IDs of turns, in order
+func turnIDsOf(turns []llmModels.Turn) []string {
+	turnIDs := make([]string, len(turns))
+	for i, turn := range turns {
+		turnIDs[i] = turn.ID
+	}
+	return turnIDs
+}
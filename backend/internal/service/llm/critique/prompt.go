@@ -0,0 +1,59 @@
+package critique
+
+import (
+	"strings"
+
+	llmModels "meridian/internal/domain/models/llm"
+	"meridian/internal/utils"
+)
+
+// DefaultRubric is used when neither the project nor the request sets one
+const DefaultRubric = `Review the response as an experienced editor would:
+1. Does it do what the writer asked? Note anything missed or misunderstood.
+2. Accuracy and consistency: contradictions, continuity errors, unsupported claims.
+3. Structure and pacing: what drags, what is rushed, what is out of order.
+4. Voice and style: clichés, repetition, tone that doesn't fit.
+Quote the passages you refer to and end with the two or three changes that would help most.`
+
+// maxExcerptChars bounds the request and the response quoted to the critic
+const maxExcerptChars = 60000
+
+// systemPrompt tells the critic its role and rubric
+func systemPrompt(rubric string) string {
+	return "You are a critic reviewing another assistant's response for a writer. " +
+		"Assess the response; do not rewrite it. Be specific and brief.\n\nRubric:\n" + rubric
+}
+
+// reviewMessage quotes the writer's request and the response under review
+func reviewMessage(request, response string) string {
+	var b strings.Builder
+	if request != "" {
+		b.WriteString("<request>\n" + utils.TruncateWithEllipsis(request, maxExcerptChars) + "\n</request>\n\n")
+	}
+	b.WriteString("<response>\n" + utils.TruncateWithEllipsis(response, maxExcerptChars) + "\n</response>")
+	return b.String()
+}
+
+// blocksText joins the text blocks of a turn (thinking, tool calls and results are left out)
+func blocksText(blocks []llmModels.TurnBlock) string {
+	var parts []string
+	for _, block := range blocks {
+		if block.BlockType == llmModels.BlockTypeText && block.TextContent != nil {
+			if text := strings.TrimSpace(*block.TextContent); text != "" {
+				parts = append(parts, text)
+			}
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// responseText joins the text blocks of a provider response
+func responseText(blocks []*llmModels.TurnBlock) string {
+	var parts []string
+	for _, block := range blocks {
+		if block != nil && block.BlockType == llmModels.BlockTypeText && block.TextContent != nil {
+			parts = append(parts, *block.TextContent)
+		}
+	}
+	return strings.TrimSpace(strings.Join(parts, ""))
+}
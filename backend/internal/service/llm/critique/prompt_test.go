@@ -0,0 +1,32 @@
+package critique
+
+import (
+	"strings"
+	"testing"
+
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+func TestBlocksText(t *testing.T) {
+	text1, text2, thinking := " First. ", "Second.", "hidden"
+	blocks := []llmModels.TurnBlock{
+		{BlockType: llmModels.BlockTypeText, TextContent: &text1},
+		{BlockType: llmModels.BlockTypeThinking, TextContent: &thinking},
+		{BlockType: llmModels.BlockTypeText, TextContent: &text2},
+	}
+	if got := blocksText(blocks); got != "First.\n\nSecond." {
+		t.Errorf("blocksText = %q", got)
+	}
+}
+
+func TestReviewMessage(t *testing.T) {
+	got := reviewMessage("", "Answer")
+	if strings.Contains(got, "<request>") || !strings.Contains(got, "<response>\nAnswer\n</response>") {
+		t.Errorf("reviewMessage without request = %q", got)
+	}
+
+	long := strings.Repeat("x", maxExcerptChars+100)
+	if got := reviewMessage(long, "Answer"); len(got) > 2*maxExcerptChars {
+		t.Errorf("request not truncated: %d chars", len(got))
+	}
+}
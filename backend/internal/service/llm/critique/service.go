@@ -0,0 +1,303 @@
+// Package critique implements the critic pass: after an assistant turn completes, a
+// second model reviews it against a rubric and the review is stored on the turn.
+package critique
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+	"unicode/utf8"
+
+	"meridian/internal/config"
+	"meridian/internal/domain"
+	docsysModels "meridian/internal/domain/models/docsystem"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/domain/services"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/errreport"
+	"meridian/internal/logging"
+)
+
+const (
+	// critiqueTimeout bounds one review; a pending critique older than this is stale
+	critiqueTimeout = 3 * time.Minute
+
+	// critiqueMaxTokens caps the length of a review
+	critiqueMaxTokens = 2048
+)
+
+// ProviderGetter returns provider adapters by provider name (e.g. "anthropic", "openrouter")
+type ProviderGetter interface {
+	GetProvider(provider string) (llmSvc.LLMProvider, error)
+}
+
+// Service implements CritiqueService and TurnCritic
+type Service struct {
+	critiqueRepo   llmRepo.CritiqueRepository
+	turnReader     llmRepo.TurnReader
+	providerGetter ProviderGetter
+	authorizer     services.ResourceAuthorizer
+	defaultModel   string                    // Critic model when the project sets none; empty = the turn's model
+	dataPolicy     llmSvc.ProviderDataPolicy // Users' per-provider data controls (nil = not enforced)
+	logger         *slog.Logger
+}
+
+var (
+	_ llmSvc.CritiqueService = (*Service)(nil)
+	_ llmSvc.TurnCritic      = (*Service)(nil)
+)
+
+// NewService creates a new critique service
+func NewService(
+	critiqueRepo llmRepo.CritiqueRepository,
+	turnReader llmRepo.TurnReader,
+	providerGetter ProviderGetter,
+	authorizer services.ResourceAuthorizer,
+	defaultModel string,
+	dataPolicy llmSvc.ProviderDataPolicy,
+	logger *slog.Logger,
+) *Service {
+	return &Service{
+		critiqueRepo:   critiqueRepo,
+		turnReader:     turnReader,
+		providerGetter: providerGetter,
+		authorizer:     authorizer,
+		defaultModel:   defaultModel,
+		dataPolicy:     dataPolicy,
+		logger:         logger,
+	}
+}
+
+// CritiqueTurn starts a review of an assistant turn on request
+func (s *Service) CritiqueTurn(ctx context.Context, userID, turnID string, req *llmSvc.CritiqueTurnRequest) (*llmModels.TurnCritique, error) {
+	if req.Rubric != nil && utf8.RuneCountInString(*req.Rubric) > config.MaxCritiqueRubricLength {
+		return nil, fmt.Errorf("%w: rubric: at most %d characters", domain.ErrValidation, config.MaxCritiqueRubricLength)
+	}
+	if err := s.authorizer.CanAccessTurn(ctx, userID, turnID); err != nil {
+		return nil, err
+	}
+
+	turn, err := s.turnReader.GetTurn(ctx, turnID)
+	if err != nil {
+		return nil, err
+	}
+	if turn.Role != "assistant" || turn.Status != "complete" {
+		return nil, fmt.Errorf("%w: only completed assistant turns can be critiqued", domain.ErrValidation)
+	}
+
+	existing, err := s.critiqueRepo.GetCritique(ctx, turnID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, err
+	}
+	if existing != nil && existing.Status == llmModels.CritiqueStatusPending && time.Since(existing.CreatedAt) < critiqueTimeout {
+		return nil, fmt.Errorf("%w: a critique of this turn is already running", domain.ErrConflict)
+	}
+
+	settings := docsysModels.ProjectCritique{Model: req.Model, Rubric: req.Rubric}
+	if err := s.checkProvider(ctx, userID, turn, settings); err != nil {
+		return nil, err
+	}
+
+	critique, request, err := s.prepare(ctx, turn, settings)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, fmt.Errorf("%w: turn has no text to critique", domain.ErrValidation)
+	}
+
+	pending := *critique
+	go s.run(logging.Detach(ctx), critique, turn, request)
+	return &pending, nil
+}
+
+// GetCritique returns a turn's critique
+func (s *Service) GetCritique(ctx context.Context, userID, turnID string) (*llmModels.TurnCritique, error) {
+	if err := s.authorizer.CanAccessTurn(ctx, userID, turnID); err != nil {
+		return nil, err
+	}
+	return s.critiqueRepo.GetCritique(ctx, turnID)
+}
+
+// CritiqueCompletedTurn reviews a turn that just completed streaming
+func (s *Service) CritiqueCompletedTurn(ctx context.Context, userID, turnID string, settings docsysModels.ProjectCritique) {
+	logger := logging.FromContext(ctx, s.logger)
+
+	turn, err := s.turnReader.GetTurn(ctx, turnID)
+	if err != nil {
+		logger.Error("failed to load turn for critique", "error", err)
+		return
+	}
+	if err := s.checkProvider(ctx, userID, turn, settings); err != nil {
+		logger.Info("skipping critique", "reason", err)
+		return
+	}
+
+	critique, request, err := s.prepare(ctx, turn, settings)
+	if err != nil {
+		logger.Error("failed to prepare critique", "error", err)
+		return
+	}
+	if request == nil {
+		logger.Debug("skipping critique of turn without text")
+		return
+	}
+
+	s.run(ctx, critique, turn, request)
+}
+
+// checkProvider applies the user's data controls to the critic model's provider
+func (s *Service) checkProvider(ctx context.Context, userID string, turn *llmModels.Turn, settings docsysModels.ProjectCritique) error {
+	if s.dataPolicy == nil {
+		return nil
+	}
+	return s.dataPolicy.CheckProvider(ctx, userID, s.provider(turn, s.model(turn, settings)))
+}
+
+// prepare loads the turn's text and its request and saves a pending critique.
+// Returns a nil request (and saves nothing) when the turn has no text.
+func (s *Service) prepare(ctx context.Context, turn *llmModels.Turn, settings docsysModels.ProjectCritique) (*llmModels.TurnCritique, *llmSvc.GenerateRequest, error) {
+	blocks, err := s.turnReader.GetTurnBlocks(ctx, turn.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	response := blocksText(blocks)
+	if response == "" {
+		return &llmModels.TurnCritique{TurnID: turn.ID}, nil, nil
+	}
+
+	var request string
+	if turn.PrevTurnID != nil {
+		prevBlocks, err := s.turnReader.GetTurnBlocks(ctx, *turn.PrevTurnID)
+		if err != nil {
+			return nil, nil, err
+		}
+		request = blocksText(prevBlocks)
+	}
+
+	rubric := DefaultRubric
+	if settings.Rubric != nil && *settings.Rubric != "" {
+		rubric = *settings.Rubric
+	}
+	model := s.model(turn, settings)
+
+	critique := &llmModels.TurnCritique{
+		TurnID: turn.ID,
+		Model:  model,
+		Rubric: rubric,
+		Status: llmModels.CritiqueStatusPending,
+	}
+	if err := s.critiqueRepo.SaveCritique(ctx, critique); err != nil {
+		return nil, nil, err
+	}
+
+	system := systemPrompt(rubric)
+	maxTokens := critiqueMaxTokens
+	generateReq := &llmSvc.GenerateRequest{
+		Model: model,
+		Messages: []llmSvc.Message{{
+			Role:    "user",
+			Content: []*llmModels.TurnBlock{{BlockType: llmModels.BlockTypeText, TextContent: ptr(reviewMessage(request, response))}},
+		}},
+		Params: &llmModels.RequestParams{Model: &model, System: &system, MaxTokens: &maxTokens},
+	}
+	return critique, generateReq, nil
+}
+
+// run asks the critic model for the review and stores the result
+func (s *Service) run(ctx context.Context, critique *llmModels.TurnCritique, turn *llmModels.Turn, req *llmSvc.GenerateRequest) {
+	logger := logging.FromContext(logging.WithTurnID(ctx, turn.ID), s.logger)
+
+	// Runs in its own goroutine for manual critiques: record panics instead of crashing
+	defer func() {
+		if rec := recover(); rec != nil {
+			logger.Error("panic while critiquing turn", "error", rec, "stack", string(debug.Stack()))
+			errreport.CapturePanic(ctx, rec)
+			s.fail(ctx, critique, "internal error while critiquing turn", logger)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, critiqueTimeout)
+	defer cancel()
+
+	provider, err := s.providerGetter.GetProvider(s.provider(turn, critique.Model))
+	if err != nil {
+		s.fail(ctx, critique, fmt.Sprintf("critic model unavailable: %v", err), logger)
+		return
+	}
+
+	start := time.Now()
+	resp, err := provider.GenerateResponse(ctx, req)
+	if err != nil {
+		s.fail(ctx, critique, fmt.Sprintf("critic request failed: %v", err), logger)
+		return
+	}
+
+	now := time.Now()
+	critique.Status = llmModels.CritiqueStatusComplete
+	critique.Content = responseText(resp.Content)
+	critique.InputTokens = &resp.InputTokens
+	critique.OutputTokens = &resp.OutputTokens
+	critique.CompletedAt = &now
+	if err := s.critiqueRepo.SaveCritique(ctx, critique); err != nil {
+		logger.Error("failed to save critique", "error", err)
+		return
+	}
+
+	logger.Info("turn critiqued",
+		"model", critique.Model,
+		"input_tokens", resp.InputTokens,
+		"output_tokens", resp.OutputTokens,
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+}
+
+// fail records a failed review
+func (s *Service) fail(ctx context.Context, critique *llmModels.TurnCritique, message string, logger *slog.Logger) {
+	logger.Warn("turn critique failed", "model", critique.Model, "error", message)
+
+	now := time.Now()
+	critique.Status = llmModels.CritiqueStatusError
+	critique.Error = &message
+	critique.CompletedAt = &now
+	// The request context may have timed out; recording the failure must not
+	if err := s.critiqueRepo.SaveCritique(context.WithoutCancel(ctx), critique); err != nil {
+		logger.Error("failed to save critique error", "error", err)
+	}
+}
+
+// model picks the critic model: settings, then server default, then the turn's own model
+func (s *Service) model(turn *llmModels.Turn, settings docsysModels.ProjectCritique) string {
+	if settings.Model != nil && *settings.Model != "" {
+		return *settings.Model
+	}
+	if s.defaultModel != "" {
+		return s.defaultModel
+	}
+	if turn.Model != nil {
+		return *turn.Model
+	}
+	return ""
+}
+
+// provider resolves the provider for the critic model the same way turns do: the turn's
+// provider when the critic uses the turn's model, else the model mapping, else openrouter
+func (s *Service) provider(turn *llmModels.Turn, model string) string {
+	if turn.Model != nil && *turn.Model == model {
+		if provider := turn.RequestParams.GetProvider(); provider != "" {
+			return provider
+		}
+	}
+	if provider, found := llmModels.GetProviderForModel(model); found {
+		return provider
+	}
+	return "openrouter"
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,195 @@
+package critique
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"meridian/internal/domain"
+	docsysModels "meridian/internal/domain/models/docsystem"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/testmocks"
+)
+
+// fakeTurnReader serves turns by ID; other TurnReader methods are unused
+type fakeTurnReader struct {
+	llmRepo.TurnReader
+	turns  map[string]llmModels.Turn
+	blocks map[string][]llmModels.TurnBlock
+}
+
+func (f *fakeTurnReader) GetTurn(ctx context.Context, turnID string) (*llmModels.Turn, error) {
+	turn, ok := f.turns[turnID]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &turn, nil
+}
+
+func (f *fakeTurnReader) GetTurnBlocks(ctx context.Context, turnID string) ([]llmModels.TurnBlock, error) {
+	return f.blocks[turnID], nil
+}
+
+// fakeCritiqueRepo keeps the latest saved critique per turn
+type fakeCritiqueRepo struct {
+	saved map[string]llmModels.TurnCritique
+}
+
+func (f *fakeCritiqueRepo) SaveCritique(ctx context.Context, critique *llmModels.TurnCritique) error {
+	if critique.Status == llmModels.CritiqueStatusPending {
+		critique.CreatedAt = time.Now()
+	}
+	f.saved[critique.TurnID] = *critique
+	return nil
+}
+
+func (f *fakeCritiqueRepo) GetCritique(ctx context.Context, turnID string) (*llmModels.TurnCritique, error) {
+	critique, ok := f.saved[turnID]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &critique, nil
+}
+
+// fakeProvider answers every request with a fixed review; other methods are unused
+type fakeProvider struct {
+	llmSvc.LLMProvider
+	requests []*llmSvc.GenerateRequest
+	err      error
+}
+
+func (f *fakeProvider) GenerateResponse(ctx context.Context, req *llmSvc.GenerateRequest) (*llmSvc.GenerateResponse, error) {
+	f.requests = append(f.requests, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	review := "Tighten the opening."
+	return &llmSvc.GenerateResponse{
+		Content:      []*llmModels.TurnBlock{{BlockType: llmModels.BlockTypeText, TextContent: &review}},
+		InputTokens:  100,
+		OutputTokens: 10,
+	}, nil
+}
+
+type fakeProviderGetter struct {
+	provider *fakeProvider
+	names    []string
+}
+
+func (f *fakeProviderGetter) GetProvider(name string) (llmSvc.LLMProvider, error) {
+	f.names = append(f.names, name)
+	return f.provider, nil
+}
+
+func newTestService(responseText string) (*Service, *fakeCritiqueRepo, *fakeProviderGetter) {
+	request := "Write the opening scene."
+	prevID := "turn-user"
+	model := "claude-haiku-4-5"
+	reader := &fakeTurnReader{
+		turns: map[string]llmModels.Turn{
+			"turn-user": {ID: "turn-user", Role: "user", Status: "complete"},
+			"turn-1":    {ID: "turn-1", Role: "assistant", Status: "complete", PrevTurnID: &prevID, Model: &model},
+		},
+		blocks: map[string][]llmModels.TurnBlock{
+			"turn-user": {{BlockType: llmModels.BlockTypeText, TextContent: &request}},
+			"turn-1":    {{BlockType: llmModels.BlockTypeText, TextContent: &responseText}},
+		},
+	}
+	repo := &fakeCritiqueRepo{saved: map[string]llmModels.TurnCritique{}}
+	providers := &fakeProviderGetter{provider: &fakeProvider{}}
+	svc := NewService(repo, reader, providers, &testmocks.Authorizer{}, "", nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return svc, repo, providers
+}
+
+func TestCritiqueCompletedTurn_SavesReview(t *testing.T) {
+	svc, repo, providers := newTestService("The harbor was quiet.")
+	rubric := "Check the pacing."
+
+	svc.CritiqueCompletedTurn(context.Background(), "user-1", "turn-1", docsysModels.ProjectCritique{Enabled: true, Rubric: &rubric})
+
+	got := repo.saved["turn-1"]
+	if got.Status != llmModels.CritiqueStatusComplete || got.Content != "Tighten the opening." {
+		t.Fatalf("critique = %+v", got)
+	}
+	if got.Model != "claude-haiku-4-5" || got.Rubric != rubric {
+		t.Errorf("model/rubric = %q/%q, want the turn's model and the project rubric", got.Model, got.Rubric)
+	}
+	if got.InputTokens == nil || *got.InputTokens != 100 || got.CompletedAt == nil {
+		t.Errorf("usage not recorded: %+v", got)
+	}
+	if len(providers.names) != 1 || providers.names[0] != "anthropic" {
+		t.Errorf("providers = %v, want [anthropic]", providers.names)
+	}
+
+	req := providers.provider.requests[0]
+	message := *req.Messages[0].Content[0].TextContent
+	if !strings.Contains(message, "Write the opening scene.") || !strings.Contains(message, "The harbor was quiet.") {
+		t.Errorf("review message missing request or response:\n%s", message)
+	}
+	if !strings.Contains(*req.Params.System, rubric) {
+		t.Errorf("system prompt missing rubric: %s", *req.Params.System)
+	}
+}
+
+func TestCritiqueCompletedTurn_RecordsFailure(t *testing.T) {
+	svc, repo, providers := newTestService("The harbor was quiet.")
+	providers.provider.err = errors.New("rate limited")
+
+	svc.CritiqueCompletedTurn(context.Background(), "user-1", "turn-1", docsysModels.ProjectCritique{Enabled: true})
+
+	got := repo.saved["turn-1"]
+	if got.Status != llmModels.CritiqueStatusError || got.Error == nil || !strings.Contains(*got.Error, "rate limited") {
+		t.Fatalf("critique = %+v, want error status", got)
+	}
+	if got.Rubric != DefaultRubric {
+		t.Errorf("rubric = %q, want default", got.Rubric)
+	}
+}
+
+func TestCritiqueCompletedTurn_SkipsTurnWithoutText(t *testing.T) {
+	svc, repo, providers := newTestService("   ")
+
+	svc.CritiqueCompletedTurn(context.Background(), "user-1", "turn-1", docsysModels.ProjectCritique{Enabled: true})
+
+	if len(repo.saved) != 0 || len(providers.names) != 0 {
+		t.Errorf("expected no critique, saved %v", repo.saved)
+	}
+}
+
+func TestCritiqueTurn_Validation(t *testing.T) {
+	svc, repo, _ := newTestService("The harbor was quiet.")
+	ctx := context.Background()
+
+	if _, err := svc.CritiqueTurn(ctx, "user-1", "turn-user", &llmSvc.CritiqueTurnRequest{}); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("user turn: err = %v, want ErrValidation", err)
+	}
+
+	repo.saved["turn-1"] = llmModels.TurnCritique{TurnID: "turn-1", Status: llmModels.CritiqueStatusPending, CreatedAt: time.Now()}
+	if _, err := svc.CritiqueTurn(ctx, "user-1", "turn-1", &llmSvc.CritiqueTurnRequest{}); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("running critique: err = %v, want ErrConflict", err)
+	}
+
+	svc.authorizer = &testmocks.Authorizer{Deny: map[string]error{"turn-1": domain.ErrForbidden}}
+	if _, err := svc.CritiqueTurn(ctx, "user-1", "turn-1", &llmSvc.CritiqueTurnRequest{}); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("denied: err = %v, want ErrForbidden", err)
+	}
+}
+
+func TestCritiqueTurn_ReturnsPending(t *testing.T) {
+	svc, _, _ := newTestService("The harbor was quiet.")
+	model := "gpt-4o"
+
+	got, err := svc.CritiqueTurn(context.Background(), "user-1", "turn-1", &llmSvc.CritiqueTurnRequest{Model: &model})
+	if err != nil {
+		t.Fatalf("CritiqueTurn: %v", err)
+	}
+	if got.Status != llmModels.CritiqueStatusPending || got.Model != model {
+		t.Errorf("critique = %+v, want pending with requested model", got)
+	}
+}
@@ -0,0 +1,87 @@
+// Package datacontrols enforces users' per-provider data controls: providers a user
+// blocked in their preferences (data_controls.blocked_providers) never receive their
+// content, and every provider integration discloses what it transmits.
+package datacontrols
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"meridian/internal/domain"
+	"meridian/internal/domain/models"
+	llmModels "meridian/internal/domain/models/llm"
+	"meridian/internal/domain/services"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+// Service implements the DataControlsService interface
+type Service struct {
+	preferences services.UserPreferencesService
+}
+
+// NewService creates a data controls service reading the users' preferences
+func NewService(preferences services.UserPreferencesService) llmSvc.DataControlsService {
+	return &Service{preferences: preferences}
+}
+
+// CheckProvider fails closed: if the user's preferences can't be read, nothing is sent
+func (s *Service) CheckProvider(ctx context.Context, userID, provider string) error {
+	dataControls, err := s.load(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("check data controls: %w", err)
+	}
+	if !dataControls.BlocksProvider(provider) {
+		return nil
+	}
+
+	name := provider
+	for _, d := range llmModels.ProviderDisclosures() {
+		if d.Provider == provider {
+			name = d.Name
+		}
+	}
+	return fmt.Errorf("%w: your data controls don't allow sending content to %s; choose a model from another provider or remove %q from data_controls.blocked_providers in your preferences",
+		domain.ErrForbidden, name, provider)
+}
+
+// GetDataControls returns the user's data controls with the provider disclosures
+func (s *Service) GetDataControls(ctx context.Context, userID string) (*llmModels.DataControlsStatus, error) {
+	dataControls, err := s.load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := llmModels.ProviderDisclosures()
+	for i := range providers {
+		providers[i].Allowed = !dataControls.BlocksProvider(providers[i].Provider)
+	}
+
+	version := dataControls.PolicyAcknowledgedVersion
+	return &llmModels.DataControlsStatus{
+		PolicyVersion:             llmModels.ContentPolicyVersion,
+		PolicyAcknowledged:        version != nil && *version == llmModels.ContentPolicyVersion,
+		PolicyAcknowledgedVersion: version,
+		PolicyAcknowledgedAt:      dataControls.PolicyAcknowledgedAt,
+		BlockedProviders:          dataControls.BlockedProviders,
+		Providers:                 providers,
+	}, nil
+}
+
+// load reads the data_controls namespace of the user's preferences
+func (s *Service) load(ctx context.Context, userID string) (*models.DataControlsPreferences, error) {
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid user ID", domain.ErrValidation)
+	}
+	prefs, err := s.preferences.GetPreferences(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	dataControls, err := prefs.GetDataControls()
+	if err != nil {
+		return nil, fmt.Errorf("decode data controls: %w", err)
+	}
+	return dataControls, nil
+}
@@ -0,0 +1,76 @@
+package datacontrols
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"meridian/internal/domain"
+	"meridian/internal/domain/models"
+	"meridian/internal/domain/services"
+)
+
+type fakePreferences struct {
+	services.UserPreferencesService
+	prefs *models.UserPreferences
+	err   error
+}
+
+func (f *fakePreferences) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.UserPreferences, error) {
+	return f.prefs, f.err
+}
+
+func newTestService(blocked ...interface{}) *Service {
+	prefs := &models.UserPreferences{Preferences: models.JSONMap{
+		"data_controls": map[string]interface{}{"blocked_providers": blocked},
+	}}
+	return &Service{preferences: &fakePreferences{prefs: prefs}}
+}
+
+func TestCheckProvider(t *testing.T) {
+	svc := newTestService("openai")
+	userID := uuid.NewString()
+
+	if err := svc.CheckProvider(context.Background(), userID, "anthropic"); err != nil {
+		t.Fatalf("anthropic should be allowed: %v", err)
+	}
+
+	err := svc.CheckProvider(context.Background(), userID, "openai")
+	if !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "OpenAI") {
+		t.Errorf("error should name the provider: %v", err)
+	}
+}
+
+func TestCheckProvider_FailsClosed(t *testing.T) {
+	svc := &Service{preferences: &fakePreferences{err: errors.New("database down")}}
+
+	if err := svc.CheckProvider(context.Background(), uuid.NewString(), "anthropic"); err == nil {
+		t.Fatal("expected an error when preferences can't be read")
+	}
+}
+
+func TestGetDataControls(t *testing.T) {
+	svc := newTestService("google")
+
+	status, err := svc.GetDataControls(context.Background(), uuid.NewString())
+	if err != nil {
+		t.Fatalf("GetDataControls: %v", err)
+	}
+	if status.PolicyAcknowledged {
+		t.Error("policy should not be acknowledged")
+	}
+	for _, p := range status.Providers {
+		if p.Allowed != (p.Provider != "google") {
+			t.Errorf("%s: allowed = %v", p.Provider, p.Allowed)
+		}
+		if len(p.DataCategories) == 0 {
+			t.Errorf("%s: no data categories disclosed", p.Provider)
+		}
+	}
+}
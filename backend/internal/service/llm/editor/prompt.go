@@ -0,0 +1,131 @@
+package editor
+
+import (
+	"strings"
+)
+
+const (
+	// maxPrefixChars is how much text before the cursor the model sees
+	maxPrefixChars = 6000
+
+	// maxSuffixChars is how much text after the cursor the model sees
+	maxSuffixChars = 1000
+)
+
+const completionSystemPrompt = `You are an inline writing assistant inside a document editor. The user is typing and sees your output as ghost text at the cursor.
+
+Continue the text at the cursor. Rules:
+- Output only the continuation itself: no quotes, labels, commentary or Markdown fences.
+- Never repeat text that comes before the cursor.
+- Match the voice, tense, point of view and formatting of the surrounding text.
+- Keep it short: finish the current sentence, or write at most one or two more.
+- If there is text after the cursor, the continuation must lead into it naturally.
+- Start with a space when the continuation begins a new word after a finished one.`
+
+// splitAtCursor returns up to maxPrefixChars characters before the cursor and up to
+// maxSuffixChars after it. cursor must be within [0, len(runes)].
+func splitAtCursor(runes []rune, cursor int) (before, after string) {
+	start := max(cursor-maxPrefixChars, 0)
+	end := min(cursor+maxSuffixChars, len(runes))
+	return string(runes[start:cursor]), string(runes[cursor:end])
+}
+
+// completionSystem builds the completion system prompt, appending project context when given
+func completionSystem(projectPrompt, documentPath string) string {
+	return withProjectContext(completionSystemPrompt, projectPrompt, documentPath)
+}
+
+// withProjectContext appends the document's path and the project's instructions to a
+// system prompt; both are empty unless the request asks for project context
+func withProjectContext(system, projectPrompt, documentPath string) string {
+	var b strings.Builder
+	b.WriteString(system)
+	if documentPath != "" {
+		b.WriteString("\n\nThe document is \"" + documentPath + "\".")
+	}
+	if projectPrompt = strings.TrimSpace(projectPrompt); projectPrompt != "" {
+		b.WriteString("\n\nProject instructions:\n" + projectPrompt)
+	}
+	return b.String()
+}
+
+// completionMessage wraps the text around the cursor for the user message
+func completionMessage(before, after string) string {
+	var b strings.Builder
+	b.WriteString("<before_cursor>\n" + before + "</before_cursor>\n")
+	if after != "" {
+		b.WriteString("<after_cursor>" + after + "\n</after_cursor>\n")
+	}
+	b.WriteString("\nWrite the continuation at the cursor.")
+	return b.String()
+}
+
+const rewriteSystemPrompt = `You are a writing assistant inside a document editor. The user selected a passage and wants it rewritten.
+
+Rewrite the selected passage following the user's instruction. Rules:
+- Output only the replacement for the selection: no quotes, labels, commentary or Markdown fences.
+- Change only what the instruction asks for; keep the meaning, facts and names otherwise.
+- Keep the Markdown formatting style of the selection (headings, lists, emphasis).
+- The replacement must read naturally between the text before and after the selection.
+- Match the voice, tense and point of view of the surrounding text unless told otherwise.`
+
+// splitSelection returns up to maxPrefixChars characters before the range, the range
+// itself and up to maxSuffixChars after it. The range must be within [0, len(runes)].
+func splitSelection(runes []rune, start, end int) (before, selection, after string) {
+	before, _ = splitAtCursor(runes, start)
+	_, after = splitAtCursor(runes, end)
+	return before, string(runes[start:end]), after
+}
+
+// rewriteSystem builds the rewrite system prompt, appending project context when given
+func rewriteSystem(projectPrompt, documentPath string) string {
+	return withProjectContext(rewriteSystemPrompt, projectPrompt, documentPath)
+}
+
+// rewriteMessage wraps the selection, its surroundings and the instruction
+func rewriteMessage(before, selection, after, instruction string) string {
+	var b strings.Builder
+	if before != "" {
+		b.WriteString("<before_selection>\n" + before + "</before_selection>\n")
+	}
+	b.WriteString("<selection>" + selection + "</selection>\n")
+	if after != "" {
+		b.WriteString("<after_selection>" + after + "\n</after_selection>\n")
+	}
+	b.WriteString("\nInstruction: " + instruction + "\n\nWrite the replacement for the selection.")
+	return b.String()
+}
+
+const translateSystemPrompt = `You are a literary translator inside a document editor. The user wants a passage of their writing translated.
+
+Translate the passage into the target language. Rules:
+- Output only the translation: no quotes, labels, notes, commentary or Markdown fences.
+- Translate everything in the passage; never summarize, skip or add content.
+- Keep the Markdown formatting, line breaks and paragraph structure exactly.
+- Keep the voice, tone, register and tense; translate idioms to natural equivalents.
+- Keep names of characters and places as they are unless they have an established translation.
+- If there is surrounding text, use it only as context; do not translate it.`
+
+// translateSystem builds the translation system prompt, appending project context when given
+func translateSystem(projectPrompt, documentPath string) string {
+	return withProjectContext(translateSystemPrompt, projectPrompt, documentPath)
+}
+
+// translateMessage wraps the passage, its surroundings and the language pair. An empty
+// sourceName leaves the source language to the model.
+func translateMessage(before, passage, after, sourceName, targetName string) string {
+	var b strings.Builder
+	if before != "" {
+		b.WriteString("<before_passage>\n" + before + "</before_passage>\n")
+	}
+	b.WriteString("<passage>" + passage + "</passage>\n")
+	if after != "" {
+		b.WriteString("<after_passage>" + after + "\n</after_passage>\n")
+	}
+	if sourceName != "" {
+		b.WriteString("\nTranslate the passage from " + sourceName + " into " + targetName + ".")
+	} else {
+		b.WriteString("\nTranslate the passage into " + targetName + ".")
+	}
+	return b.String()
+}
@@ -0,0 +1,79 @@
+package editor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitAtCursor(t *testing.T) {
+	runes := []rune("Ünïcödé café, then more")
+	before, after := splitAtCursor(runes, 12)
+	if before != "Ünïcödé café" || after != ", then more" {
+		t.Errorf("split = %q | %q", before, after)
+	}
+
+	before, after = splitAtCursor(runes, 0)
+	if before != "" || after != string(runes) {
+		t.Errorf("split at start = %q | %q", before, after)
+	}
+
+	before, after = splitAtCursor(runes, len(runes))
+	if before != string(runes) || after != "" {
+		t.Errorf("split at end = %q | %q", before, after)
+	}
+}
+
+func TestSplitAtCursor_TrimsLongContext(t *testing.T) {
+	runes := []rune(strings.Repeat("a", maxPrefixChars+10) + strings.Repeat("b", maxSuffixChars+10))
+	before, after := splitAtCursor(runes, maxPrefixChars+10)
+	if len(before) != maxPrefixChars || strings.Contains(before, "b") {
+		t.Errorf("before has %d characters", len(before))
+	}
+	if len(after) != maxSuffixChars || strings.Contains(after, "a") {
+		t.Errorf("after has %d characters", len(after))
+	}
+}
+
+func TestCompletionSystem(t *testing.T) {
+	if got := completionSystem("", ""); got != completionSystemPrompt {
+		t.Errorf("system without context = %q", got)
+	}
+
+	got := completionSystem("  Write in British English.  ", "Chapters/One")
+	if !strings.Contains(got, `The document is "Chapters/One".`) {
+		t.Errorf("system is missing the document path: %q", got)
+	}
+	if !strings.HasSuffix(got, "Project instructions:\nWrite in British English.") {
+		t.Errorf("system is missing the project prompt: %q", got)
+	}
+}
+
+func TestCompletionMessage(t *testing.T) {
+	got := completionMessage("The storm ", "")
+	if !strings.Contains(got, "<before_cursor>\nThe storm </before_cursor>") || strings.Contains(got, "<after_cursor>") {
+		t.Errorf("message = %q", got)
+	}
+
+	got = completionMessage("The storm ", " by dawn.")
+	if !strings.Contains(got, "<after_cursor> by dawn.\n</after_cursor>") {
+		t.Errorf("message with text after the cursor = %q", got)
+	}
+}
+
+func TestSplitSelection(t *testing.T) {
+	runes := []rune("Before. Selected part. After.")
+	before, selection, after := splitSelection(runes, 8, 22)
+	if before != "Before. " || selection != "Selected part." || after != " After." {
+		t.Errorf("split = %q | %q | %q", before, selection, after)
+	}
+}
+
+func TestRewriteMessage(t *testing.T) {
+	got := rewriteMessage("Before. ", "Selected.", "", "shorter")
+	if !strings.Contains(got, "<before_selection>\nBefore. </before_selection>\n<selection>Selected.</selection>") {
+		t.Errorf("message = %q", got)
+	}
+	if strings.Contains(got, "<after_selection>") || !strings.Contains(got, "Instruction: shorter") {
+		t.Errorf("message = %q", got)
+	}
+}
@@ -0,0 +1,546 @@
+// Package editor implements editor-side generations (inline completions, selection
+// rewrites) that stream straight back to the editor instead of going through chats and turns.
+package editor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"meridian/internal/config"
+	"meridian/internal/domain"
+	docsysModels "meridian/internal/domain/models/docsystem"
+	llmModels "meridian/internal/domain/models/llm"
+	"meridian/internal/domain/repositories"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/errreport"
+	"meridian/internal/logging"
+)
+
+const (
+	// completionTimeout bounds one completion stream
+	completionTimeout = time.Minute
+
+	// rewriteTimeout bounds one rewrite request (all variants)
+	rewriteTimeout = 3 * time.Minute
+
+	// defaultCompletionTokens is the completion length when the request sets none
+	defaultCompletionTokens = 64
+
+	// defaultRewriteTokens is the per-variant rewrite length when the request sets none
+	defaultRewriteTokens = 1024
+
+	// defaultRewriteVariants is the number of alternatives when the request sets none
+	defaultRewriteVariants = 3
+
+	// translationTimeout bounds one translation request
+	translationTimeout = 5 * time.Minute
+
+	// defaultTranslationTokens is the translation length when the request sets none
+	defaultTranslationTokens = 8192
+)
+
+// ProviderGetter returns provider adapters by provider name (e.g. "anthropic", "openrouter")
+type ProviderGetter interface {
+	GetProvider(provider string) (llmSvc.LLMProvider, error)
+}
+
+// Service implements EditorService
+type Service struct {
+	documentService docsysSvc.DocumentService
+	settingsRepo    docsysRepo.ProjectSettingsRepository
+	suggestionRepo  docsysRepo.SuggestionRepository
+	txManager       repositories.TransactionManager
+	providerGetter  ProviderGetter
+	authorizer      services.ResourceAuthorizer
+	editorModel     string                    // Model for editor generations; empty = the project's default model
+	defaultModel    string                    // Server default model, used when neither is set
+	dataPolicy      llmSvc.ProviderDataPolicy // Users' per-provider data controls (nil = not enforced)
+	logger          *slog.Logger
+}
+
+var _ llmSvc.EditorService = (*Service)(nil)
+
+// NewService creates a new editor service
+func NewService(
+	documentService docsysSvc.DocumentService,
+	settingsRepo docsysRepo.ProjectSettingsRepository,
+	suggestionRepo docsysRepo.SuggestionRepository,
+	txManager repositories.TransactionManager,
+	providerGetter ProviderGetter,
+	authorizer services.ResourceAuthorizer,
+	editorModel string,
+	defaultModel string,
+	dataPolicy llmSvc.ProviderDataPolicy,
+	logger *slog.Logger,
+) *Service {
+	return &Service{
+		documentService: documentService,
+		settingsRepo:    settingsRepo,
+		suggestionRepo:  suggestionRepo,
+		txManager:       txManager,
+		providerGetter:  providerGetter,
+		authorizer:      authorizer,
+		editorModel:     editorModel,
+		defaultModel:    defaultModel,
+		dataPolicy:      dataPolicy,
+		logger:          logger,
+	}
+}
+
+// editorContext is what every editor generation starts from
+type editorContext struct {
+	runes         []rune // Content the offsets refer to
+	settings      *docsysModels.ProjectSettings
+	projectPrompt string // Empty unless project context was requested
+	documentPath  string // Empty unless project context was requested
+}
+
+// load authorizes access to the document and loads its content (or the editor's unsaved
+// content) and project settings
+func (s *Service) load(ctx context.Context, userID, documentID string, content *string, includeProjectContext bool) (*editorContext, error) {
+	// GetDocument authorizes access and computes the path
+	doc, err := s.documentService.GetDocument(ctx, userID, documentID)
+	if err != nil {
+		return nil, err
+	}
+	text := doc.Content
+	if content != nil {
+		text = *content
+	}
+
+	settings, err := s.settingsRepo.Get(ctx, doc.ProjectID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ec := &editorContext{runes: []rune(text), settings: settings}
+	if includeProjectContext {
+		if settings.SystemPrompt != nil {
+			ec.projectPrompt = *settings.SystemPrompt
+		}
+		ec.documentPath = doc.Path
+	}
+	return ec, nil
+}
+
+// CompleteDocument streams a short continuation of a document at the cursor
+func (s *Service) CompleteDocument(ctx context.Context, userID, documentID string, req *llmSvc.CompleteDocumentRequest) (<-chan llmSvc.EditorEvent, error) {
+	maxTokens, err := tokenLimit(req.MaxTokens, defaultCompletionTokens, config.MaxEditorCompletionTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	ec, err := s.load(ctx, userID, documentID, req.Content, req.IncludeProjectContext)
+	if err != nil {
+		return nil, err
+	}
+	if req.Cursor < 0 || req.Cursor > len(ec.runes) {
+		return nil, fmt.Errorf("%w: cursor: must be between 0 and %d", domain.ErrValidation, len(ec.runes))
+	}
+
+	before, after := splitAtCursor(ec.runes, req.Cursor)
+	if strings.TrimSpace(before) == "" && strings.TrimSpace(after) == "" {
+		return nil, fmt.Errorf("%w: nothing to complete in an empty document", domain.ErrValidation)
+	}
+
+	model := s.model(req.Model, ec.settings)
+	provider, err := s.provider(ctx, userID, model)
+	if err != nil {
+		return nil, err
+	}
+	generateReq := newGenerateRequest(model, completionSystem(ec.projectPrompt, ec.documentPath), completionMessage(before, after), maxTokens)
+
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	stream, err := provider.StreamResponse(ctx, generateReq)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("start completion: %w", err)
+	}
+
+	events := make(chan llmSvc.EditorEvent)
+	go func() {
+		defer cancel()
+		defer close(events)
+		s.forward(ctx, stream, events, 0, model, nil)
+	}()
+	return events, nil
+}
+
+// RewriteSelection streams alternative rewrites of a document range
+func (s *Service) RewriteSelection(ctx context.Context, userID, documentID string, req *llmSvc.RewriteSelectionRequest) (<-chan llmSvc.EditorEvent, error) {
+	instruction := strings.TrimSpace(req.Instruction)
+	if instruction == "" {
+		return nil, fmt.Errorf("%w: instruction: required", domain.ErrValidation)
+	}
+	if utf8.RuneCountInString(instruction) > config.MaxEditorInstructionLength {
+		return nil, fmt.Errorf("%w: instruction: at most %d characters", domain.ErrValidation, config.MaxEditorInstructionLength)
+	}
+	variants := defaultRewriteVariants
+	if req.Variants != nil {
+		if *req.Variants < 1 || *req.Variants > config.MaxEditorRewriteVariants {
+			return nil, fmt.Errorf("%w: variants: must be between 1 and %d", domain.ErrValidation, config.MaxEditorRewriteVariants)
+		}
+		variants = *req.Variants
+	}
+	maxTokens, err := tokenLimit(req.MaxTokens, defaultRewriteTokens, config.MaxEditorRewriteTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	ec, err := s.load(ctx, userID, documentID, req.Content, req.IncludeProjectContext)
+	if err != nil {
+		return nil, err
+	}
+	if req.Start < 0 || req.End <= req.Start || req.End > len(ec.runes) {
+		return nil, fmt.Errorf("%w: start and end: must select a range within 0 and %d", domain.ErrValidation, len(ec.runes))
+	}
+	if req.End-req.Start > config.MaxEditorSelectionLength {
+		return nil, fmt.Errorf("%w: selection: at most %d characters", domain.ErrValidation, config.MaxEditorSelectionLength)
+	}
+
+	before, selection, after := splitSelection(ec.runes, req.Start, req.End)
+	model := s.model(req.Model, ec.settings)
+	provider, err := s.provider(ctx, userID, model)
+	if err != nil {
+		return nil, err
+	}
+	system := rewriteSystem(ec.projectPrompt, ec.documentPath)
+	message := rewriteMessage(before, selection, after, instruction)
+
+	// Start every variant before answering, so a provider that rejects the request
+	// fails the call instead of the stream. Each variant gets its own request, since
+	// adapters may modify it.
+	ctx, cancel := context.WithTimeout(ctx, rewriteTimeout)
+	streams := make([]<-chan llmSvc.StreamEvent, variants)
+	for i := range streams {
+		if streams[i], err = provider.StreamResponse(ctx, newGenerateRequest(model, system, message, maxTokens)); err != nil {
+			cancel()
+			return nil, fmt.Errorf("start rewrite: %w", err)
+		}
+	}
+
+	var save func(context.Context, *llmSvc.EditorEvent)
+	if req.SaveSuggestions {
+		save = func(ctx context.Context, done *llmSvc.EditorEvent) {
+			s.saveSuggestion(ctx, documentID, req.Start, req.End, selection, instruction, done)
+		}
+	}
+
+	events := make(chan llmSvc.EditorEvent)
+	var wg sync.WaitGroup
+	for i, stream := range streams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.forward(ctx, stream, events, i, model, save)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		cancel()
+		close(events)
+	}()
+	return events, nil
+}
+
+// TranslateDocument streams a translation of a document or a range of it
+func (s *Service) TranslateDocument(ctx context.Context, userID, documentID string, req *llmSvc.TranslateDocumentRequest) (<-chan llmSvc.EditorEvent, error) {
+	target, err := llmModels.NormalizeLanguage(req.TargetLanguage)
+	if err != nil {
+		return nil, fmt.Errorf("%w: target_language: %v", domain.ErrValidation, err)
+	}
+	source := ""
+	if req.SourceLanguage != nil {
+		if source, err = llmModels.NormalizeLanguage(*req.SourceLanguage); err != nil {
+			return nil, fmt.Errorf("%w: source_language: %v", domain.ErrValidation, err)
+		}
+	}
+	maxTokens, err := tokenLimit(req.MaxTokens, defaultTranslationTokens, config.MaxEditorTranslationTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	ec, err := s.load(ctx, userID, documentID, req.Content, req.IncludeProjectContext)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := translationRange(req.Start, req.End, len(ec.runes))
+	if err != nil {
+		return nil, err
+	}
+
+	before, passage, after := splitSelection(ec.runes, start, end)
+	if strings.TrimSpace(passage) == "" {
+		return nil, fmt.Errorf("%w: nothing to translate", domain.ErrValidation)
+	}
+	if source == "" {
+		source = llmModels.DetectLanguage(passage)
+	}
+	if source == target {
+		return nil, fmt.Errorf("%w: the text is already in %s", domain.ErrValidation, llmModels.LanguageName(target))
+	}
+
+	model := s.model(req.Model, ec.settings)
+	provider, err := s.provider(ctx, userID, model)
+	if err != nil {
+		return nil, err
+	}
+	sourceName := ""
+	if source != "" {
+		sourceName = llmModels.LanguageName(source)
+	}
+	generateReq := newGenerateRequest(model, translateSystem(ec.projectPrompt, ec.documentPath),
+		translateMessage(before, passage, after, sourceName, llmModels.LanguageName(target)), maxTokens)
+
+	ctx, cancel := context.WithTimeout(ctx, translationTimeout)
+	stream, err := provider.StreamResponse(ctx, generateReq)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("start translation: %w", err)
+	}
+
+	finish := func(ctx context.Context, done *llmSvc.EditorEvent) {
+		done.SourceLanguage = source
+		done.TargetLanguage = target
+		if req.SaveSuggestion {
+			instruction := fmt.Sprintf("Translate into %s", llmModels.LanguageName(target))
+			s.saveSuggestion(ctx, documentID, start, end, passage, instruction, done)
+		}
+	}
+
+	events := make(chan llmSvc.EditorEvent)
+	go func() {
+		defer cancel()
+		defer close(events)
+		s.forward(ctx, stream, events, 0, model, finish)
+	}()
+	return events, nil
+}
+
+// translationRange resolves a translation's range: the whole content when neither offset
+// is set, else [start, end) (a missing start or end is the content's start or end)
+func translationRange(start, end *int, length int) (int, int, error) {
+	from, to := 0, length
+	if start != nil {
+		from = *start
+	}
+	if end != nil {
+		to = *end
+	}
+	if from < 0 || to <= from || to > length {
+		return 0, 0, fmt.Errorf("%w: start and end: must select a range within 0 and %d", domain.ErrValidation, length)
+	}
+	if to-from > config.MaxEditorTranslationLength {
+		return 0, 0, fmt.Errorf("%w: text to translate: at most %d characters; translate the document in ranges", domain.ErrValidation, config.MaxEditorTranslationLength)
+	}
+	return from, to, nil
+}
+
+// saveSuggestion stores a completed rewrite variant and sets its suggestion ID on the done
+// event. Empty variants aren't saved; a failed save is reported on the done event.
+func (s *Service) saveSuggestion(ctx context.Context, documentID string, start, end int, selection, instruction string, done *llmSvc.EditorEvent) {
+	if strings.TrimSpace(done.Text) == "" {
+		return
+	}
+	suggestion := &docsysModels.DocumentSuggestion{
+		DocumentID:    documentID,
+		Start:         start,
+		End:           end,
+		OriginalText:  selection,
+		SuggestedText: done.Text,
+		Instruction:   instruction,
+		Model:         done.Model,
+	}
+	if err := s.suggestionRepo.CreateSuggestion(ctx, suggestion); err != nil {
+		logging.FromContext(ctx, s.logger).Error("failed to save rewrite suggestion", "error", err)
+		done.Error = "failed to save suggestion"
+		return
+	}
+	done.SuggestionID = suggestion.ID
+}
+
+// ListSuggestions returns a document's saved inline suggestions
+func (s *Service) ListSuggestions(ctx context.Context, userID, documentID string) ([]docsysModels.DocumentSuggestion, error) {
+	if err := s.authorizer.CanAccessDocument(ctx, userID, documentID); err != nil {
+		return nil, err
+	}
+	return s.suggestionRepo.ListSuggestions(ctx, documentID)
+}
+
+// AcceptSuggestion applies an inline suggestion to the saved document and removes it
+func (s *Service) AcceptSuggestion(ctx context.Context, userID, documentID, suggestionID string) (*docsysModels.Document, error) {
+	// GetDocument authorizes access
+	doc, err := s.documentService.GetDocument(ctx, userID, documentID)
+	if err != nil {
+		return nil, err
+	}
+	suggestion, err := s.suggestionRepo.GetSuggestion(ctx, suggestionID, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, ok := suggestion.Apply(doc.Content)
+	if !ok {
+		return nil, &domain.ConflictError{
+			Message:      "the text this suggestion rewrites has changed",
+			ResourceType: "suggestion",
+			ResourceID:   suggestionID,
+		}
+	}
+
+	// Delete before updating so a concurrent accept of the same suggestion waits on the row,
+	// then finds it gone and rolls back instead of applying it twice
+	var updated *docsysModels.Document
+	err = s.txManager.ExecTx(ctx, func(txCtx context.Context) error {
+		if err := s.suggestionRepo.DeleteSuggestion(txCtx, suggestionID, documentID); err != nil {
+			return err
+		}
+		updated, err = s.documentService.UpdateDocument(txCtx, userID, documentID, &docsysSvc.UpdateDocumentRequest{
+			ProjectID: doc.ProjectID,
+			Content:   domain.Some(content),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteSuggestion removes an inline suggestion
+func (s *Service) DeleteSuggestion(ctx context.Context, userID, documentID, suggestionID string) error {
+	if err := s.authorizer.CanAccessDocument(ctx, userID, documentID); err != nil {
+		return err
+	}
+	return s.suggestionRepo.DeleteSuggestion(ctx, suggestionID, documentID)
+}
+
+// forward relays a provider stream as editor events for one variant: text deltas as they
+// arrive, then a done event with the full text and usage (or an error event). finish, when
+// set, runs on the done event before it is sent.
+func (s *Service) forward(ctx context.Context, stream <-chan llmSvc.StreamEvent, events chan<- llmSvc.EditorEvent, variant int, model string, finish func(context.Context, *llmSvc.EditorEvent)) {
+	logger := logging.FromContext(ctx, s.logger)
+
+	send := func(event llmSvc.EditorEvent) bool {
+		event.Variant = variant
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			logger.Error("panic while streaming editor generation", "error", rec, "stack", string(debug.Stack()))
+			errreport.CapturePanic(ctx, rec)
+			send(llmSvc.EditorEvent{Type: llmSvc.EditorEventError, Error: "internal error"})
+		}
+	}()
+
+	var text strings.Builder
+	done := llmSvc.EditorEvent{Type: llmSvc.EditorEventDone, Model: model}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-stream:
+			if !ok {
+				done.Text = text.String()
+				if finish != nil {
+					finish(ctx, &done)
+				}
+				send(done)
+				return
+			}
+			switch {
+			case event.Error != nil:
+				logger.Warn("editor generation failed", "model", model, "variant", variant, "error", event.Error)
+				send(llmSvc.EditorEvent{Type: llmSvc.EditorEventError, Error: event.Error.Error()})
+				return
+			case event.Delta != nil:
+				if event.Delta.DeltaType != llmModels.DeltaTypeText || event.Delta.TextDelta == nil || *event.Delta.TextDelta == "" {
+					continue // Thinking and other block types never reach the editor
+				}
+				text.WriteString(*event.Delta.TextDelta)
+				if !send(llmSvc.EditorEvent{Type: llmSvc.EditorEventDelta, Text: *event.Delta.TextDelta}) {
+					return
+				}
+			case event.Metadata != nil:
+				if event.Metadata.Model != "" {
+					done.Model = event.Metadata.Model
+				}
+				done.InputTokens = event.Metadata.InputTokens
+				done.OutputTokens = event.Metadata.OutputTokens
+				done.StopReason = event.Metadata.StopReason
+			}
+		}
+	}
+}
+
+// model picks the request's model, then the server editor model, then the project's
+// default model, then the server default
+func (s *Service) model(requested *string, settings *docsysModels.ProjectSettings) string {
+	if requested != nil && *requested != "" {
+		return *requested
+	}
+	if s.editorModel != "" {
+		return s.editorModel
+	}
+	if settings.DefaultModel != nil && *settings.DefaultModel != "" {
+		return *settings.DefaultModel
+	}
+	return s.defaultModel
+}
+
+// provider resolves the model's provider from the model mapping, else openrouter, if the
+// user's data controls allow it
+func (s *Service) provider(ctx context.Context, userID, model string) (llmSvc.LLMProvider, error) {
+	name := "openrouter"
+	if mapped, found := llmModels.GetProviderForModel(model); found {
+		name = mapped
+	}
+	if s.dataPolicy != nil {
+		if err := s.dataPolicy.CheckProvider(ctx, userID, name); err != nil {
+			return nil, err
+		}
+	}
+	provider, err := s.providerGetter.GetProvider(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: model %q: %v", domain.ErrValidation, model, err)
+	}
+	return provider, nil
+}
+
+// tokenLimit validates a requested max_tokens against [1, limit]
+func tokenLimit(requested *int, fallback, limit int) (int, error) {
+	if requested == nil {
+		return fallback, nil
+	}
+	if *requested < 1 || *requested > limit {
+		return 0, fmt.Errorf("%w: max_tokens: must be between 1 and %d", domain.ErrValidation, limit)
+	}
+	return *requested, nil
+}
+
+// newGenerateRequest builds a single-message request
+func newGenerateRequest(model, system, message string, maxTokens int) *llmSvc.GenerateRequest {
+	return &llmSvc.GenerateRequest{
+		Model: model,
+		Messages: []llmSvc.Message{{
+			Role:    "user",
+			Content: []*llmModels.TurnBlock{{BlockType: llmModels.BlockTypeText, TextContent: &message}},
+		}},
+		Params: &llmModels.RequestParams{Model: &model, System: &system, MaxTokens: &maxTokens},
+	}
+}
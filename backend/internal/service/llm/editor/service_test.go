@@ -0,0 +1,545 @@
+package editor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"meridian/internal/domain"
+	docsysModels "meridian/internal/domain/models/docsystem"
+	llmModels "meridian/internal/domain/models/llm"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/testmocks"
+)
+
+// fakeDocumentService serves one document and records content updates; other
+// DocumentService methods are unused
+type fakeDocumentService struct {
+	docsysSvc.DocumentService
+	doc       docsysModels.Document
+	err       error
+	outsideTx bool // An update ran outside a transaction
+}
+
+func (f *fakeDocumentService) UpdateDocument(ctx context.Context, userID, documentID string, req *docsysSvc.UpdateDocumentRequest) (*docsysModels.Document, error) {
+	f.outsideTx = f.outsideTx || testmocks.TxDepth(ctx) == 0
+	if content, ok := req.Content.Value(); ok {
+		f.doc.Content = content
+	}
+	doc := f.doc
+	return &doc, nil
+}
+
+func (f *fakeDocumentService) GetDocument(ctx context.Context, userID, documentID string) (*docsysModels.Document, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	doc := f.doc
+	return &doc, nil
+}
+
+// fakeSettingsRepo returns fixed settings; other methods are unused
+type fakeSettingsRepo struct {
+	docsysRepo.ProjectSettingsRepository
+	systemPrompt *string
+	defaultModel *string
+}
+
+func (f *fakeSettingsRepo) Get(ctx context.Context, projectID, userID string) (*docsysModels.ProjectSettings, error) {
+	settings := docsysModels.DefaultProjectSettings(projectID)
+	settings.SystemPrompt = f.systemPrompt
+	settings.DefaultModel = f.defaultModel
+	return settings, nil
+}
+
+// fakeProvider streams the given events; other methods are unused
+type fakeProvider struct {
+	llmSvc.LLMProvider
+	events   []llmSvc.StreamEvent
+	requests []*llmSvc.GenerateRequest
+}
+
+func (f *fakeProvider) StreamResponse(ctx context.Context, req *llmSvc.GenerateRequest) (<-chan llmSvc.StreamEvent, error) {
+	f.requests = append(f.requests, req)
+	stream := make(chan llmSvc.StreamEvent, len(f.events))
+	for _, event := range f.events {
+		stream <- event
+	}
+	close(stream)
+	return stream, nil
+}
+
+// fakeSuggestionRepo keeps suggestions in memory (saved concurrently by rewrite variants)
+type fakeSuggestionRepo struct {
+	mu        sync.Mutex
+	saved     []docsysModels.DocumentSuggestion
+	err       error
+	outsideTx bool // A delete ran outside a transaction
+}
+
+func (f *fakeSuggestionRepo) CreateSuggestion(ctx context.Context, suggestion *docsysModels.DocumentSuggestion) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	suggestion.ID = fmt.Sprintf("suggestion-%d", len(f.saved)+1)
+	f.saved = append(f.saved, *suggestion)
+	return nil
+}
+
+func (f *fakeSuggestionRepo) ListSuggestions(ctx context.Context, documentID string) ([]docsysModels.DocumentSuggestion, error) {
+	return f.saved, nil
+}
+
+func (f *fakeSuggestionRepo) GetSuggestion(ctx context.Context, suggestionID, documentID string) (*docsysModels.DocumentSuggestion, error) {
+	for _, suggestion := range f.saved {
+		if suggestion.ID == suggestionID && suggestion.DocumentID == documentID {
+			return &suggestion, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (f *fakeSuggestionRepo) DeleteSuggestion(ctx context.Context, suggestionID, documentID string) error {
+	f.outsideTx = f.outsideTx || testmocks.TxDepth(ctx) == 0
+	for i, suggestion := range f.saved {
+		if suggestion.ID == suggestionID && suggestion.DocumentID == documentID {
+			f.saved = append(f.saved[:i], f.saved[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
+
+type fakeProviderGetter struct {
+	provider *fakeProvider
+	names    []string
+}
+
+func (f *fakeProviderGetter) GetProvider(name string) (llmSvc.LLMProvider, error) {
+	f.names = append(f.names, name)
+	return f.provider, nil
+}
+
+func textDelta(text string) llmSvc.StreamEvent {
+	return llmSvc.StreamEvent{Delta: &llmModels.TurnBlockDelta{DeltaType: llmModels.DeltaTypeText, TextDelta: &text}}
+}
+
+func newTestService(content string, events ...llmSvc.StreamEvent) (*Service, *fakeSettingsRepo, *fakeProviderGetter) {
+	docs := &fakeDocumentService{doc: docsysModels.Document{ID: "doc-1", ProjectID: "project-1", Path: "Chapters/One", Content: content}}
+	settings := &fakeSettingsRepo{}
+	providers := &fakeProviderGetter{provider: &fakeProvider{events: events}}
+	svc := NewService(docs, settings, &fakeSuggestionRepo{}, &testmocks.TxManager{}, providers, &testmocks.Authorizer{}, "", "moonshotai/kimi-k2-thinking", nil,
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return svc, settings, providers
+}
+
+func collect(events <-chan llmSvc.EditorEvent) []llmSvc.EditorEvent {
+	var got []llmSvc.EditorEvent
+	for event := range events {
+		got = append(got, event)
+	}
+	return got
+}
+
+func TestCompleteDocument_StreamsText(t *testing.T) {
+	thinking := "Let me think."
+	svc, _, providers := newTestService("The storm rolled in. It",
+		llmSvc.StreamEvent{Delta: &llmModels.TurnBlockDelta{DeltaType: llmModels.DeltaTypeThinking, TextDelta: &thinking}},
+		textDelta(" tore the"),
+		textDelta(" sails."),
+		llmSvc.StreamEvent{Metadata: &llmSvc.StreamMetadata{Model: "claude-haiku-4-5", InputTokens: 120, OutputTokens: 4, StopReason: "end_turn"}},
+	)
+
+	events, err := svc.CompleteDocument(context.Background(), "user-1", "doc-1", &llmSvc.CompleteDocumentRequest{Cursor: 23})
+	if err != nil {
+		t.Fatalf("CompleteDocument: %v", err)
+	}
+	got := collect(events)
+
+	if len(got) != 3 {
+		t.Fatalf("got %d events, want 2 deltas and done: %+v", len(got), got)
+	}
+	if got[0].Type != llmSvc.EditorEventDelta || got[0].Text != " tore the" || got[1].Text != " sails." {
+		t.Errorf("deltas = %+v", got[:2])
+	}
+	done := got[2]
+	if done.Type != llmSvc.EditorEventDone || done.Text != " tore the sails." || done.Model != "claude-haiku-4-5" || done.OutputTokens != 4 || done.StopReason != "end_turn" {
+		t.Errorf("done = %+v", done)
+	}
+
+	req := providers.provider.requests[0]
+	if *req.Params.MaxTokens != defaultCompletionTokens {
+		t.Errorf("max tokens = %d", *req.Params.MaxTokens)
+	}
+	if !strings.Contains(*req.Messages[0].Content[0].TextContent, "The storm rolled in. It</before_cursor>") {
+		t.Errorf("message = %q", *req.Messages[0].Content[0].TextContent)
+	}
+	if strings.Contains(*req.Params.System, "Chapters/One") {
+		t.Error("system includes project context that was not requested")
+	}
+}
+
+func TestCompleteDocument_ProviderError(t *testing.T) {
+	svc, _, _ := newTestService("Once upon", textDelta(" a"), llmSvc.StreamEvent{Error: errors.New("rate limited")})
+
+	events, err := svc.CompleteDocument(context.Background(), "user-1", "doc-1", &llmSvc.CompleteDocumentRequest{Cursor: 9})
+	if err != nil {
+		t.Fatalf("CompleteDocument: %v", err)
+	}
+	got := collect(events)
+
+	last := got[len(got)-1]
+	if last.Type != llmSvc.EditorEventError || last.Error != "rate limited" {
+		t.Errorf("last event = %+v", last)
+	}
+}
+
+func TestCompleteDocument_UnsavedContentAndProjectContext(t *testing.T) {
+	svc, settings, providers := newTestService("Saved text", textDelta("!"))
+	prompt := "Write in British English."
+	model := "claude-haiku-4-5"
+	settings.systemPrompt = &prompt
+	settings.defaultModel = &model
+
+	content := "Unsaved draft"
+	events, err := svc.CompleteDocument(context.Background(), "user-1", "doc-1", &llmSvc.CompleteDocumentRequest{
+		Cursor:                13,
+		Content:               &content,
+		IncludeProjectContext: true,
+	})
+	if err != nil {
+		t.Fatalf("CompleteDocument: %v", err)
+	}
+	collect(events)
+
+	req := providers.provider.requests[0]
+	if !strings.Contains(*req.Messages[0].Content[0].TextContent, "Unsaved draft") {
+		t.Errorf("message does not use the unsaved content: %q", *req.Messages[0].Content[0].TextContent)
+	}
+	if !strings.Contains(*req.Params.System, prompt) || !strings.Contains(*req.Params.System, "Chapters/One") {
+		t.Errorf("system is missing project context: %q", *req.Params.System)
+	}
+	if req.Model != model || providers.names[0] != "anthropic" {
+		t.Errorf("model = %q via %v, want the project default", req.Model, providers.names)
+	}
+}
+
+func TestCompleteDocument_Validation(t *testing.T) {
+	svc, _, _ := newTestService("Short")
+	tooMany := 1000
+
+	tests := []struct {
+		name string
+		req  llmSvc.CompleteDocumentRequest
+	}{
+		{"cursor past the end", llmSvc.CompleteDocumentRequest{Cursor: 6}},
+		{"negative cursor", llmSvc.CompleteDocumentRequest{Cursor: -1}},
+		{"too many tokens", llmSvc.CompleteDocumentRequest{Cursor: 5, MaxTokens: &tooMany}},
+		{"empty content", llmSvc.CompleteDocumentRequest{Cursor: 0, Content: new(string)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.CompleteDocument(context.Background(), "user-1", "doc-1", &tt.req); !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("err = %v, want ErrValidation", err)
+			}
+		})
+	}
+}
+
+func TestCompleteDocument_DocumentErrors(t *testing.T) {
+	svc, _, _ := newTestService("Text")
+	svc.documentService.(*fakeDocumentService).err = domain.ErrForbidden
+
+	if _, err := svc.CompleteDocument(context.Background(), "user-1", "doc-1", &llmSvc.CompleteDocumentRequest{}); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("err = %v, want ErrForbidden", err)
+	}
+}
+
+func TestRewriteSelection_StreamsVariants(t *testing.T) {
+	svc, _, providers := newTestService("The storm was very big. Then it passed.",
+		textDelta("The storm "),
+		textDelta("loomed."),
+		llmSvc.StreamEvent{Metadata: &llmSvc.StreamMetadata{OutputTokens: 3, StopReason: "end_turn"}},
+	)
+	suggestions := svc.suggestionRepo.(*fakeSuggestionRepo)
+	variants := 2
+
+	events, err := svc.RewriteSelection(context.Background(), "user-1", "doc-1", &llmSvc.RewriteSelectionRequest{
+		Start:           0,
+		End:             23,
+		Instruction:     " make it punchier ",
+		Variants:        &variants,
+		SaveSuggestions: true,
+	})
+	if err != nil {
+		t.Fatalf("RewriteSelection: %v", err)
+	}
+	got := collect(events)
+
+	deltas := map[int]string{}
+	done := map[int]llmSvc.EditorEvent{}
+	for _, event := range got {
+		switch event.Type {
+		case llmSvc.EditorEventDelta:
+			deltas[event.Variant] += event.Text
+		case llmSvc.EditorEventDone:
+			done[event.Variant] = event
+		default:
+			t.Errorf("unexpected event %+v", event)
+		}
+	}
+	for variant := range variants {
+		if deltas[variant] != "The storm loomed." || done[variant].Text != "The storm loomed." {
+			t.Errorf("variant %d: deltas %q, done %+v", variant, deltas[variant], done[variant])
+		}
+		if done[variant].SuggestionID == "" {
+			t.Errorf("variant %d was not saved as a suggestion", variant)
+		}
+	}
+
+	if len(providers.provider.requests) != variants {
+		t.Fatalf("made %d provider requests, want %d", len(providers.provider.requests), variants)
+	}
+	message := *providers.provider.requests[0].Messages[0].Content[0].TextContent
+	if !strings.Contains(message, "<selection>The storm was very big.</selection>") || !strings.Contains(message, "Instruction: make it punchier\n") {
+		t.Errorf("message = %q", message)
+	}
+	if strings.Contains(message, "<before_selection>") {
+		t.Error("message has text before a selection that starts the document")
+	}
+
+	if len(suggestions.saved) != variants {
+		t.Fatalf("saved %d suggestions, want %d", len(suggestions.saved), variants)
+	}
+	saved := suggestions.saved[0]
+	if saved.Start != 0 || saved.End != 23 || saved.OriginalText != "The storm was very big." || saved.SuggestedText != "The storm loomed." || saved.Instruction != "make it punchier" {
+		t.Errorf("saved suggestion = %+v", saved)
+	}
+}
+
+func TestRewriteSelection_DoesNotSaveByDefault(t *testing.T) {
+	svc, _, _ := newTestService("Old words here.", textDelta("New words."))
+	suggestions := svc.suggestionRepo.(*fakeSuggestionRepo)
+
+	events, err := svc.RewriteSelection(context.Background(), "user-1", "doc-1", &llmSvc.RewriteSelectionRequest{Start: 0, End: 15, Instruction: "rephrase"})
+	if err != nil {
+		t.Fatalf("RewriteSelection: %v", err)
+	}
+	got := collect(events)
+
+	if len(got) != 2*defaultRewriteVariants {
+		t.Errorf("got %d events, want a delta and done per default variant", len(got))
+	}
+	for _, event := range got {
+		if event.SuggestionID != "" {
+			t.Errorf("event has a suggestion ID: %+v", event)
+		}
+	}
+	if len(suggestions.saved) != 0 {
+		t.Errorf("saved %d suggestions without save_suggestions", len(suggestions.saved))
+	}
+}
+
+func TestRewriteSelection_SaveFailure(t *testing.T) {
+	svc, _, _ := newTestService("Old words here.", textDelta("New words."))
+	svc.suggestionRepo.(*fakeSuggestionRepo).err = errors.New("connection refused")
+	variants := 1
+
+	events, err := svc.RewriteSelection(context.Background(), "user-1", "doc-1", &llmSvc.RewriteSelectionRequest{
+		Start: 0, End: 15, Instruction: "rephrase", Variants: &variants, SaveSuggestions: true,
+	})
+	if err != nil {
+		t.Fatalf("RewriteSelection: %v", err)
+	}
+	got := collect(events)
+
+	done := got[len(got)-1]
+	if done.Type != llmSvc.EditorEventDone || done.Text != "New words." || done.Error == "" || done.SuggestionID != "" {
+		t.Errorf("done = %+v, want the text with a save error", done)
+	}
+}
+
+func TestRewriteSelection_Validation(t *testing.T) {
+	svc, _, _ := newTestService("Some text.")
+	zero, tooMany := 0, 6
+	longInstruction := strings.Repeat("x", 2001)
+
+	tests := []struct {
+		name string
+		req  llmSvc.RewriteSelectionRequest
+	}{
+		{"missing instruction", llmSvc.RewriteSelectionRequest{Start: 0, End: 4, Instruction: "  "}},
+		{"long instruction", llmSvc.RewriteSelectionRequest{Start: 0, End: 4, Instruction: longInstruction}},
+		{"empty range", llmSvc.RewriteSelectionRequest{Start: 4, End: 4, Instruction: "shorter"}},
+		{"reversed range", llmSvc.RewriteSelectionRequest{Start: 5, End: 2, Instruction: "shorter"}},
+		{"range past the end", llmSvc.RewriteSelectionRequest{Start: 0, End: 11, Instruction: "shorter"}},
+		{"no variants", llmSvc.RewriteSelectionRequest{Start: 0, End: 4, Instruction: "shorter", Variants: &zero}},
+		{"too many variants", llmSvc.RewriteSelectionRequest{Start: 0, End: 4, Instruction: "shorter", Variants: &tooMany}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.RewriteSelection(context.Background(), "user-1", "doc-1", &tt.req); !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("err = %v, want ErrValidation", err)
+			}
+		})
+	}
+}
+
+func TestTranslateDocument_Range(t *testing.T) {
+	content := "# Chapter One\n\nThe dragon slept on the gold, and the knight waited at the gate.\n\nMore text."
+	svc, _, providers := newTestService(content,
+		textDelta("Le dragon dormait sur l'or, "),
+		textDelta("et le chevalier attendait à la porte."),
+		llmSvc.StreamEvent{Metadata: &llmSvc.StreamMetadata{OutputTokens: 14, StopReason: "end_turn"}},
+	)
+	suggestions := svc.suggestionRepo.(*fakeSuggestionRepo)
+	start, end := 15, 79
+
+	events, err := svc.TranslateDocument(context.Background(), "user-1", "doc-1", &llmSvc.TranslateDocumentRequest{
+		TargetLanguage: "FR",
+		Start:          &start,
+		End:            &end,
+		SaveSuggestion: true,
+	})
+	if err != nil {
+		t.Fatalf("TranslateDocument: %v", err)
+	}
+	got := collect(events)
+
+	done := got[len(got)-1]
+	want := "Le dragon dormait sur l'or, et le chevalier attendait à la porte."
+	if done.Type != llmSvc.EditorEventDone || done.Text != want || done.SourceLanguage != "en" || done.TargetLanguage != "fr" || done.SuggestionID == "" {
+		t.Errorf("done = %+v", done)
+	}
+
+	message := *providers.provider.requests[0].Messages[0].Content[0].TextContent
+	if !strings.Contains(message, "<passage>The dragon slept on the gold, and the knight waited at the gate.</passage>") ||
+		!strings.Contains(message, "from English into French.") {
+		t.Errorf("message = %q", message)
+	}
+
+	if len(suggestions.saved) != 1 {
+		t.Fatalf("saved %d suggestions, want 1", len(suggestions.saved))
+	}
+	if saved := suggestions.saved[0]; saved.Start != start || saved.End != end || saved.SuggestedText != want || saved.Instruction != "Translate into French" {
+		t.Errorf("saved suggestion = %+v", saved)
+	}
+}
+
+func TestTranslateDocument_WholeDocument(t *testing.T) {
+	svc, _, providers := newTestService("Hola.", textDelta("Hello."))
+	source := "es"
+
+	events, err := svc.TranslateDocument(context.Background(), "user-1", "doc-1", &llmSvc.TranslateDocumentRequest{
+		TargetLanguage: "en-GB",
+		SourceLanguage: &source,
+	})
+	if err != nil {
+		t.Fatalf("TranslateDocument: %v", err)
+	}
+	got := collect(events)
+
+	if done := got[len(got)-1]; done.Text != "Hello." || done.SourceLanguage != "es" || done.TargetLanguage != "en-GB" || done.SuggestionID != "" {
+		t.Errorf("done = %+v", done)
+	}
+	message := *providers.provider.requests[0].Messages[0].Content[0].TextContent
+	if !strings.HasPrefix(message, "<passage>Hola.</passage>") || !strings.Contains(message, "from Spanish into British English.") {
+		t.Errorf("message = %q", message)
+	}
+	if len(svc.suggestionRepo.(*fakeSuggestionRepo).saved) != 0 {
+		t.Error("saved a suggestion without save_suggestion")
+	}
+}
+
+func TestTranslateDocument_Validation(t *testing.T) {
+	svc, _, _ := newTestService("The knight waited at the gate for the dragon to wake.")
+	zero, four, past := 0, 4, 100
+	invalid := "klingon!"
+
+	tests := []struct {
+		name string
+		req  llmSvc.TranslateDocumentRequest
+	}{
+		{"missing target", llmSvc.TranslateDocumentRequest{}},
+		{"invalid target", llmSvc.TranslateDocumentRequest{TargetLanguage: "french"}},
+		{"invalid source", llmSvc.TranslateDocumentRequest{TargetLanguage: "fr", SourceLanguage: &invalid}},
+		{"already in target", llmSvc.TranslateDocumentRequest{TargetLanguage: "en"}},
+		{"empty range", llmSvc.TranslateDocumentRequest{TargetLanguage: "fr", Start: &four, End: &four}},
+		{"range past the end", llmSvc.TranslateDocumentRequest{TargetLanguage: "fr", End: &past}},
+		{"no tokens", llmSvc.TranslateDocumentRequest{TargetLanguage: "fr", MaxTokens: &zero}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.TranslateDocument(context.Background(), "user-1", "doc-1", &tt.req); !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("err = %v, want ErrValidation", err)
+			}
+		})
+	}
+}
+
+func TestSuggestions_Authorization(t *testing.T) {
+	svc, _, _ := newTestService("Text")
+	svc.authorizer = &testmocks.Authorizer{Deny: map[string]error{"doc-1": domain.ErrForbidden}}
+
+	if _, err := svc.ListSuggestions(context.Background(), "user-1", "doc-1"); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("ListSuggestions err = %v, want ErrForbidden", err)
+	}
+	if err := svc.DeleteSuggestion(context.Background(), "user-1", "doc-1", "suggestion-1"); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("DeleteSuggestion err = %v, want ErrForbidden", err)
+	}
+}
+
+func TestAcceptSuggestion(t *testing.T) {
+	svc, _, _ := newTestService("The quick fox. The lazy dog.")
+	docs := svc.documentService.(*fakeDocumentService)
+	suggestions := svc.suggestionRepo.(*fakeSuggestionRepo)
+	suggestions.saved = []docsysModels.DocumentSuggestion{
+		{ID: "suggestion-1", DocumentID: "doc-1", Start: 4, End: 9, OriginalText: "quick", SuggestedText: "sluggish"},
+		{ID: "suggestion-2", DocumentID: "doc-1", Start: 19, End: 23, OriginalText: "lazy", SuggestedText: "sleepy"},
+		{ID: "suggestion-3", DocumentID: "doc-1", Start: 4, End: 9, OriginalText: "quick", SuggestedText: "swift"},
+	}
+
+	doc, err := svc.AcceptSuggestion(context.Background(), "user-1", "doc-1", "suggestion-1")
+	if err != nil {
+		t.Fatalf("AcceptSuggestion: %v", err)
+	}
+	if want := "The sluggish fox. The lazy dog."; doc.Content != want || docs.doc.Content != want {
+		t.Errorf("content = %q, want %q", doc.Content, want)
+	}
+
+	// Accepting the first shifted this one's range; its text is found again
+	if _, err := svc.AcceptSuggestion(context.Background(), "user-1", "doc-1", "suggestion-2"); err != nil {
+		t.Fatalf("AcceptSuggestion (moved range): %v", err)
+	}
+	if want := "The sluggish fox. The sleepy dog."; docs.doc.Content != want {
+		t.Errorf("content = %q, want %q", docs.doc.Content, want)
+	}
+
+	// The text this one rewrites is gone
+	var conflict *domain.ConflictError
+	if _, err := svc.AcceptSuggestion(context.Background(), "user-1", "doc-1", "suggestion-3"); !errors.As(err, &conflict) {
+		t.Errorf("AcceptSuggestion (stale) err = %v, want ConflictError", err)
+	}
+
+	if len(suggestions.saved) != 1 || suggestions.saved[0].ID != "suggestion-3" {
+		t.Errorf("remaining suggestions = %+v, want only the stale one", suggestions.saved)
+	}
+	if _, err := svc.AcceptSuggestion(context.Background(), "user-1", "doc-1", "suggestion-1"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("AcceptSuggestion (accepted) err = %v, want ErrNotFound", err)
+	}
+	if want := "The sluggish fox. The sleepy dog."; docs.doc.Content != want {
+		t.Errorf("content after re-accept = %q, want %q", docs.doc.Content, want)
+	}
+	if docs.outsideTx || suggestions.outsideTx {
+		t.Error("accept wrote outside a transaction")
+	}
+}
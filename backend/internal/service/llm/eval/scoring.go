@@ -0,0 +1,174 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	llmModels "meridian/internal/domain/models/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+// judgeMaxTokens caps the length of a verdict
+const judgeMaxTokens = 512
+
+// judgeSystemPrompt instructs the judge to answer with a parseable verdict
+const judgeSystemPrompt = `You are grading a model's response to a prompt against one criterion.
+Decide only whether the response meets the criterion; ignore everything else about it.
+Answer with PASS or FAIL on the first line, then one or two sentences explaining why.`
+
+// evaluate generates the case's output with a model and checks it against each criterion
+func (s *Service) evaluate(ctx context.Context, run *llmModels.EvalRun, evalCase *llmModels.EvalCase, model string, system *string, maxTokens int) *llmModels.EvalResult {
+	result := &llmModels.EvalResult{
+		RunID:      run.ID,
+		CaseID:     evalCase.ID,
+		CaseName:   evalCase.Name,
+		Model:      model,
+		Assertions: []llmModels.EvalAssertion{},
+	}
+
+	resp, err := s.generate(ctx, model, system, evalCase.Prompt, maxTokens)
+	if err != nil {
+		message := err.Error()
+		result.Error = &message
+		return result
+	}
+	result.Output = responseText(resp.Content)
+	result.InputTokens = resp.InputTokens
+	result.OutputTokens = resp.OutputTokens
+
+	passed := 0
+	for _, criterion := range evalCase.Criteria {
+		var assertion llmModels.EvalAssertion
+		if criterion.Type == llmModels.EvalCriterionJudge {
+			assertion = s.judge(ctx, run.JudgeModel, evalCase.Prompt, result.Output, criterion)
+		} else {
+			assertion = checkPattern(criterion, result.Output)
+		}
+		if assertion.Passed {
+			passed++
+		}
+		result.Assertions = append(result.Assertions, assertion)
+	}
+
+	if len(evalCase.Criteria) > 0 {
+		result.Score = float64(passed) / float64(len(evalCase.Criteria))
+	}
+	result.Passed = passed == len(evalCase.Criteria)
+	return result
+}
+
+// judge asks the judge model whether the output meets a judge criterion. A judge that
+// fails or answers without a verdict fails the criterion, with the reason recorded.
+func (s *Service) judge(ctx context.Context, model, prompt, output string, criterion llmModels.EvalCriterion) llmModels.EvalAssertion {
+	assertion := llmModels.EvalAssertion{Type: criterion.Type, Value: criterion.Value}
+
+	system := judgeSystemPrompt
+	resp, err := s.generate(ctx, model, &system, judgeMessage(prompt, output, criterion.Value), judgeMaxTokens)
+	if err != nil {
+		assertion.Reason = fmt.Sprintf("judge request failed: %v", err)
+		return assertion
+	}
+
+	passed, reason, err := parseVerdict(responseText(resp.Content))
+	if err != nil {
+		assertion.Reason = err.Error()
+		return assertion
+	}
+	assertion.Passed = passed
+	assertion.Reason = reason
+	return assertion
+}
+
+// generate sends a single user message to a model
+func (s *Service) generate(ctx context.Context, model string, system *string, message string, maxTokens int) (*llmSvc.GenerateResponse, error) {
+	provider, err := s.providerGetter.GetProvider(providerFor(model))
+	if err != nil {
+		return nil, fmt.Errorf("model unavailable: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, generationTimeout)
+	defer cancel()
+
+	resp, err := provider.GenerateResponse(ctx, &llmSvc.GenerateRequest{
+		Model: model,
+		Messages: []llmSvc.Message{{
+			Role:    "user",
+			Content: []*llmModels.TurnBlock{{BlockType: llmModels.BlockTypeText, TextContent: &message}},
+		}},
+		Params: &llmModels.RequestParams{Model: &model, System: system, MaxTokens: &maxTokens},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("generation failed: %w", err)
+	}
+	return resp, nil
+}
+
+// checkPattern checks a regex or not_regex criterion against the output
+func checkPattern(criterion llmModels.EvalCriterion, output string) llmModels.EvalAssertion {
+	assertion := llmModels.EvalAssertion{Type: criterion.Type, Value: criterion.Value}
+
+	pattern, err := regexp.Compile(criterion.Value)
+	if err != nil {
+		assertion.Reason = fmt.Sprintf("invalid pattern: %v", err)
+		return assertion
+	}
+
+	matched := pattern.MatchString(output)
+	if criterion.Type == llmModels.EvalCriterionNotRegex {
+		assertion.Passed = !matched
+		if matched {
+			assertion.Reason = fmt.Sprintf("output contains %q", pattern.FindString(output))
+		}
+		return assertion
+	}
+	assertion.Passed = matched
+	if !matched {
+		assertion.Reason = "output doesn't match the pattern"
+	}
+	return assertion
+}
+
+// judgeMessage asks for a verdict on one criterion
+func judgeMessage(prompt, output, criterion string) string {
+	return fmt.Sprintf("## Prompt\n\n%s\n\n## Response\n\n%s\n\n## Criterion\n\n%s", prompt, output, criterion)
+}
+
+// parseVerdict reads PASS or FAIL from the first line of the judge's reply; the rest is
+// the reason
+func parseVerdict(reply string) (bool, string, error) {
+	line, reason, _ := strings.Cut(strings.TrimSpace(reply), "\n")
+	line = strings.Trim(strings.TrimSpace(line), "*#`'\" ")
+	reason = strings.TrimSpace(reason)
+
+	for _, verdict := range []string{"PASS", "FAIL"} {
+		if len(line) >= len(verdict) && strings.EqualFold(line[:len(verdict)], verdict) {
+			if reason == "" {
+				// Reason on the verdict's line, e.g. "PASS: stays in first person"
+				reason = strings.TrimSpace(strings.TrimLeft(line[len(verdict):], "*:.-— "))
+			}
+			return verdict == "PASS", reason, nil
+		}
+	}
+	return false, "", fmt.Errorf("judge gave no PASS/FAIL verdict: %q", truncate(reply, 200))
+}
+
+// responseText joins the text blocks of a provider response
+func responseText(blocks []*llmModels.TurnBlock) string {
+	var parts []string
+	for _, block := range blocks {
+		if block != nil && block.BlockType == llmModels.BlockTypeText && block.TextContent != nil {
+			parts = append(parts, *block.TextContent)
+		}
+	}
+	return strings.TrimSpace(strings.Join(parts, ""))
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n]) + "…"
+}
@@ -0,0 +1,479 @@
+// Package eval implements EvalService: per-project test cases for prompts and skills
+// (a prompt and the criteria its response must meet), run against selected models by the
+// background job queue and scored by regex assertions or an LLM judge. Results are kept,
+// so a case's history shows how prompt and skill changes affected it.
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"meridian/internal/config"
+	"meridian/internal/domain"
+	docsysModels "meridian/internal/domain/models/docsystem"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/logging"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	// defaultOutputTokens caps each output when the run sets no max_tokens
+	defaultOutputTokens = 2048
+
+	// generationTimeout bounds one output or judge request
+	generationTimeout = 3 * time.Minute
+
+	// maxLabelLength bounds a run's label (characters)
+	maxLabelLength = 255
+
+	// Limits of the list endpoints
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+// ProviderGetter returns provider adapters by provider name (e.g. "anthropic", "openrouter")
+type ProviderGetter interface {
+	GetProvider(provider string) (llmSvc.LLMProvider, error)
+}
+
+// Service implements the EvalService interface
+type Service struct {
+	evalRepo       llmRepo.EvalRepository
+	jobs           docsysSvc.JobService
+	prompts        llmSvc.SystemPromptResolver
+	providerGetter ProviderGetter
+	authorizer     services.ResourceAuthorizer
+	judgeModel     string                    // Judge when the run sets none
+	dataPolicy     llmSvc.ProviderDataPolicy // Users' per-provider data controls (nil = not enforced)
+	logger         *slog.Logger
+}
+
+// evalJobParams is the input of an eval job
+type evalJobParams struct {
+	RunID     string `json:"run_id"`
+	MaxTokens int    `json:"max_tokens"`
+}
+
+// evalJobResult is the output of an eval job
+type evalJobResult struct {
+	RunID   string                       `json:"run_id"`
+	Summary []llmModels.EvalModelSummary `json:"summary"`
+}
+
+// NewService creates a new eval service
+func NewService(
+	evalRepo llmRepo.EvalRepository,
+	jobs docsysSvc.JobService,
+	prompts llmSvc.SystemPromptResolver,
+	providerGetter ProviderGetter,
+	authorizer services.ResourceAuthorizer,
+	judgeModel string,
+	dataPolicy llmSvc.ProviderDataPolicy,
+	logger *slog.Logger,
+) llmSvc.EvalService {
+	return &Service{
+		evalRepo:       evalRepo,
+		jobs:           jobs,
+		prompts:        prompts,
+		providerGetter: providerGetter,
+		authorizer:     authorizer,
+		judgeModel:     judgeModel,
+		dataPolicy:     dataPolicy,
+		logger:         logger,
+	}
+}
+
+// CreateCase validates and stores a new case
+func (s *Service) CreateCase(ctx context.Context, userID, projectID string, req *llmSvc.CreateEvalCaseRequest) (*llmModels.EvalCase, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	evalCase := &llmModels.EvalCase{
+		ProjectID: projectID,
+		Name:      strings.TrimSpace(req.Name),
+		Prompt:    req.Prompt,
+		Criteria:  req.Criteria,
+	}
+	if err := validateCase(evalCase); err != nil {
+		return nil, err
+	}
+
+	if err := s.evalRepo.CreateCase(ctx, evalCase); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("eval case created",
+		"id", evalCase.ID,
+		"project_id", projectID,
+		"criteria", len(evalCase.Criteria),
+	)
+	return evalCase, nil
+}
+
+// ListCases returns a project's cases
+func (s *Service) ListCases(ctx context.Context, userID, projectID string) ([]llmModels.EvalCase, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+	return s.evalRepo.ListCases(ctx, projectID)
+}
+
+// UpdateCase applies a partial update to a case. Past results keep the output they were
+// scored on.
+func (s *Service) UpdateCase(ctx context.Context, userID, projectID, caseID string, req *llmSvc.UpdateEvalCaseRequest) (*llmModels.EvalCase, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	evalCase, err := s.evalRepo.GetCase(ctx, caseID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name.IsSet() {
+		if req.Name.IsNull() {
+			return nil, fmt.Errorf("%w: name: cannot be null", domain.ErrValidation)
+		}
+		name, _ := req.Name.Value()
+		evalCase.Name = strings.TrimSpace(name)
+	}
+	if req.Prompt.IsSet() {
+		if req.Prompt.IsNull() {
+			return nil, fmt.Errorf("%w: prompt: cannot be null", domain.ErrValidation)
+		}
+		evalCase.Prompt, _ = req.Prompt.Value()
+	}
+	if req.Criteria.IsSet() {
+		evalCase.Criteria, _ = req.Criteria.Value()
+	}
+
+	if err := validateCase(evalCase); err != nil {
+		return nil, err
+	}
+	if err := s.evalRepo.UpdateCase(ctx, evalCase); err != nil {
+		return nil, err
+	}
+	return evalCase, nil
+}
+
+// DeleteCase removes a case and its results
+func (s *Service) DeleteCase(ctx context.Context, userID, projectID, caseID string) error {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return err
+	}
+
+	if err := s.evalRepo.DeleteCase(ctx, caseID, projectID); err != nil {
+		return err
+	}
+
+	s.logger.Info("eval case deleted", "id", caseID, "project_id", projectID)
+	return nil
+}
+
+// CaseHistory returns a case's results across runs
+func (s *Service) CaseHistory(ctx context.Context, userID, projectID, caseID string, limit int) ([]llmModels.EvalResult, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+	if _, err := s.evalRepo.GetCase(ctx, caseID, projectID); err != nil {
+		return nil, err
+	}
+	return s.evalRepo.ListCaseResults(ctx, caseID, listLimit(limit))
+}
+
+// StartRun validates the models and cases up front, so a run doesn't fail in the
+// background for a reason the request could have reported, then queues it
+func (s *Service) StartRun(ctx context.Context, userID, projectID string, req *llmSvc.StartEvalRunRequest) (*llmModels.EvalRun, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	run := &llmModels.EvalRun{
+		ProjectID:  projectID,
+		UserID:     userID,
+		Label:      strings.TrimSpace(req.Label),
+		Models:     distinct(req.Models),
+		Skills:     distinct(req.Skills),
+		JudgeModel: strings.TrimSpace(req.JudgeModel),
+	}
+	if run.JudgeModel == "" {
+		run.JudgeModel = s.judgeModel
+	}
+	if err := validation.ValidateStruct(run,
+		validation.Field(&run.Label, validation.RuneLength(0, maxLabelLength)),
+		validation.Field(&run.Models, validation.Required, validation.Length(1, config.MaxEvalRunModels)),
+	); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrValidation, err)
+	}
+	if req.MaxTokens < 0 || req.MaxTokens > config.MaxEvalOutputTokens {
+		return nil, fmt.Errorf("%w: max_tokens: must be between 1 and %d", domain.ErrValidation, config.MaxEvalOutputTokens)
+	}
+
+	cases, err := s.selectCases(ctx, projectID, distinct(req.CaseIDs))
+	if err != nil {
+		return nil, err
+	}
+	if generations := len(cases) * len(run.Models); generations > config.MaxEvalRunGenerations {
+		return nil, fmt.Errorf("%w: %d cases x %d models is over the limit of %d outputs per run",
+			domain.ErrValidation, len(cases), len(run.Models), config.MaxEvalRunGenerations)
+	}
+
+	models := run.Models
+	if usesJudge(cases) {
+		if run.JudgeModel == "" {
+			return nil, fmt.Errorf("%w: judge_model: required for judge criteria", domain.ErrValidation)
+		}
+		models = append(append([]string{}, models...), run.JudgeModel)
+	}
+	for _, model := range models {
+		if err := s.checkModel(ctx, userID, model); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, evalCase := range cases {
+		run.CaseIDs = append(run.CaseIDs, evalCase.ID)
+	}
+	if err := s.evalRepo.CreateRun(ctx, run); err != nil {
+		return nil, err
+	}
+
+	params, err := json.Marshal(evalJobParams{RunID: run.ID, MaxTokens: req.MaxTokens})
+	if err != nil {
+		return nil, fmt.Errorf("encode eval job: %w", err)
+	}
+	job := &docsysModels.Job{
+		ProjectID: projectID,
+		UserID:    userID,
+		Kind:      docsysModels.JobKindEval,
+		Params:    params,
+	}
+	if err := s.jobs.Enqueue(ctx, job); err != nil {
+		if deleteErr := s.evalRepo.DeleteRun(context.WithoutCancel(ctx), run.ID); deleteErr != nil {
+			s.logger.Error("failed to remove unqueued eval run", "run_id", run.ID, "error", deleteErr)
+		}
+		return nil, err
+	}
+	if err := s.evalRepo.SetRunJob(ctx, run.ID, job.ID); err != nil {
+		return nil, err
+	}
+	run.JobID = &job.ID
+	run.Status = docsysModels.JobStatusPending
+	run.Summary = []llmModels.EvalModelSummary{}
+
+	logging.FromContext(ctx, s.logger).Info("eval run queued",
+		"run_id", run.ID,
+		"job_id", job.ID,
+		"project_id", projectID,
+		"cases", len(cases),
+		"models", run.Models,
+	)
+	return run, nil
+}
+
+// ListRuns returns a project's runs, newest first
+func (s *Service) ListRuns(ctx context.Context, userID, projectID string, limit int) ([]llmModels.EvalRun, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+	return s.evalRepo.ListRuns(ctx, projectID, listLimit(limit))
+}
+
+// GetRun returns a run with its results
+func (s *Service) GetRun(ctx context.Context, userID, projectID, runID string) (*llmModels.EvalRun, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	run, err := s.evalRepo.GetRun(ctx, runID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	run.Results, err = s.evalRepo.ListResults(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// RunJob generates each case's output with each model and scores it. A case deleted
+// since the run was queued is reported as a job error and skipped; a failed generation is
+// stored as an errored result. A requeued job (after a shutdown) starts over.
+func (s *Service) RunJob(ctx context.Context, job *docsysModels.Job, progress docsysSvc.JobProgressFunc) (any, error) {
+	var params evalJobParams
+	if err := json.Unmarshal(job.Params, &params); err != nil {
+		return nil, fmt.Errorf("decode eval job: %w", err)
+	}
+	maxTokens := params.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultOutputTokens
+	}
+
+	run, err := s.evalRepo.GetRun(ctx, params.RunID, job.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.evalRepo.DeleteResults(ctx, run.ID); err != nil {
+		return nil, err
+	}
+
+	system, err := s.prompts.ResolveProject(ctx, run.ProjectID, run.UserID, run.Skills)
+	if err != nil {
+		return nil, fmt.Errorf("resolve system prompt: %w", err)
+	}
+
+	logger := logging.FromContext(ctx, s.logger).With("run_id", run.ID)
+	var results []llmModels.EvalResult
+	for _, caseID := range run.CaseIDs {
+		evalCase, err := s.evalRepo.GetCase(ctx, caseID, run.ProjectID)
+		if errors.Is(err, domain.ErrNotFound) {
+			progress(caseID, "case was deleted")
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, model := range run.Models {
+			result := s.evaluate(ctx, run, evalCase, model, system, maxTokens)
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if err := s.evalRepo.SaveResult(ctx, result); err != nil {
+				return nil, err
+			}
+			results = append(results, *result)
+
+			var resultErr string
+			if result.Error != nil {
+				resultErr = *result.Error
+			}
+			progress(fmt.Sprintf("%s (%s)", evalCase.Name, model), resultErr)
+		}
+	}
+
+	summary := llmModels.SummarizeEvalResults(run.Models, results)
+	if err := s.evalRepo.CompleteRun(ctx, run.ID, summary); err != nil {
+		return nil, err
+	}
+
+	logger.Info("eval run completed", "results", len(results), "summary", summary)
+	return evalJobResult{RunID: run.ID, Summary: summary}, nil
+}
+
+// selectCases loads the requested cases, or all of the project's when none are requested
+func (s *Service) selectCases(ctx context.Context, projectID string, caseIDs []string) ([]llmModels.EvalCase, error) {
+	if len(caseIDs) == 0 {
+		cases, err := s.evalRepo.ListCases(ctx, projectID)
+		if err != nil {
+			return nil, err
+		}
+		if len(cases) == 0 {
+			return nil, fmt.Errorf("%w: the project has no eval cases", domain.ErrValidation)
+		}
+		return cases, nil
+	}
+
+	cases := make([]llmModels.EvalCase, 0, len(caseIDs))
+	for _, caseID := range caseIDs {
+		evalCase, err := s.evalRepo.GetCase(ctx, caseID, projectID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: case_ids: eval case %s not found", domain.ErrValidation, caseID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, *evalCase)
+	}
+	return cases, nil
+}
+
+// checkModel applies the user's data controls to the model's provider and checks the
+// provider is configured
+func (s *Service) checkModel(ctx context.Context, userID, model string) error {
+	provider := providerFor(model)
+	if s.dataPolicy != nil {
+		if err := s.dataPolicy.CheckProvider(ctx, userID, provider); err != nil {
+			return err
+		}
+	}
+	if _, err := s.providerGetter.GetProvider(provider); err != nil {
+		return fmt.Errorf("%w: model %s: provider %s is unavailable", domain.ErrValidation, model, provider)
+	}
+	return nil
+}
+
+// validateCase trims and validates a case's fields (each criterion validates itself)
+func validateCase(evalCase *llmModels.EvalCase) error {
+	for i := range evalCase.Criteria {
+		evalCase.Criteria[i].Type = strings.TrimSpace(evalCase.Criteria[i].Type)
+	}
+	err := validation.ValidateStruct(evalCase,
+		validation.Field(&evalCase.Name, validation.Required, validation.RuneLength(1, config.MaxEvalCaseNameLength)),
+		validation.Field(&evalCase.Prompt, validation.Required, validation.RuneLength(1, config.MaxEvalPromptLength)),
+		validation.Field(&evalCase.Criteria, validation.Required, validation.Length(1, config.MaxEvalCriteria)),
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrValidation, err)
+	}
+	if strings.TrimSpace(evalCase.Prompt) == "" {
+		return fmt.Errorf("%w: prompt: cannot be blank", domain.ErrValidation)
+	}
+	return nil
+}
+
+// usesJudge reports whether any case has a judge criterion
+func usesJudge(cases []llmModels.EvalCase) bool {
+	for _, evalCase := range cases {
+		for _, criterion := range evalCase.Criteria {
+			if criterion.Type == llmModels.EvalCriterionJudge {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// providerFor resolves a model's provider the way turns do: the model mapping, else openrouter
+func providerFor(model string) string {
+	if provider, found := llmModels.GetProviderForModel(model); found {
+		return provider
+	}
+	return "openrouter"
+}
+
+// distinct trims values and drops blanks and duplicates, keeping the first occurrence
+func distinct(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var out []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		out = append(out, value)
+	}
+	return out
+}
+
+func listLimit(limit int) int {
+	if limit <= 0 {
+		return defaultListLimit
+	}
+	if limit > maxListLimit {
+		return maxListLimit
+	}
+	return limit
+}
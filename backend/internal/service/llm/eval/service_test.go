@@ -0,0 +1,419 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"meridian/internal/domain"
+	docsysModels "meridian/internal/domain/models/docsystem"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/testmocks"
+)
+
+// fakeEvalRepo keeps cases, runs and results in memory; unused methods are left to the
+// embedded interface
+type fakeEvalRepo struct {
+	llmRepo.EvalRepository
+	cases   []llmModels.EvalCase
+	runs    map[string]*llmModels.EvalRun
+	results []llmModels.EvalResult
+}
+
+func (f *fakeEvalRepo) GetCase(ctx context.Context, caseID, projectID string) (*llmModels.EvalCase, error) {
+	for _, evalCase := range f.cases {
+		if evalCase.ID == caseID && evalCase.ProjectID == projectID {
+			return &evalCase, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (f *fakeEvalRepo) ListCases(ctx context.Context, projectID string) ([]llmModels.EvalCase, error) {
+	var cases []llmModels.EvalCase
+	for _, evalCase := range f.cases {
+		if evalCase.ProjectID == projectID {
+			cases = append(cases, evalCase)
+		}
+	}
+	return cases, nil
+}
+
+func (f *fakeEvalRepo) CreateRun(ctx context.Context, run *llmModels.EvalRun) error {
+	run.ID = fmt.Sprintf("run-%d", len(f.runs)+1)
+	stored := *run
+	f.runs[run.ID] = &stored
+	return nil
+}
+
+func (f *fakeEvalRepo) SetRunJob(ctx context.Context, runID, jobID string) error {
+	f.runs[runID].JobID = &jobID
+	return nil
+}
+
+func (f *fakeEvalRepo) DeleteRun(ctx context.Context, runID string) error {
+	delete(f.runs, runID)
+	return nil
+}
+
+func (f *fakeEvalRepo) GetRun(ctx context.Context, runID, projectID string) (*llmModels.EvalRun, error) {
+	run, ok := f.runs[runID]
+	if !ok || run.ProjectID != projectID {
+		return nil, domain.ErrNotFound
+	}
+	copied := *run
+	return &copied, nil
+}
+
+func (f *fakeEvalRepo) CompleteRun(ctx context.Context, runID string, summary []llmModels.EvalModelSummary) error {
+	f.runs[runID].Summary = summary
+	return nil
+}
+
+func (f *fakeEvalRepo) SaveResult(ctx context.Context, result *llmModels.EvalResult) error {
+	f.results = append(f.results, *result)
+	return nil
+}
+
+func (f *fakeEvalRepo) DeleteResults(ctx context.Context, runID string) error {
+	kept := f.results[:0]
+	for _, result := range f.results {
+		if result.RunID != runID {
+			kept = append(kept, result)
+		}
+	}
+	f.results = kept
+	return nil
+}
+
+// fakeJobs records queued jobs
+type fakeJobs struct {
+	docsysSvc.JobService
+	queued []*docsysModels.Job
+	err    error
+}
+
+func (f *fakeJobs) Enqueue(ctx context.Context, job *docsysModels.Job) error {
+	if f.err != nil {
+		return f.err
+	}
+	job.ID = fmt.Sprintf("job-%d", len(f.queued)+1)
+	f.queued = append(f.queued, job)
+	return nil
+}
+
+// fakePrompts returns a fixed system prompt naming the skills
+type fakePrompts struct {
+	llmSvc.SystemPromptResolver
+}
+
+func (f *fakePrompts) ResolveProject(ctx context.Context, projectID, userID string, selectedSkills []string) (*string, error) {
+	system := "Project prompt. Skills: " + strings.Join(selectedSkills, ",")
+	return &system, nil
+}
+
+// fakeProvider answers judge requests with verdicts[criterion] and other requests with
+// outputs[model]; a model without an output fails
+type fakeProvider struct {
+	llmSvc.LLMProvider
+	outputs  map[string]string
+	verdicts map[string]string
+	requests []*llmSvc.GenerateRequest
+}
+
+func (f *fakeProvider) GenerateResponse(ctx context.Context, req *llmSvc.GenerateRequest) (*llmSvc.GenerateResponse, error) {
+	f.requests = append(f.requests, req)
+	message := *req.Messages[0].Content[0].TextContent
+
+	var text string
+	if *req.Params.System == judgeSystemPrompt {
+		for criterion, verdict := range f.verdicts {
+			if strings.HasSuffix(message, criterion) {
+				text = verdict
+			}
+		}
+	} else {
+		output, ok := f.outputs[req.Model]
+		if !ok {
+			return nil, errors.New("model overloaded")
+		}
+		text = output
+	}
+	return &llmSvc.GenerateResponse{
+		Content:      []*llmModels.TurnBlock{{BlockType: llmModels.BlockTypeText, TextContent: &text}},
+		InputTokens:  50,
+		OutputTokens: 20,
+	}, nil
+}
+
+type fakeProviderGetter struct {
+	provider *fakeProvider
+}
+
+func (f *fakeProviderGetter) GetProvider(name string) (llmSvc.LLMProvider, error) {
+	return f.provider, nil
+}
+
+var testCases = []llmModels.EvalCase{
+	{ID: "case-1", ProjectID: "project-1", Name: "Opening", Prompt: "Write chapter 1's opening.", Criteria: []llmModels.EvalCriterion{
+		{Type: llmModels.EvalCriterionRegex, Value: `(?i)chapter \d+`},
+		{Type: llmModels.EvalCriterionNotRegex, Value: "As an AI"},
+		{Type: llmModels.EvalCriterionJudge, Value: "Written in the first person"},
+	}},
+	{ID: "case-2", ProjectID: "project-1", Name: "Title", Prompt: "Suggest a title.", Criteria: []llmModels.EvalCriterion{
+		{Type: llmModels.EvalCriterionRegex, Value: `^[A-Z]`},
+	}},
+}
+
+func newTestService() (*Service, *fakeEvalRepo, *fakeJobs, *fakeProvider) {
+	repo := &fakeEvalRepo{cases: testCases, runs: map[string]*llmModels.EvalRun{}}
+	jobs := &fakeJobs{}
+	provider := &fakeProvider{}
+	svc := NewService(repo, jobs, &fakePrompts{}, &fakeProviderGetter{provider: provider}, &testmocks.Authorizer{},
+		"judge-model", nil, slog.New(slog.NewTextHandler(io.Discard, nil))).(*Service)
+	return svc, repo, jobs, provider
+}
+
+func TestStartRun_QueuesJob(t *testing.T) {
+	svc, repo, jobs, _ := newTestService()
+
+	run, err := svc.StartRun(context.Background(), "user-1", "project-1", &llmSvc.StartEvalRunRequest{
+		Label:  " tighter outline skill ",
+		Models: []string{"model-a", "model-b", "model-a"},
+		Skills: []string{"outline"},
+	})
+	if err != nil {
+		t.Fatalf("StartRun: %v", err)
+	}
+
+	if run.Label != "tighter outline skill" || strings.Join(run.Models, ",") != "model-a,model-b" {
+		t.Errorf("run = %+v, want trimmed label and distinct models", run)
+	}
+	if strings.Join(run.CaseIDs, ",") != "case-1,case-2" || run.JudgeModel != "judge-model" {
+		t.Errorf("run = %+v, want every case and the default judge", run)
+	}
+	if len(jobs.queued) != 1 || jobs.queued[0].Kind != docsysModels.JobKindEval {
+		t.Fatalf("queued = %+v, want one eval job", jobs.queued)
+	}
+	if run.JobID == nil || *run.JobID != jobs.queued[0].ID || repo.runs[run.ID].JobID == nil {
+		t.Errorf("run not linked to its job: %+v", run)
+	}
+
+	var params evalJobParams
+	if err := json.Unmarshal(jobs.queued[0].Params, &params); err != nil || params.RunID != run.ID {
+		t.Errorf("params = %s, want the run ID", jobs.queued[0].Params)
+	}
+}
+
+func TestStartRun_RemovesRunWhenQueueFails(t *testing.T) {
+	svc, repo, jobs, _ := newTestService()
+	jobs.err = errors.New("queue unavailable")
+
+	_, err := svc.StartRun(context.Background(), "user-1", "project-1", &llmSvc.StartEvalRunRequest{Models: []string{"model-a"}})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(repo.runs) != 0 {
+		t.Errorf("runs = %v, want the unqueued run removed", repo.runs)
+	}
+}
+
+func TestStartRun_Validation(t *testing.T) {
+	tests := map[string]struct {
+		req        llmSvc.StartEvalRunRequest
+		judgeModel string
+	}{
+		"no models":      {req: llmSvc.StartEvalRunRequest{Models: []string{" "}}},
+		"too many":       {req: llmSvc.StartEvalRunRequest{Models: []string{"a", "b", "c", "d", "e", "f"}}},
+		"unknown case":   {req: llmSvc.StartEvalRunRequest{Models: []string{"a"}, CaseIDs: []string{"case-9"}}},
+		"max tokens":     {req: llmSvc.StartEvalRunRequest{Models: []string{"a"}, MaxTokens: 1 << 20}},
+		"judge required": {req: llmSvc.StartEvalRunRequest{Models: []string{"a"}, CaseIDs: []string{"case-1"}}, judgeModel: "-"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			svc, _, jobs, _ := newTestService()
+			if tt.judgeModel == "-" {
+				svc.judgeModel = ""
+			}
+			if _, err := svc.StartRun(context.Background(), "user-1", "project-1", &tt.req); !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("err = %v, want ErrValidation", err)
+			}
+			if len(jobs.queued) != 0 {
+				t.Error("invalid run was queued")
+			}
+		})
+	}
+
+	// Regex-only cases don't need a judge
+	svc, _, _, _ := newTestService()
+	svc.judgeModel = ""
+	if _, err := svc.StartRun(context.Background(), "user-1", "project-1", &llmSvc.StartEvalRunRequest{Models: []string{"a"}, CaseIDs: []string{"case-2"}}); err != nil {
+		t.Errorf("regex-only run refused: %v", err)
+	}
+}
+
+func TestRunJob_ScoresAndSummarizes(t *testing.T) {
+	svc, repo, jobs, provider := newTestService()
+	provider.outputs = map[string]string{"model-a": "Chapter 1. I woke to the sound of gulls."}
+	provider.verdicts = map[string]string{"Written in the first person": "PASS\nNarrated by \"I\" throughout."}
+
+	run, err := svc.StartRun(context.Background(), "user-1", "project-1", &llmSvc.StartEvalRunRequest{
+		Models: []string{"model-a", "model-b"},
+		Skills: []string{"outline"},
+	})
+	if err != nil {
+		t.Fatalf("StartRun: %v", err)
+	}
+
+	var progressed []string
+	progress := func(file, fileErr string) { progressed = append(progressed, file+"|"+fileErr) }
+	out, err := svc.RunJob(context.Background(), jobs.queued[0], progress)
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+
+	if len(repo.results) != 4 || len(progressed) != 4 {
+		t.Fatalf("got %d results and %d progress calls, want 4", len(repo.results), len(progressed))
+	}
+
+	opening := repo.results[0]
+	if opening.Model != "model-a" || !opening.Passed || opening.Score != 1 || len(opening.Assertions) != 3 {
+		t.Errorf("opening result = %+v, want every criterion met", opening)
+	}
+	if judged := opening.Assertions[2]; !judged.Passed || judged.Reason != `Narrated by "I" throughout.` {
+		t.Errorf("judge assertion = %+v", judged)
+	}
+	title := repo.results[2]
+	if title.CaseID != "case-2" || title.Model != "model-a" || !title.Passed {
+		t.Errorf("title result = %+v", title)
+	}
+	failed := repo.results[1]
+	if failed.Model != "model-b" || failed.Error == nil || failed.Passed || !strings.Contains(progressed[1], "model overloaded") {
+		t.Errorf("model-b result = %+v, want the generation error", failed)
+	}
+
+	if !strings.Contains(*provider.requests[0].Params.System, "Skills: outline") {
+		t.Errorf("system prompt = %q, want the run's skills", *provider.requests[0].Params.System)
+	}
+
+	summary := repo.runs[run.ID].Summary
+	if len(summary) != 2 || summary[0].PassRate != 1 || summary[1].Errors != 2 || summary[1].PassRate != 0 {
+		t.Errorf("summary = %+v", summary)
+	}
+	if result, ok := out.(evalJobResult); !ok || result.RunID != run.ID {
+		t.Errorf("job result = %+v", out)
+	}
+
+	// A requeued job starts over rather than duplicating results
+	if _, err := svc.RunJob(context.Background(), jobs.queued[0], progress); err != nil {
+		t.Fatalf("RunJob again: %v", err)
+	}
+	if len(repo.results) != 4 {
+		t.Errorf("got %d results after rerun, want 4", len(repo.results))
+	}
+}
+
+func TestRunJob_SkipsDeletedCases(t *testing.T) {
+	svc, repo, jobs, provider := newTestService()
+	provider.outputs = map[string]string{"model-a": "Dune"}
+
+	if _, err := svc.StartRun(context.Background(), "user-1", "project-1", &llmSvc.StartEvalRunRequest{
+		Models:  []string{"model-a"},
+		CaseIDs: []string{"case-2"},
+	}); err != nil {
+		t.Fatalf("StartRun: %v", err)
+	}
+	repo.cases = nil
+
+	var progressed []string
+	_, err := svc.RunJob(context.Background(), jobs.queued[0], func(file, fileErr string) {
+		progressed = append(progressed, fileErr)
+	})
+	if err != nil {
+		t.Fatalf("RunJob: %v", err)
+	}
+	if len(repo.results) != 0 || len(progressed) != 1 || progressed[0] != "case was deleted" {
+		t.Errorf("results = %+v, progress = %v", repo.results, progressed)
+	}
+}
+
+func TestValidateCase(t *testing.T) {
+	valid := func() *llmModels.EvalCase {
+		return &llmModels.EvalCase{Name: "Opening", Prompt: "Write the opening.", Criteria: []llmModels.EvalCriterion{
+			{Type: " regex ", Value: "harbor"},
+		}}
+	}
+
+	evalCase := valid()
+	if err := validateCase(evalCase); err != nil {
+		t.Fatalf("validateCase: %v", err)
+	}
+	if evalCase.Criteria[0].Type != llmModels.EvalCriterionRegex {
+		t.Errorf("type not trimmed: %q", evalCase.Criteria[0].Type)
+	}
+
+	tests := map[string]func(*llmModels.EvalCase){
+		"no name":       func(c *llmModels.EvalCase) { c.Name = "" },
+		"blank prompt":  func(c *llmModels.EvalCase) { c.Prompt = " \n" },
+		"no criteria":   func(c *llmModels.EvalCase) { c.Criteria = nil },
+		"bad pattern":   func(c *llmModels.EvalCase) { c.Criteria[0].Value = "(" },
+		"unknown type":  func(c *llmModels.EvalCase) { c.Criteria[0].Type = "contains" },
+		"blank rubric":  func(c *llmModels.EvalCase) { c.Criteria[0] = llmModels.EvalCriterion{Type: "judge"} },
+		"long criteria": func(c *llmModels.EvalCase) { c.Criteria = make([]llmModels.EvalCriterion, 21) },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			evalCase := valid()
+			mutate(evalCase)
+			if err := validateCase(evalCase); !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("err = %v, want ErrValidation", err)
+			}
+		})
+	}
+}
+
+func TestParseVerdict(t *testing.T) {
+	tests := []struct {
+		reply  string
+		passed bool
+		reason string
+	}{
+		{"PASS\nStays in the first person.", true, "Stays in the first person."},
+		{"**FAIL**\n\nSwitches to third person in paragraph 2.", false, "Switches to third person in paragraph 2."},
+		{"Pass: every line rhymes", true, "every line rhymes"},
+		{"fail - too long", false, "too long"},
+	}
+	for _, tt := range tests {
+		passed, reason, err := parseVerdict(tt.reply)
+		if err != nil || passed != tt.passed || reason != tt.reason {
+			t.Errorf("parseVerdict(%q) = %v, %q, %v", tt.reply, passed, reason, err)
+		}
+	}
+
+	if _, _, err := parseVerdict("The response mostly meets the criterion."); err == nil {
+		t.Error("expected an error for a reply without a verdict")
+	}
+}
+
+func TestCheckPattern(t *testing.T) {
+	output := "Chapter 3 opens at the harbor."
+
+	if a := checkPattern(llmModels.EvalCriterion{Type: llmModels.EvalCriterionRegex, Value: `Chapter \d`}, output); !a.Passed {
+		t.Errorf("regex = %+v, want passed", a)
+	}
+	if a := checkPattern(llmModels.EvalCriterion{Type: llmModels.EvalCriterionRegex, Value: `^The`}, output); a.Passed || a.Reason == "" {
+		t.Errorf("regex = %+v, want failed with a reason", a)
+	}
+	a := checkPattern(llmModels.EvalCriterion{Type: llmModels.EvalCriterionNotRegex, Value: `(?i)harbou?r`}, output)
+	if a.Passed || a.Reason != `output contains "harbor"` {
+		t.Errorf("not_regex = %+v, want failed naming the match", a)
+	}
+}
@@ -0,0 +1,174 @@
+// Package experiment runs prompt/params A/B experiments: it assigns new assistant turns
+// a variant of each active experiment, records the assignment on the turn, and
+// aggregates usage and user feedback per variant for the admin API.
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/domain/services"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+// Service implements the ExperimentService interface
+type Service struct {
+	repo       llmRepo.ExperimentRepository
+	turnReader llmRepo.TurnReader
+	authorizer services.ResourceAuthorizer
+	logger     *slog.Logger
+}
+
+// NewService creates an experiment service
+func NewService(
+	repo llmRepo.ExperimentRepository,
+	turnReader llmRepo.TurnReader,
+	authorizer services.ResourceAuthorizer,
+	logger *slog.Logger,
+) llmSvc.ExperimentService {
+	return &Service{
+		repo:       repo,
+		turnReader: turnReader,
+		authorizer: authorizer,
+		logger:     logger,
+	}
+}
+
+// CreateExperiment validates and stores a new experiment
+func (s *Service) CreateExperiment(ctx context.Context, req *llmSvc.CreateExperimentRequest) (*llmModels.Experiment, error) {
+	experiment := &llmModels.Experiment{
+		Name:        req.Name,
+		Description: req.Description,
+		Unit:        req.Unit,
+		Status:      req.Status,
+		Variants:    req.Variants,
+	}
+	if experiment.Status == "" {
+		experiment.Status = llmModels.ExperimentActive
+	}
+	if err := experiment.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrValidation, err)
+	}
+
+	if err := s.repo.CreateExperiment(ctx, experiment); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("experiment created",
+		"id", experiment.ID,
+		"name", experiment.Name,
+		"unit", experiment.Unit,
+		"variants", len(experiment.Variants),
+	)
+	return experiment, nil
+}
+
+// ListExperiments returns all experiments, newest first
+func (s *Service) ListExperiments(ctx context.Context) ([]llmModels.Experiment, error) {
+	return s.repo.ListExperiments(ctx)
+}
+
+// SetExperimentStatus starts or stops an experiment
+func (s *Service) SetExperimentStatus(ctx context.Context, experimentID, status string) (*llmModels.Experiment, error) {
+	switch status {
+	case llmModels.ExperimentActive, llmModels.ExperimentStopped:
+	default:
+		return nil, fmt.Errorf("%w: status must be %q or %q", domain.ErrValidation, llmModels.ExperimentActive, llmModels.ExperimentStopped)
+	}
+
+	if err := s.repo.UpdateExperimentStatus(ctx, experimentID, status); err != nil {
+		return nil, err
+	}
+	s.logger.Info("experiment status changed", "id", experimentID, "status", status)
+
+	return s.repo.GetExperiment(ctx, experimentID)
+}
+
+// GetResults aggregates the experiment's turns by variant. Variants without turns are
+// listed with zero counts, in the experiment's order.
+func (s *Service) GetResults(ctx context.Context, experimentID string) (*llmModels.ExperimentResults, error) {
+	experiment, err := s.repo.GetExperiment(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+	recorded, err := s.repo.GetVariantResults(ctx, experimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	byVariant := make(map[string]llmModels.ExperimentVariantResult, len(recorded))
+	for _, result := range recorded {
+		byVariant[result.Variant] = result
+	}
+
+	results := &llmModels.ExperimentResults{
+		Experiment: experiment,
+		Variants:   make([]llmModels.ExperimentVariantResult, 0, len(experiment.Variants)),
+	}
+	for _, variant := range experiment.Variants {
+		result, ok := byVariant[variant.Name]
+		if !ok {
+			result = llmModels.ExperimentVariantResult{Variant: variant.Name}
+		}
+		if rated := result.FeedbackUp + result.FeedbackDown; rated > 0 {
+			result.FeedbackScore = float64(result.FeedbackUp-result.FeedbackDown) / float64(rated)
+		}
+		results.Variants = append(results.Variants, result)
+	}
+
+	return results, nil
+}
+
+// SetTurnFeedback rates an assistant turn the user can access
+func (s *Service) SetTurnFeedback(ctx context.Context, userID, turnID string, rating *int) error {
+	if rating != nil && *rating != llmModels.FeedbackUp && *rating != llmModels.FeedbackDown {
+		return fmt.Errorf("%w: rating must be 1, -1 or null", domain.ErrValidation)
+	}
+	if err := s.authorizer.CanAccessTurn(ctx, userID, turnID); err != nil {
+		return err
+	}
+
+	turn, err := s.turnReader.GetTurn(ctx, turnID)
+	if err != nil {
+		return err
+	}
+	if turn.Role != "assistant" {
+		return fmt.Errorf("%w: only assistant turns can be rated", domain.ErrValidation)
+	}
+
+	return s.repo.SetTurnFeedback(ctx, turnID, rating)
+}
+
+// AssignTurn returns the variant of every active experiment for a new turn
+func (s *Service) AssignTurn(ctx context.Context, userID, chatID string) ([]llmModels.ExperimentAssignment, error) {
+	experiments, err := s.repo.ListActiveExperiments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var assignments []llmModels.ExperimentAssignment
+	for i := range experiments {
+		experiment := &experiments[i]
+		unitID := userID
+		if experiment.Unit == llmModels.ExperimentUnitChat {
+			if chatID == "" {
+				continue // Cold start: the chat is assigned from its next turn on
+			}
+			unitID = chatID
+		}
+		assignments = append(assignments, llmModels.ExperimentAssignment{
+			ExperimentID: experiment.ID,
+			Variant:      experiment.Assign(unitID),
+		})
+	}
+	return assignments, nil
+}
+
+// RecordTurn stores the assignments on the created assistant turn
+func (s *Service) RecordTurn(ctx context.Context, turnID string, assignments []llmModels.ExperimentAssignment) error {
+	return s.repo.RecordTurnVariants(ctx, turnID, assignments)
+}
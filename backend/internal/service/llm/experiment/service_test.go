@@ -0,0 +1,234 @@
+package experiment
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/testmocks"
+)
+
+// fakeExperimentRepo serves fixed experiments and results, recording writes
+type fakeExperimentRepo struct {
+	llmRepo.ExperimentRepository
+	experiments []llmModels.Experiment
+	results     []llmModels.ExperimentVariantResult
+	created     []*llmModels.Experiment
+	feedback    map[string]*int
+}
+
+func (f *fakeExperimentRepo) CreateExperiment(ctx context.Context, experiment *llmModels.Experiment) error {
+	experiment.ID = "experiment-new"
+	f.created = append(f.created, experiment)
+	return nil
+}
+
+func (f *fakeExperimentRepo) GetExperiment(ctx context.Context, experimentID string) (*llmModels.Experiment, error) {
+	for i := range f.experiments {
+		if f.experiments[i].ID == experimentID {
+			experiment := f.experiments[i]
+			return &experiment, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (f *fakeExperimentRepo) ListActiveExperiments(ctx context.Context) ([]llmModels.Experiment, error) {
+	return f.experiments, nil
+}
+
+func (f *fakeExperimentRepo) GetVariantResults(ctx context.Context, experimentID string) ([]llmModels.ExperimentVariantResult, error) {
+	return f.results, nil
+}
+
+func (f *fakeExperimentRepo) SetTurnFeedback(ctx context.Context, turnID string, rating *int) error {
+	if f.feedback == nil {
+		f.feedback = map[string]*int{}
+	}
+	f.feedback[turnID] = rating
+	return nil
+}
+
+// fakeTurnReader serves fixed turns; other TurnReader methods are unused
+type fakeTurnReader struct {
+	llmRepo.TurnReader
+	turns map[string]llmModels.Turn
+}
+
+func (f *fakeTurnReader) GetTurn(ctx context.Context, turnID string) (*llmModels.Turn, error) {
+	turn, ok := f.turns[turnID]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &turn, nil
+}
+
+func newTestService(repo *fakeExperimentRepo, turns *fakeTurnReader, authorizer *testmocks.Authorizer) llmSvc.ExperimentService {
+	if turns == nil {
+		turns = &fakeTurnReader{}
+	}
+	if authorizer == nil {
+		authorizer = &testmocks.Authorizer{}
+	}
+	return NewService(repo, turns, authorizer, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func twoVariants() []llmModels.ExperimentVariant {
+	return []llmModels.ExperimentVariant{
+		{Name: "control", Weight: 1},
+		{Name: "concise", Weight: 1},
+	}
+}
+
+func TestService_CreateExperiment(t *testing.T) {
+	repo := &fakeExperimentRepo{}
+	svc := newTestService(repo, nil, nil)
+
+	experiment, err := svc.CreateExperiment(context.Background(), &llmSvc.CreateExperimentRequest{
+		Name:     "concise-prompt",
+		Unit:     llmModels.ExperimentUnitChat,
+		Variants: twoVariants(),
+	})
+	if err != nil {
+		t.Fatalf("CreateExperiment: %v", err)
+	}
+	if experiment.Status != llmModels.ExperimentActive {
+		t.Errorf("status = %q, want %q by default", experiment.Status, llmModels.ExperimentActive)
+	}
+	if len(repo.created) != 1 {
+		t.Fatalf("created %d experiments, want 1", len(repo.created))
+	}
+
+	_, err = svc.CreateExperiment(context.Background(), &llmSvc.CreateExperimentRequest{
+		Name:     "one-arm",
+		Unit:     llmModels.ExperimentUnitUser,
+		Variants: twoVariants()[:1],
+	})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("one variant: error = %v, want ErrValidation", err)
+	}
+}
+
+func TestService_SetExperimentStatus_RejectsUnknownStatus(t *testing.T) {
+	svc := newTestService(&fakeExperimentRepo{}, nil, nil)
+
+	_, err := svc.SetExperimentStatus(context.Background(), "experiment-1", "paused")
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("error = %v, want ErrValidation", err)
+	}
+}
+
+func TestService_GetResults(t *testing.T) {
+	repo := &fakeExperimentRepo{
+		experiments: []llmModels.Experiment{{ID: "experiment-1", Variants: twoVariants()}},
+		results: []llmModels.ExperimentVariantResult{
+			{Variant: "concise", Turns: 5, FeedbackUp: 3, FeedbackDown: 1},
+		},
+	}
+	svc := newTestService(repo, nil, nil)
+
+	results, err := svc.GetResults(context.Background(), "experiment-1")
+	if err != nil {
+		t.Fatalf("GetResults: %v", err)
+	}
+	if len(results.Variants) != 2 {
+		t.Fatalf("got %d variants, want 2 (including the one without turns)", len(results.Variants))
+	}
+
+	control, concise := results.Variants[0], results.Variants[1]
+	if control.Variant != "control" || control.Turns != 0 || control.FeedbackScore != 0 {
+		t.Errorf("control = %+v, want an empty result", control)
+	}
+	if concise.Variant != "concise" || concise.Turns != 5 || concise.FeedbackScore != 0.5 {
+		t.Errorf("concise = %+v, want 5 turns scoring 0.5", concise)
+	}
+}
+
+func TestService_SetTurnFeedback(t *testing.T) {
+	up, sideways := llmModels.FeedbackUp, 2
+	turns := &fakeTurnReader{turns: map[string]llmModels.Turn{
+		"user-turn":      {ID: "user-turn", Role: "user"},
+		"assistant-turn": {ID: "assistant-turn", Role: "assistant"},
+	}}
+
+	tests := []struct {
+		name       string
+		turnID     string
+		rating     *int
+		authorizer *testmocks.Authorizer
+		wantErr    error
+	}{
+		{name: "rate", turnID: "assistant-turn", rating: &up},
+		{name: "clear", turnID: "assistant-turn", rating: nil},
+		{name: "bad rating", turnID: "assistant-turn", rating: &sideways, wantErr: domain.ErrValidation},
+		{name: "user turn", turnID: "user-turn", rating: &up, wantErr: domain.ErrValidation},
+		{
+			name:       "forbidden",
+			turnID:     "assistant-turn",
+			rating:     &up,
+			authorizer: &testmocks.Authorizer{Err: domain.ErrForbidden},
+			wantErr:    domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeExperimentRepo{}
+			svc := newTestService(repo, turns, tt.authorizer)
+
+			err := svc.SetTurnFeedback(context.Background(), "user-1", tt.turnID, tt.rating)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				if _, ok := repo.feedback[tt.turnID]; ok {
+					t.Error("feedback stored despite the error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetTurnFeedback: %v", err)
+			}
+			if got, ok := repo.feedback[tt.turnID]; !ok || got != tt.rating {
+				t.Errorf("stored rating = %v, want %v", got, tt.rating)
+			}
+		})
+	}
+}
+
+func TestService_AssignTurn(t *testing.T) {
+	repo := &fakeExperimentRepo{experiments: []llmModels.Experiment{
+		{ID: "by-user", Unit: llmModels.ExperimentUnitUser, Variants: twoVariants()},
+		{ID: "by-chat", Unit: llmModels.ExperimentUnitChat, Variants: twoVariants()},
+	}}
+	svc := newTestService(repo, nil, nil)
+
+	assignments, err := svc.AssignTurn(context.Background(), "user-1", "chat-1")
+	if err != nil {
+		t.Fatalf("AssignTurn: %v", err)
+	}
+	if len(assignments) != 2 {
+		t.Fatalf("got %d assignments, want 2", len(assignments))
+	}
+	if want := repo.experiments[0].Assign("user-1"); assignments[0].Variant.Name != want.Name {
+		t.Errorf("user-unit variant = %q, want %q", assignments[0].Variant.Name, want.Name)
+	}
+	if want := repo.experiments[1].Assign("chat-1"); assignments[1].Variant.Name != want.Name {
+		t.Errorf("chat-unit variant = %q, want %q", assignments[1].Variant.Name, want.Name)
+	}
+
+	// Cold start: no chat yet, so only the user-unit experiment applies
+	assignments, err = svc.AssignTurn(context.Background(), "user-1", "")
+	if err != nil {
+		t.Fatalf("AssignTurn (cold start): %v", err)
+	}
+	if len(assignments) != 1 || assignments[0].ExperimentID != "by-user" {
+		t.Errorf("cold start assignments = %+v, want only by-user", assignments)
+	}
+}
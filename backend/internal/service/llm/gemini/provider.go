@@ -0,0 +1,258 @@
+// Package gemini implements the meridian-llm-go Provider interface for the Google Gemini
+// API (generateContent), so Gemini models can be used with a Gemini API key instead of
+// through OpenRouter.
+//
+// Text, thought summaries and function calls stream as library blocks; all tools are
+// function tools executed by the backend. Thought signatures, which Gemini needs back to
+// keep its reasoning across tool rounds, are kept in the blocks' provider data.
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+const (
+	// DefaultBaseURL is the Gemini API base URL
+	DefaultBaseURL = "https://generativelanguage.googleapis.com/v1beta"
+
+	// requestTimeout bounds a whole request, including a streamed response
+	requestTimeout = 10 * time.Minute
+)
+
+// Config configures a Provider
+type Config struct {
+	APIKey  string
+	BaseURL string // Empty = DefaultBaseURL
+}
+
+// Provider implements llmprovider.Provider for the Gemini API
+type Provider struct {
+	apiKey     string
+	baseURL    string
+	httpClient *http.Client
+}
+
+var _ llmprovider.Provider = (*Provider)(nil)
+
+// NewProvider creates a Gemini provider
+func NewProvider(cfg Config) (*Provider, error) {
+	if cfg.APIKey == "" {
+		return nil, llmprovider.ErrInvalidAPIKey
+	}
+
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return &Provider{
+		apiKey:     cfg.APIKey,
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// Name returns the provider identifier
+func (p *Provider) Name() llmprovider.ProviderID {
+	return llmprovider.ProviderGoogle
+}
+
+// SupportsModel returns true for Gemini model IDs (e.g. "gemini-2.5-flash")
+func (p *Provider) SupportsModel(model string) bool {
+	model = strings.ToLower(model)
+	return strings.HasPrefix(model, "gemini-") && !strings.Contains(model, "/")
+}
+
+// GenerateResponse generates a complete response
+func (p *Provider) GenerateResponse(ctx context.Context, req *llmprovider.GenerateRequest) (*llmprovider.GenerateResponse, error) {
+	body, err := p.buildRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.post(ctx, body, req.Model, false)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return parseResponse(data)
+}
+
+// StreamResponse streams a response. Consecutive parts of one kind become one block: a
+// first delta carrying the block type, content deltas, then the complete block. The
+// metadata event comes last; a stream that ends early (e.g. cancelled) ends with an
+// error instead.
+func (p *Provider) StreamResponse(ctx context.Context, req *llmprovider.GenerateRequest) (<-chan llmprovider.StreamEvent, error) {
+	body, err := p.buildRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.post(ctx, body, req.Model, true)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan llmprovider.StreamEvent, 10)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+
+		metadata, err := streamResponse(resp.Body, &blockStream{events: events})
+		if err != nil {
+			events <- llmprovider.StreamEvent{Error: err}
+			return
+		}
+		events <- llmprovider.StreamEvent{Metadata: metadata}
+	}()
+
+	return events, nil
+}
+
+// BuildRequestDebug returns the request body the provider would send for req
+func (p *Provider) BuildRequestDebug(req *llmprovider.GenerateRequest) (map[string]interface{}, error) {
+	body, err := p.buildRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var debug map[string]interface{}
+	if err := json.Unmarshal(body, &debug); err != nil {
+		return nil, fmt.Errorf("failed to decode request: %w", err)
+	}
+	return debug, nil
+}
+
+// buildRequest validates the model and marshals the request body
+func (p *Provider) buildRequest(req *llmprovider.GenerateRequest) ([]byte, error) {
+	if !p.SupportsModel(req.Model) {
+		return nil, &llmprovider.ModelError{
+			Model:    req.Model,
+			Provider: p.Name().String(),
+			Reason:   "not a Gemini model ID (use e.g. gemini-2.5-flash; provider/model IDs go through OpenRouter)",
+			Err:      llmprovider.ErrInvalidModel,
+		}
+	}
+
+	body, err := buildGenerateRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	return data, nil
+}
+
+// post sends a request body for model and returns the successful response
+func (p *Provider) post(ctx context.Context, body []byte, model string, stream bool) (*http.Response, error) {
+	endpoint := fmt.Sprintf("%s/models/%s:generateContent", p.baseURL, url.PathEscape(model))
+	if stream {
+		endpoint = fmt.Sprintf("%s/models/%s:streamGenerateContent?alt=sse", p.baseURL, url.PathEscape(model))
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("x-goog-api-key", p.apiKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("gemini HTTP request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, p.handleErrorResponse(resp, model)
+	}
+	return resp, nil
+}
+
+// apiError is the error object of Gemini error responses and stream error chunks
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Status  string `json:"status"` // e.g. "INVALID_ARGUMENT", "RESOURCE_EXHAUSTED"
+}
+
+// handleErrorResponse maps an error response to library errors
+func (p *Provider) handleErrorResponse(resp *http.Response, model string) error {
+	body, _ := io.ReadAll(resp.Body)
+
+	var errResp struct {
+		Error apiError `json:"error"`
+	}
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error.Message == "" {
+		return fmt.Errorf("gemini error (HTTP %d): %s", resp.StatusCode, string(body))
+	}
+	message := errResp.Error.Message
+
+	providerError := func(code llmprovider.ErrorCode, retryable bool, err error) error {
+		return &llmprovider.ProviderError{
+			Code:       code,
+			Provider:   p.Name().String(),
+			StatusCode: resp.StatusCode,
+			Message:    message,
+			Retryable:  retryable,
+			Err:        err,
+		}
+	}
+
+	switch {
+	// Invalid keys are reported as 400 INVALID_ARGUMENT, revoked ones as 403
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden,
+		strings.Contains(message, "API key not valid"):
+		return llmprovider.ErrInvalidAPIKey
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return providerError(llmprovider.ErrorCodeRateLimited, true, llmprovider.ErrRateLimited)
+	case resp.StatusCode == http.StatusGatewayTimeout, resp.StatusCode == http.StatusRequestTimeout:
+		return providerError(llmprovider.ErrorCodeTimeout, true, llmprovider.ErrTimeout)
+	case resp.StatusCode == http.StatusBadRequest:
+		return providerError(llmprovider.ErrorCodeInvalidRequest, false, fmt.Errorf("bad request: %s", message))
+	case resp.StatusCode == http.StatusNotFound:
+		return &llmprovider.ModelError{
+			Model:    model,
+			Provider: p.Name().String(),
+			Reason:   message,
+			Err:      llmprovider.ErrInvalidModel,
+		}
+	default:
+		return providerError(llmprovider.ErrorCodeProviderUnavailable, resp.StatusCode >= 500, llmprovider.ErrProviderUnavailable)
+	}
+}
+
+// streamError converts an error chunk of a stream
+func streamError(apiErr *apiError) error {
+	retryable := apiErr.Status == "UNAVAILABLE" || apiErr.Status == "INTERNAL" || apiErr.Status == "RESOURCE_EXHAUSTED"
+	code := llmprovider.ErrorCodeProviderUnavailable
+	err := llmprovider.ErrProviderUnavailable
+	if apiErr.Status == "RESOURCE_EXHAUSTED" {
+		code, err = llmprovider.ErrorCodeRateLimited, llmprovider.ErrRateLimited
+	}
+	return &llmprovider.ProviderError{
+		Code:       code,
+		Provider:   llmprovider.ProviderGoogle.String(),
+		StatusCode: apiErr.Code,
+		Message:    apiErr.Message,
+		Retryable:  retryable,
+		Err:        err,
+	}
+}
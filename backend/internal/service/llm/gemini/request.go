@@ -0,0 +1,366 @@
+package gemini
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+// Thinking budgets (tokens) per thinking level, as for Anthropic
+var thinkingBudgets = map[string]int{
+	"low":    2000,
+	"medium": 5000,
+	"high":   12000,
+}
+
+// dynamicThinkingBudget lets the model decide how much to think
+const dynamicThinkingBudget = -1
+
+// generateRequest is the generateContent request body
+type generateRequest struct {
+	Contents          []content         `json:"contents"`
+	SystemInstruction *content          `json:"systemInstruction,omitempty"`
+	Tools             []tool            `json:"tools,omitempty"`
+	ToolConfig        *toolConfig       `json:"toolConfig,omitempty"`
+	GenerationConfig  *generationConfig `json:"generationConfig,omitempty"`
+}
+
+type content struct {
+	Role  string `json:"role,omitempty"` // "user" or "model"
+	Parts []part `json:"parts"`
+}
+
+// part is one piece of content. Thought parts are summaries of the model's thinking;
+// a thought signature may come with any part and must be sent back with it.
+type part struct {
+	Text             *string           `json:"text,omitempty"`
+	Thought          bool              `json:"thought,omitempty"`
+	ThoughtSignature string            `json:"thoughtSignature,omitempty"`
+	FunctionCall     *functionCall     `json:"functionCall,omitempty"`
+	FunctionResponse *functionResponse `json:"functionResponse,omitempty"`
+	InlineData       *inlineData       `json:"inlineData,omitempty"` // User images
+}
+
+// inlineData is a file sent with the request, base64-encoded
+type inlineData struct {
+	MimeType string `json:"mimeType"`
+	Data     string `json:"data"`
+}
+
+type functionCall struct {
+	ID   string                 `json:"id,omitempty"`
+	Name string                 `json:"name"`
+	Args map[string]interface{} `json:"args"`
+}
+
+type functionResponse struct {
+	Name     string                 `json:"name"`
+	Response map[string]interface{} `json:"response"`
+}
+
+type tool struct {
+	FunctionDeclarations []functionDeclaration `json:"functionDeclarations"`
+}
+
+type functionDeclaration struct {
+	Name                 string      `json:"name"`
+	Description          string      `json:"description,omitempty"`
+	ParametersJSONSchema interface{} `json:"parametersJsonSchema"`
+}
+
+type toolConfig struct {
+	FunctionCallingConfig functionCallingConfig `json:"functionCallingConfig"`
+}
+
+type functionCallingConfig struct {
+	Mode                 string   `json:"mode"` // "AUTO", "ANY" or "NONE"
+	AllowedFunctionNames []string `json:"allowedFunctionNames,omitempty"`
+}
+
+type generationConfig struct {
+	MaxOutputTokens *int            `json:"maxOutputTokens,omitempty"`
+	Temperature     *float64        `json:"temperature,omitempty"`
+	TopP            *float64        `json:"topP,omitempty"`
+	TopK            *int            `json:"topK,omitempty"`
+	StopSequences   []string        `json:"stopSequences,omitempty"`
+	Seed            *int            `json:"seed,omitempty"`
+	ThinkingConfig  *thinkingConfig `json:"thinkingConfig,omitempty"`
+}
+
+func (c *generationConfig) empty() bool {
+	return c.MaxOutputTokens == nil && c.Temperature == nil && c.TopP == nil && c.TopK == nil &&
+		len(c.StopSequences) == 0 && c.Seed == nil && c.ThinkingConfig == nil
+}
+
+type thinkingConfig struct {
+	ThinkingBudget  *int `json:"thinkingBudget,omitempty"`
+	IncludeThoughts bool `json:"includeThoughts,omitempty"`
+}
+
+// providerData is the provider data of blocks from Gemini parts
+type providerData struct {
+	ThoughtSignature string `json:"thought_signature,omitempty"`
+}
+
+// buildGenerateRequest converts a library request
+func buildGenerateRequest(req *llmprovider.GenerateRequest) (*generateRequest, error) {
+	messages, err := llmprovider.SplitMessagesAtCrossProviderTool(req.Messages, llmprovider.ProviderGoogle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process cross-provider tools: %w", err)
+	}
+	contents, err := historyContents(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &generateRequest{Contents: contents}
+
+	params := req.Params
+	if params == nil {
+		return body, nil
+	}
+	if params.System != nil && *params.System != "" {
+		body.SystemInstruction = &content{Parts: []part{textPart(*params.System)}}
+	}
+
+	config := &generationConfig{
+		MaxOutputTokens: params.MaxTokens,
+		Temperature:     params.Temperature,
+		TopP:            params.TopP,
+		TopK:            params.TopK,
+		StopSequences:   params.Stop,
+		Seed:            params.Seed,
+		ThinkingConfig:  thinking(req.Model, params),
+	}
+	if !config.empty() {
+		body.GenerationConfig = config
+	}
+
+	if len(params.Tools) > 0 {
+		declarations := make([]functionDeclaration, 0, len(params.Tools))
+		for _, t := range params.Tools {
+			schema := interface{}(t.Function.Parameters)
+			if t.Function.Parameters == nil {
+				schema = map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+			}
+			declarations = append(declarations, functionDeclaration{
+				Name:                 t.Function.Name,
+				Description:          t.Function.Description,
+				ParametersJSONSchema: schema,
+			})
+		}
+		body.Tools = []tool{{FunctionDeclarations: declarations}}
+	}
+
+	choice, err := toolChoice(params)
+	if err != nil {
+		return nil, err
+	}
+	if choice != nil {
+		config := functionCallingConfig{}
+		switch choice.Mode {
+		case llmprovider.ToolChoiceModeAuto:
+			config.Mode = "AUTO"
+		case llmprovider.ToolChoiceModeRequired:
+			config.Mode = "ANY"
+		case llmprovider.ToolChoiceModeNone:
+			config.Mode = "NONE"
+		case llmprovider.ToolChoiceModeSpecific:
+			config.Mode = "ANY"
+			config.AllowedFunctionNames = []string{*choice.ToolName}
+		}
+		body.ToolConfig = &toolConfig{FunctionCallingConfig: config}
+	}
+
+	return body, nil
+}
+
+// thinking maps the thinking settings (nil = model default). Enabled thinking streams
+// thought summaries; disabled thinking sets a zero budget, except on Pro models, which
+// always think.
+func thinking(model string, params *llmprovider.RequestParams) *thinkingConfig {
+	if params.ThinkingEnabled == nil {
+		return nil
+	}
+	if !*params.ThinkingEnabled {
+		if strings.Contains(strings.ToLower(model), "-pro") {
+			return nil
+		}
+		budget := 0
+		return &thinkingConfig{ThinkingBudget: &budget}
+	}
+
+	budget := dynamicThinkingBudget
+	if params.ThinkingBudget != nil {
+		budget = *params.ThinkingBudget
+	} else if params.ThinkingLevel != nil {
+		if levelBudget, ok := thinkingBudgets[*params.ThinkingLevel]; ok {
+			budget = levelBudget
+		}
+	}
+	return &thinkingConfig{ThinkingBudget: &budget, IncludeThoughts: true}
+}
+
+// toolChoice reads the library tool choice (nil = provider default)
+func toolChoice(params *llmprovider.RequestParams) (*llmprovider.ToolChoice, error) {
+	if params.ToolChoice == nil {
+		return nil, nil
+	}
+	choice, ok := params.ToolChoice.(*llmprovider.ToolChoice)
+	if !ok {
+		return nil, fmt.Errorf("unsupported tool_choice type %T", params.ToolChoice)
+	}
+	if choice == nil {
+		return nil, nil
+	}
+	if err := choice.Validate(); err != nil {
+		return nil, err
+	}
+	return choice, nil
+}
+
+// historyContents converts the history in block order. The backend sends one message
+// per turn, so an assistant message can hold tool_use and tool_result blocks of several
+// tool rounds: function calls go into model contents and each round's results into the
+// user content after them. Function responses are matched to calls by name, so the
+// call's name is looked up by tool_use_id.
+func historyContents(messages []llmprovider.Message) ([]content, error) {
+	var contents []content
+	add := func(role string, p part) {
+		if n := len(contents); n > 0 && contents[n-1].Role == role {
+			contents[n-1].Parts = append(contents[n-1].Parts, p)
+			return
+		}
+		contents = append(contents, content{Role: role, Parts: []part{p}})
+	}
+	callNames := make(map[string]string)
+
+	for _, msg := range messages {
+		role := "user"
+		if msg.Role == "assistant" {
+			role = "model"
+		}
+
+		for _, block := range msg.Blocks {
+			switch block.BlockType {
+			case llmprovider.BlockTypeText:
+				signature := thoughtSignature(block)
+				if (block.TextContent == nil || *block.TextContent == "") && signature == "" {
+					continue
+				}
+				p := textPart(stringValue(block.TextContent))
+				p.ThoughtSignature = signature
+				add(role, p)
+
+			case llmprovider.BlockTypeImage:
+				// The backend inlines user images as base64 data URLs
+				if mimeType, data, ok := imageData(block); ok && role == "user" {
+					add(role, part{InlineData: &inlineData{MimeType: mimeType, Data: data}})
+				}
+
+			case llmprovider.BlockTypeToolUse:
+				callID, _ := block.GetToolUseID()
+				name, _ := block.GetToolName()
+				if callID == "" || name == "" {
+					return nil, fmt.Errorf("tool_use block %d is missing tool_use_id or tool_name", block.Sequence)
+				}
+				args, _ := block.Content["input"].(map[string]interface{})
+				if args == nil {
+					args = map[string]interface{}{}
+				}
+				callNames[callID] = name
+				add("model", part{
+					FunctionCall:     &functionCall{Name: name, Args: args},
+					ThoughtSignature: thoughtSignature(block),
+				})
+
+			case llmprovider.BlockTypeToolResult:
+				callID, _ := block.GetToolUseID()
+				name, ok := callNames[callID]
+				if !ok {
+					return nil, fmt.Errorf("tool_result block %d answers unknown tool call %q", block.Sequence, callID)
+				}
+				add("user", part{FunctionResponse: &functionResponse{Name: name, Response: toolResponse(block)}})
+
+			case llmprovider.BlockTypeThinking:
+				// Only signed thoughts are sent back; others' thinking is skipped
+				if signature := thoughtSignature(block); signature != "" {
+					p := textPart(stringValue(block.TextContent))
+					p.Thought = true
+					p.ThoughtSignature = signature
+					add("model", p)
+				}
+
+			default:
+				// Other block types aren't part of the Gemini history
+			}
+		}
+	}
+
+	return contents, nil
+}
+
+// toolResponse returns the response object of a tool_result block. The backend
+// normalizes results to strings; errors are sent as {"error": message}.
+func toolResponse(block *llmprovider.Block) map[string]interface{} {
+	output := ""
+	switch {
+	case block.TextContent != nil:
+		output = *block.TextContent
+	case stringContent(block, "content") != "":
+		output = stringContent(block, "content")
+	case stringContent(block, "result") != "":
+		output = stringContent(block, "result")
+	default:
+		output = stringContent(block, "error")
+	}
+
+	if isError, _ := block.Content["is_error"].(bool); isError {
+		return map[string]interface{}{"error": output}
+	}
+	return map[string]interface{}{"output": output}
+}
+
+func stringContent(block *llmprovider.Block, key string) string {
+	value, _ := block.Content[key].(string)
+	return value
+}
+
+// thoughtSignature returns the signature a Gemini block carries ("" if none)
+func thoughtSignature(block *llmprovider.Block) string {
+	if !block.IsFromProvider(llmprovider.ProviderGoogle) || !block.HasProviderData() {
+		return ""
+	}
+	var data providerData
+	if err := json.Unmarshal(block.ProviderData, &data); err != nil {
+		return ""
+	}
+	return data.ThoughtSignature
+}
+
+// imageData returns the MIME type and base64 data of an image block inlined as a data URL
+func imageData(block *llmprovider.Block) (mimeType, data string, ok bool) {
+	rest, found := strings.CutPrefix(stringContent(block, "url"), "data:")
+	if !found {
+		return "", "", false
+	}
+	meta, data, found := strings.Cut(rest, ",")
+	mimeType, found64 := strings.CutSuffix(meta, ";base64")
+	if !found || !found64 || mimeType == "" || data == "" {
+		return "", "", false
+	}
+	return mimeType, data, true
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func textPart(text string) part {
+	return part{Text: &text}
+}
@@ -0,0 +1,137 @@
+package gemini
+
+import (
+	"strings"
+	"testing"
+
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+func TestStreamResponse_SignatureInTrailingPart(t *testing.T) {
+	body := strings.Join([]string{
+		`data: {"candidates":[{"content":{"parts":[{"text":"Weighing it up.","thought":true}],"role":"model"}}],"modelVersion":"gemini-2.5-pro"}`,
+		`data: {"candidates":[{"content":{"parts":[{"text":"The answer"}],"role":"model"}}]}`,
+		`data: {"candidates":[{"content":{"parts":[{"text":" is 42."}],"role":"model"}}]}`,
+		`data: {"candidates":[{"content":{"parts":[{"text":"","thoughtSignature":"sig"}],"role":"model"},"finishReason":"STOP"}],"usageMetadata":{"promptTokenCount":10,"candidatesTokenCount":5,"thoughtsTokenCount":30}}`,
+	}, "\n\n")
+
+	events := make(chan llmprovider.StreamEvent, 16)
+	metadata, err := streamResponse(strings.NewReader(body), &blockStream{events: events})
+	if err != nil {
+		t.Fatalf("streamResponse: %v", err)
+	}
+	close(events)
+
+	var blocks []*llmprovider.Block
+	for event := range events {
+		if event.Block != nil {
+			blocks = append(blocks, event.Block)
+		}
+	}
+	if len(blocks) != 2 || blocks[0].BlockType != llmprovider.BlockTypeThinking || blocks[1].BlockType != llmprovider.BlockTypeText {
+		t.Fatalf("expected a thinking and a text block, got %+v", blocks)
+	}
+	if got := *blocks[1].TextContent; got != "The answer is 42." {
+		t.Errorf("text = %q", got)
+	}
+	if thoughtSignature(blocks[1]) != "sig" || blocks[0].HasProviderData() {
+		t.Errorf("signature should be on the text block only: %s / %s", blocks[0].ProviderData, blocks[1].ProviderData)
+	}
+	if metadata.Model != "gemini-2.5-pro" || metadata.StopReason != "end_turn" ||
+		metadata.InputTokens != 10 || metadata.OutputTokens != 35 || metadata.ResponseMetadata["thinking_tokens"] != 30 {
+		t.Errorf("metadata = %+v", metadata)
+	}
+}
+
+func TestHistoryContents_ToolRounds(t *testing.T) {
+	prompt, answer := "Look up my plot note.", "A heist on the moon."
+	call := &llmprovider.Block{
+		BlockType: llmprovider.BlockTypeToolUse,
+		Content:   map[string]interface{}{"tool_use_id": "call_1", "tool_name": "lookup_note", "input": map[string]interface{}{"name": "plot"}},
+		Provider:  providerName(),
+	}
+	if err := setSignature(call, "sig"); err != nil {
+		t.Fatal(err)
+	}
+
+	contents, err := historyContents([]llmprovider.Message{
+		{Role: "user", Blocks: []*llmprovider.Block{{BlockType: llmprovider.BlockTypeText, TextContent: &prompt}}},
+		{Role: "assistant", Blocks: []*llmprovider.Block{
+			call,
+			{BlockType: llmprovider.BlockTypeToolResult, Content: map[string]interface{}{"tool_use_id": "call_1", "result": "a heist"}},
+			{BlockType: llmprovider.BlockTypeText, TextContent: &answer},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("historyContents: %v", err)
+	}
+
+	var roles []string
+	for _, c := range contents {
+		roles = append(roles, c.Role)
+	}
+	if got := strings.Join(roles, ","); got != "user,model,user,model" {
+		t.Fatalf("roles = %s", got)
+	}
+	if p := contents[1].Parts[0]; p.FunctionCall == nil || p.FunctionCall.Name != "lookup_note" || p.ThoughtSignature != "sig" {
+		t.Errorf("function call part = %+v", p)
+	}
+	if p := contents[2].Parts[0]; p.FunctionResponse == nil || p.FunctionResponse.Name != "lookup_note" || p.FunctionResponse.Response["output"] != "a heist" {
+		t.Errorf("function response part = %+v", p)
+	}
+}
+
+func TestHistoryContents_UserImages(t *testing.T) {
+	prompt := "What's in this picture?"
+	contents, err := historyContents([]llmprovider.Message{{Role: "user", Blocks: []*llmprovider.Block{
+		{BlockType: llmprovider.BlockTypeText, TextContent: &prompt},
+		{BlockType: llmprovider.BlockTypeImage, Content: map[string]interface{}{"url": "data:image/png;base64,cG5n", "mime_type": "image/png"}},
+		{BlockType: llmprovider.BlockTypeImage, Content: map[string]interface{}{"url": "https://example.com/a.png", "mime_type": "image/png"}},
+	}}})
+	if err != nil {
+		t.Fatalf("historyContents: %v", err)
+	}
+
+	if len(contents) != 1 || len(contents[0].Parts) != 2 {
+		t.Fatalf("contents = %+v (images that aren't inlined are skipped)", contents)
+	}
+	if p := contents[0].Parts[1]; p.InlineData == nil || p.InlineData.MimeType != "image/png" || p.InlineData.Data != "cG5n" {
+		t.Errorf("image part = %+v", p)
+	}
+}
+
+func TestThinking(t *testing.T) {
+	enabled, disabled, high := true, false, "high"
+
+	tests := []struct {
+		name     string
+		model    string
+		params   llmprovider.RequestParams
+		budget   *int
+		includes bool
+	}{
+		{"default", "gemini-2.5-flash", llmprovider.RequestParams{}, nil, false},
+		{"dynamic", "gemini-2.5-flash", llmprovider.RequestParams{ThinkingEnabled: &enabled}, intPtr(-1), true},
+		{"level", "gemini-2.5-flash", llmprovider.RequestParams{ThinkingEnabled: &enabled, ThinkingLevel: &high}, intPtr(12000), true},
+		{"disabled", "gemini-2.5-flash", llmprovider.RequestParams{ThinkingEnabled: &disabled}, intPtr(0), false},
+		{"pro always thinks", "gemini-2.5-pro", llmprovider.RequestParams{ThinkingEnabled: &disabled}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := thinking(tt.model, &tt.params)
+			if tt.budget == nil {
+				if config != nil {
+					t.Errorf("config = %+v, want none", config)
+				}
+				return
+			}
+			if config == nil || config.ThinkingBudget == nil || *config.ThinkingBudget != *tt.budget || config.IncludeThoughts != tt.includes {
+				t.Errorf("config = %+v, want budget %d", config, *tt.budget)
+			}
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,385 @@
+package gemini
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+// maxEventSize bounds one SSE data line (a streamed chunk is far smaller)
+const maxEventSize = 4 * 1024 * 1024
+
+// generateResponse is a generateContent response, or one chunk of a streamed response
+type generateResponse struct {
+	Candidates     []candidate     `json:"candidates"`
+	PromptFeedback *promptFeedback `json:"promptFeedback"`
+	UsageMetadata  *usageMetadata  `json:"usageMetadata"`
+	ModelVersion   string          `json:"modelVersion"`
+	Error          *apiError       `json:"error"` // Error chunks of a stream
+}
+
+type candidate struct {
+	Content      *content `json:"content"`
+	FinishReason string   `json:"finishReason"`
+}
+
+type promptFeedback struct {
+	BlockReason string `json:"blockReason"`
+}
+
+type usageMetadata struct {
+	PromptTokenCount        int `json:"promptTokenCount"`
+	CandidatesTokenCount    int `json:"candidatesTokenCount"`
+	ThoughtsTokenCount      int `json:"thoughtsTokenCount"`
+	CachedContentTokenCount int `json:"cachedContentTokenCount"`
+}
+
+// parseResponse converts a complete response
+func parseResponse(data []byte) (*llmprovider.GenerateResponse, error) {
+	var resp generateResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if err := blockedError(&resp); err != nil {
+		return nil, err
+	}
+
+	stream := &blockStream{}
+	finishReason := ""
+	if len(resp.Candidates) > 0 {
+		if err := stream.parts(resp.Candidates[0].Content); err != nil {
+			return nil, err
+		}
+		finishReason = resp.Candidates[0].FinishReason
+	}
+	if err := stream.finish(); err != nil {
+		return nil, err
+	}
+
+	result := &llmprovider.GenerateResponse{
+		Blocks:     stream.blocks,
+		Model:      resp.ModelVersion,
+		StopReason: mapStopReason(finishReason, stream.hasToolUse),
+	}
+	if resp.UsageMetadata != nil {
+		result.InputTokens, result.OutputTokens, result.ResponseMetadata = usage(resp.UsageMetadata)
+	}
+	return result, nil
+}
+
+// streamResponse reads a streamGenerateContent event stream into stream and returns the
+// metadata. Every chunk carries new parts; the finish reason comes with the last parts
+// and the stream simply ends after it (there is no end marker).
+func streamResponse(body io.Reader, stream *blockStream) (*llmprovider.StreamMetadata, error) {
+	var model, finishReason string
+	var lastUsage *usageMetadata
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue // Blank separators
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "" {
+			continue
+		}
+
+		var chunk generateResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return nil, fmt.Errorf("failed to parse stream chunk: %w", err)
+		}
+		if chunk.Error != nil {
+			return nil, streamError(chunk.Error)
+		}
+		if err := blockedError(&chunk); err != nil {
+			return nil, err
+		}
+		if chunk.ModelVersion != "" {
+			model = chunk.ModelVersion
+		}
+		if chunk.UsageMetadata != nil {
+			lastUsage = chunk.UsageMetadata
+		}
+		if len(chunk.Candidates) == 0 {
+			continue
+		}
+
+		c := chunk.Candidates[0]
+		if err := stream.parts(c.Content); err != nil {
+			return nil, err
+		}
+		if c.FinishReason != "" {
+			finishReason = c.FinishReason
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading stream: %w", err)
+	}
+	if finishReason == "" {
+		return nil, fmt.Errorf("gemini stream ended before the response completed")
+	}
+	if err := stream.finish(); err != nil {
+		return nil, err
+	}
+
+	metadata := &llmprovider.StreamMetadata{
+		Model:      model,
+		StopReason: mapStopReason(finishReason, stream.hasToolUse),
+	}
+	if lastUsage != nil {
+		metadata.InputTokens, metadata.OutputTokens, metadata.ResponseMetadata = usage(lastUsage)
+	}
+	return metadata, nil
+}
+
+// blockedError returns an error for a prompt Gemini refused to answer
+func blockedError(resp *generateResponse) error {
+	if len(resp.Candidates) > 0 || resp.PromptFeedback == nil || resp.PromptFeedback.BlockReason == "" {
+		return nil
+	}
+	return &llmprovider.ProviderError{
+		Code:     llmprovider.ErrorCodeInvalidRequest,
+		Provider: llmprovider.ProviderGoogle.String(),
+		Message:  fmt.Sprintf("prompt blocked (%s)", resp.PromptFeedback.BlockReason),
+		Err:      llmprovider.ErrInvalidRequest,
+	}
+}
+
+// mapStopReason maps a Gemini finish reason to the library's stop reason. Gemini
+// finishes function calls with STOP, so a response with calls stops for tool_use.
+func mapStopReason(reason string, hasToolUse bool) string {
+	switch reason {
+	case "STOP", "":
+		if hasToolUse {
+			return "tool_use"
+		}
+		return "end_turn"
+	case "MAX_TOKENS":
+		return "max_tokens"
+	default:
+		// SAFETY, RECITATION, MALFORMED_FUNCTION_CALL etc.
+		return "stop_sequence"
+	}
+}
+
+// usage returns the token counts and response metadata of usage. Thinking tokens are
+// billed as output.
+func usage(u *usageMetadata) (inputTokens, outputTokens int, metadata map[string]interface{}) {
+	metadata = make(map[string]interface{})
+	if u.ThoughtsTokenCount > 0 {
+		metadata["thinking_tokens"] = u.ThoughtsTokenCount
+	}
+	if u.CachedContentTokenCount > 0 {
+		metadata["cache_read_input_tokens"] = u.CachedContentTokenCount
+	}
+	if len(metadata) == 0 {
+		metadata = nil
+	}
+	return u.PromptTokenCount, u.CandidatesTokenCount + u.ThoughtsTokenCount, metadata
+}
+
+// blockStream turns response parts into library blocks, emitting stream events when
+// events is set. Consecutive text (or thought) parts make one block; each function call
+// is a block of its own. One block is open at a time, so deltas always arrive in block
+// order and every block completes exactly once.
+type blockStream struct {
+	events     chan<- llmprovider.StreamEvent // Nil for a complete response
+	blocks     []*llmprovider.Block           // Completed blocks
+	open       *openBlock                     // Block receiving text, nil between blocks
+	hasToolUse bool
+}
+
+// openBlock accumulates a text or thinking block while it streams
+type openBlock struct {
+	index     int
+	blockType string
+	text      strings.Builder
+	signature string
+}
+
+// parts adds a content's parts
+func (s *blockStream) parts(c *content) error {
+	if c == nil {
+		return nil
+	}
+	for _, p := range c.Parts {
+		if p.FunctionCall != nil {
+			if err := s.functionCall(p.FunctionCall, p.ThoughtSignature); err != nil {
+				return err
+			}
+			continue
+		}
+		if p.Text == nil {
+			continue // Parts we don't request (inline data, code execution)
+		}
+
+		blockType := llmprovider.BlockTypeText
+		if p.Thought {
+			blockType = llmprovider.BlockTypeThinking
+		}
+		// A signature can come alone in an empty part: it belongs to the open block
+		if *p.Text == "" && s.open != nil {
+			if p.ThoughtSignature != "" {
+				s.open.signature = p.ThoughtSignature
+			}
+			continue
+		}
+		if err := s.text(blockType, *p.Text); err != nil {
+			return err
+		}
+		if p.ThoughtSignature != "" {
+			s.open.signature = p.ThoughtSignature
+		}
+	}
+	return nil
+}
+
+// text appends text, starting a text or thinking block if needed
+func (s *blockStream) text(blockType, delta string) error {
+	if s.open == nil || s.open.blockType != blockType {
+		if err := s.finish(); err != nil {
+			return err
+		}
+		s.open = &openBlock{index: len(s.blocks), blockType: blockType}
+		deltaType := llmprovider.DeltaTypeText
+		if blockType == llmprovider.BlockTypeThinking {
+			deltaType = llmprovider.DeltaTypeThinking
+		}
+		s.send(llmprovider.StreamEvent{Delta: &llmprovider.BlockDelta{
+			BlockIndex: s.open.index,
+			BlockType:  &s.open.blockType,
+			DeltaType:  deltaType,
+		}})
+	}
+	if delta == "" {
+		return nil
+	}
+	s.open.text.WriteString(delta)
+
+	deltaType := llmprovider.DeltaTypeText
+	if blockType == llmprovider.BlockTypeThinking {
+		deltaType = llmprovider.DeltaTypeThinking
+	}
+	s.send(llmprovider.StreamEvent{Delta: &llmprovider.BlockDelta{
+		BlockIndex: s.open.index,
+		DeltaType:  deltaType,
+		TextDelta:  &delta,
+	}})
+	return nil
+}
+
+// functionCall emits a complete tool_use block. Gemini sends each call whole; calls
+// without an ID get one, since the backend pairs results with calls by ID.
+func (s *blockStream) functionCall(call *functionCall, signature string) error {
+	if err := s.finish(); err != nil {
+		return err
+	}
+
+	callID := call.ID
+	if callID == "" {
+		callID = "call_" + uuid.NewString()
+	}
+	args := call.Args
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+	arguments, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("invalid arguments for function call %s: %w", call.Name, err)
+	}
+
+	index := len(s.blocks)
+	blockType := llmprovider.BlockTypeToolUse
+	s.send(llmprovider.StreamEvent{Delta: &llmprovider.BlockDelta{
+		BlockIndex:   index,
+		BlockType:    &blockType,
+		DeltaType:    llmprovider.DeltaTypeToolCallStart,
+		ToolCallID:   &callID,
+		ToolCallName: &call.Name,
+	}})
+	argumentsDelta := string(arguments)
+	s.send(llmprovider.StreamEvent{Delta: &llmprovider.BlockDelta{
+		BlockIndex: index,
+		DeltaType:  llmprovider.DeltaTypeJSON,
+		JSONDelta:  &argumentsDelta,
+	}})
+
+	executionSide := llmprovider.ExecutionSideServer // Function tools run in the backend
+	block := &llmprovider.Block{
+		BlockType: llmprovider.BlockTypeToolUse,
+		Sequence:  index,
+		Content: map[string]interface{}{
+			"tool_use_id": callID,
+			"tool_name":   call.Name,
+			"input":       args,
+		},
+		ExecutionSide: &executionSide,
+		Provider:      providerName(),
+	}
+	if err := setSignature(block, signature); err != nil {
+		return err
+	}
+	s.hasToolUse = true
+	s.complete(block)
+	return nil
+}
+
+// finish completes the open block, if any
+func (s *blockStream) finish() error {
+	open := s.open
+	if open == nil {
+		return nil
+	}
+	s.open = nil
+
+	text := open.text.String()
+	block := &llmprovider.Block{
+		BlockType:   open.blockType,
+		Sequence:    open.index,
+		TextContent: &text,
+		Provider:    providerName(),
+	}
+	if err := setSignature(block, open.signature); err != nil {
+		return err
+	}
+	s.complete(block)
+	return nil
+}
+
+// complete records a completed block and emits it
+func (s *blockStream) complete(block *llmprovider.Block) {
+	s.blocks = append(s.blocks, block)
+	s.send(llmprovider.StreamEvent{Block: block})
+}
+
+// send emits an event; the consumer reads until the channel closes
+func (s *blockStream) send(event llmprovider.StreamEvent) {
+	if s.events != nil {
+		s.events <- event
+	}
+}
+
+// setSignature keeps a thought signature in the block's provider data
+func setSignature(block *llmprovider.Block, signature string) error {
+	if signature == "" {
+		return nil
+	}
+	data, err := json.Marshal(providerData{ThoughtSignature: signature})
+	if err != nil {
+		return fmt.Errorf("failed to encode thought signature: %w", err)
+	}
+	block.ProviderData = data
+	return nil
+}
+
+func providerName() *string {
+	name := llmprovider.ProviderGoogle.String()
+	return &name
+}
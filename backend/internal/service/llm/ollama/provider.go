@@ -0,0 +1,236 @@
+// Package ollama implements the meridian-llm-go Provider interface for Ollama's chat API
+// (/api/chat), so chats can run against local or self-hosted models.
+//
+// Ollama needs no API key; the provider is enabled by configuring the server's URL.
+// Any model the server has pulled can be requested by name (e.g. "llama3.2",
+// "qwen3:8b"). Text, thinking and tool calls stream as library blocks; all tools are
+// function tools executed by the backend.
+package ollama
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+// ProviderID identifies Ollama. The library has no constant for it, but provider IDs are
+// plain strings, so blocks and routing use "ollama" like any other provider.
+const ProviderID llmprovider.ProviderID = "ollama"
+
+const (
+	// DefaultBaseURL is where a local Ollama server listens
+	DefaultBaseURL = "http://localhost:11434"
+
+	// requestTimeout bounds a whole request, including a streamed response. Local models
+	// can be slow, and the first request loads the model.
+	requestTimeout = 30 * time.Minute
+)
+
+// Config configures a Provider
+type Config struct {
+	BaseURL string // Empty = DefaultBaseURL
+}
+
+// Provider implements llmprovider.Provider for Ollama
+type Provider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+var _ llmprovider.Provider = (*Provider)(nil)
+
+// NewProvider creates an Ollama provider
+func NewProvider(cfg Config) (*Provider, error) {
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return &Provider{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// Name returns the provider identifier
+func (p *Provider) Name() llmprovider.ProviderID {
+	return ProviderID
+}
+
+// SupportsModel returns true for any model name; whether the server has the model is
+// only known when it's requested
+func (p *Provider) SupportsModel(model string) bool {
+	return strings.TrimSpace(model) != ""
+}
+
+// GenerateResponse generates a complete response
+func (p *Provider) GenerateResponse(ctx context.Context, req *llmprovider.GenerateRequest) (*llmprovider.GenerateResponse, error) {
+	body, err := p.buildRequest(req, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.post(ctx, body, req.Model)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return parseResponse(data)
+}
+
+// StreamResponse streams a response. Consecutive chunks of one kind become one block: a
+// first delta carrying the block type, content deltas, then the complete block. The
+// metadata event comes last; a stream that ends early (e.g. cancelled) ends with an
+// error instead.
+func (p *Provider) StreamResponse(ctx context.Context, req *llmprovider.GenerateRequest) (<-chan llmprovider.StreamEvent, error) {
+	body, err := p.buildRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.post(ctx, body, req.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan llmprovider.StreamEvent, 10)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+
+		metadata, err := streamResponse(resp.Body, &blockStream{events: events})
+		if err != nil {
+			events <- llmprovider.StreamEvent{Error: err}
+			return
+		}
+		events <- llmprovider.StreamEvent{Metadata: metadata}
+	}()
+
+	return events, nil
+}
+
+// BuildRequestDebug returns the request body the provider would stream for req
+func (p *Provider) BuildRequestDebug(req *llmprovider.GenerateRequest) (map[string]interface{}, error) {
+	body, err := p.buildRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var debug map[string]interface{}
+	if err := json.Unmarshal(body, &debug); err != nil {
+		return nil, fmt.Errorf("failed to decode request: %w", err)
+	}
+	return debug, nil
+}
+
+// buildRequest validates the model and marshals the request body
+func (p *Provider) buildRequest(req *llmprovider.GenerateRequest, stream bool) ([]byte, error) {
+	if !p.SupportsModel(req.Model) {
+		return nil, &llmprovider.ModelError{
+			Model:    req.Model,
+			Provider: p.Name().String(),
+			Reason:   "model name is required",
+			Err:      llmprovider.ErrInvalidModel,
+		}
+	}
+
+	body, err := buildChatRequest(req, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	return data, nil
+}
+
+// post sends a request body to /api/chat and returns the successful response
+func (p *Provider) post(ctx context.Context, body []byte, model string) (*http.Response, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/api/chat", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, &llmprovider.ProviderError{
+			Code:      llmprovider.ErrorCodeProviderUnavailable,
+			Provider:  p.Name().String(),
+			Message:   fmt.Sprintf("can't reach Ollama at %s; is it running?", p.baseURL),
+			Retryable: true,
+			Err:       fmt.Errorf("%w: %v", llmprovider.ErrProviderUnavailable, err),
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, p.handleErrorResponse(resp, model)
+	}
+	return resp, nil
+}
+
+// handleErrorResponse maps an error response ({"error": message}) to library errors
+func (p *Provider) handleErrorResponse(resp *http.Response, model string) error {
+	body, _ := io.ReadAll(resp.Body)
+
+	var errResp struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error == "" {
+		return fmt.Errorf("ollama error (HTTP %d): %s", resp.StatusCode, string(body))
+	}
+	message := errResp.Error
+
+	providerError := func(code llmprovider.ErrorCode, retryable bool, err error) error {
+		return &llmprovider.ProviderError{
+			Code:       code,
+			Provider:   p.Name().String(),
+			StatusCode: resp.StatusCode,
+			Message:    message,
+			Retryable:  retryable,
+			Err:        err,
+		}
+	}
+
+	switch {
+	// Models that haven't been pulled are 404 "model ... not found, try pulling it first"
+	case resp.StatusCode == http.StatusNotFound:
+		return &llmprovider.ModelError{
+			Model:    model,
+			Provider: p.Name().String(),
+			Reason:   message,
+			Err:      llmprovider.ErrInvalidModel,
+		}
+	case resp.StatusCode == http.StatusBadRequest:
+		return providerError(llmprovider.ErrorCodeInvalidRequest, false, fmt.Errorf("bad request: %s", message))
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode == http.StatusServiceUnavailable:
+		// The server's request queue is full
+		return providerError(llmprovider.ErrorCodeRateLimited, true, llmprovider.ErrRateLimited)
+	default:
+		return providerError(llmprovider.ErrorCodeProviderUnavailable, resp.StatusCode >= 500, llmprovider.ErrProviderUnavailable)
+	}
+}
+
+// streamError converts an error reported in a response body
+func streamError(message string) error {
+	return &llmprovider.ProviderError{
+		Code:     llmprovider.ErrorCodeProviderUnavailable,
+		Provider: ProviderID.String(),
+		Message:  message,
+		Err:      llmprovider.ErrProviderUnavailable,
+	}
+}
@@ -0,0 +1,263 @@
+package ollama
+
+import (
+	"fmt"
+	"strings"
+
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+// chatRequest is the /api/chat request body
+type chatRequest struct {
+	Model    string    `json:"model"`
+	Messages []message `json:"messages"`
+	Tools    []tool    `json:"tools,omitempty"`
+	Think    *bool     `json:"think,omitempty"`
+	Options  *options  `json:"options,omitempty"`
+	Stream   bool      `json:"stream"`
+}
+
+// message is a chat message; assistant messages carry the model's thinking and tool
+// calls, tool messages one tool's result
+type message struct {
+	Role      string     `json:"role"` // "system", "user", "assistant" or "tool"
+	Content   string     `json:"content"`
+	Thinking  string     `json:"thinking,omitempty"`
+	ToolCalls []toolCall `json:"tool_calls,omitempty"`
+	ToolName  string     `json:"tool_name,omitempty"` // Tool messages
+	Images    []string   `json:"images,omitempty"`    // Base64 user images
+}
+
+type toolCall struct {
+	ID       string           `json:"id,omitempty"` // Only sent by newer Ollama versions
+	Function toolCallFunction `json:"function"`
+}
+
+type toolCallFunction struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+type tool struct {
+	Type     string       `json:"type"` // "function"
+	Function toolFunction `json:"function"`
+}
+
+type toolFunction struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Parameters  interface{} `json:"parameters"`
+}
+
+// options are the model parameters of a request
+type options struct {
+	NumPredict  *int     `json:"num_predict,omitempty"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	TopP        *float64 `json:"top_p,omitempty"`
+	TopK        *int     `json:"top_k,omitempty"`
+	Stop        []string `json:"stop,omitempty"`
+	Seed        *int     `json:"seed,omitempty"`
+}
+
+func (o *options) empty() bool {
+	return o.NumPredict == nil && o.Temperature == nil && o.TopP == nil && o.TopK == nil &&
+		len(o.Stop) == 0 && o.Seed == nil
+}
+
+// buildChatRequest converts a library request
+func buildChatRequest(req *llmprovider.GenerateRequest, stream bool) (*chatRequest, error) {
+	messages, err := llmprovider.SplitMessagesAtCrossProviderTool(req.Messages, ProviderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process cross-provider tools: %w", err)
+	}
+
+	body := &chatRequest{Model: req.Model, Stream: stream}
+
+	params := req.Params
+	if params != nil && params.System != nil && *params.System != "" {
+		body.Messages = append(body.Messages, message{Role: "system", Content: *params.System})
+	}
+	history, err := historyMessages(messages)
+	if err != nil {
+		return nil, err
+	}
+	body.Messages = append(body.Messages, history...)
+
+	if params == nil {
+		return body, nil
+	}
+
+	opts := &options{
+		NumPredict:  params.MaxTokens,
+		Temperature: params.Temperature,
+		TopP:        params.TopP,
+		TopK:        params.TopK,
+		Stop:        params.Stop,
+		Seed:        params.Seed,
+	}
+	if !opts.empty() {
+		body.Options = opts
+	}
+
+	// Ollama takes thinking on or off; levels and budgets aren't supported
+	body.Think = params.ThinkingEnabled
+
+	// Ollama has no tool choice: "none" sends no tools, other choices leave it to the model
+	if len(params.Tools) > 0 && !toolChoiceNone(params) {
+		body.Tools = make([]tool, 0, len(params.Tools))
+		for _, t := range params.Tools {
+			parameters := interface{}(t.Function.Parameters)
+			if t.Function.Parameters == nil {
+				parameters = map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+			}
+			body.Tools = append(body.Tools, tool{
+				Type: "function",
+				Function: toolFunction{
+					Name:        t.Function.Name,
+					Description: t.Function.Description,
+					Parameters:  parameters,
+				},
+			})
+		}
+	}
+
+	return body, nil
+}
+
+// toolChoiceNone reports whether the request forbids tool calls
+func toolChoiceNone(params *llmprovider.RequestParams) bool {
+	choice, ok := params.ToolChoice.(*llmprovider.ToolChoice)
+	return ok && choice != nil && choice.Mode == llmprovider.ToolChoiceModeNone
+}
+
+// historyMessages converts the history in block order. The backend sends one message
+// per turn, so an assistant message can hold tool_use and tool_result blocks of several
+// tool rounds: each round's calls go into one assistant message and each result into a
+// tool message after it. Tool messages name the tool they answer, so the call's name is
+// looked up by tool_use_id.
+func historyMessages(messages []llmprovider.Message) ([]message, error) {
+	var result []message
+	var open *message // Assistant message receiving blocks
+	flush := func() {
+		if open != nil {
+			result = append(result, *open)
+			open = nil
+		}
+	}
+	assistant := func() *message {
+		if open == nil {
+			open = &message{Role: "assistant"}
+		}
+		return open
+	}
+	callNames := make(map[string]string)
+
+	for _, msg := range messages {
+		for _, block := range msg.Blocks {
+			switch block.BlockType {
+			case llmprovider.BlockTypeText:
+				text := stringValue(block.TextContent)
+				if text == "" {
+					continue
+				}
+				if msg.Role != "assistant" {
+					result = append(result, message{Role: msg.Role, Content: text})
+					continue
+				}
+				// Text after a tool round starts the next assistant message
+				if open != nil && len(open.ToolCalls) > 0 {
+					flush()
+				}
+				assistant().Content += text
+
+			case llmprovider.BlockTypeImage:
+				// The backend inlines user images as base64 data URLs; consecutive images
+				// share a message
+				data, ok := imageData(block)
+				if !ok || msg.Role != "user" {
+					continue
+				}
+				if n := len(result); n > 0 && result[n-1].Role == "user" && result[n-1].Content == "" {
+					result[n-1].Images = append(result[n-1].Images, data)
+					continue
+				}
+				result = append(result, message{Role: "user", Images: []string{data}})
+
+			case llmprovider.BlockTypeThinking:
+				// Only Ollama's own thinking is sent back; others' is skipped
+				if block.IsFromProvider(ProviderID) && msg.Role == "assistant" {
+					assistant().Thinking += stringValue(block.TextContent)
+				}
+
+			case llmprovider.BlockTypeToolUse:
+				callID, _ := block.GetToolUseID()
+				name, _ := block.GetToolName()
+				if callID == "" || name == "" {
+					return nil, fmt.Errorf("tool_use block %d is missing tool_use_id or tool_name", block.Sequence)
+				}
+				args, _ := block.Content["input"].(map[string]interface{})
+				if args == nil {
+					args = map[string]interface{}{}
+				}
+				callNames[callID] = name
+				a := assistant()
+				a.ToolCalls = append(a.ToolCalls, toolCall{Function: toolCallFunction{Name: name, Arguments: args}})
+
+			case llmprovider.BlockTypeToolResult:
+				callID, _ := block.GetToolUseID()
+				name, ok := callNames[callID]
+				if !ok {
+					return nil, fmt.Errorf("tool_result block %d answers unknown tool call %q", block.Sequence, callID)
+				}
+				flush()
+				result = append(result, message{Role: "tool", Content: toolOutput(block), ToolName: name})
+
+			default:
+				// Other block types aren't part of the Ollama history
+			}
+		}
+		flush()
+	}
+
+	return result, nil
+}
+
+// toolOutput returns the output text of a tool_result block. The backend normalizes
+// results to strings; errors are sent as their message.
+func toolOutput(block *llmprovider.Block) string {
+	switch {
+	case block.TextContent != nil:
+		return *block.TextContent
+	case stringContent(block, "content") != "":
+		return stringContent(block, "content")
+	case stringContent(block, "result") != "":
+		return stringContent(block, "result")
+	default:
+		return stringContent(block, "error")
+	}
+}
+
+// imageData returns the base64 data of an image block inlined as a data URL
+func imageData(block *llmprovider.Block) (string, bool) {
+	rest, found := strings.CutPrefix(stringContent(block, "url"), "data:")
+	if !found {
+		return "", false
+	}
+	meta, data, found := strings.Cut(rest, ",")
+	if !found || !strings.HasSuffix(meta, ";base64") || data == "" {
+		return "", false
+	}
+	return data, true
+}
+
+func stringContent(block *llmprovider.Block, key string) string {
+	value, _ := block.Content[key].(string)
+	return value
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
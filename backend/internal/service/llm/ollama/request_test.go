@@ -0,0 +1,109 @@
+package ollama
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+func TestHistoryMessages_ToolRounds(t *testing.T) {
+	prompt, thought, answer := "Look up my plot note.", "Checking the note.", "A heist on the moon."
+	ollamaName, otherName := ProviderID.String(), "anthropic"
+
+	messages, err := historyMessages([]llmprovider.Message{
+		{Role: "user", Blocks: []*llmprovider.Block{{BlockType: llmprovider.BlockTypeText, TextContent: &prompt}}},
+		{Role: "assistant", Blocks: []*llmprovider.Block{
+			{BlockType: llmprovider.BlockTypeThinking, TextContent: &thought, Provider: &ollamaName},
+			{BlockType: llmprovider.BlockTypeToolUse, Content: map[string]interface{}{"tool_use_id": "call_1", "tool_name": "lookup_note", "input": map[string]interface{}{"name": "plot"}}},
+			{BlockType: llmprovider.BlockTypeToolResult, Content: map[string]interface{}{"tool_use_id": "call_1", "result": "a heist"}},
+			{BlockType: llmprovider.BlockTypeThinking, TextContent: &thought, Provider: &otherName},
+			{BlockType: llmprovider.BlockTypeText, TextContent: &answer},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("historyMessages: %v", err)
+	}
+
+	var roles []string
+	for _, m := range messages {
+		roles = append(roles, m.Role)
+	}
+	if got := strings.Join(roles, ","); got != "user,assistant,tool,assistant" {
+		t.Fatalf("roles = %s", got)
+	}
+	if m := messages[1]; m.Thinking != thought || len(m.ToolCalls) != 1 || m.ToolCalls[0].Function.Name != "lookup_note" {
+		t.Errorf("tool call message = %+v", m)
+	}
+	if m := messages[2]; m.ToolName != "lookup_note" || m.Content != "a heist" {
+		t.Errorf("tool message = %+v", m)
+	}
+	if m := messages[3]; m.Content != answer || m.Thinking != "" {
+		t.Errorf("answer message = %+v (other providers' thinking must be skipped)", m)
+	}
+}
+
+func TestHistoryMessages_UserImages(t *testing.T) {
+	prompt := "What's in this picture?"
+	image := &llmprovider.Block{BlockType: llmprovider.BlockTypeImage, Content: map[string]interface{}{"url": "data:image/png;base64,cG5n", "mime_type": "image/png"}}
+
+	messages, err := historyMessages([]llmprovider.Message{
+		{Role: "user", Blocks: []*llmprovider.Block{{BlockType: llmprovider.BlockTypeText, TextContent: &prompt}, image, image}},
+	})
+	if err != nil {
+		t.Fatalf("historyMessages: %v", err)
+	}
+
+	if len(messages) != 2 || messages[0].Content != prompt {
+		t.Fatalf("messages = %+v", messages)
+	}
+	if m := messages[1]; m.Role != "user" || strings.Join(m.Images, ",") != "cG5n,cG5n" {
+		t.Errorf("image message = %+v", m)
+	}
+}
+
+func TestStreamResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		stopReason string
+		wantErr    bool
+	}{
+		{
+			name: "cut off",
+			body: `{"model":"llama3.2","message":{"role":"assistant","content":"Once upon"},"done":false}
+{"model":"llama3.2","message":{"role":"assistant","content":""},"done":true,"done_reason":"length","prompt_eval_count":9,"eval_count":2}`,
+			stopReason: "max_tokens",
+		},
+		{
+			name:    "error during the stream",
+			body:    `{"error":"model runner has unexpectedly stopped"}`,
+			wantErr: true,
+		},
+		{
+			name:    "ends without done",
+			body:    `{"model":"llama3.2","message":{"role":"assistant","content":"Once upon"},"done":false}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := make(chan llmprovider.StreamEvent, 16)
+			metadata, err := streamResponse(strings.NewReader(tt.body), &blockStream{events: events})
+			if tt.wantErr {
+				var providerErr *llmprovider.ProviderError
+				if err == nil || (strings.Contains(tt.body, `"error"`) && !errors.As(err, &providerErr)) {
+					t.Fatalf("err = %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("streamResponse: %v", err)
+			}
+			if metadata.StopReason != tt.stopReason || metadata.InputTokens != 9 || metadata.OutputTokens != 2 {
+				t.Errorf("metadata = %+v", metadata)
+			}
+		})
+	}
+}
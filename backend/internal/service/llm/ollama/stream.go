@@ -0,0 +1,250 @@
+package ollama
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+// maxLineSize bounds one NDJSON line (a streamed chunk is far smaller)
+const maxLineSize = 4 * 1024 * 1024
+
+// chatResponse is an /api/chat response, or one line of a streamed response. The last
+// line of a stream has done set and carries the stop reason and token counts.
+type chatResponse struct {
+	Model           string  `json:"model"`
+	Message         message `json:"message"`
+	Done            bool    `json:"done"`
+	DoneReason      string  `json:"done_reason"` // "stop", "length", "load" or "unload"
+	PromptEvalCount int     `json:"prompt_eval_count"`
+	EvalCount       int     `json:"eval_count"`
+	Error           string  `json:"error"` // Errors during a stream
+}
+
+// parseResponse converts a complete response
+func parseResponse(data []byte) (*llmprovider.GenerateResponse, error) {
+	var resp chatResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, streamError(resp.Error)
+	}
+
+	stream := &blockStream{}
+	if err := stream.message(&resp.Message); err != nil {
+		return nil, err
+	}
+	stream.finish()
+
+	return &llmprovider.GenerateResponse{
+		Blocks:       stream.blocks,
+		Model:        resp.Model,
+		StopReason:   mapStopReason(resp.DoneReason, stream.hasToolUse),
+		InputTokens:  resp.PromptEvalCount,
+		OutputTokens: resp.EvalCount,
+	}, nil
+}
+
+// streamResponse reads a streamed /api/chat response (one JSON object per line) into
+// stream and returns the metadata
+func streamResponse(body io.Reader, stream *blockStream) (*llmprovider.StreamMetadata, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var chunk chatResponse
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return nil, fmt.Errorf("failed to parse stream chunk: %w", err)
+		}
+		if chunk.Error != "" {
+			return nil, streamError(chunk.Error)
+		}
+		if err := stream.message(&chunk.Message); err != nil {
+			return nil, err
+		}
+		if !chunk.Done {
+			continue
+		}
+
+		stream.finish()
+		return &llmprovider.StreamMetadata{
+			Model:        chunk.Model,
+			StopReason:   mapStopReason(chunk.DoneReason, stream.hasToolUse),
+			InputTokens:  chunk.PromptEvalCount,
+			OutputTokens: chunk.EvalCount,
+		}, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading stream: %w", err)
+	}
+	return nil, fmt.Errorf("ollama stream ended before the response completed")
+}
+
+// mapStopReason maps an Ollama done reason to the library's stop reason. Ollama
+// finishes tool calls with "stop", so a response with calls stops for tool_use.
+func mapStopReason(reason string, hasToolUse bool) string {
+	switch reason {
+	case "length":
+		return "max_tokens"
+	default:
+		if hasToolUse {
+			return "tool_use"
+		}
+		return "end_turn"
+	}
+}
+
+// blockStream turns response messages into library blocks, emitting stream events when
+// events is set. Consecutive thinking (or content) text makes one block; each tool call
+// is a block of its own. One block is open at a time, so deltas always arrive in block
+// order and every block completes exactly once.
+type blockStream struct {
+	events     chan<- llmprovider.StreamEvent // Nil for a complete response
+	blocks     []*llmprovider.Block           // Completed blocks
+	open       *openBlock                     // Block receiving text, nil between blocks
+	hasToolUse bool
+}
+
+// openBlock accumulates a text or thinking block while it streams
+type openBlock struct {
+	index     int
+	blockType string
+	text      strings.Builder
+}
+
+// message adds a message or message chunk: thinking comes before content, and tool
+// calls arrive whole
+func (s *blockStream) message(m *message) error {
+	if m.Thinking != "" {
+		s.text(llmprovider.BlockTypeThinking, m.Thinking)
+	}
+	if m.Content != "" {
+		s.text(llmprovider.BlockTypeText, m.Content)
+	}
+	for i := range m.ToolCalls {
+		if err := s.toolCall(&m.ToolCalls[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// text appends text, starting a text or thinking block if needed
+func (s *blockStream) text(blockType, delta string) {
+	deltaType := llmprovider.DeltaTypeText
+	if blockType == llmprovider.BlockTypeThinking {
+		deltaType = llmprovider.DeltaTypeThinking
+	}
+
+	if s.open == nil || s.open.blockType != blockType {
+		s.finish()
+		s.open = &openBlock{index: len(s.blocks), blockType: blockType}
+		s.send(llmprovider.StreamEvent{Delta: &llmprovider.BlockDelta{
+			BlockIndex: s.open.index,
+			BlockType:  &s.open.blockType,
+			DeltaType:  deltaType,
+		}})
+	}
+	s.open.text.WriteString(delta)
+
+	s.send(llmprovider.StreamEvent{Delta: &llmprovider.BlockDelta{
+		BlockIndex: s.open.index,
+		DeltaType:  deltaType,
+		TextDelta:  &delta,
+	}})
+}
+
+// toolCall emits a complete tool_use block. Calls without an ID (older Ollama versions)
+// get one, since the backend pairs results with calls by ID.
+func (s *blockStream) toolCall(call *toolCall) error {
+	s.finish()
+
+	callID := call.ID
+	if callID == "" {
+		callID = "call_" + uuid.NewString()
+	}
+	args := call.Function.Arguments
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+	arguments, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("invalid arguments for tool call %s: %w", call.Function.Name, err)
+	}
+
+	index := len(s.blocks)
+	blockType := llmprovider.BlockTypeToolUse
+	s.send(llmprovider.StreamEvent{Delta: &llmprovider.BlockDelta{
+		BlockIndex:   index,
+		BlockType:    &blockType,
+		DeltaType:    llmprovider.DeltaTypeToolCallStart,
+		ToolCallID:   &callID,
+		ToolCallName: &call.Function.Name,
+	}})
+	argumentsDelta := string(arguments)
+	s.send(llmprovider.StreamEvent{Delta: &llmprovider.BlockDelta{
+		BlockIndex: index,
+		DeltaType:  llmprovider.DeltaTypeJSON,
+		JSONDelta:  &argumentsDelta,
+	}})
+
+	executionSide := llmprovider.ExecutionSideServer // Function tools run in the backend
+	s.hasToolUse = true
+	s.complete(&llmprovider.Block{
+		BlockType: llmprovider.BlockTypeToolUse,
+		Sequence:  index,
+		Content: map[string]interface{}{
+			"tool_use_id": callID,
+			"tool_name":   call.Function.Name,
+			"input":       args,
+		},
+		ExecutionSide: &executionSide,
+		Provider:      providerName(),
+	})
+	return nil
+}
+
+// finish completes the open block, if any
+func (s *blockStream) finish() {
+	open := s.open
+	if open == nil {
+		return
+	}
+	s.open = nil
+
+	text := open.text.String()
+	s.complete(&llmprovider.Block{
+		BlockType:   open.blockType,
+		Sequence:    open.index,
+		TextContent: &text,
+		Provider:    providerName(),
+	})
+}
+
+// complete records a completed block and emits it
+func (s *blockStream) complete(block *llmprovider.Block) {
+	s.blocks = append(s.blocks, block)
+	s.send(llmprovider.StreamEvent{Block: block})
+}
+
+// send emits an event; the consumer reads until the channel closes
+func (s *blockStream) send(event llmprovider.StreamEvent) {
+	if s.events != nil {
+		s.events <- event
+	}
+}
+
+func providerName() *string {
+	name := ProviderID.String()
+	return &name
+}
@@ -0,0 +1,360 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+// Chat Completions API (POST /chat/completions). Reasoning models reason but return no
+// reasoning text here; only the reasoning token count is reported.
+
+// chatRequest is the request body
+type chatRequest struct {
+	Model               string         `json:"model"`
+	Messages            []chatMessage  `json:"messages"`
+	MaxCompletionTokens *int           `json:"max_completion_tokens,omitempty"`
+	Temperature         *float64       `json:"temperature,omitempty"`
+	TopP                *float64       `json:"top_p,omitempty"`
+	Stop                []string       `json:"stop,omitempty"`
+	Tools               []chatTool     `json:"tools,omitempty"`
+	ToolChoice          interface{}    `json:"tool_choice,omitempty"`
+	ParallelToolCalls   *bool          `json:"parallel_tool_calls,omitempty"`
+	ReasoningEffort     string         `json:"reasoning_effort,omitempty"`
+	Stream              bool           `json:"stream,omitempty"`
+	StreamOptions       *streamOptions `json:"stream_options,omitempty"`
+}
+
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type chatMessage struct {
+	Role       string         `json:"role"`
+	Content    *string        `json:"content"` // null for assistant messages with only tool calls
+	Refusal    *string        `json:"refusal,omitempty"`
+	ToolCalls  []chatToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string         `json:"tool_call_id,omitempty"`
+	Images     []string       `json:"-"` // User image URLs, sent as content parts after the text
+}
+
+// chatContentPart is a part of a user message with images
+type chatContentPart struct {
+	Type     string        `json:"type"` // "text" or "image_url"
+	Text     string        `json:"text,omitempty"`
+	ImageURL *chatImageURL `json:"image_url,omitempty"`
+}
+
+type chatImageURL struct {
+	URL string `json:"url"`
+}
+
+// MarshalJSON sends a message with images as content parts: its text, then the images
+func (m chatMessage) MarshalJSON() ([]byte, error) {
+	type plain chatMessage
+	if len(m.Images) == 0 {
+		return json.Marshal(plain(m))
+	}
+
+	parts := make([]chatContentPart, 0, len(m.Images)+1)
+	if m.Content != nil {
+		parts = append(parts, chatContentPart{Type: "text", Text: *m.Content})
+	}
+	for _, url := range m.Images {
+		parts = append(parts, chatContentPart{Type: "image_url", ImageURL: &chatImageURL{URL: url}})
+	}
+	return json.Marshal(struct {
+		plain
+		Content []chatContentPart `json:"content"`
+	}{plain(m), parts})
+}
+
+type chatToolCall struct {
+	Index    *int   `json:"index,omitempty"` // Streamed deltas only
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type,omitempty"` // "function"
+	Function struct {
+		Name      string `json:"name,omitempty"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+type chatTool struct {
+	Type     string `json:"type"` // "function"
+	Function struct {
+		Name        string      `json:"name"`
+		Description string      `json:"description,omitempty"`
+		Parameters  interface{} `json:"parameters"`
+	} `json:"function"`
+}
+
+type chatCompletion struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message      chatMessage `json:"message"` // Complete responses
+		Delta        chatMessage `json:"delta"`   // Streamed chunks
+		FinishReason *string     `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *chatUsage `json:"usage"`
+	Error *apiError  `json:"error"` // Error chunks of OpenAI-compatible servers
+}
+
+type chatUsage struct {
+	PromptTokens        int `json:"prompt_tokens"`
+	CompletionTokens    int `json:"completion_tokens"`
+	PromptTokensDetails struct {
+		CachedTokens int `json:"cached_tokens"`
+	} `json:"prompt_tokens_details"`
+	CompletionTokensDetails struct {
+		ReasoningTokens int `json:"reasoning_tokens"`
+	} `json:"completion_tokens_details"`
+}
+
+// buildChatCompletionRequest converts a library request
+func buildChatCompletionRequest(req *llmprovider.GenerateRequest, stream bool) (*chatRequest, error) {
+	messages, err := historyMessages(req.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &chatRequest{
+		Model:  req.Model,
+		Stream: stream,
+	}
+	if stream {
+		body.StreamOptions = &streamOptions{IncludeUsage: true}
+	}
+
+	params := req.Params
+	if params != nil && params.System != nil {
+		system := *params.System
+		body.Messages = append(body.Messages, chatMessage{Role: "developer", Content: &system})
+	}
+	history, err := chatMessages(messages)
+	if err != nil {
+		return nil, err
+	}
+	body.Messages = append(body.Messages, history...)
+
+	if params == nil {
+		return body, nil
+	}
+	body.MaxCompletionTokens = params.MaxTokens
+	body.Temperature = params.Temperature
+	body.TopP = params.TopP
+	body.Stop = params.Stop
+	body.ParallelToolCalls = params.ParallelToolCalls
+	body.ReasoningEffort = reasoningEffort(params)
+
+	for _, tool := range params.Tools {
+		var chat chatTool
+		chat.Type = "function"
+		chat.Function.Name = tool.Function.Name
+		chat.Function.Description = tool.Function.Description
+		chat.Function.Parameters = functionParameters(tool)
+		body.Tools = append(body.Tools, chat)
+	}
+
+	choice, err := toolChoice(params)
+	if err != nil {
+		return nil, err
+	}
+	if choice != nil {
+		if choice.Mode == llmprovider.ToolChoiceModeSpecific {
+			body.ToolChoice = map[string]interface{}{
+				"type":     "function",
+				"function": map[string]interface{}{"name": *choice.ToolName},
+			}
+		} else {
+			body.ToolChoice = string(choice.Mode)
+		}
+	}
+
+	return body, nil
+}
+
+// chatMessages converts the history in block order: an assistant message collects text
+// and tool calls, and each tool result becomes a "tool" message after it
+func chatMessages(messages []llmprovider.Message) ([]chatMessage, error) {
+	var result []chatMessage
+	var current *chatMessage
+	flush := func() {
+		if current != nil {
+			result = append(result, *current)
+			current = nil
+		}
+	}
+	message := func(role string) *chatMessage {
+		if current == nil {
+			current = &chatMessage{Role: role}
+		}
+		return current
+	}
+
+	for _, msg := range messages {
+		for _, block := range msg.Blocks {
+			switch block.BlockType {
+			case llmprovider.BlockTypeText:
+				if block.TextContent == nil || *block.TextContent == "" {
+					continue
+				}
+				m := message(msg.Role)
+				text := *block.TextContent
+				if m.Content != nil {
+					text = *m.Content + "\n\n" + text
+				}
+				m.Content = &text
+
+			case llmprovider.BlockTypeImage:
+				if url := imageURL(block); url != "" && msg.Role == "user" {
+					m := message(msg.Role)
+					m.Images = append(m.Images, url)
+				}
+
+			case llmprovider.BlockTypeToolUse:
+				call, err := toFunctionCall(block)
+				if err != nil {
+					return nil, err
+				}
+				var toolCall chatToolCall
+				toolCall.ID = call.CallID
+				toolCall.Type = "function"
+				toolCall.Function.Name = call.Name
+				toolCall.Function.Arguments = call.Arguments
+				m := message("assistant")
+				m.ToolCalls = append(m.ToolCalls, toolCall)
+
+			case llmprovider.BlockTypeToolResult:
+				callID, output, err := toolResultOutput(block)
+				if err != nil {
+					return nil, err
+				}
+				flush()
+				result = append(result, chatMessage{Role: "tool", Content: &output, ToolCallID: callID})
+
+			default:
+				// Reasoning can't be sent back, and other providers' blocks are skipped
+			}
+		}
+		flush()
+	}
+
+	return result, nil
+}
+
+// parseChatCompletion converts a complete response
+func parseChatCompletion(data []byte) (*llmprovider.GenerateResponse, error) {
+	var resp chatCompletion
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("openai response has no choices")
+	}
+
+	choice := resp.Choices[0]
+	var blocks []*llmprovider.Block
+	text := ""
+	if choice.Message.Content != nil {
+		text = *choice.Message.Content
+	} else if choice.Message.Refusal != nil {
+		text = *choice.Message.Refusal
+	}
+	if text != "" {
+		blocks = append(blocks, textBlock(llmprovider.BlockTypeText, 0, text))
+	}
+	for _, call := range choice.Message.ToolCalls {
+		block, err := toolUseBlock(len(blocks), call.ID, call.Function.Name, call.Function.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+
+	result := &llmprovider.GenerateResponse{
+		Blocks: blocks,
+		Model:  resp.Model,
+	}
+	if choice.FinishReason != nil {
+		result.StopReason = mapStopReason(*choice.FinishReason)
+	}
+	if resp.Usage != nil {
+		result.InputTokens = resp.Usage.PromptTokens
+		result.OutputTokens = resp.Usage.CompletionTokens
+		result.ResponseMetadata = usageMetadata(resp.Usage.CompletionTokensDetails.ReasoningTokens, resp.Usage.PromptTokensDetails.CachedTokens)
+	}
+	return result, nil
+}
+
+// streamChatCompletion reads a Chat Completions chunk stream into stream and returns the
+// metadata. Usage arrives in a last chunk without choices (stream_options.include_usage).
+func streamChatCompletion(body io.Reader, stream *blockStream) (*llmprovider.StreamMetadata, error) {
+	metadata := &llmprovider.StreamMetadata{}
+	finished := false
+
+	err := readEvents(body, func(data []byte) (bool, error) {
+		var chunk chatCompletion
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return false, nil // Not a chunk we know
+		}
+		if chunk.Error != nil {
+			return false, responseError(chunk.Error)
+		}
+		if chunk.Model != "" {
+			metadata.Model = chunk.Model
+		}
+		if usage := chunk.Usage; usage != nil {
+			metadata.InputTokens = usage.PromptTokens
+			metadata.OutputTokens = usage.CompletionTokens
+			metadata.ResponseMetadata = usageMetadata(usage.CompletionTokensDetails.ReasoningTokens, usage.PromptTokensDetails.CachedTokens)
+		}
+		if len(chunk.Choices) == 0 {
+			return false, nil
+		}
+
+		choice := chunk.Choices[0]
+		delta := choice.Delta
+		if delta.Content != nil {
+			if err := stream.text("content", llmprovider.BlockTypeText, *delta.Content); err != nil {
+				return false, err
+			}
+		}
+		if delta.Refusal != nil {
+			if err := stream.text("content", llmprovider.BlockTypeText, *delta.Refusal); err != nil {
+				return false, err
+			}
+		}
+		for _, call := range delta.ToolCalls {
+			index := 0
+			if call.Index != nil {
+				index = *call.Index
+			}
+			key := fmt.Sprintf("tool_call:%d", index)
+			if !stream.isOpen(key) {
+				if err := stream.toolCall(key, call.ID, call.Function.Name); err != nil {
+					return false, err
+				}
+			}
+			if err := stream.toolArguments(key, call.Function.Arguments); err != nil {
+				return false, err
+			}
+		}
+		if choice.FinishReason != nil {
+			metadata.StopReason = mapStopReason(*choice.FinishReason)
+			finished = true
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !finished {
+		return nil, fmt.Errorf("openai stream ended before the response completed")
+	}
+	if err := stream.finish(); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
@@ -0,0 +1,155 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+// Conversation history shared by both APIs. The backend sends one message per turn, so an
+// assistant message can hold tool_use and tool_result blocks of several tool rounds; both
+// APIs want them as separate items in order, which the request builders produce by
+// walking the blocks in order.
+
+// historyMessages prepares library messages for OpenAI: server tools of other providers
+// (e.g. Anthropic web search) become plain text turns
+func historyMessages(messages []llmprovider.Message) ([]llmprovider.Message, error) {
+	converted, err := llmprovider.SplitMessagesAtCrossProviderTool(messages, llmprovider.ProviderOpenAI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process cross-provider tools: %w", err)
+	}
+	return converted, nil
+}
+
+// functionCall is a tool_use block as an OpenAI function call
+type functionCall struct {
+	CallID    string
+	Name      string
+	Arguments string // JSON object
+}
+
+// toFunctionCall converts a tool_use block
+func toFunctionCall(block *llmprovider.Block) (functionCall, error) {
+	callID, _ := block.GetToolUseID()
+	name, _ := block.GetToolName()
+	if callID == "" || name == "" {
+		return functionCall{}, fmt.Errorf("tool_use block %d is missing tool_use_id or tool_name", block.Sequence)
+	}
+
+	input, ok := block.Content["input"]
+	if !ok || input == nil {
+		input = map[string]interface{}{}
+	}
+	arguments, err := json.Marshal(input)
+	if err != nil {
+		return functionCall{}, fmt.Errorf("tool_use block %d: failed to marshal input: %w", block.Sequence, err)
+	}
+	return functionCall{CallID: callID, Name: name, Arguments: string(arguments)}, nil
+}
+
+// toolResultOutput returns the call ID and output text of a tool_result block. The
+// backend normalizes results to strings; errors are sent as their message.
+func toolResultOutput(block *llmprovider.Block) (callID, output string, err error) {
+	callID, _ = block.GetToolUseID()
+	if callID == "" {
+		return "", "", fmt.Errorf("tool_result block %d is missing tool_use_id", block.Sequence)
+	}
+
+	isError, _ := block.Content["is_error"].(bool)
+	switch {
+	case block.TextContent != nil:
+		output = *block.TextContent
+	case stringContent(block, "content") != "":
+		output = stringContent(block, "content")
+	case isError && stringContent(block, "error") != "":
+		output = stringContent(block, "error")
+	default:
+		output = stringContent(block, "result")
+		if output == "" {
+			output = stringContent(block, "error")
+		}
+	}
+	return callID, output, nil
+}
+
+// imageURL returns the URL of a user image block. The backend inlines images as data
+// URLs, which both APIs accept.
+func imageURL(block *llmprovider.Block) string {
+	return stringContent(block, "url")
+}
+
+func stringContent(block *llmprovider.Block, key string) string {
+	value, _ := block.Content[key].(string)
+	return value
+}
+
+// isOpenAIReasoning reports whether a thinking block holds an OpenAI reasoning item,
+// the only reasoning OpenAI accepts back as input
+func isOpenAIReasoning(block *llmprovider.Block) bool {
+	return block.BlockType == llmprovider.BlockTypeThinking &&
+		block.IsFromProvider(llmprovider.ProviderOpenAI) && block.HasProviderData()
+}
+
+// reasoningEffort maps the thinking settings to a reasoning effort ("" = not requested)
+func reasoningEffort(params *llmprovider.RequestParams) string {
+	if params == nil || params.ThinkingEnabled == nil || !*params.ThinkingEnabled {
+		return ""
+	}
+	if params.ThinkingLevel != nil {
+		switch *params.ThinkingLevel {
+		case "minimal", "low", "medium", "high":
+			return *params.ThinkingLevel
+		}
+	}
+	return "medium"
+}
+
+// toolChoice reads the library tool choice (nil = provider default)
+func toolChoice(params *llmprovider.RequestParams) (*llmprovider.ToolChoice, error) {
+	if params == nil || params.ToolChoice == nil {
+		return nil, nil
+	}
+	choice, ok := params.ToolChoice.(*llmprovider.ToolChoice)
+	if !ok {
+		return nil, fmt.Errorf("unsupported tool_choice type %T", params.ToolChoice)
+	}
+	if choice == nil {
+		return nil, nil
+	}
+	if err := choice.Validate(); err != nil {
+		return nil, err
+	}
+	return choice, nil
+}
+
+// mapStopReason maps OpenAI finish reasons to the library's stop reasons
+func mapStopReason(reason string) string {
+	switch reason {
+	case "stop", "completed":
+		return "end_turn"
+	case "length", "max_output_tokens":
+		return "max_tokens"
+	case "tool_calls":
+		return "tool_use"
+	case "content_filter":
+		return "stop_sequence"
+	default:
+		return reason
+	}
+}
+
+// usageMetadata returns the response metadata for token details (nil if none)
+func usageMetadata(reasoningTokens, cachedTokens int) map[string]interface{} {
+	metadata := make(map[string]interface{})
+	if reasoningTokens > 0 {
+		metadata["reasoning_tokens"] = reasoningTokens
+	}
+	if cachedTokens > 0 {
+		metadata["cache_read_input_tokens"] = cachedTokens
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
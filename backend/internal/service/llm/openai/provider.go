@@ -0,0 +1,293 @@
+// Package openai implements the meridian-llm-go Provider interface for the OpenAI API,
+// so models can be used with a direct OpenAI key instead of through OpenRouter.
+//
+// Two APIs are supported: the Responses API (default), which returns reasoning summaries
+// and replays encrypted reasoning across tool rounds, and Chat Completions, for
+// OpenAI-compatible endpoints that only implement it. Both stream text, reasoning and
+// function calls as library blocks; all tools are function tools executed by the backend.
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+// API selects the OpenAI endpoint used for generation
+type API string
+
+const (
+	// APIResponses uses POST /responses
+	APIResponses API = "responses"
+	// APIChatCompletions uses POST /chat/completions
+	APIChatCompletions API = "chat_completions"
+)
+
+const (
+	// DefaultBaseURL is the OpenAI API base URL
+	DefaultBaseURL = "https://api.openai.com/v1"
+
+	// requestTimeout bounds a whole request, including a streamed response
+	requestTimeout = 10 * time.Minute
+)
+
+// modelPrefixes are the OpenAI model families (IDs without a "provider/" prefix)
+var modelPrefixes = []string{"gpt-", "chatgpt-", "o1", "o3", "o4-", "codex-"}
+
+// Config configures a Provider
+type Config struct {
+	APIKey  string
+	BaseURL string // Empty = DefaultBaseURL
+	API     API    // Empty = APIResponses
+}
+
+// Provider implements llmprovider.Provider for the OpenAI API
+type Provider struct {
+	apiKey     string
+	baseURL    string
+	api        API
+	httpClient *http.Client
+}
+
+var _ llmprovider.Provider = (*Provider)(nil)
+
+// NewProvider creates an OpenAI provider
+func NewProvider(cfg Config) (*Provider, error) {
+	if cfg.APIKey == "" {
+		return nil, llmprovider.ErrInvalidAPIKey
+	}
+
+	baseURL := strings.TrimSuffix(cfg.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	api := cfg.API
+	switch api {
+	case "":
+		api = APIResponses
+	case APIResponses, APIChatCompletions:
+	default:
+		return nil, fmt.Errorf("unknown OpenAI API %q (want %s or %s)", api, APIResponses, APIChatCompletions)
+	}
+
+	return &Provider{
+		apiKey:     cfg.APIKey,
+		baseURL:    baseURL,
+		api:        api,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// Name returns the provider identifier
+func (p *Provider) Name() llmprovider.ProviderID {
+	return llmprovider.ProviderOpenAI
+}
+
+// SupportsModel returns true for OpenAI model IDs (e.g. "gpt-5-mini", "o4-mini")
+func (p *Provider) SupportsModel(model string) bool {
+	model = strings.ToLower(model)
+	if strings.Contains(model, "/") {
+		return false // OpenRouter format ("openai/gpt-5-mini")
+	}
+	for _, prefix := range modelPrefixes {
+		if strings.HasPrefix(model, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateResponse generates a complete response
+func (p *Provider) GenerateResponse(ctx context.Context, req *llmprovider.GenerateRequest) (*llmprovider.GenerateResponse, error) {
+	body, err := p.buildRequest(req, false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.post(ctx, body, req.Model)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if p.api == APIChatCompletions {
+		return parseChatCompletion(data)
+	}
+	return parseResponse(data)
+}
+
+// StreamResponse streams a response. Each output item becomes one block: a first delta
+// carrying the block type, content deltas, then the complete block. The metadata event
+// comes last; a stream that ends early (e.g. cancelled) ends with an error instead.
+func (p *Provider) StreamResponse(ctx context.Context, req *llmprovider.GenerateRequest) (<-chan llmprovider.StreamEvent, error) {
+	body, err := p.buildRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.post(ctx, body, req.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan llmprovider.StreamEvent, 10)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+
+		stream := &blockStream{events: events}
+		var metadata *llmprovider.StreamMetadata
+		var err error
+		if p.api == APIChatCompletions {
+			metadata, err = streamChatCompletion(resp.Body, stream)
+		} else {
+			metadata, err = streamResponse(resp.Body, stream)
+		}
+		if err != nil {
+			events <- llmprovider.StreamEvent{Error: err}
+			return
+		}
+		events <- llmprovider.StreamEvent{Metadata: metadata}
+	}()
+
+	return events, nil
+}
+
+// BuildRequestDebug returns the request body the provider would send for req
+func (p *Provider) BuildRequestDebug(req *llmprovider.GenerateRequest) (map[string]interface{}, error) {
+	body, err := p.buildRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var debug map[string]interface{}
+	if err := json.Unmarshal(body, &debug); err != nil {
+		return nil, fmt.Errorf("failed to decode request: %w", err)
+	}
+	return debug, nil
+}
+
+// buildRequest validates the model and marshals the request body for the configured API
+func (p *Provider) buildRequest(req *llmprovider.GenerateRequest, stream bool) ([]byte, error) {
+	if !p.SupportsModel(req.Model) {
+		return nil, &llmprovider.ModelError{
+			Model:    req.Model,
+			Provider: p.Name().String(),
+			Reason:   "not an OpenAI model ID (use e.g. gpt-5-mini; provider/model IDs go through OpenRouter)",
+			Err:      llmprovider.ErrInvalidModel,
+		}
+	}
+
+	var body interface{}
+	var err error
+	if p.api == APIChatCompletions {
+		body, err = buildChatCompletionRequest(req, stream)
+	} else {
+		body, err = buildResponsesRequest(req, stream)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	return data, nil
+}
+
+// post sends a request body to the configured API and returns the successful response
+func (p *Provider) post(ctx context.Context, body []byte, model string) (*http.Response, error) {
+	path := "/responses"
+	if p.api == APIChatCompletions {
+		path = "/chat/completions"
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+p.apiKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("openai HTTP request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, p.handleErrorResponse(resp, model)
+	}
+	return resp, nil
+}
+
+// apiError is the error object of OpenAI error responses and stream error events
+type apiError struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+	Code    string `json:"code"`
+	Param   string `json:"param"`
+}
+
+// handleErrorResponse maps an error response to library errors
+func (p *Provider) handleErrorResponse(resp *http.Response, model string) error {
+	body, _ := io.ReadAll(resp.Body)
+
+	var errResp struct {
+		Error apiError `json:"error"`
+	}
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error.Message == "" {
+		return fmt.Errorf("openai error (HTTP %d): %s", resp.StatusCode, string(body))
+	}
+	message := errResp.Error.Message
+	if errResp.Error.Param != "" {
+		message = fmt.Sprintf("%s (param: %s)", message, errResp.Error.Param)
+	}
+
+	providerError := func(code llmprovider.ErrorCode, retryable bool, err error) error {
+		return &llmprovider.ProviderError{
+			Code:       code,
+			Provider:   p.Name().String(),
+			StatusCode: resp.StatusCode,
+			Message:    message,
+			Retryable:  retryable,
+			Err:        err,
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return llmprovider.ErrInvalidAPIKey
+	case http.StatusTooManyRequests:
+		if errResp.Error.Code == "insufficient_quota" {
+			return providerError(llmprovider.ErrorCodeProviderUnavailable, false, llmprovider.ErrProviderUnavailable)
+		}
+		return providerError(llmprovider.ErrorCodeRateLimited, true, llmprovider.ErrRateLimited)
+	case http.StatusRequestTimeout:
+		return providerError(llmprovider.ErrorCodeTimeout, true, llmprovider.ErrTimeout)
+	case http.StatusBadRequest:
+		return providerError(llmprovider.ErrorCodeInvalidRequest, false, fmt.Errorf("bad request: %s", message))
+	case http.StatusNotFound:
+		return &llmprovider.ModelError{
+			Model:    model,
+			Provider: p.Name().String(),
+			Reason:   message,
+			Err:      llmprovider.ErrInvalidModel,
+		}
+	default:
+		return providerError(llmprovider.ErrorCodeProviderUnavailable, resp.StatusCode >= 500, llmprovider.ErrProviderUnavailable)
+	}
+}
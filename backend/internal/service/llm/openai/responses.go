@@ -0,0 +1,534 @@
+package openai
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+// Responses API (POST /responses). Requests are stateless (store: false): the whole
+// history is sent each time, and reasoning is replayed from its encrypted content.
+
+// responsesRequest is the request body
+type responsesRequest struct {
+	Model             string           `json:"model"`
+	Input             []interface{}    `json:"input"`
+	Instructions      string           `json:"instructions,omitempty"`
+	MaxOutputTokens   *int             `json:"max_output_tokens,omitempty"`
+	Temperature       *float64         `json:"temperature,omitempty"`
+	TopP              *float64         `json:"top_p,omitempty"`
+	Tools             []responsesTool  `json:"tools,omitempty"`
+	ToolChoice        interface{}      `json:"tool_choice,omitempty"`
+	ParallelToolCalls *bool            `json:"parallel_tool_calls,omitempty"`
+	Reasoning         *reasoningConfig `json:"reasoning,omitempty"`
+	Include           []string         `json:"include,omitempty"`
+	Store             bool             `json:"store"`
+	Stream            bool             `json:"stream,omitempty"`
+}
+
+type reasoningConfig struct {
+	Effort  string `json:"effort"`
+	Summary string `json:"summary,omitempty"`
+}
+
+type responsesTool struct {
+	Type        string      `json:"type"` // "function"
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Parameters  interface{} `json:"parameters"`
+}
+
+// Input items
+
+type inputMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// imageMessage is a user message holding one image
+type imageMessage struct {
+	Role    string              `json:"role"` // "user"
+	Content []inputImageContent `json:"content"`
+}
+
+type inputImageContent struct {
+	Type     string `json:"type"` // "input_image"
+	ImageURL string `json:"image_url"`
+}
+
+type functionCallItem struct {
+	Type      string `json:"type"` // "function_call"
+	CallID    string `json:"call_id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+type functionCallOutputItem struct {
+	Type   string `json:"type"` // "function_call_output"
+	CallID string `json:"call_id"`
+	Output string `json:"output"`
+}
+
+// reasoningItem is a reasoning output item, replayed as input from a thinking block's
+// provider data
+type reasoningItem struct {
+	Type             string        `json:"type"` // "reasoning"
+	ID               string        `json:"id"`
+	Summary          []summaryPart `json:"summary"`
+	EncryptedContent *string       `json:"encrypted_content,omitempty"`
+}
+
+type summaryPart struct {
+	Type string `json:"type"` // "summary_text"
+	Text string `json:"text"`
+}
+
+// Output
+
+type responseObject struct {
+	ID                string            `json:"id"`
+	Model             string            `json:"model"`
+	Status            string            `json:"status"` // "completed", "incomplete", "failed"
+	Output            []json.RawMessage `json:"output"`
+	Usage             *responsesUsage   `json:"usage"`
+	IncompleteDetails *struct {
+		Reason string `json:"reason"`
+	} `json:"incomplete_details"`
+	Error *apiError `json:"error"`
+}
+
+type responsesUsage struct {
+	InputTokens        int `json:"input_tokens"`
+	OutputTokens       int `json:"output_tokens"`
+	InputTokensDetails struct {
+		CachedTokens int `json:"cached_tokens"`
+	} `json:"input_tokens_details"`
+	OutputTokensDetails struct {
+		ReasoningTokens int `json:"reasoning_tokens"`
+	} `json:"output_tokens_details"`
+}
+
+type outputItem struct {
+	Type    string `json:"type"` // "message", "reasoning", "function_call", ...
+	ID      string `json:"id"`
+	Content []struct {
+		Type    string `json:"type"` // "output_text" or "refusal"
+		Text    string `json:"text"`
+		Refusal string `json:"refusal"`
+	} `json:"content"`
+	Summary          []summaryPart `json:"summary"`
+	EncryptedContent *string       `json:"encrypted_content"`
+	CallID           string        `json:"call_id"`
+	Name             string        `json:"name"`
+	Arguments        string        `json:"arguments"`
+}
+
+// text returns a message's text or a reasoning item's summary (parts joined by a blank line)
+func (item *outputItem) text() string {
+	var parts []string
+	for _, content := range item.Content {
+		if content.Type == "refusal" {
+			parts = append(parts, content.Refusal)
+		} else {
+			parts = append(parts, content.Text)
+		}
+	}
+	if len(item.Summary) > 0 {
+		summaries := make([]string, len(item.Summary))
+		for i, summary := range item.Summary {
+			summaries[i] = summary.Text
+		}
+		parts = append(parts, strings.Join(summaries, "\n\n"))
+	}
+	return strings.Join(parts, "")
+}
+
+// replayable reports whether a reasoning item can be sent back as input
+func (item *outputItem) replayable() bool {
+	return item.EncryptedContent != nil && *item.EncryptedContent != ""
+}
+
+// streamEvent is one Responses streaming event
+type streamEvent struct {
+	Type         string          `json:"type"`
+	ItemID       string          `json:"item_id"`
+	SummaryIndex int             `json:"summary_index"`
+	Delta        string          `json:"delta"`
+	Item         json.RawMessage `json:"item"`
+	Response     *responseObject `json:"response"`
+	// "error" events
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// buildResponsesRequest converts a library request
+func buildResponsesRequest(req *llmprovider.GenerateRequest, stream bool) (*responsesRequest, error) {
+	messages, err := historyMessages(req.Messages)
+	if err != nil {
+		return nil, err
+	}
+	input, err := responsesInput(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &responsesRequest{
+		Model:  req.Model,
+		Input:  input,
+		Store:  false,
+		Stream: stream,
+	}
+
+	params := req.Params
+	if params == nil {
+		return body, nil
+	}
+	if params.System != nil {
+		body.Instructions = *params.System
+	}
+	body.MaxOutputTokens = params.MaxTokens
+	body.Temperature = params.Temperature
+	body.TopP = params.TopP
+	body.ParallelToolCalls = params.ParallelToolCalls
+
+	for _, tool := range params.Tools {
+		body.Tools = append(body.Tools, responsesTool{
+			Type:        "function",
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description,
+			Parameters:  functionParameters(tool),
+		})
+	}
+
+	choice, err := toolChoice(params)
+	if err != nil {
+		return nil, err
+	}
+	if choice != nil {
+		if choice.Mode == llmprovider.ToolChoiceModeSpecific {
+			body.ToolChoice = map[string]interface{}{"type": "function", "name": *choice.ToolName}
+		} else {
+			body.ToolChoice = string(choice.Mode)
+		}
+	}
+
+	// Reasoning summaries stream as thinking blocks; the encrypted reasoning lets tool
+	// rounds continue the same reasoning
+	if effort := reasoningEffort(params); effort != "" {
+		body.Reasoning = &reasoningConfig{Effort: effort, Summary: "auto"}
+		body.Include = []string{"reasoning.encrypted_content"}
+	}
+
+	return body, nil
+}
+
+// responsesInput converts the history to input items in block order
+func responsesInput(messages []llmprovider.Message) ([]interface{}, error) {
+	var input []interface{}
+	var text *inputMessage
+	flush := func() {
+		if text != nil {
+			input = append(input, *text)
+			text = nil
+		}
+	}
+
+	for _, msg := range messages {
+		for i, block := range msg.Blocks {
+			switch block.BlockType {
+			case llmprovider.BlockTypeText:
+				if block.TextContent == nil || *block.TextContent == "" {
+					continue
+				}
+				if text == nil {
+					text = &inputMessage{Role: msg.Role}
+				} else {
+					text.Content += "\n\n"
+				}
+				text.Content += *block.TextContent
+
+			case llmprovider.BlockTypeImage:
+				url := imageURL(block)
+				if url == "" || msg.Role != "user" {
+					continue
+				}
+				flush()
+				input = append(input, imageMessage{
+					Role:    "user",
+					Content: []inputImageContent{{Type: "input_image", ImageURL: url}},
+				})
+
+			case llmprovider.BlockTypeThinking:
+				// Reasoning only matters to the function calls that follow it
+				if !isOpenAIReasoning(block) || !precedesToolUse(msg.Blocks[i+1:]) {
+					continue
+				}
+				var item reasoningItem
+				if err := json.Unmarshal(block.ProviderData, &item); err != nil || item.ID == "" {
+					continue
+				}
+				item.Type = "reasoning"
+				if item.Summary == nil {
+					item.Summary = []summaryPart{}
+				}
+				flush()
+				input = append(input, item)
+
+			case llmprovider.BlockTypeToolUse:
+				call, err := toFunctionCall(block)
+				if err != nil {
+					return nil, err
+				}
+				flush()
+				input = append(input, functionCallItem{
+					Type:      "function_call",
+					CallID:    call.CallID,
+					Name:      call.Name,
+					Arguments: call.Arguments,
+				})
+
+			case llmprovider.BlockTypeToolResult:
+				callID, output, err := toolResultOutput(block)
+				if err != nil {
+					return nil, err
+				}
+				flush()
+				input = append(input, functionCallOutputItem{
+					Type:   "function_call_output",
+					CallID: callID,
+					Output: output,
+				})
+
+			default:
+				// Other providers' reasoning and server tools etc. can't be sent to OpenAI
+				// and are skipped
+			}
+		}
+		flush()
+	}
+
+	return input, nil
+}
+
+// precedesToolUse reports whether the next non-thinking block is a tool_use
+func precedesToolUse(blocks []*llmprovider.Block) bool {
+	for _, block := range blocks {
+		if block.BlockType != llmprovider.BlockTypeThinking {
+			return block.BlockType == llmprovider.BlockTypeToolUse
+		}
+	}
+	return false
+}
+
+// functionParameters returns a tool's JSON schema (an empty object schema if it has none)
+func functionParameters(tool llmprovider.Tool) interface{} {
+	if tool.Function.Parameters == nil {
+		return map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+	}
+	return tool.Function.Parameters
+}
+
+// parseResponse converts a complete response
+func parseResponse(data []byte) (*llmprovider.GenerateResponse, error) {
+	var resp responseObject
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if resp.Status == "failed" {
+		return nil, responseError(resp.Error)
+	}
+
+	var blocks []*llmprovider.Block
+	hasToolUse := false
+	for _, raw := range resp.Output {
+		var item outputItem
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, fmt.Errorf("failed to parse output item: %w", err)
+		}
+
+		sequence := len(blocks)
+		switch item.Type {
+		case "message":
+			if text := item.text(); text != "" {
+				blocks = append(blocks, textBlock(llmprovider.BlockTypeText, sequence, text))
+			}
+		case "reasoning":
+			text := item.text()
+			if text == "" && !item.replayable() {
+				continue
+			}
+			block := textBlock(llmprovider.BlockTypeThinking, sequence, text)
+			if item.replayable() {
+				block.ProviderData = raw
+			}
+			blocks = append(blocks, block)
+		case "function_call":
+			block, err := toolUseBlock(sequence, item.CallID, item.Name, item.Arguments)
+			if err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, block)
+			hasToolUse = true
+		}
+	}
+
+	result := &llmprovider.GenerateResponse{
+		Blocks:     blocks,
+		Model:      resp.Model,
+		StopReason: responseStopReason(&resp, hasToolUse),
+	}
+	if resp.Usage != nil {
+		result.InputTokens = resp.Usage.InputTokens
+		result.OutputTokens = resp.Usage.OutputTokens
+		result.ResponseMetadata = usageMetadata(resp.Usage.OutputTokensDetails.ReasoningTokens, resp.Usage.InputTokensDetails.CachedTokens)
+	}
+	return result, nil
+}
+
+// streamResponse reads a Responses event stream into stream and returns the metadata
+func streamResponse(body io.Reader, stream *blockStream) (*llmprovider.StreamMetadata, error) {
+	var metadata *llmprovider.StreamMetadata
+
+	err := readEvents(body, func(data []byte) (bool, error) {
+		var event streamEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return false, nil // Not an event we know
+		}
+
+		switch event.Type {
+		case "response.output_item.added":
+			var item outputItem
+			if err := json.Unmarshal(event.Item, &item); err != nil {
+				return false, fmt.Errorf("failed to parse output item: %w", err)
+			}
+			if item.Type == "function_call" {
+				return false, stream.toolCall(item.ID, item.CallID, item.Name)
+			}
+
+		case "response.output_text.delta", "response.refusal.delta":
+			return false, stream.text(event.ItemID, llmprovider.BlockTypeText, event.Delta)
+
+		case "response.reasoning_summary_part.added":
+			if event.SummaryIndex > 0 && stream.isOpen(event.ItemID) {
+				return false, stream.text(event.ItemID, llmprovider.BlockTypeThinking, "\n\n")
+			}
+
+		case "response.reasoning_summary_text.delta":
+			return false, stream.text(event.ItemID, llmprovider.BlockTypeThinking, event.Delta)
+
+		case "response.function_call_arguments.delta":
+			return false, stream.toolArguments(event.ItemID, event.Delta)
+
+		case "response.output_item.done":
+			return false, finishOutputItem(stream, event.Item)
+
+		case "response.completed", "response.incomplete":
+			if err := stream.finish(); err != nil {
+				return false, err
+			}
+			resp := event.Response
+			if resp == nil {
+				return false, fmt.Errorf("openai stream: %s event without a response", event.Type)
+			}
+			metadata = &llmprovider.StreamMetadata{
+				Model:      resp.Model,
+				StopReason: responseStopReason(resp, stream.hasToolUse),
+			}
+			if resp.Usage != nil {
+				metadata.InputTokens = resp.Usage.InputTokens
+				metadata.OutputTokens = resp.Usage.OutputTokens
+				metadata.ResponseMetadata = usageMetadata(resp.Usage.OutputTokensDetails.ReasoningTokens, resp.Usage.InputTokensDetails.CachedTokens)
+			}
+			return true, nil
+
+		case "response.failed":
+			var apiErr *apiError
+			if event.Response != nil {
+				apiErr = event.Response.Error
+			}
+			return false, responseError(apiErr)
+
+		case "error":
+			return false, responseError(&apiError{Code: event.Code, Message: event.Message})
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if metadata == nil {
+		return nil, fmt.Errorf("openai stream ended before the response completed")
+	}
+	return metadata, nil
+}
+
+// finishOutputItem completes the block of a finished output item. Items whose content
+// didn't stream (a reasoning item with only encrypted content, a function call without
+// argument deltas) are streamed whole first.
+func finishOutputItem(stream *blockStream, raw json.RawMessage) error {
+	var item outputItem
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return fmt.Errorf("failed to parse output item: %w", err)
+	}
+
+	switch item.Type {
+	case "message":
+		if !stream.isOpen(item.ID) {
+			return nil // No text
+		}
+
+	case "reasoning":
+		if !stream.isOpen(item.ID) {
+			if !item.replayable() {
+				return nil
+			}
+			if err := stream.text(item.ID, llmprovider.BlockTypeThinking, ""); err != nil {
+				return err
+			}
+		}
+		if item.replayable() {
+			stream.open.providerData = raw
+		}
+
+	case "function_call":
+		if !stream.isOpen(item.ID) {
+			if err := stream.toolCall(item.ID, item.CallID, item.Name); err != nil {
+				return err
+			}
+		}
+		if stream.open.arguments.Len() == 0 {
+			if err := stream.toolArguments(item.ID, item.Arguments); err != nil {
+				return err
+			}
+		}
+
+	default:
+		return nil // Built-in tool calls etc. aren't requested
+	}
+	return stream.finish()
+}
+
+// responseStopReason maps a response's status to the library's stop reason
+func responseStopReason(resp *responseObject, hasToolUse bool) string {
+	if resp.Status == "incomplete" && resp.IncompleteDetails != nil {
+		return mapStopReason(resp.IncompleteDetails.Reason)
+	}
+	if hasToolUse {
+		return "tool_use"
+	}
+	return "end_turn"
+}
+
+// responseError converts a failed response's error
+func responseError(apiErr *apiError) error {
+	if apiErr == nil || apiErr.Message == "" {
+		return fmt.Errorf("openai response failed")
+	}
+	if apiErr.Code != "" {
+		return fmt.Errorf("openai response failed (%s): %s", apiErr.Code, apiErr.Message)
+	}
+	return fmt.Errorf("openai response failed: %s", apiErr.Message)
+}
@@ -0,0 +1,130 @@
+package openai
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+func TestStreamResponse_ReasoningSummary(t *testing.T) {
+	item := `{"id":"rs_1","type":"reasoning","summary":[{"type":"summary_text","text":"Plan."},{"type":"summary_text","text":"Check."}],"encrypted_content":"enc"}`
+	body := strings.Join([]string{
+		`data: {"type":"response.output_item.added","item":{"id":"rs_1","type":"reasoning","summary":[]}}`,
+		`data: {"type":"response.reasoning_summary_part.added","item_id":"rs_1","summary_index":0}`,
+		`data: {"type":"response.reasoning_summary_text.delta","item_id":"rs_1","summary_index":0,"delta":"Plan."}`,
+		`data: {"type":"response.reasoning_summary_part.added","item_id":"rs_1","summary_index":1}`,
+		`data: {"type":"response.reasoning_summary_text.delta","item_id":"rs_1","summary_index":1,"delta":"Check."}`,
+		`data: {"type":"response.output_item.done","item":` + item + `}`,
+		`data: {"type":"response.completed","response":{"model":"gpt-5","status":"completed","usage":{"input_tokens":10,"output_tokens":40,"output_tokens_details":{"reasoning_tokens":32}}}}`,
+	}, "\n\n")
+
+	events := make(chan llmprovider.StreamEvent, 16)
+	metadata, err := streamResponse(strings.NewReader(body), &blockStream{events: events})
+	if err != nil {
+		t.Fatalf("streamResponse: %v", err)
+	}
+	close(events)
+
+	var blocks []*llmprovider.Block
+	for event := range events {
+		if event.Block != nil {
+			blocks = append(blocks, event.Block)
+		}
+	}
+	if len(blocks) != 1 || blocks[0].BlockType != llmprovider.BlockTypeThinking {
+		t.Fatalf("expected one thinking block, got %+v", blocks)
+	}
+	if got := *blocks[0].TextContent; got != "Plan.\n\nCheck." {
+		t.Errorf("thinking = %q", got)
+	}
+	if string(blocks[0].ProviderData) != item {
+		t.Errorf("provider data = %s, want the reasoning item", blocks[0].ProviderData)
+	}
+	if metadata.StopReason != "end_turn" || metadata.ResponseMetadata["reasoning_tokens"] != 32 {
+		t.Errorf("metadata = %+v", metadata)
+	}
+}
+
+func TestResponsesInput_ReplaysReasoningBeforeFunctionCalls(t *testing.T) {
+	provider := providerName()
+	reasoning := func(id string) *llmprovider.Block {
+		text := "thinking"
+		return &llmprovider.Block{
+			BlockType:    llmprovider.BlockTypeThinking,
+			TextContent:  &text,
+			Provider:     provider,
+			ProviderData: json.RawMessage(`{"id":"` + id + `","type":"reasoning","summary":[],"encrypted_content":"enc"}`),
+		}
+	}
+	call, err := toolUseBlock(1, "call_1", "lookup_note", `{"name":"plot"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	answer := "Done."
+
+	input, err := responsesInput([]llmprovider.Message{{
+		Role: "assistant",
+		Blocks: []*llmprovider.Block{
+			reasoning("rs_1"),
+			call,
+			{BlockType: llmprovider.BlockTypeToolResult, Content: map[string]interface{}{"tool_use_id": "call_1", "result": "a heist"}},
+			reasoning("rs_2"), // Followed by text only: not replayed
+			{BlockType: llmprovider.BlockTypeText, TextContent: &answer},
+		},
+	}})
+	if err != nil {
+		t.Fatalf("responsesInput: %v", err)
+	}
+
+	data, _ := json.Marshal(input)
+	var items []map[string]interface{}
+	_ = json.Unmarshal(data, &items)
+
+	var types []string
+	for _, item := range items {
+		if itemType, ok := item["type"].(string); ok {
+			types = append(types, itemType)
+		} else {
+			types = append(types, item["role"].(string))
+		}
+	}
+	if got := strings.Join(types, ","); got != "reasoning,function_call,function_call_output,assistant" {
+		t.Fatalf("items = %s", got)
+	}
+	if items[0]["id"] != "rs_1" || items[0]["encrypted_content"] != "enc" {
+		t.Errorf("reasoning item = %v", items[0])
+	}
+	if items[2]["call_id"] != "call_1" || items[2]["output"] != "a heist" {
+		t.Errorf("function_call_output = %v", items[2])
+	}
+}
+
+func TestUserImages(t *testing.T) {
+	prompt, url := "What's in this picture?", "data:image/png;base64,cG5n"
+	messages := []llmprovider.Message{{Role: "user", Blocks: []*llmprovider.Block{
+		{BlockType: llmprovider.BlockTypeText, TextContent: &prompt},
+		{BlockType: llmprovider.BlockTypeImage, Content: map[string]interface{}{"url": url, "mime_type": "image/png"}},
+	}}}
+
+	input, err := responsesInput(messages)
+	if err != nil {
+		t.Fatalf("responsesInput: %v", err)
+	}
+	data, _ := json.Marshal(input)
+	want := `[{"role":"user","content":"What's in this picture?"},{"role":"user","content":[{"type":"input_image","image_url":"` + url + `"}]}]`
+	if string(data) != want {
+		t.Errorf("responses input = %s", data)
+	}
+
+	chat, err := chatMessages(messages)
+	if err != nil {
+		t.Fatalf("chatMessages: %v", err)
+	}
+	data, _ = json.Marshal(chat)
+	want = `[{"role":"user","content":[{"type":"text","text":"What's in this picture?"},{"type":"image_url","image_url":{"url":"` + url + `"}}]}]`
+	if string(data) != want {
+		t.Errorf("chat messages = %s", data)
+	}
+}
@@ -0,0 +1,209 @@
+package openai
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	llmprovider "github.com/haowjy/meridian-llm-go"
+)
+
+// maxEventSize bounds one SSE data line (a streamed event is far smaller)
+const maxEventSize = 4 * 1024 * 1024
+
+// readEvents calls fn with the data of each SSE event until fn returns done, fn fails,
+// or the "data: [DONE]" that ends a Chat Completions stream. A stream that ends otherwise
+// is incomplete.
+func readEvents(body io.Reader, fn func(data []byte) (done bool, err error)) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue // event names, comments (keep-alives) and blank separators
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			return nil
+		}
+		if data == "" {
+			continue
+		}
+		done, err := fn([]byte(data))
+		if err != nil || done {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading stream: %w", err)
+	}
+	return fmt.Errorf("openai stream ended before the response completed")
+}
+
+// blockStream emits library stream events for the blocks of a streamed response. One
+// block is open at a time: starting the next block completes the open one, so deltas
+// always arrive in block order and every streamed block completes exactly once.
+type blockStream struct {
+	events     chan<- llmprovider.StreamEvent
+	next       int        // Index of the next block
+	open       *openBlock // Block receiving deltas, nil between blocks
+	hasToolUse bool
+}
+
+// openBlock accumulates a block's content while it streams
+type openBlock struct {
+	key          string // Output item (Responses) or choice part (Chat Completions)
+	index        int
+	blockType    string
+	text         strings.Builder
+	toolCallID   string
+	toolName     string
+	arguments    strings.Builder
+	providerData json.RawMessage
+}
+
+// text appends text to the block with key, starting a text or thinking block if needed
+func (s *blockStream) text(key, blockType, delta string) error {
+	if s.open == nil || s.open.key != key {
+		if err := s.start(&openBlock{key: key, blockType: blockType}); err != nil {
+			return err
+		}
+	}
+	if delta == "" {
+		return nil
+	}
+	s.open.text.WriteString(delta)
+
+	deltaType := llmprovider.DeltaTypeText
+	if blockType == llmprovider.BlockTypeThinking {
+		deltaType = llmprovider.DeltaTypeThinking
+	}
+	s.send(llmprovider.StreamEvent{Delta: &llmprovider.BlockDelta{
+		BlockIndex: s.open.index,
+		DeltaType:  deltaType,
+		TextDelta:  &delta,
+	}})
+	return nil
+}
+
+// toolCall starts a tool_use block for a function call
+func (s *blockStream) toolCall(key, callID, name string) error {
+	return s.start(&openBlock{key: key, blockType: llmprovider.BlockTypeToolUse, toolCallID: callID, toolName: name})
+}
+
+// toolArguments appends argument JSON to the function call with key
+func (s *blockStream) toolArguments(key, delta string) error {
+	if s.open == nil || s.open.key != key || s.open.blockType != llmprovider.BlockTypeToolUse {
+		return fmt.Errorf("openai stream: arguments for function call %s, which is not streaming", key)
+	}
+	if delta == "" {
+		return nil
+	}
+	s.open.arguments.WriteString(delta)
+	s.send(llmprovider.StreamEvent{Delta: &llmprovider.BlockDelta{
+		BlockIndex: s.open.index,
+		DeltaType:  llmprovider.DeltaTypeJSON,
+		JSONDelta:  &delta,
+	}})
+	return nil
+}
+
+// isOpen reports whether the block with key is streaming
+func (s *blockStream) isOpen(key string) bool {
+	return s.open != nil && s.open.key == key
+}
+
+// start completes the open block and starts block with its first delta
+func (s *blockStream) start(block *openBlock) error {
+	if err := s.finish(); err != nil {
+		return err
+	}
+	block.index = s.next
+	s.next++
+	s.open = block
+
+	delta := &llmprovider.BlockDelta{
+		BlockIndex: block.index,
+		BlockType:  &block.blockType,
+		DeltaType:  llmprovider.DeltaTypeText,
+	}
+	switch block.blockType {
+	case llmprovider.BlockTypeThinking:
+		delta.DeltaType = llmprovider.DeltaTypeThinking
+	case llmprovider.BlockTypeToolUse:
+		delta.DeltaType = llmprovider.DeltaTypeToolCallStart
+		delta.ToolCallID = &block.toolCallID
+		delta.ToolCallName = &block.toolName
+		s.hasToolUse = true
+	}
+	s.send(llmprovider.StreamEvent{Delta: delta})
+	return nil
+}
+
+// finish completes the open block, if any
+func (s *blockStream) finish() error {
+	open := s.open
+	if open == nil {
+		return nil
+	}
+	s.open = nil
+
+	var block *llmprovider.Block
+	if open.blockType == llmprovider.BlockTypeToolUse {
+		var err error
+		block, err = toolUseBlock(open.index, open.toolCallID, open.toolName, open.arguments.String())
+		if err != nil {
+			return err
+		}
+	} else {
+		block = textBlock(open.blockType, open.index, open.text.String())
+	}
+	block.ProviderData = open.providerData
+	s.send(llmprovider.StreamEvent{Block: block})
+	return nil
+}
+
+// send emits an event; the consumer reads until the channel closes
+func (s *blockStream) send(event llmprovider.StreamEvent) {
+	s.events <- event
+}
+
+// textBlock builds a complete text or thinking block
+func textBlock(blockType string, sequence int, text string) *llmprovider.Block {
+	return &llmprovider.Block{
+		BlockType:   blockType,
+		Sequence:    sequence,
+		TextContent: &text,
+		Provider:    providerName(),
+	}
+}
+
+// toolUseBlock builds a complete tool_use block from a function call
+func toolUseBlock(sequence int, callID, name, arguments string) (*llmprovider.Block, error) {
+	input := make(map[string]interface{})
+	if strings.TrimSpace(arguments) != "" {
+		if err := json.Unmarshal([]byte(arguments), &input); err != nil {
+			return nil, fmt.Errorf("invalid arguments for function call %s (%s): %w", callID, name, err)
+		}
+	}
+
+	executionSide := llmprovider.ExecutionSideServer // Function tools run in the backend
+	return &llmprovider.Block{
+		BlockType: llmprovider.BlockTypeToolUse,
+		Sequence:  sequence,
+		Content: map[string]interface{}{
+			"tool_use_id": callID,
+			"tool_name":   name,
+			"input":       input,
+		},
+		ExecutionSide: &executionSide,
+		Provider:      providerName(),
+	}, nil
+}
+
+func providerName() *string {
+	name := llmprovider.ProviderOpenAI.String()
+	return &name
+}
@@ -0,0 +1,66 @@
+package pin
+
+import (
+	"strings"
+
+	llmModels "meridian/internal/domain/models/llm"
+	"meridian/internal/utils"
+)
+
+const (
+	// nameMaxWords and nameMaxLength bound names derived from a turn's first line
+	nameMaxWords  = 8
+	nameMaxLength = 80
+
+	// fallbackName is used when neither the turn nor its chat yields a name
+	fallbackName = "Pinned answer"
+)
+
+// turnMarkdown joins the text blocks of a turn (thinking, tool calls and results are left out)
+func turnMarkdown(blocks []llmModels.TurnBlock) string {
+	var parts []string
+	for _, block := range blocks {
+		if block.BlockType == llmModels.BlockTypeText && block.TextContent != nil {
+			if text := strings.TrimSpace(*block.TextContent); text != "" {
+				parts = append(parts, text)
+			}
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// documentName derives a document name from the first line of prose in content (without
+// Markdown markers, code blocks skipped), falling back to the chat title
+func documentName(content, chatTitle string) string {
+	inCode := false
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+		if name := nameFromText(line); name != "" {
+			return name
+		}
+	}
+	if name := nameFromText(chatTitle); name != "" {
+		return name
+	}
+	return fallbackName
+}
+
+// nameFromText strips Markdown markers and "/" (the path separator in names) and keeps
+// the first few words
+func nameFromText(text string) string {
+	text = strings.TrimLeft(strings.TrimSpace(text), "#>-+*` ")
+	text = strings.NewReplacer("**", "", "__", "", "`", "", "/", "-").Replace(text)
+
+	words := strings.Fields(text)
+	if len(words) > nameMaxWords {
+		words = words[:nameMaxWords]
+	}
+	name := strings.TrimRight(strings.Join(words, " "), ".:;,")
+	return utils.TruncateWithEllipsis(name, nameMaxLength)
+}
@@ -0,0 +1,27 @@
+package pin
+
+import "testing"
+
+func TestDocumentName(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		chatTitle string
+		want      string
+	}{
+		{"heading", "# The Harbor\n\nText", "Chat", "The Harbor"},
+		{"bold first line", "**Summary:** what we decided", "Chat", "Summary: what we decided"},
+		{"long line keeps first words", "Aria walks along the harbor wall counting lanterns as the wind rises", "Chat", "Aria walks along the harbor wall counting lanterns"},
+		{"slash replaced", "Act 1/Scene 2 outline", "Chat", "Act 1-Scene 2 outline"},
+		{"code block skipped", "```go\nfunc main() {}\n```\nThe fix", "Chat", "The fix"},
+		{"chat title fallback", "---\n", "Magic system", "Magic system"},
+		{"fixed fallback", "", "", fallbackName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := documentName(tt.content, tt.chatTitle); got != tt.want {
+				t.Errorf("documentName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Package pin saves assistant turns into the project as documents
+// ("save this answer to my notes"), keeping a backlink to the source turn.
+package pin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"meridian/internal/domain"
+	docsysModels "meridian/internal/domain/models/docsystem"
+	llmModels "meridian/internal/domain/models/llm"
+	"meridian/internal/domain/repositories"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/domain/services"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+// maxRenameAttempts bounds the "name (n)" candidates tried when the name is taken
+const maxRenameAttempts = 20
+
+// Service implements the PinService interface
+type Service struct {
+	pinRepo         llmRepo.PinRepository
+	turnReader      llmRepo.TurnReader
+	chatRepo        llmRepo.ChatRepository
+	settingsRepo    docsysRepo.ProjectSettingsRepository
+	documentService docsysSvc.DocumentService
+	txManager       repositories.TransactionManager
+	authorizer      services.ResourceAuthorizer
+	logger          *slog.Logger
+}
+
+// NewService creates a new pin service
+func NewService(
+	pinRepo llmRepo.PinRepository,
+	turnReader llmRepo.TurnReader,
+	chatRepo llmRepo.ChatRepository,
+	settingsRepo docsysRepo.ProjectSettingsRepository,
+	documentService docsysSvc.DocumentService,
+	txManager repositories.TransactionManager,
+	authorizer services.ResourceAuthorizer,
+	logger *slog.Logger,
+) llmSvc.PinService {
+	return &Service{
+		pinRepo:         pinRepo,
+		turnReader:      turnReader,
+		chatRepo:        chatRepo,
+		settingsRepo:    settingsRepo,
+		documentService: documentService,
+		txManager:       txManager,
+		authorizer:      authorizer,
+		logger:          logger,
+	}
+}
+
+// PinTurn saves an assistant turn's text as a document in the project's pin folder
+func (s *Service) PinTurn(ctx context.Context, userID, turnID string, req *llmSvc.PinTurnRequest) (*llmSvc.PinnedTurn, error) {
+	if err := s.authorizer.CanAccessTurn(ctx, userID, turnID); err != nil {
+		return nil, err
+	}
+
+	turn, err := s.turnReader.GetTurn(ctx, turnID)
+	if err != nil {
+		return nil, err
+	}
+	if turn.Role != "assistant" || turn.Status != "complete" {
+		return nil, fmt.Errorf("%w: only completed assistant turns can be pinned", domain.ErrValidation)
+	}
+
+	blocks, err := s.turnReader.GetTurnBlocks(ctx, turnID)
+	if err != nil {
+		return nil, err
+	}
+	content := turnMarkdown(blocks)
+	if content == "" {
+		return nil, fmt.Errorf("%w: turn has no text to pin", domain.ErrValidation)
+	}
+
+	chat, err := s.chatRepo.GetChat(ctx, turn.ChatID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	folderPath, err := s.folderPath(ctx, chat.ProjectID, userID, req.FolderPath)
+	if err != nil {
+		return nil, err
+	}
+
+	name := documentName(content, chat.Title)
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: name cannot be empty", domain.ErrValidation)
+		}
+	}
+
+	var pinned *llmSvc.PinnedTurn
+	err = s.txManager.ExecTx(ctx, func(txCtx context.Context) error {
+		doc, err := s.createDocument(txCtx, chat.ProjectID, userID, folderPath, name, content)
+		if err != nil {
+			return err
+		}
+
+		pin := &llmModels.TurnPin{DocumentID: doc.ID, TurnID: turnID}
+		if err := s.pinRepo.CreatePin(txCtx, pin); err != nil {
+			return err
+		}
+
+		pinned = &llmSvc.PinnedTurn{Document: doc, Source: pin}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("turn pinned to project",
+		"turn_id", turnID,
+		"document_id", pinned.Document.ID,
+		"path", pinned.Document.Path,
+		"project_id", chat.ProjectID,
+		"user_id", userID,
+	)
+
+	return pinned, nil
+}
+
+// GetDocumentSource returns the turn a document was pinned from
+func (s *Service) GetDocumentSource(ctx context.Context, userID, documentID string) (*llmModels.TurnPin, error) {
+	if err := s.authorizer.CanAccessDocument(ctx, userID, documentID); err != nil {
+		return nil, err
+	}
+	return s.pinRepo.GetPinByDocument(ctx, documentID)
+}
+
+// folderPath picks the target folder: the request's, else the project's pin_folder, else DefaultPinFolder
+func (s *Service) folderPath(ctx context.Context, projectID, userID string, requested *string) (string, error) {
+	if requested != nil {
+		return strings.Trim(strings.TrimSpace(*requested), "/"), nil
+	}
+
+	settings, err := s.settingsRepo.Get(ctx, projectID, userID)
+	if err != nil {
+		return "", err
+	}
+	if settings.PinFolder != nil && *settings.PinFolder != "" {
+		return *settings.PinFolder, nil
+	}
+	return docsysModels.DefaultPinFolder, nil
+}
+
+// createDocument creates the document, appending " (n)" to the name while it is taken
+func (s *Service) createDocument(ctx context.Context, projectID, userID, folderPath, name, content string) (*docsysModels.Document, error) {
+	candidate := name
+	for n := 2; ; n++ {
+		doc, err := s.documentService.CreateDocument(ctx, &docsysSvc.CreateDocumentRequest{
+			ProjectID:  projectID,
+			UserID:     userID,
+			FolderPath: &folderPath,
+			Name:       candidate,
+			Content:    content,
+		})
+		if !errors.Is(err, domain.ErrConflict) || n > maxRenameAttempts {
+			return doc, err
+		}
+		candidate = fmt.Sprintf("%s (%d)", name, n)
+	}
+}
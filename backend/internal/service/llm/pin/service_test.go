@@ -0,0 +1,165 @@
+package pin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"meridian/internal/domain"
+	docsysModels "meridian/internal/domain/models/docsystem"
+	llmModels "meridian/internal/domain/models/llm"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/testmocks"
+)
+
+// fakeTurnReader serves one turn; other TurnReader methods are unused
+type fakeTurnReader struct {
+	llmRepo.TurnReader
+	turn   llmModels.Turn
+	blocks []llmModels.TurnBlock
+}
+
+func (f *fakeTurnReader) GetTurn(ctx context.Context, turnID string) (*llmModels.Turn, error) {
+	if turnID != f.turn.ID {
+		return nil, domain.ErrNotFound
+	}
+	turn := f.turn
+	return &turn, nil
+}
+
+func (f *fakeTurnReader) GetTurnBlocks(ctx context.Context, turnID string) ([]llmModels.TurnBlock, error) {
+	return f.blocks, nil
+}
+
+// fakeChatRepo serves one chat; other ChatRepository methods are unused
+type fakeChatRepo struct {
+	llmRepo.ChatRepository
+}
+
+func (f *fakeChatRepo) GetChat(ctx context.Context, chatID, userID string) (*llmModels.Chat, error) {
+	return &llmModels.Chat{ID: chatID, ProjectID: "project-1", Title: "Worldbuilding"}, nil
+}
+
+// fakeSettingsRepo returns fixed settings; other methods are unused
+type fakeSettingsRepo struct {
+	docsysRepo.ProjectSettingsRepository
+	pinFolder *string
+}
+
+func (f *fakeSettingsRepo) Get(ctx context.Context, projectID, userID string) (*docsysModels.ProjectSettings, error) {
+	settings := docsysModels.DefaultProjectSettings(projectID)
+	settings.PinFolder = f.pinFolder
+	return settings, nil
+}
+
+// fakeDocumentService rejects names in taken and records created documents
+type fakeDocumentService struct {
+	docsysSvc.DocumentService
+	taken   map[string]bool
+	created []*docsysSvc.CreateDocumentRequest
+}
+
+func (f *fakeDocumentService) CreateDocument(ctx context.Context, req *docsysSvc.CreateDocumentRequest) (*docsysModels.Document, error) {
+	if f.taken[req.Name] {
+		return nil, &domain.ConflictError{Message: "taken", ResourceType: "document"}
+	}
+	f.created = append(f.created, req)
+	return &docsysModels.Document{ID: "doc-1", ProjectID: req.ProjectID, Name: req.Name, Path: *req.FolderPath + "/" + req.Name, Content: req.Content}, nil
+}
+
+// fakePinRepo records pins
+type fakePinRepo struct {
+	pins []llmModels.TurnPin
+}
+
+func (f *fakePinRepo) CreatePin(ctx context.Context, pin *llmModels.TurnPin) error {
+	if testmocks.TxDepth(ctx) == 0 {
+		return errors.New("pin created outside a transaction")
+	}
+	pin.ID = "pin-1"
+	pin.ChatID = "chat-1"
+	f.pins = append(f.pins, *pin)
+	return nil
+}
+
+func (f *fakePinRepo) GetPinByDocument(ctx context.Context, documentID string) (*llmModels.TurnPin, error) {
+	return nil, domain.ErrNotFound
+}
+
+func newTestService(text string, pinFolder *string, taken ...string) (llmSvc.PinService, *fakeDocumentService, *fakePinRepo) {
+	reader := &fakeTurnReader{
+		turn:   llmModels.Turn{ID: "turn-1", ChatID: "chat-1", Role: "assistant", Status: "complete"},
+		blocks: []llmModels.TurnBlock{{BlockType: llmModels.BlockTypeText, TextContent: &text}},
+	}
+	docs := &fakeDocumentService{taken: map[string]bool{}}
+	for _, name := range taken {
+		docs.taken[name] = true
+	}
+	pins := &fakePinRepo{}
+	svc := NewService(pins, reader, &fakeChatRepo{}, &fakeSettingsRepo{pinFolder: pinFolder}, docs,
+		&testmocks.TxManager{}, &testmocks.Authorizer{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return svc, docs, pins
+}
+
+func TestPinTurn_CreatesDocumentWithBacklink(t *testing.T) {
+	svc, docs, pins := newTestService("## Aria's backstory\n\nAria grew up in the harbor.", nil)
+
+	pinned, err := svc.PinTurn(context.Background(), "user-1", "turn-1", &llmSvc.PinTurnRequest{})
+	if err != nil {
+		t.Fatalf("PinTurn: %v", err)
+	}
+
+	req := docs.created[0]
+	if *req.FolderPath != docsysModels.DefaultPinFolder || req.Name != "Aria's backstory" || req.ProjectID != "project-1" {
+		t.Errorf("document request = folder %q, name %q, project %q", *req.FolderPath, req.Name, req.ProjectID)
+	}
+	if req.Content != "## Aria's backstory\n\nAria grew up in the harbor." {
+		t.Errorf("content = %q", req.Content)
+	}
+	if len(pins.pins) != 1 || pins.pins[0].TurnID != "turn-1" || pins.pins[0].DocumentID != "doc-1" {
+		t.Errorf("pins = %+v", pins.pins)
+	}
+	if pinned.Source.ChatID != "chat-1" {
+		t.Errorf("source = %+v", pinned.Source)
+	}
+}
+
+func TestPinTurn_FolderAndRename(t *testing.T) {
+	folder := "Research/Pinned"
+	svc, docs, _ := newTestService("Harbor notes", &folder, "Harbor notes", "Harbor notes (2)")
+
+	if _, err := svc.PinTurn(context.Background(), "user-1", "turn-1", &llmSvc.PinTurnRequest{}); err != nil {
+		t.Fatalf("PinTurn: %v", err)
+	}
+	req := docs.created[0]
+	if *req.FolderPath != folder || req.Name != "Harbor notes (3)" {
+		t.Errorf("got folder %q, name %q; want project pin folder and next free name", *req.FolderPath, req.Name)
+	}
+
+	requested := " /Inbox/ "
+	name := "Keep"
+	if _, err := svc.PinTurn(context.Background(), "user-1", "turn-1", &llmSvc.PinTurnRequest{FolderPath: &requested, Name: &name}); err != nil {
+		t.Fatalf("PinTurn with overrides: %v", err)
+	}
+	if req := docs.created[1]; *req.FolderPath != "Inbox" || req.Name != "Keep" {
+		t.Errorf("got folder %q, name %q; want request overrides", *req.FolderPath, req.Name)
+	}
+}
+
+func TestPinTurn_Validation(t *testing.T) {
+	svc, _, _ := newTestService("   ", nil)
+	if _, err := svc.PinTurn(context.Background(), "user-1", "turn-1", &llmSvc.PinTurnRequest{}); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("empty turn: err = %v, want ErrValidation", err)
+	}
+
+	svc, _, _ = newTestService("Text", nil)
+	blank := "  "
+	if _, err := svc.PinTurn(context.Background(), "user-1", "turn-1", &llmSvc.PinTurnRequest{Name: &blank}); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("blank name: err = %v, want ErrValidation", err)
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"fmt"
 
 	llmprovider "github.com/haowjy/meridian-llm-go"
-	"github.com/haowjy/meridian-llm-go/providers/anthropic"
 	"github.com/haowjy/meridian-llm-go/providers/lorem"
 	"github.com/haowjy/meridian-llm-go/providers/openrouter"
 
 	"meridian/internal/config"
+	"meridian/internal/service/llm/anthropic"
+	"meridian/internal/service/llm/gemini"
+	"meridian/internal/service/llm/ollama"
+	"meridian/internal/service/llm/openai"
 )
 
 // ProviderFactory creates and manages LLM provider instances
@@ -29,9 +32,10 @@ func NewProviderFactory(cfg *config.Config) *ProviderFactory {
 //   - "anthropic" - Claude models via Anthropic API
 //   - "lorem" - Mock provider for testing (no API key required)
 //   - "openrouter" - Multiple providers via OpenRouter
+//   - "openai" - OpenAI models via the OpenAI API (no proxy)
+//   - "google" - Gemini models via the Gemini API (no proxy)
+//   - "ollama" - Local or self-hosted models via an Ollama server (no API key)
 //   - "bedrock" - AWS Bedrock (future)
-//   - "openai" - OpenAI models (future)
-//   - "gemini" - Google Gemini models (future)
 func (f *ProviderFactory) GetProvider(providerName string) (llmprovider.Provider, error) {
 	switch providerName {
 	case "anthropic":
@@ -43,26 +47,62 @@ func (f *ProviderFactory) GetProvider(providerName string) (llmprovider.Provider
 	case "openrouter":
 		return f.createOpenRouterProvider()
 
+	case "openai":
+		return f.createOpenAIProvider()
+
+	case "google":
+		return f.createGeminiProvider()
+
+	case "ollama":
+		return f.createOllamaProvider()
+
 	// Future providers:
 	// case "bedrock":
 	// 	return f.createBedrockProvider()
-	// case "openai":
-	// 	return f.createOpenAIProvider()
-	// case "gemini":
-	// 	return f.createGeminiProvider()
 
 	default:
 		return nil, fmt.Errorf("unsupported provider: %s", providerName)
 	}
 }
 
+// GetProviderWithKey returns a provider instance that authenticates with the given API
+// key instead of the server's (a user's own key). Only key-based providers are supported.
+func (f *ProviderFactory) GetProviderWithKey(providerName, apiKey string) (llmprovider.Provider, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("API key cannot be empty")
+	}
+
+	switch providerName {
+	case "anthropic":
+		return newAnthropicProvider(apiKey)
+
+	case "openrouter":
+		return newOpenRouterProvider(apiKey)
+
+	case "openai":
+		return f.newOpenAIProvider(apiKey)
+
+	case "google":
+		return f.newGeminiProvider(apiKey)
+
+	default:
+		return nil, fmt.Errorf("provider %s does not accept user API keys", providerName)
+	}
+}
+
 // createAnthropicProvider creates an Anthropic provider instance
 func (f *ProviderFactory) createAnthropicProvider() (llmprovider.Provider, error) {
 	if f.config.AnthropicAPIKey == "" {
 		return nil, fmt.Errorf("ANTHROPIC_API_KEY environment variable not set")
 	}
 
-	provider, err := anthropic.NewProvider(f.config.AnthropicAPIKey)
+	return newAnthropicProvider(f.config.AnthropicAPIKey)
+}
+
+// newAnthropicProvider creates an Anthropic provider with the given API key. The backend's
+// provider is used rather than the library's, since only it supports prompt caching.
+func newAnthropicProvider(apiKey string) (llmprovider.Provider, error) {
+	provider, err := anthropic.NewProvider(anthropic.Config{APIKey: apiKey})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Anthropic provider: %w", err)
 	}
@@ -83,7 +123,12 @@ func (f *ProviderFactory) createOpenRouterProvider() (llmprovider.Provider, erro
 		return nil, fmt.Errorf("OPENROUTER_API_KEY environment variable not set")
 	}
 
-	provider, err := openrouter.NewProvider(f.config.OpenRouterAPIKey)
+	return newOpenRouterProvider(f.config.OpenRouterAPIKey)
+}
+
+// newOpenRouterProvider creates an OpenRouter provider with the given API key
+func newOpenRouterProvider(apiKey string) (llmprovider.Provider, error) {
+	provider, err := openrouter.NewProvider(apiKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create OpenRouter provider: %w", err)
 	}
@@ -91,23 +136,71 @@ func (f *ProviderFactory) createOpenRouterProvider() (llmprovider.Provider, erro
 	return provider, nil
 }
 
+// createOpenAIProvider creates an OpenAI provider instance
+func (f *ProviderFactory) createOpenAIProvider() (llmprovider.Provider, error) {
+	if f.config.OpenAIAPIKey == "" {
+		return nil, fmt.Errorf("OPENAI_API_KEY environment variable not set")
+	}
+
+	return f.newOpenAIProvider(f.config.OpenAIAPIKey)
+}
+
+// newOpenAIProvider creates an OpenAI provider with the given API key and the configured
+// base URL and API
+func (f *ProviderFactory) newOpenAIProvider(apiKey string) (llmprovider.Provider, error) {
+	provider, err := openai.NewProvider(openai.Config{
+		APIKey:  apiKey,
+		BaseURL: f.config.OpenAIBaseURL,
+		API:     openai.API(f.config.OpenAIAPI),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OpenAI provider: %w", err)
+	}
+
+	return provider, nil
+}
+
+// createGeminiProvider creates a Gemini provider instance
+func (f *ProviderFactory) createGeminiProvider() (llmprovider.Provider, error) {
+	if f.config.GeminiAPIKey == "" {
+		return nil, fmt.Errorf("GEMINI_API_KEY environment variable not set")
+	}
+
+	return f.newGeminiProvider(f.config.GeminiAPIKey)
+}
+
+// newGeminiProvider creates a Gemini provider with the given API key and the configured
+// base URL
+func (f *ProviderFactory) newGeminiProvider(apiKey string) (llmprovider.Provider, error) {
+	provider, err := gemini.NewProvider(gemini.Config{
+		APIKey:  apiKey,
+		BaseURL: f.config.GeminiBaseURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Gemini provider: %w", err)
+	}
+
+	return provider, nil
+}
+
+// createOllamaProvider creates an Ollama provider instance. Ollama needs no API key, so
+// the server's URL is what enables it.
+func (f *ProviderFactory) createOllamaProvider() (llmprovider.Provider, error) {
+	if f.config.OllamaBaseURL == "" {
+		return nil, fmt.Errorf("OLLAMA_BASE_URL environment variable not set")
+	}
+
+	provider, err := ollama.NewProvider(ollama.Config{BaseURL: f.config.OllamaBaseURL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Ollama provider: %w", err)
+	}
+
+	return provider, nil
+}
+
 // Future provider creation methods:
 //
 // func (f *ProviderFactory) createBedrockProvider() (llmprovider.Provider, error) {
 // 	// AWS Bedrock uses IAM credentials, not API keys
 // 	return bedrock.NewProvider(f.config.AWSRegion, f.config.AWSProfile)
 // }
-//
-// func (f *ProviderFactory) createOpenAIProvider() (llmprovider.Provider, error) {
-// 	if f.config.OpenAIAPIKey == "" {
-// 		return nil, fmt.Errorf("OPENAI_API_KEY environment variable not set")
-// 	}
-// 	return openai.NewProvider(f.config.OpenAIAPIKey)
-// }
-//
-// func (f *ProviderFactory) createGeminiProvider() (llmprovider.Provider, error) {
-// 	if f.config.GeminiAPIKey == "" {
-// 		return nil, fmt.Errorf("GEMINI_API_KEY environment variable not set")
-// 	}
-// 	return gemini.NewProvider(f.config.GeminiAPIKey)
-// }
@@ -0,0 +1,51 @@
+package providerkeys
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// keyCipher encrypts API keys with AES-256-GCM. The AES key is the SHA-256 of the
+// configured secret; each ciphertext is prefixed with its random nonce.
+type keyCipher struct {
+	aead cipher.AEAD
+}
+
+func newKeyCipher(secret string) (*keyCipher, error) {
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create GCM: %w", err)
+	}
+	return &keyCipher{aead: aead}, nil
+}
+
+// encrypt returns nonce || ciphertext
+func (c *keyCipher) encrypt(plaintext string) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return c.aead.Seal(nonce, nonce, []byte(plaintext), nil), nil
+}
+
+// decrypt fails if the data was encrypted with another secret or was modified
+func (c *keyCipher) decrypt(data []byte) (string, error) {
+	nonceSize := c.aead.NonceSize()
+	if len(data) < nonceSize {
+		return "", errors.New("encrypted key is too short")
+	}
+	plaintext, err := c.aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypt key: %w", err)
+	}
+	return string(plaintext), nil
+}
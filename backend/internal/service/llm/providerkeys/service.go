@@ -0,0 +1,149 @@
+// Package providerkeys stores users' own LLM provider API keys ("bring your own key").
+// Keys are encrypted at rest; the streaming service uses a user's key for a provider
+// before falling back to the server's.
+package providerkeys
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	// minKeyLength and maxKeyLength bound accepted API keys (provider keys are ~40-110 chars)
+	minKeyLength = 16
+	maxKeyLength = 512
+
+	// hintLength is how many trailing characters of a key are kept in clear
+	hintLength = 4
+)
+
+// supportedProviders are the providers users can bring their own key for
+var supportedProviders = []interface{}{"anthropic", "google", "openai", "openrouter"}
+
+// Service implements the ProviderKeyService interface
+type Service struct {
+	repo   llmRepo.ProviderKeyRepository
+	cipher *keyCipher // nil when no secret is configured (user keys disabled)
+	logger *slog.Logger
+}
+
+// NewService creates a provider key service. An empty secret disables user keys:
+// setting one fails and every user falls back to the server's keys.
+func NewService(repo llmRepo.ProviderKeyRepository, secret string, logger *slog.Logger) (llmSvc.ProviderKeyService, error) {
+	s := &Service{repo: repo, logger: logger}
+	if secret != "" {
+		c, err := newKeyCipher(secret)
+		if err != nil {
+			return nil, err
+		}
+		s.cipher = c
+	}
+	return s, nil
+}
+
+// ListKeys returns the user's keys (hints only)
+func (s *Service) ListKeys(ctx context.Context, userID string) ([]llmModels.UserProviderKey, error) {
+	return s.repo.ListKeys(ctx, userID)
+}
+
+// SetKey encrypts and stores the user's key for a provider
+func (s *Service) SetKey(ctx context.Context, userID, provider string, req *llmSvc.SetProviderKeyRequest) (*llmModels.UserProviderKey, error) {
+	if s.cipher == nil {
+		return nil, fmt.Errorf("%w: user provider keys are not enabled on this server", domain.ErrValidation)
+	}
+	if err := validateProvider(provider); err != nil {
+		return nil, err
+	}
+
+	apiKey := strings.TrimSpace(req.APIKey)
+	err := validation.Validate(apiKey,
+		validation.Required,
+		validation.RuneLength(minKeyLength, maxKeyLength),
+		validation.By(noWhitespace),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: api_key: %v", domain.ErrValidation, err)
+	}
+
+	encrypted, err := s.cipher.encrypt(apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt %s key: %w", provider, err)
+	}
+
+	key := &llmModels.UserProviderKey{
+		UserID:       userID,
+		Provider:     provider,
+		EncryptedKey: encrypted,
+		KeyHint:      keyHint(apiKey),
+	}
+	if err := s.repo.UpsertKey(ctx, key); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("provider key set", "user_id", userID, "provider", provider)
+	return key, nil
+}
+
+// DeleteKey removes the user's key for a provider
+func (s *Service) DeleteKey(ctx context.Context, userID, provider string) error {
+	if err := validateProvider(provider); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteKey(ctx, userID, provider); err != nil {
+		return err
+	}
+
+	s.logger.Info("provider key deleted", "user_id", userID, "provider", provider)
+	return nil
+}
+
+// GetAPIKey returns the user's decrypted key for a provider (ErrNotFound if none or
+// user keys are disabled)
+func (s *Service) GetAPIKey(ctx context.Context, userID, provider string) (string, error) {
+	if s.cipher == nil {
+		return "", fmt.Errorf("%s key: %w", provider, domain.ErrNotFound)
+	}
+
+	key, err := s.repo.GetKey(ctx, userID, provider)
+	if err != nil {
+		return "", err
+	}
+	apiKey, err := s.cipher.decrypt(key.EncryptedKey)
+	if err != nil {
+		// Most likely PROVIDER_KEY_SECRET changed since the key was stored
+		return "", fmt.Errorf("%s key: %w", provider, err)
+	}
+	return apiKey, nil
+}
+
+// validateProvider checks that users can store a key for the provider
+func validateProvider(provider string) error {
+	if err := validation.Validate(provider, validation.Required, validation.In(supportedProviders...)); err != nil {
+		return fmt.Errorf("%w: provider must be anthropic, google, openai or openrouter", domain.ErrValidation)
+	}
+	return nil
+}
+
+// noWhitespace rejects keys with inner whitespace (usually a bad paste)
+func noWhitespace(value interface{}) error {
+	if strings.IndexFunc(value.(string), unicode.IsSpace) >= 0 {
+		return fmt.Errorf("must not contain whitespace")
+	}
+	return nil
+}
+
+// keyHint returns the key's last characters for display
+func keyHint(apiKey string) string {
+	runes := []rune(apiKey)
+	return "…" + string(runes[len(runes)-hintLength:])
+}
@@ -0,0 +1,145 @@
+package providerkeys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+const testKey = "sk-ant-REDACTED"
+
+// fakeRepo stores keys in memory by user and provider
+type fakeRepo struct {
+	llmRepo.ProviderKeyRepository
+	keys map[string]llmModels.UserProviderKey
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{keys: map[string]llmModels.UserProviderKey{}}
+}
+
+func (r *fakeRepo) UpsertKey(ctx context.Context, key *llmModels.UserProviderKey) error {
+	key.ID = "key-" + key.Provider
+	r.keys[key.UserID+"/"+key.Provider] = *key
+	return nil
+}
+
+func (r *fakeRepo) GetKey(ctx context.Context, userID, provider string) (*llmModels.UserProviderKey, error) {
+	key, ok := r.keys[userID+"/"+provider]
+	if !ok {
+		return nil, fmt.Errorf("%s key: %w", provider, domain.ErrNotFound)
+	}
+	return &key, nil
+}
+
+func (r *fakeRepo) DeleteKey(ctx context.Context, userID, provider string) error {
+	if _, ok := r.keys[userID+"/"+provider]; !ok {
+		return fmt.Errorf("%s key: %w", provider, domain.ErrNotFound)
+	}
+	delete(r.keys, userID+"/"+provider)
+	return nil
+}
+
+func newTestService(t *testing.T, repo llmRepo.ProviderKeyRepository, secret string) llmSvc.ProviderKeyService {
+	t.Helper()
+	svc, err := NewService(repo, secret, slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatalf("NewService: %v", err)
+	}
+	return svc
+}
+
+func TestSetKey_EncryptsAndResolves(t *testing.T) {
+	repo := newFakeRepo()
+	svc := newTestService(t, repo, "server-secret")
+	ctx := context.Background()
+
+	key, err := svc.SetKey(ctx, "user-1", "anthropic", &llmSvc.SetProviderKeyRequest{APIKey: "  " + testKey + "\n"})
+	if err != nil {
+		t.Fatalf("SetKey: %v", err)
+	}
+	if key.KeyHint != "…a1b2" {
+		t.Errorf("hint = %q", key.KeyHint)
+	}
+	if strings.Contains(string(repo.keys["user-1/anthropic"].EncryptedKey), testKey) {
+		t.Error("key stored in clear")
+	}
+
+	got, err := svc.GetAPIKey(ctx, "user-1", "anthropic")
+	if err != nil || got != testKey {
+		t.Fatalf("GetAPIKey = %q, %v", got, err)
+	}
+	if _, err := svc.GetAPIKey(ctx, "user-2", "anthropic"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("other user's key: err = %v, want ErrNotFound", err)
+	}
+
+	// Another secret can't decrypt the key (and doesn't report it as missing)
+	rotated := newTestService(t, repo, "new-secret")
+	if _, err := rotated.GetAPIKey(ctx, "user-1", "anthropic"); err == nil || errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetAPIKey with another secret: err = %v", err)
+	}
+}
+
+func TestSetKey_Validation(t *testing.T) {
+	svc := newTestService(t, newFakeRepo(), "server-secret")
+	tests := []struct {
+		name     string
+		provider string
+		apiKey   string
+	}{
+		{"unsupported provider", "lorem", testKey},
+		{"empty key", "anthropic", "   "},
+		{"short key", "openrouter", "sk-or-123"},
+		{"inner whitespace", "openrouter", "sk-or-v1-abcdef ghijklmnop"},
+		{"too long", "anthropic", strings.Repeat("k", maxKeyLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.SetKey(context.Background(), "user-1", tt.provider, &llmSvc.SetProviderKeyRequest{APIKey: tt.apiKey})
+			if !errors.Is(err, domain.ErrValidation) {
+				t.Fatalf("err = %v, want ErrValidation", err)
+			}
+		})
+	}
+}
+
+func TestDisabledWithoutSecret(t *testing.T) {
+	repo := newFakeRepo()
+	svc := newTestService(t, repo, "")
+	ctx := context.Background()
+
+	if _, err := svc.SetKey(ctx, "user-1", "anthropic", &llmSvc.SetProviderKeyRequest{APIKey: testKey}); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("SetKey err = %v, want ErrValidation", err)
+	}
+	repo.keys["user-1/anthropic"] = llmModels.UserProviderKey{EncryptedKey: []byte("stored earlier")}
+	if _, err := svc.GetAPIKey(ctx, "user-1", "anthropic"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetAPIKey err = %v, want ErrNotFound (use the server key)", err)
+	}
+}
+
+func TestDeleteKey(t *testing.T) {
+	svc := newTestService(t, newFakeRepo(), "server-secret")
+	ctx := context.Background()
+
+	if _, err := svc.SetKey(ctx, "user-1", "openrouter", &llmSvc.SetProviderKeyRequest{APIKey: testKey}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.DeleteKey(ctx, "user-1", "openrouter"); err != nil {
+		t.Fatalf("DeleteKey: %v", err)
+	}
+	if err := svc.DeleteKey(ctx, "user-1", "openrouter"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("second DeleteKey err = %v, want ErrNotFound", err)
+	}
+	if err := svc.DeleteKey(ctx, "user-1", "bedrock"); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("unsupported provider err = %v, want ErrValidation", err)
+	}
+}
@@ -0,0 +1,379 @@
+// Package providertest is a conformance suite for LLMProvider implementations. The
+// stream executor relies on the streaming contract documented on LLMProvider: deltas
+// in block order, one complete block per streamed block, metadata last, tool_use blocks
+// that can be answered with tool_result blocks, and prompt shutdown on cancellation.
+// Run the suite against fake or recorded providers, so adapter regressions (e.g. after a
+// meridian-llm-go update) fail CI instead of breaking turns.
+//
+// Recorded providers choose their response from the suite's prompts (PromptText,
+// PromptToolUse, PromptLong) and see tool results as ToolResultText.
+package providertest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	llmModels "meridian/internal/domain/models/llm"
+	domainllm "meridian/internal/domain/services/llm"
+)
+
+// Checks of the suite, the keys of Target.KnownGaps
+const (
+	CheckDeltaOrdering    = "delta_ordering"
+	CheckBlockCompletion  = "block_completion"
+	CheckMetadata         = "metadata"
+	CheckToolUseRoundTrip = "tool_use_round_trip"
+	CheckCancellation     = "cancellation"
+)
+
+// Requests the suite sends
+const (
+	// PromptText asks for a short text answer
+	PromptText = "Say hello in one sentence."
+	// PromptToolUse offers ToolName and expects a call to it
+	PromptToolUse = "Look up my note called plot."
+	// PromptLong asks for a response long enough to be cancelled mid-stream
+	PromptLong = "Write a very long story."
+
+	// ToolName is the tool offered with PromptToolUse; its input has a string "name"
+	ToolName = "lookup_note"
+	// ToolResultText answers every call to ToolName
+	ToolResultText = "The plot: a heist on the moon."
+)
+
+const (
+	// streamTimeout bounds a whole stream
+	streamTimeout = 30 * time.Second
+	// cancelTimeout is how soon a stream must close once its context is cancelled
+	cancelTimeout = 5 * time.Second
+)
+
+// Target is a provider under test
+type Target struct {
+	Provider domainllm.LLMProvider
+
+	// Model is sent with every request and must be supported by Provider
+	Model string
+
+	// KnownGaps skips checks the provider is known to fail (check → reason), so the
+	// rest of the suite still guards it
+	KnownGaps map[string]string
+}
+
+// Run runs every check against the target
+func Run(t *testing.T, target Target) {
+	t.Helper()
+	if !target.Provider.SupportsModel(target.Model) {
+		t.Fatalf("provider %s does not support model %q", target.Provider.Name(), target.Model)
+	}
+
+	var text []domainllm.StreamEvent
+	streamText := func(t *testing.T) []domainllm.StreamEvent {
+		if text == nil {
+			text = stream(t, target.Provider, textRequest(target.Model))
+		}
+		return text
+	}
+
+	run(t, target, CheckDeltaOrdering, func(t *testing.T) { checkDeltaOrdering(t, streamText(t)) })
+	run(t, target, CheckBlockCompletion, func(t *testing.T) { checkBlockCompletion(t, streamText(t)) })
+	run(t, target, CheckMetadata, func(t *testing.T) { checkMetadata(t, streamText(t)) })
+	run(t, target, CheckToolUseRoundTrip, func(t *testing.T) { checkToolUseRoundTrip(t, target) })
+	run(t, target, CheckCancellation, func(t *testing.T) { checkCancellation(t, target) })
+}
+
+func run(t *testing.T, target Target, check string, fn func(t *testing.T)) {
+	t.Run(check, func(t *testing.T) {
+		if reason, ok := target.KnownGaps[check]; ok {
+			t.Skipf("known gap: %s", reason)
+		}
+		fn(t)
+	})
+}
+
+// stream collects every event of one response until the channel closes
+func stream(t *testing.T, provider domainllm.LLMProvider, req *domainllm.GenerateRequest) []domainllm.StreamEvent {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	events, err := provider.StreamResponse(ctx, req)
+	if err != nil {
+		t.Fatalf("StreamResponse: %v", err)
+	}
+	var collected []domainllm.StreamEvent
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return collected
+			}
+			collected = append(collected, event)
+		case <-ctx.Done():
+			t.Fatalf("stream did not close within %s (%d events)", streamTimeout, len(collected))
+		}
+	}
+}
+
+// checkDeltaOrdering checks the event sequence: each event carries one thing, blocks
+// start in index order with their type on the first delta, no block receives deltas
+// after it completes or after the next block starts, and nothing follows the metadata
+func checkDeltaOrdering(t *testing.T, events []domainllm.StreamEvent) {
+	t.Helper()
+	current, completed := -1, make(map[int]bool)
+	metadata, text := false, false
+	for i, event := range events {
+		if n := eventParts(event); n != 1 {
+			t.Fatalf("event %d carries %d of delta/block/metadata/error, want 1: %+v", i, n, event)
+		}
+		if metadata {
+			t.Fatalf("event %d follows the metadata: %+v", i, event)
+		}
+
+		switch {
+		case event.Error != nil:
+			t.Fatalf("event %d: stream error: %v", i, event.Error)
+
+		case event.Delta != nil:
+			delta := event.Delta
+			if delta.BlockIndex != current {
+				if delta.BlockIndex != current+1 {
+					t.Fatalf("event %d: block %d started after block %d, want %d", i, delta.BlockIndex, current, current+1)
+				}
+				if delta.BlockType == nil {
+					t.Fatalf("event %d: first delta of block %d has no block type", i, delta.BlockIndex)
+				}
+				current = delta.BlockIndex
+			}
+			if completed[delta.BlockIndex] {
+				t.Fatalf("event %d: delta for block %d after it completed", i, delta.BlockIndex)
+			}
+			if delta.TextDelta != nil && *delta.TextDelta != "" {
+				text = true
+			}
+
+		case event.Block != nil:
+			if event.Block.Sequence > current || completed[event.Block.Sequence] {
+				t.Fatalf("event %d: unexpected completion of block %d (current block %d)", i, event.Block.Sequence, current)
+			}
+			completed[event.Block.Sequence] = true
+
+		case event.Metadata != nil:
+			metadata = true
+		}
+	}
+	if !metadata {
+		t.Fatalf("stream closed without metadata (%d events)", len(events))
+	}
+	if !text {
+		t.Errorf("stream had no text deltas")
+	}
+}
+
+// checkBlockCompletion checks that every streamed block completes exactly once, in
+// order, with its streamed type, and that text blocks hold exactly the streamed text
+func checkBlockCompletion(t *testing.T, events []domainllm.StreamEvent) {
+	t.Helper()
+	types := make(map[int]string)
+	texts := make(map[int]*strings.Builder)
+	var blocks []*llmModels.TurnBlock
+	for _, event := range events {
+		if delta := event.Delta; delta != nil {
+			if delta.BlockType != nil {
+				types[delta.BlockIndex] = *delta.BlockType
+				texts[delta.BlockIndex] = &strings.Builder{}
+			}
+			if delta.TextDelta != nil && texts[delta.BlockIndex] != nil {
+				texts[delta.BlockIndex].WriteString(*delta.TextDelta)
+			}
+		}
+		if event.Block != nil {
+			blocks = append(blocks, event.Block)
+		}
+	}
+
+	if len(blocks) != len(types) {
+		t.Fatalf("%d blocks completed, %d streamed", len(blocks), len(types))
+	}
+	for i, block := range blocks {
+		if block.Sequence != i {
+			t.Fatalf("completed block %d has sequence %d", i, block.Sequence)
+		}
+		if block.BlockType != types[i] {
+			t.Errorf("block %d: completed as %q, streamed as %q", i, block.BlockType, types[i])
+		}
+		if block.BlockType == llmModels.BlockTypeText || block.BlockType == llmModels.BlockTypeThinking {
+			if block.TextContent == nil || *block.TextContent != texts[i].String() {
+				t.Errorf("block %d: text %v does not match the streamed %q", i, block.TextContent, texts[i].String())
+			}
+		}
+	}
+}
+
+// checkMetadata checks that the stream ends with exactly one metadata event with a stop
+// reason and token counts
+func checkMetadata(t *testing.T, events []domainllm.StreamEvent) {
+	t.Helper()
+	if len(events) == 0 || events[len(events)-1].Metadata == nil {
+		t.Fatalf("last event is not metadata")
+	}
+	for i, event := range events[:len(events)-1] {
+		if event.Metadata != nil {
+			t.Fatalf("event %d: metadata before the last event", i)
+		}
+	}
+
+	metadata := events[len(events)-1].Metadata
+	if metadata.StopReason == "" {
+		t.Errorf("metadata has no stop reason")
+	}
+	if metadata.InputTokens <= 0 || metadata.OutputTokens <= 0 {
+		t.Errorf("metadata tokens = %d in, %d out; want both positive", metadata.InputTokens, metadata.OutputTokens)
+	}
+}
+
+// checkToolUseRoundTrip checks that a tool call completes as a valid tool_use block
+// and that the conversation continues once the block is answered with a tool_result
+func checkToolUseRoundTrip(t *testing.T, target Target) {
+	t.Helper()
+	req := toolUseRequest(target.Model)
+	events := stream(t, target.Provider, req)
+	checkDeltaOrdering(t, events)
+	checkBlockCompletion(t, events)
+
+	var assistant []*llmModels.TurnBlock
+	var call *llmModels.ToolUseContent
+	for _, event := range events {
+		if event.Block == nil {
+			continue
+		}
+		assistant = append(assistant, event.Block)
+		if event.Block.BlockType == llmModels.BlockTypeToolUse && call == nil {
+			call = &llmModels.ToolUseContent{}
+			if err := llmModels.DecodeContent(event.Block, call); err != nil {
+				t.Fatalf("tool_use block: %v", err)
+			}
+		}
+	}
+	if call == nil {
+		t.Fatalf("no tool_use block among %d blocks", len(assistant))
+	}
+	if call.ToolName != ToolName {
+		t.Errorf("tool_use calls %q, want %q", call.ToolName, ToolName)
+	}
+	if _, ok := call.Input["name"].(string); !ok {
+		t.Errorf("tool_use input %v has no string name", call.Input)
+	}
+
+	toolResult := &llmModels.TurnBlock{
+		BlockType: llmModels.BlockTypeToolResult,
+		Content: map[string]interface{}{
+			"tool_use_id": call.ToolUseID,
+			"tool_name":   call.ToolName,
+			"is_error":    false,
+			"result":      ToolResultText,
+		},
+	}
+	req.Messages = append(req.Messages,
+		domainllm.Message{Role: "assistant", Content: assistant},
+		domainllm.Message{Role: "user", Content: []*llmModels.TurnBlock{toolResult}},
+	)
+	checkDeltaOrdering(t, stream(t, target.Provider, req))
+}
+
+// checkCancellation cancels a stream after its first text and checks that the stream
+// closes promptly and ends with an error rather than metadata, so consumers still
+// reading can tell the response is incomplete
+func checkCancellation(t *testing.T, target Target) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := target.Provider.StreamResponse(ctx, longRequest(target.Model))
+	if err != nil {
+		t.Fatalf("StreamResponse: %v", err)
+	}
+
+	timeout := time.After(streamTimeout)
+	for cancelled := false; !cancelled; {
+		select {
+		case event, ok := <-events:
+			if !ok || event.Metadata != nil || event.Error != nil {
+				t.Fatalf("stream ended before it could be cancelled: %+v", event)
+			}
+			if event.Delta != nil && event.Delta.TextDelta != nil && *event.Delta.TextDelta != "" {
+				cancel()
+				cancelled = true
+			}
+		case <-timeout:
+			t.Fatalf("no text within %s", streamTimeout)
+		}
+	}
+
+	var last domainllm.StreamEvent
+	timeout = time.After(cancelTimeout)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				if last.Error == nil {
+					t.Errorf("cancelled stream closed without an error (last event %+v)", last)
+				}
+				return
+			}
+			last = event
+		case <-timeout:
+			t.Fatalf("stream still open %s after cancellation", cancelTimeout)
+		}
+	}
+}
+
+func eventParts(event domainllm.StreamEvent) int {
+	n := 0
+	for _, set := range []bool{event.Delta != nil, event.Block != nil, event.Metadata != nil, event.Error != nil} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+func textRequest(model string) *domainllm.GenerateRequest {
+	return userRequest(model, PromptText, 16)
+}
+
+func longRequest(model string) *domainllm.GenerateRequest {
+	return userRequest(model, PromptLong, 4096)
+}
+
+func toolUseRequest(model string) *domainllm.GenerateRequest {
+	req := userRequest(model, PromptToolUse, 256)
+	req.Params.Tools = []llmModels.ToolDefinition{{
+		Type: "function",
+		Function: &llmModels.FunctionDetails{
+			Name:        ToolName,
+			Description: "Look up one of the user's notes by name",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{"type": "string", "description": "Note name"},
+				},
+				"required": []string{"name"},
+			},
+		},
+	}}
+	return req
+}
+
+func userRequest(model, prompt string, maxTokens int) *domainllm.GenerateRequest {
+	return &domainllm.GenerateRequest{
+		Model: model,
+		Messages: []domainllm.Message{{
+			Role:    "user",
+			Content: []*llmModels.TurnBlock{{BlockType: llmModels.BlockTypeText, TextContent: &prompt}},
+		}},
+		Params: &llmModels.RequestParams{MaxTokens: &maxTokens},
+	}
+}
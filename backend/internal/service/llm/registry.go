@@ -75,6 +75,22 @@ func (r *ProviderRegistry) GetProvider(provider string) (domainllm.LLMProvider,
 	return adapter, nil
 }
 
+// GetProviderWithKey returns a provider adapter that uses the given API key (a user's
+// own key). These adapters are not cached: each call creates a fresh provider.
+func (r *ProviderRegistry) GetProviderWithKey(provider, apiKey string) (domainllm.LLMProvider, error) {
+	libraryProvider, err := r.providerFactory.GetProviderWithKey(provider, apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create provider '%s': %w", provider, err)
+	}
+
+	adapter, err := r.adapterFactory.CreateAdapter(provider, libraryProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create adapter for provider '%s': %w", provider, err)
+	}
+
+	return adapter, nil
+}
+
 // Validate checks if the factories are properly configured.
 // Should be called at startup to fail fast if misconfigured.
 func (r *ProviderRegistry) Validate() error {
@@ -1,11 +1,9 @@
 package llm
 
 import (
-	"context"
 	"fmt"
 	"log/slog"
-
-	mstream "github.com/haowjy/meridian-stream-go"
+	"time"
 
 	"meridian/internal/capabilities"
 	"meridian/internal/config"
@@ -14,9 +12,14 @@ import (
 	llmRepo "meridian/internal/domain/repositories/llm"
 	"meridian/internal/domain/services"
 	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/eventstream"
+	"meridian/internal/service/llm/abuse"
 	"meridian/internal/service/llm/chat"
 	"meridian/internal/service/llm/conversation"
+	"meridian/internal/service/llm/critique"
+	"meridian/internal/service/llm/experiment"
 	"meridian/internal/service/llm/formatting"
+	"meridian/internal/service/llm/sanitize"
 	"meridian/internal/service/llm/streaming"
 )
 
@@ -45,10 +48,17 @@ func SetupProviders(cfg *config.Config, logger *slog.Logger) (*ProviderRegistry,
 		logger.Warn("ANTHROPIC_API_KEY not set - Anthropic provider not available")
 	}
 
-	// Future: Log other providers when added
-	// if cfg.OpenAIAPIKey != "" {
-	//     logger.Info("provider available", "name", "openai", "models", "gpt-*, o1-*")
-	// }
+	if cfg.OpenAIAPIKey != "" {
+		logger.Info("provider available", "name", "openai", "models", "gpt-*, o*", "api", cfg.OpenAIAPI)
+	}
+
+	if cfg.GeminiAPIKey != "" {
+		logger.Info("provider available", "name", "google", "models", "gemini-*")
+	}
+
+	if cfg.OllamaBaseURL != "" {
+		logger.Info("provider available", "name", "ollama", "url", cfg.OllamaBaseURL)
+	}
 
 	logger.Info("provider registry initialized with factory-based routing")
 
@@ -60,31 +70,43 @@ type Services struct {
 	Chat         llmSvc.ChatService
 	Conversation llmSvc.ConversationService
 	Streaming    llmSvc.StreamingService
+	Critique     llmSvc.CritiqueService
+	Experiment   llmSvc.ExperimentService
+	Abuse        llmSvc.AbuseService
 }
 
 // SetupServices initializes all LLM services with proper dependency injection
 func SetupServices(
 	chatRepo llmRepo.ChatRepository,
 	turnRepo llmRepo.TurnRepository,
+	critiqueRepo llmRepo.CritiqueRepository,
+	experimentRepo llmRepo.ExperimentRepository,
+	attachmentRepo llmRepo.AttachmentRepository,
+	abuseFlagRepo llmRepo.AbuseFlagRepository,
 	projectRepo docsysRepo.ProjectRepository,
+	projectSettingsRepo docsysRepo.ProjectSettingsRepository,
 	documentRepo docsysRepo.DocumentRepository,
 	folderRepo docsysRepo.FolderRepository,
 	providerRegistry *ProviderRegistry,
 	cfg *config.Config,
 	txManager repositories.TransactionManager,
-	capabilityRegistry *capabilities.Registry,
+	capabilityRegistry capabilities.Lookup,
 	authorizer services.ResourceAuthorizer,
 	toolLimitResolver llmSvc.ToolLimitResolver,
+	modelAllowlist llmSvc.ModelAllowlistResolver,
+	objectStore services.ObjectStore,
+	providerKeys llmSvc.ProviderKeyResolver,
+	dataPolicy llmSvc.ProviderDataPolicy,
 	logger *slog.Logger,
-) (*Services, *mstream.Registry, error) {
+) (*Services, eventstream.Registry, error) {
 	// Create shared validator
 	validator := NewChatValidator(chatRepo)
 
-	// Create mstream registry (for SSE streaming)
-	streamRegistry := mstream.NewRegistry()
-
-	// Start cleanup goroutine for old streams
-	go streamRegistry.StartCleanup(context.Background())
+	// Create stream registry (for SSE streaming); the caller runs its cleanup
+	streamRegistry := eventstream.NewRegistry(eventstream.RegistryOptions{
+		Retention:       time.Duration(cfg.StreamRetentionSeconds) * time.Second,
+		CleanupInterval: time.Duration(cfg.StreamCleanupIntervalSeconds) * time.Second,
+	})
 
 	// Create response generator (uses TurnReader + TurnNavigator for ISP compliance)
 	responseGenerator := streaming.NewResponseGenerator(
@@ -98,21 +120,26 @@ func SetupServices(
 	chatService := chat.NewService(
 		chatRepo,
 		projectRepo,
+		authorizer,
 		logger,
 	)
 
-	// Create conversation service (uses TurnReader + TurnNavigator for ISP compliance)
+	// Create conversation service (uses TurnReader + TurnNavigator, TurnWriter for annotations)
 	conversationService := conversation.NewService(
 		chatRepo,
 		turnRepo, // TurnReader
 		turnRepo, // TurnNavigator (same repo implements both)
+		turnRepo, // TurnWriter
+		projectSettingsRepo,
 		capabilityRegistry,
 		authorizer,
+		objectStore,
+		time.Duration(cfg.StorageURLTTLSeconds)*time.Second,
 	)
 
 	// Create system prompt resolver
 	systemPromptResolver := streaming.NewSystemPromptResolver(
-		projectRepo,
+		projectSettingsRepo,
 		chatRepo,
 		documentRepo,
 		logger,
@@ -124,10 +151,53 @@ func SetupServices(
 	formatterRegistry.Register("doc_view", &formatting.DocViewFormatter{})
 	formatterRegistry.Register("doc_tree", formatting.NewDocTreeFormatter())
 
+	// Prompt-injection guard for tool results (shared by persistence and message building)
+	toolResultGuard := sanitize.NewGuard(sanitize.ParseLevel(cfg.ToolResultSanitization))
+
 	// Create MessageBuilder service (pure conversion, no data loading)
 	messageBuilder := conversation.NewMessageBuilderService(
 		formatterRegistry,
+		toolResultGuard,
 		capabilityRegistry,
+		objectStore, // Attached images, inlined for vision models
+		logger,
+	)
+
+	// Create critique service (critic pass over completed assistant turns)
+	critiqueService := critique.NewService(
+		critiqueRepo,
+		turnRepo, // TurnReader
+		providerRegistry,
+		authorizer,
+		cfg.CritiqueModel,
+		dataPolicy, // Users' data controls for the critic model's provider
+		logger,
+	)
+
+	// Create experiment service (prompt/params A/B tests and turn feedback)
+	experimentService := experiment.NewService(
+		experimentRepo,
+		turnRepo, // TurnReader
+		authorizer,
+		logger,
+	)
+
+	// Create abuse detection service (turn/tool round spikes, oversized tool inputs);
+	// admins are alerted at the webhook, if configured, besides the logs
+	var abuseNotifier abuse.Notifier
+	if cfg.AbuseAlertWebhookURL != "" {
+		abuseNotifier = abuse.NewWebhookNotifier(cfg.AbuseAlertWebhookURL)
+	}
+	abuseService := abuse.NewService(
+		abuseFlagRepo,
+		abuseNotifier,
+		abuse.Limits{
+			Window:            time.Duration(cfg.AbuseWindowMinutes) * time.Minute,
+			MaxTurns:          cfg.AbuseMaxTurnsPerWindow,
+			MaxToolRounds:     cfg.AbuseMaxToolRoundsPerWindow,
+			MaxToolInputBytes: cfg.AbuseMaxToolInputKB << 10,
+			ThrottleDuration:  time.Duration(cfg.AbuseThrottleMinutes) * time.Minute,
+		},
 		logger,
 	)
 
@@ -139,7 +209,8 @@ func SetupServices(
 		turnRepo, // TurnReader
 		turnRepo, // TurnNavigator (same repo implements all three)
 		chatRepo,
-		projectRepo, // For validating project access on cold start
+		projectRepo,         // For validating project access on cold start
+		projectSettingsRepo, // Project defaults (model, tool policy, guardrails)
 		documentRepo,
 		folderRepo,
 		validator,
@@ -149,8 +220,17 @@ func SetupServices(
 		txManager,
 		systemPromptResolver,
 		messageBuilder,
-		toolLimitResolver,    // Tool round limit resolver (tier-ready)
-		capabilityRegistry,   // For checking model capabilities (e.g., supports_tools)
+		toolResultGuard,    // Prompt-injection guard for tool results
+		toolLimitResolver,  // Tool round limit resolver (tier-ready)
+		modelAllowlist,     // Models "auto" may route to (tier-ready)
+		capabilityRegistry, // For checking model capabilities (e.g., supports_tools)
+		objectStore,        // Storage for generated images
+		critiqueService,    // Critic pass for projects that enable it
+		providerKeys,       // Users' own provider API keys, tried before the server's
+		experimentService,  // Assigns experiment variants to new assistant turns
+		dataPolicy,         // Users' per-provider data controls
+		attachmentRepo,     // Uploads referenced by image blocks
+		abuseService,       // Throttles or flags runaway/abusive usage
 		logger,
 	)
 
@@ -158,5 +238,8 @@ func SetupServices(
 		Chat:         chatService,
 		Conversation: conversationService,
 		Streaming:    streamingService,
+		Critique:     critiqueService,
+		Experiment:   experimentService,
+		Abuse:        abuseService,
 	}, streamRegistry, nil
 }
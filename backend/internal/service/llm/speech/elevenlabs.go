@@ -0,0 +1,82 @@
+package speech
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+const (
+	// DefaultElevenLabsURL is the ElevenLabs text-to-speech endpoint prefix; the voice ID is appended
+	DefaultElevenLabsURL = "https://api.elevenlabs.io/v1/text-to-speech/"
+	// DefaultElevenLabsModel and DefaultElevenLabsVoice ("Rachel") are used when not configured
+	DefaultElevenLabsModel = "eleven_multilingual_v2"
+	DefaultElevenLabsVoice = "21m00Tcm4TlvDq8ikWAM"
+
+	// elevenLabsMaxInputChars stays well under the per-request limit (10,000 for multilingual v2)
+	elevenLabsMaxInputChars = 5000
+)
+
+var _ llmSvc.SpeechSynthesizer = (*ElevenLabsSynthesizer)(nil)
+
+// ElevenLabsSynthesizer implements SpeechSynthesizer with the ElevenLabs API (MP3 output)
+type ElevenLabsSynthesizer struct {
+	apiKey     string
+	model      string
+	voice      string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewElevenLabsSynthesizer creates an ElevenLabs synthesizer; empty model/voice use the defaults
+func NewElevenLabsSynthesizer(apiKey, model, voice string) *ElevenLabsSynthesizer {
+	if model == "" {
+		model = DefaultElevenLabsModel
+	}
+	if voice == "" {
+		voice = DefaultElevenLabsVoice
+	}
+	return &ElevenLabsSynthesizer{
+		apiKey:     apiKey,
+		model:      model,
+		voice:      voice,
+		baseURL:    DefaultElevenLabsURL,
+		httpClient: &http.Client{Timeout: DefaultSpeechTimeout},
+	}
+}
+
+// Synthesize implements SpeechSynthesizer
+func (s *ElevenLabsSynthesizer) Synthesize(ctx context.Context, text string) ([]byte, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"text":     text,
+		"model_id": s.model,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	endpoint := s.baseURL + url.PathEscape(s.voice) + "?output_format=mp3_44100_128"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "audio/mpeg")
+	req.Header.Set("xi-api-key", s.apiKey)
+
+	return doSpeechRequest(s.httpClient, req)
+}
+
+// MaxInputChars implements SpeechSynthesizer
+func (s *ElevenLabsSynthesizer) MaxInputChars() int { return elevenLabsMaxInputChars }
+
+// MIMEType implements SpeechSynthesizer
+func (s *ElevenLabsSynthesizer) MIMEType() string { return "audio/mpeg" }
+
+// Voice implements SpeechSynthesizer
+func (s *ElevenLabsSynthesizer) Voice() string { return "elevenlabs/" + s.model + "/" + s.voice }
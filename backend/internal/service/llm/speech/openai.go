@@ -0,0 +1,111 @@
+package speech
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+const (
+	// DefaultOpenAISpeechURL is the OpenAI text-to-speech endpoint
+	DefaultOpenAISpeechURL = "https://api.openai.com/v1/audio/speech"
+	// DefaultOpenAISpeechModel and DefaultOpenAIVoice are used when not configured
+	DefaultOpenAISpeechModel = "gpt-4o-mini-tts"
+	DefaultOpenAIVoice       = "alloy"
+
+	// openAIMaxInputChars is the API's input limit
+	openAIMaxInputChars = 4096
+
+	// DefaultSpeechTimeout is the HTTP timeout for one synthesis request
+	DefaultSpeechTimeout = 120 * time.Second
+)
+
+var _ llmSvc.SpeechSynthesizer = (*OpenAISynthesizer)(nil)
+
+// OpenAISynthesizer implements SpeechSynthesizer with the OpenAI audio API (MP3 output)
+type OpenAISynthesizer struct {
+	apiKey     string
+	model      string
+	voice      string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewOpenAISynthesizer creates an OpenAI synthesizer; empty model/voice use the defaults
+func NewOpenAISynthesizer(apiKey, model, voice string) *OpenAISynthesizer {
+	if model == "" {
+		model = DefaultOpenAISpeechModel
+	}
+	if voice == "" {
+		voice = DefaultOpenAIVoice
+	}
+	return &OpenAISynthesizer{
+		apiKey:     apiKey,
+		model:      model,
+		voice:      voice,
+		baseURL:    DefaultOpenAISpeechURL,
+		httpClient: &http.Client{Timeout: DefaultSpeechTimeout},
+	}
+}
+
+// Synthesize implements SpeechSynthesizer
+func (s *OpenAISynthesizer) Synthesize(ctx context.Context, text string) ([]byte, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"model":           s.model,
+		"voice":           s.voice,
+		"input":           text,
+		"response_format": "mp3",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	return doSpeechRequest(s.httpClient, req)
+}
+
+// MaxInputChars implements SpeechSynthesizer
+func (s *OpenAISynthesizer) MaxInputChars() int { return openAIMaxInputChars }
+
+// MIMEType implements SpeechSynthesizer
+func (s *OpenAISynthesizer) MIMEType() string { return "audio/mpeg" }
+
+// Voice implements SpeechSynthesizer
+func (s *OpenAISynthesizer) Voice() string { return "openai/" + s.model + "/" + s.voice }
+
+// doSpeechRequest sends a synthesis request and returns the audio body
+func doSpeechRequest(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("speech API error (status %d): %s", resp.StatusCode, truncate(string(body), 500))
+	}
+	return body, nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
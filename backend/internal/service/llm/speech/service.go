@@ -0,0 +1,158 @@
+// Package speech implements text-to-speech for turns: SpeechService and the
+// SpeechSynthesizer providers (OpenAI, ElevenLabs).
+package speech
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+	"unicode/utf8"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/domain/services"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+// NewSynthesizer creates the synthesizer for a provider name ("openai" or "elevenlabs").
+// Empty model/voice use the provider's defaults.
+func NewSynthesizer(provider, apiKey, model, voice string) (llmSvc.SpeechSynthesizer, error) {
+	switch provider {
+	case "openai":
+		return NewOpenAISynthesizer(apiKey, model, voice), nil
+	case "elevenlabs":
+		return NewElevenLabsSynthesizer(apiKey, model, voice), nil
+	default:
+		return nil, fmt.Errorf("unknown TTS provider %q (want \"openai\" or \"elevenlabs\")", provider)
+	}
+}
+
+// Service implements the SpeechService interface.
+// Audio is stored at audio/turns/{turn_id}/{hash}.mp3, where the hash covers the voice and
+// the text, so repeat requests reuse it and a voice change produces new audio.
+type Service struct {
+	synthesizer llmSvc.SpeechSynthesizer // nil = text-to-speech not configured
+	turnReader  llmRepo.TurnReader
+	objectStore services.ObjectStore
+	authorizer  services.ResourceAuthorizer
+	urlTTL      time.Duration
+	maxChars    int // Longest turn text that is read (0 = no limit)
+	logger      *slog.Logger
+}
+
+// NewService creates a new speech service. synthesizer may be nil (feature disabled).
+func NewService(
+	synthesizer llmSvc.SpeechSynthesizer,
+	turnReader llmRepo.TurnReader,
+	objectStore services.ObjectStore,
+	authorizer services.ResourceAuthorizer,
+	urlTTL time.Duration,
+	maxChars int,
+	logger *slog.Logger,
+) llmSvc.SpeechService {
+	return &Service{
+		synthesizer: synthesizer,
+		turnReader:  turnReader,
+		objectStore: objectStore,
+		authorizer:  authorizer,
+		urlTTL:      urlTTL,
+		maxChars:    maxChars,
+		logger:      logger,
+	}
+}
+
+// SynthesizeTurn implements SpeechService
+func (s *Service) SynthesizeTurn(ctx context.Context, userID, turnID string) (*llmModels.TurnAudio, error) {
+	if s.synthesizer == nil {
+		return nil, fmt.Errorf("%w: text-to-speech is not configured on this server", domain.ErrValidation)
+	}
+
+	if err := s.authorizer.CanAccessTurn(ctx, userID, turnID); err != nil {
+		return nil, err
+	}
+
+	turn, err := s.turnReader.GetTurn(ctx, turnID)
+	if err != nil {
+		return nil, err
+	}
+	if turn.Role != "assistant" {
+		return nil, fmt.Errorf("%w: only assistant turns can be read aloud", domain.ErrValidation)
+	}
+	if turn.Status == "pending" || turn.Status == "streaming" || turn.Status == "waiting_subagents" {
+		return nil, fmt.Errorf("%w: turn is still being generated", domain.ErrValidation)
+	}
+
+	blocks, err := s.turnReader.GetTurnBlocks(ctx, turnID)
+	if err != nil {
+		return nil, err
+	}
+	text := speakableText(blocks)
+	characters := utf8.RuneCountInString(text)
+	if characters == 0 {
+		return nil, fmt.Errorf("%w: turn has no text to read", domain.ErrValidation)
+	}
+	if s.maxChars > 0 && characters > s.maxChars {
+		return nil, fmt.Errorf("%w: turn text is too long to read (%d characters, max %d)", domain.ErrValidation, characters, s.maxChars)
+	}
+
+	key := audioKey(turnID, s.synthesizer.Voice(), text)
+	cached, err := s.objectStore.Exists(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("check cached audio: %w", err)
+	}
+
+	if !cached {
+		audio, err := s.synthesize(ctx, text)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.objectStore.Put(ctx, key, s.synthesizer.MIMEType(), audio); err != nil {
+			return nil, fmt.Errorf("store audio: %w", err)
+		}
+		s.logger.Info("synthesized turn audio",
+			"turn_id", turnID,
+			"voice", s.synthesizer.Voice(),
+			"characters", characters,
+			"bytes", len(audio),
+		)
+	}
+
+	url, err := s.objectStore.SignedURL(ctx, key, s.urlTTL)
+	if err != nil {
+		return nil, fmt.Errorf("sign audio URL: %w", err)
+	}
+
+	return &llmModels.TurnAudio{
+		TurnID:     turnID,
+		URL:        url,
+		MIMEType:   s.synthesizer.MIMEType(),
+		Characters: characters,
+		Cached:     cached,
+		ExpiresAt:  time.Now().Add(s.urlTTL).UTC(),
+	}, nil
+}
+
+// synthesize reads text in provider-sized chunks and joins the audio.
+// MP3 is a stream of independent frames, so concatenated chunks play as one file.
+func (s *Service) synthesize(ctx context.Context, text string) ([]byte, error) {
+	var audio bytes.Buffer
+	for i, chunk := range splitText(text, s.synthesizer.MaxInputChars()) {
+		part, err := s.synthesizer.Synthesize(ctx, chunk)
+		if err != nil {
+			return nil, fmt.Errorf("synthesize chunk %d: %w", i+1, err)
+		}
+		audio.Write(part)
+	}
+	return audio.Bytes(), nil
+}
+
+// audioKey returns the object key for a turn's audio in a voice
+func audioKey(turnID, voice, text string) string {
+	sum := sha256.Sum256([]byte(voice + "\n" + text))
+	return fmt.Sprintf("audio/turns/%s/%s.mp3", turnID, hex.EncodeToString(sum[:8]))
+}
@@ -0,0 +1,170 @@
+package speech
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/testmocks"
+)
+
+// fakeSynthesizer returns "[chunk]" for each chunk it reads
+type fakeSynthesizer struct {
+	maxChars int
+	calls    []string
+}
+
+func (f *fakeSynthesizer) Synthesize(ctx context.Context, text string) ([]byte, error) {
+	f.calls = append(f.calls, text)
+	return []byte("[" + text + "]"), nil
+}
+func (f *fakeSynthesizer) MaxInputChars() int { return f.maxChars }
+func (f *fakeSynthesizer) MIMEType() string   { return "audio/mpeg" }
+func (f *fakeSynthesizer) Voice() string      { return "fake/voice" }
+
+// fakeTurnReader serves one turn; other TurnReader methods are unused
+type fakeTurnReader struct {
+	llmRepo.TurnReader
+	turn   llmModels.Turn
+	blocks []llmModels.TurnBlock
+}
+
+func (f *fakeTurnReader) GetTurn(ctx context.Context, turnID string) (*llmModels.Turn, error) {
+	if turnID != f.turn.ID {
+		return nil, domain.ErrNotFound
+	}
+	turn := f.turn
+	return &turn, nil
+}
+
+func (f *fakeTurnReader) GetTurnBlocks(ctx context.Context, turnID string) ([]llmModels.TurnBlock, error) {
+	return f.blocks, nil
+}
+
+func newTestService(synth *fakeSynthesizer, text string) (*Service, *testmocks.ObjectStore) {
+	reader := &fakeTurnReader{
+		turn:   llmModels.Turn{ID: "turn-1", Role: "assistant", Status: "complete"},
+		blocks: []llmModels.TurnBlock{{BlockType: llmModels.BlockTypeText, TextContent: &text}},
+	}
+	store := &testmocks.ObjectStore{}
+	var synthesizer llmSvc.SpeechSynthesizer
+	if synth != nil { // Avoid a typed nil
+		synthesizer = synth
+	}
+	svc := &Service{
+		synthesizer: synthesizer,
+		turnReader:  reader,
+		objectStore: store,
+		authorizer:  &testmocks.Authorizer{},
+		urlTTL:      time.Minute,
+		maxChars:    1000,
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	return svc, store
+}
+
+func TestSynthesizeTurn_CachesAudio(t *testing.T) {
+	synth := &fakeSynthesizer{maxChars: 100}
+	svc, store := newTestService(synth, "Once upon a *time*.")
+
+	first, err := svc.SynthesizeTurn(context.Background(), "user-1", "turn-1")
+	if err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	if first.Cached || first.Characters != len("Once upon a time.") || first.MIMEType != "audio/mpeg" {
+		t.Errorf("first = %+v", first)
+	}
+	if keys := store.Keys(); len(keys) != 1 || !strings.HasPrefix(keys[0], "audio/turns/turn-1/") {
+		t.Fatalf("stored keys = %v", keys)
+	}
+
+	second, err := svc.SynthesizeTurn(context.Background(), "user-1", "turn-1")
+	if err != nil {
+		t.Fatalf("second call: %v", err)
+	}
+	if !second.Cached || second.URL != first.URL {
+		t.Errorf("second = %+v, want cached with URL %s", second, first.URL)
+	}
+	if len(synth.calls) != 1 {
+		t.Errorf("synthesized %d times, want 1", len(synth.calls))
+	}
+}
+
+func TestSynthesizeTurn_ChunksLongText(t *testing.T) {
+	synth := &fakeSynthesizer{maxChars: 30}
+	svc, store := newTestService(synth, "The lanterns swayed all night. The harbor slept. Aria counted.")
+
+	if _, err := svc.SynthesizeTurn(context.Background(), "user-1", "turn-1"); err != nil {
+		t.Fatalf("SynthesizeTurn: %v", err)
+	}
+	if len(synth.calls) < 2 {
+		t.Fatalf("calls = %q, want several chunks", synth.calls)
+	}
+
+	object, err := store.Get(context.Background(), store.Keys()[0])
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	var want strings.Builder
+	for _, chunk := range synth.calls {
+		want.WriteString("[" + chunk + "]")
+	}
+	if string(object.Data) != want.String() {
+		t.Errorf("stored audio = %q, want %q", object.Data, want.String())
+	}
+}
+
+func TestSynthesizeTurn_Validation(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		svc, _ := newTestService(nil, "Hello")
+		if _, err := svc.SynthesizeTurn(context.Background(), "user-1", "turn-1"); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("err = %v, want ErrValidation", err)
+		}
+	})
+
+	t.Run("no text", func(t *testing.T) {
+		svc, _ := newTestService(&fakeSynthesizer{maxChars: 100}, "```\ncode only\n```")
+		if _, err := svc.SynthesizeTurn(context.Background(), "user-1", "turn-1"); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("err = %v, want ErrValidation", err)
+		}
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		svc, _ := newTestService(&fakeSynthesizer{maxChars: 100}, strings.Repeat("word ", 300))
+		if _, err := svc.SynthesizeTurn(context.Background(), "user-1", "turn-1"); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("err = %v, want ErrValidation", err)
+		}
+	})
+
+	t.Run("still streaming", func(t *testing.T) {
+		svc, _ := newTestService(&fakeSynthesizer{maxChars: 100}, "Hello")
+		svc.turnReader.(*fakeTurnReader).turn.Status = "streaming"
+		if _, err := svc.SynthesizeTurn(context.Background(), "user-1", "turn-1"); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("err = %v, want ErrValidation", err)
+		}
+	})
+
+	t.Run("user turn", func(t *testing.T) {
+		svc, _ := newTestService(&fakeSynthesizer{maxChars: 100}, "Hello")
+		svc.turnReader.(*fakeTurnReader).turn.Role = "user"
+		if _, err := svc.SynthesizeTurn(context.Background(), "user-1", "turn-1"); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("err = %v, want ErrValidation", err)
+		}
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		svc, _ := newTestService(&fakeSynthesizer{maxChars: 100}, "Hello")
+		svc.authorizer = &testmocks.Authorizer{Err: domain.ErrForbidden}
+		if _, err := svc.SynthesizeTurn(context.Background(), "user-1", "turn-1"); !errors.Is(err, domain.ErrForbidden) {
+			t.Errorf("err = %v, want ErrForbidden", err)
+		}
+	})
+}
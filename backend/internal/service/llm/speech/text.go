@@ -0,0 +1,104 @@
+package speech
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+var (
+	codeFencePattern  = regexp.MustCompile("(?s)```.*?(```|$)")
+	inlineCodePattern = regexp.MustCompile("`([^`]*)`")
+	imagePattern      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	linkPattern       = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	htmlTagPattern    = regexp.MustCompile(`<[^>\n]+>`)
+	emphasisPattern   = regexp.MustCompile(`(\*\*|__|\*|~~)`)
+	headingPattern    = regexp.MustCompile(`(?m)^\s{0,3}#{1,6}\s+`)
+	quotePattern      = regexp.MustCompile(`(?m)^\s{0,3}>\s?`)
+	listMarkerPattern = regexp.MustCompile(`(?m)^\s*([-*+]|\d+[.)])\s+`)
+	rulePattern       = regexp.MustCompile(`(?m)^\s*([-*_]\s*){3,}$`)
+	tablePipePattern  = regexp.MustCompile(`\s*\|\s*`)
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// speakableText joins a turn's text blocks and strips Markdown syntax that would be read
+// out literally. Code blocks are dropped: listening to code isn't useful.
+func speakableText(blocks []llmModels.TurnBlock) string {
+	var parts []string
+	for _, block := range blocks {
+		if block.BlockType != llmModels.BlockTypeText || block.TextContent == nil {
+			continue
+		}
+		if text := stripMarkdown(*block.TextContent); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// stripMarkdown reduces Markdown to plain prose
+func stripMarkdown(text string) string {
+	text = codeFencePattern.ReplaceAllString(text, "")
+	text = imagePattern.ReplaceAllString(text, "$1")
+	text = linkPattern.ReplaceAllString(text, "$1")
+	text = inlineCodePattern.ReplaceAllString(text, "$1")
+	text = htmlTagPattern.ReplaceAllString(text, "")
+	text = rulePattern.ReplaceAllString(text, "")
+	text = headingPattern.ReplaceAllString(text, "")
+	text = quotePattern.ReplaceAllString(text, "")
+	text = listMarkerPattern.ReplaceAllString(text, "")
+	text = emphasisPattern.ReplaceAllString(text, "")
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.Contains(line, "|") {
+			line = strings.Trim(tablePipePattern.ReplaceAllString(line, ", "), ", ")
+			if strings.Trim(line, ",-: ") == "" {
+				line = "" // Table header separator row
+			}
+		}
+		lines[i] = strings.TrimSpace(line)
+	}
+	text = strings.Join(lines, "\n")
+	return strings.TrimSpace(blankLinesPattern.ReplaceAllString(text, "\n\n"))
+}
+
+// splitText cuts text into chunks of at most maxChars characters, preferring paragraph,
+// then sentence, then word boundaries, so each provider request reads naturally
+func splitText(text string, maxChars int) []string {
+	var chunks []string
+	for utf8.RuneCountInString(text) > maxChars {
+		cut := cutPoint(text, maxChars)
+		if chunk := strings.TrimSpace(text[:cut]); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		text = text[cut:]
+	}
+	if chunk := strings.TrimSpace(text); chunk != "" {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// cutPoint returns the byte offset to cut text at so the first part holds at most maxChars characters
+func cutPoint(text string, maxChars int) int {
+	limit := len(text)
+	for i := range text {
+		if maxChars == 0 {
+			limit = i
+			break
+		}
+		maxChars--
+	}
+	window := text[:limit]
+
+	for _, separator := range []string{"\n\n", "\n", ". ", "! ", "? ", "; ", ", ", " "} {
+		// Don't cut so early that chunks become tiny
+		if i := strings.LastIndex(window, separator); i > limit/2 {
+			return i + len(separator)
+		}
+	}
+	return limit
+}
@@ -0,0 +1,82 @@
+package speech
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+func TestStripMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "The wind rose.", "The wind rose."},
+		{"heading and emphasis", "## Chapter One\n\nShe was **very** *tired*.", "Chapter One\n\nShe was very tired."},
+		{"link and image", "See [the map](https://x.test) ![a harbor](h.png)", "See the map a harbor"},
+		{"code fence dropped", "Before\n\n```go\nfmt.Println()\n```\n\nAfter", "Before\n\nAfter"},
+		{"inline code kept", "Call `open` twice", "Call open twice"},
+		{"lists and quotes", "- one\n2. two\n> quoted", "one\ntwo\nquoted"},
+		{"rule", "Above\n\n---\n\nBelow", "Above\n\nBelow"},
+		{"table", "| Name | Age |\n|---|---|\n| Aria | 19 |", "Name, Age\n\nAria, 19"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripMarkdown(tt.in); got != tt.want {
+				t.Errorf("stripMarkdown(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpeakableText_TextBlocksOnly(t *testing.T) {
+	text := func(s string) *string { return &s }
+	blocks := []llmModels.TurnBlock{
+		{BlockType: llmModels.BlockTypeThinking, TextContent: text("hmm")},
+		{BlockType: llmModels.BlockTypeText, TextContent: text("**First**")},
+		{BlockType: llmModels.BlockTypeToolUse},
+		{BlockType: llmModels.BlockTypeText, TextContent: text("Second")},
+	}
+	if got := speakableText(blocks); got != "First\n\nSecond" {
+		t.Errorf("speakableText = %q", got)
+	}
+}
+
+func TestSplitText(t *testing.T) {
+	t.Run("short text is one chunk", func(t *testing.T) {
+		if got := splitText("Hello there.", 100); len(got) != 1 || got[0] != "Hello there." {
+			t.Errorf("splitText = %q", got)
+		}
+	})
+
+	t.Run("prefers sentence boundaries", func(t *testing.T) {
+		text := "The lanterns swayed. The harbor slept. Aria kept counting."
+		got := splitText(text, 45)
+		want := []string{"The lanterns swayed. The harbor slept.", "Aria kept counting."}
+		if strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("splitText = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("respects limit in characters", func(t *testing.T) {
+		text := strings.Repeat("ünïcödé wörds ", 200)
+		for _, chunk := range splitText(text, 100) {
+			if n := utf8.RuneCountInString(chunk); n > 100 {
+				t.Errorf("chunk has %d characters", n)
+			}
+			if !utf8.ValidString(chunk) {
+				t.Errorf("chunk split a character: %q", chunk)
+			}
+		}
+	})
+
+	t.Run("hard cut without separators", func(t *testing.T) {
+		got := splitText(strings.Repeat("a", 250), 100)
+		if len(got) != 3 || len(got[0]) != 100 || len(got[2]) != 50 {
+			t.Errorf("splitText lengths = %d chunks", len(got))
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package streaming
+
+import (
+	"fmt"
+	"strings"
+)
+
+// accumulator.go - size limits for delta accumulation.
+//
+// Partial JSON deltas are buffered per block until the block completes, and the provider
+// library buffers text the same way to build complete blocks. A pathological provider (a
+// runaway tool_use input, a block that never stops) would grow these buffers without bound.
+// Every delta is counted against a per-block and a per-turn limit; exceeding either fails
+// the turn with an AccumulatorLimitError instead of accumulating further.
+
+// Default limits (overridable via STREAM_MAX_BLOCK_BYTES / STREAM_MAX_TURN_BYTES)
+const (
+	DefaultMaxBlockBytes = 4 << 20  // 4 MiB per block
+	DefaultMaxTurnBytes  = 16 << 20 // 16 MiB per turn, across all rounds
+)
+
+// AccumulatorLimits bounds the delta bytes a stream accepts (0 = unlimited)
+type AccumulatorLimits struct {
+	MaxBlockBytes int // Per block (text, thinking, tool input JSON)
+	MaxTurnBytes  int // Per turn, summed over all blocks and continuation rounds
+}
+
+// DefaultAccumulatorLimits returns the default limits
+func DefaultAccumulatorLimits() AccumulatorLimits {
+	return AccumulatorLimits{MaxBlockBytes: DefaultMaxBlockBytes, MaxTurnBytes: DefaultMaxTurnBytes}
+}
+
+// AccumulatorLimitError is returned when a delta would exceed an accumulator limit
+type AccumulatorLimitError struct {
+	Scope      string // "block" or "turn"
+	Limit      int
+	Size       int // bytes including the rejected delta
+	BlockIndex int // turn-level sequence of the block that received the delta
+}
+
+func (e *AccumulatorLimitError) Error() string {
+	return fmt.Sprintf("streamed content exceeds the %s size limit (%d bytes > %d bytes, block %d)",
+		e.Scope, e.Size, e.Limit, e.BlockIndex)
+}
+
+// accumulatorStats are logged when a stream ends
+type accumulatorStats struct {
+	TurnBytes      int // delta bytes received over the whole turn
+	PeakBlockBytes int // largest single block
+	PeakHeldBytes  int // most JSON held in memory at once
+	Rejections     int // deltas rejected by a limit
+}
+
+// deltaAccumulator counts delta bytes per block and turn, and buffers partial JSON.
+// Keys are provider block indices, which restart at 0 each round, so per-block state must
+// be reset between rounds (resetRound). Not safe for concurrent use (one per executor).
+type deltaAccumulator struct {
+	limits     AccumulatorLimits
+	json       map[int]*strings.Builder // provider block index -> partial JSON
+	blockBytes map[int]int              // provider block index -> bytes received this round
+	heldBytes  int                      // JSON bytes currently buffered
+	stats      accumulatorStats
+}
+
+func newDeltaAccumulator(limits AccumulatorLimits) *deltaAccumulator {
+	return &deltaAccumulator{
+		limits:     limits,
+		json:       make(map[int]*strings.Builder),
+		blockBytes: make(map[int]int),
+	}
+}
+
+// count checks size against the limits and records it for the block.
+// sequence is the turn-level block index (for the error).
+func (a *deltaAccumulator) count(blockIndex, sequence, size int) error {
+	blockTotal := a.blockBytes[blockIndex] + size
+	turnTotal := a.stats.TurnBytes + size
+
+	if a.limits.MaxBlockBytes > 0 && blockTotal > a.limits.MaxBlockBytes {
+		a.stats.Rejections++
+		return &AccumulatorLimitError{Scope: "block", Limit: a.limits.MaxBlockBytes, Size: blockTotal, BlockIndex: sequence}
+	}
+	if a.limits.MaxTurnBytes > 0 && turnTotal > a.limits.MaxTurnBytes {
+		a.stats.Rejections++
+		return &AccumulatorLimitError{Scope: "turn", Limit: a.limits.MaxTurnBytes, Size: turnTotal, BlockIndex: sequence}
+	}
+
+	a.blockBytes[blockIndex] = blockTotal
+	a.stats.TurnBytes = turnTotal
+	a.stats.PeakBlockBytes = max(a.stats.PeakBlockBytes, blockTotal)
+	return nil
+}
+
+// addText counts a text/thinking/signature delta (forwarded immediately, not buffered here)
+func (a *deltaAccumulator) addText(blockIndex, sequence int, text string) error {
+	return a.count(blockIndex, sequence, len(text))
+}
+
+// addJSON counts and buffers a partial JSON delta
+func (a *deltaAccumulator) addJSON(blockIndex, sequence int, partial string) error {
+	if err := a.count(blockIndex, sequence, len(partial)); err != nil {
+		return err
+	}
+	builder, ok := a.json[blockIndex]
+	if !ok {
+		builder = &strings.Builder{}
+		a.json[blockIndex] = builder
+	}
+	builder.WriteString(partial)
+	a.heldBytes += len(partial)
+	a.stats.PeakHeldBytes = max(a.stats.PeakHeldBytes, a.heldBytes)
+	return nil
+}
+
+// takeJSON returns and releases the buffered JSON of a completed block
+func (a *deltaAccumulator) takeJSON(blockIndex int) (string, bool) {
+	builder, ok := a.json[blockIndex]
+	if !ok {
+		return "", false
+	}
+	delete(a.json, blockIndex)
+	a.heldBytes -= builder.Len()
+	return builder.String(), true
+}
+
+// resetRound drops per-block state before a new provider stream (indices restart at 0).
+// Partial JSON of blocks that never completed is discarded.
+func (a *deltaAccumulator) resetRound() {
+	clear(a.json)
+	clear(a.blockBytes)
+	a.heldBytes = 0
+}
@@ -0,0 +1,104 @@
+package streaming
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	domainllm "meridian/internal/domain/services/llm"
+)
+
+func TestDeltaAccumulator_Limits(t *testing.T) {
+	acc := newDeltaAccumulator(AccumulatorLimits{MaxBlockBytes: 10, MaxTurnBytes: 15})
+
+	if err := acc.addJSON(0, 3, `{"a":`); err != nil {
+		t.Fatal(err)
+	}
+	if err := acc.addText(1, 4, "12345678"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Block 0 would reach 11 bytes
+	var limitErr *AccumulatorLimitError
+	if err := acc.addJSON(0, 3, `"long"}`); !errors.As(err, &limitErr) || limitErr.Scope != "block" || limitErr.BlockIndex != 3 {
+		t.Fatalf("expected block limit error, got %v", err)
+	}
+
+	// Turn total would reach 16 bytes
+	if err := acc.addText(2, 5, "abc"); !errors.As(err, &limitErr) || limitErr.Scope != "turn" {
+		t.Fatalf("expected turn limit error, got %v", err)
+	}
+
+	// Rejected deltas are not buffered
+	if got, _ := acc.takeJSON(0); got != `{"a":` {
+		t.Errorf("buffered JSON = %q", got)
+	}
+	if acc.stats.Rejections != 2 || acc.stats.TurnBytes != 13 || acc.stats.PeakBlockBytes != 8 {
+		t.Errorf("stats = %+v", acc.stats)
+	}
+}
+
+func TestDeltaAccumulator_ResetRound(t *testing.T) {
+	acc := newDeltaAccumulator(AccumulatorLimits{MaxBlockBytes: 10})
+
+	// A block that never completed must not leak into the next round's block 0
+	if err := acc.addJSON(0, 0, `{"partial":`); err == nil {
+		t.Fatal("11 bytes should exceed the block limit")
+	}
+	if err := acc.addJSON(0, 0, `{"p":`); err != nil {
+		t.Fatal(err)
+	}
+	acc.resetRound()
+
+	if _, ok := acc.takeJSON(0); ok {
+		t.Error("partial JSON survived resetRound")
+	}
+	if err := acc.addJSON(0, 3, `{"q":1}`); err != nil {
+		t.Errorf("block budget should restart each round: %v", err)
+	}
+	if acc.heldBytes != 7 || acc.stats.PeakHeldBytes != 7 {
+		t.Errorf("held = %d, peak = %d", acc.heldBytes, acc.stats.PeakHeldBytes)
+	}
+}
+
+func TestStreamExecutor_OversizedToolInputFailsTurn(t *testing.T) {
+	input := map[string]interface{}{"query": strings.Repeat("x", 200)}
+	round := providerRound{events: append(
+		toolUseEvents(0, "call-1", "lookup", input),
+		metadataEvent("tool_use"),
+	)}
+	h := newHarness(t, 5, round)
+	h.executor.accumulator = newDeltaAccumulator(AccumulatorLimits{MaxBlockBytes: 100})
+
+	events := h.run(t, nil)
+
+	assertBlocks(t, h.store, nil)
+	assertStatuses(t, h.store, "streaming", "error")
+	assertLabels(t, events, []string{"block_start:0", "turn_error"})
+	if h.store.errorMsg == nil || !strings.Contains(*h.store.errorMsg, "block size limit") {
+		t.Errorf("persisted turn error = %v", h.store.errorMsg)
+	}
+	if len(h.executor.accumulator.json) != 0 || h.executor.accumulator.heldBytes != 0 {
+		t.Error("accumulator not released after the failed stream")
+	}
+}
+
+// Text deltas count against the limits too, even though they are forwarded immediately
+func TestStreamExecutor_TextCountsAgainstTurnLimit(t *testing.T) {
+	round := providerRound{events: []domainllm.StreamEvent{
+		textDelta(0, strings.Repeat("a", 60)),
+		textDelta(0, strings.Repeat("b", 60)),
+		textBlock(0, "unused"),
+		metadataEvent("end_turn"),
+	}}
+	h := newHarness(t, 5, round)
+	h.executor.accumulator = newDeltaAccumulator(AccumulatorLimits{MaxTurnBytes: 100})
+
+	events := h.run(t, nil)
+
+	assertLabels(t, events, []string{"block_start:0", "block_delta:0", "turn_error"})
+	if final := events[len(events)-1]; final.Data["error"] == nil ||
+		!strings.Contains(final.Data["error"].(string), "turn size limit") {
+		t.Errorf("turn_error = %v", final.Data)
+	}
+}
@@ -0,0 +1,74 @@
+package streaming
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+// resolveAttachments fills in the image blocks that reference an uploaded attachment
+// ({"attachment_id": ...}) with the attachment's MIME type, storage key and a signed URL,
+// so they're stored like any other image kept in object storage. Attachments must belong
+// to the chat's project; the caller has already checked the user can access it.
+func (s *Service) resolveAttachments(ctx context.Context, projectID string, blocks []llmSvc.TurnBlockInput) error {
+	for i := range blocks {
+		input := &blocks[i]
+		if input.BlockType != llmModels.BlockTypeImage {
+			continue
+		}
+
+		var image llmModels.ImageContent
+		block := llmModels.TurnBlock{BlockType: input.BlockType, Content: input.Content}
+		if err := llmModels.DecodeContent(&block, &image); err != nil || image.AttachmentID == "" {
+			continue // Validated with the request; URL-only images pass through
+		}
+		if s.attachments == nil {
+			return fmt.Errorf("%w: attachments are not supported on this server", domain.ErrValidation)
+		}
+
+		attachment, err := s.getProjectAttachment(ctx, projectID, image.AttachmentID)
+		if err != nil {
+			return err
+		}
+		image.MIMEType = attachment.ContentType
+		image.StorageKey = attachment.StorageKey
+		if s.objectStore != nil {
+			ttl := time.Duration(s.config.StorageURLTTLSeconds) * time.Second
+			if image.URL, err = s.objectStore.SignedURL(ctx, attachment.StorageKey, ttl); err != nil {
+				return fmt.Errorf("sign attachment URL: %w", err)
+			}
+		}
+
+		if input.Content, err = llmModels.EncodeContent(&image); err != nil {
+			return fmt.Errorf("encode image block: %w", err)
+		}
+	}
+	return nil
+}
+
+// getProjectAttachment loads an attachment of the project; others are reported as missing
+func (s *Service) getProjectAttachment(ctx context.Context, projectID, attachmentID string) (*llmModels.Attachment, error) {
+	notFound := fmt.Errorf("%w: attachment %s not found in this project", domain.ErrValidation, attachmentID)
+	if _, err := uuid.Parse(attachmentID); err != nil {
+		return nil, notFound
+	}
+
+	attachment, err := s.attachments.GetAttachment(ctx, attachmentID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, notFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if attachment.ProjectID != projectID {
+		return nil, notFound
+	}
+	return attachment, nil
+}
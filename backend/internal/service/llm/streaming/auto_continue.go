@@ -0,0 +1,126 @@
+package streaming
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	llmModels "meridian/internal/domain/models/llm"
+	domainllm "meridian/internal/domain/services/llm"
+	"meridian/internal/eventstream"
+)
+
+// auto_continue.go - max_tokens auto-continue.
+//
+// When a response is cut off by max_tokens and the request set auto_continue, the executor
+// sends the conversation back with the partial assistant content as the final (prefill)
+// message. The provider continues from where it stopped, and the new blocks are appended
+// to the same turn, exactly like a tool continuation round.
+//
+// Only providers that accept an assistant prefill can do this. Anthropic rejects prefill
+// when extended thinking is enabled, so thinking requests always complete normally.
+
+// stopReasonMaxTokens is the normalized stop reason for responses cut off by max_tokens
+const stopReasonMaxTokens = "max_tokens"
+
+// prefillProviders lists providers that continue from a trailing assistant message
+var prefillProviders = map[string]bool{
+	"anthropic":  true,
+	"openrouter": true,
+	"lorem":      true,
+}
+
+// canAutoContinue reports whether the request opted in and another round is allowed
+func (se *StreamExecutor) canAutoContinue() bool {
+	params := se.req.Params
+	if se.continuations >= params.AutoContinueRounds() {
+		return false
+	}
+	if !prefillProviders[se.provider.Name()] {
+		se.logger.Debug("auto-continue skipped: provider does not support assistant prefill",
+			"provider", se.provider.Name(),
+		)
+		return false
+	}
+	if params.ThinkingEnabled != nil && *params.ThinkingEnabled {
+		se.logger.Debug("auto-continue skipped: thinking enabled")
+		return false
+	}
+	return true
+}
+
+// continueAfterMaxTokens streams another round that continues the truncated response.
+// Falls back to completing the turn when the conversation can't be used as a prefill
+// (e.g. the truncated block was a tool_use, whose partial JSON can't be continued).
+func (se *StreamExecutor) continueAfterMaxTokens(ctx context.Context, send eventstream.EventSink, metadata *domainllm.StreamMetadata) error {
+	path, err := se.loadConversationPath(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to load conversation for auto-continue: %w", err)
+		se.handleError(ctx, send, err)
+		return err
+	}
+
+	messages, err := se.messageBuilder.BuildMessages(ctx, path, se.messageTarget())
+	if err != nil {
+		err = fmt.Errorf("failed to build auto-continue messages: %w", err)
+		se.handleError(ctx, send, err)
+		return err
+	}
+
+	if !prepareAssistantPrefill(messages) {
+		se.logger.Info("auto-continue skipped: response does not end with assistant text")
+		return se.completeTurn(ctx, send, metadata.StopReason, metadata)
+	}
+
+	se.continuations++
+
+	contReq := &domainllm.GenerateRequest{
+		Messages: messages,
+		Model:    se.req.Model,
+		Params:   se.req.Params, // Same params: the continuation gets a fresh max_tokens budget
+	}
+
+	se.logContinuationRequest(contReq)
+
+	contStreamChan, err := se.provider.StreamResponse(ctx, contReq)
+	if err != nil {
+		se.handleError(ctx, send, fmt.Errorf("auto-continue stream failed: %w", err))
+		return fmt.Errorf("auto-continue stream failed: %w", err)
+	}
+
+	se.logger.Info("auto-continue stream started",
+		"continuation", se.continuations,
+		"max_continuations", se.req.Params.AutoContinueRounds(),
+		"next_expected_block", se.maxBlockSequence+1,
+	)
+
+	// New blocks are appended after the truncated ones (sequence remapping in processProviderStream)
+	return se.processProviderStream(ctx, contStreamChan, send)
+}
+
+// prepareAssistantPrefill checks that the last message is assistant text (a usable prefill)
+// and trims trailing whitespace from it, which Anthropic rejects in a final assistant message.
+// Modifies messages in-memory only; persisted blocks keep the original text.
+func prepareAssistantPrefill(messages []domainllm.Message) bool {
+	if len(messages) == 0 {
+		return false
+	}
+
+	last := messages[len(messages)-1]
+	if last.Role != "assistant" || len(last.Content) == 0 {
+		return false
+	}
+
+	block := last.Content[len(last.Content)-1]
+	if block.BlockType != llmModels.BlockTypeText || block.TextContent == nil {
+		return false
+	}
+
+	trimmed := strings.TrimRight(*block.TextContent, " \t\r\n")
+	if trimmed == "" {
+		return false
+	}
+	block.TextContent = &trimmed
+
+	return true
+}
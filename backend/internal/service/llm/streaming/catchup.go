@@ -5,46 +5,94 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strconv"
 
-	mstream "github.com/haowjy/meridian-stream-go"
-
+	"meridian/internal/domain"
 	llmModels "meridian/internal/domain/models/llm"
 	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/eventstream"
 )
 
 // buildCatchupFunc creates a catchup function that retrieves events from the database.
-// This function is used by mstream to replay missed events during reconnection or first connection.
+// This function is used by the turn stream to replay missed events during reconnection or first connection.
 // Uses TurnReader interface for better ISP compliance (only needs read operations)
-func buildCatchupFunc(turnRepo llmRepo.TurnReader, serializer *llmModels.BlockSerializer, logger *slog.Logger) mstream.CatchupFunc {
-	return func(streamID string, lastEventID string) ([]mstream.Event, error) {
+func buildCatchupFunc(turnRepo llmRepo.TurnReader, serializer *llmModels.BlockSerializer, logger *slog.Logger) eventstream.CatchupFunc {
+	return func(streamID string, lastEventID string) ([]eventstream.Event, error) {
 		ctx := context.Background()
 		turnID := streamID // streamID is the turnID
 
-		// Get turn metadata for model info
-		turn, err := turnRepo.GetTurn(ctx, turnID)
+		// Saved when the stream ended (nil while it runs)
+		index, err := turnRepo.GetStreamIndex(ctx, turnID)
 		if err != nil {
-			logger.Error("failed to get turn for catchup",
-				"turn_id", turnID,
+			logger.Error("failed to get stream index for catchup",
 				"error", err,
 			)
-			return nil, fmt.Errorf("failed to get turn: %w", err)
+			return nil, fmt.Errorf("failed to get stream index: %w", err)
 		}
 
-		// Get all TurnBlocks from database
-		blocks, err := turnRepo.GetTurnBlocks(ctx, turnID)
-		if err != nil {
-			logger.Error("failed to get turn blocks for catchup",
-				"turn_id", turnID,
-				"error", err,
-			)
-			return nil, fmt.Errorf("failed to get turn blocks: %w", err)
+		return replayTurnEvents(ctx, turnRepo, serializer, turnID, lastEventID, index, logger)
+	}
+}
+
+// StreamHistory rebuilds the events of a turn whose stream has ended, for a client
+// connecting after the stream left the registry (evicted, or streamed on another
+// instance). Returns domain.ErrNotFound if no stream index was saved for the turn.
+func (s *Service) StreamHistory(ctx context.Context, turnID, lastEventID string) ([]eventstream.Event, error) {
+	index, err := s.turnReader.GetStreamIndex(ctx, turnID)
+	if err != nil {
+		return nil, err
+	}
+	if index == nil {
+		return nil, fmt.Errorf("turn %s has no stream history: %w", turnID, domain.ErrNotFound)
+	}
+
+	return replayTurnEvents(ctx, s.turnReader, llmModels.NewBlockSerializer(), turnID, lastEventID, index, s.logger)
+}
+
+// replayTurnEvents rebuilds a turn's stream from the database: turn_start, the persisted
+// blocks and, once the stream has ended (index with a final event), the event that ended
+// it. With the index, a numeric lastEventID (DEBUG event IDs) skips what the client has
+// already received; otherwise everything is replayed.
+func replayTurnEvents(
+	ctx context.Context,
+	turnRepo llmRepo.TurnReader,
+	serializer *llmModels.BlockSerializer,
+	turnID, lastEventID string,
+	index *llmModels.StreamIndex,
+	logger *slog.Logger,
+) ([]eventstream.Event, error) {
+	// Get turn metadata for model info
+	turn, err := turnRepo.GetTurn(ctx, turnID)
+	if err != nil {
+		logger.Error("failed to get turn for catchup",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get turn: %w", err)
+	}
+
+	// Get all TurnBlocks from database
+	blocks, err := turnRepo.GetTurnBlocks(ctx, turnID)
+	if err != nil {
+		logger.Error("failed to get turn blocks for catchup",
+			"error", err,
+		)
+		return nil, fmt.Errorf("failed to get turn blocks: %w", err)
+	}
+
+	// Events the client has received (0 = none, or unknown without the index)
+	seen := 0
+	if index != nil {
+		if last, err := strconv.Atoi(lastEventID); err == nil {
+			seen = last
 		}
+	}
 
-		// Convert to mstream.Events
-		var events []mstream.Event
+	// Convert to stream events
+	var events []eventstream.Event
 
-		// ALWAYS emit turn_start (even if no blocks yet)
-		// Library will add event IDs if DEBUG mode enabled
+	// turn_start (even if no blocks yet), unless the client is resuming
+	// Library will add event IDs if DEBUG mode enabled
+	if seen == 0 {
 		model := ""
 		if turn.Model != nil {
 			model = *turn.Model
@@ -53,15 +101,24 @@ func buildCatchupFunc(turnRepo llmRepo.TurnReader, serializer *llmModels.BlockSe
 			TurnID: turnID,
 			Model:  model,
 		})
-		events = append(events, mstream.NewEvent(turnStartData).
-			WithType(llmModels.SSEEventTurnStart))
+		events = append(events, eventstream.NewEvent(llmModels.SSEEventTurnStart, turnStartData))
+	}
 
-		// Emit block events with full content using BlockSerializer
-		for i, block := range blocks {
-			blockEvents := serializer.BlockToSSEEvents(&block, i)
-			events = append(events, blockEvents...)
+	// Emit block events with full content using BlockSerializer
+	for i, block := range blocks {
+		if seen > 0 {
+			if ended := index.BlockEvents(block.Sequence); ended > 0 && ended <= seen {
+				continue // The client received the block's block_stop
+			}
 		}
+		blockEvents := serializer.BlockToSSEEvents(&block, i)
+		events = append(events, blockEvents...)
+	}
 
-		return events, nil
+	// The event that ended the stream
+	if index != nil && index.Final != nil && index.Final.Events > seen {
+		events = append(events, eventstream.NewEvent(index.Final.Event, index.Final.Data))
 	}
+
+	return events, nil
 }
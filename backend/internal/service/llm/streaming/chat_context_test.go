@@ -0,0 +1,77 @@
+package streaming
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"meridian/internal/domain"
+	docsysModels "meridian/internal/domain/models/docsystem"
+	llmModels "meridian/internal/domain/models/llm"
+	docsysRepo "meridian/internal/domain/repositories/docsystem"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	domainllm "meridian/internal/domain/services/llm"
+)
+
+// keyedChatRepo serves chats created by earlier cold starts, by idempotency key
+type keyedChatRepo struct {
+	llmRepo.ChatRepository
+	chatIDsByKey map[string]string
+}
+
+func (r *keyedChatRepo) GetChatByIdempotencyKey(ctx context.Context, projectID, userID, key string) (*llmModels.Chat, error) {
+	chatID, ok := r.chatIDsByKey[key]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &llmModels.Chat{ID: chatID, ProjectID: projectID, UserID: userID}, nil
+}
+
+// openProjectRepo grants access to every project
+type openProjectRepo struct {
+	docsysRepo.ProjectRepository
+}
+
+func (r *openProjectRepo) GetByID(ctx context.Context, id, userID string) (*docsysModels.Project, error) {
+	return &docsysModels.Project{ID: id, UserID: userID}, nil
+}
+
+func TestService_ResolveChatContext_IdempotencyKey(t *testing.T) {
+	s := &Service{
+		chatRepo:    &keyedChatRepo{chatIDsByKey: map[string]string{"first-send": "chat-1"}},
+		projectRepo: &openProjectRepo{},
+	}
+	projectID := "project-1"
+	key := func(k string) *string { return &k }
+
+	tests := []struct {
+		name         string
+		key          *string
+		wantConflict string
+	}{
+		{name: "no key", key: nil},
+		{name: "new key", key: key("second-send")},
+		{name: "repeated key", key: key("first-send"), wantConflict: "chat-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &domainllm.CreateTurnRequest{UserID: "user-1", ProjectID: &projectID, Role: "user", ChatIdempotencyKey: tt.key}
+
+			chatCtx, err := s.resolveChatContext(context.Background(), req)
+			if tt.wantConflict != "" {
+				var conflict *domain.ConflictError
+				if !errors.As(err, &conflict) || conflict.ResourceID != tt.wantConflict {
+					t.Fatalf("error = %v, want a conflict naming %s", err, tt.wantConflict)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveChatContext: %v", err)
+			}
+			if !chatCtx.isNewChat {
+				t.Error("isNewChat = false, want a cold start")
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package streaming
+
+import (
+	"reflect"
+	"testing"
+
+	llmModels "meridian/internal/domain/models/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+func TestApplyChatDefaults(t *testing.T) {
+	chat := &llmModels.Chat{
+		DefaultSkills: []string{"worldbuilding"},
+		DefaultTools:  []string{"doc_view", "doc_search"},
+	}
+	s := &Service{}
+
+	// Absent fields take the chat defaults
+	req := &llmSvc.CreateTurnRequest{}
+	params := &llmModels.RequestParams{}
+	s.applyChatDefaults(chat, req, params)
+	if !reflect.DeepEqual(req.SelectedSkills, []string{"worldbuilding"}) {
+		t.Errorf("skills = %v", req.SelectedSkills)
+	}
+	if !reflect.DeepEqual(params.ToolNames(), []string{"doc_view", "doc_search"}) {
+		t.Errorf("tools = %v", params.ToolNames())
+	}
+
+	// Explicit (even empty) values are kept
+	req = &llmSvc.CreateTurnRequest{SelectedSkills: []string{}}
+	params = &llmModels.RequestParams{Tools: []llmModels.ToolDefinition{{Name: "doc_tree"}}}
+	s.applyChatDefaults(chat, req, params)
+	if len(req.SelectedSkills) != 0 {
+		t.Errorf("explicit empty skills overridden: %v", req.SelectedSkills)
+	}
+	if !reflect.DeepEqual(params.ToolNames(), []string{"doc_tree"}) {
+		t.Errorf("explicit tools overridden: %v", params.ToolNames())
+	}
+
+	// Cold start (no chat yet) is a no-op
+	req = &llmSvc.CreateTurnRequest{}
+	params = &llmModels.RequestParams{}
+	s.applyChatDefaults(nil, req, params)
+	if req.SelectedSkills != nil || params.Tools != nil {
+		t.Error("defaults applied without a chat")
+	}
+}
@@ -51,7 +51,13 @@ func (s *Service) BuildDebugProviderRequest(ctx context.Context, req *llmSvc.Cre
 	// Prepare request params and model (mirror CreateTurn)
 	requestParams := req.RequestParams
 	if requestParams == nil {
-		requestParams = make(map[string]interface{})
+		requestParams = &llmModels.RequestParams{}
+	}
+
+	// Validate request params
+	if err := requestParams.Validate(); err != nil {
+		s.logger.Error("invalid request params for debug", "error", err)
+		return nil, fmt.Errorf("%w: invalid request params: %v", domain.ErrValidation, err)
 	}
 
 	// Extract model from request_params with default fallback from config
@@ -59,33 +65,13 @@ func (s *Service) BuildDebugProviderRequest(ctx context.Context, req *llmSvc.Cre
 	if model == "" {
 		model = "claude-haiku-4-5-20251001" // Fallback if config not set
 	}
-	if modelParam, ok := requestParams["model"].(string); ok && modelParam != "" {
-		model = modelParam
-	}
-
-	// Validate and parse request params
-	if err := llmModels.ValidateRequestParams(requestParams); err != nil {
-		s.logger.Error("invalid request params for debug", "error", err)
-		return nil, fmt.Errorf("invalid request params: %w", err)
-	}
-
-	params, err := llmModels.GetRequestParamStruct(requestParams)
-	if err != nil {
-		s.logger.Error("failed to parse request params for debug", "error", err)
-		return nil, fmt.Errorf("failed to parse request params: %w", err)
-	}
-
-	// Allow model override via params
-	if params.Model != nil && *params.Model != "" {
-		model = *params.Model
+	if requestParams.Model != nil && *requestParams.Model != "" {
+		model = *requestParams.Model
 	}
 
 	// Extract provider from request_params or infer from model
-	var provider string
-	if params.Provider != nil && *params.Provider != "" {
-		// Provider explicitly specified
-		provider = *params.Provider
-	} else {
+	provider := requestParams.GetProvider()
+	if provider == "" {
 		// Try to infer provider from model name
 		if mappedProvider, found := llmModels.GetProviderForModel(model); found {
 			provider = mappedProvider
@@ -95,16 +81,21 @@ func (s *Service) BuildDebugProviderRequest(ctx context.Context, req *llmSvc.Cre
 		}
 	}
 
+	// Provider-facing copy with tools resolved to full schemas
+	params := requestParams.WithResolvedTools()
+
 	// Resolve system prompt from user, project, chat, and selected skills (mirror CreateTurn)
 	// Always resolve if skills are selected, or if no user system prompt provided
-	if err := s.resolveSystemPromptForParams(ctx, *req.ChatID, req.UserID, params, req.SelectedSkills); err != nil {
+	if err := s.resolveSystemPromptForParams(ctx, *req.ChatID, req.UserID, params, req.SelectedSkills, req.MatchStyle); err != nil {
 		s.logger.Error("failed to resolve system prompt for debug", "error", err)
 		return nil, err
 	}
+	appendResponseLanguage(params, req.TurnBlocks)
 
 	// Build conversation path from prev_turn_id (if provided)
 	var path []llmModels.Turn
 	if req.PrevTurnID != nil {
+		var err error
 		path, err = s.turnNavigator.GetTurnPath(ctx, *req.PrevTurnID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get turn path for debug: %w", err)
@@ -121,7 +112,7 @@ func (s *Service) BuildDebugProviderRequest(ctx context.Context, req *llmSvc.Cre
 	}
 
 	// Build messages from turn history using MessageBuilder
-	messages, err := s.messageBuilder.BuildMessages(ctx, path)
+	messages, err := s.messageBuilder.BuildMessages(ctx, path, llmSvc.MessageTarget{Provider: provider, Model: model})
 	if err != nil {
 		return nil, fmt.Errorf("failed to build messages for debug: %w", err)
 	}
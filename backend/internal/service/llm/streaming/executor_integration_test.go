@@ -0,0 +1,781 @@
+package streaming
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"meridian/internal/capabilities"
+	llmModels "meridian/internal/domain/models/llm"
+	domainllm "meridian/internal/domain/services/llm"
+	"meridian/internal/eventstream"
+	"meridian/internal/service/llm/conversation"
+	"meridian/internal/service/llm/tools"
+)
+
+// End-to-end tests for the StreamExecutor continuation state machine.
+// A scripted provider plays back one round of stream events per StreamResponse call, an
+// in-memory turn store stands in for the database, and events are captured from a real
+// stream subscriber, so persistence and SSE ordering are observed exactly as in production.
+
+const (
+	testTurnID     = "assistant-turn"
+	testUserTurnID = "user-turn"
+)
+
+// fakeTurnStore is an in-memory TurnWriter, TurnReader and TurnNavigator for one chat
+// (a user turn followed by the assistant turn being streamed)
+type fakeTurnStore struct {
+	mu       sync.Mutex
+	blocks   []llmModels.TurnBlock // assistant turn blocks, in persistence order
+	statuses []string              // status history of the assistant turn
+	errorMsg *string
+	metadata []llmModels.TurnMetadataUpdate
+	index    *llmModels.StreamIndex
+}
+
+func (s *fakeTurnStore) CreateTurn(ctx context.Context, turn *llmModels.Turn) error { return nil }
+
+func (s *fakeTurnStore) CreateTurnBlock(ctx context.Context, block *llmModels.TurnBlock) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Mirrors the (turn_id, sequence) unique constraint
+	for _, existing := range s.blocks {
+		if existing.Sequence == block.Sequence {
+			return fmt.Errorf("duplicate block sequence %d", block.Sequence)
+		}
+	}
+	s.blocks = append(s.blocks, *block)
+	return nil
+}
+
+func (s *fakeTurnStore) CreateTurnBlocks(ctx context.Context, blocks []llmModels.TurnBlock) error {
+	for i := range blocks {
+		if err := s.CreateTurnBlock(ctx, &blocks[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeTurnStore) DeleteTurnBlocks(ctx context.Context, turnID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blocks = nil
+	return nil
+}
+
+func (s *fakeTurnStore) UpdateTurnStatus(ctx context.Context, turnID, status string, completedAt *llmModels.Turn) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses = append(s.statuses, status)
+	return nil
+}
+
+func (s *fakeTurnStore) UpdateTurn(ctx context.Context, turn *llmModels.Turn) error { return nil }
+
+func (s *fakeTurnStore) UpdateTurnError(ctx context.Context, turnID, errorMsg string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses = append(s.statuses, "error")
+	s.errorMsg = &errorMsg
+	return nil
+}
+
+func (s *fakeTurnStore) UpdateTurnMetadata(ctx context.Context, turnID string, update *llmModels.TurnMetadataUpdate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metadata = append(s.metadata, *update)
+	return nil
+}
+
+func (s *fakeTurnStore) UpdateTurnAnnotations(ctx context.Context, turnID string, update *llmModels.TurnAnnotationsUpdate) (*llmModels.TurnAnnotations, error) {
+	return &llmModels.TurnAnnotations{}, nil
+}
+
+func (s *fakeTurnStore) SaveStreamIndex(ctx context.Context, turnID string, index *llmModels.StreamIndex) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.index = index
+	return nil
+}
+
+// GetTurn returns the assistant turn's latest status and stop reason
+func (s *fakeTurnStore) GetTurn(ctx context.Context, turnID string) (*llmModels.Turn, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if turnID != testTurnID {
+		return nil, errors.New("not implemented")
+	}
+	turn := &llmModels.Turn{ID: testTurnID, Role: "assistant", Status: "pending"}
+	if len(s.statuses) > 0 {
+		turn.Status = s.statuses[len(s.statuses)-1]
+	}
+	for _, update := range s.metadata {
+		if update.StopReason != nil {
+			turn.StopReason = update.StopReason
+		}
+	}
+	return turn, nil
+}
+
+func (s *fakeTurnStore) GetTurns(ctx context.Context, turnIDs []string) ([]llmModels.Turn, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *fakeTurnStore) GetTurnOwnerIDs(ctx context.Context, turnIDs []string) (map[string]string, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *fakeTurnStore) GetRootTurns(ctx context.Context, chatID string) ([]llmModels.Turn, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *fakeTurnStore) GetTurnBlocks(ctx context.Context, turnID string) ([]llmModels.TurnBlock, error) {
+	if turnID == testUserTurnID {
+		text := "Research the harbor scene"
+		return []llmModels.TurnBlock{{TurnID: testUserTurnID, BlockType: llmModels.BlockTypeText, TextContent: &text}}, nil
+	}
+	return s.sortedBlocks(), nil
+}
+
+func (s *fakeTurnStore) GetTurnBlocksForTurns(ctx context.Context, turnIDs []string) (map[string][]llmModels.TurnBlock, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *fakeTurnStore) GetStreamIndex(ctx context.Context, turnID string) (*llmModels.StreamIndex, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.index, nil
+}
+
+func (s *fakeTurnStore) SearchTurns(ctx context.Context, opts *llmModels.TurnSearchOptions) ([]llmModels.TurnSearchMatch, bool, error) {
+	return nil, false, errors.New("not implemented")
+}
+
+func (s *fakeTurnStore) RecallTurns(ctx context.Context, opts *llmModels.ConversationRecallOptions) ([]llmModels.RecalledTurn, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *fakeTurnStore) SearchChats(ctx context.Context, opts *llmModels.ChatSearchOptions) ([]llmModels.ChatSearchMatch, bool, error) {
+	return nil, false, errors.New("not implemented")
+}
+
+func (s *fakeTurnStore) GetTurnPath(ctx context.Context, turnID string) ([]llmModels.Turn, error) {
+	userTurnID := testUserTurnID
+	return []llmModels.Turn{
+		{ID: testUserTurnID, Role: "user"},
+		{ID: testTurnID, Role: "assistant", PrevTurnID: &userTurnID},
+	}, nil
+}
+
+func (s *fakeTurnStore) GetTurnPathWindow(ctx context.Context, turnID string, last int) ([]llmModels.Turn, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *fakeTurnStore) GetTurnSiblings(ctx context.Context, turnID string, opts *llmModels.TurnSiblingsOptions) (*llmModels.TurnSiblings, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *fakeTurnStore) GetSiblingsForTurns(ctx context.Context, turnIDs []string) (map[string][]string, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *fakeTurnStore) GetPaginatedTurns(ctx context.Context, chatID, userID string, fromTurnID *string, limit int, direction string, updateLastViewed bool) (*llmModels.PaginatedTurnsResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+// sortedBlocks returns the persisted blocks ordered by sequence
+func (s *fakeTurnStore) sortedBlocks() []llmModels.TurnBlock {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	blocks := make([]llmModels.TurnBlock, len(s.blocks))
+	for i, block := range s.blocks {
+		// Copy content so message building can't modify the "database"
+		if block.Content != nil {
+			content := make(map[string]interface{}, len(block.Content))
+			for k, v := range block.Content {
+				content[k] = v
+			}
+			block.Content = content
+		}
+		blocks[i] = block
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].Sequence < blocks[j].Sequence })
+	return blocks
+}
+
+// providerRound is the scripted output of one StreamResponse call
+type providerRound struct {
+	events []domainllm.StreamEvent
+	hang   bool // after the events, block until the context is cancelled
+}
+
+// scriptedProvider plays back one round per StreamResponse call and records the requests
+type scriptedProvider struct {
+	mu       sync.Mutex
+	rounds   []providerRound
+	requests []*domainllm.GenerateRequest
+}
+
+func (p *scriptedProvider) GenerateResponse(ctx context.Context, req *domainllm.GenerateRequest) (*domainllm.GenerateResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (p *scriptedProvider) StreamResponse(ctx context.Context, req *domainllm.GenerateRequest) (<-chan domainllm.StreamEvent, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = append(p.requests, req)
+	if len(p.rounds) == 0 {
+		return nil, fmt.Errorf("unexpected request %d", len(p.requests))
+	}
+	round := p.rounds[0]
+	p.rounds = p.rounds[1:]
+
+	ch := make(chan domainllm.StreamEvent)
+	go func() {
+		defer close(ch)
+		for _, event := range round.events {
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if round.hang {
+			<-ctx.Done()
+		}
+	}()
+	return ch, nil
+}
+
+func (p *scriptedProvider) Name() string { return "fake" }
+
+func (p *scriptedProvider) SupportsModel(model string) bool { return true }
+
+func (p *scriptedProvider) requestCount() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.requests)
+}
+
+// Round builders. Block indices are provider-relative (0-based per round), as real adapters emit them.
+
+func textDelta(index int, text string) domainllm.StreamEvent {
+	blockType := llmModels.BlockTypeText
+	return domainllm.StreamEvent{Delta: &llmModels.TurnBlockDelta{
+		BlockIndex: index,
+		BlockType:  &blockType,
+		DeltaType:  llmModels.DeltaTypeText,
+		TextDelta:  &text,
+	}}
+}
+
+func textBlock(index int, text string) domainllm.StreamEvent {
+	return domainllm.StreamEvent{Block: &llmModels.TurnBlock{
+		BlockType:   llmModels.BlockTypeText,
+		Sequence:    index,
+		TextContent: &text,
+	}}
+}
+
+func toolUseEvents(index int, id, name string, input map[string]interface{}) []domainllm.StreamEvent {
+	blockType := llmModels.BlockTypeToolUse
+	inputJSON, _ := json.Marshal(input)
+	partial := string(inputJSON)
+	return []domainllm.StreamEvent{
+		{Delta: &llmModels.TurnBlockDelta{
+			BlockIndex: index,
+			BlockType:  &blockType,
+			DeltaType:  llmModels.DeltaTypeJSON,
+			JSONDelta:  &partial,
+		}},
+		{Block: &llmModels.TurnBlock{
+			BlockType: llmModels.BlockTypeToolUse,
+			Sequence:  index,
+			Content: map[string]interface{}{
+				"tool_use_id": id,
+				"tool_name":   name,
+				"input":       input,
+			},
+		}},
+	}
+}
+
+func metadataEvent(stopReason string) domainllm.StreamEvent {
+	return domainllm.StreamEvent{Metadata: &domainllm.StreamMetadata{
+		Model:        "fake-model",
+		InputTokens:  100,
+		OutputTokens: 20,
+		StopReason:   stopReason,
+	}}
+}
+
+// answerRound streams a single text block and ends the turn
+func answerRound(text string) providerRound {
+	return providerRound{events: []domainllm.StreamEvent{
+		textDelta(0, text),
+		textBlock(0, text),
+		metadataEvent("end_turn"),
+	}}
+}
+
+// toolRound streams one tool_use block per call id (all calling "lookup")
+func toolRound(ids ...string) providerRound {
+	var events []domainllm.StreamEvent
+	for i, id := range ids {
+		events = append(events, toolUseEvents(i, id, "lookup", map[string]interface{}{"query": id})...)
+	}
+	return providerRound{events: append(events, metadataEvent("tool_use"))}
+}
+
+// lookupTool echoes its query; queries starting with "fail" return an error
+type lookupTool struct{}
+
+func (lookupTool) Execute(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	query, _ := input["query"].(string)
+	if strings.HasPrefix(query, "fail") {
+		return nil, fmt.Errorf("lookup failed for %s", query)
+	}
+	return map[string]interface{}{"query": query, "answer": "result for " + query}, nil
+}
+
+// capturedEvent is an SSE event as a client receives it
+type capturedEvent struct {
+	Type string
+	Data map[string]interface{}
+}
+
+// label renders an event compactly for order assertions: "block_start:3", "turn_complete".
+// Tool execution events carry no index - completions within a round arrive in any order.
+func (e capturedEvent) label() string {
+	switch e.Type {
+	case llmModels.SSEEventBlockStart, llmModels.SSEEventBlockDelta, llmModels.SSEEventBlockStop:
+		return fmt.Sprintf("%s:%v", e.Type, e.Data["block_index"])
+	default:
+		return e.Type
+	}
+}
+
+func labels(events []capturedEvent) []string {
+	out := make([]string, len(events))
+	for i, event := range events {
+		out[i] = event.label()
+	}
+	return out
+}
+
+// harness wires a StreamExecutor to the fakes
+type harness struct {
+	store    *fakeTurnStore
+	provider *scriptedProvider
+	executor *StreamExecutor
+}
+
+func newHarness(t *testing.T, maxToolRounds int, rounds ...providerRound) *harness {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	store := &fakeTurnStore{}
+	provider := &scriptedProvider{rounds: rounds}
+	registry := tools.NewToolRegistry()
+	registry.Register("lookup", lookupTool{})
+	messageBuilder := conversation.NewMessageBuilderService(nil, nil, nil, nil, logger)
+
+	executor := NewStreamExecutor(
+		testTurnID, "fake-model",
+		store, store, store,
+		provider, registry, messageBuilder, nil, logger,
+		maxToolRounds, ToolResultLimits{}, DefaultAccumulatorLimits(), false,
+	)
+	// Client channels large enough that no event is dropped while the test reads
+	executor.stream = eventstream.NewStream(testTurnID, executor.workFunc, eventstream.StreamOptions{BufferSize: 1024})
+
+	return &harness{store: store, provider: provider, executor: executor}
+}
+
+// run starts the stream and collects events until the stream closes its clients.
+// onEvent (optional) sees each event as it arrives.
+func (h *harness) run(t *testing.T, onEvent func(capturedEvent)) []capturedEvent {
+	t.Helper()
+	client := h.executor.GetStream().Subscribe("test-client")
+	h.executor.Start(&domainllm.GenerateRequest{
+		Model:  "fake-model",
+		Params: &llmModels.RequestParams{},
+	})
+
+	var events []capturedEvent
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case raw, ok := <-client:
+			if !ok {
+				return events
+			}
+			event := capturedEvent{Type: raw.Type}
+			if err := json.Unmarshal(raw.Data, &event.Data); err != nil {
+				t.Fatalf("event %s has invalid JSON: %v", raw.Type, err)
+			}
+			events = append(events, event)
+			if onEvent != nil {
+				onEvent(event)
+			}
+		case <-timeout:
+			t.Fatalf("stream did not finish; events so far: %v", labels(events))
+		}
+	}
+}
+
+func assertLabels(t *testing.T, got []capturedEvent, want []string) {
+	t.Helper()
+	if strings.Join(labels(got), " ") != strings.Join(want, " ") {
+		t.Errorf("SSE events:\n got: %v\nwant: %v", labels(got), want)
+	}
+}
+
+func assertBlocks(t *testing.T, store *fakeTurnStore, want []string) {
+	t.Helper()
+	blocks := store.sortedBlocks()
+	got := make([]string, len(blocks))
+	for i, block := range blocks {
+		got[i] = fmt.Sprintf("%d:%s", block.Sequence, block.BlockType)
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("persisted blocks:\n got: %v\nwant: %v", got, want)
+	}
+}
+
+func assertStatuses(t *testing.T, store *fakeTurnStore, want ...string) {
+	t.Helper()
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if strings.Join(store.statuses, " ") != strings.Join(want, " ") {
+		t.Errorf("turn statuses = %v, want %v", store.statuses, want)
+	}
+}
+
+func TestStreamExecutor_MultiRoundToolUse(t *testing.T) {
+	intro := providerRound{events: append(
+		[]domainllm.StreamEvent{textDelta(0, "Let me check."), textBlock(0, "Let me check.")},
+		append(
+			toolUseEvents(1, "call-a", "lookup", map[string]interface{}{"query": "a"}),
+			append(
+				toolUseEvents(2, "call-b", "lookup", map[string]interface{}{"query": "b"}),
+				metadataEvent("tool_use"),
+			)...,
+		)...,
+	)}
+	h := newHarness(t, 5, intro, toolRound("call-c"), answerRound("Done."))
+
+	events := h.run(t, nil)
+
+	// Provider indices restart at 0 each round; turn sequences continue after the
+	// tool_result blocks of the previous round
+	assertBlocks(t, h.store, []string{
+		"0:text", "1:tool_use", "2:tool_use", "3:tool_result", "4:tool_result",
+		"5:tool_use", "6:tool_result",
+		"7:text",
+	})
+	assertLabels(t, events, []string{
+		"block_start:0", "block_delta:0", "block_stop:0",
+		"block_start:1", "block_delta:1", "block_stop:1",
+		"block_start:2", "block_delta:2", "block_stop:2",
+		"tool_execution_start", "tool_execution_start",
+		"tool_execution_complete", "tool_execution_complete",
+		"block_start:3", "block_delta:3", "block_stop:3",
+		"block_start:4", "block_delta:4", "block_stop:4",
+		"block_start:5", "block_delta:5", "block_stop:5",
+		"tool_execution_start", "tool_execution_complete",
+		"block_start:6", "block_delta:6", "block_stop:6",
+		"block_start:7", "block_delta:7", "block_stop:7",
+		"turn_complete",
+	})
+	assertStatuses(t, h.store, "streaming", "complete")
+
+	// Results are persisted in call order, matched to their tool_use ids
+	blocks := h.store.sortedBlocks()
+	for i, id := range []string{"call-a", "call-b"} {
+		var content llmModels.ToolResultContent
+		if err := llmModels.DecodeContent(&blocks[3+i], &content); err != nil {
+			t.Fatalf("tool_result %d: %v", 3+i, err)
+		}
+		if content.ToolUseID != id || content.IsError || content.Attempts != 1 {
+			t.Errorf("tool_result %d content = %+v", 3+i, content)
+		}
+	}
+
+	// Each continuation resends the conversation including every block persisted so far
+	if got := h.provider.requestCount(); got != 3 {
+		t.Fatalf("provider requests = %d, want 3", got)
+	}
+	last := h.provider.requests[2].Messages
+	if len(last) != 2 || last[1].Role != "assistant" || len(last[1].Content) != 7 {
+		t.Errorf("final continuation should carry the user turn and 7 assistant blocks, got %d messages", len(last))
+	}
+
+	// Metadata is written every round; the turn completes with the last round's stop reason
+	if len(h.store.metadata) != 3 {
+		t.Errorf("metadata updates = %d, want 3", len(h.store.metadata))
+	}
+	if stop := events[len(events)-1].Data["stop_reason"]; stop != "end_turn" {
+		t.Errorf("turn_complete stop_reason = %v", stop)
+	}
+}
+
+func TestStreamExecutor_ToolErrorFallback(t *testing.T) {
+	h := newHarness(t, 5, toolRound("ok-1", "fail-1"), answerRound("One lookup failed."))
+
+	events := h.run(t, nil)
+
+	assertBlocks(t, h.store, []string{"0:tool_use", "1:tool_use", "2:tool_result", "3:tool_result", "4:text"})
+	assertStatuses(t, h.store, "streaming", "complete")
+
+	// The failure becomes an is_error tool_result so the model can react, instead of failing the turn
+	failed := h.store.sortedBlocks()[3].Content
+	if failed["is_error"] != true || failed["error"] != "lookup failed for fail-1" {
+		t.Errorf("failed tool_result content = %v", failed)
+	}
+	if _, hasResult := failed["result"]; hasResult {
+		t.Error("failed tool_result should not carry a result")
+	}
+
+	var completeErrors []string
+	for _, event := range events {
+		if event.Type == llmModels.SSEEventToolExecutionComplete && event.Data["is_error"] == true {
+			completeErrors = append(completeErrors, fmt.Sprint(event.Data["error"]))
+		}
+	}
+	if len(completeErrors) != 1 || completeErrors[0] != "lookup failed for fail-1" {
+		t.Errorf("tool_execution_complete errors = %v", completeErrors)
+	}
+	if h.store.errorMsg != nil {
+		t.Errorf("turn should not be marked as errored: %s", *h.store.errorMsg)
+	}
+}
+
+func TestStreamExecutor_SoftAndHardLimits(t *testing.T) {
+	// maxToolRounds = 1: soft limit after round 1, hard limit (2x) after round 2
+	h := newHarness(t, 1, toolRound("call-1"), toolRound("call-2"), answerRound("Best answer so far."))
+
+	h.run(t, nil)
+
+	assertBlocks(t, h.store, []string{"0:tool_use", "1:tool_result", "2:tool_use", "3:tool_result", "4:text"})
+	assertStatuses(t, h.store, "streaming", "complete")
+
+	if got := h.provider.requestCount(); got != 3 {
+		t.Fatalf("provider requests = %d, want 3", got)
+	}
+
+	// Soft limit: a user notification is prepended, tools remain available
+	soft := h.provider.requests[1]
+	first := soft.Messages[0]
+	if first.Role != "user" || first.Content[0].TextContent == nil ||
+		!strings.Contains(*first.Content[0].TextContent, "recommended tool usage limit of 1 rounds") {
+		t.Errorf("soft limit notification missing, first message = %+v", first)
+	}
+	if soft.Params.System != nil {
+		t.Errorf("soft limit should not change the system prompt, got %q", *soft.Params.System)
+	}
+
+	// Hard limit: the final round gets the limit instruction and a note in the last tool_result
+	hard := h.provider.requests[2]
+	if hard.Params.System == nil || !strings.Contains(*hard.Params.System, "reached your tool usage limit") {
+		t.Error("hard limit instruction missing from system prompt")
+	}
+	assistant := hard.Messages[len(hard.Messages)-1].Content
+	note := fmt.Sprint(assistant[len(assistant)-1].Content["result"])
+	if !strings.Contains(note, "maximum tool rounds (2/1)") {
+		t.Errorf("limit note missing from last tool_result: %q", note)
+	}
+
+	// The note is only sent to the provider, never persisted
+	stored := fmt.Sprint(h.store.sortedBlocks()[3].Content["result"])
+	if strings.Contains(stored, "maximum tool rounds") {
+		t.Error("limit note must not be persisted")
+	}
+}
+
+func TestStreamExecutor_HardLimitIgnoresFurtherToolCalls(t *testing.T) {
+	// The model calls a tool despite the limit instruction: the call is persisted but not run
+	h := newHarness(t, 1, toolRound("call-1"), toolRound("call-2"), toolRound("call-3"))
+
+	events := h.run(t, nil)
+
+	assertBlocks(t, h.store, []string{"0:tool_use", "1:tool_result", "2:tool_use", "3:tool_result", "4:tool_use"})
+	assertStatuses(t, h.store, "streaming", "complete")
+
+	starts := 0
+	for _, event := range events {
+		if event.Type == llmModels.SSEEventToolExecutionStart {
+			starts++
+		}
+	}
+	if starts != 2 {
+		t.Errorf("tool executions = %d, want 2", starts)
+	}
+	final := events[len(events)-1]
+	if final.Type != llmModels.SSEEventTurnComplete || final.Data["stop_reason"] != "tool_use" {
+		t.Errorf("last event = %s %v, want turn_complete with stop_reason tool_use", final.Type, final.Data)
+	}
+}
+
+func TestStreamExecutor_Interruption(t *testing.T) {
+	// The continuation round streams one block, then the stream is cancelled mid-round
+	interrupted := providerRound{
+		events: []domainllm.StreamEvent{textDelta(0, "Partial"), textBlock(0, "Partial")},
+		hang:   true,
+	}
+	h := newHarness(t, 5, toolRound("call-1"), interrupted)
+
+	events := h.run(t, func(event capturedEvent) {
+		if event.label() == "block_stop:2" {
+			h.executor.GetStream().Cancel()
+		}
+	})
+
+	// Everything completed before the cancel survives; the turn is cancelled, not failed
+	assertBlocks(t, h.store, []string{"0:tool_use", "1:tool_result", "2:text"})
+	assertStatuses(t, h.store, "streaming", "cancelled")
+
+	final := events[len(events)-1]
+	if final.Type != llmModels.SSEEventTurnError {
+		t.Fatalf("last event = %s, want turn_error", final.Type)
+	}
+	if msg := fmt.Sprint(final.Data["error"]); !strings.Contains(msg, "streaming interrupted") {
+		t.Errorf("turn_error = %q", msg)
+	}
+	if h.store.errorMsg != nil {
+		t.Errorf("persisted turn error = %q, want none", *h.store.errorMsg)
+	}
+	last := h.store.metadata[len(h.store.metadata)-1]
+	if last.StopReason == nil || *last.StopReason != llmModels.StopReasonInterrupted {
+		t.Errorf("stop_reason = %v, want %q", last.StopReason, llmModels.StopReasonInterrupted)
+	}
+}
+
+func TestStreamExecutor_ProviderErrorMidStream(t *testing.T) {
+	failing := providerRound{events: []domainllm.StreamEvent{
+		textDelta(0, "Start"),
+		textBlock(0, "Start"),
+		{Error: errors.New("upstream overloaded")},
+	}}
+	h := newHarness(t, 5, failing)
+
+	events := h.run(t, nil)
+
+	assertBlocks(t, h.store, []string{"0:text"})
+	assertStatuses(t, h.store, "streaming", "error")
+	assertLabels(t, events, []string{"block_start:0", "block_delta:0", "block_stop:0", "turn_error"})
+	if h.store.errorMsg == nil || *h.store.errorMsg != "upstream overloaded" {
+		t.Errorf("persisted turn error = %v", h.store.errorMsg)
+	}
+}
+
+func TestStreamExecutor_BlockWithoutDeltasIsPersisted(t *testing.T) {
+	// Some adapters emit a complete block without streaming deltas for it first
+	round := providerRound{events: []domainllm.StreamEvent{
+		textBlock(0, "No deltas for this one."),
+		metadataEvent("end_turn"),
+	}}
+	h := newHarness(t, 5, round)
+
+	events := h.run(t, nil)
+
+	assertBlocks(t, h.store, []string{"0:text"})
+	assertLabels(t, events, []string{"block_stop:0", "turn_complete"})
+	assertStatuses(t, h.store, "streaming", "complete")
+}
+
+func TestStreamExecutor_CostSumsRounds(t *testing.T) {
+	h := newHarness(t, 5, toolRound("call-a"), answerRound("Done."))
+	h.executor.SetPricing(&capabilities.ModelCapabilities{
+		PricingTiers: []capabilities.PricingTier{{
+			InputPrice:  map[string]float64{"text": 1},
+			OutputPrice: map[string]float64{"text": 5},
+		}},
+	})
+
+	h.run(t, nil)
+
+	// Each round is 100 input + 20 output tokens: $0.0002 at $1/$5 per million
+	if len(h.store.metadata) != 2 {
+		t.Fatalf("metadata updates = %d, want 2", len(h.store.metadata))
+	}
+	for i, want := range []float64{0.0002, 0.0004} {
+		got := h.store.metadata[i].CostUSD
+		if got == nil || math.Abs(*got-want) > 1e-12 {
+			t.Errorf("round %d cost_usd = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestStreamExecutor_NoPricingLeavesCostUnset(t *testing.T) {
+	h := newHarness(t, 5, answerRound("Done."))
+
+	h.run(t, nil)
+
+	if len(h.store.metadata) != 1 || h.store.metadata[0].CostUSD != nil {
+		t.Errorf("metadata = %+v, want one update without cost", h.store.metadata)
+	}
+}
+
+func TestStreamExecutor_StreamIndexReplaysEndedStream(t *testing.T) {
+	round := providerRound{events: []domainllm.StreamEvent{
+		textDelta(0, "One"), textBlock(0, "One"),
+		textDelta(1, "Two"), textBlock(1, "Two"),
+		metadataEvent("end_turn"),
+	}}
+	h := newHarness(t, 5, round)
+	h.run(t, nil)
+
+	// Live events are numbered 1-7: block 0 ends at 3, block 1 at 6, turn_complete is 7
+	index := h.store.index
+	if index == nil || index.Final == nil {
+		t.Fatalf("stream index = %+v, want one with the final event", index)
+	}
+	if index.BlockEvents(0) != 3 || index.BlockEvents(1) != 6 || index.Final.Event != llmModels.SSEEventTurnComplete || index.Final.Events != 7 {
+		t.Errorf("stream index = %+v (final %+v)", index, index.Final)
+	}
+
+	block0 := []string{"block_start:0", "block_delta:0", "block_stop:0"}
+	block1 := []string{"block_start:1", "block_delta:1", "block_stop:1"}
+	tests := []struct {
+		name        string
+		lastEventID string
+		want        []string
+	}{
+		{"first connection", "", append(append(append([]string{"turn_start"}, block0...), block1...), "turn_complete")},
+		{"non-numeric ID", "abc", append(append(append([]string{"turn_start"}, block0...), block1...), "turn_complete")},
+		{"mid first block", "2", append(append(block0, block1...), "turn_complete")},
+		{"after first block", "3", append(block1, "turn_complete")},
+		{"after last block", "6", []string{"turn_complete"}},
+		{"seen everything", "7", []string{}},
+	}
+
+	catchup := buildCatchupFunc(h.store, llmModels.NewBlockSerializer(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := catchup(testTurnID, tt.lastEventID)
+			if err != nil {
+				t.Fatalf("catchup: %v", err)
+			}
+			captured := make([]capturedEvent, len(events))
+			for i, event := range events {
+				captured[i] = capturedEvent{Type: event.Type}
+				if err := json.Unmarshal(event.Data, &captured[i].Data); err != nil {
+					t.Fatalf("event %s has invalid JSON: %v", event.Type, err)
+				}
+			}
+			assertLabels(t, captured, tt.want)
+		})
+	}
+}
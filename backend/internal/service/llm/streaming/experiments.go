@@ -0,0 +1,75 @@
+package streaming
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+// assignExperiments returns the experiment variants of a new assistant turn. Failures are
+// logged and the turn runs without experiments, so they never block chatting.
+func (s *Service) assignExperiments(ctx context.Context, userID string, chatContext *chatContext, logger *slog.Logger) []llmModels.ExperimentAssignment {
+	if s.experiments == nil || chatContext.isSandbox {
+		return nil
+	}
+
+	assignments, err := s.experiments.AssignTurn(ctx, userID, chatContext.chatID)
+	if err != nil {
+		logger.Warn("failed to assign experiments, continuing without", "error", err)
+		return nil
+	}
+	return assignments
+}
+
+// applyExperimentParams returns the request params with the variants' params as defaults:
+// fields the request sets win, and earlier (older) experiments win over later ones.
+func applyExperimentParams(requestParams *llmModels.RequestParams, assignments []llmModels.ExperimentAssignment) (*llmModels.RequestParams, error) {
+	// Each pass layers the params so far over the next variant's defaults
+	for _, assignment := range assignments {
+		defaults := assignment.Variant.RequestParams
+		if defaults == nil {
+			continue
+		}
+		merged, err := defaults.WithOverrides(requestParams)
+		if err != nil {
+			return nil, fmt.Errorf("apply experiment %s: %w", assignment.ExperimentID, err)
+		}
+		requestParams = merged
+	}
+	return requestParams, nil
+}
+
+// appendExperimentPrompts appends the variants' system prompts to the resolved one
+func appendExperimentPrompts(params *llmModels.RequestParams, assignments []llmModels.ExperimentAssignment) {
+	var prompts []string
+	for _, assignment := range assignments {
+		if prompt := assignment.Variant.SystemPrompt; prompt != nil && *prompt != "" {
+			prompts = append(prompts, *prompt)
+		}
+	}
+	if len(prompts) == 0 {
+		return
+	}
+	if params.System != nil && *params.System != "" {
+		prompts = append([]string{*params.System}, prompts...)
+	}
+	system := strings.Join(prompts, "\n\n")
+	params.System = &system
+}
+
+// recordExperiments stores the variants on the created assistant turn. A failure only
+// leaves the turn out of the experiment results, so it is logged rather than returned.
+func (s *Service) recordExperiments(ctx context.Context, assistantTurnID string, assignments []llmModels.ExperimentAssignment, logger *slog.Logger) {
+	if s.experiments == nil || len(assignments) == 0 {
+		return
+	}
+	if err := s.experiments.RecordTurn(ctx, assistantTurnID, assignments); err != nil {
+		logger.Warn("failed to record experiment variants",
+			"assistant_turn_id", assistantTurnID,
+			"error", err,
+		)
+	}
+}
@@ -0,0 +1,73 @@
+package streaming
+
+import (
+	"testing"
+
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+func TestApplyExperimentParams(t *testing.T) {
+	clientTemperature := 0.3
+	olderTemperature, newerTemperature := 0.9, 0.5
+	olderMaxTokens, newerMaxTokens := 512, 2048
+	stopOnly := []string{"END"}
+
+	assignments := []llmModels.ExperimentAssignment{
+		{ExperimentID: "older", Variant: llmModels.ExperimentVariant{
+			RequestParams: &llmModels.RequestParams{Temperature: &olderTemperature, MaxTokens: &olderMaxTokens},
+		}},
+		{ExperimentID: "no-params"},
+		{ExperimentID: "newer", Variant: llmModels.ExperimentVariant{
+			RequestParams: &llmModels.RequestParams{Temperature: &newerTemperature, MaxTokens: &newerMaxTokens, Stop: stopOnly},
+		}},
+	}
+
+	params, err := applyExperimentParams(&llmModels.RequestParams{Temperature: &clientTemperature}, assignments)
+	if err != nil {
+		t.Fatalf("applyExperimentParams: %v", err)
+	}
+
+	if params.Temperature == nil || *params.Temperature != clientTemperature {
+		t.Errorf("temperature = %v, want the client's %v", params.Temperature, clientTemperature)
+	}
+	if params.MaxTokens == nil || *params.MaxTokens != olderMaxTokens {
+		t.Errorf("max_tokens = %v, want the older experiment's %d", params.MaxTokens, olderMaxTokens)
+	}
+	if len(params.Stop) != 1 || params.Stop[0] != "END" {
+		t.Errorf("stop = %v, want the newer experiment's default", params.Stop)
+	}
+}
+
+func TestAppendExperimentPrompts(t *testing.T) {
+	base, concise, empty := "You are a writing assistant.", "Be concise.", ""
+	combined := base + "\n\n" + concise
+
+	tests := []struct {
+		name    string
+		system  *string
+		prompts []*string
+		want    *string
+	}{
+		{name: "no variant prompts", system: &base, prompts: []*string{nil, &empty}, want: &base},
+		{name: "appended", system: &base, prompts: []*string{&concise}, want: &combined},
+		{name: "no resolved prompt", system: nil, prompts: []*string{&concise}, want: &concise},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var assignments []llmModels.ExperimentAssignment
+			for _, prompt := range tt.prompts {
+				assignments = append(assignments, llmModels.ExperimentAssignment{
+					Variant: llmModels.ExperimentVariant{SystemPrompt: prompt},
+				})
+			}
+			params := &llmModels.RequestParams{System: tt.system}
+
+			appendExperimentPrompts(params, assignments)
+
+			if params.System == nil || *params.System != *tt.want {
+				t.Errorf("system = %v, want %q", params.System, *tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package streaming
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/eventstream"
+)
+
+// interruptWaitTimeout bounds how long InterruptTurn waits for the executor to stop.
+// Cancellation stops the provider stream and tool calls, so this is only reached
+// when a write hangs.
+const interruptWaitTimeout = 10 * time.Second
+
+// interruptPollInterval is how often InterruptTurn checks whether the stream stopped
+const interruptPollInterval = 10 * time.Millisecond
+
+// InterruptTurn cancels a streaming turn. The executor marks the turn cancelled with
+// stop_reason "interrupted", keeping the blocks completed so far (a block cut off
+// mid-stream is never persisted). In discard mode the blocks are then deleted and
+// the stop reason becomes "discarded". Waiting for the executor first guarantees it
+// no longer writes blocks that discard would miss.
+func (s *Service) InterruptTurn(ctx context.Context, req *llmSvc.InterruptTurnRequest) (*llmModels.Turn, error) {
+	if err := req.Mode.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrValidation, err)
+	}
+
+	stream := s.registry.Get(req.TurnID)
+	if stream == nil {
+		return nil, fmt.Errorf("turn %s is not currently streaming: %w", req.TurnID, domain.ErrNotFound)
+	}
+
+	stream.Cancel()
+	if err := waitForStream(ctx, stream, interruptWaitTimeout); err != nil {
+		return nil, fmt.Errorf("interrupt turn %s: %w", req.TurnID, err)
+	}
+
+	// A turn that completed before the cancel landed keeps its content
+	if stream.Status() == eventstream.StatusCancelled && req.Mode == llmModels.InterruptDiscard {
+		if err := s.discardTurnContent(ctx, req.TurnID); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.turnReader.GetTurn(ctx, req.TurnID)
+}
+
+// discardTurnContent deletes an interrupted turn's blocks and records the discard
+func (s *Service) discardTurnContent(ctx context.Context, turnID string) error {
+	return s.txManager.ExecTx(ctx, func(txCtx context.Context) error {
+		if err := s.turnWriter.DeleteTurnBlocks(txCtx, turnID); err != nil {
+			return err
+		}
+		stopReason := llmModels.StopReasonDiscarded
+		return s.turnWriter.UpdateTurnMetadata(txCtx, turnID, &llmModels.TurnMetadataUpdate{StopReason: &stopReason})
+	})
+}
+
+// waitForStream polls until the stream's work function has returned
+func waitForStream(ctx context.Context, stream eventstream.StreamHandle, timeout time.Duration) error {
+	ticker := time.NewTicker(interruptPollInterval)
+	defer ticker.Stop()
+	deadline := time.After(timeout)
+
+	for !stream.Status().Done() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			return fmt.Errorf("stream did not stop within %s", timeout)
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package streaming
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	domainllm "meridian/internal/domain/services/llm"
+	"meridian/internal/eventstream"
+	"meridian/internal/testmocks"
+)
+
+func TestService_InterruptTurn(t *testing.T) {
+	tests := []struct {
+		name           string
+		mode           llmModels.InterruptMode
+		wantBlocks     []string
+		wantStopReason string
+	}{
+		{name: "default keeps blocks", wantBlocks: []string{"0:text"}, wantStopReason: llmModels.StopReasonInterrupted},
+		{name: "keep", mode: llmModels.InterruptKeep, wantBlocks: []string{"0:text"}, wantStopReason: llmModels.StopReasonInterrupted},
+		{name: "discard", mode: llmModels.InterruptDiscard, wantBlocks: []string{}, wantStopReason: llmModels.StopReasonDiscarded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// One block streams, then the provider hangs until the interrupt
+			h := newHarness(t, 5, providerRound{
+				events: []domainllm.StreamEvent{textDelta(0, "Partial"), textBlock(0, "Partial")},
+				hang:   true,
+			})
+			registry := eventstream.NewRegistry(eventstream.RegistryOptions{})
+			if err := registry.Register(h.executor.GetStream()); err != nil {
+				t.Fatalf("Register() error = %v", err)
+			}
+			svc := &Service{registry: registry, turnWriter: h.store, turnReader: h.store, txManager: &testmocks.TxManager{}}
+
+			var turn *llmModels.Turn
+			var err error
+			h.run(t, func(event capturedEvent) {
+				if event.label() == "block_stop:0" {
+					turn, err = svc.InterruptTurn(context.Background(), &domainllm.InterruptTurnRequest{TurnID: testTurnID, Mode: tt.mode})
+				}
+			})
+			if err != nil {
+				t.Fatalf("InterruptTurn() error = %v", err)
+			}
+
+			assertBlocks(t, h.store, tt.wantBlocks)
+			if turn.Status != "cancelled" {
+				t.Errorf("status = %q, want cancelled", turn.Status)
+			}
+			if turn.StopReason == nil || *turn.StopReason != tt.wantStopReason {
+				t.Errorf("stop_reason = %v, want %q", turn.StopReason, tt.wantStopReason)
+			}
+		})
+	}
+}
+
+func TestService_InterruptTurn_Errors(t *testing.T) {
+	svc := &Service{registry: eventstream.NewRegistry(eventstream.RegistryOptions{})}
+
+	_, err := svc.InterruptTurn(context.Background(), &domainllm.InterruptTurnRequest{TurnID: testTurnID, Mode: "pause"})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("unknown mode: error = %v, want ErrValidation", err)
+	}
+
+	_, err = svc.InterruptTurn(context.Background(), &domainllm.InterruptTurnRequest{TurnID: testTurnID})
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("turn not streaming: error = %v, want ErrNotFound", err)
+	}
+}
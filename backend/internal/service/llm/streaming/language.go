@@ -0,0 +1,50 @@
+package streaming
+
+import (
+	"fmt"
+	"strings"
+
+	llmModels "meridian/internal/domain/models/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+// detectTurnLanguage detects the language of a user message's text blocks (nil if
+// undetermined), stored on the user turn
+func detectTurnLanguage(blocks []llmSvc.TurnBlockInput) *string {
+	var texts []string
+	for _, block := range blocks {
+		if block.BlockType == llmModels.BlockTypeText && block.TextContent != nil {
+			texts = append(texts, *block.TextContent)
+		}
+	}
+	if lang := llmModels.DetectLanguage(strings.Join(texts, "\n")); lang != "" {
+		return &lang
+	}
+	return nil
+}
+
+// appendResponseLanguage appends the request's response_language to the resolved system
+// prompt. "auto" uses the user message's detected language and adds nothing when it is
+// undetermined. params must have been validated.
+func appendResponseLanguage(params *llmModels.RequestParams, blocks []llmSvc.TurnBlockInput) {
+	if params.ResponseLanguage == nil {
+		return
+	}
+	lang := *params.ResponseLanguage
+	if lang == llmModels.ResponseLanguageAuto {
+		detected := detectTurnLanguage(blocks)
+		if detected == nil {
+			return
+		}
+		lang = *detected
+	} else if normalized, err := llmModels.NormalizeLanguage(lang); err == nil {
+		lang = normalized
+	}
+
+	instruction := fmt.Sprintf("Respond in %s (%s), whatever language the conversation or the documents you read are in, unless the user explicitly asks for another language.",
+		llmModels.LanguageName(lang), lang)
+	if params.System != nil && *params.System != "" {
+		instruction = *params.System + "\n\n" + instruction
+	}
+	params.System = &instruction
+}
@@ -0,0 +1,59 @@
+package streaming
+
+import (
+	"strings"
+	"testing"
+
+	llmModels "meridian/internal/domain/models/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+func textBlocks(texts ...string) []llmSvc.TurnBlockInput {
+	blocks := make([]llmSvc.TurnBlockInput, len(texts))
+	for i := range texts {
+		blocks[i] = llmSvc.TurnBlockInput{BlockType: llmModels.BlockTypeText, TextContent: &texts[i]}
+	}
+	return blocks
+}
+
+func TestDetectTurnLanguage(t *testing.T) {
+	if lang := detectTurnLanguage(textBlocks("¿Puedes revisar el capítulo dos?", "La escena de la batalla es demasiado larga.")); lang == nil || *lang != "es" {
+		t.Errorf("detectTurnLanguage = %v, want es", lang)
+	}
+	if lang := detectTurnLanguage(textBlocks("ok")); lang != nil {
+		t.Errorf("detectTurnLanguage(short) = %q, want nil", *lang)
+	}
+}
+
+func TestAppendResponseLanguage(t *testing.T) {
+	system := "Project prompt."
+	tag, auto := "pt-br", llmModels.ResponseLanguageAuto
+	tests := []struct {
+		name     string
+		language *string
+		blocks   []llmSvc.TurnBlockInput
+		want     string // Substring of the instruction; "" = system prompt unchanged
+	}{
+		{"not requested", nil, nil, ""},
+		{"explicit tag", &tag, nil, "Respond in Brazilian Portuguese (pt-BR)"},
+		{"auto", &auto, textBlocks("Peux-tu relire le chapitre deux ? La scène est trop longue."), "Respond in French (fr)"},
+		{"auto undetermined", &auto, textBlocks("ok"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompt := system
+			params := &llmModels.RequestParams{System: &prompt, ResponseLanguage: tt.language}
+			appendResponseLanguage(params, tt.blocks)
+
+			if tt.want == "" {
+				if *params.System != system {
+					t.Errorf("system = %q, want it unchanged", *params.System)
+				}
+				return
+			}
+			if !strings.HasPrefix(*params.System, system+"\n\n") || !strings.Contains(*params.System, tt.want) {
+				t.Errorf("system = %q, want %q appended", *params.System, tt.want)
+			}
+		})
+	}
+}
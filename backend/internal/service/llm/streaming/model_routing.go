@@ -0,0 +1,98 @@
+package streaming
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"unicode/utf8"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+// routeAutoModel resolves the "auto" model of a turn: the cheap model for short, simple
+// prompts and the strong one (defaultModel unless configured) for long prompts, tools or
+// thinking. A tier whose model isn't in the user's allowlist falls back to the other tier.
+func (s *Service) routeAutoModel(ctx context.Context, req *llmSvc.CreateTurnRequest, requestParams *llmModels.RequestParams, defaultModel string, logger *slog.Logger) (*llmModels.ModelRouting, error) {
+	signals := llmModels.ModelRoutingSignals{
+		PromptChars:     promptChars(req.TurnBlocks),
+		ToolsRequested:  len(requestParams.Tools) > 0,
+		ThinkingEnabled: requestParams.ThinkingEnabled != nil && *requestParams.ThinkingEnabled,
+	}
+	tier, reasons := llmModels.RouteModelTier(signals, s.config.AutoModelLongPromptChars)
+
+	tierModels := map[string]string{
+		llmModels.ModelTierCheap:  s.config.AutoModelCheap,
+		llmModels.ModelTierStrong: s.config.AutoModelStrong,
+	}
+	if tierModels[llmModels.ModelTierStrong] == "" {
+		tierModels[llmModels.ModelTierStrong] = defaultModel
+	}
+
+	allowed, err := s.allowedModels(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed(tierModels[tier]) {
+		fallback := llmModels.ModelTierCheap
+		if tier == llmModels.ModelTierCheap {
+			fallback = llmModels.ModelTierStrong
+		}
+		if !allowed(tierModels[fallback]) {
+			return nil, fmt.Errorf("%w: no model available for auto routing; choose a model explicitly", domain.ErrValidation)
+		}
+		tier = fallback
+		reasons = append(reasons, llmModels.RoutingReasonTierNotAllowed)
+	}
+
+	routing := &llmModels.ModelRouting{Tier: tier, Model: tierModels[tier], Reasons: reasons}
+	logger.Info("auto model routed",
+		"tier", routing.Tier,
+		"model", routing.Model,
+		"reasons", routing.Reasons,
+		"prompt_chars", signals.PromptChars,
+	)
+	return routing, nil
+}
+
+// allowedModels returns a check against the user's model allowlist (everything is allowed
+// without a resolver or allowlist)
+func (s *Service) allowedModels(ctx context.Context, userID string) (func(model string) bool, error) {
+	if s.modelAllowlist == nil {
+		return func(string) bool { return true }, nil
+	}
+	models, err := s.modelAllowlist.GetAllowedModels(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve model allowlist: %w", err)
+	}
+	return func(model string) bool {
+		return len(models) == 0 || slices.Contains(models, model)
+	}, nil
+}
+
+// promptChars returns the length of the text blocks of a user message
+func promptChars(blocks []llmSvc.TurnBlockInput) int {
+	chars := 0
+	for _, block := range blocks {
+		if block.BlockType == llmModels.BlockTypeText && block.TextContent != nil {
+			chars += utf8.RuneCountInString(*block.TextContent)
+		}
+	}
+	return chars
+}
+
+// turnBlockInputs converts a stored user message back to create-turn input, so a retried
+// or replayed turn is planned (and routed) like the original
+func turnBlockInputs(blocks []llmModels.TurnBlock) []llmSvc.TurnBlockInput {
+	inputs := make([]llmSvc.TurnBlockInput, len(blocks))
+	for i, block := range blocks {
+		inputs[i] = llmSvc.TurnBlockInput{
+			BlockType:   block.BlockType,
+			TextContent: block.TextContent,
+			Content:     block.Content,
+		}
+	}
+	return inputs
+}
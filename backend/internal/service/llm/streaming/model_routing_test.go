@@ -0,0 +1,72 @@
+package streaming
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"meridian/internal/config"
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+func TestRouteAutoModel(t *testing.T) {
+	logger := slog.New(slog.DiscardHandler)
+	cfg := &config.Config{AutoModelCheap: "cheap-model", AutoModelLongPromptChars: 100}
+	thinking := true
+	message := func(text string) *llmSvc.CreateTurnRequest {
+		return &llmSvc.CreateTurnRequest{
+			UserID:     "user-1",
+			TurnBlocks: []llmSvc.TurnBlockInput{{BlockType: llmModels.BlockTypeText, TextContent: &text}},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		req        *llmSvc.CreateTurnRequest
+		params     *llmModels.RequestParams
+		allowlist  string
+		wantModel  string
+		wantReason string
+		wantErr    error
+	}{
+		{name: "short prompt", req: message("Fix this typo"), params: &llmModels.RequestParams{}, wantModel: "cheap-model", wantReason: llmModels.RoutingReasonShortPrompt},
+		{name: "long prompt", req: message(strings.Repeat("a", 100)), params: &llmModels.RequestParams{}, wantModel: "default-model", wantReason: llmModels.RoutingReasonLongPrompt},
+		{name: "thinking", req: message("Hi"), params: &llmModels.RequestParams{ThinkingEnabled: &thinking}, wantModel: "default-model", wantReason: llmModels.RoutingReasonThinkingEnabled},
+		{
+			name:       "strong tier not allowed",
+			req:        message(strings.Repeat("a", 100)),
+			params:     &llmModels.RequestParams{},
+			allowlist:  "cheap-model",
+			wantModel:  "cheap-model",
+			wantReason: llmModels.RoutingReasonTierNotAllowed,
+		},
+		{name: "nothing allowed", req: message("Hi"), params: &llmModels.RequestParams{}, allowlist: "other-model", wantErr: domain.ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{config: cfg, modelAllowlist: llmSvc.NewConfigModelAllowlistResolver(tt.allowlist)}
+
+			routing, err := s.routeAutoModel(context.Background(), tt.req, tt.params, "default-model", logger)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("routeAutoModel: %v", err)
+			}
+			if routing.Model != tt.wantModel {
+				t.Errorf("model = %q, want %q", routing.Model, tt.wantModel)
+			}
+			if last := routing.Reasons[len(routing.Reasons)-1]; last != tt.wantReason {
+				t.Errorf("reasons = %v, want ending in %q", routing.Reasons, tt.wantReason)
+			}
+		})
+	}
+}
@@ -3,22 +3,28 @@ package streaming
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
+	"time"
 
-	mstream "github.com/haowjy/meridian-stream-go"
-
+	"meridian/internal/capabilities"
 	llmModels "meridian/internal/domain/models/llm"
 	llmRepo "meridian/internal/domain/repositories/llm"
 	domainllm "meridian/internal/domain/services/llm"
+	"meridian/internal/errreport"
+	"meridian/internal/eventstream"
+	"meridian/internal/logging"
+	"meridian/internal/service/llm/sanitize"
 	"meridian/internal/service/llm/tools"
 )
 
-// StreamExecutor wraps mstream.Stream and manages LLM streaming for a turn.
-// It adapts the existing TurnExecutor logic to work with mstream's architecture.
+// StreamExecutor runs a turn's event stream and manages LLM streaming for the turn.
+// It adapts the existing TurnExecutor logic to the eventstream work function model.
 // Complete blocks come from the library (already normalized), so no accumulation needed.
 type StreamExecutor struct {
-	stream   *mstream.Stream
+	stream   eventstream.StreamHandle
 	turnID   string
 	model    string
 	turnRepo llmRepo.TurnWriter // Only needs write operations (ISP compliance)
@@ -28,20 +34,34 @@ type StreamExecutor struct {
 
 	// Tool execution support
 	toolRegistry     *tools.ToolRegistry
-	turnNavigator    llmRepo.TurnNavigator     // For loading conversation path during continuation
-	turnReader       llmRepo.TurnReader        // For loading turn blocks during continuation
-	messageBuilder   domainllm.MessageBuilder  // For building messages from conversation history
-	collectedTools   []tools.ToolCall          // tool_use blocks collected during streaming
-	toolIteration    int                       // current tool round (0 = initial, 1+ = continuations)
-	maxToolRounds    int                       // maximum number of tool execution rounds (default: 5)
-	maxBlockSequence int                       // highest block sequence number persisted (for tool_result sequencing)
+	turnNavigator    llmRepo.TurnNavigator    // For loading conversation path during continuation
+	turnReader       llmRepo.TurnReader       // For loading turn blocks during continuation
+	messageBuilder   domainllm.MessageBuilder // For building messages from conversation history
+	guard            *sanitize.Guard          // Prompt-injection guard for tool results (nil = off)
+	collectedTools   []tools.ToolCall         // tool_use blocks collected during streaming
+	toolIteration    int                      // current tool round (0 = initial, 1+ = continuations)
+	maxToolRounds    int                      // maximum number of tool execution rounds (default: 5)
+	maxBlockSequence int                      // highest block sequence number persisted (for tool_result sequencing)
+	continuations    int                      // max_tokens auto-continue rounds used (see auto_continue.go)
+	toolResultLimits ToolResultLimits         // size limits for tool results (see tool_result_budget.go)
+	contextUsed      int                      // input+output tokens of the latest round (context consumed so far)
+	onComplete       func()                   // Called after the turn completes successfully (nil = none)
+	onToolRound      func()                   // Called after each round of tool results (nil = none)
+
+	// Cost tracking (see SetPricing)
+	pricing *capabilities.ModelCapabilities // Model pricing tiers (nil = cost not tracked)
+	costUSD float64                         // Cost of all rounds completed so far
 
 	// JSON delta accumulation (for complete block deltas)
 	// Partial JSON deltas are useless - accumulate and send complete JSON once
-	jsonAccumulator map[int]string // blockIndex -> accumulated JSON
+	// Also enforces per-block and per-turn delta size limits (see accumulator.go)
+	accumulator *deltaAccumulator
+
+	// Event index saved when the stream ends, for catchup after eviction (see stream_index.go)
+	indexer streamIndexer
 }
 
-// NewStreamExecutor creates a new mstream-based executor for a turn.
+// NewStreamExecutor creates a new stream-based executor for a turn.
 // Accepts minimal interfaces for better ISP compliance: TurnWriter for writes, TurnReader for block reads and catchup
 func NewStreamExecutor(
 	turnID string,
@@ -52,10 +72,16 @@ func NewStreamExecutor(
 	provider domainllm.LLMProvider,
 	toolRegistry *tools.ToolRegistry,
 	messageBuilder domainllm.MessageBuilder,
+	guard *sanitize.Guard,
 	logger *slog.Logger,
 	maxToolRounds int,
+	toolResultLimits ToolResultLimits,
+	accumulatorLimits AccumulatorLimits,
 	debugMode bool,
 ) *StreamExecutor {
+	// Every executor log line (and catchup) identifies the turn
+	logger = logger.With(logging.KeyTurnID, turnID)
+
 	se := &StreamExecutor{
 		turnID:         turnID,
 		model:          model,
@@ -66,28 +92,51 @@ func NewStreamExecutor(
 		turnNavigator:  turnNavigator,
 		turnReader:     turnReader,
 		messageBuilder: messageBuilder,
+		guard:          guard,
 		toolIteration:  0,
 		maxToolRounds:  maxToolRounds,
+
+		// Nothing persisted yet: the initial stream starts at sequence 0
+		maxBlockSequence: -1,
+
+		toolResultLimits: toolResultLimits,
+		accumulator:      newDeltaAccumulator(accumulatorLimits),
 	}
 
 	// Create catchup function for database-backed event replay (needs TurnReader)
 	serializer := llmModels.NewBlockSerializer()
 	catchupFunc := buildCatchupFunc(turnReader, serializer, logger)
 
-	// Create mstream.Stream with WorkFunc, catchup support, and optional event IDs (DEBUG mode)
-	stream := mstream.NewStream(
-		turnID,
-		se.workFunc,
-		mstream.WithCatchup(catchupFunc),
-		mstream.WithEventIDs(debugMode), // Enable event IDs only in DEBUG mode
-	)
+	// Create the stream with WorkFunc, catchup support, and optional event IDs (DEBUG mode)
+	stream := eventstream.NewStream(turnID, se.workFunc, eventstream.StreamOptions{
+		Catchup:  catchupFunc,
+		EventIDs: debugMode, // Enable event IDs only in DEBUG mode
+	})
 	se.stream = stream
 
 	return se
 }
 
-// GetStream returns the underlying mstream.Stream
-func (se *StreamExecutor) GetStream() *mstream.Stream {
+// OnComplete registers fn to run once the turn has been marked complete.
+// It runs on the streaming goroutine, so fn should hand off slow work.
+func (se *StreamExecutor) OnComplete(fn func()) {
+	se.onComplete = fn
+}
+
+// OnToolRound registers fn to run after each round of tool calls has executed.
+// It runs on the streaming goroutine, so fn should be quick.
+func (se *StreamExecutor) OnToolRound(fn func()) {
+	se.onToolRound = fn
+}
+
+// SetPricing enables cost tracking with the model's pricing tiers.
+// Each round's cost is added to the turn's cost_usd as the round completes.
+func (se *StreamExecutor) SetPricing(model *capabilities.ModelCapabilities) {
+	se.pricing = model
+}
+
+// GetStream returns the turn's stream
+func (se *StreamExecutor) GetStream() eventstream.StreamHandle {
 	return se.stream
 }
 
@@ -100,14 +149,25 @@ func (se *StreamExecutor) Start(req *domainllm.GenerateRequest) {
 	se.stream.Start()
 }
 
-// workFunc is the mstream WorkFunc that performs the actual streaming
-func (se *StreamExecutor) workFunc(ctx context.Context, send func(mstream.Event)) error {
+// workFunc is the stream WorkFunc that performs the actual streaming
+func (se *StreamExecutor) workFunc(ctx context.Context, send eventstream.EventSink) (err error) {
 	// Use the stored GenerateRequest
 	req := se.req
 	if req == nil {
 		return fmt.Errorf("generate request not set")
 	}
 
+	// Release buffered deltas however the stream ends (complete, error, cancel)
+	defer se.releaseAccumulator()
+
+	// An interrupt isn't a failure (handleError marked the turn cancelled):
+	// returning nil ends the stream "cancelled" rather than "error"
+	defer func() {
+		if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+			err = nil
+		}
+	}()
+
 	// Update turn status to "streaming"
 	// NOTE: Turn stays "streaming" through all continuation rounds.
 	// Only marked "complete" when handleCompletion receives stop_reason != "tool_use"
@@ -134,7 +194,7 @@ func (se *StreamExecutor) workFunc(ctx context.Context, send func(mstream.Event)
 func (se *StreamExecutor) processProviderStream(
 	ctx context.Context,
 	streamChan <-chan domainllm.StreamEvent,
-	send func(mstream.Event),
+	send eventstream.EventSink,
 ) error {
 	// CRITICAL: Track where this stream starts for sequence remapping
 	// Provider always emits block indices starting at 0, but continuation streams
@@ -143,6 +203,9 @@ func (se *StreamExecutor) processProviderStream(
 	// Continuation: maxBlockSequence = 2, streamStartSequence = 3
 	streamStartSequence := se.maxBlockSequence + 1
 
+	// Provider block indices restart at 0: drop leftovers from the previous round
+	se.accumulator.resetRound()
+
 	// Track current block index for delta events (-1 means no block started yet)
 	currentBlockIndex := -1
 
@@ -196,7 +259,7 @@ func (se *StreamExecutor) processProviderStream(
 // - Text/signature deltas are sent immediately (useful for progressive display)
 // - JSON deltas are accumulated (partial JSON is unparseable/useless, send complete JSON later)
 // streamStartSequence is used to remap provider block indices to turn-level sequences
-func (se *StreamExecutor) processDelta(ctx context.Context, send func(mstream.Event), delta *llmModels.TurnBlockDelta, currentBlockIndex *int, streamStartSequence int) error {
+func (se *StreamExecutor) processDelta(ctx context.Context, send eventstream.EventSink, delta *llmModels.TurnBlockDelta, currentBlockIndex *int, streamStartSequence int) error {
 	// Detect new block start
 	if delta.BlockIndex != *currentBlockIndex {
 		// CRITICAL: Remap provider block index to turn-level sequence for SSE event
@@ -213,14 +276,20 @@ func (se *StreamExecutor) processDelta(ctx context.Context, send func(mstream.Ev
 	}
 
 	// Accumulate JSON deltas instead of sending (partial JSON is useless)
-	// NOTE: Use provider's block index as map key (not remapped sequence)
+	// NOTE: Use provider's block index as key (not remapped sequence)
 	if delta.JSONDelta != nil && *delta.JSONDelta != "" {
-		if se.jsonAccumulator == nil {
-			se.jsonAccumulator = make(map[int]string)
-		}
-		se.jsonAccumulator[delta.BlockIndex] += *delta.JSONDelta
 		// Don't send - partial JSON is unparseable
-		return nil
+		return se.accumulator.addJSON(delta.BlockIndex, streamStartSequence+delta.BlockIndex, *delta.JSONDelta)
+	}
+
+	// Text is forwarded, but the library accumulates it for the complete block: count it
+	for _, text := range []*string{delta.TextDelta, delta.SignatureDelta} {
+		if text == nil {
+			continue
+		}
+		if err := se.accumulator.addText(delta.BlockIndex, streamStartSequence+delta.BlockIndex, *text); err != nil {
+			return err
+		}
 	}
 
 	// Send text/signature deltas immediately (useful incrementally)
@@ -242,12 +311,12 @@ func (se *StreamExecutor) processDelta(ctx context.Context, send func(mstream.Ev
 // processCompleteBlock handles a complete, normalized block from the library.
 // The library has already normalized provider-specific types (web_search_tool_result → tool_result).
 // streamStartSequence is used to remap provider block indices to turn-level sequences
-func (se *StreamExecutor) processCompleteBlock(ctx context.Context, send func(mstream.Event), block *llmModels.TurnBlock, streamStartSequence int) error {
+func (se *StreamExecutor) processCompleteBlock(ctx context.Context, send eventstream.EventSink, block *llmModels.TurnBlock, streamStartSequence int) error {
 	// Set turn ID
 	block.TurnID = se.turnID
 
 	// CRITICAL: Save provider's original block index before remapping
-	// We need this to access jsonAccumulator (which uses provider indices as keys)
+	// We need this to access the accumulator (which uses provider indices as keys)
 	providerBlockIndex := block.Sequence
 
 	// CRITICAL FIX: Remap provider block index to turn-level sequence
@@ -273,16 +342,26 @@ func (se *StreamExecutor) processCompleteBlock(ctx context.Context, send func(ms
 	// Even if context is cancelled (e.g., client disconnect, server shutdown),
 	// we want to persist LLM responses to avoid losing data. This ensures
 	// graceful shutdown and allows users to retrieve responses later via catchup.
-	if err := se.stream.PersistAndClear(func(events []mstream.Event) error {
+	persisted := false
+	if err := se.stream.PersistAndClear(func(events []eventstream.Event) error {
 		// Persist the block to database
 		if err := se.turnRepo.CreateTurnBlock(ctx, block); err != nil {
 			return fmt.Errorf("create turn block: %w", err)
 		}
+		persisted = true
 		return nil
 	}); err != nil {
 		return fmt.Errorf("failed to persist block %d: %w", block.Sequence, err)
 	}
 
+	// PersistAndClear skips persistFn when the event buffer is empty (a block that arrived
+	// without preceding deltas) - the block must still be saved
+	if !persisted {
+		if err := se.turnRepo.CreateTurnBlock(ctx, block); err != nil {
+			return fmt.Errorf("failed to persist block %d: create turn block: %w", block.Sequence, err)
+		}
+	}
+
 	// Track max sequence for tool_result block sequencing
 	if block.Sequence > se.maxBlockSequence {
 		se.maxBlockSequence = block.Sequence
@@ -290,14 +369,13 @@ func (se *StreamExecutor) processCompleteBlock(ctx context.Context, send func(ms
 
 	// Send accumulated JSON as complete delta (if any)
 	// This provides complete, parseable JSON instead of useless partial fragments
-	// NOTE: Use provider's original block index to access jsonAccumulator
-	if accumulatedJSON, exists := se.jsonAccumulator[providerBlockIndex]; exists {
+	// NOTE: Use provider's original block index to access the accumulator (takeJSON releases it)
+	if accumulatedJSON, exists := se.accumulator.takeJSON(providerBlockIndex); exists {
 		se.sendEvent(send, llmModels.SSEEventBlockDelta, llmModels.BlockDeltaEvent{
 			BlockIndex: block.Sequence, // Use remapped sequence for SSE
 			DeltaType:  llmModels.DeltaTypeJSON,
 			JSONDelta:  &accumulatedJSON,
 		})
-		delete(se.jsonAccumulator, providerBlockIndex) // Cleanup using provider index
 	}
 
 	// Send block_stop event to SSE clients
@@ -308,14 +386,13 @@ func (se *StreamExecutor) processCompleteBlock(ctx context.Context, send func(ms
 	se.logger.Debug("persisted complete block",
 		"block_index", block.Sequence,
 		"block_type", block.BlockType,
-		"turn_id", se.turnID,
 	)
 
 	return nil
 }
 
 // handleCompletion handles successful stream completion
-func (se *StreamExecutor) handleCompletion(ctx context.Context, send func(mstream.Event), metadata *domainllm.StreamMetadata) error {
+func (se *StreamExecutor) handleCompletion(ctx context.Context, send eventstream.EventSink, metadata *domainllm.StreamMetadata) error {
 	// No need to finalize accumulator - complete blocks are received directly from library
 	// and persisted in processCompleteBlock()
 
@@ -325,12 +402,25 @@ func (se *StreamExecutor) handleCompletion(ctx context.Context, send func(mstrea
 		metadata.Model = se.model
 	}
 
+	// Every round is a billed provider request: the turn's cost is the sum of its rounds
+	var cost *float64
+	if se.pricing != nil {
+		if roundCost, ok := se.pricing.Cost(metadata.InputTokens, metadata.OutputTokens); ok {
+			se.costUSD += roundCost
+			total := se.costUSD
+			cost = &total
+		}
+	}
+
 	// Update turn with metadata
-	if err := se.updateTurnMetadata(ctx, metadata); err != nil {
+	if err := se.updateTurnMetadata(ctx, metadata, cost); err != nil {
 		se.handleError(ctx, send, fmt.Errorf("failed to update turn metadata: %w", err))
 		return err
 	}
 
+	// The next round resends everything this round saw and produced
+	se.contextUsed = metadata.InputTokens + metadata.OutputTokens
+
 	// Check if we have collected tools to execute
 	if len(se.collectedTools) > 0 && se.toolRegistry != nil {
 		// Check hard limit to prevent infinite loops
@@ -353,20 +443,54 @@ func (se *StreamExecutor) handleCompletion(ctx context.Context, send func(mstrea
 		}
 	}
 
+	// Response cut off by max_tokens: continue in the same turn if the request opted in
+	if metadata.StopReason == stopReasonMaxTokens && se.canAutoContinue() {
+		return se.continueAfterMaxTokens(ctx, send, metadata)
+	}
+
 	// No tools to execute (or stop_reason != "tool_use"), complete the turn
 	return se.completeTurn(ctx, send, metadata.StopReason, metadata)
 }
 
+// releaseAccumulator frees buffered deltas when the stream ends and logs the size stats
+func (se *StreamExecutor) releaseAccumulator() {
+	stats := se.accumulator.stats
+	se.accumulator.resetRound()
+
+	logFn := se.logger.Debug
+	if stats.Rejections > 0 {
+		logFn = se.logger.Warn
+	}
+	logFn("stream accumulator stats",
+		"turn_bytes", stats.TurnBytes,
+		"peak_block_bytes", stats.PeakBlockBytes,
+		"peak_held_bytes", stats.PeakHeldBytes,
+		"rejections", stats.Rejections,
+	)
+}
+
 // handleError handles streaming errors
-func (se *StreamExecutor) handleError(ctx context.Context, send func(mstream.Event), err error) {
+func (se *StreamExecutor) handleError(ctx context.Context, send eventstream.EventSink, err error) {
 	// No need to finalize accumulator - complete blocks are already persisted
 	// Partial blocks are not persisted (streaming stopped mid-block)
 
+	// Cancel() means the user stopped the turn (whatever failed as a result)
+	if errors.Is(ctx.Err(), context.Canceled) {
+		se.handleInterrupt(ctx, send)
+		return
+	}
+
 	// Update turn status in database
 	if updateErr := se.turnRepo.UpdateTurnError(ctx, se.turnID, err.Error()); updateErr != nil {
 		se.logger.Error("failed to update turn error", "error", updateErr)
 	}
 
+	// Report provider/persistence failures (cancellation is ignored by errreport)
+	errreport.CaptureError(logging.WithTurnID(ctx, se.turnID), err,
+		"provider", se.provider.Name(),
+		"model", se.model,
+	)
+
 	// Send turn_error event
 	errorMsg := err.Error()
 	if errorMsg == "" {
@@ -380,127 +504,130 @@ func (se *StreamExecutor) handleError(ctx context.Context, send func(mstream.Eve
 	})
 }
 
-// sendEvent sends an event via mstream.
-// Event IDs are automatically generated by the library when DEBUG mode is enabled.
-func (se *StreamExecutor) sendEvent(send func(mstream.Event), eventType string, data interface{}) {
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		se.logger.Error("failed to marshal event data", "error", err, "event_type", eventType)
-		return
-	}
+// handleInterrupt marks a turn stopped by the user as cancelled, keeping the blocks
+// completed so far. The stream context is already cancelled, so the writes use one
+// that isn't. InterruptTurn may then discard the blocks.
+func (se *StreamExecutor) handleInterrupt(ctx context.Context, send eventstream.EventSink) {
+	ctx = context.WithoutCancel(ctx)
 
-	// Create event with type - library will add event ID if DEBUG mode enabled
-	event := mstream.NewEvent(jsonData).WithType(eventType)
-	send(event)
-}
+	now := time.Now()
+	if err := se.turnRepo.UpdateTurnStatus(ctx, se.turnID, "cancelled", &llmModels.Turn{CompletedAt: &now}); err != nil {
+		se.logger.Error("failed to mark turn cancelled", "error", err)
+	}
+	stopReason := llmModels.StopReasonInterrupted
+	if err := se.turnRepo.UpdateTurnMetadata(ctx, se.turnID, &llmModels.TurnMetadataUpdate{StopReason: &stopReason}); err != nil {
+		se.logger.Error("failed to record interrupt stop reason", "error", err)
+	}
 
-// updateTurnMetadata updates the turn with final metadata
-func (se *StreamExecutor) updateTurnMetadata(ctx context.Context, metadata *domainllm.StreamMetadata) error {
-	return se.turnRepo.UpdateTurnMetadata(ctx, se.turnID, map[string]interface{}{
-		"model":             metadata.Model,
-		"input_tokens":      metadata.InputTokens,
-		"output_tokens":     metadata.OutputTokens,
-		"stop_reason":       metadata.StopReason,
-		"response_metadata": metadata.ResponseMetadata,
+	se.sendEvent(send, llmModels.SSEEventTurnError, llmModels.TurnErrorEvent{
+		TurnID: se.turnID,
+		Error:  "streaming interrupted",
 	})
 }
 
-// collectToolUse extracts tool use information from a tool_use block and adds it to the collection.
-func (se *StreamExecutor) collectToolUse(block *llmModels.TurnBlock) {
-	// Extract tool use info from block.Content
-	// Expected format: {"tool_use_id": "...", "tool_name": "...", "input": {...}}
-	if block.Content == nil {
-		se.logger.Warn("tool_use block has no content",
-			"sequence", block.Sequence,
-			"block_type", block.BlockType)
+// sendEvent sends an event to the stream.
+// Event IDs are automatically generated by the stream when DEBUG mode is enabled.
+func (se *StreamExecutor) sendEvent(send eventstream.EventSink, eventType string, data interface{}) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		se.logger.Error("failed to marshal event data", "error", err, "event_type", eventType)
 		return
 	}
 
-	// Helper to get map keys for debugging
-	getKeys := func(m map[string]interface{}) []string {
-		keys := make([]string, 0, len(m))
-		for k := range m {
-			keys = append(keys, k)
-		}
-		return keys
-	}
+	// The stream adds an event ID if DEBUG mode is enabled
+	send.Send(eventstream.NewEvent(eventType, jsonData))
 
-	// Extract tool_use_id (string)
-	toolUseID, ok := block.Content["tool_use_id"].(string)
-	if !ok {
-		// Try fallback: fmt.Sprintf
-		if val, exists := block.Content["tool_use_id"]; exists {
-			toolUseID = fmt.Sprintf("%v", val)
-		} else {
-			se.logger.Warn("tool_use block missing tool_use_id",
-				"sequence", block.Sequence,
-				"available_keys", getKeys(block.Content))
-			return
+	// The final event saves the stream index (the stream may already be cancelled)
+	if index := se.indexer.record(eventType, jsonData); index != nil {
+		if err := se.turnRepo.SaveStreamIndex(context.Background(), se.turnID, index); err != nil {
+			se.logger.Error("failed to save stream index", "error", err)
 		}
 	}
+}
 
-	// Extract tool_name (string)
-	toolName, ok := block.Content["tool_name"].(string)
-	if !ok {
-		// Try fallback: fmt.Sprintf
-		if val, exists := block.Content["tool_name"]; exists {
-			toolName = fmt.Sprintf("%v", val)
-		} else {
-			se.logger.Warn("tool_use block missing tool_name",
-				"sequence", block.Sequence,
-				"available_keys", getKeys(block.Content))
-			return
-		}
+// updateTurnMetadata updates the turn with final metadata
+// (costUSD nil = keep the stored cost)
+func (se *StreamExecutor) updateTurnMetadata(ctx context.Context, metadata *domainllm.StreamMetadata, costUSD *float64) error {
+	update := &llmModels.TurnMetadataUpdate{
+		InputTokens:      &metadata.InputTokens,
+		OutputTokens:     &metadata.OutputTokens,
+		CostUSD:          costUSD,
+		ResponseMetadata: metadata.ResponseMetadata, // nil keeps previous round's metadata
 	}
-
-	// Extract input (map[string]interface{})
-	var toolInput map[string]interface{}
-	inputRaw, exists := block.Content["input"]
-	if !exists {
-		se.logger.Warn("tool_use block missing input field",
-			"sequence", block.Sequence,
-			"available_keys", getKeys(block.Content))
-		return
+	// Empty strings mean the provider didn't send a value - keep what's stored
+	if metadata.Model != "" {
+		update.Model = &metadata.Model
 	}
-
-	// Try direct type assertion first (fast path)
-	toolInput, ok = inputRaw.(map[string]interface{})
-	if !ok {
-		// Fallback: marshal to JSON and unmarshal to target type
-		// This handles cases where the type is correct but wrapped in interface{}
-		inputJSON, err := json.Marshal(inputRaw)
-		if err != nil {
-			se.logger.Warn("tool_use block input cannot be marshaled",
-				"sequence", block.Sequence,
-				"input_type", fmt.Sprintf("%T", inputRaw),
-				"error", err)
-			return
-		}
-
-		if err := json.Unmarshal(inputJSON, &toolInput); err != nil {
-			se.logger.Warn("tool_use block input cannot be unmarshaled",
-				"sequence", block.Sequence,
-				"input_json", string(inputJSON),
-				"error", err)
-			return
-		}
+	if metadata.StopReason != "" {
+		update.StopReason = &metadata.StopReason
 	}
+	return se.turnRepo.UpdateTurnMetadata(ctx, se.turnID, update)
+}
 
-	// Add to collected tools
-	toolCall := tools.ToolCall{
-		ID:    toolUseID,
-		Name:  toolName,
-		Input: toolInput,
+// collectToolUse extracts tool use information from a tool_use block and adds it to the collection.
+func (se *StreamExecutor) collectToolUse(block *llmModels.TurnBlock) {
+	var toolUse llmModels.ToolUseContent
+	if err := llmModels.DecodeContent(block, &toolUse); err != nil {
+		se.logger.Warn("skipping invalid tool_use block",
+			"sequence", block.Sequence,
+			"error", err)
+		return
 	}
 
-	se.collectedTools = append(se.collectedTools, toolCall)
+	se.collectedTools = append(se.collectedTools, tools.ToolCall{
+		ID:    toolUse.ToolUseID,
+		Name:  toolUse.ToolName,
+		Input: toolUse.Input,
+	})
 }
 
 // executeToolsAndContinue executes the collected tools in parallel, persists the results,
 // and continues streaming with the tool results.
-func (se *StreamExecutor) executeToolsAndContinue(ctx context.Context, send func(mstream.Event)) error {
-	// Execute all collected tools in parallel
-	toolResults := se.toolRegistry.ExecuteParallel(ctx, se.collectedTools)
+func (se *StreamExecutor) executeToolsAndContinue(ctx context.Context, send eventstream.EventSink) error {
+	// Execute all collected tools in parallel. The client is told which tools start, then
+	// their progress and completion as they happen (sent from the tool goroutines).
+	var sendMu sync.Mutex
+	sendLocked := func(eventType string, data interface{}) {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		se.sendEvent(send, eventType, data)
+	}
+	toolResults := se.toolRegistry.ExecuteParallelWithCallbacks(ctx, se.collectedTools, tools.ExecutionCallbacks{
+		OnStart: func(call tools.ToolCall) {
+			sendLocked(llmModels.SSEEventToolExecutionStart, llmModels.ToolExecutionStartEvent{
+				TurnID:    se.turnID,
+				ToolUseID: call.ID,
+				ToolName:  call.Name,
+				Iteration: se.toolIteration,
+			})
+		},
+		OnProgress: func(call tools.ToolCall, progress tools.ToolProgress) {
+			sendLocked(llmModels.SSEEventToolExecutionProgress, llmModels.ToolExecutionProgressEvent{
+				TurnID:    se.turnID,
+				ToolUseID: call.ID,
+				ToolName:  call.Name,
+				Iteration: se.toolIteration,
+				Stage:     progress.Stage,
+				Message:   progress.Message,
+			})
+		},
+		OnComplete: func(result tools.ToolResult) {
+			event := llmModels.ToolExecutionCompleteEvent{
+				TurnID:     se.turnID,
+				ToolUseID:  result.ID,
+				ToolName:   result.Name,
+				Iteration:  se.toolIteration,
+				DurationMs: result.Duration.Milliseconds(),
+				Attempts:   result.Attempts,
+				IsError:    result.IsError,
+			}
+			if result.IsError && result.Error != nil {
+				message := result.Error.Error()
+				event.Error = &message
+			}
+			sendLocked(llmModels.SSEEventToolExecutionComplete, event)
+		},
+	})
 
 	se.logger.Info("tool execution completed",
 		"tool_count", len(toolResults),
@@ -512,83 +639,33 @@ func (se *StreamExecutor) executeToolsAndContinue(ctx context.Context, send func
 	// Start sequencing after the last block persisted during streaming
 	nextSequence := se.maxBlockSequence + 1
 
-	for i, toolResult := range toolResults {
-		resultBlock := &llmModels.TurnBlock{
-			TurnID:    se.turnID,
-			BlockType: llmModels.BlockTypeToolResult,
-			Sequence:  nextSequence + i,
-			Content: map[string]interface{}{
-				"tool_use_id": toolResult.ID,
-				"tool_name":   toolResult.Name,
-				"is_error":    toolResult.IsError,
-			},
-		}
-
-		// Add result or error to content
-		if toolResult.IsError {
-			resultBlock.Content["error"] = toolResult.Error.Error()
-		} else {
-			resultBlock.Content["result"] = toolResult.Result
-		}
+	// Oversized results are shortened to fit the model's remaining context
+	budget := se.toolResultBudget(len(toolResults))
 
-		// Persist the tool_result block
-		if err := se.turnRepo.CreateTurnBlock(ctx, resultBlock); err != nil {
-			se.logger.Error("failed to persist tool result block",
-				"error", err,
-				"tool_use_id", toolResult.ID,
-			)
-			// Update turn status to error before returning
-			if updateErr := se.turnRepo.UpdateTurnError(ctx, se.turnID, err.Error()); updateErr != nil {
-				se.logger.Error("failed to update turn error status", "error", updateErr)
-			}
-			return fmt.Errorf("failed to persist tool result: %w", err)
+	// Tools that produced an image return it alongside their result
+	var images []llmModels.ImageContent
+	for i, toolResult := range toolResults {
+		if imageResult, ok := toolResult.Result.(*tools.ImageResult); ok {
+			toolResult.Result = imageResult.Summary
+			images = append(images, imageResult.Image)
 		}
-
-		// Track max sequence for potential future tool rounds
-		if resultBlock.Sequence > se.maxBlockSequence {
-			se.maxBlockSequence = resultBlock.Sequence
+		if err := se.persistAndStreamToolResult(ctx, send, toolResult, nextSequence+i, budget); err != nil {
+			return err
 		}
+	}
 
-		// Stream the tool_result block to frontend via SSE
-		// Send block_start
-		blockType := resultBlock.BlockType
-		se.sendEvent(send, llmModels.SSEEventBlockStart, llmModels.BlockStartEvent{
-			BlockIndex: resultBlock.Sequence,
-			BlockType:  &blockType,
-		})
-
-		// Send block_delta with full JSON content
-		contentJSON, err := json.Marshal(resultBlock.Content)
-		if err != nil {
-			se.logger.Error("failed to marshal tool result content for delta",
-				"error", err,
-				"tool_use_id", toolResult.ID,
-			)
-		} else {
-			contentStr := string(contentJSON)
-			se.sendEvent(send, llmModels.SSEEventBlockDelta, llmModels.BlockDeltaEvent{
-				BlockIndex:     resultBlock.Sequence,
-				DeltaType:      llmModels.DeltaTypeJSON,
-				TextDelta:      nil,
-				SignatureDelta: nil,
-				JSONDelta:      &contentStr,
-			})
+	// Images follow the tool results as image blocks (shown to the user, not replayed to the model)
+	for _, image := range images {
+		if err := se.persistAndStreamImage(ctx, send, image, se.maxBlockSequence+1); err != nil {
+			return err
 		}
-
-		// Send block_stop
-		se.sendEvent(send, llmModels.SSEEventBlockStop, llmModels.BlockStopEvent{
-			BlockIndex: resultBlock.Sequence,
-		})
-
-		se.logger.Debug("persisted and streamed tool result",
-			"tool_use_id", toolResult.ID,
-			"is_error", toolResult.IsError,
-			"sequence", resultBlock.Sequence,
-		)
 	}
 
 	// 4. Check iteration limit with tiered approach
 	se.toolIteration++
+	if se.onToolRound != nil {
+		se.onToolRound()
+	}
 
 	softLimit := se.maxToolRounds
 	hardLimit := se.maxToolRounds * 2
@@ -604,24 +681,15 @@ func (se *StreamExecutor) executeToolsAndContinue(ctx context.Context, send func
 	}
 
 	// 5. Load conversation history with tool results (using TurnNavigator + TurnReader)
-	path, err := se.turnNavigator.GetTurnPath(ctx, se.turnID)
+	path, err := se.loadConversationPath(ctx)
 	if err != nil {
-		se.handleError(ctx, send, fmt.Errorf("failed to load turn path for continuation: %w", err))
-		return fmt.Errorf("failed to load turn path for continuation: %w", err)
-	}
-
-	// Load blocks for all turns in the path
-	for i := range path {
-		blocks, err := se.turnReader.GetTurnBlocks(ctx, path[i].ID)
-		if err != nil {
-			se.handleError(ctx, send, fmt.Errorf("failed to load blocks for turn %s: %w", path[i].ID, err))
-			return fmt.Errorf("failed to load blocks for turn %s: %w", path[i].ID, err)
-		}
-		path[i].Blocks = blocks
+		err = fmt.Errorf("failed to load conversation for continuation: %w", err)
+		se.handleError(ctx, send, err)
+		return err
 	}
 
 	// 6. Build messages using MessageBuilder (pure conversion)
-	messages, err := se.messageBuilder.BuildMessages(ctx, path)
+	messages, err := se.messageBuilder.BuildMessages(ctx, path, se.messageTarget())
 	if err != nil {
 		se.handleError(ctx, send, fmt.Errorf("failed to build continuation messages: %w", err))
 		return fmt.Errorf("failed to build continuation messages: %w", err)
@@ -669,9 +737,9 @@ func (se *StreamExecutor) executeToolsAndContinue(ctx context.Context, send func
 	// 8. Call provider for continuation stream
 	// NOTE: Use ctx from workFunc (NOT context.Background())
 	// - The background goroutine already uses context.Background() (see service.go:304)
-	// - This ctx comes from mstream, which manages stream lifecycle
+	// - This ctx comes from the stream, which manages its lifecycle
 	// - Browser disconnection doesn't cancel this ctx (goroutine-level protection)
-	// - Using mstream's ctx prevents goroutine leaks and respects cancellation
+	// - Using the stream's ctx prevents goroutine leaks and respects cancellation
 	contStreamChan, err := se.provider.StreamResponse(ctx, contReq)
 	if err != nil {
 		se.handleError(ctx, send, fmt.Errorf("continuation stream failed: %w", err))
@@ -691,35 +759,181 @@ func (se *StreamExecutor) executeToolsAndContinue(ctx context.Context, send func
 	return se.processProviderStream(ctx, contStreamChan, send)
 }
 
+// persistAndStreamToolResult persists one tool result as a tool_result block at the given
+// sequence and streams it to the client (block_start, one JSON delta, block_stop).
+// Results larger than budgetTokens are truncated first (0 = no limit).
+func (se *StreamExecutor) persistAndStreamToolResult(ctx context.Context, send eventstream.EventSink, toolResult tools.ToolResult, sequence int, budgetTokens int) error {
+	content := llmModels.ToolResultContent{
+		ToolUseID: toolResult.ID,
+		ToolName:  toolResult.Name,
+		IsError:   toolResult.IsError,
+		Attempts:  toolResult.Attempts,
+	}
+
+	// Add result or error to content
+	// Results are size-checked, then pass through the injection guard; the block records
+	// what was truncated or stripped
+	if toolResult.IsError {
+		content.Error = toolResult.Error.Error()
+	} else {
+		result, truncation := truncateToolResult(toolResult.Result, budgetTokens)
+		if truncation != nil {
+			content.Truncated = true
+			content.Truncation = &llmModels.ToolResultTruncation{
+				OriginalTokens: truncation.OriginalTokens,
+				BudgetTokens:   truncation.BudgetTokens,
+			}
+			se.logger.Info("truncated oversized tool result",
+				"tool_use_id", toolResult.ID,
+				"tool_name", toolResult.Name,
+				"original_tokens", truncation.OriginalTokens,
+				"budget_tokens", truncation.BudgetTokens,
+				"context_used", se.contextUsed,
+			)
+		}
+
+		result, report := se.guard.Sanitize(result)
+		content.Result = result
+		if report.Sanitized() {
+			content.Sanitized = true
+			content.Sanitization = &llmModels.ToolResultSanitization{
+				Level:   string(report.Level),
+				Matches: report.Matches,
+			}
+			se.logger.Warn("stripped prompt injection patterns from tool result",
+				"tool_use_id", toolResult.ID,
+				"tool_name", toolResult.Name,
+				"matches", report.Matches,
+			)
+		}
+	}
+
+	encoded, err := llmModels.EncodeContent(&content)
+	if err != nil {
+		return fmt.Errorf("encode tool result %s: %w", toolResult.ID, err)
+	}
+	resultBlock := &llmModels.TurnBlock{
+		TurnID:    se.turnID,
+		BlockType: llmModels.BlockTypeToolResult,
+		Sequence:  sequence,
+		Content:   encoded,
+	}
+
+	// Persist the tool_result block
+	if err := se.turnRepo.CreateTurnBlock(ctx, resultBlock); err != nil {
+		se.logger.Error("failed to persist tool result block",
+			"error", err,
+			"tool_use_id", toolResult.ID,
+		)
+		// Update turn status to error before returning
+		if updateErr := se.turnRepo.UpdateTurnError(ctx, se.turnID, err.Error()); updateErr != nil {
+			se.logger.Error("failed to update turn error status", "error", updateErr)
+		}
+		return fmt.Errorf("failed to persist tool result: %w", err)
+	}
+
+	// Track max sequence for potential future tool rounds
+	if resultBlock.Sequence > se.maxBlockSequence {
+		se.maxBlockSequence = resultBlock.Sequence
+	}
+
+	// Stream the tool_result block to frontend via SSE
+	se.streamJSONBlock(send, resultBlock)
+
+	se.logger.Debug("persisted and streamed tool result",
+		"tool_use_id", toolResult.ID,
+		"is_error", toolResult.IsError,
+		"sequence", resultBlock.Sequence,
+	)
+
+	return nil
+}
+
+// persistAndStreamImage persists a generated image as an image block at the given sequence
+// and streams it to the client like a tool result.
+func (se *StreamExecutor) persistAndStreamImage(ctx context.Context, send eventstream.EventSink, image llmModels.ImageContent, sequence int) error {
+	encoded, err := llmModels.EncodeContent(&image)
+	if err != nil {
+		return fmt.Errorf("encode image block: %w", err)
+	}
+	imageBlock := &llmModels.TurnBlock{
+		TurnID:    se.turnID,
+		BlockType: llmModels.BlockTypeImage,
+		Sequence:  sequence,
+		Content:   encoded,
+	}
+
+	if err := se.turnRepo.CreateTurnBlock(ctx, imageBlock); err != nil {
+		se.logger.Error("failed to persist image block",
+			"error", err,
+			"storage_key", image.StorageKey,
+		)
+		if updateErr := se.turnRepo.UpdateTurnError(ctx, se.turnID, err.Error()); updateErr != nil {
+			se.logger.Error("failed to update turn error status", "error", updateErr)
+		}
+		return fmt.Errorf("failed to persist image block: %w", err)
+	}
+
+	if imageBlock.Sequence > se.maxBlockSequence {
+		se.maxBlockSequence = imageBlock.Sequence
+	}
+
+	se.streamJSONBlock(send, imageBlock)
+	return nil
+}
+
+// streamJSONBlock streams a persisted block to the client: block_start, one JSON delta
+// with the full content, block_stop
+func (se *StreamExecutor) streamJSONBlock(send eventstream.EventSink, block *llmModels.TurnBlock) {
+	blockType := block.BlockType
+	se.sendEvent(send, llmModels.SSEEventBlockStart, llmModels.BlockStartEvent{
+		BlockIndex: block.Sequence,
+		BlockType:  &blockType,
+	})
+
+	contentJSON, err := json.Marshal(block.Content)
+	if err != nil {
+		se.logger.Error("failed to marshal block content for delta",
+			"error", err,
+			"block_type", block.BlockType,
+			"sequence", block.Sequence,
+		)
+	} else {
+		contentStr := string(contentJSON)
+		se.sendEvent(send, llmModels.SSEEventBlockDelta, llmModels.BlockDeltaEvent{
+			BlockIndex:     block.Sequence,
+			DeltaType:      llmModels.DeltaTypeJSON,
+			TextDelta:      nil,
+			SignatureDelta: nil,
+			JSONDelta:      &contentStr,
+		})
+	}
+
+	se.sendEvent(send, llmModels.SSEEventBlockStop, llmModels.BlockStopEvent{
+		BlockIndex: block.Sequence,
+	})
+}
+
 // executeToolsAndContinueWithLimit is called when tool round limit is reached.
 // It loads conversation history (including tool results just persisted), injects
 // a limit note into the last tool_result, and streams one final LLM response.
 // This allows graceful completion where the LLM synthesizes findings instead of abrupt cutoff.
-func (se *StreamExecutor) executeToolsAndContinueWithLimit(ctx context.Context, send func(mstream.Event)) error {
+func (se *StreamExecutor) executeToolsAndContinueWithLimit(ctx context.Context, send eventstream.EventSink) error {
 	se.logger.Info("graceful completion: injecting limit note for final LLM response",
 		"iteration", se.toolIteration,
 		"max_rounds", se.maxToolRounds,
 	)
 
 	// 1. Load conversation history with tool results (using TurnNavigator + TurnReader)
-	path, err := se.turnNavigator.GetTurnPath(ctx, se.turnID)
+	path, err := se.loadConversationPath(ctx)
 	if err != nil {
-		se.handleError(ctx, send, fmt.Errorf("failed to load turn path for graceful completion: %w", err))
-		return fmt.Errorf("failed to load turn path for graceful completion: %w", err)
-	}
-
-	// Load blocks for all turns in the path
-	for i := range path {
-		blocks, err := se.turnReader.GetTurnBlocks(ctx, path[i].ID)
-		if err != nil {
-			se.handleError(ctx, send, fmt.Errorf("failed to load blocks for turn %s: %w", path[i].ID, err))
-			return fmt.Errorf("failed to load blocks for turn %s: %w", path[i].ID, err)
-		}
-		path[i].Blocks = blocks
+		err = fmt.Errorf("failed to load conversation for graceful completion: %w", err)
+		se.handleError(ctx, send, err)
+		return err
 	}
 
 	// 2. Build messages using MessageBuilder (pure conversion)
-	messages, err := se.messageBuilder.BuildMessages(ctx, path)
+	messages, err := se.messageBuilder.BuildMessages(ctx, path, se.messageTarget())
 	if err != nil {
 		se.handleError(ctx, send, fmt.Errorf("failed to build messages for graceful completion: %w", err))
 		return fmt.Errorf("failed to build messages for graceful completion: %w", err)
@@ -782,32 +996,56 @@ func (se *StreamExecutor) executeToolsAndContinueWithLimit(ctx context.Context,
 	return se.processProviderStream(ctx, contStreamChan, send)
 }
 
+// messageTarget is the provider and model this executor's messages are built for
+func (se *StreamExecutor) messageTarget() domainllm.MessageTarget {
+	return domainllm.MessageTarget{Provider: se.provider.Name(), Model: se.model}
+}
+
+// loadConversationPath loads the path from root to this turn with all blocks attached,
+// including the blocks this executor has persisted so far (used for continuation requests)
+func (se *StreamExecutor) loadConversationPath(ctx context.Context) ([]llmModels.Turn, error) {
+	path, err := se.turnNavigator.GetTurnPath(ctx, se.turnID)
+	if err != nil {
+		return nil, fmt.Errorf("load turn path: %w", err)
+	}
+
+	for i := range path {
+		blocks, err := se.turnReader.GetTurnBlocks(ctx, path[i].ID)
+		if err != nil {
+			return nil, fmt.Errorf("load blocks for turn %s: %w", path[i].ID, err)
+		}
+		path[i].Blocks = blocks
+	}
+
+	return path, nil
+}
+
 // injectToolLimitNote appends a limit notification to the last tool_result block.
 // This tells the LLM it has reached the maximum tool rounds and should respond
 // with the information gathered so far. The note is injected into messages
 // before sending to the provider, but is NOT persisted to the database.
+// Tool results live in the assistant turn that called the tools (adapters move them into
+// provider-specific tool/user messages), so every message is searched, not only user ones.
 func injectToolLimitNote(messages []domainllm.Message, currentRound, maxRounds int) {
-	// Find last message with role="user" (tool results are sent as user messages)
 	for i := len(messages) - 1; i >= 0; i-- {
-		if messages[i].Role == "user" {
-			// Find last tool_result block in this message
-			blocks := messages[i].Content
-			for j := len(blocks) - 1; j >= 0; j-- {
-				if blocks[j].BlockType == llmModels.BlockTypeToolResult {
-					// Inject limit note into the result field
-					// Content is already map[string]interface{} - no type assertion needed
-					content := blocks[j].Content
-					if result, exists := content["result"]; exists {
-						// Append limit note to existing result
-						resultStr := fmt.Sprintf("%v", result)
-						limitNote := fmt.Sprintf(
-							"\n\n---\nNote: You have reached the maximum tool rounds (%d/%d). Please provide your response based on the information you've gathered so far. No additional tool calls are available.",
-							currentRound, maxRounds,
-						)
-						content["result"] = resultStr + limitNote
+		// Find last tool_result block in this message
+		blocks := messages[i].Content
+		for j := len(blocks) - 1; j >= 0; j-- {
+			if blocks[j].BlockType == llmModels.BlockTypeToolResult {
+				// Inject limit note into the result field
+				var content llmModels.ToolResultContent
+				if err := llmModels.DecodeContent(blocks[j], &content); err == nil && content.Result != nil {
+					// Append limit note to existing result
+					limitNote := fmt.Sprintf(
+						"\n\n---\nNote: You have reached the maximum tool rounds (%d/%d). Please provide your response based on the information you've gathered so far. No additional tool calls are available.",
+						currentRound, maxRounds,
+					)
+					content.Result = fmt.Sprintf("%v", content.Result) + limitNote
+					if encoded, err := llmModels.EncodeContent(&content); err == nil {
+						blocks[j].Content = encoded
 					}
-					return
 				}
+				return
 			}
 		}
 	}
@@ -819,12 +1057,11 @@ func injectToolLimitNote(messages []domainllm.Message, currentRound, maxRounds i
 // metadata can be nil (e.g., when max_tool_rounds is hit before next stream)
 func (se *StreamExecutor) completeTurn(
 	ctx context.Context,
-	send func(mstream.Event),
+	send eventstream.EventSink,
 	stopReason string,
 	metadata *domainllm.StreamMetadata,
 ) error {
 	se.logger.Info("completing turn",
-		"turn_id", se.turnID,
 		"stop_reason", stopReason,
 		"total_tool_iterations", se.toolIteration,
 	)
@@ -852,6 +1089,10 @@ func (se *StreamExecutor) completeTurn(
 	// Send turn_complete SSE event
 	se.sendEvent(send, llmModels.SSEEventTurnComplete, completeEvent)
 
+	if se.onComplete != nil {
+		se.onComplete()
+	}
+
 	return nil
 }
 
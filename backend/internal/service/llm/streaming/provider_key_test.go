@@ -0,0 +1,65 @@
+package streaming
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"meridian/internal/domain"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+// namedProvider identifies which provider adapter was returned
+type namedProvider struct {
+	llmSvc.LLMProvider
+	name string
+}
+
+// fakeProviderGetter returns server-key or user-key providers by name
+type fakeProviderGetter struct{}
+
+func (fakeProviderGetter) GetProvider(provider string) (llmSvc.LLMProvider, error) {
+	return namedProvider{name: provider + "/server"}, nil
+}
+
+func (fakeProviderGetter) GetProviderWithKey(provider, apiKey string) (llmSvc.LLMProvider, error) {
+	return namedProvider{name: provider + "/" + apiKey}, nil
+}
+
+// fakeKeyResolver returns a fixed key or error
+type fakeKeyResolver struct {
+	key string
+	err error
+}
+
+func (r fakeKeyResolver) GetAPIKey(ctx context.Context, userID, provider string) (string, error) {
+	return r.key, r.err
+}
+
+func TestResolveProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolver llmSvc.ProviderKeyResolver
+		want     string
+	}{
+		{"user keys disabled", nil, "anthropic/server"},
+		{"user key", fakeKeyResolver{key: "user-key"}, "anthropic/user-key"},
+		{"no user key", fakeKeyResolver{err: fmt.Errorf("anthropic key: %w", domain.ErrNotFound)}, "anthropic/server"},
+		{"lookup failed", fakeKeyResolver{err: errors.New("decrypt key: message authentication failed")}, "anthropic/server"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{providerGetter: fakeProviderGetter{}, providerKeys: tt.resolver}
+			provider, err := s.resolveProvider(context.Background(), "user-1", "anthropic", slog.New(slog.DiscardHandler))
+			if err != nil {
+				t.Fatalf("resolveProvider: %v", err)
+			}
+			if got := provider.(namedProvider).name; got != tt.want {
+				t.Errorf("provider = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package streaming
+
+// replay.go - Debug replay of a turn into a sandbox chat (ENVIRONMENT=dev only).
+// Reproduces a reported generation without writing to the reporting user's chat.
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/logging"
+)
+
+// ReplayTurnDebug copies the conversation path that led to an assistant turn into a new
+// sandbox chat in the caller's project, then regenerates the turn there with the original
+// request params (model, tools, thinking, ...) plus the request's overrides, e.g. a
+// lorem model to replay without provider cost.
+//
+// The source turn is read without an ownership check: this is a debug endpoint for
+// reproducing other users' reports. Tools and the system prompt resolve against the
+// sandbox project, so document tools never see the source project.
+func (s *Service) ReplayTurnDebug(ctx context.Context, req *llmSvc.ReplayTurnRequest) (*llmSvc.CreateTurnResponse, error) {
+	if req.ProjectID == "" {
+		return nil, fmt.Errorf("%w: project_id is required", domain.ErrValidation)
+	}
+	if _, err := s.projectRepo.GetByID(ctx, req.ProjectID, req.UserID); err != nil {
+		return nil, fmt.Errorf("project_id references inaccessible project: %w", err)
+	}
+
+	original, err := s.turnReader.GetTurn(ctx, req.TurnID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRetryableTurn(original); err != nil {
+		return nil, err
+	}
+
+	// Root to the user turn the original answered, with blocks
+	path, err := s.turnNavigator.GetTurnPath(ctx, *original.PrevTurnID)
+	if err != nil {
+		return nil, fmt.Errorf("load turn path: %w", err)
+	}
+	turnIDs := make([]string, len(path))
+	for i := range path {
+		turnIDs[i] = path[i].ID
+	}
+	blocksByTurn, err := s.turnReader.GetTurnBlocksForTurns(ctx, turnIDs)
+	if err != nil {
+		return nil, fmt.Errorf("load turn blocks: %w", err)
+	}
+
+	if err := req.RequestParams.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: invalid request params: %v", domain.ErrValidation, err)
+	}
+	requestParams, err := original.RequestParams.WithOverrides(req.RequestParams)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid request params: %v", domain.ErrValidation, err)
+	}
+
+	ctx = logging.WithUserID(ctx, req.UserID)
+	logger := logging.FromContext(ctx, s.logger)
+
+	// Plan against the sandbox project before creating anything
+	createReq := &llmSvc.CreateTurnRequest{
+		UserID:        req.UserID,
+		ProjectID:     &req.ProjectID,
+		Role:          "user",
+		TurnBlocks:    turnBlockInputs(blocksByTurn[*original.PrevTurnID]),
+		RequestParams: requestParams,
+	}
+	chatCtx := &chatContext{projectID: req.ProjectID, isNewChat: true, isSandbox: true}
+	plan, err := s.planTurn(ctx, createReq, chatCtx, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var chat *llmModels.Chat
+	var userTurn, assistantTurn *llmModels.Turn
+	now := time.Now()
+	err = s.txManager.ExecTx(ctx, func(txCtx context.Context) error {
+		chat = &llmModels.Chat{
+			ProjectID: req.ProjectID,
+			UserID:    req.UserID,
+			Title:     fmt.Sprintf("Replay of turn %s", original.ID),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := s.chatRepo.CreateChat(txCtx, chat); err != nil {
+			return fmt.Errorf("failed to create sandbox chat: %w", err)
+		}
+
+		var prevTurnID *string
+		for i := range path {
+			copied, err := s.copyTurn(txCtx, &path[i], blocksByTurn[path[i].ID], chat.ID, prevTurnID)
+			if err != nil {
+				return err
+			}
+			prevTurnID = &copied.ID
+			userTurn = copied
+		}
+
+		assistantTurn = &llmModels.Turn{
+			ChatID:             chat.ID,
+			PrevTurnID:         prevTurnID,
+			Role:               "assistant",
+			Status:             "streaming",
+			Model:              &plan.model,
+			RequestParams:      plan.requestParams,
+			SystemPromptTokens: plan.systemPromptTokens,
+			ModelRouting:       plan.modelRouting,
+			CreatedAt:          time.Now(),
+		}
+		if err := s.turnWriter.CreateTurn(txCtx, assistantTurn); err != nil {
+			return fmt.Errorf("failed to create assistant turn: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = logging.WithChatID(ctx, chat.ID)
+	logger = logging.FromContext(ctx, s.logger)
+	logger.Info("turn replay started in sandbox chat",
+		"replayed_turn_id", original.ID,
+		"copied_turns", len(path),
+		"assistant_turn_id", assistantTurn.ID,
+		"model", plan.model,
+		"provider", plan.provider,
+	)
+
+	if err := s.startTurn(ctx, plan, chat, req.UserID, userTurn.ID, assistantTurn, logger); err != nil {
+		return nil, err
+	}
+
+	return &llmSvc.CreateTurnResponse{
+		Chat:          chat,
+		UserTurn:      userTurn,
+		AssistantTurn: assistantTurn,
+		StreamURL:     fmt.Sprintf("/api/turns/%s/stream", assistantTurn.ID),
+		SystemPrompt:  plan.systemPromptUsage,
+	}, nil
+}
+
+// copyTurn creates a finished copy of a turn and its blocks in another chat
+func (s *Service) copyTurn(ctx context.Context, turn *llmModels.Turn, blocks []llmModels.TurnBlock, chatID string, prevTurnID *string) (*llmModels.Turn, error) {
+	copied := &llmModels.Turn{
+		ChatID:        chatID,
+		PrevTurnID:    prevTurnID,
+		Role:          turn.Role,
+		Status:        "complete",
+		Model:         turn.Model,
+		RequestParams: turn.RequestParams,
+		StopReason:    turn.StopReason,
+		CreatedAt:     time.Now(),
+	}
+	if err := s.turnWriter.CreateTurn(ctx, copied); err != nil {
+		return nil, fmt.Errorf("copy turn %s: %w", turn.ID, err)
+	}
+
+	if len(blocks) == 0 {
+		return copied, nil
+	}
+	copiedBlocks := make([]llmModels.TurnBlock, len(blocks))
+	for i, block := range blocks {
+		copiedBlocks[i] = llmModels.TurnBlock{
+			TurnID:        copied.ID,
+			BlockType:     block.BlockType,
+			Sequence:      block.Sequence,
+			TextContent:   block.TextContent,
+			Content:       block.Content,
+			Provider:      block.Provider,
+			ProviderData:  block.ProviderData,
+			ExecutionSide: block.ExecutionSide,
+		}
+	}
+	if err := s.turnWriter.CreateTurnBlocks(ctx, copiedBlocks); err != nil {
+		return nil, fmt.Errorf("copy blocks of turn %s: %w", turn.ID, err)
+	}
+	return copied, nil
+}
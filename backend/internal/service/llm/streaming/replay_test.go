@@ -0,0 +1,71 @@
+package streaming
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	domainllm "meridian/internal/domain/services/llm"
+)
+
+// recordingTurnWriter assigns IDs to created turns and keeps everything written.
+// Unused interface methods panic.
+type recordingTurnWriter struct {
+	llmRepo.TurnWriter
+	turns  []llmModels.Turn
+	blocks []llmModels.TurnBlock
+}
+
+func (w *recordingTurnWriter) CreateTurn(ctx context.Context, turn *llmModels.Turn) error {
+	turn.ID = fmt.Sprintf("copy-%d", len(w.turns)+1)
+	w.turns = append(w.turns, *turn)
+	return nil
+}
+
+func (w *recordingTurnWriter) CreateTurnBlocks(ctx context.Context, blocks []llmModels.TurnBlock) error {
+	w.blocks = append(w.blocks, blocks...)
+	return nil
+}
+
+func TestService_CopyTurn(t *testing.T) {
+	writer := &recordingTurnWriter{}
+	s := &Service{turnWriter: writer}
+
+	model := "claude-haiku-4-5"
+	text := "Rewrite the opening"
+	source := &llmModels.Turn{ID: "source", ChatID: "user-chat", Role: "assistant", Status: "error", Model: &model}
+	blocks := []llmModels.TurnBlock{
+		{ID: "block-1", TurnID: "source", BlockType: llmModels.BlockTypeText, Sequence: 0, TextContent: &text},
+	}
+	prev := "copy-0"
+
+	copied, err := s.copyTurn(context.Background(), source, blocks, "sandbox", &prev)
+	if err != nil {
+		t.Fatalf("copyTurn() error = %v", err)
+	}
+
+	// The copy lives in the sandbox chat, after prev, and is always finished
+	if copied.ChatID != "sandbox" || copied.PrevTurnID != &prev || copied.Status != "complete" || copied.Role != "assistant" {
+		t.Errorf("copied turn = %+v", copied)
+	}
+	if len(writer.blocks) != 1 {
+		t.Fatalf("copied %d blocks, want 1", len(writer.blocks))
+	}
+	block := writer.blocks[0]
+	if block.TurnID != copied.ID || block.ID != "" || *block.TextContent != text {
+		t.Errorf("copied block = %+v, want a new block of %s", block, copied.ID)
+	}
+}
+
+func TestService_ReplayTurnDebug_RequiresProject(t *testing.T) {
+	s := &Service{}
+
+	_, err := s.ReplayTurnDebug(context.Background(), &domainllm.ReplayTurnRequest{TurnID: "turn-1", UserID: "dev"})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("ReplayTurnDebug() error = %v, want ErrValidation", err)
+	}
+}
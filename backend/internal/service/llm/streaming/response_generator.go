@@ -14,6 +14,7 @@ import (
 // This avoids import cycles with the parent llm package
 type ProviderRegistry interface {
 	GetProvider(model string) (domainllm.LLMProvider, error)
+	GetProviderWithKey(provider, apiKey string) (domainllm.LLMProvider, error)
 }
 
 // ResponseGenerator handles LLM response generation
@@ -46,20 +47,22 @@ func (g *ResponseGenerator) GetProvider(model string) (domainllm.LLMProvider, er
 	return g.registry.GetProvider(model)
 }
 
+// GetProviderWithKey gets an LLM provider that uses a user's own API key (delegates to registry)
+func (g *ResponseGenerator) GetProviderWithKey(provider, apiKey string) (domainllm.LLMProvider, error) {
+	return g.registry.GetProviderWithKey(provider, apiKey)
+}
+
 // GenerateResponse generates an LLM response for a user turn.
 // This is a synchronous implementation - it blocks until the response is complete.
 // Streaming support will be added in a future phase.
-func (g *ResponseGenerator) GenerateResponse(ctx context.Context, userTurnID string, model string, requestParams map[string]interface{}) (*domainllm.GenerateResponse, error) {
+func (g *ResponseGenerator) GenerateResponse(ctx context.Context, userTurnID string, model string, requestParams *llm.RequestParams) (*domainllm.GenerateResponse, error) {
 	// Validate request params
-	if err := llm.ValidateRequestParams(requestParams); err != nil {
+	if err := requestParams.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid request params: %w", err)
 	}
 
-	// Parse request params to typed struct
-	params, err := llm.GetRequestParamStruct(requestParams)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse request params: %w", err)
-	}
+	// Resolve minimal tool definitions to full schemas
+	params := requestParams.WithResolvedTools()
 
 	// Allow model override via params
 	if params.Model != nil && *params.Model != "" {
@@ -67,11 +70,8 @@ func (g *ResponseGenerator) GenerateResponse(ctx context.Context, userTurnID str
 	}
 
 	// Extract provider from request_params or infer from model
-	var provider string
-	if params.Provider != nil && *params.Provider != "" {
-		// Provider explicitly specified
-		provider = *params.Provider
-	} else {
+	provider := params.GetProvider()
+	if provider == "" {
 		// Try to infer provider from model name
 		if mappedProvider, found := llm.GetProviderForModel(model); found {
 			provider = mappedProvider
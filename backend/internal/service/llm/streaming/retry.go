@@ -0,0 +1,128 @@
+package streaming
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/logging"
+)
+
+// RetryTurn regenerates an assistant turn without re-sending the user message: a new
+// assistant turn is created as a sibling of the retried one (same prev_turn_id) and
+// streamed like a fresh response. Its request params are the retried turn's with the
+// request's overrides applied.
+func (s *Service) RetryTurn(ctx context.Context, req *llmSvc.RetryTurnRequest) (*llmSvc.CreateTurnResponse, error) {
+	original, err := s.turnReader.GetTurn(ctx, req.TurnID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validator.ValidateChat(ctx, original.ChatID, req.UserID); err != nil {
+		return nil, err
+	}
+	if err := validateRetryableTurn(original); err != nil {
+		return nil, err
+	}
+
+	userTurn, err := s.turnReader.GetTurn(ctx, *original.PrevTurnID)
+	if err != nil {
+		return nil, fmt.Errorf("load user turn: %w", err)
+	}
+	if userTurn.Role != "user" {
+		return nil, fmt.Errorf("%w: only assistant turns that answer a user turn can be retried", domain.ErrValidation)
+	}
+
+	// The user message is planned again, so "auto" routes it like the original
+	userBlocks, err := s.turnReader.GetTurnBlocks(ctx, userTurn.ID)
+	if err != nil {
+		return nil, fmt.Errorf("load user turn blocks: %w", err)
+	}
+
+	chat, err := s.chatRepo.GetChat(ctx, original.ChatID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = logging.WithChatID(logging.WithUserID(ctx, req.UserID), chat.ID)
+	logger := logging.FromContext(ctx, s.logger)
+
+	if err := s.checkAbuse(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	if err := req.RequestParams.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: invalid request params: %v", domain.ErrValidation, err)
+	}
+	requestParams, err := original.RequestParams.WithOverrides(req.RequestParams)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid request params: %v", domain.ErrValidation, err)
+	}
+
+	// Plan the turn as if the user turn had just been sent
+	createReq := &llmSvc.CreateTurnRequest{
+		UserID:         req.UserID,
+		PrevTurnID:     &userTurn.ID,
+		Role:           "user",
+		SelectedSkills: req.SelectedSkills,
+		MatchStyle:     req.MatchStyle,
+		TurnBlocks:     turnBlockInputs(userBlocks),
+		RequestParams:  requestParams,
+	}
+	chatCtx := &chatContext{chatID: chat.ID, projectID: chat.ProjectID, chat: chat}
+	plan, err := s.planTurn(ctx, createReq, chatCtx, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	assistantTurn := &llmModels.Turn{
+		ChatID:             chat.ID,
+		PrevTurnID:         &userTurn.ID, // Sibling of the retried turn
+		Role:               "assistant",
+		Status:             "streaming",
+		Model:              &plan.model,
+		RequestParams:      plan.requestParams,
+		SystemPromptTokens: plan.systemPromptTokens,
+		ModelRouting:       plan.modelRouting,
+		CreatedAt:          time.Now(),
+	}
+	if err := s.turnWriter.CreateTurn(ctx, assistantTurn); err != nil {
+		return nil, fmt.Errorf("failed to create assistant turn: %w", err)
+	}
+
+	logger.Info("assistant turn created for retry",
+		"retried_turn_id", original.ID,
+		"user_turn_id", userTurn.ID,
+		"assistant_turn_id", assistantTurn.ID,
+		"model", plan.model,
+		"provider", plan.provider,
+	)
+
+	if err := s.startTurn(ctx, plan, chat, req.UserID, userTurn.ID, assistantTurn, logger); err != nil {
+		return nil, err
+	}
+
+	return &llmSvc.CreateTurnResponse{
+		UserTurn:      userTurn,
+		AssistantTurn: assistantTurn,
+		StreamURL:     fmt.Sprintf("/api/turns/%s/stream", assistantTurn.ID),
+		SystemPrompt:  plan.systemPromptUsage,
+	}, nil
+}
+
+// validateRetryableTurn checks that a turn is a finished assistant turn with a previous turn
+func validateRetryableTurn(turn *llmModels.Turn) error {
+	if turn.Role != "assistant" {
+		return fmt.Errorf("%w: only assistant turns can be retried", domain.ErrValidation)
+	}
+	if turn.PrevTurnID == nil {
+		return fmt.Errorf("%w: only assistant turns that answer a user turn can be retried", domain.ErrValidation)
+	}
+	switch turn.Status {
+	case "pending", "streaming", "waiting_subagents":
+		return fmt.Errorf("%w: turn is still %s; interrupt it before retrying", domain.ErrConflict, turn.Status)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package streaming
+
+import (
+	"errors"
+	"testing"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+func TestValidateRetryableTurn(t *testing.T) {
+	prev := "user-turn"
+	tests := []struct {
+		name string
+		turn llmModels.Turn
+		want error
+	}{
+		{"complete assistant turn", llmModels.Turn{Role: "assistant", Status: "complete", PrevTurnID: &prev}, nil},
+		{"errored assistant turn", llmModels.Turn{Role: "assistant", Status: "error", PrevTurnID: &prev}, nil},
+		{"cancelled assistant turn", llmModels.Turn{Role: "assistant", Status: "cancelled", PrevTurnID: &prev}, nil},
+		{"user turn", llmModels.Turn{Role: "user", Status: "complete", PrevTurnID: &prev}, domain.ErrValidation},
+		{"root assistant turn", llmModels.Turn{Role: "assistant", Status: "complete"}, domain.ErrValidation},
+		{"still streaming", llmModels.Turn{Role: "assistant", Status: "streaming", PrevTurnID: &prev}, domain.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRetryableTurn(&tt.turn)
+			if tt.want == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Fatalf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
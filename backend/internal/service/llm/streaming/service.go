@@ -2,34 +2,39 @@ package streaming
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"runtime/debug"
 	"strings"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
-	mstream "github.com/haowjy/meridian-stream-go"
 
 	"meridian/internal/capabilities"
 	"meridian/internal/config"
 	"meridian/internal/domain"
+	docsysModels "meridian/internal/domain/models/docsystem"
 	llmModels "meridian/internal/domain/models/llm"
 	"meridian/internal/domain/repositories"
 	docsysRepo "meridian/internal/domain/repositories/docsystem"
 	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/domain/services"
 	llmSvc "meridian/internal/domain/services/llm"
+	"meridian/internal/errreport"
+	"meridian/internal/eventstream"
+	"meridian/internal/logging"
+	"meridian/internal/service/llm/sanitize"
 	"meridian/internal/service/llm/tools"
 	"meridian/internal/service/llm/tools/external"
+	"meridian/internal/utils"
 )
 
-// ChatValidator is shared validation logic for chat operations
-type ChatValidator interface {
-	ValidateChat(ctx context.Context, chatID, userID string) error
-}
-
 // LLMProviderGetter provides access to LLM providers by model name
 type LLMProviderGetter interface {
 	GetProvider(model string) (llmSvc.LLMProvider, error)
+	// GetProviderWithKey returns an uncached provider that uses a user's own API key
+	GetProviderWithKey(provider, apiKey string) (llmSvc.LLMProvider, error)
 }
 
 // Service implements the StreamingService interface
@@ -40,40 +45,63 @@ type Service struct {
 	turnReader           llmRepo.TurnReader
 	turnNavigator        llmRepo.TurnNavigator
 	chatRepo             llmRepo.ChatRepository
-	projectRepo          docsysRepo.ProjectRepository // For validating project access on cold start
+	projectRepo          docsysRepo.ProjectRepository         // For validating project access on cold start
+	projectSettingsRepo  docsysRepo.ProjectSettingsRepository // Project defaults (model, tool policy, guardrails)
 	documentRepo         docsysRepo.DocumentRepository
 	folderRepo           docsysRepo.FolderRepository
-	validator            ChatValidator
+	validator            llmSvc.ChatValidator
 	providerGetter       LLMProviderGetter
-	registry             *mstream.Registry
+	registry             eventstream.Registry
 	config               *config.Config
 	txManager            repositories.TransactionManager
 	systemPromptResolver llmSvc.SystemPromptResolver
 	messageBuilder       llmSvc.MessageBuilder
-	toolLimitResolver    llmSvc.ToolLimitResolver   // Resolves tool round limits (tier-ready)
-	capabilityRegistry   *capabilities.Registry     // For checking model capabilities (e.g., supports_tools)
+	guard                *sanitize.Guard               // Prompt-injection guard applied to tool results before persisting
+	toolLimitResolver    llmSvc.ToolLimitResolver      // Resolves tool round limits (tier-ready)
+	modelAllowlist       llmSvc.ModelAllowlistResolver // Models "auto" may route to (tier-ready, nil = any)
+	capabilityRegistry   capabilities.Lookup           // For checking model capabilities (e.g., supports_tools)
+	objectStore          services.ObjectStore          // Storage for generated images
+	critic               llmSvc.TurnCritic             // Reviews completed turns when the project enables it (nil = disabled)
+	providerKeys         llmSvc.ProviderKeyResolver    // Users' own provider API keys (nil = server keys only)
+	experiments          llmSvc.ExperimentAssigner     // Enrolls turns in prompt/params experiments (nil = disabled)
+	dataPolicy           llmSvc.ProviderDataPolicy     // Users' per-provider data controls (nil = not enforced)
+	attachments          llmRepo.AttachmentRepository  // Uploads referenced by image blocks (nil = not supported)
+	abuse                llmSvc.AbuseMonitor           // Throttles or flags runaway/abusive usage (nil = disabled)
 	logger               *slog.Logger
 }
 
+// maxToolRetryBackoff caps the wait between tool retries so a round isn't stalled for long
+const maxToolRetryBackoff = 2 * time.Second
+
 // NewService creates a new streaming service
 func NewService(
-	turnWriter           llmRepo.TurnWriter,
-	turnReader           llmRepo.TurnReader,
-	turnNavigator        llmRepo.TurnNavigator,
-	chatRepo             llmRepo.ChatRepository,
-	projectRepo          docsysRepo.ProjectRepository,
-	documentRepo         docsysRepo.DocumentRepository,
-	folderRepo           docsysRepo.FolderRepository,
-	validator            ChatValidator,
-	providerGetter       LLMProviderGetter,
-	registry             *mstream.Registry,
-	cfg                  *config.Config,
-	txManager            repositories.TransactionManager,
+	turnWriter llmRepo.TurnWriter,
+	turnReader llmRepo.TurnReader,
+	turnNavigator llmRepo.TurnNavigator,
+	chatRepo llmRepo.ChatRepository,
+	projectRepo docsysRepo.ProjectRepository,
+	projectSettingsRepo docsysRepo.ProjectSettingsRepository,
+	documentRepo docsysRepo.DocumentRepository,
+	folderRepo docsysRepo.FolderRepository,
+	validator llmSvc.ChatValidator,
+	providerGetter LLMProviderGetter,
+	registry eventstream.Registry,
+	cfg *config.Config,
+	txManager repositories.TransactionManager,
 	systemPromptResolver llmSvc.SystemPromptResolver,
-	messageBuilder       llmSvc.MessageBuilder,
-	toolLimitResolver    llmSvc.ToolLimitResolver,
-	capabilityRegistry   *capabilities.Registry,
-	logger               *slog.Logger,
+	messageBuilder llmSvc.MessageBuilder,
+	guard *sanitize.Guard,
+	toolLimitResolver llmSvc.ToolLimitResolver,
+	modelAllowlist llmSvc.ModelAllowlistResolver,
+	capabilityRegistry capabilities.Lookup,
+	objectStore services.ObjectStore,
+	critic llmSvc.TurnCritic,
+	providerKeys llmSvc.ProviderKeyResolver,
+	experiments llmSvc.ExperimentAssigner,
+	dataPolicy llmSvc.ProviderDataPolicy,
+	attachments llmRepo.AttachmentRepository,
+	abuse llmSvc.AbuseMonitor,
+	logger *slog.Logger,
 ) llmSvc.StreamingService {
 	return &Service{
 		turnWriter:           turnWriter,
@@ -81,6 +109,7 @@ func NewService(
 		turnNavigator:        turnNavigator,
 		chatRepo:             chatRepo,
 		projectRepo:          projectRepo,
+		projectSettingsRepo:  projectSettingsRepo,
 		documentRepo:         documentRepo,
 		folderRepo:           folderRepo,
 		validator:            validator,
@@ -90,8 +119,17 @@ func NewService(
 		txManager:            txManager,
 		systemPromptResolver: systemPromptResolver,
 		messageBuilder:       messageBuilder,
+		guard:                guard,
 		toolLimitResolver:    toolLimitResolver,
+		modelAllowlist:       modelAllowlist,
 		capabilityRegistry:   capabilityRegistry,
+		objectStore:          objectStore,
+		critic:               critic,
+		providerKeys:         providerKeys,
+		experiments:          experiments,
+		dataPolicy:           dataPolicy,
+		attachments:          attachments,
+		abuse:                abuse,
 		logger:               logger,
 	}
 }
@@ -127,79 +165,24 @@ func (s *Service) CreateTurn(ctx context.Context, req *llmSvc.CreateTurnRequest)
 		return nil, err
 	}
 
-	// Prepare request params and model before transaction
-	requestParams := req.RequestParams
-	if requestParams == nil {
-		requestParams = make(map[string]interface{})
-	}
+	// Log lines from here on carry the user and chat IDs (chat ID set after a cold start)
+	ctx = logging.WithChatID(logging.WithUserID(ctx, req.UserID), chatContext.chatID)
+	logger := logging.FromContext(ctx, s.logger)
 
-	// Validate request params first
-	if err := llmModels.ValidateRequestParams(requestParams); err != nil {
-		s.logger.Error("invalid request params", "error", err)
-		return nil, fmt.Errorf("invalid request params: %w", err)
-	}
-
-	params, err := llmModels.GetRequestParamStruct(requestParams)
-	if err != nil {
-		s.logger.Error("failed to parse request params", "error", err)
-		return nil, fmt.Errorf("failed to parse request params: %w", err)
-	}
-
-	// Extract model from request_params (pure model name, no provider prefix)
-	model := s.config.DefaultModel
-	if model == "" {
-		model = "moonshotai/kimi-k2-thinking" // Fallback if config not set
-	}
-	if params.Model != nil && *params.Model != "" {
-		model = *params.Model
-	}
-
-	// Extract provider from request_params or infer from model
-	var provider string
-	if params.Provider != nil && *params.Provider != "" {
-		// Provider explicitly specified
-		provider = *params.Provider
-	} else {
-		// Try to infer provider from model name
-		if mappedProvider, found := llmModels.GetProviderForModel(model); found {
-			provider = mappedProvider
-		} else {
-			// No mapping found - default to openrouter (has all models)
-			provider = "openrouter"
-		}
-		// Persist resolved provider to request_params for turn history/edit
-		// This ensures we always know which provider was actually used
-		requestParams["provider"] = provider
+	if err := s.checkAbuse(ctx, req.UserID); err != nil {
+		return nil, err
 	}
 
-	// Filter out tools if model doesn't support them
-	// This prevents "No endpoints found that support tool use" errors from providers
-	if modelCap, err := s.capabilityRegistry.GetModelCapabilities(provider, model); err == nil {
-		if !modelCap.SupportsTools && params.Tools != nil && len(params.Tools) > 0 {
-			s.logger.Info("filtering out tools - model doesn't support tools",
-				"provider", provider,
-				"model", model,
-				"tools_count", len(params.Tools),
-			)
-			params.Tools = nil
-			// Also remove from requestParams to keep them in sync
-			delete(requestParams, "tools")
-		}
-	} else {
-		// Model not found in registry - log warning but continue (fail-open)
-		s.logger.Warn("model not found in capability registry, skipping tool filter",
-			"provider", provider,
-			"model", model,
-			"error", err,
-		)
+	// Image blocks may reference uploads by attachment ID
+	if err := s.resolveAttachments(ctx, chatContext.projectID, req.TurnBlocks); err != nil {
+		return nil, err
 	}
 
-	// Resolve system prompt from user, project, chat, and selected skills
-	// For new chat (cold start), chatContext.chatID will be empty - resolver handles this gracefully
-	if err := s.resolveSystemPromptForParams(ctx, chatContext.chatID, req.UserID, params, req.SelectedSkills); err != nil {
-		s.logger.Error("failed to resolve system prompt", "error", err)
+	plan, err := s.planTurn(ctx, req, chatContext, logger)
+	if err != nil {
 		return nil, err
 	}
+	requestParams, model := plan.requestParams, plan.model
 
 	// Create user turn + blocks and assistant turn atomically in a transaction
 	// If cold start, also create the chat in the same transaction
@@ -213,11 +196,12 @@ func (s *Service) CreateTurn(ctx context.Context, req *llmSvc.CreateTurnRequest)
 		if chatContext.isNewChat {
 			title := deriveTitleFromTurnBlocks(req.TurnBlocks)
 			createdChat = &llmModels.Chat{
-				ProjectID: chatContext.projectID,
-				UserID:    req.UserID,
-				Title:     title,
-				CreatedAt: now,
-				UpdatedAt: now,
+				ProjectID:      chatContext.projectID,
+				UserID:         req.UserID,
+				Title:          title,
+				IdempotencyKey: req.ChatIdempotencyKey,
+				CreatedAt:      now,
+				UpdatedAt:      now,
 			}
 			if err := s.chatRepo.CreateChat(txCtx, createdChat); err != nil {
 				return fmt.Errorf("failed to create chat: %w", err)
@@ -225,11 +209,10 @@ func (s *Service) CreateTurn(ctx context.Context, req *llmSvc.CreateTurnRequest)
 			// Update chatContext with the new chat ID
 			chatContext.chatID = createdChat.ID
 
-			s.logger.Info("chat created (cold start)",
+			logger.Info("chat created (cold start)",
 				"id", createdChat.ID,
 				"title", createdChat.Title,
 				"project_id", chatContext.projectID,
-				"user_id", req.UserID,
 			)
 		}
 
@@ -241,6 +224,7 @@ func (s *Service) CreateTurn(ctx context.Context, req *llmSvc.CreateTurnRequest)
 			Role:          req.Role,
 			Status:        "complete", // User turn is immediately complete
 			RequestParams: requestParams,
+			Language:      detectTurnLanguage(req.TurnBlocks),
 			CreatedAt:     now,
 		}
 
@@ -272,13 +256,15 @@ func (s *Service) CreateTurn(ctx context.Context, req *llmSvc.CreateTurnRequest)
 
 		// Create assistant turn with status="streaming"
 		assistantTurn = &llmModels.Turn{
-			ChatID:        chatContext.chatID,
-			PrevTurnID:    &turn.ID, // Assistant turn follows user turn
-			Role:          "assistant",
-			Status:        "streaming",
-			Model:         &model,
-			RequestParams: requestParams,
-			CreatedAt:     time.Now(),
+			ChatID:             chatContext.chatID,
+			PrevTurnID:         &turn.ID, // Assistant turn follows user turn
+			Role:               "assistant",
+			Status:             "streaming",
+			Model:              &model,
+			RequestParams:      requestParams,
+			SystemPromptTokens: plan.systemPromptTokens,
+			ModelRouting:       plan.modelRouting,
+			CreatedAt:          time.Now(),
 		}
 
 		if err := s.turnWriter.CreateTurn(txCtx, assistantTurn); err != nil {
@@ -289,23 +275,32 @@ func (s *Service) CreateTurn(ctx context.Context, req *llmSvc.CreateTurnRequest)
 	})
 
 	if err != nil {
+		// Lost a race with a double-submit of the same cold start
+		if chatContext.isNewChat && req.ChatIdempotencyKey != nil && errors.Is(err, domain.ErrConflict) {
+			if conflict := s.existingChatConflict(ctx, chatContext.projectID, req); conflict != nil {
+				return nil, conflict
+			}
+		}
 		return nil, err
 	}
+	if chatContext.isNewChat {
+		ctx = logging.WithChatID(ctx, chatContext.chatID)
+		logger = logging.FromContext(ctx, s.logger)
+	}
 
-	s.logger.Info("user turn created",
+	logger.Info("user turn created",
 		"id", turn.ID,
-		"chat_id", chatContext.chatID,
 		"role", req.Role,
 		"prev_turn_id", req.PrevTurnID,
 		"turn_blocks", len(req.TurnBlocks),
 		"is_cold_start", chatContext.isNewChat,
 	)
 
-	s.logger.Info("assistant turn created with streaming status",
+	logger.Info("assistant turn created with streaming status",
 		"user_turn_id", turn.ID,
 		"assistant_turn_id", assistantTurn.ID,
 		"model", model,
-		"provider", provider,
+		"provider", plan.provider,
 	)
 
 	// Get chat to extract project_id for tools
@@ -317,29 +312,223 @@ func (s *Service) CreateTurn(ctx context.Context, req *llmSvc.CreateTurnRequest)
 		var chatErr error
 		chat, chatErr = s.chatRepo.GetChat(ctx, chatContext.chatID, req.UserID)
 		if chatErr != nil {
-			s.logger.Error("failed to get chat for tools",
+			logger.Error("failed to get chat for tools",
 				"error", chatErr,
-				"chat_id", chatContext.chatID,
-				"user_id", req.UserID,
 			)
 			// Update turn to error status
 			if updateErr := s.turnWriter.UpdateTurnError(ctx, assistantTurn.ID, fmt.Sprintf("failed to get chat: %v", chatErr)); updateErr != nil {
-				s.logger.Error("failed to update turn error", "error", updateErr)
+				logger.Error("failed to update turn error", "error", updateErr)
 			}
 			return nil, fmt.Errorf("failed to get chat for tools: %w", chatErr)
 		}
 	}
 
+	if err := s.startTurn(ctx, plan, chat, req.UserID, turn.ID, assistantTurn, logger); err != nil {
+		return nil, err
+	}
+
+	// Return both turns and stream URL
+	// If cold start, also return the created chat
+	streamURL := fmt.Sprintf("/api/turns/%s/stream", assistantTurn.ID)
+	return &llmSvc.CreateTurnResponse{
+		Chat:          createdChat, // Only populated on cold start
+		UserTurn:      turn,
+		AssistantTurn: assistantTurn,
+		StreamURL:     streamURL,
+		SystemPrompt:  plan.systemPromptUsage,
+	}, nil
+}
+
+// turnPlan is the resolved request of a new assistant turn
+type turnPlan struct {
+	requestParams      *llmModels.RequestParams // Persisted with the turns (client's shape + resolved provider)
+	params             *llmModels.RequestParams // Provider-facing: tools and system prompt resolved
+	model              string
+	provider           string
+	projectSettings    *docsysModels.ProjectSettings
+	systemPromptUsage  *llmModels.SystemPromptUsage
+	systemPromptTokens *int
+	modelRouting       *llmModels.ModelRouting          // Set when the model was "auto"
+	experiments        []llmModels.ExperimentAssignment // Variants recorded on the assistant turn
+}
+
+// planTurn validates the request params and resolves the model, provider, tools and system
+// prompt of a new assistant turn. It may fill in req.SelectedSkills from the chat's defaults.
+func (s *Service) planTurn(ctx context.Context, req *llmSvc.CreateTurnRequest, chatContext *chatContext, logger *slog.Logger) (*turnPlan, error) {
+	// Prepare request params and model before transaction
+	// requestParams is what gets persisted (client's original shape + resolved provider)
+	requestParams := req.RequestParams
+	if requestParams == nil {
+		requestParams = &llmModels.RequestParams{}
+	}
+
+	// Validate request params first
+	if err := requestParams.Validate(); err != nil {
+		logger.Error("invalid request params", "error", err)
+		return nil, fmt.Errorf("%w: invalid request params: %v", domain.ErrValidation, err)
+	}
+
+	// Experiment variants supply defaults for the params the request leaves out
+	experiments := s.assignExperiments(ctx, req.UserID, chatContext, logger)
+	requestParams, err := applyExperimentParams(requestParams, experiments)
+	if err != nil {
+		return nil, err
+	}
+
+	// Chat settings fill in skills and tools the request leaves out
+	s.applyChatDefaults(chatContext.chat, req, requestParams)
+
+	// Project settings: default model, tool policy and guardrails
+	projectSettings, err := s.projectSettingsRepo.Get(ctx, chatContext.projectID, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("load project settings: %w", err)
+	}
+	s.applyToolPolicy(projectSettings.ToolPolicy, requestParams, logger)
+
+	// Extract model from request_params (pure model name, no provider prefix)
+	// Precedence: request > project default > server default
+	serverDefaultModel := s.config.DefaultModel
+	if serverDefaultModel == "" {
+		serverDefaultModel = "moonshotai/kimi-k2-thinking" // Fallback if config not set
+	}
+	model := serverDefaultModel
+	if projectSettings.DefaultModel != nil && *projectSettings.DefaultModel != "" {
+		model = *projectSettings.DefaultModel
+	}
+	if requestParams.Model != nil && *requestParams.Model != "" {
+		model = *requestParams.Model
+	}
+
+	// "auto" lets the backend pick the model from the turn's complexity
+	var modelRouting *llmModels.ModelRouting
+	if model == llmModels.AutoModel {
+		modelRouting, err = s.routeAutoModel(ctx, req, requestParams, serverDefaultModel, logger)
+		if err != nil {
+			return nil, err
+		}
+		model = modelRouting.Model
+	}
+
+	// Extract provider from request_params or infer from model
+	provider := requestParams.GetProvider()
+	if provider == "" {
+		// Try to infer provider from model name
+		if mappedProvider, found := llmModels.GetProviderForModel(model); found {
+			provider = mappedProvider
+		} else {
+			// No mapping found - default to openrouter (has all models)
+			provider = "openrouter"
+		}
+		// Persist resolved provider to request_params for turn history/edit
+		// This ensures we always know which provider was actually used.
+		// Not for "auto": the next turn may route to another provider's model.
+		if modelRouting == nil {
+			requestParams.Provider = &provider
+		}
+	}
+
+	// The user's data controls may block the provider (also when "auto" picked the model)
+	if s.dataPolicy != nil {
+		if err := s.dataPolicy.CheckProvider(ctx, req.UserID, provider); err != nil {
+			logger.Info("provider blocked by user's data controls", "provider", provider, "model", model)
+			return nil, err
+		}
+	}
+
+	// Filter out tools if model doesn't support them
+	// This prevents "No endpoints found that support tool use" errors from providers
+	if modelCap, err := s.capabilityRegistry.GetModelCapabilities(provider, model); err == nil {
+		if !modelCap.SupportsTools && len(requestParams.Tools) > 0 {
+			logger.Info("filtering out tools - model doesn't support tools",
+				"provider", provider,
+				"model", model,
+				"tools_count", len(requestParams.Tools),
+			)
+			requestParams.Tools = nil
+		}
+	} else {
+		// Model not found in registry - log warning but continue (fail-open)
+		logger.Warn("model not found in capability registry, skipping tool filter",
+			"provider", provider,
+			"model", model,
+			"error", err,
+		)
+	}
+
+	// params is the provider-facing copy: tools resolved to full schemas, system prompt resolved.
+	// Neither change is persisted, so requestParams keeps the client's original tool names.
+	params := requestParams.WithResolvedTools()
+
+	// Resolve system prompt from user, project, chat, and selected skills
+	// For new chat (cold start), chatContext.chatID will be empty - resolver handles this gracefully
+	if err := s.resolveSystemPromptForParams(ctx, chatContext.chatID, req.UserID, params, req.SelectedSkills, req.MatchStyle); err != nil {
+		logger.Error("failed to resolve system prompt", "error", err)
+		return nil, err
+	}
+	appendExperimentPrompts(params, experiments)
+	appendResponseLanguage(params, req.TurnBlocks)
+
+	// Account for the combined system prompt before creating anything
+	systemPromptUsage, err := s.checkSystemPromptBudget(provider, model, params.System)
+	if err != nil {
+		return nil, err
+	}
+	var systemPromptTokens *int
+	if systemPromptUsage != nil {
+		systemPromptTokens = &systemPromptUsage.EstimatedTokens
+	}
+
+	return &turnPlan{
+		requestParams:      requestParams,
+		params:             params,
+		model:              model,
+		provider:           provider,
+		projectSettings:    projectSettings,
+		systemPromptUsage:  systemPromptUsage,
+		systemPromptTokens: systemPromptTokens,
+		modelRouting:       modelRouting,
+		experiments:        experiments,
+	}, nil
+}
+
+// checkAbuse refuses turns of throttled users, and counts the turn toward the user's
+// turn rate limit
+func (s *Service) checkAbuse(ctx context.Context, userID string) error {
+	if s.abuse == nil {
+		return nil
+	}
+	return s.abuse.CheckTurn(ctx, userID)
+}
+
+// startTurn builds the tools and executor of a created assistant turn, registers its
+// stream and starts streaming in the background. The turn is marked as errored if the
+// provider can't be resolved.
+func (s *Service) startTurn(ctx context.Context, plan *turnPlan, chat *llmModels.Chat, userID, userTurnID string, assistantTurn *llmModels.Turn, logger *slog.Logger) error {
+	model, provider, params := plan.model, plan.provider, plan.params
+
+	s.recordExperiments(ctx, assistantTurn.ID, plan.experiments, logger)
+
 	// Create per-request tool registry with project-specific tools
 	builder := tools.NewToolRegistryBuilder().
-		WithDocumentTools(chat.ProjectID, s.documentRepo, s.folderRepo)
+		WithDocumentTools(chat.ProjectID, s.documentRepo, s.folderRepo).
+		WithConversationRecall(chat.ProjectID, userID, chat.ID, plan.projectSettings.SearchLanguage, s.turnReader).
+		WithRetryPolicy(tools.RetryPolicy{
+			MaxAttempts:    s.config.ToolRetryMaxAttempts,
+			InitialBackoff: time.Duration(s.config.ToolRetryBackoffMs) * time.Millisecond,
+			MaxBackoff:     maxToolRetryBackoff,
+		})
+	if s.abuse != nil {
+		builder.WithInputGuard(func(ctx context.Context, call tools.ToolCall) error {
+			return s.abuse.CheckToolInput(ctx, userID, assistantTurn.ID, call.Name, call.Input)
+		})
+	}
 
 	// Add web search tool if requested via provider-specific tool name
 	var hasWebSearch bool
 	var webSearchProvider string
 
 	// Extract tools from request params
-	requestedTools := extractToolNames(requestParams)
+	requestedTools := plan.requestParams.ToolNames()
 
 	// Check for provider-specific web search tools
 	if contains(requestedTools, "tavily_web_search") {
@@ -349,33 +538,50 @@ func (s *Service) CreateTurn(ctx context.Context, req *llmSvc.CreateTurnRequest)
 			hasWebSearch = true
 			webSearchProvider = "tavily"
 		} else {
-			s.logger.Warn("tavily_web_search requested but SEARCH_API_KEY not configured")
+			logger.Warn("tavily_web_search requested but SEARCH_API_KEY not configured")
 		}
 	} else if contains(requestedTools, "brave_web_search") {
 		// Future: Brave implementation
-		s.logger.Warn("brave_web_search requested but not yet implemented")
+		logger.Warn("brave_web_search requested but not yet implemented")
 	} else if contains(requestedTools, "serper_web_search") {
 		// Future: Serper implementation
-		s.logger.Warn("serper_web_search requested but not yet implemented")
+		logger.Warn("serper_web_search requested but not yet implemented")
 	} else if contains(requestedTools, "exa_web_search") {
 		// Future: Exa implementation
-		s.logger.Warn("exa_web_search requested but not yet implemented")
+		logger.Warn("exa_web_search requested but not yet implemented")
+	}
+
+	// Add image generation tool if requested and a provider is configured
+	var hasImageGeneration bool
+	if contains(requestedTools, "image_generate") {
+		if s.config.ImageProvider != "" && s.config.ImageAPIKey != "" {
+			imageClient, err := external.NewImageClient(s.config.ImageProvider, s.config.ImageAPIKey, s.config.ImageModel)
+			if err != nil {
+				logger.Warn("image_generate requested but image provider is invalid", "error", err)
+			} else {
+				urlTTL := time.Duration(s.config.StorageURLTTLSeconds) * time.Second
+				builder.WithImageGeneration(chat.ProjectID, imageClient, s.objectStore, urlTTL)
+				hasImageGeneration = true
+			}
+		} else {
+			logger.Warn("image_generate requested but IMAGE_PROVIDER/IMAGE_API_KEY not configured")
+		}
 	}
 
 	toolRegistry := builder.Build()
 
-	s.logger.Info("per-request tool registry created",
+	logger.Info("per-request tool registry created",
 		"project_id", chat.ProjectID,
-		"chat_id", chatContext.chatID,
 		"assistant_turn_id", assistantTurn.ID,
 		"web_search_enabled", hasWebSearch,
 		"web_search_provider", webSearchProvider,
+		"image_generation_enabled", hasImageGeneration,
 	)
 
 	// Get provider adapter (do this synchronously to avoid race)
-	llmProvider, err := s.providerGetter.GetProvider(provider)
+	llmProvider, err := s.resolveProvider(ctx, userID, provider, logger)
 	if err != nil {
-		s.logger.Error("failed to get provider for streaming",
+		logger.Error("failed to get provider for streaming",
 			"error", err,
 			"provider", provider,
 			"model", model,
@@ -383,23 +589,42 @@ func (s *Service) CreateTurn(ctx context.Context, req *llmSvc.CreateTurnRequest)
 		)
 		// Update turn to error status
 		if updateErr := s.turnWriter.UpdateTurnError(ctx, assistantTurn.ID, fmt.Sprintf("failed to get provider: %v", err)); updateErr != nil {
-			s.logger.Error("failed to update turn error", "error", updateErr)
+			logger.Error("failed to update turn error", "error", updateErr)
 		}
-		return nil, fmt.Errorf("failed to get provider '%s': %w", provider, err)
+		return fmt.Errorf("failed to get provider '%s': %w", provider, err)
 	}
 
 	// Resolve tool round limit for this user (tier-ready)
-	toolRoundLimit, err := s.toolLimitResolver.GetToolRoundLimit(ctx, req.UserID)
+	toolRoundLimit, err := s.toolLimitResolver.GetToolRoundLimit(ctx, userID)
 	if err != nil {
 		// Log warning and fall back to config default
-		s.logger.Warn("failed to get tool round limit, using config default",
+		logger.Warn("failed to get tool round limit, using config default",
 			"error", err,
-			"user_id", req.UserID,
 			"fallback_limit", s.config.MaxToolRounds,
 		)
 		toolRoundLimit = s.config.MaxToolRounds
 	}
 
+	// Project guardrails can tighten (never loosen) the round limit and change the injection guard
+	guardrails := plan.projectSettings.Guardrails
+	if guardrails.MaxToolRounds != nil && *guardrails.MaxToolRounds < toolRoundLimit {
+		toolRoundLimit = *guardrails.MaxToolRounds
+	}
+	guard := s.guard
+	if guardrails.ToolResultSanitization != nil {
+		guard = sanitize.NewGuard(sanitize.ParseLevel(*guardrails.ToolResultSanitization))
+	}
+
+	// Tool results are limited to what fits the model's context (see tool_result_budget.go)
+	toolResultLimits := ToolResultLimits{MaxTokens: s.config.ToolResultMaxTokens}
+	modelCap, capErr := s.capabilityRegistry.GetModelCapabilities(provider, model)
+	if capErr == nil {
+		toolResultLimits.ContextWindow = modelCap.ContextWindow
+	}
+
+	// The background goroutine logs with the assistant turn as turn_id (the executor adds it itself)
+	turnCtx := logging.WithTurnID(ctx, assistantTurn.ID)
+
 	// Create StreamExecutor immediately (before goroutine) to avoid race condition
 	// This ensures SSE clients can connect while we're preparing the request
 	executor := NewStreamExecutor(
@@ -407,58 +632,110 @@ func (s *Service) CreateTurn(ctx context.Context, req *llmSvc.CreateTurnRequest)
 		model,            // Pure model name (no provider prefix)
 		s.turnWriter,     // TurnWriter
 		s.turnReader,     // TurnReader
-		s.turnNavigator,       // TurnNavigator (for continuation path loading)
-		llmProvider,           // Provider adapter
-		toolRegistry,          // Per-request ToolRegistry with project-specific tools
-		s.messageBuilder,      // MessageBuilder (for continuation message building)
-		s.logger,
-		toolRoundLimit,        // Per-user tool round limit (tier-ready)
-		s.config.Debug,        // Pass DEBUG flag for optional event IDs
+		s.turnNavigator,  // TurnNavigator (for continuation path loading)
+		llmProvider,      // Provider adapter
+		toolRegistry,     // Per-request ToolRegistry with project-specific tools
+		s.messageBuilder, // MessageBuilder (for continuation message building)
+		guard,            // Prompt-injection guard for tool results (project override or server default)
+		logger,           // Carries request/user/chat IDs
+		toolRoundLimit,   // Per-user tool round limit (tier-ready)
+		toolResultLimits, // Tool result size limits
+		AccumulatorLimits{ // Streamed delta size limits
+			MaxBlockBytes: s.config.StreamMaxBlockBytes,
+			MaxTurnBytes:  s.config.StreamMaxTurnBytes,
+		},
+		s.config.Debug, // Pass DEBUG flag for optional event IDs
 	)
 
+	// Cost is tracked for models with pricing in the capability registry
+	if capErr == nil {
+		executor.SetPricing(modelCap)
+	}
+
+	// Tool rounds count toward the user's tool round spike limit
+	if s.abuse != nil {
+		executor.OnToolRound(func() {
+			s.abuse.RecordToolRound(turnCtx, userID, assistantTurn.ID)
+		})
+	}
+
+	// Critic pass: review the turn once it completes (runs beside the stream, never blocks it)
+	if critique := plan.projectSettings.Critique; critique.Enabled && s.critic != nil {
+		critiqueCtx := logging.Detach(turnCtx)
+		executor.OnComplete(func() {
+			go s.critic.CritiqueCompletedTurn(critiqueCtx, userID, assistantTurn.ID, critique)
+		})
+	}
+
 	// Register stream in registry IMMEDIATELY
 	// This must happen before returning response to prevent race with SSE connections
 	stream := executor.GetStream()
-	s.registry.Register(stream)
+	if err := s.registry.Register(stream); err != nil {
+		logger.Error("failed to register stream", "error", err)
+		if updateErr := s.turnWriter.UpdateTurnError(ctx, assistantTurn.ID, fmt.Sprintf("failed to register stream: %v", err)); updateErr != nil {
+			logger.Error("failed to update turn error", "error", updateErr)
+		}
+		return fmt.Errorf("failed to register stream: %w", err)
+	}
 
-	s.logger.Info("stream registered, starting background streaming",
-		"assistant_turn_id", assistantTurn.ID,
+	logger.Info("stream registered, starting background streaming",
 		"model", model,
 	)
 
 	// Start streaming in background goroutine
-	// Use context.Background() to prevent cancellation when HTTP request completes
-	// Pass the already-created executor to avoid race
-	go s.startStreamingExecution(context.Background(), assistantTurn.ID, turn.ID, executor, params)
+	// Detach from the request context to prevent cancellation when HTTP request completes
+	// (logging IDs are kept). Pass the already-created executor to avoid race
+	go s.startStreamingExecution(logging.Detach(turnCtx), assistantTurn.ID, userTurnID, executor, params)
+	return nil
+}
 
-	// Return both turns and stream URL
-	// If cold start, also return the created chat
-	streamURL := fmt.Sprintf("/api/turns/%s/stream", assistantTurn.ID)
-	return &llmSvc.CreateTurnResponse{
-		Chat:          createdChat, // Only populated on cold start
-		UserTurn:      turn,
-		AssistantTurn: assistantTurn,
-		StreamURL:     streamURL,
-	}, nil
+// resolveProvider returns the provider adapter for a turn: one using the user's own API
+// key when they stored one for the provider, else the shared one using the server's key.
+// A failed key lookup (e.g. undecryptable key) is logged and falls back to the server key.
+func (s *Service) resolveProvider(ctx context.Context, userID, provider string, logger *slog.Logger) (llmSvc.LLMProvider, error) {
+	if s.providerKeys != nil {
+		apiKey, err := s.providerKeys.GetAPIKey(ctx, userID, provider)
+		switch {
+		case err == nil:
+			logger.Debug("using user's own provider key", "provider", provider)
+			return s.providerGetter.GetProviderWithKey(provider, apiKey)
+		case !errors.Is(err, domain.ErrNotFound):
+			logger.Warn("failed to load user's provider key, using server key", "error", err, "provider", provider)
+		}
+	}
+	return s.providerGetter.GetProvider(provider)
 }
 
 // startStreamingExecution starts the streaming execution for an assistant turn.
 // This runs in a background goroutine and prepares the request before starting the stream.
 // The executor is already created and registered before this function is called.
 func (s *Service) startStreamingExecution(ctx context.Context, assistantTurnID, userTurnID string, executor *StreamExecutor, params *llmModels.RequestParams) {
-	s.logger.Info("preparing streaming request",
-		"assistant_turn_id", assistantTurnID,
-	)
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("preparing streaming request")
+
+	// A panic here would otherwise crash the server (no HTTP recovery in this goroutine)
+	defer func() {
+		if rec := recover(); rec != nil {
+			logger.Error("panic while preparing streaming request",
+				"error", rec,
+				"stack", string(debug.Stack()),
+			)
+			errreport.CapturePanic(ctx, rec)
+			if updateErr := s.turnWriter.UpdateTurnError(ctx, assistantTurnID, "internal error while preparing request"); updateErr != nil {
+				logger.Error("failed to update turn error", "error", updateErr)
+			}
+		}
+	}()
 
 	// Get conversation history (turn path)
 	path, err := s.turnNavigator.GetTurnPath(ctx, userTurnID)
 	if err != nil {
-		s.logger.Error("failed to get turn path for streaming",
+		logger.Error("failed to get turn path for streaming",
 			"error", err,
 			"user_turn_id", userTurnID,
 		)
 		if updateErr := s.turnWriter.UpdateTurnError(ctx, assistantTurnID, fmt.Sprintf("failed to get turn path: %v", err)); updateErr != nil {
-			s.logger.Error("failed to update turn error", "error", updateErr)
+			logger.Error("failed to update turn error", "error", updateErr)
 		}
 		return
 	}
@@ -467,12 +744,12 @@ func (s *Service) startStreamingExecution(ctx context.Context, assistantTurnID,
 	for i := range path {
 		blocks, err := s.turnReader.GetTurnBlocks(ctx, path[i].ID)
 		if err != nil {
-			s.logger.Error("failed to get content blocks",
+			logger.Error("failed to get content blocks",
 				"error", err,
-				"turn_id", path[i].ID,
+				"path_turn_id", path[i].ID,
 			)
 			if updateErr := s.turnWriter.UpdateTurnError(ctx, assistantTurnID, fmt.Sprintf("failed to get content blocks: %v", err)); updateErr != nil {
-				s.logger.Error("failed to update turn error", "error", updateErr)
+				logger.Error("failed to update turn error", "error", updateErr)
 			}
 			return
 		}
@@ -480,13 +757,13 @@ func (s *Service) startStreamingExecution(ctx context.Context, assistantTurnID,
 	}
 
 	// Build messages from turn history using MessageBuilder
-	messages, err := s.messageBuilder.BuildMessages(ctx, path)
+	messages, err := s.messageBuilder.BuildMessages(ctx, path, executor.messageTarget())
 	if err != nil {
-		s.logger.Error("failed to build messages for streaming",
+		logger.Error("failed to build messages for streaming",
 			"error", err,
 		)
 		if updateErr := s.turnWriter.UpdateTurnError(ctx, assistantTurnID, fmt.Sprintf("failed to build messages: %v", err)); updateErr != nil {
-			s.logger.Error("failed to update turn error", "error", updateErr)
+			logger.Error("failed to update turn error", "error", updateErr)
 		}
 		return
 	}
@@ -501,20 +778,18 @@ func (s *Service) startStreamingExecution(ctx context.Context, assistantTurnID,
 	// Start streaming execution (non-blocking)
 	executor.Start(generateReq)
 
-	s.logger.Info("streaming execution started",
-		"assistant_turn_id", assistantTurnID,
+	logger.Info("streaming execution started",
 		"model", executor.model,
 	)
 
 	// Note: StreamExecutor will:
 	// - Stream from provider
 	// - Accumulate deltas into TurnBlocks
-	// - Broadcast events via mstream
+	// - Broadcast events via the turn stream
 	// - Update turn status on completion/error
 	// - Registry will clean up stream after retention period
 }
 
-
 // CreateAssistantTurnDebug creates an assistant turn (DEBUG/INTERNAL USE ONLY)
 //
 // WARNING: This method is exposed for:
@@ -605,11 +880,13 @@ func (s *Service) CreateAssistantTurnDebug(
 // Resolution order:
 // 1. User-provided system prompt (from params.System)
 // 2. Project system prompt
-// 3. Chat system prompt
-// 4. Selected skills (from .skills/{skillName}/SKILL documents)
+// 3. Project style profile (when matchStyle is set)
+// 4. Chat system prompt
+// 5. Selected skills (from .skills/{skillName}/SKILL documents)
 //
 // The method only resolves when:
 // - Skills are selected (len(selectedSkills) > 0), OR
+// - The style profile is requested (matchStyle), OR
 // - No user system prompt is provided (params.System == nil)
 func (s *Service) resolveSystemPromptForParams(
 	ctx context.Context,
@@ -617,9 +894,10 @@ func (s *Service) resolveSystemPromptForParams(
 	userID string,
 	params *llmModels.RequestParams,
 	selectedSkills []string,
+	matchStyle bool,
 ) error {
-	if len(selectedSkills) > 0 || params.System == nil {
-		systemPrompt, err := s.systemPromptResolver.Resolve(ctx, chatID, userID, params.System, selectedSkills)
+	if len(selectedSkills) > 0 || matchStyle || params.System == nil {
+		systemPrompt, err := s.systemPromptResolver.Resolve(ctx, chatID, userID, params.System, selectedSkills, matchStyle)
 		if err != nil {
 			return fmt.Errorf("failed to resolve system prompt: %w", err)
 		}
@@ -635,9 +913,52 @@ func (s *Service) resolveSystemPromptForParams(
 
 // chatContext holds resolved chat information for turn creation
 type chatContext struct {
-	chatID    string // Resolved chat ID (may be empty if isNewChat=true until chat is created)
-	projectID string // Project ID (always set)
-	isNewChat bool   // True if we need to create a new chat (cold start)
+	chatID    string          // Resolved chat ID (may be empty if isNewChat=true until chat is created)
+	projectID string          // Project ID (always set)
+	isNewChat bool            // True if we need to create a new chat (cold start)
+	chat      *llmModels.Chat // Existing chat (nil on cold start), for its settings
+	isSandbox bool            // Debug replay: not enrolled in experiments
+}
+
+// applyChatDefaults applies the chat's default skills and tools to a request that doesn't
+// specify its own. Only absent fields are filled: an explicit empty list
+// ("selected_skills": [] or "tools": []) opts out of the defaults.
+// Tools are added before capability filtering and persisted with the turn's request_params.
+func (s *Service) applyChatDefaults(chat *llmModels.Chat, req *llmSvc.CreateTurnRequest, requestParams *llmModels.RequestParams) {
+	if chat == nil {
+		return // Cold start: a new chat has no settings yet
+	}
+	if req.SelectedSkills == nil && len(chat.DefaultSkills) > 0 {
+		req.SelectedSkills = append([]string(nil), chat.DefaultSkills...)
+	}
+	if requestParams.Tools == nil && len(chat.DefaultTools) > 0 {
+		requestParams.Tools = make([]llmModels.ToolDefinition, len(chat.DefaultTools))
+		for i, name := range chat.DefaultTools {
+			requestParams.Tools[i] = llmModels.ToolDefinition{Name: name}
+		}
+	}
+}
+
+// applyToolPolicy drops requested tools the project's tool policy doesn't permit.
+// Runs after chat defaults so those are filtered too; the persisted request_params
+// record only the tools that were actually offered to the model.
+func (s *Service) applyToolPolicy(policy docsysModels.ProjectToolPolicy, requestParams *llmModels.RequestParams, logger *slog.Logger) {
+	if len(requestParams.Tools) == 0 {
+		return
+	}
+	permitted := requestParams.Tools[:0:0]
+	var removed []string
+	for _, tool := range requestParams.Tools {
+		if policy.Permits(tool.Name) {
+			permitted = append(permitted, tool)
+		} else {
+			removed = append(removed, tool.Name)
+		}
+	}
+	if len(removed) > 0 {
+		logger.Info("tools removed by project tool policy", "tools", removed)
+		requestParams.Tools = permitted
+	}
 }
 
 // resolveChatContext determines which chat to use for turn creation.
@@ -670,6 +991,7 @@ func (s *Service) resolveChatContext(ctx context.Context, req *llmSvc.CreateTurn
 			chatID:    prevTurn.ChatID,
 			projectID: chat.ProjectID,
 			isNewChat: false,
+			chat:      chat,
 		}, nil
 	}
 
@@ -689,6 +1011,7 @@ func (s *Service) resolveChatContext(ctx context.Context, req *llmSvc.CreateTurn
 			chatID:    *req.ChatID,
 			projectID: chat.ProjectID,
 			isNewChat: false,
+			chat:      chat,
 		}, nil
 	}
 
@@ -700,6 +1023,13 @@ func (s *Service) resolveChatContext(ctx context.Context, req *llmSvc.CreateTurn
 			return nil, fmt.Errorf("project_id references inaccessible project: %w", err)
 		}
 
+		// A repeated idempotency key means this cold start already created its chat
+		if req.ChatIdempotencyKey != nil {
+			if conflict := s.existingChatConflict(ctx, *req.ProjectID, req); conflict != nil {
+				return nil, conflict
+			}
+		}
+
 		return &chatContext{
 			chatID:    "", // Will be set after chat creation
 			projectID: *req.ProjectID,
@@ -711,6 +1041,23 @@ func (s *Service) resolveChatContext(ctx context.Context, req *llmSvc.CreateTurn
 	return nil, fmt.Errorf("%w: must provide chat_id, project_id, or prev_turn_id", domain.ErrValidation)
 }
 
+// existingChatConflict returns a conflict naming the chat an earlier cold start with the
+// request's idempotency key created, or nil if there is none
+func (s *Service) existingChatConflict(ctx context.Context, projectID string, req *llmSvc.CreateTurnRequest) error {
+	chat, err := s.chatRepo.GetChatByIdempotencyKey(ctx, projectID, req.UserID, *req.ChatIdempotencyKey)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	return &domain.ConflictError{
+		Message:      "chat already created for this chat_idempotency_key",
+		ResourceType: "chat",
+		ResourceID:   chat.ID,
+	}
+}
+
 // Validation methods
 
 func (s *Service) validateCreateTurnRequest(req *llmSvc.CreateTurnRequest) error {
@@ -721,6 +1068,7 @@ func (s *Service) validateCreateTurnRequest(req *llmSvc.CreateTurnRequest) error
 			validation.In("user"), // Only allow user role from client (assistant turns created internally)
 		),
 		validation.Field(&req.TurnBlocks, validation.Each(validation.By(s.validateTurnBlock))),
+		validation.Field(&req.ChatIdempotencyKey, validation.NilOrNotEmpty, validation.Length(1, 128)),
 	)
 }
 
@@ -759,47 +1107,6 @@ func (s *Service) validateTurnBlock(value interface{}) error {
 	return nil
 }
 
-// extractToolNames extracts tool names from request params
-// Handles both minimal format {"name": "tool"} and full format {"function": {"name": "tool"}}
-func extractToolNames(requestParams map[string]interface{}) []string {
-	toolNames := []string{}
-
-	// Extract "tools" array from request params
-	toolsRaw, ok := requestParams["tools"]
-	if !ok {
-		return toolNames
-	}
-
-	tools, ok := toolsRaw.([]interface{})
-	if !ok {
-		return toolNames
-	}
-
-	for _, toolRaw := range tools {
-		toolMap, ok := toolRaw.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		// Check minimal format: {"name": "tool"}
-		if name, ok := toolMap["name"].(string); ok {
-			toolNames = append(toolNames, name)
-			continue
-		}
-
-		// Check full format: {"function": {"name": "tool"}}
-		if functionRaw, ok := toolMap["function"]; ok {
-			if functionMap, ok := functionRaw.(map[string]interface{}); ok {
-				if name, ok := functionMap["name"].(string); ok {
-					toolNames = append(toolNames, name)
-				}
-			}
-		}
-	}
-
-	return toolNames
-}
-
 // contains checks if a slice contains a string
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -835,12 +1142,6 @@ func truncateTitleFromText(text string) string {
 		words = words[:defaultTitleMaxWords]
 	}
 
-	title := strings.Join(words, " ")
-
-	// Truncate if exceeds max length
-	if len(title) > config.MaxChatTitleLength {
-		title = title[:config.MaxChatTitleLength-3] + "..."
-	}
-
-	return title
+	// Truncate if exceeds max length (in characters, never splitting a rune)
+	return utils.TruncateWithEllipsis(strings.Join(words, " "), config.MaxChatTitleLength)
 }
@@ -0,0 +1,38 @@
+package streaming
+
+import (
+	"encoding/json"
+	"sync"
+
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+// streamIndexer builds a turn's stream index from the events the executor sends.
+// Tool progress events are sent from the tools' goroutines, so it locks.
+type streamIndexer struct {
+	mu     sync.Mutex
+	events int // Events sent so far (the DEBUG event ID of the latest one)
+	index  llmModels.StreamIndex
+}
+
+// record counts an event sent to the stream. It returns a copy of the index when the
+// event ended the stream (turn_complete or turn_error), nil otherwise.
+func (x *streamIndexer) record(eventType string, data []byte) *llmModels.StreamIndex {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.events++
+
+	switch eventType {
+	case llmModels.SSEEventBlockStop:
+		var stop llmModels.BlockStopEvent
+		if err := json.Unmarshal(data, &stop); err == nil {
+			x.index.Blocks = append(x.index.Blocks, llmModels.StreamIndexBlock{Index: stop.BlockIndex, Events: x.events})
+		}
+	case llmModels.SSEEventTurnComplete, llmModels.SSEEventTurnError:
+		x.index.Final = &llmModels.StreamIndexFinal{Event: eventType, Data: data, Events: x.events}
+		index := x.index
+		index.Blocks = append([]llmModels.StreamIndexBlock(nil), x.index.Blocks...)
+		return &index
+	}
+	return nil
+}
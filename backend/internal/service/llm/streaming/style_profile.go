@@ -0,0 +1,36 @@
+package streaming
+
+import (
+	"fmt"
+	"strings"
+
+	docsysModels "meridian/internal/domain/models/docsystem"
+)
+
+// formatStyleProfile renders a complete style profile as system prompt instructions.
+// Statistics the analysis couldn't determine (tense, person, characteristic words) are
+// left out rather than guessed.
+func formatStyleProfile(profile *docsysModels.StyleProfile) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Match the user's writing style, measured from %d words of their own writing:\n", profile.WordCount)
+	fmt.Fprintf(&b, "- Sentences average %.1f words; %.0f%% are under 8 words and %.0f%% over 25 words.\n",
+		profile.AvgSentenceLength, profile.ShortSentenceShare*100, profile.LongSentenceShare*100)
+	fmt.Fprintf(&b, "- Words average %.1f letters; vocabulary richness is %.0f distinct words per 100.\n",
+		profile.AvgWordLength, profile.VocabularyRichness)
+	fmt.Fprintf(&b, "- Dialogue makes up %.0f%% of the text.\n", profile.DialogueShare*100)
+
+	switch {
+	case profile.Tense != "" && profile.Person != "":
+		fmt.Fprintf(&b, "- Narration is in %s tense, %s person.\n", profile.Tense, profile.Person)
+	case profile.Tense != "":
+		fmt.Fprintf(&b, "- Narration is in %s tense.\n", profile.Tense)
+	case profile.Person != "":
+		fmt.Fprintf(&b, "- Narration is in %s person.\n", profile.Person)
+	}
+	if len(profile.CharacteristicWords) > 0 {
+		fmt.Fprintf(&b, "- Characteristic words: %s.\n", strings.Join(profile.CharacteristicWords, ", "))
+	}
+
+	b.WriteString("Keep prose you write close to these patterns unless the user asks otherwise.")
+	return b.String()
+}
@@ -0,0 +1,44 @@
+package streaming
+
+import (
+	"strings"
+	"testing"
+
+	docsysModels "meridian/internal/domain/models/docsystem"
+)
+
+func TestFormatStyleProfile(t *testing.T) {
+	profile := &docsysModels.StyleProfile{
+		Status:              docsysModels.StyleProfileStatusComplete,
+		WordCount:           5200,
+		AvgSentenceLength:   12.4,
+		ShortSentenceShare:  0.31,
+		LongSentenceShare:   0.05,
+		AvgWordLength:       4.2,
+		VocabularyRichness:  47.5,
+		DialogueShare:       0.18,
+		Tense:               docsysModels.StyleTensePast,
+		Person:              docsysModels.StylePersonThird,
+		CharacteristicWords: []string{"mara", "harbor"},
+	}
+
+	got := formatStyleProfile(profile)
+	for _, want := range []string{
+		"measured from 5200 words",
+		"Sentences average 12.4 words; 31% are under 8 words and 5% over 25 words.",
+		"Dialogue makes up 18% of the text.",
+		"Narration is in past tense, third person.",
+		"Characteristic words: mara, harbor.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("prompt is missing %q:\n%s", want, got)
+		}
+	}
+
+	// Undetermined statistics are left out
+	profile.Tense, profile.Person, profile.CharacteristicWords = "", "", nil
+	got = formatStyleProfile(profile)
+	if strings.Contains(got, "Narration") || strings.Contains(got, "Characteristic") {
+		t.Errorf("prompt includes undetermined statistics:\n%s", got)
+	}
+}
@@ -0,0 +1,59 @@
+package streaming
+
+import (
+	"fmt"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+// checkSystemPromptBudget estimates the size of the resolved system prompt and compares it
+// to the model's context window.
+// Returns nil usage when there is no system prompt. Returns a validation error when the
+// prompt takes more than SystemPromptMaxPercent of the context window (the model would
+// have little room left for the conversation itself).
+// Unknown models (not in the capability registry) only get the token estimate.
+func (s *Service) checkSystemPromptBudget(provider, model string, systemPrompt *string) (*llmModels.SystemPromptUsage, error) {
+	if systemPrompt == nil || *systemPrompt == "" {
+		return nil, nil
+	}
+
+	usage := &llmModels.SystemPromptUsage{
+		EstimatedTokens: llmModels.EstimateTokens(*systemPrompt),
+	}
+
+	modelCap, err := s.capabilityRegistry.GetModelCapabilities(provider, model)
+	if err != nil || modelCap.ContextWindow <= 0 {
+		return usage, nil
+	}
+
+	contextLimit := modelCap.ContextWindow
+	percent := float64(usage.EstimatedTokens) / float64(contextLimit) * 100
+	usage.ContextLimit = &contextLimit
+	usage.UsagePercent = &percent
+
+	if maxPercent := s.config.SystemPromptMaxPercent; maxPercent > 0 && percent > float64(maxPercent) {
+		s.logger.Warn("system prompt exceeds context budget",
+			"provider", provider,
+			"model", model,
+			"estimated_tokens", usage.EstimatedTokens,
+			"context_limit", contextLimit,
+			"usage_percent", percent,
+			"max_percent", maxPercent,
+		)
+		return nil, fmt.Errorf(
+			"%w: system prompt is too large (~%d tokens, %.1f%% of the %d token context window; limit is %d%%). Shorten the project/chat prompt or select fewer skills",
+			domain.ErrValidation, usage.EstimatedTokens, percent, contextLimit, maxPercent,
+		)
+	}
+
+	if warnPercent := s.config.SystemPromptWarnPercent; warnPercent > 0 && percent > float64(warnPercent) {
+		warning := fmt.Sprintf(
+			"System prompt uses ~%d tokens (%.1f%% of the context window). Consider shortening the project/chat prompt or selecting fewer skills.",
+			usage.EstimatedTokens, percent,
+		)
+		usage.WarningMessage = &warning
+	}
+
+	return usage, nil
+}
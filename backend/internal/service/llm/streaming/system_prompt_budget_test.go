@@ -0,0 +1,80 @@
+package streaming
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"meridian/internal/capabilities"
+	"meridian/internal/config"
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	"meridian/internal/testmocks"
+)
+
+func TestCheckSystemPromptBudget(t *testing.T) {
+	s := &Service{
+		capabilityRegistry: testmocks.NewCapabilityLookup("anthropic",
+			capabilities.ModelCapabilities{ID: "small", ContextWindow: 1000},
+		),
+		config: &config.Config{SystemPromptWarnPercent: 25, SystemPromptMaxPercent: 50},
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	// promptOf returns a prompt of roughly the given token count (~4 chars per token)
+	promptOf := func(tokens int) *string {
+		prompt := strings.Repeat("x", tokens*4)
+		if got := llmModels.EstimateTokens(prompt); got != tokens {
+			t.Fatalf("prompt estimates to %d tokens, want %d", got, tokens)
+		}
+		return &prompt
+	}
+
+	t.Run("no prompt", func(t *testing.T) {
+		usage, err := s.checkSystemPromptBudget("anthropic", "small", nil)
+		if usage != nil || err != nil {
+			t.Fatalf("got %+v, %v; want nil, nil", usage, err)
+		}
+	})
+
+	t.Run("within budget", func(t *testing.T) {
+		usage, err := s.checkSystemPromptBudget("anthropic", "small", promptOf(100))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if usage.ContextLimit == nil || *usage.ContextLimit != 1000 {
+			t.Fatalf("context limit = %v, want 1000", usage.ContextLimit)
+		}
+		if usage.WarningMessage != nil {
+			t.Fatalf("unexpected warning: %s", *usage.WarningMessage)
+		}
+	})
+
+	t.Run("warns above warn percent", func(t *testing.T) {
+		usage, err := s.checkSystemPromptBudget("anthropic", "small", promptOf(300))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if usage.WarningMessage == nil {
+			t.Fatal("expected a warning at 30% of the context window")
+		}
+	})
+
+	t.Run("rejects above max percent", func(t *testing.T) {
+		_, err := s.checkSystemPromptBudget("anthropic", "small", promptOf(600))
+		if !errors.Is(err, domain.ErrValidation) {
+			t.Fatalf("err = %v, want ErrValidation", err)
+		}
+	})
+
+	t.Run("unknown model only estimates", func(t *testing.T) {
+		usage, err := s.checkSystemPromptBudget("anthropic", "unknown", promptOf(600))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if usage.EstimatedTokens == 0 || usage.ContextLimit != nil {
+			t.Fatalf("got %+v, want estimate only", usage)
+		}
+	})
+}
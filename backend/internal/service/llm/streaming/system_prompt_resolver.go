@@ -15,7 +15,7 @@ import (
 // systemPromptResolver builds the final system prompt from project, chat, and skills
 // Implements llmSvc.SystemPromptResolver interface
 type systemPromptResolver struct {
-	projectRepo  docsysRepo.ProjectRepository
+	settingsRepo docsysRepo.ProjectSettingsRepository
 	chatRepo     llmRepo.ChatRepository
 	documentRepo docsysRepo.DocumentRepository
 	logger       *slog.Logger
@@ -23,13 +23,13 @@ type systemPromptResolver struct {
 
 // NewSystemPromptResolver creates a new system prompt resolver
 func NewSystemPromptResolver(
-	projectRepo docsysRepo.ProjectRepository,
+	settingsRepo docsysRepo.ProjectSettingsRepository,
 	chatRepo llmRepo.ChatRepository,
 	documentRepo docsysRepo.DocumentRepository,
 	logger *slog.Logger,
 ) llmSvc.SystemPromptResolver {
 	return &systemPromptResolver{
-		projectRepo:  projectRepo,
+		settingsRepo: settingsRepo,
 		chatRepo:     chatRepo,
 		documentRepo: documentRepo,
 		logger:       logger,
@@ -38,21 +38,24 @@ func NewSystemPromptResolver(
 
 // Resolve builds the final system prompt by concatenating:
 // 1. user-provided system prompt (from request_params.system)
-// 2. project.system_prompt
-// 3. chat.system_prompt
-// 4. Content of each skill's SKILL.md file from .skills/{skill_name}/SKILL.md
+// 2. project settings system_prompt
+// 3. project style profile (when matchStyle is set)
+// 4. chat.system_prompt
+// 5. Content of each skill's SKILL.md file from .skills/{skill_name}/SKILL.md
 func (r *systemPromptResolver) Resolve(
 	ctx context.Context,
 	chatID string,
 	userID string,
 	userSystem *string,
 	selectedSkills []string,
+	matchStyle bool,
 ) (*string, error) {
 	r.logger.Info("resolving system prompt",
 		"chat_id", chatID,
 		"user_id", userID,
 		"user_system_provided", userSystem != nil,
 		"selected_skills", selectedSkills,
+		"match_style", matchStyle,
 	)
 
 	// For cold start (new chat), chatID is empty - skip chat/project system prompt loading
@@ -80,22 +83,34 @@ func (r *systemPromptResolver) Resolve(
 	}
 
 	// 3. Load project system prompt
-	project, err := r.projectRepo.GetByID(ctx, chat.ProjectID, userID)
+	settings, err := r.settingsRepo.Get(ctx, chat.ProjectID, userID)
 	if err != nil {
-		return nil, fmt.Errorf("load project: %w", err)
+		return nil, fmt.Errorf("load project settings: %w", err)
 	}
-	if project.SystemPrompt != nil && *project.SystemPrompt != "" {
-		r.logger.Info("project system prompt found", "length", len(*project.SystemPrompt))
-		parts = append(parts, *project.SystemPrompt)
+	if settings.SystemPrompt != nil && *settings.SystemPrompt != "" {
+		r.logger.Info("project system prompt found", "length", len(*settings.SystemPrompt))
+		parts = append(parts, *settings.SystemPrompt)
 	}
 
-	// 4. Load chat system prompt
+	// 4. Project style profile. A missing or unfinished profile doesn't fail the turn.
+	if matchStyle {
+		if settings.StyleProfile.IsComplete() {
+			r.logger.Info("style profile found", "words", settings.StyleProfile.WordCount)
+			parts = append(parts, formatStyleProfile(settings.StyleProfile))
+		} else {
+			r.logger.Warn("match_style requested but the project has no complete style profile",
+				"project_id", chat.ProjectID,
+			)
+		}
+	}
+
+	// 5. Load chat system prompt
 	if chat.SystemPrompt != nil && *chat.SystemPrompt != "" {
 		r.logger.Info("chat system prompt found", "length", len(*chat.SystemPrompt))
 		parts = append(parts, *chat.SystemPrompt)
 	}
 
-	// 5. Load selected skills
+	// 6. Load selected skills
 	if len(selectedSkills) > 0 {
 		skillsContent, err := r.loadSkills(ctx, chat.ProjectID, selectedSkills)
 		if err != nil {
@@ -120,6 +135,41 @@ func (r *systemPromptResolver) Resolve(
 	return &result, nil
 }
 
+// ResolveProject builds the system prompt from the project settings system_prompt and
+// the content of each selected skill
+func (r *systemPromptResolver) ResolveProject(
+	ctx context.Context,
+	projectID string,
+	userID string,
+	selectedSkills []string,
+) (*string, error) {
+	var parts []string
+
+	settings, err := r.settingsRepo.Get(ctx, projectID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("load project settings: %w", err)
+	}
+	if settings.SystemPrompt != nil && *settings.SystemPrompt != "" {
+		parts = append(parts, *settings.SystemPrompt)
+	}
+
+	if len(selectedSkills) > 0 {
+		skillsContent, err := r.loadSkills(ctx, projectID, selectedSkills)
+		if err != nil {
+			return nil, fmt.Errorf("load skills: %w", err)
+		}
+		if skillsContent != "" {
+			parts = append(parts, skillsContent)
+		}
+	}
+
+	if len(parts) == 0 {
+		return nil, nil
+	}
+	result := strings.Join(parts, "\n\n")
+	return &result, nil
+}
+
 // loadSkills loads the SKILL.md content for each selected skill
 func (r *systemPromptResolver) loadSkills(
 	ctx context.Context,
@@ -158,10 +208,7 @@ func (r *systemPromptResolver) loadSkills(
 		)
 		loadedCount++
 
-		// Format skill with path and code block wrapper
-		skillPath := fmt.Sprintf(".skills/%s/SKILL", skillName)
-		skillFormatted := fmt.Sprintf("%s:\n```\n%s\n```", skillPath, doc.Content)
-		parts = append(parts, skillFormatted)
+		parts = append(parts, r.formatSkill(projectID, skillName, doc.Content))
 	}
 
 	r.logger.Info("skills loading complete",
@@ -173,6 +220,40 @@ func (r *systemPromptResolver) loadSkills(
 	return strings.Join(parts, "\n\n"), nil
 }
 
+// formatSkill renders a skill for the system prompt: path and metadata header, then the
+// instructions in a code block.
+// Invalid skills (saved before validation existed, or imported directly) are still injected
+// rather than failing the turn; their issues are logged. Without parseable frontmatter the
+// raw content is used as-is.
+func (r *systemPromptResolver) formatSkill(projectID, skillName, content string) string {
+	skillPath := fmt.Sprintf(".skills/%s/SKILL", skillName)
+	parsed := docsysModels.ParseSkill(skillName, content)
+
+	if len(parsed.Issues) > 0 {
+		r.logger.Warn("skill has validation issues",
+			"skill", skillName,
+			"project_id", projectID,
+			"has_errors", parsed.HasErrors(),
+			"issues", parsed.Issues,
+		)
+	}
+
+	meta := parsed.Metadata
+	if meta.Name == "" {
+		return fmt.Sprintf("%s:\n```\n%s\n```", skillPath, parsed.Body)
+	}
+
+	header := fmt.Sprintf("%s (%s", skillPath, meta.Name)
+	if meta.Version != "" {
+		header += " v" + meta.Version
+	}
+	header += ")"
+	if meta.Description != "" {
+		header += " - " + meta.Description
+	}
+	return fmt.Sprintf("%s:\n```\n%s\n```", header, parsed.Body)
+}
+
 // getSkillDocument retrieves the SKILL.md document for a given skill
 func (r *systemPromptResolver) getSkillDocument(
 	ctx context.Context,
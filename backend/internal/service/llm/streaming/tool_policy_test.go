@@ -0,0 +1,42 @@
+package streaming
+
+import (
+	"log/slog"
+	"reflect"
+	"testing"
+
+	docsysModels "meridian/internal/domain/models/docsystem"
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+func TestApplyToolPolicy(t *testing.T) {
+	s := &Service{}
+	logger := slog.New(slog.DiscardHandler)
+	tools := func(names ...string) *llmModels.RequestParams {
+		params := &llmModels.RequestParams{}
+		for _, name := range names {
+			params.Tools = append(params.Tools, llmModels.ToolDefinition{Name: name})
+		}
+		return params
+	}
+
+	tests := []struct {
+		name   string
+		policy docsysModels.ProjectToolPolicy
+		want   []string
+	}{
+		{"empty policy permits all", docsysModels.ProjectToolPolicy{}, []string{"doc_view", "tavily_web_search"}},
+		{"allow list", docsysModels.ProjectToolPolicy{Allowed: []string{"doc_view"}}, []string{"doc_view"}},
+		{"deny list", docsysModels.ProjectToolPolicy{Denied: []string{"tavily_web_search"}}, []string{"doc_view"}},
+		{"deny wins", docsysModels.ProjectToolPolicy{Allowed: []string{"doc_view"}, Denied: []string{"doc_view"}}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tools("doc_view", "tavily_web_search")
+			s.applyToolPolicy(tt.policy, params, logger)
+			if got := params.ToolNames(); !reflect.DeepEqual(got, tt.want) && (len(got) != 0 || len(tt.want) != 0) {
+				t.Errorf("tools = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package streaming
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+// tool_result_budget.go - size limits for tool results.
+//
+// A single doc_view of a long chapter or a verbose web_search can be larger than the
+// context the model has left, which fails the continuation request mid-round. Before
+// persisting, each result is checked against a per-result token budget and shortened
+// when it doesn't fit. The budget is the smaller of:
+//   - the configured per-result cap (TOOL_RESULT_MAX_TOKENS), and
+//   - an even share of half the model's remaining context (context window minus what the
+//     last round consumed and the response reserve), so a round can't starve the answer.
+//
+// Shortening cuts the longest strings first (document content, page text), so the
+// structure the formatters and the model rely on (paths, titles, counts) survives.
+// Results are only truncated, never summarized: a summary would need another model
+// call inside the tool round, which is out of scope here.
+
+const (
+	// defaultResponseReserve is kept free for the model's answer when max_tokens isn't set
+	defaultResponseReserve = 4096
+
+	// minToolResultBudget is the floor for a result's budget; below this a result is useless
+	minToolResultBudget = 256
+
+	// minTruncatedStringChars is the shortest a string is cut to before falling back to
+	// truncating the serialized result
+	minTruncatedStringChars = 200
+
+	// truncationMarker is appended to every shortened string (%d = characters removed)
+	truncationMarker = " …[truncated %d characters to fit the context window]"
+)
+
+// ToolResultLimits configures tool result size checks for a stream
+type ToolResultLimits struct {
+	// MaxTokens caps each tool result (0 = no fixed cap)
+	MaxTokens int
+
+	// ContextWindow is the model's context window from the capability registry
+	// (0 = unknown model, only MaxTokens applies)
+	ContextWindow int
+}
+
+// toolResultTruncation records how a tool result was shortened
+type toolResultTruncation struct {
+	OriginalTokens int `json:"original_tokens"`
+	BudgetTokens   int `json:"budget_tokens"`
+}
+
+// toolResultBudget returns the token budget for each of resultCount results in this round.
+// Returns 0 when no limit applies.
+func (se *StreamExecutor) toolResultBudget(resultCount int) int {
+	limits := se.toolResultLimits
+	if resultCount < 1 {
+		resultCount = 1
+	}
+
+	budget := limits.MaxTokens
+
+	if limits.ContextWindow > 0 {
+		reserve := defaultResponseReserve
+		if se.req != nil && se.req.Params != nil && se.req.Params.MaxTokens != nil {
+			reserve = *se.req.Params.MaxTokens
+		}
+
+		remaining := limits.ContextWindow - se.contextUsed - reserve
+		share := remaining / 2 / resultCount
+		if share < minToolResultBudget {
+			share = minToolResultBudget
+		}
+		if budget == 0 || share < budget {
+			budget = share
+		}
+	}
+
+	return budget
+}
+
+// truncateToolResult shortens a result whose estimated size exceeds budgetTokens.
+// Returns the result unchanged (and nil) when it fits or budgetTokens is 0.
+func truncateToolResult(result interface{}, budgetTokens int) (interface{}, *toolResultTruncation) {
+	if budgetTokens <= 0 || result == nil {
+		return result, nil
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return result, nil
+	}
+	originalTokens := llmModels.EstimateTokens(string(encoded))
+	if originalTokens <= budgetTokens {
+		return result, nil
+	}
+
+	truncation := &toolResultTruncation{
+		OriginalTokens: originalTokens,
+		BudgetTokens:   budgetTokens,
+	}
+
+	// Work on generic JSON so typed tool results can be walked
+	var generic interface{}
+	if err := json.Unmarshal(encoded, &generic); err != nil {
+		return truncateString(string(encoded), budgetTokens*llmModels.CharsPerToken), truncation
+	}
+
+	// JSON escaping makes the encoded form longer than the sum of its strings, so aim for
+	// the budget in characters, measure, and cut further by the overshoot if needed
+	targetChars := budgetTokens * llmModels.CharsPerToken
+	excess := utf8.RuneCount(encoded) - targetChars
+	for attempt := 0; attempt < 3; attempt++ {
+		limit, ok := stringLengthLimit(generic, excess)
+		if !ok {
+			break
+		}
+		shortened := capStrings(generic, limit)
+		out, err := json.Marshal(shortened)
+		if err != nil {
+			break
+		}
+		if llmModels.EstimateTokens(string(out)) <= budgetTokens {
+			return shortened, truncation
+		}
+		excess += utf8.RuneCount(out) - targetChars
+	}
+
+	// Too many small items to fit by cutting strings: keep a prefix of the serialized result
+	return truncateString(string(encoded), budgetTokens*llmModels.CharsPerToken), truncation
+}
+
+// stringLengthLimit finds the largest per-string length L such that capping every string
+// at L removes at least excess characters. Returns false when even the minimum length
+// doesn't free enough.
+func stringLengthLimit(value interface{}, excess int) (int, bool) {
+	var lengths []int
+	collectStringLengths(value, &lengths)
+
+	// Each cut string gains a marker, which counts against the savings
+	markerLen := len([]rune(fmt.Sprintf(truncationMarker, 0))) + 4
+	savings := func(limit int) int {
+		total := 0
+		for _, n := range lengths {
+			if n > limit {
+				total += n - limit - markerLen
+			}
+		}
+		return total
+	}
+
+	if savings(minTruncatedStringChars) < excess {
+		return 0, false
+	}
+
+	// savings decreases as the limit grows: binary search for the largest sufficient limit
+	maxLen := 0
+	for _, n := range lengths {
+		maxLen = max(maxLen, n)
+	}
+	lo, hi := minTruncatedStringChars, maxLen
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if savings(mid) >= excess {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo, true
+}
+
+// collectStringLengths appends the rune length of every string leaf
+func collectStringLengths(value interface{}, lengths *[]int) {
+	switch v := value.(type) {
+	case string:
+		*lengths = append(*lengths, len([]rune(v)))
+	case map[string]interface{}:
+		for _, item := range v {
+			collectStringLengths(item, lengths)
+		}
+	case []interface{}:
+		for _, item := range v {
+			collectStringLengths(item, lengths)
+		}
+	}
+}
+
+// capStrings returns a copy of value with every string longer than limit runes shortened
+func capStrings(value interface{}, limit int) interface{} {
+	switch v := value.(type) {
+	case string:
+		return truncateString(v, limit)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = capStrings(item, limit)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = capStrings(item, limit)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// truncateString keeps the first limit runes of text and appends the truncation marker
+func truncateString(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit]) + fmt.Sprintf(truncationMarker, len(runes)-limit)
+}
@@ -0,0 +1,90 @@
+package streaming
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	llmModels "meridian/internal/domain/models/llm"
+	domainllm "meridian/internal/domain/services/llm"
+)
+
+func estimate(t *testing.T, v interface{}) int {
+	t.Helper()
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return llmModels.EstimateTokens(string(encoded))
+}
+
+func TestTruncateToolResult(t *testing.T) {
+	t.Run("fits", func(t *testing.T) {
+		result := map[string]interface{}{"path": "a", "content": "short"}
+		out, truncation := truncateToolResult(result, 100)
+		if truncation != nil || out.(map[string]interface{})["content"] != "short" {
+			t.Errorf("small result should be unchanged, got %v", out)
+		}
+	})
+
+	t.Run("long content keeps structure", func(t *testing.T) {
+		result := map[string]interface{}{
+			"path":    "Chapters/One",
+			"name":    "One",
+			"content": strings.Repeat("The quick brown fox.\n", 2000),
+		}
+		out, truncation := truncateToolResult(result, 1000)
+		if truncation == nil {
+			t.Fatal("expected truncation")
+		}
+		if got := estimate(t, out); got > 1000 {
+			t.Errorf("truncated result is %d tokens, budget 1000", got)
+		}
+		m := out.(map[string]interface{})
+		if m["path"] != "Chapters/One" || m["name"] != "One" {
+			t.Errorf("short fields should survive: %v", m)
+		}
+		if !strings.Contains(m["content"].(string), "truncated") {
+			t.Error("missing truncation marker")
+		}
+	})
+
+	t.Run("many small items fall back to prefix", func(t *testing.T) {
+		items := make([]interface{}, 2000)
+		for i := range items {
+			items[i] = map[string]interface{}{"title": "result", "n": float64(i)}
+		}
+		out, truncation := truncateToolResult(map[string]interface{}{"results": items}, 500)
+		if truncation == nil {
+			t.Fatal("expected truncation")
+		}
+		if _, ok := out.(string); !ok {
+			t.Fatalf("expected serialized prefix, got %T", out)
+		}
+	})
+}
+
+func TestToolResultBudget(t *testing.T) {
+	maxTokens := 1000
+	se := &StreamExecutor{
+		req:              &domainllm.GenerateRequest{Params: &llmModels.RequestParams{MaxTokens: &maxTokens}},
+		toolResultLimits: ToolResultLimits{MaxTokens: 8000, ContextWindow: 100000},
+	}
+
+	// Plenty of room: the fixed cap applies
+	if got := se.toolResultBudget(1); got != 8000 {
+		t.Errorf("budget = %d, want 8000", got)
+	}
+
+	// 100000 - 89000 used - 1000 reserve = 10000 remaining, half shared by 2 results
+	se.contextUsed = 89000
+	if got := se.toolResultBudget(2); got != 2500 {
+		t.Errorf("budget = %d, want 2500", got)
+	}
+
+	// Context exhausted: floor applies
+	se.contextUsed = 99500
+	if got := se.toolResultBudget(1); got != minToolResultBudget {
+		t.Errorf("budget = %d, want %d", got, minToolResultBudget)
+	}
+}
@@ -1,7 +1,10 @@
 package tools
 
 import (
+	"time"
+
 	docsystemRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/domain/services"
 	"meridian/internal/service/llm/tools/external"
 )
 
@@ -49,6 +52,19 @@ func (b *ToolRegistryBuilder) WithDocumentTools(
 	return b
 }
 
+// WithConversationRecall registers the conversation_recall tool, which searches the
+// user's other chats in the project. chatID is the current chat (excluded from results).
+func (b *ToolRegistryBuilder) WithConversationRecall(
+	projectID string,
+	userID string,
+	chatID string,
+	language string,
+	recaller ConversationRecaller,
+) *ToolRegistryBuilder {
+	b.registry.Register("conversation_recall", NewConversationRecallTool(projectID, userID, chatID, language, recaller, b.config))
+	return b
+}
+
 // WithWebSearch registers the web_search tool using an external search client.
 // Only registers if a valid client is provided.
 func (b *ToolRegistryBuilder) WithWebSearch(client external.SearchClient) *ToolRegistryBuilder {
@@ -59,6 +75,32 @@ func (b *ToolRegistryBuilder) WithWebSearch(client external.SearchClient) *ToolR
 	return b
 }
 
+// WithImageGeneration registers the image_generate tool. Generated images are stored
+// in store under the project's prefix. Only registers if a client and store are provided.
+func (b *ToolRegistryBuilder) WithImageGeneration(
+	projectID string,
+	client external.ImageClient,
+	store services.ObjectStore,
+	urlTTL time.Duration,
+) *ToolRegistryBuilder {
+	if client != nil && store != nil {
+		b.registry.Register("image_generate", NewImageGenerateTool(projectID, client, store, urlTTL))
+	}
+	return b
+}
+
+// WithRetryPolicy enables retries of transient failures for idempotent tools.
+func (b *ToolRegistryBuilder) WithRetryPolicy(policy RetryPolicy) *ToolRegistryBuilder {
+	b.registry.SetRetryPolicy(policy)
+	return b
+}
+
+// WithInputGuard makes every tool call pass guard before it runs.
+func (b *ToolRegistryBuilder) WithInputGuard(guard InputGuard) *ToolRegistryBuilder {
+	b.registry.SetInputGuard(guard)
+	return b
+}
+
 // Build returns the constructed tool registry.
 func (b *ToolRegistryBuilder) Build() *ToolRegistry {
 	return b.registry
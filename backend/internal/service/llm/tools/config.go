@@ -1,5 +1,7 @@
 package tools
 
+import llmModels "meridian/internal/domain/models/llm"
+
 // ToolConfig centralizes configuration for all tools.
 // Replaces magic numbers scattered throughout tool implementations.
 type ToolConfig struct {
@@ -17,6 +19,10 @@ type ToolConfig struct {
 	// Web search tool configuration (external APIs)
 	WebSearchDefaultLimit int // Default number of web search results
 	WebSearchMaxLimit     int // Maximum allowed web search results
+
+	// Conversation recall tool configuration
+	RecallDefaultLimit int // Default number of recalled turns
+	RecallMaxLimit     int // Maximum allowed recalled turns
 }
 
 // DefaultToolConfig returns the default tool configuration.
@@ -36,5 +42,9 @@ func DefaultToolConfig() *ToolConfig {
 		// Web search tool defaults
 		WebSearchDefaultLimit: 5,
 		WebSearchMaxLimit:     10,
+
+		// Conversation recall defaults
+		RecallDefaultLimit: llmModels.DefaultRecallLimit,
+		RecallMaxLimit:     llmModels.MaxRecallLimit,
 	}
 }
@@ -0,0 +1,107 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+// ConversationRecaller searches a project's past conversations.
+// Implemented by the turn repository (full-text search over text blocks).
+type ConversationRecaller interface {
+	RecallTurns(ctx context.Context, opts *llmModels.ConversationRecallOptions) ([]llmModels.RecalledTurn, error)
+}
+
+// ConversationRecallTool implements the 'conversation_recall' tool: it finds what was
+// said in the user's other chats in the project, so the model can pick up earlier
+// decisions without the user pasting history.
+type ConversationRecallTool struct {
+	projectID string
+	userID    string
+	chatID    string // Current chat, excluded from results (already in context)
+	language  string // Project search language (FTS configuration)
+	recaller  ConversationRecaller
+	config    *ToolConfig
+}
+
+// NewConversationRecallTool creates a new ConversationRecallTool instance.
+func NewConversationRecallTool(
+	projectID string,
+	userID string,
+	chatID string,
+	language string,
+	recaller ConversationRecaller,
+	config *ToolConfig,
+) *ConversationRecallTool {
+	if config == nil {
+		config = DefaultToolConfig()
+	}
+	return &ConversationRecallTool{
+		projectID: projectID,
+		userID:    userID,
+		chatID:    chatID,
+		language:  language,
+		recaller:  recaller,
+		config:    config,
+	}
+}
+
+// Idempotent implements IdempotentTool: recall is read-only, so transient failures are retried.
+func (t *ConversationRecallTool) Idempotent() bool {
+	return true
+}
+
+// Execute implements ToolExecutor interface.
+// Input parameters:
+//   - query (string, required): What to look for (keywords or phrases)
+//   - limit (integer, optional): Maximum results to return (default: 5, max: 20)
+//
+// Returns:
+//   - {results: [{chat_id, chat_title, turn_id, role, date, excerpt}], count: N}
+func (t *ConversationRecallTool) Execute(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	query, ok := input["query"].(string)
+	if !ok || strings.TrimSpace(query) == "" {
+		return nil, invalidInput("missing required parameter: query (string)")
+	}
+
+	limit := t.config.RecallDefaultLimit
+	if limitFloat, ok := input["limit"].(float64); ok {
+		limit = int(limitFloat)
+		if limit < 1 {
+			limit = 1
+		} else if limit > t.config.RecallMaxLimit {
+			limit = t.config.RecallMaxLimit
+		}
+	}
+
+	recalled, err := t.recaller.RecallTurns(ctx, &llmModels.ConversationRecallOptions{
+		ProjectID:     t.projectID,
+		UserID:        t.userID,
+		ExcludeChatID: t.chatID,
+		Query:         strings.TrimSpace(query),
+		Limit:         limit,
+		Language:      t.language,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("recall failed: %w", err)
+	}
+
+	results := make([]map[string]interface{}, len(recalled))
+	for i, turn := range recalled {
+		results[i] = map[string]interface{}{
+			"chat_id":    turn.ChatID,
+			"chat_title": turn.ChatTitle,
+			"turn_id":    turn.TurnID,
+			"role":       turn.Role,
+			"date":       turn.CreatedAt.Format("2006-01-02"),
+			"excerpt":    turn.Excerpt,
+		}
+	}
+
+	return map[string]interface{}{
+		"results": results,
+		"count":   len(results),
+	}, nil
+}
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+// fakeRecaller returns fixed turns and records the last options
+type fakeRecaller struct {
+	opts  *llmModels.ConversationRecallOptions
+	turns []llmModels.RecalledTurn
+}
+
+func (r *fakeRecaller) RecallTurns(ctx context.Context, opts *llmModels.ConversationRecallOptions) ([]llmModels.RecalledTurn, error) {
+	r.opts = opts
+	return r.turns, nil
+}
+
+func TestConversationRecallTool_ScopesSearch(t *testing.T) {
+	recaller := &fakeRecaller{turns: []llmModels.RecalledTurn{{
+		ChatID:    "chat-2",
+		ChatTitle: "Magic system",
+		TurnID:    "turn-9",
+		Role:      "assistant",
+		Excerpt:   "We agreed **magic** costs memories.",
+		CreatedAt: time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC),
+	}}}
+	tool := NewConversationRecallTool("project-1", "user-1", "chat-1", "french", recaller, nil)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"query": "  magic cost ", "limit": float64(50)})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	opts := recaller.opts
+	if opts.ProjectID != "project-1" || opts.UserID != "user-1" || opts.ExcludeChatID != "chat-1" || opts.Language != "french" {
+		t.Errorf("options not scoped to the project, user and other chats: %+v", opts)
+	}
+	if opts.Query != "magic cost" || opts.Limit != llmModels.MaxRecallLimit {
+		t.Errorf("query/limit = %q/%d, want trimmed query and capped limit", opts.Query, opts.Limit)
+	}
+
+	output := result.(map[string]interface{})
+	results := output["results"].([]map[string]interface{})
+	if output["count"] != 1 || results[0]["chat_title"] != "Magic system" || results[0]["date"] != "2025-03-04" {
+		t.Errorf("result = %v", output)
+	}
+}
+
+func TestConversationRecallTool_RequiresQuery(t *testing.T) {
+	tool := NewConversationRecallTool("project-1", "user-1", "chat-1", "english", &fakeRecaller{}, nil)
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{"query": "   "})
+	if !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("err = %v, want ErrInvalidInput", err)
+	}
+}
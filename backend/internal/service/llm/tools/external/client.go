@@ -2,6 +2,8 @@ package external
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"time"
 )
 
@@ -34,3 +36,19 @@ type SearchResult struct {
 	PublishedAt *time.Time // Publication date (if available)
 	Score       float64    // Relevance score (if available)
 }
+
+// APIError is a non-200 response from an external API
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Body)
+}
+
+// Temporary reports whether the request may succeed on retry (rate limited or server error)
+func (e *APIError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
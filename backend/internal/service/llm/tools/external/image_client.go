@@ -0,0 +1,45 @@
+package external
+
+import (
+	"context"
+	"fmt"
+)
+
+// Image aspect ratios (ImageOptions.Aspect). Providers map them to their own sizes.
+const (
+	ImageAspectSquare    = "square"
+	ImageAspectLandscape = "landscape"
+	ImageAspectPortrait  = "portrait"
+)
+
+// ImageClient defines the interface for external image generation APIs.
+// Implementations include OpenAI Images and Stability AI.
+type ImageClient interface {
+	// Generate creates one image from a text prompt.
+	Generate(ctx context.Context, prompt string, opts ImageOptions) (*GeneratedImage, error)
+}
+
+// ImageOptions configures image generation.
+type ImageOptions struct {
+	Aspect string // ImageAspectSquare (default), ImageAspectLandscape or ImageAspectPortrait
+}
+
+// GeneratedImage is an image returned by an external API.
+type GeneratedImage struct {
+	Data          []byte // Encoded image
+	MIMEType      string // e.g. "image/png"
+	RevisedPrompt string // Prompt the provider actually used (if it rewrites prompts)
+}
+
+// NewImageClient creates the image client for a provider name ("openai" or "stability").
+// An empty model uses the provider's default.
+func NewImageClient(provider, apiKey, model string) (ImageClient, error) {
+	switch provider {
+	case "openai":
+		return NewOpenAIImageClient(apiKey, model), nil
+	case "stability":
+		return NewStabilityImageClient(apiKey, model), nil
+	default:
+		return nil, fmt.Errorf("unknown image provider %q (want \"openai\" or \"stability\")", provider)
+	}
+}
@@ -0,0 +1,128 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultOpenAIImagesURL is the OpenAI image generation endpoint
+	DefaultOpenAIImagesURL = "https://api.openai.com/v1/images/generations"
+	// DefaultOpenAIImageModel is used when no model is configured
+	DefaultOpenAIImageModel = "gpt-image-1"
+	// DefaultImageTimeout is the default HTTP timeout for image generation requests
+	DefaultImageTimeout = 120 * time.Second
+)
+
+// OpenAIImageClient implements ImageClient for the OpenAI Images API.
+type OpenAIImageClient struct {
+	apiKey     string
+	model      string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewOpenAIImageClient creates a new OpenAI image client.
+func NewOpenAIImageClient(apiKey, model string) *OpenAIImageClient {
+	if model == "" {
+		model = DefaultOpenAIImageModel
+	}
+	return &OpenAIImageClient{
+		apiKey:  apiKey,
+		model:   model,
+		baseURL: DefaultOpenAIImagesURL,
+		httpClient: &http.Client{
+			Timeout: DefaultImageTimeout,
+		},
+	}
+}
+
+// Generate implements ImageClient interface for OpenAI.
+func (c *OpenAIImageClient) Generate(ctx context.Context, prompt string, opts ImageOptions) (*GeneratedImage, error) {
+	payload := map[string]interface{}{
+		"model":  c.model,
+		"prompt": prompt,
+		"n":      1,
+		"size":   c.size(opts.Aspect),
+	}
+	// DALL-E models return URLs unless asked for base64; gpt-image models always return base64
+	if strings.HasPrefix(c.model, "dall-e") {
+		payload["response_format"] = "b64_json"
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL, bytes.NewReader(payloadBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var openAIResp openAIImageResponse
+	if err := json.Unmarshal(body, &openAIResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(openAIResp.Data) == 0 || openAIResp.Data[0].B64JSON == "" {
+		return nil, fmt.Errorf("response contained no image")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(openAIResp.Data[0].B64JSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	return &GeneratedImage{
+		Data:          data,
+		MIMEType:      "image/png",
+		RevisedPrompt: openAIResp.Data[0].RevisedPrompt,
+	}, nil
+}
+
+// size maps an aspect to the sizes the configured model accepts
+func (c *OpenAIImageClient) size(aspect string) string {
+	wide, tall := "1536x1024", "1024x1536"
+	if strings.HasPrefix(c.model, "dall-e") {
+		wide, tall = "1792x1024", "1024x1792"
+	}
+	switch aspect {
+	case ImageAspectLandscape:
+		return wide
+	case ImageAspectPortrait:
+		return tall
+	default:
+		return "1024x1024"
+	}
+}
+
+// openAIImageResponse represents the response from the OpenAI Images API
+type openAIImageResponse struct {
+	Data []struct {
+		B64JSON       string `json:"b64_json"`
+		RevisedPrompt string `json:"revised_prompt"`
+	} `json:"data"`
+}
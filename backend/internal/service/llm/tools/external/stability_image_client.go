@@ -0,0 +1,100 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+const (
+	// DefaultStabilityBaseURL is the Stability AI Stable Image endpoint prefix; the model
+	// ("core", "ultra", "sd3") is appended
+	DefaultStabilityBaseURL = "https://api.stability.ai/v2beta/stable-image/generate/"
+	// DefaultStabilityImageModel is used when no model is configured
+	DefaultStabilityImageModel = "core"
+)
+
+// StabilityImageClient implements ImageClient for Stability AI.
+type StabilityImageClient struct {
+	apiKey     string
+	model      string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewStabilityImageClient creates a new Stability AI image client.
+func NewStabilityImageClient(apiKey, model string) *StabilityImageClient {
+	if model == "" {
+		model = DefaultStabilityImageModel
+	}
+	return &StabilityImageClient{
+		apiKey:  apiKey,
+		model:   model,
+		baseURL: DefaultStabilityBaseURL,
+		httpClient: &http.Client{
+			Timeout: DefaultImageTimeout,
+		},
+	}
+}
+
+// Generate implements ImageClient interface for Stability AI.
+// The API takes multipart form fields and returns the raw image when asked for image/*.
+func (c *StabilityImageClient) Generate(ctx context.Context, prompt string, opts ImageOptions) (*GeneratedImage, error) {
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	fields := map[string]string{
+		"prompt":        prompt,
+		"aspect_ratio":  stabilityAspectRatio(opts.Aspect),
+		"output_format": "png",
+	}
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			return nil, fmt.Errorf("failed to build request: %w", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+c.model, &form)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Accept", "image/*")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	return &GeneratedImage{
+		Data:     body,
+		MIMEType: "image/png",
+	}, nil
+}
+
+// stabilityAspectRatio maps an aspect to Stability's aspect_ratio values
+func stabilityAspectRatio(aspect string) string {
+	switch aspect {
+	case ImageAspectLandscape:
+		return "16:9"
+	case ImageAspectPortrait:
+		return "9:16"
+	default:
+		return "1:1"
+	}
+}
@@ -104,7 +104,7 @@ func (c *TavilyClient) Search(ctx context.Context, query string, opts SearchOpti
 
 	// Check HTTP status
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	// Parse response
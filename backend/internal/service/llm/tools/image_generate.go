@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	llmModels "meridian/internal/domain/models/llm"
+	"meridian/internal/domain/services"
+	"meridian/internal/service/llm/tools/external"
+)
+
+// maxImagePromptLength bounds prompts sent to image providers (OpenAI accepts up to 4000 characters for DALL-E 3)
+const maxImagePromptLength = 4000
+
+// ImageResult is returned by tools that produce an image. The model sees Summary as the
+// tool result; the stream executor also adds Image to the assistant turn as an image block.
+type ImageResult struct {
+	Summary map[string]interface{}
+	Image   llmModels.ImageContent
+}
+
+// ImageGenerateTool implements the 'image_generate' tool: it generates an image via an
+// external API and stores it in object storage under images/{project_id}/.
+type ImageGenerateTool struct {
+	projectID string
+	client    external.ImageClient
+	store     services.ObjectStore
+	urlTTL    time.Duration
+}
+
+// NewImageGenerateTool creates a new ImageGenerateTool instance.
+// urlTTL is the lifetime of the signed URL in the streamed image block.
+func NewImageGenerateTool(
+	projectID string,
+	client external.ImageClient,
+	store services.ObjectStore,
+	urlTTL time.Duration,
+) *ImageGenerateTool {
+	return &ImageGenerateTool{
+		projectID: projectID,
+		client:    client,
+		store:     store,
+		urlTTL:    urlTTL,
+	}
+}
+
+// Execute implements ToolExecutor interface.
+// Not idempotent: every call is billed and produces a different image, so failures aren't retried.
+// Input parameters:
+//   - prompt (string, required): Description of the image
+//   - aspect (string, optional): "square" (default), "landscape" or "portrait"
+//   - alt_text (string, optional): Short description for accessibility
+//
+// Returns an *ImageResult whose summary is {prompt, aspect, mime_type, revised_prompt?}
+func (t *ImageGenerateTool) Execute(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	prompt, ok := input["prompt"].(string)
+	if !ok || strings.TrimSpace(prompt) == "" {
+		return nil, invalidInput("missing required parameter: prompt (string)")
+	}
+	prompt = strings.TrimSpace(prompt)
+	if len(prompt) > maxImagePromptLength {
+		return nil, invalidInput("prompt is too long (%d characters, max %d)", len(prompt), maxImagePromptLength)
+	}
+
+	aspect := external.ImageAspectSquare
+	if aspectVal, ok := input["aspect"].(string); ok && aspectVal != "" {
+		switch aspectVal {
+		case external.ImageAspectSquare, external.ImageAspectLandscape, external.ImageAspectPortrait:
+			aspect = aspectVal
+		default:
+			return nil, invalidInput("invalid aspect '%s': must be 'square', 'landscape', or 'portrait'", aspectVal)
+		}
+	}
+
+	altText := prompt
+	if altVal, ok := input["alt_text"].(string); ok && strings.TrimSpace(altVal) != "" {
+		altText = strings.TrimSpace(altVal)
+	}
+
+	ReportProgress(ctx, ProgressStageGenerating, "Generating image")
+	image, err := t.client.Generate(ctx, prompt, external.ImageOptions{Aspect: aspect})
+	if err != nil {
+		return nil, fmt.Errorf("image generation failed: %w", err)
+	}
+
+	ReportProgress(ctx, ProgressStageStoring, "Saving image")
+	key := fmt.Sprintf("images/%s/%s%s", t.projectID, uuid.NewString(), imageExtension(image.MIMEType))
+	if err := t.store.Put(ctx, key, image.MIMEType, image.Data); err != nil {
+		return nil, fmt.Errorf("failed to store generated image: %w", err)
+	}
+	url, err := t.store.SignedURL(ctx, key, t.urlTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign image URL: %w", err)
+	}
+
+	summary := map[string]interface{}{
+		"prompt":    prompt,
+		"aspect":    aspect,
+		"mime_type": image.MIMEType,
+		"status":    "The image was generated and is shown to the user below your message.",
+	}
+	if image.RevisedPrompt != "" {
+		summary["revised_prompt"] = image.RevisedPrompt
+	}
+
+	return &ImageResult{
+		Summary: summary,
+		Image: llmModels.ImageContent{
+			URL:        url,
+			MIMEType:   image.MIMEType,
+			AltText:    &altText,
+			StorageKey: key,
+		},
+	}, nil
+}
+
+// imageExtension returns the file extension for an image MIME type (".png" when unknown).
+// LocalStore derives the served content type from it.
+func imageExtension(mimeType string) string {
+	switch mimeType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/webp":
+		return ".webp"
+	default:
+		return ".png"
+	}
+}
@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"meridian/internal/service/llm/tools/external"
+	"meridian/internal/testmocks"
+)
+
+// fakeImageClient returns a fixed image and records the last request
+type fakeImageClient struct {
+	err    error
+	prompt string
+	opts   external.ImageOptions
+}
+
+func (c *fakeImageClient) Generate(ctx context.Context, prompt string, opts external.ImageOptions) (*external.GeneratedImage, error) {
+	c.prompt, c.opts = prompt, opts
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &external.GeneratedImage{Data: []byte("png-bytes"), MIMEType: "image/png", RevisedPrompt: "a lighthouse, oil painting"}, nil
+}
+
+func TestImageGenerateTool_StoresImage(t *testing.T) {
+	client := &fakeImageClient{}
+	store := &testmocks.ObjectStore{}
+	tool := NewImageGenerateTool("project-1", client, store, 15*time.Minute)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
+		"prompt": "  a lighthouse in a storm  ",
+		"aspect": "landscape",
+	})
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if client.prompt != "a lighthouse in a storm" || client.opts.Aspect != external.ImageAspectLandscape {
+		t.Errorf("client got prompt %q, aspect %q", client.prompt, client.opts.Aspect)
+	}
+
+	image, ok := result.(*ImageResult)
+	if !ok {
+		t.Fatalf("result = %T, want *ImageResult", result)
+	}
+	keys := store.Keys()
+	if len(keys) != 1 || keys[0] != image.Image.StorageKey {
+		t.Fatalf("stored keys = %v, image key = %q", keys, image.Image.StorageKey)
+	}
+	if !strings.HasPrefix(image.Image.StorageKey, "images/project-1/") || !strings.HasSuffix(image.Image.StorageKey, ".png") {
+		t.Errorf("storage key = %q", image.Image.StorageKey)
+	}
+	if image.Image.URL != "signed://"+image.Image.StorageKey+"?ttl=15m0s" {
+		t.Errorf("url = %q", image.Image.URL)
+	}
+	if image.Image.AltText == nil || *image.Image.AltText != "a lighthouse in a storm" {
+		t.Errorf("alt text = %v, want the prompt", image.Image.AltText)
+	}
+	if image.Summary["revised_prompt"] != "a lighthouse, oil painting" {
+		t.Errorf("summary = %v", image.Summary)
+	}
+}
+
+func TestImageGenerateTool_InvalidInput(t *testing.T) {
+	tool := NewImageGenerateTool("project-1", &fakeImageClient{}, &testmocks.ObjectStore{}, time.Minute)
+
+	for name, input := range map[string]map[string]interface{}{
+		"missing prompt": {},
+		"blank prompt":   {"prompt": "   "},
+		"long prompt":    {"prompt": strings.Repeat("x", maxImagePromptLength+1)},
+		"bad aspect":     {"prompt": "a cat", "aspect": "panorama"},
+	} {
+		if _, err := tool.Execute(context.Background(), input); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("%s: err = %v, want ErrInvalidInput", name, err)
+		}
+	}
+}
+
+func TestImageGenerateTool_Failures(t *testing.T) {
+	providerErr := errors.New("quota exceeded")
+	tool := NewImageGenerateTool("project-1", &fakeImageClient{err: providerErr}, &testmocks.ObjectStore{}, time.Minute)
+	if _, err := tool.Execute(context.Background(), map[string]interface{}{"prompt": "a cat"}); !errors.Is(err, providerErr) {
+		t.Errorf("provider failure: err = %v", err)
+	}
+
+	storeErr := errors.New("bucket missing")
+	tool = NewImageGenerateTool("project-1", &fakeImageClient{}, &testmocks.ObjectStore{Err: storeErr}, time.Minute)
+	if _, err := tool.Execute(context.Background(), map[string]interface{}{"prompt": "a cat"}); !errors.Is(err, storeErr) {
+		t.Errorf("store failure: err = %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package tools
+
+import "context"
+
+// Progress stages reported by the registry and the built-in tools
+const (
+	ProgressStageRetrying   = "retrying"   // A transient failure is retried after backoff
+	ProgressStageGenerating = "generating" // image_generate is waiting for the image provider
+	ProgressStageStoring    = "storing"    // image_generate is storing the generated image
+)
+
+// ToolProgress is an intermediate status report of a running tool
+type ToolProgress struct {
+	Stage   string // Short machine-readable stage (e.g. "retrying")
+	Message string // Human-readable status for the UI
+}
+
+// ExecutionCallbacks observe a parallel execution (all optional). OnStart is called for
+// every call, in call order, before any tool runs. OnProgress and OnComplete run on the
+// goroutine of the tool they report, so they must be safe for concurrent use; OnComplete
+// is a call's last callback.
+type ExecutionCallbacks struct {
+	OnStart    func(call ToolCall)
+	OnProgress func(call ToolCall, progress ToolProgress)
+	OnComplete func(result ToolResult)
+}
+
+// progressKey is the context key of a running call's progress reporter
+type progressKey struct{}
+
+// withProgress returns ctx carrying report as the progress reporter of the call it runs
+func withProgress(ctx context.Context, report func(ToolProgress)) context.Context {
+	return context.WithValue(ctx, progressKey{}, report)
+}
+
+// ReportProgress reports the progress of the tool running under ctx. It does nothing
+// when nobody observes the execution (e.g. ExecuteParallel without callbacks).
+func ReportProgress(ctx context.Context, stage, message string) {
+	if report, ok := ctx.Value(progressKey{}).(func(ToolProgress)); ok {
+		report(ToolProgress{Stage: stage, Message: message})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 )
 
 // ToolCall represents a single tool invocation request.
@@ -20,22 +21,46 @@ type ToolResult struct {
 	Result  interface{} `json:"result"`   // execution result (nil if error)
 	Error   error       `json:"error"`    // execution error (nil if success)
 	IsError bool        `json:"is_error"` // whether execution failed
+
+	Duration time.Duration `json:"-"` // wall-clock execution time (all attempts, including backoff)
+	Attempts int           `json:"-"` // number of executions (> 1 when transient failures were retried)
 }
 
+// InputGuard vets a tool call before it runs; an error refuses the call and is returned
+// as its result.
+type InputGuard func(ctx context.Context, call ToolCall) error
+
 // ToolRegistry manages tool executors and handles tool execution.
 // It is thread-safe and can be used concurrently.
 type ToolRegistry struct {
-	mu        sync.RWMutex
-	executors map[string]ToolExecutor
+	mu          sync.RWMutex
+	executors   map[string]ToolExecutor
+	retryPolicy RetryPolicy
+	inputGuard  InputGuard // nil = every call runs
 }
 
 // NewToolRegistry creates a new tool registry.
 func NewToolRegistry() *ToolRegistry {
 	return &ToolRegistry{
-		executors: make(map[string]ToolExecutor),
+		executors:   make(map[string]ToolExecutor),
+		retryPolicy: NoRetry,
 	}
 }
 
+// SetRetryPolicy configures retries of transient failures for idempotent tools.
+func (r *ToolRegistry) SetRetryPolicy(policy RetryPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retryPolicy = policy
+}
+
+// SetInputGuard configures a check every tool call must pass before it runs.
+func (r *ToolRegistry) SetInputGuard(guard InputGuard) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inputGuard = guard
+}
+
 // Register adds a tool executor to the registry.
 // If a tool with the same name already exists, it will be replaced.
 func (r *ToolRegistry) Register(name string, executor ToolExecutor) {
@@ -54,35 +79,80 @@ func (r *ToolRegistry) Get(name string) ToolExecutor {
 
 // Execute runs a single tool and returns the result.
 // Returns an error if the tool is not found or execution fails.
+// Idempotent tools that fail transiently are retried according to the retry policy.
 func (r *ToolRegistry) Execute(ctx context.Context, call ToolCall) ToolResult {
 	executor := r.Get(call.Name)
 	if executor == nil {
 		return ToolResult{
-			ID:      call.ID,
-			Name:    call.Name,
-			Result:  nil,
-			Error:   fmt.Errorf("tool not found: %s", call.Name),
-			IsError: true,
+			ID:       call.ID,
+			Name:     call.Name,
+			Result:   nil,
+			Error:    invalidInput("tool not found: %s", call.Name),
+			IsError:  true,
+			Attempts: 0,
+		}
+	}
+
+	r.mu.RLock()
+	policy, guard := r.retryPolicy, r.inputGuard
+	r.mu.RUnlock()
+
+	if guard != nil {
+		if err := guard(ctx, call); err != nil {
+			return ToolResult{
+				ID:       call.ID,
+				Name:     call.Name,
+				Result:   nil,
+				Error:    err,
+				IsError:  true,
+				Attempts: 0,
+			}
+		}
+	}
+
+	maxAttempts := 1
+	if policy.MaxAttempts > 1 && isIdempotent(executor) {
+		maxAttempts = policy.MaxAttempts
+	}
+
+	start := time.Now()
+	var (
+		result  interface{}
+		err     error
+		attempt int
+	)
+	// The last attempt always breaks, so attempt ends as the number of executions
+	for attempt = 1; attempt <= maxAttempts; attempt++ {
+		result, err = executor.Execute(ctx, call.Input)
+		if err == nil || attempt == maxAttempts || !IsTransient(err) {
+			break
+		}
+		ReportProgress(ctx, ProgressStageRetrying, fmt.Sprintf("Retrying after a temporary error (attempt %d of %d)", attempt+1, maxAttempts))
+		if sleepErr := sleepContext(ctx, policy.backoff(attempt)); sleepErr != nil {
+			break
 		}
 	}
 
-	result, err := executor.Execute(ctx, call.Input)
 	if err != nil {
 		return ToolResult{
-			ID:      call.ID,
-			Name:    call.Name,
-			Result:  nil,
-			Error:   err,
-			IsError: true,
+			ID:       call.ID,
+			Name:     call.Name,
+			Result:   nil,
+			Error:    err,
+			IsError:  true,
+			Duration: time.Since(start),
+			Attempts: attempt,
 		}
 	}
 
 	return ToolResult{
-		ID:      call.ID,
-		Name:    call.Name,
-		Result:  result,
-		Error:   nil,
-		IsError: false,
+		ID:       call.ID,
+		Name:     call.Name,
+		Result:   result,
+		Error:    nil,
+		IsError:  false,
+		Duration: time.Since(start),
+		Attempts: attempt,
 	}
 }
 
@@ -90,6 +160,13 @@ func (r *ToolRegistry) Execute(ctx context.Context, call ToolCall) ToolResult {
 // This method uses goroutines for parallel execution while preserving result order.
 // Context cancellation will stop all ongoing executions.
 func (r *ToolRegistry) ExecuteParallel(ctx context.Context, calls []ToolCall) []ToolResult {
+	return r.ExecuteParallelWithCallbacks(ctx, calls, ExecutionCallbacks{})
+}
+
+// ExecuteParallelWithCallbacks is ExecuteParallel with callbacks as each tool starts, reports
+// progress (retries, or ReportProgress from the tool) and finishes. Completion callbacks come
+// in completion order, not call order.
+func (r *ToolRegistry) ExecuteParallelWithCallbacks(ctx context.Context, calls []ToolCall, callbacks ExecutionCallbacks) []ToolResult {
 	if len(calls) == 0 {
 		return []ToolResult{}
 	}
@@ -98,6 +175,13 @@ func (r *ToolRegistry) ExecuteParallel(ctx context.Context, calls []ToolCall) []
 	results := make([]ToolResult, len(calls))
 	var wg sync.WaitGroup
 
+	// Announce every call before any of them runs
+	if callbacks.OnStart != nil {
+		for _, call := range calls {
+			callbacks.OnStart(call)
+		}
+	}
+
 	// Execute each tool in a separate goroutine
 	for i, call := range calls {
 		wg.Add(1)
@@ -114,12 +198,24 @@ func (r *ToolRegistry) ExecuteParallel(ctx context.Context, calls []ToolCall) []
 					Error:   ctx.Err(),
 					IsError: true,
 				}
+				if callbacks.OnComplete != nil {
+					callbacks.OnComplete(results[index])
+				}
 				return
 			default:
 			}
 
-			// Execute the tool
-			results[index] = r.Execute(ctx, toolCall)
+			// Execute the tool, passing its progress reports to the callback
+			callCtx := ctx
+			if callbacks.OnProgress != nil {
+				callCtx = withProgress(ctx, func(progress ToolProgress) {
+					callbacks.OnProgress(toolCall, progress)
+				})
+			}
+			results[index] = r.Execute(callCtx, toolCall)
+			if callbacks.OnComplete != nil {
+				callbacks.OnComplete(results[index])
+			}
 		}(i, call)
 	}
 
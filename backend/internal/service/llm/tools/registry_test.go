@@ -439,3 +439,161 @@ func TestToolRegistry_ConcurrentRegisterAndGet(t *testing.T) {
 		}
 	}
 }
+
+// flakyTool fails with err for the first failures calls, then succeeds.
+type flakyTool struct {
+	mockTool
+	failures   int
+	err        error
+	idempotent bool
+}
+
+func (f *flakyTool) Execute(ctx context.Context, input map[string]interface{}) (interface{}, error) {
+	f.mu.Lock()
+	f.execCount++
+	count := f.execCount
+	f.mu.Unlock()
+
+	if count <= f.failures {
+		return nil, f.err
+	}
+	return "ok", nil
+}
+
+func (f *flakyTool) Idempotent() bool { return f.idempotent }
+
+func TestToolRegistry_Retry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+	call := ToolCall{ID: "call_1", Name: "flaky"}
+
+	t.Run("transient failure is retried", func(t *testing.T) {
+		registry := NewToolRegistry()
+		registry.SetRetryPolicy(policy)
+		tool := &flakyTool{failures: 2, err: errors.New("connection reset"), idempotent: true}
+		registry.Register("flaky", tool)
+
+		result := registry.Execute(context.Background(), call)
+		if result.IsError {
+			t.Fatalf("expected success after retries, got %v", result.Error)
+		}
+		if result.Attempts != 3 || tool.getExecCount() != 3 {
+			t.Errorf("attempts = %d, executions = %d, want 3", result.Attempts, tool.getExecCount())
+		}
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		registry := NewToolRegistry()
+		registry.SetRetryPolicy(policy)
+		tool := &flakyTool{failures: 10, err: errors.New("connection reset"), idempotent: true}
+		registry.Register("flaky", tool)
+
+		result := registry.Execute(context.Background(), call)
+		if !result.IsError || result.Attempts != 3 {
+			t.Errorf("expected error after 3 attempts, got is_error=%v attempts=%d", result.IsError, result.Attempts)
+		}
+	})
+
+	t.Run("input errors are not retried", func(t *testing.T) {
+		registry := NewToolRegistry()
+		registry.SetRetryPolicy(policy)
+		tool := &flakyTool{failures: 10, err: invalidInput("missing required parameter: path"), idempotent: true}
+		registry.Register("flaky", tool)
+
+		result := registry.Execute(context.Background(), call)
+		if result.Attempts != 1 {
+			t.Errorf("attempts = %d, want 1", result.Attempts)
+		}
+		if result.Error.Error() != "missing required parameter: path" {
+			t.Errorf("input error message changed: %q", result.Error.Error())
+		}
+	})
+
+	t.Run("non-idempotent tools are not retried", func(t *testing.T) {
+		registry := NewToolRegistry()
+		registry.SetRetryPolicy(policy)
+		tool := &flakyTool{failures: 1, err: errors.New("connection reset"), idempotent: false}
+		registry.Register("flaky", tool)
+
+		result := registry.Execute(context.Background(), call)
+		if !result.IsError || result.Attempts != 1 {
+			t.Errorf("expected single failed attempt, got is_error=%v attempts=%d", result.IsError, result.Attempts)
+		}
+	})
+}
+
+func TestToolRegistry_InputGuard(t *testing.T) {
+	registry := NewToolRegistry()
+	tool := &mockTool{name: "search"}
+	registry.Register("search", tool)
+	registry.SetInputGuard(func(ctx context.Context, call ToolCall) error {
+		if call.Input["query"] == "forbidden" {
+			return errors.New("input refused")
+		}
+		return nil
+	})
+
+	refused := registry.Execute(context.Background(), ToolCall{ID: "call_1", Name: "search", Input: map[string]interface{}{"query": "forbidden"}})
+	if !refused.IsError || refused.Error.Error() != "input refused" || refused.Attempts != 0 {
+		t.Errorf("expected refused call, got is_error=%v error=%v attempts=%d", refused.IsError, refused.Error, refused.Attempts)
+	}
+	if tool.getExecCount() != 0 {
+		t.Errorf("refused call ran the tool")
+	}
+
+	allowed := registry.Execute(context.Background(), ToolCall{ID: "call_2", Name: "search", Input: map[string]interface{}{"query": "dragons"}})
+	if allowed.IsError || tool.getExecCount() != 1 {
+		t.Errorf("expected allowed call to run, got is_error=%v executions=%d", allowed.IsError, tool.getExecCount())
+	}
+}
+
+func TestToolRegistry_ExecuteParallelWithCallbacks(t *testing.T) {
+	registry := NewToolRegistry()
+	registry.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+	registry.Register("flaky", &flakyTool{failures: 2, err: errors.New("connection reset"), idempotent: true})
+	registry.Register("steady", &mockTool{name: "steady"})
+
+	var mu sync.Mutex
+	events := make(map[string][]string)
+	record := func(callID, event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events[callID] = append(events[callID], event)
+	}
+
+	registry.ExecuteParallelWithCallbacks(context.Background(), []ToolCall{
+		{ID: "call_1", Name: "flaky"},
+		{ID: "call_2", Name: "steady"},
+	}, ExecutionCallbacks{
+		OnStart: func(call ToolCall) { record(call.ID, "start") },
+		OnProgress: func(call ToolCall, progress ToolProgress) {
+			record(call.ID, progress.Stage)
+		},
+		OnComplete: func(result ToolResult) { record(result.ID, "complete") },
+	})
+
+	// Each call's events arrive in order; retries are reported as progress
+	want := map[string][]string{
+		"call_1": {"start", ProgressStageRetrying, ProgressStageRetrying, "complete"},
+		"call_2": {"start", "complete"},
+	}
+	for callID, wantEvents := range want {
+		if fmt.Sprint(events[callID]) != fmt.Sprint(wantEvents) {
+			t.Errorf("%s events = %v, want %v", callID, events[callID], wantEvents)
+		}
+	}
+}
+
+func TestReportProgress_Unobserved(t *testing.T) {
+	// Tools report progress unconditionally; without an observer it's dropped
+	ReportProgress(context.Background(), ProgressStageGenerating, "Generating image")
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	for i, w := range want {
+		if got := policy.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
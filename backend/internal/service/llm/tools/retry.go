@@ -0,0 +1,113 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"meridian/internal/domain"
+)
+
+// ErrInvalidInput marks tool errors caused by the model's input (missing parameters,
+// unknown paths). Retrying them can't succeed, so they are never retried.
+var ErrInvalidInput = errors.New("invalid tool input")
+
+// inputError is an ErrInvalidInput that keeps the original message for the model
+type inputError struct {
+	message string
+}
+
+func (e *inputError) Error() string        { return e.message }
+func (e *inputError) Is(target error) bool { return target == ErrInvalidInput }
+
+// invalidInput creates an error for bad tool input, formatted like fmt.Errorf
+func invalidInput(format string, args ...interface{}) error {
+	return &inputError{message: fmt.Sprintf(format, args...)}
+}
+
+// IdempotentTool is implemented by executors that can safely run more than once for the
+// same input (read-only tools). Only idempotent tools are retried.
+type IdempotentTool interface {
+	Idempotent() bool
+}
+
+// RetryPolicy configures retries of transiently failing tools within a round
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per tool call, including the first
+	// (1 or less = no retries)
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry; doubles for each further retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the wait between retries
+	MaxBackoff time.Duration
+}
+
+// NoRetry is the policy of a registry without retries
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// backoff returns the wait before the given retry (1 = first retry)
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	wait := p.InitialBackoff
+	for i := 1; i < retry; i++ {
+		wait *= 2
+		if p.MaxBackoff > 0 && wait >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return wait
+}
+
+// IsTransient reports whether a tool error may succeed on retry.
+// Input errors, missing resources and access errors are permanent; errors can also decide
+// for themselves via a Temporary() method (e.g. external API errors: 429/5xx are temporary).
+// Anything else (database hiccups, network errors) is assumed transient.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return false
+	case errors.Is(err, ErrInvalidInput),
+		errors.Is(err, domain.ErrNotFound),
+		errors.Is(err, domain.ErrValidation),
+		errors.Is(err, domain.ErrForbidden),
+		errors.Is(err, domain.ErrUnauthorized):
+		return false
+	}
+
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) {
+		return temporary.Temporary()
+	}
+
+	return true
+}
+
+// isIdempotent reports whether an executor declared itself safe to retry
+func isIdempotent(executor ToolExecutor) bool {
+	idempotent, ok := executor.(IdempotentTool)
+	return ok && idempotent.Idempotent()
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
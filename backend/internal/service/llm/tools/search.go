@@ -9,6 +9,7 @@ import (
 	"meridian/internal/domain"
 	"meridian/internal/domain/models/docsystem"
 	docsystemRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/utils"
 )
 
 // SearchTool implements the 'search' tool for full-text search across documents.
@@ -37,6 +38,11 @@ func NewSearchTool(
 	}
 }
 
+// Idempotent implements IdempotentTool: searches are read-only, so transient failures are retried.
+func (t *SearchTool) Idempotent() bool {
+	return true
+}
+
 // Execute implements ToolExecutor interface.
 // Input parameters:
 //   - query (string, required): Search query (keywords or phrases)
@@ -50,7 +56,7 @@ func (t *SearchTool) Execute(ctx context.Context, input map[string]interface{})
 	// Validate and extract query
 	query, ok := input["query"].(string)
 	if !ok || strings.TrimSpace(query) == "" {
-		return nil, errors.New("missing required parameter: query (string)")
+		return nil, invalidInput("missing required parameter: query (string)")
 	}
 
 	query = strings.TrimSpace(query)
@@ -63,7 +69,7 @@ func (t *SearchTool) Execute(ctx context.Context, input map[string]interface{})
 			resolvedID, _, err := t.pathResolver.ResolveFolderPath(ctx, folderPath)
 			if err != nil {
 				if errors.Is(err, domain.ErrNotFound) {
-					return nil, fmt.Errorf("folder not found: %s", folderPath)
+					return nil, invalidInput("folder not found: %s", folderPath)
 				}
 				return nil, fmt.Errorf("failed to resolve folder path: %w", err)
 			}
@@ -102,6 +108,8 @@ func (t *SearchTool) Execute(ctx context.Context, input map[string]interface{})
 		Limit:     limit,
 		Offset:    offset,
 		FolderID:  folderID,
+		// Match documents in their project's configured search language (indexed)
+		UseDocumentLanguage: true,
 	}
 
 	// Apply defaults and validate
@@ -121,8 +129,8 @@ func (t *SearchTool) Execute(ctx context.Context, input map[string]interface{})
 	for i, result := range results.Results {
 		// Extract preview from content (first 200 characters)
 		preview := result.Document.Content
-		if len(preview) > 200 {
-			preview = preview[:200] + "..."
+		if utils.RuneLen(preview) > 200 {
+			preview = utils.TruncateRunes(preview, 200) + utils.Ellipsis
 		}
 
 		resultList[i] = map[string]interface{}{
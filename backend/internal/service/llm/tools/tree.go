@@ -36,6 +36,11 @@ func NewTreeTool(
 	}
 }
 
+// Idempotent implements IdempotentTool: listing is read-only, so transient failures are retried.
+func (t *TreeTool) Idempotent() bool {
+	return true
+}
+
 // Execute implements ToolExecutor interface.
 // Input parameters:
 //   - folder (string, required): Unix-style path to folder
@@ -65,7 +70,7 @@ func (t *TreeTool) Execute(ctx context.Context, input map[string]interface{}) (i
 	if depthVal, exists := input["depth"]; exists {
 		depthFloat, ok := depthVal.(float64)
 		if !ok {
-			return nil, errors.New("depth must be a number")
+			return nil, invalidInput("depth must be a number")
 		}
 		depth = int(depthFloat)
 	}
@@ -87,7 +92,7 @@ func (t *TreeTool) Execute(ctx context.Context, input map[string]interface{}) (i
 		resolvedID, resolvedPathStr, err := t.pathResolver.ResolveFolderPath(ctx, folderPath)
 		if err != nil {
 			if errors.Is(err, domain.ErrNotFound) {
-				return nil, fmt.Errorf("folder not found: %s", folderPath)
+				return nil, invalidInput("folder not found: %s", folderPath)
 			}
 			return nil, fmt.Errorf("failed to resolve folder path: %w", err)
 		}
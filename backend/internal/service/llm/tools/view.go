@@ -9,6 +9,7 @@ import (
 	"meridian/internal/domain"
 	"meridian/internal/domain/models/docsystem"
 	docsystemRepo "meridian/internal/domain/repositories/docsystem"
+	"meridian/internal/utils"
 )
 
 // ViewTool implements the 'view' tool for reading document content or listing folder contents.
@@ -39,6 +40,11 @@ func NewViewTool(
 	}
 }
 
+// Idempotent implements IdempotentTool: viewing is read-only, so transient failures are retried.
+func (t *ViewTool) Idempotent() bool {
+	return true
+}
+
 // Execute implements ToolExecutor interface.
 // Input parameters:
 //   - path (string, required): Unix-style path to document or folder
@@ -50,7 +56,7 @@ func (t *ViewTool) Execute(ctx context.Context, input map[string]interface{}) (i
 	// Validate and extract path
 	path, ok := input["path"].(string)
 	if !ok || path == "" {
-		return nil, errors.New("missing required parameter: path (string)")
+		return nil, invalidInput("missing required parameter: path (string)")
 	}
 
 	// Normalize path (trim whitespace, ensure it starts with /)
@@ -84,7 +90,7 @@ func (t *ViewTool) Execute(ctx context.Context, input map[string]interface{}) (i
 	folderID, folderPath, err := t.pathResolver.ResolveFolderPath(ctx, path)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
-			return nil, fmt.Errorf("path not found: %s (tried as both document and folder)", path)
+			return nil, invalidInput("path not found: %s (tried as both document and folder)", path)
 		}
 		return nil, fmt.Errorf("failed to resolve folder path: %w", err)
 	}
@@ -105,8 +111,8 @@ func (t *ViewTool) formatDocument(ctx context.Context, doc *docsystem.Document)
 	wasTruncated := false
 
 	// Truncate content if too large
-	if len(content) > t.config.MaxContentSize {
-		content = content[:t.config.MaxContentSize] + "\n\n[Content truncated - too large to display fully]"
+	if utils.RuneLen(content) > t.config.MaxContentSize {
+		content = utils.TruncateRunes(content, t.config.MaxContentSize) + "\n\n[Content truncated - too large to display fully]"
 		wasTruncated = true
 	}
 
@@ -2,7 +2,6 @@ package tools
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 
@@ -30,6 +29,11 @@ func NewWebSearchTool(
 	}
 }
 
+// Idempotent implements IdempotentTool: searches are read-only, so transient failures are retried.
+func (t *WebSearchTool) Idempotent() bool {
+	return true
+}
+
 // Execute implements ToolExecutor interface.
 // Input parameters:
 //   - query (string, required): Search query
@@ -42,7 +46,7 @@ func (t *WebSearchTool) Execute(ctx context.Context, input map[string]interface{
 	// Validate and extract query
 	query, ok := input["query"].(string)
 	if !ok || strings.TrimSpace(query) == "" {
-		return nil, errors.New("missing required parameter: query (string)")
+		return nil, invalidInput("missing required parameter: query (string)")
 	}
 
 	query = strings.TrimSpace(query)
@@ -67,7 +71,7 @@ func (t *WebSearchTool) Execute(ctx context.Context, input map[string]interface{
 			topic = strings.TrimSpace(topicStr)
 			// Validate topic is one of the allowed values
 			if topic != "" && topic != "general" && topic != "news" && topic != "finance" {
-				return nil, fmt.Errorf("invalid topic '%s': must be 'general', 'news', or 'finance'", topic)
+				return nil, invalidInput("invalid topic '%s': must be 'general', 'news', or 'finance'", topic)
 			}
 		}
 	}
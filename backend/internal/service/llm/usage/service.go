@@ -0,0 +1,185 @@
+// Package usage reports users' LLM spend from the token counts and costs stored on
+// assistant turns (the cost is computed by the streaming executor as each round completes),
+// and from the daily rollups the turn repository maintains as turns finish. Months and
+// days are bucketed in the user's time zone (the locale preference, UTC by default).
+package usage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"meridian/internal/domain"
+	"meridian/internal/domain/models"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/domain/services"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+// Service implements the UsageService interface
+type Service struct {
+	repo        llmRepo.UsageRepository
+	authorizer  services.ResourceAuthorizer
+	preferences services.UserPreferencesService
+	logger      *slog.Logger
+}
+
+// NewService creates a usage service. preferences supplies each user's time zone
+// (nil = UTC unless the request names one).
+func NewService(repo llmRepo.UsageRepository, authorizer services.ResourceAuthorizer, preferences services.UserPreferencesService, logger *slog.Logger) llmSvc.UsageService {
+	return &Service{repo: repo, authorizer: authorizer, preferences: preferences, logger: logger}
+}
+
+// GetUsage validates the grouping and range, then sums the user's turns
+func (s *Service) GetUsage(ctx context.Context, userID string, req *llmSvc.GetUsageRequest) (*llmModels.UsageReport, error) {
+	groupBy := llmModels.UsageGroupBy(req.GroupBy)
+	switch groupBy {
+	case "":
+		groupBy = llmModels.UsageGroupByMonth
+	case llmModels.UsageGroupByChat, llmModels.UsageGroupByProject, llmModels.UsageGroupByMonth:
+	default:
+		return nil, fmt.Errorf("%w: group_by must be one of chat, project, month", domain.ErrValidation)
+	}
+	loc, err := s.location(ctx, userID, req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	from, to := inLocation(req.From, loc), inLocation(req.To, loc)
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, fmt.Errorf("%w: from must be before to", domain.ErrValidation)
+	}
+
+	groups, err := s.repo.GetUsage(ctx, &llmModels.UsageQuery{
+		UserID:   userID,
+		GroupBy:  groupBy,
+		From:     from,
+		To:       to,
+		Location: loc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &llmModels.UsageReport{
+		GroupBy:  groupBy,
+		Timezone: loc.String(),
+		From:     from,
+		To:       to,
+		Groups:   groups,
+	}
+	for _, group := range groups {
+		report.Total.Add(group.UsageTotals)
+	}
+
+	return report, nil
+}
+
+// GetChatUsage authorizes the chat, then reads its rollups
+func (s *Service) GetChatUsage(ctx context.Context, userID, chatID string, req *llmSvc.GetUsageRollupRequest) (*llmModels.UsageRollup, error) {
+	if err := s.authorizer.CanAccessChat(ctx, userID, chatID); err != nil {
+		return nil, err
+	}
+	return s.getRollup(ctx, userID, &llmModels.UsageRollupQuery{ChatID: chatID}, req)
+}
+
+// GetProjectUsage authorizes the project, then reads its rollups
+func (s *Service) GetProjectUsage(ctx context.Context, userID, projectID string, req *llmSvc.GetUsageRollupRequest) (*llmModels.UsageRollup, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+	return s.getRollup(ctx, userID, &llmModels.UsageRollupQuery{ProjectID: projectID}, req)
+}
+
+// getRollup validates the granularity and range, then reads the rollups of the chat or
+// project selected by query
+func (s *Service) getRollup(ctx context.Context, userID string, query *llmModels.UsageRollupQuery, req *llmSvc.GetUsageRollupRequest) (*llmModels.UsageRollup, error) {
+	granularity := llmModels.UsageGranularity(req.Granularity)
+	switch granularity {
+	case "":
+		granularity = llmModels.UsageGranularityDay
+	case llmModels.UsageGranularityDay, llmModels.UsageGranularityMonth:
+	default:
+		return nil, fmt.Errorf("%w: granularity must be one of day, month", domain.ErrValidation)
+	}
+	loc, err := s.location(ctx, userID, req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	from, to := inLocation(req.From, loc), inLocation(req.To, loc)
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, fmt.Errorf("%w: from must be before to", domain.ErrValidation)
+	}
+
+	query.Granularity = granularity
+	query.From = from
+	query.To = to
+	query.Location = loc
+	points, err := s.repo.GetUsageRollup(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	rollup := &llmModels.UsageRollup{
+		Granularity: granularity,
+		Timezone:    loc.String(),
+		From:        from,
+		To:          to,
+		Points:      points,
+	}
+	for _, point := range points {
+		rollup.Total.Add(point.UsageTotals)
+	}
+
+	return rollup, nil
+}
+
+// location resolves the time zone of a request: the timezone parameter, else the user's
+// locale preference, else UTC. A stored zone that no longer loads falls back to UTC.
+func (s *Service) location(ctx context.Context, userID, timezone string) (*time.Location, error) {
+	if timezone != "" {
+		loc, err := models.LoadTimezone(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("%w: timezone: unknown IANA time zone %q", domain.ErrValidation, timezone)
+		}
+		return loc, nil
+	}
+	if s.preferences == nil {
+		return time.UTC, nil
+	}
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid user ID", domain.ErrValidation)
+	}
+	prefs, err := s.preferences.GetPreferences(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	locale, err := prefs.GetLocale()
+	if err != nil {
+		return nil, fmt.Errorf("read locale preferences: %w", err)
+	}
+	loc, err := locale.Location()
+	if err != nil {
+		s.logger.Warn("stored timezone not loadable, using UTC", "user_id", userID, "timezone", locale.Timezone, "error", err)
+		return time.UTC, nil
+	}
+	return loc, nil
+}
+
+// inLocation reads a bound at midnight UTC (a YYYY-MM-DD parameter) as midnight of that
+// date in loc. Other times are kept as they are.
+func inLocation(t *time.Time, loc *time.Location) *time.Time {
+	if t == nil || loc == time.UTC {
+		return t
+	}
+	utc := t.UTC()
+	if !utc.Equal(utc.Truncate(24 * time.Hour)) {
+		return t
+	}
+	local := time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, loc)
+	return &local
+}
@@ -0,0 +1,239 @@
+package usage
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"meridian/internal/domain"
+	"meridian/internal/domain/models"
+	llmModels "meridian/internal/domain/models/llm"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/domain/services"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+// fakeRepo returns fixed groups and points and records the queries
+type fakeRepo struct {
+	llmRepo.UsageRepository
+	groups      []llmModels.UsageGroup
+	query       *llmModels.UsageQuery
+	points      []llmModels.UsagePoint
+	rollupQuery *llmModels.UsageRollupQuery
+}
+
+func (r *fakeRepo) GetUsage(ctx context.Context, query *llmModels.UsageQuery) ([]llmModels.UsageGroup, error) {
+	r.query = query
+	return r.groups, nil
+}
+
+func (r *fakeRepo) GetUsageRollup(ctx context.Context, query *llmModels.UsageRollupQuery) ([]llmModels.UsagePoint, error) {
+	r.rollupQuery = query
+	return r.points, nil
+}
+
+// ownerAuthorizer grants access to the chat and project of user-1 only
+type ownerAuthorizer struct {
+	services.ResourceAuthorizer
+}
+
+func (ownerAuthorizer) CanAccessChat(ctx context.Context, userID, chatID string) error {
+	if userID != "user-1" || chatID != "chat-1" {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+func (ownerAuthorizer) CanAccessProject(ctx context.Context, userID, projectID string) error {
+	if userID != "user-1" || projectID != "project-1" {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// zonePreferences returns preferences with the given locale timezone
+type zonePreferences struct {
+	services.UserPreferencesService
+	timezone string
+}
+
+func (p zonePreferences) GetPreferences(ctx context.Context, userID uuid.UUID) (*models.UserPreferences, error) {
+	return &models.UserPreferences{
+		UserID:      userID,
+		Preferences: models.JSONMap{"locale": map[string]interface{}{"timezone": p.timezone}},
+	}, nil
+}
+
+func newTestService(repo *fakeRepo) llmSvc.UsageService {
+	return NewService(repo, ownerAuthorizer{}, nil, slog.New(slog.DiscardHandler))
+}
+
+func TestGetUsage_SumsGroups(t *testing.T) {
+	repo := &fakeRepo{groups: []llmModels.UsageGroup{
+		{Key: "2026-09", UsageTotals: llmModels.UsageTotals{Turns: 3, InputTokens: 3000, OutputTokens: 600, CostUSD: 0.25, UnpricedTurns: 1}},
+		{Key: "2026-10", UsageTotals: llmModels.UsageTotals{Turns: 2, InputTokens: 1000, OutputTokens: 400, CostUSD: 0.5}},
+	}}
+
+	report, err := newTestService(repo).GetUsage(context.Background(), "user-1", &llmSvc.GetUsageRequest{})
+	if err != nil {
+		t.Fatalf("GetUsage: %v", err)
+	}
+
+	if repo.query.UserID != "user-1" || repo.query.GroupBy != llmModels.UsageGroupByMonth {
+		t.Errorf("query = %+v, want user-1 grouped by month", repo.query)
+	}
+	want := llmModels.UsageTotals{Turns: 5, InputTokens: 4000, OutputTokens: 1000, CostUSD: 0.75, UnpricedTurns: 1}
+	if report.Total != want {
+		t.Errorf("total = %+v, want %+v", report.Total, want)
+	}
+	if report.GroupBy != llmModels.UsageGroupByMonth || len(report.Groups) != 2 {
+		t.Errorf("report = %+v", report)
+	}
+	if report.Timezone != "UTC" || repo.query.Location != time.UTC {
+		t.Errorf("timezone = %q (query %v), want UTC without preferences", report.Timezone, repo.query.Location)
+	}
+}
+
+func TestGetUsage_Timezone(t *testing.T) {
+	userID := uuid.NewString()
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	instant := time.Date(2026, 10, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		preference string
+		param      string
+		from       *time.Time
+		wantZone   string
+		wantFrom   string
+	}{
+		{"preference", "America/New_York", "", &from, "America/New_York", "2026-10-01T00:00:00-04:00"},
+		{"parameter overrides preference", "America/New_York", "Asia/Tokyo", &from, "Asia/Tokyo", "2026-10-01T00:00:00+09:00"},
+		{"no preference", "", "", &from, "UTC", "2026-10-01T00:00:00Z"},
+		{"stale preference falls back to UTC", "Mars/Olympus_Mons", "", &from, "UTC", "2026-10-01T00:00:00Z"},
+		{"time kept as given", "Europe/Paris", "", &instant, "Europe/Paris", "2026-10-01T12:30:00Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepo{}
+			svc := NewService(repo, ownerAuthorizer{}, zonePreferences{timezone: tt.preference}, slog.New(slog.DiscardHandler))
+
+			report, err := svc.GetUsage(context.Background(), userID, &llmSvc.GetUsageRequest{From: tt.from, Timezone: tt.param})
+			if err != nil {
+				t.Fatalf("GetUsage: %v", err)
+			}
+
+			if report.Timezone != tt.wantZone || repo.query.Location.String() != tt.wantZone {
+				t.Errorf("timezone = %q (query %v), want %q", report.Timezone, repo.query.Location, tt.wantZone)
+			}
+			if got := repo.query.From.Format(time.RFC3339); got != tt.wantFrom {
+				t.Errorf("from = %s, want %s", got, tt.wantFrom)
+			}
+		})
+	}
+}
+
+func TestGetUsage_Validation(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, -1, 0)
+
+	tests := []struct {
+		name string
+		req  llmSvc.GetUsageRequest
+	}{
+		{"unknown grouping", llmSvc.GetUsageRequest{GroupBy: "model"}},
+		{"from after to", llmSvc.GetUsageRequest{GroupBy: "chat", From: &from, To: &to}},
+		{"empty range", llmSvc.GetUsageRequest{From: &from, To: &from}},
+		{"unknown timezone", llmSvc.GetUsageRequest{Timezone: "Mars/Olympus_Mons"}},
+		{"server timezone", llmSvc.GetUsageRequest{Timezone: "Local"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepo{}
+			_, err := newTestService(repo).GetUsage(context.Background(), "user-1", &tt.req)
+			if !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("err = %v, want ErrValidation", err)
+			}
+			if repo.query != nil {
+				t.Error("repository queried for an invalid request")
+			}
+		})
+	}
+}
+
+func TestGetProjectUsage_SumsPoints(t *testing.T) {
+	repo := &fakeRepo{points: []llmModels.UsagePoint{
+		{Period: "2026-10-14", Model: "claude-haiku-4-5", UsageTotals: llmModels.UsageTotals{Turns: 2, InputTokens: 2000, OutputTokens: 300, CostUSD: 0.5}},
+		{Period: "2026-10-15", Model: "claude-haiku-4-5", UsageTotals: llmModels.UsageTotals{Turns: 1, InputTokens: 500, OutputTokens: 100, CostUSD: 0.25}},
+		{Period: "2026-10-15", Model: "local-model", UsageTotals: llmModels.UsageTotals{Turns: 1, InputTokens: 700, OutputTokens: 50, UnpricedTurns: 1}},
+	}}
+
+	rollup, err := newTestService(repo).GetProjectUsage(context.Background(), "user-1", "project-1", &llmSvc.GetUsageRollupRequest{})
+	if err != nil {
+		t.Fatalf("GetProjectUsage: %v", err)
+	}
+
+	if repo.rollupQuery.ProjectID != "project-1" || repo.rollupQuery.ChatID != "" || repo.rollupQuery.Granularity != llmModels.UsageGranularityDay {
+		t.Errorf("query = %+v, want project-1 by day", repo.rollupQuery)
+	}
+	want := llmModels.UsageTotals{Turns: 4, InputTokens: 3200, OutputTokens: 450, CostUSD: 0.75, UnpricedTurns: 1}
+	if rollup.Total != want {
+		t.Errorf("total = %+v, want %+v", rollup.Total, want)
+	}
+	if rollup.Granularity != llmModels.UsageGranularityDay || len(rollup.Points) != 3 {
+		t.Errorf("rollup = %+v", rollup)
+	}
+	if rollup.Timezone != "UTC" {
+		t.Errorf("timezone = %q, want UTC", rollup.Timezone)
+	}
+}
+
+func TestGetChatUsage_Timezone(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	repo := &fakeRepo{}
+
+	rollup, err := newTestService(repo).GetChatUsage(context.Background(), "user-1", "chat-1", &llmSvc.GetUsageRollupRequest{From: &from, Timezone: "Pacific/Auckland"})
+	if err != nil {
+		t.Fatalf("GetChatUsage: %v", err)
+	}
+
+	if rollup.Timezone != "Pacific/Auckland" || repo.rollupQuery.Location.String() != "Pacific/Auckland" {
+		t.Errorf("timezone = %q (query %v), want Pacific/Auckland", rollup.Timezone, repo.rollupQuery.Location)
+	}
+	if got := repo.rollupQuery.From.Format(time.DateOnly); got != "2026-10-01" {
+		t.Errorf("from day = %s, want 2026-10-01 in Auckland", got)
+	}
+}
+
+func TestGetChatUsage_Rejected(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, -1, 0)
+
+	tests := []struct {
+		name    string
+		userID  string
+		chatID  string
+		req     llmSvc.GetUsageRollupRequest
+		wantErr error
+	}{
+		{"unknown granularity", "user-1", "chat-1", llmSvc.GetUsageRollupRequest{Granularity: "week"}, domain.ErrValidation},
+		{"from after to", "user-1", "chat-1", llmSvc.GetUsageRollupRequest{From: &from, To: &to}, domain.ErrValidation},
+		{"unknown timezone", "user-1", "chat-1", llmSvc.GetUsageRollupRequest{Timezone: "UTC+2"}, domain.ErrValidation},
+		{"another user's chat", "user-2", "chat-1", llmSvc.GetUsageRollupRequest{}, domain.ErrForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepo{}
+			_, err := newTestService(repo).GetChatUsage(context.Background(), tt.userID, tt.chatID, &tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if repo.rollupQuery != nil {
+				t.Error("repository queried for a rejected request")
+			}
+		})
+	}
+}
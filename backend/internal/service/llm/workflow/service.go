@@ -0,0 +1,324 @@
+// Package workflow implements WorkflowService: per-project prompt sequences that are
+// started as chats, with the user's progress through the steps tracked per chat.
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"meridian/internal/config"
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+	"meridian/internal/domain/repositories"
+	llmRepo "meridian/internal/domain/repositories/llm"
+	"meridian/internal/domain/services"
+	llmSvc "meridian/internal/domain/services/llm"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// maxDescriptionLength bounds a workflow's description (characters)
+const maxDescriptionLength = 2000
+
+// Service implements the WorkflowService interface
+type Service struct {
+	workflowRepo llmRepo.WorkflowRepository
+	chatRepo     llmRepo.ChatRepository
+	txManager    repositories.TransactionManager
+	authorizer   services.ResourceAuthorizer
+	logger       *slog.Logger
+}
+
+// NewService creates a new workflow service
+func NewService(
+	workflowRepo llmRepo.WorkflowRepository,
+	chatRepo llmRepo.ChatRepository,
+	txManager repositories.TransactionManager,
+	authorizer services.ResourceAuthorizer,
+	logger *slog.Logger,
+) llmSvc.WorkflowService {
+	return &Service{
+		workflowRepo: workflowRepo,
+		chatRepo:     chatRepo,
+		txManager:    txManager,
+		authorizer:   authorizer,
+		logger:       logger,
+	}
+}
+
+// CreateWorkflow validates and stores a new workflow
+func (s *Service) CreateWorkflow(ctx context.Context, userID, projectID string, req *llmSvc.CreateWorkflowRequest) (*llmModels.Workflow, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	workflow := &llmModels.Workflow{
+		ProjectID:    projectID,
+		Name:         strings.TrimSpace(req.Name),
+		Description:  strings.TrimSpace(req.Description),
+		SystemPrompt: req.SystemPrompt,
+		Steps:        req.Steps,
+	}
+	if err := validateWorkflow(workflow); err != nil {
+		return nil, err
+	}
+
+	if err := s.workflowRepo.CreateWorkflow(ctx, workflow); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("workflow created",
+		"id", workflow.ID,
+		"project_id", projectID,
+		"steps", len(workflow.Steps),
+	)
+
+	return withPlaceholders(workflow), nil
+}
+
+// ListWorkflows returns a project's workflows
+func (s *Service) ListWorkflows(ctx context.Context, userID, projectID string) ([]llmModels.Workflow, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	workflows, err := s.workflowRepo.ListWorkflows(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range workflows {
+		withPlaceholders(&workflows[i])
+	}
+	return workflows, nil
+}
+
+// GetWorkflow returns a workflow
+func (s *Service) GetWorkflow(ctx context.Context, userID, projectID, workflowID string) (*llmModels.Workflow, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.workflowRepo.GetWorkflow(ctx, workflowID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return withPlaceholders(workflow), nil
+}
+
+// UpdateWorkflow applies a partial update to a workflow. Chats already started from it
+// keep the steps they were started with.
+func (s *Service) UpdateWorkflow(ctx context.Context, userID, projectID, workflowID string, req *llmSvc.UpdateWorkflowRequest) (*llmModels.Workflow, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.workflowRepo.GetWorkflow(ctx, workflowID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name.IsSet() {
+		if req.Name.IsNull() {
+			return nil, fmt.Errorf("%w: name: cannot be null", domain.ErrValidation)
+		}
+		name, _ := req.Name.Value()
+		workflow.Name = strings.TrimSpace(name)
+	}
+	if req.Description.IsSet() {
+		description, _ := req.Description.Value()
+		workflow.Description = strings.TrimSpace(description)
+	}
+	if req.SystemPrompt.IsSet() {
+		workflow.SystemPrompt = req.SystemPrompt.Ptr()
+	}
+	if req.Steps.IsSet() {
+		workflow.Steps, _ = req.Steps.Value()
+	}
+
+	if err := validateWorkflow(workflow); err != nil {
+		return nil, err
+	}
+	if err := s.workflowRepo.UpdateWorkflow(ctx, workflow); err != nil {
+		return nil, err
+	}
+
+	return withPlaceholders(workflow), nil
+}
+
+// DeleteWorkflow removes a workflow; chats started from it keep their runs
+func (s *Service) DeleteWorkflow(ctx context.Context, userID, projectID, workflowID string) error {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return err
+	}
+
+	if err := s.workflowRepo.DeleteWorkflow(ctx, workflowID, projectID); err != nil {
+		return err
+	}
+
+	s.logger.Info("workflow deleted", "id", workflowID, "project_id", projectID)
+	return nil
+}
+
+// StartWorkflow creates a chat for the workflow and a run at its first step
+func (s *Service) StartWorkflow(ctx context.Context, userID, projectID, workflowID string, req *llmSvc.StartWorkflowRequest) (*llmSvc.StartedWorkflow, error) {
+	if err := s.authorizer.CanAccessProject(ctx, userID, projectID); err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.workflowRepo.GetWorkflow(ctx, workflowID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := renderSteps(workflow.Steps, req.Values)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrValidation, err)
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		title = workflow.Name
+	}
+	if err := validation.Validate(title, validation.RuneLength(1, config.MaxChatTitleLength)); err != nil {
+		return nil, fmt.Errorf("%w: title: %v", domain.ErrValidation, err)
+	}
+
+	// Only keep values the workflow uses
+	values := make(map[string]string)
+	for _, name := range placeholders(workflow.Steps) {
+		values[name] = req.Values[name]
+	}
+
+	now := time.Now()
+	chat := &llmModels.Chat{
+		ProjectID: projectID,
+		UserID:    userID,
+		Title:     title,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	run := &llmModels.WorkflowRun{
+		WorkflowID:   &workflow.ID,
+		WorkflowName: workflow.Name,
+		Values:       values,
+		Steps:        steps,
+		Status:       llmModels.WorkflowRunActive,
+	}
+
+	err = s.txManager.ExecTx(ctx, func(txCtx context.Context) error {
+		if err := s.chatRepo.CreateChat(txCtx, chat); err != nil {
+			return err
+		}
+		if workflow.SystemPrompt != nil {
+			chat.SystemPrompt = workflow.SystemPrompt
+			if err := s.chatRepo.UpdateChat(txCtx, chat); err != nil {
+				return err
+			}
+		}
+		run.ChatID = chat.ID
+		return s.workflowRepo.CreateRun(txCtx, run)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("workflow started",
+		"workflow_id", workflow.ID,
+		"chat_id", chat.ID,
+		"run_id", run.ID,
+		"project_id", projectID,
+	)
+
+	return &llmSvc.StartedWorkflow{Chat: chat, Run: run}, nil
+}
+
+// GetRun returns the workflow run of a chat
+func (s *Service) GetRun(ctx context.Context, userID, chatID string) (*llmModels.WorkflowRun, error) {
+	if err := s.authorizer.CanAccessChat(ctx, userID, chatID); err != nil {
+		return nil, err
+	}
+
+	return s.workflowRepo.GetRunByChat(ctx, chatID)
+}
+
+// AdvanceRun moves a chat's run past its current step
+func (s *Service) AdvanceRun(ctx context.Context, userID, chatID string, req *llmSvc.AdvanceWorkflowRunRequest) (*llmModels.WorkflowRun, error) {
+	if err := s.authorizer.CanAccessChat(ctx, userID, chatID); err != nil {
+		return nil, err
+	}
+
+	run, err := s.workflowRepo.GetRunByChat(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromStep := run.CurrentStep
+	if err := advance(run, req.Step, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.workflowRepo.UpdateRunProgress(ctx, run, fromStep); err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("workflow run advanced",
+		"run_id", run.ID,
+		"chat_id", chatID,
+		"step", run.CurrentStep,
+		"status", run.Status,
+	)
+
+	return run, nil
+}
+
+// advance moves run to its next step, completing it after the last one.
+// step, when given, must be the run's current step.
+func advance(run *llmModels.WorkflowRun, step *int, now time.Time) error {
+	if run.Status == llmModels.WorkflowRunCompleted {
+		return fmt.Errorf("%w: workflow is already completed", domain.ErrConflict)
+	}
+	if step != nil && *step != run.CurrentStep {
+		return fmt.Errorf("%w: workflow is at step %d, not %d", domain.ErrConflict, run.CurrentStep, *step)
+	}
+
+	run.CurrentStep++
+	if run.CurrentStep >= len(run.Steps) {
+		run.CurrentStep = len(run.Steps)
+		run.Status = llmModels.WorkflowRunCompleted
+		run.CompletedAt = &now
+	}
+	return nil
+}
+
+// validateWorkflow checks a workflow's fields and steps
+func validateWorkflow(workflow *llmModels.Workflow) error {
+	err := validation.ValidateStruct(workflow,
+		validation.Field(&workflow.Name, validation.Required, validation.RuneLength(1, config.MaxWorkflowNameLength)),
+		validation.Field(&workflow.Description, validation.RuneLength(0, maxDescriptionLength)),
+		validation.Field(&workflow.Steps, validation.Required, validation.Length(1, config.MaxWorkflowSteps)),
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrValidation, err)
+	}
+
+	for i := range workflow.Steps {
+		step := &workflow.Steps[i]
+		step.Title = strings.TrimSpace(step.Title)
+		if step.Title == "" || utf8.RuneCountInString(step.Title) > config.MaxWorkflowNameLength {
+			return fmt.Errorf("%w: steps[%d].title: must be 1-%d characters", domain.ErrValidation, i, config.MaxWorkflowNameLength)
+		}
+		if strings.TrimSpace(step.Prompt) == "" || utf8.RuneCountInString(step.Prompt) > config.MaxWorkflowPromptLength {
+			return fmt.Errorf("%w: steps[%d].prompt: must be 1-%d characters", domain.ErrValidation, i, config.MaxWorkflowPromptLength)
+		}
+	}
+	return nil
+}
+
+// withPlaceholders sets the workflow's derived placeholder list
+func withPlaceholders(workflow *llmModels.Workflow) *llmModels.Workflow {
+	workflow.Placeholders = placeholders(workflow.Steps)
+	return workflow
+}
@@ -0,0 +1,112 @@
+package workflow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"meridian/internal/domain"
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+var testSteps = []llmModels.WorkflowStep{
+	{Title: "Outline", Prompt: "Outline a {{ genre }} story about {{topic}}."},
+	{Title: "Draft {{chapter}}", Prompt: "Draft chapter {{chapter}} of the {{genre}} outline."},
+	{Title: "Critique", Prompt: "Critique the draft."},
+}
+
+func TestPlaceholders(t *testing.T) {
+	got := placeholders(testSteps)
+	if strings.Join(got, ",") != "genre,topic,chapter" {
+		t.Errorf("placeholders = %v", got)
+	}
+	if got := placeholders(nil); got == nil || len(got) != 0 {
+		t.Errorf("placeholders(nil) = %#v, want empty slice", got)
+	}
+}
+
+func TestRenderSteps(t *testing.T) {
+	rendered, err := renderSteps(testSteps, map[string]string{"genre": "noir", "topic": "a harbor", "chapter": "1"})
+	if err != nil {
+		t.Fatalf("renderSteps: %v", err)
+	}
+	if rendered[0].Prompt != "Outline a noir story about a harbor." {
+		t.Errorf("prompt = %q", rendered[0].Prompt)
+	}
+	if rendered[1].Title != "Draft 1" {
+		t.Errorf("title = %q", rendered[1].Title)
+	}
+	if testSteps[0].Prompt != "Outline a {{ genre }} story about {{topic}}." {
+		t.Error("renderSteps modified its input")
+	}
+
+	_, err = renderSteps(testSteps, map[string]string{"genre": "noir", "topic": "  "})
+	if err == nil || err.Error() != "missing values for: topic, chapter" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	now := time.Now()
+	run := &llmModels.WorkflowRun{Steps: testSteps, Status: llmModels.WorkflowRunActive}
+
+	if err := advance(run, nil, now); err != nil || run.CurrentStep != 1 {
+		t.Fatalf("advance = %v, step %d", err, run.CurrentStep)
+	}
+
+	stale := 0
+	if err := advance(run, &stale, now); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("advance from stale step: err = %v, want ErrConflict", err)
+	}
+
+	current := 1
+	if err := advance(run, &current, now); err != nil || run.CurrentStep != 2 {
+		t.Fatalf("advance = %v, step %d", err, run.CurrentStep)
+	}
+	if err := advance(run, nil, now); err != nil {
+		t.Fatalf("advance past last step: %v", err)
+	}
+	if run.Status != llmModels.WorkflowRunCompleted || run.CompletedAt == nil || run.NextStep() != nil {
+		t.Errorf("run = %+v, want completed", run)
+	}
+
+	if err := advance(run, nil, now); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("advance completed run: err = %v, want ErrConflict", err)
+	}
+}
+
+func TestValidateWorkflow(t *testing.T) {
+	valid := func() *llmModels.Workflow {
+		return &llmModels.Workflow{Name: "Chapter loop", Steps: []llmModels.WorkflowStep{{Title: " Draft ", Prompt: "Draft it."}}}
+	}
+
+	workflow := valid()
+	if err := validateWorkflow(workflow); err != nil {
+		t.Fatalf("validateWorkflow: %v", err)
+	}
+	if workflow.Steps[0].Title != "Draft" {
+		t.Errorf("title not trimmed: %q", workflow.Steps[0].Title)
+	}
+
+	tests := map[string]func(*llmModels.Workflow){
+		"no name":      func(w *llmModels.Workflow) { w.Name = "" },
+		"no steps":     func(w *llmModels.Workflow) { w.Steps = nil },
+		"blank title":  func(w *llmModels.Workflow) { w.Steps[0].Title = " " },
+		"blank prompt": func(w *llmModels.Workflow) { w.Steps[0].Prompt = "\n" },
+		"too many steps": func(w *llmModels.Workflow) {
+			for len(w.Steps) <= 20 {
+				w.Steps = append(w.Steps, w.Steps[0])
+			}
+		},
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			workflow := valid()
+			mutate(workflow)
+			if err := validateWorkflow(workflow); !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("err = %v, want ErrValidation", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	llmModels "meridian/internal/domain/models/llm"
+)
+
+// placeholderPattern matches {{name}} (spaces inside the braces are allowed)
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+// placeholders returns the distinct placeholder names in the steps' prompts and titles,
+// in order of first appearance
+func placeholders(steps []llmModels.WorkflowStep) []string {
+	names := []string{}
+	seen := make(map[string]bool)
+	for _, step := range steps {
+		for _, text := range []string{step.Title, step.Prompt} {
+			for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+				if !seen[match[1]] {
+					seen[match[1]] = true
+					names = append(names, match[1])
+				}
+			}
+		}
+	}
+	return names
+}
+
+// renderSteps fills every placeholder in the steps with values.
+// Returns an error naming the placeholders without a (non-blank) value.
+func renderSteps(steps []llmModels.WorkflowStep, values map[string]string) ([]llmModels.WorkflowStep, error) {
+	var missing []string
+	for _, name := range placeholders(steps) {
+		if strings.TrimSpace(values[name]) == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing values for: %s", strings.Join(missing, ", "))
+	}
+
+	replace := func(text string) string {
+		return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+			return values[placeholderPattern.FindStringSubmatch(match)[1]]
+		})
+	}
+	rendered := make([]llmModels.WorkflowStep, len(steps))
+	for i, step := range steps {
+		rendered[i] = llmModels.WorkflowStep{Title: replace(step.Title), Prompt: replace(step.Prompt)}
+	}
+	return rendered, nil
+}
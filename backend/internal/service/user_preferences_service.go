@@ -5,10 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"golang.org/x/text/language"
+	"meridian/internal/domain"
 	"meridian/internal/domain/models"
+	llmModels "meridian/internal/domain/models/llm"
 	"meridian/internal/domain/repositories"
 	"meridian/internal/domain/services"
 )
@@ -46,6 +50,15 @@ func (s *UserPreferencesService) getDefaultPreferences(userID uuid.UUID) *models
 			"editor":              map[string]interface{}{},
 			"system_instructions": nil,
 			"notifications":       map[string]interface{}{},
+			"data_controls": map[string]interface{}{
+				"blocked_providers":           []string{},
+				"policy_acknowledged_version": nil,
+				"policy_acknowledged_at":      nil,
+			},
+			"locale": map[string]interface{}{
+				"timezone": "",
+				"locale":   "",
+			},
 		},
 		CreatedAt: now,
 		UpdatedAt: now,
@@ -115,6 +128,18 @@ func (s *UserPreferencesService) UpdatePreferences(ctx context.Context, userID u
 		}
 	}
 
+	if req.DataControls != nil {
+		if err := s.updateDataControlsNamespace(existing, req.DataControls); err != nil {
+			return nil, fmt.Errorf("update data_controls namespace: %w", err)
+		}
+	}
+
+	if req.Locale != nil {
+		if err := s.updateLocaleNamespace(existing, req.Locale); err != nil {
+			return nil, fmt.Errorf("update locale namespace: %w", err)
+		}
+	}
+
 	// Update timestamp
 	existing.UpdatedAt = time.Now()
 
@@ -130,6 +155,8 @@ func (s *UserPreferencesService) UpdatePreferences(ctx context.Context, userID u
 		"has_editor", req.Editor != nil,
 		"has_system_instructions", req.SystemInstructions != nil,
 		"has_notifications", req.Notifications != nil,
+		"has_data_controls", req.DataControls != nil,
+		"has_locale", req.Locale != nil,
 	)
 
 	return existing, nil
@@ -199,3 +226,78 @@ func (s *UserPreferencesService) updateNotificationsNamespace(prefs *models.User
 	prefs.Preferences["notifications"] = notificationsMap
 	return nil
 }
+
+// updateDataControlsNamespace validates and updates the data_controls namespace in
+// preferences. The acknowledgment time is kept while the acknowledged version stays the
+// same and set to now when it changes.
+func (s *UserPreferencesService) updateDataControlsNamespace(prefs *models.UserPreferences, dataControls *models.DataControlsPreferences) error {
+	blocked := make([]string, 0, len(dataControls.BlockedProviders))
+	for _, provider := range dataControls.BlockedProviders {
+		if !llmModels.IsDisclosedProvider(provider) {
+			return fmt.Errorf("%w: blocked_providers: unknown provider %q", domain.ErrValidation, provider)
+		}
+		if !slices.Contains(blocked, provider) {
+			blocked = append(blocked, provider)
+		}
+	}
+
+	version := dataControls.PolicyAcknowledgedVersion
+	if version != nil && *version != llmModels.ContentPolicyVersion {
+		return fmt.Errorf("%w: policy_acknowledged_version: must be the current policy version %q",
+			domain.ErrValidation, llmModels.ContentPolicyVersion)
+	}
+
+	current, err := prefs.GetDataControls()
+	if err != nil {
+		return err
+	}
+	var acknowledgedAt *time.Time
+	switch {
+	case version == nil:
+	case current.PolicyAcknowledgedVersion != nil && *current.PolicyAcknowledgedVersion == *version:
+		acknowledgedAt = current.PolicyAcknowledgedAt
+	default:
+		now := time.Now().UTC()
+		acknowledgedAt = &now
+	}
+
+	data, err := json.Marshal(&models.DataControlsPreferences{
+		BlockedProviders:          blocked,
+		PolicyAcknowledgedVersion: version,
+		PolicyAcknowledgedAt:      acknowledgedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	var dataControlsMap map[string]interface{}
+	if err := json.Unmarshal(data, &dataControlsMap); err != nil {
+		return err
+	}
+
+	prefs.Preferences["data_controls"] = dataControlsMap
+	return nil
+}
+
+// updateLocaleNamespace validates and updates the locale namespace in preferences. The
+// locale is stored in its canonical form ("en-gb" becomes "en-GB").
+func (s *UserPreferencesService) updateLocaleNamespace(prefs *models.UserPreferences, locale *models.LocalePreferences) error {
+	if _, err := locale.Location(); err != nil {
+		return fmt.Errorf("%w: timezone: unknown IANA time zone %q", domain.ErrValidation, locale.Timezone)
+	}
+
+	tag := ""
+	if locale.Locale != "" {
+		parsed, err := language.Parse(locale.Locale)
+		if err != nil {
+			return fmt.Errorf("%w: locale: invalid BCP 47 language tag %q", domain.ErrValidation, locale.Locale)
+		}
+		tag = parsed.String()
+	}
+
+	prefs.Preferences["locale"] = map[string]interface{}{
+		"timezone": locale.Timezone,
+		"locale":   tag,
+	}
+	return nil
+}
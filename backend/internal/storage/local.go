@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"meridian/internal/domain"
+	"meridian/internal/domain/services"
+)
+
+// LocalRoutePrefix is where LocalStore serves signed downloads (see ServeHTTP)
+const LocalRoutePrefix = "/storage/"
+
+var _ services.ObjectStore = (*LocalStore)(nil)
+
+// LocalStore keeps objects as files under a root directory. Signed URLs point back at
+// this server (LocalRoutePrefix) and carry an expiry and an HMAC of key+expiry, so they
+// work without a session. The content type is derived from the key's extension.
+type LocalStore struct {
+	root      string
+	publicURL string
+	secret    []byte
+	now       func() time.Time
+}
+
+// NewLocalStore creates the root directory if needed. An empty secret gets a random
+// one, so signed URLs stop working when the process restarts.
+func NewLocalStore(root, publicURL, secret string) (*LocalStore, error) {
+	if root == "" {
+		return nil, fmt.Errorf("local storage directory is required")
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("create storage directory: %w", err)
+	}
+
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("generate signing secret: %w", err)
+		}
+	}
+
+	return &LocalStore{
+		root:      root,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+		secret:    key,
+		now:       time.Now,
+	}, nil
+}
+
+func (s *LocalStore) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(key))
+}
+
+// Put writes to a temporary file and renames it, so readers never see a partial object
+func (s *LocalStore) Put(ctx context.Context, key, contentType string, data []byte) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	target := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("put object: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("put object: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("put object: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("put object: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("put object: %w", err)
+	}
+	return nil
+}
+
+// Get reads an object
+func (s *LocalStore) Get(ctx context.Context, key string) (*services.StoredObject, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("object %s: %w", key, domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("get object: %w", err)
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &services.StoredObject{ContentType: contentType, Data: data}, nil
+}
+
+// Exists reports whether an object file exists
+func (s *LocalStore) Exists(ctx context.Context, key string) (bool, error) {
+	if err := validateKey(key); err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(s.path(key)); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("stat object: %w", err)
+	}
+	return true, nil
+}
+
+// Delete removes an object
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("delete object: %w", err)
+	}
+	return nil
+}
+
+// SignedURL returns {publicURL}/storage/{key}?expires=...&signature=...
+func (s *LocalStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(s.now().Add(ttl).Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(key, expires))
+	return s.publicURL + LocalRoutePrefix + (&url.URL{Path: key}).EscapedPath() + "?" + query.Encode(), nil
+}
+
+func (s *LocalStore) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verify checks a signed URL's expiry and signature
+func (s *LocalStore) verify(key, expires, signature string) bool {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || s.now().Unix() >= expiresAt {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(s.sign(key, expires)))
+}
+
+// ServeHTTP serves signed downloads under LocalRoutePrefix. The signature is the
+// authorization, so this route must bypass the auth middleware.
+func (s *LocalStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, LocalRoutePrefix)
+	query := r.URL.Query()
+	if validateKey(key) != nil || !s.verify(key, query.Get("expires"), query.Get("signature")) {
+		http.Error(w, "invalid or expired link", http.StatusForbidden)
+		return
+	}
+
+	object, err := s.Get(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		http.Error(w, "failed to read object", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", object.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(object.Data)))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write(object.Data)
+}
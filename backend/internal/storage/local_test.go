@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"meridian/internal/domain"
+)
+
+func newTestLocalStore(t *testing.T) *LocalStore {
+	t.Helper()
+	store, err := NewLocalStore(t.TempDir(), "http://localhost:8080", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestLocalStore_RoundTrip(t *testing.T) {
+	store := newTestLocalStore(t)
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "exports/p1/book.pdf", "application/pdf", []byte("pdf")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	object, err := store.Get(ctx, "exports/p1/book.pdf")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if string(object.Data) != "pdf" || object.ContentType != "application/pdf" {
+		t.Errorf("Get = %q (%s)", object.Data, object.ContentType)
+	}
+
+	if ok, err := store.Exists(ctx, "exports/p1/book.pdf"); err != nil || !ok {
+		t.Errorf("Exists = %v, %v", ok, err)
+	}
+
+	if err := store.Delete(ctx, "exports/p1/book.pdf"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if ok, err := store.Exists(ctx, "exports/p1/book.pdf"); err != nil || ok {
+		t.Errorf("Exists after delete = %v, %v", ok, err)
+	}
+	if err := store.Delete(ctx, "exports/p1/book.pdf"); err != nil {
+		t.Errorf("Delete of missing object: %v", err)
+	}
+	if _, err := store.Get(ctx, "exports/p1/book.pdf"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Get after delete = %v, want ErrNotFound", err)
+	}
+}
+
+func TestLocalStore_RejectsEscapingKeys(t *testing.T) {
+	store := newTestLocalStore(t)
+	for _, key := range []string{"", "/etc/passwd", "../outside", "a/../../b", "a//b", "dir/"} {
+		if err := store.Put(context.Background(), key, "", nil); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("Put(%q) = %v, want ErrValidation", key, err)
+		}
+	}
+}
+
+func TestLocalStore_SignedURL(t *testing.T) {
+	store := newTestLocalStore(t)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+	store.Put(ctx, "images/a b.png", "image/png", []byte("png"))
+
+	signed, err := store.SignedURL(ctx, "images/a b.png", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(signed, "http://localhost:8080/storage/images/a%20b.png?") {
+		t.Fatalf("unexpected URL %s", signed)
+	}
+	u, _ := url.Parse(signed)
+
+	serve := func(target string) int {
+		rec := httptest.NewRecorder()
+		store.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec.Code
+	}
+
+	if code := serve(u.RequestURI()); code != http.StatusOK {
+		t.Errorf("signed URL = %d, want 200", code)
+	}
+
+	// Signature is bound to the key
+	tampered := strings.Replace(u.RequestURI(), "a%20b.png", "other.png", 1)
+	if code := serve(tampered); code != http.StatusForbidden {
+		t.Errorf("tampered URL = %d, want 403", code)
+	}
+
+	now = now.Add(time.Minute)
+	if code := serve(u.RequestURI()); code != http.StatusForbidden {
+		t.Errorf("expired URL = %d, want 403", code)
+	}
+}
@@ -0,0 +1,337 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"meridian/internal/domain"
+	"meridian/internal/domain/services"
+)
+
+const (
+	// maxPresignTTL is the longest expiry SigV4 presigned URLs accept
+	maxPresignTTL = 7 * 24 * time.Hour
+
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	sigV4Algorithm  = "AWS4-HMAC-SHA256"
+	amzDateFormat   = "20060102T150405Z"
+)
+
+var _ services.ObjectStore = (*S3Store)(nil)
+
+// S3Config configures an S3-compatible backend. Objects are addressed path-style
+// ({Endpoint}/{Bucket}/{key}), which every S3-compatible service accepts.
+type S3Config struct {
+	Endpoint        string // e.g. https://s3.us-east-1.amazonaws.com or https://<ref>.supabase.co/storage/v1/s3
+	Region          string // e.g. us-east-1 (Supabase: the project's region)
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3Store implements ObjectStore against the S3 REST API, signing requests with
+// AWS Signature Version 4 (no SDK dependency)
+type S3Store struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+	now       func() time.Time
+}
+
+// NewS3Store validates cfg and creates the store
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	if cfg.Endpoint == "" || cfg.Region == "" || cfg.Bucket == "" || cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("S3 storage requires endpoint, region, bucket, access key ID and secret access key")
+	}
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+	}
+
+	return &S3Store{
+		endpoint:  endpoint,
+		region:    cfg.Region,
+		bucket:    cfg.Bucket,
+		accessKey: cfg.AccessKeyID,
+		secretKey: cfg.SecretAccessKey,
+		client:    &http.Client{Timeout: 60 * time.Second},
+		now:       time.Now,
+	}, nil
+}
+
+// objectURL returns the path-style URL of key. The path is encoded per SigV4 (each
+// segment by uriEncode), so the wire path and the signed canonical path match.
+func (s *S3Store) objectURL(key string) *url.URL {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+
+	u := *s.endpoint
+	u.RawPath = s.endpoint.EscapedPath() + "/" + uriEncode(s.bucket) + "/" + strings.Join(segments, "/")
+	u.Path = s.endpoint.Path + "/" + s.bucket + "/" + key
+	return &u
+}
+
+// Put uploads an object
+func (s *S3Store) Put(ctx context.Context, key, contentType string, data []byte) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, contentType, data)
+	if err != nil {
+		return fmt.Errorf("put object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("put object: %w", s3Error(resp))
+	}
+	return nil
+}
+
+// Get downloads an object
+func (s *S3Store) Get(ctx context.Context, key string) (*services.StoredObject, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	resp, err := s.do(ctx, http.MethodGet, key, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("get object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("object %s: %w", key, domain.ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get object: %w", s3Error(resp))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("get object: %w", err)
+	}
+	return &services.StoredObject{ContentType: resp.Header.Get("Content-Type"), Data: data}, nil
+}
+
+// Exists checks for an object with a HEAD request
+func (s *S3Store) Exists(ctx context.Context, key string) (bool, error) {
+	if err := validateKey(key); err != nil {
+		return false, err
+	}
+	resp, err := s.do(ctx, http.MethodHead, key, "", nil)
+	if err != nil {
+		return false, fmt.Errorf("head object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("head object: storage returned %s", resp.Status)
+	}
+}
+
+// Delete removes an object (S3 reports success for missing keys too)
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodDelete, key, "", nil)
+	if err != nil {
+		return fmt.Errorf("delete object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("delete object: %w", s3Error(resp))
+	}
+	return nil
+}
+
+// SignedURL returns a presigned GET URL (ttl is capped at 7 days, the SigV4 maximum)
+func (s *S3Store) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	if ttl > maxPresignTTL {
+		ttl = maxPresignTTL
+	}
+	return s.presign(s.objectURL(key), ttl).String(), nil
+}
+
+// do sends a signed request for key
+func (s *S3Store) do(ctx context.Context, method, key, contentType string, body []byte) (*http.Response, error) {
+	u := s.objectURL(key)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.signRequest(req, body)
+	return s.client.Do(req)
+}
+
+// signRequest adds SigV4 Authorization headers, signing host, x-amz-content-sha256 and x-amz-date
+func (s *S3Store) signRequest(req *http.Request, body []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format(amzDateFormat)
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	canonicalHeaders, signedHeaders := canonicalizeHeaders(headers)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := s.scope(now)
+	signature := s.signature(now, amzDate, scope, canonicalRequest)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, s.accessKey, scope, signedHeaders, signature))
+}
+
+// presign returns u with SigV4 query authentication for a GET valid for ttl
+func (s *S3Store) presign(u *url.URL, ttl time.Duration) *url.URL {
+	now := s.now().UTC()
+	amzDate := now.Format(amzDateFormat)
+	scope := s.scope(now)
+
+	query := u.Query()
+	query.Set("X-Amz-Algorithm", sigV4Algorithm)
+	query.Set("X-Amz-Credential", s.accessKey+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.Itoa(int(ttl.Seconds())))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		canonicalQuery(query),
+		"host:" + u.Host + "\n",
+		"host",
+		unsignedPayload,
+	}, "\n")
+	query.Set("X-Amz-Signature", s.signature(now, amzDate, scope, canonicalRequest))
+
+	signed := *u
+	signed.RawQuery = canonicalQuery(query)
+	return &signed
+}
+
+func (s *S3Store) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.region + "/s3/aws4_request"
+}
+
+// signature derives the signing key for the day and signs the canonical request
+func (s *S3Store) signature(now time.Time, amzDate, scope, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{
+		sigV4Algorithm,
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// canonicalizeHeaders returns SigV4 canonical headers (lowercase, sorted) and the signed header list
+func canonicalizeHeaders(headers map[string]string) (string, string) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	return canonical.String(), strings.Join(names, ";")
+}
+
+// canonicalQuery sorts parameters and encodes them per SigV4 (RFC 3986, %20 for spaces)
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(key)+"="+uriEncode(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes everything except RFC 3986 unreserved characters
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Error summarizes a failed response (S3 returns an XML body with a Code and Message)
+func s3Error(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("storage returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
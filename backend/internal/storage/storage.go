@@ -0,0 +1,58 @@
+// Package storage implements services.ObjectStore backends for binary assets.
+//
+// LocalStore keeps objects on disk and serves them through signed URLs under
+// /storage/ on this server; S3Store talks to any S3-compatible service (AWS S3,
+// Supabase Storage's S3 endpoint, Cloudflare R2, MinIO) using presigned URLs.
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"meridian/internal/domain"
+	"meridian/internal/domain/services"
+)
+
+// Backends (Config.Backend)
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// Config selects and configures a backend
+type Config struct {
+	Backend string // BackendLocal (default) or BackendS3
+
+	// Local backend
+	LocalDir      string // Root directory for objects
+	PublicURL     string // Base URL of this server, used in signed URLs
+	SigningSecret string // HMAC key for signed URLs; empty = random per process
+
+	// S3 backend
+	S3 S3Config
+}
+
+// New returns the backend selected by cfg.Backend
+func New(cfg Config) (services.ObjectStore, error) {
+	switch cfg.Backend {
+	case "", BackendLocal:
+		return NewLocalStore(cfg.LocalDir, cfg.PublicURL, cfg.SigningSecret)
+	case BackendS3:
+		return NewS3Store(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q (want %q or %q)", cfg.Backend, BackendLocal, BackendS3)
+	}
+}
+
+// validateKey rejects keys that could escape the store's namespace
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.HasSuffix(key, "/") {
+		return fmt.Errorf("%w: invalid object key %q", domain.ErrValidation, key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("%w: invalid object key %q", domain.ErrValidation, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package testmocks
+
+import (
+	"context"
+	"sync"
+
+	"meridian/internal/domain/services"
+)
+
+var _ services.ResourceAuthorizer = (*Authorizer)(nil)
+
+// AuthCheck records one authorization call
+type AuthCheck struct {
+	Kind       string // "project", "folder", "document", "chat" or "turn"
+	UserID     string
+	ResourceID string
+}
+
+// Authorizer is a ResourceAuthorizer that allows everything unless Err (all checks)
+// or Deny (keyed by resource ID) says otherwise
+type Authorizer struct {
+	Err  error            // Returned by every check when set
+	Deny map[string]error // Per-resource errors, e.g. {"chat-1": domain.ErrForbidden}
+
+	mu          sync.Mutex
+	checks      []AuthCheck
+	invalidated []string
+}
+
+// Checks returns the calls made so far, in order
+func (a *Authorizer) Checks() []AuthCheck {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]AuthCheck(nil), a.checks...)
+}
+
+// Invalidated returns the user IDs passed to InvalidateUser, in order
+func (a *Authorizer) Invalidated() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.invalidated...)
+}
+
+func (a *Authorizer) check(kind, userID, resourceID string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.checks = append(a.checks, AuthCheck{Kind: kind, UserID: userID, ResourceID: resourceID})
+	if a.Err != nil {
+		return a.Err
+	}
+	return a.Deny[resourceID]
+}
+
+func (a *Authorizer) CanAccessProject(ctx context.Context, userID, projectID string) error {
+	return a.check("project", userID, projectID)
+}
+
+func (a *Authorizer) CanAccessFolder(ctx context.Context, userID, folderID string) error {
+	return a.check("folder", userID, folderID)
+}
+
+func (a *Authorizer) CanAccessDocument(ctx context.Context, userID, documentID string) error {
+	return a.check("document", userID, documentID)
+}
+
+func (a *Authorizer) CanAccessChat(ctx context.Context, userID, chatID string) error {
+	return a.check("chat", userID, chatID)
+}
+
+func (a *Authorizer) CanAccessTurn(ctx context.Context, userID, turnID string) error {
+	return a.check("turn", userID, turnID)
+}
+
+func (a *Authorizer) CanAccessTurns(ctx context.Context, userID string, turnIDs []string) error {
+	for _, turnID := range turnIDs {
+		if err := a.check("turn", userID, turnID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *Authorizer) InvalidateUser(userID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.invalidated = append(a.invalidated, userID)
+}
@@ -0,0 +1,41 @@
+package testmocks
+
+import (
+	"fmt"
+
+	"meridian/internal/capabilities"
+)
+
+var _ capabilities.Lookup = (*CapabilityLookup)(nil)
+
+// CapabilityLookup serves fixed model capabilities per provider
+// Unknown providers and models return an error, like capabilities.Registry
+type CapabilityLookup struct {
+	Models map[string][]capabilities.ModelCapabilities // provider -> models
+}
+
+// NewCapabilityLookup returns a lookup with the given models registered under provider
+func NewCapabilityLookup(provider string, models ...capabilities.ModelCapabilities) *CapabilityLookup {
+	return &CapabilityLookup{Models: map[string][]capabilities.ModelCapabilities{provider: models}}
+}
+
+func (l *CapabilityLookup) GetModelCapabilities(provider, model string) (*capabilities.ModelCapabilities, error) {
+	models, ok := l.Models[provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider: %s", provider)
+	}
+	for i := range models {
+		if models[i].ID == model {
+			return &models[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown model %s for provider %s", model, provider)
+}
+
+func (l *CapabilityLookup) ListProviderModels(provider string) ([]capabilities.ModelCapabilities, error) {
+	models, ok := l.Models[provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider: %s", provider)
+	}
+	return models, nil
+}
@@ -0,0 +1,11 @@
+// Package testmocks provides hand-rolled test doubles for the cross-cutting
+// dependencies services take (authorizer, validators, transaction manager,
+// capability lookup, object storage), so service tests can run without Postgres.
+//
+// Each double is usable as a zero value with permissive defaults (everything is
+// allowed, valid and succeeds); set the exported fields to inject failures.
+// Calls are recorded so tests can assert what was checked.
+//
+// This package must only import domain-level packages: service packages import it
+// from their tests, so depending on a service package would create an import cycle.
+package testmocks
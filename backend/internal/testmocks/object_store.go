@@ -0,0 +1,88 @@
+package testmocks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"meridian/internal/domain"
+	"meridian/internal/domain/services"
+)
+
+var _ services.ObjectStore = (*ObjectStore)(nil)
+
+// ObjectStore is an in-memory ObjectStore. Signed URLs are "signed://{key}?ttl={ttl}",
+// so tests can tell which key and lifetime a URL was made for. Err fails every call.
+type ObjectStore struct {
+	Err error // Returned by every call when set
+
+	mu      sync.Mutex
+	objects map[string]services.StoredObject
+}
+
+// Keys returns the stored keys, sorted
+func (s *ObjectStore) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *ObjectStore) Put(ctx context.Context, key, contentType string, data []byte) error {
+	if s.Err != nil {
+		return s.Err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.objects == nil {
+		s.objects = make(map[string]services.StoredObject)
+	}
+	s.objects[key] = services.StoredObject{ContentType: contentType, Data: append([]byte(nil), data...)}
+	return nil
+}
+
+func (s *ObjectStore) Get(ctx context.Context, key string) (*services.StoredObject, error) {
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	object, ok := s.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("object %s: %w", key, domain.ErrNotFound)
+	}
+	return &object, nil
+}
+
+func (s *ObjectStore) Exists(ctx context.Context, key string) (bool, error) {
+	if s.Err != nil {
+		return false, s.Err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.objects[key]
+	return ok, nil
+}
+
+func (s *ObjectStore) Delete(ctx context.Context, key string) error {
+	if s.Err != nil {
+		return s.Err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, key)
+	return nil
+}
+
+func (s *ObjectStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if s.Err != nil {
+		return "", s.Err
+	}
+	return fmt.Sprintf("signed://%s?ttl=%s", key, ttl), nil
+}
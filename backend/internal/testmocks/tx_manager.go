@@ -0,0 +1,39 @@
+package testmocks
+
+import (
+	"context"
+	"sync/atomic"
+
+	"meridian/internal/domain/repositories"
+)
+
+var _ repositories.TransactionManager = (*TxManager)(nil)
+
+type txDepthKey struct{}
+
+// TxManager runs fn directly (no real transaction) and counts calls
+// If Err is set, fn is not run and Err is returned, as if BEGIN failed
+// fn's context is marked so fakes can assert their writes happen inside a transaction
+type TxManager struct {
+	Err   error
+	calls atomic.Int64
+}
+
+// Calls returns how many times ExecTx was called (nested calls included)
+func (tm *TxManager) Calls() int {
+	return int(tm.calls.Load())
+}
+
+// TxDepth returns how many ExecTx calls ctx is nested in (0 = not in a transaction)
+func TxDepth(ctx context.Context) int {
+	depth, _ := ctx.Value(txDepthKey{}).(int)
+	return depth
+}
+
+func (tm *TxManager) ExecTx(ctx context.Context, fn repositories.TxFn) error {
+	tm.calls.Add(1)
+	if tm.Err != nil {
+		return tm.Err
+	}
+	return fn(context.WithValue(ctx, txDepthKey{}, TxDepth(ctx)+1))
+}
@@ -0,0 +1,40 @@
+package testmocks
+
+import (
+	"context"
+
+	docsysSvc "meridian/internal/domain/services/docsystem"
+	llmSvc "meridian/internal/domain/services/llm"
+)
+
+var (
+	_ docsysSvc.ParentValidator = (*ParentValidator)(nil)
+	_ llmSvc.ChatValidator      = (*ChatValidator)(nil)
+)
+
+// ParentValidator is a docsystem ParentValidator; every parent is valid unless
+// ProjectErr / FolderErr is set
+type ParentValidator struct {
+	ProjectErr error
+	FolderErr  error
+}
+
+func (v *ParentValidator) ValidateProject(ctx context.Context, projectID, userID string) error {
+	return v.ProjectErr
+}
+
+func (v *ParentValidator) ValidateFolder(ctx context.Context, folderID, projectID string) error {
+	if folderID == "" {
+		return nil // Root folder is always valid
+	}
+	return v.FolderErr
+}
+
+// ChatValidator is an llm ChatValidator; every chat is valid unless Err is set
+type ChatValidator struct {
+	Err error
+}
+
+func (v *ChatValidator) ValidateChat(ctx context.Context, chatID, userID string) error {
+	return v.Err
+}
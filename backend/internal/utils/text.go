@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Ellipsis is appended by TruncateWithEllipsis
+const Ellipsis = "..."
+
+// Length limits are in characters (runes), matching validation and Postgres VARCHAR(n).
+// Byte slicing (s[:n]) can split a multi-byte rune and produce invalid UTF-8.
+
+// RuneLen returns the number of characters in s
+func RuneLen(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+// TruncateRunes shortens s to at most max characters.
+// The cut never splits a rune and backs off so combining marks, variation selectors,
+// skin-tone modifiers and zero-width joiners stay with their base character
+// (e.g. "👩‍💻" or "é" written as e + U+0301 are kept whole or dropped whole).
+func TruncateRunes(s string, max int) string {
+	if max <= 0 {
+		return ""
+	}
+	if RuneLen(s) <= max {
+		return s
+	}
+
+	runes := []rune(s)
+	cut := max
+	for cut > 0 && (isExtendingRune(runes[cut]) || runes[cut-1] == zeroWidthJoiner) {
+		cut--
+	}
+	if cut == 0 {
+		// A single cluster longer than max: fall back to a plain rune cut
+		cut = max
+	}
+	return string(runes[:cut])
+}
+
+// TruncateWithEllipsis shortens s to at most max characters including the ellipsis.
+// Trailing whitespace before the ellipsis is dropped.
+func TruncateWithEllipsis(s string, max int) string {
+	if RuneLen(s) <= max {
+		return s
+	}
+	if max <= len(Ellipsis) {
+		return TruncateRunes(s, max)
+	}
+	return strings.TrimRightFunc(TruncateRunes(s, max-len(Ellipsis)), unicode.IsSpace) + Ellipsis
+}
+
+const zeroWidthJoiner = '\u200d'
+
+// isExtendingRune reports whether r attaches to the preceding character
+func isExtendingRune(r rune) bool {
+	switch {
+	case r == zeroWidthJoiner:
+		return true
+	case r >= 0xFE00 && r <= 0xFE0F: // Variation selectors (text/emoji presentation)
+		return true
+	case r >= 0x1F3FB && r <= 0x1F3FF: // Emoji skin-tone modifiers
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // Tag characters (subdivision flags)
+		return true
+	}
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc)
+}
+
+// Slugify converts s to a URL slug of at most max characters: lowercase letters and
+// digits of any script separated by single hyphens. Latin accents are removed
+// ("Café Noir" → "cafe-noir"); CJK and other scripts are kept ("第一章 開始" → "第一章-開始");
+// emoji and punctuation become separators. Returns "" when nothing usable remains,
+// so callers should fall back to an ID.
+func Slugify(s string, max int) string {
+	var b strings.Builder
+	pendingHyphen := false
+	var base rune // Last letter or digit written
+	for _, r := range norm.NFKD.String(s) {
+		switch {
+		case unicode.In(r, unicode.Mn, unicode.Mc, unicode.Me):
+			// Accents on Latin letters are dropped; other scripts need their marks
+			// (e.g. Devanagari vowel signs)
+			if base != 0 && !unicode.Is(unicode.Latin, base) && !pendingHyphen {
+				b.WriteRune(r)
+			}
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			base = r
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			pendingHyphen = true
+		}
+	}
+
+	// Recompose so scripts that rely on combining characters (e.g. Hangul jamo) stay compact
+	slug := norm.NFC.String(b.String())
+	if RuneLen(slug) > max {
+		slug = strings.TrimRight(TruncateRunes(slug, max), "-")
+	}
+	return slug
+}
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateRunes(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		max   int
+		want  string
+	}{
+		{"short ascii", "hello", 10, "hello"},
+		{"ascii", "hello world", 5, "hello"},
+		{"cjk", "第一章开始了", 3, "第一章"},
+		{"emoji", "🐉🐉🐉", 2, "🐉🐉"},
+		{"combining accent kept whole", "cafés", 4, "caf"},
+		{"zwj sequence kept whole", "ab👩‍💻", 4, "ab"},
+		{"skin tone kept whole", "a👍🏽", 2, "a"},
+		{"variation selector kept whole", "a❤️", 2, "a"},
+		{"zero max", "abc", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateRunes(tt.input, tt.max)
+			if got != tt.want {
+				t.Errorf("TruncateRunes(%q, %d) = %q, want %q", tt.input, tt.max, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("TruncateRunes(%q, %d) returned invalid UTF-8", tt.input, tt.max)
+			}
+		})
+	}
+}
+
+func TestTruncateWithEllipsis(t *testing.T) {
+	if got := TruncateWithEllipsis("short", 10); got != "short" {
+		t.Errorf("unchanged input = %q", got)
+	}
+	if got := TruncateWithEllipsis("hello world again", 9); got != "hello..." {
+		t.Errorf("ascii = %q", got)
+	}
+
+	// Every result fits the limit and is valid UTF-8, whatever the script
+	long := strings.Repeat("龍の物語🐉", 100)
+	for max := 1; max < 20; max++ {
+		got := TruncateWithEllipsis(long, max)
+		if RuneLen(got) > max || !utf8.ValidString(got) {
+			t.Errorf("TruncateWithEllipsis(long, %d) = %q (%d runes)", max, got, RuneLen(got))
+		}
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Chapter 1: The Dragon's Lair", "chapter-1-the-dragon-s-lair"},
+		{"  Café   Noir!  ", "cafe-noir"},
+		{"Ünïcödé Straße", "unicode-straße"},
+		{"第一章 開始", "第一章-開始"},
+		{"がんばって", "がんばって"},
+		{"한국어 제목", "한국어-제목"},
+		{"नमस्ते दुनिया", "नमस्ते-दुनिया"},
+		{"Dragons 🐉 & Knights ⚔️", "dragons-knights"},
+		{"🐉🔥", ""},
+		{"ﬁle №5", "file-no5"}, // Compatibility forms are decomposed
+	}
+	for _, tt := range tests {
+		if got := Slugify(tt.input, 60); got != tt.want {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	// Truncation doesn't leave a trailing hyphen
+	if got := Slugify("one two three", 8); got != "one-two" {
+		t.Errorf("truncated slug = %q", got)
+	}
+}
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Estimated token size of the resolved system prompt (user + project + chat + skills)
+-- Stored on assistant turns so the token-usage endpoint can report it after the fact
+
+ALTER TABLE ${TABLE_PREFIX}turns ADD COLUMN IF NOT EXISTS system_prompt_tokens INT;
+
+COMMENT ON COLUMN ${TABLE_PREFIX}turns.system_prompt_tokens IS 'Estimated tokens of the resolved system prompt sent with this turn';
+
+-- +goose Down
+ALTER TABLE ${TABLE_PREFIX}turns DROP COLUMN IF EXISTS system_prompt_tokens;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Chat-level defaults applied to new turns that don't specify their own
+-- (selected_skills / request_params.tools absent from the create-turn request)
+
+ALTER TABLE ${TABLE_PREFIX}chats ADD COLUMN IF NOT EXISTS default_skills TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE ${TABLE_PREFIX}chats ADD COLUMN IF NOT EXISTS default_tools TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN ${TABLE_PREFIX}chats.default_skills IS 'Skills loaded for turns that do not select any (names under .skills/)';
+COMMENT ON COLUMN ${TABLE_PREFIX}chats.default_tools IS 'Tool names enabled for turns that do not request any';
+
+-- +goose Down
+ALTER TABLE ${TABLE_PREFIX}chats DROP COLUMN IF EXISTS default_tools;
+ALTER TABLE ${TABLE_PREFIX}chats DROP COLUMN IF EXISTS default_skills;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Project-level settings (GET/PATCH /api/projects/{id}/settings)
+-- system_prompt stays on the projects row; a project without a row here uses the defaults.
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}project_settings (
+    project_id UUID PRIMARY KEY REFERENCES ${TABLE_PREFIX}projects(id) ON DELETE CASCADE,
+    default_model TEXT,
+    tool_policy JSONB NOT NULL DEFAULT '{}',
+    guardrails JSONB NOT NULL DEFAULT '{}',
+    search_language TEXT NOT NULL DEFAULT 'english',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE ${TABLE_PREFIX}project_settings IS 'Project-wide defaults for chats: model, tool policy, guardrails, search language';
+COMMENT ON COLUMN ${TABLE_PREFIX}project_settings.tool_policy IS '{"allowed": [...], "denied": [...]} tool names; empty allowed permits all';
+COMMENT ON COLUMN ${TABLE_PREFIX}project_settings.guardrails IS '{"tool_result_sanitization": "strict", "max_tool_rounds": 5} overrides of server defaults';
+
+-- +goose Down
+DROP TABLE IF EXISTS ${TABLE_PREFIX}project_settings;
@@ -0,0 +1,64 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Per-project full-text search language
+-- Each document carries its project's text search configuration so one pair of
+-- expression indexes serves every language (instead of one index per language).
+
+ALTER TABLE ${TABLE_PREFIX}documents ADD COLUMN IF NOT EXISTS search_language regconfig NOT NULL DEFAULT 'english';
+
+COMMENT ON COLUMN ${TABLE_PREFIX}documents.search_language IS 'Text search configuration, copied from project_settings.search_language';
+
+-- Re-tagging documents after a project language change must not look like an edit
+DROP TRIGGER IF EXISTS update_documents_updated_at ON ${TABLE_PREFIX}documents;
+CREATE TRIGGER update_documents_updated_at
+    BEFORE UPDATE ON ${TABLE_PREFIX}documents
+    FOR EACH ROW
+    WHEN (OLD.search_language IS NOT DISTINCT FROM NEW.search_language)
+    EXECUTE FUNCTION ${TABLE_PREFIX}update_updated_at_column();
+
+-- New documents take their project's language
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION ${TABLE_PREFIX}set_document_search_language()
+RETURNS TRIGGER AS $$$$
+BEGIN
+    NEW.search_language = COALESCE(
+        (SELECT search_language::regconfig FROM ${TABLE_PREFIX}project_settings WHERE project_id = NEW.project_id),
+        'english'::regconfig
+    );
+    RETURN NEW;
+END;
+$$$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER set_documents_search_language
+    BEFORE INSERT ON ${TABLE_PREFIX}documents
+    FOR EACH ROW
+    EXECUTE FUNCTION ${TABLE_PREFIX}set_document_search_language();
+
+UPDATE ${TABLE_PREFIX}documents d
+SET search_language = s.search_language::regconfig
+FROM ${TABLE_PREFIX}project_settings s
+WHERE s.project_id = d.project_id AND d.search_language <> s.search_language::regconfig;
+
+-- Indexes matching to_tsvector(search_language, ...) replace the english-only ones
+CREATE INDEX IF NOT EXISTS idx_documents_content_fts_lang ON ${TABLE_PREFIX}documents USING gin(to_tsvector(search_language, content)) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_documents_name_fts_lang ON ${TABLE_PREFIX}documents USING gin(to_tsvector(search_language, name)) WHERE deleted_at IS NULL;
+DROP INDEX IF EXISTS idx_documents_content_fts_english;
+DROP INDEX IF EXISTS idx_documents_name_fts_english;
+
+-- +goose Down
+CREATE INDEX IF NOT EXISTS idx_documents_content_fts_english ON ${TABLE_PREFIX}documents USING gin(to_tsvector('english', content)) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_documents_name_fts_english ON ${TABLE_PREFIX}documents USING gin(to_tsvector('english', name)) WHERE deleted_at IS NULL;
+DROP INDEX IF EXISTS idx_documents_name_fts_lang;
+DROP INDEX IF EXISTS idx_documents_content_fts_lang;
+
+DROP TRIGGER IF EXISTS set_documents_search_language ON ${TABLE_PREFIX}documents;
+DROP FUNCTION IF EXISTS ${TABLE_PREFIX}set_document_search_language();
+
+DROP TRIGGER IF EXISTS update_documents_updated_at ON ${TABLE_PREFIX}documents;
+CREATE TRIGGER update_documents_updated_at
+    BEFORE UPDATE ON ${TABLE_PREFIX}documents
+    FOR EACH ROW
+    EXECUTE FUNCTION ${TABLE_PREFIX}update_updated_at_column();
+
+ALTER TABLE ${TABLE_PREFIX}documents DROP COLUMN IF EXISTS search_language;
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Folder/document name normalization
+-- Names are now normalized (trimmed, whitespace collapsed) on write and may be compared
+-- case-insensitively (NAME_UNIQUENESS=case_insensitive). Rows written before that can
+-- already contain siblings that only differ in whitespace or case. They are not renamed
+-- automatically; this view lists them so they can be resolved by hand.
+
+CREATE OR REPLACE VIEW ${TABLE_PREFIX}name_near_duplicates AS
+SELECT 'folder' AS kind,
+       project_id,
+       parent_id AS parent_id,
+       lower(regexp_replace(btrim(name), '\s+', ' ', 'g')) AS normalized_name,
+       array_agg(id ORDER BY created_at) AS ids,
+       array_agg(name ORDER BY created_at) AS names
+FROM ${TABLE_PREFIX}folders
+WHERE deleted_at IS NULL
+GROUP BY project_id, parent_id, lower(regexp_replace(btrim(name), '\s+', ' ', 'g'))
+HAVING count(*) > 1
+UNION ALL
+SELECT 'document' AS kind,
+       project_id,
+       folder_id AS parent_id,
+       lower(regexp_replace(btrim(name), '\s+', ' ', 'g')) AS normalized_name,
+       array_agg(id ORDER BY created_at) AS ids,
+       array_agg(name ORDER BY created_at) AS names
+FROM ${TABLE_PREFIX}documents
+WHERE deleted_at IS NULL
+GROUP BY project_id, folder_id, lower(regexp_replace(btrim(name), '\s+', ' ', 'g'))
+HAVING count(*) > 1;
+
+COMMENT ON VIEW ${TABLE_PREFIX}name_near_duplicates IS 'Sibling folders/documents whose names collide after trimming, whitespace collapsing and lowercasing';
+
+-- +goose StatementBegin
+DO $$$$
+DECLARE
+    groups INTEGER;
+BEGIN
+    SELECT count(*) INTO groups FROM ${TABLE_PREFIX}name_near_duplicates;
+    IF groups > 0 THEN
+        RAISE WARNING '% group(s) of near-duplicate sibling names found; see ${TABLE_PREFIX}name_near_duplicates', groups;
+    END IF;
+END
+$$$$;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP VIEW IF EXISTS ${TABLE_PREFIX}name_near_duplicates;
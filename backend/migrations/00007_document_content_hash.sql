@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Content hash per document
+-- SHA-256 (hex) of the Markdown content, kept current by a trigger so every write path
+-- maintains it. Imports compare hashes to skip unchanged files, and sync clients compare
+-- them against local files to avoid re-uploading unchanged content.
+
+ALTER TABLE ${TABLE_PREFIX}documents ADD COLUMN IF NOT EXISTS content_hash TEXT;
+
+COMMENT ON COLUMN ${TABLE_PREFIX}documents.content_hash IS 'Hex SHA-256 of content (UTF-8), maintained by trigger';
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION ${TABLE_PREFIX}set_document_content_hash()
+RETURNS TRIGGER AS $$$$
+BEGIN
+    NEW.content_hash = encode(sha256(convert_to(NEW.content, 'UTF8')), 'hex');
+    RETURN NEW;
+END;
+$$$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER set_documents_content_hash
+    BEFORE INSERT OR UPDATE OF content ON ${TABLE_PREFIX}documents
+    FOR EACH ROW
+    EXECUTE FUNCTION ${TABLE_PREFIX}set_document_content_hash();
+
+-- Backfill without touching updated_at
+ALTER TABLE ${TABLE_PREFIX}documents DISABLE TRIGGER update_documents_updated_at;
+UPDATE ${TABLE_PREFIX}documents SET content_hash = encode(sha256(convert_to(content, 'UTF8')), 'hex');
+ALTER TABLE ${TABLE_PREFIX}documents ENABLE TRIGGER update_documents_updated_at;
+
+ALTER TABLE ${TABLE_PREFIX}documents ALTER COLUMN content_hash SET NOT NULL;
+
+-- +goose Down
+DROP TRIGGER IF EXISTS set_documents_content_hash ON ${TABLE_PREFIX}documents;
+DROP FUNCTION IF EXISTS ${TABLE_PREFIX}set_document_content_hash();
+ALTER TABLE ${TABLE_PREFIX}documents DROP COLUMN IF EXISTS content_hash;
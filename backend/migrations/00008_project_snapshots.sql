@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Project snapshots: immutable save points of a project's whole tree
+-- Content is stored once per distinct hash in content_blobs (content-addressed), so
+-- snapshots of a mostly unchanged project only add rows to project_snapshot_entries.
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}content_blobs (
+    hash TEXT PRIMARY KEY,  -- Hex SHA-256 of content (same as documents.content_hash)
+    content TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}project_snapshots (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}projects(id) ON DELETE CASCADE,
+    name TEXT NOT NULL DEFAULT '',
+    document_count INT NOT NULL DEFAULT 0,
+    folder_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}project_snapshot_entries (
+    snapshot_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}project_snapshots(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('folder', 'document')),
+    path TEXT NOT NULL,
+    source_id UUID NOT NULL,  -- Folder/document ID at capture time (informational; may be gone)
+    content_hash TEXT REFERENCES ${TABLE_PREFIX}content_blobs(hash),  -- Documents only
+    PRIMARY KEY (snapshot_id, kind, path)
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_snapshots_project ON ${TABLE_PREFIX}project_snapshots(project_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_project_snapshot_entries_hash ON ${TABLE_PREFIX}project_snapshot_entries(content_hash);
+
+COMMENT ON TABLE ${TABLE_PREFIX}content_blobs IS 'Content-addressed document content referenced by snapshots';
+COMMENT ON TABLE ${TABLE_PREFIX}project_snapshots IS 'Immutable save points of a project tree (POST /api/projects/{id}/snapshots)';
+
+-- +goose Down
+DROP TABLE IF EXISTS ${TABLE_PREFIX}project_snapshot_entries;
+DROP TABLE IF EXISTS ${TABLE_PREFIX}project_snapshots;
+DROP TABLE IF EXISTS ${TABLE_PREFIX}content_blobs;
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Export jobs: asynchronous compilation of a project (or selected folders) into a book file.
+-- The finished file is stored on the job row and downloaded via GET /api/exports/{id}/download.
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}export_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}projects(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    format TEXT NOT NULL CHECK (format IN ('epub', 'pdf')),
+    folder_ids UUID[] NOT NULL DEFAULT '{}',  -- Reading order; empty = whole project
+    title TEXT NOT NULL,
+    author TEXT NOT NULL DEFAULT '',
+    language TEXT NOT NULL DEFAULT 'en',
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    error TEXT,
+    file_name TEXT,
+    content_type TEXT,
+    file BYTEA,
+    file_size BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_export_jobs_project ON ${TABLE_PREFIX}export_jobs(project_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_export_jobs_unfinished ON ${TABLE_PREFIX}export_jobs(status) WHERE status IN ('pending', 'running');
+
+COMMENT ON TABLE ${TABLE_PREFIX}export_jobs IS 'Asynchronous EPUB/PDF exports (POST /api/projects/{id}/exports)';
+
+-- +goose Down
+DROP TABLE IF EXISTS ${TABLE_PREFIX}export_jobs;
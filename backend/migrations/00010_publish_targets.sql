@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Publish targets: per-project connections that push selected documents to an external
+-- destination (GitHub commit, S3 bucket, webhook) on demand or when they change.
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}publish_targets (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}projects(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('github', 's3', 'webhook')),
+    config JSONB NOT NULL DEFAULT '{}',  -- Connection settings, including credentials
+    document_ids UUID[] NOT NULL DEFAULT '{}',
+    folder_ids UUID[] NOT NULL DEFAULT '{}',  -- Whole subtrees; both empty = whole project
+    on_change BOOLEAN NOT NULL DEFAULT FALSE,
+    last_fingerprint TEXT NOT NULL DEFAULT '',  -- Selection state at the last attempt
+    last_status TEXT,
+    last_attempt_at TIMESTAMPTZ,
+    last_published_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (project_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_publish_targets_on_change ON ${TABLE_PREFIX}publish_targets(on_change) WHERE on_change;
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}publish_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    target_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}publish_targets(id) ON DELETE CASCADE,
+    trigger TEXT NOT NULL CHECK (trigger IN ('manual', 'document_change')),
+    status TEXT NOT NULL CHECK (status IN ('succeeded', 'failed')),
+    document_count INT NOT NULL DEFAULT 0,
+    detail TEXT NOT NULL DEFAULT '',  -- e.g. commit SHA, HTTP status
+    error TEXT,
+    duration_ms INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_publish_deliveries_target ON ${TABLE_PREFIX}publish_deliveries(target_id, created_at DESC);
+
+COMMENT ON TABLE ${TABLE_PREFIX}publish_targets IS 'External publish destinations per project (/api/projects/{id}/publish-targets)';
+COMMENT ON TABLE ${TABLE_PREFIX}publish_deliveries IS 'Delivery log of publish attempts, newest kept per target';
+
+-- +goose Down
+DROP TABLE IF EXISTS ${TABLE_PREFIX}publish_deliveries;
+DROP TABLE IF EXISTS ${TABLE_PREFIX}publish_targets;
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Versioned turn block content
+-- Upgrades existing rows to content schema version 1 (see models/llm/content_types.go).
+-- Rows this can't upgrade in SQL (tool_use input stored as a JSON string) are left
+-- unversioned; the compatibility reader upgrades them when they are loaded.
+
+-- Tool blocks: delta-style "id"/"name" keys
+UPDATE ${TABLE_PREFIX}turn_blocks
+SET content = (content - 'id') || jsonb_build_object('tool_use_id', content->'id')
+WHERE block_type IN ('tool_use', 'web_search_use', 'tool_result')
+  AND content ? 'id' AND NOT content ? 'tool_use_id';
+
+UPDATE ${TABLE_PREFIX}turn_blocks
+SET content = (content - 'name') || jsonb_build_object('tool_name', content->'name')
+WHERE block_type IN ('tool_use', 'web_search_use')
+  AND content ? 'name' AND NOT content ? 'tool_name';
+
+UPDATE ${TABLE_PREFIX}turn_blocks
+SET content = content || '{"input": {}}'::jsonb
+WHERE block_type IN ('tool_use', 'web_search_use')
+  AND (NOT content ? 'input' OR jsonb_typeof(content->'input') = 'null');
+
+-- tool_result: errors stored without the is_error flag
+UPDATE ${TABLE_PREFIX}turn_blocks
+SET content = content || jsonb_build_object('is_error', content ? 'error')
+WHERE block_type = 'tool_result' AND NOT content ? 'is_error';
+
+-- Stamp the version on everything else with structured content
+UPDATE ${TABLE_PREFIX}turn_blocks
+SET content = content || '{"schema_version": 1}'::jsonb
+WHERE content IS NOT NULL
+  AND jsonb_typeof(content) = 'object'
+  AND block_type <> 'text'
+  AND NOT content ? 'schema_version'
+  AND NOT (block_type IN ('tool_use', 'web_search_use') AND jsonb_typeof(content->'input') = 'string');
+
+-- +goose Down
+-- Upgraded keys are kept (older code reads them); only the version marker is removed
+UPDATE ${TABLE_PREFIX}turn_blocks
+SET content = content - 'schema_version'
+WHERE content ? 'schema_version';
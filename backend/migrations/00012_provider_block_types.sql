@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Provider-specific block types: redacted_thinking, server_tool_use, server_tool_result
+-- Previously these were stored under guessed types (tool_use / content-less tool_result)
+-- and replayed as malformed tool blocks. Existing rows are re-typed from provider_data.
+
+ALTER TABLE ${TABLE_PREFIX}turn_blocks DROP CONSTRAINT IF EXISTS ${TABLE_PREFIX}turn_blocks_block_type_check;
+ALTER TABLE ${TABLE_PREFIX}turn_blocks ADD CONSTRAINT ${TABLE_PREFIX}turn_blocks_block_type_check
+    CHECK (block_type IN ('text', 'thinking', 'redacted_thinking', 'tool_use', 'tool_result', 'image', 'reference',
+                          'partial_reference', 'web_search_use', 'web_search_result', 'server_tool_use', 'server_tool_result'));
+
+UPDATE ${TABLE_PREFIX}turn_blocks
+SET block_type = 'redacted_thinking', content = NULL
+WHERE block_type = 'tool_result' AND provider_data->>'type' = 'redacted_thinking';
+
+UPDATE ${TABLE_PREFIX}turn_blocks
+SET block_type = 'server_tool_use'
+WHERE block_type = 'tool_use' AND provider_data->>'type' = 'server_tool_use';
+
+UPDATE ${TABLE_PREFIX}turn_blocks
+SET block_type = 'server_tool_result',
+    content = jsonb_build_object(
+        'tool_use_id', COALESCE(provider_data->>'tool_use_id', ''),
+        'result_type', provider_data->>'type',
+        'schema_version', 1
+    )
+WHERE block_type = 'tool_result'
+  AND content IS NULL
+  AND provider_data->>'type' LIKE '%\_tool\_result'
+  AND provider_data->>'type' <> 'web_search_tool_result';
+
+-- +goose Down
+UPDATE ${TABLE_PREFIX}turn_blocks
+SET block_type = 'tool_result', content = NULL
+WHERE block_type IN ('redacted_thinking', 'server_tool_result');
+
+UPDATE ${TABLE_PREFIX}turn_blocks
+SET block_type = 'tool_use'
+WHERE block_type = 'server_tool_use';
+
+ALTER TABLE ${TABLE_PREFIX}turn_blocks DROP CONSTRAINT IF EXISTS ${TABLE_PREFIX}turn_blocks_block_type_check;
+ALTER TABLE ${TABLE_PREFIX}turn_blocks ADD CONSTRAINT ${TABLE_PREFIX}turn_blocks_block_type_check
+    CHECK (block_type IN ('text', 'thinking', 'tool_use', 'tool_result', 'image', 'reference', 'partial_reference', 'web_search_use', 'web_search_result'));
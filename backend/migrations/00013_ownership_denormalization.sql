@@ -0,0 +1,99 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Denormalized owner (user_id) on turns, turn_blocks and documents
+-- Ownership checks and row-level security policies can then filter on the row itself
+-- instead of joining turn_blocks → turns → chats → projects.
+-- The column is filled from the parent row by BEFORE INSERT triggers, so every insert
+-- path (streaming, imports, snapshot restores) stays correct without passing the owner.
+-- Ownership never changes (projects aren't transferable, chats/documents don't move
+-- between projects), so no update propagation is needed.
+
+ALTER TABLE ${TABLE_PREFIX}turns ADD COLUMN user_id TEXT;
+ALTER TABLE ${TABLE_PREFIX}turn_blocks ADD COLUMN user_id TEXT;
+ALTER TABLE ${TABLE_PREFIX}documents ADD COLUMN user_id TEXT;
+
+UPDATE ${TABLE_PREFIX}turns t
+SET user_id = c.user_id
+FROM ${TABLE_PREFIX}chats c
+WHERE c.id = t.chat_id;
+
+UPDATE ${TABLE_PREFIX}turn_blocks b
+SET user_id = t.user_id
+FROM ${TABLE_PREFIX}turns t
+WHERE t.id = b.turn_id;
+
+-- Backfill without touching updated_at
+ALTER TABLE ${TABLE_PREFIX}documents DISABLE TRIGGER update_documents_updated_at;
+UPDATE ${TABLE_PREFIX}documents d
+SET user_id = p.user_id
+FROM ${TABLE_PREFIX}projects p
+WHERE p.id = d.project_id;
+ALTER TABLE ${TABLE_PREFIX}documents ENABLE TRIGGER update_documents_updated_at;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION ${TABLE_PREFIX}set_turn_user_id()
+RETURNS TRIGGER AS $$$$
+BEGIN
+    IF NEW.user_id IS NULL THEN
+        SELECT user_id INTO NEW.user_id FROM ${TABLE_PREFIX}chats WHERE id = NEW.chat_id;
+    END IF;
+    RETURN NEW;
+END;
+$$$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION ${TABLE_PREFIX}set_turn_block_user_id()
+RETURNS TRIGGER AS $$$$
+BEGIN
+    IF NEW.user_id IS NULL THEN
+        SELECT user_id INTO NEW.user_id FROM ${TABLE_PREFIX}turns WHERE id = NEW.turn_id;
+    END IF;
+    RETURN NEW;
+END;
+$$$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION ${TABLE_PREFIX}set_document_user_id()
+RETURNS TRIGGER AS $$$$
+BEGIN
+    IF NEW.user_id IS NULL THEN
+        SELECT user_id INTO NEW.user_id FROM ${TABLE_PREFIX}projects WHERE id = NEW.project_id;
+    END IF;
+    RETURN NEW;
+END;
+$$$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER set_turns_user_id
+    BEFORE INSERT ON ${TABLE_PREFIX}turns
+    FOR EACH ROW
+    EXECUTE FUNCTION ${TABLE_PREFIX}set_turn_user_id();
+
+CREATE TRIGGER set_turn_blocks_user_id
+    BEFORE INSERT ON ${TABLE_PREFIX}turn_blocks
+    FOR EACH ROW
+    EXECUTE FUNCTION ${TABLE_PREFIX}set_turn_block_user_id();
+
+CREATE TRIGGER set_documents_user_id
+    BEFORE INSERT ON ${TABLE_PREFIX}documents
+    FOR EACH ROW
+    EXECUTE FUNCTION ${TABLE_PREFIX}set_document_user_id();
+
+-- Rows whose parent is missing can't exist (foreign keys), so the backfill covers everything
+ALTER TABLE ${TABLE_PREFIX}turns ALTER COLUMN user_id SET NOT NULL;
+ALTER TABLE ${TABLE_PREFIX}turn_blocks ALTER COLUMN user_id SET NOT NULL;
+ALTER TABLE ${TABLE_PREFIX}documents ALTER COLUMN user_id SET NOT NULL;
+
+-- +goose Down
+DROP TRIGGER IF EXISTS set_documents_user_id ON ${TABLE_PREFIX}documents;
+DROP TRIGGER IF EXISTS set_turn_blocks_user_id ON ${TABLE_PREFIX}turn_blocks;
+DROP TRIGGER IF EXISTS set_turns_user_id ON ${TABLE_PREFIX}turns;
+DROP FUNCTION IF EXISTS ${TABLE_PREFIX}set_document_user_id();
+DROP FUNCTION IF EXISTS ${TABLE_PREFIX}set_turn_block_user_id();
+DROP FUNCTION IF EXISTS ${TABLE_PREFIX}set_turn_user_id();
+
+ALTER TABLE ${TABLE_PREFIX}documents DROP COLUMN IF EXISTS user_id;
+ALTER TABLE ${TABLE_PREFIX}turn_blocks DROP COLUMN IF EXISTS user_id;
+ALTER TABLE ${TABLE_PREFIX}turns DROP COLUMN IF EXISTS user_id;
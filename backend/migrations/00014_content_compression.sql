@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Content compression at rest
+--
+-- Live documents stay TEXT: full-text indexes, the content_hash trigger and snapshot
+-- capture all read documents.content in SQL. Postgres already compresses large values
+-- in TOAST; switch the column to lz4 (much faster than the default pglz, similar ratio)
+-- where the server supports it (PG 14+ built with lz4), and keep pglz otherwise.
+--
+-- Snapshot blobs are never searched, so the application gzips large ones after capture
+-- (see CONTENT_COMPRESSION_MIN_BYTES). A blob holds either content or compressed.
+
+-- +goose StatementBegin
+DO $$$$
+BEGIN
+    -- Dynamic so older servers fail (and fall back) at run time, not parse time
+    EXECUTE 'ALTER TABLE ${TABLE_PREFIX}documents ALTER COLUMN content SET COMPRESSION lz4';
+    EXECUTE 'ALTER TABLE ${TABLE_PREFIX}content_blobs ALTER COLUMN content SET COMPRESSION lz4';
+EXCEPTION WHEN feature_not_supported OR syntax_error THEN
+    RAISE NOTICE 'lz4 TOAST compression unavailable, keeping the default';
+END
+$$$$;
+-- +goose StatementEnd
+
+ALTER TABLE ${TABLE_PREFIX}content_blobs ADD COLUMN compressed BYTEA;
+ALTER TABLE ${TABLE_PREFIX}content_blobs ALTER COLUMN content DROP NOT NULL;
+ALTER TABLE ${TABLE_PREFIX}content_blobs ADD CONSTRAINT content_blobs_one_form
+    CHECK ((content IS NULL) <> (compressed IS NULL));
+
+-- Already gzipped: store out of line without a second compression attempt
+ALTER TABLE ${TABLE_PREFIX}content_blobs ALTER COLUMN compressed SET STORAGE EXTERNAL;
+
+-- Finds blobs still waiting for compression
+CREATE INDEX IF NOT EXISTS idx_content_blobs_uncompressed ON ${TABLE_PREFIX}content_blobs(created_at)
+    WHERE compressed IS NULL;
+
+COMMENT ON COLUMN ${TABLE_PREFIX}content_blobs.compressed IS 'gzip of content (UTF-8) once compressed by the application; content is then NULL';
+
+-- +goose Down
+-- Compressed blobs can only be decompressed by the application
+-- +goose StatementBegin
+DO $$$$
+BEGIN
+    IF EXISTS (SELECT 1 FROM ${TABLE_PREFIX}content_blobs WHERE compressed IS NOT NULL) THEN
+        RAISE EXCEPTION 'content_blobs has compressed rows; decompress them before rolling back';
+    END IF;
+END
+$$$$;
+-- +goose StatementEnd
+
+DROP INDEX IF EXISTS idx_content_blobs_uncompressed;
+ALTER TABLE ${TABLE_PREFIX}content_blobs DROP CONSTRAINT IF EXISTS content_blobs_one_form;
+ALTER TABLE ${TABLE_PREFIX}content_blobs DROP COLUMN IF EXISTS compressed;
+ALTER TABLE ${TABLE_PREFIX}content_blobs ALTER COLUMN content SET NOT NULL;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Export files move to object storage (see internal/storage): a completed job records
+-- the object's key instead of the file bytes. Jobs completed before this migration keep
+-- their file in the file column and stay downloadable.
+
+ALTER TABLE ${TABLE_PREFIX}export_jobs ADD COLUMN storage_key TEXT;
+
+COMMENT ON COLUMN ${TABLE_PREFIX}export_jobs.storage_key IS 'Object storage key of the output file (file is NULL when set)';
+
+-- +goose Down
+ALTER TABLE ${TABLE_PREFIX}export_jobs DROP COLUMN IF EXISTS storage_key;
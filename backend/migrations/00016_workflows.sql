@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Workflows: per-project prompt sequences (outline → draft → critique) that can be
+-- started as a chat. A run snapshots the rendered steps and tracks the user's progress.
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}workflows (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}projects(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    system_prompt TEXT,
+    steps JSONB NOT NULL DEFAULT '[]',  -- [{"title", "prompt"}]
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (project_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}workflow_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    chat_id UUID NOT NULL UNIQUE REFERENCES ${TABLE_PREFIX}chats(id) ON DELETE CASCADE,
+    workflow_id UUID REFERENCES ${TABLE_PREFIX}workflows(id) ON DELETE SET NULL,
+    workflow_name TEXT NOT NULL,
+    placeholder_values JSONB NOT NULL DEFAULT '{}',
+    steps JSONB NOT NULL,  -- Rendered with placeholder_values when the run started
+    current_step INT NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'completed')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_workflow_runs_workflow ON ${TABLE_PREFIX}workflow_runs(workflow_id);
+
+COMMENT ON TABLE ${TABLE_PREFIX}workflows IS 'Prompt sequences per project (/api/projects/{id}/workflows)';
+COMMENT ON TABLE ${TABLE_PREFIX}workflow_runs IS 'A workflow started in a chat, with the user''s progress through its steps';
+
+-- +goose Down
+DROP TABLE IF EXISTS ${TABLE_PREFIX}workflow_runs;
+DROP TABLE IF EXISTS ${TABLE_PREFIX}workflows;
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Critic pass: a second model reviews completed assistant turns against a rubric.
+-- The review is an annotation on the turn, not a turn of its own, so it never enters
+-- the conversation sent to the model.
+
+ALTER TABLE ${TABLE_PREFIX}project_settings ADD COLUMN IF NOT EXISTS critique JSONB NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN ${TABLE_PREFIX}project_settings.critique IS '{"enabled": true, "model": "...", "rubric": "..."} automatic review of assistant turns';
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}turn_critiques (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    turn_id UUID NOT NULL UNIQUE REFERENCES ${TABLE_PREFIX}turns(id) ON DELETE CASCADE,
+    model TEXT NOT NULL,
+    rubric TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('pending', 'complete', 'error')),
+    content TEXT NOT NULL DEFAULT '',
+    error TEXT,
+    input_tokens INT,
+    output_tokens INT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ
+);
+
+COMMENT ON TABLE ${TABLE_PREFIX}turn_critiques IS 'Critic reviews of assistant turns (/api/turns/{id}/critique), one per turn';
+
+-- +goose Down
+DROP TABLE IF EXISTS ${TABLE_PREFIX}turn_critiques;
+ALTER TABLE ${TABLE_PREFIX}project_settings DROP COLUMN IF EXISTS critique;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Pinning turns: an assistant answer saved as a document in the project's pin folder.
+-- The pin row is the document's backlink to the turn it came from.
+
+ALTER TABLE ${TABLE_PREFIX}project_settings ADD COLUMN IF NOT EXISTS pin_folder TEXT;
+
+COMMENT ON COLUMN ${TABLE_PREFIX}project_settings.pin_folder IS 'Folder path for pinned turns (NULL = "Notes")';
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}turn_pins (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    document_id UUID NOT NULL UNIQUE REFERENCES ${TABLE_PREFIX}documents(id) ON DELETE CASCADE,
+    turn_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}turns(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_turn_pins_turn ON ${TABLE_PREFIX}turn_pins(turn_id);
+
+COMMENT ON TABLE ${TABLE_PREFIX}turn_pins IS 'Documents created from assistant turns (/api/turns/{id}/pin-to-project)';
+
+-- +goose Down
+DROP TABLE IF EXISTS ${TABLE_PREFIX}turn_pins;
+ALTER TABLE ${TABLE_PREFIX}project_settings DROP COLUMN IF EXISTS pin_folder;
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Inline suggestions: rewrites of a document range saved for the editor to show as
+-- suggestion marks. Offsets can drift as the document changes, so the original text is
+-- stored too; the editor drops a suggestion whose range no longer holds that text.
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}document_suggestions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    document_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}documents(id) ON DELETE CASCADE,
+    start_offset INTEGER NOT NULL,
+    end_offset INTEGER NOT NULL,
+    original_text TEXT NOT NULL,
+    suggested_text TEXT NOT NULL,
+    instruction TEXT NOT NULL,
+    model TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT document_suggestions_range CHECK (start_offset >= 0 AND end_offset > start_offset)
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_suggestions_document ON ${TABLE_PREFIX}document_suggestions(document_id, created_at);
+
+COMMENT ON TABLE ${TABLE_PREFIX}document_suggestions IS 'Saved rewrite variants of document ranges (/api/documents/{id}/rewrite)';
+COMMENT ON COLUMN ${TABLE_PREFIX}document_suggestions.start_offset IS 'Range start in characters (Unicode code points) when the suggestion was made';
+
+-- +goose Down
+DROP TABLE IF EXISTS ${TABLE_PREFIX}document_suggestions;
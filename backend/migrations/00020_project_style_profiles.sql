@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Style fingerprint: writing statistics derived from documents the user picked, injected
+-- into the system prompt of turns that ask to "match my style".
+
+ALTER TABLE ${TABLE_PREFIX}project_settings ADD COLUMN IF NOT EXISTS style_profile JSONB;
+
+COMMENT ON COLUMN ${TABLE_PREFIX}project_settings.style_profile IS 'Style analysis of selected documents (/api/projects/{id}/style-profile); NULL = never analyzed';
+
+-- +goose Down
+ALTER TABLE ${TABLE_PREFIX}project_settings DROP COLUMN IF EXISTS style_profile;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Document analytics cache: readability and pacing statistics for the editor's analytics
+-- panel (GET /api/documents/{id}/analytics). Rows are keyed by the content hash they were
+-- computed from and recomputed on the first read after the content changes.
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}document_analytics (
+    document_id UUID PRIMARY KEY REFERENCES ${TABLE_PREFIX}documents(id) ON DELETE CASCADE,
+    content_hash TEXT NOT NULL,
+    analytics JSONB NOT NULL,
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE ${TABLE_PREFIX}document_analytics IS 'Cached document analytics; stale when content_hash differs from the document''s';
+
+-- +goose Down
+DROP TABLE IF EXISTS ${TABLE_PREFIX}document_analytics;
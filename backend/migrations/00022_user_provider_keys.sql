@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Users' own LLM provider API keys (GET/PUT/DELETE /api/users/me/provider-keys).
+-- Keys are AES-GCM encrypted with a key derived from PROVIDER_KEY_SECRET; only the
+-- last characters are kept in clear so users can tell their keys apart.
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}user_provider_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    provider TEXT NOT NULL,
+    encrypted_key BYTEA NOT NULL,
+    key_hint TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, provider)
+);
+
+COMMENT ON TABLE ${TABLE_PREFIX}user_provider_keys IS 'User-supplied provider API keys, used before the server''s keys';
+COMMENT ON COLUMN ${TABLE_PREFIX}user_provider_keys.encrypted_key IS 'AES-256-GCM nonce || ciphertext';
+
+-- +goose Down
+DROP TABLE IF EXISTS ${TABLE_PREFIX}user_provider_keys;
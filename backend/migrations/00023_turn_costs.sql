@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Provider cost of assistant turns, computed at completion from the model's pricing
+-- tiers in the capability registry; summed by GET /api/usage
+
+ALTER TABLE ${TABLE_PREFIX}turns ADD COLUMN IF NOT EXISTS cost_usd NUMERIC(12, 6);
+
+COMMENT ON COLUMN ${TABLE_PREFIX}turns.cost_usd IS 'Cost in USD of all provider requests for this turn (NULL if the model has no pricing)';
+
+CREATE INDEX IF NOT EXISTS idx_turns_chat_created ON ${TABLE_PREFIX}turns(chat_id, created_at) WHERE role = 'assistant';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_turns_chat_created;
+ALTER TABLE ${TABLE_PREFIX}turns DROP COLUMN IF EXISTS cost_usd;
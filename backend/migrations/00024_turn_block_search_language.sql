@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Full-text index for conversation_recall
+-- Like documents, each turn block carries its project's text search configuration so a
+-- single expression index serves every language. Recall searches every text block of a
+-- project's chats on each tool call, which would otherwise be a sequential scan.
+
+ALTER TABLE ${TABLE_PREFIX}turn_blocks ADD COLUMN IF NOT EXISTS search_language regconfig NOT NULL DEFAULT 'english';
+
+COMMENT ON COLUMN ${TABLE_PREFIX}turn_blocks.search_language IS 'Text search configuration, copied from project_settings.search_language of the chat''s project';
+
+-- New blocks take their chat's project language
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION ${TABLE_PREFIX}set_turn_block_search_language()
+RETURNS TRIGGER AS $$$$
+BEGIN
+    NEW.search_language = COALESCE(
+        (SELECT s.search_language::regconfig
+         FROM ${TABLE_PREFIX}turns t
+         INNER JOIN ${TABLE_PREFIX}chats c ON c.id = t.chat_id
+         INNER JOIN ${TABLE_PREFIX}project_settings s ON s.project_id = c.project_id
+         WHERE t.id = NEW.turn_id),
+        'english'::regconfig
+    );
+    RETURN NEW;
+END;
+$$$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER set_turn_blocks_search_language
+    BEFORE INSERT ON ${TABLE_PREFIX}turn_blocks
+    FOR EACH ROW
+    EXECUTE FUNCTION ${TABLE_PREFIX}set_turn_block_search_language();
+
+UPDATE ${TABLE_PREFIX}turn_blocks b
+SET search_language = s.search_language::regconfig
+FROM ${TABLE_PREFIX}turns t, ${TABLE_PREFIX}chats c, ${TABLE_PREFIX}project_settings s
+WHERE t.id = b.turn_id AND c.id = t.chat_id AND s.project_id = c.project_id
+  AND b.search_language <> s.search_language::regconfig;
+
+-- Matches to_tsvector(search_language, text_content) in RecallTurns
+CREATE INDEX IF NOT EXISTS idx_turn_blocks_text_fts_lang ON ${TABLE_PREFIX}turn_blocks
+    USING gin(to_tsvector(search_language, text_content))
+    WHERE block_type = 'text' AND text_content IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_turn_blocks_text_fts_lang;
+DROP TRIGGER IF EXISTS set_turn_blocks_search_language ON ${TABLE_PREFIX}turn_blocks;
+DROP FUNCTION IF EXISTS ${TABLE_PREFIX}set_turn_block_search_language();
+ALTER TABLE ${TABLE_PREFIX}turn_blocks DROP COLUMN IF EXISTS search_language;
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Prompt/params experiments: variants of the system prompt and default request params,
+-- assigned per user or chat. Each assistant turn records the variants it ran with, and
+-- users can rate turns so the admin API can compare variants.
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}experiments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    unit TEXT NOT NULL CHECK (unit IN ('user', 'chat')),
+    status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'stopped')),
+    variants JSONB NOT NULL,  -- [{"name", "weight", "system_prompt", "request_params"}]
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}turn_experiments (
+    turn_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}turns(id) ON DELETE CASCADE,
+    experiment_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}experiments(id) ON DELETE CASCADE,
+    variant TEXT NOT NULL,
+    PRIMARY KEY (turn_id, experiment_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_turn_experiments_experiment ON ${TABLE_PREFIX}turn_experiments(experiment_id, variant);
+
+ALTER TABLE ${TABLE_PREFIX}turns ADD COLUMN IF NOT EXISTS feedback SMALLINT CHECK (feedback IN (-1, 1));
+
+COMMENT ON TABLE ${TABLE_PREFIX}experiments IS 'A/B experiments over system prompts and default request params (/admin/api/experiments)';
+COMMENT ON TABLE ${TABLE_PREFIX}turn_experiments IS 'Experiment variant each assistant turn ran with';
+COMMENT ON COLUMN ${TABLE_PREFIX}turns.feedback IS 'User rating of an assistant turn: 1 (up), -1 (down), NULL (unrated)';
+
+-- +goose Down
+ALTER TABLE ${TABLE_PREFIX}turns DROP COLUMN IF EXISTS feedback;
+DROP TABLE IF EXISTS ${TABLE_PREFIX}turn_experiments;
+DROP TABLE IF EXISTS ${TABLE_PREFIX}experiments;
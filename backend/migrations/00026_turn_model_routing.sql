@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- How an "auto" model request was routed for an assistant turn (tier, model, reasons)
+
+ALTER TABLE ${TABLE_PREFIX}turns ADD COLUMN IF NOT EXISTS model_routing JSONB;
+
+COMMENT ON COLUMN ${TABLE_PREFIX}turns.model_routing IS 'Auto-routing decision {tier, model, reasons} (NULL unless request_params.model was "auto")';
+
+-- +goose Down
+ALTER TABLE ${TABLE_PREFIX}turns DROP COLUMN IF EXISTS model_routing;
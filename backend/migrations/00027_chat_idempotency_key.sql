@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Client key of the cold-start turn that created a chat (POST /api/turns with project_id),
+-- so a double-submitted first message can't create the chat twice
+
+ALTER TABLE ${TABLE_PREFIX}chats ADD COLUMN IF NOT EXISTS idempotency_key TEXT;
+
+COMMENT ON COLUMN ${TABLE_PREFIX}chats.idempotency_key IS 'chat_idempotency_key of the cold-start turn that created the chat (NULL if none was sent)';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_chats_idempotency_key
+    ON ${TABLE_PREFIX}chats(user_id, project_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_chats_idempotency_key;
+ALTER TABLE ${TABLE_PREFIX}chats DROP COLUMN IF EXISTS idempotency_key;
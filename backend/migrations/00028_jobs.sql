@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Background jobs: long-running work (imports) queued by a request and run by the
+-- server's worker pool. Workers claim pending jobs with FOR UPDATE SKIP LOCKED, so
+-- several server instances can share the queue. Progress is polled via GET /api/jobs/{id}.
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}projects(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('import')),
+    params JSONB NOT NULL DEFAULT '{}',       -- Kind-specific input (e.g. uploaded file keys)
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    files_processed INTEGER NOT NULL DEFAULT 0,
+    errors JSONB NOT NULL DEFAULT '[]',       -- Per-file errors: [{"file": ..., "error": ...}]
+    result JSONB,                             -- Kind-specific output, set on completion
+    error TEXT,                               -- Why the whole job failed
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_project ON ${TABLE_PREFIX}jobs(project_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_jobs_pending ON ${TABLE_PREFIX}jobs(created_at) WHERE status = 'pending';
+
+COMMENT ON TABLE ${TABLE_PREFIX}jobs IS 'Background job queue (POST /api/import); progress via GET /api/jobs/{id}';
+
+-- +goose Down
+DROP TABLE IF EXISTS ${TABLE_PREFIX}jobs;
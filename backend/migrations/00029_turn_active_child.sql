@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Active branch pointer: the child a turn's forward navigation follows. Set along the
+-- path to a turn when the user views it (sibling switch, last-viewed bookmark) or creates
+-- it, so pagination shows the branch the user last chose instead of the newest one.
+
+ALTER TABLE ${TABLE_PREFIX}turns
+    ADD COLUMN IF NOT EXISTS active_child_turn_id UUID REFERENCES ${TABLE_PREFIX}turns(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN ${TABLE_PREFIX}turns.active_child_turn_id IS 'Child followed when paginating forward (NULL = most recent child)';
+
+-- +goose Down
+ALTER TABLE ${TABLE_PREFIX}turns DROP COLUMN IF EXISTS active_child_turn_id;
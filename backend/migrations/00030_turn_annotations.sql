@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Client rendering hints for a turn (collapsed, color label, branch name), kept server-side
+-- so branch management looks the same on every device
+
+ALTER TABLE ${TABLE_PREFIX}turns ADD COLUMN IF NOT EXISTS annotations JSONB;
+
+COMMENT ON COLUMN ${TABLE_PREFIX}turns.annotations IS 'Client annotations {collapsed, color_label, branch_name} (NULL = none)';
+
+-- +goose Down
+ALTER TABLE ${TABLE_PREFIX}turns DROP COLUMN IF EXISTS annotations;
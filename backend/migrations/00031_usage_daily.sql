@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Daily token usage rollups for the usage dashboard. An assistant turn is added to its
+-- (chat, day, model) row once, when it reaches a final status (complete, cancelled or
+-- error); turns.usage_recorded keeps a turn from being counted twice.
+-- Days are UTC dates of the turn's creation, matching GET /api/usage.
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}usage_daily (
+    chat_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}chats(id) ON DELETE CASCADE,
+    project_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}projects(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    day DATE NOT NULL,
+    model TEXT NOT NULL,                          -- '' when the turn failed before a model was recorded
+    turns INTEGER NOT NULL DEFAULT 0,
+    input_tokens BIGINT NOT NULL DEFAULT 0,
+    output_tokens BIGINT NOT NULL DEFAULT 0,
+    cost_usd NUMERIC(14, 6) NOT NULL DEFAULT 0,
+    unpriced_turns INTEGER NOT NULL DEFAULT 0,    -- Turns without a cost
+    PRIMARY KEY (chat_id, day, model)
+);
+
+CREATE INDEX IF NOT EXISTS idx_usage_daily_project ON ${TABLE_PREFIX}usage_daily(project_id, day);
+
+ALTER TABLE ${TABLE_PREFIX}turns ADD COLUMN IF NOT EXISTS usage_recorded BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON TABLE ${TABLE_PREFIX}usage_daily IS 'Token usage and cost of assistant turns per chat, UTC day and model (GET /api/chats/{id}/usage, GET /api/projects/{id}/usage)';
+COMMENT ON COLUMN ${TABLE_PREFIX}turns.usage_recorded IS 'Whether the turn has been added to usage_daily';
+
+-- Backfill from the turns that already finished
+INSERT INTO ${TABLE_PREFIX}usage_daily (chat_id, project_id, user_id, day, model, turns, input_tokens, output_tokens, cost_usd, unpriced_turns)
+SELECT
+    t.chat_id,
+    c.project_id,
+    c.user_id,
+    (t.created_at AT TIME ZONE 'UTC')::date,
+    COALESCE(t.model, ''),
+    COUNT(*),
+    COALESCE(SUM(t.input_tokens), 0),
+    COALESCE(SUM(t.output_tokens), 0),
+    COALESCE(SUM(t.cost_usd), 0),
+    COUNT(*) FILTER (WHERE t.cost_usd IS NULL)
+FROM ${TABLE_PREFIX}turns t
+JOIN ${TABLE_PREFIX}chats c ON c.id = t.chat_id
+WHERE t.role = 'assistant'
+  AND t.status IN ('complete', 'cancelled', 'error')
+GROUP BY 1, 2, 3, 4, 5
+ON CONFLICT (chat_id, day, model) DO NOTHING;
+
+UPDATE ${TABLE_PREFIX}turns SET usage_recorded = TRUE
+WHERE role = 'assistant'
+  AND status IN ('complete', 'cancelled', 'error');
+
+-- +goose Down
+ALTER TABLE ${TABLE_PREFIX}turns DROP COLUMN IF EXISTS usage_recorded;
+DROP TABLE IF EXISTS ${TABLE_PREFIX}usage_daily;
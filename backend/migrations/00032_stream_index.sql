@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Compact event index of an assistant turn's stream (block boundaries and the final
+-- event), saved when the stream ends. With the turn's blocks it rebuilds the whole
+-- stream for clients that reconnect after the stream left the registry.
+
+ALTER TABLE ${TABLE_PREFIX}turns ADD COLUMN IF NOT EXISTS stream_index JSONB;
+
+COMMENT ON COLUMN ${TABLE_PREFIX}turns.stream_index IS 'Event index of the turn''s stream: {"blocks": [{"index", "events"}], "final": {"event", "data", "events"}}; NULL while streaming';
+
+-- +goose Down
+ALTER TABLE ${TABLE_PREFIX}turns DROP COLUMN IF EXISTS stream_index;
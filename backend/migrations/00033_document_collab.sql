@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Realtime document collaboration (GET /api/documents/{id}/collab). The server relays
+-- Yjs updates without decoding them: document_collab_updates is a document's update log,
+-- replaced by one full-state update whenever a client connects and sends its state.
+-- document_revisions keeps the content the collaboration channel saved into the
+-- document, newest first.
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}document_collab_updates (
+    seq BIGSERIAL PRIMARY KEY,
+    document_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}documents(id) ON DELETE CASCADE,
+    data BYTEA NOT NULL,                          -- Yjs update (v1 encoding)
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_collab_updates_document ON ${TABLE_PREFIX}document_collab_updates(document_id, seq);
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}document_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    document_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}documents(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    content_hash TEXT NOT NULL,
+    word_count INTEGER NOT NULL DEFAULT 0,
+    source TEXT NOT NULL,                         -- 'collab'
+    update_seq BIGINT,                            -- Last collab update the content includes
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_revisions_document ON ${TABLE_PREFIX}document_revisions(document_id, created_at DESC);
+
+COMMENT ON TABLE ${TABLE_PREFIX}document_collab_updates IS 'Yjs update log of a document''s collaboration channel, in seq order';
+COMMENT ON TABLE ${TABLE_PREFIX}document_revisions IS 'Content saved into documents by the collaboration channel (the newest revisions are kept)';
+
+-- +goose Down
+DROP TABLE IF EXISTS ${TABLE_PREFIX}document_revisions;
+DROP TABLE IF EXISTS ${TABLE_PREFIX}document_collab_updates;
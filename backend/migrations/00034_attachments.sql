@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Files users attach to chat messages (POST /api/attachments). The file is kept in object
+-- storage under storage_key; image blocks reference it by attachment ID. Quotas are
+-- enforced per project from the sum of size_bytes.
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}attachments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}projects(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    storage_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_project ON ${TABLE_PREFIX}attachments(project_id);
+
+COMMENT ON TABLE ${TABLE_PREFIX}attachments IS 'Uploaded files referenced by image blocks of user turns';
+COMMENT ON COLUMN ${TABLE_PREFIX}attachments.storage_key IS 'Object storage key of the file';
+
+-- +goose Down
+DROP TABLE IF EXISTS ${TABLE_PREFIX}attachments;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Detected language of a user turn's text, so multilingual chats can be labelled and
+-- translations default their source language
+
+ALTER TABLE ${TABLE_PREFIX}turns ADD COLUMN IF NOT EXISTS language TEXT;
+
+COMMENT ON COLUMN ${TABLE_PREFIX}turns.language IS 'Detected language of a user turn (ISO 639-1, NULL = undetermined or assistant turn)';
+
+-- +goose Down
+ALTER TABLE ${TABLE_PREFIX}turns DROP COLUMN IF EXISTS language;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Anomalous usage detected by the streaming service (turn rate and tool round spikes,
+-- oversized tool inputs). Throttling flags refuse the user's new turns until
+-- throttled_until or until an admin resolves them (POST /admin/api/abuse-flags/{id}/resolve).
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}abuse_flags (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id TEXT NOT NULL,
+    signal TEXT NOT NULL,                          -- 'turn_rate', 'tool_rounds', 'tool_input_size'
+    action TEXT NOT NULL,                          -- 'flagged', 'throttled'
+    detail TEXT NOT NULL DEFAULT '',
+    turn_id UUID REFERENCES ${TABLE_PREFIX}turns(id) ON DELETE SET NULL,
+    throttled_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_abuse_flags_unresolved ON ${TABLE_PREFIX}abuse_flags(user_id, throttled_until) WHERE resolved_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_abuse_flags_created ON ${TABLE_PREFIX}abuse_flags(created_at DESC);
+
+COMMENT ON TABLE ${TABLE_PREFIX}abuse_flags IS 'Anomalous usage flagged for admins; unresolved throttling flags pause the user''s turns';
+
+-- +goose Down
+DROP TABLE IF EXISTS ${TABLE_PREFIX}abuse_flags;
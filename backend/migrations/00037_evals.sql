@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose ENVSUB ON
+-- Evals: test cases for prompts and skills (a prompt and the criteria its response must
+-- meet), and runs of them against selected models and skills. Runs are executed by the
+-- background job queue (kind 'eval'); results are kept, so a case's history shows how
+-- prompt and skill changes affected it.
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}eval_cases (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}projects(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    prompt TEXT NOT NULL,
+    criteria JSONB NOT NULL DEFAULT '[]',     -- [{"type": "judge"|"regex"|"not_regex", "value": ...}]
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (project_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}eval_runs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}projects(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    job_id UUID REFERENCES ${TABLE_PREFIX}jobs(id) ON DELETE SET NULL,
+    label TEXT NOT NULL DEFAULT '',
+    models JSONB NOT NULL DEFAULT '[]',
+    skills JSONB NOT NULL DEFAULT '[]',
+    case_ids JSONB NOT NULL DEFAULT '[]',
+    judge_model TEXT NOT NULL,
+    summary JSONB NOT NULL DEFAULT '[]',      -- Per-model pass rates, set once completed
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_eval_runs_project ON ${TABLE_PREFIX}eval_runs(project_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS ${TABLE_PREFIX}eval_results (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    run_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}eval_runs(id) ON DELETE CASCADE,
+    case_id UUID NOT NULL REFERENCES ${TABLE_PREFIX}eval_cases(id) ON DELETE CASCADE,
+    case_name TEXT NOT NULL,
+    model TEXT NOT NULL,
+    output TEXT NOT NULL DEFAULT '',
+    passed BOOLEAN NOT NULL DEFAULT FALSE,
+    score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    assertions JSONB NOT NULL DEFAULT '[]',   -- [{"type", "value", "passed", "reason"}]
+    input_tokens INTEGER NOT NULL DEFAULT 0,
+    output_tokens INTEGER NOT NULL DEFAULT 0,
+    error TEXT,                               -- Why the output couldn't be generated
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_eval_results_run ON ${TABLE_PREFIX}eval_results(run_id);
+CREATE INDEX IF NOT EXISTS idx_eval_results_case ON ${TABLE_PREFIX}eval_results(case_id, created_at DESC);
+
+ALTER TABLE ${TABLE_PREFIX}jobs DROP CONSTRAINT IF EXISTS ${TABLE_PREFIX}jobs_kind_check;
+ALTER TABLE ${TABLE_PREFIX}jobs ADD CONSTRAINT ${TABLE_PREFIX}jobs_kind_check CHECK (kind IN ('import', 'eval'));
+
+COMMENT ON TABLE ${TABLE_PREFIX}eval_cases IS 'Eval test cases: a prompt and the criteria its response must meet';
+COMMENT ON TABLE ${TABLE_PREFIX}eval_runs IS 'Eval runs (POST /api/projects/{id}/evals/runs), executed by the job queue';
+COMMENT ON TABLE ${TABLE_PREFIX}eval_results IS 'One case''s output from one model in an eval run, with its checks';
+
+-- +goose Down
+DELETE FROM ${TABLE_PREFIX}jobs WHERE kind = 'eval';
+ALTER TABLE ${TABLE_PREFIX}jobs DROP CONSTRAINT IF EXISTS ${TABLE_PREFIX}jobs_kind_check;
+ALTER TABLE ${TABLE_PREFIX}jobs ADD CONSTRAINT ${TABLE_PREFIX}jobs_kind_check CHECK (kind IN ('import'));
+DROP TABLE IF EXISTS ${TABLE_PREFIX}eval_results;
+DROP TABLE IF EXISTS ${TABLE_PREFIX}eval_runs;
+DROP TABLE IF EXISTS ${TABLE_PREFIX}eval_cases;
@@ -304,6 +304,35 @@ export interface ImportResponse {
   documents: Array<{ id: string; path: string; name: string; action: string }>
 }
 
+/** Background job (imports). `result` is set once the job has completed. */
+export interface ImportJob {
+  id: string
+  project_id: string
+  kind: 'import'
+  status: 'pending' | 'running' | 'completed' | 'failed'
+  files_processed: number
+  errors: Array<{ file: string; error: string }>
+  result?: Omit<ImportResponse, 'success'>
+  error?: string
+}
+
+const IMPORT_JOB_POLL_MS = 1000
+
+/** Waits ms, rejecting early with an AbortError if signal aborts */
+function sleep(ms: number, signal?: AbortSignal): Promise<void> {
+  return new Promise((resolve, reject) => {
+    if (signal?.aborted) {
+      reject(new DOMException('Aborted', 'AbortError'))
+      return
+    }
+    const timer = setTimeout(resolve, ms)
+    signal?.addEventListener('abort', () => {
+      clearTimeout(timer)
+      reject(new DOMException('Aborted', 'AbortError'))
+    }, { once: true })
+  })
+}
+
 type SendTurnOptions = {
   chatId?: string           // Optional - if not provided with projectId, creates new chat
   projectId?: string        // Required if chatId is not provided (for cold start)
@@ -524,7 +553,7 @@ export const api = {
     },
 
     getSiblings: async (turnId: string, options?: { signal?: AbortSignal }): Promise<string[]> => {
-      const data = await fetchAPI<TurnDto[]>(`/api/turns/${turnId}/siblings`, {
+      const data = await fetchAPI<TurnDto[]>(`/api/turns/${turnId}/siblings?blocks=false`, {
         signal: options?.signal,
       })
       return (data ?? []).map((t) => t.id)
@@ -612,6 +641,9 @@ export const api = {
     /**
      * Import documents from files (zip, markdown, text, or HTML).
      *
+     * The backend queues the import as a background job; this polls the job
+     * until it finishes and resolves with its result.
+     *
      * Uses multipart/form-data for file upload. Note: Do NOT set Content-Type header
      * manually - the browser automatically sets it with the correct boundary when
      * using FormData as the body.
@@ -638,12 +670,21 @@ export const api = {
       }
 
       // FormData body: browser sets Content-Type to multipart/form-data with boundary
-      const data = await fetchAPI<ImportResponse>(url, {
+      let job = await fetchAPI<ImportJob>(url, {
         method: 'POST',
         body: formData,
         signal: options?.signal,
       })
-      return data
+
+      while (job.status === 'pending' || job.status === 'running') {
+        await sleep(IMPORT_JOB_POLL_MS, options?.signal)
+        job = await fetchAPI<ImportJob>(`/api/jobs/${job.id}`, { signal: options?.signal })
+      }
+      if (job.status === 'failed' || !job.result) {
+        const { ErrorType, AppError } = await import('./errors')
+        throw new AppError(ErrorType.ServerError, job.error || 'Import failed')
+      }
+      return { ...job.result, success: job.result.summary.failed === 0 }
     },
   },
 
@@ -0,0 +1,43 @@
+import React from 'react'
+import type { TurnBlock } from '@/features/chats/types'
+
+interface ImageBlockProps {
+  block: TurnBlock
+}
+
+interface ImageBlockContent {
+  url?: string
+  mime_type?: string
+  alt_text?: string
+}
+
+/**
+ * Renders an image block (e.g. generated by the image_generate tool).
+ *
+ * Stored images carry a signed URL that the backend refreshes whenever the turn
+ * is loaded, so the link is used as-is.
+ */
+export const ImageBlock = React.memo(function ImageBlock({ block }: ImageBlockProps) {
+  const content = (block.content ?? {}) as ImageBlockContent
+  if (!content.url) {
+    return null
+  }
+
+  const alt = content.alt_text ?? 'Generated image'
+
+  return (
+    <figure className="my-2">
+      <a href={content.url} target="_blank" rel="noopener noreferrer">
+        <img
+          src={content.url}
+          alt={alt}
+          loading="lazy"
+          className="max-h-[512px] max-w-full rounded border border-border object-contain"
+        />
+      </a>
+      {content.alt_text && (
+        <figcaption className="mt-1 text-xs text-muted-foreground">{content.alt_text}</figcaption>
+      )}
+    </figure>
+  )
+})
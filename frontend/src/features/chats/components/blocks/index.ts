@@ -8,6 +8,7 @@
 export { BlockRenderer } from './BlockRenderer'
 export { TextBlock } from './TextBlock'
 export { ThinkingBlock } from './ThinkingBlock'
+export { ImageBlock } from './ImageBlock'
 export {
   getBlockRenderer,
   registerBlockRenderer,
@@ -2,6 +2,7 @@ import React from 'react'
 import type { TurnBlock } from '@/features/chats/types'
 import { TextBlock } from './TextBlock'
 import { ThinkingBlock } from './ThinkingBlock'
+import { ImageBlock } from './ImageBlock'
 
 /**
  * Block renderer function type.
@@ -18,8 +19,8 @@ export type BlockRendererFn = (block: TurnBlock) => React.ReactElement
 const BLOCK_RENDERERS: Record<string, BlockRendererFn> = {
   text: (block) => React.createElement(TextBlock, { block }),
   thinking: (block) => React.createElement(ThinkingBlock, { block }),
+  image: (block) => React.createElement(ImageBlock, { block }),
   // citation: (block) => React.createElement(CitationBlock, { block }),
-  // image: (block) => React.createElement(ImageBlock, { block }),
 }
 
 /**
@@ -18,6 +18,9 @@ export type BlockType =
   | 'partial_reference'
   | 'web_search_use'
   | 'web_search_result'
+  | 'redacted_thinking'
+  | 'server_tool_use'
+  | 'server_tool_result'
 
 export interface ToolBlockContent {
   tool_use_id?: string
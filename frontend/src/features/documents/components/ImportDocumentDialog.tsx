@@ -71,7 +71,7 @@ export function ImportDocumentDialog({
       setSelection(processed)
       setPhase('preview')
     } else if (processed.skippedFiles.length > 0) {
-      setError('No supported files found. Supported formats: .md, .txt, .html, .zip')
+      setError('No supported files found. Supported formats: .md, .txt, .html, .pdf, .docx, .zip')
     }
   }
 
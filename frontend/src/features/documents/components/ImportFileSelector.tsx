@@ -138,7 +138,7 @@ export function ImportFileSelector({
         <input
           ref={fileInputRef}
           type="file"
-          accept=".zip,.md,.txt,.html"
+          accept=".zip,.md,.txt,.html,.pdf,.docx"
           onChange={handleFileChange}
           multiple
           className="hidden"
@@ -11,7 +11,7 @@
  */
 
 // Supported file extensions for import (must match backend converters)
-const SUPPORTED_EXTENSIONS = ['.zip', '.md', '.txt', '.html'] as const
+const SUPPORTED_EXTENSIONS = ['.zip', '.md', '.txt', '.html', '.pdf', '.docx'] as const
 
 // Maximum file size: 100MB (must match backend ParseMultipartForm limit)
 const MAX_FILE_SIZE = 100 * 1024 * 1024 // 100MB in bytes
@@ -9,7 +9,7 @@ import type { ImportSelection, FolderTreeNode } from '../types/import'
 import { shouldIgnoreFile, getIgnoredRoot, getIgnoreReason } from './importFilters'
 
 // Content extensions (excluding .zip which is handled separately)
-const CONTENT_EXTENSIONS = ['.md', '.txt', '.html'] as const
+const CONTENT_EXTENSIONS = ['.md', '.txt', '.html', '.pdf', '.docx'] as const
 
 /**
  * Check if file is a content file (not a zip)